@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.infratographer.com/x/echox/echozap"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/clientcert"
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// adminOptions returns the options of the REST handler serving the admin routes on the admin
+// listener, none when they are served with the other routes. The middleware is the one of the
+// other routes, whose token authentication is at authAt when authenticated. Under the mtls policy
+// the client certificate authenticates callers in its place.
+func adminOptions(middleware []echo.MiddlewareFunc, authAt int, authenticated bool) []restapi.Option {
+	cfg := config.AppConfig.Admin
+
+	policy, err := restapi.ParseAdminPolicy(cfg.Policy)
+	if err != nil {
+		logger.Fatal("invalid admin policy", zap.Error(err))
+	}
+
+	if cfg.Listen == "" {
+		if policy == restapi.AdminPolicyMTLS {
+			logger.Fatal("the mtls admin policy requires an admin listener")
+		}
+
+		return nil
+	}
+
+	if policy != restapi.AdminPolicyMTLS {
+		return []restapi.Option{restapi.WithAdminListener(policy, nil)}
+	}
+
+	if cfg.TLSCert == "" || cfg.ClientCA == "" {
+		logger.Fatal("the mtls admin policy requires the admin tls certificate and client ca")
+	}
+
+	after := middleware[authAt:]
+	if authenticated {
+		after = after[1:]
+	}
+
+	admin := make([]echo.MiddlewareFunc, 0, len(middleware)+1)
+	admin = append(admin, middleware[:authAt]...)
+	admin = append(admin, clientcert.Middleware())
+	admin = append(admin, after...)
+
+	return []restapi.Option{restapi.WithAdminListener(policy, admin)}
+}
+
+// newAdminServer returns the server of the admin listener serving the admin routes of the
+// handler, nil when they are served with the other routes.
+func newAdminServer(handler *restapi.Handler) *http.Server {
+	cfg := config.AppConfig.Admin
+
+	if cfg.Listen == "" {
+		return nil
+	}
+
+	engine := echo.New()
+
+	engine.HideBanner = true
+	engine.HidePort = true
+
+	engine.Use(middleware.RequestID())
+	engine.Use(echozap.Middleware(logger.Desugar()))
+	engine.Use(middleware.Recover())
+
+	handler.AdminRoutes(engine.Group(""))
+
+	srv := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           engine,
+		ReadHeaderTimeout: shutdownTimeout,
+	}
+
+	if cfg.TLSCert != "" {
+		tlsConfig, err := clientcert.TLSConfig(cfg.TLSCert, cfg.TLSKey, cfg.ClientCA)
+		if err != nil {
+			logger.Fatal("invalid admin tls config", zap.Error(err))
+		}
+
+		srv.TLSConfig = tlsConfig
+	} else if cfg.ClientCA != "" {
+		logger.Fatal("the admin client ca requires the admin tls certificate")
+	}
+
+	return srv
+}
+
+// runAdminServer serves the admin listener until it is shut down.
+func runAdminServer(srv *http.Server) {
+	logger.Infow("starting admin server", "address", srv.Addr)
+
+	var err error
+
+	if srv.TLSConfig != nil {
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		err = srv.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Fatal("failed to run admin server", zap.Error(err))
+	}
+}
+
+// stopAdminServer gracefully shuts the admin listener down, closing the remaining connections once
+// ctx is done.
+func stopAdminServer(ctx context.Context, srv *http.Server) {
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Errorw("failed to shutdown admin server gracefully", "error", err)
+
+		_ = srv.Close()
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"go.infratographer.com/tenant-api/internal/migration"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:          "backfill [name]",
+	Short:        "Backfill a column added by an expand migration",
+	Long:         "Fills the column added by an expand migration on the rows written by binaries predating it, in batches so it runs against a live database. Run it once no binary of the previous release serves anymore, before the contract migration. Interrupted backfills resume where they stopped. Without a name the backfills are listed.",
+	Args:         cobra.MaximumNArgs(1),
+	RunE:         runBackfill,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+
+	backfillCmd.Flags().Int("batch-size", migration.DefaultBatchSize, "number of rows filled at once")
+	backfillCmd.Flags().Duration("pause", 0, "time waited between two batches")
+}
+
+func runBackfill(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+		for _, b := range migration.Backfills() {
+			fmt.Fprintf(w, "%s\t%s\n", b.Name, b.Description)
+		}
+
+		return w.Flush()
+	}
+
+	backfill, ok := migration.LookupBackfill(args[0])
+	if !ok {
+		return fmt.Errorf("unknown backfill %q", args[0])
+	}
+
+	ctx := cmd.Context()
+
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	pause, _ := cmd.Flags().GetDuration("pause")
+
+	client, closeFn := initializeEntClient(ctx, nil)
+	defer closeFn()
+
+	runner := migration.NewRunner(
+		migration.WithBatchSize(batchSize),
+		migration.WithPause(pause),
+		migration.WithLogger(logger.Named("backfill")),
+	)
+
+	filled, err := runner.Run(ctx, client, backfill)
+	if err != nil {
+		return fmt.Errorf("backfill %s stopped after %d rows: %w", backfill.Name, filled, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "backfilled %d rows\n", filled)
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.infratographer.com/x/events"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/bootstrap"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/config"
+)
+
+var bootstrapCmd = &cobra.Command{
+	Use:          "bootstrap",
+	Short:        "Ensure the root tenant of the deployment exists",
+	Long:         "Creates the root tenant with the given name and external id unless it exists already, then prints its id. The root is created as the system actor and its change is published the same as for the apis, so it is safe to run on every deploy.",
+	RunE:         runBootstrap,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(bootstrapCmd)
+
+	config.MustBootstrapViperFlags(viper.GetViper(), bootstrapCmd.Flags())
+}
+
+func runBootstrap(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	conn, err := events.NewConnection(config.AppConfig.Events, events.WithLogger(logger))
+	if err != nil {
+		logger.Fatal("failed to initialize events", zap.Error(err))
+	}
+
+	defer conn.Shutdown(context.Background()) //nolint:errcheck // best effort on exit
+
+	perms, err := permissions.New(config.AppConfig.Permissions,
+		permissions.WithLogger(logger),
+		permissions.WithEventsPublisher(conn),
+	)
+	if err != nil {
+		logger.Fatal("failed to initialize permissions", zap.Error(err))
+	}
+
+	ctx = context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	feedOpts := []changefeed.Option{changefeed.WithRequireActor()}
+
+	if config.AppConfig.Changes.SubtreeTopics {
+		feedOpts = append(feedOpts, changefeed.WithSubtreeTopics())
+	}
+
+	ancestorLimit, err := changefeed.ParseAncestorLimit(config.AppConfig.Changes.Ancestors)
+	if err != nil {
+		logger.Fatal("invalid change ancestors", zap.Error(err))
+	}
+
+	feedOpts = append(feedOpts, changefeed.WithAncestorLimit(ancestorLimit))
+
+	client, closeFn := initializeEntClient(ctx, changefeed.New(encryptEvents(conn), feedOpts...))
+	defer closeFn()
+
+	root, created, err := bootstrap.EnsureRoot(ctx, client, bootstrap.Root{
+		Name:       config.AppConfig.Bootstrap.RootName,
+		ExternalID: config.AppConfig.Bootstrap.RootExternalID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if created {
+		logger.Infow("created root tenant", "tenant_id", root.ID, "name", root.Name)
+	} else {
+		logger.Infow("root tenant exists", "tenant_id", root.ID, "name", root.Name)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), root.ID)
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+
+	entgo "entgo.io/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3" // sqlite driver for the development backend
+	"go.infratographer.com/x/crdbx"
+	"go.infratographer.com/x/events"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/dependents"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/faults"
+	"go.infratographer.com/tenant-api/internal/hookchain"
+	"go.infratographer.com/tenant-api/internal/querybudget"
+	"go.infratographer.com/tenant-api/internal/servertiming"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// initializeEntClient opens the configured database and returns an ent client for it.
+// When an events connection is provided the event hooks are registered. The hooks given run
+// before the others, such as rejecting changes during maintenance.
+func initializeEntClient(ctx context.Context, conn events.Connection, hooks ...entgo.Hook) (*ent.Client, func()) {
+	var (
+		db  *sql.DB
+		dia string
+		err error
+	)
+
+	if config.AppConfig.Database.IsSQLite() {
+		logger.Warnw("using the sqlite database backend, this is meant for development only and data may not persist",
+			"uri", config.AppConfig.Database.SQLite.URI,
+		)
+
+		dia = dialect.SQLite
+
+		db, err = sql.Open("sqlite3", config.AppConfig.Database.SQLite.URI)
+	} else {
+		dia = dialect.Postgres
+
+		db, err = crdbx.NewDB(config.AppConfig.CRDB, config.AppConfig.Tracing.Enabled)
+	}
+
+	if err != nil {
+		logger.Fatal("unable to initialize database client", zap.Error(err))
+	}
+
+	cOpts := []ent.Option{ent.Driver(faults.Driver(querybudget.Driver(servertiming.Driver(entsql.OpenDB(dia, db))), newFaultInjector()))}
+
+	if conn != nil {
+		cOpts = append(cOpts, ent.EventsPublisher(conn))
+	}
+
+	if config.AppConfig.Logging.Debug {
+		cOpts = append(cOpts,
+			ent.Log(logger.Named("ent").Debugln),
+			ent.Debug(),
+		)
+	}
+
+	client := ent.NewClient(cOpts...)
+
+	// goose migrations are written for cockroachdb, sqlite gets its schema created by ent directly
+	if dia == dialect.SQLite {
+		if err := client.Schema.Create(ctx); err != nil {
+			logger.Fatal("unable to create sqlite schema", zap.Error(err))
+		}
+	}
+
+	client.Tenant.Use(hooks...)
+
+	pipeline := newValidationPipeline()
+	logger.Infow("validating tenants", "rules", pipeline.Rules())
+
+	nameReuse, err := validation.ParseNameReusePolicy(config.AppConfig.Validation.NameReusePolicy)
+	if err != nil {
+		logger.Fatal("invalid name reuse policy", zap.Error(err))
+	}
+
+	chain := []hookchain.Option{
+		hookchain.WithActorGuard(
+			actor.WithSystemActor(config.AppConfig.Changes.SystemActor),
+			actor.WithAnonymous(config.AppConfig.Changes.AllowAnonymous),
+		),
+		// the ancestors walked for creation freezes and subtree topics are cached once, tenants
+		// moved through this process are forgotten by the resolver hook
+		hookchain.WithAncestors(newAncestorResolver()),
+		hookchain.WithAdminScope(config.AppConfig.REST.AdminScope),
+		hookchain.WithRenameScope(config.AppConfig.Validation.RenameScope),
+		hookchain.WithPipeline(pipeline),
+		hookchain.WithNameReusePolicy(nameReuse),
+		hookchain.WithMaxChildren(config.AppConfig.Validation.MaxChildren),
+		hookchain.WithMaxDepth(config.AppConfig.Validation.MaxDepth),
+		hookchain.WithDeletion(deletion.WithTraversalMetrics(newTraversalMetrics())),
+		hookchain.WithEvents(conn != nil),
+		hookchain.WithSnapshots(config.AppConfig.Changes.Snapshots),
+		hookchain.WithEffectiveLabels(config.AppConfig.Changes.EffectiveLabels),
+	}
+
+	if reporters := config.AppConfig.Dependents.Reporters; len(reporters) != 0 {
+		checkers := make([]dependents.Checker, len(reporters))
+		httpClient := newHTTPClient("dependents").Client
+
+		for i, endpoint := range reporters {
+			checkers[i] = dependents.NewReporter(endpoint, httpClient)
+		}
+
+		chain = append(chain, hookchain.WithDependents(dependents.New(checkers,
+			dependents.WithTimeout(config.AppConfig.Dependents.Timeout),
+			dependents.WithFailOpen(config.AppConfig.Dependents.FailOpen),
+			dependents.WithLogger(logger.Named("dependents")),
+		)))
+	}
+
+	hookchain.Register(client, chain...)
+
+	return client, func() { client.Close(); db.Close() }
+}
+
+// newAncestorResolver returns the resolver walking the ancestors of tenants with the configured
+// cache ttl.
+func newAncestorResolver() *subtree.Resolver {
+	return subtree.NewResolver(subtree.WithTTL(config.AppConfig.Changes.RootCacheTTL))
+}
+
+// newValidationPipeline returns the pipeline validating and normalizing tenant fields with the
+// configured limits, shared by the ent hook and the apis.
+func newValidationPipeline() *validation.Pipeline {
+	var rules []validation.Rule
+
+	rules = append(rules, validation.NewNameValidator(
+		validation.WithNameMaxLength(config.AppConfig.Validation.NameMaxLength),
+		validation.WithReservedNames(config.AppConfig.Validation.ReservedNames...),
+	).Rules()...)
+	rules = append(rules, validation.NewMetadataValidator(
+		validation.WithBillingReferenceMaxLength(config.AppConfig.Validation.BillingReferenceMaxLength),
+		validation.WithDescriptionMaxLength(config.AppConfig.Validation.DescriptionMaxLength),
+	).Rules()...)
+	rules = append(rules, validation.NewSettingsValidator(
+		validation.WithSettingsMaxSize(config.AppConfig.Validation.SettingsMaxSize),
+		validation.WithSettingsMaxDepth(config.AppConfig.Validation.SettingsMaxDepth),
+	).Rules()...)
+	rules = append(rules, validation.NewLabelsValidator(
+		validation.WithMaxLabels(config.AppConfig.Validation.MaxLabels),
+	).Rules()...)
+
+	return validation.NewPipeline(rules...)
+}
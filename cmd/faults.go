@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/faults"
+)
+
+var (
+	faultInjectorOnce sync.Once
+	faultInjector     *faults.Injector
+)
+
+// newFaultInjector returns the injector of the configured faults, shared by the database, the
+// events connection and the jwks client. It is nil, injecting nothing, unless the binary is built
+// with the faults build tag or unsafe injection is enabled.
+func newFaultInjector() *faults.Injector {
+	faultInjectorOnce.Do(func() {
+		cfg := config.AppConfig.Faults
+
+		if !faults.Compiled && !cfg.Unsafe {
+			if len(cfg.Rules) != 0 || cfg.HeaderTrigger {
+				logger.Warn("fault injection is configured but disabled, it requires the faults build tag or --unsafe-fault-injection")
+			}
+
+			return
+		}
+
+		opts := make([]faults.Option, 0, len(cfg.Rules)+1)
+
+		for _, rule := range cfg.Rules {
+			point, fault, err := faults.ParseRule(rule)
+			if err != nil {
+				logger.Fatal("invalid fault rule", zap.Error(err))
+			}
+
+			opts = append(opts, faults.WithFault(point, fault))
+		}
+
+		if cfg.HeaderTrigger {
+			opts = append(opts, faults.WithHeaderTrigger())
+		}
+
+		logger.Warnw("fault injection is enabled, this must never run in production",
+			"rules", cfg.Rules,
+			"header_trigger", cfg.HeaderTrigger,
+		)
+
+		faultInjector = faults.New(opts...)
+	})
+
+	return faultInjector
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/httpx"
+)
+
+var (
+	httpClientMetricsOnce sync.Once
+	httpClientMetrics     *httpx.Metrics
+)
+
+// newHTTPClient returns the http client of the named outbound integration, configured from the
+// http client flags. The clients share their metrics, registered with the first client.
+func newHTTPClient(name string) *httpx.Client {
+	httpClientMetricsOnce.Do(func() {
+		m, err := httpx.NewMetrics(prometheus.DefaultRegisterer)
+		if err != nil {
+			logger.Fatal("failed to register http client metrics", zap.Error(err))
+		}
+
+		httpClientMetrics = m
+	})
+
+	cfg := httpx.DefaultConfig()
+	cfg.Timeout = config.AppConfig.HTTPClient.Timeout
+	cfg.MaxAttempts = config.AppConfig.HTTPClient.MaxAttempts
+	cfg.RetryBackoff = config.AppConfig.HTTPClient.RetryBackoff
+	cfg.MaxConnsPerHost = config.AppConfig.HTTPClient.MaxConnsPerHost
+	cfg.BreakerFailures = config.AppConfig.HTTPClient.BreakerFailures
+	cfg.BreakerOpenTimeout = config.AppConfig.HTTPClient.BreakerOpenTimeout
+
+	return httpx.New(name, cfg, httpx.WithMetrics(httpClientMetrics))
+}
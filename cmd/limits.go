@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/concurrency"
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// concurrencyLimits returns the limiter of the exports and the route hooks limiting the requests
+// served at once by the expensive REST routes. Only the walks over whole subtrees, which hold a
+// connection for long, are limited.
+func concurrencyLimits() (*concurrency.Limiter, restapi.RouteHooks) {
+	metrics, err := concurrency.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		logger.Fatal("failed to register concurrency metrics", zap.Error(err))
+	}
+
+	cfg := config.AppConfig.REST
+
+	limiter := func(name string, limit int) *concurrency.Limiter {
+		return concurrency.New(name, limit,
+			concurrency.WithQueueTimeout(cfg.QueueTimeout),
+			concurrency.WithMetrics(metrics),
+		)
+	}
+
+	// the statistics and aggregations walk the same subtrees, they share their slots
+	stats := limiter("stats", cfg.StatsConcurrency).Middleware()
+
+	return limiter("export", cfg.ExportConcurrency), restapi.RouteHooks{Routes: map[string][]echo.MiddlewareFunc{
+		restapi.RouteTenantCrawl:     {limiter("crawl", cfg.CrawlConcurrency).Middleware()},
+		restapi.RouteTenantStats:     {stats},
+		restapi.RouteTenantAggregate: {stats},
+		restapi.RouteTenantSearch:    {limiter("search", cfg.SearchConcurrency).Middleware()},
+	}}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"go.infratographer.com/x/loggingx"
+	"go.infratographer.com/x/versionx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logLevel is the level of the logger, changed when debug logging is reloaded.
+var logLevel = zap.NewAtomicLevel()
+
+// initLogger returns the logger configured the same as loggingx does, with its level held in
+// logLevel so it can be changed while the service runs.
+func initLogger(cfg loggingx.Config) *zap.SugaredLogger {
+	lgrCfg := zap.NewProductionConfig()
+	if cfg.Pretty {
+		lgrCfg = zap.NewDevelopmentConfig()
+	}
+
+	setDebugLogging(cfg.Debug)
+
+	lgrCfg.Level = logLevel
+
+	l, err := lgrCfg.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	return l.Sugar().With(
+		"app", appName,
+		"version", versionx.BuildDetails().Version,
+	)
+}
+
+// setDebugLogging switches the logger between the debug and info levels.
+func setDebugLogging(debug bool) {
+	if debug {
+		logLevel.SetLevel(zapcore.DebugLevel)
+	} else {
+		logLevel.SetLevel(zapcore.InfoLevel)
+	}
+}
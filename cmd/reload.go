@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/liveconfig"
+)
+
+// liveSettings returns the settings of the config which can change while the service runs.
+func liveSettings(cfg config.Config) liveconfig.Settings {
+	return liveconfig.Settings{
+		Debug:       cfg.Logging.Debug || serveDevMode,
+		Maintenance: cfg.Maintenance.Enabled,
+		CrawlRate:   cfg.REST.CrawlRate,
+		CrawlBurst:  cfg.REST.CrawlBurst,
+		MaxPageSize: cfg.REST.MaxPageSize,
+	}
+}
+
+// newLiveConfig returns the store of the settings which can change while the service runs, holding
+// those of the app config. The log level follows the settings of the store.
+func newLiveConfig() *liveconfig.Store {
+	metrics, err := liveconfig.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		logger.Fatal("failed to register config reload metrics", zap.Error(err))
+	}
+
+	store, err := liveconfig.NewStore(liveSettings(config.AppConfig),
+		liveconfig.WithLogger(logger.Named("config")),
+		liveconfig.WithMetrics(metrics),
+	)
+	if err != nil {
+		logger.Fatal("invalid settings", zap.Error(err))
+	}
+
+	store.Subscribe(func(s liveconfig.Settings) {
+		setDebugLogging(s.Debug)
+	})
+
+	if store.Load().Maintenance {
+		logger.Warn("maintenance mode is on, changes of tenants are rejected")
+	}
+
+	return store
+}
+
+// loadLiveSettings reads the config file again and returns the settings which can change. Flags
+// and environment variables keep their precedence over the file.
+func loadLiveSettings() (liveconfig.Settings, error) {
+	if _, err := readConfigFile(); err != nil {
+		return liveconfig.Settings{}, err
+	}
+
+	var cfg config.Config
+
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return liveconfig.Settings{}, err
+	}
+
+	return liveSettings(cfg), nil
+}
+
+// watchLiveConfig reloads the settings of the store on SIGHUP, and when the config file changes if
+// watching it is enabled, until ctx is done. Reloads are logged and counted by the store.
+func watchLiveConfig(ctx context.Context, store *liveconfig.Store) {
+	if config.AppConfig.Reload.Watch {
+		if viper.ConfigFileUsed() == "" {
+			logger.Warn("not watching the config file as there is none")
+		} else {
+			// reloads are serialized by the store, so a change and a SIGHUP don't interleave
+			viper.OnConfigChange(func(fsnotify.Event) {
+				_ = store.Reload(loadLiveSettings)
+			})
+			viper.WatchConfig()
+		}
+	}
+
+	hup := make(chan os.Signal, 1)
+
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-hup:
+			_ = store.Reload(loadLiveSettings)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
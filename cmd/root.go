@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -37,7 +38,7 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -58,6 +59,22 @@ func init() {
 
 	// Database Flags
 	crdbx.MustViperFlags(viper.GetViper(), rootCmd.Flags())
+	config.MustDatabaseViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	// Validation Flags
+	config.MustValidationViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	// Change Event Flags
+	config.MustChangesViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	// Traversal Flags
+	config.MustTraversalViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	// Search Flags
+	config.MustSearchViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
+
+	// Runtime Flags
+	config.MustRuntimeViperFlags(viper.GetViper(), rootCmd.PersistentFlags())
 
 	// Add migrate command
 	goosex.RegisterCobraCommand(rootCmd, func() {
@@ -86,17 +103,40 @@ func initConfig() {
 	setupAppConfig()
 
 	// setupLogging()
-	logger = loggingx.InitLogger(appName, config.AppConfig.Logging)
+	logger = initLogger(config.AppConfig.Logging)
 
 	// If a config file is found, read it in.
-	err := viper.ReadInConfig()
-	if err == nil {
+	found, err := readConfigFile()
+	if err != nil {
+		logger.Fatalw("unable to read config file", "file", viper.ConfigFileUsed(), "error", err)
+	}
+
+	if found {
 		logger.Infow("using config file",
 			"file", viper.ConfigFileUsed(),
 		)
 	}
 
 	setupAppConfig()
+
+	runtimeSettings = tuneRuntime()
+}
+
+// readConfigFile reads the config file, reporting whether there is one. Only the default config
+// file may be missing, one given with --config must exist.
+func readConfigFile() (bool, error) {
+	err := viper.ReadInConfig()
+
+	var notFound viper.ConfigFileNotFoundError
+
+	switch {
+	case errors.As(err, &notFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return true, nil
 }
 
 // setupAppConfig loads our config.AppConfig struct with the values bound by
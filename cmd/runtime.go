@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/tuning"
+)
+
+// runtimeSettings are the runtime settings applied on startup, exported by the server's metrics.
+var runtimeSettings tuning.Settings
+
+// runtimeOptions returns the options planning the runtime settings with the runtime config.
+func runtimeOptions(c config.RuntimeConfig) ([]tuning.Option, error) {
+	memoryLimit, err := tuning.ParseSize(c.MemoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return []tuning.Option{
+		tuning.WithMaxProcs(c.MaxProcs),
+		tuning.WithMemoryLimit(memoryLimit),
+		tuning.WithMemoryLimitRatio(c.MemoryLimitRatio),
+		tuning.WithGCPercent(c.GCPercent),
+		tuning.WithCgroupDetection(c.DetectCgroup),
+	}, nil
+}
+
+// tuneRuntime sizes the runtime to the configuration and the cgroup limits and logs the settings
+// in effect. The runtime defaults are kept when the cgroup limits can't be read.
+func tuneRuntime() tuning.Settings {
+	opts, err := runtimeOptions(config.AppConfig.Runtime)
+	if err != nil {
+		logger.Fatalw("invalid runtime memory limit", "error", err)
+	}
+
+	s, err := tuning.Plan(opts...)
+	if err != nil {
+		logger.Warnw("failed to detect cgroup limits, runtime settings are only taken from the config and environment", "error", err)
+
+		s, err = tuning.Plan(append(opts, tuning.WithCgroupDetection(false))...)
+		if err != nil {
+			logger.Fatalw("failed to plan runtime settings", "error", err)
+		}
+	}
+
+	s.Apply()
+
+	logger.Infow("tuned go runtime",
+		"gomaxprocs", s.MaxProcs,
+		"gomaxprocs_source", s.MaxProcsSource,
+		"memory_limit_bytes", s.MemoryLimit,
+		"memory_limit_source", s.MemoryLimitSource,
+		"gc_percent", s.GCPercent,
+		"gc_percent_source", s.GCPercentSource,
+		"cgroup_cpu_limit", s.Cgroup.CPU,
+		"cgroup_memory_limit_bytes", s.Cgroup.Memory,
+	)
+
+	return s
+}
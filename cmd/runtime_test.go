@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/tuning"
+)
+
+func TestRuntimeOptions(t *testing.T) {
+	opts, err := runtimeOptions(config.RuntimeConfig{
+		MaxProcs:         3,
+		MemoryLimit:      "1536MiB",
+		MemoryLimitRatio: 0.9,
+		GCPercent:        50,
+		DetectCgroup:     false,
+	})
+	require.NoError(t, err)
+
+	s, err := tuning.Plan(opts...)
+	require.NoError(t, err)
+
+	assert.Equal(t, tuning.Settings{
+		MaxProcs:          3,
+		MaxProcsSource:    tuning.SourceConfig,
+		MemoryLimit:       1536 << 20,
+		MemoryLimitSource: tuning.SourceConfig,
+		GCPercent:         50,
+		GCPercentSource:   tuning.SourceConfig,
+	}, s)
+
+	_, err = runtimeOptions(config.RuntimeConfig{MemoryLimit: "1.5GB"})
+	assert.Error(t, err)
+}
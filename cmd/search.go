@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/search"
+)
+
+var errSearchIndexPath = errors.New("the search index is only rebuilt on disk, set --search-index-path")
+
+var searchRebuildCmd = &cobra.Command{
+	Use:          "search-rebuild",
+	Short:        "Rebuild the bleve search index from the database",
+	Long:         "Indexes every tenant into a new bleve index which replaces the one at the search index path once built. Run it while the server is stopped, the server syncs the index with the changes made since.",
+	RunE:         runSearchRebuild,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(searchRebuildCmd)
+}
+
+func runSearchRebuild(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	path := config.AppConfig.Search.IndexPath
+	if path == "" {
+		return errSearchIndexPath
+	}
+
+	client, closeFn := initializeEntClient(ctx, nil)
+	defer closeFn()
+
+	// built aside so the index is only replaced once complete
+	building := path + ".rebuild"
+
+	if err := os.RemoveAll(building); err != nil {
+		return err
+	}
+
+	index, err := search.NewBleve(building)
+	if err != nil {
+		return err
+	}
+
+	indexed, err := search.Rebuild(ctx, client, index)
+	if err != nil {
+		index.Close()
+
+		return err
+	}
+
+	if err := index.Close(); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	if err := os.Rename(building, path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "indexed %d tenants into %s\n", indexed, path)
+
+	return nil
+}
+
+// newSearchIndex opens the bleve search index when it is the configured backend, nil otherwise.
+func newSearchIndex() *search.Bleve {
+	switch config.AppConfig.Search.Backend {
+	case config.SearchBackendSQL:
+		return nil
+	case config.SearchBackendBleve:
+	default:
+		logger.Fatal("unsupported search backend", zap.String("backend", config.AppConfig.Search.Backend))
+	}
+
+	index, err := search.NewBleve(config.AppConfig.Search.IndexPath)
+	if err != nil {
+		logger.Fatal("failed to open the search index", zap.Error(err))
+	}
+
+	return index
+}
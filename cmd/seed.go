@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.infratographer.com/x/events"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/seed"
+)
+
+var seedCmd = &cobra.Command{
+	Use:          "seed",
+	Short:        "Generate a tenant hierarchy for performance testing",
+	RunE:         runSeed,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(seedCmd)
+
+	events.MustViperFlags(viper.GetViper(), seedCmd.Flags(), appName)
+	permissions.MustViperFlags(viper.GetViper(), seedCmd.Flags())
+
+	seedCmd.Flags().Int("roots", 1, "number of root tenants to generate")
+	seedCmd.Flags().Int("depth", 3, "number of levels generated below each root")      //nolint:gomnd // default depth
+	seedCmd.Flags().Int("fan-out", 10, "number of children generated for each tenant") //nolint:gomnd // default fan-out
+	seedCmd.Flags().String("name-pattern", seed.DefaultNamePattern, "tenant name pattern, {level} and {n} are replaced")
+	seedCmd.Flags().Int("batch-size", seed.DefaultBatchSize, "number of tenants inserted per statement")
+	seedCmd.Flags().Int("label-keys", 0, "number of label keys set on the generated tenants")
+	seedCmd.Flags().Int("label-values", 1, "number of values of each label key")
+	seedCmd.Flags().Float64("label-probability", 0.5, "probability of each label key being set on a tenant") //nolint:gomnd // default probability
+	seedCmd.Flags().Int64("label-seed", 0, "seed of the random label choices")
+	seedCmd.Flags().Bool("publish-events", false, "publish change events for every generated tenant")
+	seedCmd.Flags().Bool("force", false, "seed even when the database already contains tenants")
+}
+
+func runSeed(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	flags := cmd.Flags()
+
+	cfg := seed.Config{}
+
+	cfg.Roots, _ = flags.GetInt("roots")
+	cfg.Depth, _ = flags.GetInt("depth")
+	cfg.FanOut, _ = flags.GetInt("fan-out")
+	cfg.NamePattern, _ = flags.GetString("name-pattern")
+	cfg.BatchSize, _ = flags.GetInt("batch-size")
+	cfg.Labels.Keys, _ = flags.GetInt("label-keys")
+	cfg.Labels.Values, _ = flags.GetInt("label-values")
+	cfg.Labels.Probability, _ = flags.GetFloat64("label-probability")
+	cfg.Labels.Seed, _ = flags.GetInt64("label-seed")
+	cfg.Force, _ = flags.GetBool("force")
+
+	var conn events.Connection
+
+	if publish, _ := flags.GetBool("publish-events"); publish {
+		var err error
+
+		conn, err = events.NewConnection(config.AppConfig.Events, events.WithLogger(logger))
+		if err != nil {
+			logger.Fatal("failed to initialize events", zap.Error(err))
+		}
+
+		defer conn.Shutdown(context.Background()) //nolint:errcheck // best effort on exit
+
+		perms, err := permissions.New(config.AppConfig.Permissions,
+			permissions.WithLogger(logger),
+			permissions.WithEventsPublisher(conn),
+		)
+		if err != nil {
+			logger.Fatal("failed to initialize permissions", zap.Error(err))
+		}
+
+		ctx = context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+	}
+
+	client, closeFn := initializeEntClient(ctx, encryptEvents(conn))
+	defer closeFn()
+
+	summary, err := seed.Run(actor.Internal(ctx), client, cfg)
+	if err != nil {
+		return err
+	}
+
+	logger.Infow("seed complete",
+		"tenants", summary.Tenants,
+		"levels", summary.Levels,
+		"labels", summary.Labels,
+		"duration", summary.Duration.String(),
+	)
+
+	fmt.Fprintln(cmd.OutOrStdout(), summary.String())
+
+	return nil
+}
@@ -2,32 +2,64 @@ package cmd
 
 import (
 	"context"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"entgo.io/ent/dialect"
-	entsql "entgo.io/ent/dialect/sql"
+	"github.com/MicahParks/keyfunc/v2"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.infratographer.com/x/crdbx"
 	"go.infratographer.com/x/echojwtx"
 	"go.infratographer.com/x/echox"
 	"go.infratographer.com/x/events"
 	"go.infratographer.com/x/otelx"
 	"go.infratographer.com/x/versionx"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"go.infratographer.com/permissions-api/pkg/permissions"
 
+	dbm "go.infratographer.com/tenant-api/db"
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/changefeed"
 	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/duplicates"
 	ent "go.infratographer.com/tenant-api/internal/ent/generated"
-	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/eventstream"
+	"go.infratographer.com/tenant-api/internal/export"
+	"go.infratographer.com/tenant-api/internal/failures"
+	"go.infratographer.com/tenant-api/internal/faults"
+	"go.infratographer.com/tenant-api/internal/freeze"
 	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/grpcapi"
+	"go.infratographer.com/tenant-api/internal/liveconfig"
+	"go.infratographer.com/tenant-api/internal/pubsub"
+	"go.infratographer.com/tenant-api/internal/querybudget"
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/internal/search"
+	"go.infratographer.com/tenant-api/internal/servertiming"
+	"go.infratographer.com/tenant-api/internal/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/startup"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/tuning"
+	"go.infratographer.com/tenant-api/internal/usage"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/eventcrypt"
 )
 
 const (
@@ -57,6 +89,23 @@ func init() {
 	echojwtx.MustViperFlags(viper.GetViper(), serveCmd.Flags())
 	events.MustViperFlags(viper.GetViper(), serveCmd.Flags(), appName)
 	permissions.MustViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustGRPCViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustRESTViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustAdminViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustDeletionViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustDependentsViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustUsageViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustHTTPClientViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustFailuresViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustAuditViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustStartupViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustQueriesViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustTimingViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustFaultsViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustConsumerViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustEncryptionViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustMaintenanceViperFlags(viper.GetViper(), serveCmd.Flags())
+	config.MustReloadViperFlags(viper.GetViper(), serveCmd.Flags())
 
 	// only available as a CLI arg because it shouldn't be something that could accidentially end up in a config file or env var
 	serveCmd.Flags().BoolVar(&serveDevMode, "dev", false, "dev mode: enables playground, disables all auth checks, sets CORS to allow all, pretty logging, etc.")
@@ -71,9 +120,35 @@ func serve(ctx context.Context) {
 		config.AppConfig.Server.WithMiddleware(middleware.CORS())
 		// this is a hack, echojwt needs to be updated to go into AppConfig
 		viper.Set("oidc.enabled", false)
+
+		config.AppConfig.Changes.AllowAnonymous = true
+	}
+
+	switch {
+	case config.AppConfig.Changes.AllowAnonymous:
+		logger.Warn("tenants may be changed without an authenticated actor, their changes are published with an unknown actor")
+	case config.AppConfig.OIDC.Issuer == "":
+		logger.Warn("authentication is disabled, tenant changes made through the apis are rejected as they have no actor")
+	}
+
+	timestampFormat, err := timefmt.ParseFormat(config.AppConfig.REST.TimestampFormat)
+	if err != nil {
+		logger.Fatal("invalid timestamp format", zap.Error(err))
+	}
+
+	timefmt.SetFormat(timestampFormat)
+
+	// the server only listens once its dependencies are available, when waiting for them
+	var waiter *startup.Waiter
+
+	if timeout := config.AppConfig.Startup.WaitTimeout; timeout > 0 {
+		waiter = startup.NewWaiter(timeout,
+			startup.WithBackoff(config.AppConfig.Startup.Backoff, config.AppConfig.Startup.MaxBackoff),
+			startup.WithLogger(logger.Named("startup")),
+		)
 	}
 
-	events, err := events.NewConnection(config.AppConfig.Events, events.WithLogger(logger))
+	events, err := connectEvents(ctx, waiter)
 	if err != nil {
 		logger.Fatal("unable to initialize events", zap.Error(err))
 	}
@@ -83,74 +158,267 @@ func serve(ctx context.Context) {
 		logger.Fatal("unable to initialize tracing system", zap.Error(err))
 	}
 
-	db, err := crdbx.NewDB(config.AppConfig.CRDB, config.AppConfig.Tracing.Enabled)
-	if err != nil {
-		logger.Fatal("unable to initialize crdb client", zap.Error(err))
+	if err := tuning.RegisterMetrics(prometheus.DefaultRegisterer, runtimeSettings); err != nil {
+		logger.Fatal("failed to register runtime metrics", zap.Error(err))
 	}
 
-	defer db.Close()
+	feedOpts, dispatcher := changeFeedOptions()
 
-	entDB := entsql.OpenDB(dialect.Postgres, db)
+	feed := changefeed.New(faults.Connection(servertiming.Connection(encryptEvents(events)), newFaultInjector()), feedOpts...)
 
-	cOpts := []ent.Option{ent.Driver(entDB), ent.EventsPublisher(events)}
+	live := newLiveConfig()
 
-	if config.AppConfig.Logging.Debug {
-		cOpts = append(cOpts,
-			ent.Log(logger.Named("ent").Debugln),
-			ent.Debug(),
-		)
+	client, closeFn := initializeEntClient(ctx, feed, liveconfig.MaintenanceHook(live))
+	defer closeFn()
+
+	if waiter != nil {
+		if err := waiter.Wait(ctx, databaseDependencies(client)...); err != nil {
+			logger.Fatal("the database is unavailable at startup", zap.Error(err))
+		}
 	}
 
-	client := ent.NewClient(cOpts...)
-	defer client.Close()
+	capabilities := detectCapabilities(ctx, client)
 
-	eventhooks.EventHooks(client)
+	readiness := new(startup.Readiness)
 
 	srv, err := echox.NewServer(logger.Desugar(), echox.ConfigFromViper(viper.GetViper()), versionx.BuildDetails())
 	if err != nil {
 		logger.Fatal("failed to initialize new server", zap.Error(err))
 	}
 
-	var middleware []echo.MiddlewareFunc
+	var (
+		middleware      []echo.MiddlewareFunc
+		failureRecorder *failures.Recorder
+	)
+
+	// requests are timed first, their total duration covers every other middleware
+	middleware = append(middleware, servertiming.Middleware(servertiming.WithHeader(config.AppConfig.Timing.Header)))
+
+	// failures are recorded before authentication so rejected tokens are recorded as well
+	if config.AppConfig.Failures.Size > 0 {
+		failureRecorder = failures.NewRecorder(
+			failures.WithSize(config.AppConfig.Failures.Size),
+			failures.WithRetention(config.AppConfig.Failures.Retention),
+			failures.WithStatuses(config.AppConfig.Failures.Statuses),
+		)
+
+		middleware = append(middleware, failureRecorder.Middleware())
+	}
+
+	// tests may force faults with a request header when the trigger is enabled
+	middleware = append(middleware, faults.Middleware(newFaultInjector()))
+
+	// requests the caller canceled aren't failures, they are answered before the recorder sees them
+	middleware = append(middleware, errmap.Middleware(logger))
+
+	middleware = append(middleware, querybudget.Middleware(
+		querybudget.WithBudget(config.AppConfig.Queries.Budget),
+		querybudget.WithHeader(config.AppConfig.Queries.CountHeader),
+	))
+
+	// the admin listener may authenticate its callers by their client certificate in place of this
+	authAt, authenticated := len(middleware), false
 
 	if authConfig := config.AppConfig.OIDC; authConfig.Issuer != "" {
-		auth, err := echojwtx.NewAuth(ctx, authConfig, echojwtx.WithJWTConfig(echojwt.Config{
+		authOpts := []echojwtx.Opts{echojwtx.WithJWTConfig(echojwt.Config{
 			Skipper: echox.SkipDefaultEndpoints,
-		}))
+		})}
+
+		if injector := newFaultInjector(); injector != nil {
+			authOpts = append(authOpts, echojwtx.WithKeyFuncOptions(keyfunc.Options{
+				Client: &http.Client{Transport: faults.Transport(otelhttp.DefaultClient.Transport, faults.PointJWKSFetch, injector)},
+			}))
+		}
+
+		auth, err := echojwtx.NewAuth(ctx, authConfig, authOpts...)
 		if err != nil {
 			logger.Fatal("failed to initialize jwt authentication", zap.Error(err))
 		}
 
 		middleware = append(middleware, auth.Middleware())
+		authenticated = true
 	}
 
+	permsHTTP := newHTTPClient("permissions")
+
 	perms, err := permissions.New(config.AppConfig.Permissions,
 		permissions.WithLogger(logger),
 		permissions.WithDefaultChecker(permissions.DefaultAllowChecker),
 		permissions.WithEventsPublisher(events),
+		permissions.WithHTTPClient(permsHTTP.Client),
 	)
 	if err != nil {
 		logger.Fatal("failed to initialize permissions", zap.Error(err))
 	}
 
-	middleware = append(middleware, perms.Middleware())
+	// permission checks fail while the permissions api is unreachable, so it is critical
+	if config.AppConfig.Permissions.URL != "" {
+		srv.AddReadinessCheck("permissions-api", readiness.Check(permsHTTP.Check))
+	}
+
+	middleware = append(middleware, perms.Middleware(), scopes.Middleware(), redact.NewPolicy(config.AppConfig.Redaction.Scopes).Middleware())
+	middleware = append(middleware, serviceaccount.Middleware(client))
 
-	r := graphapi.NewResolver(client, logger.Named("resolvers"))
+	deletionMetrics, err := deletion.NewMetrics(client, logger.Named("deletion"), prometheus.DefaultRegisterer)
+	if err != nil {
+		logger.Fatal("failed to register deletion metrics", zap.Error(err))
+	}
+
+	scheduler := deletion.NewScheduler(client, logger.Named("deletion"),
+		deletion.WithGracePeriod(config.AppConfig.Deletion.GracePeriod),
+		deletion.WithInterval(config.AppConfig.Deletion.CheckInterval),
+		deletion.WithMetrics(deletionMetrics),
+		deletion.WithChangeRetention(config.AppConfig.Changes.Retention),
+		deletion.WithTombstoneRetention(config.AppConfig.Deletion.TombstoneRetention),
+	)
+
+	nameReuse, err := validation.ParseNameReusePolicy(config.AppConfig.Validation.NameReusePolicy)
+	if err != nil {
+		logger.Fatal("invalid name reuse policy", zap.Error(err))
+	}
+
+	pipeline := newValidationPipeline()
+
+	r := graphapi.NewResolver(client, logger.Named("resolvers"),
+		graphapi.WithNameReusePolicy(nameReuse),
+		graphapi.WithValidation(pipeline),
+		graphapi.WithForceDeleteScope(config.AppConfig.Dependents.ForceScope),
+	)
 	handler := r.Handler(enablePlayground, middleware)
 
 	srv.AddHandler(handler)
+	srv.AddHandler(eventstream.NewHandler(client, feed, logger.Named("eventstream"), middleware,
+		eventstream.WithWatchTimeout(config.AppConfig.REST.WatchTimeout),
+		eventstream.WithChangeRetention(config.AppConfig.Changes.Retention),
+	))
+	exportLimiter, routeHooks := concurrencyLimits()
+
+	exports := export.NewHandler(client, logger.Named("export"), middleware,
+		export.WithConcurrencyLimiter(exportLimiter),
+		export.WithTraversalMetrics(newTraversalMetrics()),
+	)
 
-	// TODO: we should have a database check
-	// srv.AddReadinessCheck("database", r.DatabaseCheck)
+	srv.AddHandler(exports)
+
+	restOpts := []restapi.Option{
+		restapi.WithCacheMaxAge(config.AppConfig.REST.CacheMaxAge),
+		restapi.WithMaxBatchSize(config.AppConfig.REST.MaxBatchSize),
+		restapi.WithMaxIncludedChildren(config.AppConfig.REST.MaxIncludedChildren),
+		restapi.WithMaxListOffset(config.AppConfig.REST.MaxListOffset),
+		restapi.WithExport(exports),
+		restapi.WithChangeFeed(feed),
+		restapi.WithWatchTimeout(config.AppConfig.REST.WatchTimeout),
+		restapi.WithValidation(pipeline),
+		restapi.WithNameReusePolicy(nameReuse),
+		restapi.WithCreationGuard(freeze.NewCreationGuard(newAncestorResolver())),
+		restapi.WithAdminScope(config.AppConfig.REST.AdminScope),
+		restapi.WithForceDeleteScope(config.AppConfig.Dependents.ForceScope),
+		restapi.WithStatsCacheTTL(config.AppConfig.REST.StatsCacheTTL),
+		restapi.WithStatsStaleAfter(config.AppConfig.REST.StatsStaleAfter),
+		restapi.WithStatsRefreshInterval(config.AppConfig.REST.StatsRefreshInterval),
+		restapi.WithDeletionScheduler(scheduler),
+		restapi.WithCrawlScope(config.AppConfig.REST.CrawlScope),
+		restapi.WithCrawlRateLimit(config.AppConfig.REST.CrawlRate, config.AppConfig.REST.CrawlBurst),
+		restapi.WithCrawlMaxAge(config.AppConfig.REST.CrawlMaxAge),
+		restapi.WithCrawlTokenKey([]byte(config.AppConfig.REST.CrawlTokenKey)),
+		restapi.WithChangeRetention(config.AppConfig.Changes.Retention),
+		restapi.WithLimits(config.AppConfig.Validation.MaxChildren, config.AppConfig.Validation.MaxDepth),
+		restapi.WithTraversalMetrics(newTraversalMetrics()),
+		restapi.WithLiveConfig(live),
+		restapi.WithDispatcher(dispatcher),
+		restapi.WithRouteHooks(routeHooks),
+		restapi.WithAuditRecorder(audit.NewRecorder(client,
+			audit.WithRejectedAttempts(config.AppConfig.Audit.RejectedAttempts),
+			audit.WithLogger(logger.Named("audit")),
+		)),
+	}
+
+	restOpts = append(restOpts, optionalRESTOptions(ctx, client, capabilities)...)
+
+	var usageRecorder *usage.Recorder
+
+	if config.AppConfig.Usage.Enabled {
+		usageRecorder = usage.NewRecorder(client, logger.Named("usage"),
+			usage.WithBucketSize(config.AppConfig.Usage.BucketSize),
+			usage.WithMaxPending(config.AppConfig.Usage.MaxPending),
+		)
+
+		restOpts = append(restOpts, restapi.WithUsageRecorder(usageRecorder))
+	}
+
+	if failureRecorder != nil {
+		restOpts = append(restOpts, restapi.WithFailureRecorder(failureRecorder))
+	}
+
+	// the bleve index is synced from the recorded changes, built from the database when it is new
+	if index := newSearchIndex(); index != nil {
+		defer index.Close()
+
+		syncer := search.NewSyncer(client, index, logger.Named("search"), search.WithSyncInterval(config.AppConfig.Search.SyncInterval))
+
+		go syncer.Run(ctx)
+
+		restOpts = append(restOpts, restapi.WithSearchIndexer(index))
+	}
+
+	restOpts = append(restOpts, adminOptions(middleware, authAt, authenticated)...)
+
+	restHandler := restapi.NewHandler(client, logger.Named("rest"), middleware, restOpts...)
+
+	srv.AddHandler(restHandler)
+
+	// the admin routes are served on a listener of their own, the public one refuses them
+	adminSrv := newAdminServer(restHandler)
+	if adminSrv != nil {
+		go runAdminServer(adminSrv)
+	}
+
+	var grpcSrv *grpc.Server
+
+	if listen := config.AppConfig.GRPC.Listen; listen != "" {
+		lis, err := net.Listen("tcp", listen)
+		if err != nil {
+			logger.Fatal("failed to listen for grpc", zap.Error(err))
+		}
+
+		grpcSrv = grpcapi.NewServer(client, feed, logger.Named("grpc"), grpcapi.WithValidation(pipeline)).GRPCServer(middleware)
+
+		go func() {
+			if err := grpcSrv.Serve(lis); err != nil {
+				logger.Fatal("failed to run grpc server", zap.Error(err))
+			}
+		}()
+	}
+
+	srv.AddReadinessCheck("database", readiness.Check(startup.DatabaseCheck(client)))
 
 	ctx, cancel := context.WithCancel(ctx)
 
 	defer cancel()
 
+	// deletions made by the scheduler publish relationship changes the same as api requests
+	go scheduler.Run(actor.Internal(context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, perms)))
+	go deletionMetrics.Run(ctx, config.AppConfig.Deletion.MetricsInterval)
+	go watchLiveConfig(ctx, live)
+
+	if usageRecorder != nil {
+		go usageRecorder.Run(ctx, config.AppConfig.Usage.FlushInterval)
+	}
+
+	if consumer := newConsumer(client, events, logger.Named("consumer")); consumer != nil {
+		go func() {
+			if err := consumer.Run(actor.Internal(context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, perms))); err != nil {
+				logger.Fatal("failed to run events consumer", zap.Error(err))
+			}
+		}()
+	}
+
 	sig := make(chan os.Signal, 1)
 
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 
+	readiness.MarkStarted()
+
 	go func() {
 		if err := srv.Run(); err != nil {
 			logger.Fatal("failed to run server", zap.Error(err))
@@ -172,7 +440,217 @@ func serve(ctx context.Context) {
 
 	defer cancel()
 
+	if grpcSrv != nil {
+		stopGRPC(ctx, grpcSrv)
+	}
+
+	if adminSrv != nil {
+		stopAdminServer(ctx, adminSrv)
+	}
+
+	if usageRecorder != nil {
+		if err := usageRecorder.Flush(ctx); err != nil {
+			logger.Errorw("failed to flush tenant usage", "error", err)
+		}
+	}
+
 	if err := events.Shutdown(ctx); err != nil {
 		logger.Fatalw("failed to shutdown events gracefully", "error", err)
 	}
 }
+
+// connectEvents connects to the events server. When waiting for the dependencies, connecting is
+// retried until the startup deadline.
+func connectEvents(ctx context.Context, waiter *startup.Waiter) (events.Connection, error) {
+	var conn events.Connection
+
+	connect := func(context.Context) error {
+		var err error
+
+		conn, err = events.NewConnection(config.AppConfig.Events, events.WithLogger(logger))
+
+		return err
+	}
+
+	if waiter == nil {
+		return conn, connect(ctx)
+	}
+
+	return conn, waiter.Wait(ctx, startup.Dependency{Name: "events", Check: connect})
+}
+
+// encryptionKeys returns the keys the payloads of change events are encrypted with, nil unless
+// encryption is enabled.
+func encryptionKeys() eventcrypt.KeyProvider {
+	if !config.AppConfig.Encryption.Enabled {
+		return nil
+	}
+
+	keys, err := eventcrypt.ParseKeys(config.AppConfig.Encryption.Keys)
+	if err != nil {
+		logger.Fatal("invalid event encryption keys", zap.Error(err))
+	}
+
+	return keys
+}
+
+// encryptEvents wraps the events connection to encrypt the payloads of the changes published when
+// encryption is enabled.
+func encryptEvents(conn events.Connection) events.Connection {
+	keys := encryptionKeys()
+	if conn == nil || keys == nil {
+		return conn
+	}
+
+	return pubsub.EncryptConnection(conn, keys)
+}
+
+// databaseDependencies returns the checks of the database accepting connections and, unless it is
+// sqlite whose schema is created directly, having its migrations applied.
+func databaseDependencies(client *ent.Client) []startup.Dependency {
+	deps := []startup.Dependency{{Name: "database", Check: startup.DatabaseCheck(client)}}
+
+	if config.AppConfig.Database.IsSQLite() {
+		return deps
+	}
+
+	migrations, err := startup.MigrationsCheck(client, dbm.Migrations)
+	if err != nil {
+		logger.Fatal("unable to read the migrations", zap.Error(err))
+	}
+
+	return append(deps, startup.Dependency{Name: "migrations", Check: migrations})
+}
+
+// changeFeedOptions returns the options of the change feed, along with the dispatcher delivering
+// the changes which the REST handler shares.
+func changeFeedOptions() ([]changefeed.Option, *changefeed.Dispatcher) {
+	var opts []changefeed.Option
+
+	if !config.AppConfig.Changes.AllowAnonymous {
+		opts = append(opts, changefeed.WithRequireActor())
+	}
+
+	if config.AppConfig.Changes.SubtreeTopics {
+		opts = append(opts, changefeed.WithSubtreeTopics())
+	}
+
+	ancestorLimit, err := changefeed.ParseAncestorLimit(config.AppConfig.Changes.Ancestors)
+	if err != nil {
+		logger.Fatal("invalid change ancestors", zap.Error(err))
+	}
+
+	dispatchMetrics, err := changefeed.NewDispatchMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		logger.Fatal("failed to register dispatch metrics", zap.Error(err))
+	}
+
+	dispatcher := changefeed.NewDispatcher(
+		changefeed.WithDispatchWorkers(config.AppConfig.Changes.DispatchWorkers),
+		changefeed.WithDispatchBatchSize(config.AppConfig.Changes.DispatchBatchSize),
+		changefeed.WithDispatchRate(config.AppConfig.Changes.DispatchRate),
+		changefeed.WithDispatchMetrics(dispatchMetrics),
+	)
+
+	return append(opts, changefeed.WithAncestorLimit(ancestorLimit), changefeed.WithDispatcher(dispatcher)), dispatcher
+}
+
+// optionalRESTOptions returns the options of the REST handler for the features turned on in the
+// configuration or supported by the database. The duplicate guard runs until ctx is done.
+func optionalRESTOptions(ctx context.Context, client *ent.Client, capabilities crdb.Capabilities) []restapi.Option {
+	var opts []restapi.Option
+
+	if config.AppConfig.REST.CoalesceReads {
+		opts = append(opts, restapi.WithReadCoalescing())
+	}
+
+	if config.AppConfig.REST.LenientDecoding {
+		opts = append(opts, restapi.WithLenientDecoding())
+	}
+
+	if window := config.AppConfig.REST.DuplicateWindow; window > 0 {
+		guard := duplicates.New(window, duplicates.WithLogger(logger.Named("duplicates")))
+
+		go guard.Run(ctx, client)
+
+		opts = append(opts, restapi.WithDuplicateGuard(guard))
+	}
+
+	if config.AppConfig.REST.QueryGuard {
+		opts = append(opts, restapi.WithQueryGuard(querycost.New(
+			querycost.WithRules(querycost.DefaultRules(config.AppConfig.REST.UnscopedMaxPageSize)...),
+			// searches are served by the index, which bounds their pages itself
+			querycost.WithExemption(restapi.RouteTenantSearch, search.MaxLimit),
+		)))
+	}
+
+	// crawls are read at their snapshot time, which only cockroachdb supports
+	if capabilities.FollowerReads {
+		opts = append(opts, restapi.WithFollowerReads())
+	}
+
+	return opts
+}
+
+// detectCapabilities returns the cockroachdb features the database supports. sqlite has none, and
+// a database whose version can't be read is given none rather than failing its requests later.
+func detectCapabilities(ctx context.Context, client *ent.Client) crdb.Capabilities {
+	if config.AppConfig.Database.IsSQLite() {
+		return crdb.Capabilities{}
+	}
+
+	capabilities, err := crdb.Detect(ctx, client)
+	if err != nil {
+		logger.Warnw("unable to detect the database capabilities, cockroachdb features are disabled", "error", err)
+
+		return crdb.Capabilities{}
+	}
+
+	logger.Infow("detected the database capabilities", "follower_reads", capabilities.FollowerReads)
+
+	return capabilities
+}
+
+// newConsumer returns the consumer of the changes of other services, or nil when nothing is
+// configured to consume them.
+func newConsumer(client *ent.Client, subscriber events.Subscriber, logger *zap.SugaredLogger) *pubsub.Consumer {
+	cfg := config.AppConfig.Consumer
+
+	if !cfg.SuspendOnOwnerDelete || len(cfg.OwnerTopics) == 0 {
+		return nil
+	}
+
+	opts := []pubsub.Option{
+		pubsub.WithMaxDeliveries(cfg.MaxDeliveries),
+		pubsub.WithRetryDelay(cfg.RetryDelay),
+	}
+
+	if keys := encryptionKeys(); keys != nil {
+		opts = append(opts, pubsub.WithDecryption(keys))
+	}
+
+	consumer := pubsub.NewConsumer(subscriber, logger, opts...)
+
+	for _, topic := range cfg.OwnerTopics {
+		// changes are published to the event type followed by the topic
+		consumer.Handle(string(events.DeleteChangeType)+"."+topic, pubsub.SuspendOnOwnerDelete(client, logger))
+	}
+
+	return consumer
+}
+
+// stopGRPC gracefully stops the grpc server, closing any remaining streams once ctx is done.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
@@ -1,58 +1,126 @@
 package cmd
 
 import (
-	"entgo.io/ent/dialect"
-	entsql "entgo.io/ent/dialect/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
 	"github.com/spf13/cobra"
-	"go.infratographer.com/x/crdbx"
-	"go.infratographer.com/x/events"
-	"go.infratographer.com/x/otelx"
-	"go.uber.org/zap"
-
-	"go.infratographer.com/tenant-api/internal/config"
-	ent "go.infratographer.com/tenant-api/internal/ent/generated"
-	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"github.com/spf13/viper"
+	"go.infratographer.com/x/viperx"
+
+	"go.infratographer.com/tenant-api/pkg/client"
 )
 
+const (
+	defaultAPIEndpoint = "http://localhost" + APIDefaultListen + "/query"
+
+	outputJSON  = "json"
+	outputTable = "table"
+
+	exitCodeError            = 1
+	exitCodePermissionDenied = 3
+	exitCodeNotFound         = 4
+	exitCodeIntegrity        = 5
+)
+
+var errInvalidOutput = errors.New("invalid output format, must be one of: json, table")
+
 var tenantCmd = &cobra.Command{
-	Use:   "tenant",
-	Short: "Tenant management",
+	Use:          "tenant",
+	Short:        "Tenant management",
+	SilenceUsage: true,
 }
 
-func initializeGraphClient() (*ent.Client, func()) {
-	events, err := events.NewConnection(config.AppConfig.Events, events.WithLogger(logger))
-	if err != nil {
-		logger.Fatal("failed to initialize events", zap.Error(err))
+func init() {
+	rootCmd.AddCommand(tenantCmd)
+
+	tenantCmd.PersistentFlags().String("api-endpoint", defaultAPIEndpoint, "tenant api graph endpoint")
+	viperx.MustBindFlag(viper.GetViper(), "api.endpoint", tenantCmd.PersistentFlags().Lookup("api-endpoint"))
+
+	tenantCmd.PersistentFlags().String("api-rest-endpoint", "", "tenant api rest base url, such as http://localhost"+APIDefaultListen+", required by the list filters")
+	viperx.MustBindFlag(viper.GetViper(), "api.rest_endpoint", tenantCmd.PersistentFlags().Lookup("api-rest-endpoint"))
+
+	tenantCmd.PersistentFlags().String("api-token", "", "bearer token used to authenticate with the tenant api")
+	viperx.MustBindFlag(viper.GetViper(), "api.token", tenantCmd.PersistentFlags().Lookup("api-token"))
+
+	tenantCmd.PersistentFlags().StringP("output", "o", outputJSON, "output format (json, table)")
+}
+
+func newAPIClient() *client.Client {
+	opts := []client.Option{
+		client.WithToken(viper.GetString("api.token")),
+		client.WithRetryPolicy(client.DefaultRetryPolicy()),
 	}
 
-	err = otelx.InitTracer(config.AppConfig.Tracing, appName, logger)
-	if err != nil {
-		logger.Fatal("unable to initialize tracing system", zap.Error(err))
+	if restURL := viper.GetString("api.rest_endpoint"); restURL != "" {
+		opts = append(opts, client.WithRESTURL(restURL))
 	}
 
-	db, err := crdbx.NewDB(config.AppConfig.CRDB, config.AppConfig.Tracing.Enabled)
-	if err != nil {
-		logger.Fatal("unable to initialize crdb client", zap.Error(err))
+	return client.New(viper.GetString("api.endpoint"), opts...)
+}
+
+func printTenant(cmd *cobra.Command, tenant *client.Tenant) error {
+	return render(cmd, tenant, []*client.Tenant{tenant})
+}
+
+func printTenants(cmd *cobra.Command, tenants []*client.Tenant) error {
+	if tenants == nil {
+		tenants = []*client.Tenant{}
 	}
 
-	entDB := entsql.OpenDB(dialect.Postgres, db)
+	return render(cmd, tenants, tenants)
+}
+
+// render writes value as json or rows as a table depending on the requested output format.
+func render(cmd *cobra.Command, value any, rows []*client.Tenant) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
 
-	cOpts := []ent.Option{ent.Driver(entDB), ent.EventsPublisher(events)}
+	switch output {
+	case outputJSON:
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
 
-	if config.AppConfig.Logging.Debug {
-		cOpts = append(cOpts,
-			ent.Log(logger.Named("ent").Debugln),
-			ent.Debug(),
-		)
+		return enc.Encode(value)
+	case outputTable:
+		return writeTenantTable(cmd.OutOrStdout(), rows)
+	default:
+		return errInvalidOutput
 	}
+}
+
+func writeTenantTable(out io.Writer, tenants []*client.Tenant) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:gomnd // column padding
+
+	fmt.Fprintln(w, "ID\tNAME\tPARENT\tCREATED")
 
-	client := ent.NewClient(cOpts...)
+	for _, tnt := range tenants {
+		parent := ""
+		if tnt.Parent != nil {
+			parent = tnt.Parent.ID.String()
+		}
 
-	eventhooks.EventHooks(client)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", tnt.ID, tnt.Name, parent, tnt.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	}
 
-	return client, func() { db.Close(); client.Close() }
+	return w.Flush()
 }
 
-func init() {
-	rootCmd.AddCommand(tenantCmd)
+// exitCode maps errors returned by the api to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, client.ErrPermissionDenied):
+		return exitCodePermissionDenied
+	case errors.Is(err, client.ErrTenantNotFound):
+		return exitCodeNotFound
+	case errors.Is(err, errIntegrityProblems):
+		return exitCodeIntegrity
+	default:
+		return exitCodeError
+	}
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.infratographer.com/x/gidx"
+)
+
+var tenantDeleteCmd = &cobra.Command{
+	Use:   "delete ID",
+	Short: "Delete a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE:  deleteTenant,
+}
+
+func init() {
+	tenantCmd.AddCommand(tenantDeleteCmd)
+}
+
+func deleteTenant(cmd *cobra.Command, args []string) error {
+	id, err := gidx.Parse(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := newAPIClient().Delete(cmd.Context(), id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "deleted %s\n", id)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go.infratographer.com/x/gidx"
+)
+
+var tenantGetCmd = &cobra.Command{
+	Use:   "get ID",
+	Short: "Get a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE:  getTenant,
+}
+
+func init() {
+	tenantCmd.AddCommand(tenantGetCmd)
+}
+
+func getTenant(cmd *cobra.Command, args []string) error {
+	id, err := gidx.Parse(args[0])
+	if err != nil {
+		return err
+	}
+
+	tenant, err := newAPIClient().Get(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return printTenant(cmd, tenant)
+}
@@ -1,76 +1,131 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
-
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-	"go.infratographer.com/x/events"
 	"go.infratographer.com/x/gidx"
 
-	"go.infratographer.com/permissions-api/pkg/permissions"
-
-	ent "go.infratographer.com/tenant-api/internal/ent/generated"
-	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/pkg/client"
 )
 
 var tenantList = &cobra.Command{
 	Use:   "list",
-	Short: "List Tenants",
-	Run:   listTenant,
+	Short: "List the children of a tenant",
+	Long: `List the children of a tenant.
+
+The filters, the order and the offset are those of the rest list endpoint, they require
+--api-rest-endpoint.`,
+	RunE: listTenant,
 }
 
 func init() {
 	tenantCmd.AddCommand(tenantList)
 
-	events.MustViperFlags(viper.GetViper(), tenantList.Flags(), appName)
-	permissions.MustViperFlags(viper.GetViper(), tenantList.Flags())
-
-	tenantList.Flags().Bool("all", false, "query all")
-	tenantList.Flags().String("only", "", "only get the provided tenant id")
+	tenantList.Flags().Bool("all", false, "follow pagination and list all children")
+	tenantList.Flags().Int("limit", 0, "maximum number of children to return when not listing all")
+	tenantList.Flags().String("after", "", "cursor to continue listing from")
 	tenantList.Flags().String("parent", "", "parent tenant id")
+	tenantList.Flags().String("name-contains", "", "only list children whose name contains the value")
+	tenantList.Flags().Bool("include-archived", false, "list archived children as well")
+	tenantList.Flags().String("order-by", "", "order children by id or name")
+	tenantList.Flags().String("collation", "", "language names are sorted for when ordering by name, such as de")
+	tenantList.Flags().Int("offset", 0, "number of children skipped on the first page")
+
+	if err := tenantList.MarkFlagRequired("parent"); err != nil {
+		panic(err)
+	}
 }
 
-func listTenant(cmd *cobra.Command, _ []string) {
-	client, closeFn := initializeGraphClient()
-	defer closeFn()
+func listTenant(cmd *cobra.Command, _ []string) error {
+	parent, err := cmd.Flags().GetString("parent")
+	if err != nil {
+		return err
+	}
 
-	query := client.Tenant.Query()
+	parentID, err := gidx.Parse(parent)
+	if err != nil {
+		return err
+	}
 
-	var (
-		tenants []*ent.Tenant
-		err     error
-	)
+	opts, err := listOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	cli := newAPIClient()
 
 	if all, _ := cmd.Flags().GetBool("all"); all {
-		tenants, err = query.All(cmd.Context())
-		if err != nil {
-			logger.Fatalw("failed to query all tenants", "error", err)
-		}
-	} else if only, _ := cmd.Flags().GetString("only"); only != "" {
-		onlyID, _ := gidx.Parse(only)
-		tenants, err = query.Where(tenant.IDEQ(onlyID)).All(cmd.Context())
+		tenants, err := listAllTenants(cmd, cli, parentID, opts)
 		if err != nil {
-			logger.Fatalw("failed to get tenant", "error", err)
+			return err
 		}
-	} else if parent, _ := cmd.Flags().GetString("parent"); parent != "" {
-		parentID, _ := gidx.Parse(parent)
-		tenants, err = query.Where(tenant.ParentTenantIDEQ(parentID)).All(cmd.Context())
+
+		return printTenants(cmd, tenants)
+	}
+
+	page, err := cli.ListChildren(cmd.Context(), parentID, opts)
+	if err != nil {
+		return err
+	}
+
+	return printTenants(cmd, page.Tenants)
+}
+
+// listAllTenants follows the pages of the children listed with the options, the offset only
+// skipping children on the first one.
+func listAllTenants(cmd *cobra.Command, cli *client.Client, parentID gidx.PrefixedID, opts *client.ListOptions) ([]*client.Tenant, error) {
+	var tenants []*client.Tenant
+
+	for {
+		page, err := cli.ListChildren(cmd.Context(), parentID, opts)
 		if err != nil {
-			logger.Fatalw("failed to query all children", "error", err)
+			return nil, err
 		}
-	} else {
-		tenants, err = query.Where(tenant.ParentTenantIDIsNil()).All(cmd.Context())
-		if err != nil {
-			logger.Fatalw("failed to query all root tenants", "error", err)
+
+		tenants = append(tenants, page.Tenants...)
+
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			return tenants, nil
 		}
+
+		opts.After = page.PageInfo.EndCursor
+		opts.Offset = 0
 	}
+}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
+// listOptions returns the list options given by the flags of the command.
+func listOptions(cmd *cobra.Command) (*client.ListOptions, error) {
+	var (
+		opts = &client.ListOptions{}
+		err  error
+	)
 
-	if err := enc.Encode(tenants); err != nil {
-		logger.Fatalw("failed to encode payload", "error", err)
+	if opts.First, err = cmd.Flags().GetInt("limit"); err != nil {
+		return nil, err
 	}
+
+	if after, _ := cmd.Flags().GetString("after"); after != "" {
+		opts.After = &after
+	}
+
+	if opts.NameContains, err = cmd.Flags().GetString("name-contains"); err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeArchived, err = cmd.Flags().GetBool("include-archived"); err != nil {
+		return nil, err
+	}
+
+	if opts.OrderBy, err = cmd.Flags().GetString("order-by"); err != nil {
+		return nil, err
+	}
+
+	if opts.Collation, err = cmd.Flags().GetString("collation"); err != nil {
+		return nil, err
+	}
+
+	if opts.Offset, err = cmd.Flags().GetInt("offset"); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
 }
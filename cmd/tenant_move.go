@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go.infratographer.com/x/gidx"
+)
+
+var tenantMoveCmd = &cobra.Command{
+	Use:   "move ID",
+	Short: "Move a tenant under another parent",
+	Args:  cobra.ExactArgs(1),
+	RunE:  moveTenant,
+}
+
+func init() {
+	tenantCmd.AddCommand(tenantMoveCmd)
+
+	tenantMoveCmd.Flags().String("parent", "", "id of the new parent tenant")
+
+	if err := tenantMoveCmd.MarkFlagRequired("parent"); err != nil {
+		panic(err)
+	}
+}
+
+func moveTenant(cmd *cobra.Command, args []string) error {
+	id, err := gidx.Parse(args[0])
+	if err != nil {
+		return err
+	}
+
+	parent, err := cmd.Flags().GetString("parent")
+	if err != nil {
+		return err
+	}
+
+	parentID, err := gidx.Parse(parent)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := newAPIClient().Move(cmd.Context(), id, parentID)
+	if err != nil {
+		return err
+	}
+
+	return printTenant(cmd, tenant)
+}
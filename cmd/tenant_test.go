@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/permissions-api/pkg/permissions/mockpermissions"
+	"go.uber.org/zap"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/reparent"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/pkg/client"
+)
+
+// testAPI is a tenant api serving the graph api at graph and the rest api under rest.
+type testAPI struct {
+	graph  string
+	rest   string
+	client *ent.Client
+}
+
+func newTestAPI(t *testing.T, checker permissions.Checker) testAPI {
+	ctx := context.Background()
+
+	entClient, err := ent.Open(dialect.SQLite, "file:cmd?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	require.NoError(t, entClient.Schema.Create(ctx))
+
+	t.Cleanup(func() { entClient.Close() })
+
+	entClient.Tenant.Use(reparent.Hook())
+
+	perms := new(mockpermissions.MockPermissions)
+	perms.On("CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	perms.On("DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	gqlHandler := handler.NewDefaultServer(graphapi.NewExecutableSchema(
+		graphapi.Config{Resolvers: graphapi.NewResolver(entClient, zap.NewNop().Sugar())},
+	))
+
+	e := echo.New()
+	restapi.NewHandler(entClient, zap.NewNop().Sugar(), nil).Routes(e.Group("/api"))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := perms.ContextWithHandler(r.Context())
+		ctx = context.WithValue(ctx, permissions.CheckerCtxKey, checker)
+
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			e.ServeHTTP(w, r.WithContext(ctx))
+
+			return
+		}
+
+		gqlHandler.ServeHTTP(w, r.WithContext(ctx))
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return testAPI{graph: srv.URL + "/query", rest: srv.URL + "/api", client: entClient}
+}
+
+func runTenantCmd(t *testing.T, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"tenant"}, args...))
+
+	err := rootCmd.Execute()
+
+	return out.String(), err
+}
+
+func TestTenantCommands(t *testing.T) {
+	endpoint := newTestAPI(t, permissions.DefaultAllowChecker).graph
+
+	out, err := runTenantCmd(t, "create", "root", "--api-endpoint", endpoint, "--output", "json", "--parent", "")
+	require.NoError(t, err)
+
+	var root client.Tenant
+
+	require.NoError(t, json.Unmarshal([]byte(out), &root))
+	assert.Equal(t, "root", root.Name)
+
+	for _, name := range []string{"child-a", "child-b", "child-c"} {
+		_, err := runTenantCmd(t, "create", name, "--api-endpoint", endpoint, "--output", "json", "--parent", root.ID.String())
+		require.NoError(t, err)
+	}
+
+	out, err = runTenantCmd(t, "get", root.ID.String(), "--api-endpoint", endpoint, "--output", "table")
+	require.NoError(t, err)
+	assert.Contains(t, out, "NAME")
+	assert.Contains(t, out, root.ID.String())
+
+	out, err = runTenantCmd(t, "list", "--parent", root.ID.String(), "--all", "--api-endpoint", endpoint, "--output", "json")
+	require.NoError(t, err)
+
+	var children []client.Tenant
+
+	require.NoError(t, json.Unmarshal([]byte(out), &children))
+	assert.Len(t, children, 3)
+
+	out, err = runTenantCmd(t, "list", "--parent", root.ID.String(), "--all=false", "--limit", "2", "--api-endpoint", endpoint, "--output", "json")
+	require.NoError(t, err)
+
+	require.NoError(t, json.Unmarshal([]byte(out), &children))
+	assert.Len(t, children, 2)
+
+	for _, child := range children {
+		_, err := runTenantCmd(t, "delete", child.ID.String(), "--api-endpoint", endpoint)
+		require.NoError(t, err)
+	}
+
+	_, err = runTenantCmd(t, "get", children[0].ID.String(), "--api-endpoint", endpoint, "--output", "json")
+	require.Error(t, err)
+	assert.Equal(t, exitCodeNotFound, exitCode(err))
+}
+
+func TestTenantCommandsPermissionDenied(t *testing.T) {
+	endpoint := newTestAPI(t, permissions.DefaultDenyChecker).graph
+
+	_, err := runTenantCmd(t, "create", "denied", "--api-endpoint", endpoint, "--output", "json", "--parent", "")
+	require.Error(t, err)
+	assert.Equal(t, exitCodePermissionDenied, exitCode(err))
+}
+
+func TestTenantMoveCommand(t *testing.T) {
+	api := newTestAPI(t, permissions.DefaultAllowChecker)
+
+	create := func(name, parent string) client.Tenant {
+		out, err := runTenantCmd(t, "create", name, "--api-endpoint", api.graph, "--api-rest-endpoint", "", "--output", "json", "--parent", parent)
+		require.NoError(t, err)
+
+		var tnt client.Tenant
+
+		require.NoError(t, json.Unmarshal([]byte(out), &tnt))
+
+		return tnt
+	}
+
+	root := create("root", "")
+	first := create("first", root.ID.String())
+	second := create("second", root.ID.String())
+	child := create("child", first.ID.String())
+
+	out, err := runTenantCmd(t, "move", child.ID.String(), "--parent", second.ID.String(), "--api-endpoint", api.graph, "--output", "json")
+	require.NoError(t, err)
+
+	var moved client.Tenant
+
+	require.NoError(t, json.Unmarshal([]byte(out), &moved))
+	require.NotNil(t, moved.Parent)
+	assert.Equal(t, second.ID, moved.Parent.ID)
+
+	// tenants can't be moved under their descendants
+	_, err = runTenantCmd(t, "move", second.ID.String(), "--parent", child.ID.String(), "--api-endpoint", api.graph, "--output", "json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "descendants")
+
+	_, err = runTenantCmd(t, "move", child.ID.String(), "--api-endpoint", api.graph)
+	require.Error(t, err)
+}
+
+func TestTenantListFilters(t *testing.T) {
+	ctx := context.Background()
+	api := newTestAPI(t, permissions.DefaultAllowChecker)
+
+	root := api.client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	for _, name := range []string{"beta", "alpha", "Äpfel", "gamma-old"} {
+		api.client.Tenant.Create().SetName(name).SetParentID(root.ID).SaveX(ctx)
+	}
+
+	old := api.client.Tenant.Query().Where(enttenant.Name("gamma-old")).OnlyX(ctx)
+	api.client.Tenant.UpdateOne(old).SetArchived(true).ExecX(ctx)
+
+	list := func(args ...string) []string {
+		args = append([]string{"list", "--parent", root.ID.String(), "--api-endpoint", api.graph, "--output", "json"}, args...)
+
+		out, err := runTenantCmd(t, args...)
+		require.NoError(t, err)
+
+		var children []client.Tenant
+
+		require.NoError(t, json.Unmarshal([]byte(out), &children))
+
+		names := []string{}
+		for _, child := range children {
+			names = append(names, child.Name)
+		}
+
+		return names
+	}
+
+	rest := []string{"--api-rest-endpoint", api.rest, "--all", "--limit", "1", "--name-contains", "", "--include-archived=false", "--order-by", "name", "--collation", "", "--offset", "0"}
+
+	assert.Equal(t, []string{"alpha", "beta", "Äpfel"}, list(rest...), "archived children are left out, names are compared by their bytes")
+	assert.Equal(t, []string{"alpha", "Äpfel", "beta"}, list(append(rest, "--collation", "de")...))
+	assert.Equal(t, []string{"alpha", "beta", "gamma-old", "Äpfel"}, list(append(rest, "--include-archived")...))
+	assert.Equal(t, []string{"gamma-old"}, list(append(rest, "--include-archived", "--name-contains", "old")...))
+	assert.Equal(t, []string{"beta", "Äpfel"}, list(append(rest, "--offset", "1")...), "the offset only skips children on the first page")
+
+	// the filters aren't supported by the graph api
+	_, err := runTenantCmd(t, "list", "--parent", root.ID.String(), "--api-endpoint", api.graph, "--api-rest-endpoint", "", "--all=false", "--name-contains", "a")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, client.ErrRESTRequired)
+
+	_, err = runTenantCmd(t, "list", "--name-contains", "")
+	require.Error(t, err, "the parent is required")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/config"
+	"go.infratographer.com/tenant-api/internal/traversal"
+)
+
+var (
+	traversalMetricsOnce sync.Once
+	traversalMetrics     *traversal.Metrics
+)
+
+// newTraversalMetrics returns the metrics of the walks through the hierarchy, configured from the
+// traversal flags. The ent hooks and the apis share them, they are registered on first use.
+func newTraversalMetrics() *traversal.Metrics {
+	traversalMetricsOnce.Do(func() {
+		m, err := traversal.NewMetrics(logger.Named("traversal"), prometheus.DefaultRegisterer,
+			traversal.WithWarnNodes(config.AppConfig.Traversal.WarnNodes),
+			traversal.WithWarnDepth(config.AppConfig.Traversal.WarnDepth),
+			traversal.WithWarnDuration(config.AppConfig.Traversal.WarnDuration),
+		)
+		if err != nil {
+			logger.Fatal("failed to register traversal metrics", zap.Error(err))
+		}
+
+		traversalMetrics = m
+	})
+
+	return traversalMetrics
+}
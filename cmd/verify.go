@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"go.infratographer.com/tenant-api/internal/integrity"
+)
+
+var errIntegrityProblems = errors.New("tenant hierarchy has integrity problems")
+
+var verifyCmd = &cobra.Command{
+	Use:          "verify",
+	Short:        "Verify the integrity of the tenant hierarchy",
+	Long:         "Scans every tenant for orphans and parent cycles. The report is written as json, followed by a summary on stderr. Exits non-zero when unfixed problems remain.",
+	RunE:         runVerify,
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().Bool("fix", false, "detach orphans from their missing parent, other problems are only reported")
+}
+
+func runVerify(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	fix, _ := cmd.Flags().GetBool("fix")
+
+	// no events connection, repairs must not relate orphans to their missing parent
+	client, closeFn := initializeEntClient(ctx, nil)
+	defer closeFn()
+
+	report, err := integrity.Verify(ctx, client, integrity.Options{Fix: fix})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), report.String())
+
+	if !report.OK() {
+		return errIntegrityProblems
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "deletion_scheduled_at" timestamptz NULL;
+-- create index "tenant_deletion_scheduled_at" to table: "tenants"
+CREATE INDEX "tenant_deletion_scheduled_at" ON "tenants" ("deletion_scheduled_at");
+-- +goose Down
+-- reverse: create index "tenant_deletion_scheduled_at" to table: "tenants"
+DROP INDEX "tenant_deletion_scheduled_at";
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "deletion_scheduled_at";
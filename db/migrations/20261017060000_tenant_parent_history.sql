@@ -0,0 +1,18 @@
+-- +goose Up
+-- create "tenant_parent_history" table
+CREATE TABLE "tenant_parent_history" (
+  "id" character varying NOT NULL,
+  "tenant_id" character varying NOT NULL,
+  "previous_parent_id" character varying NULL,
+  "new_parent_id" character varying NULL,
+  "actor" character varying NULL,
+  "changed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenantparenthistory_tenant_id_changed_at" to table: "tenant_parent_history"
+CREATE INDEX "tenantparenthistory_tenant_id_changed_at" ON "tenant_parent_history" ("tenant_id", "changed_at");
+-- +goose Down
+-- reverse: create index "tenantparenthistory_tenant_id_changed_at" to table: "tenant_parent_history"
+DROP INDEX "tenantparenthistory_tenant_id_changed_at";
+-- reverse: create "tenant_parent_history" table
+DROP TABLE "tenant_parent_history";
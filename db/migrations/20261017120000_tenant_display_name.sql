@@ -0,0 +1,6 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "display_name" character varying NULL;
+-- +goose Down
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "display_name";
@@ -0,0 +1,7 @@
+-- +goose Up
+-- backfill the display names of existing tenants, separate from adding the column as cockroachdb
+-- doesn't allow writing a column in the transaction adding it
+UPDATE "tenants" SET "display_name" = "name" WHERE "display_name" IS NULL;
+-- +goose Down
+-- nothing to reverse, the column is dropped by the previous migration
+SELECT 1;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "contact_email" character varying NULL, ADD COLUMN "billing_reference" character varying NULL;
+-- create index "tenant_billing_reference" to table: "tenants"
+CREATE INDEX "tenant_billing_reference" ON "tenants" ("billing_reference");
+-- +goose Down
+-- reverse: create index "tenant_billing_reference" to table: "tenants"
+DROP INDEX "tenant_billing_reference";
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "billing_reference", DROP COLUMN "contact_email";
@@ -0,0 +1,10 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "owner_id" character varying NULL, ADD COLUMN "suspended_at" timestamptz NULL;
+-- create index "tenant_owner_id" to table: "tenants"
+CREATE INDEX "tenant_owner_id" ON "tenants" ("owner_id");
+-- +goose Down
+-- reverse: create index "tenant_owner_id" to table: "tenants"
+DROP INDEX "tenant_owner_id";
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "suspended_at", DROP COLUMN "owner_id";
@@ -0,0 +1,24 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "change_seq" bigint NULL;
+-- create index "tenant_change_seq" to table: "tenants"
+CREATE INDEX "tenant_change_seq" ON "tenants" ("change_seq");
+-- create "tenant_changes" table
+CREATE TABLE "tenant_changes" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" character varying NOT NULL,
+  "operation" character varying NOT NULL,
+  "changed_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenantchange_tenant_id" to table: "tenant_changes"
+CREATE INDEX "tenantchange_tenant_id" ON "tenant_changes" ("tenant_id");
+-- +goose Down
+-- reverse: create index "tenantchange_tenant_id" to table: "tenant_changes"
+DROP INDEX "tenantchange_tenant_id";
+-- reverse: create "tenant_changes" table
+DROP TABLE "tenant_changes";
+-- reverse: create index "tenant_change_seq" to table: "tenants"
+DROP INDEX "tenant_change_seq";
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "change_seq";
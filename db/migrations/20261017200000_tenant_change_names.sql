@@ -0,0 +1,10 @@
+-- +goose Up
+-- modify "tenant_changes" table
+ALTER TABLE "tenant_changes" ADD COLUMN "name" character varying NULL, ADD COLUMN "parent_tenant_id" character varying NULL;
+-- create index "tenantchange_parent_tenant_id_name" to table: "tenant_changes"
+CREATE INDEX "tenantchange_parent_tenant_id_name" ON "tenant_changes" ("parent_tenant_id", "name");
+-- +goose Down
+-- reverse: create index "tenantchange_parent_tenant_id_name" to table: "tenant_changes"
+DROP INDEX "tenantchange_parent_tenant_id_name";
+-- reverse: modify "tenant_changes" table
+ALTER TABLE "tenant_changes" DROP COLUMN "parent_tenant_id", DROP COLUMN "name";
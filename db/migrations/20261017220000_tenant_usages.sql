@@ -0,0 +1,17 @@
+-- +goose Up
+-- create "tenant_usages" table
+CREATE TABLE "tenant_usages" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "tenant_id" character varying NOT NULL,
+  "operation" character varying NOT NULL,
+  "bucket_start" timestamptz NOT NULL,
+  "count" bigint NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenantusage_tenant_id_bucket_start_operation" to table: "tenant_usages"
+CREATE UNIQUE INDEX "tenantusage_tenant_id_bucket_start_operation" ON "tenant_usages" ("tenant_id", "bucket_start", "operation");
+-- +goose Down
+-- reverse: create index "tenantusage_tenant_id_bucket_start_operation" to table: "tenant_usages"
+DROP INDEX "tenantusage_tenant_id_bucket_start_operation";
+-- reverse: create "tenant_usages" table
+DROP TABLE "tenant_usages";
@@ -0,0 +1,6 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "archived" boolean NULL;
+-- +goose Down
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "archived";
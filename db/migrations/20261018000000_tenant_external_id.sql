@@ -0,0 +1,10 @@
+-- +goose Up
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "external_id" character varying NULL;
+-- create index "tenant_parent_tenant_id_external_id" to table: "tenants"
+CREATE UNIQUE INDEX "tenant_parent_tenant_id_external_id" ON "tenants" ("parent_tenant_id", "external_id");
+-- +goose Down
+-- reverse: create index "tenant_parent_tenant_id_external_id" to table: "tenants"
+DROP INDEX "tenant_parent_tenant_id_external_id";
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "external_id";
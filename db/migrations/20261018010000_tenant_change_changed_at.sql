@@ -0,0 +1,6 @@
+-- +goose Up
+-- create index "tenantchange_changed_at" to table: "tenant_changes"
+CREATE INDEX "tenantchange_changed_at" ON "tenant_changes" ("changed_at");
+-- +goose Down
+-- reverse: create index "tenantchange_changed_at" to table: "tenant_changes"
+DROP INDEX "tenantchange_changed_at";
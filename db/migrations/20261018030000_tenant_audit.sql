@@ -0,0 +1,24 @@
+-- +goose Up
+-- create "tenant_audit" table
+CREATE TABLE "tenant_audit" (
+  "id" character varying NOT NULL,
+  "tenant_id" character varying NOT NULL,
+  "actor" character varying NULL,
+  "operation" character varying NOT NULL,
+  "attempted_change" text NULL,
+  "outcome" character varying NOT NULL,
+  "code" character varying NULL,
+  "recorded_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenantaudit_tenant_id_recorded_at" to table: "tenant_audit"
+CREATE INDEX "tenantaudit_tenant_id_recorded_at" ON "tenant_audit" ("tenant_id", "recorded_at");
+-- create index "tenantaudit_tenant_id_outcome_recorded_at" to table: "tenant_audit"
+CREATE INDEX "tenantaudit_tenant_id_outcome_recorded_at" ON "tenant_audit" ("tenant_id", "outcome", "recorded_at");
+-- +goose Down
+-- reverse: create index "tenantaudit_tenant_id_outcome_recorded_at" to table: "tenant_audit"
+DROP INDEX "tenantaudit_tenant_id_outcome_recorded_at";
+-- reverse: create index "tenantaudit_tenant_id_recorded_at" to table: "tenant_audit"
+DROP INDEX "tenantaudit_tenant_id_recorded_at";
+-- reverse: create "tenant_audit" table
+DROP TABLE "tenant_audit";
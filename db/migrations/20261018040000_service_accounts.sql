@@ -0,0 +1,28 @@
+-- +goose Up
+-- create "service_accounts" table
+CREATE TABLE "service_accounts" (
+  "id" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  "updated_at" timestamptz NOT NULL,
+  "name" character varying NOT NULL,
+  "description" character varying NULL,
+  "created_by" character varying NULL,
+  "tenant_id" character varying NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "service_accounts_tenants_service_accounts" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- create index "serviceaccount_created_at" to table: "service_accounts"
+CREATE INDEX "serviceaccount_created_at" ON "service_accounts" ("created_at");
+-- create index "serviceaccount_updated_at" to table: "service_accounts"
+CREATE INDEX "serviceaccount_updated_at" ON "service_accounts" ("updated_at");
+-- create index "serviceaccount_tenant_id_name" to table: "service_accounts"
+CREATE UNIQUE INDEX "serviceaccount_tenant_id_name" ON "service_accounts" ("tenant_id", "name");
+-- +goose Down
+-- reverse: create index "serviceaccount_tenant_id_name" to table: "service_accounts"
+DROP INDEX "serviceaccount_tenant_id_name";
+-- reverse: create index "serviceaccount_updated_at" to table: "service_accounts"
+DROP INDEX "serviceaccount_updated_at";
+-- reverse: create index "serviceaccount_created_at" to table: "service_accounts"
+DROP INDEX "serviceaccount_created_at";
+-- reverse: create "service_accounts" table
+DROP TABLE "service_accounts";
@@ -0,0 +1,15 @@
+-- +goose Up
+-- create "tenant_create_guards" table
+CREATE TABLE "tenant_create_guards" (
+  "id" character varying NOT NULL,
+  "tenant_id" character varying NOT NULL,
+  "created_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenantcreateguard_created_at" to table: "tenant_create_guards"
+CREATE INDEX "tenantcreateguard_created_at" ON "tenant_create_guards" ("created_at");
+-- +goose Down
+-- reverse: create index "tenantcreateguard_created_at" to table: "tenant_create_guards"
+DROP INDEX "tenantcreateguard_created_at";
+-- reverse: create "tenant_create_guards" table
+DROP TABLE "tenant_create_guards";
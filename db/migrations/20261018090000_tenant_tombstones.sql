@@ -0,0 +1,17 @@
+-- +goose Up
+-- create "tenant_tombstones" table
+CREATE TABLE "tenant_tombstones" (
+  "id" character varying NOT NULL,
+  "name" character varying NOT NULL,
+  "parent_tenant_id" character varying NULL,
+  "deleted_at" timestamptz NOT NULL,
+  "purged_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- create index "tenanttombstone_purged_at" to table: "tenant_tombstones"
+CREATE INDEX "tenanttombstone_purged_at" ON "tenant_tombstones" ("purged_at");
+-- +goose Down
+-- reverse: create index "tenanttombstone_purged_at" to table: "tenant_tombstones"
+DROP INDEX "tenanttombstone_purged_at";
+-- reverse: create "tenant_tombstones" table
+DROP TABLE "tenant_tombstones";
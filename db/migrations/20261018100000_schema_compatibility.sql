@@ -0,0 +1,10 @@
+-- +goose Up
+-- create "schema_compatibility" table
+CREATE TABLE "schema_compatibility" (
+  "id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+  "min_binary_version" bigint NOT NULL,
+  PRIMARY KEY ("id")
+);
+-- +goose Down
+-- reverse: create "schema_compatibility" table
+DROP TABLE "schema_compatibility";
@@ -0,0 +1,9 @@
+-- +goose Up
+-- expand: binaries predating the column keep working, it is nullable without a default, so the
+-- migration declares no compatibility. The contract migration making it required declares the
+-- binaries writing it, see internal/migration.
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "version" bigint NULL;
+-- +goose Down
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "version";
@@ -0,0 +1,7 @@
+-- +goose Up
+-- expand: nullable without a default, existing tenants keep their duplicate names unindexed
+-- modify "tenants" table
+ALTER TABLE "tenants" ADD COLUMN "unique_name" boolean NULL;
+-- +goose Down
+-- reverse: modify "tenants" table
+ALTER TABLE "tenants" DROP COLUMN "unique_name";
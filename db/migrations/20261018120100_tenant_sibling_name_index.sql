@@ -0,0 +1,8 @@
+-- +goose Up
+-- separate from adding the column as cockroachdb doesn't allow indexing a column in the
+-- transaction adding it
+-- create index "tenant_parent_tenant_id_name" to table: "tenants"
+CREATE UNIQUE INDEX "tenant_parent_tenant_id_name" ON "tenants" ("parent_tenant_id", "name") WHERE (unique_name AND (deletion_scheduled_at IS NULL));
+-- +goose Down
+-- reverse: create index "tenant_parent_tenant_id_name" to table: "tenants"
+DROP INDEX "tenant_parent_tenant_id_name";
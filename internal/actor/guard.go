@@ -0,0 +1,101 @@
+package actor
+
+import (
+	"context"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/echojwtx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// ErrMissingActor is returned when a tenant is changed without an actor to attribute the change to.
+var ErrMissingActor = apierrors.New(apierrors.ErrUnauthenticated, "changing tenants requires an authenticated actor")
+
+// FromContext returns the actor of the request, empty when it has none.
+func FromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(echojwtx.ActorCtxKey).(string)
+
+	return actor
+}
+
+// NewContext returns a context with the actor, the context is returned as it is when empty.
+func NewContext(ctx context.Context, actor string) context.Context {
+	if actor == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, echojwtx.ActorCtxKey, actor)
+}
+
+type internalCtxKey struct{}
+
+// Internal returns a context for changes made by the service itself, such as scheduled deletions,
+// which get the system actor of the guard rather than being rejected.
+func Internal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalCtxKey{}, true)
+}
+
+func isInternal(ctx context.Context) bool {
+	internal, _ := ctx.Value(internalCtxKey{}).(bool)
+
+	return internal
+}
+
+// Option configures a Guard.
+type Option func(*Guard)
+
+// WithSystemActor sets the actor of changes made with an Internal context. Without one they are
+// rejected like any other change without an actor.
+func WithSystemActor(actor string) Option {
+	return func(g *Guard) {
+		g.systemActor = actor
+	}
+}
+
+// WithAnonymous lets changes without an actor through, they are published with an unknown actor.
+// It is meant for development deployments with authentication disabled.
+func WithAnonymous(allow bool) Option {
+	return func(g *Guard) {
+		g.anonymous = allow
+	}
+}
+
+// Guard rejects tenant changes which can't be attributed to an actor.
+type Guard struct {
+	systemActor string
+	anonymous   bool
+}
+
+// NewGuard creates a new actor guard.
+func NewGuard(opts ...Option) *Guard {
+	g := &Guard{}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Hook returns an ent hook rejecting tenant changes made without an actor. Changes with an
+// Internal context get the system actor, which the following hooks and the published events see
+// as the actor of the change. It must be registered before the hooks recording the actor.
+func (g *Guard) Hook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+			if FromContext(ctx) == "" {
+				switch {
+				case isInternal(ctx) && g.systemActor != "":
+					ctx = NewContext(ctx, g.systemActor)
+				case !g.anonymous:
+					return nil, ErrMissingActor
+				}
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package actor_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// newClient returns a client guarded by the guard, recording the actor each change is made with.
+func newClient(t *testing.T, guard *actor.Guard) (*ent.Client, *[]string) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	var actors []string
+
+	client.Tenant.Use(guard.Hook())
+	client.Tenant.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TenantFunc(func(ctx context.Context, m *ent.TenantMutation) (ent.Value, error) {
+			actors = append(actors, actor.FromContext(ctx))
+
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	return client, &actors
+}
+
+func TestGuardRejects(t *testing.T) {
+	ctx := context.Background()
+	client, actors := newClient(t, actor.NewGuard(actor.WithSystemActor("tenant-api")))
+
+	_, err := client.Tenant.Create().SetName("anonymous").Save(ctx)
+	require.ErrorIs(t, err, actor.ErrMissingActor)
+	assert.ErrorIs(t, err, apierrors.ErrUnauthenticated)
+
+	_, err = client.Tenant.Create().SetName("someone").Save(actor.NewContext(ctx, "idntusr-someone"))
+	require.NoError(t, err)
+
+	// only internal changes get the system actor, never those of an actor
+	_, err = client.Tenant.Create().SetName("internal").Save(actor.Internal(ctx))
+	require.NoError(t, err)
+
+	_, err = client.Tenant.Create().SetName("job").Save(actor.NewContext(actor.Internal(ctx), "idntusr-admin"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"idntusr-someone", "tenant-api", "idntusr-admin"}, *actors)
+}
+
+func TestGuardWithoutSystemActor(t *testing.T) {
+	client, _ := newClient(t, actor.NewGuard())
+
+	_, err := client.Tenant.Create().SetName("internal").Save(actor.Internal(context.Background()))
+	assert.ErrorIs(t, err, actor.ErrMissingActor)
+}
+
+func TestGuardAnonymous(t *testing.T) {
+	ctx := context.Background()
+	client, actors := newClient(t, actor.NewGuard(actor.WithSystemActor("tenant-api"), actor.WithAnonymous(true)))
+
+	_, err := client.Tenant.Create().SetName("anonymous").Save(ctx)
+	require.NoError(t, err)
+
+	_, err = client.Tenant.Create().SetName("internal").Save(actor.Internal(ctx))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "tenant-api"}, *actors)
+}
@@ -0,0 +1,94 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+var (
+	// ErrArchivedParent is returned, wrapped in a validation error, when creating or moving a tenant
+	// under an archived parent.
+	ErrArchivedParent = apierrors.New(apierrors.ErrConflict, "parent tenant is archived")
+
+	// ErrPendingDeletion is returned when archiving a tenant which is scheduled for deletion or
+	// has an ancestor which is.
+	ErrPendingDeletion = apierrors.New(apierrors.ErrConflict, "tenant is scheduled for deletion and can't be archived")
+)
+
+// Archive archives the tenant, which must not be scheduled for deletion nor have an ancestor
+// which is. Archiving an archived tenant does nothing. The client should be that of a transaction,
+// so the status can't change between checking and archiving.
+func Archive(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*generated.Tenant, error) {
+	t, err := client.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Archived {
+		return t, nil
+	}
+
+	status, err := deletion.EffectiveStatus(ctx, client, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != deletion.StatusActive {
+		return nil, ErrPendingDeletion
+	}
+
+	return client.Tenant.UpdateOne(t).SetArchived(true).Save(ctx)
+}
+
+// Unarchive restores the archived tenant. Unarchiving a tenant which isn't archived does nothing.
+func Unarchive(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*generated.Tenant, error) {
+	t, err := client.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Archived {
+		return t, nil
+	}
+
+	return client.Tenant.UpdateOne(t).SetArchived(false).Save(ctx)
+}
+
+// Hook returns an ent hook rejecting the creation of tenants under an archived parent, and moving
+// tenants under one. Parents which don't exist are left to the deletion hook.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if parentID, ok := m.ParentTenantID(); ok && parentID != gidx.NullPrefixedID {
+					parent, err := m.Client().Tenant.Get(ctx, parentID)
+
+					switch {
+					case generated.IsNotFound(err):
+					case err != nil:
+						return nil, err
+					case parent.Archived:
+						return nil, &validation.Error{
+							Field:   "parent",
+							Code:    validation.CodeParentArchived,
+							Message: fmt.Sprintf("%s is archived", parentID),
+							Err:     ErrArchivedParent,
+						}
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
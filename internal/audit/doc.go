@@ -0,0 +1,23 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the rejected mutation attempts of tenants: those denied, by permissions
+// or by a freeze, and those which conflicted. Deletions of protected tenants confirmed by their
+// name are recorded as well, though they succeed, and so are the admin bulk operations which
+// suppressed the change events of a subtree. Each entry holds the actor, the route, the start of
+// the request body and the code the attempt was rejected with. Recording every rejected attempt is
+// costly under load, so it is optional, see WithRejectedAttempts. Confirmed and suppressed attempts
+// are rare and always recorded. Merges of a tenant into another are recorded as well, in the
+// transaction of the merge rather than by the middleware, see RecordMerge.
+package audit
@@ -0,0 +1,215 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/failures"
+)
+
+// Outcomes of the audited mutation attempts. Confirmed attempts succeeded, but are audited as
+// they required an explicit confirmation, see Confirm. Suppressed ones succeeded without
+// publishing the changes of the tenants, see Suppress. Merged ones merged another tenant into the
+// tenant, see RecordMerge.
+const (
+	OutcomeDenied     = "denied"
+	OutcomeConflicted = "conflicted"
+	OutcomeConfirmed  = "confirmed"
+	OutcomeSuppressed = "suppressed"
+	OutcomeMerged     = "merged"
+)
+
+// Keys of the echo context values marking confirmed attempts and those which suppressed their
+// change events.
+const (
+	confirmedKey  = "audit.confirmed"
+	suppressedKey = "audit.suppressed"
+)
+
+// Confirm marks the request as a confirmed attempt, recorded by the middleware once it succeeds,
+// such as the deletion of a protected tenant.
+func Confirm(c echo.Context) {
+	c.Set(confirmedKey, true)
+}
+
+// Suppress marks the request as an admin bulk operation suppressing the change events of the
+// subtree under root, recorded by the middleware on the root once it succeeds.
+func Suppress(c echo.Context, root gidx.PrefixedID) {
+	c.Set(suppressedKey, root)
+}
+
+// RecordMerge writes the entry of the merge of the source into the target, on the target, with
+// the client of the transaction of the merge, so the merge isn't committed without its entry.
+// Unlike the attempts recorded by the middleware, the entry is written whether a recorder is
+// configured or not.
+func RecordMerge(ctx context.Context, client *ent.Client, operation string, targetID, sourceID gidx.PrefixedID, at time.Time) error {
+	change, err := json.Marshal(map[string]gidx.PrefixedID{"sourceID": sourceID})
+	if err != nil {
+		return err
+	}
+
+	actor, _ := ctx.Value(echojwtx.ActorCtxKey).(string)
+
+	return client.TenantAudit.Create().
+		SetTenantID(targetID).
+		SetActor(actor).
+		SetOperation(operation).
+		SetAttemptedChange(string(change)).
+		SetOutcome(OutcomeMerged).
+		SetRecordedAt(at.UTC()).
+		Exec(ctx)
+}
+
+// MaxChangeSize is the number of bytes of the request body recorded as the attempted change.
+const MaxChangeSize = 4096
+
+// OutcomeOf returns the outcome of a mutation rejected with the status, empty when such
+// rejections aren't audited.
+func OutcomeOf(status int) string {
+	switch status {
+	case http.StatusForbidden, http.StatusLocked:
+		return OutcomeDenied
+	case http.StatusConflict:
+		return OutcomeConflicted
+	default:
+		return ""
+	}
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithClock sets the clock attempts are timed with, the wall clock by default.
+func WithClock(c clock.Clock) Option {
+	return func(r *Recorder) {
+		r.clock = c
+	}
+}
+
+// WithRejectedAttempts sets whether the denied and conflicted attempts are recorded, they are by
+// default. Confirmed and suppressed attempts are recorded regardless.
+func WithRejectedAttempts(record bool) Option {
+	return func(r *Recorder) {
+		r.rejected = record
+	}
+}
+
+// WithLogger sets the logger the attempts which couldn't be recorded are logged with.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(r *Recorder) {
+		r.logger = l
+	}
+}
+
+// Recorder writes the rejected mutation attempts to the audit table.
+type Recorder struct {
+	client   *ent.Client
+	clock    clock.Clock
+	logger   *zap.SugaredLogger
+	rejected bool
+}
+
+// NewRecorder returns a recorder writing with the client.
+func NewRecorder(client *ent.Client, opts ...Option) *Recorder {
+	r := &Recorder{
+		client:   client,
+		clock:    clock.Real{},
+		logger:   zap.NewNop().Sugar(),
+		rejected: true,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Middleware returns echo middleware recording the rejected and confirmed attempts of the
+// operation on the tenant given by the id path parameter, and those suppressing change events on
+// the root of their subtree. It must run after the authentication middleware, for the actor to be
+// known. Failing to record an attempt is logged, the response is left as is.
+func (r *Recorder) Middleware(operation string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			change := captureBody(c.Request())
+
+			err := next(c)
+
+			status, code := failures.Outcome(c, err)
+
+			outcome := OutcomeOf(status)
+			if confirmed, _ := c.Get(confirmedKey).(bool); confirmed && err == nil {
+				outcome = OutcomeConfirmed
+			}
+
+			root, suppressed := c.Get(suppressedKey).(gidx.PrefixedID)
+			if suppressed && err == nil {
+				outcome = OutcomeSuppressed
+			}
+
+			if outcome == "" || (!r.rejected && (outcome == OutcomeDenied || outcome == OutcomeConflicted)) {
+				return err
+			}
+
+			id, perr := gidx.Parse(c.Param("id"))
+			if outcome == OutcomeSuppressed {
+				id, perr = root, nil
+			}
+
+			if perr != nil || id.Prefix() != schema.TenantPrefix {
+				return err
+			}
+
+			actor, _ := c.Request().Context().Value(echojwtx.ActorCtxKey).(string)
+
+			// the attempt is recorded even when the caller went away
+			if rerr := r.client.TenantAudit.Create().
+				SetTenantID(id).
+				SetActor(actor).
+				SetOperation(operation).
+				SetAttemptedChange(string(change)).
+				SetOutcome(outcome).
+				SetCode(code).
+				SetRecordedAt(r.clock.Now().UTC()).
+				Exec(context.Background()); rerr != nil {
+				r.logger.Errorw("failed to record rejected mutation attempt", "tenant_id", id, "operation", operation, "error", rerr)
+			}
+
+			return err
+		}
+	}
+}
+
+// captureBody returns the start of the request body, leaving the body to be read in full.
+func captureBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(req.Body, MaxChangeSize))
+	if err != nil {
+		return nil
+	}
+
+	req.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(head), req.Body), Closer: req.Body}
+
+	return head
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,112 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/pkg/externalid"
+)
+
+var (
+	// ErrMissingRoot is returned when bootstrapping without the name or external id of the root.
+	ErrMissingRoot = errors.New("the root tenant needs a name and an external id")
+
+	// ErrNotRoot is returned when the tenant with the id of the root has been moved under a parent.
+	ErrNotRoot = errors.New("the bootstrapped tenant is no longer a root tenant")
+)
+
+// Root describes the root tenant of the deployment.
+type Root struct {
+	// Name is the name the root is created with. It isn't changed for an existing root.
+	Name string
+	// ExternalID is the fixed reference of the root, its id is derived from it.
+	ExternalID string
+}
+
+// ID returns the id of the root, derived from its external id, see pkg/externalid.
+func (r Root) ID() gidx.PrefixedID {
+	return externalid.TenantID(gidx.NullPrefixedID, r.ExternalID)
+}
+
+// EnsureRoot returns the root tenant, creating it when it doesn't exist yet, and whether it was
+// created. The root is created as the service itself, with the system actor, through the hooks of
+// the client, so it is validated and its change published the same as the tenants created through
+// the apis. The context must carry the auth relationship handler used by the event hooks. When the
+// root is created but its change can't be published, it is returned along with the error.
+func EnsureRoot(ctx context.Context, client *ent.Client, root Root) (*ent.Tenant, bool, error) {
+	if strings.TrimSpace(root.Name) == "" || root.ExternalID == "" {
+		return nil, false, ErrMissingRoot
+	}
+
+	if len(root.ExternalID) > externalid.MaxLength {
+		return nil, false, fmt.Errorf("%w: the external id must be at most %d bytes long", ErrMissingRoot, externalid.MaxLength)
+	}
+
+	t, err := getRoot(ctx, client, root)
+	if err == nil || !ent.IsNotFound(err) {
+		return t, false, err
+	}
+
+	t, err = create(actor.Internal(ctx), client, root)
+
+	switch {
+	case err == nil:
+		return t, true, nil
+	case ent.IsConstraintError(err):
+		// another deploy bootstrapping at the same time got there first
+		t, err = getRoot(ctx, client, root)
+
+		return t, false, err
+	default:
+		return t, t != nil, err
+	}
+}
+
+func getRoot(ctx context.Context, client *ent.Client, root Root) (*ent.Tenant, error) {
+	t, err := client.Tenant.Get(ctx, root.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ParentTenantID != gidx.NullPrefixedID {
+		return nil, fmt.Errorf("%w: %s has parent %s", ErrNotRoot, t.ID, t.ParentTenantID)
+	}
+
+	return t, nil
+}
+
+// create creates the root in a transaction, publishing the change once committed.
+func create(ctx context.Context, client *ent.Client, root Root) (*ent.Tenant, error) {
+	txCtx, batch := changefeed.WithBatch(ctx)
+
+	tx, err := client.Tx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tx.Client().Tenant.Create().
+		SetInput(ent.CreateTenantInput{Name: root.Name}).
+		SetID(root.ID()).
+		SetExternalID(root.ExternalID).
+		Save(txCtx)
+	if err != nil {
+		return nil, errors.Join(err, tx.Rollback())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		return t.Unwrap(), fmt.Errorf("root tenant %s created but its change wasn't published: %w", t.ID, err)
+	}
+
+	return t.Unwrap(), nil
+}
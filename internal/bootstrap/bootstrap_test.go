@@ -0,0 +1,92 @@
+package bootstrap_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/bootstrap"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func newClient(t *testing.T) (context.Context, *ent.Client, *eventtools.MockConnection) {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn, changefeed.WithRequireActor()))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(actor.NewGuard(actor.WithSystemActor("tenant-api")).Hook())
+	client.Tenant.Use(validation.NewNameValidator().Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms), client, conn
+}
+
+func TestEnsureRoot(t *testing.T) {
+	ctx, client, conn := newClient(t)
+
+	root := bootstrap.Root{Name: "  Example ", ExternalID: "deployment-root"}
+
+	first, created, err := bootstrap.EnsureRoot(ctx, client, root)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, root.ID(), first.ID)
+	assert.Equal(t, "Example", first.Name, "the name is validated the same as through the apis")
+	assert.Equal(t, gidx.NullPrefixedID, first.ParentTenantID)
+
+	// running it again, even with another name, finds the same root
+	second, created, err := bootstrap.EnsureRoot(ctx, client, bootstrap.Root{Name: "renamed", ExternalID: root.ExternalID})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, "Example", second.Name)
+
+	assert.Equal(t, 1, client.Tenant.Query().Where(enttenant.ParentTenantIDIsNil()).CountX(ctx))
+
+	// only the creation is published
+	conn.AssertNumberOfCalls(t, "PublishChange", 1)
+}
+
+func TestEnsureRootInvalid(t *testing.T) {
+	ctx, client, _ := newClient(t)
+
+	for _, root := range []bootstrap.Root{
+		{ExternalID: "deployment-root"},
+		{Name: "example"},
+	} {
+		_, _, err := bootstrap.EnsureRoot(ctx, client, root)
+		assert.ErrorIs(t, err, bootstrap.ErrMissingRoot)
+	}
+
+	// the id of the root was taken by a tenant moved under a parent
+	root := bootstrap.Root{Name: "example", ExternalID: "deployment-root"}
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(actor.Internal(ctx))
+	client.Tenant.Create().SetID(root.ID()).SetName("example").SetParentID(parent.ID).ExecX(actor.Internal(ctx))
+
+	_, _, err := bootstrap.EnsureRoot(ctx, client, root)
+	assert.ErrorIs(t, err, bootstrap.ErrNotRoot)
+}
@@ -0,0 +1,132 @@
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+)
+
+// DepthKey is the additional data key of the depth of the tenant of a change, zero for roots. It is
+// the number of ancestors the tenant has, so consumers know whether those among the additional
+// subjects were truncated.
+const DepthKey = "depth"
+
+// AncestorLimit is the number of ancestors of their tenant tenant changes carry among their
+// additional subjects, nearest first.
+type AncestorLimit int
+
+const (
+	// AncestorsNone carries no ancestor, not even the parent.
+	AncestorsNone AncestorLimit = 0
+	// AncestorsParent carries the parent only, the default.
+	AncestorsParent AncestorLimit = 1
+	// AncestorsFull carries every ancestor up to the root.
+	AncestorsFull AncestorLimit = -1
+)
+
+// ParseAncestorLimit parses an ancestor limit: none, parent, full or a number of levels. The empty
+// string is the parent.
+func ParseAncestorLimit(s string) (AncestorLimit, error) {
+	switch s {
+	case "", "parent":
+		return AncestorsParent, nil
+	case "none":
+		return AncestorsNone, nil
+	case "full":
+		return AncestorsFull, nil
+	}
+
+	levels, err := strconv.Atoi(s)
+	if err != nil || levels < 0 {
+		return 0, fmt.Errorf("unknown ancestor limit %q, expected none, parent, full or a number of levels", s)
+	}
+
+	return AncestorLimit(levels), nil
+}
+
+// String returns the limit as parsed by ParseAncestorLimit.
+func (l AncestorLimit) String() string {
+	switch {
+	case l == AncestorsNone:
+		return "none"
+	case l == AncestorsParent:
+		return "parent"
+	case l < 0:
+		return "full"
+	default:
+		return strconv.Itoa(int(l))
+	}
+}
+
+// WithAncestorLimit sets the number of ancestors tenant changes carry among their additional
+// subjects when the context carries an ancestry, see WithAncestry.
+func WithAncestorLimit(limit AncestorLimit) Option {
+	return func(f *Feed) {
+		f.ancestorLimit = limit
+	}
+}
+
+// Ancestry returns the ancestors of the tenant of a change, nearest first. Nil leaves the change
+// as the event hooks made it.
+type Ancestry func(ctx context.Context, message events.ChangeMessage) ([]gidx.PrefixedID, error)
+
+type ancestryCtxKey struct{}
+
+// WithAncestry returns a context in which the tenant changes published through a feed carry the
+// ancestors of their tenant up to the ancestor limit of the feed, in place of the parent the event
+// hooks add, along with its depth. The ancestors are resolved when the change is published, like
+// the root of its subtree.
+func WithAncestry(ctx context.Context, ancestry Ancestry) context.Context {
+	return context.WithValue(ctx, ancestryCtxKey{}, ancestry)
+}
+
+// withAncestors returns the message carrying every ancestor, ahead of its additional subjects
+// which aren't tenants, and the depth of its tenant. Watchers are delivered it as is, to tell the
+// subtrees the change is in, only the published payload is truncated to the limit, see payload.
+func (f *Feed) withAncestors(message events.ChangeMessage, ancestors []gidx.PrefixedID) events.ChangeMessage {
+	subjects := make([]gidx.PrefixedID, 0, len(ancestors)+len(message.AdditionalSubjectIDs))
+	subjects = append(subjects, ancestors...)
+
+	for _, id := range message.AdditionalSubjectIDs {
+		if id.Prefix() != tenantPrefix {
+			subjects = append(subjects, id)
+		}
+	}
+
+	message.AdditionalSubjectIDs = subjects
+	message.AdditionalData = mergeData(message.AdditionalData, map[string]any{DepthKey: len(ancestors)})
+
+	return message
+}
+
+// payload returns the message published to the events pipeline, carrying the ancestors kept under
+// the limit of the feed. Messages without their ancestors, whose depth isn't known, are left as
+// the event hooks made them.
+func (f *Feed) payload(message events.ChangeMessage) events.ChangeMessage {
+	if _, ok := message.AdditionalData[DepthKey]; !ok || f.ancestorLimit < 0 {
+		return message
+	}
+
+	// the ancestors are the only tenants among the subjects, nearest first
+	subjects := make([]gidx.PrefixedID, 0, len(message.AdditionalSubjectIDs))
+	kept := 0
+
+	for _, id := range message.AdditionalSubjectIDs {
+		if id.Prefix() == tenantPrefix {
+			if kept == int(f.ancestorLimit) {
+				continue
+			}
+
+			kept++
+		}
+
+		subjects = append(subjects, id)
+	}
+
+	message.AdditionalSubjectIDs = subjects
+
+	return message
+}
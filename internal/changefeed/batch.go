@@ -0,0 +1,108 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+)
+
+type batchCtxKey struct{}
+
+type pendingChange struct {
+	feed    *Feed
+	topic   string
+	message events.ChangeMessage
+	root    gidx.PrefixedID
+}
+
+// Batch holds the changes published through a feed until they are flushed. It lets changes made in
+// a transaction be published once the transaction commits instead of as each mutation completes.
+type Batch struct {
+	mu            sync.Mutex
+	pending       []pendingChange
+	relationships []heldRelationships
+}
+
+// WithBatch returns a context in which changes published through a feed are held by the returned
+// batch. Flush the batch after committing, changes of a rolled back transaction are discarded by
+// not flushing it.
+func WithBatch(ctx context.Context) (context.Context, *Batch) {
+	b := new(Batch)
+
+	return context.WithValue(ctx, batchCtxKey{}, b), b
+}
+
+func batchFromContext(ctx context.Context) *Batch {
+	b, _ := ctx.Value(batchCtxKey{}).(*Batch)
+
+	return b
+}
+
+// Len returns the number of changes waiting to be published.
+func (b *Batch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.pending)
+}
+
+func (b *Batch) add(change pendingChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, change)
+}
+
+func (b *Batch) addRelationships(r heldRelationships) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.relationships = append(b.relationships, r)
+}
+
+// Flush sends the held auth relationship requests in the order they were made, see
+// HoldRelationships, then publishes the held changes in the order they were made, through the
+// dispatcher of their feed when it has one, which keeps the order of the changes of each tenant
+// only. Every request and change is attempted, the errors of those which failed are joined.
+func (b *Batch) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	relationships := b.relationships
+	b.pending = nil
+	b.relationships = nil
+	b.mu.Unlock()
+
+	var (
+		errs       []error
+		dispatched = make(map[*Dispatcher][]pendingChange)
+	)
+
+	for _, r := range relationships {
+		if err := r.send(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, change := range pending {
+		if d := change.feed.dispatcher; d != nil {
+			dispatched[d] = append(dispatched[d], change)
+
+			continue
+		}
+
+		if _, err := change.feed.publish(ctx, change.topic, change.message, change.root); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for d, changes := range dispatched {
+		if err := d.dispatch(ctx, changes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
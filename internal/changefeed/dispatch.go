@@ -0,0 +1,447 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.infratographer.com/x/gidx"
+	"golang.org/x/time/rate"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+)
+
+// Defaults of the dispatcher.
+const (
+	// DefaultDispatchWorkersPerProc is the number of workers per GOMAXPROCS publishing changes by
+	// default, publishing mostly waits for the events server.
+	DefaultDispatchWorkersPerProc = 2
+	// MinDispatchWorkers is the least number of workers publishing changes by default.
+	MinDispatchWorkers       = 4
+	DefaultDispatchBatchSize = 100
+)
+
+// DefaultDispatchWorkers returns the number of workers publishing changes by default, relative to
+// GOMAXPROCS so it follows the cpus the process is given.
+func DefaultDispatchWorkers() int {
+	n := DefaultDispatchWorkersPerProc * runtime.GOMAXPROCS(0)
+	if n < MinDispatchWorkers {
+		return MinDispatchWorkers
+	}
+
+	return n
+}
+
+const (
+	metricsNamespace = "tenant_api"
+	metricsSubsystem = "dispatch"
+
+	dispatchSucceeded = "success"
+	dispatchFailed    = "failure"
+
+	// tenantPrefix is the id prefix of tenants, changes are partitioned by tenant.
+	tenantPrefix = "tnntten"
+)
+
+// DispatchMetrics exposes the changes waiting to be published, how long the oldest has been
+// waiting, how many were published and how many workers are busy, so dashboards can tell whether
+// a backlog is draining and whether the workers are saturated.
+type DispatchMetrics struct {
+	backlog    prometheus.Gauge
+	backlogAge prometheus.Gauge
+	dispatched *prometheus.CounterVec
+	workers    prometheus.Gauge
+	busy       prometheus.Gauge
+}
+
+// NewDispatchMetrics returns the dispatcher metrics, registered with the registerer.
+func NewDispatchMetrics(reg prometheus.Registerer) (*DispatchMetrics, error) {
+	m := &DispatchMetrics{
+		backlog: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "backlog_changes",
+			Help:      "Number of changes waiting to be published.",
+		}),
+		backlogAge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "backlog_age_seconds",
+			Help:      "Time the oldest change waiting to be published has been waiting, zero when none is.",
+		}),
+		dispatched: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "changes_total",
+			Help:      "Number of changes published by the dispatcher, by result.",
+		}, []string{"result"}),
+		workers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "workers",
+			Help:      "Number of workers publishing changes at most at once.",
+		}),
+		busy: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "busy_workers",
+			Help:      "Number of workers publishing changes, over workers it is their utilization.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{m.backlog, m.backlogAge, m.dispatched, m.workers, m.busy} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	// report both results from the start so rates work before the first failure
+	m.dispatched.WithLabelValues(dispatchSucceeded)
+	m.dispatched.WithLabelValues(dispatchFailed)
+
+	return m, nil
+}
+
+// DispatchOption configures a Dispatcher.
+type DispatchOption func(*Dispatcher)
+
+// WithDispatchWorkers sets the number of workers publishing changes at once, DefaultDispatchWorkers
+// when it isn't positive.
+func WithDispatchWorkers(n int) DispatchOption {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.workers = n
+		}
+	}
+}
+
+// WithDispatchBatchSize sets the number of changes a worker publishes between two waits for the
+// rate limit.
+func WithDispatchBatchSize(n int) DispatchOption {
+	return func(d *Dispatcher) {
+		if n > 0 {
+			d.batchSize = n
+		}
+	}
+}
+
+// WithDispatchRate sets the number of batches published per second, zero doesn't limit them.
+func WithDispatchRate(perSecond float64) DispatchOption {
+	return func(d *Dispatcher) {
+		if perSecond > 0 {
+			d.rate = rate.Limit(perSecond)
+		}
+	}
+}
+
+// WithDispatchMetrics sets the metrics the dispatcher reports to.
+func WithDispatchMetrics(m *DispatchMetrics) DispatchOption {
+	return func(d *Dispatcher) {
+		d.metrics = m
+	}
+}
+
+// WithDispatchClock sets the clock the backlog age and drains are timed with.
+func WithDispatchClock(c clock.Clock) DispatchOption {
+	return func(d *Dispatcher) {
+		d.clock = c
+	}
+}
+
+// Dispatcher publishes held changes with a bounded number of workers, shared by every flush, so
+// a large backlog neither overloads the broker nor starves the other users of the process. The
+// changes are partitioned by tenant, the changes of a tenant are published by one worker in the
+// order they were made. Workers publish changes in batches, waiting for the rate limit before
+// each batch.
+type Dispatcher struct {
+	workers   int
+	batchSize int
+	rate      rate.Limit
+	metrics   *DispatchMetrics
+	clock     clock.Clock
+
+	slots   chan struct{}
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	drainUntil  time.Time
+	drainTimer  *time.Timer
+	lastCall    uint64
+	outstanding map[uint64]time.Time
+	pending     int
+}
+
+// NewDispatcher returns a dispatcher.
+func NewDispatcher(opts ...DispatchOption) *Dispatcher {
+	d := &Dispatcher{
+		workers:     DefaultDispatchWorkers(),
+		batchSize:   DefaultDispatchBatchSize,
+		rate:        rate.Inf,
+		clock:       clock.Real{},
+		outstanding: make(map[uint64]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	d.slots = make(chan struct{}, d.workers)
+	d.limiter = rate.NewLimiter(d.rate, 1)
+
+	if d.metrics != nil {
+		d.metrics.workers.Set(float64(d.workers))
+	}
+
+	return d
+}
+
+// DispatchStatus describes the configuration of a dispatcher and the drain in progress.
+type DispatchStatus struct {
+	Workers   int
+	BatchSize int
+	// Rate is the number of batches published per second, zero when they aren't limited.
+	Rate float64
+	// DrainUntil is the time the raised rate of a drain is lowered back at, nil without a drain.
+	DrainUntil *time.Time
+	// Backlog is the number of changes waiting to be published.
+	Backlog int
+}
+
+// Status returns the configuration of the dispatcher and the drain in progress.
+func (d *Dispatcher) Status() DispatchStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := DispatchStatus{
+		Workers:   d.workers,
+		BatchSize: d.batchSize,
+		Backlog:   d.pending,
+	}
+
+	if limit := d.limiter.Limit(); limit != rate.Inf {
+		status.Rate = float64(limit)
+	}
+
+	if !d.drainUntil.IsZero() {
+		until := d.drainUntil
+		status.DrainUntil = &until
+	}
+
+	return status
+}
+
+// Drain raises the rate to perSecond batches for the duration, to drain a backlog faster during
+// recovery, then lowers it back to the configured rate. A later drain replaces the former one.
+func (d *Dispatcher) Drain(perSecond float64, duration time.Duration) DispatchStatus {
+	d.mu.Lock()
+
+	if d.drainTimer != nil {
+		d.drainTimer.Stop()
+	}
+
+	d.limiter.SetLimit(rate.Limit(perSecond))
+	d.drainUntil = d.clock.Now().Add(duration)
+	d.drainTimer = time.AfterFunc(duration, d.endDrain)
+
+	d.mu.Unlock()
+
+	return d.Status()
+}
+
+func (d *Dispatcher) endDrain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.limiter.SetLimit(d.rate)
+	d.drainUntil = time.Time{}
+	d.drainTimer = nil
+}
+
+// dispatch publishes the changes, returning once every change was attempted. The errors of those
+// which failed are joined.
+func (d *Dispatcher) dispatch(ctx context.Context, changes []pendingChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	call := d.enqueue(len(changes))
+	defer d.dequeue(call)
+
+	partitions := make([][]pendingChange, d.workers)
+
+	for _, change := range changes {
+		i := partition(change, d.workers)
+		partitions[i] = append(partitions[i], change)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, queue := range partitions {
+		if len(queue) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(queue []pendingChange) {
+			defer wg.Done()
+
+			if err := d.run(ctx, queue); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(queue)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// run publishes the queue of a partition in order, batch by batch, each batch taking a worker
+// slot once the rate limit allows it.
+func (d *Dispatcher) run(ctx context.Context, queue []pendingChange) error {
+	var errs []error
+
+	for len(queue) > 0 {
+		n := d.batchSize
+		if n > len(queue) {
+			n = len(queue)
+		}
+
+		batch := queue[:n]
+		queue = queue[n:]
+
+		if err := d.limiter.Wait(ctx); err != nil {
+			d.done(len(batch)+len(queue), dispatchFailed)
+
+			return errors.Join(append(errs, err)...)
+		}
+
+		select {
+		case d.slots <- struct{}{}:
+		case <-ctx.Done():
+			d.done(len(batch)+len(queue), dispatchFailed)
+
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		d.busy(1)
+
+		for _, change := range batch {
+			result := dispatchSucceeded
+
+			if _, err := change.feed.publish(ctx, change.topic, change.message, change.root); err != nil {
+				errs = append(errs, err)
+				result = dispatchFailed
+			}
+
+			d.done(1, result)
+		}
+
+		d.busy(-1)
+		<-d.slots
+	}
+
+	return errors.Join(errs...)
+}
+
+// enqueue adds n changes to the backlog, returning the id of the dispatch they belong to.
+func (d *Dispatcher) enqueue(n int) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastCall++
+	d.outstanding[d.lastCall] = d.clock.Now()
+	d.pending += n
+
+	d.reportBacklog()
+
+	return d.lastCall
+}
+
+// dequeue removes a completed dispatch, its changes already left the backlog.
+func (d *Dispatcher) dequeue(call uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.outstanding, call)
+
+	d.reportBacklog()
+}
+
+// done counts changes leaving the backlog.
+func (d *Dispatcher) done(n int, result string) {
+	d.mu.Lock()
+	d.pending -= n
+	d.reportBacklog()
+	d.mu.Unlock()
+
+	if d.metrics != nil {
+		d.metrics.dispatched.WithLabelValues(result).Add(float64(n))
+	}
+}
+
+func (d *Dispatcher) busy(delta float64) {
+	if d.metrics != nil {
+		d.metrics.busy.Add(delta)
+	}
+}
+
+// reportBacklog sets the backlog gauges, the mutex must be held.
+func (d *Dispatcher) reportBacklog() {
+	if d.metrics == nil {
+		return
+	}
+
+	var oldest time.Time
+
+	for _, enqueued := range d.outstanding {
+		if oldest.IsZero() || enqueued.Before(oldest) {
+			oldest = enqueued
+		}
+	}
+
+	age := 0.0
+	if !oldest.IsZero() {
+		age = d.clock.Now().Sub(oldest).Seconds()
+	}
+
+	d.metrics.backlog.Set(float64(d.pending))
+	d.metrics.backlogAge.Set(age)
+}
+
+// partition returns the worker publishing the change, chosen by its tenant: the subject of
+// tenant changes, the first tenant among the additional subjects of other changes, such as those
+// of service accounts, and the subject when there is none.
+func partition(change pendingChange, workers int) int {
+	key := change.message.SubjectID
+
+	if key.Prefix() != tenantPrefix {
+		for _, id := range change.message.AdditionalSubjectIDs {
+			if id.Prefix() == tenantPrefix {
+				key = id
+
+				break
+			}
+		}
+	}
+
+	return int(hashID(key) % uint32(workers))
+}
+
+func hashID(id gidx.PrefixedID) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+
+	return h.Sum32()
+}
@@ -0,0 +1,227 @@
+package changefeed_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+)
+
+// recordingConn records the order the changes of each tenant are published in and the most
+// publishes in flight at once.
+type recordingConn struct {
+	*eventtools.MockConnection
+
+	mu       sync.Mutex
+	order    map[gidx.PrefixedID][]int
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+}
+
+func newRecordingConn() *recordingConn {
+	c := &recordingConn{
+		MockConnection: new(eventtools.MockConnection),
+		order:          make(map[gidx.PrefixedID][]int),
+	}
+
+	c.On("PublishChange", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		n := c.inFlight.Add(1)
+		defer c.inFlight.Add(-1)
+
+		for seen := c.maxSeen.Load(); n > seen && !c.maxSeen.CompareAndSwap(seen, n); seen = c.maxSeen.Load() {
+		}
+
+		// let the other workers catch up so publishes overlap
+		time.Sleep(20 * time.Microsecond)
+
+		msg := args.Get(1).(events.ChangeMessage)
+
+		c.mu.Lock()
+		c.order[msg.AdditionalSubjectIDs[0]] = append(c.order[msg.AdditionalSubjectIDs[0]], msg.AdditionalData["seq"].(int))
+		c.mu.Unlock()
+	}).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	return c
+}
+
+// holdChanges holds n changes spread over the tenants in the batch, each carrying its sequence
+// within its tenant. Half of them are changes of the tenants themselves, the others of service
+// accounts of the tenants.
+func holdChanges(t *testing.T, ctx context.Context, f *changefeed.Feed, tenants []gidx.PrefixedID, n int) {
+	t.Helper()
+
+	seq := make(map[gidx.PrefixedID]int)
+
+	for i := 0; i < n; i++ {
+		tenant := tenants[i%len(tenants)]
+
+		subject := tenant
+		if i%2 == 1 {
+			subject = gidx.MustNewID("tnntsac")
+		}
+
+		_, err := f.PublishChange(ctx, "tenant", events.ChangeMessage{
+			SubjectID:            subject,
+			AdditionalSubjectIDs: []gidx.PrefixedID{tenant},
+			EventType:            "update",
+			AdditionalData:       map[string]interface{}{"seq": seq[tenant]},
+		})
+		require.NoError(t, err)
+
+		seq[tenant]++
+	}
+}
+
+func TestDispatcherOrderingAndConcurrency(t *testing.T) {
+	const (
+		workers  = 3
+		tenants  = 50
+		perFlush = 1500
+		flushes  = 2
+	)
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := changefeed.NewDispatchMetrics(reg)
+	require.NoError(t, err)
+
+	conn := newRecordingConn()
+	dispatcher := changefeed.NewDispatcher(
+		changefeed.WithDispatchWorkers(workers),
+		changefeed.WithDispatchBatchSize(25),
+		changefeed.WithDispatchMetrics(metrics),
+	)
+	f := changefeed.New(conn, changefeed.WithDispatcher(dispatcher))
+
+	// concurrent flushes, each of its own tenants, share the workers
+	var wg sync.WaitGroup
+
+	for i := 0; i < flushes; i++ {
+		ids := make([]gidx.PrefixedID, tenants)
+		for j := range ids {
+			ids[j] = gidx.MustNewID("tnntten")
+		}
+
+		ctx, batch := changefeed.WithBatch(context.Background())
+		holdChanges(t, ctx, f, ids, perFlush)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, batch.Flush(context.Background()))
+		}()
+	}
+
+	wg.Wait()
+
+	conn.AssertNumberOfCalls(t, "PublishChange", perFlush*flushes)
+
+	require.Len(t, conn.order, tenants*flushes)
+
+	for tenant, order := range conn.order {
+		for i, seq := range order {
+			require.Equal(t, i, seq, "changes of %s published out of order", tenant)
+		}
+	}
+
+	assert.LessOrEqual(t, conn.maxSeen.Load(), int32(workers))
+	assert.Greater(t, conn.maxSeen.Load(), int32(1), "the workers publish at once")
+
+	assert.Equal(t, float64(perFlush*flushes), metricValue(t, reg, "tenant_api_dispatch_changes_total", "success"))
+	assert.Zero(t, metricValue(t, reg, "tenant_api_dispatch_changes_total", "failure"))
+	assert.Zero(t, metricValue(t, reg, "tenant_api_dispatch_backlog_changes", ""))
+	assert.Zero(t, metricValue(t, reg, "tenant_api_dispatch_backlog_age_seconds", ""))
+	assert.Zero(t, metricValue(t, reg, "tenant_api_dispatch_busy_workers", ""))
+	assert.Equal(t, float64(workers), metricValue(t, reg, "tenant_api_dispatch_workers", ""))
+
+	status := dispatcher.Status()
+	assert.Equal(t, workers, status.Workers)
+	assert.Zero(t, status.Backlog)
+}
+
+func TestDispatcherDrain(t *testing.T) {
+	conn := newRecordingConn()
+	dispatcher := changefeed.NewDispatcher(
+		changefeed.WithDispatchWorkers(1),
+		changefeed.WithDispatchBatchSize(10),
+		changefeed.WithDispatchRate(0.001),
+	)
+	f := changefeed.New(conn, changefeed.WithDispatcher(dispatcher))
+	tenants := []gidx.PrefixedID{gidx.MustNewID("tnntten")}
+
+	// the first batch is published at once, the next would wait for long
+	ctx, batch := changefeed.WithBatch(context.Background())
+	holdChanges(t, ctx, f, tenants, 30)
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.Error(t, batch.Flush(flushCtx))
+	conn.AssertNumberOfCalls(t, "PublishChange", 10)
+
+	status := dispatcher.Drain(1000, time.Minute)
+	assert.Equal(t, 1000.0, status.Rate)
+	require.NotNil(t, status.DrainUntil)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), *status.DrainUntil, time.Second)
+
+	ctx, batch = changefeed.WithBatch(context.Background())
+	holdChanges(t, ctx, f, tenants, 30)
+
+	flushCtx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, batch.Flush(flushCtx))
+	conn.AssertNumberOfCalls(t, "PublishChange", 40)
+
+	// the drain ends on its own, restoring the configured rate
+	status = dispatcher.Drain(1000, 10*time.Millisecond)
+	assert.NotNil(t, status.DrainUntil)
+
+	assert.Eventually(t, func() bool {
+		return dispatcher.Status().DrainUntil == nil
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 0.001, dispatcher.Status().Rate)
+}
+
+// metricValue returns the value of the metric, of its series with the label value when not empty.
+func metricValue(t *testing.T, reg *prometheus.Registry, name, label string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if label != "" && (len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != label) {
+				continue
+			}
+
+			if metric.GetCounter() != nil {
+				return metric.GetCounter().GetValue()
+			}
+
+			return metric.GetGauge().GetValue()
+		}
+	}
+
+	require.Failf(t, "metric not found", "%s{%s}", name, label)
+
+	return 0
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changefeed delivers the tenant changes published to the events pipeline to in-process watchers.
+//
+// Every change published through a Feed carries, in its additional data:
+//
+//   - event_id, a UUID unique to the change. Publishing the change again keeps it, so
+//     consumers may receive a change more than once and should drop the ids they processed.
+//   - occurred_at, the time the change was made at, in UTC in the timestamp format of the
+//     deployment: RFC 3339 with milliseconds, or with nanoseconds for compatibility.
+//   - resource_version, for tenant changes, the change sequence the tenant was left at. It
+//     grows with every change, so consumers should ignore a change whose version is not
+//     above the last they applied for the tenant, as changes may arrive out of order.
+//   - depth, for tenant changes, the number of ancestors of the tenant. The additional
+//     subjects carry its ancestors nearest first, up to the limit of the deployment: none,
+//     the parent only by default, a number of levels or every one. Fewer ancestors than
+//     the depth means they were truncated.
+//
+// Admin bulk operations, importing, rebuilding or restoring a subtree, may suppress the change of
+// every tenant they touch. A single change of event type subtree_resynced is published instead
+// once the operation is done, its subject the root of the affected subtree. Consumers should
+// resync that subtree from the api, its additional data holds:
+//
+//   - operation, the bulk operation: import, rebuild or restore.
+//   - root_urn, the urn of the root of the subtree.
+//   - tenants, the number of tenants the operation affected.
+//   - suppressed, the number of changes suppressed by event type, such as create or update.
+//
+// Other api operations never suppress their changes.
+package changefeed
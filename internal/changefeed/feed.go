@@ -0,0 +1,427 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+const (
+	// TenantTopic is the topic the event hooks publish tenant changes to.
+	TenantTopic = "tenant"
+
+	// DefaultHistorySize is the number of changes retained for resuming watchers.
+	DefaultHistorySize = 1024
+
+	watcherBufferSize = 64
+)
+
+// ErrHistoryUnavailable is returned when resuming after a change which is no longer retained,
+// either because it fell out of the history or was published before this process started.
+var ErrHistoryUnavailable = errors.New("changes after the requested id are no longer available")
+
+// Change is a tenant change along with its position in the feed.
+type Change struct {
+	// ID increases by one for every change published through the feed.
+	ID      uint64
+	Message events.ChangeMessage
+}
+
+// Option configures a Feed.
+type Option func(*Feed)
+
+// WithRequireActor rejects changes published without an actor, rather than letting the events
+// pipeline attribute them to an unknown actor.
+func WithRequireActor() Option {
+	return func(f *Feed) {
+		f.requireActor = true
+	}
+}
+
+// WithHistorySize sets the number of changes retained for resuming watchers.
+func WithHistorySize(size int) Option {
+	return func(f *Feed) {
+		f.historySize = size
+	}
+}
+
+// WithSubtreeTopics also publishes tenant changes to the topic of the subtree their tenant is in,
+// see SubtreeTopic. It doubles the tenant changes published, so it is off by default. Changes are
+// only published to it when the context carries a root resolver.
+func WithSubtreeTopics() Option {
+	return func(f *Feed) {
+		f.subtreeTopics = true
+	}
+}
+
+// WithClock sets the clock the time of changes published without a timestamp is taken from.
+func WithClock(c clock.Clock) Option {
+	return func(f *Feed) {
+		f.clock = c
+	}
+}
+
+// WithDispatcher publishes the changes held by batches through the dispatcher when they are
+// flushed, bounding the workers and the rate they are published at.
+func WithDispatcher(d *Dispatcher) Option {
+	return func(f *Feed) {
+		f.dispatcher = d
+	}
+}
+
+// Feed is an events connection which, after publishing a tenant change, also delivers it to
+// the watchers of this process. Watchers only see changes made through this instance, changes
+// made by other replicas are only available from the events pipeline itself.
+type Feed struct {
+	events.Connection
+
+	epoch         string
+	historySize   int
+	requireActor  bool
+	subtreeTopics bool
+	ancestorLimit AncestorLimit
+	clock         clock.Clock
+	dispatcher    *Dispatcher
+
+	mu       sync.Mutex
+	lastID   uint64
+	history  []Change
+	watchers map[chan Change]struct{}
+}
+
+// New wraps an events connection so published tenant changes can be watched.
+func New(conn events.Connection, opts ...Option) *Feed {
+	f := &Feed{
+		Connection:    conn,
+		epoch:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		historySize:   DefaultHistorySize,
+		ancestorLimit: AncestorsParent,
+		clock:         clock.Real{},
+		watchers:      make(map[chan Change]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Epoch identifies this feed instance. Change ids are only comparable within the same epoch.
+func (f *Feed) Epoch() string {
+	return f.epoch
+}
+
+type additionalDataCtxKey struct{}
+
+// WithAdditionalData returns a context in which changes published through a feed carry the data in
+// their additional data. It lets callers annotate the changes made by the event hooks, the data is
+// added to any the context already carries.
+func WithAdditionalData(ctx context.Context, data map[string]any) context.Context {
+	if existing, ok := ctx.Value(additionalDataCtxKey{}).(map[string]any); ok {
+		data = mergeData(existing, data)
+	}
+
+	return context.WithValue(ctx, additionalDataCtxKey{}, data)
+}
+
+// Additional data keys of the consumer contract, see the package documentation.
+const (
+	// EventIDKey is the key of the unique id of the change, kept when its publishing is retried.
+	EventIDKey = "event_id"
+	// ResourceVersionKey is the key of the version of the tenant the change left, its change
+	// sequence. Changes published without a sequence, such as those of other topics, have none.
+	ResourceVersionKey = "resource_version"
+	// OccurredAtKey is the key of the time the change was made at, in the timestamp format of
+	// timefmt.
+	OccurredAtKey = "occurred_at"
+)
+
+// SnapshotKey is the additional data key tenant changes carry the snapshot of their tenant under.
+const SnapshotKey = "snapshot"
+
+// Snapshotter returns the snapshot of the tenant a change is about, nil when there is none.
+type Snapshotter func(ctx context.Context, id gidx.PrefixedID) (any, error)
+
+type snapshotterCtxKey struct{}
+
+// WithSnapshotter returns a context in which tenant changes published through a feed carry the
+// snapshot of their tenant. The snapshot is taken when the change is published, before it is held
+// by a batch, so it reflects the tenant within the transaction making the change.
+func WithSnapshotter(ctx context.Context, snapshotter Snapshotter) context.Context {
+	return context.WithValue(ctx, snapshotterCtxKey{}, snapshotter)
+}
+
+// EffectiveLabelsKey is the additional data key of the effective labels of the tenant of a change.
+const EffectiveLabelsKey = "effective_labels"
+
+// Labeler returns the effective labels of the tenant a change is about, nil when there are none.
+type Labeler func(ctx context.Context, id gidx.PrefixedID) (map[string]string, error)
+
+type labelerCtxKey struct{}
+
+// WithLabeler returns a context in which tenant changes published through a feed carry the
+// effective labels of their tenant, resolved when the change is published like snapshots.
+func WithLabeler(ctx context.Context, labeler Labeler) context.Context {
+	return context.WithValue(ctx, labelerCtxKey{}, labeler)
+}
+
+// SubtreeTopic returns the topic the changes of the tenants under the root are also published to
+// with WithSubtreeTopics. Consumers of a single subtree subscribe to the changes of
+// "*.tenant.<root id>" rather than filtering those of every tenant.
+func SubtreeTopic(root gidx.PrefixedID) string {
+	return TenantTopic + "." + root.String()
+}
+
+// RootResolver returns the root of the subtree the tenant of the change is in, the tenant itself
+// for roots. A null id leaves the change on the tenant topic only.
+type RootResolver func(ctx context.Context, message events.ChangeMessage) (gidx.PrefixedID, error)
+
+type rootResolverCtxKey struct{}
+
+// WithRootResolver returns a context in which tenant changes published through a feed with subtree
+// topics are also published to the topic of their root. The root is resolved when the change is
+// published, before it is held by a batch, like the snapshot.
+func WithRootResolver(ctx context.Context, resolver RootResolver) context.Context {
+	return context.WithValue(ctx, rootResolverCtxKey{}, resolver)
+}
+
+// PublishChange publishes the change to the events pipeline and then delivers tenant changes to watchers.
+// When the context carries a batch the change is held by it instead and nil is returned, when it
+// carries a suppression the change is only counted. Changes get the actor of the context, as a
+// batch may be flushed with another one.
+func (f *Feed) PublishChange(ctx context.Context, topic string, message events.ChangeMessage) (events.Message[events.ChangeMessage], error) {
+	if s := suppressionFromContext(ctx); s != nil {
+		s.add(message.EventType)
+
+		return nil, nil
+	}
+
+	if message.ActorID == gidx.NullPrefixedID {
+		if id := actor.FromContext(ctx); id != "" {
+			message.ActorID = gidx.PrefixedID(id)
+		} else if f.requireActor {
+			return nil, actor.ErrMissingActor
+		}
+	}
+
+	if data, ok := ctx.Value(additionalDataCtxKey{}).(map[string]any); ok && len(data) != 0 {
+		message.AdditionalData = mergeData(message.AdditionalData, data)
+	}
+
+	message.AdditionalData = mergeData(message.AdditionalData, f.eventData(message))
+
+	if snapshotter, ok := ctx.Value(snapshotterCtxKey{}).(Snapshotter); ok && topic == TenantTopic {
+		snapshot, err := snapshotter(ctx, message.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		if snapshot != nil {
+			message.AdditionalData = mergeData(message.AdditionalData, map[string]any{SnapshotKey: snapshot})
+		}
+	}
+
+	if labeler, ok := ctx.Value(labelerCtxKey{}).(Labeler); ok && topic == TenantTopic {
+		labels, err := labeler(ctx, message.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+
+		if labels != nil {
+			message.AdditionalData = mergeData(message.AdditionalData, map[string]any{EffectiveLabelsKey: labels})
+		}
+	}
+
+	root := gidx.NullPrefixedID
+
+	if resolver, ok := ctx.Value(rootResolverCtxKey{}).(RootResolver); ok && f.subtreeTopics && topic == TenantTopic {
+		var err error
+
+		root, err = resolver(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the root is resolved from the parent the event hooks add, before it may be dropped
+	if ancestry, ok := ctx.Value(ancestryCtxKey{}).(Ancestry); ok && topic == TenantTopic {
+		ancestors, err := ancestry(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+
+		if ancestors != nil {
+			message = f.withAncestors(message, ancestors)
+		}
+	}
+
+	if b := batchFromContext(ctx); b != nil {
+		b.add(pendingChange{feed: f, topic: topic, message: message, root: root})
+
+		return nil, nil
+	}
+
+	return f.publish(ctx, topic, message, root)
+}
+
+// eventData returns the id and time of the change, unless the message carries them already. They
+// are set as the change is published, before it is held by a batch, so retrying to publish it
+// keeps them.
+func (f *Feed) eventData(message events.ChangeMessage) map[string]any {
+	data := make(map[string]any, 2)
+
+	if _, ok := message.AdditionalData[EventIDKey]; !ok {
+		data[EventIDKey] = uuid.NewString()
+	}
+
+	if _, ok := message.AdditionalData[OccurredAtKey]; !ok {
+		occurredAt := message.Timestamp
+		if occurredAt.IsZero() {
+			occurredAt = f.clock.Now()
+		}
+
+		data[OccurredAtKey] = timefmt.New(occurredAt).String()
+	}
+
+	return data
+}
+
+// mergeData returns a copy of the additional data with the given data added, the message's own
+// additional data is left as is.
+func mergeData(additional, data map[string]any) map[string]any {
+	merged := make(map[string]any, len(additional)+len(data))
+
+	for k, v := range additional {
+		merged[k] = v
+	}
+
+	for k, v := range data {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// publish publishes the change, then to the topic of the subtree under root unless it is null.
+// Watchers are delivered the change once it is published to its own topic, with every ancestor
+// of its tenant whatever the limit of the published payload.
+func (f *Feed) publish(ctx context.Context, topic string, message events.ChangeMessage, root gidx.PrefixedID) (events.Message[events.ChangeMessage], error) {
+	payload := f.payload(message)
+
+	msg, err := f.Connection.PublishChange(ctx, topic, payload)
+	if err != nil {
+		return msg, err
+	}
+
+	if topic == TenantTopic {
+		f.broadcast(message)
+	}
+
+	if root != gidx.NullPrefixedID {
+		if _, err := f.Connection.PublishChange(ctx, SubtreeTopic(root), payload); err != nil {
+			return msg, fmt.Errorf("publishing to the subtree of %s: %w", root, err)
+		}
+	}
+
+	return msg, nil
+}
+
+// LastID returns the id of the last change published through the feed, zero before the first.
+func (f *Feed) LastID() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.lastID
+}
+
+// Subscribe registers a watcher receiving changes published from now on. The returned channel
+// is closed when the watcher is unsubscribed or falls too far behind.
+func (f *Feed) Subscribe() (<-chan Change, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.subscribe()
+}
+
+// SubscribeAfter registers a watcher and returns the retained changes published after lastID.
+// When some of those changes are no longer retained ErrHistoryUnavailable is returned along with
+// the live subscription, so the watcher can continue after refreshing its state.
+func (f *Feed) SubscribeAfter(lastID uint64) ([]Change, <-chan Change, func(), error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch, unsubscribe := f.subscribe()
+
+	if lastID > f.lastID {
+		return nil, ch, unsubscribe, ErrHistoryUnavailable
+	}
+
+	if len(f.history) != 0 && lastID+1 < f.history[0].ID {
+		return nil, ch, unsubscribe, ErrHistoryUnavailable
+	}
+
+	var replay []Change
+
+	for _, change := range f.history {
+		if change.ID > lastID {
+			replay = append(replay, change)
+		}
+	}
+
+	return replay, ch, unsubscribe, nil
+}
+
+func (f *Feed) subscribe() (<-chan Change, func()) {
+	ch := make(chan Change, watcherBufferSize)
+
+	f.watchers[ch] = struct{}{}
+
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if _, ok := f.watchers[ch]; ok {
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *Feed) broadcast(message events.ChangeMessage) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastID++
+
+	change := Change{ID: f.lastID, Message: message}
+
+	if f.historySize > 0 {
+		if len(f.history) == f.historySize {
+			f.history = f.history[1:]
+		}
+
+		f.history = append(f.history, change)
+	}
+
+	for ch := range f.watchers {
+		select {
+		case ch <- change:
+		default:
+			// never block publishers on a slow watcher, drop it instead
+			delete(f.watchers, ch)
+			close(ch)
+		}
+	}
+}
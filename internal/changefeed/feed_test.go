@@ -0,0 +1,433 @@
+package changefeed_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/permissions-api/pkg/permissions/mockpermissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+func newFeed(opts ...changefeed.Option) *changefeed.Feed {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	return changefeed.New(conn, opts...)
+}
+
+func publish(t *testing.T, f *changefeed.Feed, topic string, subject gidx.PrefixedID) {
+	t.Helper()
+
+	_, err := f.PublishChange(context.Background(), topic, events.ChangeMessage{SubjectID: subject, EventType: "update"})
+	require.NoError(t, err)
+}
+
+func TestFeedSubscribe(t *testing.T) {
+	f := newFeed()
+
+	changes, unsubscribe := f.Subscribe()
+
+	publish(t, f, "other", "tnntten-other")
+	publish(t, f, changefeed.TenantTopic, "tnntten-one")
+
+	change := <-changes
+	assert.Equal(t, uint64(1), change.ID)
+	assert.Equal(t, gidx.PrefixedID("tnntten-one"), change.Message.SubjectID)
+
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-changes
+	assert.False(t, ok)
+}
+
+func TestFeedSubscribeAfter(t *testing.T) {
+	f := newFeed(changefeed.WithHistorySize(2))
+
+	for _, id := range []gidx.PrefixedID{"tnntten-one", "tnntten-two", "tnntten-three"} {
+		publish(t, f, changefeed.TenantTopic, id)
+	}
+
+	replay, _, unsubscribe, err := f.SubscribeAfter(2)
+	require.NoError(t, err)
+	require.Len(t, replay, 1)
+	assert.Equal(t, gidx.PrefixedID("tnntten-three"), replay[0].Message.SubjectID)
+
+	unsubscribe()
+
+	replay, _, unsubscribe, err = f.SubscribeAfter(3)
+	require.NoError(t, err)
+	assert.Empty(t, replay)
+
+	unsubscribe()
+
+	// the first change fell out of the history
+	_, changes, unsubscribe, err := f.SubscribeAfter(0)
+	assert.ErrorIs(t, err, changefeed.ErrHistoryUnavailable)
+
+	publish(t, f, changefeed.TenantTopic, "tnntten-four")
+	assert.Equal(t, uint64(4), (<-changes).ID)
+
+	unsubscribe()
+
+	_, _, unsubscribe, err = f.SubscribeAfter(10)
+	assert.ErrorIs(t, err, changefeed.ErrHistoryUnavailable)
+
+	unsubscribe()
+}
+
+func TestBatch(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	f := changefeed.New(conn)
+
+	changes, unsubscribe := f.Subscribe()
+	defer unsubscribe()
+
+	ctx, batch := changefeed.WithBatch(context.Background())
+
+	for _, id := range []gidx.PrefixedID{"tnntten-one", "tnntten-two"} {
+		_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: id, EventType: "update"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, batch.Len())
+	conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+	assert.Empty(t, changes)
+
+	require.NoError(t, batch.Flush(context.Background()))
+	assert.Equal(t, 0, batch.Len())
+	conn.AssertNumberOfCalls(t, "PublishChange", 2)
+
+	assert.Equal(t, gidx.PrefixedID("tnntten-one"), (<-changes).Message.SubjectID)
+	assert.Equal(t, gidx.PrefixedID("tnntten-two"), (<-changes).Message.SubjectID)
+}
+
+func TestBatchHoldsRelationships(t *testing.T) {
+	perms := new(mockpermissions.MockPermissions)
+	perms.On("CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	perms.On("DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx, batch := changefeed.WithBatch(perms.ContextWithHandler(context.Background()))
+	ctx = changefeed.HoldRelationships(ctx)
+
+	parent := events.AuthRelationshipRelation{Relation: "parent", SubjectID: "tnntten-parent"}
+
+	require.NoError(t, permissions.DeleteAuthRelationships(ctx, "tenant", "tnntten-child", parent))
+	require.NoError(t, permissions.CreateAuthRelationships(ctx, "tenant", "tnntten-child", parent))
+
+	perms.AssertNotCalled(t, "CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything)
+	perms.AssertNotCalled(t, "DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, batch.Flush(context.Background()))
+
+	require.Len(t, perms.Calls, 2)
+	assert.Equal(t, "DeleteAuthRelationships", perms.Calls[0].Method)
+	assert.Equal(t, "CreateAuthRelationships", perms.Calls[1].Method)
+
+	// without a batch the requests are sent at once
+	require.NoError(t, permissions.CreateAuthRelationships(changefeed.HoldRelationships(perms.ContextWithHandler(context.Background())), "tenant", "tnntten-child", parent))
+	perms.AssertNumberOfCalls(t, "CreateAuthRelationships", 2)
+}
+
+func TestSuppression(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	f := changefeed.New(conn)
+
+	changes, unsubscribe := f.Subscribe()
+	defer unsubscribe()
+
+	// suppressed changes are counted rather than held by the batch
+	ctx, s := changefeed.WithSuppression(context.Background())
+	ctx, batch := changefeed.WithBatch(ctx)
+
+	for _, eventType := range []string{"create", "create", "update"} {
+		_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: eventType})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 0, batch.Len())
+	assert.Equal(t, map[string]int{"create": 2, "update": 1}, s.Counts())
+
+	require.NoError(t, changefeed.PublishSummary(ctx, f, changefeed.Summary{Operation: "import", Root: "tnntten-one", Tenants: 2}, s))
+
+	conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	change := <-changes
+	assert.Equal(t, changefeed.SummaryEventType, change.Message.EventType)
+	assert.Equal(t, gidx.PrefixedID("tnntten-one"), change.Message.SubjectID)
+	assert.Equal(t, "import", change.Message.AdditionalData[changefeed.SummaryOperationKey])
+	assert.Equal(t, 2, change.Message.AdditionalData[changefeed.SummaryTenantsKey])
+	assert.Empty(t, changes)
+}
+
+func TestFeedAdditionalData(t *testing.T) {
+	f := newFeed()
+
+	changes, unsubscribe := f.Subscribe()
+	defer unsubscribe()
+
+	ctx := changefeed.WithAdditionalData(context.Background(), map[string]any{"merged_into": "tnntten-target"})
+
+	_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{
+		SubjectID:      "tnntten-one",
+		AdditionalData: map[string]any{"other": "value"},
+	})
+	require.NoError(t, err)
+
+	change := <-changes
+	assert.Equal(t, map[string]any{
+		"merged_into":            "tnntten-target",
+		"other":                  "value",
+		changefeed.EventIDKey:    change.Message.AdditionalData[changefeed.EventIDKey],
+		changefeed.OccurredAtKey: change.Message.AdditionalData[changefeed.OccurredAtKey],
+	}, change.Message.AdditionalData)
+}
+
+func TestFeedEventData(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 30, 0, 123456789, time.FixedZone("", 2*60*60))
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	f := changefeed.New(conn, changefeed.WithClock(clock.NewFake(now)))
+
+	ctx := changefeed.WithAdditionalData(context.Background(), map[string]any{changefeed.ResourceVersionKey: int64(7)})
+
+	_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: "update"})
+	require.NoError(t, err)
+
+	message := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+
+	eventID, ok := message.AdditionalData[changefeed.EventIDKey].(string)
+	require.True(t, ok)
+
+	_, err = uuid.Parse(eventID)
+	require.NoError(t, err)
+
+	// the serialized format is part of the consumer contract
+	data, err := json.Marshal(message.AdditionalData)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event_id": "`+eventID+`",
+		"resource_version": 7,
+		"occurred_at": "2026-10-17T10:30:00.123Z"
+	}`, string(data))
+
+	// publishing the message again, as a retry does, keeps its id and time
+	_, err = f.PublishChange(context.Background(), changefeed.TenantTopic, message)
+	require.NoError(t, err)
+
+	assert.Equal(t, message.AdditionalData, conn.Calls[1].Arguments.Get(1).(events.ChangeMessage).AdditionalData)
+
+	// the time of the message is preferred over the clock
+	_, err = f.PublishChange(context.Background(), changefeed.TenantTopic, events.ChangeMessage{
+		SubjectID: "tnntten-two",
+		Timestamp: now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+
+	other := conn.Calls[2].Arguments.Get(1).(events.ChangeMessage).AdditionalData
+	assert.Equal(t, "2026-10-17T09:30:00.123Z", other[changefeed.OccurredAtKey])
+	assert.NotEqual(t, eventID, other[changefeed.EventIDKey])
+}
+
+func TestFeedEventDataNanoFormat(t *testing.T) {
+	timefmt.SetFormat(timefmt.RFC3339Nano)
+	t.Cleanup(func() { timefmt.SetFormat(timefmt.RFC3339) })
+
+	now := time.Date(2026, 10, 17, 12, 30, 0, 123456789, time.FixedZone("", 2*60*60))
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	f := changefeed.New(conn, changefeed.WithClock(clock.NewFake(now)))
+
+	_, err := f.PublishChange(context.Background(), changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one"})
+	require.NoError(t, err)
+
+	message := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, "2026-10-17T10:30:00.123456789Z", message.AdditionalData[changefeed.OccurredAtKey])
+}
+
+func TestBatchKeepsEventID(t *testing.T) {
+	f := newFeed()
+
+	changes, unsubscribe := f.Subscribe()
+	defer unsubscribe()
+
+	ctx, batch := changefeed.WithBatch(context.Background())
+
+	_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{
+		SubjectID:      "tnntten-one",
+		AdditionalData: map[string]any{changefeed.EventIDKey: "set-by-producer"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, batch.Flush(context.Background()))
+
+	assert.Equal(t, "set-by-producer", (<-changes).Message.AdditionalData[changefeed.EventIDKey])
+}
+
+func TestFeedRequireActor(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	f := changefeed.New(conn, changefeed.WithRequireActor())
+
+	_, err := f.PublishChange(context.Background(), changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: "update"})
+	require.ErrorIs(t, err, actor.ErrMissingActor)
+	conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+
+	// batched changes keep the actor they were made with, whoever flushes them
+	ctx, batch := changefeed.WithBatch(actor.NewContext(context.Background(), "idntusr-someone"))
+
+	_, err = f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: "update"})
+	require.NoError(t, err)
+
+	require.NoError(t, batch.Flush(context.Background()))
+	conn.AssertCalled(t, "PublishChange", changefeed.TenantTopic, mock.MatchedBy(func(m events.ChangeMessage) bool {
+		return m.ActorID == "idntusr-someone"
+	}))
+}
+
+func TestFeedSubtreeTopics(t *testing.T) {
+	resolver := func(_ context.Context, message events.ChangeMessage) (gidx.PrefixedID, error) {
+		return "tnntten-root", nil
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []changefeed.Option
+		resolver bool
+		topics   []string
+	}{
+		{"enabled", []changefeed.Option{changefeed.WithSubtreeTopics()}, true, []string{changefeed.TenantTopic, "tenant.tnntten-root"}},
+		{"disabled", nil, true, []string{changefeed.TenantTopic}},
+		{"without resolver", []changefeed.Option{changefeed.WithSubtreeTopics()}, false, []string{changefeed.TenantTopic}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := new(eventtools.MockConnection)
+			conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+			f := changefeed.New(conn, tt.opts...)
+
+			ctx, batch := changefeed.WithBatch(context.Background())
+
+			if tt.resolver {
+				ctx = changefeed.WithRootResolver(ctx, resolver)
+			}
+
+			_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: "update"})
+			require.NoError(t, err)
+
+			// the root is resolved as the change is published, the batch is flushed without a resolver
+			require.NoError(t, batch.Flush(context.Background()))
+
+			var topics []string
+
+			for _, call := range conn.Calls {
+				topics = append(topics, call.Arguments.String(0))
+			}
+
+			assert.Equal(t, tt.topics, topics)
+		})
+	}
+}
+
+func TestFeedAncestors(t *testing.T) {
+	ancestry := func(_ context.Context, message events.ChangeMessage) ([]gidx.PrefixedID, error) {
+		return []gidx.PrefixedID{"tnntten-parent", "tnntten-grandparent", "tnntten-root"}, nil
+	}
+
+	// the serialized subjects and depth are part of the consumer contract
+	for _, tt := range []struct {
+		limit    string
+		expected string
+	}{
+		{"none", `{"subjects": ["idntusr-other"], "depth": 3}`},
+		{"parent", `{"subjects": ["tnntten-parent", "idntusr-other"], "depth": 3}`},
+		{"2", `{"subjects": ["tnntten-parent", "tnntten-grandparent", "idntusr-other"], "depth": 3}`},
+		{"5", `{"subjects": ["tnntten-parent", "tnntten-grandparent", "tnntten-root", "idntusr-other"], "depth": 3}`},
+		{"full", `{"subjects": ["tnntten-parent", "tnntten-grandparent", "tnntten-root", "idntusr-other"], "depth": 3}`},
+	} {
+		t.Run(tt.limit, func(t *testing.T) {
+			limit, err := changefeed.ParseAncestorLimit(tt.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.limit, limit.String())
+
+			conn := new(eventtools.MockConnection)
+			conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+			f := changefeed.New(conn, changefeed.WithAncestorLimit(limit))
+
+			changes, unsubscribe := f.Subscribe()
+			defer unsubscribe()
+
+			ctx := changefeed.WithAncestry(context.Background(), ancestry)
+
+			_, err = f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{
+				SubjectID:            "tnntten-one",
+				EventType:            "create",
+				AdditionalSubjectIDs: []gidx.PrefixedID{"tnntten-parent", "idntusr-other"},
+			})
+			require.NoError(t, err)
+
+			message := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+
+			data, err := json.Marshal(map[string]any{
+				"subjects": message.AdditionalSubjectIDs,
+				"depth":    message.AdditionalData[changefeed.DepthKey],
+			})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+
+			// watchers tell the subtrees of the change from every ancestor, whatever the limit
+			assert.Equal(t, []gidx.PrefixedID{"tnntten-parent", "tnntten-grandparent", "tnntten-root", "idntusr-other"},
+				(<-changes).Message.AdditionalSubjectIDs)
+		})
+	}
+
+	t.Run("root", func(t *testing.T) {
+		conn := new(eventtools.MockConnection)
+		conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+		f := changefeed.New(conn, changefeed.WithAncestorLimit(changefeed.AncestorsFull))
+
+		ctx := changefeed.WithAncestry(context.Background(), func(context.Context, events.ChangeMessage) ([]gidx.PrefixedID, error) {
+			return []gidx.PrefixedID{}, nil
+		})
+
+		_, err := f.PublishChange(ctx, changefeed.TenantTopic, events.ChangeMessage{SubjectID: "tnntten-one", EventType: "create"})
+		require.NoError(t, err)
+
+		message := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+		assert.Empty(t, message.AdditionalSubjectIDs)
+		assert.Equal(t, 0, message.AdditionalData[changefeed.DepthKey])
+	})
+
+	_, err := changefeed.ParseAncestorLimit("-1")
+	assert.Error(t, err)
+
+	limit, err := changefeed.ParseAncestorLimit("")
+	require.NoError(t, err)
+	assert.Equal(t, changefeed.AncestorsParent, limit)
+}
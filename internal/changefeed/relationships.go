@@ -0,0 +1,59 @@
+package changefeed
+
+import (
+	"context"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+)
+
+// heldRelationships is an auth relationship request held by a batch until it is flushed.
+type heldRelationships struct {
+	handler   permissions.AuthRelationshipRequestHandler
+	create    bool
+	topic     string
+	resource  gidx.PrefixedID
+	relations []events.AuthRelationshipRelation
+}
+
+func (r heldRelationships) send(ctx context.Context) error {
+	if r.create {
+		return r.handler.CreateAuthRelationships(ctx, r.topic, r.resource, r.relations...)
+	}
+
+	return r.handler.DeleteAuthRelationships(ctx, r.topic, r.resource, r.relations...)
+}
+
+// relationshipHolder is an auth relationship request handler adding the requests to a batch.
+type relationshipHolder struct {
+	batch   *Batch
+	handler permissions.AuthRelationshipRequestHandler
+}
+
+func (h *relationshipHolder) CreateAuthRelationships(_ context.Context, topic string, resource gidx.PrefixedID, relations ...events.AuthRelationshipRelation) error {
+	h.batch.addRelationships(heldRelationships{handler: h.handler, create: true, topic: topic, resource: resource, relations: relations})
+
+	return nil
+}
+
+func (h *relationshipHolder) DeleteAuthRelationships(_ context.Context, topic string, resource gidx.PrefixedID, relations ...events.AuthRelationshipRelation) error {
+	h.batch.addRelationships(heldRelationships{handler: h.handler, topic: topic, resource: resource, relations: relations})
+
+	return nil
+}
+
+// HoldRelationships returns a context in which the auth relationship requests, such as those of
+// the event hooks, are held by the batch of the context along with the changes, so a rolled back
+// transaction leaves the relationships as they were. The context is returned as is when it holds
+// no batch or no relationship request handler.
+func HoldRelationships(ctx context.Context) context.Context {
+	b := batchFromContext(ctx)
+
+	handler, ok := ctx.Value(permissions.AuthRelationshipRequestHandlerCtxKey).(permissions.AuthRelationshipRequestHandler)
+	if b == nil || !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, &relationshipHolder{batch: b, handler: handler})
+}
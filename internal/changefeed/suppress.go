@@ -0,0 +1,106 @@
+package changefeed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+// SummaryEventType is the event type of the single change published in place of the changes an
+// admin bulk operation suppressed, telling consumers to resync the subtree under its subject.
+const SummaryEventType = "subtree_resynced"
+
+// Additional data keys of summary events.
+const (
+	// SummaryOperationKey holds the bulk operation, such as import, rebuild or restore.
+	SummaryOperationKey = "operation"
+	// SummaryRootURNKey holds the urn of the root of the affected subtree, the subject of the event.
+	SummaryRootURNKey = "root_urn"
+	// SummaryTenantsKey holds the number of tenants the operation affected.
+	SummaryTenantsKey = "tenants"
+	// SummarySuppressedKey holds the number of suppressed changes by event type.
+	SummarySuppressedKey = "suppressed"
+)
+
+type suppressionCtxKey struct{}
+
+// Suppression counts the changes published through a feed in its context instead of publishing
+// them.
+type Suppression struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// WithSuppression returns a context in which changes published through a feed are counted by the
+// returned suppression and dropped, watchers aren't delivered them either. Only admin bulk
+// operations suppress their changes, publishing a summary once done, see PublishSummary.
+func WithSuppression(ctx context.Context) (context.Context, *Suppression) {
+	s := &Suppression{counts: make(map[string]int)}
+
+	return context.WithValue(ctx, suppressionCtxKey{}, s), s
+}
+
+func suppressionFromContext(ctx context.Context) *Suppression {
+	s, _ := ctx.Value(suppressionCtxKey{}).(*Suppression)
+
+	return s
+}
+
+func (s *Suppression) add(eventType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[eventType]++
+}
+
+// Counts returns the number of suppressed changes by event type.
+func (s *Suppression) Counts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int, len(s.counts))
+
+	for k, v := range s.counts {
+		counts[k] = v
+	}
+
+	return counts
+}
+
+// Summary describes a bulk operation which suppressed its changes.
+type Summary struct {
+	// Operation is the bulk operation, such as import, rebuild or restore.
+	Operation string
+	// Root is the root of the subtree the operation affected.
+	Root gidx.PrefixedID
+	// Tenants is the number of tenants the operation affected.
+	Tenants int
+}
+
+// PublishSummary publishes the summary of the changes suppressed by the bulk operation to the
+// tenant topic, a single change of SummaryEventType whose subject is the root of the affected
+// subtree. Through a feed it carries the event id, time and actor of any other change.
+func PublishSummary(ctx context.Context, conn events.Connection, summary Summary, s *Suppression) error {
+	// the summary itself isn't suppressed, nor held by a batch
+	ctx = context.WithValue(ctx, suppressionCtxKey{}, (*Suppression)(nil))
+	ctx = context.WithValue(ctx, batchCtxKey{}, (*Batch)(nil))
+
+	_, err := conn.PublishChange(ctx, TenantTopic, events.ChangeMessage{
+		SubjectID: summary.Root,
+		EventType: SummaryEventType,
+		Timestamp: time.Now().UTC(),
+		AdditionalData: map[string]any{
+			SummaryOperationKey:  summary.Operation,
+			SummaryRootURNKey:    urnx.NewTenantURN(summary.Root),
+			SummaryTenantsKey:    summary.Tenants,
+			SummarySuppressedKey: s.Counts(),
+		},
+	})
+
+	return err
+}
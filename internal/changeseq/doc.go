@@ -0,0 +1,24 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changeseq assigns every change made to a tenant a sequence number. Sequences are the
+// primary keys of the tenant_changes table, so they increase with every change committed and
+// are stored on the tenant as change_seq and carried by its change events under the change_seq
+// additional data key.
+//
+// Events may be delivered more than once and out of order. Consumers should remember the highest
+// sequence applied for each tenant and ignore the events of a tenant with a lower or equal
+// sequence, they are older than the state already applied. Sequences may have gaps, they only
+// tell which of two changes of a tenant is the latest.
+package changeseq
@@ -0,0 +1,141 @@
+package changeseq
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// Key is the additional data key change events carry the sequence of their change under.
+const Key = "change_seq"
+
+// Operations recorded for the changes.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// Hook returns an ent hook recording each tenant mutation in the tenant_changes table, with the
+// client of the mutation so the sequence is only taken when its transaction commits. Created and
+// updated tenants are stored with the sequence of the change, every tenant of an update of many
+// gets the same one. Deletions record the name and parent of the tenant, so the names of purged
+// tenants can still be looked up. Mutations setting the sequence themselves, as Repair does,
+// aren't recorded. It must be registered before the event hooks so their events carry the
+// sequence, as their resource version as well.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if _, ok := m.ChangeSeq(); ok {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := changedIDs(ctx, m)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(ids) == 0 {
+					return next.Mutate(ctx, m)
+				}
+
+				op := operation(m.Op())
+				changedAt := time.Now().UTC()
+
+				var deleted map[gidx.PrefixedID]*generated.Tenant
+
+				if op == OpDelete {
+					if deleted, err = loadDeleted(ctx, m.Client(), ids); err != nil {
+						return nil, err
+					}
+				}
+
+				builders := make([]*generated.TenantChangeCreate, len(ids))
+
+				for i, id := range ids {
+					builders[i] = m.Client().TenantChange.Create().
+						SetTenantID(id).
+						SetOperation(op).
+						SetChangedAt(changedAt)
+
+					if t, ok := deleted[id]; ok {
+						builders[i].SetName(t.Name)
+
+						if t.ParentTenantID != gidx.NullPrefixedID {
+							builders[i].SetParentTenantID(t.ParentTenantID)
+						}
+					}
+				}
+
+				changes, err := m.Client().TenantChange.CreateBulk(builders...).Save(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				seq := changes[len(changes)-1].ID
+
+				if op != OpDelete {
+					m.SetChangeSeq(seq)
+				}
+
+				return next.Mutate(changefeed.WithAdditionalData(ctx, map[string]any{
+					Key:                           seq,
+					changefeed.ResourceVersionKey: seq,
+				}), m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+func operation(op ent.Op) string {
+	switch {
+	case op.Is(ent.OpCreate):
+		return OpCreate
+	case op.Is(ent.OpDelete | ent.OpDeleteOne):
+		return OpDelete
+	default:
+		return OpUpdate
+	}
+}
+
+// changedIDs returns the ids of the tenants the mutation changes. The id of a created tenant is
+// set with its defaults, before the hooks run.
+func changedIDs(ctx context.Context, m *generated.TenantMutation) ([]gidx.PrefixedID, error) {
+	if m.Op().Is(ent.OpCreate) {
+		if id, ok := m.ID(); ok {
+			return []gidx.PrefixedID{id}, nil
+		}
+
+		return nil, nil
+	}
+
+	return m.IDs(ctx)
+}
+
+// loadDeleted returns the names and parents of the tenants about to be deleted.
+func loadDeleted(ctx context.Context, client *generated.Client, ids []gidx.PrefixedID) (map[gidx.PrefixedID]*generated.Tenant, error) {
+	tenants, err := client.Tenant.Query().
+		Where(enttenant.IDIn(ids...)).
+		Select(enttenant.FieldID, enttenant.FieldName, enttenant.FieldParentTenantID).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[gidx.PrefixedID]*generated.Tenant, len(tenants))
+
+	for _, t := range tenants {
+		deleted[t.ID] = t
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,144 @@
+package changeseq_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+func newClient(t *testing.T) (context.Context, *ent.Client, *eventtools.MockConnection) {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms), client, conn
+}
+
+// publishedSeqs returns the sequences of the published changes of each tenant, as consumers
+// decode them.
+func publishedSeqs(t *testing.T, conn *eventtools.MockConnection) map[string][]int64 {
+	t.Helper()
+
+	seqs := map[string][]int64{}
+
+	for _, call := range conn.Calls {
+		raw, err := json.Marshal(call.Arguments.Get(1))
+		require.NoError(t, err)
+
+		var msg struct {
+			SubjectID      string `json:"subjectID"`
+			AdditionalData struct {
+				ChangeSeq *int64 `json:"change_seq"`
+			} `json:"additionalData"`
+		}
+
+		require.NoError(t, json.Unmarshal(raw, &msg))
+		require.NotNil(t, msg.AdditionalData.ChangeSeq, "every change carries its sequence")
+
+		seqs[msg.SubjectID] = append(seqs[msg.SubjectID], *msg.AdditionalData.ChangeSeq)
+	}
+
+	return seqs
+}
+
+func TestSequencesIncreasePerTenant(t *testing.T) {
+	ctx, client, conn := newClient(t)
+
+	tenants := []*ent.Tenant{
+		client.Tenant.Create().SetName("one").SaveX(ctx),
+		client.Tenant.Create().SetName("two").SaveX(ctx),
+	}
+
+	stored := map[gidx.PrefixedID][]int64{}
+
+	for _, tnt := range tenants {
+		assert.NotZero(t, tnt.ChangeSeq)
+
+		stored[tnt.ID] = append(stored[tnt.ID], tnt.ChangeSeq)
+	}
+
+	// rapid interleaved updates of both tenants
+	for i := 0; i < 25; i++ {
+		for _, tnt := range tenants {
+			updated := client.Tenant.UpdateOneID(tnt.ID).SetDescription(fmt.Sprintf("update %d", i)).SaveX(ctx)
+
+			stored[tnt.ID] = append(stored[tnt.ID], updated.ChangeSeq)
+		}
+	}
+
+	client.Tenant.DeleteOneID(tenants[0].ID).ExecX(ctx)
+
+	published := publishedSeqs(t, conn)
+
+	for _, tnt := range tenants {
+		assert.True(t, strictlyIncreasing(stored[tnt.ID]), "stored sequences of %s: %v", tnt.Name, stored[tnt.ID])
+		assert.True(t, strictlyIncreasing(published[tnt.ID.String()]), "published sequences of %s: %v", tnt.Name, published[tnt.ID.String()])
+
+		// the stored sequence is the one of the last published change
+		assert.Equal(t, stored[tnt.ID], published[tnt.ID.String()][:len(stored[tnt.ID])])
+	}
+
+	// the deletion is the latest change of the tenant
+	deleted := published[tenants[0].ID.String()]
+	require.Len(t, deleted, len(stored[tenants[0].ID])+1)
+
+	count := client.TenantChange.Query().Where(enttenantchange.TenantID(tenants[0].ID)).CountX(ctx)
+	assert.Equal(t, len(deleted), count, "changes are kept after the tenant is deleted")
+}
+
+func TestSequencesRolledBack(t *testing.T) {
+	ctx, client, _ := newClient(t)
+
+	tnt := client.Tenant.Create().SetName("one").SaveX(ctx)
+
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+
+	tx.Tenant.UpdateOneID(tnt.ID).SetDescription("rolled back").ExecX(ctx)
+	require.NoError(t, tx.Rollback())
+
+	assert.Equal(t, tnt.ChangeSeq, client.Tenant.GetX(ctx, tnt.ID).ChangeSeq)
+
+	updated := client.Tenant.UpdateOneID(tnt.ID).SetDescription("committed").SaveX(ctx)
+	assert.Greater(t, updated.ChangeSeq, tnt.ChangeSeq)
+}
+
+func strictlyIncreasing(seqs []int64) bool {
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			return false
+		}
+	}
+
+	return len(seqs) != 0
+}
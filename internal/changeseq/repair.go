@@ -0,0 +1,85 @@
+package changeseq
+
+import (
+	"context"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// Repair sets the change sequence stored on the tenants back to the sequence of their latest
+// recorded change, returning how many were wrong. Tenants without any recorded change, such as
+// those created before sequences were introduced, get one recorded. The tenants are updated one
+// at a time without publishing change events, only their sequence and update time change.
+func Repair(ctx context.Context, client *generated.Client, ids []gidx.PrefixedID) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var latest []struct {
+		TenantID gidx.PrefixedID `json:"tenant_id"`
+		Max      int64           `json:"max"`
+	}
+
+	err := client.TenantChange.Query().
+		Where(enttenantchange.TenantIDIn(ids...)).
+		GroupBy(enttenantchange.FieldTenantID).
+		Aggregate(generated.Max(enttenantchange.FieldID)).
+		Scan(ctx, &latest)
+	if err != nil {
+		return 0, err
+	}
+
+	expected := make(map[gidx.PrefixedID]int64, len(latest))
+
+	for _, l := range latest {
+		expected[l.TenantID] = l.Max
+	}
+
+	tenants, err := client.Tenant.Query().
+		Where(enttenant.IDIn(ids...)).
+		Select(enttenant.FieldID, enttenant.FieldChangeSeq).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	repaired := 0
+
+	for _, t := range tenants {
+		seq, ok := expected[t.ID]
+		if !ok {
+			change, err := client.TenantChange.Create().
+				SetTenantID(t.ID).
+				SetOperation(OpUpdate).
+				SetChangedAt(time.Now().UTC()).
+				Save(ctx)
+			if err != nil {
+				return repaired, err
+			}
+
+			seq = change.ID
+		}
+
+		if t.ChangeSeq == seq {
+			continue
+		}
+
+		// an update of many doesn't publish events, the predicate limits it to the tenant
+		err := client.Tenant.Update().
+			Where(enttenant.ID(t.ID)).
+			SetChangeSeq(seq).
+			Exec(ctx)
+		if err != nil {
+			return repaired, err
+		}
+
+		repaired++
+	}
+
+	return repaired, nil
+}
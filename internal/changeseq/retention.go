@@ -0,0 +1,45 @@
+package changeseq
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// Horizon returns the highest sequence of the changes made before the time, 0 when there is none.
+// The changes up to the horizon may have been purged, so consumers which haven't seen every one of
+// them can't catch up from the recorded changes.
+func Horizon(ctx context.Context, client *generated.Client, before time.Time) (int64, error) {
+	var horizon []struct {
+		Max sql.NullInt64 `json:"max"`
+	}
+
+	err := client.TenantChange.Query().
+		Where(enttenantchange.ChangedAtLT(before)).
+		Aggregate(generated.Max(enttenantchange.FieldID)).
+		Scan(ctx, &horizon)
+	if err != nil || len(horizon) == 0 {
+		return 0, err
+	}
+
+	return horizon[0].Max.Int64, nil
+}
+
+// Purge deletes the changes up to the horizon of the time, returning how many were deleted.
+// Deletions are kept, they hold the names of purged tenants, which may stay blocked.
+func Purge(ctx context.Context, client *generated.Client, before time.Time) (int, error) {
+	horizon, err := Horizon(ctx, client, before)
+	if err != nil || horizon == 0 {
+		return 0, err
+	}
+
+	return client.TenantChange.Delete().
+		Where(
+			enttenantchange.IDLTE(horizon),
+			enttenantchange.OperationNEQ(OpDelete),
+		).
+		Exec(ctx)
+}
@@ -0,0 +1,48 @@
+package changeseq_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+func TestPurge(t *testing.T) {
+	ctx, client, _ := newClient(t)
+
+	horizon, err := changeseq.Horizon(ctx, client, time.Now().UTC())
+	require.NoError(t, err)
+	assert.Zero(t, horizon)
+
+	kept := client.Tenant.Create().SetName("kept").SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("deleted").SaveX(ctx)
+	client.Tenant.DeleteOneID(deleted.ID).ExecX(ctx)
+
+	cutoff := time.Now().UTC()
+
+	time.Sleep(time.Millisecond)
+
+	recent := client.Tenant.UpdateOneID(kept.ID).SetDescription("recent").SaveX(ctx)
+
+	horizon, err = changeseq.Horizon(ctx, client, cutoff)
+	require.NoError(t, err)
+	assert.Less(t, horizon, recent.ChangeSeq)
+
+	purged, err := changeseq.Purge(ctx, client, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	// the deletion is kept for the name of the purged tenant, as is the change after the cutoff
+	left := client.TenantChange.Query().Order(enttenantchange.ByID()).AllX(ctx)
+	require.Len(t, left, 2)
+	assert.Equal(t, changeseq.OpDelete, left[0].Operation)
+	assert.Equal(t, recent.ChangeSeq, left[1].ID)
+
+	purged, err = changeseq.Purge(ctx, client, cutoff)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
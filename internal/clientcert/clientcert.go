@@ -0,0 +1,82 @@
+package clientcert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/echojwtx"
+)
+
+// ErrNoClientCA is returned by TLSConfig for a listener requiring client certificates without
+// the certificate authority verifying them.
+var ErrNoClientCA = errors.New("no client certificates in the client ca file")
+
+// TLSConfig returns the tls config of a listener serving the certificate and key. With a client
+// ca file the listener requires client certificates signed by one of its certificates, otherwise
+// it doesn't ask for them.
+func TLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading the server certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile == "" {
+		return cfg, nil
+	}
+
+	pem, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading the client ca: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", ErrNoClientCA, clientCAFile)
+	}
+
+	cfg.ClientCAs = pool
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return cfg, nil
+}
+
+// Verified returns the client certificate of the request the listener verified, nil when the
+// request wasn't made over tls or its listener didn't verify client certificates.
+func Verified(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// Middleware rejects the requests without a verified client certificate and sets the common name
+// of the certificate as the actor of the others, the same as the subject of a token.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cert := Verified(c.Request())
+			if cert == nil || cert.Subject.CommonName == "" {
+				return echo.ErrUnauthorized
+			}
+
+			actor := cert.Subject.CommonName
+
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), echojwtx.ActorCtxKey, actor)))
+			c.Set(echojwtx.ActorKey, actor)
+
+			return next(c)
+		}
+	}
+}
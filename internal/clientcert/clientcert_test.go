@@ -0,0 +1,186 @@
+package clientcert_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+
+	"go.infratographer.com/tenant-api/internal/clientcert"
+)
+
+// issuer signs the certificates of the tests.
+type issuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newIssuer(t *testing.T, name string) *issuer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &issuer{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a certificate for the common name, signed by the issuer, with its key.
+func (i *issuer) issue(t *testing.T, name string, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, i.cert, &key.PublicKey, i.key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// writeFiles writes the pem files of the certificate and key to the directory.
+func writeFiles(t *testing.T, dir string, cert tls.Certificate) (string, string) {
+	t.Helper()
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestMiddleware(t *testing.T) {
+	dir := t.TempDir()
+
+	clients, servers := newIssuer(t, "clients"), newIssuer(t, "servers")
+
+	certFile, keyFile := writeFiles(t, dir, servers.issue(t, "admin", x509.ExtKeyUsageServerAuth))
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, clients.pem, 0o600))
+
+	cfg, err := clientcert.TLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/actor", func(c echo.Context) error {
+		actor, _ := c.Request().Context().Value(echojwtx.ActorCtxKey).(string)
+
+		return c.String(http.StatusOK, actor+" "+echojwtx.Actor(c))
+	}, clientcert.Middleware())
+
+	srv := httptest.NewUnstartedServer(e)
+	srv.TLS = cfg
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(servers.cert)
+
+	request := func(certs ...tls.Certificate) (string, error) {
+		cli := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			MinVersion:   tls.VersionTLS12,
+		}}}
+
+		resp, err := cli.Get(srv.URL + "/actor")
+		if err != nil {
+			return "", err
+		}
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+
+		return string(body), err
+	}
+
+	// the common name of the verified certificate is the actor of the request
+	body, err := request(clients.issue(t, "operator", x509.ExtKeyUsageClientAuth))
+	require.NoError(t, err)
+	assert.Equal(t, "operator operator", body)
+
+	// the listener refuses callers without a certificate or with one signed by another issuer
+	_, err = request()
+	assert.Error(t, err)
+
+	_, err = request(newIssuer(t, "others").issue(t, "operator", x509.ExtKeyUsageClientAuth))
+	assert.Error(t, err)
+}
+
+func TestMiddlewareWithoutTLS(t *testing.T) {
+	e := echo.New()
+	e.GET("/actor", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, clientcert.Middleware())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/actor", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	certFile, keyFile := writeFiles(t, dir, newIssuer(t, "servers").issue(t, "admin", x509.ExtKeyUsageServerAuth))
+
+	// client certificates aren't asked for without a client ca
+	cfg, err := clientcert.TLSConfig(certFile, keyFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+
+	empty := filepath.Join(dir, "empty.crt")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+
+	_, err = clientcert.TLSConfig(certFile, keyFile, empty)
+	assert.ErrorIs(t, err, clientcert.ErrNoClientCA)
+
+	_, err = clientcert.TLSConfig(filepath.Join(dir, "missing.crt"), keyFile, "")
+	assert.Error(t, err)
+}
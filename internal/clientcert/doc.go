@@ -0,0 +1,20 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clientcert authenticates callers by the client certificate they present to a listener
+// verifying it against a certificate authority, the way the admin listener authorizes its callers
+// under the mtls policy. The listener is configured with TLSConfig, Middleware then takes the
+// common name of the verified certificate as the actor of the request, in place of the subject of
+// a token.
+package clientcert
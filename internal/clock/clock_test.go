@@ -0,0 +1,30 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	fake := clock.NewFake(start)
+	assert.Equal(t, start, fake.Now())
+	assert.Equal(t, start, fake.Now(), "the clock only moves when told to")
+
+	fake.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), fake.Now())
+
+	fake.Set(start)
+	assert.Equal(t, start, fake.Now())
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+
+	assert.WithinDuration(t, before, clock.Real{}.Now(), time.Second)
+}
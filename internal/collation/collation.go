@@ -0,0 +1,77 @@
+package collation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// ErrUnsupported is returned when parsing a collation which isn't a language tag or whose
+// language has no collation.
+var ErrUnsupported = errors.New("unsupported collation")
+
+var matcher = language.NewMatcher(collate.Supported())
+
+// Supported returns the tags of the languages with a collation, each may be refined with
+// collation options.
+func Supported() []string {
+	tags := collate.Supported()
+	names := make([]string, len(tags))
+
+	for i, tag := range tags {
+		names[i] = tag.String()
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Collation orders strings by the rules of a language.
+type Collation struct {
+	tag language.Tag
+}
+
+// Parse parses a collation, a BCP 47 language tag of a supported language.
+func Parse(s string) (Collation, error) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return Collation{}, fmt.Errorf("%w %q: %s", ErrUnsupported, s, err)
+	}
+
+	if _, _, confidence := matcher.Match(tag); confidence < language.High {
+		return Collation{}, fmt.Errorf("%w %q: no collation for its language", ErrUnsupported, s)
+	}
+
+	return Collation{tag: tag}, nil
+}
+
+// String returns the canonical form of the collation, equal for the tags of the same collation.
+func (c Collation) String() string {
+	return c.tag.String()
+}
+
+// Keyer returns the sort keys of strings by a collation. It isn't safe for concurrent use.
+type Keyer struct {
+	collator *collate.Collator
+	buf      collate.Buffer
+}
+
+// NewKeyer returns a keyer of the collation.
+func (c Collation) NewKeyer() *Keyer {
+	return &Keyer{collator: collate.New(c.tag)}
+}
+
+// Key returns the sort key of the string, comparing keys with bytes.Compare orders their strings
+// by the collation. Strings the collation considers equal, such as differing by case when it
+// ignores case, have equal keys.
+func (k *Keyer) Key(s string) []byte {
+	key := bytes.Clone(k.collator.KeyFromString(&k.buf, s))
+	k.buf.Reset()
+
+	return key
+}
@@ -0,0 +1,86 @@
+package collation_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/collation"
+)
+
+// names mixes accented latin names, which byte order puts after every ascii name, and chinese and
+// japanese city names.
+var names = []string{"zoo", "Zebra", "apple", "Ångström", "Émile", "eagle", "Éclair", "北京", "上海", "東京", "大阪"}
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		raw  string
+		name string
+	}{
+		{"en", "en"},
+		{"EN-us", "en-US"},
+		{"und-u-ks-level2", "und-u-ks-level2"},
+		{"zh", "zh"},
+		{"de-u-co-phonebk", "de-u-co-phonebk"},
+	} {
+		c, err := collation.Parse(tt.raw)
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.name, c.String())
+	}
+
+	for _, raw := range []string{"", "xx", "not a tag", "en_US!"} {
+		_, err := collation.Parse(raw)
+		assert.ErrorIs(t, err, collation.ErrUnsupported, raw)
+	}
+
+	assert.Contains(t, collation.Supported(), "en")
+	assert.Contains(t, collation.Supported(), "und")
+	assert.True(t, sort.StringsAreSorted(collation.Supported()))
+}
+
+func TestKeyerOrder(t *testing.T) {
+	for _, tt := range []struct {
+		collation string
+		expected  []string
+	}{
+		{"en", []string{"Ångström", "apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "上海", "北京", "大阪", "東京"}},
+		// å is a letter of its own, sorted after z
+		{"sv", []string{"apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "Ångström", "上海", "北京", "大阪", "東京"}},
+		// pinyin: bei jing, da ban, dong jing, shang hai
+		{"zh", []string{"Ångström", "apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "北京", "大阪", "東京", "上海"}},
+	} {
+		t.Run(tt.collation, func(t *testing.T) {
+			c, err := collation.Parse(tt.collation)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, sorted(c, names))
+		})
+	}
+
+	t.Run("case insensitive", func(t *testing.T) {
+		c, err := collation.Parse("und-u-ks-level2")
+		require.NoError(t, err)
+
+		keyer := c.NewKeyer()
+
+		assert.Equal(t, keyer.Key("Acme"), keyer.Key("acme"))
+		assert.NotEqual(t, keyer.Key("acme"), keyer.Key("ácme"), "accents still differ at level 2")
+	})
+}
+
+func sorted(c collation.Collation, names []string) []string {
+	keyer := c.NewKeyer()
+	keys := make(map[string][]byte, len(names))
+
+	for _, name := range names {
+		keys[name] = keyer.Key(name)
+	}
+
+	out := append([]string(nil), names...)
+	sort.Slice(out, func(i, j int) bool { return bytes.Compare(keys[out[i]], keys[out[j]]) < 0 })
+
+	return out
+}
@@ -0,0 +1,80 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errCallPanicked is handed to the callers sharing a call which panicked.
+var errCallPanicked = errors.New("coalesced call panicked")
+
+// Coalescer runs a single call for the concurrent calls with the same key and hands its result to
+// all of them. A nil coalescer runs every call on its own.
+type Coalescer[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[V]
+}
+
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// NewCoalescer returns a coalescer.
+func NewCoalescer[V any]() *Coalescer[V] {
+	return &Coalescer[V]{calls: make(map[string]*call[V])}
+}
+
+// Do runs fn with the context unless a call with the key is in flight, in which case it waits for
+// that call and returns its result, shared reporting so. The shared value must not be modified.
+// When the shared call failed as its own context ended while the context of the caller is still
+// live, fn is run again for the caller.
+func (c *Coalescer[V]) Do(ctx context.Context, key string, fn func(context.Context) (V, error)) (v V, shared bool, err error) {
+	if c == nil {
+		v, err = fn(ctx)
+
+		return v, false, err
+	}
+
+	c.mu.Lock()
+
+	if cl, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+
+		select {
+		case <-cl.done:
+		case <-ctx.Done():
+			return v, false, ctx.Err()
+		}
+
+		if isContextError(cl.err) && ctx.Err() == nil {
+			v, err = fn(ctx)
+
+			return v, false, err
+		}
+
+		return cl.val, true, cl.err
+	}
+
+	cl := &call[V]{done: make(chan struct{}), err: errCallPanicked}
+	c.calls[key] = cl
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+
+		close(cl.done)
+	}()
+
+	cl.val, cl.err = fn(ctx)
+
+	return cl.val, false, cl.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
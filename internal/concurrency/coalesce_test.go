@@ -0,0 +1,157 @@
+package concurrency_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/concurrency"
+)
+
+func TestCoalescerDo(t *testing.T) {
+	const callers = 20
+
+	c := concurrency.NewCoalescer[int]()
+
+	var (
+		calls   atomic.Int64
+		started = make(chan struct{})
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+		shared  atomic.Int64
+	)
+
+	fn := func(context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+
+		<-release
+
+		return 42, nil
+	}
+
+	wg.Add(callers)
+
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+
+			v, s, err := c.Do(context.Background(), "key", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, v)
+
+			if s {
+				shared.Add(1)
+			}
+		}()
+	}
+
+	<-started
+	// let the other callers join the call in flight
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Equal(t, int64(callers-1), shared.Load())
+
+	// the call is forgotten once done
+	v, s, err := c.Do(context.Background(), "key", func(context.Context) (int, error) { return 7, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+	assert.False(t, s)
+}
+
+func TestCoalescerCanceledLeader(t *testing.T) {
+	c := concurrency.NewCoalescer[string]()
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		_, _, err := c.Do(leaderCtx, "key", func(ctx context.Context) (string, error) {
+			close(started)
+			<-ctx.Done()
+
+			return "", ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+
+	<-started
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// the follower runs the call again rather than failing with the leader
+		v, shared, err := c.Do(context.Background(), "key", func(context.Context) (string, error) { return "own", nil })
+		assert.NoError(t, err)
+		assert.Equal(t, "own", v)
+		assert.False(t, shared)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	<-done
+	wg.Wait()
+}
+
+func TestCoalescerPanic(t *testing.T) {
+	c := concurrency.NewCoalescer[int]()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { _ = recover() }()
+
+		_, _, _ = c.Do(context.Background(), "key", func(context.Context) (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+
+	<-started
+
+	errs := make(chan error)
+
+	go func() {
+		_, _, err := c.Do(context.Background(), "key", func(context.Context) (int, error) { return 1, nil })
+		errs <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the follower of a panicking call never returned")
+	}
+}
+
+func TestCoalescerNil(t *testing.T) {
+	var c *concurrency.Coalescer[int]
+
+	_, shared, err := c.Do(context.Background(), "key", func(context.Context) (int, error) { return 0, errors.New("failed") })
+	assert.EqualError(t, err, "failed")
+	assert.False(t, shared)
+}
@@ -0,0 +1,167 @@
+package concurrency
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultQueueTimeout is the default time a request waits for a free slot before it is rejected.
+const DefaultQueueTimeout = time.Second
+
+const (
+	metricsNamespace = "tenant_api"
+	metricsSubsystem = "concurrency"
+)
+
+// Metrics exposes how long requests wait for a slot and how many are rejected, by limiter.
+type Metrics struct {
+	queued     *prometheus.HistogramVec
+	rejections *prometheus.CounterVec
+}
+
+// NewMetrics returns the limiter metrics, registered with the registerer.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		queued: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "queue_seconds",
+			Help:      "Time requests waited for a slot, rejected requests included.",
+			Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		}, []string{"limiter"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "rejections_total",
+			Help:      "Number of requests rejected as no slot freed up in time.",
+		}, []string{"limiter"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.queued, m.rejections} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Option configures a Limiter.
+type Option func(*Limiter)
+
+// WithQueueTimeout sets the time a request waits for a free slot before it is rejected.
+func WithQueueTimeout(d time.Duration) Option {
+	return func(l *Limiter) {
+		if d >= 0 {
+			l.queueTimeout = d
+		}
+	}
+}
+
+// WithMetrics sets the metrics the limiter reports to.
+func WithMetrics(m *Metrics) Option {
+	return func(l *Limiter) {
+		l.metrics = m
+	}
+}
+
+// Limiter serves at most a fixed number of requests at once, the others wait briefly for a slot
+// and are rejected with 503 Service Unavailable when none frees up. A limiter may be shared by
+// several routes, they then share its slots.
+type Limiter struct {
+	name         string
+	slots        chan struct{}
+	queueTimeout time.Duration
+	metrics      *Metrics
+}
+
+// New returns a limiter serving up to limit requests at once, nil when limit isn't positive. The
+// name labels its metrics.
+func New(name string, limit int, opts ...Option) *Limiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	l := &Limiter{
+		name:         name,
+		slots:        make(chan struct{}, limit),
+		queueTimeout: DefaultQueueTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Middleware returns the middleware limiting the requests, a nil limiter doesn't limit them.
+// Rejected requests are told to retry after the queue timeout, rounded up to a second.
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if l == nil {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			if err := l.acquire(c); err != nil {
+				return err
+			}
+
+			defer func() { <-l.slots }()
+
+			return next(c)
+		}
+	}
+}
+
+// acquire waits for a slot, returning the error to respond with when none frees up in time.
+func (l *Limiter) acquire(c echo.Context) error {
+	start := time.Now()
+
+	select {
+	case l.slots <- struct{}{}:
+		l.observe(start)
+
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		l.observe(start)
+
+		return nil
+	case <-timer.C:
+	case <-c.Request().Context().Done():
+	}
+
+	l.observe(start)
+
+	if l.metrics != nil {
+		l.metrics.rejections.WithLabelValues(l.name).Inc()
+	}
+
+	retryAfter := int(math.Ceil(l.queueTimeout.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	return echo.NewHTTPError(http.StatusServiceUnavailable, "too many concurrent requests, retry later")
+}
+
+func (l *Limiter) observe(start time.Time) {
+	if l.metrics != nil {
+		l.metrics.queued.WithLabelValues(l.name).Observe(time.Since(start).Seconds())
+	}
+}
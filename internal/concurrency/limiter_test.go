@@ -0,0 +1,182 @@
+package concurrency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/concurrency"
+)
+
+// slowServer serves /slow, blocking until release is closed, and /cheap without a limiter.
+func slowServer(t *testing.T, limiter *concurrency.Limiter) (string, chan struct{}, chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+
+	e := echo.New()
+
+	e.GET("/slow", func(c echo.Context) error {
+		started <- struct{}{}
+		<-release
+
+		return c.NoContent(http.StatusOK)
+	}, limiter.Middleware())
+
+	e.GET("/cheap", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return srv.URL, started, release
+}
+
+func get(t *testing.T, url string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	resp.Body.Close()
+
+	return resp
+}
+
+func TestLimiterRejectsWhenSaturated(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	metrics, err := concurrency.NewMetrics(reg)
+	require.NoError(t, err)
+
+	limiter := concurrency.New("export", 2,
+		concurrency.WithQueueTimeout(50*time.Millisecond),
+		concurrency.WithMetrics(metrics),
+	)
+
+	url, started, release := slowServer(t, limiter)
+
+	var wg sync.WaitGroup
+
+	statuses := make(chan int, 2)
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			statuses <- get(t, url+"/slow").StatusCode
+		}()
+	}
+
+	<-started
+	<-started
+
+	// both slots are taken, the next request waits for the queue timeout and is rejected
+	resp := get(t, url+"/slow")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+	// routes without the limiter are unaffected
+	assert.Equal(t, http.StatusOK, get(t, url+"/cheap").StatusCode)
+
+	close(release)
+	wg.Wait()
+	close(statuses)
+
+	for status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	// the slots are freed once the slow requests are done
+	assert.Equal(t, http.StatusOK, get(t, url+"/slow").StatusCode)
+
+	rejections, queued := collect(t, reg, "export")
+	assert.Equal(t, float64(1), rejections)
+	assert.Equal(t, uint64(4), queued, "every request is timed, the rejected one included")
+}
+
+// collect returns the rejections and the number of requests timed by the limiter.
+func collect(t *testing.T, reg *prometheus.Registry, limiter string) (float64, uint64) {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	var (
+		rejections float64
+		queued     uint64
+	)
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != limiter {
+				continue
+			}
+
+			switch family.GetName() {
+			case "tenant_api_concurrency_rejections_total":
+				rejections = metric.GetCounter().GetValue()
+			case "tenant_api_concurrency_queue_seconds":
+				queued = metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return rejections, queued
+}
+
+func TestLimiterQueues(t *testing.T) {
+	limiter := concurrency.New("crawl", 1, concurrency.WithQueueTimeout(5*time.Second))
+
+	url, started, release := slowServer(t, limiter)
+
+	done := make(chan int)
+
+	go func() {
+		done <- get(t, url+"/slow").StatusCode
+	}()
+
+	<-started
+
+	queued := make(chan int)
+
+	go func() {
+		queued <- get(t, url+"/slow").StatusCode
+	}()
+
+	// the queued request is served once the slot frees up
+	release <- struct{}{}
+	assert.Equal(t, http.StatusOK, <-done)
+
+	<-started
+	close(release)
+	assert.Equal(t, http.StatusOK, <-queued)
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	limiter := concurrency.New("stats", 0)
+	assert.Nil(t, limiter)
+
+	url, started, release := slowServer(t, limiter)
+	close(release)
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, get(t, url+"/slow").StatusCode)
+		<-started
+	}
+}
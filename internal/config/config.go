@@ -1,20 +1,153 @@
 // Package config defines the application config used through tenant-api.
+//
+// Each setting is taken from the first of these which sets it:
+//
+//  1. the command line flag, such as --rest-crawl-rate
+//  2. the environment variable, the key prefixed with TENANTAPI_ with dots and dashes replaced by
+//     underscores, such as TENANTAPI_REST_CRAWL_RATE
+//  3. the config file given with --config, or /etc/infratographer/tenant-api.yaml, where settings
+//     are nested under their section, such as crawl_rate under rest
+//  4. the default of the flag
+//
+// Most settings are read once at startup. Those which are safe to change while the service runs,
+// debug logging, maintenance mode, the crawl rate limit and the page size cap of tenant lists, are
+// read again on SIGHUP, or whenever the config file changes with --watch-config. A reload with an
+// invalid value is rejected as a whole and the settings in use are kept.
 package config
 
 import (
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 	"go.infratographer.com/x/crdbx"
 	"go.infratographer.com/x/echojwtx"
 	"go.infratographer.com/x/echox"
 	"go.infratographer.com/x/events"
 	"go.infratographer.com/x/loggingx"
 	"go.infratographer.com/x/otelx"
+	"go.infratographer.com/x/viperx"
 
 	"go.infratographer.com/permissions-api/pkg/permissions"
 )
 
-// AppConfig contains the application configuration structure.
-var AppConfig struct {
+const (
+	// DatabaseDriverCRDB selects CockroachDB as the database backend.
+	DatabaseDriverCRDB = "crdb"
+	// DatabaseDriverSQLite selects SQLite as the database backend. This is meant for development only.
+	DatabaseDriverSQLite = "sqlite"
+
+	defaultSQLiteURI = "file:tenant-api?mode=memory&cache=shared&_fk=1"
+
+	// SearchBackendSQL searches tenants with a query of the database, the default.
+	SearchBackendSQL = "sql"
+	// SearchBackendBleve searches tenants with an in-process Bleve index.
+	SearchBackendBleve = "bleve"
+
+	defaultGRPCListen = ":7903"
+
+	defaultAdminPolicy = "scope"
+
+	defaultRESTMaxBatchSize  = 100
+	defaultRESTMaxPageSize   = 100
+	defaultRESTMaxChildren   = 50
+	defaultRESTMaxListOffset = 1000
+	defaultRESTStatsCacheTTL = 30 * time.Second
+	defaultRESTStatsRefresh  = 10 * time.Second
+	defaultRESTWatchTimeout  = 30 * time.Second
+	defaultRESTCrawlRate     = 5
+	defaultRESTCrawlBurst    = 10
+	defaultRESTCrawlMaxAge   = 4 * time.Hour
+
+	defaultRESTExportConcurrency = 4
+	defaultRESTCrawlConcurrency  = 4
+	defaultRESTStatsConcurrency  = 16
+	defaultRESTSearchConcurrency = 8
+	defaultRESTQueueTimeout      = time.Second
+
+	defaultRESTUnscopedMaxPageSize = 20
+
+	defaultStartupBackoff    = 250 * time.Millisecond
+	defaultStartupMaxBackoff = 10 * time.Second
+
+	defaultNameMaxLength = 255
+	defaultMaxChildren   = 10000
+
+	defaultNameReusePolicy = "block_during_retention"
+
+	defaultSettingsMaxSize  = 16 << 10
+	defaultSettingsMaxDepth = 8
+
+	defaultMaxLabels = 64
+
+	defaultBillingReferenceMaxLength = 64
+	defaultDescriptionMaxLength      = 1024
+
+	defaultDeletionGracePeriod        = 7 * 24 * time.Hour
+	defaultDeletionCheckInterval      = time.Minute
+	defaultDeletionSampleInterval     = time.Minute
+	defaultDeletionTombstoneRetention = 90 * 24 * time.Hour
+
+	defaultChangesSystemActor       = "tenant-api"
+	defaultChangesAncestors         = "parent"
+	defaultChangesRootCacheTTL      = time.Minute
+	defaultChangesDispatchBatchSize = 100
+
+	defaultSearchSyncInterval = 5 * time.Second
+
+	defaultRuntimeMemoryLimitRatio = 0.9
+
+	defaultDependentsTimeout = 5 * time.Second
+
+	defaultHTTPClientTimeout            = 10 * time.Second
+	defaultHTTPClientMaxAttempts        = 3
+	defaultHTTPClientRetryBackoff       = 100 * time.Millisecond
+	defaultHTTPClientMaxConnsPerHost    = 64
+	defaultHTTPClientBreakerFailures    = 5
+	defaultHTTPClientBreakerOpenTimeout = 30 * time.Second
+
+	defaultFailedRequestsSize      = 256
+	defaultFailedRequestsRetention = time.Hour
+
+	defaultUsageBucketSize    = time.Hour
+	defaultUsageFlushInterval = time.Minute
+	defaultUsageMaxPending    = 10000
+
+	defaultConsumerMaxDeliveries = 5
+	defaultConsumerRetryDelay    = 10 * time.Second
+)
+
+// AppConfig contains the application configuration.
+var AppConfig Config
+
+// Config is the application configuration structure.
+type Config struct {
 	CRDB        crdbx.Config
+	Database    DatabaseConfig
+	GRPC        GRPCConfig
+	REST        RESTConfig
+	Admin       AdminConfig
+	Redaction   RedactionConfig
+	Validation  ValidationConfig
+	Deletion    DeletionConfig
+	Dependents  DependentsConfig
+	Usage       UsageConfig
+	HTTPClient  HTTPClientConfig
+	Failures    FailuresConfig
+	Audit       AuditConfig
+	Startup     StartupConfig
+	Queries     QueriesConfig
+	Timing      TimingConfig
+	Faults      FaultsConfig
+	Maintenance MaintenanceConfig
+	Reload      ReloadConfig
+	Bootstrap   BootstrapConfig
+	Consumer    ConsumerConfig
+	Encryption  EncryptionConfig
+	Changes     ChangesConfig
+	Traversal   TraversalConfig
+	Search      SearchConfig
+	Runtime     RuntimeConfig
 	Logging     loggingx.Config
 	Events      events.Config
 	Server      echox.Config
@@ -22,3 +155,798 @@ var AppConfig struct {
 	Tracing     otelx.Config
 	Permissions permissions.Config
 }
+
+// DatabaseConfig selects the database backend.
+type DatabaseConfig struct {
+	// Driver is the database backend, either crdb or sqlite.
+	Driver string `mapstructure:"driver"`
+	SQLite struct {
+		// URI is the sqlite data source, a file path or an in-memory database.
+		URI string `mapstructure:"uri"`
+	} `mapstructure:"sqlite"`
+}
+
+// IsSQLite reports whether the sqlite backend is selected.
+func (c DatabaseConfig) IsSQLite() bool {
+	return c.Driver == DatabaseDriverSQLite
+}
+
+// MustDatabaseViperFlags sets the flags selecting the database backend.
+func MustDatabaseViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.String("db-driver", DatabaseDriverCRDB, "database backend (crdb, sqlite), sqlite is for development only")
+	viperx.MustBindFlag(v, "database.driver", flags.Lookup("db-driver"))
+
+	flags.String("sqlite-uri", defaultSQLiteURI, "sqlite data source when using the sqlite database backend")
+	viperx.MustBindFlag(v, "database.sqlite.uri", flags.Lookup("sqlite-uri"))
+}
+
+// GRPCConfig configures the grpc tenant service.
+type GRPCConfig struct {
+	// Listen is the address the grpc server listens on, the grpc server is disabled when empty.
+	Listen string `mapstructure:"listen"`
+}
+
+// MustGRPCViperFlags sets the flags configuring the grpc tenant service.
+func MustGRPCViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.String("grpc-listen", defaultGRPCListen, "address the grpc server listens on, set to empty to disable")
+	viperx.MustBindFlag(v, "grpc.listen", flags.Lookup("grpc-listen"))
+}
+
+// AdminConfig configures the listener of the admin endpoints.
+type AdminConfig struct {
+	// Listen is the address the admin endpoints are served on. When set the public listener
+	// refuses them, network policies can then keep them from anyone but operators. They are served
+	// with the other endpoints when empty.
+	Listen string `mapstructure:"listen"`
+	// Policy is how the callers of the admin listener are authorized, scope requires a token
+	// with the admin scope of the REST endpoints and mtls a client certificate signed by the
+	// client ca, whose common name is the actor of their changes.
+	Policy string `mapstructure:"policy"`
+	// TLSCert and TLSKey are the certificate and key the admin listener serves tls with, it serves
+	// plain http without them. They are required by the mtls policy.
+	TLSCert string `mapstructure:"tls_cert"`
+	TLSKey  string `mapstructure:"tls_key"`
+	// ClientCA is the file of the certificates client certificates are verified with, the admin
+	// listener requires one signed by them when set. It is required by the mtls policy.
+	ClientCA string `mapstructure:"client_ca"`
+}
+
+// MustAdminViperFlags sets the flags configuring the listener of the admin endpoints.
+func MustAdminViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.String("admin-listen", "", "address the admin endpoints are served on rather than with the other endpoints, empty serves them together")
+	viperx.MustBindFlag(v, "admin.listen", flags.Lookup("admin-listen"))
+
+	flags.String("admin-policy", defaultAdminPolicy, "how callers of the admin listener are authorized (scope, mtls)")
+	viperx.MustBindFlag(v, "admin.policy", flags.Lookup("admin-policy"))
+
+	flags.String("admin-tls-cert", "", "certificate the admin listener serves tls with")
+	viperx.MustBindFlag(v, "admin.tls_cert", flags.Lookup("admin-tls-cert"))
+
+	flags.String("admin-tls-key", "", "key of the certificate the admin listener serves tls with")
+	viperx.MustBindFlag(v, "admin.tls_key", flags.Lookup("admin-tls-key"))
+
+	flags.String("admin-client-ca", "", "certificates verifying the client certificates the admin listener requires when set")
+	viperx.MustBindFlag(v, "admin.client_ca", flags.Lookup("admin-client-ca"))
+}
+
+// RESTConfig configures the REST endpoints.
+type RESTConfig struct {
+	// CacheMaxAge is the max-age clients may privately cache tenant responses for.
+	CacheMaxAge time.Duration `mapstructure:"cache_max_age"`
+	// MaxBatchSize is the maximum number of tenants a batch update may list.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+	// MaxPageSize is the largest page of tenants a list may request. It can be reloaded.
+	MaxPageSize int `mapstructure:"max_page_size"`
+	// MaxIncludedChildren is the largest number of children included with a tenant requested with
+	// ?include=children, the others are listed with the list endpoint.
+	MaxIncludedChildren int `mapstructure:"max_included_children"`
+	// MaxListOffset is the largest number of tenants a list may skip with the offset query
+	// parameter, lists going further follow the page tokens.
+	MaxListOffset int `mapstructure:"max_list_offset"`
+	// AdminScope is the token scope required by the admin endpoints, they are disabled when empty.
+	AdminScope string `mapstructure:"admin_scope"`
+	// StatsCacheTTL is the time subtree statistics may be served from the cache for.
+	StatsCacheTTL time.Duration `mapstructure:"stats_cache_ttl"`
+	// StatsStaleAfter is the age past which cached subtree statistics are refreshed in the
+	// background while still being served, they are only computed again past the ttl when zero.
+	StatsStaleAfter time.Duration `mapstructure:"stats_stale_after"`
+	// StatsRefreshInterval is the least time between two refreshes of the statistics of a tenant
+	// forced with refresh=true.
+	StatsRefreshInterval time.Duration `mapstructure:"stats_refresh_interval"`
+	// WatchTimeout is how long polling for tenant changes, or watching the tenant list, with
+	// watch=true waits for a change.
+	WatchTimeout time.Duration `mapstructure:"watch_timeout"`
+	// CrawlScope is the token scope required to crawl every tenant, crawling is disabled when empty.
+	CrawlScope string `mapstructure:"crawl_scope"`
+	// CrawlRate is the number of batches each caller may crawl per second. It can be reloaded.
+	CrawlRate float64 `mapstructure:"crawl_rate"`
+	// CrawlBurst is the number of batches each caller may crawl at once. It can be reloaded.
+	CrawlBurst int `mapstructure:"crawl_burst"`
+	// CrawlMaxAge is the age of its snapshot after which a crawl can't be resumed, it must stay
+	// below the garbage collection window of the database.
+	CrawlMaxAge time.Duration `mapstructure:"crawl_max_age"`
+	// CrawlTokenKey is the key crawl resume tokens are signed with, replicas need the same one to
+	// resume each other's crawls. A random key is used when empty.
+	CrawlTokenKey string `mapstructure:"crawl_token_key"`
+	// ExportConcurrency is the number of exports served at once, zero doesn't limit them.
+	ExportConcurrency int `mapstructure:"export_concurrency"`
+	// CrawlConcurrency is the number of crawl batches served at once, zero doesn't limit them.
+	CrawlConcurrency int `mapstructure:"crawl_concurrency"`
+	// StatsConcurrency is the number of subtree statistics and aggregations served at once, zero
+	// doesn't limit them.
+	StatsConcurrency int `mapstructure:"stats_concurrency"`
+	// SearchConcurrency is the number of searches served at once, zero doesn't limit them.
+	SearchConcurrency int `mapstructure:"search_concurrency"`
+	// QueueTimeout is how long requests to a saturated endpoint wait for a slot before they are
+	// rejected.
+	QueueTimeout time.Duration `mapstructure:"queue_timeout"`
+	// QueryGuard rejects the tenant lists whose filters can't use an index, small deployments may
+	// disable it to serve every combination.
+	QueryGuard bool `mapstructure:"query_guard"`
+	// UnscopedMaxPageSize is the largest page of tenants a list without a parent may request when
+	// the query guard is enabled.
+	UnscopedMaxPageSize int `mapstructure:"unscoped_max_page_size"`
+	// CoalesceReads lets concurrent identical reads of a tenant or its statistics share one query.
+	CoalesceReads bool `mapstructure:"coalesce_reads"`
+	// LenientDecoding ignores the fields the bodies of creates and updates don't have rather than
+	// rejecting them, unless a request sets the X-Strict-Validation header.
+	LenientDecoding bool `mapstructure:"lenient_decoding"`
+	// TimestampFormat is the format of the timestamps of responses and events, rfc3339 with
+	// milliseconds or rfc3339nano for clients relying on the former output.
+	TimestampFormat string `mapstructure:"timestamp_format"`
+	// DuplicateWindow is the time after a create during which a create with the same parent, name
+	// and actor is taken for a retry, responding with the tenant created then. Zero disables it,
+	// as deployments whose siblings may share a name must, the second one would never be created.
+	DuplicateWindow time.Duration `mapstructure:"duplicate_window"`
+}
+
+// MustRESTViperFlags sets the flags configuring the REST endpoints.
+func MustRESTViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Duration("rest-cache-max-age", 0, "max-age clients may privately cache tenant responses for")
+	viperx.MustBindFlag(v, "rest.cache_max_age", flags.Lookup("rest-cache-max-age"))
+
+	flags.Int("rest-max-batch-size", defaultRESTMaxBatchSize, "maximum number of tenants a batch update may list")
+	viperx.MustBindFlag(v, "rest.max_batch_size", flags.Lookup("rest-max-batch-size"))
+
+	flags.Int("rest-max-page-size", defaultRESTMaxPageSize, "largest page of tenants a list may request")
+	viperx.MustBindFlag(v, "rest.max_page_size", flags.Lookup("rest-max-page-size"))
+
+	flags.Int("rest-max-included-children", defaultRESTMaxChildren, "largest number of children included with a tenant requested with include=children")
+	viperx.MustBindFlag(v, "rest.max_included_children", flags.Lookup("rest-max-included-children"))
+
+	flags.Int("rest-max-list-offset", defaultRESTMaxListOffset, "largest number of tenants a list may skip with offset, lists going further follow the page tokens")
+	viperx.MustBindFlag(v, "rest.max_list_offset", flags.Lookup("rest-max-list-offset"))
+
+	flags.String("rest-admin-scope", "", "token scope required by the admin endpoints, they are disabled when empty")
+	viperx.MustBindFlag(v, "rest.admin_scope", flags.Lookup("rest-admin-scope"))
+
+	flags.Duration("rest-stats-cache-ttl", defaultRESTStatsCacheTTL, "time subtree statistics may be served from the cache for")
+	viperx.MustBindFlag(v, "rest.stats_cache_ttl", flags.Lookup("rest-stats-cache-ttl"))
+
+	flags.Duration("rest-stats-stale-after", 0, "age past which cached subtree statistics are refreshed in the background while still served, never when zero")
+	viperx.MustBindFlag(v, "rest.stats_stale_after", flags.Lookup("rest-stats-stale-after"))
+
+	flags.Duration("rest-stats-refresh-interval", defaultRESTStatsRefresh, "least time between two refreshes of the statistics of a tenant forced with refresh=true")
+	viperx.MustBindFlag(v, "rest.stats_refresh_interval", flags.Lookup("rest-stats-refresh-interval"))
+
+	flags.Duration("rest-watch-timeout", defaultRESTWatchTimeout, "how long polling for tenant changes, or watching the tenant list, with watch=true waits for a change")
+	viperx.MustBindFlag(v, "rest.watch_timeout", flags.Lookup("rest-watch-timeout"))
+
+	flags.String("rest-crawl-scope", "", "token scope required to crawl every tenant, crawling is disabled when empty")
+	viperx.MustBindFlag(v, "rest.crawl_scope", flags.Lookup("rest-crawl-scope"))
+
+	flags.Float64("rest-crawl-rate", defaultRESTCrawlRate, "number of batches each caller may crawl per second")
+	viperx.MustBindFlag(v, "rest.crawl_rate", flags.Lookup("rest-crawl-rate"))
+
+	flags.Int("rest-crawl-burst", defaultRESTCrawlBurst, "number of batches each caller may crawl at once")
+	viperx.MustBindFlag(v, "rest.crawl_burst", flags.Lookup("rest-crawl-burst"))
+
+	flags.Duration("rest-crawl-max-age", defaultRESTCrawlMaxAge, "age of its snapshot after which a crawl can't be resumed")
+	viperx.MustBindFlag(v, "rest.crawl_max_age", flags.Lookup("rest-crawl-max-age"))
+
+	flags.String("rest-crawl-token-key", "", "key crawl resume tokens are signed with, a random key is used when empty")
+	viperx.MustBindFlag(v, "rest.crawl_token_key", flags.Lookup("rest-crawl-token-key"))
+
+	flags.Int("rest-export-concurrency", defaultRESTExportConcurrency, "number of exports served at once, zero doesn't limit them")
+	viperx.MustBindFlag(v, "rest.export_concurrency", flags.Lookup("rest-export-concurrency"))
+
+	flags.Int("rest-crawl-concurrency", defaultRESTCrawlConcurrency, "number of crawl batches served at once, zero doesn't limit them")
+	viperx.MustBindFlag(v, "rest.crawl_concurrency", flags.Lookup("rest-crawl-concurrency"))
+
+	flags.Int("rest-stats-concurrency", defaultRESTStatsConcurrency, "number of subtree statistics and aggregations served at once, zero doesn't limit them")
+	viperx.MustBindFlag(v, "rest.stats_concurrency", flags.Lookup("rest-stats-concurrency"))
+
+	flags.Int("rest-search-concurrency", defaultRESTSearchConcurrency, "number of searches served at once, zero doesn't limit them")
+	viperx.MustBindFlag(v, "rest.search_concurrency", flags.Lookup("rest-search-concurrency"))
+
+	flags.Duration("rest-queue-timeout", defaultRESTQueueTimeout, "how long requests to a saturated endpoint wait for a slot before they are rejected")
+	viperx.MustBindFlag(v, "rest.queue_timeout", flags.Lookup("rest-queue-timeout"))
+
+	flags.Bool("rest-query-guard", true, "reject tenant lists whose filters can't use an index")
+	viperx.MustBindFlag(v, "rest.query_guard", flags.Lookup("rest-query-guard"))
+
+	flags.Int("rest-unscoped-max-page-size", defaultRESTUnscopedMaxPageSize, "largest page of tenants a list without a parent may request when the query guard is enabled")
+	viperx.MustBindFlag(v, "rest.unscoped_max_page_size", flags.Lookup("rest-unscoped-max-page-size"))
+
+	flags.Bool("rest-coalesce-reads", false, "let concurrent identical reads of a tenant or its statistics share one query")
+	viperx.MustBindFlag(v, "rest.coalesce_reads", flags.Lookup("rest-coalesce-reads"))
+
+	flags.Bool("rest-lenient-decoding", false, "ignore unknown fields in the bodies of creates and updates unless a request sets X-Strict-Validation")
+	viperx.MustBindFlag(v, "rest.lenient_decoding", flags.Lookup("rest-lenient-decoding"))
+
+	flags.String("rest-timestamp-format", "rfc3339", "format of the timestamps of responses and events, rfc3339 (milliseconds) or rfc3339nano")
+	viperx.MustBindFlag(v, "rest.timestamp_format", flags.Lookup("rest-timestamp-format"))
+
+	flags.Duration("rest-duplicate-window", 0, "time after a create during which a create with the same parent, name and actor returns the created tenant, 0 disables it")
+	viperx.MustBindFlag(v, "rest.duplicate_window", flags.Lookup("rest-duplicate-window"))
+}
+
+// RedactionConfig maps token scopes to the tenant fields visible with them. It is only read from the
+// config file, when empty no fields are redacted.
+type RedactionConfig struct {
+	// Scopes lists the visible fields for each scope, * grants every field.
+	Scopes map[string][]string `mapstructure:"scopes"`
+}
+
+// ValidationConfig configures the validation of tenant fields.
+type ValidationConfig struct {
+	// NameMaxLength is the maximum length of a tenant name in characters.
+	NameMaxLength int `mapstructure:"name_max_length"`
+	// ReservedNames can't be used as tenant names, they are case insensitive and may be globs.
+	ReservedNames []string `mapstructure:"reserved_names"`
+	// MaxChildren is the maximum number of direct children of a tenant, zero is unlimited. Admins
+	// may override it for single tenants.
+	MaxChildren int `mapstructure:"max_children"`
+	// MaxDepth is the deepest level of the hierarchy tenants may be at, roots being at level 1,
+	// zero is unlimited.
+	MaxDepth int `mapstructure:"max_depth"`
+	// SettingsMaxSize is the maximum size of a tenant settings document in bytes of json.
+	SettingsMaxSize int `mapstructure:"settings_max_size"`
+	// SettingsMaxDepth is the maximum nesting depth of a tenant settings document.
+	SettingsMaxDepth int `mapstructure:"settings_max_depth"`
+	// MaxLabels is the maximum number of labels a tenant may set itself.
+	MaxLabels int `mapstructure:"max_labels"`
+	// BillingReferenceMaxLength is the maximum length of a tenant billing reference in characters.
+	BillingReferenceMaxLength int `mapstructure:"billing_reference_max_length"`
+	// DescriptionMaxLength is the maximum length of a tenant description in characters.
+	DescriptionMaxLength int `mapstructure:"description_max_length"`
+	// RenameScope is the token scope required to change the name of a tenant, the display name may
+	// be changed by anyone allowed to update the tenant. Names can be changed freely when empty.
+	RenameScope string `mapstructure:"rename_scope"`
+	// NameReusePolicy tells whether created, renamed and moved tenants may take the names of
+	// deleted siblings: allow, block_during_retention until they are purged, or always_block.
+	NameReusePolicy string `mapstructure:"name_reuse_policy"`
+}
+
+// MustValidationViperFlags sets the flags configuring the validation of tenant fields.
+func MustValidationViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Int("name-max-length", defaultNameMaxLength, "maximum length of a tenant name in characters")
+	viperx.MustBindFlag(v, "validation.name_max_length", flags.Lookup("name-max-length"))
+
+	flags.StringSlice("reserved-names", nil, "tenant names which can't be used, case insensitive and may be globs")
+	viperx.MustBindFlag(v, "validation.reserved_names", flags.Lookup("reserved-names"))
+
+	flags.Int("max-children", defaultMaxChildren, "maximum number of direct children of a tenant, 0 is unlimited")
+	viperx.MustBindFlag(v, "validation.max_children", flags.Lookup("max-children"))
+
+	flags.Int("max-depth", 0, "deepest level of the hierarchy tenants may be at, roots being at level 1, 0 is unlimited")
+	viperx.MustBindFlag(v, "validation.max_depth", flags.Lookup("max-depth"))
+
+	flags.Int("settings-max-size", defaultSettingsMaxSize, "maximum size of a tenant settings document in bytes")
+	viperx.MustBindFlag(v, "validation.settings_max_size", flags.Lookup("settings-max-size"))
+
+	flags.Int("settings-max-depth", defaultSettingsMaxDepth, "maximum nesting depth of a tenant settings document")
+	viperx.MustBindFlag(v, "validation.settings_max_depth", flags.Lookup("settings-max-depth"))
+
+	flags.Int("max-labels", defaultMaxLabels, "maximum number of labels a tenant may set itself")
+	viperx.MustBindFlag(v, "validation.max_labels", flags.Lookup("max-labels"))
+
+	flags.Int("billing-reference-max-length", defaultBillingReferenceMaxLength, "maximum length of a tenant billing reference in characters")
+	viperx.MustBindFlag(v, "validation.billing_reference_max_length", flags.Lookup("billing-reference-max-length"))
+
+	flags.Int("description-max-length", defaultDescriptionMaxLength, "maximum length of a tenant description in characters")
+	viperx.MustBindFlag(v, "validation.description_max_length", flags.Lookup("description-max-length"))
+
+	flags.String("rename-scope", "", "token scope required to change the name of a tenant, unrestricted when empty")
+	viperx.MustBindFlag(v, "validation.rename_scope", flags.Lookup("rename-scope"))
+
+	flags.String("name-reuse-policy", defaultNameReusePolicy, "whether created, renamed and moved tenants may take the names of deleted siblings: allow, block_during_retention or always_block")
+	viperx.MustBindFlag(v, "validation.name_reuse_policy", flags.Lookup("name-reuse-policy"))
+}
+
+// DeletionConfig configures scheduled tenant deletions.
+type DeletionConfig struct {
+	// GracePeriod is the time between scheduling a deletion and performing it.
+	GracePeriod time.Duration `mapstructure:"grace_period"`
+	// CheckInterval is the interval due deletions are checked for at.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+	// MetricsInterval is the interval the pending deletion metrics are sampled at.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
+	// TombstoneRetention is the time the tombstones of deleted tenants are kept for, naming them
+	// in their audit history and allowing them to be restored. Zero keeps them forever.
+	TombstoneRetention time.Duration `mapstructure:"tombstone_retention"`
+}
+
+// MustDeletionViperFlags sets the flags configuring scheduled tenant deletions.
+func MustDeletionViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Duration("deletion-grace-period", defaultDeletionGracePeriod, "time between scheduling a tenant deletion and performing it")
+	viperx.MustBindFlag(v, "deletion.grace_period", flags.Lookup("deletion-grace-period"))
+
+	flags.Duration("deletion-check-interval", defaultDeletionCheckInterval, "interval due tenant deletions are checked for at")
+	viperx.MustBindFlag(v, "deletion.check_interval", flags.Lookup("deletion-check-interval"))
+
+	flags.Duration("deletion-metrics-interval", defaultDeletionSampleInterval, "interval the pending deletion metrics are sampled at")
+	viperx.MustBindFlag(v, "deletion.metrics_interval", flags.Lookup("deletion-metrics-interval"))
+
+	flags.Duration("deletion-tombstone-retention", defaultDeletionTombstoneRetention, "time the tombstones of deleted tenants are kept for, 0 keeps them forever")
+	viperx.MustBindFlag(v, "deletion.tombstone_retention", flags.Lookup("deletion-tombstone-retention"))
+}
+
+// DependentsConfig configures checking the resources of other services which depend on tenants
+// before they are deleted.
+type DependentsConfig struct {
+	// Reporters are the resource reporter endpoints asked for the dependents of deleted tenants,
+	// deletions aren't checked when empty.
+	Reporters []string `mapstructure:"reporters"`
+	// Timeout is the time the reporters have to respond.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// FailOpen lets deletions through when a reporter fails or doesn't respond in time, they are
+	// rejected otherwise.
+	FailOpen bool `mapstructure:"fail_open"`
+	// ForceScope is the token scope required to delete tenants despite their dependents,
+	// deletions can't be forced when empty.
+	ForceScope string `mapstructure:"force_scope"`
+}
+
+// MustDependentsViperFlags sets the flags configuring the dependents checks of deleted tenants.
+func MustDependentsViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.StringSlice("dependents-reporters", nil, "resource reporter endpoints asked for the dependents of deleted tenants")
+	viperx.MustBindFlag(v, "dependents.reporters", flags.Lookup("dependents-reporters"))
+
+	flags.Duration("dependents-timeout", defaultDependentsTimeout, "time the resource reporters have to respond")
+	viperx.MustBindFlag(v, "dependents.timeout", flags.Lookup("dependents-timeout"))
+
+	flags.Bool("dependents-fail-open", false, "delete tenants when a resource reporter fails or doesn't respond in time")
+	viperx.MustBindFlag(v, "dependents.fail_open", flags.Lookup("dependents-fail-open"))
+
+	flags.String("dependents-force-scope", "", "token scope required to delete tenants despite their dependents, deletions can't be forced when empty")
+	viperx.MustBindFlag(v, "dependents.force_scope", flags.Lookup("dependents-force-scope"))
+}
+
+// HTTPClientConfig configures the http clients of the outbound integrations, such as the
+// permissions checks and the resource reporters.
+type HTTPClientConfig struct {
+	// Timeout bounds a request, including its retries.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxAttempts is the number of times an idempotent request is sent at most.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RetryBackoff is the delay before the first retry, doubled on each further retry.
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+	// MaxConnsPerHost bounds the connections to a host, zero for no limit.
+	MaxConnsPerHost int `mapstructure:"max_conns_per_host"`
+	// BreakerFailures is the number of consecutive failures opening the circuit breaker of a host.
+	BreakerFailures int `mapstructure:"breaker_failures"`
+	// BreakerOpenTimeout is the time the circuit breaker of a host stays open before a request
+	// probes it.
+	BreakerOpenTimeout time.Duration `mapstructure:"breaker_open_timeout"`
+}
+
+// MustHTTPClientViperFlags sets the flags configuring the http clients of the outbound integrations.
+func MustHTTPClientViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Duration("http-client-timeout", defaultHTTPClientTimeout, "time outbound http requests may take, including their retries")
+	viperx.MustBindFlag(v, "http_client.timeout", flags.Lookup("http-client-timeout"))
+
+	flags.Int("http-client-max-attempts", defaultHTTPClientMaxAttempts, "number of times idempotent outbound http requests are sent at most")
+	viperx.MustBindFlag(v, "http_client.max_attempts", flags.Lookup("http-client-max-attempts"))
+
+	flags.Duration("http-client-retry-backoff", defaultHTTPClientRetryBackoff, "delay before retrying outbound http requests, doubled on each further retry")
+	viperx.MustBindFlag(v, "http_client.retry_backoff", flags.Lookup("http-client-retry-backoff"))
+
+	flags.Int("http-client-max-conns-per-host", defaultHTTPClientMaxConnsPerHost, "maximum number of outbound http connections to a host, zero for no limit")
+	viperx.MustBindFlag(v, "http_client.max_conns_per_host", flags.Lookup("http-client-max-conns-per-host"))
+
+	flags.Int("http-client-breaker-failures", defaultHTTPClientBreakerFailures, "number of consecutive failures opening the circuit breaker of a host")
+	viperx.MustBindFlag(v, "http_client.breaker_failures", flags.Lookup("http-client-breaker-failures"))
+
+	flags.Duration("http-client-breaker-open-timeout", defaultHTTPClientBreakerOpenTimeout, "time the circuit breaker of a host stays open before a request probes it")
+	viperx.MustBindFlag(v, "http_client.breaker_open_timeout", flags.Lookup("http-client-breaker-open-timeout"))
+}
+
+// UsageConfig configures counting the api requests made for each tenant.
+type UsageConfig struct {
+	// Enabled counts the requests and serves the usage endpoint.
+	Enabled bool `mapstructure:"enabled"`
+	// BucketSize is the length of the time buckets requests are counted in.
+	BucketSize time.Duration `mapstructure:"bucket_size"`
+	// FlushInterval is the interval the counts are written to the database at.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	// MaxPending is the number of counters kept between flushes, requests needing more aren't
+	// counted.
+	MaxPending int `mapstructure:"max_pending"`
+}
+
+// MustUsageViperFlags sets the flags configuring the usage counters of tenants.
+func MustUsageViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("usage-enabled", true, "count the api requests made for each tenant")
+	viperx.MustBindFlag(v, "usage.enabled", flags.Lookup("usage-enabled"))
+
+	flags.Duration("usage-bucket-size", defaultUsageBucketSize, "length of the time buckets tenant requests are counted in")
+	viperx.MustBindFlag(v, "usage.bucket_size", flags.Lookup("usage-bucket-size"))
+
+	flags.Duration("usage-flush-interval", defaultUsageFlushInterval, "interval the tenant request counts are written to the database at")
+	viperx.MustBindFlag(v, "usage.flush_interval", flags.Lookup("usage-flush-interval"))
+
+	flags.Int("usage-max-pending", defaultUsageMaxPending, "number of tenant request counters kept between flushes")
+	viperx.MustBindFlag(v, "usage.max_pending", flags.Lookup("usage-max-pending"))
+}
+
+// FailuresConfig configures the record of recent failed requests served to admins.
+type FailuresConfig struct {
+	// Size is the number of failed requests kept, zero disables the record.
+	Size int `mapstructure:"size"`
+	// Retention is the time failed requests are reported for.
+	Retention time.Duration `mapstructure:"retention"`
+	// Statuses are the client error statuses recorded, server errors are always recorded.
+	Statuses []int `mapstructure:"statuses"`
+}
+
+// MustFailuresViperFlags sets the flags configuring the record of recent failed requests.
+func MustFailuresViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Int("failed-requests-size", defaultFailedRequestsSize, "number of recent failed requests kept for admins, 0 disables the record")
+	viperx.MustBindFlag(v, "failures.size", flags.Lookup("failed-requests-size"))
+
+	flags.Duration("failed-requests-retention", defaultFailedRequestsRetention, "time recent failed requests are reported for")
+	viperx.MustBindFlag(v, "failures.retention", flags.Lookup("failed-requests-retention"))
+
+	flags.IntSlice("failed-requests-statuses", []int{401, 403, 409, 429}, "client error statuses of the failed requests recorded, server errors are always recorded")
+	viperx.MustBindFlag(v, "failures.statuses", flags.Lookup("failed-requests-statuses"))
+}
+
+// AuditConfig configures the audit of rejected tenant mutations.
+type AuditConfig struct {
+	// RejectedAttempts records the denied and conflicted mutation attempts of single tenants.
+	// Confirmed deletions of protected tenants and admin bulk operations suppressing their
+	// change events are recorded regardless, the audit endpoint is always served.
+	RejectedAttempts bool `mapstructure:"rejected_attempts"`
+}
+
+// MustAuditViperFlags sets the flags configuring the audit of rejected tenant mutations.
+func MustAuditViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("audit-rejected-attempts", false, "record the denied and conflicted mutation attempts of tenants")
+	viperx.MustBindFlag(v, "audit.rejected_attempts", flags.Lookup("audit-rejected-attempts"))
+}
+
+// StartupConfig configures the wait for the dependencies of the server at startup.
+type StartupConfig struct {
+	// WaitTimeout is how long the database and the events server have to become available before
+	// the server exits, zero doesn't wait for them.
+	WaitTimeout time.Duration `mapstructure:"wait_timeout"`
+	// Backoff is the delay before checking a dependency again the first time, doubled on each
+	// further check.
+	Backoff time.Duration `mapstructure:"backoff"`
+	// MaxBackoff is the longest delay between two checks of a dependency.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// MustStartupViperFlags sets the flags configuring the wait for dependencies at startup.
+func MustStartupViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Duration("startup-wait-timeout", 0, "how long the database and the events server have to become available at startup, 0 doesn't wait")
+	viperx.MustBindFlag(v, "startup.wait_timeout", flags.Lookup("startup-wait-timeout"))
+
+	flags.Duration("startup-backoff", defaultStartupBackoff, "delay before checking an unavailable dependency again, doubled on each check")
+	viperx.MustBindFlag(v, "startup.backoff", flags.Lookup("startup-backoff"))
+
+	flags.Duration("startup-max-backoff", defaultStartupMaxBackoff, "longest delay between two checks of an unavailable dependency")
+	viperx.MustBindFlag(v, "startup.max_backoff", flags.Lookup("startup-max-backoff"))
+}
+
+// QueriesConfig configures the count of the database queries of each request.
+type QueriesConfig struct {
+	// CountHeader reports the number of queries of each request in the X-Query-Count response
+	// header.
+	CountHeader bool `mapstructure:"count_header"`
+	// Budget fails the requests issuing more queries, zero doesn't limit them. It is meant for
+	// tests and staging, not production.
+	Budget int `mapstructure:"budget"`
+}
+
+// MustQueriesViperFlags sets the flags configuring the count of the queries of each request.
+func MustQueriesViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("query-count-header", false, "report the number of database queries of each request in the X-Query-Count response header")
+	viperx.MustBindFlag(v, "queries.count_header", flags.Lookup("query-count-header"))
+
+	flags.Int("query-budget", 0, "fail requests issuing more database queries, 0 doesn't limit them, meant for tests")
+	viperx.MustBindFlag(v, "queries.budget", flags.Lookup("query-budget"))
+}
+
+// TimingConfig configures the report of the server-side duration of each request.
+type TimingConfig struct {
+	// Header reports the durations of each request, in total, in the database and publishing
+	// events, in the Server-Timing response header. Deployments which consider them a leak of
+	// information disable it, the durations are still set on the span of the request.
+	Header bool `mapstructure:"header"`
+}
+
+// MustTimingViperFlags sets the flags configuring the report of the duration of each request.
+func MustTimingViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("server-timing-header", true, "report the server-side durations of each request in the Server-Timing response header")
+	viperx.MustBindFlag(v, "timing.header", flags.Lookup("server-timing-header"))
+}
+
+// FaultsConfig configures the injection of faults, to test the service under failure. Faults are
+// only injected by binaries built with the faults build tag, or with Unsafe enabled, and must
+// never be in production.
+type FaultsConfig struct {
+	// Unsafe enables injection in binaries built without the faults build tag.
+	Unsafe bool `mapstructure:"unsafe"`
+	// Rules program the faults, each formatted as point:probability[:latency], such as
+	// events.publish:0.1 or db.before_commit:1:2s.
+	Rules []string `mapstructure:"rules"`
+	// HeaderTrigger lets requests force faults with the X-Fault-Inject header, listing points.
+	HeaderTrigger bool `mapstructure:"header_trigger"`
+}
+
+// MustFaultsViperFlags sets the flags configuring the injection of faults.
+func MustFaultsViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("unsafe-fault-injection", false, "inject the configured faults without the faults build tag, never in production")
+	viperx.MustBindFlag(v, "faults.unsafe", flags.Lookup("unsafe-fault-injection"))
+
+	flags.StringSlice("fault-rules", nil, "faults injected, each as point:probability[:latency]")
+	viperx.MustBindFlag(v, "faults.rules", flags.Lookup("fault-rules"))
+
+	flags.Bool("fault-header-trigger", false, "let requests force faults with the X-Fault-Inject header")
+	viperx.MustBindFlag(v, "faults.header_trigger", flags.Lookup("fault-header-trigger"))
+}
+
+// MaintenanceConfig configures maintenance mode, it can be reloaded.
+type MaintenanceConfig struct {
+	// Enabled rejects every change of tenants with a retryable error, reads are still served.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// MustMaintenanceViperFlags sets the flags configuring maintenance mode.
+func MustMaintenanceViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("maintenance", false, "reject every change of tenants while still serving reads")
+	viperx.MustBindFlag(v, "maintenance.enabled", flags.Lookup("maintenance"))
+}
+
+// ReloadConfig configures reloading the settings which can change while the service runs.
+type ReloadConfig struct {
+	// Watch reloads the settings whenever the config file changes. They are reloaded on SIGHUP
+	// either way.
+	Watch bool `mapstructure:"watch"`
+}
+
+// MustReloadViperFlags sets the flags configuring reloading settings.
+func MustReloadViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("watch-config", false, "reload the settings which can change at runtime whenever the config file changes")
+	viperx.MustBindFlag(v, "reload.watch", flags.Lookup("watch-config"))
+}
+
+// BootstrapConfig describes the root tenant the bootstrap command ensures exists.
+type BootstrapConfig struct {
+	// RootName is the name the root tenant is created with.
+	RootName string `mapstructure:"root_name"`
+	// RootExternalID is the fixed external id of the root tenant, its id is derived from it.
+	RootExternalID string `mapstructure:"root_external_id"`
+}
+
+// MustBootstrapViperFlags sets the flags describing the root tenant the bootstrap command ensures.
+func MustBootstrapViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.String("root-name", "", "name the root tenant is created with")
+	viperx.MustBindFlag(v, "bootstrap.root_name", flags.Lookup("root-name"))
+
+	flags.String("root-external-id", "", "fixed external id of the root tenant, its id is derived from it")
+	viperx.MustBindFlag(v, "bootstrap.root_external_id", flags.Lookup("root-external-id"))
+}
+
+// ConsumerConfig configures consuming the changes published by other services.
+type ConsumerConfig struct {
+	// OwnerTopics are the topics the owners of tenants publish their changes to.
+	OwnerTopics []string `mapstructure:"owner_topics"`
+	// SuspendOnOwnerDelete suspends the root tenants of an owner once it is deleted.
+	SuspendOnOwnerDelete bool `mapstructure:"suspend_on_owner_delete"`
+	// MaxDeliveries is the number of times a change is delivered before giving up on it.
+	MaxDeliveries int `mapstructure:"max_deliveries"`
+	// RetryDelay is the delay before a change which failed to be handled is delivered again.
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+}
+
+// MustConsumerViperFlags sets the flags configuring consuming the changes of other services.
+func MustConsumerViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.StringSlice("consumer-owner-topics", nil, "topics the owners of tenants publish their changes to")
+	viperx.MustBindFlag(v, "consumer.owner_topics", flags.Lookup("consumer-owner-topics"))
+
+	flags.Bool("consumer-suspend-on-owner-delete", false, "suspend the root tenants of an owner once it is deleted")
+	viperx.MustBindFlag(v, "consumer.suspend_on_owner_delete", flags.Lookup("consumer-suspend-on-owner-delete"))
+
+	flags.Int("consumer-max-deliveries", defaultConsumerMaxDeliveries, "number of times a change is delivered before giving up on it")
+	viperx.MustBindFlag(v, "consumer.max_deliveries", flags.Lookup("consumer-max-deliveries"))
+
+	flags.Duration("consumer-retry-delay", defaultConsumerRetryDelay, "delay before a change which failed to be handled is delivered again")
+	viperx.MustBindFlag(v, "consumer.retry_delay", flags.Lookup("consumer-retry-delay"))
+}
+
+// EncryptionConfig configures the encryption of the payloads of the change events published and
+// consumed, for deployments sharing the events server with less trusted workloads.
+type EncryptionConfig struct {
+	// Enabled encrypts the payloads of the changes published and decrypts those consumed, it is
+	// off by default.
+	Enabled bool `mapstructure:"enabled"`
+	// Keys are the AES keys, each formatted as id=base64 key. The first encrypts the changes
+	// published, every one decrypts those consumed.
+	Keys []string `mapstructure:"keys"`
+}
+
+// MustEncryptionViperFlags sets the flags configuring the encryption of change event payloads.
+func MustEncryptionViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("event-encryption", false, "encrypt the payloads of the change events published and decrypt those consumed")
+	viperx.MustBindFlag(v, "encryption.enabled", flags.Lookup("event-encryption"))
+
+	flags.StringSlice("event-encryption-keys", nil, "keys event payloads are encrypted with, each as id=base64 key, the first encrypts and every one decrypts")
+	viperx.MustBindFlag(v, "encryption.keys", flags.Lookup("event-encryption-keys"))
+}
+
+// ChangesConfig configures the change events published for tenants.
+type ChangesConfig struct {
+	// Snapshots embeds the tenant resource in the change events, deletions carry the tenant as it
+	// was before being deleted. It is off by default as it grows the events and exposes every field
+	// of the tenant to their consumers.
+	Snapshots bool `mapstructure:"snapshots"`
+	// EffectiveLabels embeds the labels the tenant has with those inherited from its ancestors in
+	// its change events. The events of the descendants of a tenant whose labels change aren't
+	// published, consumers resolve them again when they care.
+	EffectiveLabels bool `mapstructure:"effective_labels"`
+	// AllowAnonymous lets tenants be changed without an authenticated actor, their changes are
+	// published with an unknown actor. It is meant for development only.
+	AllowAnonymous bool `mapstructure:"allow_anonymous"`
+	// SystemActor is the actor of the changes the service makes itself, such as scheduled
+	// deletions. They are rejected like anonymous changes when empty.
+	SystemActor string `mapstructure:"system_actor"`
+	// Retention is the time the recorded changes of tenants are kept for, replicas can catch up
+	// from the changes within it. Changes are kept forever when it is zero.
+	Retention time.Duration `mapstructure:"retention"`
+	// SubtreeTopics also publishes the changes of tenants to the topic of the subtree they are in,
+	// so consumers of a single subtree needn't filter the changes of every tenant. It is off by
+	// default as it doubles the tenant changes published.
+	SubtreeTopics bool `mapstructure:"subtree_topics"`
+	// Ancestors is the number of ancestors of their tenant the changes of tenants carry among their
+	// additional subjects, nearest first: none, parent, full or a number of levels. The parent is
+	// carried by default. Changes also carry the depth of their tenant, so consumers know whether
+	// its ancestors were truncated. The event stream finds the changes of a subtree from their
+	// ancestors, so none leaves it with the changes of the root only.
+	Ancestors string `mapstructure:"ancestors"`
+	// RootCacheTTL is the time the parents walked to resolve the roots of subtrees, and the
+	// ancestors checked for creation freezes, are cached for.
+	RootCacheTTL time.Duration `mapstructure:"root_cache_ttl"`
+	// DispatchWorkers is the number of workers publishing held changes at once, such as those of
+	// batch requests. The changes of a tenant are always published by the same worker, in order.
+	// It is relative to GOMAXPROCS when zero.
+	DispatchWorkers int `mapstructure:"dispatch_workers"`
+	// DispatchBatchSize is the number of changes a worker publishes between two waits for the
+	// dispatch rate.
+	DispatchBatchSize int `mapstructure:"dispatch_batch_size"`
+	// DispatchRate is the number of batches published per second, zero doesn't limit them. It can
+	// be raised for a while with the drain admin endpoint.
+	DispatchRate float64 `mapstructure:"dispatch_rate"`
+}
+
+// MustChangesViperFlags sets the flags configuring the change events published for tenants.
+func MustChangesViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Bool("change-snapshots", false, "embed the tenant resource in the change events published for it")
+	viperx.MustBindFlag(v, "changes.snapshots", flags.Lookup("change-snapshots"))
+
+	flags.Bool("change-effective-labels", false, "embed the effective labels of the tenant in the change events published for it")
+	viperx.MustBindFlag(v, "changes.effective_labels", flags.Lookup("change-effective-labels"))
+
+	flags.Bool("allow-anonymous-changes", false, "let tenants be changed without an authenticated actor, for development only")
+	viperx.MustBindFlag(v, "changes.allow_anonymous", flags.Lookup("allow-anonymous-changes"))
+
+	flags.String("system-actor", defaultChangesSystemActor, "actor of the changes the service makes itself, such as scheduled deletions")
+	viperx.MustBindFlag(v, "changes.system_actor", flags.Lookup("system-actor"))
+
+	flags.Duration("change-retention", 0, "time the recorded changes of tenants are kept for, forever when zero")
+	viperx.MustBindFlag(v, "changes.retention", flags.Lookup("change-retention"))
+
+	flags.Bool("change-subtree-topics", false, "also publish the changes of tenants to the topic of the subtree they are in")
+	viperx.MustBindFlag(v, "changes.subtree_topics", flags.Lookup("change-subtree-topics"))
+
+	flags.String("change-ancestors", defaultChangesAncestors, "ancestors of their tenant the changes of tenants carry: none, parent, full or a number of levels")
+	viperx.MustBindFlag(v, "changes.ancestors", flags.Lookup("change-ancestors"))
+
+	flags.Duration("change-root-cache-ttl", defaultChangesRootCacheTTL, "time the parents walked to resolve the roots of subtrees and the ancestors checked for creation freezes are cached for")
+	viperx.MustBindFlag(v, "changes.root_cache_ttl", flags.Lookup("change-root-cache-ttl"))
+
+	flags.Int("change-dispatch-workers", 0, "number of workers publishing held changes at once, 2 per GOMAXPROCS and at least 4 when zero")
+	viperx.MustBindFlag(v, "changes.dispatch_workers", flags.Lookup("change-dispatch-workers"))
+
+	flags.Int("change-dispatch-batch-size", defaultChangesDispatchBatchSize, "number of changes a worker publishes between two waits for the dispatch rate")
+	viperx.MustBindFlag(v, "changes.dispatch_batch_size", flags.Lookup("change-dispatch-batch-size"))
+
+	flags.Float64("change-dispatch-rate", 0, "number of change batches published per second, unlimited when zero")
+	viperx.MustBindFlag(v, "changes.dispatch_rate", flags.Lookup("change-dispatch-rate"))
+}
+
+// TraversalConfig configures the thresholds past which walks through the hierarchy are logged.
+type TraversalConfig struct {
+	// WarnNodes is the number of tenants a walk may return before it is logged, zero never logs.
+	WarnNodes int `mapstructure:"warn_nodes"`
+	// WarnDepth is the number of levels a walk may go before it is logged, zero never logs.
+	WarnDepth int `mapstructure:"warn_depth"`
+	// WarnDuration is the time a walk may take before it is logged, zero never logs.
+	WarnDuration time.Duration `mapstructure:"warn_duration"`
+}
+
+// MustTraversalViperFlags sets the flags configuring the thresholds of hierarchy walks.
+func MustTraversalViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Int("traversal-warn-nodes", 0, "log hierarchy walks returning more tenants than this, never when zero")
+	viperx.MustBindFlag(v, "traversal.warn_nodes", flags.Lookup("traversal-warn-nodes"))
+
+	flags.Int("traversal-warn-depth", 0, "log hierarchy walks going more levels deep than this, never when zero")
+	viperx.MustBindFlag(v, "traversal.warn_depth", flags.Lookup("traversal-warn-depth"))
+
+	flags.Duration("traversal-warn-duration", 0, "log hierarchy walks taking longer than this, never when zero")
+	viperx.MustBindFlag(v, "traversal.warn_duration", flags.Lookup("traversal-warn-duration"))
+}
+
+// SearchConfig configures the backend tenants are searched with.
+type SearchConfig struct {
+	// Backend is the backend tenants are searched with, sql or bleve.
+	Backend string `mapstructure:"backend"`
+	// IndexPath is the directory of the Bleve index, it is kept in memory and rebuilt on startup
+	// when empty.
+	IndexPath string `mapstructure:"index_path"`
+	// SyncInterval is the interval the Bleve index is synced with the recorded tenant changes at.
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+}
+
+// IsBleve reports whether the bleve backend is selected.
+func (c SearchConfig) IsBleve() bool {
+	return c.Backend == SearchBackendBleve
+}
+
+// MustSearchViperFlags sets the flags configuring the backend tenants are searched with.
+func MustSearchViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.String("search-backend", SearchBackendSQL, "backend tenants are searched with: sql or bleve")
+	viperx.MustBindFlag(v, "search.backend", flags.Lookup("search-backend"))
+
+	flags.String("search-index-path", "", "directory of the bleve search index, kept in memory when empty")
+	viperx.MustBindFlag(v, "search.index_path", flags.Lookup("search-index-path"))
+
+	flags.Duration("search-sync-interval", defaultSearchSyncInterval, "interval the bleve search index is synced with the tenant changes at")
+	viperx.MustBindFlag(v, "search.sync_interval", flags.Lookup("search-sync-interval"))
+}
+
+// RuntimeConfig tunes the go runtime. The settings which aren't configured are taken from the
+// GOMAXPROCS, GOMEMLIMIT and GOGC environment variables, then derived from the cgroup limits.
+type RuntimeConfig struct {
+	// MaxProcs sets GOMAXPROCS when positive.
+	MaxProcs int `mapstructure:"max_procs"`
+	// MemoryLimit sets the soft memory limit, such as 1536MiB, when not empty.
+	MemoryLimit string `mapstructure:"memory_limit"`
+	// MemoryLimitRatio is the share of the cgroup memory limit the soft memory limit is derived as.
+	MemoryLimitRatio float64 `mapstructure:"memory_limit_ratio"`
+	// GCPercent sets the garbage collection target percentage when not zero, negative turns the
+	// collector off until the memory limit is reached.
+	GCPercent int `mapstructure:"gc_percent"`
+	// DetectCgroup derives GOMAXPROCS and the soft memory limit from the cgroup limits.
+	DetectCgroup bool `mapstructure:"detect_cgroup"`
+}
+
+// MustRuntimeViperFlags sets the flags tuning the go runtime.
+func MustRuntimeViperFlags(v *viper.Viper, flags *pflag.FlagSet) {
+	flags.Int("runtime-max-procs", 0, "GOMAXPROCS, derived from the cgroup cpu quota when zero")
+	viperx.MustBindFlag(v, "runtime.max_procs", flags.Lookup("runtime-max-procs"))
+
+	flags.String("runtime-memory-limit", "", "soft memory limit of the runtime such as 1536MiB, derived from the cgroup memory limit when empty")
+	viperx.MustBindFlag(v, "runtime.memory_limit", flags.Lookup("runtime-memory-limit"))
+
+	flags.Float64("runtime-memory-limit-ratio", defaultRuntimeMemoryLimitRatio, "share of the cgroup memory limit the soft memory limit is derived as")
+	viperx.MustBindFlag(v, "runtime.memory_limit_ratio", flags.Lookup("runtime-memory-limit-ratio"))
+
+	flags.Int("runtime-gc-percent", 0, "garbage collection target percentage, GOGC applies when zero and negative turns the collector off")
+	viperx.MustBindFlag(v, "runtime.gc_percent", flags.Lookup("runtime-gc-percent"))
+
+	flags.Bool("runtime-detect-cgroup", true, "derive GOMAXPROCS and the soft memory limit from the cgroup limits")
+	viperx.MustBindFlag(v, "runtime.detect_cgroup", flags.Lookup("runtime-detect-cgroup"))
+}
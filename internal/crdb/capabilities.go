@@ -0,0 +1,49 @@
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// Capabilities are the features of CockroachDB the connected database supports.
+type Capabilities struct {
+	// FollowerReads reports whether transactions can be read at a past time with AS OF SYSTEM TIME.
+	FollowerReads bool
+}
+
+// Querier runs a query, such as an ent client or a *sql.DB.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Detect returns the capabilities of the database from its version, once it accepts connections.
+// Only postgres compatible databases can be detected, sqlite has none of the capabilities.
+func Detect(ctx context.Context, q Querier) (Capabilities, error) {
+	rows, err := q.QueryContext(ctx, "SELECT version()")
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	defer rows.Close()
+
+	var version string
+
+	if rows.Next() {
+		if err := rows.Scan(&version); err != nil {
+			return Capabilities{}, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return Capabilities{}, err
+	}
+
+	return FromVersion(version), nil
+}
+
+// FromVersion returns the capabilities of a database from the version it reports. Postgres
+// compatible databases other than CockroachDB have none of them.
+func FromVersion(version string) Capabilities {
+	return Capabilities{FollowerReads: strings.HasPrefix(version, "CockroachDB")}
+}
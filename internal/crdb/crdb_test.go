@@ -0,0 +1,106 @@
+package crdb_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/crdb"
+)
+
+var errSerialization = &pq.Error{Code: "40001", Message: "restart transaction"}
+
+func TestFromVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    crdb.Capabilities
+	}{
+		{"cockroachdb", "CockroachDB CCL v23.1.11 (x86_64-pc-linux-gnu, built 2023/09/27 01:53:43, go1.19.10)", crdb.Capabilities{FollowerReads: true}},
+		{"postgres", "PostgreSQL 15.4 on x86_64-pc-linux-gnu", crdb.Capabilities{}},
+		{"empty", "", crdb.Capabilities{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, crdb.FromVersion(tt.version))
+		})
+	}
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	assert.True(t, crdb.IsSerializationFailure(errSerialization))
+	assert.True(t, crdb.IsSerializationFailure(fmt.Errorf("committing: %w", errSerialization)))
+	assert.False(t, crdb.IsSerializationFailure(&pq.Error{Code: "23505"}))
+	assert.False(t, crdb.IsSerializationFailure(errors.New("40001")))
+	assert.False(t, crdb.IsSerializationFailure(nil))
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	fast := crdb.WithBackoff(time.Microsecond, time.Microsecond)
+
+	t.Run("until it succeeds", func(t *testing.T) {
+		calls := 0
+
+		err := crdb.Retry(ctx, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return errSerialization
+			}
+
+			return nil
+		}, fast)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("up to the attempts", func(t *testing.T) {
+		calls := 0
+
+		err := crdb.Retry(ctx, func(context.Context) error {
+			calls++
+
+			return errSerialization
+		}, fast, crdb.WithAttempts(2))
+
+		assert.ErrorIs(t, err, errSerialization)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("not other errors", func(t *testing.T) {
+		calls := 0
+		other := errors.New("boom")
+
+		err := crdb.Retry(ctx, func(context.Context) error {
+			calls++
+
+			return other
+		}, fast)
+
+		assert.ErrorIs(t, err, other)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		calls := 0
+
+		err := crdb.Retry(ctx, func(context.Context) error {
+			calls++
+			cancel()
+
+			return errSerialization
+		}, crdb.WithBackoff(time.Hour, time.Hour))
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+}
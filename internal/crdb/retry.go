@@ -0,0 +1,97 @@
+package crdb
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Retry defaults, the delay before the first retry doubles on each further one up to the max.
+const (
+	DefaultAttempts   = 5
+	DefaultBackoff    = 10 * time.Millisecond
+	DefaultMaxBackoff = 500 * time.Millisecond
+)
+
+// codeSerializationFailure is the sql state of transactions aborted to keep them serializable,
+// which CockroachDB asks clients to retry.
+const codeSerializationFailure = "40001"
+
+// IsSerializationFailure reports whether the error is a transaction aborted by a serialization
+// failure, which succeeds when the transaction is run again.
+func IsSerializationFailure(err error) bool {
+	var state interface{ SQLState() string }
+
+	return errors.As(err, &state) && state.SQLState() == codeSerializationFailure
+}
+
+// Option configures the retries of a transaction.
+type Option func(*retrier)
+
+// WithAttempts sets the number of times a transaction is run before its serialization failure is
+// returned.
+func WithAttempts(n int) Option {
+	return func(r *retrier) {
+		if n > 0 {
+			r.attempts = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the longest delay between two retries.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(r *retrier) {
+		if initial > 0 {
+			r.backoff = initial
+		}
+
+		if max >= r.backoff {
+			r.maxBackoff = max
+		}
+	}
+}
+
+type retrier struct {
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// Retry runs fn until it doesn't fail with a serialization failure, up to the attempts. fn runs
+// the whole transaction, from beginning it to committing it, as an aborted one can't be reused.
+// The last serialization failure is returned when no attempt succeeds, and the error of the
+// context when it is done while waiting for the next attempt.
+func Retry(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	r := &retrier{
+		attempts:   DefaultAttempts,
+		backoff:    DefaultBackoff,
+		maxBackoff: DefaultMaxBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	delay := r.backoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= r.attempts || !IsSerializationFailure(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > r.maxBackoff {
+			delay = r.maxBackoff
+		}
+	}
+}
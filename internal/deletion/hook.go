@@ -0,0 +1,100 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/internal/traversal"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// HookOption configures the hook returned by Hook.
+type HookOption func(*hookConfig)
+
+type hookConfig struct {
+	traversals *traversal.Metrics
+}
+
+// WithTraversalMetrics records the walks up from the parent on the metrics, as cascade checks.
+func WithTraversalMetrics(m *traversal.Metrics) HookOption {
+	return func(c *hookConfig) {
+		c.traversals = m
+	}
+}
+
+// Hook returns an ent hook rejecting the creation of tenants under a parent which is scheduled
+// for deletion or has an ancestor which is. Moving tenants under such a parent is rejected the same,
+// as is using a parent which doesn't exist.
+func Hook(opts ...HookOption) ent.Hook {
+	var cfg hookConfig
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if parentID, ok := m.ParentTenantID(); ok && parentID != gidx.NullPrefixedID {
+					parent, err := m.Client().Tenant.Get(ctx, parentID)
+
+					switch {
+					case generated.IsNotFound(err):
+						return nil, &validation.Error{
+							Field:   "parent",
+							Code:    validation.CodeParentNotFound,
+							Message: fmt.Sprintf("%s does not exist", parentID),
+						}
+					case err != nil:
+						return nil, err
+					default:
+						if err := checkParent(ctx, m.Client(), parent, cfg.traversals); err != nil {
+							return nil, err
+						}
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// checkParent returns an error unless the effective status of the parent is active.
+func checkParent(ctx context.Context, client *generated.Client, parent *generated.Tenant, traversals *traversal.Metrics) error {
+	start := time.Now()
+
+	statuses, ws, err := effectiveStatuses(ctx, client, []*generated.Tenant{parent})
+	if err != nil {
+		return err
+	}
+
+	traversals.Observe(ctx, traversal.OpCascadeCheck, ws.loaded, ws.levels, time.Since(start))
+
+	status := statuses[parent.ID]
+
+	message := ""
+
+	switch status {
+	case StatusActive:
+		return nil
+	case StatusPendingDeletion:
+		message = "the parent tenant is scheduled for deletion"
+	default:
+		message = "an ancestor of the parent tenant is scheduled for deletion"
+	}
+
+	return &validation.Error{
+		Field:   "parent",
+		Code:    validation.CodeParentDeleted,
+		Message: message,
+		Err:     ErrPendingDeletion,
+	}
+}
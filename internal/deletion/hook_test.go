@@ -0,0 +1,55 @@
+package deletion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/traversal"
+)
+
+func TestHookTraversalMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := traversal.NewMetrics(zap.NewNop().Sugar(), reg)
+	require.NoError(t, err)
+
+	client.Tenant.Use(deletion.Hook(deletion.WithTraversalMetrics(metrics)))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	// creating under the grandchild walks up the child and the root
+	client.Tenant.Create().SetName("leaf").SetParent(grandchild).ExecX(ctx)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	sums := map[string]float64{}
+
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			assert.Equal(t, traversal.OpCascadeCheck, m.GetLabel()[0].GetValue())
+
+			// the checks under the root and the child walked 0 and 1 levels before
+			assert.Equal(t, uint64(3), m.GetHistogram().GetSampleCount(), family.GetName())
+
+			sums[family.GetName()] = m.GetHistogram().GetSampleSum()
+		}
+	}
+
+	assert.Equal(t, float64(0+1+2), sums["tenant_api_traversal_nodes"])
+	assert.Equal(t, float64(0+1+2), sums["tenant_api_traversal_depth"])
+}
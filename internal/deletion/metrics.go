@@ -0,0 +1,178 @@
+package deletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// DefaultSampleInterval is the default interval the deletion metrics are sampled at.
+const DefaultSampleInterval = time.Minute
+
+const (
+	metricsNamespace = "tenant_api"
+	metricsSubsystem = "deletion"
+
+	purgeSucceeded = "success"
+	purgeFailed    = "failure"
+)
+
+// dueBuckets are the upper bounds the pending deletions are counted by, by the time until they
+// are due. Deletions past their due time are counted as overdue and the rest as later.
+var dueBuckets = []struct {
+	label  string
+	within time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+	{"168h", 7 * 24 * time.Hour},
+}
+
+// Metrics exposes how many deletions are pending, how overdue the oldest one is and how many
+// tenants the scheduler deleted, so dashboards can tell whether deletions are carried out on time.
+// The gauges are sampled periodically by Run, the queries use the deletion_scheduled_at index.
+type Metrics struct {
+	client *ent.Client
+	logger *zap.SugaredLogger
+
+	pending *prometheus.GaugeVec
+	overdue prometheus.Gauge
+	purges  *prometheus.CounterVec
+}
+
+// NewMetrics returns the deletion metrics, registered with the registerer.
+func NewMetrics(client *ent.Client, logger *zap.SugaredLogger, reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		client: client,
+		logger: logger,
+		pending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "pending_tenants",
+			Help:      "Number of tenants scheduled for deletion, by the time until the deletion is due.",
+		}, []string{"due"}),
+		overdue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "oldest_overdue_seconds",
+			Help:      "Time the oldest due deletion is past its scheduled time, zero when none is due.",
+		}),
+		purges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "purges_total",
+			Help:      "Number of due deletions performed by the scheduler, by result.",
+		}, []string{"result"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.pending, m.overdue, m.purges} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	// report both results from the start so rates work before the first failure
+	m.purges.WithLabelValues(purgeSucceeded)
+	m.purges.WithLabelValues(purgeFailed)
+
+	return m, nil
+}
+
+// Run samples the gauges every interval until the context is done.
+func (m *Metrics) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSampleInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sample(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Errorw("failed to sample deletion metrics", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample updates the gauges from the pending deletions.
+func (m *Metrics) Sample(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	overdue, err := m.client.Tenant.Query().Where(tenant.DeletionScheduledAtLTE(now)).Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int{"overdue": overdue}
+
+	from := now
+
+	for _, bucket := range dueBuckets {
+		to := now.Add(bucket.within)
+
+		n, err := m.client.Tenant.Query().
+			Where(tenant.DeletionScheduledAtGT(from), tenant.DeletionScheduledAtLTE(to)).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		counts[bucket.label] = n
+		from = to
+	}
+
+	later, err := m.client.Tenant.Query().Where(tenant.DeletionScheduledAtGT(from)).Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	counts["later"] = later
+
+	oldest := 0.0
+
+	if overdue != 0 {
+		first, err := m.client.Tenant.Query().
+			Where(tenant.DeletionScheduledAtLTE(now)).
+			Order(tenant.ByDeletionScheduledAt()).
+			First(ctx)
+		if err != nil {
+			return err
+		}
+
+		oldest = now.Sub(first.DeletionScheduledAt).Seconds()
+	}
+
+	for due, n := range counts {
+		m.pending.WithLabelValues(due).Set(float64(n))
+	}
+
+	m.overdue.Set(oldest)
+
+	return nil
+}
+
+// observePurge counts a deletion performed by the scheduler.
+func (m *Metrics) observePurge(err error) {
+	if m == nil {
+		return
+	}
+
+	if err != nil {
+		m.purges.WithLabelValues(purgeFailed).Inc()
+
+		return
+	}
+
+	m.purges.WithLabelValues(purgeSucceeded).Inc()
+}
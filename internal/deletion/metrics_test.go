@@ -0,0 +1,83 @@
+package deletion_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+)
+
+func TestMetrics(t *testing.T) {
+	ctx, client, _ := newTestClient(t)
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := deletion.NewMetrics(client, zap.NewNop().Sugar(), reg)
+	require.NoError(t, err)
+
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithMetrics(metrics))
+
+	overdue := client.Tenant.Create().SetName("overdue").SaveX(ctx)
+	client.Tenant.Create().SetName("soon").SetDeletionScheduledAt(time.Now().Add(30 * time.Minute)).SaveX(ctx)
+	client.Tenant.Create().SetName("later").SetDeletionScheduledAt(time.Now().Add(30 * 24 * time.Hour)).SaveX(ctx)
+	client.Tenant.Create().SetName("kept").SaveX(ctx)
+
+	client.Tenant.UpdateOne(overdue).SetDeletionScheduledAt(time.Now().Add(-time.Hour)).ExecX(ctx)
+
+	require.NoError(t, metrics.Sample(ctx))
+
+	assert.Equal(t, float64(1), gauge(t, reg, "tenant_api_deletion_pending_tenants", "overdue"))
+	assert.Equal(t, float64(1), gauge(t, reg, "tenant_api_deletion_pending_tenants", "1h"))
+	assert.Equal(t, float64(0), gauge(t, reg, "tenant_api_deletion_pending_tenants", "24h"))
+	assert.Equal(t, float64(1), gauge(t, reg, "tenant_api_deletion_pending_tenants", "later"))
+	assert.InDelta(t, time.Hour.Seconds(), gauge(t, reg, "tenant_api_deletion_oldest_overdue_seconds", ""), time.Minute.Seconds())
+
+	deleted, err := s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	require.NoError(t, metrics.Sample(ctx))
+
+	assert.Equal(t, float64(0), gauge(t, reg, "tenant_api_deletion_pending_tenants", "overdue"))
+	assert.Equal(t, float64(0), gauge(t, reg, "tenant_api_deletion_oldest_overdue_seconds", ""))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "tenant_api_deletion_purges_total"))
+	assert.Equal(t, float64(1), gauge(t, reg, "tenant_api_deletion_purges_total", "success"))
+	assert.Equal(t, float64(0), gauge(t, reg, "tenant_api_deletion_purges_total", "failure"))
+}
+
+// gauge returns the value of the metric with the label value, the only label of the metrics.
+func gauge(t *testing.T, reg *prometheus.Registry, name, label string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if label != "" && (len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != label) {
+				continue
+			}
+
+			if metric.GetCounter() != nil {
+				return metric.GetCounter().GetValue()
+			}
+
+			return metric.GetGauge().GetValue()
+		}
+	}
+
+	require.Failf(t, "metric not found", "%s{%s}", name, label)
+
+	return 0
+}
@@ -0,0 +1,405 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/clock"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+const (
+	// DefaultGracePeriod is the default time between scheduling a deletion and performing it.
+	DefaultGracePeriod = 7 * 24 * time.Hour
+
+	// DefaultInterval is the default interval the scheduler checks for due deletions at.
+	DefaultInterval = time.Minute
+
+	// subtreeBatchSize is the most parents whose children are loaded by a single query when
+	// walking the subtree of a deletion.
+	subtreeBatchSize = 500
+)
+
+var (
+	// ErrHasChildren is returned when deleting a tenant which still has children right away, its
+	// deletion can be scheduled instead.
+	ErrHasChildren = apierrors.New(apierrors.ErrConflict, "tenant has children and can't be deleted")
+
+	// ErrProtected is returned when scheduling the deletion of a tenant protected against deletion,
+	// or with a descendant which is, as scheduled deletions can't confirm their names.
+	ErrProtected = apierrors.New(apierrors.ErrDeletionProtected, "tenant or one of its descendants is protected against deletion and can't be scheduled for deletion")
+
+	// ErrPendingDeletion is returned, wrapped in a validation error, when creating or moving a
+	// tenant under a parent which is scheduled for deletion or has an ancestor which is.
+	ErrPendingDeletion = apierrors.New(apierrors.ErrConflict, "parent tenant is scheduled for deletion")
+)
+
+// Option configures a Scheduler.
+type Option func(*Scheduler)
+
+// WithGracePeriod sets the time between scheduling a deletion and performing it.
+func WithGracePeriod(d time.Duration) Option {
+	return func(s *Scheduler) {
+		if d >= 0 {
+			s.gracePeriod = d
+		}
+	}
+}
+
+// WithInterval sets the interval Run checks for due deletions at.
+func WithInterval(d time.Duration) Option {
+	return func(s *Scheduler) {
+		if d > 0 {
+			s.interval = d
+		}
+	}
+}
+
+// WithChangeRetention sets the time the recorded tenant changes are kept for before Run purges
+// them. They are kept forever when it isn't positive.
+func WithChangeRetention(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.changeRetention = d
+	}
+}
+
+// WithTombstoneRetention sets the time the tombstones of deleted tenants are kept for after they
+// were removed, before Run purges them. They are kept forever when it isn't positive.
+func WithTombstoneRetention(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.tombstoneRetention = d
+	}
+}
+
+// WithClock sets the clock deletions are scheduled with and found due by, the wall clock by
+// default.
+func WithClock(c clock.Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = c
+	}
+}
+
+// WithMetrics sets the metrics the deletions performed by the scheduler are counted on.
+func WithMetrics(m *Metrics) Option {
+	return func(s *Scheduler) {
+		s.metrics = m
+	}
+}
+
+// Scheduler schedules tenant deletions and performs them once their grace period has passed.
+type Scheduler struct {
+	client             *ent.Client
+	logger             *zap.SugaredLogger
+	gracePeriod        time.Duration
+	interval           time.Duration
+	changeRetention    time.Duration
+	tombstoneRetention time.Duration
+	metrics            *Metrics
+	clock              clock.Clock
+}
+
+// NewScheduler returns a deletion scheduler.
+func NewScheduler(client *ent.Client, logger *zap.SugaredLogger, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		client:      client,
+		logger:      logger,
+		gracePeriod: DefaultGracePeriod,
+		interval:    DefaultInterval,
+		clock:       clock.Real{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Schedule marks the tenant for deletion once the grace period has passed, along with its
+// descendants, which are deleted with it. Tenants protected against deletion, or with a descendant
+// which is, can't be scheduled, and no tenants can be created in the subtree while the deletion is
+// pending. Only the tenant is marked, its descendants have the parent_deleted effective status.
+// Scheduling an already scheduled tenant keeps the original time.
+func (s *Scheduler) Schedule(ctx context.Context, id gidx.PrefixedID) (*ent.Tenant, error) {
+	return s.withTx(ctx, func(tx *ent.Tx) (*ent.Tenant, error) {
+		t, err := tx.Tenant.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.DeletionScheduledAt.IsZero() {
+			return t, nil
+		}
+
+		if t.DeletionProtected {
+			return nil, ErrProtected
+		}
+
+		levels, err := descendants(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if anyProtected(levels) {
+			return nil, ErrProtected
+		}
+
+		return tx.Tenant.UpdateOne(t).SetDeletionScheduledAt(s.clock.Now().UTC().Add(s.gracePeriod)).Save(ctx)
+	})
+}
+
+// Cancel aborts the pending deletion of the tenant. Cancelling when no deletion is pending does
+// nothing.
+func (s *Scheduler) Cancel(ctx context.Context, id gidx.PrefixedID) (*ent.Tenant, error) {
+	return s.withTx(ctx, func(tx *ent.Tx) (*ent.Tenant, error) {
+		t, err := tx.Tenant.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.DeletionScheduledAt.IsZero() {
+			return t, nil
+		}
+
+		return tx.Tenant.UpdateOne(t).ClearDeletionScheduledAt().Save(ctx)
+	})
+}
+
+// Run deletes due tenants, and purges the tenant changes and tombstones past their retention,
+// every interval until the context is done. The context must carry the auth relationship handler used by the
+// event hooks.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.DeleteDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Errorw("failed to delete tenants scheduled for deletion", "error", err)
+		}
+
+		if _, err := s.PurgeChanges(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Errorw("failed to purge tenant changes", "error", err)
+		}
+
+		if _, err := s.PurgeTombstones(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Errorw("failed to purge tenant tombstones", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteDue deletes the tenants whose scheduled deletion time has passed, each with its
+// descendants in a transaction of its own, the deepest first, and returns how many tenants were
+// deleted. Deletions racing with another replica are skipped. Tenants protected against deletion
+// since their deletion was scheduled, or with a descendant which is, are kept until the protection
+// is lifted.
+func (s *Scheduler) DeleteDue(ctx context.Context) (int, error) {
+	ids, err := s.client.Tenant.Query().
+		Where(
+			tenant.DeletionScheduledAtLTE(s.clock.Now().UTC()),
+			tenant.Or(tenant.DeletionProtectedIsNil(), tenant.DeletionProtected(false)),
+		).
+		IDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		deleted int
+		errs    []error
+	)
+
+	for _, id := range ids {
+		var count int
+
+		_, err := s.withTx(ctx, func(tx *ent.Tx) (*ent.Tenant, error) {
+			levels, err := descendants(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			if anyProtected(levels) {
+				return nil, ErrProtected
+			}
+
+			for i := len(levels) - 1; i >= 0; i-- {
+				for _, d := range levels[i] {
+					if err := tx.Tenant.DeleteOneID(d.ID).Exec(ctx); err != nil {
+						return nil, err
+					}
+				}
+
+				count += len(levels[i])
+			}
+
+			if err := tx.Tenant.DeleteOneID(id).Exec(ctx); err != nil {
+				return nil, err
+			}
+
+			count++
+
+			return nil, nil
+		})
+
+		switch {
+		case err == nil:
+			deleted += count
+
+			for i := 0; i < count; i++ {
+				s.metrics.observePurge(nil)
+			}
+
+			s.logger.Infow("deleted tenant scheduled for deletion", "tenant_id", id, "deleted", count)
+		case ent.IsNotFound(err):
+		case errors.Is(err, ErrProtected):
+			s.logger.Debugw("kept tenant scheduled for deletion with a protected descendant", "tenant_id", id)
+		default:
+			errs = append(errs, err)
+
+			s.metrics.observePurge(err)
+
+			s.logger.Errorw("failed to delete tenant scheduled for deletion", "tenant_id", id, "error", err)
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// descendants returns the descendants of the tenant a level at a time, the children first, with
+// their IDs and protection. Tenants already seen are skipped so a cycle doesn't walk forever.
+func descendants(ctx context.Context, tx *ent.Tx, root gidx.PrefixedID) ([][]*ent.Tenant, error) {
+	var levels [][]*ent.Tenant
+
+	seen := map[gidx.PrefixedID]bool{root: true}
+
+	for parents := []gidx.PrefixedID{root}; len(parents) != 0; {
+		var (
+			level []*ent.Tenant
+			next  []gidx.PrefixedID
+		)
+
+		for start := 0; start < len(parents); start += subtreeBatchSize {
+			end := start + subtreeBatchSize
+			if end > len(parents) {
+				end = len(parents)
+			}
+
+			children, err := tx.Tenant.Query().
+				Where(tenant.ParentTenantIDIn(parents[start:end]...)).
+				Select(tenant.FieldID, tenant.FieldDeletionProtected).
+				All(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, child := range children {
+				if !seen[child.ID] {
+					seen[child.ID] = true
+
+					level = append(level, child)
+					next = append(next, child.ID)
+				}
+			}
+		}
+
+		if len(level) != 0 {
+			levels = append(levels, level)
+		}
+
+		parents = next
+	}
+
+	return levels, nil
+}
+
+// anyProtected reports whether any of the tenants is protected against deletion.
+func anyProtected(levels [][]*ent.Tenant) bool {
+	for _, level := range levels {
+		for _, t := range level {
+			if t.DeletionProtected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// PurgeChanges deletes the recorded tenant changes older than the change retention and returns
+// how many were deleted, see changeseq.Purge. Nothing is purged without a retention.
+func (s *Scheduler) PurgeChanges(ctx context.Context) (int, error) {
+	if s.changeRetention <= 0 {
+		return 0, nil
+	}
+
+	purged, err := changeseq.Purge(ctx, s.client, s.clock.Now().UTC().Add(-s.changeRetention))
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		s.logger.Infow("purged tenant changes past their retention", "count", purged, "retention", s.changeRetention)
+	}
+
+	return purged, nil
+}
+
+// PurgeTombstones deletes the tombstones of the tenants removed longer than the tombstone
+// retention ago and returns how many were deleted, see tombstone.Purge. Nothing is purged without
+// a retention.
+func (s *Scheduler) PurgeTombstones(ctx context.Context) (int, error) {
+	if s.tombstoneRetention <= 0 {
+		return 0, nil
+	}
+
+	purged, err := tombstone.Purge(ctx, s.client, s.clock.Now().UTC().Add(-s.tombstoneRetention))
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		s.logger.Infow("purged tenant tombstones past their retention", "count", purged, "retention", s.tombstoneRetention)
+	}
+
+	return purged, nil
+}
+
+func (s *Scheduler) withTx(ctx context.Context, fn func(tx *ent.Tx) (*ent.Tenant, error)) (*ent.Tenant, error) {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := fn(tx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			reqlog.FromContext(ctx, s.logger).Errorw("failed to roll back", "error", rerr)
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if t != nil {
+		t = t.Unwrap()
+	}
+
+	return t, nil
+}
@@ -0,0 +1,252 @@
+package deletion_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+)
+
+func newTestClient(t *testing.T) (context.Context, *ent.Client, *eventtools.MockConnection) {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(conn)),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(deletion.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{})
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms), client, conn
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+	ctx, client, _ := newTestClient(t)
+
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(time.Hour))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	leaf := client.Tenant.Create().SetName("leaf").SetParent(root).SaveX(ctx)
+
+	before := time.Now()
+
+	scheduled, err := s.Schedule(ctx, leaf.ID)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(time.Hour), scheduled.DeletionScheduledAt, time.Minute)
+
+	again, err := s.Schedule(ctx, leaf.ID)
+	require.NoError(t, err)
+	assert.True(t, scheduled.DeletionScheduledAt.Equal(again.DeletionScheduledAt), "rescheduling keeps the original time")
+
+	_, err = client.Tenant.Create().SetName("late child").SetParentID(leaf.ID).Save(ctx)
+	assert.ErrorIs(t, err, deletion.ErrPendingDeletion)
+
+	cancelled, err := s.Cancel(ctx, leaf.ID)
+	require.NoError(t, err)
+	assert.True(t, cancelled.DeletionScheduledAt.IsZero())
+
+	_, err = client.Tenant.Create().SetName("child").SetParentID(leaf.ID).Save(ctx)
+	assert.NoError(t, err, "children can be created once the deletion is cancelled")
+
+	_, err = s.Schedule(ctx, "tnntten-missing")
+	assert.True(t, ent.IsNotFound(err))
+
+	protected := client.Tenant.Create().SetName("protected").SetDeletionProtected(true).SaveX(ctx)
+
+	_, err = s.Schedule(ctx, protected.ID)
+	assert.ErrorIs(t, err, deletion.ErrProtected)
+
+	client.Tenant.Create().SetName("protected child").SetDeletionProtected(true).SetParentID(leaf.ID).ExecX(ctx)
+
+	_, err = s.Schedule(ctx, root.ID)
+	assert.ErrorIs(t, err, deletion.ErrProtected, "the descendants are deleted with the tenant")
+}
+
+func TestSchedulerDeleteDueSubtree(t *testing.T) {
+	ctx, client, conn := newTestClient(t)
+
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(0))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(root).SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(parent).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	_, err := s.Schedule(ctx, parent.ID)
+	require.NoError(t, err)
+
+	status, err := deletion.EffectiveStatus(ctx, client, grandchild)
+	require.NoError(t, err)
+	assert.Equal(t, deletion.StatusParentDeleted, status)
+
+	// descendants protected once scheduled keep the whole subtree
+	client.Tenant.UpdateOneID(grandchild.ID).SetDeletionProtected(true).ExecX(ctx)
+
+	deleted, err := s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	client.Tenant.UpdateOneID(grandchild.ID).SetDeletionProtected(false).ExecX(ctx)
+
+	conn.Calls = nil
+
+	deleted, err = s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+
+	assert.Equal(t, []string{"root"}, client.Tenant.Query().Select("name").StringsX(ctx))
+
+	// the deepest are deleted first
+	conn.AssertNumberOfCalls(t, "PublishChange", 3)
+	assert.Equal(t, grandchild.ID, conn.Calls[0].Arguments.Get(1).(events.ChangeMessage).SubjectID)
+	assert.Equal(t, parent.ID, conn.Calls[2].Arguments.Get(1).(events.ChangeMessage).SubjectID)
+}
+
+func TestSchedulerDeleteDue(t *testing.T) {
+	ctx, client, conn := newTestClient(t)
+
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(0))
+	pending := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(time.Hour))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	due := client.Tenant.Create().SetName("due").SetParent(root).SaveX(ctx)
+	notDue := client.Tenant.Create().SetName("not due").SetParent(root).SaveX(ctx)
+
+	_, err := s.Schedule(ctx, due.ID)
+	require.NoError(t, err)
+
+	_, err = pending.Schedule(ctx, notDue.ID)
+	require.NoError(t, err)
+
+	conn.Calls = nil
+
+	deleted, err := s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = client.Tenant.Get(ctx, due.ID)
+	assert.True(t, ent.IsNotFound(err))
+
+	_, err = client.Tenant.Get(ctx, notDue.ID)
+	assert.NoError(t, err)
+
+	conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, due.ID, msg.SubjectID)
+	assert.Equal(t, string(events.DeleteChangeType), msg.EventType)
+
+	deleted, err = s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	// tenants protected once scheduled are kept until the protection is lifted
+	protected := client.Tenant.Create().SetName("protected").SetParent(root).SaveX(ctx)
+
+	_, err = s.Schedule(ctx, protected.ID)
+	require.NoError(t, err)
+
+	client.Tenant.UpdateOneID(protected.ID).SetDeletionProtected(true).ExecX(ctx)
+
+	deleted, err = s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestSchedulerDeleteDueOnceGracePeriodPassed(t *testing.T) {
+	ctx, client, _ := newTestClient(t)
+
+	fake := clock.NewFake(time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(time.Hour), deletion.WithClock(fake))
+
+	leaf := client.Tenant.Create().SetName("leaf").SaveX(ctx)
+
+	scheduled, err := s.Schedule(ctx, leaf.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fake.Now().Add(time.Hour), scheduled.DeletionScheduledAt.UTC())
+
+	fake.Advance(59 * time.Minute)
+
+	deleted, err := s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "the grace period hasn't passed")
+
+	fake.Advance(time.Minute)
+
+	deleted, err = s.DeleteDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = client.Tenant.Get(ctx, leaf.ID)
+	assert.True(t, ent.IsNotFound(err))
+}
+
+func TestSchedulerPurgeChanges(t *testing.T) {
+	ctx, client, _ := newTestClient(t)
+
+	client.Tenant.Use(changeseq.Hook())
+
+	fake := clock.NewFake(time.Now().UTC())
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithChangeRetention(time.Hour), deletion.WithClock(fake))
+
+	client.Tenant.Create().SetName("one").ExecX(ctx)
+	client.Tenant.Create().SetName("two").ExecX(ctx)
+
+	purged, err := s.PurgeChanges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged, "the changes are within the retention")
+
+	fake.Advance(time.Hour + time.Minute)
+
+	purged, err = s.PurgeChanges(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	assert.Equal(t, 0, client.TenantChange.Query().CountX(ctx))
+}
+
+func TestSchedulerPurgeTombstones(t *testing.T) {
+	ctx, client, _ := newTestClient(t)
+
+	client.Tenant.Use(tombstone.Hook())
+
+	fake := clock.NewFake(time.Now().UTC())
+	s := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithTombstoneRetention(time.Hour), deletion.WithClock(fake))
+
+	tnt := client.Tenant.Create().SetName("deleted").SaveX(ctx)
+	client.Tenant.DeleteOne(tnt).ExecX(ctx)
+
+	purged, err := s.PurgeTombstones(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged, "the tombstone is within the retention")
+
+	fake.Advance(time.Hour + time.Minute)
+
+	purged, err = s.PurgeTombstones(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, 0, client.TenantTombstone.Query().CountX(ctx))
+}
@@ -0,0 +1,138 @@
+package deletion
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// Effective statuses of a tenant, taking the deletions pending on its ancestors into account.
+const (
+	// StatusActive is the status of tenants without a pending deletion on them or their ancestors.
+	StatusActive = "active"
+	// StatusPendingDeletion is the status of tenants scheduled for deletion.
+	StatusPendingDeletion = "pending_deletion"
+	// StatusParentDeleted is the status of tenants below an ancestor scheduled for deletion.
+	StatusParentDeleted = "parent_deleted"
+)
+
+// EffectiveStatus returns the effective status of the tenant.
+func EffectiveStatus(ctx context.Context, client *ent.Client, t *ent.Tenant) (string, error) {
+	statuses, err := EffectiveStatuses(ctx, client, []*ent.Tenant{t})
+	if err != nil {
+		return "", err
+	}
+
+	return statuses[t.ID], nil
+}
+
+// EffectiveStatuses returns the effective status of each tenant by id. The ancestors of all
+// tenants are loaded together one level at a time, so the number of queries is bounded by the
+// depth of the hierarchy rather than the number of tenants. Chains broken by a missing ancestor
+// or a cycle end where they break.
+func EffectiveStatuses(ctx context.Context, client *ent.Client, tenants []*ent.Tenant) (map[gidx.PrefixedID]string, error) {
+	statuses, _, err := effectiveStatuses(ctx, client, tenants)
+
+	return statuses, err
+}
+
+// effectiveStatuses returns the effective statuses, and the number of ancestors loaded and of
+// levels they were loaded in.
+func effectiveStatuses(ctx context.Context, client *ent.Client, tenants []*ent.Tenant) (map[gidx.PrefixedID]string, walkStats, error) {
+	var ws walkStats
+
+	statuses := make(map[gidx.PrefixedID]string, len(tenants))
+
+	// the parent of each tenant whose status depends on its ancestors
+	next := make(map[gidx.PrefixedID]gidx.PrefixedID, len(tenants))
+
+	for _, t := range tenants {
+		switch {
+		case !t.DeletionScheduledAt.IsZero():
+			statuses[t.ID] = StatusPendingDeletion
+		case t.ParentTenantID == gidx.NullPrefixedID:
+			statuses[t.ID] = StatusActive
+		default:
+			next[t.ID] = t.ParentTenantID
+		}
+	}
+
+	// loaded ancestors, nil for those which don't exist
+	ancestors := map[gidx.PrefixedID]*ent.Tenant{}
+
+	for len(next) != 0 {
+		missing := map[gidx.PrefixedID]bool{}
+
+		for id, ancestorID := range next {
+			if status, ok := walk(ancestors, ancestorID, missing); ok {
+				statuses[id] = status
+
+				delete(next, id)
+			}
+		}
+
+		if len(missing) == 0 {
+			break
+		}
+
+		ids := make([]gidx.PrefixedID, 0, len(missing))
+
+		for id := range missing {
+			ids = append(ids, id)
+			ancestors[id] = nil
+		}
+
+		loaded, err := client.Tenant.Query().
+			Where(tenant.IDIn(ids...)).
+			Select(tenant.FieldID, tenant.FieldParentTenantID, tenant.FieldDeletionScheduledAt).
+			All(ctx)
+		if err != nil {
+			return nil, ws, err
+		}
+
+		ws.levels++
+		ws.loaded += len(loaded)
+
+		for _, a := range loaded {
+			ancestors[a.ID] = a
+		}
+	}
+
+	return statuses, ws, nil
+}
+
+// walkStats tells how far resolving effective statuses walked up the hierarchy.
+type walkStats struct {
+	loaded int
+	levels int
+}
+
+// walk follows the chain from the ancestor through the loaded ancestors, returning the status it
+// resolves to. When it reaches an ancestor which isn't loaded yet, that ancestor is added to
+// missing and the chain is left unresolved.
+func walk(ancestors map[gidx.PrefixedID]*ent.Tenant, id gidx.PrefixedID, missing map[gidx.PrefixedID]bool) (string, bool) {
+	for steps := 0; steps <= len(ancestors); steps++ {
+		a, ok := ancestors[id]
+
+		switch {
+		case !ok:
+			missing[id] = true
+
+			return "", false
+		case a == nil:
+			return StatusActive, true
+		case !a.DeletionScheduledAt.IsZero():
+			return StatusParentDeleted, true
+		case a.ParentTenantID == gidx.NullPrefixedID:
+			return StatusActive, true
+		}
+
+		id = a.ParentTenantID
+	}
+
+	// the chain loops without reaching a root
+	return StatusActive, true
+}
@@ -0,0 +1,75 @@
+package deletion_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestEffectiveStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	grandparent := client.Tenant.Create().SetName("grandparent").SetParent(root).SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(grandparent).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(parent).SaveX(ctx)
+	sibling := client.Tenant.Create().SetName("sibling").SetParent(root).SaveX(ctx)
+	nephew := client.Tenant.Create().SetName("nephew").SetParent(sibling).SaveX(ctx)
+
+	grandparent, err := deletion.NewScheduler(client, zap.NewNop().Sugar(), deletion.WithGracePeriod(time.Hour)).Schedule(ctx, grandparent.ID)
+	require.NoError(t, err)
+
+	statuses, err := deletion.EffectiveStatuses(ctx, client, []*ent.Tenant{root, grandparent, parent, grandchild, sibling, nephew})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"root":        deletion.StatusActive,
+		"grandparent": deletion.StatusPendingDeletion,
+		"parent":      deletion.StatusParentDeleted,
+		"grandchild":  deletion.StatusParentDeleted,
+		"sibling":     deletion.StatusActive,
+		"nephew":      deletion.StatusActive,
+	}, map[string]string{
+		"root":        statuses[root.ID],
+		"grandparent": statuses[grandparent.ID],
+		"parent":      statuses[parent.ID],
+		"grandchild":  statuses[grandchild.ID],
+		"sibling":     statuses[sibling.ID],
+		"nephew":      statuses[nephew.ID],
+	})
+
+	status, err := deletion.EffectiveStatus(ctx, client, grandchild)
+	require.NoError(t, err)
+	assert.Equal(t, deletion.StatusParentDeleted, status)
+
+	// creating under a descendant of the pending tenant is rejected, the same as under the tenant
+	client.Tenant.Use(deletion.Hook())
+
+	_, err = client.Tenant.Create().SetName("great grandchild").SetParent(grandchild).Save(ctx)
+
+	var verr *validation.Error
+
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, validation.CodeParentDeleted, verr.Code)
+	assert.ErrorIs(t, err, deletion.ErrPendingDeletion)
+
+	_, err = client.Tenant.Create().SetName("cousin").SetParent(nephew).Save(ctx)
+	assert.NoError(t, err)
+
+	client.Tenant.UpdateOne(grandparent).ClearDeletionScheduledAt().ExecX(ctx)
+
+	_, err = client.Tenant.Create().SetName("great grandchild").SetParent(grandchild).Save(ctx)
+	assert.NoError(t, err, "children can be created once the deletion is cancelled")
+}
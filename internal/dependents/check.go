@@ -0,0 +1,223 @@
+package dependents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+// DefaultTimeout is the default time the checkers of a deletion have to respond.
+const DefaultTimeout = 5 * time.Second
+
+// CodeHasDependents is the code of the errors of tenants with dependents.
+const CodeHasDependents = "has_dependents"
+
+var (
+	// ErrHasDependents is returned when resources of other services still depend on a deleted tenant.
+	ErrHasDependents = apierrors.New(apierrors.ErrConflict, "resources of other services depend on the tenant")
+
+	// ErrCheckFailed is returned when the dependents of a tenant couldn't be checked and the check
+	// fails closed.
+	ErrCheckFailed = errors.New("dependents of the tenant couldn't be checked")
+)
+
+// Error lists the types of the resources depending on a tenant.
+type Error struct {
+	ID    gidx.PrefixedID
+	Types []string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", ErrHasDependents, strings.Join(e.Types, ", "))
+}
+
+// Is matches ErrHasDependents and its class.
+func (e *Error) Is(target error) bool {
+	return target == ErrHasDependents || errors.Is(ErrHasDependents, target)
+}
+
+// Checker reports the types of the resources depending on a tenant, none when it has no dependents.
+type Checker interface {
+	Dependents(ctx context.Context, urn string) ([]string, error)
+}
+
+// CheckerFunc is a function implementing Checker.
+type CheckerFunc func(ctx context.Context, urn string) ([]string, error)
+
+// Dependents implements Checker.
+func (f CheckerFunc) Dependents(ctx context.Context, urn string) ([]string, error) {
+	return f(ctx, urn)
+}
+
+// Option configures a Check.
+type Option func(*Check)
+
+// WithTimeout sets the time the checkers have to respond.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Check) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithFailOpen lets deletions through when a checker fails or doesn't respond in time, they are
+// rejected with ErrCheckFailed by default.
+func WithFailOpen(failOpen bool) Option {
+	return func(c *Check) {
+		c.failOpen = failOpen
+	}
+}
+
+// WithLogger sets the logger reporting the checkers which failed when failing open.
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(c *Check) {
+		c.logger = logger
+	}
+}
+
+// Check runs the checkers of other services before tenants are deleted.
+type Check struct {
+	checkers []Checker
+	timeout  time.Duration
+	failOpen bool
+	logger   *zap.SugaredLogger
+}
+
+// New creates a check running the checkers in parallel.
+func New(checkers []Checker, opts ...Option) *Check {
+	c := &Check{
+		checkers: checkers,
+		timeout:  DefaultTimeout,
+		logger:   zap.NewNop().Sugar(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Run asks every checker for the dependents of the tenant and returns an *Error listing their
+// types when any are reported.
+func (c *Check) Run(ctx context.Context, id gidx.PrefixedID) error {
+	if len(c.checkers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	urn := urnx.NewTenantURN(id)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		types = map[string]bool{}
+		errs  []error
+	)
+
+	for _, checker := range c.checkers {
+		wg.Add(1)
+
+		go func(checker Checker) {
+			defer wg.Done()
+
+			found, err := checker.Dependents(ctx, urn)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+
+				return
+			}
+
+			for _, t := range found {
+				types[t] = true
+			}
+		}(checker)
+	}
+
+	wg.Wait()
+
+	if len(types) != 0 {
+		err := &Error{ID: id}
+
+		for t := range types {
+			err.Types = append(err.Types, t)
+		}
+
+		sort.Strings(err.Types)
+
+		return err
+	}
+
+	if len(errs) != 0 {
+		if !c.failOpen {
+			return fmt.Errorf("%w: %w", ErrCheckFailed, errors.Join(errs...))
+		}
+
+		c.logger.Warnw("deleting tenant without checking all its dependents", "tenant_id", id, "error", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+type forceCtxKey struct{}
+
+// Force returns a context in which deletions skip the check.
+func Force(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceCtxKey{}, true)
+}
+
+func forced(ctx context.Context) bool {
+	force, _ := ctx.Value(forceCtxKey{}).(bool)
+
+	return force
+}
+
+// Hook returns an ent hook running the check before each tenant is deleted, unless the context
+// is forced. Deletions made by the deletion scheduler are checked as well, a tenant with
+// dependents stays scheduled and its deletion is retried.
+func (c *Check) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if forced(ctx) {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, id := range ids {
+					if err := c.Run(ctx, id); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpDelete|ent.OpDeleteOne,
+	)
+}
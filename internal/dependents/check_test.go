@@ -0,0 +1,73 @@
+package dependents_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/dependents"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestCheckRun(t *testing.T) {
+	ctx := context.Background()
+
+	none := dependents.New(nil)
+	assert.NoError(t, none.Run(ctx, "tnntten-one"))
+
+	check := dependents.New([]dependents.Checker{
+		&dependents.Stub{Types: []string{"load-balancer", "instance"}},
+		&dependents.Stub{Types: []string{"instance"}},
+		&dependents.Stub{Err: errors.New("unreachable")},
+	})
+
+	// reported dependents win over failed checkers
+	err := check.Run(ctx, "tnntten-one")
+
+	var derr *dependents.Error
+
+	require.ErrorAs(t, err, &derr)
+	assert.Equal(t, []string{"instance", "load-balancer"}, derr.Types)
+	assert.ErrorIs(t, err, dependents.ErrHasDependents)
+	assert.ErrorIs(t, err, apierrors.ErrConflict)
+
+	failing := []dependents.Checker{&dependents.Stub{}, &dependents.Stub{Err: errors.New("unreachable")}}
+
+	err = dependents.New(failing).Run(ctx, "tnntten-one")
+	assert.ErrorIs(t, err, dependents.ErrCheckFailed)
+	assert.ErrorContains(t, err, "unreachable")
+
+	assert.NoError(t, dependents.New(failing, dependents.WithFailOpen(true)).Run(ctx, "tnntten-one"))
+}
+
+func TestReporter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("tenant_urn") {
+		case "urn:infratographer:tenant:tnntten-owner":
+			_, _ = w.Write([]byte(`{"resource_types":["load-balancer"]}`))
+		case "urn:infratographer:tenant:tnntten-free":
+			_, _ = w.Write([]byte(`{"resource_types":[]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	reporter := dependents.NewReporter(srv.URL+"/dependents?source=tenant-api", nil)
+
+	types, err := reporter.Dependents(context.Background(), "urn:infratographer:tenant:tnntten-owner")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"load-balancer"}, types)
+
+	types, err = reporter.Dependents(context.Background(), "urn:infratographer:tenant:tnntten-free")
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	_, err = reporter.Dependents(context.Background(), "urn:infratographer:tenant:tnntten-other")
+	assert.ErrorContains(t, err, "responded with status 500")
+}
@@ -0,0 +1,72 @@
+package dependents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Reporter is a checker asking a resource reporter endpoint of another service for the dependents
+// of a tenant. The endpoint is sent a GET request with the tenant URN in the tenant_urn query
+// parameter, and responds with the types of the resources depending on it:
+//
+//	{"resource_types": ["load-balancer"]}
+type Reporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewReporter returns a checker for the resource reporter endpoint, using the http client to call
+// it or the default client when nil.
+func NewReporter(endpoint string, client *http.Client) *Reporter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Reporter{
+		endpoint: endpoint,
+		client:   client,
+	}
+}
+
+type reporterResponse struct {
+	ResourceTypes []string `json:"resource_types"`
+}
+
+// Dependents implements Checker.
+func (r *Reporter) Dependents(ctx context.Context, urn string) ([]string, error) {
+	u, err := url.Parse(r.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	query.Set("tenant_urn", urn)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("resource reporter %s responded with status %d", r.endpoint, resp.StatusCode)
+	}
+
+	var body reporterResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("resource reporter %s: %w", r.endpoint, err)
+	}
+
+	return body.ResourceTypes, nil
+}
@@ -0,0 +1,52 @@
+package dependents
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stub is a checker reporting fixed dependents, meant for tests.
+type Stub struct {
+	// Types are the types of the resources reported for every tenant.
+	Types []string
+	// Delay is the time the checker takes to respond, it gives up when the context is done first.
+	Delay time.Duration
+	// Err is returned instead of the types when set.
+	Err error
+
+	mu   sync.Mutex
+	urns []string
+}
+
+// Dependents implements Checker.
+func (s *Stub) Dependents(ctx context.Context, urn string) ([]string, error) {
+	s.mu.Lock()
+	s.urns = append(s.urns, urn)
+	s.mu.Unlock()
+
+	if s.Delay > 0 {
+		timer := time.NewTimer(s.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	return s.Types, nil
+}
+
+// URNs returns the URNs of the tenants the checker was asked about.
+func (s *Stub) URNs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.urns...)
+}
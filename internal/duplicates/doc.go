@@ -0,0 +1,20 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package duplicates recognizes the creates of tenants retried by clients which don't send an
+// idempotency key. A create with the parent, name and actor of a tenant created within the
+// duplicate window is a duplicate, the existing tenant is returned rather than creating another.
+// Creates are recorded in the tenant_create_guards table, keyed by a hash of the three, whose
+// primary key makes concurrent duplicates fail to record so only one of them is committed.
+package duplicates
@@ -0,0 +1,150 @@
+package duplicates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+)
+
+// ErrConcurrent is returned when recording a create whose duplicate was recorded concurrently,
+// the transaction of the create must be rolled back.
+var ErrConcurrent = errors.New("a duplicate create was recorded concurrently")
+
+// Option configures the guard.
+type Option func(*Guard)
+
+// WithClock sets the clock the creates are timed with.
+func WithClock(c clock.Clock) Option {
+	return func(g *Guard) {
+		g.clock = c
+	}
+}
+
+// WithLogger sets the logger of the failed purges.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(g *Guard) {
+		g.logger = l
+	}
+}
+
+// Guard recognizes duplicate creates of tenants.
+type Guard struct {
+	window time.Duration
+	clock  clock.Clock
+	logger *zap.SugaredLogger
+}
+
+// New returns a guard treating the creates within the window of another with the same parent,
+// name and actor as duplicates.
+func New(window time.Duration, opts ...Option) *Guard {
+	g := &Guard{
+		window: window,
+		clock:  clock.Real{},
+		logger: zap.NewNop().Sugar(),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Window returns the time after a create during which its duplicates are recognized.
+func (g *Guard) Window() time.Duration {
+	return g.window
+}
+
+// Key returns the key of a create of a tenant with the name under the parent, null for root
+// tenants, by the actor.
+func Key(parentID gidx.PrefixedID, name, actor string) string {
+	h := sha256.New()
+
+	for _, part := range []string{parentID.String(), name, actor} {
+		// the parts are separated by a byte none of them has
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Find returns the tenant created with the key within the window, nil when there is none. The
+// record of an older create, or of a deleted tenant, is removed so the create may be recorded.
+// It is meant to run in the transaction of the create.
+func (g *Guard) Find(ctx context.Context, client *ent.Client, key string) (*ent.Tenant, error) {
+	guard, err := client.TenantCreateGuard.Get(ctx, key)
+
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if guard.CreatedAt.After(g.clock.Now().Add(-g.window)) {
+		t, err := client.Tenant.Get(ctx, guard.TenantID)
+
+		switch {
+		case err == nil:
+			return t, nil
+		case !ent.IsNotFound(err):
+			return nil, err
+		}
+	}
+
+	if err := client.TenantCreateGuard.DeleteOneID(key).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Record records the create of the tenant with the key, in the transaction of the create. It
+// returns ErrConcurrent when a duplicate create was recorded first.
+func (g *Guard) Record(ctx context.Context, client *ent.Client, key string, tenantID gidx.PrefixedID) error {
+	err := client.TenantCreateGuard.Create().
+		SetID(key).
+		SetTenantID(tenantID).
+		SetCreatedAt(g.clock.Now().UTC()).
+		Exec(ctx)
+	if ent.IsConstraintError(err) {
+		return errors.Join(ErrConcurrent, err)
+	}
+
+	return err
+}
+
+// Purge removes the records of the creates past the window, returning how many were removed.
+func (g *Guard) Purge(ctx context.Context, client *ent.Client) (int, error) {
+	return client.TenantCreateGuard.Delete().
+		Where(tenantcreateguard.CreatedAtLTE(g.clock.Now().UTC().Add(-g.window))).
+		Exec(ctx)
+}
+
+// Run purges the records of the creates past the window, every window until the context is done.
+func (g *Guard) Run(ctx context.Context, client *ent.Client) {
+	ticker := time.NewTicker(g.window)
+	defer ticker.Stop()
+
+	for {
+		if _, err := g.Purge(ctx, client); err != nil && ctx.Err() == nil {
+			g.logger.Errorw("failed to purge the records of past tenant creates", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
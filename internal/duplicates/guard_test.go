@@ -0,0 +1,94 @@
+package duplicates_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/duplicates"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+)
+
+func TestKey(t *testing.T) {
+	key := duplicates.Key("tnntten-parent", "acme", "idntusr-alice")
+
+	assert.Equal(t, key, duplicates.Key("tnntten-parent", "acme", "idntusr-alice"))
+	assert.NotEqual(t, key, duplicates.Key("tnntten-other", "acme", "idntusr-alice"))
+	assert.NotEqual(t, key, duplicates.Key("tnntten-parent", "acme", "idntusr-bob"))
+	assert.NotEqual(t, key, duplicates.Key(gidx.NullPrefixedID, "acme", "idntusr-alice"))
+	assert.NotEqual(t, duplicates.Key("", "ab", "c"), duplicates.Key("", "a", "bc"), "the parts are separated")
+}
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	clk := clock.NewFake(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	guard := duplicates.New(10*time.Second, duplicates.WithClock(clk))
+
+	key := duplicates.Key(gidx.NullPrefixedID, "acme", "idntusr-alice")
+
+	found, err := guard.Find(ctx, client, key)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	acme := client.Tenant.Create().SetName("acme").SaveX(ctx)
+	require.NoError(t, guard.Record(ctx, client, key, acme.ID))
+
+	other := client.Tenant.Create().SetName("acme").SaveX(ctx)
+	assert.ErrorIs(t, guard.Record(ctx, client, key, other.ID), duplicates.ErrConcurrent, "a duplicate can't be recorded")
+
+	clk.Advance(9 * time.Second)
+
+	found, err = guard.Find(ctx, client, key)
+	require.NoError(t, err)
+	require.NotNil(t, found, "duplicates are found within the window")
+	assert.Equal(t, acme.ID, found.ID)
+
+	clk.Advance(time.Second)
+
+	found, err = guard.Find(ctx, client, key)
+	require.NoError(t, err)
+	assert.Nil(t, found, "creates past the window aren't duplicated")
+	require.NoError(t, guard.Record(ctx, client, key, other.ID), "the record of the past create was removed")
+
+	client.Tenant.DeleteOne(other).ExecX(ctx)
+
+	found, err = guard.Find(ctx, client, key)
+	require.NoError(t, err)
+	assert.Nil(t, found, "creates of deleted tenants aren't duplicated")
+	assert.Zero(t, client.TenantCreateGuard.Query().CountX(ctx))
+}
+
+func TestGuardPurge(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	clk := clock.NewFake(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	guard := duplicates.New(10*time.Second, duplicates.WithClock(clk))
+
+	old := client.Tenant.Create().SetName("old").SaveX(ctx)
+	require.NoError(t, guard.Record(ctx, client, duplicates.Key(gidx.NullPrefixedID, "old", ""), old.ID))
+
+	clk.Advance(5 * time.Second)
+
+	recent := client.Tenant.Create().SetName("recent").SaveX(ctx)
+	require.NoError(t, guard.Record(ctx, client, duplicates.Key(gidx.NullPrefixedID, "recent", ""), recent.ID))
+
+	clk.Advance(5 * time.Second)
+
+	purged, err := guard.Purge(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, recent.ID, client.TenantCreateGuard.Query().OnlyX(ctx).TenantID)
+}
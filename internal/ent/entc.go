@@ -17,12 +17,14 @@
 package main
 
 import (
+	"fmt"
 	"log"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent/entc"
 	"entgo.io/ent/entc/gen"
 	"entgo.io/ent/schema/field"
+	"github.com/vektah/gqlparser/v2/ast"
 	"go.infratographer.com/x/entx"
 )
 
@@ -36,7 +38,23 @@ func main() {
 		log.Fatalf("creating entx extension: %v", err)
 	}
 
+	// the field collection is replaced, leaving archived children out, see includeArchivedHook
+	collectionTemplate := gen.MustParse(gen.NewTemplate("gql_collection").
+		Funcs(entgql.TemplateFuncs).
+		ParseFiles("./internal/ent/gqltemplates/collection.tmpl"))
+
+	gqlTemplates := make([]*gen.Template, 0, len(entgql.AllTemplates))
+
+	for _, tmpl := range entgql.AllTemplates {
+		if tmpl == entgql.CollectionTemplate {
+			tmpl = collectionTemplate
+		}
+
+		gqlTemplates = append(gqlTemplates, tmpl)
+	}
+
 	gqlExt, err := entgql.NewExtension(
+		entgql.WithTemplates(gqlTemplates...),
 		// Tell Ent to generate a GraphQL schema for
 		// the Ent schema in a file named ent.graphql.
 		entgql.WithSchemaGenerator(),
@@ -44,6 +62,7 @@ func main() {
 		entgql.WithConfigPath("gqlgen.yml"),
 		entgql.WithWhereInputs(true),
 		entgql.WithSchemaHook(xExt.GQLSchemaHooks()...),
+		entgql.WithSchemaHook(includeArchivedHook),
 	)
 	if err != nil {
 		log.Fatalf("creating entgql extension: %v", err)
@@ -55,7 +74,7 @@ func main() {
 			gqlExt,
 		),
 		entc.TemplateDir("./internal/ent/templates"),
-		entc.FeatureNames("intercept"),
+		entc.FeatureNames("intercept", "sql/execquery"),
 		entc.Dependency(
 			entc.DependencyName("EventsPublisher"),
 			entc.DependencyTypeInfo(&field.TypeInfo{
@@ -74,3 +93,22 @@ func main() {
 		log.Fatalf("running ent codegen: %v", err)
 	}
 }
+
+// includeArchivedHook adds the includeArchived argument to the children connection of tenants,
+// archived children are left out unless it is true, by the collection template in
+// internal/ent/gqltemplates when eager loaded and by the children resolver otherwise.
+func includeArchivedHook(_ *gen.Graph, s *ast.Schema) error {
+	children := s.Types["Tenant"].Fields.ForName("children")
+	if children == nil {
+		return fmt.Errorf("tenant children field not found")
+	}
+
+	children.Arguments = append(children.Arguments, &ast.ArgumentDefinition{
+		Name:         "includeArchived",
+		Description:  "Whether archived children are listed as well.",
+		Type:         ast.NonNullNamedType("Boolean", nil),
+		DefaultValue: &ast.Value{Kind: ast.BooleanValue, Raw: "false"},
+	})
+
+	return nil
+}
@@ -28,9 +28,19 @@ import (
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
 	"go.infratographer.com/x/events"
 	"go.infratographer.com/x/gidx"
+
+	stdsql "database/sql"
 )
 
 // Client is the client that holds all ent builders.
@@ -38,8 +48,24 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// SchemaCompatibility is the client for interacting with the SchemaCompatibility builders.
+	SchemaCompatibility *SchemaCompatibilityClient
+	// ServiceAccount is the client for interacting with the ServiceAccount builders.
+	ServiceAccount *ServiceAccountClient
 	// Tenant is the client for interacting with the Tenant builders.
 	Tenant *TenantClient
+	// TenantAudit is the client for interacting with the TenantAudit builders.
+	TenantAudit *TenantAuditClient
+	// TenantChange is the client for interacting with the TenantChange builders.
+	TenantChange *TenantChangeClient
+	// TenantCreateGuard is the client for interacting with the TenantCreateGuard builders.
+	TenantCreateGuard *TenantCreateGuardClient
+	// TenantParentHistory is the client for interacting with the TenantParentHistory builders.
+	TenantParentHistory *TenantParentHistoryClient
+	// TenantTombstone is the client for interacting with the TenantTombstone builders.
+	TenantTombstone *TenantTombstoneClient
+	// TenantUsage is the client for interacting with the TenantUsage builders.
+	TenantUsage *TenantUsageClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -53,7 +79,15 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.SchemaCompatibility = NewSchemaCompatibilityClient(c.config)
+	c.ServiceAccount = NewServiceAccountClient(c.config)
 	c.Tenant = NewTenantClient(c.config)
+	c.TenantAudit = NewTenantAuditClient(c.config)
+	c.TenantChange = NewTenantChangeClient(c.config)
+	c.TenantCreateGuard = NewTenantCreateGuardClient(c.config)
+	c.TenantParentHistory = NewTenantParentHistoryClient(c.config)
+	c.TenantTombstone = NewTenantTombstoneClient(c.config)
+	c.TenantUsage = NewTenantUsageClient(c.config)
 }
 
 type (
@@ -142,9 +176,17 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		Tenant: NewTenantClient(cfg),
+		ctx:                 ctx,
+		config:              cfg,
+		SchemaCompatibility: NewSchemaCompatibilityClient(cfg),
+		ServiceAccount:      NewServiceAccountClient(cfg),
+		Tenant:              NewTenantClient(cfg),
+		TenantAudit:         NewTenantAuditClient(cfg),
+		TenantChange:        NewTenantChangeClient(cfg),
+		TenantCreateGuard:   NewTenantCreateGuardClient(cfg),
+		TenantParentHistory: NewTenantParentHistoryClient(cfg),
+		TenantTombstone:     NewTenantTombstoneClient(cfg),
+		TenantUsage:         NewTenantUsageClient(cfg),
 	}, nil
 }
 
@@ -162,16 +204,24 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		Tenant: NewTenantClient(cfg),
+		ctx:                 ctx,
+		config:              cfg,
+		SchemaCompatibility: NewSchemaCompatibilityClient(cfg),
+		ServiceAccount:      NewServiceAccountClient(cfg),
+		Tenant:              NewTenantClient(cfg),
+		TenantAudit:         NewTenantAuditClient(cfg),
+		TenantChange:        NewTenantChangeClient(cfg),
+		TenantCreateGuard:   NewTenantCreateGuardClient(cfg),
+		TenantParentHistory: NewTenantParentHistoryClient(cfg),
+		TenantTombstone:     NewTenantTombstoneClient(cfg),
+		TenantUsage:         NewTenantUsageClient(cfg),
 	}, nil
 }
 
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		Tenant.
+//		SchemaCompatibility.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -193,25 +243,305 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
-	c.Tenant.Use(hooks...)
+	for _, n := range []interface{ Use(...Hook) }{
+		c.SchemaCompatibility, c.ServiceAccount, c.Tenant, c.TenantAudit,
+		c.TenantChange, c.TenantCreateGuard, c.TenantParentHistory, c.TenantTombstone,
+		c.TenantUsage,
+	} {
+		n.Use(hooks...)
+	}
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
-	c.Tenant.Intercept(interceptors...)
+	for _, n := range []interface{ Intercept(...Interceptor) }{
+		c.SchemaCompatibility, c.ServiceAccount, c.Tenant, c.TenantAudit,
+		c.TenantChange, c.TenantCreateGuard, c.TenantParentHistory, c.TenantTombstone,
+		c.TenantUsage,
+	} {
+		n.Intercept(interceptors...)
+	}
 }
 
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *SchemaCompatibilityMutation:
+		return c.SchemaCompatibility.mutate(ctx, m)
+	case *ServiceAccountMutation:
+		return c.ServiceAccount.mutate(ctx, m)
 	case *TenantMutation:
 		return c.Tenant.mutate(ctx, m)
+	case *TenantAuditMutation:
+		return c.TenantAudit.mutate(ctx, m)
+	case *TenantChangeMutation:
+		return c.TenantChange.mutate(ctx, m)
+	case *TenantCreateGuardMutation:
+		return c.TenantCreateGuard.mutate(ctx, m)
+	case *TenantParentHistoryMutation:
+		return c.TenantParentHistory.mutate(ctx, m)
+	case *TenantTombstoneMutation:
+		return c.TenantTombstone.mutate(ctx, m)
+	case *TenantUsageMutation:
+		return c.TenantUsage.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("generated: unknown mutation type %T", m)
 	}
 }
 
+// SchemaCompatibilityClient is a client for the SchemaCompatibility schema.
+type SchemaCompatibilityClient struct {
+	config
+}
+
+// NewSchemaCompatibilityClient returns a client for the SchemaCompatibility from the given config.
+func NewSchemaCompatibilityClient(c config) *SchemaCompatibilityClient {
+	return &SchemaCompatibilityClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `schemacompatibility.Hooks(f(g(h())))`.
+func (c *SchemaCompatibilityClient) Use(hooks ...Hook) {
+	c.hooks.SchemaCompatibility = append(c.hooks.SchemaCompatibility, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `schemacompatibility.Intercept(f(g(h())))`.
+func (c *SchemaCompatibilityClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SchemaCompatibility = append(c.inters.SchemaCompatibility, interceptors...)
+}
+
+// Create returns a builder for creating a SchemaCompatibility entity.
+func (c *SchemaCompatibilityClient) Create() *SchemaCompatibilityCreate {
+	mutation := newSchemaCompatibilityMutation(c.config, OpCreate)
+	return &SchemaCompatibilityCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SchemaCompatibility entities.
+func (c *SchemaCompatibilityClient) CreateBulk(builders ...*SchemaCompatibilityCreate) *SchemaCompatibilityCreateBulk {
+	return &SchemaCompatibilityCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SchemaCompatibility.
+func (c *SchemaCompatibilityClient) Update() *SchemaCompatibilityUpdate {
+	mutation := newSchemaCompatibilityMutation(c.config, OpUpdate)
+	return &SchemaCompatibilityUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SchemaCompatibilityClient) UpdateOne(sc *SchemaCompatibility) *SchemaCompatibilityUpdateOne {
+	mutation := newSchemaCompatibilityMutation(c.config, OpUpdateOne, withSchemaCompatibility(sc))
+	return &SchemaCompatibilityUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SchemaCompatibilityClient) UpdateOneID(id int64) *SchemaCompatibilityUpdateOne {
+	mutation := newSchemaCompatibilityMutation(c.config, OpUpdateOne, withSchemaCompatibilityID(id))
+	return &SchemaCompatibilityUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SchemaCompatibility.
+func (c *SchemaCompatibilityClient) Delete() *SchemaCompatibilityDelete {
+	mutation := newSchemaCompatibilityMutation(c.config, OpDelete)
+	return &SchemaCompatibilityDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SchemaCompatibilityClient) DeleteOne(sc *SchemaCompatibility) *SchemaCompatibilityDeleteOne {
+	return c.DeleteOneID(sc.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SchemaCompatibilityClient) DeleteOneID(id int64) *SchemaCompatibilityDeleteOne {
+	builder := c.Delete().Where(schemacompatibility.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SchemaCompatibilityDeleteOne{builder}
+}
+
+// Query returns a query builder for SchemaCompatibility.
+func (c *SchemaCompatibilityClient) Query() *SchemaCompatibilityQuery {
+	return &SchemaCompatibilityQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSchemaCompatibility},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SchemaCompatibility entity by its id.
+func (c *SchemaCompatibilityClient) Get(ctx context.Context, id int64) (*SchemaCompatibility, error) {
+	return c.Query().Where(schemacompatibility.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SchemaCompatibilityClient) GetX(ctx context.Context, id int64) *SchemaCompatibility {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SchemaCompatibilityClient) Hooks() []Hook {
+	return c.hooks.SchemaCompatibility
+}
+
+// Interceptors returns the client interceptors.
+func (c *SchemaCompatibilityClient) Interceptors() []Interceptor {
+	return c.inters.SchemaCompatibility
+}
+
+func (c *SchemaCompatibilityClient) mutate(ctx context.Context, m *SchemaCompatibilityMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SchemaCompatibilityCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SchemaCompatibilityUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SchemaCompatibilityUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SchemaCompatibilityDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown SchemaCompatibility mutation op: %q", m.Op())
+	}
+}
+
+// ServiceAccountClient is a client for the ServiceAccount schema.
+type ServiceAccountClient struct {
+	config
+}
+
+// NewServiceAccountClient returns a client for the ServiceAccount from the given config.
+func NewServiceAccountClient(c config) *ServiceAccountClient {
+	return &ServiceAccountClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `serviceaccount.Hooks(f(g(h())))`.
+func (c *ServiceAccountClient) Use(hooks ...Hook) {
+	c.hooks.ServiceAccount = append(c.hooks.ServiceAccount, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `serviceaccount.Intercept(f(g(h())))`.
+func (c *ServiceAccountClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ServiceAccount = append(c.inters.ServiceAccount, interceptors...)
+}
+
+// Create returns a builder for creating a ServiceAccount entity.
+func (c *ServiceAccountClient) Create() *ServiceAccountCreate {
+	mutation := newServiceAccountMutation(c.config, OpCreate)
+	return &ServiceAccountCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ServiceAccount entities.
+func (c *ServiceAccountClient) CreateBulk(builders ...*ServiceAccountCreate) *ServiceAccountCreateBulk {
+	return &ServiceAccountCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ServiceAccount.
+func (c *ServiceAccountClient) Update() *ServiceAccountUpdate {
+	mutation := newServiceAccountMutation(c.config, OpUpdate)
+	return &ServiceAccountUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ServiceAccountClient) UpdateOne(sa *ServiceAccount) *ServiceAccountUpdateOne {
+	mutation := newServiceAccountMutation(c.config, OpUpdateOne, withServiceAccount(sa))
+	return &ServiceAccountUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ServiceAccountClient) UpdateOneID(id gidx.PrefixedID) *ServiceAccountUpdateOne {
+	mutation := newServiceAccountMutation(c.config, OpUpdateOne, withServiceAccountID(id))
+	return &ServiceAccountUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ServiceAccount.
+func (c *ServiceAccountClient) Delete() *ServiceAccountDelete {
+	mutation := newServiceAccountMutation(c.config, OpDelete)
+	return &ServiceAccountDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ServiceAccountClient) DeleteOne(sa *ServiceAccount) *ServiceAccountDeleteOne {
+	return c.DeleteOneID(sa.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ServiceAccountClient) DeleteOneID(id gidx.PrefixedID) *ServiceAccountDeleteOne {
+	builder := c.Delete().Where(serviceaccount.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ServiceAccountDeleteOne{builder}
+}
+
+// Query returns a query builder for ServiceAccount.
+func (c *ServiceAccountClient) Query() *ServiceAccountQuery {
+	return &ServiceAccountQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeServiceAccount},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ServiceAccount entity by its id.
+func (c *ServiceAccountClient) Get(ctx context.Context, id gidx.PrefixedID) (*ServiceAccount, error) {
+	return c.Query().Where(serviceaccount.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ServiceAccountClient) GetX(ctx context.Context, id gidx.PrefixedID) *ServiceAccount {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryTenant queries the tenant edge of a ServiceAccount.
+func (c *ServiceAccountClient) QueryTenant(sa *ServiceAccount) *TenantQuery {
+	query := (&TenantClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := sa.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(serviceaccount.Table, serviceaccount.FieldID, id),
+			sqlgraph.To(tenant.Table, tenant.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, serviceaccount.TenantTable, serviceaccount.TenantColumn),
+		)
+		fromV = sqlgraph.Neighbors(sa.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *ServiceAccountClient) Hooks() []Hook {
+	return c.hooks.ServiceAccount
+}
+
+// Interceptors returns the client interceptors.
+func (c *ServiceAccountClient) Interceptors() []Interceptor {
+	return c.inters.ServiceAccount
+}
+
+func (c *ServiceAccountClient) mutate(ctx context.Context, m *ServiceAccountMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ServiceAccountCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ServiceAccountUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ServiceAccountUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ServiceAccountDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown ServiceAccount mutation op: %q", m.Op())
+	}
+}
+
 // TenantClient is a client for the Tenant schema.
 type TenantClient struct {
 	config
@@ -337,6 +667,22 @@ func (c *TenantClient) QueryChildren(t *Tenant) *TenantQuery {
 	return query
 }
 
+// QueryServiceAccounts queries the service_accounts edge of a Tenant.
+func (c *TenantClient) QueryServiceAccounts(t *Tenant) *ServiceAccountQuery {
+	query := (&ServiceAccountClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := t.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(tenant.Table, tenant.FieldID, id),
+			sqlgraph.To(serviceaccount.Table, serviceaccount.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, tenant.ServiceAccountsTable, tenant.ServiceAccountsColumn),
+		)
+		fromV = sqlgraph.Neighbors(t.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *TenantClient) Hooks() []Hook {
 	return c.hooks.Tenant
@@ -362,12 +708,747 @@ func (c *TenantClient) mutate(ctx context.Context, m *TenantMutation) (Value, er
 	}
 }
 
-// hooks and interceptors per client, for fast access.
-type (
-	hooks struct {
-		Tenant []ent.Hook
+// TenantAuditClient is a client for the TenantAudit schema.
+type TenantAuditClient struct {
+	config
+}
+
+// NewTenantAuditClient returns a client for the TenantAudit from the given config.
+func NewTenantAuditClient(c config) *TenantAuditClient {
+	return &TenantAuditClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantaudit.Hooks(f(g(h())))`.
+func (c *TenantAuditClient) Use(hooks ...Hook) {
+	c.hooks.TenantAudit = append(c.hooks.TenantAudit, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantaudit.Intercept(f(g(h())))`.
+func (c *TenantAuditClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantAudit = append(c.inters.TenantAudit, interceptors...)
+}
+
+// Create returns a builder for creating a TenantAudit entity.
+func (c *TenantAuditClient) Create() *TenantAuditCreate {
+	mutation := newTenantAuditMutation(c.config, OpCreate)
+	return &TenantAuditCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantAudit entities.
+func (c *TenantAuditClient) CreateBulk(builders ...*TenantAuditCreate) *TenantAuditCreateBulk {
+	return &TenantAuditCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantAudit.
+func (c *TenantAuditClient) Update() *TenantAuditUpdate {
+	mutation := newTenantAuditMutation(c.config, OpUpdate)
+	return &TenantAuditUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantAuditClient) UpdateOne(ta *TenantAudit) *TenantAuditUpdateOne {
+	mutation := newTenantAuditMutation(c.config, OpUpdateOne, withTenantAudit(ta))
+	return &TenantAuditUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantAuditClient) UpdateOneID(id gidx.PrefixedID) *TenantAuditUpdateOne {
+	mutation := newTenantAuditMutation(c.config, OpUpdateOne, withTenantAuditID(id))
+	return &TenantAuditUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantAudit.
+func (c *TenantAuditClient) Delete() *TenantAuditDelete {
+	mutation := newTenantAuditMutation(c.config, OpDelete)
+	return &TenantAuditDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantAuditClient) DeleteOne(ta *TenantAudit) *TenantAuditDeleteOne {
+	return c.DeleteOneID(ta.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantAuditClient) DeleteOneID(id gidx.PrefixedID) *TenantAuditDeleteOne {
+	builder := c.Delete().Where(tenantaudit.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantAuditDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantAudit.
+func (c *TenantAuditClient) Query() *TenantAuditQuery {
+	return &TenantAuditQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantAudit},
+		inters: c.Interceptors(),
 	}
-	inters struct {
-		Tenant []ent.Interceptor
+}
+
+// Get returns a TenantAudit entity by its id.
+func (c *TenantAuditClient) Get(ctx context.Context, id gidx.PrefixedID) (*TenantAudit, error) {
+	return c.Query().Where(tenantaudit.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantAuditClient) GetX(ctx context.Context, id gidx.PrefixedID) *TenantAudit {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
 	}
-)
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantAuditClient) Hooks() []Hook {
+	return c.hooks.TenantAudit
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantAuditClient) Interceptors() []Interceptor {
+	return c.inters.TenantAudit
+}
+
+func (c *TenantAuditClient) mutate(ctx context.Context, m *TenantAuditMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantAuditCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantAuditUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantAuditUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantAuditDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantAudit mutation op: %q", m.Op())
+	}
+}
+
+// TenantChangeClient is a client for the TenantChange schema.
+type TenantChangeClient struct {
+	config
+}
+
+// NewTenantChangeClient returns a client for the TenantChange from the given config.
+func NewTenantChangeClient(c config) *TenantChangeClient {
+	return &TenantChangeClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantchange.Hooks(f(g(h())))`.
+func (c *TenantChangeClient) Use(hooks ...Hook) {
+	c.hooks.TenantChange = append(c.hooks.TenantChange, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantchange.Intercept(f(g(h())))`.
+func (c *TenantChangeClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantChange = append(c.inters.TenantChange, interceptors...)
+}
+
+// Create returns a builder for creating a TenantChange entity.
+func (c *TenantChangeClient) Create() *TenantChangeCreate {
+	mutation := newTenantChangeMutation(c.config, OpCreate)
+	return &TenantChangeCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantChange entities.
+func (c *TenantChangeClient) CreateBulk(builders ...*TenantChangeCreate) *TenantChangeCreateBulk {
+	return &TenantChangeCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantChange.
+func (c *TenantChangeClient) Update() *TenantChangeUpdate {
+	mutation := newTenantChangeMutation(c.config, OpUpdate)
+	return &TenantChangeUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantChangeClient) UpdateOne(tc *TenantChange) *TenantChangeUpdateOne {
+	mutation := newTenantChangeMutation(c.config, OpUpdateOne, withTenantChange(tc))
+	return &TenantChangeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantChangeClient) UpdateOneID(id int64) *TenantChangeUpdateOne {
+	mutation := newTenantChangeMutation(c.config, OpUpdateOne, withTenantChangeID(id))
+	return &TenantChangeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantChange.
+func (c *TenantChangeClient) Delete() *TenantChangeDelete {
+	mutation := newTenantChangeMutation(c.config, OpDelete)
+	return &TenantChangeDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantChangeClient) DeleteOne(tc *TenantChange) *TenantChangeDeleteOne {
+	return c.DeleteOneID(tc.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantChangeClient) DeleteOneID(id int64) *TenantChangeDeleteOne {
+	builder := c.Delete().Where(tenantchange.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantChangeDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantChange.
+func (c *TenantChangeClient) Query() *TenantChangeQuery {
+	return &TenantChangeQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantChange},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantChange entity by its id.
+func (c *TenantChangeClient) Get(ctx context.Context, id int64) (*TenantChange, error) {
+	return c.Query().Where(tenantchange.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantChangeClient) GetX(ctx context.Context, id int64) *TenantChange {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantChangeClient) Hooks() []Hook {
+	return c.hooks.TenantChange
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantChangeClient) Interceptors() []Interceptor {
+	return c.inters.TenantChange
+}
+
+func (c *TenantChangeClient) mutate(ctx context.Context, m *TenantChangeMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantChangeCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantChangeUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantChangeUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantChangeDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantChange mutation op: %q", m.Op())
+	}
+}
+
+// TenantCreateGuardClient is a client for the TenantCreateGuard schema.
+type TenantCreateGuardClient struct {
+	config
+}
+
+// NewTenantCreateGuardClient returns a client for the TenantCreateGuard from the given config.
+func NewTenantCreateGuardClient(c config) *TenantCreateGuardClient {
+	return &TenantCreateGuardClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantcreateguard.Hooks(f(g(h())))`.
+func (c *TenantCreateGuardClient) Use(hooks ...Hook) {
+	c.hooks.TenantCreateGuard = append(c.hooks.TenantCreateGuard, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantcreateguard.Intercept(f(g(h())))`.
+func (c *TenantCreateGuardClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantCreateGuard = append(c.inters.TenantCreateGuard, interceptors...)
+}
+
+// Create returns a builder for creating a TenantCreateGuard entity.
+func (c *TenantCreateGuardClient) Create() *TenantCreateGuardCreate {
+	mutation := newTenantCreateGuardMutation(c.config, OpCreate)
+	return &TenantCreateGuardCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantCreateGuard entities.
+func (c *TenantCreateGuardClient) CreateBulk(builders ...*TenantCreateGuardCreate) *TenantCreateGuardCreateBulk {
+	return &TenantCreateGuardCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantCreateGuard.
+func (c *TenantCreateGuardClient) Update() *TenantCreateGuardUpdate {
+	mutation := newTenantCreateGuardMutation(c.config, OpUpdate)
+	return &TenantCreateGuardUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantCreateGuardClient) UpdateOne(tcg *TenantCreateGuard) *TenantCreateGuardUpdateOne {
+	mutation := newTenantCreateGuardMutation(c.config, OpUpdateOne, withTenantCreateGuard(tcg))
+	return &TenantCreateGuardUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantCreateGuardClient) UpdateOneID(id string) *TenantCreateGuardUpdateOne {
+	mutation := newTenantCreateGuardMutation(c.config, OpUpdateOne, withTenantCreateGuardID(id))
+	return &TenantCreateGuardUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantCreateGuard.
+func (c *TenantCreateGuardClient) Delete() *TenantCreateGuardDelete {
+	mutation := newTenantCreateGuardMutation(c.config, OpDelete)
+	return &TenantCreateGuardDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantCreateGuardClient) DeleteOne(tcg *TenantCreateGuard) *TenantCreateGuardDeleteOne {
+	return c.DeleteOneID(tcg.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantCreateGuardClient) DeleteOneID(id string) *TenantCreateGuardDeleteOne {
+	builder := c.Delete().Where(tenantcreateguard.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantCreateGuardDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantCreateGuard.
+func (c *TenantCreateGuardClient) Query() *TenantCreateGuardQuery {
+	return &TenantCreateGuardQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantCreateGuard},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantCreateGuard entity by its id.
+func (c *TenantCreateGuardClient) Get(ctx context.Context, id string) (*TenantCreateGuard, error) {
+	return c.Query().Where(tenantcreateguard.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantCreateGuardClient) GetX(ctx context.Context, id string) *TenantCreateGuard {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantCreateGuardClient) Hooks() []Hook {
+	return c.hooks.TenantCreateGuard
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantCreateGuardClient) Interceptors() []Interceptor {
+	return c.inters.TenantCreateGuard
+}
+
+func (c *TenantCreateGuardClient) mutate(ctx context.Context, m *TenantCreateGuardMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantCreateGuardCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantCreateGuardUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantCreateGuardUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantCreateGuardDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantCreateGuard mutation op: %q", m.Op())
+	}
+}
+
+// TenantParentHistoryClient is a client for the TenantParentHistory schema.
+type TenantParentHistoryClient struct {
+	config
+}
+
+// NewTenantParentHistoryClient returns a client for the TenantParentHistory from the given config.
+func NewTenantParentHistoryClient(c config) *TenantParentHistoryClient {
+	return &TenantParentHistoryClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantparenthistory.Hooks(f(g(h())))`.
+func (c *TenantParentHistoryClient) Use(hooks ...Hook) {
+	c.hooks.TenantParentHistory = append(c.hooks.TenantParentHistory, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantparenthistory.Intercept(f(g(h())))`.
+func (c *TenantParentHistoryClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantParentHistory = append(c.inters.TenantParentHistory, interceptors...)
+}
+
+// Create returns a builder for creating a TenantParentHistory entity.
+func (c *TenantParentHistoryClient) Create() *TenantParentHistoryCreate {
+	mutation := newTenantParentHistoryMutation(c.config, OpCreate)
+	return &TenantParentHistoryCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantParentHistory entities.
+func (c *TenantParentHistoryClient) CreateBulk(builders ...*TenantParentHistoryCreate) *TenantParentHistoryCreateBulk {
+	return &TenantParentHistoryCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantParentHistory.
+func (c *TenantParentHistoryClient) Update() *TenantParentHistoryUpdate {
+	mutation := newTenantParentHistoryMutation(c.config, OpUpdate)
+	return &TenantParentHistoryUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantParentHistoryClient) UpdateOne(tph *TenantParentHistory) *TenantParentHistoryUpdateOne {
+	mutation := newTenantParentHistoryMutation(c.config, OpUpdateOne, withTenantParentHistory(tph))
+	return &TenantParentHistoryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantParentHistoryClient) UpdateOneID(id gidx.PrefixedID) *TenantParentHistoryUpdateOne {
+	mutation := newTenantParentHistoryMutation(c.config, OpUpdateOne, withTenantParentHistoryID(id))
+	return &TenantParentHistoryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantParentHistory.
+func (c *TenantParentHistoryClient) Delete() *TenantParentHistoryDelete {
+	mutation := newTenantParentHistoryMutation(c.config, OpDelete)
+	return &TenantParentHistoryDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantParentHistoryClient) DeleteOne(tph *TenantParentHistory) *TenantParentHistoryDeleteOne {
+	return c.DeleteOneID(tph.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantParentHistoryClient) DeleteOneID(id gidx.PrefixedID) *TenantParentHistoryDeleteOne {
+	builder := c.Delete().Where(tenantparenthistory.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantParentHistoryDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantParentHistory.
+func (c *TenantParentHistoryClient) Query() *TenantParentHistoryQuery {
+	return &TenantParentHistoryQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantParentHistory},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantParentHistory entity by its id.
+func (c *TenantParentHistoryClient) Get(ctx context.Context, id gidx.PrefixedID) (*TenantParentHistory, error) {
+	return c.Query().Where(tenantparenthistory.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantParentHistoryClient) GetX(ctx context.Context, id gidx.PrefixedID) *TenantParentHistory {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantParentHistoryClient) Hooks() []Hook {
+	return c.hooks.TenantParentHistory
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantParentHistoryClient) Interceptors() []Interceptor {
+	return c.inters.TenantParentHistory
+}
+
+func (c *TenantParentHistoryClient) mutate(ctx context.Context, m *TenantParentHistoryMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantParentHistoryCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantParentHistoryUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantParentHistoryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantParentHistoryDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantParentHistory mutation op: %q", m.Op())
+	}
+}
+
+// TenantTombstoneClient is a client for the TenantTombstone schema.
+type TenantTombstoneClient struct {
+	config
+}
+
+// NewTenantTombstoneClient returns a client for the TenantTombstone from the given config.
+func NewTenantTombstoneClient(c config) *TenantTombstoneClient {
+	return &TenantTombstoneClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenanttombstone.Hooks(f(g(h())))`.
+func (c *TenantTombstoneClient) Use(hooks ...Hook) {
+	c.hooks.TenantTombstone = append(c.hooks.TenantTombstone, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenanttombstone.Intercept(f(g(h())))`.
+func (c *TenantTombstoneClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantTombstone = append(c.inters.TenantTombstone, interceptors...)
+}
+
+// Create returns a builder for creating a TenantTombstone entity.
+func (c *TenantTombstoneClient) Create() *TenantTombstoneCreate {
+	mutation := newTenantTombstoneMutation(c.config, OpCreate)
+	return &TenantTombstoneCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantTombstone entities.
+func (c *TenantTombstoneClient) CreateBulk(builders ...*TenantTombstoneCreate) *TenantTombstoneCreateBulk {
+	return &TenantTombstoneCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantTombstone.
+func (c *TenantTombstoneClient) Update() *TenantTombstoneUpdate {
+	mutation := newTenantTombstoneMutation(c.config, OpUpdate)
+	return &TenantTombstoneUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantTombstoneClient) UpdateOne(tt *TenantTombstone) *TenantTombstoneUpdateOne {
+	mutation := newTenantTombstoneMutation(c.config, OpUpdateOne, withTenantTombstone(tt))
+	return &TenantTombstoneUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantTombstoneClient) UpdateOneID(id gidx.PrefixedID) *TenantTombstoneUpdateOne {
+	mutation := newTenantTombstoneMutation(c.config, OpUpdateOne, withTenantTombstoneID(id))
+	return &TenantTombstoneUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantTombstone.
+func (c *TenantTombstoneClient) Delete() *TenantTombstoneDelete {
+	mutation := newTenantTombstoneMutation(c.config, OpDelete)
+	return &TenantTombstoneDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantTombstoneClient) DeleteOne(tt *TenantTombstone) *TenantTombstoneDeleteOne {
+	return c.DeleteOneID(tt.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantTombstoneClient) DeleteOneID(id gidx.PrefixedID) *TenantTombstoneDeleteOne {
+	builder := c.Delete().Where(tenanttombstone.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantTombstoneDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantTombstone.
+func (c *TenantTombstoneClient) Query() *TenantTombstoneQuery {
+	return &TenantTombstoneQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantTombstone},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantTombstone entity by its id.
+func (c *TenantTombstoneClient) Get(ctx context.Context, id gidx.PrefixedID) (*TenantTombstone, error) {
+	return c.Query().Where(tenanttombstone.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantTombstoneClient) GetX(ctx context.Context, id gidx.PrefixedID) *TenantTombstone {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantTombstoneClient) Hooks() []Hook {
+	return c.hooks.TenantTombstone
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantTombstoneClient) Interceptors() []Interceptor {
+	return c.inters.TenantTombstone
+}
+
+func (c *TenantTombstoneClient) mutate(ctx context.Context, m *TenantTombstoneMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantTombstoneCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantTombstoneUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantTombstoneUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantTombstoneDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantTombstone mutation op: %q", m.Op())
+	}
+}
+
+// TenantUsageClient is a client for the TenantUsage schema.
+type TenantUsageClient struct {
+	config
+}
+
+// NewTenantUsageClient returns a client for the TenantUsage from the given config.
+func NewTenantUsageClient(c config) *TenantUsageClient {
+	return &TenantUsageClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantusage.Hooks(f(g(h())))`.
+func (c *TenantUsageClient) Use(hooks ...Hook) {
+	c.hooks.TenantUsage = append(c.hooks.TenantUsage, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantusage.Intercept(f(g(h())))`.
+func (c *TenantUsageClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantUsage = append(c.inters.TenantUsage, interceptors...)
+}
+
+// Create returns a builder for creating a TenantUsage entity.
+func (c *TenantUsageClient) Create() *TenantUsageCreate {
+	mutation := newTenantUsageMutation(c.config, OpCreate)
+	return &TenantUsageCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantUsage entities.
+func (c *TenantUsageClient) CreateBulk(builders ...*TenantUsageCreate) *TenantUsageCreateBulk {
+	return &TenantUsageCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantUsage.
+func (c *TenantUsageClient) Update() *TenantUsageUpdate {
+	mutation := newTenantUsageMutation(c.config, OpUpdate)
+	return &TenantUsageUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantUsageClient) UpdateOne(tu *TenantUsage) *TenantUsageUpdateOne {
+	mutation := newTenantUsageMutation(c.config, OpUpdateOne, withTenantUsage(tu))
+	return &TenantUsageUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantUsageClient) UpdateOneID(id int64) *TenantUsageUpdateOne {
+	mutation := newTenantUsageMutation(c.config, OpUpdateOne, withTenantUsageID(id))
+	return &TenantUsageUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantUsage.
+func (c *TenantUsageClient) Delete() *TenantUsageDelete {
+	mutation := newTenantUsageMutation(c.config, OpDelete)
+	return &TenantUsageDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantUsageClient) DeleteOne(tu *TenantUsage) *TenantUsageDeleteOne {
+	return c.DeleteOneID(tu.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantUsageClient) DeleteOneID(id int64) *TenantUsageDeleteOne {
+	builder := c.Delete().Where(tenantusage.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantUsageDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantUsage.
+func (c *TenantUsageClient) Query() *TenantUsageQuery {
+	return &TenantUsageQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantUsage},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantUsage entity by its id.
+func (c *TenantUsageClient) Get(ctx context.Context, id int64) (*TenantUsage, error) {
+	return c.Query().Where(tenantusage.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantUsageClient) GetX(ctx context.Context, id int64) *TenantUsage {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantUsageClient) Hooks() []Hook {
+	return c.hooks.TenantUsage
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantUsageClient) Interceptors() []Interceptor {
+	return c.inters.TenantUsage
+}
+
+func (c *TenantUsageClient) mutate(ctx context.Context, m *TenantUsageMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantUsageCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantUsageUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantUsageUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantUsageDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("generated: unknown TenantUsage mutation op: %q", m.Op())
+	}
+}
+
+// hooks and interceptors per client, for fast access.
+type (
+	hooks struct {
+		SchemaCompatibility, ServiceAccount, Tenant, TenantAudit, TenantChange,
+		TenantCreateGuard, TenantParentHistory, TenantTombstone, TenantUsage []ent.Hook
+	}
+	inters struct {
+		SchemaCompatibility, ServiceAccount, Tenant, TenantAudit, TenantChange,
+		TenantCreateGuard, TenantParentHistory, TenantTombstone,
+		TenantUsage []ent.Interceptor
+	}
+)
+
+// ExecContext allows calling the underlying ExecContext method of the driver if it is supported by it.
+// See, database/sql#DB.ExecContext for more information.
+func (c *config) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	ex, ok := c.driver.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the driver if it is supported by it.
+// See, database/sql#DB.QueryContext for more information.
+func (c *config) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	q, ok := c.driver.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
@@ -26,7 +26,15 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -87,7 +95,15 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			tenant.Table: tenant.ValidColumn,
+			schemacompatibility.Table: schemacompatibility.ValidColumn,
+			serviceaccount.Table:      serviceaccount.ValidColumn,
+			tenant.Table:              tenant.ValidColumn,
+			tenantaudit.Table:         tenantaudit.ValidColumn,
+			tenantchange.Table:        tenantchange.ValidColumn,
+			tenantcreateguard.Table:   tenantcreateguard.ValidColumn,
+			tenantparenthistory.Table: tenantparenthistory.ValidColumn,
+			tenanttombstone.Table:     tenanttombstone.ValidColumn,
+			tenantusage.Table:         tenantusage.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
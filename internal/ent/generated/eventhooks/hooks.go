@@ -29,6 +29,250 @@ import (
 	"go.infratographer.com/x/gidx"
 )
 
+func ServiceAccountHooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.ServiceAccountFunc(func(ctx context.Context, m *generated.ServiceAccountMutation) (ent.Value, error) {
+					var err error
+					additionalSubjects := []gidx.PrefixedID{}
+					relationships := []events.AuthRelationshipRelation{}
+
+					objID, ok := m.ID()
+					if !ok {
+						return nil, fmt.Errorf("object doesn't have an id %s", objID)
+					}
+
+					changeset := []events.FieldChange{}
+					cv_created_at := ""
+					created_at, ok := m.CreatedAt()
+
+					if ok {
+						cv_created_at = created_at.Format(time.RFC3339)
+						pv_created_at := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldCreatedAt(ctx)
+							if err != nil {
+								pv_created_at = "<unknown>"
+							} else {
+								pv_created_at = ov.Format(time.RFC3339)
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "created_at",
+							PreviousValue: pv_created_at,
+							CurrentValue:  cv_created_at,
+						})
+					}
+
+					cv_updated_at := ""
+					updated_at, ok := m.UpdatedAt()
+
+					if ok {
+						cv_updated_at = updated_at.Format(time.RFC3339)
+						pv_updated_at := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldUpdatedAt(ctx)
+							if err != nil {
+								pv_updated_at = "<unknown>"
+							} else {
+								pv_updated_at = ov.Format(time.RFC3339)
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "updated_at",
+							PreviousValue: pv_updated_at,
+							CurrentValue:  cv_updated_at,
+						})
+					}
+
+					cv_tenant_id := ""
+					tenant_id, ok := m.TenantID()
+					if !ok && !m.Op().Is(ent.OpCreate) {
+						// since we are doing an update or delete and these fields didn't change, load the "old" value
+						tenant_id, err = m.OldTenantID(ctx)
+						if err != nil {
+							return nil, err
+						}
+					}
+					additionalSubjects = append(additionalSubjects, tenant_id)
+
+					relationships = append(relationships, events.AuthRelationshipRelation{
+						Relation:  "tenant",
+						SubjectID: tenant_id,
+					})
+
+					if ok {
+						cv_tenant_id = fmt.Sprintf("%s", fmt.Sprint(tenant_id))
+						pv_tenant_id := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldTenantID(ctx)
+							if err != nil {
+								pv_tenant_id = "<unknown>"
+							} else {
+								pv_tenant_id = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "tenant_id",
+							PreviousValue: pv_tenant_id,
+							CurrentValue:  cv_tenant_id,
+						})
+					}
+
+					cv_name := ""
+					name, ok := m.Name()
+
+					if ok {
+						cv_name = fmt.Sprintf("%s", fmt.Sprint(name))
+						pv_name := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldName(ctx)
+							if err != nil {
+								pv_name = "<unknown>"
+							} else {
+								pv_name = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "name",
+							PreviousValue: pv_name,
+							CurrentValue:  cv_name,
+						})
+					}
+
+					cv_description := ""
+					description, ok := m.Description()
+
+					if ok {
+						cv_description = fmt.Sprintf("%s", fmt.Sprint(description))
+						pv_description := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldDescription(ctx)
+							if err != nil {
+								pv_description = "<unknown>"
+							} else {
+								pv_description = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "description",
+							PreviousValue: pv_description,
+							CurrentValue:  cv_description,
+						})
+					}
+
+					cv_created_by := ""
+					created_by, ok := m.CreatedBy()
+
+					if ok {
+						cv_created_by = fmt.Sprintf("%s", fmt.Sprint(created_by))
+						pv_created_by := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldCreatedBy(ctx)
+							if err != nil {
+								pv_created_by = "<unknown>"
+							} else {
+								pv_created_by = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "created_by",
+							PreviousValue: pv_created_by,
+							CurrentValue:  cv_created_by,
+						})
+					}
+
+					if len(relationships) != 0 {
+						if err := permissions.CreateAuthRelationships(ctx, "service-account", objID, relationships...); err != nil {
+							return nil, fmt.Errorf("relationship request failed with error: %w", err)
+						}
+					}
+
+					msg := events.ChangeMessage{
+						EventType:            eventType(m.Op()),
+						SubjectID:            objID,
+						AdditionalSubjectIDs: additionalSubjects,
+						Timestamp:            time.Now().UTC(),
+						FieldChanges:         changeset,
+					}
+
+					// complete the mutation before we process the event
+					retValue, err := next.Mutate(ctx, m)
+					if err != nil {
+						return retValue, err
+					}
+
+					if _, err := m.EventsPublisher.PublishChange(ctx, "service-account", msg); err != nil {
+						return nil, fmt.Errorf("failed to publish change: %w", err)
+					}
+
+					return retValue, nil
+				})
+			},
+			ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+		),
+
+		// Delete Hook
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return hook.ServiceAccountFunc(func(ctx context.Context, m *generated.ServiceAccountMutation) (ent.Value, error) {
+					additionalSubjects := []gidx.PrefixedID{}
+					relationships := []events.AuthRelationshipRelation{}
+
+					objID, ok := m.ID()
+					if !ok {
+						return nil, fmt.Errorf("object doesn't have an id %s", objID)
+					}
+
+					dbObj, err := m.Client().ServiceAccount.Get(ctx, objID)
+					if err != nil {
+						return nil, fmt.Errorf("failed to load object to get values for event, err %w", err)
+					}
+
+					additionalSubjects = append(additionalSubjects, dbObj.TenantID)
+
+					relationships = append(relationships, events.AuthRelationshipRelation{
+						Relation:  "tenant",
+						SubjectID: dbObj.TenantID,
+					})
+
+					if len(relationships) != 0 {
+						if err := permissions.DeleteAuthRelationships(ctx, "service-account", objID, relationships...); err != nil {
+							return nil, fmt.Errorf("relationship request failed with error: %w", err)
+						}
+					}
+
+					// we have all the info we need, now complete the mutation before we process the event
+					retValue, err := next.Mutate(ctx, m)
+					if err != nil {
+						return retValue, err
+					}
+
+					msg := events.ChangeMessage{
+						EventType:            eventType(m.Op()),
+						SubjectID:            objID,
+						AdditionalSubjectIDs: additionalSubjects,
+						Timestamp:            time.Now().UTC(),
+					}
+
+					if _, err := m.EventsPublisher.PublishChange(ctx, "service-account", msg); err != nil {
+						return nil, fmt.Errorf("failed to publish change: %w", err)
+					}
+
+					return retValue, nil
+				})
+			},
+			ent.OpDelete|ent.OpDeleteOne,
+		),
+	}
+}
 func TenantHooks() []ent.Hook {
 	return []ent.Hook{
 		hook.On(
@@ -110,6 +354,28 @@ func TenantHooks() []ent.Hook {
 						})
 					}
 
+					cv_display_name := ""
+					display_name, ok := m.DisplayName()
+
+					if ok {
+						cv_display_name = fmt.Sprintf("%s", fmt.Sprint(display_name))
+						pv_display_name := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldDisplayName(ctx)
+							if err != nil {
+								pv_display_name = "<unknown>"
+							} else {
+								pv_display_name = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "display_name",
+							PreviousValue: pv_display_name,
+							CurrentValue:  cv_display_name,
+						})
+					}
+
 					cv_description := ""
 					description, ok := m.Description()
 
@@ -132,6 +398,50 @@ func TenantHooks() []ent.Hook {
 						})
 					}
 
+					cv_contact_email := ""
+					contact_email, ok := m.ContactEmail()
+
+					if ok {
+						cv_contact_email = fmt.Sprintf("%s", fmt.Sprint(contact_email))
+						pv_contact_email := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldContactEmail(ctx)
+							if err != nil {
+								pv_contact_email = "<unknown>"
+							} else {
+								pv_contact_email = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "contact_email",
+							PreviousValue: pv_contact_email,
+							CurrentValue:  cv_contact_email,
+						})
+					}
+
+					cv_billing_reference := ""
+					billing_reference, ok := m.BillingReference()
+
+					if ok {
+						cv_billing_reference = fmt.Sprintf("%s", fmt.Sprint(billing_reference))
+						pv_billing_reference := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldBillingReference(ctx)
+							if err != nil {
+								pv_billing_reference = "<unknown>"
+							} else {
+								pv_billing_reference = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "billing_reference",
+							PreviousValue: pv_billing_reference,
+							CurrentValue:  cv_billing_reference,
+						})
+					}
+
 					cv_parent_tenant_id := ""
 					parent_tenant_id, ok := m.ParentTenantID()
 					if !ok && !m.Op().Is(ent.OpCreate) {
@@ -169,6 +479,314 @@ func TenantHooks() []ent.Hook {
 						})
 					}
 
+					cv_deletion_scheduled_at := ""
+					deletion_scheduled_at, ok := m.DeletionScheduledAt()
+
+					if ok {
+						cv_deletion_scheduled_at = deletion_scheduled_at.Format(time.RFC3339)
+						pv_deletion_scheduled_at := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldDeletionScheduledAt(ctx)
+							if err != nil {
+								pv_deletion_scheduled_at = "<unknown>"
+							} else {
+								pv_deletion_scheduled_at = ov.Format(time.RFC3339)
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "deletion_scheduled_at",
+							PreviousValue: pv_deletion_scheduled_at,
+							CurrentValue:  cv_deletion_scheduled_at,
+						})
+					}
+
+					cv_max_children := ""
+					max_children, ok := m.MaxChildren()
+
+					if ok {
+						cv_max_children = fmt.Sprintf("%s", fmt.Sprint(max_children))
+						pv_max_children := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldMaxChildren(ctx)
+							if err != nil {
+								pv_max_children = "<unknown>"
+							} else {
+								pv_max_children = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "max_children",
+							PreviousValue: pv_max_children,
+							CurrentValue:  cv_max_children,
+						})
+					}
+
+					cv_owner_id := ""
+					owner_id, ok := m.OwnerID()
+
+					if ok {
+						cv_owner_id = fmt.Sprintf("%s", fmt.Sprint(owner_id))
+						pv_owner_id := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldOwnerID(ctx)
+							if err != nil {
+								pv_owner_id = "<unknown>"
+							} else {
+								pv_owner_id = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "owner_id",
+							PreviousValue: pv_owner_id,
+							CurrentValue:  cv_owner_id,
+						})
+					}
+
+					cv_suspended_at := ""
+					suspended_at, ok := m.SuspendedAt()
+
+					if ok {
+						cv_suspended_at = suspended_at.Format(time.RFC3339)
+						pv_suspended_at := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldSuspendedAt(ctx)
+							if err != nil {
+								pv_suspended_at = "<unknown>"
+							} else {
+								pv_suspended_at = ov.Format(time.RFC3339)
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "suspended_at",
+							PreviousValue: pv_suspended_at,
+							CurrentValue:  cv_suspended_at,
+						})
+					}
+
+					cv_external_id := ""
+					external_id, ok := m.ExternalID()
+
+					if ok {
+						cv_external_id = fmt.Sprintf("%s", fmt.Sprint(external_id))
+						pv_external_id := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldExternalID(ctx)
+							if err != nil {
+								pv_external_id = "<unknown>"
+							} else {
+								pv_external_id = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "external_id",
+							PreviousValue: pv_external_id,
+							CurrentValue:  cv_external_id,
+						})
+					}
+
+					cv_archived := ""
+					archived, ok := m.Archived()
+
+					if ok {
+						cv_archived = fmt.Sprintf("%s", fmt.Sprint(archived))
+						pv_archived := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldArchived(ctx)
+							if err != nil {
+								pv_archived = "<unknown>"
+							} else {
+								pv_archived = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "archived",
+							PreviousValue: pv_archived,
+							CurrentValue:  cv_archived,
+						})
+					}
+
+					cv_frozen := ""
+					frozen, ok := m.Frozen()
+
+					if ok {
+						cv_frozen = fmt.Sprintf("%s", fmt.Sprint(frozen))
+						pv_frozen := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldFrozen(ctx)
+							if err != nil {
+								pv_frozen = "<unknown>"
+							} else {
+								pv_frozen = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "frozen",
+							PreviousValue: pv_frozen,
+							CurrentValue:  cv_frozen,
+						})
+					}
+
+					cv_creation_frozen_until := ""
+					creation_frozen_until, ok := m.CreationFrozenUntil()
+
+					if ok {
+						cv_creation_frozen_until = creation_frozen_until.Format(time.RFC3339)
+						pv_creation_frozen_until := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldCreationFrozenUntil(ctx)
+							if err != nil {
+								pv_creation_frozen_until = "<unknown>"
+							} else {
+								pv_creation_frozen_until = ov.Format(time.RFC3339)
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "creation_frozen_until",
+							PreviousValue: pv_creation_frozen_until,
+							CurrentValue:  cv_creation_frozen_until,
+						})
+					}
+
+					cv_deletion_protected := ""
+					deletion_protected, ok := m.DeletionProtected()
+
+					if ok {
+						cv_deletion_protected = fmt.Sprintf("%s", fmt.Sprint(deletion_protected))
+						pv_deletion_protected := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldDeletionProtected(ctx)
+							if err != nil {
+								pv_deletion_protected = "<unknown>"
+							} else {
+								pv_deletion_protected = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "deletion_protected",
+							PreviousValue: pv_deletion_protected,
+							CurrentValue:  cv_deletion_protected,
+						})
+					}
+
+					cv_change_seq := ""
+					change_seq, ok := m.ChangeSeq()
+
+					if ok {
+						cv_change_seq = fmt.Sprintf("%s", fmt.Sprint(change_seq))
+						pv_change_seq := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldChangeSeq(ctx)
+							if err != nil {
+								pv_change_seq = "<unknown>"
+							} else {
+								pv_change_seq = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "change_seq",
+							PreviousValue: pv_change_seq,
+							CurrentValue:  cv_change_seq,
+						})
+					}
+
+					cv_version := ""
+					version, ok := m.Version()
+
+					if ok {
+						cv_version = fmt.Sprintf("%s", fmt.Sprint(version))
+						pv_version := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldVersion(ctx)
+							if err != nil {
+								pv_version = "<unknown>"
+							} else {
+								pv_version = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "version",
+							PreviousValue: pv_version,
+							CurrentValue:  cv_version,
+						})
+					}
+
+					cv_unique_name := ""
+					unique_name, ok := m.UniqueName()
+
+					if ok {
+						cv_unique_name = fmt.Sprintf("%s", fmt.Sprint(unique_name))
+						pv_unique_name := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldUniqueName(ctx)
+							if err != nil {
+								pv_unique_name = "<unknown>"
+							} else {
+								pv_unique_name = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "unique_name",
+							PreviousValue: pv_unique_name,
+							CurrentValue:  cv_unique_name,
+						})
+					}
+
+					cv_settings := ""
+					settings, ok := m.Settings()
+
+					if ok {
+						cv_settings = fmt.Sprintf("%s", fmt.Sprint(settings))
+						pv_settings := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldSettings(ctx)
+							if err != nil {
+								pv_settings = "<unknown>"
+							} else {
+								pv_settings = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "settings",
+							PreviousValue: pv_settings,
+							CurrentValue:  cv_settings,
+						})
+					}
+
+					cv_labels := ""
+					labels, ok := m.Labels()
+
+					if ok {
+						cv_labels = fmt.Sprintf("%s", fmt.Sprint(labels))
+						pv_labels := ""
+						if !m.Op().Is(ent.OpCreate) {
+							ov, err := m.OldLabels(ctx)
+							if err != nil {
+								pv_labels = "<unknown>"
+							} else {
+								pv_labels = fmt.Sprintf("%s", fmt.Sprint(ov))
+							}
+						}
+
+						changeset = append(changeset, events.FieldChange{
+							Field:         "labels",
+							PreviousValue: pv_labels,
+							CurrentValue:  cv_labels,
+						})
+					}
+
 					if len(relationships) != 0 {
 						if err := permissions.CreateAuthRelationships(ctx, "tenant", objID, relationships...); err != nil {
 							return nil, fmt.Errorf("relationship request failed with error: %w", err)
@@ -257,6 +875,8 @@ func TenantHooks() []ent.Hook {
 }
 
 func EventHooks(c *generated.Client) {
+	c.ServiceAccount.Use(ServiceAccountHooks()...)
+
 	c.Tenant.Use(TenantHooks()...)
 
 }
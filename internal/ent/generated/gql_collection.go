@@ -80,6 +80,9 @@ func (t *TenantQuery) collectField(ctx context.Context, opCtx *graphql.Operation
 			if query, err = pager.applyFilter(query); err != nil {
 				return err
 			}
+			if v, _ := fieldArgs(ctx, nil, path...)["includeArchived"].(bool); !v {
+				query = query.Where(tenant.Or(tenant.ArchivedIsNil(), tenant.Archived(false)))
+			}
 			ignoredEdges := !hasCollectedField(ctx, append(path, edgesField)...)
 			if hasCollectedField(ctx, append(path, totalCountField)...) || hasCollectedField(ctx, append(path, pageInfoField)...) {
 				hasPagination := args.after != nil || args.first != nil || args.before != nil || args.last != nil
@@ -162,11 +165,36 @@ func (t *TenantQuery) collectField(ctx context.Context, opCtx *graphql.Operation
 				selectedFields = append(selectedFields, tenant.FieldName)
 				fieldSeen[tenant.FieldName] = struct{}{}
 			}
+		case "displayName":
+			if _, ok := fieldSeen[tenant.FieldDisplayName]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldDisplayName)
+				fieldSeen[tenant.FieldDisplayName] = struct{}{}
+			}
 		case "description":
 			if _, ok := fieldSeen[tenant.FieldDescription]; !ok {
 				selectedFields = append(selectedFields, tenant.FieldDescription)
 				fieldSeen[tenant.FieldDescription] = struct{}{}
 			}
+		case "contactEmail":
+			if _, ok := fieldSeen[tenant.FieldContactEmail]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldContactEmail)
+				fieldSeen[tenant.FieldContactEmail] = struct{}{}
+			}
+		case "billingReference":
+			if _, ok := fieldSeen[tenant.FieldBillingReference]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldBillingReference)
+				fieldSeen[tenant.FieldBillingReference] = struct{}{}
+			}
+		case "deletionProtected":
+			if _, ok := fieldSeen[tenant.FieldDeletionProtected]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldDeletionProtected)
+				fieldSeen[tenant.FieldDeletionProtected] = struct{}{}
+			}
+		case "changeSeq":
+			if _, ok := fieldSeen[tenant.FieldChangeSeq]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldChangeSeq)
+				fieldSeen[tenant.FieldChangeSeq] = struct{}{}
+			}
 		case "id":
 		case "__typename":
 		default:
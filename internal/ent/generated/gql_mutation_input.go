@@ -22,17 +22,29 @@ import (
 
 // CreateTenantInput represents a mutation input for creating tenants.
 type CreateTenantInput struct {
-	Name        string
-	Description *string
-	ParentID    *gidx.PrefixedID
+	Name             string
+	DisplayName      *string
+	Description      *string
+	ContactEmail     *string
+	BillingReference *string
+	ParentID         *gidx.PrefixedID
 }
 
 // Mutate applies the CreateTenantInput on the TenantMutation builder.
 func (i *CreateTenantInput) Mutate(m *TenantMutation) {
 	m.SetName(i.Name)
+	if v := i.DisplayName; v != nil {
+		m.SetDisplayName(*v)
+	}
 	if v := i.Description; v != nil {
 		m.SetDescription(*v)
 	}
+	if v := i.ContactEmail; v != nil {
+		m.SetContactEmail(*v)
+	}
+	if v := i.BillingReference; v != nil {
+		m.SetBillingReference(*v)
+	}
 	if v := i.ParentID; v != nil {
 		m.SetParentID(*v)
 	}
@@ -46,9 +58,19 @@ func (c *TenantCreate) SetInput(i CreateTenantInput) *TenantCreate {
 
 // UpdateTenantInput represents a mutation input for updating tenants.
 type UpdateTenantInput struct {
-	Name             *string
-	ClearDescription bool
-	Description      *string
+	Name                   *string
+	ClearDisplayName       bool
+	DisplayName            *string
+	ClearDescription       bool
+	Description            *string
+	ClearContactEmail      bool
+	ContactEmail           *string
+	ClearBillingReference  bool
+	BillingReference       *string
+	ClearDeletionProtected bool
+	DeletionProtected      *bool
+	ClearParent            bool
+	ParentID               *gidx.PrefixedID
 }
 
 // Mutate applies the UpdateTenantInput on the TenantMutation builder.
@@ -56,12 +78,42 @@ func (i *UpdateTenantInput) Mutate(m *TenantMutation) {
 	if v := i.Name; v != nil {
 		m.SetName(*v)
 	}
+	if i.ClearDisplayName {
+		m.ClearDisplayName()
+	}
+	if v := i.DisplayName; v != nil {
+		m.SetDisplayName(*v)
+	}
 	if i.ClearDescription {
 		m.ClearDescription()
 	}
 	if v := i.Description; v != nil {
 		m.SetDescription(*v)
 	}
+	if i.ClearContactEmail {
+		m.ClearContactEmail()
+	}
+	if v := i.ContactEmail; v != nil {
+		m.SetContactEmail(*v)
+	}
+	if i.ClearBillingReference {
+		m.ClearBillingReference()
+	}
+	if v := i.BillingReference; v != nil {
+		m.SetBillingReference(*v)
+	}
+	if i.ClearDeletionProtected {
+		m.ClearDeletionProtected()
+	}
+	if v := i.DeletionProtected; v != nil {
+		m.SetDeletionProtected(*v)
+	}
+	if i.ClearParent {
+		m.ClearParent()
+	}
+	if v := i.ParentID; v != nil {
+		m.SetParentID(*v)
+	}
 }
 
 // SetInput applies the change-set in the UpdateTenantInput on the TenantUpdate builder.
@@ -361,6 +361,34 @@ var (
 			}
 		},
 	}
+	// TenantOrderFieldDisplayName orders Tenant by display_name.
+	TenantOrderFieldDisplayName = &TenantOrderField{
+		Value: func(t *Tenant) (ent.Value, error) {
+			return t.DisplayName, nil
+		},
+		column: tenant.FieldDisplayName,
+		toTerm: tenant.ByDisplayName,
+		toCursor: func(t *Tenant) Cursor {
+			return Cursor{
+				ID:    t.ID,
+				Value: t.DisplayName,
+			}
+		},
+	}
+	// TenantOrderFieldChangeSeq orders Tenant by change_seq.
+	TenantOrderFieldChangeSeq = &TenantOrderField{
+		Value: func(t *Tenant) (ent.Value, error) {
+			return t.ChangeSeq, nil
+		},
+		column: tenant.FieldChangeSeq,
+		toTerm: tenant.ByChangeSeq,
+		toCursor: func(t *Tenant) Cursor {
+			return Cursor{
+				ID:    t.ID,
+				Value: t.ChangeSeq,
+			}
+		},
+	}
 )
 
 // String implement fmt.Stringer interface.
@@ -373,6 +401,10 @@ func (f TenantOrderField) String() string {
 		str = "UPDATED_AT"
 	case TenantOrderFieldName.column:
 		str = "NAME"
+	case TenantOrderFieldDisplayName.column:
+		str = "DISPLAY_NAME"
+	case TenantOrderFieldChangeSeq.column:
+		str = "CHANGE_SEQ"
 	}
 	return str
 }
@@ -395,6 +427,10 @@ func (f *TenantOrderField) UnmarshalGQL(v interface{}) error {
 		*f = *TenantOrderFieldUpdatedAt
 	case "NAME":
 		*f = *TenantOrderFieldName
+	case "DISPLAY_NAME":
+		*f = *TenantOrderFieldDisplayName
+	case "CHANGE_SEQ":
+		*f = *TenantOrderFieldChangeSeq
 	default:
 		return fmt.Errorf("%s is not a valid TenantOrderField", str)
 	}
@@ -63,6 +63,18 @@ type TenantWhereInput struct {
 	UpdatedAtLT    *time.Time  `json:"updatedAtLT,omitempty"`
 	UpdatedAtLTE   *time.Time  `json:"updatedAtLTE,omitempty"`
 
+	// "change_seq" field predicates.
+	ChangeSeq       *int64  `json:"changeSeq,omitempty"`
+	ChangeSeqNEQ    *int64  `json:"changeSeqNEQ,omitempty"`
+	ChangeSeqIn     []int64 `json:"changeSeqIn,omitempty"`
+	ChangeSeqNotIn  []int64 `json:"changeSeqNotIn,omitempty"`
+	ChangeSeqGT     *int64  `json:"changeSeqGT,omitempty"`
+	ChangeSeqGTE    *int64  `json:"changeSeqGTE,omitempty"`
+	ChangeSeqLT     *int64  `json:"changeSeqLT,omitempty"`
+	ChangeSeqLTE    *int64  `json:"changeSeqLTE,omitempty"`
+	ChangeSeqIsNil  bool    `json:"changeSeqIsNil,omitempty"`
+	ChangeSeqNotNil bool    `json:"changeSeqNotNil,omitempty"`
+
 	// "parent" edge predicates.
 	HasParent     *bool               `json:"hasParent,omitempty"`
 	HasParentWith []*TenantWhereInput `json:"hasParentWith,omitempty"`
@@ -215,6 +227,36 @@ func (i *TenantWhereInput) P() (predicate.Tenant, error) {
 	if i.UpdatedAtLTE != nil {
 		predicates = append(predicates, tenant.UpdatedAtLTE(*i.UpdatedAtLTE))
 	}
+	if i.ChangeSeq != nil {
+		predicates = append(predicates, tenant.ChangeSeqEQ(*i.ChangeSeq))
+	}
+	if i.ChangeSeqNEQ != nil {
+		predicates = append(predicates, tenant.ChangeSeqNEQ(*i.ChangeSeqNEQ))
+	}
+	if len(i.ChangeSeqIn) > 0 {
+		predicates = append(predicates, tenant.ChangeSeqIn(i.ChangeSeqIn...))
+	}
+	if len(i.ChangeSeqNotIn) > 0 {
+		predicates = append(predicates, tenant.ChangeSeqNotIn(i.ChangeSeqNotIn...))
+	}
+	if i.ChangeSeqGT != nil {
+		predicates = append(predicates, tenant.ChangeSeqGT(*i.ChangeSeqGT))
+	}
+	if i.ChangeSeqGTE != nil {
+		predicates = append(predicates, tenant.ChangeSeqGTE(*i.ChangeSeqGTE))
+	}
+	if i.ChangeSeqLT != nil {
+		predicates = append(predicates, tenant.ChangeSeqLT(*i.ChangeSeqLT))
+	}
+	if i.ChangeSeqLTE != nil {
+		predicates = append(predicates, tenant.ChangeSeqLTE(*i.ChangeSeqLTE))
+	}
+	if i.ChangeSeqIsNil {
+		predicates = append(predicates, tenant.ChangeSeqIsNil())
+	}
+	if i.ChangeSeqNotNil {
+		predicates = append(predicates, tenant.ChangeSeqNotNil())
+	}
 
 	if i.HasParent != nil {
 		p := tenant.HasParent()
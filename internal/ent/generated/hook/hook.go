@@ -23,6 +23,30 @@ import (
 	"go.infratographer.com/tenant-api/internal/ent/generated"
 )
 
+// The SchemaCompatibilityFunc type is an adapter to allow the use of ordinary
+// function as SchemaCompatibility mutator.
+type SchemaCompatibilityFunc func(context.Context, *generated.SchemaCompatibilityMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SchemaCompatibilityFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.SchemaCompatibilityMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.SchemaCompatibilityMutation", m)
+}
+
+// The ServiceAccountFunc type is an adapter to allow the use of ordinary
+// function as ServiceAccount mutator.
+type ServiceAccountFunc func(context.Context, *generated.ServiceAccountMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ServiceAccountFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.ServiceAccountMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.ServiceAccountMutation", m)
+}
+
 // The TenantFunc type is an adapter to allow the use of ordinary
 // function as Tenant mutator.
 type TenantFunc func(context.Context, *generated.TenantMutation) (generated.Value, error)
@@ -35,6 +59,78 @@ func (f TenantFunc) Mutate(ctx context.Context, m generated.Mutation) (generated
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantMutation", m)
 }
 
+// The TenantAuditFunc type is an adapter to allow the use of ordinary
+// function as TenantAudit mutator.
+type TenantAuditFunc func(context.Context, *generated.TenantAuditMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantAuditFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantAuditMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantAuditMutation", m)
+}
+
+// The TenantChangeFunc type is an adapter to allow the use of ordinary
+// function as TenantChange mutator.
+type TenantChangeFunc func(context.Context, *generated.TenantChangeMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantChangeFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantChangeMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantChangeMutation", m)
+}
+
+// The TenantCreateGuardFunc type is an adapter to allow the use of ordinary
+// function as TenantCreateGuard mutator.
+type TenantCreateGuardFunc func(context.Context, *generated.TenantCreateGuardMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantCreateGuardFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantCreateGuardMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantCreateGuardMutation", m)
+}
+
+// The TenantParentHistoryFunc type is an adapter to allow the use of ordinary
+// function as TenantParentHistory mutator.
+type TenantParentHistoryFunc func(context.Context, *generated.TenantParentHistoryMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantParentHistoryFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantParentHistoryMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantParentHistoryMutation", m)
+}
+
+// The TenantTombstoneFunc type is an adapter to allow the use of ordinary
+// function as TenantTombstone mutator.
+type TenantTombstoneFunc func(context.Context, *generated.TenantTombstoneMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantTombstoneFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantTombstoneMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantTombstoneMutation", m)
+}
+
+// The TenantUsageFunc type is an adapter to allow the use of ordinary
+// function as TenantUsage mutator.
+type TenantUsageFunc func(context.Context, *generated.TenantUsageMutation) (generated.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TenantUsageFunc) Mutate(ctx context.Context, m generated.Mutation) (generated.Value, error) {
+	if mv, ok := m.(*generated.TenantUsageMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *generated.TenantUsageMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, generated.Mutation) bool
 
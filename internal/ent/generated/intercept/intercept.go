@@ -23,7 +23,15 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"go.infratographer.com/tenant-api/internal/ent/generated"
 	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
 )
 
 // The Query interface represents an operation that queries a graph.
@@ -82,6 +90,60 @@ func (f TraverseFunc) Traverse(ctx context.Context, q generated.Query) error {
 	return f(ctx, query)
 }
 
+// The SchemaCompatibilityFunc type is an adapter to allow the use of ordinary function as a Querier.
+type SchemaCompatibilityFunc func(context.Context, *generated.SchemaCompatibilityQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f SchemaCompatibilityFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.SchemaCompatibilityQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.SchemaCompatibilityQuery", q)
+}
+
+// The TraverseSchemaCompatibility type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseSchemaCompatibility func(context.Context, *generated.SchemaCompatibilityQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseSchemaCompatibility) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseSchemaCompatibility) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.SchemaCompatibilityQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.SchemaCompatibilityQuery", q)
+}
+
+// The ServiceAccountFunc type is an adapter to allow the use of ordinary function as a Querier.
+type ServiceAccountFunc func(context.Context, *generated.ServiceAccountQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f ServiceAccountFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.ServiceAccountQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.ServiceAccountQuery", q)
+}
+
+// The TraverseServiceAccount type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseServiceAccount func(context.Context, *generated.ServiceAccountQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseServiceAccount) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseServiceAccount) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.ServiceAccountQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.ServiceAccountQuery", q)
+}
+
 // The TenantFunc type is an adapter to allow the use of ordinary function as a Querier.
 type TenantFunc func(context.Context, *generated.TenantQuery) (generated.Value, error)
 
@@ -109,11 +171,189 @@ func (f TraverseTenant) Traverse(ctx context.Context, q generated.Query) error {
 	return fmt.Errorf("unexpected query type %T. expect *generated.TenantQuery", q)
 }
 
+// The TenantAuditFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantAuditFunc func(context.Context, *generated.TenantAuditQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantAuditFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantAuditQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantAuditQuery", q)
+}
+
+// The TraverseTenantAudit type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantAudit func(context.Context, *generated.TenantAuditQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantAudit) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantAudit) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantAuditQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantAuditQuery", q)
+}
+
+// The TenantChangeFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantChangeFunc func(context.Context, *generated.TenantChangeQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantChangeFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantChangeQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantChangeQuery", q)
+}
+
+// The TraverseTenantChange type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantChange func(context.Context, *generated.TenantChangeQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantChange) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantChange) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantChangeQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantChangeQuery", q)
+}
+
+// The TenantCreateGuardFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantCreateGuardFunc func(context.Context, *generated.TenantCreateGuardQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantCreateGuardFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantCreateGuardQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantCreateGuardQuery", q)
+}
+
+// The TraverseTenantCreateGuard type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantCreateGuard func(context.Context, *generated.TenantCreateGuardQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantCreateGuard) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantCreateGuard) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantCreateGuardQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantCreateGuardQuery", q)
+}
+
+// The TenantParentHistoryFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantParentHistoryFunc func(context.Context, *generated.TenantParentHistoryQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantParentHistoryFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantParentHistoryQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantParentHistoryQuery", q)
+}
+
+// The TraverseTenantParentHistory type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantParentHistory func(context.Context, *generated.TenantParentHistoryQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantParentHistory) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantParentHistory) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantParentHistoryQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantParentHistoryQuery", q)
+}
+
+// The TenantTombstoneFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantTombstoneFunc func(context.Context, *generated.TenantTombstoneQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantTombstoneFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantTombstoneQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantTombstoneQuery", q)
+}
+
+// The TraverseTenantTombstone type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantTombstone func(context.Context, *generated.TenantTombstoneQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantTombstone) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantTombstone) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantTombstoneQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantTombstoneQuery", q)
+}
+
+// The TenantUsageFunc type is an adapter to allow the use of ordinary function as a Querier.
+type TenantUsageFunc func(context.Context, *generated.TenantUsageQuery) (generated.Value, error)
+
+// Query calls f(ctx, q).
+func (f TenantUsageFunc) Query(ctx context.Context, q generated.Query) (generated.Value, error) {
+	if q, ok := q.(*generated.TenantUsageQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *generated.TenantUsageQuery", q)
+}
+
+// The TraverseTenantUsage type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseTenantUsage func(context.Context, *generated.TenantUsageQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseTenantUsage) Intercept(next generated.Querier) generated.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseTenantUsage) Traverse(ctx context.Context, q generated.Query) error {
+	if q, ok := q.(*generated.TenantUsageQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *generated.TenantUsageQuery", q)
+}
+
 // NewQuery returns the generic Query interface for the given typed query.
 func NewQuery(q generated.Query) (Query, error) {
 	switch q := q.(type) {
+	case *generated.SchemaCompatibilityQuery:
+		return &query[*generated.SchemaCompatibilityQuery, predicate.SchemaCompatibility, schemacompatibility.OrderOption]{typ: generated.TypeSchemaCompatibility, tq: q}, nil
+	case *generated.ServiceAccountQuery:
+		return &query[*generated.ServiceAccountQuery, predicate.ServiceAccount, serviceaccount.OrderOption]{typ: generated.TypeServiceAccount, tq: q}, nil
 	case *generated.TenantQuery:
 		return &query[*generated.TenantQuery, predicate.Tenant, tenant.OrderOption]{typ: generated.TypeTenant, tq: q}, nil
+	case *generated.TenantAuditQuery:
+		return &query[*generated.TenantAuditQuery, predicate.TenantAudit, tenantaudit.OrderOption]{typ: generated.TypeTenantAudit, tq: q}, nil
+	case *generated.TenantChangeQuery:
+		return &query[*generated.TenantChangeQuery, predicate.TenantChange, tenantchange.OrderOption]{typ: generated.TypeTenantChange, tq: q}, nil
+	case *generated.TenantCreateGuardQuery:
+		return &query[*generated.TenantCreateGuardQuery, predicate.TenantCreateGuard, tenantcreateguard.OrderOption]{typ: generated.TypeTenantCreateGuard, tq: q}, nil
+	case *generated.TenantParentHistoryQuery:
+		return &query[*generated.TenantParentHistoryQuery, predicate.TenantParentHistory, tenantparenthistory.OrderOption]{typ: generated.TypeTenantParentHistory, tq: q}, nil
+	case *generated.TenantTombstoneQuery:
+		return &query[*generated.TenantTombstoneQuery, predicate.TenantTombstone, tenanttombstone.OrderOption]{typ: generated.TypeTenantTombstone, tq: q}, nil
+	case *generated.TenantUsageQuery:
+		return &query[*generated.TenantUsageQuery, predicate.TenantUsage, tenantusage.OrderOption]{typ: generated.TypeTenantUsage, tq: q}, nil
 	default:
 		return nil, fmt.Errorf("unknown query type %T", q)
 	}
@@ -17,18 +17,88 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// SchemaCompatibilityColumns holds the columns for the "schema_compatibility" table.
+	SchemaCompatibilityColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "min_binary_version", Type: field.TypeInt64},
+	}
+	// SchemaCompatibilityTable holds the schema information for the "schema_compatibility" table.
+	SchemaCompatibilityTable = &schema.Table{
+		Name:       "schema_compatibility",
+		Columns:    SchemaCompatibilityColumns,
+		PrimaryKey: []*schema.Column{SchemaCompatibilityColumns[0]},
+	}
+	// ServiceAccountsColumns holds the columns for the "service_accounts" table.
+	ServiceAccountsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "name", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "created_by", Type: field.TypeString, Nullable: true},
+		{Name: "tenant_id", Type: field.TypeString},
+	}
+	// ServiceAccountsTable holds the schema information for the "service_accounts" table.
+	ServiceAccountsTable = &schema.Table{
+		Name:       "service_accounts",
+		Columns:    ServiceAccountsColumns,
+		PrimaryKey: []*schema.Column{ServiceAccountsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "service_accounts_tenants_service_accounts",
+				Columns:    []*schema.Column{ServiceAccountsColumns[6]},
+				RefColumns: []*schema.Column{TenantsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "serviceaccount_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{ServiceAccountsColumns[1]},
+			},
+			{
+				Name:    "serviceaccount_updated_at",
+				Unique:  false,
+				Columns: []*schema.Column{ServiceAccountsColumns[2]},
+			},
+			{
+				Name:    "serviceaccount_tenant_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{ServiceAccountsColumns[6], ServiceAccountsColumns[3]},
+			},
+		},
+	}
 	// TenantsColumns holds the columns for the "tenants" table.
 	TenantsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeString, Unique: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "name", Type: field.TypeString},
+		{Name: "display_name", Type: field.TypeString, Nullable: true},
 		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "contact_email", Type: field.TypeString, Nullable: true},
+		{Name: "billing_reference", Type: field.TypeString, Nullable: true},
+		{Name: "deletion_scheduled_at", Type: field.TypeTime, Nullable: true},
+		{Name: "max_children", Type: field.TypeInt, Nullable: true},
+		{Name: "owner_id", Type: field.TypeString, Nullable: true},
+		{Name: "suspended_at", Type: field.TypeTime, Nullable: true},
+		{Name: "external_id", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "archived", Type: field.TypeBool, Nullable: true},
+		{Name: "frozen", Type: field.TypeBool, Nullable: true},
+		{Name: "creation_frozen_until", Type: field.TypeTime, Nullable: true},
+		{Name: "deletion_protected", Type: field.TypeBool, Nullable: true},
+		{Name: "change_seq", Type: field.TypeInt64, Nullable: true},
+		{Name: "version", Type: field.TypeInt64, Nullable: true},
+		{Name: "unique_name", Type: field.TypeBool, Nullable: true},
+		{Name: "settings", Type: field.TypeJSON, Nullable: true},
+		{Name: "labels", Type: field.TypeJSON, Nullable: true},
 		{Name: "parent_tenant_id", Type: field.TypeString, Nullable: true},
 	}
 	// TenantsTable holds the schema information for the "tenants" table.
@@ -39,7 +109,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "tenants_tenants_children",
-				Columns:    []*schema.Column{TenantsColumns[5]},
+				Columns:    []*schema.Column{TenantsColumns[22]},
 				RefColumns: []*schema.Column{TenantsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -55,14 +125,224 @@ var (
 				Unique:  false,
 				Columns: []*schema.Column{TenantsColumns[2]},
 			},
+			{
+				Name:    "tenant_deletion_scheduled_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantsColumns[8]},
+			},
+			{
+				Name:    "tenant_billing_reference",
+				Unique:  false,
+				Columns: []*schema.Column{TenantsColumns[7]},
+			},
+			{
+				Name:    "tenant_owner_id",
+				Unique:  false,
+				Columns: []*schema.Column{TenantsColumns[10]},
+			},
+			{
+				Name:    "tenant_change_seq",
+				Unique:  false,
+				Columns: []*schema.Column{TenantsColumns[17]},
+			},
+			{
+				Name:    "tenant_parent_tenant_id_external_id",
+				Unique:  true,
+				Columns: []*schema.Column{TenantsColumns[22], TenantsColumns[12]},
+			},
+			{
+				Name:    "tenant_parent_tenant_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{TenantsColumns[22], TenantsColumns[3]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "unique_name AND deletion_scheduled_at IS NULL",
+				},
+			},
+		},
+	}
+	// TenantAuditColumns holds the columns for the "tenant_audit" table.
+	TenantAuditColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true},
+		{Name: "tenant_id", Type: field.TypeString},
+		{Name: "actor", Type: field.TypeString, Nullable: true},
+		{Name: "operation", Type: field.TypeString},
+		{Name: "attempted_change", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "outcome", Type: field.TypeString},
+		{Name: "code", Type: field.TypeString, Nullable: true},
+		{Name: "recorded_at", Type: field.TypeTime},
+	}
+	// TenantAuditTable holds the schema information for the "tenant_audit" table.
+	TenantAuditTable = &schema.Table{
+		Name:       "tenant_audit",
+		Columns:    TenantAuditColumns,
+		PrimaryKey: []*schema.Column{TenantAuditColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenantaudit_tenant_id_recorded_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantAuditColumns[1], TenantAuditColumns[7]},
+			},
+			{
+				Name:    "tenantaudit_tenant_id_outcome_recorded_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantAuditColumns[1], TenantAuditColumns[5], TenantAuditColumns[7]},
+			},
+		},
+	}
+	// TenantChangesColumns holds the columns for the "tenant_changes" table.
+	TenantChangesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "tenant_id", Type: field.TypeString},
+		{Name: "operation", Type: field.TypeString},
+		{Name: "changed_at", Type: field.TypeTime},
+		{Name: "name", Type: field.TypeString, Nullable: true},
+		{Name: "parent_tenant_id", Type: field.TypeString, Nullable: true},
+	}
+	// TenantChangesTable holds the schema information for the "tenant_changes" table.
+	TenantChangesTable = &schema.Table{
+		Name:       "tenant_changes",
+		Columns:    TenantChangesColumns,
+		PrimaryKey: []*schema.Column{TenantChangesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenantchange_tenant_id",
+				Unique:  false,
+				Columns: []*schema.Column{TenantChangesColumns[1]},
+			},
+			{
+				Name:    "tenantchange_parent_tenant_id_name",
+				Unique:  false,
+				Columns: []*schema.Column{TenantChangesColumns[5], TenantChangesColumns[4]},
+			},
+			{
+				Name:    "tenantchange_changed_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantChangesColumns[3]},
+			},
+		},
+	}
+	// TenantCreateGuardsColumns holds the columns for the "tenant_create_guards" table.
+	TenantCreateGuardsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString},
+		{Name: "tenant_id", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// TenantCreateGuardsTable holds the schema information for the "tenant_create_guards" table.
+	TenantCreateGuardsTable = &schema.Table{
+		Name:       "tenant_create_guards",
+		Columns:    TenantCreateGuardsColumns,
+		PrimaryKey: []*schema.Column{TenantCreateGuardsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenantcreateguard_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantCreateGuardsColumns[2]},
+			},
+		},
+	}
+	// TenantParentHistoryColumns holds the columns for the "tenant_parent_history" table.
+	TenantParentHistoryColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true},
+		{Name: "tenant_id", Type: field.TypeString},
+		{Name: "previous_parent_id", Type: field.TypeString, Nullable: true},
+		{Name: "new_parent_id", Type: field.TypeString, Nullable: true},
+		{Name: "actor", Type: field.TypeString, Nullable: true},
+		{Name: "changed_at", Type: field.TypeTime},
+	}
+	// TenantParentHistoryTable holds the schema information for the "tenant_parent_history" table.
+	TenantParentHistoryTable = &schema.Table{
+		Name:       "tenant_parent_history",
+		Columns:    TenantParentHistoryColumns,
+		PrimaryKey: []*schema.Column{TenantParentHistoryColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenantparenthistory_tenant_id_changed_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantParentHistoryColumns[1], TenantParentHistoryColumns[5]},
+			},
+		},
+	}
+	// TenantTombstonesColumns holds the columns for the "tenant_tombstones" table.
+	TenantTombstonesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "parent_tenant_id", Type: field.TypeString, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeTime},
+		{Name: "purged_at", Type: field.TypeTime},
+	}
+	// TenantTombstonesTable holds the schema information for the "tenant_tombstones" table.
+	TenantTombstonesTable = &schema.Table{
+		Name:       "tenant_tombstones",
+		Columns:    TenantTombstonesColumns,
+		PrimaryKey: []*schema.Column{TenantTombstonesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenanttombstone_purged_at",
+				Unique:  false,
+				Columns: []*schema.Column{TenantTombstonesColumns[4]},
+			},
+		},
+	}
+	// TenantUsagesColumns holds the columns for the "tenant_usages" table.
+	TenantUsagesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "tenant_id", Type: field.TypeString},
+		{Name: "operation", Type: field.TypeString},
+		{Name: "bucket_start", Type: field.TypeTime},
+		{Name: "count", Type: field.TypeInt64},
+	}
+	// TenantUsagesTable holds the schema information for the "tenant_usages" table.
+	TenantUsagesTable = &schema.Table{
+		Name:       "tenant_usages",
+		Columns:    TenantUsagesColumns,
+		PrimaryKey: []*schema.Column{TenantUsagesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "tenantusage_tenant_id_bucket_start_operation",
+				Unique:  true,
+				Columns: []*schema.Column{TenantUsagesColumns[1], TenantUsagesColumns[3], TenantUsagesColumns[2]},
+			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		SchemaCompatibilityTable,
+		ServiceAccountsTable,
 		TenantsTable,
+		TenantAuditTable,
+		TenantChangesTable,
+		TenantCreateGuardsTable,
+		TenantParentHistoryTable,
+		TenantTombstonesTable,
+		TenantUsagesTable,
 	}
 )
 
 func init() {
+	SchemaCompatibilityTable.Annotation = &entsql.Annotation{
+		Table: "schema_compatibility",
+	}
+	ServiceAccountsTable.ForeignKeys[0].RefTable = TenantsTable
+	ServiceAccountsTable.Annotation = &entsql.Annotation{
+		Table: "service_accounts",
+	}
 	TenantsTable.ForeignKeys[0].RefTable = TenantsTable
+	TenantAuditTable.Annotation = &entsql.Annotation{
+		Table: "tenant_audit",
+	}
+	TenantChangesTable.Annotation = &entsql.Annotation{
+		Table: "tenant_changes",
+	}
+	TenantCreateGuardsTable.Annotation = &entsql.Annotation{
+		Table: "tenant_create_guards",
+	}
+	TenantParentHistoryTable.Annotation = &entsql.Annotation{
+		Table: "tenant_parent_history",
+	}
+	TenantTombstonesTable.Annotation = &entsql.Annotation{
+		Table: "tenant_tombstones",
+	}
+	TenantUsagesTable.Annotation = &entsql.Annotation{
+		Table: "tenant_usages",
+	}
 }
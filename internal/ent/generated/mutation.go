@@ -26,7 +26,15 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
 	"go.infratographer.com/x/gidx"
 )
 
@@ -39,41 +47,42 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeTenant = "Tenant"
+	TypeSchemaCompatibility = "SchemaCompatibility"
+	TypeServiceAccount      = "ServiceAccount"
+	TypeTenant              = "Tenant"
+	TypeTenantAudit         = "TenantAudit"
+	TypeTenantChange        = "TenantChange"
+	TypeTenantCreateGuard   = "TenantCreateGuard"
+	TypeTenantParentHistory = "TenantParentHistory"
+	TypeTenantTombstone     = "TenantTombstone"
+	TypeTenantUsage         = "TenantUsage"
 )
 
-// TenantMutation represents an operation that mutates the Tenant nodes in the graph.
-type TenantMutation struct {
+// SchemaCompatibilityMutation represents an operation that mutates the SchemaCompatibility nodes in the graph.
+type SchemaCompatibilityMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *gidx.PrefixedID
-	created_at      *time.Time
-	updated_at      *time.Time
-	name            *string
-	description     *string
-	clearedFields   map[string]struct{}
-	parent          *gidx.PrefixedID
-	clearedparent   bool
-	children        map[gidx.PrefixedID]struct{}
-	removedchildren map[gidx.PrefixedID]struct{}
-	clearedchildren bool
-	done            bool
-	oldValue        func(context.Context) (*Tenant, error)
-	predicates      []predicate.Tenant
+	op                    Op
+	typ                   string
+	id                    *int64
+	min_binary_version    *int64
+	addmin_binary_version *int64
+	clearedFields         map[string]struct{}
+	done                  bool
+	oldValue              func(context.Context) (*SchemaCompatibility, error)
+	predicates            []predicate.SchemaCompatibility
 }
 
-var _ ent.Mutation = (*TenantMutation)(nil)
+var _ ent.Mutation = (*SchemaCompatibilityMutation)(nil)
 
-// tenantOption allows management of the mutation configuration using functional options.
-type tenantOption func(*TenantMutation)
+// schemacompatibilityOption allows management of the mutation configuration using functional options.
+type schemacompatibilityOption func(*SchemaCompatibilityMutation)
 
-// newTenantMutation creates new mutation for the Tenant entity.
-func newTenantMutation(c config, op Op, opts ...tenantOption) *TenantMutation {
-	m := &TenantMutation{
+// newSchemaCompatibilityMutation creates new mutation for the SchemaCompatibility entity.
+func newSchemaCompatibilityMutation(c config, op Op, opts ...schemacompatibilityOption) *SchemaCompatibilityMutation {
+	m := &SchemaCompatibilityMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeTenant,
+		typ:           TypeSchemaCompatibility,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -82,20 +91,20 @@ func newTenantMutation(c config, op Op, opts ...tenantOption) *TenantMutation {
 	return m
 }
 
-// withTenantID sets the ID field of the mutation.
-func withTenantID(id gidx.PrefixedID) tenantOption {
-	return func(m *TenantMutation) {
+// withSchemaCompatibilityID sets the ID field of the mutation.
+func withSchemaCompatibilityID(id int64) schemacompatibilityOption {
+	return func(m *SchemaCompatibilityMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Tenant
+			value *SchemaCompatibility
 		)
-		m.oldValue = func(ctx context.Context) (*Tenant, error) {
+		m.oldValue = func(ctx context.Context) (*SchemaCompatibility, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Tenant.Get(ctx, id)
+					value, err = m.Client().SchemaCompatibility.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -104,10 +113,10 @@ func withTenantID(id gidx.PrefixedID) tenantOption {
 	}
 }
 
-// withTenant sets the old Tenant of the mutation.
-func withTenant(node *Tenant) tenantOption {
-	return func(m *TenantMutation) {
-		m.oldValue = func(context.Context) (*Tenant, error) {
+// withSchemaCompatibility sets the old SchemaCompatibility of the mutation.
+func withSchemaCompatibility(node *SchemaCompatibility) schemacompatibilityOption {
+	return func(m *SchemaCompatibilityMutation) {
+		m.oldValue = func(context.Context) (*SchemaCompatibility, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -116,7 +125,7 @@ func withTenant(node *Tenant) tenantOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m TenantMutation) Client() *Client {
+func (m SchemaCompatibilityMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -124,7 +133,7 @@ func (m TenantMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m TenantMutation) Tx() (*Tx, error) {
+func (m SchemaCompatibilityMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("generated: mutation is not running in a transaction")
 	}
@@ -134,14 +143,14 @@ func (m TenantMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Tenant entities.
-func (m *TenantMutation) SetID(id gidx.PrefixedID) {
+// operation is only accepted on creation of SchemaCompatibility entities.
+func (m *SchemaCompatibilityMutation) SetID(id int64) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *TenantMutation) ID() (id gidx.PrefixedID, exists bool) {
+func (m *SchemaCompatibilityMutation) ID() (id int64, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -152,329 +161,6245 @@ func (m *TenantMutation) ID() (id gidx.PrefixedID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *TenantMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+func (m *SchemaCompatibilityMutation) IDs(ctx context.Context) ([]int64, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []gidx.PrefixedID{id}, nil
+			return []int64{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Tenant.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().SchemaCompatibility.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *TenantMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetMinBinaryVersion sets the "min_binary_version" field.
+func (m *SchemaCompatibilityMutation) SetMinBinaryVersion(i int64) {
+	m.min_binary_version = &i
+	m.addmin_binary_version = nil
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *TenantMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// MinBinaryVersion returns the value of the "min_binary_version" field in the mutation.
+func (m *SchemaCompatibilityMutation) MinBinaryVersion() (r int64, exists bool) {
+	v := m.min_binary_version
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Tenant entity.
-// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// OldMinBinaryVersion returns the old "min_binary_version" field's value of the SchemaCompatibility entity.
+// If the SchemaCompatibility object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *TenantMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *SchemaCompatibilityMutation) OldMinBinaryVersion(ctx context.Context) (v int64, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldMinBinaryVersion is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldMinBinaryVersion requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldMinBinaryVersion: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.MinBinaryVersion, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *TenantMutation) ResetCreatedAt() {
-	m.created_at = nil
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (m *TenantMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// AddMinBinaryVersion adds i to the "min_binary_version" field.
+func (m *SchemaCompatibilityMutation) AddMinBinaryVersion(i int64) {
+	if m.addmin_binary_version != nil {
+		*m.addmin_binary_version += i
+	} else {
+		m.addmin_binary_version = &i
+	}
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *TenantMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// AddedMinBinaryVersion returns the value that was added to the "min_binary_version" field in this mutation.
+func (m *SchemaCompatibilityMutation) AddedMinBinaryVersion() (r int64, exists bool) {
+	v := m.addmin_binary_version
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Tenant entity.
-// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *TenantMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
-	}
-	return oldValue.UpdatedAt, nil
+// ResetMinBinaryVersion resets all changes to the "min_binary_version" field.
+func (m *SchemaCompatibilityMutation) ResetMinBinaryVersion() {
+	m.min_binary_version = nil
+	m.addmin_binary_version = nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *TenantMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// Where appends a list predicates to the SchemaCompatibilityMutation builder.
+func (m *SchemaCompatibilityMutation) Where(ps ...predicate.SchemaCompatibility) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// SetName sets the "name" field.
-func (m *TenantMutation) SetName(s string) {
-	m.name = &s
+// WhereP appends storage-level predicates to the SchemaCompatibilityMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SchemaCompatibilityMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SchemaCompatibility, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *TenantMutation) Name() (r string, exists bool) {
-	v := m.name
-	if v == nil {
-		return
-	}
-	return *v, true
+// Op returns the operation name.
+func (m *SchemaCompatibilityMutation) Op() Op {
+	return m.op
 }
 
-// OldName returns the old "name" field's value of the Tenant entity.
-// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *TenantMutation) OldName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
-	}
-	return oldValue.Name, nil
+// SetOp allows setting the mutation operation.
+func (m *SchemaCompatibilityMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *TenantMutation) ResetName() {
-	m.name = nil
+// Type returns the node type of this mutation (SchemaCompatibility).
+func (m *SchemaCompatibilityMutation) Type() string {
+	return m.typ
 }
 
-// SetDescription sets the "description" field.
-func (m *TenantMutation) SetDescription(s string) {
-	m.description = &s
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SchemaCompatibilityMutation) Fields() []string {
+	fields := make([]string, 0, 1)
+	if m.min_binary_version != nil {
+		fields = append(fields, schemacompatibility.FieldMinBinaryVersion)
+	}
+	return fields
 }
 
-// Description returns the value of the "description" field in the mutation.
-func (m *TenantMutation) Description() (r string, exists bool) {
-	v := m.description
-	if v == nil {
-		return
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SchemaCompatibilityMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		return m.MinBinaryVersion()
 	}
-	return *v, true
+	return nil, false
 }
 
-// OldDescription returns the old "description" field's value of the Tenant entity.
-// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *TenantMutation) OldDescription(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SchemaCompatibilityMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		return m.OldMinBinaryVersion(ctx)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDescription requires an ID field in the mutation")
+	return nil, fmt.Errorf("unknown SchemaCompatibility field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SchemaCompatibilityMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMinBinaryVersion(v)
+		return nil
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	return fmt.Errorf("unknown SchemaCompatibility field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SchemaCompatibilityMutation) AddedFields() []string {
+	var fields []string
+	if m.addmin_binary_version != nil {
+		fields = append(fields, schemacompatibility.FieldMinBinaryVersion)
 	}
-	return oldValue.Description, nil
+	return fields
 }
 
-// ClearDescription clears the value of the "description" field.
-func (m *TenantMutation) ClearDescription() {
-	m.description = nil
-	m.clearedFields[tenant.FieldDescription] = struct{}{}
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SchemaCompatibilityMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		return m.AddedMinBinaryVersion()
+	}
+	return nil, false
 }
 
-// DescriptionCleared returns if the "description" field was cleared in this mutation.
-func (m *TenantMutation) DescriptionCleared() bool {
-	_, ok := m.clearedFields[tenant.FieldDescription]
-	return ok
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SchemaCompatibilityMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMinBinaryVersion(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SchemaCompatibility numeric field %s", name)
 }
 
-// ResetDescription resets all changes to the "description" field.
-func (m *TenantMutation) ResetDescription() {
-	m.description = nil
-	delete(m.clearedFields, tenant.FieldDescription)
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SchemaCompatibilityMutation) ClearedFields() []string {
+	return nil
 }
 
-// SetParentTenantID sets the "parent_tenant_id" field.
-func (m *TenantMutation) SetParentTenantID(gi gidx.PrefixedID) {
-	m.parent = &gi
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SchemaCompatibilityMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// ParentTenantID returns the value of the "parent_tenant_id" field in the mutation.
-func (m *TenantMutation) ParentTenantID() (r gidx.PrefixedID, exists bool) {
-	v := m.parent
-	if v == nil {
-		return
-	}
-	return *v, true
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SchemaCompatibilityMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown SchemaCompatibility nullable field %s", name)
 }
 
-// OldParentTenantID returns the old "parent_tenant_id" field's value of the Tenant entity.
-// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *TenantMutation) OldParentTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldParentTenantID is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldParentTenantID requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldParentTenantID: %w", err)
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SchemaCompatibilityMutation) ResetField(name string) error {
+	switch name {
+	case schemacompatibility.FieldMinBinaryVersion:
+		m.ResetMinBinaryVersion()
+		return nil
 	}
-	return oldValue.ParentTenantID, nil
+	return fmt.Errorf("unknown SchemaCompatibility field %s", name)
 }
 
-// ClearParentTenantID clears the value of the "parent_tenant_id" field.
-func (m *TenantMutation) ClearParentTenantID() {
-	m.parent = nil
-	m.clearedFields[tenant.FieldParentTenantID] = struct{}{}
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SchemaCompatibilityMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ParentTenantIDCleared returns if the "parent_tenant_id" field was cleared in this mutation.
-func (m *TenantMutation) ParentTenantIDCleared() bool {
-	_, ok := m.clearedFields[tenant.FieldParentTenantID]
-	return ok
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SchemaCompatibilityMutation) AddedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ResetParentTenantID resets all changes to the "parent_tenant_id" field.
-func (m *TenantMutation) ResetParentTenantID() {
-	m.parent = nil
-	delete(m.clearedFields, tenant.FieldParentTenantID)
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SchemaCompatibilityMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// SetParentID sets the "parent" edge to the Tenant entity by id.
-func (m *TenantMutation) SetParentID(id gidx.PrefixedID) {
-	m.parent = &id
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SchemaCompatibilityMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ClearParent clears the "parent" edge to the Tenant entity.
-func (m *TenantMutation) ClearParent() {
-	m.clearedparent = true
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SchemaCompatibilityMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ParentCleared reports if the "parent" edge to the Tenant entity was cleared.
-func (m *TenantMutation) ParentCleared() bool {
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SchemaCompatibilityMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SchemaCompatibilityMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown SchemaCompatibility unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SchemaCompatibilityMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown SchemaCompatibility edge %s", name)
+}
+
+// ServiceAccountMutation represents an operation that mutates the ServiceAccount nodes in the graph.
+type ServiceAccountMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *gidx.PrefixedID
+	created_at    *time.Time
+	updated_at    *time.Time
+	name          *string
+	description   *string
+	created_by    *string
+	clearedFields map[string]struct{}
+	tenant        *gidx.PrefixedID
+	clearedtenant bool
+	done          bool
+	oldValue      func(context.Context) (*ServiceAccount, error)
+	predicates    []predicate.ServiceAccount
+}
+
+var _ ent.Mutation = (*ServiceAccountMutation)(nil)
+
+// serviceaccountOption allows management of the mutation configuration using functional options.
+type serviceaccountOption func(*ServiceAccountMutation)
+
+// newServiceAccountMutation creates new mutation for the ServiceAccount entity.
+func newServiceAccountMutation(c config, op Op, opts ...serviceaccountOption) *ServiceAccountMutation {
+	m := &ServiceAccountMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeServiceAccount,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withServiceAccountID sets the ID field of the mutation.
+func withServiceAccountID(id gidx.PrefixedID) serviceaccountOption {
+	return func(m *ServiceAccountMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ServiceAccount
+		)
+		m.oldValue = func(ctx context.Context) (*ServiceAccount, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ServiceAccount.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withServiceAccount sets the old ServiceAccount of the mutation.
+func withServiceAccount(node *ServiceAccount) serviceaccountOption {
+	return func(m *ServiceAccountMutation) {
+		m.oldValue = func(context.Context) (*ServiceAccount, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ServiceAccountMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ServiceAccountMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of ServiceAccount entities.
+func (m *ServiceAccountMutation) SetID(id gidx.PrefixedID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ServiceAccountMutation) ID() (id gidx.PrefixedID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ServiceAccountMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []gidx.PrefixedID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ServiceAccount.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ServiceAccountMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ServiceAccountMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ServiceAccountMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *ServiceAccountMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *ServiceAccountMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *ServiceAccountMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *ServiceAccountMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *ServiceAccountMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *ServiceAccountMutation) ResetTenantID() {
+	m.tenant = nil
+}
+
+// SetName sets the "name" field.
+func (m *ServiceAccountMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ServiceAccountMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *ServiceAccountMutation) ResetName() {
+	m.name = nil
+}
+
+// SetDescription sets the "description" field.
+func (m *ServiceAccountMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *ServiceAccountMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDescription returns the old "description" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldDescription(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *ServiceAccountMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[serviceaccount.FieldDescription] = struct{}{}
+}
+
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *ServiceAccountMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[serviceaccount.FieldDescription]
+	return ok
+}
+
+// ResetDescription resets all changes to the "description" field.
+func (m *ServiceAccountMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, serviceaccount.FieldDescription)
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (m *ServiceAccountMutation) SetCreatedBy(s string) {
+	m.created_by = &s
+}
+
+// CreatedBy returns the value of the "created_by" field in the mutation.
+func (m *ServiceAccountMutation) CreatedBy() (r string, exists bool) {
+	v := m.created_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedBy returns the old "created_by" field's value of the ServiceAccount entity.
+// If the ServiceAccount object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ServiceAccountMutation) OldCreatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+	}
+	return oldValue.CreatedBy, nil
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (m *ServiceAccountMutation) ClearCreatedBy() {
+	m.created_by = nil
+	m.clearedFields[serviceaccount.FieldCreatedBy] = struct{}{}
+}
+
+// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
+func (m *ServiceAccountMutation) CreatedByCleared() bool {
+	_, ok := m.clearedFields[serviceaccount.FieldCreatedBy]
+	return ok
+}
+
+// ResetCreatedBy resets all changes to the "created_by" field.
+func (m *ServiceAccountMutation) ResetCreatedBy() {
+	m.created_by = nil
+	delete(m.clearedFields, serviceaccount.FieldCreatedBy)
+}
+
+// ClearTenant clears the "tenant" edge to the Tenant entity.
+func (m *ServiceAccountMutation) ClearTenant() {
+	m.clearedtenant = true
+}
+
+// TenantCleared reports if the "tenant" edge to the Tenant entity was cleared.
+func (m *ServiceAccountMutation) TenantCleared() bool {
+	return m.clearedtenant
+}
+
+// TenantIDs returns the "tenant" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// TenantID instead. It exists only for internal usage by the builders.
+func (m *ServiceAccountMutation) TenantIDs() (ids []gidx.PrefixedID) {
+	if id := m.tenant; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetTenant resets all changes to the "tenant" edge.
+func (m *ServiceAccountMutation) ResetTenant() {
+	m.tenant = nil
+	m.clearedtenant = false
+}
+
+// Where appends a list predicates to the ServiceAccountMutation builder.
+func (m *ServiceAccountMutation) Where(ps ...predicate.ServiceAccount) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ServiceAccountMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ServiceAccountMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ServiceAccount, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ServiceAccountMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ServiceAccountMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ServiceAccount).
+func (m *ServiceAccountMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ServiceAccountMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.created_at != nil {
+		fields = append(fields, serviceaccount.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, serviceaccount.FieldUpdatedAt)
+	}
+	if m.tenant != nil {
+		fields = append(fields, serviceaccount.FieldTenantID)
+	}
+	if m.name != nil {
+		fields = append(fields, serviceaccount.FieldName)
+	}
+	if m.description != nil {
+		fields = append(fields, serviceaccount.FieldDescription)
+	}
+	if m.created_by != nil {
+		fields = append(fields, serviceaccount.FieldCreatedBy)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ServiceAccountMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case serviceaccount.FieldCreatedAt:
+		return m.CreatedAt()
+	case serviceaccount.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case serviceaccount.FieldTenantID:
+		return m.TenantID()
+	case serviceaccount.FieldName:
+		return m.Name()
+	case serviceaccount.FieldDescription:
+		return m.Description()
+	case serviceaccount.FieldCreatedBy:
+		return m.CreatedBy()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ServiceAccountMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case serviceaccount.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case serviceaccount.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case serviceaccount.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case serviceaccount.FieldName:
+		return m.OldName(ctx)
+	case serviceaccount.FieldDescription:
+		return m.OldDescription(ctx)
+	case serviceaccount.FieldCreatedBy:
+		return m.OldCreatedBy(ctx)
+	}
+	return nil, fmt.Errorf("unknown ServiceAccount field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ServiceAccountMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case serviceaccount.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case serviceaccount.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case serviceaccount.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenantID(v)
+		return nil
+	case serviceaccount.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case serviceaccount.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case serviceaccount.FieldCreatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedBy(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ServiceAccount field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ServiceAccountMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ServiceAccountMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ServiceAccountMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown ServiceAccount numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ServiceAccountMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(serviceaccount.FieldDescription) {
+		fields = append(fields, serviceaccount.FieldDescription)
+	}
+	if m.FieldCleared(serviceaccount.FieldCreatedBy) {
+		fields = append(fields, serviceaccount.FieldCreatedBy)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ServiceAccountMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ServiceAccountMutation) ClearField(name string) error {
+	switch name {
+	case serviceaccount.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case serviceaccount.FieldCreatedBy:
+		m.ClearCreatedBy()
+		return nil
+	}
+	return fmt.Errorf("unknown ServiceAccount nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ServiceAccountMutation) ResetField(name string) error {
+	switch name {
+	case serviceaccount.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case serviceaccount.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case serviceaccount.FieldTenantID:
+		m.ResetTenantID()
+		return nil
+	case serviceaccount.FieldName:
+		m.ResetName()
+		return nil
+	case serviceaccount.FieldDescription:
+		m.ResetDescription()
+		return nil
+	case serviceaccount.FieldCreatedBy:
+		m.ResetCreatedBy()
+		return nil
+	}
+	return fmt.Errorf("unknown ServiceAccount field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ServiceAccountMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.tenant != nil {
+		edges = append(edges, serviceaccount.EdgeTenant)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ServiceAccountMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case serviceaccount.EdgeTenant:
+		if id := m.tenant; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ServiceAccountMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ServiceAccountMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ServiceAccountMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedtenant {
+		edges = append(edges, serviceaccount.EdgeTenant)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ServiceAccountMutation) EdgeCleared(name string) bool {
+	switch name {
+	case serviceaccount.EdgeTenant:
+		return m.clearedtenant
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ServiceAccountMutation) ClearEdge(name string) error {
+	switch name {
+	case serviceaccount.EdgeTenant:
+		m.ClearTenant()
+		return nil
+	}
+	return fmt.Errorf("unknown ServiceAccount unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ServiceAccountMutation) ResetEdge(name string) error {
+	switch name {
+	case serviceaccount.EdgeTenant:
+		m.ResetTenant()
+		return nil
+	}
+	return fmt.Errorf("unknown ServiceAccount edge %s", name)
+}
+
+// TenantMutation represents an operation that mutates the Tenant nodes in the graph.
+type TenantMutation struct {
+	config
+	op                      Op
+	typ                     string
+	id                      *gidx.PrefixedID
+	created_at              *time.Time
+	updated_at              *time.Time
+	name                    *string
+	display_name            *string
+	description             *string
+	contact_email           *string
+	billing_reference       *string
+	deletion_scheduled_at   *time.Time
+	max_children            *int
+	addmax_children         *int
+	owner_id                *gidx.PrefixedID
+	suspended_at            *time.Time
+	external_id             *string
+	archived                *bool
+	frozen                  *bool
+	creation_frozen_until   *time.Time
+	deletion_protected      *bool
+	change_seq              *int64
+	addchange_seq           *int64
+	version                 *int64
+	addversion              *int64
+	unique_name             *bool
+	settings                *map[string]interface{}
+	labels                  *map[string]string
+	clearedFields           map[string]struct{}
+	parent                  *gidx.PrefixedID
+	clearedparent           bool
+	children                map[gidx.PrefixedID]struct{}
+	removedchildren         map[gidx.PrefixedID]struct{}
+	clearedchildren         bool
+	service_accounts        map[gidx.PrefixedID]struct{}
+	removedservice_accounts map[gidx.PrefixedID]struct{}
+	clearedservice_accounts bool
+	done                    bool
+	oldValue                func(context.Context) (*Tenant, error)
+	predicates              []predicate.Tenant
+}
+
+var _ ent.Mutation = (*TenantMutation)(nil)
+
+// tenantOption allows management of the mutation configuration using functional options.
+type tenantOption func(*TenantMutation)
+
+// newTenantMutation creates new mutation for the Tenant entity.
+func newTenantMutation(c config, op Op, opts ...tenantOption) *TenantMutation {
+	m := &TenantMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenant,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantID sets the ID field of the mutation.
+func withTenantID(id gidx.PrefixedID) tenantOption {
+	return func(m *TenantMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Tenant
+		)
+		m.oldValue = func(ctx context.Context) (*Tenant, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Tenant.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenant sets the old Tenant of the mutation.
+func withTenant(node *Tenant) tenantOption {
+	return func(m *TenantMutation) {
+		m.oldValue = func(context.Context) (*Tenant, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Tenant entities.
+func (m *TenantMutation) SetID(id gidx.PrefixedID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantMutation) ID() (id gidx.PrefixedID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []gidx.PrefixedID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Tenant.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *TenantMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *TenantMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *TenantMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *TenantMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *TenantMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *TenantMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetName sets the "name" field.
+func (m *TenantMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *TenantMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *TenantMutation) ResetName() {
+	m.name = nil
+}
+
+// SetDisplayName sets the "display_name" field.
+func (m *TenantMutation) SetDisplayName(s string) {
+	m.display_name = &s
+}
+
+// DisplayName returns the value of the "display_name" field in the mutation.
+func (m *TenantMutation) DisplayName() (r string, exists bool) {
+	v := m.display_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDisplayName returns the old "display_name" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldDisplayName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisplayName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisplayName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisplayName: %w", err)
+	}
+	return oldValue.DisplayName, nil
+}
+
+// ClearDisplayName clears the value of the "display_name" field.
+func (m *TenantMutation) ClearDisplayName() {
+	m.display_name = nil
+	m.clearedFields[tenant.FieldDisplayName] = struct{}{}
+}
+
+// DisplayNameCleared returns if the "display_name" field was cleared in this mutation.
+func (m *TenantMutation) DisplayNameCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldDisplayName]
+	return ok
+}
+
+// ResetDisplayName resets all changes to the "display_name" field.
+func (m *TenantMutation) ResetDisplayName() {
+	m.display_name = nil
+	delete(m.clearedFields, tenant.FieldDisplayName)
+}
+
+// SetDescription sets the "description" field.
+func (m *TenantMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *TenantMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDescription returns the old "description" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldDescription(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *TenantMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[tenant.FieldDescription] = struct{}{}
+}
+
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *TenantMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldDescription]
+	return ok
+}
+
+// ResetDescription resets all changes to the "description" field.
+func (m *TenantMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, tenant.FieldDescription)
+}
+
+// SetContactEmail sets the "contact_email" field.
+func (m *TenantMutation) SetContactEmail(s string) {
+	m.contact_email = &s
+}
+
+// ContactEmail returns the value of the "contact_email" field in the mutation.
+func (m *TenantMutation) ContactEmail() (r string, exists bool) {
+	v := m.contact_email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldContactEmail returns the old "contact_email" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldContactEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldContactEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldContactEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldContactEmail: %w", err)
+	}
+	return oldValue.ContactEmail, nil
+}
+
+// ClearContactEmail clears the value of the "contact_email" field.
+func (m *TenantMutation) ClearContactEmail() {
+	m.contact_email = nil
+	m.clearedFields[tenant.FieldContactEmail] = struct{}{}
+}
+
+// ContactEmailCleared returns if the "contact_email" field was cleared in this mutation.
+func (m *TenantMutation) ContactEmailCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldContactEmail]
+	return ok
+}
+
+// ResetContactEmail resets all changes to the "contact_email" field.
+func (m *TenantMutation) ResetContactEmail() {
+	m.contact_email = nil
+	delete(m.clearedFields, tenant.FieldContactEmail)
+}
+
+// SetBillingReference sets the "billing_reference" field.
+func (m *TenantMutation) SetBillingReference(s string) {
+	m.billing_reference = &s
+}
+
+// BillingReference returns the value of the "billing_reference" field in the mutation.
+func (m *TenantMutation) BillingReference() (r string, exists bool) {
+	v := m.billing_reference
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBillingReference returns the old "billing_reference" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldBillingReference(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBillingReference is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBillingReference requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBillingReference: %w", err)
+	}
+	return oldValue.BillingReference, nil
+}
+
+// ClearBillingReference clears the value of the "billing_reference" field.
+func (m *TenantMutation) ClearBillingReference() {
+	m.billing_reference = nil
+	m.clearedFields[tenant.FieldBillingReference] = struct{}{}
+}
+
+// BillingReferenceCleared returns if the "billing_reference" field was cleared in this mutation.
+func (m *TenantMutation) BillingReferenceCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldBillingReference]
+	return ok
+}
+
+// ResetBillingReference resets all changes to the "billing_reference" field.
+func (m *TenantMutation) ResetBillingReference() {
+	m.billing_reference = nil
+	delete(m.clearedFields, tenant.FieldBillingReference)
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (m *TenantMutation) SetParentTenantID(gi gidx.PrefixedID) {
+	m.parent = &gi
+}
+
+// ParentTenantID returns the value of the "parent_tenant_id" field in the mutation.
+func (m *TenantMutation) ParentTenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.parent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldParentTenantID returns the old "parent_tenant_id" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldParentTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldParentTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldParentTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldParentTenantID: %w", err)
+	}
+	return oldValue.ParentTenantID, nil
+}
+
+// ClearParentTenantID clears the value of the "parent_tenant_id" field.
+func (m *TenantMutation) ClearParentTenantID() {
+	m.parent = nil
+	m.clearedFields[tenant.FieldParentTenantID] = struct{}{}
+}
+
+// ParentTenantIDCleared returns if the "parent_tenant_id" field was cleared in this mutation.
+func (m *TenantMutation) ParentTenantIDCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldParentTenantID]
+	return ok
+}
+
+// ResetParentTenantID resets all changes to the "parent_tenant_id" field.
+func (m *TenantMutation) ResetParentTenantID() {
+	m.parent = nil
+	delete(m.clearedFields, tenant.FieldParentTenantID)
+}
+
+// SetDeletionScheduledAt sets the "deletion_scheduled_at" field.
+func (m *TenantMutation) SetDeletionScheduledAt(t time.Time) {
+	m.deletion_scheduled_at = &t
+}
+
+// DeletionScheduledAt returns the value of the "deletion_scheduled_at" field in the mutation.
+func (m *TenantMutation) DeletionScheduledAt() (r time.Time, exists bool) {
+	v := m.deletion_scheduled_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletionScheduledAt returns the old "deletion_scheduled_at" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldDeletionScheduledAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletionScheduledAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletionScheduledAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletionScheduledAt: %w", err)
+	}
+	return oldValue.DeletionScheduledAt, nil
+}
+
+// ClearDeletionScheduledAt clears the value of the "deletion_scheduled_at" field.
+func (m *TenantMutation) ClearDeletionScheduledAt() {
+	m.deletion_scheduled_at = nil
+	m.clearedFields[tenant.FieldDeletionScheduledAt] = struct{}{}
+}
+
+// DeletionScheduledAtCleared returns if the "deletion_scheduled_at" field was cleared in this mutation.
+func (m *TenantMutation) DeletionScheduledAtCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldDeletionScheduledAt]
+	return ok
+}
+
+// ResetDeletionScheduledAt resets all changes to the "deletion_scheduled_at" field.
+func (m *TenantMutation) ResetDeletionScheduledAt() {
+	m.deletion_scheduled_at = nil
+	delete(m.clearedFields, tenant.FieldDeletionScheduledAt)
+}
+
+// SetMaxChildren sets the "max_children" field.
+func (m *TenantMutation) SetMaxChildren(i int) {
+	m.max_children = &i
+	m.addmax_children = nil
+}
+
+// MaxChildren returns the value of the "max_children" field in the mutation.
+func (m *TenantMutation) MaxChildren() (r int, exists bool) {
+	v := m.max_children
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxChildren returns the old "max_children" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldMaxChildren(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxChildren is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxChildren requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxChildren: %w", err)
+	}
+	return oldValue.MaxChildren, nil
+}
+
+// AddMaxChildren adds i to the "max_children" field.
+func (m *TenantMutation) AddMaxChildren(i int) {
+	if m.addmax_children != nil {
+		*m.addmax_children += i
+	} else {
+		m.addmax_children = &i
+	}
+}
+
+// AddedMaxChildren returns the value that was added to the "max_children" field in this mutation.
+func (m *TenantMutation) AddedMaxChildren() (r int, exists bool) {
+	v := m.addmax_children
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearMaxChildren clears the value of the "max_children" field.
+func (m *TenantMutation) ClearMaxChildren() {
+	m.max_children = nil
+	m.addmax_children = nil
+	m.clearedFields[tenant.FieldMaxChildren] = struct{}{}
+}
+
+// MaxChildrenCleared returns if the "max_children" field was cleared in this mutation.
+func (m *TenantMutation) MaxChildrenCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldMaxChildren]
+	return ok
+}
+
+// ResetMaxChildren resets all changes to the "max_children" field.
+func (m *TenantMutation) ResetMaxChildren() {
+	m.max_children = nil
+	m.addmax_children = nil
+	delete(m.clearedFields, tenant.FieldMaxChildren)
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (m *TenantMutation) SetOwnerID(gi gidx.PrefixedID) {
+	m.owner_id = &gi
+}
+
+// OwnerID returns the value of the "owner_id" field in the mutation.
+func (m *TenantMutation) OwnerID() (r gidx.PrefixedID, exists bool) {
+	v := m.owner_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOwnerID returns the old "owner_id" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldOwnerID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOwnerID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOwnerID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOwnerID: %w", err)
+	}
+	return oldValue.OwnerID, nil
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (m *TenantMutation) ClearOwnerID() {
+	m.owner_id = nil
+	m.clearedFields[tenant.FieldOwnerID] = struct{}{}
+}
+
+// OwnerIDCleared returns if the "owner_id" field was cleared in this mutation.
+func (m *TenantMutation) OwnerIDCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldOwnerID]
+	return ok
+}
+
+// ResetOwnerID resets all changes to the "owner_id" field.
+func (m *TenantMutation) ResetOwnerID() {
+	m.owner_id = nil
+	delete(m.clearedFields, tenant.FieldOwnerID)
+}
+
+// SetSuspendedAt sets the "suspended_at" field.
+func (m *TenantMutation) SetSuspendedAt(t time.Time) {
+	m.suspended_at = &t
+}
+
+// SuspendedAt returns the value of the "suspended_at" field in the mutation.
+func (m *TenantMutation) SuspendedAt() (r time.Time, exists bool) {
+	v := m.suspended_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSuspendedAt returns the old "suspended_at" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldSuspendedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSuspendedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSuspendedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSuspendedAt: %w", err)
+	}
+	return oldValue.SuspendedAt, nil
+}
+
+// ClearSuspendedAt clears the value of the "suspended_at" field.
+func (m *TenantMutation) ClearSuspendedAt() {
+	m.suspended_at = nil
+	m.clearedFields[tenant.FieldSuspendedAt] = struct{}{}
+}
+
+// SuspendedAtCleared returns if the "suspended_at" field was cleared in this mutation.
+func (m *TenantMutation) SuspendedAtCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldSuspendedAt]
+	return ok
+}
+
+// ResetSuspendedAt resets all changes to the "suspended_at" field.
+func (m *TenantMutation) ResetSuspendedAt() {
+	m.suspended_at = nil
+	delete(m.clearedFields, tenant.FieldSuspendedAt)
+}
+
+// SetExternalID sets the "external_id" field.
+func (m *TenantMutation) SetExternalID(s string) {
+	m.external_id = &s
+}
+
+// ExternalID returns the value of the "external_id" field in the mutation.
+func (m *TenantMutation) ExternalID() (r string, exists bool) {
+	v := m.external_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExternalID returns the old "external_id" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldExternalID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExternalID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExternalID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExternalID: %w", err)
+	}
+	return oldValue.ExternalID, nil
+}
+
+// ClearExternalID clears the value of the "external_id" field.
+func (m *TenantMutation) ClearExternalID() {
+	m.external_id = nil
+	m.clearedFields[tenant.FieldExternalID] = struct{}{}
+}
+
+// ExternalIDCleared returns if the "external_id" field was cleared in this mutation.
+func (m *TenantMutation) ExternalIDCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldExternalID]
+	return ok
+}
+
+// ResetExternalID resets all changes to the "external_id" field.
+func (m *TenantMutation) ResetExternalID() {
+	m.external_id = nil
+	delete(m.clearedFields, tenant.FieldExternalID)
+}
+
+// SetArchived sets the "archived" field.
+func (m *TenantMutation) SetArchived(b bool) {
+	m.archived = &b
+}
+
+// Archived returns the value of the "archived" field in the mutation.
+func (m *TenantMutation) Archived() (r bool, exists bool) {
+	v := m.archived
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldArchived returns the old "archived" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldArchived(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldArchived is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldArchived requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldArchived: %w", err)
+	}
+	return oldValue.Archived, nil
+}
+
+// ClearArchived clears the value of the "archived" field.
+func (m *TenantMutation) ClearArchived() {
+	m.archived = nil
+	m.clearedFields[tenant.FieldArchived] = struct{}{}
+}
+
+// ArchivedCleared returns if the "archived" field was cleared in this mutation.
+func (m *TenantMutation) ArchivedCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldArchived]
+	return ok
+}
+
+// ResetArchived resets all changes to the "archived" field.
+func (m *TenantMutation) ResetArchived() {
+	m.archived = nil
+	delete(m.clearedFields, tenant.FieldArchived)
+}
+
+// SetFrozen sets the "frozen" field.
+func (m *TenantMutation) SetFrozen(b bool) {
+	m.frozen = &b
+}
+
+// Frozen returns the value of the "frozen" field in the mutation.
+func (m *TenantMutation) Frozen() (r bool, exists bool) {
+	v := m.frozen
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFrozen returns the old "frozen" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldFrozen(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFrozen is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFrozen requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFrozen: %w", err)
+	}
+	return oldValue.Frozen, nil
+}
+
+// ClearFrozen clears the value of the "frozen" field.
+func (m *TenantMutation) ClearFrozen() {
+	m.frozen = nil
+	m.clearedFields[tenant.FieldFrozen] = struct{}{}
+}
+
+// FrozenCleared returns if the "frozen" field was cleared in this mutation.
+func (m *TenantMutation) FrozenCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldFrozen]
+	return ok
+}
+
+// ResetFrozen resets all changes to the "frozen" field.
+func (m *TenantMutation) ResetFrozen() {
+	m.frozen = nil
+	delete(m.clearedFields, tenant.FieldFrozen)
+}
+
+// SetCreationFrozenUntil sets the "creation_frozen_until" field.
+func (m *TenantMutation) SetCreationFrozenUntil(t time.Time) {
+	m.creation_frozen_until = &t
+}
+
+// CreationFrozenUntil returns the value of the "creation_frozen_until" field in the mutation.
+func (m *TenantMutation) CreationFrozenUntil() (r time.Time, exists bool) {
+	v := m.creation_frozen_until
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreationFrozenUntil returns the old "creation_frozen_until" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldCreationFrozenUntil(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreationFrozenUntil is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreationFrozenUntil requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreationFrozenUntil: %w", err)
+	}
+	return oldValue.CreationFrozenUntil, nil
+}
+
+// ClearCreationFrozenUntil clears the value of the "creation_frozen_until" field.
+func (m *TenantMutation) ClearCreationFrozenUntil() {
+	m.creation_frozen_until = nil
+	m.clearedFields[tenant.FieldCreationFrozenUntil] = struct{}{}
+}
+
+// CreationFrozenUntilCleared returns if the "creation_frozen_until" field was cleared in this mutation.
+func (m *TenantMutation) CreationFrozenUntilCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldCreationFrozenUntil]
+	return ok
+}
+
+// ResetCreationFrozenUntil resets all changes to the "creation_frozen_until" field.
+func (m *TenantMutation) ResetCreationFrozenUntil() {
+	m.creation_frozen_until = nil
+	delete(m.clearedFields, tenant.FieldCreationFrozenUntil)
+}
+
+// SetDeletionProtected sets the "deletion_protected" field.
+func (m *TenantMutation) SetDeletionProtected(b bool) {
+	m.deletion_protected = &b
+}
+
+// DeletionProtected returns the value of the "deletion_protected" field in the mutation.
+func (m *TenantMutation) DeletionProtected() (r bool, exists bool) {
+	v := m.deletion_protected
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletionProtected returns the old "deletion_protected" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldDeletionProtected(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletionProtected is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletionProtected requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletionProtected: %w", err)
+	}
+	return oldValue.DeletionProtected, nil
+}
+
+// ClearDeletionProtected clears the value of the "deletion_protected" field.
+func (m *TenantMutation) ClearDeletionProtected() {
+	m.deletion_protected = nil
+	m.clearedFields[tenant.FieldDeletionProtected] = struct{}{}
+}
+
+// DeletionProtectedCleared returns if the "deletion_protected" field was cleared in this mutation.
+func (m *TenantMutation) DeletionProtectedCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldDeletionProtected]
+	return ok
+}
+
+// ResetDeletionProtected resets all changes to the "deletion_protected" field.
+func (m *TenantMutation) ResetDeletionProtected() {
+	m.deletion_protected = nil
+	delete(m.clearedFields, tenant.FieldDeletionProtected)
+}
+
+// SetChangeSeq sets the "change_seq" field.
+func (m *TenantMutation) SetChangeSeq(i int64) {
+	m.change_seq = &i
+	m.addchange_seq = nil
+}
+
+// ChangeSeq returns the value of the "change_seq" field in the mutation.
+func (m *TenantMutation) ChangeSeq() (r int64, exists bool) {
+	v := m.change_seq
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldChangeSeq returns the old "change_seq" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldChangeSeq(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldChangeSeq is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldChangeSeq requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldChangeSeq: %w", err)
+	}
+	return oldValue.ChangeSeq, nil
+}
+
+// AddChangeSeq adds i to the "change_seq" field.
+func (m *TenantMutation) AddChangeSeq(i int64) {
+	if m.addchange_seq != nil {
+		*m.addchange_seq += i
+	} else {
+		m.addchange_seq = &i
+	}
+}
+
+// AddedChangeSeq returns the value that was added to the "change_seq" field in this mutation.
+func (m *TenantMutation) AddedChangeSeq() (r int64, exists bool) {
+	v := m.addchange_seq
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearChangeSeq clears the value of the "change_seq" field.
+func (m *TenantMutation) ClearChangeSeq() {
+	m.change_seq = nil
+	m.addchange_seq = nil
+	m.clearedFields[tenant.FieldChangeSeq] = struct{}{}
+}
+
+// ChangeSeqCleared returns if the "change_seq" field was cleared in this mutation.
+func (m *TenantMutation) ChangeSeqCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldChangeSeq]
+	return ok
+}
+
+// ResetChangeSeq resets all changes to the "change_seq" field.
+func (m *TenantMutation) ResetChangeSeq() {
+	m.change_seq = nil
+	m.addchange_seq = nil
+	delete(m.clearedFields, tenant.FieldChangeSeq)
+}
+
+// SetVersion sets the "version" field.
+func (m *TenantMutation) SetVersion(i int64) {
+	m.version = &i
+	m.addversion = nil
+}
+
+// Version returns the value of the "version" field in the mutation.
+func (m *TenantMutation) Version() (r int64, exists bool) {
+	v := m.version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersion returns the old "version" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldVersion(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+	}
+	return oldValue.Version, nil
+}
+
+// AddVersion adds i to the "version" field.
+func (m *TenantMutation) AddVersion(i int64) {
+	if m.addversion != nil {
+		*m.addversion += i
+	} else {
+		m.addversion = &i
+	}
+}
+
+// AddedVersion returns the value that was added to the "version" field in this mutation.
+func (m *TenantMutation) AddedVersion() (r int64, exists bool) {
+	v := m.addversion
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearVersion clears the value of the "version" field.
+func (m *TenantMutation) ClearVersion() {
+	m.version = nil
+	m.addversion = nil
+	m.clearedFields[tenant.FieldVersion] = struct{}{}
+}
+
+// VersionCleared returns if the "version" field was cleared in this mutation.
+func (m *TenantMutation) VersionCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldVersion]
+	return ok
+}
+
+// ResetVersion resets all changes to the "version" field.
+func (m *TenantMutation) ResetVersion() {
+	m.version = nil
+	m.addversion = nil
+	delete(m.clearedFields, tenant.FieldVersion)
+}
+
+// SetUniqueName sets the "unique_name" field.
+func (m *TenantMutation) SetUniqueName(b bool) {
+	m.unique_name = &b
+}
+
+// UniqueName returns the value of the "unique_name" field in the mutation.
+func (m *TenantMutation) UniqueName() (r bool, exists bool) {
+	v := m.unique_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUniqueName returns the old "unique_name" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldUniqueName(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUniqueName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUniqueName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUniqueName: %w", err)
+	}
+	return oldValue.UniqueName, nil
+}
+
+// ClearUniqueName clears the value of the "unique_name" field.
+func (m *TenantMutation) ClearUniqueName() {
+	m.unique_name = nil
+	m.clearedFields[tenant.FieldUniqueName] = struct{}{}
+}
+
+// UniqueNameCleared returns if the "unique_name" field was cleared in this mutation.
+func (m *TenantMutation) UniqueNameCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldUniqueName]
+	return ok
+}
+
+// ResetUniqueName resets all changes to the "unique_name" field.
+func (m *TenantMutation) ResetUniqueName() {
+	m.unique_name = nil
+	delete(m.clearedFields, tenant.FieldUniqueName)
+}
+
+// SetSettings sets the "settings" field.
+func (m *TenantMutation) SetSettings(value map[string]interface{}) {
+	m.settings = &value
+}
+
+// Settings returns the value of the "settings" field in the mutation.
+func (m *TenantMutation) Settings() (r map[string]interface{}, exists bool) {
+	v := m.settings
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSettings returns the old "settings" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldSettings(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSettings is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSettings requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSettings: %w", err)
+	}
+	return oldValue.Settings, nil
+}
+
+// ClearSettings clears the value of the "settings" field.
+func (m *TenantMutation) ClearSettings() {
+	m.settings = nil
+	m.clearedFields[tenant.FieldSettings] = struct{}{}
+}
+
+// SettingsCleared returns if the "settings" field was cleared in this mutation.
+func (m *TenantMutation) SettingsCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldSettings]
+	return ok
+}
+
+// ResetSettings resets all changes to the "settings" field.
+func (m *TenantMutation) ResetSettings() {
+	m.settings = nil
+	delete(m.clearedFields, tenant.FieldSettings)
+}
+
+// SetLabels sets the "labels" field.
+func (m *TenantMutation) SetLabels(value map[string]string) {
+	m.labels = &value
+}
+
+// Labels returns the value of the "labels" field in the mutation.
+func (m *TenantMutation) Labels() (r map[string]string, exists bool) {
+	v := m.labels
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLabels returns the old "labels" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldLabels(ctx context.Context) (v map[string]string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLabels is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLabels requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLabels: %w", err)
+	}
+	return oldValue.Labels, nil
+}
+
+// ClearLabels clears the value of the "labels" field.
+func (m *TenantMutation) ClearLabels() {
+	m.labels = nil
+	m.clearedFields[tenant.FieldLabels] = struct{}{}
+}
+
+// LabelsCleared returns if the "labels" field was cleared in this mutation.
+func (m *TenantMutation) LabelsCleared() bool {
+	_, ok := m.clearedFields[tenant.FieldLabels]
+	return ok
+}
+
+// ResetLabels resets all changes to the "labels" field.
+func (m *TenantMutation) ResetLabels() {
+	m.labels = nil
+	delete(m.clearedFields, tenant.FieldLabels)
+}
+
+// SetParentID sets the "parent" edge to the Tenant entity by id.
+func (m *TenantMutation) SetParentID(id gidx.PrefixedID) {
+	m.parent = &id
+}
+
+// ClearParent clears the "parent" edge to the Tenant entity.
+func (m *TenantMutation) ClearParent() {
+	m.clearedparent = true
+}
+
+// ParentCleared reports if the "parent" edge to the Tenant entity was cleared.
+func (m *TenantMutation) ParentCleared() bool {
 	return m.ParentTenantIDCleared() || m.clearedparent
 }
 
-// ParentID returns the "parent" edge ID in the mutation.
-func (m *TenantMutation) ParentID() (id gidx.PrefixedID, exists bool) {
-	if m.parent != nil {
-		return *m.parent, true
-	}
-	return
+// ParentID returns the "parent" edge ID in the mutation.
+func (m *TenantMutation) ParentID() (id gidx.PrefixedID, exists bool) {
+	if m.parent != nil {
+		return *m.parent, true
+	}
+	return
+}
+
+// ParentIDs returns the "parent" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ParentID instead. It exists only for internal usage by the builders.
+func (m *TenantMutation) ParentIDs() (ids []gidx.PrefixedID) {
+	if id := m.parent; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetParent resets all changes to the "parent" edge.
+func (m *TenantMutation) ResetParent() {
+	m.parent = nil
+	m.clearedparent = false
+}
+
+// AddChildIDs adds the "children" edge to the Tenant entity by ids.
+func (m *TenantMutation) AddChildIDs(ids ...gidx.PrefixedID) {
+	if m.children == nil {
+		m.children = make(map[gidx.PrefixedID]struct{})
+	}
+	for i := range ids {
+		m.children[ids[i]] = struct{}{}
+	}
+}
+
+// ClearChildren clears the "children" edge to the Tenant entity.
+func (m *TenantMutation) ClearChildren() {
+	m.clearedchildren = true
+}
+
+// ChildrenCleared reports if the "children" edge to the Tenant entity was cleared.
+func (m *TenantMutation) ChildrenCleared() bool {
+	return m.clearedchildren
+}
+
+// RemoveChildIDs removes the "children" edge to the Tenant entity by IDs.
+func (m *TenantMutation) RemoveChildIDs(ids ...gidx.PrefixedID) {
+	if m.removedchildren == nil {
+		m.removedchildren = make(map[gidx.PrefixedID]struct{})
+	}
+	for i := range ids {
+		delete(m.children, ids[i])
+		m.removedchildren[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedChildren returns the removed IDs of the "children" edge to the Tenant entity.
+func (m *TenantMutation) RemovedChildrenIDs() (ids []gidx.PrefixedID) {
+	for id := range m.removedchildren {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ChildrenIDs returns the "children" edge IDs in the mutation.
+func (m *TenantMutation) ChildrenIDs() (ids []gidx.PrefixedID) {
+	for id := range m.children {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetChildren resets all changes to the "children" edge.
+func (m *TenantMutation) ResetChildren() {
+	m.children = nil
+	m.clearedchildren = false
+	m.removedchildren = nil
+}
+
+// AddServiceAccountIDs adds the "service_accounts" edge to the ServiceAccount entity by ids.
+func (m *TenantMutation) AddServiceAccountIDs(ids ...gidx.PrefixedID) {
+	if m.service_accounts == nil {
+		m.service_accounts = make(map[gidx.PrefixedID]struct{})
+	}
+	for i := range ids {
+		m.service_accounts[ids[i]] = struct{}{}
+	}
+}
+
+// ClearServiceAccounts clears the "service_accounts" edge to the ServiceAccount entity.
+func (m *TenantMutation) ClearServiceAccounts() {
+	m.clearedservice_accounts = true
+}
+
+// ServiceAccountsCleared reports if the "service_accounts" edge to the ServiceAccount entity was cleared.
+func (m *TenantMutation) ServiceAccountsCleared() bool {
+	return m.clearedservice_accounts
+}
+
+// RemoveServiceAccountIDs removes the "service_accounts" edge to the ServiceAccount entity by IDs.
+func (m *TenantMutation) RemoveServiceAccountIDs(ids ...gidx.PrefixedID) {
+	if m.removedservice_accounts == nil {
+		m.removedservice_accounts = make(map[gidx.PrefixedID]struct{})
+	}
+	for i := range ids {
+		delete(m.service_accounts, ids[i])
+		m.removedservice_accounts[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedServiceAccounts returns the removed IDs of the "service_accounts" edge to the ServiceAccount entity.
+func (m *TenantMutation) RemovedServiceAccountsIDs() (ids []gidx.PrefixedID) {
+	for id := range m.removedservice_accounts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ServiceAccountsIDs returns the "service_accounts" edge IDs in the mutation.
+func (m *TenantMutation) ServiceAccountsIDs() (ids []gidx.PrefixedID) {
+	for id := range m.service_accounts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetServiceAccounts resets all changes to the "service_accounts" edge.
+func (m *TenantMutation) ResetServiceAccounts() {
+	m.service_accounts = nil
+	m.clearedservice_accounts = false
+	m.removedservice_accounts = nil
+}
+
+// Where appends a list predicates to the TenantMutation builder.
+func (m *TenantMutation) Where(ps ...predicate.Tenant) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Tenant, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Tenant).
+func (m *TenantMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantMutation) Fields() []string {
+	fields := make([]string, 0, 22)
+	if m.created_at != nil {
+		fields = append(fields, tenant.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, tenant.FieldUpdatedAt)
+	}
+	if m.name != nil {
+		fields = append(fields, tenant.FieldName)
+	}
+	if m.display_name != nil {
+		fields = append(fields, tenant.FieldDisplayName)
+	}
+	if m.description != nil {
+		fields = append(fields, tenant.FieldDescription)
+	}
+	if m.contact_email != nil {
+		fields = append(fields, tenant.FieldContactEmail)
+	}
+	if m.billing_reference != nil {
+		fields = append(fields, tenant.FieldBillingReference)
+	}
+	if m.parent != nil {
+		fields = append(fields, tenant.FieldParentTenantID)
+	}
+	if m.deletion_scheduled_at != nil {
+		fields = append(fields, tenant.FieldDeletionScheduledAt)
+	}
+	if m.max_children != nil {
+		fields = append(fields, tenant.FieldMaxChildren)
+	}
+	if m.owner_id != nil {
+		fields = append(fields, tenant.FieldOwnerID)
+	}
+	if m.suspended_at != nil {
+		fields = append(fields, tenant.FieldSuspendedAt)
+	}
+	if m.external_id != nil {
+		fields = append(fields, tenant.FieldExternalID)
+	}
+	if m.archived != nil {
+		fields = append(fields, tenant.FieldArchived)
+	}
+	if m.frozen != nil {
+		fields = append(fields, tenant.FieldFrozen)
+	}
+	if m.creation_frozen_until != nil {
+		fields = append(fields, tenant.FieldCreationFrozenUntil)
+	}
+	if m.deletion_protected != nil {
+		fields = append(fields, tenant.FieldDeletionProtected)
+	}
+	if m.change_seq != nil {
+		fields = append(fields, tenant.FieldChangeSeq)
+	}
+	if m.version != nil {
+		fields = append(fields, tenant.FieldVersion)
+	}
+	if m.unique_name != nil {
+		fields = append(fields, tenant.FieldUniqueName)
+	}
+	if m.settings != nil {
+		fields = append(fields, tenant.FieldSettings)
+	}
+	if m.labels != nil {
+		fields = append(fields, tenant.FieldLabels)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenant.FieldCreatedAt:
+		return m.CreatedAt()
+	case tenant.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case tenant.FieldName:
+		return m.Name()
+	case tenant.FieldDisplayName:
+		return m.DisplayName()
+	case tenant.FieldDescription:
+		return m.Description()
+	case tenant.FieldContactEmail:
+		return m.ContactEmail()
+	case tenant.FieldBillingReference:
+		return m.BillingReference()
+	case tenant.FieldParentTenantID:
+		return m.ParentTenantID()
+	case tenant.FieldDeletionScheduledAt:
+		return m.DeletionScheduledAt()
+	case tenant.FieldMaxChildren:
+		return m.MaxChildren()
+	case tenant.FieldOwnerID:
+		return m.OwnerID()
+	case tenant.FieldSuspendedAt:
+		return m.SuspendedAt()
+	case tenant.FieldExternalID:
+		return m.ExternalID()
+	case tenant.FieldArchived:
+		return m.Archived()
+	case tenant.FieldFrozen:
+		return m.Frozen()
+	case tenant.FieldCreationFrozenUntil:
+		return m.CreationFrozenUntil()
+	case tenant.FieldDeletionProtected:
+		return m.DeletionProtected()
+	case tenant.FieldChangeSeq:
+		return m.ChangeSeq()
+	case tenant.FieldVersion:
+		return m.Version()
+	case tenant.FieldUniqueName:
+		return m.UniqueName()
+	case tenant.FieldSettings:
+		return m.Settings()
+	case tenant.FieldLabels:
+		return m.Labels()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenant.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case tenant.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case tenant.FieldName:
+		return m.OldName(ctx)
+	case tenant.FieldDisplayName:
+		return m.OldDisplayName(ctx)
+	case tenant.FieldDescription:
+		return m.OldDescription(ctx)
+	case tenant.FieldContactEmail:
+		return m.OldContactEmail(ctx)
+	case tenant.FieldBillingReference:
+		return m.OldBillingReference(ctx)
+	case tenant.FieldParentTenantID:
+		return m.OldParentTenantID(ctx)
+	case tenant.FieldDeletionScheduledAt:
+		return m.OldDeletionScheduledAt(ctx)
+	case tenant.FieldMaxChildren:
+		return m.OldMaxChildren(ctx)
+	case tenant.FieldOwnerID:
+		return m.OldOwnerID(ctx)
+	case tenant.FieldSuspendedAt:
+		return m.OldSuspendedAt(ctx)
+	case tenant.FieldExternalID:
+		return m.OldExternalID(ctx)
+	case tenant.FieldArchived:
+		return m.OldArchived(ctx)
+	case tenant.FieldFrozen:
+		return m.OldFrozen(ctx)
+	case tenant.FieldCreationFrozenUntil:
+		return m.OldCreationFrozenUntil(ctx)
+	case tenant.FieldDeletionProtected:
+		return m.OldDeletionProtected(ctx)
+	case tenant.FieldChangeSeq:
+		return m.OldChangeSeq(ctx)
+	case tenant.FieldVersion:
+		return m.OldVersion(ctx)
+	case tenant.FieldUniqueName:
+		return m.OldUniqueName(ctx)
+	case tenant.FieldSettings:
+		return m.OldSettings(ctx)
+	case tenant.FieldLabels:
+		return m.OldLabels(ctx)
+	}
+	return nil, fmt.Errorf("unknown Tenant field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenant.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case tenant.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case tenant.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case tenant.FieldDisplayName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisplayName(v)
+		return nil
+	case tenant.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case tenant.FieldContactEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContactEmail(v)
+		return nil
+	case tenant.FieldBillingReference:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBillingReference(v)
+		return nil
+	case tenant.FieldParentTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetParentTenantID(v)
+		return nil
+	case tenant.FieldDeletionScheduledAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletionScheduledAt(v)
+		return nil
+	case tenant.FieldMaxChildren:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxChildren(v)
+		return nil
+	case tenant.FieldOwnerID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOwnerID(v)
+		return nil
+	case tenant.FieldSuspendedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSuspendedAt(v)
+		return nil
+	case tenant.FieldExternalID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExternalID(v)
+		return nil
+	case tenant.FieldArchived:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetArchived(v)
+		return nil
+	case tenant.FieldFrozen:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFrozen(v)
+		return nil
+	case tenant.FieldCreationFrozenUntil:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreationFrozenUntil(v)
+		return nil
+	case tenant.FieldDeletionProtected:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletionProtected(v)
+		return nil
+	case tenant.FieldChangeSeq:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetChangeSeq(v)
+		return nil
+	case tenant.FieldVersion:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case tenant.FieldUniqueName:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUniqueName(v)
+		return nil
+	case tenant.FieldSettings:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSettings(v)
+		return nil
+	case tenant.FieldLabels:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLabels(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantMutation) AddedFields() []string {
+	var fields []string
+	if m.addmax_children != nil {
+		fields = append(fields, tenant.FieldMaxChildren)
+	}
+	if m.addchange_seq != nil {
+		fields = append(fields, tenant.FieldChangeSeq)
+	}
+	if m.addversion != nil {
+		fields = append(fields, tenant.FieldVersion)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case tenant.FieldMaxChildren:
+		return m.AddedMaxChildren()
+	case tenant.FieldChangeSeq:
+		return m.AddedChangeSeq()
+	case tenant.FieldVersion:
+		return m.AddedVersion()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case tenant.FieldMaxChildren:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxChildren(v)
+		return nil
+	case tenant.FieldChangeSeq:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddChangeSeq(v)
+		return nil
+	case tenant.FieldVersion:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVersion(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(tenant.FieldDisplayName) {
+		fields = append(fields, tenant.FieldDisplayName)
+	}
+	if m.FieldCleared(tenant.FieldDescription) {
+		fields = append(fields, tenant.FieldDescription)
+	}
+	if m.FieldCleared(tenant.FieldContactEmail) {
+		fields = append(fields, tenant.FieldContactEmail)
+	}
+	if m.FieldCleared(tenant.FieldBillingReference) {
+		fields = append(fields, tenant.FieldBillingReference)
+	}
+	if m.FieldCleared(tenant.FieldParentTenantID) {
+		fields = append(fields, tenant.FieldParentTenantID)
+	}
+	if m.FieldCleared(tenant.FieldDeletionScheduledAt) {
+		fields = append(fields, tenant.FieldDeletionScheduledAt)
+	}
+	if m.FieldCleared(tenant.FieldMaxChildren) {
+		fields = append(fields, tenant.FieldMaxChildren)
+	}
+	if m.FieldCleared(tenant.FieldOwnerID) {
+		fields = append(fields, tenant.FieldOwnerID)
+	}
+	if m.FieldCleared(tenant.FieldSuspendedAt) {
+		fields = append(fields, tenant.FieldSuspendedAt)
+	}
+	if m.FieldCleared(tenant.FieldExternalID) {
+		fields = append(fields, tenant.FieldExternalID)
+	}
+	if m.FieldCleared(tenant.FieldArchived) {
+		fields = append(fields, tenant.FieldArchived)
+	}
+	if m.FieldCleared(tenant.FieldFrozen) {
+		fields = append(fields, tenant.FieldFrozen)
+	}
+	if m.FieldCleared(tenant.FieldCreationFrozenUntil) {
+		fields = append(fields, tenant.FieldCreationFrozenUntil)
+	}
+	if m.FieldCleared(tenant.FieldDeletionProtected) {
+		fields = append(fields, tenant.FieldDeletionProtected)
+	}
+	if m.FieldCleared(tenant.FieldChangeSeq) {
+		fields = append(fields, tenant.FieldChangeSeq)
+	}
+	if m.FieldCleared(tenant.FieldVersion) {
+		fields = append(fields, tenant.FieldVersion)
+	}
+	if m.FieldCleared(tenant.FieldUniqueName) {
+		fields = append(fields, tenant.FieldUniqueName)
+	}
+	if m.FieldCleared(tenant.FieldSettings) {
+		fields = append(fields, tenant.FieldSettings)
+	}
+	if m.FieldCleared(tenant.FieldLabels) {
+		fields = append(fields, tenant.FieldLabels)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantMutation) ClearField(name string) error {
+	switch name {
+	case tenant.FieldDisplayName:
+		m.ClearDisplayName()
+		return nil
+	case tenant.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case tenant.FieldContactEmail:
+		m.ClearContactEmail()
+		return nil
+	case tenant.FieldBillingReference:
+		m.ClearBillingReference()
+		return nil
+	case tenant.FieldParentTenantID:
+		m.ClearParentTenantID()
+		return nil
+	case tenant.FieldDeletionScheduledAt:
+		m.ClearDeletionScheduledAt()
+		return nil
+	case tenant.FieldMaxChildren:
+		m.ClearMaxChildren()
+		return nil
+	case tenant.FieldOwnerID:
+		m.ClearOwnerID()
+		return nil
+	case tenant.FieldSuspendedAt:
+		m.ClearSuspendedAt()
+		return nil
+	case tenant.FieldExternalID:
+		m.ClearExternalID()
+		return nil
+	case tenant.FieldArchived:
+		m.ClearArchived()
+		return nil
+	case tenant.FieldFrozen:
+		m.ClearFrozen()
+		return nil
+	case tenant.FieldCreationFrozenUntil:
+		m.ClearCreationFrozenUntil()
+		return nil
+	case tenant.FieldDeletionProtected:
+		m.ClearDeletionProtected()
+		return nil
+	case tenant.FieldChangeSeq:
+		m.ClearChangeSeq()
+		return nil
+	case tenant.FieldVersion:
+		m.ClearVersion()
+		return nil
+	case tenant.FieldUniqueName:
+		m.ClearUniqueName()
+		return nil
+	case tenant.FieldSettings:
+		m.ClearSettings()
+		return nil
+	case tenant.FieldLabels:
+		m.ClearLabels()
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantMutation) ResetField(name string) error {
+	switch name {
+	case tenant.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case tenant.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case tenant.FieldName:
+		m.ResetName()
+		return nil
+	case tenant.FieldDisplayName:
+		m.ResetDisplayName()
+		return nil
+	case tenant.FieldDescription:
+		m.ResetDescription()
+		return nil
+	case tenant.FieldContactEmail:
+		m.ResetContactEmail()
+		return nil
+	case tenant.FieldBillingReference:
+		m.ResetBillingReference()
+		return nil
+	case tenant.FieldParentTenantID:
+		m.ResetParentTenantID()
+		return nil
+	case tenant.FieldDeletionScheduledAt:
+		m.ResetDeletionScheduledAt()
+		return nil
+	case tenant.FieldMaxChildren:
+		m.ResetMaxChildren()
+		return nil
+	case tenant.FieldOwnerID:
+		m.ResetOwnerID()
+		return nil
+	case tenant.FieldSuspendedAt:
+		m.ResetSuspendedAt()
+		return nil
+	case tenant.FieldExternalID:
+		m.ResetExternalID()
+		return nil
+	case tenant.FieldArchived:
+		m.ResetArchived()
+		return nil
+	case tenant.FieldFrozen:
+		m.ResetFrozen()
+		return nil
+	case tenant.FieldCreationFrozenUntil:
+		m.ResetCreationFrozenUntil()
+		return nil
+	case tenant.FieldDeletionProtected:
+		m.ResetDeletionProtected()
+		return nil
+	case tenant.FieldChangeSeq:
+		m.ResetChangeSeq()
+		return nil
+	case tenant.FieldVersion:
+		m.ResetVersion()
+		return nil
+	case tenant.FieldUniqueName:
+		m.ResetUniqueName()
+		return nil
+	case tenant.FieldSettings:
+		m.ResetSettings()
+		return nil
+	case tenant.FieldLabels:
+		m.ResetLabels()
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.parent != nil {
+		edges = append(edges, tenant.EdgeParent)
+	}
+	if m.children != nil {
+		edges = append(edges, tenant.EdgeChildren)
+	}
+	if m.service_accounts != nil {
+		edges = append(edges, tenant.EdgeServiceAccounts)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case tenant.EdgeParent:
+		if id := m.parent; id != nil {
+			return []ent.Value{*id}
+		}
+	case tenant.EdgeChildren:
+		ids := make([]ent.Value, 0, len(m.children))
+		for id := range m.children {
+			ids = append(ids, id)
+		}
+		return ids
+	case tenant.EdgeServiceAccounts:
+		ids := make([]ent.Value, 0, len(m.service_accounts))
+		for id := range m.service_accounts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.removedchildren != nil {
+		edges = append(edges, tenant.EdgeChildren)
+	}
+	if m.removedservice_accounts != nil {
+		edges = append(edges, tenant.EdgeServiceAccounts)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case tenant.EdgeChildren:
+		ids := make([]ent.Value, 0, len(m.removedchildren))
+		for id := range m.removedchildren {
+			ids = append(ids, id)
+		}
+		return ids
+	case tenant.EdgeServiceAccounts:
+		ids := make([]ent.Value, 0, len(m.removedservice_accounts))
+		for id := range m.removedservice_accounts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
+	if m.clearedparent {
+		edges = append(edges, tenant.EdgeParent)
+	}
+	if m.clearedchildren {
+		edges = append(edges, tenant.EdgeChildren)
+	}
+	if m.clearedservice_accounts {
+		edges = append(edges, tenant.EdgeServiceAccounts)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantMutation) EdgeCleared(name string) bool {
+	switch name {
+	case tenant.EdgeParent:
+		return m.clearedparent
+	case tenant.EdgeChildren:
+		return m.clearedchildren
+	case tenant.EdgeServiceAccounts:
+		return m.clearedservice_accounts
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantMutation) ClearEdge(name string) error {
+	switch name {
+	case tenant.EdgeParent:
+		m.ClearParent()
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantMutation) ResetEdge(name string) error {
+	switch name {
+	case tenant.EdgeParent:
+		m.ResetParent()
+		return nil
+	case tenant.EdgeChildren:
+		m.ResetChildren()
+		return nil
+	case tenant.EdgeServiceAccounts:
+		m.ResetServiceAccounts()
+		return nil
+	}
+	return fmt.Errorf("unknown Tenant edge %s", name)
+}
+
+// TenantAuditMutation represents an operation that mutates the TenantAudit nodes in the graph.
+type TenantAuditMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *gidx.PrefixedID
+	tenant_id        *gidx.PrefixedID
+	actor            *string
+	operation        *string
+	attempted_change *string
+	outcome          *string
+	code             *string
+	recorded_at      *time.Time
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*TenantAudit, error)
+	predicates       []predicate.TenantAudit
+}
+
+var _ ent.Mutation = (*TenantAuditMutation)(nil)
+
+// tenantauditOption allows management of the mutation configuration using functional options.
+type tenantauditOption func(*TenantAuditMutation)
+
+// newTenantAuditMutation creates new mutation for the TenantAudit entity.
+func newTenantAuditMutation(c config, op Op, opts ...tenantauditOption) *TenantAuditMutation {
+	m := &TenantAuditMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantAudit,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantAuditID sets the ID field of the mutation.
+func withTenantAuditID(id gidx.PrefixedID) tenantauditOption {
+	return func(m *TenantAuditMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantAudit
+		)
+		m.oldValue = func(ctx context.Context) (*TenantAudit, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantAudit.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantAudit sets the old TenantAudit of the mutation.
+func withTenantAudit(node *TenantAudit) tenantauditOption {
+	return func(m *TenantAuditMutation) {
+		m.oldValue = func(context.Context) (*TenantAudit, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantAuditMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantAuditMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantAudit entities.
+func (m *TenantAuditMutation) SetID(id gidx.PrefixedID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantAuditMutation) ID() (id gidx.PrefixedID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantAuditMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []gidx.PrefixedID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantAudit.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *TenantAuditMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant_id = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *TenantAuditMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *TenantAuditMutation) ResetTenantID() {
+	m.tenant_id = nil
+}
+
+// SetActor sets the "actor" field.
+func (m *TenantAuditMutation) SetActor(s string) {
+	m.actor = &s
+}
+
+// Actor returns the value of the "actor" field in the mutation.
+func (m *TenantAuditMutation) Actor() (r string, exists bool) {
+	v := m.actor
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActor returns the old "actor" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldActor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActor: %w", err)
+	}
+	return oldValue.Actor, nil
+}
+
+// ClearActor clears the value of the "actor" field.
+func (m *TenantAuditMutation) ClearActor() {
+	m.actor = nil
+	m.clearedFields[tenantaudit.FieldActor] = struct{}{}
+}
+
+// ActorCleared returns if the "actor" field was cleared in this mutation.
+func (m *TenantAuditMutation) ActorCleared() bool {
+	_, ok := m.clearedFields[tenantaudit.FieldActor]
+	return ok
+}
+
+// ResetActor resets all changes to the "actor" field.
+func (m *TenantAuditMutation) ResetActor() {
+	m.actor = nil
+	delete(m.clearedFields, tenantaudit.FieldActor)
+}
+
+// SetOperation sets the "operation" field.
+func (m *TenantAuditMutation) SetOperation(s string) {
+	m.operation = &s
+}
+
+// Operation returns the value of the "operation" field in the mutation.
+func (m *TenantAuditMutation) Operation() (r string, exists bool) {
+	v := m.operation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOperation returns the old "operation" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldOperation(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOperation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOperation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOperation: %w", err)
+	}
+	return oldValue.Operation, nil
+}
+
+// ResetOperation resets all changes to the "operation" field.
+func (m *TenantAuditMutation) ResetOperation() {
+	m.operation = nil
+}
+
+// SetAttemptedChange sets the "attempted_change" field.
+func (m *TenantAuditMutation) SetAttemptedChange(s string) {
+	m.attempted_change = &s
+}
+
+// AttemptedChange returns the value of the "attempted_change" field in the mutation.
+func (m *TenantAuditMutation) AttemptedChange() (r string, exists bool) {
+	v := m.attempted_change
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAttemptedChange returns the old "attempted_change" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldAttemptedChange(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAttemptedChange is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAttemptedChange requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAttemptedChange: %w", err)
+	}
+	return oldValue.AttemptedChange, nil
+}
+
+// ClearAttemptedChange clears the value of the "attempted_change" field.
+func (m *TenantAuditMutation) ClearAttemptedChange() {
+	m.attempted_change = nil
+	m.clearedFields[tenantaudit.FieldAttemptedChange] = struct{}{}
+}
+
+// AttemptedChangeCleared returns if the "attempted_change" field was cleared in this mutation.
+func (m *TenantAuditMutation) AttemptedChangeCleared() bool {
+	_, ok := m.clearedFields[tenantaudit.FieldAttemptedChange]
+	return ok
+}
+
+// ResetAttemptedChange resets all changes to the "attempted_change" field.
+func (m *TenantAuditMutation) ResetAttemptedChange() {
+	m.attempted_change = nil
+	delete(m.clearedFields, tenantaudit.FieldAttemptedChange)
+}
+
+// SetOutcome sets the "outcome" field.
+func (m *TenantAuditMutation) SetOutcome(s string) {
+	m.outcome = &s
+}
+
+// Outcome returns the value of the "outcome" field in the mutation.
+func (m *TenantAuditMutation) Outcome() (r string, exists bool) {
+	v := m.outcome
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOutcome returns the old "outcome" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldOutcome(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOutcome is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOutcome requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOutcome: %w", err)
+	}
+	return oldValue.Outcome, nil
+}
+
+// ResetOutcome resets all changes to the "outcome" field.
+func (m *TenantAuditMutation) ResetOutcome() {
+	m.outcome = nil
+}
+
+// SetCode sets the "code" field.
+func (m *TenantAuditMutation) SetCode(s string) {
+	m.code = &s
+}
+
+// Code returns the value of the "code" field in the mutation.
+func (m *TenantAuditMutation) Code() (r string, exists bool) {
+	v := m.code
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCode returns the old "code" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldCode(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCode is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCode requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCode: %w", err)
+	}
+	return oldValue.Code, nil
+}
+
+// ClearCode clears the value of the "code" field.
+func (m *TenantAuditMutation) ClearCode() {
+	m.code = nil
+	m.clearedFields[tenantaudit.FieldCode] = struct{}{}
+}
+
+// CodeCleared returns if the "code" field was cleared in this mutation.
+func (m *TenantAuditMutation) CodeCleared() bool {
+	_, ok := m.clearedFields[tenantaudit.FieldCode]
+	return ok
+}
+
+// ResetCode resets all changes to the "code" field.
+func (m *TenantAuditMutation) ResetCode() {
+	m.code = nil
+	delete(m.clearedFields, tenantaudit.FieldCode)
+}
+
+// SetRecordedAt sets the "recorded_at" field.
+func (m *TenantAuditMutation) SetRecordedAt(t time.Time) {
+	m.recorded_at = &t
+}
+
+// RecordedAt returns the value of the "recorded_at" field in the mutation.
+func (m *TenantAuditMutation) RecordedAt() (r time.Time, exists bool) {
+	v := m.recorded_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRecordedAt returns the old "recorded_at" field's value of the TenantAudit entity.
+// If the TenantAudit object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantAuditMutation) OldRecordedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRecordedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRecordedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRecordedAt: %w", err)
+	}
+	return oldValue.RecordedAt, nil
+}
+
+// ResetRecordedAt resets all changes to the "recorded_at" field.
+func (m *TenantAuditMutation) ResetRecordedAt() {
+	m.recorded_at = nil
+}
+
+// Where appends a list predicates to the TenantAuditMutation builder.
+func (m *TenantAuditMutation) Where(ps ...predicate.TenantAudit) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantAuditMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantAuditMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantAudit, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantAuditMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantAuditMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TenantAudit).
+func (m *TenantAuditMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantAuditMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.tenant_id != nil {
+		fields = append(fields, tenantaudit.FieldTenantID)
+	}
+	if m.actor != nil {
+		fields = append(fields, tenantaudit.FieldActor)
+	}
+	if m.operation != nil {
+		fields = append(fields, tenantaudit.FieldOperation)
+	}
+	if m.attempted_change != nil {
+		fields = append(fields, tenantaudit.FieldAttemptedChange)
+	}
+	if m.outcome != nil {
+		fields = append(fields, tenantaudit.FieldOutcome)
+	}
+	if m.code != nil {
+		fields = append(fields, tenantaudit.FieldCode)
+	}
+	if m.recorded_at != nil {
+		fields = append(fields, tenantaudit.FieldRecordedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantAuditMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenantaudit.FieldTenantID:
+		return m.TenantID()
+	case tenantaudit.FieldActor:
+		return m.Actor()
+	case tenantaudit.FieldOperation:
+		return m.Operation()
+	case tenantaudit.FieldAttemptedChange:
+		return m.AttemptedChange()
+	case tenantaudit.FieldOutcome:
+		return m.Outcome()
+	case tenantaudit.FieldCode:
+		return m.Code()
+	case tenantaudit.FieldRecordedAt:
+		return m.RecordedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantAuditMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenantaudit.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case tenantaudit.FieldActor:
+		return m.OldActor(ctx)
+	case tenantaudit.FieldOperation:
+		return m.OldOperation(ctx)
+	case tenantaudit.FieldAttemptedChange:
+		return m.OldAttemptedChange(ctx)
+	case tenantaudit.FieldOutcome:
+		return m.OldOutcome(ctx)
+	case tenantaudit.FieldCode:
+		return m.OldCode(ctx)
+	case tenantaudit.FieldRecordedAt:
+		return m.OldRecordedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown TenantAudit field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantAuditMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenantaudit.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenantID(v)
+		return nil
+	case tenantaudit.FieldActor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActor(v)
+		return nil
+	case tenantaudit.FieldOperation:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOperation(v)
+		return nil
+	case tenantaudit.FieldAttemptedChange:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttemptedChange(v)
+		return nil
+	case tenantaudit.FieldOutcome:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOutcome(v)
+		return nil
+	case tenantaudit.FieldCode:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCode(v)
+		return nil
+	case tenantaudit.FieldRecordedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRecordedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TenantAudit field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantAuditMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantAuditMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantAuditMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown TenantAudit numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantAuditMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(tenantaudit.FieldActor) {
+		fields = append(fields, tenantaudit.FieldActor)
+	}
+	if m.FieldCleared(tenantaudit.FieldAttemptedChange) {
+		fields = append(fields, tenantaudit.FieldAttemptedChange)
+	}
+	if m.FieldCleared(tenantaudit.FieldCode) {
+		fields = append(fields, tenantaudit.FieldCode)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantAuditMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantAuditMutation) ClearField(name string) error {
+	switch name {
+	case tenantaudit.FieldActor:
+		m.ClearActor()
+		return nil
+	case tenantaudit.FieldAttemptedChange:
+		m.ClearAttemptedChange()
+		return nil
+	case tenantaudit.FieldCode:
+		m.ClearCode()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantAudit nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantAuditMutation) ResetField(name string) error {
+	switch name {
+	case tenantaudit.FieldTenantID:
+		m.ResetTenantID()
+		return nil
+	case tenantaudit.FieldActor:
+		m.ResetActor()
+		return nil
+	case tenantaudit.FieldOperation:
+		m.ResetOperation()
+		return nil
+	case tenantaudit.FieldAttemptedChange:
+		m.ResetAttemptedChange()
+		return nil
+	case tenantaudit.FieldOutcome:
+		m.ResetOutcome()
+		return nil
+	case tenantaudit.FieldCode:
+		m.ResetCode()
+		return nil
+	case tenantaudit.FieldRecordedAt:
+		m.ResetRecordedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantAudit field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantAuditMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantAuditMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantAuditMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantAuditMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantAuditMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantAuditMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantAuditMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantAudit unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantAuditMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantAudit edge %s", name)
+}
+
+// TenantChangeMutation represents an operation that mutates the TenantChange nodes in the graph.
+type TenantChangeMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *int64
+	tenant_id        *gidx.PrefixedID
+	operation        *string
+	changed_at       *time.Time
+	name             *string
+	parent_tenant_id *gidx.PrefixedID
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*TenantChange, error)
+	predicates       []predicate.TenantChange
+}
+
+var _ ent.Mutation = (*TenantChangeMutation)(nil)
+
+// tenantchangeOption allows management of the mutation configuration using functional options.
+type tenantchangeOption func(*TenantChangeMutation)
+
+// newTenantChangeMutation creates new mutation for the TenantChange entity.
+func newTenantChangeMutation(c config, op Op, opts ...tenantchangeOption) *TenantChangeMutation {
+	m := &TenantChangeMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantChange,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantChangeID sets the ID field of the mutation.
+func withTenantChangeID(id int64) tenantchangeOption {
+	return func(m *TenantChangeMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantChange
+		)
+		m.oldValue = func(ctx context.Context) (*TenantChange, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantChange.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantChange sets the old TenantChange of the mutation.
+func withTenantChange(node *TenantChange) tenantchangeOption {
+	return func(m *TenantChangeMutation) {
+		m.oldValue = func(context.Context) (*TenantChange, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantChangeMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantChangeMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantChange entities.
+func (m *TenantChangeMutation) SetID(id int64) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantChangeMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantChangeMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantChange.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *TenantChangeMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant_id = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *TenantChangeMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the TenantChange entity.
+// If the TenantChange object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantChangeMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *TenantChangeMutation) ResetTenantID() {
+	m.tenant_id = nil
+}
+
+// SetOperation sets the "operation" field.
+func (m *TenantChangeMutation) SetOperation(s string) {
+	m.operation = &s
+}
+
+// Operation returns the value of the "operation" field in the mutation.
+func (m *TenantChangeMutation) Operation() (r string, exists bool) {
+	v := m.operation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOperation returns the old "operation" field's value of the TenantChange entity.
+// If the TenantChange object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantChangeMutation) OldOperation(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOperation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOperation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOperation: %w", err)
+	}
+	return oldValue.Operation, nil
+}
+
+// ResetOperation resets all changes to the "operation" field.
+func (m *TenantChangeMutation) ResetOperation() {
+	m.operation = nil
+}
+
+// SetChangedAt sets the "changed_at" field.
+func (m *TenantChangeMutation) SetChangedAt(t time.Time) {
+	m.changed_at = &t
+}
+
+// ChangedAt returns the value of the "changed_at" field in the mutation.
+func (m *TenantChangeMutation) ChangedAt() (r time.Time, exists bool) {
+	v := m.changed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldChangedAt returns the old "changed_at" field's value of the TenantChange entity.
+// If the TenantChange object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantChangeMutation) OldChangedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldChangedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldChangedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldChangedAt: %w", err)
+	}
+	return oldValue.ChangedAt, nil
+}
+
+// ResetChangedAt resets all changes to the "changed_at" field.
+func (m *TenantChangeMutation) ResetChangedAt() {
+	m.changed_at = nil
+}
+
+// SetName sets the "name" field.
+func (m *TenantChangeMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *TenantChangeMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the TenantChange entity.
+// If the TenantChange object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantChangeMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ClearName clears the value of the "name" field.
+func (m *TenantChangeMutation) ClearName() {
+	m.name = nil
+	m.clearedFields[tenantchange.FieldName] = struct{}{}
+}
+
+// NameCleared returns if the "name" field was cleared in this mutation.
+func (m *TenantChangeMutation) NameCleared() bool {
+	_, ok := m.clearedFields[tenantchange.FieldName]
+	return ok
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *TenantChangeMutation) ResetName() {
+	m.name = nil
+	delete(m.clearedFields, tenantchange.FieldName)
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (m *TenantChangeMutation) SetParentTenantID(gi gidx.PrefixedID) {
+	m.parent_tenant_id = &gi
+}
+
+// ParentTenantID returns the value of the "parent_tenant_id" field in the mutation.
+func (m *TenantChangeMutation) ParentTenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.parent_tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldParentTenantID returns the old "parent_tenant_id" field's value of the TenantChange entity.
+// If the TenantChange object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantChangeMutation) OldParentTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldParentTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldParentTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldParentTenantID: %w", err)
+	}
+	return oldValue.ParentTenantID, nil
+}
+
+// ClearParentTenantID clears the value of the "parent_tenant_id" field.
+func (m *TenantChangeMutation) ClearParentTenantID() {
+	m.parent_tenant_id = nil
+	m.clearedFields[tenantchange.FieldParentTenantID] = struct{}{}
+}
+
+// ParentTenantIDCleared returns if the "parent_tenant_id" field was cleared in this mutation.
+func (m *TenantChangeMutation) ParentTenantIDCleared() bool {
+	_, ok := m.clearedFields[tenantchange.FieldParentTenantID]
+	return ok
+}
+
+// ResetParentTenantID resets all changes to the "parent_tenant_id" field.
+func (m *TenantChangeMutation) ResetParentTenantID() {
+	m.parent_tenant_id = nil
+	delete(m.clearedFields, tenantchange.FieldParentTenantID)
+}
+
+// Where appends a list predicates to the TenantChangeMutation builder.
+func (m *TenantChangeMutation) Where(ps ...predicate.TenantChange) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantChangeMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantChangeMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantChange, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantChangeMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantChangeMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TenantChange).
+func (m *TenantChangeMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantChangeMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.tenant_id != nil {
+		fields = append(fields, tenantchange.FieldTenantID)
+	}
+	if m.operation != nil {
+		fields = append(fields, tenantchange.FieldOperation)
+	}
+	if m.changed_at != nil {
+		fields = append(fields, tenantchange.FieldChangedAt)
+	}
+	if m.name != nil {
+		fields = append(fields, tenantchange.FieldName)
+	}
+	if m.parent_tenant_id != nil {
+		fields = append(fields, tenantchange.FieldParentTenantID)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantChangeMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenantchange.FieldTenantID:
+		return m.TenantID()
+	case tenantchange.FieldOperation:
+		return m.Operation()
+	case tenantchange.FieldChangedAt:
+		return m.ChangedAt()
+	case tenantchange.FieldName:
+		return m.Name()
+	case tenantchange.FieldParentTenantID:
+		return m.ParentTenantID()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantChangeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenantchange.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case tenantchange.FieldOperation:
+		return m.OldOperation(ctx)
+	case tenantchange.FieldChangedAt:
+		return m.OldChangedAt(ctx)
+	case tenantchange.FieldName:
+		return m.OldName(ctx)
+	case tenantchange.FieldParentTenantID:
+		return m.OldParentTenantID(ctx)
+	}
+	return nil, fmt.Errorf("unknown TenantChange field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantChangeMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenantchange.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenantID(v)
+		return nil
+	case tenantchange.FieldOperation:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOperation(v)
+		return nil
+	case tenantchange.FieldChangedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetChangedAt(v)
+		return nil
+	case tenantchange.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case tenantchange.FieldParentTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetParentTenantID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TenantChange field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantChangeMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantChangeMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantChangeMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown TenantChange numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantChangeMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(tenantchange.FieldName) {
+		fields = append(fields, tenantchange.FieldName)
+	}
+	if m.FieldCleared(tenantchange.FieldParentTenantID) {
+		fields = append(fields, tenantchange.FieldParentTenantID)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantChangeMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantChangeMutation) ClearField(name string) error {
+	switch name {
+	case tenantchange.FieldName:
+		m.ClearName()
+		return nil
+	case tenantchange.FieldParentTenantID:
+		m.ClearParentTenantID()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantChange nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantChangeMutation) ResetField(name string) error {
+	switch name {
+	case tenantchange.FieldTenantID:
+		m.ResetTenantID()
+		return nil
+	case tenantchange.FieldOperation:
+		m.ResetOperation()
+		return nil
+	case tenantchange.FieldChangedAt:
+		m.ResetChangedAt()
+		return nil
+	case tenantchange.FieldName:
+		m.ResetName()
+		return nil
+	case tenantchange.FieldParentTenantID:
+		m.ResetParentTenantID()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantChange field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantChangeMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantChangeMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantChangeMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantChangeMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantChangeMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantChangeMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantChangeMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantChange unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantChangeMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantChange edge %s", name)
+}
+
+// TenantCreateGuardMutation represents an operation that mutates the TenantCreateGuard nodes in the graph.
+type TenantCreateGuardMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	tenant_id     *gidx.PrefixedID
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*TenantCreateGuard, error)
+	predicates    []predicate.TenantCreateGuard
+}
+
+var _ ent.Mutation = (*TenantCreateGuardMutation)(nil)
+
+// tenantcreateguardOption allows management of the mutation configuration using functional options.
+type tenantcreateguardOption func(*TenantCreateGuardMutation)
+
+// newTenantCreateGuardMutation creates new mutation for the TenantCreateGuard entity.
+func newTenantCreateGuardMutation(c config, op Op, opts ...tenantcreateguardOption) *TenantCreateGuardMutation {
+	m := &TenantCreateGuardMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantCreateGuard,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantCreateGuardID sets the ID field of the mutation.
+func withTenantCreateGuardID(id string) tenantcreateguardOption {
+	return func(m *TenantCreateGuardMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantCreateGuard
+		)
+		m.oldValue = func(ctx context.Context) (*TenantCreateGuard, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantCreateGuard.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantCreateGuard sets the old TenantCreateGuard of the mutation.
+func withTenantCreateGuard(node *TenantCreateGuard) tenantcreateguardOption {
+	return func(m *TenantCreateGuardMutation) {
+		m.oldValue = func(context.Context) (*TenantCreateGuard, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantCreateGuardMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantCreateGuardMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantCreateGuard entities.
+func (m *TenantCreateGuardMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantCreateGuardMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantCreateGuardMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantCreateGuard.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *TenantCreateGuardMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant_id = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *TenantCreateGuardMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the TenantCreateGuard entity.
+// If the TenantCreateGuard object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantCreateGuardMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *TenantCreateGuardMutation) ResetTenantID() {
+	m.tenant_id = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *TenantCreateGuardMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *TenantCreateGuardMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the TenantCreateGuard entity.
+// If the TenantCreateGuard object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantCreateGuardMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *TenantCreateGuardMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the TenantCreateGuardMutation builder.
+func (m *TenantCreateGuardMutation) Where(ps ...predicate.TenantCreateGuard) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantCreateGuardMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantCreateGuardMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantCreateGuard, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantCreateGuardMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantCreateGuardMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TenantCreateGuard).
+func (m *TenantCreateGuardMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantCreateGuardMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.tenant_id != nil {
+		fields = append(fields, tenantcreateguard.FieldTenantID)
+	}
+	if m.created_at != nil {
+		fields = append(fields, tenantcreateguard.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantCreateGuardMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenantcreateguard.FieldTenantID:
+		return m.TenantID()
+	case tenantcreateguard.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantCreateGuardMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenantcreateguard.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case tenantcreateguard.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown TenantCreateGuard field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantCreateGuardMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenantcreateguard.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenantID(v)
+		return nil
+	case tenantcreateguard.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TenantCreateGuard field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantCreateGuardMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantCreateGuardMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantCreateGuardMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown TenantCreateGuard numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantCreateGuardMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantCreateGuardMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantCreateGuardMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown TenantCreateGuard nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantCreateGuardMutation) ResetField(name string) error {
+	switch name {
+	case tenantcreateguard.FieldTenantID:
+		m.ResetTenantID()
+		return nil
+	case tenantcreateguard.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantCreateGuard field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantCreateGuardMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantCreateGuardMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantCreateGuardMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantCreateGuardMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantCreateGuardMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantCreateGuardMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantCreateGuardMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantCreateGuard unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantCreateGuardMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantCreateGuard edge %s", name)
+}
+
+// TenantParentHistoryMutation represents an operation that mutates the TenantParentHistory nodes in the graph.
+type TenantParentHistoryMutation struct {
+	config
+	op                 Op
+	typ                string
+	id                 *gidx.PrefixedID
+	tenant_id          *gidx.PrefixedID
+	previous_parent_id *gidx.PrefixedID
+	new_parent_id      *gidx.PrefixedID
+	actor              *string
+	changed_at         *time.Time
+	clearedFields      map[string]struct{}
+	done               bool
+	oldValue           func(context.Context) (*TenantParentHistory, error)
+	predicates         []predicate.TenantParentHistory
+}
+
+var _ ent.Mutation = (*TenantParentHistoryMutation)(nil)
+
+// tenantparenthistoryOption allows management of the mutation configuration using functional options.
+type tenantparenthistoryOption func(*TenantParentHistoryMutation)
+
+// newTenantParentHistoryMutation creates new mutation for the TenantParentHistory entity.
+func newTenantParentHistoryMutation(c config, op Op, opts ...tenantparenthistoryOption) *TenantParentHistoryMutation {
+	m := &TenantParentHistoryMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantParentHistory,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantParentHistoryID sets the ID field of the mutation.
+func withTenantParentHistoryID(id gidx.PrefixedID) tenantparenthistoryOption {
+	return func(m *TenantParentHistoryMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantParentHistory
+		)
+		m.oldValue = func(ctx context.Context) (*TenantParentHistory, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantParentHistory.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantParentHistory sets the old TenantParentHistory of the mutation.
+func withTenantParentHistory(node *TenantParentHistory) tenantparenthistoryOption {
+	return func(m *TenantParentHistoryMutation) {
+		m.oldValue = func(context.Context) (*TenantParentHistory, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantParentHistoryMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantParentHistoryMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantParentHistory entities.
+func (m *TenantParentHistoryMutation) SetID(id gidx.PrefixedID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantParentHistoryMutation) ID() (id gidx.PrefixedID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantParentHistoryMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []gidx.PrefixedID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantParentHistory.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *TenantParentHistoryMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant_id = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *TenantParentHistoryMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the TenantParentHistory entity.
+// If the TenantParentHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantParentHistoryMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *TenantParentHistoryMutation) ResetTenantID() {
+	m.tenant_id = nil
+}
+
+// SetPreviousParentID sets the "previous_parent_id" field.
+func (m *TenantParentHistoryMutation) SetPreviousParentID(gi gidx.PrefixedID) {
+	m.previous_parent_id = &gi
+}
+
+// PreviousParentID returns the value of the "previous_parent_id" field in the mutation.
+func (m *TenantParentHistoryMutation) PreviousParentID() (r gidx.PrefixedID, exists bool) {
+	v := m.previous_parent_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPreviousParentID returns the old "previous_parent_id" field's value of the TenantParentHistory entity.
+// If the TenantParentHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantParentHistoryMutation) OldPreviousParentID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPreviousParentID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPreviousParentID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPreviousParentID: %w", err)
+	}
+	return oldValue.PreviousParentID, nil
+}
+
+// ClearPreviousParentID clears the value of the "previous_parent_id" field.
+func (m *TenantParentHistoryMutation) ClearPreviousParentID() {
+	m.previous_parent_id = nil
+	m.clearedFields[tenantparenthistory.FieldPreviousParentID] = struct{}{}
+}
+
+// PreviousParentIDCleared returns if the "previous_parent_id" field was cleared in this mutation.
+func (m *TenantParentHistoryMutation) PreviousParentIDCleared() bool {
+	_, ok := m.clearedFields[tenantparenthistory.FieldPreviousParentID]
+	return ok
+}
+
+// ResetPreviousParentID resets all changes to the "previous_parent_id" field.
+func (m *TenantParentHistoryMutation) ResetPreviousParentID() {
+	m.previous_parent_id = nil
+	delete(m.clearedFields, tenantparenthistory.FieldPreviousParentID)
+}
+
+// SetNewParentID sets the "new_parent_id" field.
+func (m *TenantParentHistoryMutation) SetNewParentID(gi gidx.PrefixedID) {
+	m.new_parent_id = &gi
+}
+
+// NewParentID returns the value of the "new_parent_id" field in the mutation.
+func (m *TenantParentHistoryMutation) NewParentID() (r gidx.PrefixedID, exists bool) {
+	v := m.new_parent_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNewParentID returns the old "new_parent_id" field's value of the TenantParentHistory entity.
+// If the TenantParentHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantParentHistoryMutation) OldNewParentID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNewParentID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNewParentID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNewParentID: %w", err)
+	}
+	return oldValue.NewParentID, nil
+}
+
+// ClearNewParentID clears the value of the "new_parent_id" field.
+func (m *TenantParentHistoryMutation) ClearNewParentID() {
+	m.new_parent_id = nil
+	m.clearedFields[tenantparenthistory.FieldNewParentID] = struct{}{}
+}
+
+// NewParentIDCleared returns if the "new_parent_id" field was cleared in this mutation.
+func (m *TenantParentHistoryMutation) NewParentIDCleared() bool {
+	_, ok := m.clearedFields[tenantparenthistory.FieldNewParentID]
+	return ok
+}
+
+// ResetNewParentID resets all changes to the "new_parent_id" field.
+func (m *TenantParentHistoryMutation) ResetNewParentID() {
+	m.new_parent_id = nil
+	delete(m.clearedFields, tenantparenthistory.FieldNewParentID)
+}
+
+// SetActor sets the "actor" field.
+func (m *TenantParentHistoryMutation) SetActor(s string) {
+	m.actor = &s
+}
+
+// Actor returns the value of the "actor" field in the mutation.
+func (m *TenantParentHistoryMutation) Actor() (r string, exists bool) {
+	v := m.actor
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActor returns the old "actor" field's value of the TenantParentHistory entity.
+// If the TenantParentHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantParentHistoryMutation) OldActor(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActor is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActor requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActor: %w", err)
+	}
+	return oldValue.Actor, nil
+}
+
+// ClearActor clears the value of the "actor" field.
+func (m *TenantParentHistoryMutation) ClearActor() {
+	m.actor = nil
+	m.clearedFields[tenantparenthistory.FieldActor] = struct{}{}
+}
+
+// ActorCleared returns if the "actor" field was cleared in this mutation.
+func (m *TenantParentHistoryMutation) ActorCleared() bool {
+	_, ok := m.clearedFields[tenantparenthistory.FieldActor]
+	return ok
+}
+
+// ResetActor resets all changes to the "actor" field.
+func (m *TenantParentHistoryMutation) ResetActor() {
+	m.actor = nil
+	delete(m.clearedFields, tenantparenthistory.FieldActor)
+}
+
+// SetChangedAt sets the "changed_at" field.
+func (m *TenantParentHistoryMutation) SetChangedAt(t time.Time) {
+	m.changed_at = &t
+}
+
+// ChangedAt returns the value of the "changed_at" field in the mutation.
+func (m *TenantParentHistoryMutation) ChangedAt() (r time.Time, exists bool) {
+	v := m.changed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldChangedAt returns the old "changed_at" field's value of the TenantParentHistory entity.
+// If the TenantParentHistory object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantParentHistoryMutation) OldChangedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldChangedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldChangedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldChangedAt: %w", err)
+	}
+	return oldValue.ChangedAt, nil
+}
+
+// ResetChangedAt resets all changes to the "changed_at" field.
+func (m *TenantParentHistoryMutation) ResetChangedAt() {
+	m.changed_at = nil
+}
+
+// Where appends a list predicates to the TenantParentHistoryMutation builder.
+func (m *TenantParentHistoryMutation) Where(ps ...predicate.TenantParentHistory) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantParentHistoryMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantParentHistoryMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantParentHistory, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantParentHistoryMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantParentHistoryMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TenantParentHistory).
+func (m *TenantParentHistoryMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantParentHistoryMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m.tenant_id != nil {
+		fields = append(fields, tenantparenthistory.FieldTenantID)
+	}
+	if m.previous_parent_id != nil {
+		fields = append(fields, tenantparenthistory.FieldPreviousParentID)
+	}
+	if m.new_parent_id != nil {
+		fields = append(fields, tenantparenthistory.FieldNewParentID)
+	}
+	if m.actor != nil {
+		fields = append(fields, tenantparenthistory.FieldActor)
+	}
+	if m.changed_at != nil {
+		fields = append(fields, tenantparenthistory.FieldChangedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantParentHistoryMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenantparenthistory.FieldTenantID:
+		return m.TenantID()
+	case tenantparenthistory.FieldPreviousParentID:
+		return m.PreviousParentID()
+	case tenantparenthistory.FieldNewParentID:
+		return m.NewParentID()
+	case tenantparenthistory.FieldActor:
+		return m.Actor()
+	case tenantparenthistory.FieldChangedAt:
+		return m.ChangedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantParentHistoryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenantparenthistory.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case tenantparenthistory.FieldPreviousParentID:
+		return m.OldPreviousParentID(ctx)
+	case tenantparenthistory.FieldNewParentID:
+		return m.OldNewParentID(ctx)
+	case tenantparenthistory.FieldActor:
+		return m.OldActor(ctx)
+	case tenantparenthistory.FieldChangedAt:
+		return m.OldChangedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown TenantParentHistory field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantParentHistoryMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenantparenthistory.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTenantID(v)
+		return nil
+	case tenantparenthistory.FieldPreviousParentID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPreviousParentID(v)
+		return nil
+	case tenantparenthistory.FieldNewParentID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNewParentID(v)
+		return nil
+	case tenantparenthistory.FieldActor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActor(v)
+		return nil
+	case tenantparenthistory.FieldChangedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetChangedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TenantParentHistory field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantParentHistoryMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantParentHistoryMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantParentHistoryMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown TenantParentHistory numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantParentHistoryMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(tenantparenthistory.FieldPreviousParentID) {
+		fields = append(fields, tenantparenthistory.FieldPreviousParentID)
+	}
+	if m.FieldCleared(tenantparenthistory.FieldNewParentID) {
+		fields = append(fields, tenantparenthistory.FieldNewParentID)
+	}
+	if m.FieldCleared(tenantparenthistory.FieldActor) {
+		fields = append(fields, tenantparenthistory.FieldActor)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantParentHistoryMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantParentHistoryMutation) ClearField(name string) error {
+	switch name {
+	case tenantparenthistory.FieldPreviousParentID:
+		m.ClearPreviousParentID()
+		return nil
+	case tenantparenthistory.FieldNewParentID:
+		m.ClearNewParentID()
+		return nil
+	case tenantparenthistory.FieldActor:
+		m.ClearActor()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantParentHistory nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantParentHistoryMutation) ResetField(name string) error {
+	switch name {
+	case tenantparenthistory.FieldTenantID:
+		m.ResetTenantID()
+		return nil
+	case tenantparenthistory.FieldPreviousParentID:
+		m.ResetPreviousParentID()
+		return nil
+	case tenantparenthistory.FieldNewParentID:
+		m.ResetNewParentID()
+		return nil
+	case tenantparenthistory.FieldActor:
+		m.ResetActor()
+		return nil
+	case tenantparenthistory.FieldChangedAt:
+		m.ResetChangedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantParentHistory field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantParentHistoryMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantParentHistoryMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantParentHistoryMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantParentHistoryMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantParentHistoryMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantParentHistoryMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantParentHistoryMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantParentHistory unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantParentHistoryMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantParentHistory edge %s", name)
+}
+
+// TenantTombstoneMutation represents an operation that mutates the TenantTombstone nodes in the graph.
+type TenantTombstoneMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *gidx.PrefixedID
+	name             *string
+	parent_tenant_id *gidx.PrefixedID
+	deleted_at       *time.Time
+	purged_at        *time.Time
+	clearedFields    map[string]struct{}
+	done             bool
+	oldValue         func(context.Context) (*TenantTombstone, error)
+	predicates       []predicate.TenantTombstone
+}
+
+var _ ent.Mutation = (*TenantTombstoneMutation)(nil)
+
+// tenanttombstoneOption allows management of the mutation configuration using functional options.
+type tenanttombstoneOption func(*TenantTombstoneMutation)
+
+// newTenantTombstoneMutation creates new mutation for the TenantTombstone entity.
+func newTenantTombstoneMutation(c config, op Op, opts ...tenanttombstoneOption) *TenantTombstoneMutation {
+	m := &TenantTombstoneMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantTombstone,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantTombstoneID sets the ID field of the mutation.
+func withTenantTombstoneID(id gidx.PrefixedID) tenanttombstoneOption {
+	return func(m *TenantTombstoneMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantTombstone
+		)
+		m.oldValue = func(ctx context.Context) (*TenantTombstone, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantTombstone.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantTombstone sets the old TenantTombstone of the mutation.
+func withTenantTombstone(node *TenantTombstone) tenanttombstoneOption {
+	return func(m *TenantTombstoneMutation) {
+		m.oldValue = func(context.Context) (*TenantTombstone, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantTombstoneMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantTombstoneMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantTombstone entities.
+func (m *TenantTombstoneMutation) SetID(id gidx.PrefixedID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantTombstoneMutation) ID() (id gidx.PrefixedID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantTombstoneMutation) IDs(ctx context.Context) ([]gidx.PrefixedID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []gidx.PrefixedID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantTombstone.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetName sets the "name" field.
+func (m *TenantTombstoneMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *TenantTombstoneMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the TenantTombstone entity.
+// If the TenantTombstone object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantTombstoneMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *TenantTombstoneMutation) ResetName() {
+	m.name = nil
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (m *TenantTombstoneMutation) SetParentTenantID(gi gidx.PrefixedID) {
+	m.parent_tenant_id = &gi
+}
+
+// ParentTenantID returns the value of the "parent_tenant_id" field in the mutation.
+func (m *TenantTombstoneMutation) ParentTenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.parent_tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldParentTenantID returns the old "parent_tenant_id" field's value of the TenantTombstone entity.
+// If the TenantTombstone object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantTombstoneMutation) OldParentTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldParentTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldParentTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldParentTenantID: %w", err)
+	}
+	return oldValue.ParentTenantID, nil
+}
+
+// ClearParentTenantID clears the value of the "parent_tenant_id" field.
+func (m *TenantTombstoneMutation) ClearParentTenantID() {
+	m.parent_tenant_id = nil
+	m.clearedFields[tenanttombstone.FieldParentTenantID] = struct{}{}
+}
+
+// ParentTenantIDCleared returns if the "parent_tenant_id" field was cleared in this mutation.
+func (m *TenantTombstoneMutation) ParentTenantIDCleared() bool {
+	_, ok := m.clearedFields[tenanttombstone.FieldParentTenantID]
+	return ok
+}
+
+// ResetParentTenantID resets all changes to the "parent_tenant_id" field.
+func (m *TenantTombstoneMutation) ResetParentTenantID() {
+	m.parent_tenant_id = nil
+	delete(m.clearedFields, tenanttombstone.FieldParentTenantID)
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (m *TenantTombstoneMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
+}
+
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *TenantTombstoneMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedAt returns the old "deleted_at" field's value of the TenantTombstone entity.
+// If the TenantTombstone object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantTombstoneMutation) OldDeletedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
+}
+
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *TenantTombstoneMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+}
+
+// SetPurgedAt sets the "purged_at" field.
+func (m *TenantTombstoneMutation) SetPurgedAt(t time.Time) {
+	m.purged_at = &t
+}
+
+// PurgedAt returns the value of the "purged_at" field in the mutation.
+func (m *TenantTombstoneMutation) PurgedAt() (r time.Time, exists bool) {
+	v := m.purged_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPurgedAt returns the old "purged_at" field's value of the TenantTombstone entity.
+// If the TenantTombstone object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantTombstoneMutation) OldPurgedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPurgedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPurgedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPurgedAt: %w", err)
+	}
+	return oldValue.PurgedAt, nil
+}
+
+// ResetPurgedAt resets all changes to the "purged_at" field.
+func (m *TenantTombstoneMutation) ResetPurgedAt() {
+	m.purged_at = nil
+}
+
+// Where appends a list predicates to the TenantTombstoneMutation builder.
+func (m *TenantTombstoneMutation) Where(ps ...predicate.TenantTombstone) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TenantTombstoneMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TenantTombstoneMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantTombstone, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TenantTombstoneMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TenantTombstoneMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TenantTombstone).
+func (m *TenantTombstoneMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TenantTombstoneMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.name != nil {
+		fields = append(fields, tenanttombstone.FieldName)
+	}
+	if m.parent_tenant_id != nil {
+		fields = append(fields, tenanttombstone.FieldParentTenantID)
+	}
+	if m.deleted_at != nil {
+		fields = append(fields, tenanttombstone.FieldDeletedAt)
+	}
+	if m.purged_at != nil {
+		fields = append(fields, tenanttombstone.FieldPurgedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TenantTombstoneMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tenanttombstone.FieldName:
+		return m.Name()
+	case tenanttombstone.FieldParentTenantID:
+		return m.ParentTenantID()
+	case tenanttombstone.FieldDeletedAt:
+		return m.DeletedAt()
+	case tenanttombstone.FieldPurgedAt:
+		return m.PurgedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TenantTombstoneMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tenanttombstone.FieldName:
+		return m.OldName(ctx)
+	case tenanttombstone.FieldParentTenantID:
+		return m.OldParentTenantID(ctx)
+	case tenanttombstone.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case tenanttombstone.FieldPurgedAt:
+		return m.OldPurgedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown TenantTombstone field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantTombstoneMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tenanttombstone.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case tenanttombstone.FieldParentTenantID:
+		v, ok := value.(gidx.PrefixedID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetParentTenantID(v)
+		return nil
+	case tenanttombstone.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case tenanttombstone.FieldPurgedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPurgedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TenantTombstone field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TenantTombstoneMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TenantTombstoneMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TenantTombstoneMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown TenantTombstone numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TenantTombstoneMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(tenanttombstone.FieldParentTenantID) {
+		fields = append(fields, tenanttombstone.FieldParentTenantID)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TenantTombstoneMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TenantTombstoneMutation) ClearField(name string) error {
+	switch name {
+	case tenanttombstone.FieldParentTenantID:
+		m.ClearParentTenantID()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantTombstone nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TenantTombstoneMutation) ResetField(name string) error {
+	switch name {
+	case tenanttombstone.FieldName:
+		m.ResetName()
+		return nil
+	case tenanttombstone.FieldParentTenantID:
+		m.ResetParentTenantID()
+		return nil
+	case tenanttombstone.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case tenanttombstone.FieldPurgedAt:
+		m.ResetPurgedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TenantTombstone field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TenantTombstoneMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TenantTombstoneMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TenantTombstoneMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TenantTombstoneMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TenantTombstoneMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TenantTombstoneMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TenantTombstoneMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantTombstone unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TenantTombstoneMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantTombstone edge %s", name)
+}
+
+// TenantUsageMutation represents an operation that mutates the TenantUsage nodes in the graph.
+type TenantUsageMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int64
+	tenant_id     *gidx.PrefixedID
+	operation     *string
+	bucket_start  *time.Time
+	count         *int64
+	addcount      *int64
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*TenantUsage, error)
+	predicates    []predicate.TenantUsage
+}
+
+var _ ent.Mutation = (*TenantUsageMutation)(nil)
+
+// tenantusageOption allows management of the mutation configuration using functional options.
+type tenantusageOption func(*TenantUsageMutation)
+
+// newTenantUsageMutation creates new mutation for the TenantUsage entity.
+func newTenantUsageMutation(c config, op Op, opts ...tenantusageOption) *TenantUsageMutation {
+	m := &TenantUsageMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTenantUsage,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTenantUsageID sets the ID field of the mutation.
+func withTenantUsageID(id int64) tenantusageOption {
+	return func(m *TenantUsageMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TenantUsage
+		)
+		m.oldValue = func(ctx context.Context) (*TenantUsage, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TenantUsage.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTenantUsage sets the old TenantUsage of the mutation.
+func withTenantUsage(node *TenantUsage) tenantusageOption {
+	return func(m *TenantUsageMutation) {
+		m.oldValue = func(context.Context) (*TenantUsage, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TenantUsageMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TenantUsageMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("generated: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TenantUsage entities.
+func (m *TenantUsageMutation) SetID(id int64) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TenantUsageMutation) ID() (id int64, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TenantUsageMutation) IDs(ctx context.Context) ([]int64, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int64{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TenantUsage.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (m *TenantUsageMutation) SetTenantID(gi gidx.PrefixedID) {
+	m.tenant_id = &gi
+}
+
+// TenantID returns the value of the "tenant_id" field in the mutation.
+func (m *TenantUsageMutation) TenantID() (r gidx.PrefixedID, exists bool) {
+	v := m.tenant_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTenantID returns the old "tenant_id" field's value of the TenantUsage entity.
+// If the TenantUsage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantUsageMutation) OldTenantID(ctx context.Context) (v gidx.PrefixedID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTenantID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTenantID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTenantID: %w", err)
+	}
+	return oldValue.TenantID, nil
+}
+
+// ResetTenantID resets all changes to the "tenant_id" field.
+func (m *TenantUsageMutation) ResetTenantID() {
+	m.tenant_id = nil
+}
+
+// SetOperation sets the "operation" field.
+func (m *TenantUsageMutation) SetOperation(s string) {
+	m.operation = &s
+}
+
+// Operation returns the value of the "operation" field in the mutation.
+func (m *TenantUsageMutation) Operation() (r string, exists bool) {
+	v := m.operation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOperation returns the old "operation" field's value of the TenantUsage entity.
+// If the TenantUsage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantUsageMutation) OldOperation(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOperation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOperation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOperation: %w", err)
+	}
+	return oldValue.Operation, nil
+}
+
+// ResetOperation resets all changes to the "operation" field.
+func (m *TenantUsageMutation) ResetOperation() {
+	m.operation = nil
 }
 
-// ParentIDs returns the "parent" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ParentID instead. It exists only for internal usage by the builders.
-func (m *TenantMutation) ParentIDs() (ids []gidx.PrefixedID) {
-	if id := m.parent; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// SetBucketStart sets the "bucket_start" field.
+func (m *TenantUsageMutation) SetBucketStart(t time.Time) {
+	m.bucket_start = &t
 }
 
-// ResetParent resets all changes to the "parent" edge.
-func (m *TenantMutation) ResetParent() {
-	m.parent = nil
-	m.clearedparent = false
+// BucketStart returns the value of the "bucket_start" field in the mutation.
+func (m *TenantUsageMutation) BucketStart() (r time.Time, exists bool) {
+	v := m.bucket_start
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// AddChildIDs adds the "children" edge to the Tenant entity by ids.
-func (m *TenantMutation) AddChildIDs(ids ...gidx.PrefixedID) {
-	if m.children == nil {
-		m.children = make(map[gidx.PrefixedID]struct{})
+// OldBucketStart returns the old "bucket_start" field's value of the TenantUsage entity.
+// If the TenantUsage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantUsageMutation) OldBucketStart(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBucketStart is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		m.children[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBucketStart requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBucketStart: %w", err)
 	}
+	return oldValue.BucketStart, nil
 }
 
-// ClearChildren clears the "children" edge to the Tenant entity.
-func (m *TenantMutation) ClearChildren() {
-	m.clearedchildren = true
+// ResetBucketStart resets all changes to the "bucket_start" field.
+func (m *TenantUsageMutation) ResetBucketStart() {
+	m.bucket_start = nil
 }
 
-// ChildrenCleared reports if the "children" edge to the Tenant entity was cleared.
-func (m *TenantMutation) ChildrenCleared() bool {
-	return m.clearedchildren
+// SetCount sets the "count" field.
+func (m *TenantUsageMutation) SetCount(i int64) {
+	m.count = &i
+	m.addcount = nil
 }
 
-// RemoveChildIDs removes the "children" edge to the Tenant entity by IDs.
-func (m *TenantMutation) RemoveChildIDs(ids ...gidx.PrefixedID) {
-	if m.removedchildren == nil {
-		m.removedchildren = make(map[gidx.PrefixedID]struct{})
+// Count returns the value of the "count" field in the mutation.
+func (m *TenantUsageMutation) Count() (r int64, exists bool) {
+	v := m.count
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		delete(m.children, ids[i])
-		m.removedchildren[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldCount returns the old "count" field's value of the TenantUsage entity.
+// If the TenantUsage object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantUsageMutation) OldCount(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCount requires an ID field in the mutation")
 	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCount: %w", err)
+	}
+	return oldValue.Count, nil
 }
 
-// RemovedChildren returns the removed IDs of the "children" edge to the Tenant entity.
-func (m *TenantMutation) RemovedChildrenIDs() (ids []gidx.PrefixedID) {
-	for id := range m.removedchildren {
-		ids = append(ids, id)
+// AddCount adds i to the "count" field.
+func (m *TenantUsageMutation) AddCount(i int64) {
+	if m.addcount != nil {
+		*m.addcount += i
+	} else {
+		m.addcount = &i
 	}
-	return
 }
 
-// ChildrenIDs returns the "children" edge IDs in the mutation.
-func (m *TenantMutation) ChildrenIDs() (ids []gidx.PrefixedID) {
-	for id := range m.children {
-		ids = append(ids, id)
+// AddedCount returns the value that was added to the "count" field in this mutation.
+func (m *TenantUsageMutation) AddedCount() (r int64, exists bool) {
+	v := m.addcount
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetChildren resets all changes to the "children" edge.
-func (m *TenantMutation) ResetChildren() {
-	m.children = nil
-	m.clearedchildren = false
-	m.removedchildren = nil
+// ResetCount resets all changes to the "count" field.
+func (m *TenantUsageMutation) ResetCount() {
+	m.count = nil
+	m.addcount = nil
 }
 
-// Where appends a list predicates to the TenantMutation builder.
-func (m *TenantMutation) Where(ps ...predicate.Tenant) {
+// Where appends a list predicates to the TenantUsageMutation builder.
+func (m *TenantUsageMutation) Where(ps ...predicate.TenantUsage) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the TenantMutation builder. Using this method,
+// WhereP appends storage-level predicates to the TenantUsageMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *TenantMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Tenant, len(ps))
+func (m *TenantUsageMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TenantUsage, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -482,39 +6407,36 @@ func (m *TenantMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *TenantMutation) Op() Op {
+func (m *TenantUsageMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *TenantMutation) SetOp(op Op) {
+func (m *TenantUsageMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Tenant).
-func (m *TenantMutation) Type() string {
+// Type returns the node type of this mutation (TenantUsage).
+func (m *TenantUsageMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *TenantMutation) Fields() []string {
-	fields := make([]string, 0, 5)
-	if m.created_at != nil {
-		fields = append(fields, tenant.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, tenant.FieldUpdatedAt)
+func (m *TenantUsageMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.tenant_id != nil {
+		fields = append(fields, tenantusage.FieldTenantID)
 	}
-	if m.name != nil {
-		fields = append(fields, tenant.FieldName)
+	if m.operation != nil {
+		fields = append(fields, tenantusage.FieldOperation)
 	}
-	if m.description != nil {
-		fields = append(fields, tenant.FieldDescription)
+	if m.bucket_start != nil {
+		fields = append(fields, tenantusage.FieldBucketStart)
 	}
-	if m.parent != nil {
-		fields = append(fields, tenant.FieldParentTenantID)
+	if m.count != nil {
+		fields = append(fields, tenantusage.FieldCount)
 	}
 	return fields
 }
@@ -522,18 +6444,16 @@ func (m *TenantMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *TenantMutation) Field(name string) (ent.Value, bool) {
+func (m *TenantUsageMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case tenant.FieldCreatedAt:
-		return m.CreatedAt()
-	case tenant.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case tenant.FieldName:
-		return m.Name()
-	case tenant.FieldDescription:
-		return m.Description()
-	case tenant.FieldParentTenantID:
-		return m.ParentTenantID()
+	case tenantusage.FieldTenantID:
+		return m.TenantID()
+	case tenantusage.FieldOperation:
+		return m.Operation()
+	case tenantusage.FieldBucketStart:
+		return m.BucketStart()
+	case tenantusage.FieldCount:
+		return m.Count()
 	}
 	return nil, false
 }
@@ -541,243 +6461,177 @@ func (m *TenantMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *TenantMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *TenantUsageMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case tenant.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case tenant.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case tenant.FieldName:
-		return m.OldName(ctx)
-	case tenant.FieldDescription:
-		return m.OldDescription(ctx)
-	case tenant.FieldParentTenantID:
-		return m.OldParentTenantID(ctx)
+	case tenantusage.FieldTenantID:
+		return m.OldTenantID(ctx)
+	case tenantusage.FieldOperation:
+		return m.OldOperation(ctx)
+	case tenantusage.FieldBucketStart:
+		return m.OldBucketStart(ctx)
+	case tenantusage.FieldCount:
+		return m.OldCount(ctx)
 	}
-	return nil, fmt.Errorf("unknown Tenant field %s", name)
+	return nil, fmt.Errorf("unknown TenantUsage field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *TenantMutation) SetField(name string, value ent.Value) error {
+func (m *TenantUsageMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case tenant.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
-	case tenant.FieldUpdatedAt:
-		v, ok := value.(time.Time)
+	case tenantusage.FieldTenantID:
+		v, ok := value.(gidx.PrefixedID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetTenantID(v)
 		return nil
-	case tenant.FieldName:
+	case tenantusage.FieldOperation:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetOperation(v)
 		return nil
-	case tenant.FieldDescription:
-		v, ok := value.(string)
+	case tenantusage.FieldBucketStart:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDescription(v)
+		m.SetBucketStart(v)
 		return nil
-	case tenant.FieldParentTenantID:
-		v, ok := value.(gidx.PrefixedID)
+	case tenantusage.FieldCount:
+		v, ok := value.(int64)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetParentTenantID(v)
+		m.SetCount(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Tenant field %s", name)
+	return fmt.Errorf("unknown TenantUsage field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *TenantMutation) AddedFields() []string {
-	return nil
+func (m *TenantUsageMutation) AddedFields() []string {
+	var fields []string
+	if m.addcount != nil {
+		fields = append(fields, tenantusage.FieldCount)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *TenantMutation) AddedField(name string) (ent.Value, bool) {
+func (m *TenantUsageMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case tenantusage.FieldCount:
+		return m.AddedCount()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *TenantMutation) AddField(name string, value ent.Value) error {
+func (m *TenantUsageMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case tenantusage.FieldCount:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCount(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Tenant numeric field %s", name)
+	return fmt.Errorf("unknown TenantUsage numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *TenantMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(tenant.FieldDescription) {
-		fields = append(fields, tenant.FieldDescription)
-	}
-	if m.FieldCleared(tenant.FieldParentTenantID) {
-		fields = append(fields, tenant.FieldParentTenantID)
-	}
-	return fields
+func (m *TenantUsageMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *TenantMutation) FieldCleared(name string) bool {
+func (m *TenantUsageMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *TenantMutation) ClearField(name string) error {
-	switch name {
-	case tenant.FieldDescription:
-		m.ClearDescription()
-		return nil
-	case tenant.FieldParentTenantID:
-		m.ClearParentTenantID()
-		return nil
-	}
-	return fmt.Errorf("unknown Tenant nullable field %s", name)
+func (m *TenantUsageMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown TenantUsage nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *TenantMutation) ResetField(name string) error {
+func (m *TenantUsageMutation) ResetField(name string) error {
 	switch name {
-	case tenant.FieldCreatedAt:
-		m.ResetCreatedAt()
-		return nil
-	case tenant.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case tenantusage.FieldTenantID:
+		m.ResetTenantID()
 		return nil
-	case tenant.FieldName:
-		m.ResetName()
+	case tenantusage.FieldOperation:
+		m.ResetOperation()
 		return nil
-	case tenant.FieldDescription:
-		m.ResetDescription()
+	case tenantusage.FieldBucketStart:
+		m.ResetBucketStart()
 		return nil
-	case tenant.FieldParentTenantID:
-		m.ResetParentTenantID()
+	case tenantusage.FieldCount:
+		m.ResetCount()
 		return nil
 	}
-	return fmt.Errorf("unknown Tenant field %s", name)
+	return fmt.Errorf("unknown TenantUsage field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *TenantMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.parent != nil {
-		edges = append(edges, tenant.EdgeParent)
-	}
-	if m.children != nil {
-		edges = append(edges, tenant.EdgeChildren)
-	}
+func (m *TenantUsageMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *TenantMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case tenant.EdgeParent:
-		if id := m.parent; id != nil {
-			return []ent.Value{*id}
-		}
-	case tenant.EdgeChildren:
-		ids := make([]ent.Value, 0, len(m.children))
-		for id := range m.children {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *TenantUsageMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *TenantMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedchildren != nil {
-		edges = append(edges, tenant.EdgeChildren)
-	}
+func (m *TenantUsageMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *TenantMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case tenant.EdgeChildren:
-		ids := make([]ent.Value, 0, len(m.removedchildren))
-		for id := range m.removedchildren {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *TenantUsageMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *TenantMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedparent {
-		edges = append(edges, tenant.EdgeParent)
-	}
-	if m.clearedchildren {
-		edges = append(edges, tenant.EdgeChildren)
-	}
+func (m *TenantUsageMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *TenantMutation) EdgeCleared(name string) bool {
-	switch name {
-	case tenant.EdgeParent:
-		return m.clearedparent
-	case tenant.EdgeChildren:
-		return m.clearedchildren
-	}
+func (m *TenantUsageMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *TenantMutation) ClearEdge(name string) error {
-	switch name {
-	case tenant.EdgeParent:
-		m.ClearParent()
-		return nil
-	}
-	return fmt.Errorf("unknown Tenant unique edge %s", name)
+func (m *TenantUsageMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TenantUsage unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *TenantMutation) ResetEdge(name string) error {
-	switch name {
-	case tenant.EdgeParent:
-		m.ResetParent()
-		return nil
-	case tenant.EdgeChildren:
-		m.ResetChildren()
-		return nil
-	}
-	return fmt.Errorf("unknown Tenant edge %s", name)
+func (m *TenantUsageMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TenantUsage edge %s", name)
 }
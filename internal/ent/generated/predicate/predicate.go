@@ -20,5 +20,29 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// SchemaCompatibility is the predicate function for schemacompatibility builders.
+type SchemaCompatibility func(*sql.Selector)
+
+// ServiceAccount is the predicate function for serviceaccount builders.
+type ServiceAccount func(*sql.Selector)
+
 // Tenant is the predicate function for tenant builders.
 type Tenant func(*sql.Selector)
+
+// TenantAudit is the predicate function for tenantaudit builders.
+type TenantAudit func(*sql.Selector)
+
+// TenantChange is the predicate function for tenantchange builders.
+type TenantChange func(*sql.Selector)
+
+// TenantCreateGuard is the predicate function for tenantcreateguard builders.
+type TenantCreateGuard func(*sql.Selector)
+
+// TenantParentHistory is the predicate function for tenantparenthistory builders.
+type TenantParentHistory func(*sql.Selector)
+
+// TenantTombstone is the predicate function for tenanttombstone builders.
+type TenantTombstone func(*sql.Selector)
+
+// TenantUsage is the predicate function for tenantusage builders.
+type TenantUsage func(*sql.Selector)
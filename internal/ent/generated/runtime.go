@@ -19,7 +19,13 @@ package generated
 import (
 	"time"
 
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
 	"go.infratographer.com/tenant-api/internal/ent/schema"
 	"go.infratographer.com/x/gidx"
 )
@@ -28,6 +34,35 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	schemacompatibilityFields := schema.SchemaCompatibility{}.Fields()
+	_ = schemacompatibilityFields
+	// schemacompatibilityDescMinBinaryVersion is the schema descriptor for min_binary_version field.
+	schemacompatibilityDescMinBinaryVersion := schemacompatibilityFields[1].Descriptor()
+	// schemacompatibility.MinBinaryVersionValidator is a validator for the "min_binary_version" field. It is called by the builders before save.
+	schemacompatibility.MinBinaryVersionValidator = schemacompatibilityDescMinBinaryVersion.Validators[0].(func(int64) error)
+	serviceaccountMixin := schema.ServiceAccount{}.Mixin()
+	serviceaccountMixinFields0 := serviceaccountMixin[0].Fields()
+	_ = serviceaccountMixinFields0
+	serviceaccountFields := schema.ServiceAccount{}.Fields()
+	_ = serviceaccountFields
+	// serviceaccountDescCreatedAt is the schema descriptor for created_at field.
+	serviceaccountDescCreatedAt := serviceaccountMixinFields0[0].Descriptor()
+	// serviceaccount.DefaultCreatedAt holds the default value on creation for the created_at field.
+	serviceaccount.DefaultCreatedAt = serviceaccountDescCreatedAt.Default.(func() time.Time)
+	// serviceaccountDescUpdatedAt is the schema descriptor for updated_at field.
+	serviceaccountDescUpdatedAt := serviceaccountMixinFields0[1].Descriptor()
+	// serviceaccount.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	serviceaccount.DefaultUpdatedAt = serviceaccountDescUpdatedAt.Default.(func() time.Time)
+	// serviceaccount.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	serviceaccount.UpdateDefaultUpdatedAt = serviceaccountDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// serviceaccountDescName is the schema descriptor for name field.
+	serviceaccountDescName := serviceaccountFields[2].Descriptor()
+	// serviceaccount.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	serviceaccount.NameValidator = serviceaccountDescName.Validators[0].(func(string) error)
+	// serviceaccountDescID is the schema descriptor for id field.
+	serviceaccountDescID := serviceaccountFields[0].Descriptor()
+	// serviceaccount.DefaultID holds the default value on creation for the id field.
+	serviceaccount.DefaultID = serviceaccountDescID.Default.(func() gidx.PrefixedID)
 	tenantMixin := schema.Tenant{}.Mixin()
 	tenantMixinFields0 := tenantMixin[0].Fields()
 	_ = tenantMixinFields0
@@ -43,8 +78,48 @@ func init() {
 	tenant.DefaultUpdatedAt = tenantDescUpdatedAt.Default.(func() time.Time)
 	// tenant.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	tenant.UpdateDefaultUpdatedAt = tenantDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// tenantDescMaxChildren is the schema descriptor for max_children field.
+	tenantDescMaxChildren := tenantFields[8].Descriptor()
+	// tenant.MaxChildrenValidator is a validator for the "max_children" field. It is called by the builders before save.
+	tenant.MaxChildrenValidator = tenantDescMaxChildren.Validators[0].(func(int) error)
+	// tenantDescExternalID is the schema descriptor for external_id field.
+	tenantDescExternalID := tenantFields[11].Descriptor()
+	// tenant.ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	tenant.ExternalIDValidator = tenantDescExternalID.Validators[0].(func(string) error)
+	// tenantDescChangeSeq is the schema descriptor for change_seq field.
+	tenantDescChangeSeq := tenantFields[16].Descriptor()
+	// tenant.ChangeSeqValidator is a validator for the "change_seq" field. It is called by the builders before save.
+	tenant.ChangeSeqValidator = tenantDescChangeSeq.Validators[0].(func(int64) error)
+	// tenantDescVersion is the schema descriptor for version field.
+	tenantDescVersion := tenantFields[17].Descriptor()
+	// tenant.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	tenant.VersionValidator = tenantDescVersion.Validators[0].(func(int64) error)
 	// tenantDescID is the schema descriptor for id field.
 	tenantDescID := tenantFields[0].Descriptor()
 	// tenant.DefaultID holds the default value on creation for the id field.
 	tenant.DefaultID = tenantDescID.Default.(func() gidx.PrefixedID)
+	tenantauditFields := schema.TenantAudit{}.Fields()
+	_ = tenantauditFields
+	// tenantauditDescID is the schema descriptor for id field.
+	tenantauditDescID := tenantauditFields[0].Descriptor()
+	// tenantaudit.DefaultID holds the default value on creation for the id field.
+	tenantaudit.DefaultID = tenantauditDescID.Default.(func() gidx.PrefixedID)
+	tenantcreateguardFields := schema.TenantCreateGuard{}.Fields()
+	_ = tenantcreateguardFields
+	// tenantcreateguardDescID is the schema descriptor for id field.
+	tenantcreateguardDescID := tenantcreateguardFields[0].Descriptor()
+	// tenantcreateguard.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	tenantcreateguard.IDValidator = tenantcreateguardDescID.Validators[0].(func(string) error)
+	tenantparenthistoryFields := schema.TenantParentHistory{}.Fields()
+	_ = tenantparenthistoryFields
+	// tenantparenthistoryDescID is the schema descriptor for id field.
+	tenantparenthistoryDescID := tenantparenthistoryFields[0].Descriptor()
+	// tenantparenthistory.DefaultID holds the default value on creation for the id field.
+	tenantparenthistory.DefaultID = tenantparenthistoryDescID.Default.(func() gidx.PrefixedID)
+	tenantusageFields := schema.TenantUsage{}.Fields()
+	_ = tenantusageFields
+	// tenantusageDescCount is the schema descriptor for count field.
+	tenantusageDescCount := tenantusageFields[4].Descriptor()
+	// tenantusage.CountValidator is a validator for the "count" field. It is called by the builders before save.
+	tenantusage.CountValidator = tenantusageDescCount.Validators[0].(func(int64) error)
 }
@@ -0,0 +1,119 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+)
+
+// The oldest binaries compatible with the schema, declared by migrations.
+type SchemaCompatibility struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The version of the migration declaring the compatibility.
+	ID int64 `json:"id,omitempty"`
+	// The version of the oldest binary compatible with the schema once the migration is applied, the version of the latest migration it embeds.
+	MinBinaryVersion int64 `json:"min_binary_version,omitempty"`
+	selectValues     sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SchemaCompatibility) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case schemacompatibility.FieldID, schemacompatibility.FieldMinBinaryVersion:
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SchemaCompatibility fields.
+func (sc *SchemaCompatibility) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case schemacompatibility.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			sc.ID = int64(value.Int64)
+		case schemacompatibility.FieldMinBinaryVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_binary_version", values[i])
+			} else if value.Valid {
+				sc.MinBinaryVersion = value.Int64
+			}
+		default:
+			sc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SchemaCompatibility.
+// This includes values selected through modifiers, order, etc.
+func (sc *SchemaCompatibility) Value(name string) (ent.Value, error) {
+	return sc.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this SchemaCompatibility.
+// Note that you need to call SchemaCompatibility.Unwrap() before calling this method if this SchemaCompatibility
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (sc *SchemaCompatibility) Update() *SchemaCompatibilityUpdateOne {
+	return NewSchemaCompatibilityClient(sc.config).UpdateOne(sc)
+}
+
+// Unwrap unwraps the SchemaCompatibility entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (sc *SchemaCompatibility) Unwrap() *SchemaCompatibility {
+	_tx, ok := sc.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: SchemaCompatibility is not a transactional entity")
+	}
+	sc.config.driver = _tx.drv
+	return sc
+}
+
+// String implements the fmt.Stringer.
+func (sc *SchemaCompatibility) String() string {
+	var builder strings.Builder
+	builder.WriteString("SchemaCompatibility(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", sc.ID))
+	builder.WriteString("min_binary_version=")
+	builder.WriteString(fmt.Sprintf("%v", sc.MinBinaryVersion))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (sc SchemaCompatibility) IsEntity() {}
+
+// SchemaCompatibilities is a parsable slice of SchemaCompatibility.
+type SchemaCompatibilities []*SchemaCompatibility
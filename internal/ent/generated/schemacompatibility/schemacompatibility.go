@@ -0,0 +1,66 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package schemacompatibility
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the schemacompatibility type in the database.
+	Label = "schema_compatibility"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldMinBinaryVersion holds the string denoting the min_binary_version field in the database.
+	FieldMinBinaryVersion = "min_binary_version"
+	// Table holds the table name of the schemacompatibility in the database.
+	Table = "schema_compatibility"
+)
+
+// Columns holds all SQL columns for schemacompatibility fields.
+var Columns = []string{
+	FieldID,
+	FieldMinBinaryVersion,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// MinBinaryVersionValidator is a validator for the "min_binary_version" field. It is called by the builders before save.
+	MinBinaryVersionValidator func(int64) error
+)
+
+// OrderOption defines the ordering options for the SchemaCompatibility queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByMinBinaryVersion orders the results by the min_binary_version field.
+func ByMinBinaryVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinBinaryVersion, opts...).ToFunc()
+}
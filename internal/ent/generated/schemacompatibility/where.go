@@ -0,0 +1,144 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package schemacompatibility
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldLTE(FieldID, id))
+}
+
+// MinBinaryVersion applies equality check predicate on the "min_binary_version" field. It's identical to MinBinaryVersionEQ.
+func MinBinaryVersion(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldEQ(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionEQ applies the EQ predicate on the "min_binary_version" field.
+func MinBinaryVersionEQ(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldEQ(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionNEQ applies the NEQ predicate on the "min_binary_version" field.
+func MinBinaryVersionNEQ(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldNEQ(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionIn applies the In predicate on the "min_binary_version" field.
+func MinBinaryVersionIn(vs ...int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldIn(FieldMinBinaryVersion, vs...))
+}
+
+// MinBinaryVersionNotIn applies the NotIn predicate on the "min_binary_version" field.
+func MinBinaryVersionNotIn(vs ...int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldNotIn(FieldMinBinaryVersion, vs...))
+}
+
+// MinBinaryVersionGT applies the GT predicate on the "min_binary_version" field.
+func MinBinaryVersionGT(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldGT(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionGTE applies the GTE predicate on the "min_binary_version" field.
+func MinBinaryVersionGTE(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldGTE(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionLT applies the LT predicate on the "min_binary_version" field.
+func MinBinaryVersionLT(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldLT(FieldMinBinaryVersion, v))
+}
+
+// MinBinaryVersionLTE applies the LTE predicate on the "min_binary_version" field.
+func MinBinaryVersionLTE(v int64) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(sql.FieldLTE(FieldMinBinaryVersion, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SchemaCompatibility) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SchemaCompatibility) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SchemaCompatibility) predicate.SchemaCompatibility {
+	return predicate.SchemaCompatibility(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+)
+
+// SchemaCompatibilityCreate is the builder for creating a SchemaCompatibility entity.
+type SchemaCompatibilityCreate struct {
+	config
+	mutation *SchemaCompatibilityMutation
+	hooks    []Hook
+}
+
+// SetMinBinaryVersion sets the "min_binary_version" field.
+func (scc *SchemaCompatibilityCreate) SetMinBinaryVersion(i int64) *SchemaCompatibilityCreate {
+	scc.mutation.SetMinBinaryVersion(i)
+	return scc
+}
+
+// SetID sets the "id" field.
+func (scc *SchemaCompatibilityCreate) SetID(i int64) *SchemaCompatibilityCreate {
+	scc.mutation.SetID(i)
+	return scc
+}
+
+// Mutation returns the SchemaCompatibilityMutation object of the builder.
+func (scc *SchemaCompatibilityCreate) Mutation() *SchemaCompatibilityMutation {
+	return scc.mutation
+}
+
+// Save creates the SchemaCompatibility in the database.
+func (scc *SchemaCompatibilityCreate) Save(ctx context.Context) (*SchemaCompatibility, error) {
+	return withHooks(ctx, scc.sqlSave, scc.mutation, scc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (scc *SchemaCompatibilityCreate) SaveX(ctx context.Context) *SchemaCompatibility {
+	v, err := scc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (scc *SchemaCompatibilityCreate) Exec(ctx context.Context) error {
+	_, err := scc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scc *SchemaCompatibilityCreate) ExecX(ctx context.Context) {
+	if err := scc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (scc *SchemaCompatibilityCreate) check() error {
+	if _, ok := scc.mutation.MinBinaryVersion(); !ok {
+		return &ValidationError{Name: "min_binary_version", err: errors.New(`generated: missing required field "SchemaCompatibility.min_binary_version"`)}
+	}
+	if v, ok := scc.mutation.MinBinaryVersion(); ok {
+		if err := schemacompatibility.MinBinaryVersionValidator(v); err != nil {
+			return &ValidationError{Name: "min_binary_version", err: fmt.Errorf(`generated: validator failed for field "SchemaCompatibility.min_binary_version": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (scc *SchemaCompatibilityCreate) sqlSave(ctx context.Context) (*SchemaCompatibility, error) {
+	if err := scc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := scc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, scc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	scc.mutation.id = &_node.ID
+	scc.mutation.done = true
+	return _node, nil
+}
+
+func (scc *SchemaCompatibilityCreate) createSpec() (*SchemaCompatibility, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SchemaCompatibility{config: scc.config}
+		_spec = sqlgraph.NewCreateSpec(schemacompatibility.Table, sqlgraph.NewFieldSpec(schemacompatibility.FieldID, field.TypeInt64))
+	)
+	if id, ok := scc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := scc.mutation.MinBinaryVersion(); ok {
+		_spec.SetField(schemacompatibility.FieldMinBinaryVersion, field.TypeInt64, value)
+		_node.MinBinaryVersion = value
+	}
+	return _node, _spec
+}
+
+// SchemaCompatibilityCreateBulk is the builder for creating many SchemaCompatibility entities in bulk.
+type SchemaCompatibilityCreateBulk struct {
+	config
+	builders []*SchemaCompatibilityCreate
+}
+
+// Save creates the SchemaCompatibility entities in the database.
+func (sccb *SchemaCompatibilityCreateBulk) Save(ctx context.Context) ([]*SchemaCompatibility, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(sccb.builders))
+	nodes := make([]*SchemaCompatibility, len(sccb.builders))
+	mutators := make([]Mutator, len(sccb.builders))
+	for i := range sccb.builders {
+		func(i int, root context.Context) {
+			builder := sccb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SchemaCompatibilityMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, sccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, sccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, sccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (sccb *SchemaCompatibilityCreateBulk) SaveX(ctx context.Context) []*SchemaCompatibility {
+	v, err := sccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sccb *SchemaCompatibilityCreateBulk) Exec(ctx context.Context) error {
+	_, err := sccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sccb *SchemaCompatibilityCreateBulk) ExecX(ctx context.Context) {
+	if err := sccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+)
+
+// SchemaCompatibilityDelete is the builder for deleting a SchemaCompatibility entity.
+type SchemaCompatibilityDelete struct {
+	config
+	hooks    []Hook
+	mutation *SchemaCompatibilityMutation
+}
+
+// Where appends a list predicates to the SchemaCompatibilityDelete builder.
+func (scd *SchemaCompatibilityDelete) Where(ps ...predicate.SchemaCompatibility) *SchemaCompatibilityDelete {
+	scd.mutation.Where(ps...)
+	return scd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (scd *SchemaCompatibilityDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, scd.sqlExec, scd.mutation, scd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scd *SchemaCompatibilityDelete) ExecX(ctx context.Context) int {
+	n, err := scd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (scd *SchemaCompatibilityDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(schemacompatibility.Table, sqlgraph.NewFieldSpec(schemacompatibility.FieldID, field.TypeInt64))
+	if ps := scd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, scd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	scd.mutation.done = true
+	return affected, err
+}
+
+// SchemaCompatibilityDeleteOne is the builder for deleting a single SchemaCompatibility entity.
+type SchemaCompatibilityDeleteOne struct {
+	scd *SchemaCompatibilityDelete
+}
+
+// Where appends a list predicates to the SchemaCompatibilityDelete builder.
+func (scdo *SchemaCompatibilityDeleteOne) Where(ps ...predicate.SchemaCompatibility) *SchemaCompatibilityDeleteOne {
+	scdo.scd.mutation.Where(ps...)
+	return scdo
+}
+
+// Exec executes the deletion query.
+func (scdo *SchemaCompatibilityDeleteOne) Exec(ctx context.Context) error {
+	n, err := scdo.scd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{schemacompatibility.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scdo *SchemaCompatibilityDeleteOne) ExecX(ctx context.Context) {
+	if err := scdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,553 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+)
+
+// SchemaCompatibilityQuery is the builder for querying SchemaCompatibility entities.
+type SchemaCompatibilityQuery struct {
+	config
+	ctx        *QueryContext
+	order      []schemacompatibility.OrderOption
+	inters     []Interceptor
+	predicates []predicate.SchemaCompatibility
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*SchemaCompatibility) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the SchemaCompatibilityQuery builder.
+func (scq *SchemaCompatibilityQuery) Where(ps ...predicate.SchemaCompatibility) *SchemaCompatibilityQuery {
+	scq.predicates = append(scq.predicates, ps...)
+	return scq
+}
+
+// Limit the number of records to be returned by this query.
+func (scq *SchemaCompatibilityQuery) Limit(limit int) *SchemaCompatibilityQuery {
+	scq.ctx.Limit = &limit
+	return scq
+}
+
+// Offset to start from.
+func (scq *SchemaCompatibilityQuery) Offset(offset int) *SchemaCompatibilityQuery {
+	scq.ctx.Offset = &offset
+	return scq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (scq *SchemaCompatibilityQuery) Unique(unique bool) *SchemaCompatibilityQuery {
+	scq.ctx.Unique = &unique
+	return scq
+}
+
+// Order specifies how the records should be ordered.
+func (scq *SchemaCompatibilityQuery) Order(o ...schemacompatibility.OrderOption) *SchemaCompatibilityQuery {
+	scq.order = append(scq.order, o...)
+	return scq
+}
+
+// First returns the first SchemaCompatibility entity from the query.
+// Returns a *NotFoundError when no SchemaCompatibility was found.
+func (scq *SchemaCompatibilityQuery) First(ctx context.Context) (*SchemaCompatibility, error) {
+	nodes, err := scq.Limit(1).All(setContextOp(ctx, scq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{schemacompatibility.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) FirstX(ctx context.Context) *SchemaCompatibility {
+	node, err := scq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first SchemaCompatibility ID from the query.
+// Returns a *NotFoundError when no SchemaCompatibility ID was found.
+func (scq *SchemaCompatibilityQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = scq.Limit(1).IDs(setContextOp(ctx, scq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{schemacompatibility.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := scq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single SchemaCompatibility entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one SchemaCompatibility entity is found.
+// Returns a *NotFoundError when no SchemaCompatibility entities are found.
+func (scq *SchemaCompatibilityQuery) Only(ctx context.Context) (*SchemaCompatibility, error) {
+	nodes, err := scq.Limit(2).All(setContextOp(ctx, scq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{schemacompatibility.Label}
+	default:
+		return nil, &NotSingularError{schemacompatibility.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) OnlyX(ctx context.Context) *SchemaCompatibility {
+	node, err := scq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only SchemaCompatibility ID in the query.
+// Returns a *NotSingularError when more than one SchemaCompatibility ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (scq *SchemaCompatibilityQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = scq.Limit(2).IDs(setContextOp(ctx, scq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{schemacompatibility.Label}
+	default:
+		err = &NotSingularError{schemacompatibility.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := scq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of SchemaCompatibilities.
+func (scq *SchemaCompatibilityQuery) All(ctx context.Context) ([]*SchemaCompatibility, error) {
+	ctx = setContextOp(ctx, scq.ctx, "All")
+	if err := scq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*SchemaCompatibility, *SchemaCompatibilityQuery]()
+	return withInterceptors[[]*SchemaCompatibility](ctx, scq, qr, scq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) AllX(ctx context.Context) []*SchemaCompatibility {
+	nodes, err := scq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of SchemaCompatibility IDs.
+func (scq *SchemaCompatibilityQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if scq.ctx.Unique == nil && scq.path != nil {
+		scq.Unique(true)
+	}
+	ctx = setContextOp(ctx, scq.ctx, "IDs")
+	if err = scq.Select(schemacompatibility.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := scq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (scq *SchemaCompatibilityQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, scq.ctx, "Count")
+	if err := scq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, scq, querierCount[*SchemaCompatibilityQuery](), scq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) CountX(ctx context.Context) int {
+	count, err := scq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (scq *SchemaCompatibilityQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, scq.ctx, "Exist")
+	switch _, err := scq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (scq *SchemaCompatibilityQuery) ExistX(ctx context.Context) bool {
+	exist, err := scq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the SchemaCompatibilityQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (scq *SchemaCompatibilityQuery) Clone() *SchemaCompatibilityQuery {
+	if scq == nil {
+		return nil
+	}
+	return &SchemaCompatibilityQuery{
+		config:     scq.config,
+		ctx:        scq.ctx.Clone(),
+		order:      append([]schemacompatibility.OrderOption{}, scq.order...),
+		inters:     append([]Interceptor{}, scq.inters...),
+		predicates: append([]predicate.SchemaCompatibility{}, scq.predicates...),
+		// clone intermediate query.
+		sql:  scq.sql.Clone(),
+		path: scq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		MinBinaryVersion int64 `json:"min_binary_version,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.SchemaCompatibility.Query().
+//		GroupBy(schemacompatibility.FieldMinBinaryVersion).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (scq *SchemaCompatibilityQuery) GroupBy(field string, fields ...string) *SchemaCompatibilityGroupBy {
+	scq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &SchemaCompatibilityGroupBy{build: scq}
+	grbuild.flds = &scq.ctx.Fields
+	grbuild.label = schemacompatibility.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		MinBinaryVersion int64 `json:"min_binary_version,omitempty"`
+//	}
+//
+//	client.SchemaCompatibility.Query().
+//		Select(schemacompatibility.FieldMinBinaryVersion).
+//		Scan(ctx, &v)
+func (scq *SchemaCompatibilityQuery) Select(fields ...string) *SchemaCompatibilitySelect {
+	scq.ctx.Fields = append(scq.ctx.Fields, fields...)
+	sbuild := &SchemaCompatibilitySelect{SchemaCompatibilityQuery: scq}
+	sbuild.label = schemacompatibility.Label
+	sbuild.flds, sbuild.scan = &scq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a SchemaCompatibilitySelect configured with the given aggregations.
+func (scq *SchemaCompatibilityQuery) Aggregate(fns ...AggregateFunc) *SchemaCompatibilitySelect {
+	return scq.Select().Aggregate(fns...)
+}
+
+func (scq *SchemaCompatibilityQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range scq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, scq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range scq.ctx.Fields {
+		if !schemacompatibility.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if scq.path != nil {
+		prev, err := scq.path(ctx)
+		if err != nil {
+			return err
+		}
+		scq.sql = prev
+	}
+	return nil
+}
+
+func (scq *SchemaCompatibilityQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*SchemaCompatibility, error) {
+	var (
+		nodes = []*SchemaCompatibility{}
+		_spec = scq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*SchemaCompatibility).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &SchemaCompatibility{config: scq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(scq.modifiers) > 0 {
+		_spec.Modifiers = scq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, scq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range scq.loadTotal {
+		if err := scq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (scq *SchemaCompatibilityQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := scq.querySpec()
+	if len(scq.modifiers) > 0 {
+		_spec.Modifiers = scq.modifiers
+	}
+	_spec.Node.Columns = scq.ctx.Fields
+	if len(scq.ctx.Fields) > 0 {
+		_spec.Unique = scq.ctx.Unique != nil && *scq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, scq.driver, _spec)
+}
+
+func (scq *SchemaCompatibilityQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(schemacompatibility.Table, schemacompatibility.Columns, sqlgraph.NewFieldSpec(schemacompatibility.FieldID, field.TypeInt64))
+	_spec.From = scq.sql
+	if unique := scq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if scq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := scq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, schemacompatibility.FieldID)
+		for i := range fields {
+			if fields[i] != schemacompatibility.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := scq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := scq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := scq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := scq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (scq *SchemaCompatibilityQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(scq.driver.Dialect())
+	t1 := builder.Table(schemacompatibility.Table)
+	columns := scq.ctx.Fields
+	if len(columns) == 0 {
+		columns = schemacompatibility.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if scq.sql != nil {
+		selector = scq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if scq.ctx.Unique != nil && *scq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range scq.predicates {
+		p(selector)
+	}
+	for _, p := range scq.order {
+		p(selector)
+	}
+	if offset := scq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := scq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// SchemaCompatibilityGroupBy is the group-by builder for SchemaCompatibility entities.
+type SchemaCompatibilityGroupBy struct {
+	selector
+	build *SchemaCompatibilityQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (scgb *SchemaCompatibilityGroupBy) Aggregate(fns ...AggregateFunc) *SchemaCompatibilityGroupBy {
+	scgb.fns = append(scgb.fns, fns...)
+	return scgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (scgb *SchemaCompatibilityGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, scgb.build.ctx, "GroupBy")
+	if err := scgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*SchemaCompatibilityQuery, *SchemaCompatibilityGroupBy](ctx, scgb.build, scgb, scgb.build.inters, v)
+}
+
+func (scgb *SchemaCompatibilityGroupBy) sqlScan(ctx context.Context, root *SchemaCompatibilityQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(scgb.fns))
+	for _, fn := range scgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*scgb.flds)+len(scgb.fns))
+		for _, f := range *scgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*scgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := scgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// SchemaCompatibilitySelect is the builder for selecting fields of SchemaCompatibility entities.
+type SchemaCompatibilitySelect struct {
+	*SchemaCompatibilityQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (scs *SchemaCompatibilitySelect) Aggregate(fns ...AggregateFunc) *SchemaCompatibilitySelect {
+	scs.fns = append(scs.fns, fns...)
+	return scs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (scs *SchemaCompatibilitySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, scs.ctx, "Select")
+	if err := scs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*SchemaCompatibilityQuery, *SchemaCompatibilitySelect](ctx, scs.SchemaCompatibilityQuery, scs, scs.inters, v)
+}
+
+func (scs *SchemaCompatibilitySelect) sqlScan(ctx context.Context, root *SchemaCompatibilityQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(scs.fns))
+	for _, fn := range scs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*scs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := scs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
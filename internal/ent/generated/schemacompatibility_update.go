@@ -0,0 +1,189 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/schemacompatibility"
+)
+
+// SchemaCompatibilityUpdate is the builder for updating SchemaCompatibility entities.
+type SchemaCompatibilityUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SchemaCompatibilityMutation
+}
+
+// Where appends a list predicates to the SchemaCompatibilityUpdate builder.
+func (scu *SchemaCompatibilityUpdate) Where(ps ...predicate.SchemaCompatibility) *SchemaCompatibilityUpdate {
+	scu.mutation.Where(ps...)
+	return scu
+}
+
+// Mutation returns the SchemaCompatibilityMutation object of the builder.
+func (scu *SchemaCompatibilityUpdate) Mutation() *SchemaCompatibilityMutation {
+	return scu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (scu *SchemaCompatibilityUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, scu.sqlSave, scu.mutation, scu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scu *SchemaCompatibilityUpdate) SaveX(ctx context.Context) int {
+	affected, err := scu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (scu *SchemaCompatibilityUpdate) Exec(ctx context.Context) error {
+	_, err := scu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scu *SchemaCompatibilityUpdate) ExecX(ctx context.Context) {
+	if err := scu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (scu *SchemaCompatibilityUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(schemacompatibility.Table, schemacompatibility.Columns, sqlgraph.NewFieldSpec(schemacompatibility.FieldID, field.TypeInt64))
+	if ps := scu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, scu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{schemacompatibility.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	scu.mutation.done = true
+	return n, nil
+}
+
+// SchemaCompatibilityUpdateOne is the builder for updating a single SchemaCompatibility entity.
+type SchemaCompatibilityUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SchemaCompatibilityMutation
+}
+
+// Mutation returns the SchemaCompatibilityMutation object of the builder.
+func (scuo *SchemaCompatibilityUpdateOne) Mutation() *SchemaCompatibilityMutation {
+	return scuo.mutation
+}
+
+// Where appends a list predicates to the SchemaCompatibilityUpdate builder.
+func (scuo *SchemaCompatibilityUpdateOne) Where(ps ...predicate.SchemaCompatibility) *SchemaCompatibilityUpdateOne {
+	scuo.mutation.Where(ps...)
+	return scuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (scuo *SchemaCompatibilityUpdateOne) Select(field string, fields ...string) *SchemaCompatibilityUpdateOne {
+	scuo.fields = append([]string{field}, fields...)
+	return scuo
+}
+
+// Save executes the query and returns the updated SchemaCompatibility entity.
+func (scuo *SchemaCompatibilityUpdateOne) Save(ctx context.Context) (*SchemaCompatibility, error) {
+	return withHooks(ctx, scuo.sqlSave, scuo.mutation, scuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scuo *SchemaCompatibilityUpdateOne) SaveX(ctx context.Context) *SchemaCompatibility {
+	node, err := scuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (scuo *SchemaCompatibilityUpdateOne) Exec(ctx context.Context) error {
+	_, err := scuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scuo *SchemaCompatibilityUpdateOne) ExecX(ctx context.Context) {
+	if err := scuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (scuo *SchemaCompatibilityUpdateOne) sqlSave(ctx context.Context) (_node *SchemaCompatibility, err error) {
+	_spec := sqlgraph.NewUpdateSpec(schemacompatibility.Table, schemacompatibility.Columns, sqlgraph.NewFieldSpec(schemacompatibility.FieldID, field.TypeInt64))
+	id, ok := scuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "SchemaCompatibility.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := scuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, schemacompatibility.FieldID)
+		for _, f := range fields {
+			if !schemacompatibility.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != schemacompatibility.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := scuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &SchemaCompatibility{config: scuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, scuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{schemacompatibility.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	scuo.mutation.done = true
+	return _node, nil
+}
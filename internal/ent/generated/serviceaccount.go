@@ -0,0 +1,213 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/x/gidx"
+)
+
+// A machine identity belonging to a tenant.
+type ServiceAccount struct {
+	config `json:"-"`
+	// ID of the ent.
+	// ID for the service account, the subject credentials are bound to.
+	ID gidx.PrefixedID `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// The ID of the tenant the service account belongs to, it may act within its subtree.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The name of the service account, unique within its tenant.
+	Name string `json:"name,omitempty"`
+	// An optional description of the service account.
+	Description string `json:"description,omitempty"`
+	// The subject which created the service account, empty when unknown.
+	CreatedBy string `json:"created_by,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ServiceAccountQuery when eager-loading is set.
+	Edges        ServiceAccountEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// ServiceAccountEdges holds the relations/edges for other nodes in the graph.
+type ServiceAccountEdges struct {
+	// Tenant holds the value of the tenant edge.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+	// totalCount holds the count of the edges above.
+	totalCount [1]map[string]int
+}
+
+// TenantOrErr returns the Tenant value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ServiceAccountEdges) TenantOrErr() (*Tenant, error) {
+	if e.loadedTypes[0] {
+		if e.Tenant == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: tenant.Label}
+		}
+		return e.Tenant, nil
+	}
+	return nil, &NotLoadedError{edge: "tenant"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ServiceAccount) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case serviceaccount.FieldID, serviceaccount.FieldTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case serviceaccount.FieldName, serviceaccount.FieldDescription, serviceaccount.FieldCreatedBy:
+			values[i] = new(sql.NullString)
+		case serviceaccount.FieldCreatedAt, serviceaccount.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ServiceAccount fields.
+func (sa *ServiceAccount) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case serviceaccount.FieldID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				sa.ID = *value
+			}
+		case serviceaccount.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				sa.CreatedAt = value.Time
+			}
+		case serviceaccount.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				sa.UpdatedAt = value.Time
+			}
+		case serviceaccount.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				sa.TenantID = *value
+			}
+		case serviceaccount.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				sa.Name = value.String
+			}
+		case serviceaccount.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				sa.Description = value.String
+			}
+		case serviceaccount.FieldCreatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[i])
+			} else if value.Valid {
+				sa.CreatedBy = value.String
+			}
+		default:
+			sa.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ServiceAccount.
+// This includes values selected through modifiers, order, etc.
+func (sa *ServiceAccount) Value(name string) (ent.Value, error) {
+	return sa.selectValues.Get(name)
+}
+
+// QueryTenant queries the "tenant" edge of the ServiceAccount entity.
+func (sa *ServiceAccount) QueryTenant() *TenantQuery {
+	return NewServiceAccountClient(sa.config).QueryTenant(sa)
+}
+
+// Update returns a builder for updating this ServiceAccount.
+// Note that you need to call ServiceAccount.Unwrap() before calling this method if this ServiceAccount
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (sa *ServiceAccount) Update() *ServiceAccountUpdateOne {
+	return NewServiceAccountClient(sa.config).UpdateOne(sa)
+}
+
+// Unwrap unwraps the ServiceAccount entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (sa *ServiceAccount) Unwrap() *ServiceAccount {
+	_tx, ok := sa.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: ServiceAccount is not a transactional entity")
+	}
+	sa.config.driver = _tx.drv
+	return sa
+}
+
+// String implements the fmt.Stringer.
+func (sa *ServiceAccount) String() string {
+	var builder strings.Builder
+	builder.WriteString("ServiceAccount(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", sa.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(sa.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(sa.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", sa.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(sa.Name)
+	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(sa.Description)
+	builder.WriteString(", ")
+	builder.WriteString("created_by=")
+	builder.WriteString(sa.CreatedBy)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (sa ServiceAccount) IsEntity() {}
+
+// ServiceAccounts is a parsable slice of ServiceAccount.
+type ServiceAccounts []*ServiceAccount
@@ -0,0 +1,350 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/x/gidx"
+)
+
+// ServiceAccountCreate is the builder for creating a ServiceAccount entity.
+type ServiceAccountCreate struct {
+	config
+	mutation *ServiceAccountMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sac *ServiceAccountCreate) SetCreatedAt(t time.Time) *ServiceAccountCreate {
+	sac.mutation.SetCreatedAt(t)
+	return sac
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sac *ServiceAccountCreate) SetNillableCreatedAt(t *time.Time) *ServiceAccountCreate {
+	if t != nil {
+		sac.SetCreatedAt(*t)
+	}
+	return sac
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (sac *ServiceAccountCreate) SetUpdatedAt(t time.Time) *ServiceAccountCreate {
+	sac.mutation.SetUpdatedAt(t)
+	return sac
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (sac *ServiceAccountCreate) SetNillableUpdatedAt(t *time.Time) *ServiceAccountCreate {
+	if t != nil {
+		sac.SetUpdatedAt(*t)
+	}
+	return sac
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (sac *ServiceAccountCreate) SetTenantID(gi gidx.PrefixedID) *ServiceAccountCreate {
+	sac.mutation.SetTenantID(gi)
+	return sac
+}
+
+// SetName sets the "name" field.
+func (sac *ServiceAccountCreate) SetName(s string) *ServiceAccountCreate {
+	sac.mutation.SetName(s)
+	return sac
+}
+
+// SetDescription sets the "description" field.
+func (sac *ServiceAccountCreate) SetDescription(s string) *ServiceAccountCreate {
+	sac.mutation.SetDescription(s)
+	return sac
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (sac *ServiceAccountCreate) SetNillableDescription(s *string) *ServiceAccountCreate {
+	if s != nil {
+		sac.SetDescription(*s)
+	}
+	return sac
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (sac *ServiceAccountCreate) SetCreatedBy(s string) *ServiceAccountCreate {
+	sac.mutation.SetCreatedBy(s)
+	return sac
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (sac *ServiceAccountCreate) SetNillableCreatedBy(s *string) *ServiceAccountCreate {
+	if s != nil {
+		sac.SetCreatedBy(*s)
+	}
+	return sac
+}
+
+// SetID sets the "id" field.
+func (sac *ServiceAccountCreate) SetID(gi gidx.PrefixedID) *ServiceAccountCreate {
+	sac.mutation.SetID(gi)
+	return sac
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (sac *ServiceAccountCreate) SetNillableID(gi *gidx.PrefixedID) *ServiceAccountCreate {
+	if gi != nil {
+		sac.SetID(*gi)
+	}
+	return sac
+}
+
+// SetTenant sets the "tenant" edge to the Tenant entity.
+func (sac *ServiceAccountCreate) SetTenant(t *Tenant) *ServiceAccountCreate {
+	return sac.SetTenantID(t.ID)
+}
+
+// Mutation returns the ServiceAccountMutation object of the builder.
+func (sac *ServiceAccountCreate) Mutation() *ServiceAccountMutation {
+	return sac.mutation
+}
+
+// Save creates the ServiceAccount in the database.
+func (sac *ServiceAccountCreate) Save(ctx context.Context) (*ServiceAccount, error) {
+	sac.defaults()
+	return withHooks(ctx, sac.sqlSave, sac.mutation, sac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sac *ServiceAccountCreate) SaveX(ctx context.Context) *ServiceAccount {
+	v, err := sac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sac *ServiceAccountCreate) Exec(ctx context.Context) error {
+	_, err := sac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sac *ServiceAccountCreate) ExecX(ctx context.Context) {
+	if err := sac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sac *ServiceAccountCreate) defaults() {
+	if _, ok := sac.mutation.CreatedAt(); !ok {
+		v := serviceaccount.DefaultCreatedAt()
+		sac.mutation.SetCreatedAt(v)
+	}
+	if _, ok := sac.mutation.UpdatedAt(); !ok {
+		v := serviceaccount.DefaultUpdatedAt()
+		sac.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := sac.mutation.ID(); !ok {
+		v := serviceaccount.DefaultID()
+		sac.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sac *ServiceAccountCreate) check() error {
+	if _, ok := sac.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`generated: missing required field "ServiceAccount.created_at"`)}
+	}
+	if _, ok := sac.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`generated: missing required field "ServiceAccount.updated_at"`)}
+	}
+	if _, ok := sac.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "ServiceAccount.tenant_id"`)}
+	}
+	if _, ok := sac.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`generated: missing required field "ServiceAccount.name"`)}
+	}
+	if v, ok := sac.mutation.Name(); ok {
+		if err := serviceaccount.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`generated: validator failed for field "ServiceAccount.name": %w`, err)}
+		}
+	}
+	if _, ok := sac.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant", err: errors.New(`generated: missing required edge "ServiceAccount.tenant"`)}
+	}
+	return nil
+}
+
+func (sac *ServiceAccountCreate) sqlSave(ctx context.Context) (*ServiceAccount, error) {
+	if err := sac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*gidx.PrefixedID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	sac.mutation.id = &_node.ID
+	sac.mutation.done = true
+	return _node, nil
+}
+
+func (sac *ServiceAccountCreate) createSpec() (*ServiceAccount, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ServiceAccount{config: sac.config}
+		_spec = sqlgraph.NewCreateSpec(serviceaccount.Table, sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString))
+	)
+	if id, ok := sac.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := sac.mutation.CreatedAt(); ok {
+		_spec.SetField(serviceaccount.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := sac.mutation.UpdatedAt(); ok {
+		_spec.SetField(serviceaccount.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := sac.mutation.Name(); ok {
+		_spec.SetField(serviceaccount.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := sac.mutation.Description(); ok {
+		_spec.SetField(serviceaccount.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := sac.mutation.CreatedBy(); ok {
+		_spec.SetField(serviceaccount.FieldCreatedBy, field.TypeString, value)
+		_node.CreatedBy = value
+	}
+	if nodes := sac.mutation.TenantIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   serviceaccount.TenantTable,
+			Columns: []string{serviceaccount.TenantColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.TenantID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ServiceAccountCreateBulk is the builder for creating many ServiceAccount entities in bulk.
+type ServiceAccountCreateBulk struct {
+	config
+	builders []*ServiceAccountCreate
+}
+
+// Save creates the ServiceAccount entities in the database.
+func (sacb *ServiceAccountCreateBulk) Save(ctx context.Context) ([]*ServiceAccount, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(sacb.builders))
+	nodes := make([]*ServiceAccount, len(sacb.builders))
+	mutators := make([]Mutator, len(sacb.builders))
+	for i := range sacb.builders {
+		func(i int, root context.Context) {
+			builder := sacb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ServiceAccountMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, sacb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, sacb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, sacb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (sacb *ServiceAccountCreateBulk) SaveX(ctx context.Context) []*ServiceAccount {
+	v, err := sacb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sacb *ServiceAccountCreateBulk) Exec(ctx context.Context) error {
+	_, err := sacb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sacb *ServiceAccountCreateBulk) ExecX(ctx context.Context) {
+	if err := sacb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
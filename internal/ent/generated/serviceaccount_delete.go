@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+)
+
+// ServiceAccountDelete is the builder for deleting a ServiceAccount entity.
+type ServiceAccountDelete struct {
+	config
+	hooks    []Hook
+	mutation *ServiceAccountMutation
+}
+
+// Where appends a list predicates to the ServiceAccountDelete builder.
+func (sad *ServiceAccountDelete) Where(ps ...predicate.ServiceAccount) *ServiceAccountDelete {
+	sad.mutation.Where(ps...)
+	return sad
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (sad *ServiceAccountDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, sad.sqlExec, sad.mutation, sad.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sad *ServiceAccountDelete) ExecX(ctx context.Context) int {
+	n, err := sad.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (sad *ServiceAccountDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(serviceaccount.Table, sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString))
+	if ps := sad.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, sad.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	sad.mutation.done = true
+	return affected, err
+}
+
+// ServiceAccountDeleteOne is the builder for deleting a single ServiceAccount entity.
+type ServiceAccountDeleteOne struct {
+	sad *ServiceAccountDelete
+}
+
+// Where appends a list predicates to the ServiceAccountDelete builder.
+func (sado *ServiceAccountDeleteOne) Where(ps ...predicate.ServiceAccount) *ServiceAccountDeleteOne {
+	sado.sad.mutation.Where(ps...)
+	return sado
+}
+
+// Exec executes the deletion query.
+func (sado *ServiceAccountDeleteOne) Exec(ctx context.Context) error {
+	n, err := sado.sad.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{serviceaccount.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sado *ServiceAccountDeleteOne) ExecX(ctx context.Context) {
+	if err := sado.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
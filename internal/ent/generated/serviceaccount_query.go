@@ -0,0 +1,633 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/x/gidx"
+)
+
+// ServiceAccountQuery is the builder for querying ServiceAccount entities.
+type ServiceAccountQuery struct {
+	config
+	ctx        *QueryContext
+	order      []serviceaccount.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ServiceAccount
+	withTenant *TenantQuery
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*ServiceAccount) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ServiceAccountQuery builder.
+func (saq *ServiceAccountQuery) Where(ps ...predicate.ServiceAccount) *ServiceAccountQuery {
+	saq.predicates = append(saq.predicates, ps...)
+	return saq
+}
+
+// Limit the number of records to be returned by this query.
+func (saq *ServiceAccountQuery) Limit(limit int) *ServiceAccountQuery {
+	saq.ctx.Limit = &limit
+	return saq
+}
+
+// Offset to start from.
+func (saq *ServiceAccountQuery) Offset(offset int) *ServiceAccountQuery {
+	saq.ctx.Offset = &offset
+	return saq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (saq *ServiceAccountQuery) Unique(unique bool) *ServiceAccountQuery {
+	saq.ctx.Unique = &unique
+	return saq
+}
+
+// Order specifies how the records should be ordered.
+func (saq *ServiceAccountQuery) Order(o ...serviceaccount.OrderOption) *ServiceAccountQuery {
+	saq.order = append(saq.order, o...)
+	return saq
+}
+
+// QueryTenant chains the current query on the "tenant" edge.
+func (saq *ServiceAccountQuery) QueryTenant() *TenantQuery {
+	query := (&TenantClient{config: saq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := saq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := saq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(serviceaccount.Table, serviceaccount.FieldID, selector),
+			sqlgraph.To(tenant.Table, tenant.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, serviceaccount.TenantTable, serviceaccount.TenantColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(saq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first ServiceAccount entity from the query.
+// Returns a *NotFoundError when no ServiceAccount was found.
+func (saq *ServiceAccountQuery) First(ctx context.Context) (*ServiceAccount, error) {
+	nodes, err := saq.Limit(1).All(setContextOp(ctx, saq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{serviceaccount.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (saq *ServiceAccountQuery) FirstX(ctx context.Context) *ServiceAccount {
+	node, err := saq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ServiceAccount ID from the query.
+// Returns a *NotFoundError when no ServiceAccount ID was found.
+func (saq *ServiceAccountQuery) FirstID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = saq.Limit(1).IDs(setContextOp(ctx, saq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{serviceaccount.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (saq *ServiceAccountQuery) FirstIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := saq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ServiceAccount entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ServiceAccount entity is found.
+// Returns a *NotFoundError when no ServiceAccount entities are found.
+func (saq *ServiceAccountQuery) Only(ctx context.Context) (*ServiceAccount, error) {
+	nodes, err := saq.Limit(2).All(setContextOp(ctx, saq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{serviceaccount.Label}
+	default:
+		return nil, &NotSingularError{serviceaccount.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (saq *ServiceAccountQuery) OnlyX(ctx context.Context) *ServiceAccount {
+	node, err := saq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ServiceAccount ID in the query.
+// Returns a *NotSingularError when more than one ServiceAccount ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (saq *ServiceAccountQuery) OnlyID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = saq.Limit(2).IDs(setContextOp(ctx, saq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{serviceaccount.Label}
+	default:
+		err = &NotSingularError{serviceaccount.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (saq *ServiceAccountQuery) OnlyIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := saq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ServiceAccounts.
+func (saq *ServiceAccountQuery) All(ctx context.Context) ([]*ServiceAccount, error) {
+	ctx = setContextOp(ctx, saq.ctx, "All")
+	if err := saq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ServiceAccount, *ServiceAccountQuery]()
+	return withInterceptors[[]*ServiceAccount](ctx, saq, qr, saq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (saq *ServiceAccountQuery) AllX(ctx context.Context) []*ServiceAccount {
+	nodes, err := saq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ServiceAccount IDs.
+func (saq *ServiceAccountQuery) IDs(ctx context.Context) (ids []gidx.PrefixedID, err error) {
+	if saq.ctx.Unique == nil && saq.path != nil {
+		saq.Unique(true)
+	}
+	ctx = setContextOp(ctx, saq.ctx, "IDs")
+	if err = saq.Select(serviceaccount.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (saq *ServiceAccountQuery) IDsX(ctx context.Context) []gidx.PrefixedID {
+	ids, err := saq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (saq *ServiceAccountQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, saq.ctx, "Count")
+	if err := saq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, saq, querierCount[*ServiceAccountQuery](), saq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (saq *ServiceAccountQuery) CountX(ctx context.Context) int {
+	count, err := saq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (saq *ServiceAccountQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, saq.ctx, "Exist")
+	switch _, err := saq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (saq *ServiceAccountQuery) ExistX(ctx context.Context) bool {
+	exist, err := saq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ServiceAccountQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (saq *ServiceAccountQuery) Clone() *ServiceAccountQuery {
+	if saq == nil {
+		return nil
+	}
+	return &ServiceAccountQuery{
+		config:     saq.config,
+		ctx:        saq.ctx.Clone(),
+		order:      append([]serviceaccount.OrderOption{}, saq.order...),
+		inters:     append([]Interceptor{}, saq.inters...),
+		predicates: append([]predicate.ServiceAccount{}, saq.predicates...),
+		withTenant: saq.withTenant.Clone(),
+		// clone intermediate query.
+		sql:  saq.sql.Clone(),
+		path: saq.path,
+	}
+}
+
+// WithTenant tells the query-builder to eager-load the nodes that are connected to
+// the "tenant" edge. The optional arguments are used to configure the query builder of the edge.
+func (saq *ServiceAccountQuery) WithTenant(opts ...func(*TenantQuery)) *ServiceAccountQuery {
+	query := (&TenantClient{config: saq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	saq.withTenant = query
+	return saq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ServiceAccount.Query().
+//		GroupBy(serviceaccount.FieldCreatedAt).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (saq *ServiceAccountQuery) GroupBy(field string, fields ...string) *ServiceAccountGroupBy {
+	saq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ServiceAccountGroupBy{build: saq}
+	grbuild.flds = &saq.ctx.Fields
+	grbuild.label = serviceaccount.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//	}
+//
+//	client.ServiceAccount.Query().
+//		Select(serviceaccount.FieldCreatedAt).
+//		Scan(ctx, &v)
+func (saq *ServiceAccountQuery) Select(fields ...string) *ServiceAccountSelect {
+	saq.ctx.Fields = append(saq.ctx.Fields, fields...)
+	sbuild := &ServiceAccountSelect{ServiceAccountQuery: saq}
+	sbuild.label = serviceaccount.Label
+	sbuild.flds, sbuild.scan = &saq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ServiceAccountSelect configured with the given aggregations.
+func (saq *ServiceAccountQuery) Aggregate(fns ...AggregateFunc) *ServiceAccountSelect {
+	return saq.Select().Aggregate(fns...)
+}
+
+func (saq *ServiceAccountQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range saq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, saq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range saq.ctx.Fields {
+		if !serviceaccount.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if saq.path != nil {
+		prev, err := saq.path(ctx)
+		if err != nil {
+			return err
+		}
+		saq.sql = prev
+	}
+	return nil
+}
+
+func (saq *ServiceAccountQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ServiceAccount, error) {
+	var (
+		nodes       = []*ServiceAccount{}
+		_spec       = saq.querySpec()
+		loadedTypes = [1]bool{
+			saq.withTenant != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ServiceAccount).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ServiceAccount{config: saq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	if len(saq.modifiers) > 0 {
+		_spec.Modifiers = saq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, saq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := saq.withTenant; query != nil {
+		if err := saq.loadTenant(ctx, query, nodes, nil,
+			func(n *ServiceAccount, e *Tenant) { n.Edges.Tenant = e }); err != nil {
+			return nil, err
+		}
+	}
+	for i := range saq.loadTotal {
+		if err := saq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (saq *ServiceAccountQuery) loadTenant(ctx context.Context, query *TenantQuery, nodes []*ServiceAccount, init func(*ServiceAccount), assign func(*ServiceAccount, *Tenant)) error {
+	ids := make([]gidx.PrefixedID, 0, len(nodes))
+	nodeids := make(map[gidx.PrefixedID][]*ServiceAccount)
+	for i := range nodes {
+		fk := nodes[i].TenantID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(tenant.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "tenant_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (saq *ServiceAccountQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := saq.querySpec()
+	if len(saq.modifiers) > 0 {
+		_spec.Modifiers = saq.modifiers
+	}
+	_spec.Node.Columns = saq.ctx.Fields
+	if len(saq.ctx.Fields) > 0 {
+		_spec.Unique = saq.ctx.Unique != nil && *saq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, saq.driver, _spec)
+}
+
+func (saq *ServiceAccountQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(serviceaccount.Table, serviceaccount.Columns, sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString))
+	_spec.From = saq.sql
+	if unique := saq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if saq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := saq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, serviceaccount.FieldID)
+		for i := range fields {
+			if fields[i] != serviceaccount.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+		if saq.withTenant != nil {
+			_spec.Node.AddColumnOnce(serviceaccount.FieldTenantID)
+		}
+	}
+	if ps := saq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := saq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := saq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := saq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (saq *ServiceAccountQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(saq.driver.Dialect())
+	t1 := builder.Table(serviceaccount.Table)
+	columns := saq.ctx.Fields
+	if len(columns) == 0 {
+		columns = serviceaccount.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if saq.sql != nil {
+		selector = saq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if saq.ctx.Unique != nil && *saq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range saq.predicates {
+		p(selector)
+	}
+	for _, p := range saq.order {
+		p(selector)
+	}
+	if offset := saq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := saq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ServiceAccountGroupBy is the group-by builder for ServiceAccount entities.
+type ServiceAccountGroupBy struct {
+	selector
+	build *ServiceAccountQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (sagb *ServiceAccountGroupBy) Aggregate(fns ...AggregateFunc) *ServiceAccountGroupBy {
+	sagb.fns = append(sagb.fns, fns...)
+	return sagb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (sagb *ServiceAccountGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, sagb.build.ctx, "GroupBy")
+	if err := sagb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ServiceAccountQuery, *ServiceAccountGroupBy](ctx, sagb.build, sagb, sagb.build.inters, v)
+}
+
+func (sagb *ServiceAccountGroupBy) sqlScan(ctx context.Context, root *ServiceAccountQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(sagb.fns))
+	for _, fn := range sagb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*sagb.flds)+len(sagb.fns))
+		for _, f := range *sagb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*sagb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := sagb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ServiceAccountSelect is the builder for selecting fields of ServiceAccount entities.
+type ServiceAccountSelect struct {
+	*ServiceAccountQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (sas *ServiceAccountSelect) Aggregate(fns ...AggregateFunc) *ServiceAccountSelect {
+	sas.fns = append(sas.fns, fns...)
+	return sas
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (sas *ServiceAccountSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, sas.ctx, "Select")
+	if err := sas.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ServiceAccountQuery, *ServiceAccountSelect](ctx, sas.ServiceAccountQuery, sas, sas.inters, v)
+}
+
+func (sas *ServiceAccountSelect) sqlScan(ctx context.Context, root *ServiceAccountQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(sas.fns))
+	for _, fn := range sas.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*sas.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := sas.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -17,6 +17,7 @@
 package generated
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -39,10 +40,44 @@ type Tenant struct {
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// The name of a tenant.
 	Name string `json:"name,omitempty"`
+	// The display name of a tenant, defaults to the name and may be changed freely.
+	DisplayName string `json:"display_name,omitempty"`
 	// An optional description of the tenant.
 	Description string `json:"description,omitempty"`
+	// An optional email address to contact the owners of the tenant at.
+	ContactEmail string `json:"contact_email,omitempty"`
+	// An optional reference to the tenant in the billing system.
+	BillingReference string `json:"billing_reference,omitempty"`
 	// The ID of the parent tenant for the tenant.
 	ParentTenantID gidx.PrefixedID `json:"parent_tenant_id,omitempty"`
+	// The time the tenant will be deleted at, zero unless a deletion is pending.
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at,omitempty"`
+	// Overrides the configured maximum number of direct children when positive, set by admins only.
+	MaxChildren int `json:"max_children,omitempty"`
+	// The ID of the resource provider owning the tenant, root tenants are suspended when it is deleted.
+	OwnerID gidx.PrefixedID `json:"owner_id,omitempty"`
+	// The time the tenant was suspended at, zero unless it is suspended.
+	SuspendedAt time.Time `json:"suspended_at,omitempty"`
+	// An optional reference to the tenant in the provisioning system, unique among its siblings.
+	ExternalID string `json:"external_id,omitempty"`
+	// Whether the tenant is archived, hidden from listings and closed to new children.
+	Archived bool `json:"archived,omitempty"`
+	// Whether the tenant is frozen by an admin, closed to every change but unfreezing until then.
+	Frozen bool `json:"frozen,omitempty"`
+	// The time creating tenants in the subtree of the tenant is frozen until, set by an admin, zero when it isn't.
+	CreationFrozenUntil time.Time `json:"creation_frozen_until,omitempty"`
+	// Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection.
+	DeletionProtected bool `json:"deletion_protected,omitempty"`
+	// The sequence of the last change of the tenant, increasing with every change.
+	ChangeSeq int64 `json:"change_seq,omitempty"`
+	// The version of the tenant, one when created and incremented by every update.
+	Version *int64 `json:"version,omitempty"`
+	// Whether the name is unique among the live siblings of the tenant, enforced by a unique index.
+	UniqueName bool `json:"unique_name,omitempty"`
+	// Small per tenant configuration document, managed through the settings endpoints.
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	// Key value labels of the tenant, inherited by its descendants unless they set the key themselves.
+	Labels map[string]string `json:"labels,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the TenantQuery when eager-loading is set.
 	Edges        TenantEdges `json:"edges"`
@@ -55,13 +90,16 @@ type TenantEdges struct {
 	Parent *Tenant `json:"parent,omitempty"`
 	// Children holds the value of the children edge.
 	Children []*Tenant `json:"children,omitempty"`
+	// ServiceAccounts holds the value of the service_accounts edge.
+	ServiceAccounts []*ServiceAccount `json:"service_accounts,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [3]bool
 	// totalCount holds the count of the edges above.
 	totalCount [2]map[string]int
 
-	namedChildren map[string][]*Tenant
+	namedChildren        map[string][]*Tenant
+	namedServiceAccounts map[string][]*ServiceAccount
 }
 
 // ParentOrErr returns the Parent value or an error if the edge
@@ -86,16 +124,31 @@ func (e TenantEdges) ChildrenOrErr() ([]*Tenant, error) {
 	return nil, &NotLoadedError{edge: "children"}
 }
 
+// ServiceAccountsOrErr returns the ServiceAccounts value or an error if the edge
+// was not loaded in eager-loading.
+func (e TenantEdges) ServiceAccountsOrErr() ([]*ServiceAccount, error) {
+	if e.loadedTypes[2] {
+		return e.ServiceAccounts, nil
+	}
+	return nil, &NotLoadedError{edge: "service_accounts"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Tenant) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case tenant.FieldID, tenant.FieldParentTenantID:
+		case tenant.FieldSettings, tenant.FieldLabels:
+			values[i] = new([]byte)
+		case tenant.FieldID, tenant.FieldParentTenantID, tenant.FieldOwnerID:
 			values[i] = new(gidx.PrefixedID)
-		case tenant.FieldName, tenant.FieldDescription:
+		case tenant.FieldArchived, tenant.FieldFrozen, tenant.FieldDeletionProtected, tenant.FieldUniqueName:
+			values[i] = new(sql.NullBool)
+		case tenant.FieldMaxChildren, tenant.FieldChangeSeq, tenant.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case tenant.FieldName, tenant.FieldDisplayName, tenant.FieldDescription, tenant.FieldContactEmail, tenant.FieldBillingReference, tenant.FieldExternalID:
 			values[i] = new(sql.NullString)
-		case tenant.FieldCreatedAt, tenant.FieldUpdatedAt:
+		case tenant.FieldCreatedAt, tenant.FieldUpdatedAt, tenant.FieldDeletionScheduledAt, tenant.FieldSuspendedAt, tenant.FieldCreationFrozenUntil:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -136,18 +189,125 @@ func (t *Tenant) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				t.Name = value.String
 			}
+		case tenant.FieldDisplayName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field display_name", values[i])
+			} else if value.Valid {
+				t.DisplayName = value.String
+			}
 		case tenant.FieldDescription:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field description", values[i])
 			} else if value.Valid {
 				t.Description = value.String
 			}
+		case tenant.FieldContactEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field contact_email", values[i])
+			} else if value.Valid {
+				t.ContactEmail = value.String
+			}
+		case tenant.FieldBillingReference:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field billing_reference", values[i])
+			} else if value.Valid {
+				t.BillingReference = value.String
+			}
 		case tenant.FieldParentTenantID:
 			if value, ok := values[i].(*gidx.PrefixedID); !ok {
 				return fmt.Errorf("unexpected type %T for field parent_tenant_id", values[i])
 			} else if value != nil {
 				t.ParentTenantID = *value
 			}
+		case tenant.FieldDeletionScheduledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deletion_scheduled_at", values[i])
+			} else if value.Valid {
+				t.DeletionScheduledAt = value.Time
+			}
+		case tenant.FieldMaxChildren:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_children", values[i])
+			} else if value.Valid {
+				t.MaxChildren = int(value.Int64)
+			}
+		case tenant.FieldOwnerID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field owner_id", values[i])
+			} else if value != nil {
+				t.OwnerID = *value
+			}
+		case tenant.FieldSuspendedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field suspended_at", values[i])
+			} else if value.Valid {
+				t.SuspendedAt = value.Time
+			}
+		case tenant.FieldExternalID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field external_id", values[i])
+			} else if value.Valid {
+				t.ExternalID = value.String
+			}
+		case tenant.FieldArchived:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field archived", values[i])
+			} else if value.Valid {
+				t.Archived = value.Bool
+			}
+		case tenant.FieldFrozen:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field frozen", values[i])
+			} else if value.Valid {
+				t.Frozen = value.Bool
+			}
+		case tenant.FieldCreationFrozenUntil:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field creation_frozen_until", values[i])
+			} else if value.Valid {
+				t.CreationFrozenUntil = value.Time
+			}
+		case tenant.FieldDeletionProtected:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field deletion_protected", values[i])
+			} else if value.Valid {
+				t.DeletionProtected = value.Bool
+			}
+		case tenant.FieldChangeSeq:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field change_seq", values[i])
+			} else if value.Valid {
+				t.ChangeSeq = value.Int64
+			}
+		case tenant.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				t.Version = new(int64)
+				*t.Version = value.Int64
+			}
+		case tenant.FieldUniqueName:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field unique_name", values[i])
+			} else if value.Valid {
+				t.UniqueName = value.Bool
+			}
+		case tenant.FieldSettings:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field settings", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &t.Settings); err != nil {
+					return fmt.Errorf("unmarshal field settings: %w", err)
+				}
+			}
+		case tenant.FieldLabels:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field labels", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &t.Labels); err != nil {
+					return fmt.Errorf("unmarshal field labels: %w", err)
+				}
+			}
 		default:
 			t.selectValues.Set(columns[i], values[i])
 		}
@@ -171,6 +331,11 @@ func (t *Tenant) QueryChildren() *TenantQuery {
 	return NewTenantClient(t.config).QueryChildren(t)
 }
 
+// QueryServiceAccounts queries the "service_accounts" edge of the Tenant entity.
+func (t *Tenant) QueryServiceAccounts() *ServiceAccountQuery {
+	return NewTenantClient(t.config).QueryServiceAccounts(t)
+}
+
 // Update returns a builder for updating this Tenant.
 // Note that you need to call Tenant.Unwrap() before calling this method if this Tenant
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -203,11 +368,64 @@ func (t *Tenant) String() string {
 	builder.WriteString("name=")
 	builder.WriteString(t.Name)
 	builder.WriteString(", ")
+	builder.WriteString("display_name=")
+	builder.WriteString(t.DisplayName)
+	builder.WriteString(", ")
 	builder.WriteString("description=")
 	builder.WriteString(t.Description)
 	builder.WriteString(", ")
+	builder.WriteString("contact_email=")
+	builder.WriteString(t.ContactEmail)
+	builder.WriteString(", ")
+	builder.WriteString("billing_reference=")
+	builder.WriteString(t.BillingReference)
+	builder.WriteString(", ")
 	builder.WriteString("parent_tenant_id=")
 	builder.WriteString(fmt.Sprintf("%v", t.ParentTenantID))
+	builder.WriteString(", ")
+	builder.WriteString("deletion_scheduled_at=")
+	builder.WriteString(t.DeletionScheduledAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("max_children=")
+	builder.WriteString(fmt.Sprintf("%v", t.MaxChildren))
+	builder.WriteString(", ")
+	builder.WriteString("owner_id=")
+	builder.WriteString(fmt.Sprintf("%v", t.OwnerID))
+	builder.WriteString(", ")
+	builder.WriteString("suspended_at=")
+	builder.WriteString(t.SuspendedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("external_id=")
+	builder.WriteString(t.ExternalID)
+	builder.WriteString(", ")
+	builder.WriteString("archived=")
+	builder.WriteString(fmt.Sprintf("%v", t.Archived))
+	builder.WriteString(", ")
+	builder.WriteString("frozen=")
+	builder.WriteString(fmt.Sprintf("%v", t.Frozen))
+	builder.WriteString(", ")
+	builder.WriteString("creation_frozen_until=")
+	builder.WriteString(t.CreationFrozenUntil.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("deletion_protected=")
+	builder.WriteString(fmt.Sprintf("%v", t.DeletionProtected))
+	builder.WriteString(", ")
+	builder.WriteString("change_seq=")
+	builder.WriteString(fmt.Sprintf("%v", t.ChangeSeq))
+	builder.WriteString(", ")
+	if v := t.Version; v != nil {
+		builder.WriteString("version=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("unique_name=")
+	builder.WriteString(fmt.Sprintf("%v", t.UniqueName))
+	builder.WriteString(", ")
+	builder.WriteString("settings=")
+	builder.WriteString(fmt.Sprintf("%v", t.Settings))
+	builder.WriteString(", ")
+	builder.WriteString("labels=")
+	builder.WriteString(fmt.Sprintf("%v", t.Labels))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -245,5 +463,29 @@ func (t *Tenant) appendNamedChildren(name string, edges ...*Tenant) {
 	}
 }
 
+// NamedServiceAccounts returns the ServiceAccounts named value or an error if the edge was not
+// loaded in eager-loading with this name.
+func (t *Tenant) NamedServiceAccounts(name string) ([]*ServiceAccount, error) {
+	if t.Edges.namedServiceAccounts == nil {
+		return nil, &NotLoadedError{edge: name}
+	}
+	nodes, ok := t.Edges.namedServiceAccounts[name]
+	if !ok {
+		return nil, &NotLoadedError{edge: name}
+	}
+	return nodes, nil
+}
+
+func (t *Tenant) appendNamedServiceAccounts(name string, edges ...*ServiceAccount) {
+	if t.Edges.namedServiceAccounts == nil {
+		t.Edges.namedServiceAccounts = make(map[string][]*ServiceAccount)
+	}
+	if len(edges) == 0 {
+		t.Edges.namedServiceAccounts[name] = []*ServiceAccount{}
+	} else {
+		t.Edges.namedServiceAccounts[name] = append(t.Edges.namedServiceAccounts[name], edges...)
+	}
+}
+
 // Tenants is a parsable slice of Tenant.
 type Tenants []*Tenant
@@ -35,14 +35,50 @@ const (
 	FieldUpdatedAt = "updated_at"
 	// FieldName holds the string denoting the name field in the database.
 	FieldName = "name"
+	// FieldDisplayName holds the string denoting the display_name field in the database.
+	FieldDisplayName = "display_name"
 	// FieldDescription holds the string denoting the description field in the database.
 	FieldDescription = "description"
+	// FieldContactEmail holds the string denoting the contact_email field in the database.
+	FieldContactEmail = "contact_email"
+	// FieldBillingReference holds the string denoting the billing_reference field in the database.
+	FieldBillingReference = "billing_reference"
 	// FieldParentTenantID holds the string denoting the parent_tenant_id field in the database.
 	FieldParentTenantID = "parent_tenant_id"
+	// FieldDeletionScheduledAt holds the string denoting the deletion_scheduled_at field in the database.
+	FieldDeletionScheduledAt = "deletion_scheduled_at"
+	// FieldMaxChildren holds the string denoting the max_children field in the database.
+	FieldMaxChildren = "max_children"
+	// FieldOwnerID holds the string denoting the owner_id field in the database.
+	FieldOwnerID = "owner_id"
+	// FieldSuspendedAt holds the string denoting the suspended_at field in the database.
+	FieldSuspendedAt = "suspended_at"
+	// FieldExternalID holds the string denoting the external_id field in the database.
+	FieldExternalID = "external_id"
+	// FieldArchived holds the string denoting the archived field in the database.
+	FieldArchived = "archived"
+	// FieldFrozen holds the string denoting the frozen field in the database.
+	FieldFrozen = "frozen"
+	// FieldCreationFrozenUntil holds the string denoting the creation_frozen_until field in the database.
+	FieldCreationFrozenUntil = "creation_frozen_until"
+	// FieldDeletionProtected holds the string denoting the deletion_protected field in the database.
+	FieldDeletionProtected = "deletion_protected"
+	// FieldChangeSeq holds the string denoting the change_seq field in the database.
+	FieldChangeSeq = "change_seq"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldUniqueName holds the string denoting the unique_name field in the database.
+	FieldUniqueName = "unique_name"
+	// FieldSettings holds the string denoting the settings field in the database.
+	FieldSettings = "settings"
+	// FieldLabels holds the string denoting the labels field in the database.
+	FieldLabels = "labels"
 	// EdgeParent holds the string denoting the parent edge name in mutations.
 	EdgeParent = "parent"
 	// EdgeChildren holds the string denoting the children edge name in mutations.
 	EdgeChildren = "children"
+	// EdgeServiceAccounts holds the string denoting the service_accounts edge name in mutations.
+	EdgeServiceAccounts = "service_accounts"
 	// Table holds the table name of the tenant in the database.
 	Table = "tenants"
 	// ParentTable is the table that holds the parent relation/edge.
@@ -53,6 +89,13 @@ const (
 	ChildrenTable = "tenants"
 	// ChildrenColumn is the table column denoting the children relation/edge.
 	ChildrenColumn = "parent_tenant_id"
+	// ServiceAccountsTable is the table that holds the service_accounts relation/edge.
+	ServiceAccountsTable = "service_accounts"
+	// ServiceAccountsInverseTable is the table name for the ServiceAccount entity.
+	// It exists in this package in order to avoid circular dependency with the "serviceaccount" package.
+	ServiceAccountsInverseTable = "service_accounts"
+	// ServiceAccountsColumn is the table column denoting the service_accounts relation/edge.
+	ServiceAccountsColumn = "tenant_id"
 )
 
 // Columns holds all SQL columns for tenant fields.
@@ -61,8 +104,25 @@ var Columns = []string{
 	FieldCreatedAt,
 	FieldUpdatedAt,
 	FieldName,
+	FieldDisplayName,
 	FieldDescription,
+	FieldContactEmail,
+	FieldBillingReference,
 	FieldParentTenantID,
+	FieldDeletionScheduledAt,
+	FieldMaxChildren,
+	FieldOwnerID,
+	FieldSuspendedAt,
+	FieldExternalID,
+	FieldArchived,
+	FieldFrozen,
+	FieldCreationFrozenUntil,
+	FieldDeletionProtected,
+	FieldChangeSeq,
+	FieldVersion,
+	FieldUniqueName,
+	FieldSettings,
+	FieldLabels,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -82,6 +142,14 @@ var (
 	DefaultUpdatedAt func() time.Time
 	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
 	UpdateDefaultUpdatedAt func() time.Time
+	// MaxChildrenValidator is a validator for the "max_children" field. It is called by the builders before save.
+	MaxChildrenValidator func(int) error
+	// ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	ExternalIDValidator func(string) error
+	// ChangeSeqValidator is a validator for the "change_seq" field. It is called by the builders before save.
+	ChangeSeqValidator func(int64) error
+	// VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	VersionValidator func(int64) error
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() gidx.PrefixedID
 )
@@ -109,16 +177,91 @@ func ByName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldName, opts...).ToFunc()
 }
 
+// ByDisplayName orders the results by the display_name field.
+func ByDisplayName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDisplayName, opts...).ToFunc()
+}
+
 // ByDescription orders the results by the description field.
 func ByDescription(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldDescription, opts...).ToFunc()
 }
 
+// ByContactEmail orders the results by the contact_email field.
+func ByContactEmail(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldContactEmail, opts...).ToFunc()
+}
+
+// ByBillingReference orders the results by the billing_reference field.
+func ByBillingReference(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBillingReference, opts...).ToFunc()
+}
+
 // ByParentTenantID orders the results by the parent_tenant_id field.
 func ByParentTenantID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldParentTenantID, opts...).ToFunc()
 }
 
+// ByDeletionScheduledAt orders the results by the deletion_scheduled_at field.
+func ByDeletionScheduledAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletionScheduledAt, opts...).ToFunc()
+}
+
+// ByMaxChildren orders the results by the max_children field.
+func ByMaxChildren(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxChildren, opts...).ToFunc()
+}
+
+// ByOwnerID orders the results by the owner_id field.
+func ByOwnerID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOwnerID, opts...).ToFunc()
+}
+
+// BySuspendedAt orders the results by the suspended_at field.
+func BySuspendedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSuspendedAt, opts...).ToFunc()
+}
+
+// ByExternalID orders the results by the external_id field.
+func ByExternalID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExternalID, opts...).ToFunc()
+}
+
+// ByArchived orders the results by the archived field.
+func ByArchived(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldArchived, opts...).ToFunc()
+}
+
+// ByFrozen orders the results by the frozen field.
+func ByFrozen(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFrozen, opts...).ToFunc()
+}
+
+// ByCreationFrozenUntil orders the results by the creation_frozen_until field.
+func ByCreationFrozenUntil(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreationFrozenUntil, opts...).ToFunc()
+}
+
+// ByDeletionProtected orders the results by the deletion_protected field.
+func ByDeletionProtected(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletionProtected, opts...).ToFunc()
+}
+
+// ByChangeSeq orders the results by the change_seq field.
+func ByChangeSeq(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldChangeSeq, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByUniqueName orders the results by the unique_name field.
+func ByUniqueName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUniqueName, opts...).ToFunc()
+}
+
 // ByParentField orders the results by parent field.
 func ByParentField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -139,6 +282,20 @@ func ByChildren(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newChildrenStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByServiceAccountsCount orders the results by service_accounts count.
+func ByServiceAccountsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newServiceAccountsStep(), opts...)
+	}
+}
+
+// ByServiceAccounts orders the results by service_accounts terms.
+func ByServiceAccounts(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newServiceAccountsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newParentStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -153,3 +310,10 @@ func newChildrenStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, ChildrenTable, ChildrenColumn),
 	)
 }
+func newServiceAccountsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ServiceAccountsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ServiceAccountsTable, ServiceAccountsColumn),
+	)
+}
@@ -85,16 +85,91 @@ func Name(v string) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldName, v))
 }
 
+// DisplayName applies equality check predicate on the "display_name" field. It's identical to DisplayNameEQ.
+func DisplayName(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDisplayName, v))
+}
+
 // Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
 func Description(v string) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldDescription, v))
 }
 
+// ContactEmail applies equality check predicate on the "contact_email" field. It's identical to ContactEmailEQ.
+func ContactEmail(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldContactEmail, v))
+}
+
+// BillingReference applies equality check predicate on the "billing_reference" field. It's identical to BillingReferenceEQ.
+func BillingReference(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldBillingReference, v))
+}
+
 // ParentTenantID applies equality check predicate on the "parent_tenant_id" field. It's identical to ParentTenantIDEQ.
 func ParentTenantID(v gidx.PrefixedID) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldParentTenantID, v))
 }
 
+// DeletionScheduledAt applies equality check predicate on the "deletion_scheduled_at" field. It's identical to DeletionScheduledAtEQ.
+func DeletionScheduledAt(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDeletionScheduledAt, v))
+}
+
+// MaxChildren applies equality check predicate on the "max_children" field. It's identical to MaxChildrenEQ.
+func MaxChildren(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldMaxChildren, v))
+}
+
+// OwnerID applies equality check predicate on the "owner_id" field. It's identical to OwnerIDEQ.
+func OwnerID(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// SuspendedAt applies equality check predicate on the "suspended_at" field. It's identical to SuspendedAtEQ.
+func SuspendedAt(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldSuspendedAt, v))
+}
+
+// ExternalID applies equality check predicate on the "external_id" field. It's identical to ExternalIDEQ.
+func ExternalID(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldExternalID, v))
+}
+
+// Archived applies equality check predicate on the "archived" field. It's identical to ArchivedEQ.
+func Archived(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldArchived, v))
+}
+
+// Frozen applies equality check predicate on the "frozen" field. It's identical to FrozenEQ.
+func Frozen(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldFrozen, v))
+}
+
+// CreationFrozenUntil applies equality check predicate on the "creation_frozen_until" field. It's identical to CreationFrozenUntilEQ.
+func CreationFrozenUntil(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldCreationFrozenUntil, v))
+}
+
+// DeletionProtected applies equality check predicate on the "deletion_protected" field. It's identical to DeletionProtectedEQ.
+func DeletionProtected(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDeletionProtected, v))
+}
+
+// ChangeSeq applies equality check predicate on the "change_seq" field. It's identical to ChangeSeqEQ.
+func ChangeSeq(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldChangeSeq, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldVersion, v))
+}
+
+// UniqueName applies equality check predicate on the "unique_name" field. It's identical to UniqueNameEQ.
+func UniqueName(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldUniqueName, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldCreatedAt, v))
@@ -240,6 +315,81 @@ func NameContainsFold(v string) predicate.Tenant {
 	return predicate.Tenant(sql.FieldContainsFold(FieldName, v))
 }
 
+// DisplayNameEQ applies the EQ predicate on the "display_name" field.
+func DisplayNameEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDisplayName, v))
+}
+
+// DisplayNameNEQ applies the NEQ predicate on the "display_name" field.
+func DisplayNameNEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldDisplayName, v))
+}
+
+// DisplayNameIn applies the In predicate on the "display_name" field.
+func DisplayNameIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldDisplayName, vs...))
+}
+
+// DisplayNameNotIn applies the NotIn predicate on the "display_name" field.
+func DisplayNameNotIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldDisplayName, vs...))
+}
+
+// DisplayNameGT applies the GT predicate on the "display_name" field.
+func DisplayNameGT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldDisplayName, v))
+}
+
+// DisplayNameGTE applies the GTE predicate on the "display_name" field.
+func DisplayNameGTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldDisplayName, v))
+}
+
+// DisplayNameLT applies the LT predicate on the "display_name" field.
+func DisplayNameLT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldDisplayName, v))
+}
+
+// DisplayNameLTE applies the LTE predicate on the "display_name" field.
+func DisplayNameLTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldDisplayName, v))
+}
+
+// DisplayNameContains applies the Contains predicate on the "display_name" field.
+func DisplayNameContains(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContains(FieldDisplayName, v))
+}
+
+// DisplayNameHasPrefix applies the HasPrefix predicate on the "display_name" field.
+func DisplayNameHasPrefix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasPrefix(FieldDisplayName, v))
+}
+
+// DisplayNameHasSuffix applies the HasSuffix predicate on the "display_name" field.
+func DisplayNameHasSuffix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasSuffix(FieldDisplayName, v))
+}
+
+// DisplayNameIsNil applies the IsNil predicate on the "display_name" field.
+func DisplayNameIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldDisplayName))
+}
+
+// DisplayNameNotNil applies the NotNil predicate on the "display_name" field.
+func DisplayNameNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldDisplayName))
+}
+
+// DisplayNameEqualFold applies the EqualFold predicate on the "display_name" field.
+func DisplayNameEqualFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEqualFold(FieldDisplayName, v))
+}
+
+// DisplayNameContainsFold applies the ContainsFold predicate on the "display_name" field.
+func DisplayNameContainsFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContainsFold(FieldDisplayName, v))
+}
+
 // DescriptionEQ applies the EQ predicate on the "description" field.
 func DescriptionEQ(v string) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldDescription, v))
@@ -315,6 +465,156 @@ func DescriptionContainsFold(v string) predicate.Tenant {
 	return predicate.Tenant(sql.FieldContainsFold(FieldDescription, v))
 }
 
+// ContactEmailEQ applies the EQ predicate on the "contact_email" field.
+func ContactEmailEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldContactEmail, v))
+}
+
+// ContactEmailNEQ applies the NEQ predicate on the "contact_email" field.
+func ContactEmailNEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldContactEmail, v))
+}
+
+// ContactEmailIn applies the In predicate on the "contact_email" field.
+func ContactEmailIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldContactEmail, vs...))
+}
+
+// ContactEmailNotIn applies the NotIn predicate on the "contact_email" field.
+func ContactEmailNotIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldContactEmail, vs...))
+}
+
+// ContactEmailGT applies the GT predicate on the "contact_email" field.
+func ContactEmailGT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldContactEmail, v))
+}
+
+// ContactEmailGTE applies the GTE predicate on the "contact_email" field.
+func ContactEmailGTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldContactEmail, v))
+}
+
+// ContactEmailLT applies the LT predicate on the "contact_email" field.
+func ContactEmailLT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldContactEmail, v))
+}
+
+// ContactEmailLTE applies the LTE predicate on the "contact_email" field.
+func ContactEmailLTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldContactEmail, v))
+}
+
+// ContactEmailContains applies the Contains predicate on the "contact_email" field.
+func ContactEmailContains(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContains(FieldContactEmail, v))
+}
+
+// ContactEmailHasPrefix applies the HasPrefix predicate on the "contact_email" field.
+func ContactEmailHasPrefix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasPrefix(FieldContactEmail, v))
+}
+
+// ContactEmailHasSuffix applies the HasSuffix predicate on the "contact_email" field.
+func ContactEmailHasSuffix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasSuffix(FieldContactEmail, v))
+}
+
+// ContactEmailIsNil applies the IsNil predicate on the "contact_email" field.
+func ContactEmailIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldContactEmail))
+}
+
+// ContactEmailNotNil applies the NotNil predicate on the "contact_email" field.
+func ContactEmailNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldContactEmail))
+}
+
+// ContactEmailEqualFold applies the EqualFold predicate on the "contact_email" field.
+func ContactEmailEqualFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEqualFold(FieldContactEmail, v))
+}
+
+// ContactEmailContainsFold applies the ContainsFold predicate on the "contact_email" field.
+func ContactEmailContainsFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContainsFold(FieldContactEmail, v))
+}
+
+// BillingReferenceEQ applies the EQ predicate on the "billing_reference" field.
+func BillingReferenceEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldBillingReference, v))
+}
+
+// BillingReferenceNEQ applies the NEQ predicate on the "billing_reference" field.
+func BillingReferenceNEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldBillingReference, v))
+}
+
+// BillingReferenceIn applies the In predicate on the "billing_reference" field.
+func BillingReferenceIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldBillingReference, vs...))
+}
+
+// BillingReferenceNotIn applies the NotIn predicate on the "billing_reference" field.
+func BillingReferenceNotIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldBillingReference, vs...))
+}
+
+// BillingReferenceGT applies the GT predicate on the "billing_reference" field.
+func BillingReferenceGT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldBillingReference, v))
+}
+
+// BillingReferenceGTE applies the GTE predicate on the "billing_reference" field.
+func BillingReferenceGTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldBillingReference, v))
+}
+
+// BillingReferenceLT applies the LT predicate on the "billing_reference" field.
+func BillingReferenceLT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldBillingReference, v))
+}
+
+// BillingReferenceLTE applies the LTE predicate on the "billing_reference" field.
+func BillingReferenceLTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldBillingReference, v))
+}
+
+// BillingReferenceContains applies the Contains predicate on the "billing_reference" field.
+func BillingReferenceContains(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContains(FieldBillingReference, v))
+}
+
+// BillingReferenceHasPrefix applies the HasPrefix predicate on the "billing_reference" field.
+func BillingReferenceHasPrefix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasPrefix(FieldBillingReference, v))
+}
+
+// BillingReferenceHasSuffix applies the HasSuffix predicate on the "billing_reference" field.
+func BillingReferenceHasSuffix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasSuffix(FieldBillingReference, v))
+}
+
+// BillingReferenceIsNil applies the IsNil predicate on the "billing_reference" field.
+func BillingReferenceIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldBillingReference))
+}
+
+// BillingReferenceNotNil applies the NotNil predicate on the "billing_reference" field.
+func BillingReferenceNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldBillingReference))
+}
+
+// BillingReferenceEqualFold applies the EqualFold predicate on the "billing_reference" field.
+func BillingReferenceEqualFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEqualFold(FieldBillingReference, v))
+}
+
+// BillingReferenceContainsFold applies the ContainsFold predicate on the "billing_reference" field.
+func BillingReferenceContainsFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContainsFold(FieldBillingReference, v))
+}
+
 // ParentTenantIDEQ applies the EQ predicate on the "parent_tenant_id" field.
 func ParentTenantIDEQ(v gidx.PrefixedID) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldParentTenantID, v))
@@ -395,6 +695,561 @@ func ParentTenantIDContainsFold(v gidx.PrefixedID) predicate.Tenant {
 	return predicate.Tenant(sql.FieldContainsFold(FieldParentTenantID, vc))
 }
 
+// DeletionScheduledAtEQ applies the EQ predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtNEQ applies the NEQ predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtNEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtIn applies the In predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldDeletionScheduledAt, vs...))
+}
+
+// DeletionScheduledAtNotIn applies the NotIn predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtNotIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldDeletionScheduledAt, vs...))
+}
+
+// DeletionScheduledAtGT applies the GT predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtGT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtGTE applies the GTE predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtGTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtLT applies the LT predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtLT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtLTE applies the LTE predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtLTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldDeletionScheduledAt, v))
+}
+
+// DeletionScheduledAtIsNil applies the IsNil predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldDeletionScheduledAt))
+}
+
+// DeletionScheduledAtNotNil applies the NotNil predicate on the "deletion_scheduled_at" field.
+func DeletionScheduledAtNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldDeletionScheduledAt))
+}
+
+// MaxChildrenEQ applies the EQ predicate on the "max_children" field.
+func MaxChildrenEQ(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldMaxChildren, v))
+}
+
+// MaxChildrenNEQ applies the NEQ predicate on the "max_children" field.
+func MaxChildrenNEQ(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldMaxChildren, v))
+}
+
+// MaxChildrenIn applies the In predicate on the "max_children" field.
+func MaxChildrenIn(vs ...int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldMaxChildren, vs...))
+}
+
+// MaxChildrenNotIn applies the NotIn predicate on the "max_children" field.
+func MaxChildrenNotIn(vs ...int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldMaxChildren, vs...))
+}
+
+// MaxChildrenGT applies the GT predicate on the "max_children" field.
+func MaxChildrenGT(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldMaxChildren, v))
+}
+
+// MaxChildrenGTE applies the GTE predicate on the "max_children" field.
+func MaxChildrenGTE(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldMaxChildren, v))
+}
+
+// MaxChildrenLT applies the LT predicate on the "max_children" field.
+func MaxChildrenLT(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldMaxChildren, v))
+}
+
+// MaxChildrenLTE applies the LTE predicate on the "max_children" field.
+func MaxChildrenLTE(v int) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldMaxChildren, v))
+}
+
+// MaxChildrenIsNil applies the IsNil predicate on the "max_children" field.
+func MaxChildrenIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldMaxChildren))
+}
+
+// MaxChildrenNotNil applies the NotNil predicate on the "max_children" field.
+func MaxChildrenNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldMaxChildren))
+}
+
+// OwnerIDEQ applies the EQ predicate on the "owner_id" field.
+func OwnerIDEQ(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// OwnerIDNEQ applies the NEQ predicate on the "owner_id" field.
+func OwnerIDNEQ(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldOwnerID, v))
+}
+
+// OwnerIDIn applies the In predicate on the "owner_id" field.
+func OwnerIDIn(vs ...gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDNotIn applies the NotIn predicate on the "owner_id" field.
+func OwnerIDNotIn(vs ...gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDGT applies the GT predicate on the "owner_id" field.
+func OwnerIDGT(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldOwnerID, v))
+}
+
+// OwnerIDGTE applies the GTE predicate on the "owner_id" field.
+func OwnerIDGTE(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldOwnerID, v))
+}
+
+// OwnerIDLT applies the LT predicate on the "owner_id" field.
+func OwnerIDLT(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldOwnerID, v))
+}
+
+// OwnerIDLTE applies the LTE predicate on the "owner_id" field.
+func OwnerIDLTE(v gidx.PrefixedID) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldOwnerID, v))
+}
+
+// OwnerIDContains applies the Contains predicate on the "owner_id" field.
+func OwnerIDContains(v gidx.PrefixedID) predicate.Tenant {
+	vc := string(v)
+	return predicate.Tenant(sql.FieldContains(FieldOwnerID, vc))
+}
+
+// OwnerIDHasPrefix applies the HasPrefix predicate on the "owner_id" field.
+func OwnerIDHasPrefix(v gidx.PrefixedID) predicate.Tenant {
+	vc := string(v)
+	return predicate.Tenant(sql.FieldHasPrefix(FieldOwnerID, vc))
+}
+
+// OwnerIDHasSuffix applies the HasSuffix predicate on the "owner_id" field.
+func OwnerIDHasSuffix(v gidx.PrefixedID) predicate.Tenant {
+	vc := string(v)
+	return predicate.Tenant(sql.FieldHasSuffix(FieldOwnerID, vc))
+}
+
+// OwnerIDIsNil applies the IsNil predicate on the "owner_id" field.
+func OwnerIDIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldOwnerID))
+}
+
+// OwnerIDNotNil applies the NotNil predicate on the "owner_id" field.
+func OwnerIDNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldOwnerID))
+}
+
+// OwnerIDEqualFold applies the EqualFold predicate on the "owner_id" field.
+func OwnerIDEqualFold(v gidx.PrefixedID) predicate.Tenant {
+	vc := string(v)
+	return predicate.Tenant(sql.FieldEqualFold(FieldOwnerID, vc))
+}
+
+// OwnerIDContainsFold applies the ContainsFold predicate on the "owner_id" field.
+func OwnerIDContainsFold(v gidx.PrefixedID) predicate.Tenant {
+	vc := string(v)
+	return predicate.Tenant(sql.FieldContainsFold(FieldOwnerID, vc))
+}
+
+// SuspendedAtEQ applies the EQ predicate on the "suspended_at" field.
+func SuspendedAtEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldSuspendedAt, v))
+}
+
+// SuspendedAtNEQ applies the NEQ predicate on the "suspended_at" field.
+func SuspendedAtNEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldSuspendedAt, v))
+}
+
+// SuspendedAtIn applies the In predicate on the "suspended_at" field.
+func SuspendedAtIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldSuspendedAt, vs...))
+}
+
+// SuspendedAtNotIn applies the NotIn predicate on the "suspended_at" field.
+func SuspendedAtNotIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldSuspendedAt, vs...))
+}
+
+// SuspendedAtGT applies the GT predicate on the "suspended_at" field.
+func SuspendedAtGT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldSuspendedAt, v))
+}
+
+// SuspendedAtGTE applies the GTE predicate on the "suspended_at" field.
+func SuspendedAtGTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldSuspendedAt, v))
+}
+
+// SuspendedAtLT applies the LT predicate on the "suspended_at" field.
+func SuspendedAtLT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldSuspendedAt, v))
+}
+
+// SuspendedAtLTE applies the LTE predicate on the "suspended_at" field.
+func SuspendedAtLTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldSuspendedAt, v))
+}
+
+// SuspendedAtIsNil applies the IsNil predicate on the "suspended_at" field.
+func SuspendedAtIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldSuspendedAt))
+}
+
+// SuspendedAtNotNil applies the NotNil predicate on the "suspended_at" field.
+func SuspendedAtNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldSuspendedAt))
+}
+
+// ExternalIDEQ applies the EQ predicate on the "external_id" field.
+func ExternalIDEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldExternalID, v))
+}
+
+// ExternalIDNEQ applies the NEQ predicate on the "external_id" field.
+func ExternalIDNEQ(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldExternalID, v))
+}
+
+// ExternalIDIn applies the In predicate on the "external_id" field.
+func ExternalIDIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldExternalID, vs...))
+}
+
+// ExternalIDNotIn applies the NotIn predicate on the "external_id" field.
+func ExternalIDNotIn(vs ...string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldExternalID, vs...))
+}
+
+// ExternalIDGT applies the GT predicate on the "external_id" field.
+func ExternalIDGT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldExternalID, v))
+}
+
+// ExternalIDGTE applies the GTE predicate on the "external_id" field.
+func ExternalIDGTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldExternalID, v))
+}
+
+// ExternalIDLT applies the LT predicate on the "external_id" field.
+func ExternalIDLT(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldExternalID, v))
+}
+
+// ExternalIDLTE applies the LTE predicate on the "external_id" field.
+func ExternalIDLTE(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldExternalID, v))
+}
+
+// ExternalIDContains applies the Contains predicate on the "external_id" field.
+func ExternalIDContains(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContains(FieldExternalID, v))
+}
+
+// ExternalIDHasPrefix applies the HasPrefix predicate on the "external_id" field.
+func ExternalIDHasPrefix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasPrefix(FieldExternalID, v))
+}
+
+// ExternalIDHasSuffix applies the HasSuffix predicate on the "external_id" field.
+func ExternalIDHasSuffix(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldHasSuffix(FieldExternalID, v))
+}
+
+// ExternalIDIsNil applies the IsNil predicate on the "external_id" field.
+func ExternalIDIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldExternalID))
+}
+
+// ExternalIDNotNil applies the NotNil predicate on the "external_id" field.
+func ExternalIDNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldExternalID))
+}
+
+// ExternalIDEqualFold applies the EqualFold predicate on the "external_id" field.
+func ExternalIDEqualFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEqualFold(FieldExternalID, v))
+}
+
+// ExternalIDContainsFold applies the ContainsFold predicate on the "external_id" field.
+func ExternalIDContainsFold(v string) predicate.Tenant {
+	return predicate.Tenant(sql.FieldContainsFold(FieldExternalID, v))
+}
+
+// ArchivedEQ applies the EQ predicate on the "archived" field.
+func ArchivedEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldArchived, v))
+}
+
+// ArchivedNEQ applies the NEQ predicate on the "archived" field.
+func ArchivedNEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldArchived, v))
+}
+
+// ArchivedIsNil applies the IsNil predicate on the "archived" field.
+func ArchivedIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldArchived))
+}
+
+// ArchivedNotNil applies the NotNil predicate on the "archived" field.
+func ArchivedNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldArchived))
+}
+
+// FrozenEQ applies the EQ predicate on the "frozen" field.
+func FrozenEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldFrozen, v))
+}
+
+// FrozenNEQ applies the NEQ predicate on the "frozen" field.
+func FrozenNEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldFrozen, v))
+}
+
+// FrozenIsNil applies the IsNil predicate on the "frozen" field.
+func FrozenIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldFrozen))
+}
+
+// FrozenNotNil applies the NotNil predicate on the "frozen" field.
+func FrozenNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldFrozen))
+}
+
+// CreationFrozenUntilEQ applies the EQ predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilNEQ applies the NEQ predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilNEQ(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilIn applies the In predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldCreationFrozenUntil, vs...))
+}
+
+// CreationFrozenUntilNotIn applies the NotIn predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilNotIn(vs ...time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldCreationFrozenUntil, vs...))
+}
+
+// CreationFrozenUntilGT applies the GT predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilGT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilGTE applies the GTE predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilGTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilLT applies the LT predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilLT(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilLTE applies the LTE predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilLTE(v time.Time) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldCreationFrozenUntil, v))
+}
+
+// CreationFrozenUntilIsNil applies the IsNil predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldCreationFrozenUntil))
+}
+
+// CreationFrozenUntilNotNil applies the NotNil predicate on the "creation_frozen_until" field.
+func CreationFrozenUntilNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldCreationFrozenUntil))
+}
+
+// DeletionProtectedEQ applies the EQ predicate on the "deletion_protected" field.
+func DeletionProtectedEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldDeletionProtected, v))
+}
+
+// DeletionProtectedNEQ applies the NEQ predicate on the "deletion_protected" field.
+func DeletionProtectedNEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldDeletionProtected, v))
+}
+
+// DeletionProtectedIsNil applies the IsNil predicate on the "deletion_protected" field.
+func DeletionProtectedIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldDeletionProtected))
+}
+
+// DeletionProtectedNotNil applies the NotNil predicate on the "deletion_protected" field.
+func DeletionProtectedNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldDeletionProtected))
+}
+
+// ChangeSeqEQ applies the EQ predicate on the "change_seq" field.
+func ChangeSeqEQ(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldChangeSeq, v))
+}
+
+// ChangeSeqNEQ applies the NEQ predicate on the "change_seq" field.
+func ChangeSeqNEQ(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldChangeSeq, v))
+}
+
+// ChangeSeqIn applies the In predicate on the "change_seq" field.
+func ChangeSeqIn(vs ...int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldChangeSeq, vs...))
+}
+
+// ChangeSeqNotIn applies the NotIn predicate on the "change_seq" field.
+func ChangeSeqNotIn(vs ...int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldChangeSeq, vs...))
+}
+
+// ChangeSeqGT applies the GT predicate on the "change_seq" field.
+func ChangeSeqGT(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldChangeSeq, v))
+}
+
+// ChangeSeqGTE applies the GTE predicate on the "change_seq" field.
+func ChangeSeqGTE(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldChangeSeq, v))
+}
+
+// ChangeSeqLT applies the LT predicate on the "change_seq" field.
+func ChangeSeqLT(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldChangeSeq, v))
+}
+
+// ChangeSeqLTE applies the LTE predicate on the "change_seq" field.
+func ChangeSeqLTE(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldChangeSeq, v))
+}
+
+// ChangeSeqIsNil applies the IsNil predicate on the "change_seq" field.
+func ChangeSeqIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldChangeSeq))
+}
+
+// ChangeSeqNotNil applies the NotNil predicate on the "change_seq" field.
+func ChangeSeqNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldChangeSeq))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int64) predicate.Tenant {
+	return predicate.Tenant(sql.FieldLTE(FieldVersion, v))
+}
+
+// VersionIsNil applies the IsNil predicate on the "version" field.
+func VersionIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldVersion))
+}
+
+// VersionNotNil applies the NotNil predicate on the "version" field.
+func VersionNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldVersion))
+}
+
+// UniqueNameEQ applies the EQ predicate on the "unique_name" field.
+func UniqueNameEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldUniqueName, v))
+}
+
+// UniqueNameNEQ applies the NEQ predicate on the "unique_name" field.
+func UniqueNameNEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldUniqueName, v))
+}
+
+// UniqueNameIsNil applies the IsNil predicate on the "unique_name" field.
+func UniqueNameIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldUniqueName))
+}
+
+// UniqueNameNotNil applies the NotNil predicate on the "unique_name" field.
+func UniqueNameNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldUniqueName))
+}
+
+// SettingsIsNil applies the IsNil predicate on the "settings" field.
+func SettingsIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldSettings))
+}
+
+// SettingsNotNil applies the NotNil predicate on the "settings" field.
+func SettingsNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldSettings))
+}
+
+// LabelsIsNil applies the IsNil predicate on the "labels" field.
+func LabelsIsNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldIsNull(FieldLabels))
+}
+
+// LabelsNotNil applies the NotNil predicate on the "labels" field.
+func LabelsNotNil() predicate.Tenant {
+	return predicate.Tenant(sql.FieldNotNull(FieldLabels))
+}
+
 // HasParent applies the HasEdge predicate on the "parent" edge.
 func HasParent() predicate.Tenant {
 	return predicate.Tenant(func(s *sql.Selector) {
@@ -441,6 +1296,29 @@ func HasChildrenWith(preds ...predicate.Tenant) predicate.Tenant {
 	})
 }
 
+// HasServiceAccounts applies the HasEdge predicate on the "service_accounts" edge.
+func HasServiceAccounts() predicate.Tenant {
+	return predicate.Tenant(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ServiceAccountsTable, ServiceAccountsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasServiceAccountsWith applies the HasEdge predicate on the "service_accounts" edge with a given conditions (other predicates).
+func HasServiceAccountsWith(preds ...predicate.ServiceAccount) predicate.Tenant {
+	return predicate.Tenant(func(s *sql.Selector) {
+		step := newServiceAccountsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Tenant) predicate.Tenant {
 	return predicate.Tenant(func(s *sql.Selector) {
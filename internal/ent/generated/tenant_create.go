@@ -24,6 +24,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
 	"go.infratographer.com/x/gidx"
 )
@@ -69,6 +70,20 @@ func (tc *TenantCreate) SetName(s string) *TenantCreate {
 	return tc
 }
 
+// SetDisplayName sets the "display_name" field.
+func (tc *TenantCreate) SetDisplayName(s string) *TenantCreate {
+	tc.mutation.SetDisplayName(s)
+	return tc
+}
+
+// SetNillableDisplayName sets the "display_name" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableDisplayName(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetDisplayName(*s)
+	}
+	return tc
+}
+
 // SetDescription sets the "description" field.
 func (tc *TenantCreate) SetDescription(s string) *TenantCreate {
 	tc.mutation.SetDescription(s)
@@ -83,6 +98,34 @@ func (tc *TenantCreate) SetNillableDescription(s *string) *TenantCreate {
 	return tc
 }
 
+// SetContactEmail sets the "contact_email" field.
+func (tc *TenantCreate) SetContactEmail(s string) *TenantCreate {
+	tc.mutation.SetContactEmail(s)
+	return tc
+}
+
+// SetNillableContactEmail sets the "contact_email" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableContactEmail(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetContactEmail(*s)
+	}
+	return tc
+}
+
+// SetBillingReference sets the "billing_reference" field.
+func (tc *TenantCreate) SetBillingReference(s string) *TenantCreate {
+	tc.mutation.SetBillingReference(s)
+	return tc
+}
+
+// SetNillableBillingReference sets the "billing_reference" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableBillingReference(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetBillingReference(*s)
+	}
+	return tc
+}
+
 // SetParentTenantID sets the "parent_tenant_id" field.
 func (tc *TenantCreate) SetParentTenantID(gi gidx.PrefixedID) *TenantCreate {
 	tc.mutation.SetParentTenantID(gi)
@@ -97,6 +140,186 @@ func (tc *TenantCreate) SetNillableParentTenantID(gi *gidx.PrefixedID) *TenantCr
 	return tc
 }
 
+// SetDeletionScheduledAt sets the "deletion_scheduled_at" field.
+func (tc *TenantCreate) SetDeletionScheduledAt(t time.Time) *TenantCreate {
+	tc.mutation.SetDeletionScheduledAt(t)
+	return tc
+}
+
+// SetNillableDeletionScheduledAt sets the "deletion_scheduled_at" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableDeletionScheduledAt(t *time.Time) *TenantCreate {
+	if t != nil {
+		tc.SetDeletionScheduledAt(*t)
+	}
+	return tc
+}
+
+// SetMaxChildren sets the "max_children" field.
+func (tc *TenantCreate) SetMaxChildren(i int) *TenantCreate {
+	tc.mutation.SetMaxChildren(i)
+	return tc
+}
+
+// SetNillableMaxChildren sets the "max_children" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableMaxChildren(i *int) *TenantCreate {
+	if i != nil {
+		tc.SetMaxChildren(*i)
+	}
+	return tc
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (tc *TenantCreate) SetOwnerID(gi gidx.PrefixedID) *TenantCreate {
+	tc.mutation.SetOwnerID(gi)
+	return tc
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableOwnerID(gi *gidx.PrefixedID) *TenantCreate {
+	if gi != nil {
+		tc.SetOwnerID(*gi)
+	}
+	return tc
+}
+
+// SetSuspendedAt sets the "suspended_at" field.
+func (tc *TenantCreate) SetSuspendedAt(t time.Time) *TenantCreate {
+	tc.mutation.SetSuspendedAt(t)
+	return tc
+}
+
+// SetNillableSuspendedAt sets the "suspended_at" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableSuspendedAt(t *time.Time) *TenantCreate {
+	if t != nil {
+		tc.SetSuspendedAt(*t)
+	}
+	return tc
+}
+
+// SetExternalID sets the "external_id" field.
+func (tc *TenantCreate) SetExternalID(s string) *TenantCreate {
+	tc.mutation.SetExternalID(s)
+	return tc
+}
+
+// SetNillableExternalID sets the "external_id" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableExternalID(s *string) *TenantCreate {
+	if s != nil {
+		tc.SetExternalID(*s)
+	}
+	return tc
+}
+
+// SetArchived sets the "archived" field.
+func (tc *TenantCreate) SetArchived(b bool) *TenantCreate {
+	tc.mutation.SetArchived(b)
+	return tc
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableArchived(b *bool) *TenantCreate {
+	if b != nil {
+		tc.SetArchived(*b)
+	}
+	return tc
+}
+
+// SetFrozen sets the "frozen" field.
+func (tc *TenantCreate) SetFrozen(b bool) *TenantCreate {
+	tc.mutation.SetFrozen(b)
+	return tc
+}
+
+// SetNillableFrozen sets the "frozen" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableFrozen(b *bool) *TenantCreate {
+	if b != nil {
+		tc.SetFrozen(*b)
+	}
+	return tc
+}
+
+// SetCreationFrozenUntil sets the "creation_frozen_until" field.
+func (tc *TenantCreate) SetCreationFrozenUntil(t time.Time) *TenantCreate {
+	tc.mutation.SetCreationFrozenUntil(t)
+	return tc
+}
+
+// SetNillableCreationFrozenUntil sets the "creation_frozen_until" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableCreationFrozenUntil(t *time.Time) *TenantCreate {
+	if t != nil {
+		tc.SetCreationFrozenUntil(*t)
+	}
+	return tc
+}
+
+// SetDeletionProtected sets the "deletion_protected" field.
+func (tc *TenantCreate) SetDeletionProtected(b bool) *TenantCreate {
+	tc.mutation.SetDeletionProtected(b)
+	return tc
+}
+
+// SetNillableDeletionProtected sets the "deletion_protected" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableDeletionProtected(b *bool) *TenantCreate {
+	if b != nil {
+		tc.SetDeletionProtected(*b)
+	}
+	return tc
+}
+
+// SetChangeSeq sets the "change_seq" field.
+func (tc *TenantCreate) SetChangeSeq(i int64) *TenantCreate {
+	tc.mutation.SetChangeSeq(i)
+	return tc
+}
+
+// SetNillableChangeSeq sets the "change_seq" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableChangeSeq(i *int64) *TenantCreate {
+	if i != nil {
+		tc.SetChangeSeq(*i)
+	}
+	return tc
+}
+
+// SetVersion sets the "version" field.
+func (tc *TenantCreate) SetVersion(i int64) *TenantCreate {
+	tc.mutation.SetVersion(i)
+	return tc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableVersion(i *int64) *TenantCreate {
+	if i != nil {
+		tc.SetVersion(*i)
+	}
+	return tc
+}
+
+// SetUniqueName sets the "unique_name" field.
+func (tc *TenantCreate) SetUniqueName(b bool) *TenantCreate {
+	tc.mutation.SetUniqueName(b)
+	return tc
+}
+
+// SetNillableUniqueName sets the "unique_name" field if the given value is not nil.
+func (tc *TenantCreate) SetNillableUniqueName(b *bool) *TenantCreate {
+	if b != nil {
+		tc.SetUniqueName(*b)
+	}
+	return tc
+}
+
+// SetSettings sets the "settings" field.
+func (tc *TenantCreate) SetSettings(m map[string]interface{}) *TenantCreate {
+	tc.mutation.SetSettings(m)
+	return tc
+}
+
+// SetLabels sets the "labels" field.
+func (tc *TenantCreate) SetLabels(m map[string]string) *TenantCreate {
+	tc.mutation.SetLabels(m)
+	return tc
+}
+
 // SetID sets the "id" field.
 func (tc *TenantCreate) SetID(gi gidx.PrefixedID) *TenantCreate {
 	tc.mutation.SetID(gi)
@@ -145,6 +368,21 @@ func (tc *TenantCreate) AddChildren(t ...*Tenant) *TenantCreate {
 	return tc.AddChildIDs(ids...)
 }
 
+// AddServiceAccountIDs adds the "service_accounts" edge to the ServiceAccount entity by IDs.
+func (tc *TenantCreate) AddServiceAccountIDs(ids ...gidx.PrefixedID) *TenantCreate {
+	tc.mutation.AddServiceAccountIDs(ids...)
+	return tc
+}
+
+// AddServiceAccounts adds the "service_accounts" edges to the ServiceAccount entity.
+func (tc *TenantCreate) AddServiceAccounts(s ...*ServiceAccount) *TenantCreate {
+	ids := make([]gidx.PrefixedID, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return tc.AddServiceAccountIDs(ids...)
+}
+
 // Mutation returns the TenantMutation object of the builder.
 func (tc *TenantCreate) Mutation() *TenantMutation {
 	return tc.mutation
@@ -205,6 +443,26 @@ func (tc *TenantCreate) check() error {
 	if _, ok := tc.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`generated: missing required field "Tenant.name"`)}
 	}
+	if v, ok := tc.mutation.MaxChildren(); ok {
+		if err := tenant.MaxChildrenValidator(v); err != nil {
+			return &ValidationError{Name: "max_children", err: fmt.Errorf(`generated: validator failed for field "Tenant.max_children": %w`, err)}
+		}
+	}
+	if v, ok := tc.mutation.ExternalID(); ok {
+		if err := tenant.ExternalIDValidator(v); err != nil {
+			return &ValidationError{Name: "external_id", err: fmt.Errorf(`generated: validator failed for field "Tenant.external_id": %w`, err)}
+		}
+	}
+	if v, ok := tc.mutation.ChangeSeq(); ok {
+		if err := tenant.ChangeSeqValidator(v); err != nil {
+			return &ValidationError{Name: "change_seq", err: fmt.Errorf(`generated: validator failed for field "Tenant.change_seq": %w`, err)}
+		}
+	}
+	if v, ok := tc.mutation.Version(); ok {
+		if err := tenant.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`generated: validator failed for field "Tenant.version": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -252,10 +510,78 @@ func (tc *TenantCreate) createSpec() (*Tenant, *sqlgraph.CreateSpec) {
 		_spec.SetField(tenant.FieldName, field.TypeString, value)
 		_node.Name = value
 	}
+	if value, ok := tc.mutation.DisplayName(); ok {
+		_spec.SetField(tenant.FieldDisplayName, field.TypeString, value)
+		_node.DisplayName = value
+	}
 	if value, ok := tc.mutation.Description(); ok {
 		_spec.SetField(tenant.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
+	if value, ok := tc.mutation.ContactEmail(); ok {
+		_spec.SetField(tenant.FieldContactEmail, field.TypeString, value)
+		_node.ContactEmail = value
+	}
+	if value, ok := tc.mutation.BillingReference(); ok {
+		_spec.SetField(tenant.FieldBillingReference, field.TypeString, value)
+		_node.BillingReference = value
+	}
+	if value, ok := tc.mutation.DeletionScheduledAt(); ok {
+		_spec.SetField(tenant.FieldDeletionScheduledAt, field.TypeTime, value)
+		_node.DeletionScheduledAt = value
+	}
+	if value, ok := tc.mutation.MaxChildren(); ok {
+		_spec.SetField(tenant.FieldMaxChildren, field.TypeInt, value)
+		_node.MaxChildren = value
+	}
+	if value, ok := tc.mutation.OwnerID(); ok {
+		_spec.SetField(tenant.FieldOwnerID, field.TypeString, value)
+		_node.OwnerID = value
+	}
+	if value, ok := tc.mutation.SuspendedAt(); ok {
+		_spec.SetField(tenant.FieldSuspendedAt, field.TypeTime, value)
+		_node.SuspendedAt = value
+	}
+	if value, ok := tc.mutation.ExternalID(); ok {
+		_spec.SetField(tenant.FieldExternalID, field.TypeString, value)
+		_node.ExternalID = value
+	}
+	if value, ok := tc.mutation.Archived(); ok {
+		_spec.SetField(tenant.FieldArchived, field.TypeBool, value)
+		_node.Archived = value
+	}
+	if value, ok := tc.mutation.Frozen(); ok {
+		_spec.SetField(tenant.FieldFrozen, field.TypeBool, value)
+		_node.Frozen = value
+	}
+	if value, ok := tc.mutation.CreationFrozenUntil(); ok {
+		_spec.SetField(tenant.FieldCreationFrozenUntil, field.TypeTime, value)
+		_node.CreationFrozenUntil = value
+	}
+	if value, ok := tc.mutation.DeletionProtected(); ok {
+		_spec.SetField(tenant.FieldDeletionProtected, field.TypeBool, value)
+		_node.DeletionProtected = value
+	}
+	if value, ok := tc.mutation.ChangeSeq(); ok {
+		_spec.SetField(tenant.FieldChangeSeq, field.TypeInt64, value)
+		_node.ChangeSeq = value
+	}
+	if value, ok := tc.mutation.Version(); ok {
+		_spec.SetField(tenant.FieldVersion, field.TypeInt64, value)
+		_node.Version = &value
+	}
+	if value, ok := tc.mutation.UniqueName(); ok {
+		_spec.SetField(tenant.FieldUniqueName, field.TypeBool, value)
+		_node.UniqueName = value
+	}
+	if value, ok := tc.mutation.Settings(); ok {
+		_spec.SetField(tenant.FieldSettings, field.TypeJSON, value)
+		_node.Settings = value
+	}
+	if value, ok := tc.mutation.Labels(); ok {
+		_spec.SetField(tenant.FieldLabels, field.TypeJSON, value)
+		_node.Labels = value
+	}
 	if nodes := tc.mutation.ParentIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -289,6 +615,22 @@ func (tc *TenantCreate) createSpec() (*Tenant, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := tc.mutation.ServiceAccountsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -26,6 +26,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
 	"go.infratographer.com/x/gidx"
 )
@@ -33,15 +34,17 @@ import (
 // TenantQuery is the builder for querying Tenant entities.
 type TenantQuery struct {
 	config
-	ctx               *QueryContext
-	order             []tenant.OrderOption
-	inters            []Interceptor
-	predicates        []predicate.Tenant
-	withParent        *TenantQuery
-	withChildren      *TenantQuery
-	modifiers         []func(*sql.Selector)
-	loadTotal         []func(context.Context, []*Tenant) error
-	withNamedChildren map[string]*TenantQuery
+	ctx                      *QueryContext
+	order                    []tenant.OrderOption
+	inters                   []Interceptor
+	predicates               []predicate.Tenant
+	withParent               *TenantQuery
+	withChildren             *TenantQuery
+	withServiceAccounts      *ServiceAccountQuery
+	modifiers                []func(*sql.Selector)
+	loadTotal                []func(context.Context, []*Tenant) error
+	withNamedChildren        map[string]*TenantQuery
+	withNamedServiceAccounts map[string]*ServiceAccountQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -122,6 +125,28 @@ func (tq *TenantQuery) QueryChildren() *TenantQuery {
 	return query
 }
 
+// QueryServiceAccounts chains the current query on the "service_accounts" edge.
+func (tq *TenantQuery) QueryServiceAccounts() *ServiceAccountQuery {
+	query := (&ServiceAccountClient{config: tq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := tq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := tq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(tenant.Table, tenant.FieldID, selector),
+			sqlgraph.To(serviceaccount.Table, serviceaccount.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, tenant.ServiceAccountsTable, tenant.ServiceAccountsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(tq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Tenant entity from the query.
 // Returns a *NotFoundError when no Tenant was found.
 func (tq *TenantQuery) First(ctx context.Context) (*Tenant, error) {
@@ -309,13 +334,14 @@ func (tq *TenantQuery) Clone() *TenantQuery {
 		return nil
 	}
 	return &TenantQuery{
-		config:       tq.config,
-		ctx:          tq.ctx.Clone(),
-		order:        append([]tenant.OrderOption{}, tq.order...),
-		inters:       append([]Interceptor{}, tq.inters...),
-		predicates:   append([]predicate.Tenant{}, tq.predicates...),
-		withParent:   tq.withParent.Clone(),
-		withChildren: tq.withChildren.Clone(),
+		config:              tq.config,
+		ctx:                 tq.ctx.Clone(),
+		order:               append([]tenant.OrderOption{}, tq.order...),
+		inters:              append([]Interceptor{}, tq.inters...),
+		predicates:          append([]predicate.Tenant{}, tq.predicates...),
+		withParent:          tq.withParent.Clone(),
+		withChildren:        tq.withChildren.Clone(),
+		withServiceAccounts: tq.withServiceAccounts.Clone(),
 		// clone intermediate query.
 		sql:  tq.sql.Clone(),
 		path: tq.path,
@@ -344,6 +370,17 @@ func (tq *TenantQuery) WithChildren(opts ...func(*TenantQuery)) *TenantQuery {
 	return tq
 }
 
+// WithServiceAccounts tells the query-builder to eager-load the nodes that are connected to
+// the "service_accounts" edge. The optional arguments are used to configure the query builder of the edge.
+func (tq *TenantQuery) WithServiceAccounts(opts ...func(*ServiceAccountQuery)) *TenantQuery {
+	query := (&ServiceAccountClient{config: tq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	tq.withServiceAccounts = query
+	return tq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -422,9 +459,10 @@ func (tq *TenantQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Tenan
 	var (
 		nodes       = []*Tenant{}
 		_spec       = tq.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [3]bool{
 			tq.withParent != nil,
 			tq.withChildren != nil,
+			tq.withServiceAccounts != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -461,6 +499,13 @@ func (tq *TenantQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Tenan
 			return nil, err
 		}
 	}
+	if query := tq.withServiceAccounts; query != nil {
+		if err := tq.loadServiceAccounts(ctx, query, nodes,
+			func(n *Tenant) { n.Edges.ServiceAccounts = []*ServiceAccount{} },
+			func(n *Tenant, e *ServiceAccount) { n.Edges.ServiceAccounts = append(n.Edges.ServiceAccounts, e) }); err != nil {
+			return nil, err
+		}
+	}
 	for name, query := range tq.withNamedChildren {
 		if err := tq.loadChildren(ctx, query, nodes,
 			func(n *Tenant) { n.appendNamedChildren(name) },
@@ -468,6 +513,13 @@ func (tq *TenantQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Tenan
 			return nil, err
 		}
 	}
+	for name, query := range tq.withNamedServiceAccounts {
+		if err := tq.loadServiceAccounts(ctx, query, nodes,
+			func(n *Tenant) { n.appendNamedServiceAccounts(name) },
+			func(n *Tenant, e *ServiceAccount) { n.appendNamedServiceAccounts(name, e) }); err != nil {
+			return nil, err
+		}
+	}
 	for i := range tq.loadTotal {
 		if err := tq.loadTotal[i](ctx, nodes); err != nil {
 			return nil, err
@@ -535,6 +587,36 @@ func (tq *TenantQuery) loadChildren(ctx context.Context, query *TenantQuery, nod
 	}
 	return nil
 }
+func (tq *TenantQuery) loadServiceAccounts(ctx context.Context, query *ServiceAccountQuery, nodes []*Tenant, init func(*Tenant), assign func(*Tenant, *ServiceAccount)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[gidx.PrefixedID]*Tenant)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(serviceaccount.FieldTenantID)
+	}
+	query.Where(predicate.ServiceAccount(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(tenant.ServiceAccountsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.TenantID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "tenant_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (tq *TenantQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := tq.querySpec()
@@ -637,6 +719,20 @@ func (tq *TenantQuery) WithNamedChildren(name string, opts ...func(*TenantQuery)
 	return tq
 }
 
+// WithNamedServiceAccounts tells the query-builder to eager-load the nodes that are connected to the "service_accounts"
+// edge with the given name. The optional arguments are used to configure the query builder of the edge.
+func (tq *TenantQuery) WithNamedServiceAccounts(name string, opts ...func(*ServiceAccountQuery)) *TenantQuery {
+	query := (&ServiceAccountClient{config: tq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	if tq.withNamedServiceAccounts == nil {
+		tq.withNamedServiceAccounts = make(map[string]*ServiceAccountQuery)
+	}
+	tq.withNamedServiceAccounts[name] = query
+	return tq
+}
+
 // TenantGroupBy is the group-by builder for Tenant entities.
 type TenantGroupBy struct {
 	selector
@@ -20,11 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
 	"go.infratographer.com/x/gidx"
 )
@@ -48,6 +50,26 @@ func (tu *TenantUpdate) SetName(s string) *TenantUpdate {
 	return tu
 }
 
+// SetDisplayName sets the "display_name" field.
+func (tu *TenantUpdate) SetDisplayName(s string) *TenantUpdate {
+	tu.mutation.SetDisplayName(s)
+	return tu
+}
+
+// SetNillableDisplayName sets the "display_name" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableDisplayName(s *string) *TenantUpdate {
+	if s != nil {
+		tu.SetDisplayName(*s)
+	}
+	return tu
+}
+
+// ClearDisplayName clears the value of the "display_name" field.
+func (tu *TenantUpdate) ClearDisplayName() *TenantUpdate {
+	tu.mutation.ClearDisplayName()
+	return tu
+}
+
 // SetDescription sets the "description" field.
 func (tu *TenantUpdate) SetDescription(s string) *TenantUpdate {
 	tu.mutation.SetDescription(s)
@@ -68,6 +90,350 @@ func (tu *TenantUpdate) ClearDescription() *TenantUpdate {
 	return tu
 }
 
+// SetContactEmail sets the "contact_email" field.
+func (tu *TenantUpdate) SetContactEmail(s string) *TenantUpdate {
+	tu.mutation.SetContactEmail(s)
+	return tu
+}
+
+// SetNillableContactEmail sets the "contact_email" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableContactEmail(s *string) *TenantUpdate {
+	if s != nil {
+		tu.SetContactEmail(*s)
+	}
+	return tu
+}
+
+// ClearContactEmail clears the value of the "contact_email" field.
+func (tu *TenantUpdate) ClearContactEmail() *TenantUpdate {
+	tu.mutation.ClearContactEmail()
+	return tu
+}
+
+// SetBillingReference sets the "billing_reference" field.
+func (tu *TenantUpdate) SetBillingReference(s string) *TenantUpdate {
+	tu.mutation.SetBillingReference(s)
+	return tu
+}
+
+// SetNillableBillingReference sets the "billing_reference" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableBillingReference(s *string) *TenantUpdate {
+	if s != nil {
+		tu.SetBillingReference(*s)
+	}
+	return tu
+}
+
+// ClearBillingReference clears the value of the "billing_reference" field.
+func (tu *TenantUpdate) ClearBillingReference() *TenantUpdate {
+	tu.mutation.ClearBillingReference()
+	return tu
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (tu *TenantUpdate) SetParentTenantID(gi gidx.PrefixedID) *TenantUpdate {
+	tu.mutation.SetParentTenantID(gi)
+	return tu
+}
+
+// SetNillableParentTenantID sets the "parent_tenant_id" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableParentTenantID(gi *gidx.PrefixedID) *TenantUpdate {
+	if gi != nil {
+		tu.SetParentTenantID(*gi)
+	}
+	return tu
+}
+
+// ClearParentTenantID clears the value of the "parent_tenant_id" field.
+func (tu *TenantUpdate) ClearParentTenantID() *TenantUpdate {
+	tu.mutation.ClearParentTenantID()
+	return tu
+}
+
+// SetDeletionScheduledAt sets the "deletion_scheduled_at" field.
+func (tu *TenantUpdate) SetDeletionScheduledAt(t time.Time) *TenantUpdate {
+	tu.mutation.SetDeletionScheduledAt(t)
+	return tu
+}
+
+// SetNillableDeletionScheduledAt sets the "deletion_scheduled_at" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableDeletionScheduledAt(t *time.Time) *TenantUpdate {
+	if t != nil {
+		tu.SetDeletionScheduledAt(*t)
+	}
+	return tu
+}
+
+// ClearDeletionScheduledAt clears the value of the "deletion_scheduled_at" field.
+func (tu *TenantUpdate) ClearDeletionScheduledAt() *TenantUpdate {
+	tu.mutation.ClearDeletionScheduledAt()
+	return tu
+}
+
+// SetMaxChildren sets the "max_children" field.
+func (tu *TenantUpdate) SetMaxChildren(i int) *TenantUpdate {
+	tu.mutation.ResetMaxChildren()
+	tu.mutation.SetMaxChildren(i)
+	return tu
+}
+
+// SetNillableMaxChildren sets the "max_children" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableMaxChildren(i *int) *TenantUpdate {
+	if i != nil {
+		tu.SetMaxChildren(*i)
+	}
+	return tu
+}
+
+// AddMaxChildren adds i to the "max_children" field.
+func (tu *TenantUpdate) AddMaxChildren(i int) *TenantUpdate {
+	tu.mutation.AddMaxChildren(i)
+	return tu
+}
+
+// ClearMaxChildren clears the value of the "max_children" field.
+func (tu *TenantUpdate) ClearMaxChildren() *TenantUpdate {
+	tu.mutation.ClearMaxChildren()
+	return tu
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (tu *TenantUpdate) SetOwnerID(gi gidx.PrefixedID) *TenantUpdate {
+	tu.mutation.SetOwnerID(gi)
+	return tu
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableOwnerID(gi *gidx.PrefixedID) *TenantUpdate {
+	if gi != nil {
+		tu.SetOwnerID(*gi)
+	}
+	return tu
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (tu *TenantUpdate) ClearOwnerID() *TenantUpdate {
+	tu.mutation.ClearOwnerID()
+	return tu
+}
+
+// SetSuspendedAt sets the "suspended_at" field.
+func (tu *TenantUpdate) SetSuspendedAt(t time.Time) *TenantUpdate {
+	tu.mutation.SetSuspendedAt(t)
+	return tu
+}
+
+// SetNillableSuspendedAt sets the "suspended_at" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableSuspendedAt(t *time.Time) *TenantUpdate {
+	if t != nil {
+		tu.SetSuspendedAt(*t)
+	}
+	return tu
+}
+
+// ClearSuspendedAt clears the value of the "suspended_at" field.
+func (tu *TenantUpdate) ClearSuspendedAt() *TenantUpdate {
+	tu.mutation.ClearSuspendedAt()
+	return tu
+}
+
+// SetArchived sets the "archived" field.
+func (tu *TenantUpdate) SetArchived(b bool) *TenantUpdate {
+	tu.mutation.SetArchived(b)
+	return tu
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableArchived(b *bool) *TenantUpdate {
+	if b != nil {
+		tu.SetArchived(*b)
+	}
+	return tu
+}
+
+// ClearArchived clears the value of the "archived" field.
+func (tu *TenantUpdate) ClearArchived() *TenantUpdate {
+	tu.mutation.ClearArchived()
+	return tu
+}
+
+// SetFrozen sets the "frozen" field.
+func (tu *TenantUpdate) SetFrozen(b bool) *TenantUpdate {
+	tu.mutation.SetFrozen(b)
+	return tu
+}
+
+// SetNillableFrozen sets the "frozen" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableFrozen(b *bool) *TenantUpdate {
+	if b != nil {
+		tu.SetFrozen(*b)
+	}
+	return tu
+}
+
+// ClearFrozen clears the value of the "frozen" field.
+func (tu *TenantUpdate) ClearFrozen() *TenantUpdate {
+	tu.mutation.ClearFrozen()
+	return tu
+}
+
+// SetCreationFrozenUntil sets the "creation_frozen_until" field.
+func (tu *TenantUpdate) SetCreationFrozenUntil(t time.Time) *TenantUpdate {
+	tu.mutation.SetCreationFrozenUntil(t)
+	return tu
+}
+
+// SetNillableCreationFrozenUntil sets the "creation_frozen_until" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableCreationFrozenUntil(t *time.Time) *TenantUpdate {
+	if t != nil {
+		tu.SetCreationFrozenUntil(*t)
+	}
+	return tu
+}
+
+// ClearCreationFrozenUntil clears the value of the "creation_frozen_until" field.
+func (tu *TenantUpdate) ClearCreationFrozenUntil() *TenantUpdate {
+	tu.mutation.ClearCreationFrozenUntil()
+	return tu
+}
+
+// SetDeletionProtected sets the "deletion_protected" field.
+func (tu *TenantUpdate) SetDeletionProtected(b bool) *TenantUpdate {
+	tu.mutation.SetDeletionProtected(b)
+	return tu
+}
+
+// SetNillableDeletionProtected sets the "deletion_protected" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableDeletionProtected(b *bool) *TenantUpdate {
+	if b != nil {
+		tu.SetDeletionProtected(*b)
+	}
+	return tu
+}
+
+// ClearDeletionProtected clears the value of the "deletion_protected" field.
+func (tu *TenantUpdate) ClearDeletionProtected() *TenantUpdate {
+	tu.mutation.ClearDeletionProtected()
+	return tu
+}
+
+// SetChangeSeq sets the "change_seq" field.
+func (tu *TenantUpdate) SetChangeSeq(i int64) *TenantUpdate {
+	tu.mutation.ResetChangeSeq()
+	tu.mutation.SetChangeSeq(i)
+	return tu
+}
+
+// SetNillableChangeSeq sets the "change_seq" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableChangeSeq(i *int64) *TenantUpdate {
+	if i != nil {
+		tu.SetChangeSeq(*i)
+	}
+	return tu
+}
+
+// AddChangeSeq adds i to the "change_seq" field.
+func (tu *TenantUpdate) AddChangeSeq(i int64) *TenantUpdate {
+	tu.mutation.AddChangeSeq(i)
+	return tu
+}
+
+// ClearChangeSeq clears the value of the "change_seq" field.
+func (tu *TenantUpdate) ClearChangeSeq() *TenantUpdate {
+	tu.mutation.ClearChangeSeq()
+	return tu
+}
+
+// SetVersion sets the "version" field.
+func (tu *TenantUpdate) SetVersion(i int64) *TenantUpdate {
+	tu.mutation.ResetVersion()
+	tu.mutation.SetVersion(i)
+	return tu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableVersion(i *int64) *TenantUpdate {
+	if i != nil {
+		tu.SetVersion(*i)
+	}
+	return tu
+}
+
+// AddVersion adds i to the "version" field.
+func (tu *TenantUpdate) AddVersion(i int64) *TenantUpdate {
+	tu.mutation.AddVersion(i)
+	return tu
+}
+
+// ClearVersion clears the value of the "version" field.
+func (tu *TenantUpdate) ClearVersion() *TenantUpdate {
+	tu.mutation.ClearVersion()
+	return tu
+}
+
+// SetUniqueName sets the "unique_name" field.
+func (tu *TenantUpdate) SetUniqueName(b bool) *TenantUpdate {
+	tu.mutation.SetUniqueName(b)
+	return tu
+}
+
+// SetNillableUniqueName sets the "unique_name" field if the given value is not nil.
+func (tu *TenantUpdate) SetNillableUniqueName(b *bool) *TenantUpdate {
+	if b != nil {
+		tu.SetUniqueName(*b)
+	}
+	return tu
+}
+
+// ClearUniqueName clears the value of the "unique_name" field.
+func (tu *TenantUpdate) ClearUniqueName() *TenantUpdate {
+	tu.mutation.ClearUniqueName()
+	return tu
+}
+
+// SetSettings sets the "settings" field.
+func (tu *TenantUpdate) SetSettings(m map[string]interface{}) *TenantUpdate {
+	tu.mutation.SetSettings(m)
+	return tu
+}
+
+// ClearSettings clears the value of the "settings" field.
+func (tu *TenantUpdate) ClearSettings() *TenantUpdate {
+	tu.mutation.ClearSettings()
+	return tu
+}
+
+// SetLabels sets the "labels" field.
+func (tu *TenantUpdate) SetLabels(m map[string]string) *TenantUpdate {
+	tu.mutation.SetLabels(m)
+	return tu
+}
+
+// ClearLabels clears the value of the "labels" field.
+func (tu *TenantUpdate) ClearLabels() *TenantUpdate {
+	tu.mutation.ClearLabels()
+	return tu
+}
+
+// SetParentID sets the "parent" edge to the Tenant entity by ID.
+func (tu *TenantUpdate) SetParentID(id gidx.PrefixedID) *TenantUpdate {
+	tu.mutation.SetParentID(id)
+	return tu
+}
+
+// SetNillableParentID sets the "parent" edge to the Tenant entity by ID if the given value is not nil.
+func (tu *TenantUpdate) SetNillableParentID(id *gidx.PrefixedID) *TenantUpdate {
+	if id != nil {
+		tu = tu.SetParentID(*id)
+	}
+	return tu
+}
+
+// SetParent sets the "parent" edge to the Tenant entity.
+func (tu *TenantUpdate) SetParent(t *Tenant) *TenantUpdate {
+	return tu.SetParentID(t.ID)
+}
+
 // AddChildIDs adds the "children" edge to the Tenant entity by IDs.
 func (tu *TenantUpdate) AddChildIDs(ids ...gidx.PrefixedID) *TenantUpdate {
 	tu.mutation.AddChildIDs(ids...)
@@ -83,11 +449,32 @@ func (tu *TenantUpdate) AddChildren(t ...*Tenant) *TenantUpdate {
 	return tu.AddChildIDs(ids...)
 }
 
+// AddServiceAccountIDs adds the "service_accounts" edge to the ServiceAccount entity by IDs.
+func (tu *TenantUpdate) AddServiceAccountIDs(ids ...gidx.PrefixedID) *TenantUpdate {
+	tu.mutation.AddServiceAccountIDs(ids...)
+	return tu
+}
+
+// AddServiceAccounts adds the "service_accounts" edges to the ServiceAccount entity.
+func (tu *TenantUpdate) AddServiceAccounts(s ...*ServiceAccount) *TenantUpdate {
+	ids := make([]gidx.PrefixedID, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return tu.AddServiceAccountIDs(ids...)
+}
+
 // Mutation returns the TenantMutation object of the builder.
 func (tu *TenantUpdate) Mutation() *TenantMutation {
 	return tu.mutation
 }
 
+// ClearParent clears the "parent" edge to the Tenant entity.
+func (tu *TenantUpdate) ClearParent() *TenantUpdate {
+	tu.mutation.ClearParent()
+	return tu
+}
+
 // ClearChildren clears all "children" edges to the Tenant entity.
 func (tu *TenantUpdate) ClearChildren() *TenantUpdate {
 	tu.mutation.ClearChildren()
@@ -100,163 +487,753 @@ func (tu *TenantUpdate) RemoveChildIDs(ids ...gidx.PrefixedID) *TenantUpdate {
 	return tu
 }
 
-// RemoveChildren removes "children" edges to Tenant entities.
-func (tu *TenantUpdate) RemoveChildren(t ...*Tenant) *TenantUpdate {
-	ids := make([]gidx.PrefixedID, len(t))
-	for i := range t {
-		ids[i] = t[i].ID
+// RemoveChildren removes "children" edges to Tenant entities.
+func (tu *TenantUpdate) RemoveChildren(t ...*Tenant) *TenantUpdate {
+	ids := make([]gidx.PrefixedID, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return tu.RemoveChildIDs(ids...)
+}
+
+// ClearServiceAccounts clears all "service_accounts" edges to the ServiceAccount entity.
+func (tu *TenantUpdate) ClearServiceAccounts() *TenantUpdate {
+	tu.mutation.ClearServiceAccounts()
+	return tu
+}
+
+// RemoveServiceAccountIDs removes the "service_accounts" edge to ServiceAccount entities by IDs.
+func (tu *TenantUpdate) RemoveServiceAccountIDs(ids ...gidx.PrefixedID) *TenantUpdate {
+	tu.mutation.RemoveServiceAccountIDs(ids...)
+	return tu
+}
+
+// RemoveServiceAccounts removes "service_accounts" edges to ServiceAccount entities.
+func (tu *TenantUpdate) RemoveServiceAccounts(s ...*ServiceAccount) *TenantUpdate {
+	ids := make([]gidx.PrefixedID, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return tu.RemoveServiceAccountIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tu *TenantUpdate) Save(ctx context.Context) (int, error) {
+	tu.defaults()
+	return withHooks(ctx, tu.sqlSave, tu.mutation, tu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tu *TenantUpdate) SaveX(ctx context.Context) int {
+	affected, err := tu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tu *TenantUpdate) Exec(ctx context.Context) error {
+	_, err := tu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tu *TenantUpdate) ExecX(ctx context.Context) {
+	if err := tu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tu *TenantUpdate) defaults() {
+	if _, ok := tu.mutation.UpdatedAt(); !ok {
+		v := tenant.UpdateDefaultUpdatedAt()
+		tu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tu *TenantUpdate) check() error {
+	if v, ok := tu.mutation.MaxChildren(); ok {
+		if err := tenant.MaxChildrenValidator(v); err != nil {
+			return &ValidationError{Name: "max_children", err: fmt.Errorf(`generated: validator failed for field "Tenant.max_children": %w`, err)}
+		}
+	}
+	if v, ok := tu.mutation.ChangeSeq(); ok {
+		if err := tenant.ChangeSeqValidator(v); err != nil {
+			return &ValidationError{Name: "change_seq", err: fmt.Errorf(`generated: validator failed for field "Tenant.change_seq": %w`, err)}
+		}
+	}
+	if v, ok := tu.mutation.Version(); ok {
+		if err := tenant.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`generated: validator failed for field "Tenant.version": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tu *TenantUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := tu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(tenant.Table, tenant.Columns, sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString))
+	if ps := tu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tu.mutation.UpdatedAt(); ok {
+		_spec.SetField(tenant.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := tu.mutation.Name(); ok {
+		_spec.SetField(tenant.FieldName, field.TypeString, value)
+	}
+	if value, ok := tu.mutation.DisplayName(); ok {
+		_spec.SetField(tenant.FieldDisplayName, field.TypeString, value)
+	}
+	if tu.mutation.DisplayNameCleared() {
+		_spec.ClearField(tenant.FieldDisplayName, field.TypeString)
+	}
+	if value, ok := tu.mutation.Description(); ok {
+		_spec.SetField(tenant.FieldDescription, field.TypeString, value)
+	}
+	if tu.mutation.DescriptionCleared() {
+		_spec.ClearField(tenant.FieldDescription, field.TypeString)
+	}
+	if value, ok := tu.mutation.ContactEmail(); ok {
+		_spec.SetField(tenant.FieldContactEmail, field.TypeString, value)
+	}
+	if tu.mutation.ContactEmailCleared() {
+		_spec.ClearField(tenant.FieldContactEmail, field.TypeString)
+	}
+	if value, ok := tu.mutation.BillingReference(); ok {
+		_spec.SetField(tenant.FieldBillingReference, field.TypeString, value)
+	}
+	if tu.mutation.BillingReferenceCleared() {
+		_spec.ClearField(tenant.FieldBillingReference, field.TypeString)
+	}
+	if value, ok := tu.mutation.DeletionScheduledAt(); ok {
+		_spec.SetField(tenant.FieldDeletionScheduledAt, field.TypeTime, value)
+	}
+	if tu.mutation.DeletionScheduledAtCleared() {
+		_spec.ClearField(tenant.FieldDeletionScheduledAt, field.TypeTime)
+	}
+	if value, ok := tu.mutation.MaxChildren(); ok {
+		_spec.SetField(tenant.FieldMaxChildren, field.TypeInt, value)
+	}
+	if value, ok := tu.mutation.AddedMaxChildren(); ok {
+		_spec.AddField(tenant.FieldMaxChildren, field.TypeInt, value)
+	}
+	if tu.mutation.MaxChildrenCleared() {
+		_spec.ClearField(tenant.FieldMaxChildren, field.TypeInt)
+	}
+	if value, ok := tu.mutation.OwnerID(); ok {
+		_spec.SetField(tenant.FieldOwnerID, field.TypeString, value)
+	}
+	if tu.mutation.OwnerIDCleared() {
+		_spec.ClearField(tenant.FieldOwnerID, field.TypeString)
+	}
+	if value, ok := tu.mutation.SuspendedAt(); ok {
+		_spec.SetField(tenant.FieldSuspendedAt, field.TypeTime, value)
+	}
+	if tu.mutation.SuspendedAtCleared() {
+		_spec.ClearField(tenant.FieldSuspendedAt, field.TypeTime)
+	}
+	if tu.mutation.ExternalIDCleared() {
+		_spec.ClearField(tenant.FieldExternalID, field.TypeString)
+	}
+	if value, ok := tu.mutation.Archived(); ok {
+		_spec.SetField(tenant.FieldArchived, field.TypeBool, value)
+	}
+	if tu.mutation.ArchivedCleared() {
+		_spec.ClearField(tenant.FieldArchived, field.TypeBool)
+	}
+	if value, ok := tu.mutation.Frozen(); ok {
+		_spec.SetField(tenant.FieldFrozen, field.TypeBool, value)
+	}
+	if tu.mutation.FrozenCleared() {
+		_spec.ClearField(tenant.FieldFrozen, field.TypeBool)
+	}
+	if value, ok := tu.mutation.CreationFrozenUntil(); ok {
+		_spec.SetField(tenant.FieldCreationFrozenUntil, field.TypeTime, value)
+	}
+	if tu.mutation.CreationFrozenUntilCleared() {
+		_spec.ClearField(tenant.FieldCreationFrozenUntil, field.TypeTime)
+	}
+	if value, ok := tu.mutation.DeletionProtected(); ok {
+		_spec.SetField(tenant.FieldDeletionProtected, field.TypeBool, value)
+	}
+	if tu.mutation.DeletionProtectedCleared() {
+		_spec.ClearField(tenant.FieldDeletionProtected, field.TypeBool)
+	}
+	if value, ok := tu.mutation.ChangeSeq(); ok {
+		_spec.SetField(tenant.FieldChangeSeq, field.TypeInt64, value)
+	}
+	if value, ok := tu.mutation.AddedChangeSeq(); ok {
+		_spec.AddField(tenant.FieldChangeSeq, field.TypeInt64, value)
+	}
+	if tu.mutation.ChangeSeqCleared() {
+		_spec.ClearField(tenant.FieldChangeSeq, field.TypeInt64)
+	}
+	if value, ok := tu.mutation.Version(); ok {
+		_spec.SetField(tenant.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := tu.mutation.AddedVersion(); ok {
+		_spec.AddField(tenant.FieldVersion, field.TypeInt64, value)
+	}
+	if tu.mutation.VersionCleared() {
+		_spec.ClearField(tenant.FieldVersion, field.TypeInt64)
+	}
+	if value, ok := tu.mutation.UniqueName(); ok {
+		_spec.SetField(tenant.FieldUniqueName, field.TypeBool, value)
+	}
+	if tu.mutation.UniqueNameCleared() {
+		_spec.ClearField(tenant.FieldUniqueName, field.TypeBool)
+	}
+	if value, ok := tu.mutation.Settings(); ok {
+		_spec.SetField(tenant.FieldSettings, field.TypeJSON, value)
+	}
+	if tu.mutation.SettingsCleared() {
+		_spec.ClearField(tenant.FieldSettings, field.TypeJSON)
+	}
+	if value, ok := tu.mutation.Labels(); ok {
+		_spec.SetField(tenant.FieldLabels, field.TypeJSON, value)
+	}
+	if tu.mutation.LabelsCleared() {
+		_spec.ClearField(tenant.FieldLabels, field.TypeJSON)
+	}
+	if tu.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   tenant.ParentTable,
+			Columns: []string{tenant.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   tenant.ParentTable,
+			Columns: []string{tenant.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if tu.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ChildrenTable,
+			Columns: []string{tenant.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !tu.mutation.ChildrenCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ChildrenTable,
+			Columns: []string{tenant.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.ChildrenIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ChildrenTable,
+			Columns: []string{tenant.ChildrenColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if tu.mutation.ServiceAccountsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemovedServiceAccountsIDs(); len(nodes) > 0 && !tu.mutation.ServiceAccountsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.ServiceAccountsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenant.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tu.mutation.done = true
+	return n, nil
+}
+
+// TenantUpdateOne is the builder for updating a single Tenant entity.
+type TenantUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantMutation
+}
+
+// SetName sets the "name" field.
+func (tuo *TenantUpdateOne) SetName(s string) *TenantUpdateOne {
+	tuo.mutation.SetName(s)
+	return tuo
+}
+
+// SetDisplayName sets the "display_name" field.
+func (tuo *TenantUpdateOne) SetDisplayName(s string) *TenantUpdateOne {
+	tuo.mutation.SetDisplayName(s)
+	return tuo
+}
+
+// SetNillableDisplayName sets the "display_name" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableDisplayName(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetDisplayName(*s)
+	}
+	return tuo
+}
+
+// ClearDisplayName clears the value of the "display_name" field.
+func (tuo *TenantUpdateOne) ClearDisplayName() *TenantUpdateOne {
+	tuo.mutation.ClearDisplayName()
+	return tuo
+}
+
+// SetDescription sets the "description" field.
+func (tuo *TenantUpdateOne) SetDescription(s string) *TenantUpdateOne {
+	tuo.mutation.SetDescription(s)
+	return tuo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableDescription(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetDescription(*s)
+	}
+	return tuo
+}
+
+// ClearDescription clears the value of the "description" field.
+func (tuo *TenantUpdateOne) ClearDescription() *TenantUpdateOne {
+	tuo.mutation.ClearDescription()
+	return tuo
+}
+
+// SetContactEmail sets the "contact_email" field.
+func (tuo *TenantUpdateOne) SetContactEmail(s string) *TenantUpdateOne {
+	tuo.mutation.SetContactEmail(s)
+	return tuo
+}
+
+// SetNillableContactEmail sets the "contact_email" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableContactEmail(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetContactEmail(*s)
+	}
+	return tuo
+}
+
+// ClearContactEmail clears the value of the "contact_email" field.
+func (tuo *TenantUpdateOne) ClearContactEmail() *TenantUpdateOne {
+	tuo.mutation.ClearContactEmail()
+	return tuo
+}
+
+// SetBillingReference sets the "billing_reference" field.
+func (tuo *TenantUpdateOne) SetBillingReference(s string) *TenantUpdateOne {
+	tuo.mutation.SetBillingReference(s)
+	return tuo
+}
+
+// SetNillableBillingReference sets the "billing_reference" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableBillingReference(s *string) *TenantUpdateOne {
+	if s != nil {
+		tuo.SetBillingReference(*s)
+	}
+	return tuo
+}
+
+// ClearBillingReference clears the value of the "billing_reference" field.
+func (tuo *TenantUpdateOne) ClearBillingReference() *TenantUpdateOne {
+	tuo.mutation.ClearBillingReference()
+	return tuo
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (tuo *TenantUpdateOne) SetParentTenantID(gi gidx.PrefixedID) *TenantUpdateOne {
+	tuo.mutation.SetParentTenantID(gi)
+	return tuo
+}
+
+// SetNillableParentTenantID sets the "parent_tenant_id" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableParentTenantID(gi *gidx.PrefixedID) *TenantUpdateOne {
+	if gi != nil {
+		tuo.SetParentTenantID(*gi)
+	}
+	return tuo
+}
+
+// ClearParentTenantID clears the value of the "parent_tenant_id" field.
+func (tuo *TenantUpdateOne) ClearParentTenantID() *TenantUpdateOne {
+	tuo.mutation.ClearParentTenantID()
+	return tuo
+}
+
+// SetDeletionScheduledAt sets the "deletion_scheduled_at" field.
+func (tuo *TenantUpdateOne) SetDeletionScheduledAt(t time.Time) *TenantUpdateOne {
+	tuo.mutation.SetDeletionScheduledAt(t)
+	return tuo
+}
+
+// SetNillableDeletionScheduledAt sets the "deletion_scheduled_at" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableDeletionScheduledAt(t *time.Time) *TenantUpdateOne {
+	if t != nil {
+		tuo.SetDeletionScheduledAt(*t)
+	}
+	return tuo
+}
+
+// ClearDeletionScheduledAt clears the value of the "deletion_scheduled_at" field.
+func (tuo *TenantUpdateOne) ClearDeletionScheduledAt() *TenantUpdateOne {
+	tuo.mutation.ClearDeletionScheduledAt()
+	return tuo
+}
+
+// SetMaxChildren sets the "max_children" field.
+func (tuo *TenantUpdateOne) SetMaxChildren(i int) *TenantUpdateOne {
+	tuo.mutation.ResetMaxChildren()
+	tuo.mutation.SetMaxChildren(i)
+	return tuo
+}
+
+// SetNillableMaxChildren sets the "max_children" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableMaxChildren(i *int) *TenantUpdateOne {
+	if i != nil {
+		tuo.SetMaxChildren(*i)
+	}
+	return tuo
+}
+
+// AddMaxChildren adds i to the "max_children" field.
+func (tuo *TenantUpdateOne) AddMaxChildren(i int) *TenantUpdateOne {
+	tuo.mutation.AddMaxChildren(i)
+	return tuo
+}
+
+// ClearMaxChildren clears the value of the "max_children" field.
+func (tuo *TenantUpdateOne) ClearMaxChildren() *TenantUpdateOne {
+	tuo.mutation.ClearMaxChildren()
+	return tuo
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (tuo *TenantUpdateOne) SetOwnerID(gi gidx.PrefixedID) *TenantUpdateOne {
+	tuo.mutation.SetOwnerID(gi)
+	return tuo
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableOwnerID(gi *gidx.PrefixedID) *TenantUpdateOne {
+	if gi != nil {
+		tuo.SetOwnerID(*gi)
+	}
+	return tuo
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (tuo *TenantUpdateOne) ClearOwnerID() *TenantUpdateOne {
+	tuo.mutation.ClearOwnerID()
+	return tuo
+}
+
+// SetSuspendedAt sets the "suspended_at" field.
+func (tuo *TenantUpdateOne) SetSuspendedAt(t time.Time) *TenantUpdateOne {
+	tuo.mutation.SetSuspendedAt(t)
+	return tuo
+}
+
+// SetNillableSuspendedAt sets the "suspended_at" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableSuspendedAt(t *time.Time) *TenantUpdateOne {
+	if t != nil {
+		tuo.SetSuspendedAt(*t)
+	}
+	return tuo
+}
+
+// ClearSuspendedAt clears the value of the "suspended_at" field.
+func (tuo *TenantUpdateOne) ClearSuspendedAt() *TenantUpdateOne {
+	tuo.mutation.ClearSuspendedAt()
+	return tuo
+}
+
+// SetArchived sets the "archived" field.
+func (tuo *TenantUpdateOne) SetArchived(b bool) *TenantUpdateOne {
+	tuo.mutation.SetArchived(b)
+	return tuo
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableArchived(b *bool) *TenantUpdateOne {
+	if b != nil {
+		tuo.SetArchived(*b)
+	}
+	return tuo
+}
+
+// ClearArchived clears the value of the "archived" field.
+func (tuo *TenantUpdateOne) ClearArchived() *TenantUpdateOne {
+	tuo.mutation.ClearArchived()
+	return tuo
+}
+
+// SetFrozen sets the "frozen" field.
+func (tuo *TenantUpdateOne) SetFrozen(b bool) *TenantUpdateOne {
+	tuo.mutation.SetFrozen(b)
+	return tuo
+}
+
+// SetNillableFrozen sets the "frozen" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableFrozen(b *bool) *TenantUpdateOne {
+	if b != nil {
+		tuo.SetFrozen(*b)
+	}
+	return tuo
+}
+
+// ClearFrozen clears the value of the "frozen" field.
+func (tuo *TenantUpdateOne) ClearFrozen() *TenantUpdateOne {
+	tuo.mutation.ClearFrozen()
+	return tuo
+}
+
+// SetCreationFrozenUntil sets the "creation_frozen_until" field.
+func (tuo *TenantUpdateOne) SetCreationFrozenUntil(t time.Time) *TenantUpdateOne {
+	tuo.mutation.SetCreationFrozenUntil(t)
+	return tuo
+}
+
+// SetNillableCreationFrozenUntil sets the "creation_frozen_until" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableCreationFrozenUntil(t *time.Time) *TenantUpdateOne {
+	if t != nil {
+		tuo.SetCreationFrozenUntil(*t)
+	}
+	return tuo
+}
+
+// ClearCreationFrozenUntil clears the value of the "creation_frozen_until" field.
+func (tuo *TenantUpdateOne) ClearCreationFrozenUntil() *TenantUpdateOne {
+	tuo.mutation.ClearCreationFrozenUntil()
+	return tuo
+}
+
+// SetDeletionProtected sets the "deletion_protected" field.
+func (tuo *TenantUpdateOne) SetDeletionProtected(b bool) *TenantUpdateOne {
+	tuo.mutation.SetDeletionProtected(b)
+	return tuo
+}
+
+// SetNillableDeletionProtected sets the "deletion_protected" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableDeletionProtected(b *bool) *TenantUpdateOne {
+	if b != nil {
+		tuo.SetDeletionProtected(*b)
+	}
+	return tuo
+}
+
+// ClearDeletionProtected clears the value of the "deletion_protected" field.
+func (tuo *TenantUpdateOne) ClearDeletionProtected() *TenantUpdateOne {
+	tuo.mutation.ClearDeletionProtected()
+	return tuo
+}
+
+// SetChangeSeq sets the "change_seq" field.
+func (tuo *TenantUpdateOne) SetChangeSeq(i int64) *TenantUpdateOne {
+	tuo.mutation.ResetChangeSeq()
+	tuo.mutation.SetChangeSeq(i)
+	return tuo
+}
+
+// SetNillableChangeSeq sets the "change_seq" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableChangeSeq(i *int64) *TenantUpdateOne {
+	if i != nil {
+		tuo.SetChangeSeq(*i)
+	}
+	return tuo
+}
+
+// AddChangeSeq adds i to the "change_seq" field.
+func (tuo *TenantUpdateOne) AddChangeSeq(i int64) *TenantUpdateOne {
+	tuo.mutation.AddChangeSeq(i)
+	return tuo
+}
+
+// ClearChangeSeq clears the value of the "change_seq" field.
+func (tuo *TenantUpdateOne) ClearChangeSeq() *TenantUpdateOne {
+	tuo.mutation.ClearChangeSeq()
+	return tuo
+}
+
+// SetVersion sets the "version" field.
+func (tuo *TenantUpdateOne) SetVersion(i int64) *TenantUpdateOne {
+	tuo.mutation.ResetVersion()
+	tuo.mutation.SetVersion(i)
+	return tuo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableVersion(i *int64) *TenantUpdateOne {
+	if i != nil {
+		tuo.SetVersion(*i)
 	}
-	return tu.RemoveChildIDs(ids...)
+	return tuo
 }
 
-// Save executes the query and returns the number of nodes affected by the update operation.
-func (tu *TenantUpdate) Save(ctx context.Context) (int, error) {
-	tu.defaults()
-	return withHooks(ctx, tu.sqlSave, tu.mutation, tu.hooks)
+// AddVersion adds i to the "version" field.
+func (tuo *TenantUpdateOne) AddVersion(i int64) *TenantUpdateOne {
+	tuo.mutation.AddVersion(i)
+	return tuo
 }
 
-// SaveX is like Save, but panics if an error occurs.
-func (tu *TenantUpdate) SaveX(ctx context.Context) int {
-	affected, err := tu.Save(ctx)
-	if err != nil {
-		panic(err)
-	}
-	return affected
+// ClearVersion clears the value of the "version" field.
+func (tuo *TenantUpdateOne) ClearVersion() *TenantUpdateOne {
+	tuo.mutation.ClearVersion()
+	return tuo
 }
 
-// Exec executes the query.
-func (tu *TenantUpdate) Exec(ctx context.Context) error {
-	_, err := tu.Save(ctx)
-	return err
+// SetUniqueName sets the "unique_name" field.
+func (tuo *TenantUpdateOne) SetUniqueName(b bool) *TenantUpdateOne {
+	tuo.mutation.SetUniqueName(b)
+	return tuo
 }
 
-// ExecX is like Exec, but panics if an error occurs.
-func (tu *TenantUpdate) ExecX(ctx context.Context) {
-	if err := tu.Exec(ctx); err != nil {
-		panic(err)
+// SetNillableUniqueName sets the "unique_name" field if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableUniqueName(b *bool) *TenantUpdateOne {
+	if b != nil {
+		tuo.SetUniqueName(*b)
 	}
+	return tuo
 }
 
-// defaults sets the default values of the builder before save.
-func (tu *TenantUpdate) defaults() {
-	if _, ok := tu.mutation.UpdatedAt(); !ok {
-		v := tenant.UpdateDefaultUpdatedAt()
-		tu.mutation.SetUpdatedAt(v)
-	}
+// ClearUniqueName clears the value of the "unique_name" field.
+func (tuo *TenantUpdateOne) ClearUniqueName() *TenantUpdateOne {
+	tuo.mutation.ClearUniqueName()
+	return tuo
 }
 
-func (tu *TenantUpdate) sqlSave(ctx context.Context) (n int, err error) {
-	_spec := sqlgraph.NewUpdateSpec(tenant.Table, tenant.Columns, sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString))
-	if ps := tu.mutation.predicates; len(ps) > 0 {
-		_spec.Predicate = func(selector *sql.Selector) {
-			for i := range ps {
-				ps[i](selector)
-			}
-		}
-	}
-	if value, ok := tu.mutation.UpdatedAt(); ok {
-		_spec.SetField(tenant.FieldUpdatedAt, field.TypeTime, value)
-	}
-	if value, ok := tu.mutation.Name(); ok {
-		_spec.SetField(tenant.FieldName, field.TypeString, value)
-	}
-	if value, ok := tu.mutation.Description(); ok {
-		_spec.SetField(tenant.FieldDescription, field.TypeString, value)
-	}
-	if tu.mutation.DescriptionCleared() {
-		_spec.ClearField(tenant.FieldDescription, field.TypeString)
-	}
-	if tu.mutation.ChildrenCleared() {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.O2M,
-			Inverse: false,
-			Table:   tenant.ChildrenTable,
-			Columns: []string{tenant.ChildrenColumn},
-			Bidi:    false,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
-			},
-		}
-		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
-	}
-	if nodes := tu.mutation.RemovedChildrenIDs(); len(nodes) > 0 && !tu.mutation.ChildrenCleared() {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.O2M,
-			Inverse: false,
-			Table:   tenant.ChildrenTable,
-			Columns: []string{tenant.ChildrenColumn},
-			Bidi:    false,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
-			},
-		}
-		for _, k := range nodes {
-			edge.Target.Nodes = append(edge.Target.Nodes, k)
-		}
-		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
-	}
-	if nodes := tu.mutation.ChildrenIDs(); len(nodes) > 0 {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.O2M,
-			Inverse: false,
-			Table:   tenant.ChildrenTable,
-			Columns: []string{tenant.ChildrenColumn},
-			Bidi:    false,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
-			},
-		}
-		for _, k := range nodes {
-			edge.Target.Nodes = append(edge.Target.Nodes, k)
-		}
-		_spec.Edges.Add = append(_spec.Edges.Add, edge)
-	}
-	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{tenant.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
-		}
-		return 0, err
-	}
-	tu.mutation.done = true
-	return n, nil
+// SetSettings sets the "settings" field.
+func (tuo *TenantUpdateOne) SetSettings(m map[string]interface{}) *TenantUpdateOne {
+	tuo.mutation.SetSettings(m)
+	return tuo
 }
 
-// TenantUpdateOne is the builder for updating a single Tenant entity.
-type TenantUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *TenantMutation
+// ClearSettings clears the value of the "settings" field.
+func (tuo *TenantUpdateOne) ClearSettings() *TenantUpdateOne {
+	tuo.mutation.ClearSettings()
+	return tuo
 }
 
-// SetName sets the "name" field.
-func (tuo *TenantUpdateOne) SetName(s string) *TenantUpdateOne {
-	tuo.mutation.SetName(s)
+// SetLabels sets the "labels" field.
+func (tuo *TenantUpdateOne) SetLabels(m map[string]string) *TenantUpdateOne {
+	tuo.mutation.SetLabels(m)
 	return tuo
 }
 
-// SetDescription sets the "description" field.
-func (tuo *TenantUpdateOne) SetDescription(s string) *TenantUpdateOne {
-	tuo.mutation.SetDescription(s)
+// ClearLabels clears the value of the "labels" field.
+func (tuo *TenantUpdateOne) ClearLabels() *TenantUpdateOne {
+	tuo.mutation.ClearLabels()
 	return tuo
 }
 
-// SetNillableDescription sets the "description" field if the given value is not nil.
-func (tuo *TenantUpdateOne) SetNillableDescription(s *string) *TenantUpdateOne {
-	if s != nil {
-		tuo.SetDescription(*s)
-	}
+// SetParentID sets the "parent" edge to the Tenant entity by ID.
+func (tuo *TenantUpdateOne) SetParentID(id gidx.PrefixedID) *TenantUpdateOne {
+	tuo.mutation.SetParentID(id)
 	return tuo
 }
 
-// ClearDescription clears the value of the "description" field.
-func (tuo *TenantUpdateOne) ClearDescription() *TenantUpdateOne {
-	tuo.mutation.ClearDescription()
+// SetNillableParentID sets the "parent" edge to the Tenant entity by ID if the given value is not nil.
+func (tuo *TenantUpdateOne) SetNillableParentID(id *gidx.PrefixedID) *TenantUpdateOne {
+	if id != nil {
+		tuo = tuo.SetParentID(*id)
+	}
 	return tuo
 }
 
+// SetParent sets the "parent" edge to the Tenant entity.
+func (tuo *TenantUpdateOne) SetParent(t *Tenant) *TenantUpdateOne {
+	return tuo.SetParentID(t.ID)
+}
+
 // AddChildIDs adds the "children" edge to the Tenant entity by IDs.
 func (tuo *TenantUpdateOne) AddChildIDs(ids ...gidx.PrefixedID) *TenantUpdateOne {
 	tuo.mutation.AddChildIDs(ids...)
@@ -272,11 +1249,32 @@ func (tuo *TenantUpdateOne) AddChildren(t ...*Tenant) *TenantUpdateOne {
 	return tuo.AddChildIDs(ids...)
 }
 
+// AddServiceAccountIDs adds the "service_accounts" edge to the ServiceAccount entity by IDs.
+func (tuo *TenantUpdateOne) AddServiceAccountIDs(ids ...gidx.PrefixedID) *TenantUpdateOne {
+	tuo.mutation.AddServiceAccountIDs(ids...)
+	return tuo
+}
+
+// AddServiceAccounts adds the "service_accounts" edges to the ServiceAccount entity.
+func (tuo *TenantUpdateOne) AddServiceAccounts(s ...*ServiceAccount) *TenantUpdateOne {
+	ids := make([]gidx.PrefixedID, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return tuo.AddServiceAccountIDs(ids...)
+}
+
 // Mutation returns the TenantMutation object of the builder.
 func (tuo *TenantUpdateOne) Mutation() *TenantMutation {
 	return tuo.mutation
 }
 
+// ClearParent clears the "parent" edge to the Tenant entity.
+func (tuo *TenantUpdateOne) ClearParent() *TenantUpdateOne {
+	tuo.mutation.ClearParent()
+	return tuo
+}
+
 // ClearChildren clears all "children" edges to the Tenant entity.
 func (tuo *TenantUpdateOne) ClearChildren() *TenantUpdateOne {
 	tuo.mutation.ClearChildren()
@@ -298,6 +1296,27 @@ func (tuo *TenantUpdateOne) RemoveChildren(t ...*Tenant) *TenantUpdateOne {
 	return tuo.RemoveChildIDs(ids...)
 }
 
+// ClearServiceAccounts clears all "service_accounts" edges to the ServiceAccount entity.
+func (tuo *TenantUpdateOne) ClearServiceAccounts() *TenantUpdateOne {
+	tuo.mutation.ClearServiceAccounts()
+	return tuo
+}
+
+// RemoveServiceAccountIDs removes the "service_accounts" edge to ServiceAccount entities by IDs.
+func (tuo *TenantUpdateOne) RemoveServiceAccountIDs(ids ...gidx.PrefixedID) *TenantUpdateOne {
+	tuo.mutation.RemoveServiceAccountIDs(ids...)
+	return tuo
+}
+
+// RemoveServiceAccounts removes "service_accounts" edges to ServiceAccount entities.
+func (tuo *TenantUpdateOne) RemoveServiceAccounts(s ...*ServiceAccount) *TenantUpdateOne {
+	ids := make([]gidx.PrefixedID, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return tuo.RemoveServiceAccountIDs(ids...)
+}
+
 // Where appends a list predicates to the TenantUpdate builder.
 func (tuo *TenantUpdateOne) Where(ps ...predicate.Tenant) *TenantUpdateOne {
 	tuo.mutation.Where(ps...)
@@ -347,7 +1366,30 @@ func (tuo *TenantUpdateOne) defaults() {
 	}
 }
 
+// check runs all checks and user-defined validators on the builder.
+func (tuo *TenantUpdateOne) check() error {
+	if v, ok := tuo.mutation.MaxChildren(); ok {
+		if err := tenant.MaxChildrenValidator(v); err != nil {
+			return &ValidationError{Name: "max_children", err: fmt.Errorf(`generated: validator failed for field "Tenant.max_children": %w`, err)}
+		}
+	}
+	if v, ok := tuo.mutation.ChangeSeq(); ok {
+		if err := tenant.ChangeSeqValidator(v); err != nil {
+			return &ValidationError{Name: "change_seq", err: fmt.Errorf(`generated: validator failed for field "Tenant.change_seq": %w`, err)}
+		}
+	}
+	if v, ok := tuo.mutation.Version(); ok {
+		if err := tenant.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`generated: validator failed for field "Tenant.version": %w`, err)}
+		}
+	}
+	return nil
+}
+
 func (tuo *TenantUpdateOne) sqlSave(ctx context.Context) (_node *Tenant, err error) {
+	if err := tuo.check(); err != nil {
+		return _node, err
+	}
 	_spec := sqlgraph.NewUpdateSpec(tenant.Table, tenant.Columns, sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString))
 	id, ok := tuo.mutation.ID()
 	if !ok {
@@ -379,12 +1421,149 @@ func (tuo *TenantUpdateOne) sqlSave(ctx context.Context) (_node *Tenant, err err
 	if value, ok := tuo.mutation.Name(); ok {
 		_spec.SetField(tenant.FieldName, field.TypeString, value)
 	}
+	if value, ok := tuo.mutation.DisplayName(); ok {
+		_spec.SetField(tenant.FieldDisplayName, field.TypeString, value)
+	}
+	if tuo.mutation.DisplayNameCleared() {
+		_spec.ClearField(tenant.FieldDisplayName, field.TypeString)
+	}
 	if value, ok := tuo.mutation.Description(); ok {
 		_spec.SetField(tenant.FieldDescription, field.TypeString, value)
 	}
 	if tuo.mutation.DescriptionCleared() {
 		_spec.ClearField(tenant.FieldDescription, field.TypeString)
 	}
+	if value, ok := tuo.mutation.ContactEmail(); ok {
+		_spec.SetField(tenant.FieldContactEmail, field.TypeString, value)
+	}
+	if tuo.mutation.ContactEmailCleared() {
+		_spec.ClearField(tenant.FieldContactEmail, field.TypeString)
+	}
+	if value, ok := tuo.mutation.BillingReference(); ok {
+		_spec.SetField(tenant.FieldBillingReference, field.TypeString, value)
+	}
+	if tuo.mutation.BillingReferenceCleared() {
+		_spec.ClearField(tenant.FieldBillingReference, field.TypeString)
+	}
+	if value, ok := tuo.mutation.DeletionScheduledAt(); ok {
+		_spec.SetField(tenant.FieldDeletionScheduledAt, field.TypeTime, value)
+	}
+	if tuo.mutation.DeletionScheduledAtCleared() {
+		_spec.ClearField(tenant.FieldDeletionScheduledAt, field.TypeTime)
+	}
+	if value, ok := tuo.mutation.MaxChildren(); ok {
+		_spec.SetField(tenant.FieldMaxChildren, field.TypeInt, value)
+	}
+	if value, ok := tuo.mutation.AddedMaxChildren(); ok {
+		_spec.AddField(tenant.FieldMaxChildren, field.TypeInt, value)
+	}
+	if tuo.mutation.MaxChildrenCleared() {
+		_spec.ClearField(tenant.FieldMaxChildren, field.TypeInt)
+	}
+	if value, ok := tuo.mutation.OwnerID(); ok {
+		_spec.SetField(tenant.FieldOwnerID, field.TypeString, value)
+	}
+	if tuo.mutation.OwnerIDCleared() {
+		_spec.ClearField(tenant.FieldOwnerID, field.TypeString)
+	}
+	if value, ok := tuo.mutation.SuspendedAt(); ok {
+		_spec.SetField(tenant.FieldSuspendedAt, field.TypeTime, value)
+	}
+	if tuo.mutation.SuspendedAtCleared() {
+		_spec.ClearField(tenant.FieldSuspendedAt, field.TypeTime)
+	}
+	if tuo.mutation.ExternalIDCleared() {
+		_spec.ClearField(tenant.FieldExternalID, field.TypeString)
+	}
+	if value, ok := tuo.mutation.Archived(); ok {
+		_spec.SetField(tenant.FieldArchived, field.TypeBool, value)
+	}
+	if tuo.mutation.ArchivedCleared() {
+		_spec.ClearField(tenant.FieldArchived, field.TypeBool)
+	}
+	if value, ok := tuo.mutation.Frozen(); ok {
+		_spec.SetField(tenant.FieldFrozen, field.TypeBool, value)
+	}
+	if tuo.mutation.FrozenCleared() {
+		_spec.ClearField(tenant.FieldFrozen, field.TypeBool)
+	}
+	if value, ok := tuo.mutation.CreationFrozenUntil(); ok {
+		_spec.SetField(tenant.FieldCreationFrozenUntil, field.TypeTime, value)
+	}
+	if tuo.mutation.CreationFrozenUntilCleared() {
+		_spec.ClearField(tenant.FieldCreationFrozenUntil, field.TypeTime)
+	}
+	if value, ok := tuo.mutation.DeletionProtected(); ok {
+		_spec.SetField(tenant.FieldDeletionProtected, field.TypeBool, value)
+	}
+	if tuo.mutation.DeletionProtectedCleared() {
+		_spec.ClearField(tenant.FieldDeletionProtected, field.TypeBool)
+	}
+	if value, ok := tuo.mutation.ChangeSeq(); ok {
+		_spec.SetField(tenant.FieldChangeSeq, field.TypeInt64, value)
+	}
+	if value, ok := tuo.mutation.AddedChangeSeq(); ok {
+		_spec.AddField(tenant.FieldChangeSeq, field.TypeInt64, value)
+	}
+	if tuo.mutation.ChangeSeqCleared() {
+		_spec.ClearField(tenant.FieldChangeSeq, field.TypeInt64)
+	}
+	if value, ok := tuo.mutation.Version(); ok {
+		_spec.SetField(tenant.FieldVersion, field.TypeInt64, value)
+	}
+	if value, ok := tuo.mutation.AddedVersion(); ok {
+		_spec.AddField(tenant.FieldVersion, field.TypeInt64, value)
+	}
+	if tuo.mutation.VersionCleared() {
+		_spec.ClearField(tenant.FieldVersion, field.TypeInt64)
+	}
+	if value, ok := tuo.mutation.UniqueName(); ok {
+		_spec.SetField(tenant.FieldUniqueName, field.TypeBool, value)
+	}
+	if tuo.mutation.UniqueNameCleared() {
+		_spec.ClearField(tenant.FieldUniqueName, field.TypeBool)
+	}
+	if value, ok := tuo.mutation.Settings(); ok {
+		_spec.SetField(tenant.FieldSettings, field.TypeJSON, value)
+	}
+	if tuo.mutation.SettingsCleared() {
+		_spec.ClearField(tenant.FieldSettings, field.TypeJSON)
+	}
+	if value, ok := tuo.mutation.Labels(); ok {
+		_spec.SetField(tenant.FieldLabels, field.TypeJSON, value)
+	}
+	if tuo.mutation.LabelsCleared() {
+		_spec.ClearField(tenant.FieldLabels, field.TypeJSON)
+	}
+	if tuo.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   tenant.ParentTable,
+			Columns: []string{tenant.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   tenant.ParentTable,
+			Columns: []string{tenant.ParentColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tenant.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if tuo.mutation.ChildrenCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -430,6 +1609,51 @@ func (tuo *TenantUpdateOne) sqlSave(ctx context.Context) (_node *Tenant, err err
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if tuo.mutation.ServiceAccountsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RemovedServiceAccountsIDs(); len(nodes) > 0 && !tuo.mutation.ServiceAccountsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.ServiceAccountsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   tenant.ServiceAccountsTable,
+			Columns: []string{tenant.ServiceAccountsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(serviceaccount.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Tenant{config: tuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,191 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/x/gidx"
+)
+
+// A mutation attempted on a tenant.
+type TenantAudit struct {
+	config `json:"-"`
+	// ID of the ent.
+	// ID for the audit entry.
+	ID gidx.PrefixedID `json:"id,omitempty"`
+	// The ID of the tenant the mutation was attempted on.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The subject which attempted the mutation, empty when unknown.
+	Actor string `json:"actor,omitempty"`
+	// The name of the route of the attempted mutation.
+	Operation string `json:"operation,omitempty"`
+	// The request body of the attempted mutation, truncated when large.
+	AttemptedChange string `json:"attempted_change,omitempty"`
+	// The outcome of the attempt: denied, conflicted or confirmed.
+	Outcome string `json:"outcome,omitempty"`
+	// The error code the attempt was rejected with, empty when it had none.
+	Code string `json:"code,omitempty"`
+	// The time of the attempt.
+	RecordedAt   time.Time `json:"recorded_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantAudit) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenantaudit.FieldID, tenantaudit.FieldTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case tenantaudit.FieldActor, tenantaudit.FieldOperation, tenantaudit.FieldAttemptedChange, tenantaudit.FieldOutcome, tenantaudit.FieldCode:
+			values[i] = new(sql.NullString)
+		case tenantaudit.FieldRecordedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantAudit fields.
+func (ta *TenantAudit) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenantaudit.FieldID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				ta.ID = *value
+			}
+		case tenantaudit.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				ta.TenantID = *value
+			}
+		case tenantaudit.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				ta.Actor = value.String
+			}
+		case tenantaudit.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				ta.Operation = value.String
+			}
+		case tenantaudit.FieldAttemptedChange:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field attempted_change", values[i])
+			} else if value.Valid {
+				ta.AttemptedChange = value.String
+			}
+		case tenantaudit.FieldOutcome:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field outcome", values[i])
+			} else if value.Valid {
+				ta.Outcome = value.String
+			}
+		case tenantaudit.FieldCode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field code", values[i])
+			} else if value.Valid {
+				ta.Code = value.String
+			}
+		case tenantaudit.FieldRecordedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field recorded_at", values[i])
+			} else if value.Valid {
+				ta.RecordedAt = value.Time
+			}
+		default:
+			ta.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantAudit.
+// This includes values selected through modifiers, order, etc.
+func (ta *TenantAudit) Value(name string) (ent.Value, error) {
+	return ta.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantAudit.
+// Note that you need to call TenantAudit.Unwrap() before calling this method if this TenantAudit
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ta *TenantAudit) Update() *TenantAuditUpdateOne {
+	return NewTenantAuditClient(ta.config).UpdateOne(ta)
+}
+
+// Unwrap unwraps the TenantAudit entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ta *TenantAudit) Unwrap() *TenantAudit {
+	_tx, ok := ta.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantAudit is not a transactional entity")
+	}
+	ta.config.driver = _tx.drv
+	return ta
+}
+
+// String implements the fmt.Stringer.
+func (ta *TenantAudit) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantAudit(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ta.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", ta.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(ta.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(ta.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("attempted_change=")
+	builder.WriteString(ta.AttemptedChange)
+	builder.WriteString(", ")
+	builder.WriteString("outcome=")
+	builder.WriteString(ta.Outcome)
+	builder.WriteString(", ")
+	builder.WriteString("code=")
+	builder.WriteString(ta.Code)
+	builder.WriteString(", ")
+	builder.WriteString("recorded_at=")
+	builder.WriteString(ta.RecordedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (ta TenantAudit) IsEntity() {}
+
+// TenantAudits is a parsable slice of TenantAudit.
+type TenantAudits []*TenantAudit
@@ -0,0 +1,602 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenantaudit
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/x/gidx"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Actor applies equality check predicate on the "actor" field. It's identical to ActorEQ.
+func Actor(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldActor, v))
+}
+
+// Operation applies equality check predicate on the "operation" field. It's identical to OperationEQ.
+func Operation(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldOperation, v))
+}
+
+// AttemptedChange applies equality check predicate on the "attempted_change" field. It's identical to AttemptedChangeEQ.
+func AttemptedChange(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldAttemptedChange, v))
+}
+
+// Outcome applies equality check predicate on the "outcome" field. It's identical to OutcomeEQ.
+func Outcome(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldOutcome, v))
+}
+
+// Code applies equality check predicate on the "code" field. It's identical to CodeEQ.
+func Code(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldCode, v))
+}
+
+// RecordedAt applies equality check predicate on the "recorded_at" field. It's identical to RecordedAtEQ.
+func RecordedAt(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldRecordedAt, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v gidx.PrefixedID) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenant_id" field.
+func TenantIDContains(v gidx.PrefixedID) predicate.TenantAudit {
+	vc := string(v)
+	return predicate.TenantAudit(sql.FieldContains(FieldTenantID, vc))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenant_id" field.
+func TenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantAudit {
+	vc := string(v)
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldTenantID, vc))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenant_id" field.
+func TenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantAudit {
+	vc := string(v)
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldTenantID, vc))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenant_id" field.
+func TenantIDEqualFold(v gidx.PrefixedID) predicate.TenantAudit {
+	vc := string(v)
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldTenantID, vc))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenant_id" field.
+func TenantIDContainsFold(v gidx.PrefixedID) predicate.TenantAudit {
+	vc := string(v)
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldTenantID, vc))
+}
+
+// ActorEQ applies the EQ predicate on the "actor" field.
+func ActorEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldActor, v))
+}
+
+// ActorNEQ applies the NEQ predicate on the "actor" field.
+func ActorNEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldActor, v))
+}
+
+// ActorIn applies the In predicate on the "actor" field.
+func ActorIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldActor, vs...))
+}
+
+// ActorNotIn applies the NotIn predicate on the "actor" field.
+func ActorNotIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldActor, vs...))
+}
+
+// ActorGT applies the GT predicate on the "actor" field.
+func ActorGT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldActor, v))
+}
+
+// ActorGTE applies the GTE predicate on the "actor" field.
+func ActorGTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldActor, v))
+}
+
+// ActorLT applies the LT predicate on the "actor" field.
+func ActorLT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldActor, v))
+}
+
+// ActorLTE applies the LTE predicate on the "actor" field.
+func ActorLTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldActor, v))
+}
+
+// ActorContains applies the Contains predicate on the "actor" field.
+func ActorContains(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContains(FieldActor, v))
+}
+
+// ActorHasPrefix applies the HasPrefix predicate on the "actor" field.
+func ActorHasPrefix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldActor, v))
+}
+
+// ActorHasSuffix applies the HasSuffix predicate on the "actor" field.
+func ActorHasSuffix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldActor, v))
+}
+
+// ActorIsNil applies the IsNil predicate on the "actor" field.
+func ActorIsNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIsNull(FieldActor))
+}
+
+// ActorNotNil applies the NotNil predicate on the "actor" field.
+func ActorNotNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotNull(FieldActor))
+}
+
+// ActorEqualFold applies the EqualFold predicate on the "actor" field.
+func ActorEqualFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldActor, v))
+}
+
+// ActorContainsFold applies the ContainsFold predicate on the "actor" field.
+func ActorContainsFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldActor, v))
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldOperation, v))
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldOperation, v))
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldOperation, vs...))
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldOperation, vs...))
+}
+
+// OperationGT applies the GT predicate on the "operation" field.
+func OperationGT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldOperation, v))
+}
+
+// OperationGTE applies the GTE predicate on the "operation" field.
+func OperationGTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldOperation, v))
+}
+
+// OperationLT applies the LT predicate on the "operation" field.
+func OperationLT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldOperation, v))
+}
+
+// OperationLTE applies the LTE predicate on the "operation" field.
+func OperationLTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldOperation, v))
+}
+
+// OperationContains applies the Contains predicate on the "operation" field.
+func OperationContains(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContains(FieldOperation, v))
+}
+
+// OperationHasPrefix applies the HasPrefix predicate on the "operation" field.
+func OperationHasPrefix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldOperation, v))
+}
+
+// OperationHasSuffix applies the HasSuffix predicate on the "operation" field.
+func OperationHasSuffix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldOperation, v))
+}
+
+// OperationEqualFold applies the EqualFold predicate on the "operation" field.
+func OperationEqualFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldOperation, v))
+}
+
+// OperationContainsFold applies the ContainsFold predicate on the "operation" field.
+func OperationContainsFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldOperation, v))
+}
+
+// AttemptedChangeEQ applies the EQ predicate on the "attempted_change" field.
+func AttemptedChangeEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeNEQ applies the NEQ predicate on the "attempted_change" field.
+func AttemptedChangeNEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeIn applies the In predicate on the "attempted_change" field.
+func AttemptedChangeIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldAttemptedChange, vs...))
+}
+
+// AttemptedChangeNotIn applies the NotIn predicate on the "attempted_change" field.
+func AttemptedChangeNotIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldAttemptedChange, vs...))
+}
+
+// AttemptedChangeGT applies the GT predicate on the "attempted_change" field.
+func AttemptedChangeGT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeGTE applies the GTE predicate on the "attempted_change" field.
+func AttemptedChangeGTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeLT applies the LT predicate on the "attempted_change" field.
+func AttemptedChangeLT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeLTE applies the LTE predicate on the "attempted_change" field.
+func AttemptedChangeLTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeContains applies the Contains predicate on the "attempted_change" field.
+func AttemptedChangeContains(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContains(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeHasPrefix applies the HasPrefix predicate on the "attempted_change" field.
+func AttemptedChangeHasPrefix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeHasSuffix applies the HasSuffix predicate on the "attempted_change" field.
+func AttemptedChangeHasSuffix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeIsNil applies the IsNil predicate on the "attempted_change" field.
+func AttemptedChangeIsNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIsNull(FieldAttemptedChange))
+}
+
+// AttemptedChangeNotNil applies the NotNil predicate on the "attempted_change" field.
+func AttemptedChangeNotNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotNull(FieldAttemptedChange))
+}
+
+// AttemptedChangeEqualFold applies the EqualFold predicate on the "attempted_change" field.
+func AttemptedChangeEqualFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldAttemptedChange, v))
+}
+
+// AttemptedChangeContainsFold applies the ContainsFold predicate on the "attempted_change" field.
+func AttemptedChangeContainsFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldAttemptedChange, v))
+}
+
+// OutcomeEQ applies the EQ predicate on the "outcome" field.
+func OutcomeEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldOutcome, v))
+}
+
+// OutcomeNEQ applies the NEQ predicate on the "outcome" field.
+func OutcomeNEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldOutcome, v))
+}
+
+// OutcomeIn applies the In predicate on the "outcome" field.
+func OutcomeIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldOutcome, vs...))
+}
+
+// OutcomeNotIn applies the NotIn predicate on the "outcome" field.
+func OutcomeNotIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldOutcome, vs...))
+}
+
+// OutcomeGT applies the GT predicate on the "outcome" field.
+func OutcomeGT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldOutcome, v))
+}
+
+// OutcomeGTE applies the GTE predicate on the "outcome" field.
+func OutcomeGTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldOutcome, v))
+}
+
+// OutcomeLT applies the LT predicate on the "outcome" field.
+func OutcomeLT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldOutcome, v))
+}
+
+// OutcomeLTE applies the LTE predicate on the "outcome" field.
+func OutcomeLTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldOutcome, v))
+}
+
+// OutcomeContains applies the Contains predicate on the "outcome" field.
+func OutcomeContains(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContains(FieldOutcome, v))
+}
+
+// OutcomeHasPrefix applies the HasPrefix predicate on the "outcome" field.
+func OutcomeHasPrefix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldOutcome, v))
+}
+
+// OutcomeHasSuffix applies the HasSuffix predicate on the "outcome" field.
+func OutcomeHasSuffix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldOutcome, v))
+}
+
+// OutcomeEqualFold applies the EqualFold predicate on the "outcome" field.
+func OutcomeEqualFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldOutcome, v))
+}
+
+// OutcomeContainsFold applies the ContainsFold predicate on the "outcome" field.
+func OutcomeContainsFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldOutcome, v))
+}
+
+// CodeEQ applies the EQ predicate on the "code" field.
+func CodeEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldCode, v))
+}
+
+// CodeNEQ applies the NEQ predicate on the "code" field.
+func CodeNEQ(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldCode, v))
+}
+
+// CodeIn applies the In predicate on the "code" field.
+func CodeIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldCode, vs...))
+}
+
+// CodeNotIn applies the NotIn predicate on the "code" field.
+func CodeNotIn(vs ...string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldCode, vs...))
+}
+
+// CodeGT applies the GT predicate on the "code" field.
+func CodeGT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldCode, v))
+}
+
+// CodeGTE applies the GTE predicate on the "code" field.
+func CodeGTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldCode, v))
+}
+
+// CodeLT applies the LT predicate on the "code" field.
+func CodeLT(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldCode, v))
+}
+
+// CodeLTE applies the LTE predicate on the "code" field.
+func CodeLTE(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldCode, v))
+}
+
+// CodeContains applies the Contains predicate on the "code" field.
+func CodeContains(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContains(FieldCode, v))
+}
+
+// CodeHasPrefix applies the HasPrefix predicate on the "code" field.
+func CodeHasPrefix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasPrefix(FieldCode, v))
+}
+
+// CodeHasSuffix applies the HasSuffix predicate on the "code" field.
+func CodeHasSuffix(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldHasSuffix(FieldCode, v))
+}
+
+// CodeIsNil applies the IsNil predicate on the "code" field.
+func CodeIsNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIsNull(FieldCode))
+}
+
+// CodeNotNil applies the NotNil predicate on the "code" field.
+func CodeNotNil() predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotNull(FieldCode))
+}
+
+// CodeEqualFold applies the EqualFold predicate on the "code" field.
+func CodeEqualFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEqualFold(FieldCode, v))
+}
+
+// CodeContainsFold applies the ContainsFold predicate on the "code" field.
+func CodeContainsFold(v string) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldContainsFold(FieldCode, v))
+}
+
+// RecordedAtEQ applies the EQ predicate on the "recorded_at" field.
+func RecordedAtEQ(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldEQ(FieldRecordedAt, v))
+}
+
+// RecordedAtNEQ applies the NEQ predicate on the "recorded_at" field.
+func RecordedAtNEQ(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNEQ(FieldRecordedAt, v))
+}
+
+// RecordedAtIn applies the In predicate on the "recorded_at" field.
+func RecordedAtIn(vs ...time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldIn(FieldRecordedAt, vs...))
+}
+
+// RecordedAtNotIn applies the NotIn predicate on the "recorded_at" field.
+func RecordedAtNotIn(vs ...time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldNotIn(FieldRecordedAt, vs...))
+}
+
+// RecordedAtGT applies the GT predicate on the "recorded_at" field.
+func RecordedAtGT(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGT(FieldRecordedAt, v))
+}
+
+// RecordedAtGTE applies the GTE predicate on the "recorded_at" field.
+func RecordedAtGTE(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldGTE(FieldRecordedAt, v))
+}
+
+// RecordedAtLT applies the LT predicate on the "recorded_at" field.
+func RecordedAtLT(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLT(FieldRecordedAt, v))
+}
+
+// RecordedAtLTE applies the LTE predicate on the "recorded_at" field.
+func RecordedAtLTE(v time.Time) predicate.TenantAudit {
+	return predicate.TenantAudit(sql.FieldLTE(FieldRecordedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TenantAudit) predicate.TenantAudit {
+	return predicate.TenantAudit(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TenantAudit) predicate.TenantAudit {
+	return predicate.TenantAudit(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TenantAudit) predicate.TenantAudit {
+	return predicate.TenantAudit(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantAuditCreate is the builder for creating a TenantAudit entity.
+type TenantAuditCreate struct {
+	config
+	mutation *TenantAuditMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (tac *TenantAuditCreate) SetTenantID(gi gidx.PrefixedID) *TenantAuditCreate {
+	tac.mutation.SetTenantID(gi)
+	return tac
+}
+
+// SetActor sets the "actor" field.
+func (tac *TenantAuditCreate) SetActor(s string) *TenantAuditCreate {
+	tac.mutation.SetActor(s)
+	return tac
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (tac *TenantAuditCreate) SetNillableActor(s *string) *TenantAuditCreate {
+	if s != nil {
+		tac.SetActor(*s)
+	}
+	return tac
+}
+
+// SetOperation sets the "operation" field.
+func (tac *TenantAuditCreate) SetOperation(s string) *TenantAuditCreate {
+	tac.mutation.SetOperation(s)
+	return tac
+}
+
+// SetAttemptedChange sets the "attempted_change" field.
+func (tac *TenantAuditCreate) SetAttemptedChange(s string) *TenantAuditCreate {
+	tac.mutation.SetAttemptedChange(s)
+	return tac
+}
+
+// SetNillableAttemptedChange sets the "attempted_change" field if the given value is not nil.
+func (tac *TenantAuditCreate) SetNillableAttemptedChange(s *string) *TenantAuditCreate {
+	if s != nil {
+		tac.SetAttemptedChange(*s)
+	}
+	return tac
+}
+
+// SetOutcome sets the "outcome" field.
+func (tac *TenantAuditCreate) SetOutcome(s string) *TenantAuditCreate {
+	tac.mutation.SetOutcome(s)
+	return tac
+}
+
+// SetCode sets the "code" field.
+func (tac *TenantAuditCreate) SetCode(s string) *TenantAuditCreate {
+	tac.mutation.SetCode(s)
+	return tac
+}
+
+// SetNillableCode sets the "code" field if the given value is not nil.
+func (tac *TenantAuditCreate) SetNillableCode(s *string) *TenantAuditCreate {
+	if s != nil {
+		tac.SetCode(*s)
+	}
+	return tac
+}
+
+// SetRecordedAt sets the "recorded_at" field.
+func (tac *TenantAuditCreate) SetRecordedAt(t time.Time) *TenantAuditCreate {
+	tac.mutation.SetRecordedAt(t)
+	return tac
+}
+
+// SetID sets the "id" field.
+func (tac *TenantAuditCreate) SetID(gi gidx.PrefixedID) *TenantAuditCreate {
+	tac.mutation.SetID(gi)
+	return tac
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (tac *TenantAuditCreate) SetNillableID(gi *gidx.PrefixedID) *TenantAuditCreate {
+	if gi != nil {
+		tac.SetID(*gi)
+	}
+	return tac
+}
+
+// Mutation returns the TenantAuditMutation object of the builder.
+func (tac *TenantAuditCreate) Mutation() *TenantAuditMutation {
+	return tac.mutation
+}
+
+// Save creates the TenantAudit in the database.
+func (tac *TenantAuditCreate) Save(ctx context.Context) (*TenantAudit, error) {
+	tac.defaults()
+	return withHooks(ctx, tac.sqlSave, tac.mutation, tac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tac *TenantAuditCreate) SaveX(ctx context.Context) *TenantAudit {
+	v, err := tac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tac *TenantAuditCreate) Exec(ctx context.Context) error {
+	_, err := tac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tac *TenantAuditCreate) ExecX(ctx context.Context) {
+	if err := tac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tac *TenantAuditCreate) defaults() {
+	if _, ok := tac.mutation.ID(); !ok {
+		v := tenantaudit.DefaultID()
+		tac.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tac *TenantAuditCreate) check() error {
+	if _, ok := tac.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "TenantAudit.tenant_id"`)}
+	}
+	if _, ok := tac.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`generated: missing required field "TenantAudit.operation"`)}
+	}
+	if _, ok := tac.mutation.Outcome(); !ok {
+		return &ValidationError{Name: "outcome", err: errors.New(`generated: missing required field "TenantAudit.outcome"`)}
+	}
+	if _, ok := tac.mutation.RecordedAt(); !ok {
+		return &ValidationError{Name: "recorded_at", err: errors.New(`generated: missing required field "TenantAudit.recorded_at"`)}
+	}
+	return nil
+}
+
+func (tac *TenantAuditCreate) sqlSave(ctx context.Context) (*TenantAudit, error) {
+	if err := tac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*gidx.PrefixedID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	tac.mutation.id = &_node.ID
+	tac.mutation.done = true
+	return _node, nil
+}
+
+func (tac *TenantAuditCreate) createSpec() (*TenantAudit, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantAudit{config: tac.config}
+		_spec = sqlgraph.NewCreateSpec(tenantaudit.Table, sqlgraph.NewFieldSpec(tenantaudit.FieldID, field.TypeString))
+	)
+	if id, ok := tac.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := tac.mutation.TenantID(); ok {
+		_spec.SetField(tenantaudit.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := tac.mutation.Actor(); ok {
+		_spec.SetField(tenantaudit.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := tac.mutation.Operation(); ok {
+		_spec.SetField(tenantaudit.FieldOperation, field.TypeString, value)
+		_node.Operation = value
+	}
+	if value, ok := tac.mutation.AttemptedChange(); ok {
+		_spec.SetField(tenantaudit.FieldAttemptedChange, field.TypeString, value)
+		_node.AttemptedChange = value
+	}
+	if value, ok := tac.mutation.Outcome(); ok {
+		_spec.SetField(tenantaudit.FieldOutcome, field.TypeString, value)
+		_node.Outcome = value
+	}
+	if value, ok := tac.mutation.Code(); ok {
+		_spec.SetField(tenantaudit.FieldCode, field.TypeString, value)
+		_node.Code = value
+	}
+	if value, ok := tac.mutation.RecordedAt(); ok {
+		_spec.SetField(tenantaudit.FieldRecordedAt, field.TypeTime, value)
+		_node.RecordedAt = value
+	}
+	return _node, _spec
+}
+
+// TenantAuditCreateBulk is the builder for creating many TenantAudit entities in bulk.
+type TenantAuditCreateBulk struct {
+	config
+	builders []*TenantAuditCreate
+}
+
+// Save creates the TenantAudit entities in the database.
+func (tacb *TenantAuditCreateBulk) Save(ctx context.Context) ([]*TenantAudit, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tacb.builders))
+	nodes := make([]*TenantAudit, len(tacb.builders))
+	mutators := make([]Mutator, len(tacb.builders))
+	for i := range tacb.builders {
+		func(i int, root context.Context) {
+			builder := tacb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantAuditMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tacb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tacb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tacb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tacb *TenantAuditCreateBulk) SaveX(ctx context.Context) []*TenantAudit {
+	v, err := tacb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tacb *TenantAuditCreateBulk) Exec(ctx context.Context) error {
+	_, err := tacb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tacb *TenantAuditCreateBulk) ExecX(ctx context.Context) {
+	if err := tacb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+)
+
+// TenantAuditDelete is the builder for deleting a TenantAudit entity.
+type TenantAuditDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantAuditMutation
+}
+
+// Where appends a list predicates to the TenantAuditDelete builder.
+func (tad *TenantAuditDelete) Where(ps ...predicate.TenantAudit) *TenantAuditDelete {
+	tad.mutation.Where(ps...)
+	return tad
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (tad *TenantAuditDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, tad.sqlExec, tad.mutation, tad.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tad *TenantAuditDelete) ExecX(ctx context.Context) int {
+	n, err := tad.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (tad *TenantAuditDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenantaudit.Table, sqlgraph.NewFieldSpec(tenantaudit.FieldID, field.TypeString))
+	if ps := tad.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, tad.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	tad.mutation.done = true
+	return affected, err
+}
+
+// TenantAuditDeleteOne is the builder for deleting a single TenantAudit entity.
+type TenantAuditDeleteOne struct {
+	tad *TenantAuditDelete
+}
+
+// Where appends a list predicates to the TenantAuditDelete builder.
+func (tado *TenantAuditDeleteOne) Where(ps ...predicate.TenantAudit) *TenantAuditDeleteOne {
+	tado.tad.mutation.Where(ps...)
+	return tado
+}
+
+// Exec executes the deletion query.
+func (tado *TenantAuditDeleteOne) Exec(ctx context.Context) error {
+	n, err := tado.tad.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenantaudit.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tado *TenantAuditDeleteOne) ExecX(ctx context.Context) {
+	if err := tado.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,554 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantAuditQuery is the builder for querying TenantAudit entities.
+type TenantAuditQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenantaudit.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantAudit
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantAudit) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantAuditQuery builder.
+func (taq *TenantAuditQuery) Where(ps ...predicate.TenantAudit) *TenantAuditQuery {
+	taq.predicates = append(taq.predicates, ps...)
+	return taq
+}
+
+// Limit the number of records to be returned by this query.
+func (taq *TenantAuditQuery) Limit(limit int) *TenantAuditQuery {
+	taq.ctx.Limit = &limit
+	return taq
+}
+
+// Offset to start from.
+func (taq *TenantAuditQuery) Offset(offset int) *TenantAuditQuery {
+	taq.ctx.Offset = &offset
+	return taq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (taq *TenantAuditQuery) Unique(unique bool) *TenantAuditQuery {
+	taq.ctx.Unique = &unique
+	return taq
+}
+
+// Order specifies how the records should be ordered.
+func (taq *TenantAuditQuery) Order(o ...tenantaudit.OrderOption) *TenantAuditQuery {
+	taq.order = append(taq.order, o...)
+	return taq
+}
+
+// First returns the first TenantAudit entity from the query.
+// Returns a *NotFoundError when no TenantAudit was found.
+func (taq *TenantAuditQuery) First(ctx context.Context) (*TenantAudit, error) {
+	nodes, err := taq.Limit(1).All(setContextOp(ctx, taq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenantaudit.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (taq *TenantAuditQuery) FirstX(ctx context.Context) *TenantAudit {
+	node, err := taq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantAudit ID from the query.
+// Returns a *NotFoundError when no TenantAudit ID was found.
+func (taq *TenantAuditQuery) FirstID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = taq.Limit(1).IDs(setContextOp(ctx, taq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenantaudit.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (taq *TenantAuditQuery) FirstIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := taq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantAudit entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantAudit entity is found.
+// Returns a *NotFoundError when no TenantAudit entities are found.
+func (taq *TenantAuditQuery) Only(ctx context.Context) (*TenantAudit, error) {
+	nodes, err := taq.Limit(2).All(setContextOp(ctx, taq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenantaudit.Label}
+	default:
+		return nil, &NotSingularError{tenantaudit.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (taq *TenantAuditQuery) OnlyX(ctx context.Context) *TenantAudit {
+	node, err := taq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantAudit ID in the query.
+// Returns a *NotSingularError when more than one TenantAudit ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (taq *TenantAuditQuery) OnlyID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = taq.Limit(2).IDs(setContextOp(ctx, taq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenantaudit.Label}
+	default:
+		err = &NotSingularError{tenantaudit.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (taq *TenantAuditQuery) OnlyIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := taq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantAudits.
+func (taq *TenantAuditQuery) All(ctx context.Context) ([]*TenantAudit, error) {
+	ctx = setContextOp(ctx, taq.ctx, "All")
+	if err := taq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantAudit, *TenantAuditQuery]()
+	return withInterceptors[[]*TenantAudit](ctx, taq, qr, taq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (taq *TenantAuditQuery) AllX(ctx context.Context) []*TenantAudit {
+	nodes, err := taq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantAudit IDs.
+func (taq *TenantAuditQuery) IDs(ctx context.Context) (ids []gidx.PrefixedID, err error) {
+	if taq.ctx.Unique == nil && taq.path != nil {
+		taq.Unique(true)
+	}
+	ctx = setContextOp(ctx, taq.ctx, "IDs")
+	if err = taq.Select(tenantaudit.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (taq *TenantAuditQuery) IDsX(ctx context.Context) []gidx.PrefixedID {
+	ids, err := taq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (taq *TenantAuditQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, taq.ctx, "Count")
+	if err := taq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, taq, querierCount[*TenantAuditQuery](), taq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (taq *TenantAuditQuery) CountX(ctx context.Context) int {
+	count, err := taq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (taq *TenantAuditQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, taq.ctx, "Exist")
+	switch _, err := taq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (taq *TenantAuditQuery) ExistX(ctx context.Context) bool {
+	exist, err := taq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantAuditQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (taq *TenantAuditQuery) Clone() *TenantAuditQuery {
+	if taq == nil {
+		return nil
+	}
+	return &TenantAuditQuery{
+		config:     taq.config,
+		ctx:        taq.ctx.Clone(),
+		order:      append([]tenantaudit.OrderOption{}, taq.order...),
+		inters:     append([]Interceptor{}, taq.inters...),
+		predicates: append([]predicate.TenantAudit{}, taq.predicates...),
+		// clone intermediate query.
+		sql:  taq.sql.Clone(),
+		path: taq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantAudit.Query().
+//		GroupBy(tenantaudit.FieldTenantID).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (taq *TenantAuditQuery) GroupBy(field string, fields ...string) *TenantAuditGroupBy {
+	taq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantAuditGroupBy{build: taq}
+	grbuild.flds = &taq.ctx.Fields
+	grbuild.label = tenantaudit.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//	}
+//
+//	client.TenantAudit.Query().
+//		Select(tenantaudit.FieldTenantID).
+//		Scan(ctx, &v)
+func (taq *TenantAuditQuery) Select(fields ...string) *TenantAuditSelect {
+	taq.ctx.Fields = append(taq.ctx.Fields, fields...)
+	sbuild := &TenantAuditSelect{TenantAuditQuery: taq}
+	sbuild.label = tenantaudit.Label
+	sbuild.flds, sbuild.scan = &taq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantAuditSelect configured with the given aggregations.
+func (taq *TenantAuditQuery) Aggregate(fns ...AggregateFunc) *TenantAuditSelect {
+	return taq.Select().Aggregate(fns...)
+}
+
+func (taq *TenantAuditQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range taq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, taq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range taq.ctx.Fields {
+		if !tenantaudit.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if taq.path != nil {
+		prev, err := taq.path(ctx)
+		if err != nil {
+			return err
+		}
+		taq.sql = prev
+	}
+	return nil
+}
+
+func (taq *TenantAuditQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantAudit, error) {
+	var (
+		nodes = []*TenantAudit{}
+		_spec = taq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantAudit).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantAudit{config: taq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(taq.modifiers) > 0 {
+		_spec.Modifiers = taq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, taq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range taq.loadTotal {
+		if err := taq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (taq *TenantAuditQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := taq.querySpec()
+	if len(taq.modifiers) > 0 {
+		_spec.Modifiers = taq.modifiers
+	}
+	_spec.Node.Columns = taq.ctx.Fields
+	if len(taq.ctx.Fields) > 0 {
+		_spec.Unique = taq.ctx.Unique != nil && *taq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, taq.driver, _spec)
+}
+
+func (taq *TenantAuditQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenantaudit.Table, tenantaudit.Columns, sqlgraph.NewFieldSpec(tenantaudit.FieldID, field.TypeString))
+	_spec.From = taq.sql
+	if unique := taq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if taq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := taq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantaudit.FieldID)
+		for i := range fields {
+			if fields[i] != tenantaudit.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := taq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := taq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := taq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := taq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (taq *TenantAuditQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(taq.driver.Dialect())
+	t1 := builder.Table(tenantaudit.Table)
+	columns := taq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenantaudit.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if taq.sql != nil {
+		selector = taq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if taq.ctx.Unique != nil && *taq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range taq.predicates {
+		p(selector)
+	}
+	for _, p := range taq.order {
+		p(selector)
+	}
+	if offset := taq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := taq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantAuditGroupBy is the group-by builder for TenantAudit entities.
+type TenantAuditGroupBy struct {
+	selector
+	build *TenantAuditQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (tagb *TenantAuditGroupBy) Aggregate(fns ...AggregateFunc) *TenantAuditGroupBy {
+	tagb.fns = append(tagb.fns, fns...)
+	return tagb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tagb *TenantAuditGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tagb.build.ctx, "GroupBy")
+	if err := tagb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantAuditQuery, *TenantAuditGroupBy](ctx, tagb.build, tagb, tagb.build.inters, v)
+}
+
+func (tagb *TenantAuditGroupBy) sqlScan(ctx context.Context, root *TenantAuditQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(tagb.fns))
+	for _, fn := range tagb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*tagb.flds)+len(tagb.fns))
+		for _, f := range *tagb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*tagb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tagb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantAuditSelect is the builder for selecting fields of TenantAudit entities.
+type TenantAuditSelect struct {
+	*TenantAuditQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tas *TenantAuditSelect) Aggregate(fns ...AggregateFunc) *TenantAuditSelect {
+	tas.fns = append(tas.fns, fns...)
+	return tas
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tas *TenantAuditSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tas.ctx, "Select")
+	if err := tas.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantAuditQuery, *TenantAuditSelect](ctx, tas.TenantAuditQuery, tas, tas.inters, v)
+}
+
+func (tas *TenantAuditSelect) sqlScan(ctx context.Context, root *TenantAuditQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tas.fns))
+	for _, fn := range tas.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tas.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tas.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
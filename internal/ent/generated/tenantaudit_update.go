@@ -0,0 +1,207 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+)
+
+// TenantAuditUpdate is the builder for updating TenantAudit entities.
+type TenantAuditUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantAuditMutation
+}
+
+// Where appends a list predicates to the TenantAuditUpdate builder.
+func (tau *TenantAuditUpdate) Where(ps ...predicate.TenantAudit) *TenantAuditUpdate {
+	tau.mutation.Where(ps...)
+	return tau
+}
+
+// Mutation returns the TenantAuditMutation object of the builder.
+func (tau *TenantAuditUpdate) Mutation() *TenantAuditMutation {
+	return tau.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tau *TenantAuditUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, tau.sqlSave, tau.mutation, tau.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tau *TenantAuditUpdate) SaveX(ctx context.Context) int {
+	affected, err := tau.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tau *TenantAuditUpdate) Exec(ctx context.Context) error {
+	_, err := tau.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tau *TenantAuditUpdate) ExecX(ctx context.Context) {
+	if err := tau.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tau *TenantAuditUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantaudit.Table, tenantaudit.Columns, sqlgraph.NewFieldSpec(tenantaudit.FieldID, field.TypeString))
+	if ps := tau.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tau.mutation.ActorCleared() {
+		_spec.ClearField(tenantaudit.FieldActor, field.TypeString)
+	}
+	if tau.mutation.AttemptedChangeCleared() {
+		_spec.ClearField(tenantaudit.FieldAttemptedChange, field.TypeString)
+	}
+	if tau.mutation.CodeCleared() {
+		_spec.ClearField(tenantaudit.FieldCode, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tau.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantaudit.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tau.mutation.done = true
+	return n, nil
+}
+
+// TenantAuditUpdateOne is the builder for updating a single TenantAudit entity.
+type TenantAuditUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantAuditMutation
+}
+
+// Mutation returns the TenantAuditMutation object of the builder.
+func (tauo *TenantAuditUpdateOne) Mutation() *TenantAuditMutation {
+	return tauo.mutation
+}
+
+// Where appends a list predicates to the TenantAuditUpdate builder.
+func (tauo *TenantAuditUpdateOne) Where(ps ...predicate.TenantAudit) *TenantAuditUpdateOne {
+	tauo.mutation.Where(ps...)
+	return tauo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tauo *TenantAuditUpdateOne) Select(field string, fields ...string) *TenantAuditUpdateOne {
+	tauo.fields = append([]string{field}, fields...)
+	return tauo
+}
+
+// Save executes the query and returns the updated TenantAudit entity.
+func (tauo *TenantAuditUpdateOne) Save(ctx context.Context) (*TenantAudit, error) {
+	return withHooks(ctx, tauo.sqlSave, tauo.mutation, tauo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tauo *TenantAuditUpdateOne) SaveX(ctx context.Context) *TenantAudit {
+	node, err := tauo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tauo *TenantAuditUpdateOne) Exec(ctx context.Context) error {
+	_, err := tauo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tauo *TenantAuditUpdateOne) ExecX(ctx context.Context) {
+	if err := tauo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tauo *TenantAuditUpdateOne) sqlSave(ctx context.Context) (_node *TenantAudit, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantaudit.Table, tenantaudit.Columns, sqlgraph.NewFieldSpec(tenantaudit.FieldID, field.TypeString))
+	id, ok := tauo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantAudit.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tauo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantaudit.FieldID)
+		for _, f := range fields {
+			if !tenantaudit.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenantaudit.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tauo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tauo.mutation.ActorCleared() {
+		_spec.ClearField(tenantaudit.FieldActor, field.TypeString)
+	}
+	if tauo.mutation.AttemptedChangeCleared() {
+		_spec.ClearField(tenantaudit.FieldAttemptedChange, field.TypeString)
+	}
+	if tauo.mutation.CodeCleared() {
+		_spec.ClearField(tenantaudit.FieldCode, field.TypeString)
+	}
+	_node = &TenantAudit{config: tauo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tauo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantaudit.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tauo.mutation.done = true
+	return _node, nil
+}
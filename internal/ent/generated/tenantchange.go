@@ -0,0 +1,171 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/x/gidx"
+)
+
+// A change made to a tenant.
+type TenantChange struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The sequence of the change.
+	ID int64 `json:"id,omitempty"`
+	// The ID of the changed tenant.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The operation which changed the tenant: create, update or delete.
+	Operation string `json:"operation,omitempty"`
+	// The time of the change.
+	ChangedAt time.Time `json:"changed_at,omitempty"`
+	// The name of the deleted tenant, only set for deletions.
+	Name string `json:"name,omitempty"`
+	// The ID of the parent of the deleted tenant, only set for deletions of child tenants.
+	ParentTenantID gidx.PrefixedID `json:"parent_tenant_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantChange) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenantchange.FieldTenantID, tenantchange.FieldParentTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case tenantchange.FieldID:
+			values[i] = new(sql.NullInt64)
+		case tenantchange.FieldOperation, tenantchange.FieldName:
+			values[i] = new(sql.NullString)
+		case tenantchange.FieldChangedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantChange fields.
+func (tc *TenantChange) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenantchange.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			tc.ID = int64(value.Int64)
+		case tenantchange.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				tc.TenantID = *value
+			}
+		case tenantchange.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				tc.Operation = value.String
+			}
+		case tenantchange.FieldChangedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field changed_at", values[i])
+			} else if value.Valid {
+				tc.ChangedAt = value.Time
+			}
+		case tenantchange.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				tc.Name = value.String
+			}
+		case tenantchange.FieldParentTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field parent_tenant_id", values[i])
+			} else if value != nil {
+				tc.ParentTenantID = *value
+			}
+		default:
+			tc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantChange.
+// This includes values selected through modifiers, order, etc.
+func (tc *TenantChange) Value(name string) (ent.Value, error) {
+	return tc.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantChange.
+// Note that you need to call TenantChange.Unwrap() before calling this method if this TenantChange
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tc *TenantChange) Update() *TenantChangeUpdateOne {
+	return NewTenantChangeClient(tc.config).UpdateOne(tc)
+}
+
+// Unwrap unwraps the TenantChange entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tc *TenantChange) Unwrap() *TenantChange {
+	_tx, ok := tc.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantChange is not a transactional entity")
+	}
+	tc.config.driver = _tx.drv
+	return tc
+}
+
+// String implements the fmt.Stringer.
+func (tc *TenantChange) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantChange(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tc.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tc.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(tc.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("changed_at=")
+	builder.WriteString(tc.ChangedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(tc.Name)
+	builder.WriteString(", ")
+	builder.WriteString("parent_tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tc.ParentTenantID))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (tc TenantChange) IsEntity() {}
+
+// TenantChanges is a parsable slice of TenantChange.
+type TenantChanges []*TenantChange
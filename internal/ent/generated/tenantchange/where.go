@@ -0,0 +1,457 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenantchange
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/x/gidx"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Operation applies equality check predicate on the "operation" field. It's identical to OperationEQ.
+func Operation(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldOperation, v))
+}
+
+// ChangedAt applies equality check predicate on the "changed_at" field. It's identical to ChangedAtEQ.
+func ChangedAt(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldChangedAt, v))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldName, v))
+}
+
+// ParentTenantID applies equality check predicate on the "parent_tenant_id" field. It's identical to ParentTenantIDEQ.
+func ParentTenantID(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldParentTenantID, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenant_id" field.
+func TenantIDContains(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldContains(FieldTenantID, vc))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenant_id" field.
+func TenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldHasPrefix(FieldTenantID, vc))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenant_id" field.
+func TenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldHasSuffix(FieldTenantID, vc))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenant_id" field.
+func TenantIDEqualFold(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldEqualFold(FieldTenantID, vc))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenant_id" field.
+func TenantIDContainsFold(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldContainsFold(FieldTenantID, vc))
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldOperation, v))
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldOperation, v))
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldOperation, vs...))
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldOperation, vs...))
+}
+
+// OperationGT applies the GT predicate on the "operation" field.
+func OperationGT(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldOperation, v))
+}
+
+// OperationGTE applies the GTE predicate on the "operation" field.
+func OperationGTE(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldOperation, v))
+}
+
+// OperationLT applies the LT predicate on the "operation" field.
+func OperationLT(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldOperation, v))
+}
+
+// OperationLTE applies the LTE predicate on the "operation" field.
+func OperationLTE(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldOperation, v))
+}
+
+// OperationContains applies the Contains predicate on the "operation" field.
+func OperationContains(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldContains(FieldOperation, v))
+}
+
+// OperationHasPrefix applies the HasPrefix predicate on the "operation" field.
+func OperationHasPrefix(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldHasPrefix(FieldOperation, v))
+}
+
+// OperationHasSuffix applies the HasSuffix predicate on the "operation" field.
+func OperationHasSuffix(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldHasSuffix(FieldOperation, v))
+}
+
+// OperationEqualFold applies the EqualFold predicate on the "operation" field.
+func OperationEqualFold(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEqualFold(FieldOperation, v))
+}
+
+// OperationContainsFold applies the ContainsFold predicate on the "operation" field.
+func OperationContainsFold(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldContainsFold(FieldOperation, v))
+}
+
+// ChangedAtEQ applies the EQ predicate on the "changed_at" field.
+func ChangedAtEQ(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldChangedAt, v))
+}
+
+// ChangedAtNEQ applies the NEQ predicate on the "changed_at" field.
+func ChangedAtNEQ(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldChangedAt, v))
+}
+
+// ChangedAtIn applies the In predicate on the "changed_at" field.
+func ChangedAtIn(vs ...time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldChangedAt, vs...))
+}
+
+// ChangedAtNotIn applies the NotIn predicate on the "changed_at" field.
+func ChangedAtNotIn(vs ...time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldChangedAt, vs...))
+}
+
+// ChangedAtGT applies the GT predicate on the "changed_at" field.
+func ChangedAtGT(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldChangedAt, v))
+}
+
+// ChangedAtGTE applies the GTE predicate on the "changed_at" field.
+func ChangedAtGTE(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldChangedAt, v))
+}
+
+// ChangedAtLT applies the LT predicate on the "changed_at" field.
+func ChangedAtLT(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldChangedAt, v))
+}
+
+// ChangedAtLTE applies the LTE predicate on the "changed_at" field.
+func ChangedAtLTE(v time.Time) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldChangedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameIsNil applies the IsNil predicate on the "name" field.
+func NameIsNil() predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIsNull(FieldName))
+}
+
+// NameNotNil applies the NotNil predicate on the "name" field.
+func NameNotNil() predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotNull(FieldName))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldContainsFold(FieldName, v))
+}
+
+// ParentTenantIDEQ applies the EQ predicate on the "parent_tenant_id" field.
+func ParentTenantIDEQ(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldEQ(FieldParentTenantID, v))
+}
+
+// ParentTenantIDNEQ applies the NEQ predicate on the "parent_tenant_id" field.
+func ParentTenantIDNEQ(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNEQ(FieldParentTenantID, v))
+}
+
+// ParentTenantIDIn applies the In predicate on the "parent_tenant_id" field.
+func ParentTenantIDIn(vs ...gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIn(FieldParentTenantID, vs...))
+}
+
+// ParentTenantIDNotIn applies the NotIn predicate on the "parent_tenant_id" field.
+func ParentTenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotIn(FieldParentTenantID, vs...))
+}
+
+// ParentTenantIDGT applies the GT predicate on the "parent_tenant_id" field.
+func ParentTenantIDGT(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGT(FieldParentTenantID, v))
+}
+
+// ParentTenantIDGTE applies the GTE predicate on the "parent_tenant_id" field.
+func ParentTenantIDGTE(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldGTE(FieldParentTenantID, v))
+}
+
+// ParentTenantIDLT applies the LT predicate on the "parent_tenant_id" field.
+func ParentTenantIDLT(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLT(FieldParentTenantID, v))
+}
+
+// ParentTenantIDLTE applies the LTE predicate on the "parent_tenant_id" field.
+func ParentTenantIDLTE(v gidx.PrefixedID) predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldLTE(FieldParentTenantID, v))
+}
+
+// ParentTenantIDContains applies the Contains predicate on the "parent_tenant_id" field.
+func ParentTenantIDContains(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldContains(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDHasPrefix applies the HasPrefix predicate on the "parent_tenant_id" field.
+func ParentTenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldHasPrefix(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDHasSuffix applies the HasSuffix predicate on the "parent_tenant_id" field.
+func ParentTenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldHasSuffix(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDIsNil applies the IsNil predicate on the "parent_tenant_id" field.
+func ParentTenantIDIsNil() predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldIsNull(FieldParentTenantID))
+}
+
+// ParentTenantIDNotNil applies the NotNil predicate on the "parent_tenant_id" field.
+func ParentTenantIDNotNil() predicate.TenantChange {
+	return predicate.TenantChange(sql.FieldNotNull(FieldParentTenantID))
+}
+
+// ParentTenantIDEqualFold applies the EqualFold predicate on the "parent_tenant_id" field.
+func ParentTenantIDEqualFold(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldEqualFold(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDContainsFold applies the ContainsFold predicate on the "parent_tenant_id" field.
+func ParentTenantIDContainsFold(v gidx.PrefixedID) predicate.TenantChange {
+	vc := string(v)
+	return predicate.TenantChange(sql.FieldContainsFold(FieldParentTenantID, vc))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TenantChange) predicate.TenantChange {
+	return predicate.TenantChange(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TenantChange) predicate.TenantChange {
+	return predicate.TenantChange(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TenantChange) predicate.TenantChange {
+	return predicate.TenantChange(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantChangeCreate is the builder for creating a TenantChange entity.
+type TenantChangeCreate struct {
+	config
+	mutation *TenantChangeMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (tcc *TenantChangeCreate) SetTenantID(gi gidx.PrefixedID) *TenantChangeCreate {
+	tcc.mutation.SetTenantID(gi)
+	return tcc
+}
+
+// SetOperation sets the "operation" field.
+func (tcc *TenantChangeCreate) SetOperation(s string) *TenantChangeCreate {
+	tcc.mutation.SetOperation(s)
+	return tcc
+}
+
+// SetChangedAt sets the "changed_at" field.
+func (tcc *TenantChangeCreate) SetChangedAt(t time.Time) *TenantChangeCreate {
+	tcc.mutation.SetChangedAt(t)
+	return tcc
+}
+
+// SetName sets the "name" field.
+func (tcc *TenantChangeCreate) SetName(s string) *TenantChangeCreate {
+	tcc.mutation.SetName(s)
+	return tcc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tcc *TenantChangeCreate) SetNillableName(s *string) *TenantChangeCreate {
+	if s != nil {
+		tcc.SetName(*s)
+	}
+	return tcc
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (tcc *TenantChangeCreate) SetParentTenantID(gi gidx.PrefixedID) *TenantChangeCreate {
+	tcc.mutation.SetParentTenantID(gi)
+	return tcc
+}
+
+// SetNillableParentTenantID sets the "parent_tenant_id" field if the given value is not nil.
+func (tcc *TenantChangeCreate) SetNillableParentTenantID(gi *gidx.PrefixedID) *TenantChangeCreate {
+	if gi != nil {
+		tcc.SetParentTenantID(*gi)
+	}
+	return tcc
+}
+
+// SetID sets the "id" field.
+func (tcc *TenantChangeCreate) SetID(i int64) *TenantChangeCreate {
+	tcc.mutation.SetID(i)
+	return tcc
+}
+
+// Mutation returns the TenantChangeMutation object of the builder.
+func (tcc *TenantChangeCreate) Mutation() *TenantChangeMutation {
+	return tcc.mutation
+}
+
+// Save creates the TenantChange in the database.
+func (tcc *TenantChangeCreate) Save(ctx context.Context) (*TenantChange, error) {
+	return withHooks(ctx, tcc.sqlSave, tcc.mutation, tcc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tcc *TenantChangeCreate) SaveX(ctx context.Context) *TenantChange {
+	v, err := tcc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tcc *TenantChangeCreate) Exec(ctx context.Context) error {
+	_, err := tcc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcc *TenantChangeCreate) ExecX(ctx context.Context) {
+	if err := tcc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tcc *TenantChangeCreate) check() error {
+	if _, ok := tcc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "TenantChange.tenant_id"`)}
+	}
+	if _, ok := tcc.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`generated: missing required field "TenantChange.operation"`)}
+	}
+	if _, ok := tcc.mutation.ChangedAt(); !ok {
+		return &ValidationError{Name: "changed_at", err: errors.New(`generated: missing required field "TenantChange.changed_at"`)}
+	}
+	return nil
+}
+
+func (tcc *TenantChangeCreate) sqlSave(ctx context.Context) (*TenantChange, error) {
+	if err := tcc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tcc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tcc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	tcc.mutation.id = &_node.ID
+	tcc.mutation.done = true
+	return _node, nil
+}
+
+func (tcc *TenantChangeCreate) createSpec() (*TenantChange, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantChange{config: tcc.config}
+		_spec = sqlgraph.NewCreateSpec(tenantchange.Table, sqlgraph.NewFieldSpec(tenantchange.FieldID, field.TypeInt64))
+	)
+	if id, ok := tcc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := tcc.mutation.TenantID(); ok {
+		_spec.SetField(tenantchange.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := tcc.mutation.Operation(); ok {
+		_spec.SetField(tenantchange.FieldOperation, field.TypeString, value)
+		_node.Operation = value
+	}
+	if value, ok := tcc.mutation.ChangedAt(); ok {
+		_spec.SetField(tenantchange.FieldChangedAt, field.TypeTime, value)
+		_node.ChangedAt = value
+	}
+	if value, ok := tcc.mutation.Name(); ok {
+		_spec.SetField(tenantchange.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := tcc.mutation.ParentTenantID(); ok {
+		_spec.SetField(tenantchange.FieldParentTenantID, field.TypeString, value)
+		_node.ParentTenantID = value
+	}
+	return _node, _spec
+}
+
+// TenantChangeCreateBulk is the builder for creating many TenantChange entities in bulk.
+type TenantChangeCreateBulk struct {
+	config
+	builders []*TenantChangeCreate
+}
+
+// Save creates the TenantChange entities in the database.
+func (tccb *TenantChangeCreateBulk) Save(ctx context.Context) ([]*TenantChange, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tccb.builders))
+	nodes := make([]*TenantChange, len(tccb.builders))
+	mutators := make([]Mutator, len(tccb.builders))
+	for i := range tccb.builders {
+		func(i int, root context.Context) {
+			builder := tccb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantChangeMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tccb *TenantChangeCreateBulk) SaveX(ctx context.Context) []*TenantChange {
+	v, err := tccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tccb *TenantChangeCreateBulk) Exec(ctx context.Context) error {
+	_, err := tccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tccb *TenantChangeCreateBulk) ExecX(ctx context.Context) {
+	if err := tccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// TenantChangeDelete is the builder for deleting a TenantChange entity.
+type TenantChangeDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantChangeMutation
+}
+
+// Where appends a list predicates to the TenantChangeDelete builder.
+func (tcd *TenantChangeDelete) Where(ps ...predicate.TenantChange) *TenantChangeDelete {
+	tcd.mutation.Where(ps...)
+	return tcd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (tcd *TenantChangeDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, tcd.sqlExec, tcd.mutation, tcd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcd *TenantChangeDelete) ExecX(ctx context.Context) int {
+	n, err := tcd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (tcd *TenantChangeDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenantchange.Table, sqlgraph.NewFieldSpec(tenantchange.FieldID, field.TypeInt64))
+	if ps := tcd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, tcd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	tcd.mutation.done = true
+	return affected, err
+}
+
+// TenantChangeDeleteOne is the builder for deleting a single TenantChange entity.
+type TenantChangeDeleteOne struct {
+	tcd *TenantChangeDelete
+}
+
+// Where appends a list predicates to the TenantChangeDelete builder.
+func (tcdo *TenantChangeDeleteOne) Where(ps ...predicate.TenantChange) *TenantChangeDeleteOne {
+	tcdo.tcd.mutation.Where(ps...)
+	return tcdo
+}
+
+// Exec executes the deletion query.
+func (tcdo *TenantChangeDeleteOne) Exec(ctx context.Context) error {
+	n, err := tcdo.tcd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenantchange.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcdo *TenantChangeDeleteOne) ExecX(ctx context.Context) {
+	if err := tcdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,553 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// TenantChangeQuery is the builder for querying TenantChange entities.
+type TenantChangeQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenantchange.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantChange
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantChange) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantChangeQuery builder.
+func (tcq *TenantChangeQuery) Where(ps ...predicate.TenantChange) *TenantChangeQuery {
+	tcq.predicates = append(tcq.predicates, ps...)
+	return tcq
+}
+
+// Limit the number of records to be returned by this query.
+func (tcq *TenantChangeQuery) Limit(limit int) *TenantChangeQuery {
+	tcq.ctx.Limit = &limit
+	return tcq
+}
+
+// Offset to start from.
+func (tcq *TenantChangeQuery) Offset(offset int) *TenantChangeQuery {
+	tcq.ctx.Offset = &offset
+	return tcq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (tcq *TenantChangeQuery) Unique(unique bool) *TenantChangeQuery {
+	tcq.ctx.Unique = &unique
+	return tcq
+}
+
+// Order specifies how the records should be ordered.
+func (tcq *TenantChangeQuery) Order(o ...tenantchange.OrderOption) *TenantChangeQuery {
+	tcq.order = append(tcq.order, o...)
+	return tcq
+}
+
+// First returns the first TenantChange entity from the query.
+// Returns a *NotFoundError when no TenantChange was found.
+func (tcq *TenantChangeQuery) First(ctx context.Context) (*TenantChange, error) {
+	nodes, err := tcq.Limit(1).All(setContextOp(ctx, tcq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenantchange.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (tcq *TenantChangeQuery) FirstX(ctx context.Context) *TenantChange {
+	node, err := tcq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantChange ID from the query.
+// Returns a *NotFoundError when no TenantChange ID was found.
+func (tcq *TenantChangeQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = tcq.Limit(1).IDs(setContextOp(ctx, tcq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenantchange.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (tcq *TenantChangeQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := tcq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantChange entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantChange entity is found.
+// Returns a *NotFoundError when no TenantChange entities are found.
+func (tcq *TenantChangeQuery) Only(ctx context.Context) (*TenantChange, error) {
+	nodes, err := tcq.Limit(2).All(setContextOp(ctx, tcq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenantchange.Label}
+	default:
+		return nil, &NotSingularError{tenantchange.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (tcq *TenantChangeQuery) OnlyX(ctx context.Context) *TenantChange {
+	node, err := tcq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantChange ID in the query.
+// Returns a *NotSingularError when more than one TenantChange ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (tcq *TenantChangeQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = tcq.Limit(2).IDs(setContextOp(ctx, tcq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenantchange.Label}
+	default:
+		err = &NotSingularError{tenantchange.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (tcq *TenantChangeQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := tcq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantChanges.
+func (tcq *TenantChangeQuery) All(ctx context.Context) ([]*TenantChange, error) {
+	ctx = setContextOp(ctx, tcq.ctx, "All")
+	if err := tcq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantChange, *TenantChangeQuery]()
+	return withInterceptors[[]*TenantChange](ctx, tcq, qr, tcq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (tcq *TenantChangeQuery) AllX(ctx context.Context) []*TenantChange {
+	nodes, err := tcq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantChange IDs.
+func (tcq *TenantChangeQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if tcq.ctx.Unique == nil && tcq.path != nil {
+		tcq.Unique(true)
+	}
+	ctx = setContextOp(ctx, tcq.ctx, "IDs")
+	if err = tcq.Select(tenantchange.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (tcq *TenantChangeQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := tcq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (tcq *TenantChangeQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, tcq.ctx, "Count")
+	if err := tcq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, tcq, querierCount[*TenantChangeQuery](), tcq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (tcq *TenantChangeQuery) CountX(ctx context.Context) int {
+	count, err := tcq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (tcq *TenantChangeQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, tcq.ctx, "Exist")
+	switch _, err := tcq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (tcq *TenantChangeQuery) ExistX(ctx context.Context) bool {
+	exist, err := tcq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantChangeQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (tcq *TenantChangeQuery) Clone() *TenantChangeQuery {
+	if tcq == nil {
+		return nil
+	}
+	return &TenantChangeQuery{
+		config:     tcq.config,
+		ctx:        tcq.ctx.Clone(),
+		order:      append([]tenantchange.OrderOption{}, tcq.order...),
+		inters:     append([]Interceptor{}, tcq.inters...),
+		predicates: append([]predicate.TenantChange{}, tcq.predicates...),
+		// clone intermediate query.
+		sql:  tcq.sql.Clone(),
+		path: tcq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantChange.Query().
+//		GroupBy(tenantchange.FieldTenantID).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (tcq *TenantChangeQuery) GroupBy(field string, fields ...string) *TenantChangeGroupBy {
+	tcq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantChangeGroupBy{build: tcq}
+	grbuild.flds = &tcq.ctx.Fields
+	grbuild.label = tenantchange.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//	}
+//
+//	client.TenantChange.Query().
+//		Select(tenantchange.FieldTenantID).
+//		Scan(ctx, &v)
+func (tcq *TenantChangeQuery) Select(fields ...string) *TenantChangeSelect {
+	tcq.ctx.Fields = append(tcq.ctx.Fields, fields...)
+	sbuild := &TenantChangeSelect{TenantChangeQuery: tcq}
+	sbuild.label = tenantchange.Label
+	sbuild.flds, sbuild.scan = &tcq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantChangeSelect configured with the given aggregations.
+func (tcq *TenantChangeQuery) Aggregate(fns ...AggregateFunc) *TenantChangeSelect {
+	return tcq.Select().Aggregate(fns...)
+}
+
+func (tcq *TenantChangeQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range tcq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, tcq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range tcq.ctx.Fields {
+		if !tenantchange.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if tcq.path != nil {
+		prev, err := tcq.path(ctx)
+		if err != nil {
+			return err
+		}
+		tcq.sql = prev
+	}
+	return nil
+}
+
+func (tcq *TenantChangeQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantChange, error) {
+	var (
+		nodes = []*TenantChange{}
+		_spec = tcq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantChange).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantChange{config: tcq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(tcq.modifiers) > 0 {
+		_spec.Modifiers = tcq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, tcq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range tcq.loadTotal {
+		if err := tcq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (tcq *TenantChangeQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := tcq.querySpec()
+	if len(tcq.modifiers) > 0 {
+		_spec.Modifiers = tcq.modifiers
+	}
+	_spec.Node.Columns = tcq.ctx.Fields
+	if len(tcq.ctx.Fields) > 0 {
+		_spec.Unique = tcq.ctx.Unique != nil && *tcq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, tcq.driver, _spec)
+}
+
+func (tcq *TenantChangeQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenantchange.Table, tenantchange.Columns, sqlgraph.NewFieldSpec(tenantchange.FieldID, field.TypeInt64))
+	_spec.From = tcq.sql
+	if unique := tcq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if tcq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := tcq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantchange.FieldID)
+		for i := range fields {
+			if fields[i] != tenantchange.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := tcq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := tcq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := tcq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := tcq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (tcq *TenantChangeQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(tcq.driver.Dialect())
+	t1 := builder.Table(tenantchange.Table)
+	columns := tcq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenantchange.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if tcq.sql != nil {
+		selector = tcq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if tcq.ctx.Unique != nil && *tcq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range tcq.predicates {
+		p(selector)
+	}
+	for _, p := range tcq.order {
+		p(selector)
+	}
+	if offset := tcq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := tcq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantChangeGroupBy is the group-by builder for TenantChange entities.
+type TenantChangeGroupBy struct {
+	selector
+	build *TenantChangeQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (tcgb *TenantChangeGroupBy) Aggregate(fns ...AggregateFunc) *TenantChangeGroupBy {
+	tcgb.fns = append(tcgb.fns, fns...)
+	return tcgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tcgb *TenantChangeGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tcgb.build.ctx, "GroupBy")
+	if err := tcgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantChangeQuery, *TenantChangeGroupBy](ctx, tcgb.build, tcgb, tcgb.build.inters, v)
+}
+
+func (tcgb *TenantChangeGroupBy) sqlScan(ctx context.Context, root *TenantChangeQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(tcgb.fns))
+	for _, fn := range tcgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*tcgb.flds)+len(tcgb.fns))
+		for _, f := range *tcgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*tcgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tcgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantChangeSelect is the builder for selecting fields of TenantChange entities.
+type TenantChangeSelect struct {
+	*TenantChangeQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tcs *TenantChangeSelect) Aggregate(fns ...AggregateFunc) *TenantChangeSelect {
+	tcs.fns = append(tcs.fns, fns...)
+	return tcs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tcs *TenantChangeSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tcs.ctx, "Select")
+	if err := tcs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantChangeQuery, *TenantChangeSelect](ctx, tcs.TenantChangeQuery, tcs, tcs.inters, v)
+}
+
+func (tcs *TenantChangeSelect) sqlScan(ctx context.Context, root *TenantChangeQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tcs.fns))
+	for _, fn := range tcs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tcs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tcs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
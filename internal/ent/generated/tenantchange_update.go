@@ -0,0 +1,201 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// TenantChangeUpdate is the builder for updating TenantChange entities.
+type TenantChangeUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantChangeMutation
+}
+
+// Where appends a list predicates to the TenantChangeUpdate builder.
+func (tcu *TenantChangeUpdate) Where(ps ...predicate.TenantChange) *TenantChangeUpdate {
+	tcu.mutation.Where(ps...)
+	return tcu
+}
+
+// Mutation returns the TenantChangeMutation object of the builder.
+func (tcu *TenantChangeUpdate) Mutation() *TenantChangeMutation {
+	return tcu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tcu *TenantChangeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, tcu.sqlSave, tcu.mutation, tcu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcu *TenantChangeUpdate) SaveX(ctx context.Context) int {
+	affected, err := tcu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tcu *TenantChangeUpdate) Exec(ctx context.Context) error {
+	_, err := tcu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcu *TenantChangeUpdate) ExecX(ctx context.Context) {
+	if err := tcu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tcu *TenantChangeUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantchange.Table, tenantchange.Columns, sqlgraph.NewFieldSpec(tenantchange.FieldID, field.TypeInt64))
+	if ps := tcu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tcu.mutation.NameCleared() {
+		_spec.ClearField(tenantchange.FieldName, field.TypeString)
+	}
+	if tcu.mutation.ParentTenantIDCleared() {
+		_spec.ClearField(tenantchange.FieldParentTenantID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tcu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantchange.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tcu.mutation.done = true
+	return n, nil
+}
+
+// TenantChangeUpdateOne is the builder for updating a single TenantChange entity.
+type TenantChangeUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantChangeMutation
+}
+
+// Mutation returns the TenantChangeMutation object of the builder.
+func (tcuo *TenantChangeUpdateOne) Mutation() *TenantChangeMutation {
+	return tcuo.mutation
+}
+
+// Where appends a list predicates to the TenantChangeUpdate builder.
+func (tcuo *TenantChangeUpdateOne) Where(ps ...predicate.TenantChange) *TenantChangeUpdateOne {
+	tcuo.mutation.Where(ps...)
+	return tcuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tcuo *TenantChangeUpdateOne) Select(field string, fields ...string) *TenantChangeUpdateOne {
+	tcuo.fields = append([]string{field}, fields...)
+	return tcuo
+}
+
+// Save executes the query and returns the updated TenantChange entity.
+func (tcuo *TenantChangeUpdateOne) Save(ctx context.Context) (*TenantChange, error) {
+	return withHooks(ctx, tcuo.sqlSave, tcuo.mutation, tcuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcuo *TenantChangeUpdateOne) SaveX(ctx context.Context) *TenantChange {
+	node, err := tcuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tcuo *TenantChangeUpdateOne) Exec(ctx context.Context) error {
+	_, err := tcuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcuo *TenantChangeUpdateOne) ExecX(ctx context.Context) {
+	if err := tcuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tcuo *TenantChangeUpdateOne) sqlSave(ctx context.Context) (_node *TenantChange, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantchange.Table, tenantchange.Columns, sqlgraph.NewFieldSpec(tenantchange.FieldID, field.TypeInt64))
+	id, ok := tcuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantChange.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tcuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantchange.FieldID)
+		for _, f := range fields {
+			if !tenantchange.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenantchange.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tcuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tcuo.mutation.NameCleared() {
+		_spec.ClearField(tenantchange.FieldName, field.TypeString)
+	}
+	if tcuo.mutation.ParentTenantIDCleared() {
+		_spec.ClearField(tenantchange.FieldParentTenantID, field.TypeString)
+	}
+	_node = &TenantChange{config: tcuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tcuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantchange.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tcuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/x/gidx"
+)
+
+// The recent creates of tenants, recognizing retried creates.
+type TenantCreateGuard struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The key of the create, a hash of the parent, name and actor.
+	ID string `json:"id,omitempty"`
+	// The ID of the created tenant.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The time the tenant was created at.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantCreateGuard) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenantcreateguard.FieldTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case tenantcreateguard.FieldID:
+			values[i] = new(sql.NullString)
+		case tenantcreateguard.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantCreateGuard fields.
+func (tcg *TenantCreateGuard) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenantcreateguard.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				tcg.ID = value.String
+			}
+		case tenantcreateguard.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				tcg.TenantID = *value
+			}
+		case tenantcreateguard.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				tcg.CreatedAt = value.Time
+			}
+		default:
+			tcg.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantCreateGuard.
+// This includes values selected through modifiers, order, etc.
+func (tcg *TenantCreateGuard) Value(name string) (ent.Value, error) {
+	return tcg.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantCreateGuard.
+// Note that you need to call TenantCreateGuard.Unwrap() before calling this method if this TenantCreateGuard
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tcg *TenantCreateGuard) Update() *TenantCreateGuardUpdateOne {
+	return NewTenantCreateGuardClient(tcg.config).UpdateOne(tcg)
+}
+
+// Unwrap unwraps the TenantCreateGuard entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tcg *TenantCreateGuard) Unwrap() *TenantCreateGuard {
+	_tx, ok := tcg.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantCreateGuard is not a transactional entity")
+	}
+	tcg.config.driver = _tx.drv
+	return tcg
+}
+
+// String implements the fmt.Stringer.
+func (tcg *TenantCreateGuard) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantCreateGuard(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tcg.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tcg.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(tcg.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (tcg TenantCreateGuard) IsEntity() {}
+
+// TenantCreateGuards is a parsable slice of TenantCreateGuard.
+type TenantCreateGuards []*TenantCreateGuard
@@ -0,0 +1,224 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantCreateGuardCreate is the builder for creating a TenantCreateGuard entity.
+type TenantCreateGuardCreate struct {
+	config
+	mutation *TenantCreateGuardMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (tcgc *TenantCreateGuardCreate) SetTenantID(gi gidx.PrefixedID) *TenantCreateGuardCreate {
+	tcgc.mutation.SetTenantID(gi)
+	return tcgc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (tcgc *TenantCreateGuardCreate) SetCreatedAt(t time.Time) *TenantCreateGuardCreate {
+	tcgc.mutation.SetCreatedAt(t)
+	return tcgc
+}
+
+// SetID sets the "id" field.
+func (tcgc *TenantCreateGuardCreate) SetID(s string) *TenantCreateGuardCreate {
+	tcgc.mutation.SetID(s)
+	return tcgc
+}
+
+// Mutation returns the TenantCreateGuardMutation object of the builder.
+func (tcgc *TenantCreateGuardCreate) Mutation() *TenantCreateGuardMutation {
+	return tcgc.mutation
+}
+
+// Save creates the TenantCreateGuard in the database.
+func (tcgc *TenantCreateGuardCreate) Save(ctx context.Context) (*TenantCreateGuard, error) {
+	return withHooks(ctx, tcgc.sqlSave, tcgc.mutation, tcgc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tcgc *TenantCreateGuardCreate) SaveX(ctx context.Context) *TenantCreateGuard {
+	v, err := tcgc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tcgc *TenantCreateGuardCreate) Exec(ctx context.Context) error {
+	_, err := tcgc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcgc *TenantCreateGuardCreate) ExecX(ctx context.Context) {
+	if err := tcgc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tcgc *TenantCreateGuardCreate) check() error {
+	if _, ok := tcgc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "TenantCreateGuard.tenant_id"`)}
+	}
+	if _, ok := tcgc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`generated: missing required field "TenantCreateGuard.created_at"`)}
+	}
+	if v, ok := tcgc.mutation.ID(); ok {
+		if err := tenantcreateguard.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`generated: validator failed for field "TenantCreateGuard.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tcgc *TenantCreateGuardCreate) sqlSave(ctx context.Context) (*TenantCreateGuard, error) {
+	if err := tcgc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tcgc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tcgc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected TenantCreateGuard.ID type: %T", _spec.ID.Value)
+		}
+	}
+	tcgc.mutation.id = &_node.ID
+	tcgc.mutation.done = true
+	return _node, nil
+}
+
+func (tcgc *TenantCreateGuardCreate) createSpec() (*TenantCreateGuard, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantCreateGuard{config: tcgc.config}
+		_spec = sqlgraph.NewCreateSpec(tenantcreateguard.Table, sqlgraph.NewFieldSpec(tenantcreateguard.FieldID, field.TypeString))
+	)
+	if id, ok := tcgc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := tcgc.mutation.TenantID(); ok {
+		_spec.SetField(tenantcreateguard.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := tcgc.mutation.CreatedAt(); ok {
+		_spec.SetField(tenantcreateguard.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// TenantCreateGuardCreateBulk is the builder for creating many TenantCreateGuard entities in bulk.
+type TenantCreateGuardCreateBulk struct {
+	config
+	builders []*TenantCreateGuardCreate
+}
+
+// Save creates the TenantCreateGuard entities in the database.
+func (tcgcb *TenantCreateGuardCreateBulk) Save(ctx context.Context) ([]*TenantCreateGuard, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tcgcb.builders))
+	nodes := make([]*TenantCreateGuard, len(tcgcb.builders))
+	mutators := make([]Mutator, len(tcgcb.builders))
+	for i := range tcgcb.builders {
+		func(i int, root context.Context) {
+			builder := tcgcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantCreateGuardMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tcgcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tcgcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tcgcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcgcb *TenantCreateGuardCreateBulk) SaveX(ctx context.Context) []*TenantCreateGuard {
+	v, err := tcgcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tcgcb *TenantCreateGuardCreateBulk) Exec(ctx context.Context) error {
+	_, err := tcgcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcgcb *TenantCreateGuardCreateBulk) ExecX(ctx context.Context) {
+	if err := tcgcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+)
+
+// TenantCreateGuardDelete is the builder for deleting a TenantCreateGuard entity.
+type TenantCreateGuardDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantCreateGuardMutation
+}
+
+// Where appends a list predicates to the TenantCreateGuardDelete builder.
+func (tcgd *TenantCreateGuardDelete) Where(ps ...predicate.TenantCreateGuard) *TenantCreateGuardDelete {
+	tcgd.mutation.Where(ps...)
+	return tcgd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (tcgd *TenantCreateGuardDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, tcgd.sqlExec, tcgd.mutation, tcgd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcgd *TenantCreateGuardDelete) ExecX(ctx context.Context) int {
+	n, err := tcgd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (tcgd *TenantCreateGuardDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenantcreateguard.Table, sqlgraph.NewFieldSpec(tenantcreateguard.FieldID, field.TypeString))
+	if ps := tcgd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, tcgd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	tcgd.mutation.done = true
+	return affected, err
+}
+
+// TenantCreateGuardDeleteOne is the builder for deleting a single TenantCreateGuard entity.
+type TenantCreateGuardDeleteOne struct {
+	tcgd *TenantCreateGuardDelete
+}
+
+// Where appends a list predicates to the TenantCreateGuardDelete builder.
+func (tcgdo *TenantCreateGuardDeleteOne) Where(ps ...predicate.TenantCreateGuard) *TenantCreateGuardDeleteOne {
+	tcgdo.tcgd.mutation.Where(ps...)
+	return tcgdo
+}
+
+// Exec executes the deletion query.
+func (tcgdo *TenantCreateGuardDeleteOne) Exec(ctx context.Context) error {
+	n, err := tcgdo.tcgd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenantcreateguard.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcgdo *TenantCreateGuardDeleteOne) ExecX(ctx context.Context) {
+	if err := tcgdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
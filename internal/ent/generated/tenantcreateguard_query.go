@@ -0,0 +1,553 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+)
+
+// TenantCreateGuardQuery is the builder for querying TenantCreateGuard entities.
+type TenantCreateGuardQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenantcreateguard.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantCreateGuard
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantCreateGuard) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantCreateGuardQuery builder.
+func (tcgq *TenantCreateGuardQuery) Where(ps ...predicate.TenantCreateGuard) *TenantCreateGuardQuery {
+	tcgq.predicates = append(tcgq.predicates, ps...)
+	return tcgq
+}
+
+// Limit the number of records to be returned by this query.
+func (tcgq *TenantCreateGuardQuery) Limit(limit int) *TenantCreateGuardQuery {
+	tcgq.ctx.Limit = &limit
+	return tcgq
+}
+
+// Offset to start from.
+func (tcgq *TenantCreateGuardQuery) Offset(offset int) *TenantCreateGuardQuery {
+	tcgq.ctx.Offset = &offset
+	return tcgq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (tcgq *TenantCreateGuardQuery) Unique(unique bool) *TenantCreateGuardQuery {
+	tcgq.ctx.Unique = &unique
+	return tcgq
+}
+
+// Order specifies how the records should be ordered.
+func (tcgq *TenantCreateGuardQuery) Order(o ...tenantcreateguard.OrderOption) *TenantCreateGuardQuery {
+	tcgq.order = append(tcgq.order, o...)
+	return tcgq
+}
+
+// First returns the first TenantCreateGuard entity from the query.
+// Returns a *NotFoundError when no TenantCreateGuard was found.
+func (tcgq *TenantCreateGuardQuery) First(ctx context.Context) (*TenantCreateGuard, error) {
+	nodes, err := tcgq.Limit(1).All(setContextOp(ctx, tcgq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenantcreateguard.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) FirstX(ctx context.Context) *TenantCreateGuard {
+	node, err := tcgq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantCreateGuard ID from the query.
+// Returns a *NotFoundError when no TenantCreateGuard ID was found.
+func (tcgq *TenantCreateGuardQuery) FirstID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = tcgq.Limit(1).IDs(setContextOp(ctx, tcgq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenantcreateguard.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) FirstIDX(ctx context.Context) string {
+	id, err := tcgq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantCreateGuard entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantCreateGuard entity is found.
+// Returns a *NotFoundError when no TenantCreateGuard entities are found.
+func (tcgq *TenantCreateGuardQuery) Only(ctx context.Context) (*TenantCreateGuard, error) {
+	nodes, err := tcgq.Limit(2).All(setContextOp(ctx, tcgq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenantcreateguard.Label}
+	default:
+		return nil, &NotSingularError{tenantcreateguard.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) OnlyX(ctx context.Context) *TenantCreateGuard {
+	node, err := tcgq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantCreateGuard ID in the query.
+// Returns a *NotSingularError when more than one TenantCreateGuard ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (tcgq *TenantCreateGuardQuery) OnlyID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = tcgq.Limit(2).IDs(setContextOp(ctx, tcgq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenantcreateguard.Label}
+	default:
+		err = &NotSingularError{tenantcreateguard.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) OnlyIDX(ctx context.Context) string {
+	id, err := tcgq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantCreateGuards.
+func (tcgq *TenantCreateGuardQuery) All(ctx context.Context) ([]*TenantCreateGuard, error) {
+	ctx = setContextOp(ctx, tcgq.ctx, "All")
+	if err := tcgq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantCreateGuard, *TenantCreateGuardQuery]()
+	return withInterceptors[[]*TenantCreateGuard](ctx, tcgq, qr, tcgq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) AllX(ctx context.Context) []*TenantCreateGuard {
+	nodes, err := tcgq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantCreateGuard IDs.
+func (tcgq *TenantCreateGuardQuery) IDs(ctx context.Context) (ids []string, err error) {
+	if tcgq.ctx.Unique == nil && tcgq.path != nil {
+		tcgq.Unique(true)
+	}
+	ctx = setContextOp(ctx, tcgq.ctx, "IDs")
+	if err = tcgq.Select(tenantcreateguard.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) IDsX(ctx context.Context) []string {
+	ids, err := tcgq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (tcgq *TenantCreateGuardQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, tcgq.ctx, "Count")
+	if err := tcgq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, tcgq, querierCount[*TenantCreateGuardQuery](), tcgq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) CountX(ctx context.Context) int {
+	count, err := tcgq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (tcgq *TenantCreateGuardQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, tcgq.ctx, "Exist")
+	switch _, err := tcgq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (tcgq *TenantCreateGuardQuery) ExistX(ctx context.Context) bool {
+	exist, err := tcgq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantCreateGuardQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (tcgq *TenantCreateGuardQuery) Clone() *TenantCreateGuardQuery {
+	if tcgq == nil {
+		return nil
+	}
+	return &TenantCreateGuardQuery{
+		config:     tcgq.config,
+		ctx:        tcgq.ctx.Clone(),
+		order:      append([]tenantcreateguard.OrderOption{}, tcgq.order...),
+		inters:     append([]Interceptor{}, tcgq.inters...),
+		predicates: append([]predicate.TenantCreateGuard{}, tcgq.predicates...),
+		// clone intermediate query.
+		sql:  tcgq.sql.Clone(),
+		path: tcgq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantCreateGuard.Query().
+//		GroupBy(tenantcreateguard.FieldTenantID).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (tcgq *TenantCreateGuardQuery) GroupBy(field string, fields ...string) *TenantCreateGuardGroupBy {
+	tcgq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantCreateGuardGroupBy{build: tcgq}
+	grbuild.flds = &tcgq.ctx.Fields
+	grbuild.label = tenantcreateguard.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//	}
+//
+//	client.TenantCreateGuard.Query().
+//		Select(tenantcreateguard.FieldTenantID).
+//		Scan(ctx, &v)
+func (tcgq *TenantCreateGuardQuery) Select(fields ...string) *TenantCreateGuardSelect {
+	tcgq.ctx.Fields = append(tcgq.ctx.Fields, fields...)
+	sbuild := &TenantCreateGuardSelect{TenantCreateGuardQuery: tcgq}
+	sbuild.label = tenantcreateguard.Label
+	sbuild.flds, sbuild.scan = &tcgq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantCreateGuardSelect configured with the given aggregations.
+func (tcgq *TenantCreateGuardQuery) Aggregate(fns ...AggregateFunc) *TenantCreateGuardSelect {
+	return tcgq.Select().Aggregate(fns...)
+}
+
+func (tcgq *TenantCreateGuardQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range tcgq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, tcgq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range tcgq.ctx.Fields {
+		if !tenantcreateguard.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if tcgq.path != nil {
+		prev, err := tcgq.path(ctx)
+		if err != nil {
+			return err
+		}
+		tcgq.sql = prev
+	}
+	return nil
+}
+
+func (tcgq *TenantCreateGuardQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantCreateGuard, error) {
+	var (
+		nodes = []*TenantCreateGuard{}
+		_spec = tcgq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantCreateGuard).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantCreateGuard{config: tcgq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(tcgq.modifiers) > 0 {
+		_spec.Modifiers = tcgq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, tcgq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range tcgq.loadTotal {
+		if err := tcgq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (tcgq *TenantCreateGuardQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := tcgq.querySpec()
+	if len(tcgq.modifiers) > 0 {
+		_spec.Modifiers = tcgq.modifiers
+	}
+	_spec.Node.Columns = tcgq.ctx.Fields
+	if len(tcgq.ctx.Fields) > 0 {
+		_spec.Unique = tcgq.ctx.Unique != nil && *tcgq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, tcgq.driver, _spec)
+}
+
+func (tcgq *TenantCreateGuardQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenantcreateguard.Table, tenantcreateguard.Columns, sqlgraph.NewFieldSpec(tenantcreateguard.FieldID, field.TypeString))
+	_spec.From = tcgq.sql
+	if unique := tcgq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if tcgq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := tcgq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantcreateguard.FieldID)
+		for i := range fields {
+			if fields[i] != tenantcreateguard.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := tcgq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := tcgq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := tcgq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := tcgq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (tcgq *TenantCreateGuardQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(tcgq.driver.Dialect())
+	t1 := builder.Table(tenantcreateguard.Table)
+	columns := tcgq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenantcreateguard.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if tcgq.sql != nil {
+		selector = tcgq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if tcgq.ctx.Unique != nil && *tcgq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range tcgq.predicates {
+		p(selector)
+	}
+	for _, p := range tcgq.order {
+		p(selector)
+	}
+	if offset := tcgq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := tcgq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantCreateGuardGroupBy is the group-by builder for TenantCreateGuard entities.
+type TenantCreateGuardGroupBy struct {
+	selector
+	build *TenantCreateGuardQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (tcggb *TenantCreateGuardGroupBy) Aggregate(fns ...AggregateFunc) *TenantCreateGuardGroupBy {
+	tcggb.fns = append(tcggb.fns, fns...)
+	return tcggb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tcggb *TenantCreateGuardGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tcggb.build.ctx, "GroupBy")
+	if err := tcggb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantCreateGuardQuery, *TenantCreateGuardGroupBy](ctx, tcggb.build, tcggb, tcggb.build.inters, v)
+}
+
+func (tcggb *TenantCreateGuardGroupBy) sqlScan(ctx context.Context, root *TenantCreateGuardQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(tcggb.fns))
+	for _, fn := range tcggb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*tcggb.flds)+len(tcggb.fns))
+		for _, f := range *tcggb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*tcggb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tcggb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantCreateGuardSelect is the builder for selecting fields of TenantCreateGuard entities.
+type TenantCreateGuardSelect struct {
+	*TenantCreateGuardQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tcgs *TenantCreateGuardSelect) Aggregate(fns ...AggregateFunc) *TenantCreateGuardSelect {
+	tcgs.fns = append(tcgs.fns, fns...)
+	return tcgs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tcgs *TenantCreateGuardSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tcgs.ctx, "Select")
+	if err := tcgs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantCreateGuardQuery, *TenantCreateGuardSelect](ctx, tcgs.TenantCreateGuardQuery, tcgs, tcgs.inters, v)
+}
+
+func (tcgs *TenantCreateGuardSelect) sqlScan(ctx context.Context, root *TenantCreateGuardQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tcgs.fns))
+	for _, fn := range tcgs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tcgs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tcgs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
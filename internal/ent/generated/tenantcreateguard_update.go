@@ -0,0 +1,189 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantcreateguard"
+)
+
+// TenantCreateGuardUpdate is the builder for updating TenantCreateGuard entities.
+type TenantCreateGuardUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantCreateGuardMutation
+}
+
+// Where appends a list predicates to the TenantCreateGuardUpdate builder.
+func (tcgu *TenantCreateGuardUpdate) Where(ps ...predicate.TenantCreateGuard) *TenantCreateGuardUpdate {
+	tcgu.mutation.Where(ps...)
+	return tcgu
+}
+
+// Mutation returns the TenantCreateGuardMutation object of the builder.
+func (tcgu *TenantCreateGuardUpdate) Mutation() *TenantCreateGuardMutation {
+	return tcgu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tcgu *TenantCreateGuardUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, tcgu.sqlSave, tcgu.mutation, tcgu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcgu *TenantCreateGuardUpdate) SaveX(ctx context.Context) int {
+	affected, err := tcgu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tcgu *TenantCreateGuardUpdate) Exec(ctx context.Context) error {
+	_, err := tcgu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcgu *TenantCreateGuardUpdate) ExecX(ctx context.Context) {
+	if err := tcgu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tcgu *TenantCreateGuardUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantcreateguard.Table, tenantcreateguard.Columns, sqlgraph.NewFieldSpec(tenantcreateguard.FieldID, field.TypeString))
+	if ps := tcgu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tcgu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantcreateguard.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tcgu.mutation.done = true
+	return n, nil
+}
+
+// TenantCreateGuardUpdateOne is the builder for updating a single TenantCreateGuard entity.
+type TenantCreateGuardUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantCreateGuardMutation
+}
+
+// Mutation returns the TenantCreateGuardMutation object of the builder.
+func (tcguo *TenantCreateGuardUpdateOne) Mutation() *TenantCreateGuardMutation {
+	return tcguo.mutation
+}
+
+// Where appends a list predicates to the TenantCreateGuardUpdate builder.
+func (tcguo *TenantCreateGuardUpdateOne) Where(ps ...predicate.TenantCreateGuard) *TenantCreateGuardUpdateOne {
+	tcguo.mutation.Where(ps...)
+	return tcguo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tcguo *TenantCreateGuardUpdateOne) Select(field string, fields ...string) *TenantCreateGuardUpdateOne {
+	tcguo.fields = append([]string{field}, fields...)
+	return tcguo
+}
+
+// Save executes the query and returns the updated TenantCreateGuard entity.
+func (tcguo *TenantCreateGuardUpdateOne) Save(ctx context.Context) (*TenantCreateGuard, error) {
+	return withHooks(ctx, tcguo.sqlSave, tcguo.mutation, tcguo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcguo *TenantCreateGuardUpdateOne) SaveX(ctx context.Context) *TenantCreateGuard {
+	node, err := tcguo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tcguo *TenantCreateGuardUpdateOne) Exec(ctx context.Context) error {
+	_, err := tcguo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcguo *TenantCreateGuardUpdateOne) ExecX(ctx context.Context) {
+	if err := tcguo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tcguo *TenantCreateGuardUpdateOne) sqlSave(ctx context.Context) (_node *TenantCreateGuard, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantcreateguard.Table, tenantcreateguard.Columns, sqlgraph.NewFieldSpec(tenantcreateguard.FieldID, field.TypeString))
+	id, ok := tcguo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantCreateGuard.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tcguo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantcreateguard.FieldID)
+		for _, f := range fields {
+			if !tenantcreateguard.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenantcreateguard.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tcguo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &TenantCreateGuard{config: tcguo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tcguo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantcreateguard.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tcguo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/x/gidx"
+)
+
+// A change of the parent of a tenant.
+type TenantParentHistory struct {
+	config `json:"-"`
+	// ID of the ent.
+	// ID for the history entry.
+	ID gidx.PrefixedID `json:"id,omitempty"`
+	// The ID of the tenant which was moved.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The ID of the parent before the change, empty for root tenants.
+	PreviousParentID gidx.PrefixedID `json:"previous_parent_id,omitempty"`
+	// The ID of the parent after the change, empty for root tenants.
+	NewParentID gidx.PrefixedID `json:"new_parent_id,omitempty"`
+	// The subject which made the change, empty when unknown.
+	Actor string `json:"actor,omitempty"`
+	// The time of the change.
+	ChangedAt    time.Time `json:"changed_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantParentHistory) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenantparenthistory.FieldID, tenantparenthistory.FieldTenantID, tenantparenthistory.FieldPreviousParentID, tenantparenthistory.FieldNewParentID:
+			values[i] = new(gidx.PrefixedID)
+		case tenantparenthistory.FieldActor:
+			values[i] = new(sql.NullString)
+		case tenantparenthistory.FieldChangedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantParentHistory fields.
+func (tph *TenantParentHistory) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenantparenthistory.FieldID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				tph.ID = *value
+			}
+		case tenantparenthistory.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				tph.TenantID = *value
+			}
+		case tenantparenthistory.FieldPreviousParentID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field previous_parent_id", values[i])
+			} else if value != nil {
+				tph.PreviousParentID = *value
+			}
+		case tenantparenthistory.FieldNewParentID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field new_parent_id", values[i])
+			} else if value != nil {
+				tph.NewParentID = *value
+			}
+		case tenantparenthistory.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				tph.Actor = value.String
+			}
+		case tenantparenthistory.FieldChangedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field changed_at", values[i])
+			} else if value.Valid {
+				tph.ChangedAt = value.Time
+			}
+		default:
+			tph.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantParentHistory.
+// This includes values selected through modifiers, order, etc.
+func (tph *TenantParentHistory) Value(name string) (ent.Value, error) {
+	return tph.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantParentHistory.
+// Note that you need to call TenantParentHistory.Unwrap() before calling this method if this TenantParentHistory
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tph *TenantParentHistory) Update() *TenantParentHistoryUpdateOne {
+	return NewTenantParentHistoryClient(tph.config).UpdateOne(tph)
+}
+
+// Unwrap unwraps the TenantParentHistory entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tph *TenantParentHistory) Unwrap() *TenantParentHistory {
+	_tx, ok := tph.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantParentHistory is not a transactional entity")
+	}
+	tph.config.driver = _tx.drv
+	return tph
+}
+
+// String implements the fmt.Stringer.
+func (tph *TenantParentHistory) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantParentHistory(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tph.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tph.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("previous_parent_id=")
+	builder.WriteString(fmt.Sprintf("%v", tph.PreviousParentID))
+	builder.WriteString(", ")
+	builder.WriteString("new_parent_id=")
+	builder.WriteString(fmt.Sprintf("%v", tph.NewParentID))
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(tph.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("changed_at=")
+	builder.WriteString(tph.ChangedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (tph TenantParentHistory) IsEntity() {}
+
+// TenantParentHistories is a parsable slice of TenantParentHistory.
+type TenantParentHistories []*TenantParentHistory
@@ -0,0 +1,99 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenantparenthistory
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/x/gidx"
+)
+
+const (
+	// Label holds the string label denoting the tenantparenthistory type in the database.
+	Label = "tenant_parent_history"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTenantID holds the string denoting the tenant_id field in the database.
+	FieldTenantID = "tenant_id"
+	// FieldPreviousParentID holds the string denoting the previous_parent_id field in the database.
+	FieldPreviousParentID = "previous_parent_id"
+	// FieldNewParentID holds the string denoting the new_parent_id field in the database.
+	FieldNewParentID = "new_parent_id"
+	// FieldActor holds the string denoting the actor field in the database.
+	FieldActor = "actor"
+	// FieldChangedAt holds the string denoting the changed_at field in the database.
+	FieldChangedAt = "changed_at"
+	// Table holds the table name of the tenantparenthistory in the database.
+	Table = "tenant_parent_history"
+)
+
+// Columns holds all SQL columns for tenantparenthistory fields.
+var Columns = []string{
+	FieldID,
+	FieldTenantID,
+	FieldPreviousParentID,
+	FieldNewParentID,
+	FieldActor,
+	FieldChangedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() gidx.PrefixedID
+)
+
+// OrderOption defines the ordering options for the TenantParentHistory queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTenantID orders the results by the tenant_id field.
+func ByTenantID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTenantID, opts...).ToFunc()
+}
+
+// ByPreviousParentID orders the results by the previous_parent_id field.
+func ByPreviousParentID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPreviousParentID, opts...).ToFunc()
+}
+
+// ByNewParentID orders the results by the new_parent_id field.
+func ByNewParentID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNewParentID, opts...).ToFunc()
+}
+
+// ByActor orders the results by the actor field.
+func ByActor(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldActor, opts...).ToFunc()
+}
+
+// ByChangedAt orders the results by the changed_at field.
+func ByChangedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldChangedAt, opts...).ToFunc()
+}
@@ -0,0 +1,472 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenantparenthistory
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/x/gidx"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldTenantID, v))
+}
+
+// PreviousParentID applies equality check predicate on the "previous_parent_id" field. It's identical to PreviousParentIDEQ.
+func PreviousParentID(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldPreviousParentID, v))
+}
+
+// NewParentID applies equality check predicate on the "new_parent_id" field. It's identical to NewParentIDEQ.
+func NewParentID(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldNewParentID, v))
+}
+
+// Actor applies equality check predicate on the "actor" field. It's identical to ActorEQ.
+func Actor(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldActor, v))
+}
+
+// ChangedAt applies equality check predicate on the "changed_at" field. It's identical to ChangedAtEQ.
+func ChangedAt(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldChangedAt, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenant_id" field.
+func TenantIDContains(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContains(FieldTenantID, vc))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenant_id" field.
+func TenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasPrefix(FieldTenantID, vc))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenant_id" field.
+func TenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasSuffix(FieldTenantID, vc))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenant_id" field.
+func TenantIDEqualFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldEqualFold(FieldTenantID, vc))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenant_id" field.
+func TenantIDContainsFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContainsFold(FieldTenantID, vc))
+}
+
+// PreviousParentIDEQ applies the EQ predicate on the "previous_parent_id" field.
+func PreviousParentIDEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDNEQ applies the NEQ predicate on the "previous_parent_id" field.
+func PreviousParentIDNEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDIn applies the In predicate on the "previous_parent_id" field.
+func PreviousParentIDIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldPreviousParentID, vs...))
+}
+
+// PreviousParentIDNotIn applies the NotIn predicate on the "previous_parent_id" field.
+func PreviousParentIDNotIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldPreviousParentID, vs...))
+}
+
+// PreviousParentIDGT applies the GT predicate on the "previous_parent_id" field.
+func PreviousParentIDGT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDGTE applies the GTE predicate on the "previous_parent_id" field.
+func PreviousParentIDGTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDLT applies the LT predicate on the "previous_parent_id" field.
+func PreviousParentIDLT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDLTE applies the LTE predicate on the "previous_parent_id" field.
+func PreviousParentIDLTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldPreviousParentID, v))
+}
+
+// PreviousParentIDContains applies the Contains predicate on the "previous_parent_id" field.
+func PreviousParentIDContains(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContains(FieldPreviousParentID, vc))
+}
+
+// PreviousParentIDHasPrefix applies the HasPrefix predicate on the "previous_parent_id" field.
+func PreviousParentIDHasPrefix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasPrefix(FieldPreviousParentID, vc))
+}
+
+// PreviousParentIDHasSuffix applies the HasSuffix predicate on the "previous_parent_id" field.
+func PreviousParentIDHasSuffix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasSuffix(FieldPreviousParentID, vc))
+}
+
+// PreviousParentIDIsNil applies the IsNil predicate on the "previous_parent_id" field.
+func PreviousParentIDIsNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIsNull(FieldPreviousParentID))
+}
+
+// PreviousParentIDNotNil applies the NotNil predicate on the "previous_parent_id" field.
+func PreviousParentIDNotNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotNull(FieldPreviousParentID))
+}
+
+// PreviousParentIDEqualFold applies the EqualFold predicate on the "previous_parent_id" field.
+func PreviousParentIDEqualFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldEqualFold(FieldPreviousParentID, vc))
+}
+
+// PreviousParentIDContainsFold applies the ContainsFold predicate on the "previous_parent_id" field.
+func PreviousParentIDContainsFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContainsFold(FieldPreviousParentID, vc))
+}
+
+// NewParentIDEQ applies the EQ predicate on the "new_parent_id" field.
+func NewParentIDEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldNewParentID, v))
+}
+
+// NewParentIDNEQ applies the NEQ predicate on the "new_parent_id" field.
+func NewParentIDNEQ(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldNewParentID, v))
+}
+
+// NewParentIDIn applies the In predicate on the "new_parent_id" field.
+func NewParentIDIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldNewParentID, vs...))
+}
+
+// NewParentIDNotIn applies the NotIn predicate on the "new_parent_id" field.
+func NewParentIDNotIn(vs ...gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldNewParentID, vs...))
+}
+
+// NewParentIDGT applies the GT predicate on the "new_parent_id" field.
+func NewParentIDGT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldNewParentID, v))
+}
+
+// NewParentIDGTE applies the GTE predicate on the "new_parent_id" field.
+func NewParentIDGTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldNewParentID, v))
+}
+
+// NewParentIDLT applies the LT predicate on the "new_parent_id" field.
+func NewParentIDLT(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldNewParentID, v))
+}
+
+// NewParentIDLTE applies the LTE predicate on the "new_parent_id" field.
+func NewParentIDLTE(v gidx.PrefixedID) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldNewParentID, v))
+}
+
+// NewParentIDContains applies the Contains predicate on the "new_parent_id" field.
+func NewParentIDContains(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContains(FieldNewParentID, vc))
+}
+
+// NewParentIDHasPrefix applies the HasPrefix predicate on the "new_parent_id" field.
+func NewParentIDHasPrefix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasPrefix(FieldNewParentID, vc))
+}
+
+// NewParentIDHasSuffix applies the HasSuffix predicate on the "new_parent_id" field.
+func NewParentIDHasSuffix(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldHasSuffix(FieldNewParentID, vc))
+}
+
+// NewParentIDIsNil applies the IsNil predicate on the "new_parent_id" field.
+func NewParentIDIsNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIsNull(FieldNewParentID))
+}
+
+// NewParentIDNotNil applies the NotNil predicate on the "new_parent_id" field.
+func NewParentIDNotNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotNull(FieldNewParentID))
+}
+
+// NewParentIDEqualFold applies the EqualFold predicate on the "new_parent_id" field.
+func NewParentIDEqualFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldEqualFold(FieldNewParentID, vc))
+}
+
+// NewParentIDContainsFold applies the ContainsFold predicate on the "new_parent_id" field.
+func NewParentIDContainsFold(v gidx.PrefixedID) predicate.TenantParentHistory {
+	vc := string(v)
+	return predicate.TenantParentHistory(sql.FieldContainsFold(FieldNewParentID, vc))
+}
+
+// ActorEQ applies the EQ predicate on the "actor" field.
+func ActorEQ(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldActor, v))
+}
+
+// ActorNEQ applies the NEQ predicate on the "actor" field.
+func ActorNEQ(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldActor, v))
+}
+
+// ActorIn applies the In predicate on the "actor" field.
+func ActorIn(vs ...string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldActor, vs...))
+}
+
+// ActorNotIn applies the NotIn predicate on the "actor" field.
+func ActorNotIn(vs ...string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldActor, vs...))
+}
+
+// ActorGT applies the GT predicate on the "actor" field.
+func ActorGT(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldActor, v))
+}
+
+// ActorGTE applies the GTE predicate on the "actor" field.
+func ActorGTE(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldActor, v))
+}
+
+// ActorLT applies the LT predicate on the "actor" field.
+func ActorLT(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldActor, v))
+}
+
+// ActorLTE applies the LTE predicate on the "actor" field.
+func ActorLTE(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldActor, v))
+}
+
+// ActorContains applies the Contains predicate on the "actor" field.
+func ActorContains(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldContains(FieldActor, v))
+}
+
+// ActorHasPrefix applies the HasPrefix predicate on the "actor" field.
+func ActorHasPrefix(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldHasPrefix(FieldActor, v))
+}
+
+// ActorHasSuffix applies the HasSuffix predicate on the "actor" field.
+func ActorHasSuffix(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldHasSuffix(FieldActor, v))
+}
+
+// ActorIsNil applies the IsNil predicate on the "actor" field.
+func ActorIsNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIsNull(FieldActor))
+}
+
+// ActorNotNil applies the NotNil predicate on the "actor" field.
+func ActorNotNil() predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotNull(FieldActor))
+}
+
+// ActorEqualFold applies the EqualFold predicate on the "actor" field.
+func ActorEqualFold(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEqualFold(FieldActor, v))
+}
+
+// ActorContainsFold applies the ContainsFold predicate on the "actor" field.
+func ActorContainsFold(v string) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldContainsFold(FieldActor, v))
+}
+
+// ChangedAtEQ applies the EQ predicate on the "changed_at" field.
+func ChangedAtEQ(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldEQ(FieldChangedAt, v))
+}
+
+// ChangedAtNEQ applies the NEQ predicate on the "changed_at" field.
+func ChangedAtNEQ(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNEQ(FieldChangedAt, v))
+}
+
+// ChangedAtIn applies the In predicate on the "changed_at" field.
+func ChangedAtIn(vs ...time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldIn(FieldChangedAt, vs...))
+}
+
+// ChangedAtNotIn applies the NotIn predicate on the "changed_at" field.
+func ChangedAtNotIn(vs ...time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldNotIn(FieldChangedAt, vs...))
+}
+
+// ChangedAtGT applies the GT predicate on the "changed_at" field.
+func ChangedAtGT(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGT(FieldChangedAt, v))
+}
+
+// ChangedAtGTE applies the GTE predicate on the "changed_at" field.
+func ChangedAtGTE(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldGTE(FieldChangedAt, v))
+}
+
+// ChangedAtLT applies the LT predicate on the "changed_at" field.
+func ChangedAtLT(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLT(FieldChangedAt, v))
+}
+
+// ChangedAtLTE applies the LTE predicate on the "changed_at" field.
+func ChangedAtLTE(v time.Time) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(sql.FieldLTE(FieldChangedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TenantParentHistory) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TenantParentHistory) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TenantParentHistory) predicate.TenantParentHistory {
+	return predicate.TenantParentHistory(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantParentHistoryCreate is the builder for creating a TenantParentHistory entity.
+type TenantParentHistoryCreate struct {
+	config
+	mutation *TenantParentHistoryMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (tphc *TenantParentHistoryCreate) SetTenantID(gi gidx.PrefixedID) *TenantParentHistoryCreate {
+	tphc.mutation.SetTenantID(gi)
+	return tphc
+}
+
+// SetPreviousParentID sets the "previous_parent_id" field.
+func (tphc *TenantParentHistoryCreate) SetPreviousParentID(gi gidx.PrefixedID) *TenantParentHistoryCreate {
+	tphc.mutation.SetPreviousParentID(gi)
+	return tphc
+}
+
+// SetNillablePreviousParentID sets the "previous_parent_id" field if the given value is not nil.
+func (tphc *TenantParentHistoryCreate) SetNillablePreviousParentID(gi *gidx.PrefixedID) *TenantParentHistoryCreate {
+	if gi != nil {
+		tphc.SetPreviousParentID(*gi)
+	}
+	return tphc
+}
+
+// SetNewParentID sets the "new_parent_id" field.
+func (tphc *TenantParentHistoryCreate) SetNewParentID(gi gidx.PrefixedID) *TenantParentHistoryCreate {
+	tphc.mutation.SetNewParentID(gi)
+	return tphc
+}
+
+// SetNillableNewParentID sets the "new_parent_id" field if the given value is not nil.
+func (tphc *TenantParentHistoryCreate) SetNillableNewParentID(gi *gidx.PrefixedID) *TenantParentHistoryCreate {
+	if gi != nil {
+		tphc.SetNewParentID(*gi)
+	}
+	return tphc
+}
+
+// SetActor sets the "actor" field.
+func (tphc *TenantParentHistoryCreate) SetActor(s string) *TenantParentHistoryCreate {
+	tphc.mutation.SetActor(s)
+	return tphc
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (tphc *TenantParentHistoryCreate) SetNillableActor(s *string) *TenantParentHistoryCreate {
+	if s != nil {
+		tphc.SetActor(*s)
+	}
+	return tphc
+}
+
+// SetChangedAt sets the "changed_at" field.
+func (tphc *TenantParentHistoryCreate) SetChangedAt(t time.Time) *TenantParentHistoryCreate {
+	tphc.mutation.SetChangedAt(t)
+	return tphc
+}
+
+// SetID sets the "id" field.
+func (tphc *TenantParentHistoryCreate) SetID(gi gidx.PrefixedID) *TenantParentHistoryCreate {
+	tphc.mutation.SetID(gi)
+	return tphc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (tphc *TenantParentHistoryCreate) SetNillableID(gi *gidx.PrefixedID) *TenantParentHistoryCreate {
+	if gi != nil {
+		tphc.SetID(*gi)
+	}
+	return tphc
+}
+
+// Mutation returns the TenantParentHistoryMutation object of the builder.
+func (tphc *TenantParentHistoryCreate) Mutation() *TenantParentHistoryMutation {
+	return tphc.mutation
+}
+
+// Save creates the TenantParentHistory in the database.
+func (tphc *TenantParentHistoryCreate) Save(ctx context.Context) (*TenantParentHistory, error) {
+	tphc.defaults()
+	return withHooks(ctx, tphc.sqlSave, tphc.mutation, tphc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tphc *TenantParentHistoryCreate) SaveX(ctx context.Context) *TenantParentHistory {
+	v, err := tphc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tphc *TenantParentHistoryCreate) Exec(ctx context.Context) error {
+	_, err := tphc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphc *TenantParentHistoryCreate) ExecX(ctx context.Context) {
+	if err := tphc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tphc *TenantParentHistoryCreate) defaults() {
+	if _, ok := tphc.mutation.ID(); !ok {
+		v := tenantparenthistory.DefaultID()
+		tphc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tphc *TenantParentHistoryCreate) check() error {
+	if _, ok := tphc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "TenantParentHistory.tenant_id"`)}
+	}
+	if _, ok := tphc.mutation.ChangedAt(); !ok {
+		return &ValidationError{Name: "changed_at", err: errors.New(`generated: missing required field "TenantParentHistory.changed_at"`)}
+	}
+	return nil
+}
+
+func (tphc *TenantParentHistoryCreate) sqlSave(ctx context.Context) (*TenantParentHistory, error) {
+	if err := tphc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tphc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tphc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*gidx.PrefixedID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	tphc.mutation.id = &_node.ID
+	tphc.mutation.done = true
+	return _node, nil
+}
+
+func (tphc *TenantParentHistoryCreate) createSpec() (*TenantParentHistory, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantParentHistory{config: tphc.config}
+		_spec = sqlgraph.NewCreateSpec(tenantparenthistory.Table, sqlgraph.NewFieldSpec(tenantparenthistory.FieldID, field.TypeString))
+	)
+	if id, ok := tphc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := tphc.mutation.TenantID(); ok {
+		_spec.SetField(tenantparenthistory.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := tphc.mutation.PreviousParentID(); ok {
+		_spec.SetField(tenantparenthistory.FieldPreviousParentID, field.TypeString, value)
+		_node.PreviousParentID = value
+	}
+	if value, ok := tphc.mutation.NewParentID(); ok {
+		_spec.SetField(tenantparenthistory.FieldNewParentID, field.TypeString, value)
+		_node.NewParentID = value
+	}
+	if value, ok := tphc.mutation.Actor(); ok {
+		_spec.SetField(tenantparenthistory.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := tphc.mutation.ChangedAt(); ok {
+		_spec.SetField(tenantparenthistory.FieldChangedAt, field.TypeTime, value)
+		_node.ChangedAt = value
+	}
+	return _node, _spec
+}
+
+// TenantParentHistoryCreateBulk is the builder for creating many TenantParentHistory entities in bulk.
+type TenantParentHistoryCreateBulk struct {
+	config
+	builders []*TenantParentHistoryCreate
+}
+
+// Save creates the TenantParentHistory entities in the database.
+func (tphcb *TenantParentHistoryCreateBulk) Save(ctx context.Context) ([]*TenantParentHistory, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tphcb.builders))
+	nodes := make([]*TenantParentHistory, len(tphcb.builders))
+	mutators := make([]Mutator, len(tphcb.builders))
+	for i := range tphcb.builders {
+		func(i int, root context.Context) {
+			builder := tphcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantParentHistoryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tphcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tphcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tphcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tphcb *TenantParentHistoryCreateBulk) SaveX(ctx context.Context) []*TenantParentHistory {
+	v, err := tphcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tphcb *TenantParentHistoryCreateBulk) Exec(ctx context.Context) error {
+	_, err := tphcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphcb *TenantParentHistoryCreateBulk) ExecX(ctx context.Context) {
+	if err := tphcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
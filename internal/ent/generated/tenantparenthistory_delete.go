@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+)
+
+// TenantParentHistoryDelete is the builder for deleting a TenantParentHistory entity.
+type TenantParentHistoryDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantParentHistoryMutation
+}
+
+// Where appends a list predicates to the TenantParentHistoryDelete builder.
+func (tphd *TenantParentHistoryDelete) Where(ps ...predicate.TenantParentHistory) *TenantParentHistoryDelete {
+	tphd.mutation.Where(ps...)
+	return tphd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (tphd *TenantParentHistoryDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, tphd.sqlExec, tphd.mutation, tphd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphd *TenantParentHistoryDelete) ExecX(ctx context.Context) int {
+	n, err := tphd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (tphd *TenantParentHistoryDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenantparenthistory.Table, sqlgraph.NewFieldSpec(tenantparenthistory.FieldID, field.TypeString))
+	if ps := tphd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, tphd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	tphd.mutation.done = true
+	return affected, err
+}
+
+// TenantParentHistoryDeleteOne is the builder for deleting a single TenantParentHistory entity.
+type TenantParentHistoryDeleteOne struct {
+	tphd *TenantParentHistoryDelete
+}
+
+// Where appends a list predicates to the TenantParentHistoryDelete builder.
+func (tphdo *TenantParentHistoryDeleteOne) Where(ps ...predicate.TenantParentHistory) *TenantParentHistoryDeleteOne {
+	tphdo.tphd.mutation.Where(ps...)
+	return tphdo
+}
+
+// Exec executes the deletion query.
+func (tphdo *TenantParentHistoryDeleteOne) Exec(ctx context.Context) error {
+	n, err := tphdo.tphd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenantparenthistory.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphdo *TenantParentHistoryDeleteOne) ExecX(ctx context.Context) {
+	if err := tphdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,554 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantParentHistoryQuery is the builder for querying TenantParentHistory entities.
+type TenantParentHistoryQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenantparenthistory.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantParentHistory
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantParentHistory) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantParentHistoryQuery builder.
+func (tphq *TenantParentHistoryQuery) Where(ps ...predicate.TenantParentHistory) *TenantParentHistoryQuery {
+	tphq.predicates = append(tphq.predicates, ps...)
+	return tphq
+}
+
+// Limit the number of records to be returned by this query.
+func (tphq *TenantParentHistoryQuery) Limit(limit int) *TenantParentHistoryQuery {
+	tphq.ctx.Limit = &limit
+	return tphq
+}
+
+// Offset to start from.
+func (tphq *TenantParentHistoryQuery) Offset(offset int) *TenantParentHistoryQuery {
+	tphq.ctx.Offset = &offset
+	return tphq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (tphq *TenantParentHistoryQuery) Unique(unique bool) *TenantParentHistoryQuery {
+	tphq.ctx.Unique = &unique
+	return tphq
+}
+
+// Order specifies how the records should be ordered.
+func (tphq *TenantParentHistoryQuery) Order(o ...tenantparenthistory.OrderOption) *TenantParentHistoryQuery {
+	tphq.order = append(tphq.order, o...)
+	return tphq
+}
+
+// First returns the first TenantParentHistory entity from the query.
+// Returns a *NotFoundError when no TenantParentHistory was found.
+func (tphq *TenantParentHistoryQuery) First(ctx context.Context) (*TenantParentHistory, error) {
+	nodes, err := tphq.Limit(1).All(setContextOp(ctx, tphq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenantparenthistory.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) FirstX(ctx context.Context) *TenantParentHistory {
+	node, err := tphq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantParentHistory ID from the query.
+// Returns a *NotFoundError when no TenantParentHistory ID was found.
+func (tphq *TenantParentHistoryQuery) FirstID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = tphq.Limit(1).IDs(setContextOp(ctx, tphq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenantparenthistory.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) FirstIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := tphq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantParentHistory entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantParentHistory entity is found.
+// Returns a *NotFoundError when no TenantParentHistory entities are found.
+func (tphq *TenantParentHistoryQuery) Only(ctx context.Context) (*TenantParentHistory, error) {
+	nodes, err := tphq.Limit(2).All(setContextOp(ctx, tphq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenantparenthistory.Label}
+	default:
+		return nil, &NotSingularError{tenantparenthistory.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) OnlyX(ctx context.Context) *TenantParentHistory {
+	node, err := tphq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantParentHistory ID in the query.
+// Returns a *NotSingularError when more than one TenantParentHistory ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (tphq *TenantParentHistoryQuery) OnlyID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = tphq.Limit(2).IDs(setContextOp(ctx, tphq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenantparenthistory.Label}
+	default:
+		err = &NotSingularError{tenantparenthistory.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) OnlyIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := tphq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantParentHistories.
+func (tphq *TenantParentHistoryQuery) All(ctx context.Context) ([]*TenantParentHistory, error) {
+	ctx = setContextOp(ctx, tphq.ctx, "All")
+	if err := tphq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantParentHistory, *TenantParentHistoryQuery]()
+	return withInterceptors[[]*TenantParentHistory](ctx, tphq, qr, tphq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) AllX(ctx context.Context) []*TenantParentHistory {
+	nodes, err := tphq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantParentHistory IDs.
+func (tphq *TenantParentHistoryQuery) IDs(ctx context.Context) (ids []gidx.PrefixedID, err error) {
+	if tphq.ctx.Unique == nil && tphq.path != nil {
+		tphq.Unique(true)
+	}
+	ctx = setContextOp(ctx, tphq.ctx, "IDs")
+	if err = tphq.Select(tenantparenthistory.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) IDsX(ctx context.Context) []gidx.PrefixedID {
+	ids, err := tphq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (tphq *TenantParentHistoryQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, tphq.ctx, "Count")
+	if err := tphq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, tphq, querierCount[*TenantParentHistoryQuery](), tphq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) CountX(ctx context.Context) int {
+	count, err := tphq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (tphq *TenantParentHistoryQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, tphq.ctx, "Exist")
+	switch _, err := tphq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (tphq *TenantParentHistoryQuery) ExistX(ctx context.Context) bool {
+	exist, err := tphq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantParentHistoryQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (tphq *TenantParentHistoryQuery) Clone() *TenantParentHistoryQuery {
+	if tphq == nil {
+		return nil
+	}
+	return &TenantParentHistoryQuery{
+		config:     tphq.config,
+		ctx:        tphq.ctx.Clone(),
+		order:      append([]tenantparenthistory.OrderOption{}, tphq.order...),
+		inters:     append([]Interceptor{}, tphq.inters...),
+		predicates: append([]predicate.TenantParentHistory{}, tphq.predicates...),
+		// clone intermediate query.
+		sql:  tphq.sql.Clone(),
+		path: tphq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantParentHistory.Query().
+//		GroupBy(tenantparenthistory.FieldTenantID).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (tphq *TenantParentHistoryQuery) GroupBy(field string, fields ...string) *TenantParentHistoryGroupBy {
+	tphq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantParentHistoryGroupBy{build: tphq}
+	grbuild.flds = &tphq.ctx.Fields
+	grbuild.label = tenantparenthistory.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//	}
+//
+//	client.TenantParentHistory.Query().
+//		Select(tenantparenthistory.FieldTenantID).
+//		Scan(ctx, &v)
+func (tphq *TenantParentHistoryQuery) Select(fields ...string) *TenantParentHistorySelect {
+	tphq.ctx.Fields = append(tphq.ctx.Fields, fields...)
+	sbuild := &TenantParentHistorySelect{TenantParentHistoryQuery: tphq}
+	sbuild.label = tenantparenthistory.Label
+	sbuild.flds, sbuild.scan = &tphq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantParentHistorySelect configured with the given aggregations.
+func (tphq *TenantParentHistoryQuery) Aggregate(fns ...AggregateFunc) *TenantParentHistorySelect {
+	return tphq.Select().Aggregate(fns...)
+}
+
+func (tphq *TenantParentHistoryQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range tphq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, tphq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range tphq.ctx.Fields {
+		if !tenantparenthistory.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if tphq.path != nil {
+		prev, err := tphq.path(ctx)
+		if err != nil {
+			return err
+		}
+		tphq.sql = prev
+	}
+	return nil
+}
+
+func (tphq *TenantParentHistoryQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantParentHistory, error) {
+	var (
+		nodes = []*TenantParentHistory{}
+		_spec = tphq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantParentHistory).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantParentHistory{config: tphq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(tphq.modifiers) > 0 {
+		_spec.Modifiers = tphq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, tphq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range tphq.loadTotal {
+		if err := tphq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (tphq *TenantParentHistoryQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := tphq.querySpec()
+	if len(tphq.modifiers) > 0 {
+		_spec.Modifiers = tphq.modifiers
+	}
+	_spec.Node.Columns = tphq.ctx.Fields
+	if len(tphq.ctx.Fields) > 0 {
+		_spec.Unique = tphq.ctx.Unique != nil && *tphq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, tphq.driver, _spec)
+}
+
+func (tphq *TenantParentHistoryQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenantparenthistory.Table, tenantparenthistory.Columns, sqlgraph.NewFieldSpec(tenantparenthistory.FieldID, field.TypeString))
+	_spec.From = tphq.sql
+	if unique := tphq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if tphq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := tphq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantparenthistory.FieldID)
+		for i := range fields {
+			if fields[i] != tenantparenthistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := tphq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := tphq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := tphq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := tphq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (tphq *TenantParentHistoryQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(tphq.driver.Dialect())
+	t1 := builder.Table(tenantparenthistory.Table)
+	columns := tphq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenantparenthistory.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if tphq.sql != nil {
+		selector = tphq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if tphq.ctx.Unique != nil && *tphq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range tphq.predicates {
+		p(selector)
+	}
+	for _, p := range tphq.order {
+		p(selector)
+	}
+	if offset := tphq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := tphq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantParentHistoryGroupBy is the group-by builder for TenantParentHistory entities.
+type TenantParentHistoryGroupBy struct {
+	selector
+	build *TenantParentHistoryQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (tphgb *TenantParentHistoryGroupBy) Aggregate(fns ...AggregateFunc) *TenantParentHistoryGroupBy {
+	tphgb.fns = append(tphgb.fns, fns...)
+	return tphgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tphgb *TenantParentHistoryGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tphgb.build.ctx, "GroupBy")
+	if err := tphgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantParentHistoryQuery, *TenantParentHistoryGroupBy](ctx, tphgb.build, tphgb, tphgb.build.inters, v)
+}
+
+func (tphgb *TenantParentHistoryGroupBy) sqlScan(ctx context.Context, root *TenantParentHistoryQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(tphgb.fns))
+	for _, fn := range tphgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*tphgb.flds)+len(tphgb.fns))
+		for _, f := range *tphgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*tphgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tphgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantParentHistorySelect is the builder for selecting fields of TenantParentHistory entities.
+type TenantParentHistorySelect struct {
+	*TenantParentHistoryQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tphs *TenantParentHistorySelect) Aggregate(fns ...AggregateFunc) *TenantParentHistorySelect {
+	tphs.fns = append(tphs.fns, fns...)
+	return tphs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tphs *TenantParentHistorySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tphs.ctx, "Select")
+	if err := tphs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantParentHistoryQuery, *TenantParentHistorySelect](ctx, tphs.TenantParentHistoryQuery, tphs, tphs.inters, v)
+}
+
+func (tphs *TenantParentHistorySelect) sqlScan(ctx context.Context, root *TenantParentHistoryQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tphs.fns))
+	for _, fn := range tphs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tphs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tphs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
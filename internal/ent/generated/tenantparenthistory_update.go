@@ -0,0 +1,207 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+)
+
+// TenantParentHistoryUpdate is the builder for updating TenantParentHistory entities.
+type TenantParentHistoryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantParentHistoryMutation
+}
+
+// Where appends a list predicates to the TenantParentHistoryUpdate builder.
+func (tphu *TenantParentHistoryUpdate) Where(ps ...predicate.TenantParentHistory) *TenantParentHistoryUpdate {
+	tphu.mutation.Where(ps...)
+	return tphu
+}
+
+// Mutation returns the TenantParentHistoryMutation object of the builder.
+func (tphu *TenantParentHistoryUpdate) Mutation() *TenantParentHistoryMutation {
+	return tphu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tphu *TenantParentHistoryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, tphu.sqlSave, tphu.mutation, tphu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tphu *TenantParentHistoryUpdate) SaveX(ctx context.Context) int {
+	affected, err := tphu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tphu *TenantParentHistoryUpdate) Exec(ctx context.Context) error {
+	_, err := tphu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphu *TenantParentHistoryUpdate) ExecX(ctx context.Context) {
+	if err := tphu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tphu *TenantParentHistoryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantparenthistory.Table, tenantparenthistory.Columns, sqlgraph.NewFieldSpec(tenantparenthistory.FieldID, field.TypeString))
+	if ps := tphu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tphu.mutation.PreviousParentIDCleared() {
+		_spec.ClearField(tenantparenthistory.FieldPreviousParentID, field.TypeString)
+	}
+	if tphu.mutation.NewParentIDCleared() {
+		_spec.ClearField(tenantparenthistory.FieldNewParentID, field.TypeString)
+	}
+	if tphu.mutation.ActorCleared() {
+		_spec.ClearField(tenantparenthistory.FieldActor, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tphu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantparenthistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tphu.mutation.done = true
+	return n, nil
+}
+
+// TenantParentHistoryUpdateOne is the builder for updating a single TenantParentHistory entity.
+type TenantParentHistoryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantParentHistoryMutation
+}
+
+// Mutation returns the TenantParentHistoryMutation object of the builder.
+func (tphuo *TenantParentHistoryUpdateOne) Mutation() *TenantParentHistoryMutation {
+	return tphuo.mutation
+}
+
+// Where appends a list predicates to the TenantParentHistoryUpdate builder.
+func (tphuo *TenantParentHistoryUpdateOne) Where(ps ...predicate.TenantParentHistory) *TenantParentHistoryUpdateOne {
+	tphuo.mutation.Where(ps...)
+	return tphuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tphuo *TenantParentHistoryUpdateOne) Select(field string, fields ...string) *TenantParentHistoryUpdateOne {
+	tphuo.fields = append([]string{field}, fields...)
+	return tphuo
+}
+
+// Save executes the query and returns the updated TenantParentHistory entity.
+func (tphuo *TenantParentHistoryUpdateOne) Save(ctx context.Context) (*TenantParentHistory, error) {
+	return withHooks(ctx, tphuo.sqlSave, tphuo.mutation, tphuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tphuo *TenantParentHistoryUpdateOne) SaveX(ctx context.Context) *TenantParentHistory {
+	node, err := tphuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tphuo *TenantParentHistoryUpdateOne) Exec(ctx context.Context) error {
+	_, err := tphuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tphuo *TenantParentHistoryUpdateOne) ExecX(ctx context.Context) {
+	if err := tphuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (tphuo *TenantParentHistoryUpdateOne) sqlSave(ctx context.Context) (_node *TenantParentHistory, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenantparenthistory.Table, tenantparenthistory.Columns, sqlgraph.NewFieldSpec(tenantparenthistory.FieldID, field.TypeString))
+	id, ok := tphuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantParentHistory.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tphuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantparenthistory.FieldID)
+		for _, f := range fields {
+			if !tenantparenthistory.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenantparenthistory.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tphuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if tphuo.mutation.PreviousParentIDCleared() {
+		_spec.ClearField(tenantparenthistory.FieldPreviousParentID, field.TypeString)
+	}
+	if tphuo.mutation.NewParentIDCleared() {
+		_spec.ClearField(tenantparenthistory.FieldNewParentID, field.TypeString)
+	}
+	if tphuo.mutation.ActorCleared() {
+		_spec.ClearField(tenantparenthistory.FieldActor, field.TypeString)
+	}
+	_node = &TenantParentHistory{config: tphuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tphuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantparenthistory.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tphuo.mutation.done = true
+	return _node, nil
+}
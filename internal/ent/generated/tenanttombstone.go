@@ -0,0 +1,158 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/x/gidx"
+)
+
+// The record kept of deleted tenants.
+type TenantTombstone struct {
+	config `json:"-"`
+	// ID of the ent.
+	// The ID of the deleted tenant.
+	ID gidx.PrefixedID `json:"id,omitempty"`
+	// The name of the tenant when it was deleted.
+	Name string `json:"name,omitempty"`
+	// The ID of the parent of the tenant when it was deleted, empty for roots.
+	ParentTenantID gidx.PrefixedID `json:"parent_tenant_id,omitempty"`
+	// The time the deletion took effect, when it was due for scheduled deletions.
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+	// The time the tenant was removed from the database.
+	PurgedAt     time.Time `json:"purged_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantTombstone) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenanttombstone.FieldID, tenanttombstone.FieldParentTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case tenanttombstone.FieldName:
+			values[i] = new(sql.NullString)
+		case tenanttombstone.FieldDeletedAt, tenanttombstone.FieldPurgedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantTombstone fields.
+func (tt *TenantTombstone) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenanttombstone.FieldID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				tt.ID = *value
+			}
+		case tenanttombstone.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				tt.Name = value.String
+			}
+		case tenanttombstone.FieldParentTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field parent_tenant_id", values[i])
+			} else if value != nil {
+				tt.ParentTenantID = *value
+			}
+		case tenanttombstone.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				tt.DeletedAt = value.Time
+			}
+		case tenanttombstone.FieldPurgedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field purged_at", values[i])
+			} else if value.Valid {
+				tt.PurgedAt = value.Time
+			}
+		default:
+			tt.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantTombstone.
+// This includes values selected through modifiers, order, etc.
+func (tt *TenantTombstone) Value(name string) (ent.Value, error) {
+	return tt.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantTombstone.
+// Note that you need to call TenantTombstone.Unwrap() before calling this method if this TenantTombstone
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tt *TenantTombstone) Update() *TenantTombstoneUpdateOne {
+	return NewTenantTombstoneClient(tt.config).UpdateOne(tt)
+}
+
+// Unwrap unwraps the TenantTombstone entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tt *TenantTombstone) Unwrap() *TenantTombstone {
+	_tx, ok := tt.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantTombstone is not a transactional entity")
+	}
+	tt.config.driver = _tx.drv
+	return tt
+}
+
+// String implements the fmt.Stringer.
+func (tt *TenantTombstone) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantTombstone(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tt.ID))
+	builder.WriteString("name=")
+	builder.WriteString(tt.Name)
+	builder.WriteString(", ")
+	builder.WriteString("parent_tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tt.ParentTenantID))
+	builder.WriteString(", ")
+	builder.WriteString("deleted_at=")
+	builder.WriteString(tt.DeletedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("purged_at=")
+	builder.WriteString(tt.PurgedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (tt TenantTombstone) IsEntity() {}
+
+// TenantTombstones is a parsable slice of TenantTombstone.
+type TenantTombstones []*TenantTombstone
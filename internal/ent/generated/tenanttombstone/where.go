@@ -0,0 +1,347 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenanttombstone
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/x/gidx"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLTE(FieldID, id))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldName, v))
+}
+
+// ParentTenantID applies equality check predicate on the "parent_tenant_id" field. It's identical to ParentTenantIDEQ.
+func ParentTenantID(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldParentTenantID, v))
+}
+
+// DeletedAt applies equality check predicate on the "deleted_at" field. It's identical to DeletedAtEQ.
+func DeletedAt(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// PurgedAt applies equality check predicate on the "purged_at" field. It's identical to PurgedAtEQ.
+func PurgedAt(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldPurgedAt, v))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldContainsFold(FieldName, v))
+}
+
+// ParentTenantIDEQ applies the EQ predicate on the "parent_tenant_id" field.
+func ParentTenantIDEQ(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldParentTenantID, v))
+}
+
+// ParentTenantIDNEQ applies the NEQ predicate on the "parent_tenant_id" field.
+func ParentTenantIDNEQ(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNEQ(FieldParentTenantID, v))
+}
+
+// ParentTenantIDIn applies the In predicate on the "parent_tenant_id" field.
+func ParentTenantIDIn(vs ...gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIn(FieldParentTenantID, vs...))
+}
+
+// ParentTenantIDNotIn applies the NotIn predicate on the "parent_tenant_id" field.
+func ParentTenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotIn(FieldParentTenantID, vs...))
+}
+
+// ParentTenantIDGT applies the GT predicate on the "parent_tenant_id" field.
+func ParentTenantIDGT(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGT(FieldParentTenantID, v))
+}
+
+// ParentTenantIDGTE applies the GTE predicate on the "parent_tenant_id" field.
+func ParentTenantIDGTE(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGTE(FieldParentTenantID, v))
+}
+
+// ParentTenantIDLT applies the LT predicate on the "parent_tenant_id" field.
+func ParentTenantIDLT(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLT(FieldParentTenantID, v))
+}
+
+// ParentTenantIDLTE applies the LTE predicate on the "parent_tenant_id" field.
+func ParentTenantIDLTE(v gidx.PrefixedID) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLTE(FieldParentTenantID, v))
+}
+
+// ParentTenantIDContains applies the Contains predicate on the "parent_tenant_id" field.
+func ParentTenantIDContains(v gidx.PrefixedID) predicate.TenantTombstone {
+	vc := string(v)
+	return predicate.TenantTombstone(sql.FieldContains(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDHasPrefix applies the HasPrefix predicate on the "parent_tenant_id" field.
+func ParentTenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantTombstone {
+	vc := string(v)
+	return predicate.TenantTombstone(sql.FieldHasPrefix(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDHasSuffix applies the HasSuffix predicate on the "parent_tenant_id" field.
+func ParentTenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantTombstone {
+	vc := string(v)
+	return predicate.TenantTombstone(sql.FieldHasSuffix(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDIsNil applies the IsNil predicate on the "parent_tenant_id" field.
+func ParentTenantIDIsNil() predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIsNull(FieldParentTenantID))
+}
+
+// ParentTenantIDNotNil applies the NotNil predicate on the "parent_tenant_id" field.
+func ParentTenantIDNotNil() predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotNull(FieldParentTenantID))
+}
+
+// ParentTenantIDEqualFold applies the EqualFold predicate on the "parent_tenant_id" field.
+func ParentTenantIDEqualFold(v gidx.PrefixedID) predicate.TenantTombstone {
+	vc := string(v)
+	return predicate.TenantTombstone(sql.FieldEqualFold(FieldParentTenantID, vc))
+}
+
+// ParentTenantIDContainsFold applies the ContainsFold predicate on the "parent_tenant_id" field.
+func ParentTenantIDContainsFold(v gidx.PrefixedID) predicate.TenantTombstone {
+	vc := string(v)
+	return predicate.TenantTombstone(sql.FieldContainsFold(FieldParentTenantID, vc))
+}
+
+// DeletedAtEQ applies the EQ predicate on the "deleted_at" field.
+func DeletedAtEQ(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtNEQ applies the NEQ predicate on the "deleted_at" field.
+func DeletedAtNEQ(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtIn applies the In predicate on the "deleted_at" field.
+func DeletedAtIn(vs ...time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtNotIn applies the NotIn predicate on the "deleted_at" field.
+func DeletedAtNotIn(vs ...time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtGT applies the GT predicate on the "deleted_at" field.
+func DeletedAtGT(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGT(FieldDeletedAt, v))
+}
+
+// DeletedAtGTE applies the GTE predicate on the "deleted_at" field.
+func DeletedAtGTE(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGTE(FieldDeletedAt, v))
+}
+
+// DeletedAtLT applies the LT predicate on the "deleted_at" field.
+func DeletedAtLT(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLT(FieldDeletedAt, v))
+}
+
+// DeletedAtLTE applies the LTE predicate on the "deleted_at" field.
+func DeletedAtLTE(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLTE(FieldDeletedAt, v))
+}
+
+// PurgedAtEQ applies the EQ predicate on the "purged_at" field.
+func PurgedAtEQ(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldEQ(FieldPurgedAt, v))
+}
+
+// PurgedAtNEQ applies the NEQ predicate on the "purged_at" field.
+func PurgedAtNEQ(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNEQ(FieldPurgedAt, v))
+}
+
+// PurgedAtIn applies the In predicate on the "purged_at" field.
+func PurgedAtIn(vs ...time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldIn(FieldPurgedAt, vs...))
+}
+
+// PurgedAtNotIn applies the NotIn predicate on the "purged_at" field.
+func PurgedAtNotIn(vs ...time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldNotIn(FieldPurgedAt, vs...))
+}
+
+// PurgedAtGT applies the GT predicate on the "purged_at" field.
+func PurgedAtGT(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGT(FieldPurgedAt, v))
+}
+
+// PurgedAtGTE applies the GTE predicate on the "purged_at" field.
+func PurgedAtGTE(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldGTE(FieldPurgedAt, v))
+}
+
+// PurgedAtLT applies the LT predicate on the "purged_at" field.
+func PurgedAtLT(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLT(FieldPurgedAt, v))
+}
+
+// PurgedAtLTE applies the LTE predicate on the "purged_at" field.
+func PurgedAtLTE(v time.Time) predicate.TenantTombstone {
+	return predicate.TenantTombstone(sql.FieldLTE(FieldPurgedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TenantTombstone) predicate.TenantTombstone {
+	return predicate.TenantTombstone(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TenantTombstone) predicate.TenantTombstone {
+	return predicate.TenantTombstone(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TenantTombstone) predicate.TenantTombstone {
+	return predicate.TenantTombstone(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantTombstoneCreate is the builder for creating a TenantTombstone entity.
+type TenantTombstoneCreate struct {
+	config
+	mutation *TenantTombstoneMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (ttc *TenantTombstoneCreate) SetName(s string) *TenantTombstoneCreate {
+	ttc.mutation.SetName(s)
+	return ttc
+}
+
+// SetParentTenantID sets the "parent_tenant_id" field.
+func (ttc *TenantTombstoneCreate) SetParentTenantID(gi gidx.PrefixedID) *TenantTombstoneCreate {
+	ttc.mutation.SetParentTenantID(gi)
+	return ttc
+}
+
+// SetNillableParentTenantID sets the "parent_tenant_id" field if the given value is not nil.
+func (ttc *TenantTombstoneCreate) SetNillableParentTenantID(gi *gidx.PrefixedID) *TenantTombstoneCreate {
+	if gi != nil {
+		ttc.SetParentTenantID(*gi)
+	}
+	return ttc
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (ttc *TenantTombstoneCreate) SetDeletedAt(t time.Time) *TenantTombstoneCreate {
+	ttc.mutation.SetDeletedAt(t)
+	return ttc
+}
+
+// SetPurgedAt sets the "purged_at" field.
+func (ttc *TenantTombstoneCreate) SetPurgedAt(t time.Time) *TenantTombstoneCreate {
+	ttc.mutation.SetPurgedAt(t)
+	return ttc
+}
+
+// SetID sets the "id" field.
+func (ttc *TenantTombstoneCreate) SetID(gi gidx.PrefixedID) *TenantTombstoneCreate {
+	ttc.mutation.SetID(gi)
+	return ttc
+}
+
+// Mutation returns the TenantTombstoneMutation object of the builder.
+func (ttc *TenantTombstoneCreate) Mutation() *TenantTombstoneMutation {
+	return ttc.mutation
+}
+
+// Save creates the TenantTombstone in the database.
+func (ttc *TenantTombstoneCreate) Save(ctx context.Context) (*TenantTombstone, error) {
+	return withHooks(ctx, ttc.sqlSave, ttc.mutation, ttc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ttc *TenantTombstoneCreate) SaveX(ctx context.Context) *TenantTombstone {
+	v, err := ttc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ttc *TenantTombstoneCreate) Exec(ctx context.Context) error {
+	_, err := ttc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttc *TenantTombstoneCreate) ExecX(ctx context.Context) {
+	if err := ttc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ttc *TenantTombstoneCreate) check() error {
+	if _, ok := ttc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`generated: missing required field "TenantTombstone.name"`)}
+	}
+	if _, ok := ttc.mutation.DeletedAt(); !ok {
+		return &ValidationError{Name: "deleted_at", err: errors.New(`generated: missing required field "TenantTombstone.deleted_at"`)}
+	}
+	if _, ok := ttc.mutation.PurgedAt(); !ok {
+		return &ValidationError{Name: "purged_at", err: errors.New(`generated: missing required field "TenantTombstone.purged_at"`)}
+	}
+	return nil
+}
+
+func (ttc *TenantTombstoneCreate) sqlSave(ctx context.Context) (*TenantTombstone, error) {
+	if err := ttc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ttc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ttc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*gidx.PrefixedID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	ttc.mutation.id = &_node.ID
+	ttc.mutation.done = true
+	return _node, nil
+}
+
+func (ttc *TenantTombstoneCreate) createSpec() (*TenantTombstone, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantTombstone{config: ttc.config}
+		_spec = sqlgraph.NewCreateSpec(tenanttombstone.Table, sqlgraph.NewFieldSpec(tenanttombstone.FieldID, field.TypeString))
+	)
+	if id, ok := ttc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := ttc.mutation.Name(); ok {
+		_spec.SetField(tenanttombstone.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := ttc.mutation.ParentTenantID(); ok {
+		_spec.SetField(tenanttombstone.FieldParentTenantID, field.TypeString, value)
+		_node.ParentTenantID = value
+	}
+	if value, ok := ttc.mutation.DeletedAt(); ok {
+		_spec.SetField(tenanttombstone.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = value
+	}
+	if value, ok := ttc.mutation.PurgedAt(); ok {
+		_spec.SetField(tenanttombstone.FieldPurgedAt, field.TypeTime, value)
+		_node.PurgedAt = value
+	}
+	return _node, _spec
+}
+
+// TenantTombstoneCreateBulk is the builder for creating many TenantTombstone entities in bulk.
+type TenantTombstoneCreateBulk struct {
+	config
+	builders []*TenantTombstoneCreate
+}
+
+// Save creates the TenantTombstone entities in the database.
+func (ttcb *TenantTombstoneCreateBulk) Save(ctx context.Context) ([]*TenantTombstone, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(ttcb.builders))
+	nodes := make([]*TenantTombstone, len(ttcb.builders))
+	mutators := make([]Mutator, len(ttcb.builders))
+	for i := range ttcb.builders {
+		func(i int, root context.Context) {
+			builder := ttcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantTombstoneMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ttcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ttcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ttcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ttcb *TenantTombstoneCreateBulk) SaveX(ctx context.Context) []*TenantTombstone {
+	v, err := ttcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ttcb *TenantTombstoneCreateBulk) Exec(ctx context.Context) error {
+	_, err := ttcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttcb *TenantTombstoneCreateBulk) ExecX(ctx context.Context) {
+	if err := ttcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+)
+
+// TenantTombstoneDelete is the builder for deleting a TenantTombstone entity.
+type TenantTombstoneDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantTombstoneMutation
+}
+
+// Where appends a list predicates to the TenantTombstoneDelete builder.
+func (ttd *TenantTombstoneDelete) Where(ps ...predicate.TenantTombstone) *TenantTombstoneDelete {
+	ttd.mutation.Where(ps...)
+	return ttd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ttd *TenantTombstoneDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ttd.sqlExec, ttd.mutation, ttd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttd *TenantTombstoneDelete) ExecX(ctx context.Context) int {
+	n, err := ttd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ttd *TenantTombstoneDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenanttombstone.Table, sqlgraph.NewFieldSpec(tenanttombstone.FieldID, field.TypeString))
+	if ps := ttd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ttd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ttd.mutation.done = true
+	return affected, err
+}
+
+// TenantTombstoneDeleteOne is the builder for deleting a single TenantTombstone entity.
+type TenantTombstoneDeleteOne struct {
+	ttd *TenantTombstoneDelete
+}
+
+// Where appends a list predicates to the TenantTombstoneDelete builder.
+func (ttdo *TenantTombstoneDeleteOne) Where(ps ...predicate.TenantTombstone) *TenantTombstoneDeleteOne {
+	ttdo.ttd.mutation.Where(ps...)
+	return ttdo
+}
+
+// Exec executes the deletion query.
+func (ttdo *TenantTombstoneDeleteOne) Exec(ctx context.Context) error {
+	n, err := ttdo.ttd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenanttombstone.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttdo *TenantTombstoneDeleteOne) ExecX(ctx context.Context) {
+	if err := ttdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,554 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantTombstoneQuery is the builder for querying TenantTombstone entities.
+type TenantTombstoneQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenanttombstone.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantTombstone
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantTombstone) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantTombstoneQuery builder.
+func (ttq *TenantTombstoneQuery) Where(ps ...predicate.TenantTombstone) *TenantTombstoneQuery {
+	ttq.predicates = append(ttq.predicates, ps...)
+	return ttq
+}
+
+// Limit the number of records to be returned by this query.
+func (ttq *TenantTombstoneQuery) Limit(limit int) *TenantTombstoneQuery {
+	ttq.ctx.Limit = &limit
+	return ttq
+}
+
+// Offset to start from.
+func (ttq *TenantTombstoneQuery) Offset(offset int) *TenantTombstoneQuery {
+	ttq.ctx.Offset = &offset
+	return ttq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (ttq *TenantTombstoneQuery) Unique(unique bool) *TenantTombstoneQuery {
+	ttq.ctx.Unique = &unique
+	return ttq
+}
+
+// Order specifies how the records should be ordered.
+func (ttq *TenantTombstoneQuery) Order(o ...tenanttombstone.OrderOption) *TenantTombstoneQuery {
+	ttq.order = append(ttq.order, o...)
+	return ttq
+}
+
+// First returns the first TenantTombstone entity from the query.
+// Returns a *NotFoundError when no TenantTombstone was found.
+func (ttq *TenantTombstoneQuery) First(ctx context.Context) (*TenantTombstone, error) {
+	nodes, err := ttq.Limit(1).All(setContextOp(ctx, ttq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenanttombstone.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) FirstX(ctx context.Context) *TenantTombstone {
+	node, err := ttq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantTombstone ID from the query.
+// Returns a *NotFoundError when no TenantTombstone ID was found.
+func (ttq *TenantTombstoneQuery) FirstID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = ttq.Limit(1).IDs(setContextOp(ctx, ttq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenanttombstone.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) FirstIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := ttq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantTombstone entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantTombstone entity is found.
+// Returns a *NotFoundError when no TenantTombstone entities are found.
+func (ttq *TenantTombstoneQuery) Only(ctx context.Context) (*TenantTombstone, error) {
+	nodes, err := ttq.Limit(2).All(setContextOp(ctx, ttq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenanttombstone.Label}
+	default:
+		return nil, &NotSingularError{tenanttombstone.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) OnlyX(ctx context.Context) *TenantTombstone {
+	node, err := ttq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantTombstone ID in the query.
+// Returns a *NotSingularError when more than one TenantTombstone ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (ttq *TenantTombstoneQuery) OnlyID(ctx context.Context) (id gidx.PrefixedID, err error) {
+	var ids []gidx.PrefixedID
+	if ids, err = ttq.Limit(2).IDs(setContextOp(ctx, ttq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenanttombstone.Label}
+	default:
+		err = &NotSingularError{tenanttombstone.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) OnlyIDX(ctx context.Context) gidx.PrefixedID {
+	id, err := ttq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantTombstones.
+func (ttq *TenantTombstoneQuery) All(ctx context.Context) ([]*TenantTombstone, error) {
+	ctx = setContextOp(ctx, ttq.ctx, "All")
+	if err := ttq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantTombstone, *TenantTombstoneQuery]()
+	return withInterceptors[[]*TenantTombstone](ctx, ttq, qr, ttq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) AllX(ctx context.Context) []*TenantTombstone {
+	nodes, err := ttq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantTombstone IDs.
+func (ttq *TenantTombstoneQuery) IDs(ctx context.Context) (ids []gidx.PrefixedID, err error) {
+	if ttq.ctx.Unique == nil && ttq.path != nil {
+		ttq.Unique(true)
+	}
+	ctx = setContextOp(ctx, ttq.ctx, "IDs")
+	if err = ttq.Select(tenanttombstone.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) IDsX(ctx context.Context) []gidx.PrefixedID {
+	ids, err := ttq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (ttq *TenantTombstoneQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, ttq.ctx, "Count")
+	if err := ttq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, ttq, querierCount[*TenantTombstoneQuery](), ttq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) CountX(ctx context.Context) int {
+	count, err := ttq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (ttq *TenantTombstoneQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, ttq.ctx, "Exist")
+	switch _, err := ttq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (ttq *TenantTombstoneQuery) ExistX(ctx context.Context) bool {
+	exist, err := ttq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantTombstoneQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (ttq *TenantTombstoneQuery) Clone() *TenantTombstoneQuery {
+	if ttq == nil {
+		return nil
+	}
+	return &TenantTombstoneQuery{
+		config:     ttq.config,
+		ctx:        ttq.ctx.Clone(),
+		order:      append([]tenanttombstone.OrderOption{}, ttq.order...),
+		inters:     append([]Interceptor{}, ttq.inters...),
+		predicates: append([]predicate.TenantTombstone{}, ttq.predicates...),
+		// clone intermediate query.
+		sql:  ttq.sql.Clone(),
+		path: ttq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantTombstone.Query().
+//		GroupBy(tenanttombstone.FieldName).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (ttq *TenantTombstoneQuery) GroupBy(field string, fields ...string) *TenantTombstoneGroupBy {
+	ttq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantTombstoneGroupBy{build: ttq}
+	grbuild.flds = &ttq.ctx.Fields
+	grbuild.label = tenanttombstone.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Name string `json:"name,omitempty"`
+//	}
+//
+//	client.TenantTombstone.Query().
+//		Select(tenanttombstone.FieldName).
+//		Scan(ctx, &v)
+func (ttq *TenantTombstoneQuery) Select(fields ...string) *TenantTombstoneSelect {
+	ttq.ctx.Fields = append(ttq.ctx.Fields, fields...)
+	sbuild := &TenantTombstoneSelect{TenantTombstoneQuery: ttq}
+	sbuild.label = tenanttombstone.Label
+	sbuild.flds, sbuild.scan = &ttq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantTombstoneSelect configured with the given aggregations.
+func (ttq *TenantTombstoneQuery) Aggregate(fns ...AggregateFunc) *TenantTombstoneSelect {
+	return ttq.Select().Aggregate(fns...)
+}
+
+func (ttq *TenantTombstoneQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range ttq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, ttq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range ttq.ctx.Fields {
+		if !tenanttombstone.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if ttq.path != nil {
+		prev, err := ttq.path(ctx)
+		if err != nil {
+			return err
+		}
+		ttq.sql = prev
+	}
+	return nil
+}
+
+func (ttq *TenantTombstoneQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantTombstone, error) {
+	var (
+		nodes = []*TenantTombstone{}
+		_spec = ttq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantTombstone).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantTombstone{config: ttq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(ttq.modifiers) > 0 {
+		_spec.Modifiers = ttq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, ttq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range ttq.loadTotal {
+		if err := ttq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (ttq *TenantTombstoneQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := ttq.querySpec()
+	if len(ttq.modifiers) > 0 {
+		_spec.Modifiers = ttq.modifiers
+	}
+	_spec.Node.Columns = ttq.ctx.Fields
+	if len(ttq.ctx.Fields) > 0 {
+		_spec.Unique = ttq.ctx.Unique != nil && *ttq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, ttq.driver, _spec)
+}
+
+func (ttq *TenantTombstoneQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenanttombstone.Table, tenanttombstone.Columns, sqlgraph.NewFieldSpec(tenanttombstone.FieldID, field.TypeString))
+	_spec.From = ttq.sql
+	if unique := ttq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if ttq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := ttq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenanttombstone.FieldID)
+		for i := range fields {
+			if fields[i] != tenanttombstone.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := ttq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := ttq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := ttq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := ttq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (ttq *TenantTombstoneQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(ttq.driver.Dialect())
+	t1 := builder.Table(tenanttombstone.Table)
+	columns := ttq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenanttombstone.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if ttq.sql != nil {
+		selector = ttq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if ttq.ctx.Unique != nil && *ttq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range ttq.predicates {
+		p(selector)
+	}
+	for _, p := range ttq.order {
+		p(selector)
+	}
+	if offset := ttq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := ttq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantTombstoneGroupBy is the group-by builder for TenantTombstone entities.
+type TenantTombstoneGroupBy struct {
+	selector
+	build *TenantTombstoneQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (ttgb *TenantTombstoneGroupBy) Aggregate(fns ...AggregateFunc) *TenantTombstoneGroupBy {
+	ttgb.fns = append(ttgb.fns, fns...)
+	return ttgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ttgb *TenantTombstoneGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ttgb.build.ctx, "GroupBy")
+	if err := ttgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantTombstoneQuery, *TenantTombstoneGroupBy](ctx, ttgb.build, ttgb, ttgb.build.inters, v)
+}
+
+func (ttgb *TenantTombstoneGroupBy) sqlScan(ctx context.Context, root *TenantTombstoneQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(ttgb.fns))
+	for _, fn := range ttgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*ttgb.flds)+len(ttgb.fns))
+		for _, f := range *ttgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*ttgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ttgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantTombstoneSelect is the builder for selecting fields of TenantTombstone entities.
+type TenantTombstoneSelect struct {
+	*TenantTombstoneQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tts *TenantTombstoneSelect) Aggregate(fns ...AggregateFunc) *TenantTombstoneSelect {
+	tts.fns = append(tts.fns, fns...)
+	return tts
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tts *TenantTombstoneSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tts.ctx, "Select")
+	if err := tts.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantTombstoneQuery, *TenantTombstoneSelect](ctx, tts.TenantTombstoneQuery, tts, tts.inters, v)
+}
+
+func (tts *TenantTombstoneSelect) sqlScan(ctx context.Context, root *TenantTombstoneQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tts.fns))
+	for _, fn := range tts.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tts.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tts.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+)
+
+// TenantTombstoneUpdate is the builder for updating TenantTombstone entities.
+type TenantTombstoneUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantTombstoneMutation
+}
+
+// Where appends a list predicates to the TenantTombstoneUpdate builder.
+func (ttu *TenantTombstoneUpdate) Where(ps ...predicate.TenantTombstone) *TenantTombstoneUpdate {
+	ttu.mutation.Where(ps...)
+	return ttu
+}
+
+// Mutation returns the TenantTombstoneMutation object of the builder.
+func (ttu *TenantTombstoneUpdate) Mutation() *TenantTombstoneMutation {
+	return ttu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ttu *TenantTombstoneUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ttu.sqlSave, ttu.mutation, ttu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ttu *TenantTombstoneUpdate) SaveX(ctx context.Context) int {
+	affected, err := ttu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ttu *TenantTombstoneUpdate) Exec(ctx context.Context) error {
+	_, err := ttu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttu *TenantTombstoneUpdate) ExecX(ctx context.Context) {
+	if err := ttu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ttu *TenantTombstoneUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenanttombstone.Table, tenanttombstone.Columns, sqlgraph.NewFieldSpec(tenanttombstone.FieldID, field.TypeString))
+	if ps := ttu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ttu.mutation.ParentTenantIDCleared() {
+		_spec.ClearField(tenanttombstone.FieldParentTenantID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ttu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenanttombstone.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ttu.mutation.done = true
+	return n, nil
+}
+
+// TenantTombstoneUpdateOne is the builder for updating a single TenantTombstone entity.
+type TenantTombstoneUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantTombstoneMutation
+}
+
+// Mutation returns the TenantTombstoneMutation object of the builder.
+func (ttuo *TenantTombstoneUpdateOne) Mutation() *TenantTombstoneMutation {
+	return ttuo.mutation
+}
+
+// Where appends a list predicates to the TenantTombstoneUpdate builder.
+func (ttuo *TenantTombstoneUpdateOne) Where(ps ...predicate.TenantTombstone) *TenantTombstoneUpdateOne {
+	ttuo.mutation.Where(ps...)
+	return ttuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ttuo *TenantTombstoneUpdateOne) Select(field string, fields ...string) *TenantTombstoneUpdateOne {
+	ttuo.fields = append([]string{field}, fields...)
+	return ttuo
+}
+
+// Save executes the query and returns the updated TenantTombstone entity.
+func (ttuo *TenantTombstoneUpdateOne) Save(ctx context.Context) (*TenantTombstone, error) {
+	return withHooks(ctx, ttuo.sqlSave, ttuo.mutation, ttuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ttuo *TenantTombstoneUpdateOne) SaveX(ctx context.Context) *TenantTombstone {
+	node, err := ttuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ttuo *TenantTombstoneUpdateOne) Exec(ctx context.Context) error {
+	_, err := ttuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ttuo *TenantTombstoneUpdateOne) ExecX(ctx context.Context) {
+	if err := ttuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ttuo *TenantTombstoneUpdateOne) sqlSave(ctx context.Context) (_node *TenantTombstone, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tenanttombstone.Table, tenanttombstone.Columns, sqlgraph.NewFieldSpec(tenanttombstone.FieldID, field.TypeString))
+	id, ok := ttuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantTombstone.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ttuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenanttombstone.FieldID)
+		for _, f := range fields {
+			if !tenanttombstone.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenanttombstone.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ttuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ttuo.mutation.ParentTenantIDCleared() {
+		_spec.ClearField(tenanttombstone.FieldParentTenantID, field.TypeString)
+	}
+	_node = &TenantTombstone{config: ttuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ttuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenanttombstone.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ttuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+	"go.infratographer.com/x/gidx"
+)
+
+// The api requests counted for a tenant in a bucket.
+type TenantUsage struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// The ID of the accessed tenant.
+	TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+	// The class of the counted requests: read or write.
+	Operation string `json:"operation,omitempty"`
+	// The start of the bucket the requests were counted in.
+	BucketStart time.Time `json:"bucket_start,omitempty"`
+	// The number of requests counted.
+	Count        int64 `json:"count,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TenantUsage) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case tenantusage.FieldTenantID:
+			values[i] = new(gidx.PrefixedID)
+		case tenantusage.FieldID, tenantusage.FieldCount:
+			values[i] = new(sql.NullInt64)
+		case tenantusage.FieldOperation:
+			values[i] = new(sql.NullString)
+		case tenantusage.FieldBucketStart:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TenantUsage fields.
+func (tu *TenantUsage) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case tenantusage.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			tu.ID = int64(value.Int64)
+		case tenantusage.FieldTenantID:
+			if value, ok := values[i].(*gidx.PrefixedID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				tu.TenantID = *value
+			}
+		case tenantusage.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				tu.Operation = value.String
+			}
+		case tenantusage.FieldBucketStart:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field bucket_start", values[i])
+			} else if value.Valid {
+				tu.BucketStart = value.Time
+			}
+		case tenantusage.FieldCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field count", values[i])
+			} else if value.Valid {
+				tu.Count = value.Int64
+			}
+		default:
+			tu.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TenantUsage.
+// This includes values selected through modifiers, order, etc.
+func (tu *TenantUsage) Value(name string) (ent.Value, error) {
+	return tu.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TenantUsage.
+// Note that you need to call TenantUsage.Unwrap() before calling this method if this TenantUsage
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (tu *TenantUsage) Update() *TenantUsageUpdateOne {
+	return NewTenantUsageClient(tu.config).UpdateOne(tu)
+}
+
+// Unwrap unwraps the TenantUsage entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (tu *TenantUsage) Unwrap() *TenantUsage {
+	_tx, ok := tu.config.driver.(*txDriver)
+	if !ok {
+		panic("generated: TenantUsage is not a transactional entity")
+	}
+	tu.config.driver = _tx.drv
+	return tu
+}
+
+// String implements the fmt.Stringer.
+func (tu *TenantUsage) String() string {
+	var builder strings.Builder
+	builder.WriteString("TenantUsage(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", tu.ID))
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", tu.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(tu.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("bucket_start=")
+	builder.WriteString(tu.BucketStart.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("count=")
+	builder.WriteString(fmt.Sprintf("%v", tu.Count))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IsEntity implement fedruntime.Entity
+func (tu TenantUsage) IsEntity() {}
+
+// TenantUsages is a parsable slice of TenantUsage.
+type TenantUsages []*TenantUsage
@@ -0,0 +1,337 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package tenantusage
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/x/gidx"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLTE(FieldID, id))
+}
+
+// TenantID applies equality check predicate on the "tenant_id" field. It's identical to TenantIDEQ.
+func TenantID(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldTenantID, v))
+}
+
+// Operation applies equality check predicate on the "operation" field. It's identical to OperationEQ.
+func Operation(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldOperation, v))
+}
+
+// BucketStart applies equality check predicate on the "bucket_start" field. It's identical to BucketStartEQ.
+func BucketStart(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldBucketStart, v))
+}
+
+// Count applies equality check predicate on the "count" field. It's identical to CountEQ.
+func Count(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldCount, v))
+}
+
+// TenantIDEQ applies the EQ predicate on the "tenant_id" field.
+func TenantIDEQ(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldTenantID, v))
+}
+
+// TenantIDNEQ applies the NEQ predicate on the "tenant_id" field.
+func TenantIDNEQ(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNEQ(FieldTenantID, v))
+}
+
+// TenantIDIn applies the In predicate on the "tenant_id" field.
+func TenantIDIn(vs ...gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldIn(FieldTenantID, vs...))
+}
+
+// TenantIDNotIn applies the NotIn predicate on the "tenant_id" field.
+func TenantIDNotIn(vs ...gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNotIn(FieldTenantID, vs...))
+}
+
+// TenantIDGT applies the GT predicate on the "tenant_id" field.
+func TenantIDGT(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGT(FieldTenantID, v))
+}
+
+// TenantIDGTE applies the GTE predicate on the "tenant_id" field.
+func TenantIDGTE(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGTE(FieldTenantID, v))
+}
+
+// TenantIDLT applies the LT predicate on the "tenant_id" field.
+func TenantIDLT(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLT(FieldTenantID, v))
+}
+
+// TenantIDLTE applies the LTE predicate on the "tenant_id" field.
+func TenantIDLTE(v gidx.PrefixedID) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLTE(FieldTenantID, v))
+}
+
+// TenantIDContains applies the Contains predicate on the "tenant_id" field.
+func TenantIDContains(v gidx.PrefixedID) predicate.TenantUsage {
+	vc := string(v)
+	return predicate.TenantUsage(sql.FieldContains(FieldTenantID, vc))
+}
+
+// TenantIDHasPrefix applies the HasPrefix predicate on the "tenant_id" field.
+func TenantIDHasPrefix(v gidx.PrefixedID) predicate.TenantUsage {
+	vc := string(v)
+	return predicate.TenantUsage(sql.FieldHasPrefix(FieldTenantID, vc))
+}
+
+// TenantIDHasSuffix applies the HasSuffix predicate on the "tenant_id" field.
+func TenantIDHasSuffix(v gidx.PrefixedID) predicate.TenantUsage {
+	vc := string(v)
+	return predicate.TenantUsage(sql.FieldHasSuffix(FieldTenantID, vc))
+}
+
+// TenantIDEqualFold applies the EqualFold predicate on the "tenant_id" field.
+func TenantIDEqualFold(v gidx.PrefixedID) predicate.TenantUsage {
+	vc := string(v)
+	return predicate.TenantUsage(sql.FieldEqualFold(FieldTenantID, vc))
+}
+
+// TenantIDContainsFold applies the ContainsFold predicate on the "tenant_id" field.
+func TenantIDContainsFold(v gidx.PrefixedID) predicate.TenantUsage {
+	vc := string(v)
+	return predicate.TenantUsage(sql.FieldContainsFold(FieldTenantID, vc))
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldOperation, v))
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNEQ(FieldOperation, v))
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldIn(FieldOperation, vs...))
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNotIn(FieldOperation, vs...))
+}
+
+// OperationGT applies the GT predicate on the "operation" field.
+func OperationGT(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGT(FieldOperation, v))
+}
+
+// OperationGTE applies the GTE predicate on the "operation" field.
+func OperationGTE(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGTE(FieldOperation, v))
+}
+
+// OperationLT applies the LT predicate on the "operation" field.
+func OperationLT(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLT(FieldOperation, v))
+}
+
+// OperationLTE applies the LTE predicate on the "operation" field.
+func OperationLTE(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLTE(FieldOperation, v))
+}
+
+// OperationContains applies the Contains predicate on the "operation" field.
+func OperationContains(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldContains(FieldOperation, v))
+}
+
+// OperationHasPrefix applies the HasPrefix predicate on the "operation" field.
+func OperationHasPrefix(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldHasPrefix(FieldOperation, v))
+}
+
+// OperationHasSuffix applies the HasSuffix predicate on the "operation" field.
+func OperationHasSuffix(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldHasSuffix(FieldOperation, v))
+}
+
+// OperationEqualFold applies the EqualFold predicate on the "operation" field.
+func OperationEqualFold(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEqualFold(FieldOperation, v))
+}
+
+// OperationContainsFold applies the ContainsFold predicate on the "operation" field.
+func OperationContainsFold(v string) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldContainsFold(FieldOperation, v))
+}
+
+// BucketStartEQ applies the EQ predicate on the "bucket_start" field.
+func BucketStartEQ(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldBucketStart, v))
+}
+
+// BucketStartNEQ applies the NEQ predicate on the "bucket_start" field.
+func BucketStartNEQ(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNEQ(FieldBucketStart, v))
+}
+
+// BucketStartIn applies the In predicate on the "bucket_start" field.
+func BucketStartIn(vs ...time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldIn(FieldBucketStart, vs...))
+}
+
+// BucketStartNotIn applies the NotIn predicate on the "bucket_start" field.
+func BucketStartNotIn(vs ...time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNotIn(FieldBucketStart, vs...))
+}
+
+// BucketStartGT applies the GT predicate on the "bucket_start" field.
+func BucketStartGT(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGT(FieldBucketStart, v))
+}
+
+// BucketStartGTE applies the GTE predicate on the "bucket_start" field.
+func BucketStartGTE(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGTE(FieldBucketStart, v))
+}
+
+// BucketStartLT applies the LT predicate on the "bucket_start" field.
+func BucketStartLT(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLT(FieldBucketStart, v))
+}
+
+// BucketStartLTE applies the LTE predicate on the "bucket_start" field.
+func BucketStartLTE(v time.Time) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLTE(FieldBucketStart, v))
+}
+
+// CountEQ applies the EQ predicate on the "count" field.
+func CountEQ(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldEQ(FieldCount, v))
+}
+
+// CountNEQ applies the NEQ predicate on the "count" field.
+func CountNEQ(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNEQ(FieldCount, v))
+}
+
+// CountIn applies the In predicate on the "count" field.
+func CountIn(vs ...int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldIn(FieldCount, vs...))
+}
+
+// CountNotIn applies the NotIn predicate on the "count" field.
+func CountNotIn(vs ...int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldNotIn(FieldCount, vs...))
+}
+
+// CountGT applies the GT predicate on the "count" field.
+func CountGT(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGT(FieldCount, v))
+}
+
+// CountGTE applies the GTE predicate on the "count" field.
+func CountGTE(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldGTE(FieldCount, v))
+}
+
+// CountLT applies the LT predicate on the "count" field.
+func CountLT(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLT(FieldCount, v))
+}
+
+// CountLTE applies the LTE predicate on the "count" field.
+func CountLTE(v int64) predicate.TenantUsage {
+	return predicate.TenantUsage(sql.FieldLTE(FieldCount, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TenantUsage) predicate.TenantUsage {
+	return predicate.TenantUsage(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TenantUsage) predicate.TenantUsage {
+	return predicate.TenantUsage(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TenantUsage) predicate.TenantUsage {
+	return predicate.TenantUsage(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantUsageCreate is the builder for creating a TenantUsage entity.
+type TenantUsageCreate struct {
+	config
+	mutation *TenantUsageMutation
+	hooks    []Hook
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (tuc *TenantUsageCreate) SetTenantID(gi gidx.PrefixedID) *TenantUsageCreate {
+	tuc.mutation.SetTenantID(gi)
+	return tuc
+}
+
+// SetOperation sets the "operation" field.
+func (tuc *TenantUsageCreate) SetOperation(s string) *TenantUsageCreate {
+	tuc.mutation.SetOperation(s)
+	return tuc
+}
+
+// SetBucketStart sets the "bucket_start" field.
+func (tuc *TenantUsageCreate) SetBucketStart(t time.Time) *TenantUsageCreate {
+	tuc.mutation.SetBucketStart(t)
+	return tuc
+}
+
+// SetCount sets the "count" field.
+func (tuc *TenantUsageCreate) SetCount(i int64) *TenantUsageCreate {
+	tuc.mutation.SetCount(i)
+	return tuc
+}
+
+// SetID sets the "id" field.
+func (tuc *TenantUsageCreate) SetID(i int64) *TenantUsageCreate {
+	tuc.mutation.SetID(i)
+	return tuc
+}
+
+// Mutation returns the TenantUsageMutation object of the builder.
+func (tuc *TenantUsageCreate) Mutation() *TenantUsageMutation {
+	return tuc.mutation
+}
+
+// Save creates the TenantUsage in the database.
+func (tuc *TenantUsageCreate) Save(ctx context.Context) (*TenantUsage, error) {
+	return withHooks(ctx, tuc.sqlSave, tuc.mutation, tuc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tuc *TenantUsageCreate) SaveX(ctx context.Context) *TenantUsage {
+	v, err := tuc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tuc *TenantUsageCreate) Exec(ctx context.Context) error {
+	_, err := tuc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tuc *TenantUsageCreate) ExecX(ctx context.Context) {
+	if err := tuc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tuc *TenantUsageCreate) check() error {
+	if _, ok := tuc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`generated: missing required field "TenantUsage.tenant_id"`)}
+	}
+	if _, ok := tuc.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`generated: missing required field "TenantUsage.operation"`)}
+	}
+	if _, ok := tuc.mutation.BucketStart(); !ok {
+		return &ValidationError{Name: "bucket_start", err: errors.New(`generated: missing required field "TenantUsage.bucket_start"`)}
+	}
+	if _, ok := tuc.mutation.Count(); !ok {
+		return &ValidationError{Name: "count", err: errors.New(`generated: missing required field "TenantUsage.count"`)}
+	}
+	if v, ok := tuc.mutation.Count(); ok {
+		if err := tenantusage.CountValidator(v); err != nil {
+			return &ValidationError{Name: "count", err: fmt.Errorf(`generated: validator failed for field "TenantUsage.count": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tuc *TenantUsageCreate) sqlSave(ctx context.Context) (*TenantUsage, error) {
+	if err := tuc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tuc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tuc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	tuc.mutation.id = &_node.ID
+	tuc.mutation.done = true
+	return _node, nil
+}
+
+func (tuc *TenantUsageCreate) createSpec() (*TenantUsage, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TenantUsage{config: tuc.config}
+		_spec = sqlgraph.NewCreateSpec(tenantusage.Table, sqlgraph.NewFieldSpec(tenantusage.FieldID, field.TypeInt64))
+	)
+	if id, ok := tuc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := tuc.mutation.TenantID(); ok {
+		_spec.SetField(tenantusage.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := tuc.mutation.Operation(); ok {
+		_spec.SetField(tenantusage.FieldOperation, field.TypeString, value)
+		_node.Operation = value
+	}
+	if value, ok := tuc.mutation.BucketStart(); ok {
+		_spec.SetField(tenantusage.FieldBucketStart, field.TypeTime, value)
+		_node.BucketStart = value
+	}
+	if value, ok := tuc.mutation.Count(); ok {
+		_spec.SetField(tenantusage.FieldCount, field.TypeInt64, value)
+		_node.Count = value
+	}
+	return _node, _spec
+}
+
+// TenantUsageCreateBulk is the builder for creating many TenantUsage entities in bulk.
+type TenantUsageCreateBulk struct {
+	config
+	builders []*TenantUsageCreate
+}
+
+// Save creates the TenantUsage entities in the database.
+func (tucb *TenantUsageCreateBulk) Save(ctx context.Context) ([]*TenantUsage, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(tucb.builders))
+	nodes := make([]*TenantUsage, len(tucb.builders))
+	mutators := make([]Mutator, len(tucb.builders))
+	for i := range tucb.builders {
+		func(i int, root context.Context) {
+			builder := tucb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TenantUsageMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tucb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tucb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tucb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tucb *TenantUsageCreateBulk) SaveX(ctx context.Context) []*TenantUsage {
+	v, err := tucb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tucb *TenantUsageCreateBulk) Exec(ctx context.Context) error {
+	_, err := tucb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tucb *TenantUsageCreateBulk) ExecX(ctx context.Context) {
+	if err := tucb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+)
+
+// TenantUsageDelete is the builder for deleting a TenantUsage entity.
+type TenantUsageDelete struct {
+	config
+	hooks    []Hook
+	mutation *TenantUsageMutation
+}
+
+// Where appends a list predicates to the TenantUsageDelete builder.
+func (tud *TenantUsageDelete) Where(ps ...predicate.TenantUsage) *TenantUsageDelete {
+	tud.mutation.Where(ps...)
+	return tud
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (tud *TenantUsageDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, tud.sqlExec, tud.mutation, tud.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tud *TenantUsageDelete) ExecX(ctx context.Context) int {
+	n, err := tud.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (tud *TenantUsageDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(tenantusage.Table, sqlgraph.NewFieldSpec(tenantusage.FieldID, field.TypeInt64))
+	if ps := tud.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, tud.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	tud.mutation.done = true
+	return affected, err
+}
+
+// TenantUsageDeleteOne is the builder for deleting a single TenantUsage entity.
+type TenantUsageDeleteOne struct {
+	tud *TenantUsageDelete
+}
+
+// Where appends a list predicates to the TenantUsageDelete builder.
+func (tudo *TenantUsageDeleteOne) Where(ps ...predicate.TenantUsage) *TenantUsageDeleteOne {
+	tudo.tud.mutation.Where(ps...)
+	return tudo
+}
+
+// Exec executes the deletion query.
+func (tudo *TenantUsageDeleteOne) Exec(ctx context.Context) error {
+	n, err := tudo.tud.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{tenantusage.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tudo *TenantUsageDeleteOne) ExecX(ctx context.Context) {
+	if err := tudo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,553 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+)
+
+// TenantUsageQuery is the builder for querying TenantUsage entities.
+type TenantUsageQuery struct {
+	config
+	ctx        *QueryContext
+	order      []tenantusage.OrderOption
+	inters     []Interceptor
+	predicates []predicate.TenantUsage
+	modifiers  []func(*sql.Selector)
+	loadTotal  []func(context.Context, []*TenantUsage) error
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the TenantUsageQuery builder.
+func (tuq *TenantUsageQuery) Where(ps ...predicate.TenantUsage) *TenantUsageQuery {
+	tuq.predicates = append(tuq.predicates, ps...)
+	return tuq
+}
+
+// Limit the number of records to be returned by this query.
+func (tuq *TenantUsageQuery) Limit(limit int) *TenantUsageQuery {
+	tuq.ctx.Limit = &limit
+	return tuq
+}
+
+// Offset to start from.
+func (tuq *TenantUsageQuery) Offset(offset int) *TenantUsageQuery {
+	tuq.ctx.Offset = &offset
+	return tuq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (tuq *TenantUsageQuery) Unique(unique bool) *TenantUsageQuery {
+	tuq.ctx.Unique = &unique
+	return tuq
+}
+
+// Order specifies how the records should be ordered.
+func (tuq *TenantUsageQuery) Order(o ...tenantusage.OrderOption) *TenantUsageQuery {
+	tuq.order = append(tuq.order, o...)
+	return tuq
+}
+
+// First returns the first TenantUsage entity from the query.
+// Returns a *NotFoundError when no TenantUsage was found.
+func (tuq *TenantUsageQuery) First(ctx context.Context) (*TenantUsage, error) {
+	nodes, err := tuq.Limit(1).All(setContextOp(ctx, tuq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{tenantusage.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (tuq *TenantUsageQuery) FirstX(ctx context.Context) *TenantUsage {
+	node, err := tuq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first TenantUsage ID from the query.
+// Returns a *NotFoundError when no TenantUsage ID was found.
+func (tuq *TenantUsageQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = tuq.Limit(1).IDs(setContextOp(ctx, tuq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{tenantusage.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (tuq *TenantUsageQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := tuq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single TenantUsage entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one TenantUsage entity is found.
+// Returns a *NotFoundError when no TenantUsage entities are found.
+func (tuq *TenantUsageQuery) Only(ctx context.Context) (*TenantUsage, error) {
+	nodes, err := tuq.Limit(2).All(setContextOp(ctx, tuq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{tenantusage.Label}
+	default:
+		return nil, &NotSingularError{tenantusage.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (tuq *TenantUsageQuery) OnlyX(ctx context.Context) *TenantUsage {
+	node, err := tuq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only TenantUsage ID in the query.
+// Returns a *NotSingularError when more than one TenantUsage ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (tuq *TenantUsageQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = tuq.Limit(2).IDs(setContextOp(ctx, tuq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{tenantusage.Label}
+	default:
+		err = &NotSingularError{tenantusage.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (tuq *TenantUsageQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := tuq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of TenantUsages.
+func (tuq *TenantUsageQuery) All(ctx context.Context) ([]*TenantUsage, error) {
+	ctx = setContextOp(ctx, tuq.ctx, "All")
+	if err := tuq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*TenantUsage, *TenantUsageQuery]()
+	return withInterceptors[[]*TenantUsage](ctx, tuq, qr, tuq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (tuq *TenantUsageQuery) AllX(ctx context.Context) []*TenantUsage {
+	nodes, err := tuq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of TenantUsage IDs.
+func (tuq *TenantUsageQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if tuq.ctx.Unique == nil && tuq.path != nil {
+		tuq.Unique(true)
+	}
+	ctx = setContextOp(ctx, tuq.ctx, "IDs")
+	if err = tuq.Select(tenantusage.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (tuq *TenantUsageQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := tuq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (tuq *TenantUsageQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, tuq.ctx, "Count")
+	if err := tuq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, tuq, querierCount[*TenantUsageQuery](), tuq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (tuq *TenantUsageQuery) CountX(ctx context.Context) int {
+	count, err := tuq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (tuq *TenantUsageQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, tuq.ctx, "Exist")
+	switch _, err := tuq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("generated: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (tuq *TenantUsageQuery) ExistX(ctx context.Context) bool {
+	exist, err := tuq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the TenantUsageQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (tuq *TenantUsageQuery) Clone() *TenantUsageQuery {
+	if tuq == nil {
+		return nil
+	}
+	return &TenantUsageQuery{
+		config:     tuq.config,
+		ctx:        tuq.ctx.Clone(),
+		order:      append([]tenantusage.OrderOption{}, tuq.order...),
+		inters:     append([]Interceptor{}, tuq.inters...),
+		predicates: append([]predicate.TenantUsage{}, tuq.predicates...),
+		// clone intermediate query.
+		sql:  tuq.sql.Clone(),
+		path: tuq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.TenantUsage.Query().
+//		GroupBy(tenantusage.FieldTenantID).
+//		Aggregate(generated.Count()).
+//		Scan(ctx, &v)
+func (tuq *TenantUsageQuery) GroupBy(field string, fields ...string) *TenantUsageGroupBy {
+	tuq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &TenantUsageGroupBy{build: tuq}
+	grbuild.flds = &tuq.ctx.Fields
+	grbuild.label = tenantusage.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		TenantID gidx.PrefixedID `json:"tenant_id,omitempty"`
+//	}
+//
+//	client.TenantUsage.Query().
+//		Select(tenantusage.FieldTenantID).
+//		Scan(ctx, &v)
+func (tuq *TenantUsageQuery) Select(fields ...string) *TenantUsageSelect {
+	tuq.ctx.Fields = append(tuq.ctx.Fields, fields...)
+	sbuild := &TenantUsageSelect{TenantUsageQuery: tuq}
+	sbuild.label = tenantusage.Label
+	sbuild.flds, sbuild.scan = &tuq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a TenantUsageSelect configured with the given aggregations.
+func (tuq *TenantUsageQuery) Aggregate(fns ...AggregateFunc) *TenantUsageSelect {
+	return tuq.Select().Aggregate(fns...)
+}
+
+func (tuq *TenantUsageQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range tuq.inters {
+		if inter == nil {
+			return fmt.Errorf("generated: uninitialized interceptor (forgotten import generated/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, tuq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range tuq.ctx.Fields {
+		if !tenantusage.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+		}
+	}
+	if tuq.path != nil {
+		prev, err := tuq.path(ctx)
+		if err != nil {
+			return err
+		}
+		tuq.sql = prev
+	}
+	return nil
+}
+
+func (tuq *TenantUsageQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*TenantUsage, error) {
+	var (
+		nodes = []*TenantUsage{}
+		_spec = tuq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*TenantUsage).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &TenantUsage{config: tuq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(tuq.modifiers) > 0 {
+		_spec.Modifiers = tuq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, tuq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	for i := range tuq.loadTotal {
+		if err := tuq.loadTotal[i](ctx, nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (tuq *TenantUsageQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := tuq.querySpec()
+	if len(tuq.modifiers) > 0 {
+		_spec.Modifiers = tuq.modifiers
+	}
+	_spec.Node.Columns = tuq.ctx.Fields
+	if len(tuq.ctx.Fields) > 0 {
+		_spec.Unique = tuq.ctx.Unique != nil && *tuq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, tuq.driver, _spec)
+}
+
+func (tuq *TenantUsageQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(tenantusage.Table, tenantusage.Columns, sqlgraph.NewFieldSpec(tenantusage.FieldID, field.TypeInt64))
+	_spec.From = tuq.sql
+	if unique := tuq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if tuq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := tuq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantusage.FieldID)
+		for i := range fields {
+			if fields[i] != tenantusage.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := tuq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := tuq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := tuq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := tuq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (tuq *TenantUsageQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(tuq.driver.Dialect())
+	t1 := builder.Table(tenantusage.Table)
+	columns := tuq.ctx.Fields
+	if len(columns) == 0 {
+		columns = tenantusage.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if tuq.sql != nil {
+		selector = tuq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if tuq.ctx.Unique != nil && *tuq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range tuq.predicates {
+		p(selector)
+	}
+	for _, p := range tuq.order {
+		p(selector)
+	}
+	if offset := tuq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := tuq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// TenantUsageGroupBy is the group-by builder for TenantUsage entities.
+type TenantUsageGroupBy struct {
+	selector
+	build *TenantUsageQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (tugb *TenantUsageGroupBy) Aggregate(fns ...AggregateFunc) *TenantUsageGroupBy {
+	tugb.fns = append(tugb.fns, fns...)
+	return tugb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tugb *TenantUsageGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tugb.build.ctx, "GroupBy")
+	if err := tugb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantUsageQuery, *TenantUsageGroupBy](ctx, tugb.build, tugb, tugb.build.inters, v)
+}
+
+func (tugb *TenantUsageGroupBy) sqlScan(ctx context.Context, root *TenantUsageQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(tugb.fns))
+	for _, fn := range tugb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*tugb.flds)+len(tugb.fns))
+		for _, f := range *tugb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*tugb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tugb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// TenantUsageSelect is the builder for selecting fields of TenantUsage entities.
+type TenantUsageSelect struct {
+	*TenantUsageQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (tus *TenantUsageSelect) Aggregate(fns ...AggregateFunc) *TenantUsageSelect {
+	tus.fns = append(tus.fns, fns...)
+	return tus
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (tus *TenantUsageSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, tus.ctx, "Select")
+	if err := tus.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*TenantUsageQuery, *TenantUsageSelect](ctx, tus.TenantUsageQuery, tus, tus.inters, v)
+}
+
+func (tus *TenantUsageSelect) sqlScan(ctx context.Context, root *TenantUsageQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(tus.fns))
+	for _, fn := range tus.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*tus.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := tus.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Code generated by entc, DO NOT EDIT.
+
+package generated
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+)
+
+// TenantUsageUpdate is the builder for updating TenantUsage entities.
+type TenantUsageUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TenantUsageMutation
+}
+
+// Where appends a list predicates to the TenantUsageUpdate builder.
+func (tuu *TenantUsageUpdate) Where(ps ...predicate.TenantUsage) *TenantUsageUpdate {
+	tuu.mutation.Where(ps...)
+	return tuu
+}
+
+// SetCount sets the "count" field.
+func (tuu *TenantUsageUpdate) SetCount(i int64) *TenantUsageUpdate {
+	tuu.mutation.ResetCount()
+	tuu.mutation.SetCount(i)
+	return tuu
+}
+
+// AddCount adds i to the "count" field.
+func (tuu *TenantUsageUpdate) AddCount(i int64) *TenantUsageUpdate {
+	tuu.mutation.AddCount(i)
+	return tuu
+}
+
+// Mutation returns the TenantUsageMutation object of the builder.
+func (tuu *TenantUsageUpdate) Mutation() *TenantUsageMutation {
+	return tuu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tuu *TenantUsageUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, tuu.sqlSave, tuu.mutation, tuu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tuu *TenantUsageUpdate) SaveX(ctx context.Context) int {
+	affected, err := tuu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tuu *TenantUsageUpdate) Exec(ctx context.Context) error {
+	_, err := tuu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tuu *TenantUsageUpdate) ExecX(ctx context.Context) {
+	if err := tuu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tuu *TenantUsageUpdate) check() error {
+	if v, ok := tuu.mutation.Count(); ok {
+		if err := tenantusage.CountValidator(v); err != nil {
+			return &ValidationError{Name: "count", err: fmt.Errorf(`generated: validator failed for field "TenantUsage.count": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tuu *TenantUsageUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := tuu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(tenantusage.Table, tenantusage.Columns, sqlgraph.NewFieldSpec(tenantusage.FieldID, field.TypeInt64))
+	if ps := tuu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tuu.mutation.Count(); ok {
+		_spec.SetField(tenantusage.FieldCount, field.TypeInt64, value)
+	}
+	if value, ok := tuu.mutation.AddedCount(); ok {
+		_spec.AddField(tenantusage.FieldCount, field.TypeInt64, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tuu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantusage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tuu.mutation.done = true
+	return n, nil
+}
+
+// TenantUsageUpdateOne is the builder for updating a single TenantUsage entity.
+type TenantUsageUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TenantUsageMutation
+}
+
+// SetCount sets the "count" field.
+func (tuuo *TenantUsageUpdateOne) SetCount(i int64) *TenantUsageUpdateOne {
+	tuuo.mutation.ResetCount()
+	tuuo.mutation.SetCount(i)
+	return tuuo
+}
+
+// AddCount adds i to the "count" field.
+func (tuuo *TenantUsageUpdateOne) AddCount(i int64) *TenantUsageUpdateOne {
+	tuuo.mutation.AddCount(i)
+	return tuuo
+}
+
+// Mutation returns the TenantUsageMutation object of the builder.
+func (tuuo *TenantUsageUpdateOne) Mutation() *TenantUsageMutation {
+	return tuuo.mutation
+}
+
+// Where appends a list predicates to the TenantUsageUpdate builder.
+func (tuuo *TenantUsageUpdateOne) Where(ps ...predicate.TenantUsage) *TenantUsageUpdateOne {
+	tuuo.mutation.Where(ps...)
+	return tuuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tuuo *TenantUsageUpdateOne) Select(field string, fields ...string) *TenantUsageUpdateOne {
+	tuuo.fields = append([]string{field}, fields...)
+	return tuuo
+}
+
+// Save executes the query and returns the updated TenantUsage entity.
+func (tuuo *TenantUsageUpdateOne) Save(ctx context.Context) (*TenantUsage, error) {
+	return withHooks(ctx, tuuo.sqlSave, tuuo.mutation, tuuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tuuo *TenantUsageUpdateOne) SaveX(ctx context.Context) *TenantUsage {
+	node, err := tuuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tuuo *TenantUsageUpdateOne) Exec(ctx context.Context) error {
+	_, err := tuuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tuuo *TenantUsageUpdateOne) ExecX(ctx context.Context) {
+	if err := tuuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tuuo *TenantUsageUpdateOne) check() error {
+	if v, ok := tuuo.mutation.Count(); ok {
+		if err := tenantusage.CountValidator(v); err != nil {
+			return &ValidationError{Name: "count", err: fmt.Errorf(`generated: validator failed for field "TenantUsage.count": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tuuo *TenantUsageUpdateOne) sqlSave(ctx context.Context) (_node *TenantUsage, err error) {
+	if err := tuuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(tenantusage.Table, tenantusage.Columns, sqlgraph.NewFieldSpec(tenantusage.FieldID, field.TypeInt64))
+	id, ok := tuuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`generated: missing "TenantUsage.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tuuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tenantusage.FieldID)
+		for _, f := range fields {
+			if !tenantusage.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("generated: invalid field %q for query", f)}
+			}
+			if f != tenantusage.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tuuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tuuo.mutation.Count(); ok {
+		_spec.SetField(tenantusage.FieldCount, field.TypeInt64, value)
+	}
+	if value, ok := tuuo.mutation.AddedCount(); ok {
+		_spec.AddField(tenantusage.FieldCount, field.TypeInt64, value)
+	}
+	_node = &TenantUsage{config: tuuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tuuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tenantusage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tuuo.mutation.done = true
+	return _node, nil
+}
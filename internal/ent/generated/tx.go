@@ -18,6 +18,8 @@ package generated
 
 import (
 	"context"
+	stdsql "database/sql"
+	"fmt"
 	"sync"
 
 	"entgo.io/ent/dialect"
@@ -26,8 +28,24 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// SchemaCompatibility is the client for interacting with the SchemaCompatibility builders.
+	SchemaCompatibility *SchemaCompatibilityClient
+	// ServiceAccount is the client for interacting with the ServiceAccount builders.
+	ServiceAccount *ServiceAccountClient
 	// Tenant is the client for interacting with the Tenant builders.
 	Tenant *TenantClient
+	// TenantAudit is the client for interacting with the TenantAudit builders.
+	TenantAudit *TenantAuditClient
+	// TenantChange is the client for interacting with the TenantChange builders.
+	TenantChange *TenantChangeClient
+	// TenantCreateGuard is the client for interacting with the TenantCreateGuard builders.
+	TenantCreateGuard *TenantCreateGuardClient
+	// TenantParentHistory is the client for interacting with the TenantParentHistory builders.
+	TenantParentHistory *TenantParentHistoryClient
+	// TenantTombstone is the client for interacting with the TenantTombstone builders.
+	TenantTombstone *TenantTombstoneClient
+	// TenantUsage is the client for interacting with the TenantUsage builders.
+	TenantUsage *TenantUsageClient
 
 	// lazily loaded.
 	client     *Client
@@ -159,7 +177,15 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.SchemaCompatibility = NewSchemaCompatibilityClient(tx.config)
+	tx.ServiceAccount = NewServiceAccountClient(tx.config)
 	tx.Tenant = NewTenantClient(tx.config)
+	tx.TenantAudit = NewTenantAuditClient(tx.config)
+	tx.TenantChange = NewTenantChangeClient(tx.config)
+	tx.TenantCreateGuard = NewTenantCreateGuardClient(tx.config)
+	tx.TenantParentHistory = NewTenantParentHistoryClient(tx.config)
+	tx.TenantTombstone = NewTenantTombstoneClient(tx.config)
+	tx.TenantUsage = NewTenantUsageClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -169,7 +195,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Tenant.QueryXXX(), the query will be executed
+// applies a query, for example: SchemaCompatibility.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -222,3 +248,27 @@ func (tx *txDriver) Query(ctx context.Context, query string, args, v any) error
 }
 
 var _ dialect.Driver = (*txDriver)(nil)
+
+// ExecContext allows calling the underlying ExecContext method of the transaction if it is supported by it.
+// See, database/sql#Tx.ExecContext for more information.
+func (tx *txDriver) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	ex, ok := tx.tx.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the transaction if it is supported by it.
+// See, database/sql#Tx.QueryContext for more information.
+func (tx *txDriver) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	q, ok := tx.tx.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
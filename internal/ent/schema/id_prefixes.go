@@ -20,4 +20,12 @@ const (
 	ApplicationPrefix string = "tnnt"
 	// TenantPrefix is the prefix for tenants
 	TenantPrefix string = ApplicationPrefix + "ten"
+	// ParentHistoryPrefix is the prefix for tenant parent history entries
+	ParentHistoryPrefix string = ApplicationPrefix + "phs"
+	// AuditPrefix is the prefix for tenant audit entries
+	AuditPrefix string = ApplicationPrefix + "aud"
+	// ServiceAccountPrefix is the prefix for tenant service accounts
+	ServiceAccountPrefix string = ApplicationPrefix + "sac"
+	// JobPrefix is the prefix for background jobs, which aren't stored in the database
+	JobPrefix string = ApplicationPrefix + "job"
 )
@@ -0,0 +1,51 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// SchemaCompatibility holds the schema definition for the binaries migrations are compatible
+// with. Rows are only written by the migrations declaring them, see internal/migration.
+type SchemaCompatibility struct {
+	ent.Schema
+}
+
+// Fields of the SchemaCompatibility.
+func (SchemaCompatibility) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Comment("The version of the migration declaring the compatibility.").
+			Immutable(),
+		field.Int64("min_binary_version").
+			Comment("The version of the oldest binary compatible with the schema once the migration is applied, the version of the latest migration it embeds.").
+			NonNegative().
+			Immutable(),
+	}
+}
+
+// Annotations for the SchemaCompatibility
+func (SchemaCompatibility) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "schema_compatibility"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("The oldest binaries compatible with the schema, declared by migrations."),
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/entx"
+	"go.infratographer.com/x/gidx"
+)
+
+// ServiceAccount holds the schema definition for the machine identities belonging to a tenant.
+type ServiceAccount struct {
+	ent.Schema
+}
+
+// Mixin of the ServiceAccount
+func (ServiceAccount) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		entx.NewTimestampMixin(),
+	}
+}
+
+// Fields of the ServiceAccount.
+func (ServiceAccount) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Comment("ID for the service account, the subject credentials are bound to.").
+			GoType(gidx.PrefixedID("")).
+			DefaultFunc(func() gidx.PrefixedID { return gidx.MustNewID(ServiceAccountPrefix) }).
+			Unique().
+			Immutable(),
+		field.String("tenant_id").
+			Comment("The ID of the tenant the service account belongs to, it may act within its subtree.").
+			GoType(gidx.PrefixedID("")).
+			Immutable().
+			Annotations(
+				entx.EventsHookAdditionalSubject("tenant"),
+			),
+		field.String("name").
+			Comment("The name of the service account, unique within its tenant.").
+			NotEmpty().
+			Immutable(),
+		field.String("description").
+			Comment("An optional description of the service account.").
+			Optional(),
+		field.String("created_by").
+			Comment("The subject which created the service account, empty when unknown.").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the ServiceAccount
+func (ServiceAccount) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("tenant", Tenant.Type).
+			Ref("service_accounts").
+			Field("tenant_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the ServiceAccount
+func (ServiceAccount) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "name").
+			Unique(),
+	}
+}
+
+// Annotations for the ServiceAccount
+func (ServiceAccount) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "service_accounts"},
+		entx.EventsHookSubjectName("service-account"),
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("A machine identity belonging to a tenant."),
+	}
+}
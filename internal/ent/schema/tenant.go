@@ -19,12 +19,16 @@ import (
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/vektah/gqlparser/v2/ast"
 	"go.infratographer.com/x/entx"
 	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/externalid"
 )
 
 // Tenant holds the schema definition for the Tenant entity.
@@ -54,33 +58,173 @@ func (Tenant) Fields() []ent.Field {
 				entgql.OrderField("NAME"),
 				entgql.Skip(entgql.SkipWhereInput),
 			),
+		field.String("display_name").
+			Comment("The display name of a tenant, defaults to the name and may be changed freely.").
+			Optional().
+			Annotations(
+				entgql.OrderField("DISPLAY_NAME"),
+				entgql.Skip(entgql.SkipWhereInput),
+			),
 		field.String("description").
 			Comment("An optional description of the tenant.").
 			Optional().
 			Annotations(
 				entgql.Skip(entgql.SkipWhereInput),
 			),
+		field.String("contact_email").
+			Comment("An optional email address to contact the owners of the tenant at.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipWhereInput),
+			),
+		field.String("billing_reference").
+			Comment("An optional reference to the tenant in the billing system.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipWhereInput),
+			),
 		field.String("parent_tenant_id").
 			Comment("The ID of the parent tenant for the tenant.").
 			Optional().
-			Immutable().
 			GoType(gidx.PrefixedID("")).
 			Annotations(
 				entgql.Type("ID"),
 				entgql.Skip(entgql.SkipWhereInput, entgql.SkipMutationUpdateInput, entgql.SkipType),
 				entx.EventsHookAdditionalSubject("parent"),
 			),
+		// not nillable as the event hooks can't format nil times, the zero time means no deletion is
+		// pending and the graph api resolves it to null
+		field.Time("deletion_scheduled_at").
+			Comment("The time the tenant will be deleted at, zero unless a deletion is pending.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		field.Int("max_children").
+			Comment("Overrides the configured maximum number of direct children when positive, set by admins only.").
+			Optional().
+			NonNegative().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		field.String("owner_id").
+			Comment("The ID of the resource provider owning the tenant, root tenants are suspended when it is deleted.").
+			Optional().
+			GoType(gidx.PrefixedID("")).
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		field.Time("suspended_at").
+			Comment("The time the tenant was suspended at, zero unless it is suspended.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// the id of tenants created with an external id is derived from it and the parent, see
+		// pkg/externalid
+		field.String("external_id").
+			Comment("An optional reference to the tenant in the provisioning system, unique among its siblings.").
+			Optional().
+			Immutable().
+			MaxLen(externalid.MaxLength).
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// a boolean rather than a time so update events carry unarchiving as well, optional without
+		// a default so the events of created tenants stay the same
+		field.Bool("archived").
+			Comment("Whether the tenant is archived, hidden from listings and closed to new children.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// like archived, a boolean without a default so update events carry unfreezing as well and
+		// the events of created tenants stay the same
+		field.Bool("frozen").
+			Comment("Whether the tenant is frozen by an admin, closed to every change but unfreezing until then.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		field.Time("creation_frozen_until").
+			Comment("The time creating tenants in the subtree of the tenant is frozen until, set by an admin, zero when it isn't.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// like frozen, a boolean without a default so update events carry lifting the protection
+		// and the events of created tenants stay the same
+		field.Bool("deletion_protected").
+			Comment("Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipWhereInput, entgql.SkipMutationCreateInput),
+			),
+		// set by the change sequence hook, from the primary key of the tenant_changes row recorded
+		// for every mutation
+		field.Int64("change_seq").
+			Comment("The sequence of the last change of the tenant, increasing with every change.").
+			Optional().
+			NonNegative().
+			Annotations(
+				entgql.OrderField("CHANGE_SEQ"),
+				entgql.Skip(entgql.SkipMutationCreateInput, entgql.SkipMutationUpdateInput),
+			),
+		// expanded without a default so binaries predating it keep writing tenants, the version
+		// hook writes it and the version backfill fills it on existing tenants, see
+		// internal/migration
+		field.Int64("version").
+			Comment("The version of the tenant, one when created and incremented by every update.").
+			Optional().
+			Nillable().
+			NonNegative().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// set by the creates and renames which checked the name against the siblings, so the
+		// sibling name index leaves out the tenants created with duplicate names on purpose, see
+		// validation.NameReusePolicy
+		field.Bool("unique_name").
+			Comment("Whether the name is unique among the live siblings of the tenant, enforced by a unique index.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		field.JSON("settings", map[string]any{}).
+			Comment("Small per tenant configuration document, managed through the settings endpoints.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
+		// labels are inherited down the subtree of the tenant, see internal/labels
+		field.JSON("labels", map[string]string{}).
+			Comment("Key value labels of the tenant, inherited by its descendants unless they set the key themselves.").
+			Optional().
+			Annotations(
+				entgql.Skip(entgql.SkipAll),
+			),
 	}
 }
 
 // Indexes of the Tenant
 func (Tenant) Indexes() []ent.Index {
-	return []ent.Index{}
+	return []ent.Index{
+		index.Fields("deletion_scheduled_at"),
+		index.Fields("billing_reference"),
+		index.Fields("owner_id"),
+		index.Fields("change_seq"),
+		index.Fields("parent_tenant_id", "external_id").Unique(),
+		index.Fields("parent_tenant_id", "name").
+			Unique().
+			Annotations(entsql.IndexWhere("unique_name AND deletion_scheduled_at IS NULL")),
+	}
 }
 
 // Edges of the Tenant
 func (Tenant) Edges() []ent.Edge {
 	return []ent.Edge{
+		// archived children are left out of the children connection unless includeArchived is
+		// true, the argument is added by entc.go and applied by internal/ent/gqltemplates
 		edge.To("children", Tenant.Type).
 			Annotations(
 				entgql.RelayConnection(),
@@ -88,8 +232,15 @@ func (Tenant) Edges() []ent.Edge {
 			).
 			From("parent").
 			Field("parent_tenant_id").
-			Immutable().
+			// tenants are moved by updating their parent, see internal/reparent
 			Unique(),
+		// the accounts are deleted with their tenant by serviceaccount.CascadeHook, publishing their
+		// events, the foreign key cascades deletions made outside of ent
+		edge.To("service_accounts", ServiceAccount.Type).
+			Annotations(
+				entsql.OnDelete(entsql.Cascade),
+				entgql.Skip(entgql.SkipAll),
+			),
 	}
 }
 
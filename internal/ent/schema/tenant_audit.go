@@ -0,0 +1,85 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantAudit holds the schema definition for the audited mutation attempts of tenants.
+type TenantAudit struct {
+	ent.Schema
+}
+
+// Fields of the TenantAudit.
+func (TenantAudit) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Comment("ID for the audit entry.").
+			GoType(gidx.PrefixedID("")).
+			DefaultFunc(func() gidx.PrefixedID { return gidx.MustNewID(AuditPrefix) }).
+			Unique().
+			Immutable(),
+		// no edge to the tenant, the audit outlives the tenants it references
+		field.String("tenant_id").
+			Comment("The ID of the tenant the mutation was attempted on.").
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("actor").
+			Comment("The subject which attempted the mutation, empty when unknown.").
+			Optional().
+			Immutable(),
+		field.String("operation").
+			Comment("The name of the route of the attempted mutation.").
+			Immutable(),
+		field.Text("attempted_change").
+			Comment("The request body of the attempted mutation, truncated when large.").
+			Optional().
+			Immutable(),
+		field.String("outcome").
+			Comment("The outcome of the attempt: denied, conflicted or confirmed.").
+			Immutable(),
+		field.String("code").
+			Comment("The error code the attempt was rejected with, empty when it had none.").
+			Optional().
+			Immutable(),
+		field.Time("recorded_at").
+			Comment("The time of the attempt.").
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantAudit
+func (TenantAudit) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "recorded_at"),
+		index.Fields("tenant_id", "outcome", "recorded_at"),
+	}
+}
+
+// Annotations for the TenantAudit
+func (TenantAudit) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_audit"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("A mutation attempted on a tenant."),
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantChange holds the schema definition for the changes made to tenants. Its auto incremented id
+// is the change sequence of the tenants.
+type TenantChange struct {
+	ent.Schema
+}
+
+// Fields of the TenantChange.
+func (TenantChange) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Comment("The sequence of the change.").
+			Immutable(),
+		// no edge to the tenant, changes outlive the tenants they reference
+		field.String("tenant_id").
+			Comment("The ID of the changed tenant.").
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("operation").
+			Comment("The operation which changed the tenant: create, update or delete.").
+			Immutable(),
+		field.Time("changed_at").
+			Comment("The time of the change.").
+			Immutable(),
+		// kept for deletions so the names of purged tenants can stay blocked
+		field.String("name").
+			Comment("The name of the deleted tenant, only set for deletions.").
+			Optional().
+			Immutable(),
+		field.String("parent_tenant_id").
+			Comment("The ID of the parent of the deleted tenant, only set for deletions of child tenants.").
+			GoType(gidx.PrefixedID("")).
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantChange
+func (TenantChange) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id"),
+		index.Fields("parent_tenant_id", "name"),
+		// the changes older than the retention are found by time
+		index.Fields("changed_at"),
+	}
+}
+
+// Annotations for the TenantChange
+func (TenantChange) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_changes"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("A change made to a tenant."),
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantCreateGuard holds the schema definition for the recent creates of tenants, keyed by their
+// parent, name and actor, so retried creates are recognized. Rows past the duplicate window are
+// purged.
+type TenantCreateGuard struct {
+	ent.Schema
+}
+
+// Fields of the TenantCreateGuard.
+func (TenantCreateGuard) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Comment("The key of the create, a hash of the parent, name and actor.").
+			NotEmpty().
+			Immutable(),
+		// no edge to the tenant, a guard of a deleted tenant is ignored until purged
+		field.String("tenant_id").
+			Comment("The ID of the created tenant.").
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.Time("created_at").
+			Comment("The time the tenant was created at.").
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantCreateGuard
+func (TenantCreateGuard) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+	}
+}
+
+// Annotations for the TenantCreateGuard
+func (TenantCreateGuard) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_create_guards"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("The recent creates of tenants, recognizing retried creates."),
+	}
+}
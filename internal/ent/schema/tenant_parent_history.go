@@ -0,0 +1,80 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantParentHistory holds the schema definition for the parent changes of a tenant.
+type TenantParentHistory struct {
+	ent.Schema
+}
+
+// Fields of the TenantParentHistory.
+func (TenantParentHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Comment("ID for the history entry.").
+			GoType(gidx.PrefixedID("")).
+			DefaultFunc(func() gidx.PrefixedID { return gidx.MustNewID(ParentHistoryPrefix) }).
+			Unique().
+			Immutable(),
+		// no edges to the tenants, the history outlives the tenants it references
+		field.String("tenant_id").
+			Comment("The ID of the tenant which was moved.").
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("previous_parent_id").
+			Comment("The ID of the parent before the change, empty for root tenants.").
+			Optional().
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("new_parent_id").
+			Comment("The ID of the parent after the change, empty for root tenants.").
+			Optional().
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("actor").
+			Comment("The subject which made the change, empty when unknown.").
+			Optional().
+			Immutable(),
+		field.Time("changed_at").
+			Comment("The time of the change.").
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantParentHistory
+func (TenantParentHistory) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "changed_at"),
+	}
+}
+
+// Annotations for the TenantParentHistory
+func (TenantParentHistory) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_parent_history"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("A change of the parent of a tenant."),
+	}
+}
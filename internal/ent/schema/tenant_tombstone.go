@@ -0,0 +1,74 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantTombstone holds the schema definition for the minimal record kept of deleted tenants, so
+// their audit history stays readable and they can be restored. Tombstones past their retention
+// are purged.
+type TenantTombstone struct {
+	ent.Schema
+}
+
+// Fields of the TenantTombstone.
+func (TenantTombstone) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Comment("The ID of the deleted tenant.").
+			GoType(gidx.PrefixedID("")).
+			Unique().
+			Immutable(),
+		field.String("name").
+			Comment("The name of the tenant when it was deleted.").
+			Immutable(),
+		// no edge to the parent, which may be deleted in turn
+		field.String("parent_tenant_id").
+			Comment("The ID of the parent of the tenant when it was deleted, empty for roots.").
+			GoType(gidx.PrefixedID("")).
+			Optional().
+			Immutable(),
+		field.Time("deleted_at").
+			Comment("The time the deletion took effect, when it was due for scheduled deletions.").
+			Immutable(),
+		field.Time("purged_at").
+			Comment("The time the tenant was removed from the database.").
+			Immutable(),
+	}
+}
+
+// Indexes of the TenantTombstone
+func (TenantTombstone) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("purged_at"),
+	}
+}
+
+// Annotations for the TenantTombstone
+func (TenantTombstone) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_tombstones"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("The record kept of deleted tenants."),
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"go.infratographer.com/x/gidx"
+)
+
+// TenantUsage holds the schema definition for the api requests counted for tenants, one row for
+// each tenant, operation class and bucket the tenant was accessed in.
+type TenantUsage struct {
+	ent.Schema
+}
+
+// Fields of the TenantUsage.
+func (TenantUsage) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").
+			Immutable(),
+		// no edge to the tenant, usage outlives the tenants it was counted for
+		field.String("tenant_id").
+			Comment("The ID of the accessed tenant.").
+			GoType(gidx.PrefixedID("")).
+			Immutable(),
+		field.String("operation").
+			Comment("The class of the counted requests: read or write.").
+			Immutable(),
+		field.Time("bucket_start").
+			Comment("The start of the bucket the requests were counted in.").
+			Immutable(),
+		field.Int64("count").
+			Comment("The number of requests counted.").
+			NonNegative(),
+	}
+}
+
+// Indexes of the TenantUsage
+func (TenantUsage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("tenant_id", "bucket_start", "operation").
+			Unique(),
+	}
+}
+
+// Annotations for the TenantUsage
+func (TenantUsage) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "tenant_usages"},
+		entgql.Skip(entgql.SkipAll),
+		schema.Comment("The api requests counted for a tenant in a bucket."),
+	}
+}
@@ -0,0 +1,71 @@
+package errmap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Canceled reports whether the error is the result of the caller canceling the request, or of the
+// deadline the caller gave it passing. It is when the error is a context error and the request
+// context ctx is done. Timeouts the server applies to parts of a request, through contexts derived
+// from the request context, leave it alive and so are not cancellations but failures.
+func Canceled(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() == nil {
+		return false
+	}
+
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// TranslateContext translates the error as Translate does, reporting errors of requests the
+// caller canceled as apierrors.ErrCanceled. ctx is the context of the request.
+func TranslateContext(ctx context.Context, err error) error {
+	if !Canceled(ctx, err) {
+		return Translate(err)
+	}
+
+	if _, ok := apierrors.ClassOf(err); ok {
+		return err
+	}
+
+	return &classified{class: apierrors.ErrCanceled, err: err}
+}
+
+// ClassifyContext returns the class of the error after translating it with TranslateContext,
+// false when it has none.
+func ClassifyContext(ctx context.Context, err error) (apierrors.Class, bool) {
+	return apierrors.ClassOf(TranslateContext(ctx, err))
+}
+
+// Middleware handles the errors of requests the caller canceled, as Canceled reports them. They
+// are logged at debug level and answered with apierrors.StatusClientClosedRequest rather than
+// returned, so request logging doesn't report them as errors, request metrics don't count them
+// as server errors and the failures recorder, running before this middleware, leaves them out.
+// Other errors are returned unchanged.
+func Middleware(logger *zap.SugaredLogger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			ctx := c.Request().Context()
+			if !Canceled(ctx, err) {
+				return err
+			}
+
+			reqlog.FromEcho(c, logger).Debugw("request canceled by the caller", "error", err)
+
+			// streams may have written their response already
+			if c.Response().Committed {
+				return nil
+			}
+
+			return c.NoContent(apierrors.StatusClientClosedRequest)
+		}
+	}
+}
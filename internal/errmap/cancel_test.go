@@ -0,0 +1,160 @@
+package errmap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// slowQuery counts without end, until its context is done.
+const slowQuery = "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT count(*) FROM c"
+
+type outcome struct {
+	status int
+	err    error
+}
+
+// newCancelServer serves a handler running the slow query, with the given timeout when it is not
+// zero. Outcomes are reported the way request logging sees them, outside the middleware.
+func newCancelServer(t *testing.T, client *ent.Client, timeout time.Duration) (string, *observer.ObservedLogs, <-chan outcome) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	outcomes := make(chan outcome, 1)
+
+	e := echo.New()
+
+	observe := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			outcomes <- outcome{status: c.Response().Status, err: err}
+
+			return err
+		}
+	}
+
+	e.GET("/v1/tenants", func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		rows, err := client.QueryContext(ctx, slowQuery)
+		if err == nil {
+			for rows.Next() {
+			}
+
+			err = errors.Join(rows.Err(), rows.Close())
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to query tenants: %w", err)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}, observe, errmap.Middleware(zap.New(core).Sugar()))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return srv.URL, logs, outcomes
+}
+
+func TestMiddlewareClientCanceled(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	url, logs, outcomes := newCancelServer(t, client, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/v1/tenants", nil)
+	require.NoError(t, err)
+
+	_, err = http.DefaultClient.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case got := <-outcomes:
+		assert.NoError(t, got.err, "cancellations aren't reported as errors")
+		assert.Equal(t, apierrors.StatusClientClosedRequest, got.status)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the slow query wasn't canceled")
+	}
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Contains(t, entries[0].ContextMap()["error"], "failed to query tenants")
+}
+
+func TestMiddlewareServerTimeout(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	url, logs, outcomes := newCancelServer(t, client, 50*time.Millisecond)
+
+	resp, err := http.Get(url + "/v1/tenants")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	got := <-outcomes
+	assert.ErrorIs(t, got.err, context.DeadlineExceeded, "timeouts of the server remain errors")
+	assert.Equal(t, http.StatusInternalServerError, got.status)
+	assert.Zero(t, logs.Len())
+}
+
+func TestTranslateContext(t *testing.T) {
+	errQuery := fmt.Errorf("failed to query tenants: %w", context.Canceled)
+
+	live := context.Background()
+
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.False(t, errmap.Canceled(live, errQuery), "the request is still live")
+	assert.True(t, errmap.Canceled(done, errQuery))
+	assert.False(t, errmap.Canceled(done, errors.New("connection refused")))
+	assert.False(t, errmap.Canceled(done, nil))
+
+	class, ok := errmap.ClassifyContext(done, errQuery)
+	require.True(t, ok)
+	assert.Equal(t, apierrors.ErrCanceled, class.Err)
+	assert.Equal(t, "failed to query tenants: context canceled", errmap.TranslateContext(done, errQuery).Error())
+
+	_, ok = errmap.ClassifyContext(live, errQuery)
+	assert.False(t, ok)
+
+	// errors which already have a class keep it
+	class, ok = errmap.ClassifyContext(done, fmt.Errorf("%w: %w", apierrors.ErrTenantNotFound, context.Canceled))
+	require.True(t, ok)
+	assert.Equal(t, apierrors.ErrTenantNotFound, class.Err)
+}
@@ -0,0 +1,164 @@
+package errmap
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/crdb"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Translate returns the error with the apierrors class it belongs to. Errors of the data layer
+// and the permission checks are wrapped with their class, keeping the original error, while errors
+// which already have a class are returned as they are. Entities not found are reported as missing
+// tenants, the only entities the apis look up by id. Transactions still aborted by a serialization
+// failure after their retries are unavailable, the request may be retried. Errors without a class, such as failing
+// database connections, are returned unchanged and reported as internal errors.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := apierrors.ClassOf(err); ok {
+		return err
+	}
+
+	var class error
+
+	switch {
+	case errors.Is(err, permissions.ErrPermissionDenied):
+		class = apierrors.ErrPermissionDenied
+	case ent.IsNotFound(err):
+		class = apierrors.ErrTenantNotFound
+	case ent.IsValidationError(err):
+		class = apierrors.ErrInvalidArgument
+	case ent.IsConstraintError(err):
+		class = apierrors.ErrConflict
+	case crdb.IsSerializationFailure(err):
+		class = apierrors.ErrUnavailable
+	default:
+		return err
+	}
+
+	return &classified{class: class, err: err}
+}
+
+// Classify returns the class of the error after translating it, false when it has none.
+func Classify(err error) (apierrors.Class, bool) {
+	return apierrors.ClassOf(Translate(err))
+}
+
+// classified adds a class to an error, keeping its message.
+type classified struct {
+	class error
+	err   error
+}
+
+// Error implements the error interface.
+func (e *classified) Error() string {
+	return e.err.Error()
+}
+
+// Is reports whether the target is the class of the error.
+func (e *classified) Is(target error) bool {
+	return target == e.class
+}
+
+// Unwrap returns the translated error.
+func (e *classified) Unwrap() error {
+	return e.err
+}
+
+// HTTPError converts the error into an echo http error with the status of its class. The body has
+// the code of the class, or the code and field of validation errors and the limit of those of
+// values which are too long. Creates rejected by a creation freeze have the time it expires at in
+// frozenUntil. Translated errors get the
+// message of their class, so internal details such as table names aren't exposed. Errors without a
+// class are returned unchanged, for echo to report them as internal errors.
+func HTTPError(err error) error {
+	translated := Translate(err)
+
+	class, ok := apierrors.ClassOf(translated)
+	if !ok {
+		return err
+	}
+
+	body := map[string]string{
+		"code":    class.Code,
+		"message": translated.Error(),
+	}
+
+	var (
+		verr *validation.Error
+		ferr *freeze.CreationFrozenError
+	)
+
+	switch {
+	case errors.As(err, &ferr):
+		body["frozenUntil"] = timefmt.New(ferr.Freeze.Until).String()
+	case errors.As(err, &verr):
+		body["code"] = verr.Code
+		body["field"] = verr.Field
+		body["message"] = verr.Error()
+
+		if verr.Limit != 0 {
+			body["limit"] = strconv.Itoa(verr.Limit)
+		}
+	case translated != err:
+		body["message"] = class.Err.Error()
+	}
+
+	return echo.NewHTTPError(class.HTTPStatus, body).WithInternal(err)
+}
+
+// Detail describes one of the problems of a malformed request.
+type Detail struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+// codeInvalidRequest is the code of malformed requests, their details have the code of each problem.
+const codeInvalidRequest = "invalid_request"
+
+// BadRequest converts the validation errors of a malformed request into a bad request. The body
+// lists every error in its details, with their field, code, message and limit. Other errors are
+// converted by HTTPError.
+func BadRequest(err error) error {
+	var errs validation.Errors
+
+	if !errors.As(err, &errs) {
+		var verr *validation.Error
+		if !errors.As(err, &verr) {
+			return HTTPError(err)
+		}
+
+		errs = validation.Errors{verr}
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, map[string]any{
+		"code":    codeInvalidRequest,
+		"message": errs.Error(),
+		"details": Details(errs),
+	}).WithInternal(err)
+}
+
+// Details returns the details of the validation errors, as listed by the body of bad requests.
+func Details(errs validation.Errors) []Detail {
+	details := make([]Detail, len(errs))
+
+	for i, verr := range errs {
+		details[i] = Detail{Field: verr.Field, Code: verr.Code, Message: verr.Message, Limit: verr.Limit}
+	}
+
+	return details
+}
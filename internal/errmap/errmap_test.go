@@ -0,0 +1,153 @@
+package errmap_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestTranslate(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	_, errNotFound := client.Tenant.Get(ctx, "tnntten-missing")
+	_, errInvalid := client.Tenant.Create().SetName("negative").SetMaxChildren(-1).Save(ctx)
+	_, errConstraint := client.Tenant.Create().SetName("orphan").SetParentTenantID("tnntten-missing").Save(ctx)
+
+	tests := []struct {
+		name  string
+		err   error
+		class error
+	}{
+		{"not found", errNotFound, apierrors.ErrTenantNotFound},
+		{"ent validation", errInvalid, apierrors.ErrInvalidArgument},
+		{"constraint", errConstraint, apierrors.ErrConflict},
+		{"permission denied", fmt.Errorf("%w: no access", permissions.ErrPermissionDenied), apierrors.ErrPermissionDenied},
+		{"validation", &validation.Error{Field: "name", Code: validation.CodeInvalidName}, apierrors.ErrInvalidArgument},
+		{"name taken", &validation.Error{Field: "name", Code: validation.CodeNameTaken}, apierrors.ErrNameConflict},
+		{"name taken by deleted", &validation.Error{Field: "name", Code: validation.CodeNameTakenByDeleted}, apierrors.ErrNameConflict},
+		{"external id taken", &validation.Error{Field: "externalID", Code: validation.CodeExternalIDTaken}, apierrors.ErrConflict},
+		{"id taken", &validation.Error{Field: "id", Code: validation.CodeIDTaken}, apierrors.ErrConflict},
+		{"parent not found", &validation.Error{Field: "parent", Code: validation.CodeParentNotFound}, apierrors.ErrParentNotFound},
+		{"pending deletion", &validation.Error{Field: "parent", Code: validation.CodeParentDeleted, Err: deletion.ErrPendingDeletion}, apierrors.ErrInvalidArgument},
+		{"has children", deletion.ErrHasChildren, apierrors.ErrConflict},
+		{"serialization failure", fmt.Errorf("committing: %w", &pq.Error{Code: "40001"}), apierrors.ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.err)
+
+			translated := errmap.Translate(tt.err)
+
+			assert.ErrorIs(t, translated, tt.class)
+			assert.ErrorIs(t, translated, tt.err, "the original error is kept")
+			assert.Equal(t, tt.err.Error(), translated.Error())
+
+			class, ok := errmap.Classify(tt.err)
+			require.True(t, ok)
+			assert.Equal(t, tt.class, class.Err)
+		})
+	}
+
+	unknown := errors.New("connection refused")
+
+	assert.Equal(t, unknown, errmap.Translate(unknown))
+	assert.Nil(t, errmap.Translate(nil))
+}
+
+func TestHTTPError(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	_, errNotFound := client.Tenant.Get(context.Background(), "tnntten-missing")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   map[string]string
+	}{
+		{
+			"not found",
+			fmt.Errorf("lookup: %w", errNotFound),
+			http.StatusNotFound,
+			map[string]string{"code": "tenant_not_found", "message": "tenant not found"},
+		},
+		{
+			"permission denied",
+			permissions.ErrPermissionDenied,
+			http.StatusForbidden,
+			map[string]string{"code": "permission_denied", "message": "permission denied"},
+		},
+		{
+			"conflict",
+			deletion.ErrHasChildren,
+			http.StatusConflict,
+			map[string]string{"code": "conflict", "message": deletion.ErrHasChildren.Error()},
+		},
+		{
+			"name conflict",
+			&validation.Error{Field: "name", Code: validation.CodeNameTaken, Message: "taken"},
+			http.StatusConflict,
+			map[string]string{"code": validation.CodeNameTaken, "field": "name", "message": "invalid name: taken"},
+		},
+		{
+			"parent not found",
+			&validation.Error{Field: "parent", Code: validation.CodeParentNotFound, Message: "missing"},
+			http.StatusUnprocessableEntity,
+			map[string]string{"code": validation.CodeParentNotFound, "field": "parent", "message": "invalid parent: missing"},
+		},
+		{
+			"invalid argument",
+			&validation.Error{Field: "name", Code: validation.CodeInvalidName, Message: "must not be empty"},
+			http.StatusUnprocessableEntity,
+			map[string]string{"code": validation.CodeInvalidName, "field": "name", "message": "invalid name: must not be empty"},
+		},
+		{
+			"too long",
+			&validation.Error{Field: "description", Code: validation.CodeDescriptionTooLong, Message: "must be at most 8 characters, got 9", Limit: 8},
+			http.StatusUnprocessableEntity,
+			map[string]string{"code": validation.CodeDescriptionTooLong, "field": "description", "message": "invalid description: must be at most 8 characters, got 9", "limit": "8"},
+		},
+		{
+			"missing actor",
+			actor.ErrMissingActor,
+			http.StatusUnauthorized,
+			map[string]string{"code": "unauthenticated", "message": actor.ErrMissingActor.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var herr *echo.HTTPError
+
+			require.ErrorAs(t, errmap.HTTPError(tt.err), &herr)
+			assert.Equal(t, tt.status, herr.Code)
+			assert.Equal(t, tt.body, herr.Message)
+			assert.ErrorIs(t, herr.Internal, tt.err)
+		})
+	}
+
+	unknown := errors.New("connection refused")
+
+	assert.Equal(t, unknown, errmap.HTTPError(unknown))
+}
@@ -0,0 +1,129 @@
+package eventstream
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/events"
+
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// kinds groups the fields changed by kinds of changes, so clients can ask for renames without
+// knowing every field a rename touches.
+var kinds = map[string][]string{
+	"rename":    {enttenant.FieldName, enttenant.FieldDisplayName},
+	"reparent":  {enttenant.FieldParentTenantID},
+	"settings":  {enttenant.FieldSettings},
+	"lifecycle": {enttenant.FieldArchived, enttenant.FieldFrozen, enttenant.FieldSuspendedAt, enttenant.FieldDeletionScheduledAt},
+}
+
+var eventTypes = map[string]bool{
+	string(events.CreateChangeType): true,
+	string(events.UpdateChangeType): true,
+	string(events.DeleteChangeType): true,
+}
+
+// filter selects the changes a client receives. Event types limit the changes to those types.
+// Fields and kinds limit updates to those changing one of the fields, or one of the fields of the
+// kinds; creates and deletes aren't limited by them. A zero filter selects every change.
+type filter struct {
+	eventTypes map[string]bool
+	fields     map[string]bool
+}
+
+// parseFilter reads the event_types, fields and kinds query parameters, each a comma separated
+// list. Unknown names are rejected.
+func parseFilter(c echo.Context) (filter, error) {
+	var f filter
+
+	for _, name := range splitParam(c.QueryParam("event_types")) {
+		if !eventTypes[name] {
+			return f, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown event type %q", name))
+		}
+
+		if f.eventTypes == nil {
+			f.eventTypes = map[string]bool{}
+		}
+
+		f.eventTypes[name] = true
+	}
+
+	for _, name := range splitParam(c.QueryParam("fields")) {
+		if !enttenant.ValidColumn(name) {
+			return f, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown field %q", name))
+		}
+
+		f.addField(name)
+	}
+
+	for _, name := range splitParam(c.QueryParam("kinds")) {
+		fields, ok := kinds[name]
+		if !ok {
+			return f, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown kind %q, expected one of %s", name, strings.Join(kindNames(), ", ")))
+		}
+
+		for _, field := range fields {
+			f.addField(field)
+		}
+	}
+
+	return f, nil
+}
+
+func (f *filter) addField(name string) {
+	if f.fields == nil {
+		f.fields = map[string]bool{}
+	}
+
+	f.fields[name] = true
+}
+
+// matches reports whether the change is selected. It is given the change as sent to the client,
+// so field changes the client can't see don't select updates.
+func (f filter) matches(data change) bool {
+	if f.eventTypes != nil && !f.eventTypes[data.EventType] {
+		return false
+	}
+
+	if f.fields == nil || data.EventType != string(events.UpdateChangeType) {
+		return true
+	}
+
+	for _, fc := range data.FieldChanges {
+		if f.fields[fc.Field] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitParam(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	names := strings.Split(raw, ",")
+
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+
+	return names
+}
+
+func kindNames() []string {
+	names := make([]string, 0, len(kinds))
+
+	for name := range kinds {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,312 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/clock"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often a comment is sent on idle streams to keep proxies from closing them.
+	DefaultHeartbeatInterval = 15 * time.Second
+
+	// DefaultWatchTimeout is how long a watching poll waits for changes by default.
+	DefaultWatchTimeout = 30 * time.Second
+
+	actionTenantGet = "tenant_get"
+
+	lastEventIDHeader = "Last-Event-ID"
+	resetEvent        = "reset"
+)
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithHeartbeatInterval sets how often heartbeats are sent on idle streams.
+func WithHeartbeatInterval(interval time.Duration) Option {
+	return func(h *Handler) {
+		h.heartbeat = interval
+	}
+}
+
+// WithChangeRetention sets the time the recorded tenant changes are kept for, the same as the
+// deletion scheduler purges them with. Resuming a stream from a change older than that resets it,
+// as changes following it may be gone.
+func WithChangeRetention(d time.Duration) Option {
+	return func(h *Handler) {
+		h.changeRetention = d
+	}
+}
+
+// WithWatchTimeout sets how long a watching poll waits for changes before returning none.
+func WithWatchTimeout(timeout time.Duration) Option {
+	return func(h *Handler) {
+		if timeout > 0 {
+			h.watchTimeout = timeout
+		}
+	}
+}
+
+// Handler streams the changes within a tenant's subtree as server-sent events, or returns them
+// to clients polling for them.
+type Handler struct {
+	client       *ent.Client
+	feed         *changefeed.Feed
+	logger       *zap.SugaredLogger
+	middleware   []echo.MiddlewareFunc
+	heartbeat    time.Duration
+	watchTimeout time.Duration
+
+	changeRetention time.Duration
+	clock           clock.Clock
+}
+
+// NewHandler returns a handler streaming changes from feed. The middleware authenticates the
+// connection and installs the permissions checker, the same as for the graph api.
+func NewHandler(client *ent.Client, feed *changefeed.Feed, logger *zap.SugaredLogger, middleware []echo.MiddlewareFunc, opts ...Option) *Handler {
+	h := &Handler{
+		client:       client,
+		feed:         feed,
+		logger:       logger,
+		middleware:   append(append([]echo.MiddlewareFunc{}, middleware...), reqlog.Middleware(logger)),
+		heartbeat:    DefaultHeartbeatInterval,
+		watchTimeout: DefaultWatchTimeout,
+		clock:        clock.Real{},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Routes registers the event stream and change polling routes.
+func (h *Handler) Routes(e *echo.Group) {
+	e.GET("/v1/tenants/:id/events", h.stream, h.middleware...)
+	e.GET("/v1/tenants/:id/changes", h.poll, h.middleware...)
+}
+
+// change is the data sent with each event.
+type change struct {
+	EventType            string               `json:"eventType"`
+	TenantID             gidx.PrefixedID      `json:"tenantID"`
+	AdditionalSubjectIDs []gidx.PrefixedID    `json:"additionalSubjectIDs"`
+	Timestamp            timefmt.Time         `json:"timestamp"`
+	FieldChanges         []events.FieldChange `json:"fieldChanges,omitempty"`
+}
+
+// newChange converts the change message, leaving out redacted field changes and the parent when it is redacted.
+func newChange(msg events.ChangeMessage, fields redact.Fields) change {
+	data := change{
+		EventType: msg.EventType,
+		TenantID:  msg.SubjectID,
+		Timestamp: timefmt.New(msg.Timestamp),
+	}
+
+	if fields.Visible(redact.FieldParent) {
+		data.AdditionalSubjectIDs = msg.AdditionalSubjectIDs
+	}
+
+	for _, fc := range msg.FieldChanges {
+		if fields.VisibleEventField(fc.Field) {
+			data.FieldChanges = append(data.FieldChanges, fc)
+		}
+	}
+
+	return data
+}
+
+func (h *Handler) stream(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.checkTenant(c)
+	if err != nil {
+		return err
+	}
+
+	only, err := parseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	replay, changes, unsubscribe, resumeErr := h.subscribe(c)
+	defer unsubscribe()
+
+	// changes replayed from the recorded ones may be delivered live as well
+	replayed := make(map[int64]bool, len(replay))
+
+	for _, ch := range replay {
+		if seq, ok := changeSeq(ch.Message); ok {
+			replayed[seq] = true
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// disable response buffering in nginx based proxies
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if resumeErr != nil {
+		// the client missed changes which can't be replayed, it needs to refresh its state
+		if err := h.write(res, "", resetEvent, map[string]string{"error": resumeErr.Error()}); err != nil {
+			return nil
+		}
+	}
+
+	res.Flush()
+
+	scope := subtree.NewScope(h.client, id)
+
+	for _, ch := range replay {
+		if err := h.send(ctx, res, scope, only, ch); err != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+
+			res.Flush()
+		case ch, ok := <-changes:
+			if !ok {
+				reqlog.FromContext(ctx, h.logger).Warnw("closing slow tenant event stream")
+
+				return nil
+			}
+
+			if seq, ok := changeSeq(ch.Message); ok && replayed[seq] {
+				continue
+			}
+
+			if err := h.send(ctx, res, scope, only, ch); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// checkTenant parses the tenant id path parameter and checks the tenant exists and the caller
+// may watch it.
+func (h *Handler) checkTenant(c echo.Context) (gidx.PrefixedID, error) {
+	ctx := c.Request().Context()
+
+	id, err := urnx.ParseTenantID(c.Param("id"))
+	if err != nil {
+		return gidx.NullPrefixedID, echo.NewHTTPError(http.StatusBadRequest, err.Error()).WithInternal(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return gidx.NullPrefixedID, errmap.HTTPError(err)
+	}
+
+	if _, err := h.client.Tenant.Get(ctx, id); err != nil {
+		return gidx.NullPrefixedID, errmap.HTTPError(err)
+	}
+
+	return id, nil
+}
+
+// subscribe subscribes to the feed, resuming after the Last-Event-ID when the client sent one.
+// Event ids which are change sequences resume from the recorded changes, see resume, others from
+// the history of the feed of this instance.
+func (h *Handler) subscribe(c echo.Context) ([]changefeed.Change, <-chan changefeed.Change, func(), error) {
+	lastEventID := c.Request().Header.Get(lastEventIDHeader)
+	if lastEventID == "" {
+		changes, unsubscribe := h.feed.Subscribe()
+
+		return nil, changes, unsubscribe, nil
+	}
+
+	if after, err := strconv.ParseInt(lastEventID, 10, 64); err == nil && after >= 0 {
+		return h.resume(c.Request().Context(), after)
+	}
+
+	epoch, seq, found := strings.Cut(lastEventID, ".")
+
+	lastID, err := strconv.ParseUint(seq, 10, 64)
+	if !found || err != nil || epoch != h.feed.Epoch() {
+		changes, unsubscribe := h.feed.Subscribe()
+
+		return nil, changes, unsubscribe, changefeed.ErrHistoryUnavailable
+	}
+
+	return h.feed.SubscribeAfter(lastID)
+}
+
+// send writes the change to the stream if it is within the subtree and selected by the filter.
+func (h *Handler) send(ctx context.Context, res *echo.Response, scope *subtree.Scope, only filter, ch changefeed.Change) error {
+	inScope, err := scope.Contains(ctx, ch.Message)
+	if err != nil {
+		reqlog.FromContext(ctx, h.logger).Errorw("failed to determine tenant event scope", "error", err, "subject_id", ch.Message.SubjectID)
+
+		return err
+	}
+
+	if !inScope {
+		return nil
+	}
+
+	data := newChange(ch.Message, redact.FromContext(ctx))
+	if !only.matches(data) {
+		return nil
+	}
+
+	if err := h.write(res, h.eventID(ch), ch.Message.EventType, data); err != nil {
+		return err
+	}
+
+	res.Flush()
+
+	return nil
+}
+
+func (h *Handler) write(res *echo.Response, id, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(res, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, b)
+
+	return err
+}
@@ -0,0 +1,346 @@
+package eventstream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/eventstream"
+	"go.infratographer.com/tenant-api/internal/subtree"
+)
+
+type testEnv struct {
+	ctx    context.Context
+	client *ent.Client
+	perms  *permissions.Permissions
+	url    string
+}
+
+func newTestEnv(t *testing.T, checker permissions.Checker, opts ...eventstream.Option) *testEnv {
+	t.Helper()
+
+	return newFeedTestEnv(t, checker, nil, opts...)
+}
+
+// newFeedTestEnv returns an env whose feed is created with the options, its changes carry the
+// ancestors of their tenant as those of the service do.
+func newFeedTestEnv(t *testing.T, checker permissions.Checker, feedOpts []changefeed.Option, opts ...eventstream.Option) *testEnv {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	feed := changefeed.New(conn, feedOpts...)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(feed)),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(subtree.NewResolver().Hook())
+	client.Tenant.Use(changeseq.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(checker))
+	require.NoError(t, err)
+
+	env := &testEnv{
+		// mutations made directly through ent need the relationship handler the middleware normally provides
+		ctx:    context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms),
+		client: client,
+		perms:  perms,
+	}
+
+	env.url = env.serve(t, feed, opts...)
+
+	return env
+}
+
+// serve serves the changes of the feed with a handler created with the options, returning its url.
+func (env *testEnv) serve(t *testing.T, feed *changefeed.Feed, opts ...eventstream.Option) string {
+	t.Helper()
+
+	e := echo.New()
+
+	eventstream.NewHandler(env.client, feed, zap.NewNop().Sugar(), []echo.MiddlewareFunc{env.perms.Middleware()},
+		append([]eventstream.Option{eventstream.WithHeartbeatInterval(50 * time.Millisecond)}, opts...)...,
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+type event struct {
+	id, name, data string
+}
+
+// connect opens the event stream and returns a reader of events, heartbeats are skipped.
+func (env *testEnv) connect(t *testing.T, id, lastEventID string) (*http.Response, func() event) {
+	t.Helper()
+
+	return env.connectQuery(t, id, "", lastEventID)
+}
+
+// connectQuery opens the event stream with the query, see connect.
+func (env *testEnv) connectQuery(t *testing.T, id, query, lastEventID string) (*http.Response, func() event) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(env.ctx)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, env.url+"/v1/tenants/"+id+"/events?"+query, nil)
+	require.NoError(t, err)
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { resp.Body.Close() })
+
+	scanner := bufio.NewScanner(resp.Body)
+
+	return resp, func() event {
+		var ev event
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case line == "" && ev.name != "":
+				return ev
+			case strings.HasPrefix(line, "id: "):
+				ev.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				ev.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				ev.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+
+		require.NoError(t, scanner.Err())
+		require.Fail(t, "stream closed")
+
+		return ev
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker)
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	other := env.client.Tenant.Create().SetName("other").SaveX(env.ctx)
+
+	resp, next := env.connect(t, root.ID.String(), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	env.client.Tenant.UpdateOne(other).SetName("unrelated").ExecX(env.ctx)
+	grandchild := env.client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(env.ctx)
+	env.client.Tenant.UpdateOne(child).SetName("renamed").ExecX(env.ctx)
+
+	ev := next()
+	assert.Equal(t, "create", ev.name)
+	assert.NotEmpty(t, ev.id)
+
+	var data map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(ev.data), &data))
+	assert.Equal(t, grandchild.ID.String(), data["tenantID"])
+
+	created := ev.id
+
+	ev = next()
+	assert.Equal(t, "update", ev.name)
+	assert.Contains(t, ev.data, `"tenantID":"`+child.ID.String()+`"`)
+	assert.Contains(t, ev.data, `"currentValue":"renamed"`)
+
+	t.Run("resume", func(t *testing.T) {
+		_, next := env.connect(t, root.ID.String(), created)
+
+		ev := next()
+		assert.Equal(t, "update", ev.name)
+		assert.Contains(t, ev.data, child.ID.String())
+	})
+
+	t.Run("resume unavailable", func(t *testing.T) {
+		_, next := env.connect(t, root.ID.String(), "unknown.1")
+
+		assert.Equal(t, "reset", next().name)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, next := env.connect(t, child.ID.String(), "")
+
+		env.client.Tenant.DeleteOne(grandchild).ExecX(env.ctx)
+
+		ev := next()
+		assert.Equal(t, "delete", ev.name)
+		assert.Contains(t, ev.data, grandchild.ID.String())
+	})
+}
+
+func TestEventStreamResumeFromChanges(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker)
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	other := env.client.Tenant.Create().SetName("other").SaveX(env.ctx)
+
+	_, next := env.connect(t, root.ID.String(), "")
+
+	grandchild := env.client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(env.ctx)
+
+	ev := next()
+	require.Equal(t, "create", ev.name)
+	assert.Equal(t, strconv.FormatInt(env.client.Tenant.GetX(env.ctx, grandchild.ID).ChangeSeq, 10), ev.id, "event ids are change sequences")
+
+	env.client.Tenant.UpdateOne(other).SetName("unrelated").ExecX(env.ctx)
+	env.client.Tenant.UpdateOne(child).SetName("renamed").ExecX(env.ctx)
+	env.client.Tenant.DeleteOne(grandchild).ExecX(env.ctx)
+
+	// another instance, or this one restarted, has none of the changes in its feed
+	conn := new(eventtools.MockConnection)
+	env.url = env.serve(t, changefeed.New(conn))
+
+	_, next = env.connect(t, root.ID.String(), ev.id)
+
+	ev = next()
+	assert.Equal(t, "update", ev.name)
+	assert.Contains(t, ev.data, `"tenantID":"`+child.ID.String()+`"`)
+
+	ev = next()
+	assert.Equal(t, "delete", ev.name)
+	assert.Contains(t, ev.data, `"tenantID":"`+grandchild.ID.String()+`"`, "deleted tenants are scoped by the parent they had")
+
+	t.Run("past retention", func(t *testing.T) {
+		url := env.serve(t, changefeed.New(conn), eventstream.WithChangeRetention(time.Nanosecond))
+
+		_, next := (&testEnv{ctx: env.ctx, url: url}).connect(t, root.ID.String(), "1")
+
+		assert.Equal(t, "reset", next().name)
+	})
+}
+
+func TestEventStreamAncestorsNone(t *testing.T) {
+	// the published changes carry no ancestor, the stream still tells the subtree from them
+	env := newFeedTestEnv(t, permissions.DefaultAllowChecker, []changefeed.Option{changefeed.WithAncestorLimit(changefeed.AncestorsNone)})
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+
+	_, next := env.connect(t, root.ID.String(), "")
+
+	grandchild := env.client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(env.ctx)
+
+	ev := next()
+	assert.Equal(t, "create", ev.name)
+	assert.Contains(t, ev.data, grandchild.ID.String())
+
+	env.client.Tenant.DeleteOne(grandchild).ExecX(env.ctx)
+
+	ev = next()
+	assert.Equal(t, "delete", ev.name)
+	assert.Contains(t, ev.data, grandchild.ID.String())
+}
+
+func TestEventStreamFilter(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker)
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+
+	resp, next := env.connectQuery(t, root.ID.String(), "event_types=update,delete&fields=name", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	env.client.Tenant.UpdateOne(child).SetSettings(map[string]any{"region": "us"}).ExecX(env.ctx)
+	env.client.Tenant.Create().SetName("created").SetParent(root).ExecX(env.ctx)
+	env.client.Tenant.UpdateOne(child).SetName("renamed").ExecX(env.ctx)
+
+	// the settings update and the create are never sent, the rename is the first event
+	ev := next()
+	assert.Equal(t, "update", ev.name)
+	assert.Contains(t, ev.data, `"currentValue":"renamed"`)
+	assert.NotContains(t, ev.data, `"settings"`)
+
+	t.Run("kinds", func(t *testing.T) {
+		_, next := env.connectQuery(t, root.ID.String(), "kinds=reparent", "")
+
+		env.client.Tenant.UpdateOne(child).SetName("renamed again").ExecX(env.ctx)
+		env.client.Tenant.Create().SetName("sibling").SetParent(root).ExecX(env.ctx)
+
+		ev := next()
+		assert.Equal(t, "create", ev.name, "creates aren't limited by kinds")
+		assert.Contains(t, ev.data, `"currentValue":"sibling"`)
+	})
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "unknown field", query: "fields=name,colour"},
+		{name: "unknown event type", query: "event_types=rename"},
+		{name: "unknown kind", query: "kinds=name"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := env.connectQuery(t, root.ID.String(), tt.query, "")
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestEventStreamHeartbeat(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker)
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	resp, _ := env.connect(t, root.ID.String(), "")
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": heartbeat\n", line)
+}
+
+func TestEventStreamErrors(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker)
+
+	resp, _ := env.connect(t, "invalid", "")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = env.connect(t, "tnntten-missing", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	denied := newTestEnv(t, permissions.DefaultDenyChecker)
+
+	resp, _ = denied.connect(t, "tnntten-missing", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
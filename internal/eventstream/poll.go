@@ -0,0 +1,168 @@
+package eventstream
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/subtree"
+)
+
+// polledChange is a change returned to a polling client along with its cursor.
+type polledChange struct {
+	ID string `json:"id"`
+	change
+}
+
+// pollResponse holds the changes after the cursor a client polled with. Cursor is passed as since
+// on the next poll. Reset is set when the changes after the given cursor are no longer available,
+// the client then has to refresh its state before polling from the returned cursor.
+type pollResponse struct {
+	Changes []polledChange `json:"changes"`
+	Cursor  string         `json:"cursor"`
+	Reset   bool           `json:"reset,omitempty"`
+}
+
+// poll returns the changes within the subtree of the tenant made after the since cursor, the ids
+// of the changes in the feed of this instance. Without since, only changes made from now on are returned.
+// With watch=true the request is held until a change is made or the watch timeout passes,
+// returning no changes in the latter case. The event_types, fields and kinds parameters filter
+// the changes the same as for the event stream, the cursor still advances past the others.
+//
+// Changes come from the feed of this instance, so polling is best effort: changes made through
+// other replicas aren't seen, and cursors from another instance or a restarted one reset the
+// client, as do cursors which fell out of the retained history.
+func (h *Handler) poll(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.checkTenant(c)
+	if err != nil {
+		return err
+	}
+
+	only, err := parseFilter(c)
+	if err != nil {
+		return err
+	}
+
+	watch := false
+
+	if raw := c.QueryParam("watch"); raw != "" {
+		if watch, err = strconv.ParseBool(raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid watch %q", raw)).WithInternal(err)
+		}
+	}
+
+	lastID := h.feed.LastID()
+
+	if since := c.QueryParam("since"); since != "" {
+		epoch, seq, found := strings.Cut(since, ".")
+
+		sinceID, err := strconv.ParseUint(seq, 10, 64)
+		if !found || err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid since %q", since))
+		}
+
+		if epoch != h.feed.Epoch() {
+			return h.reset(c, lastID)
+		}
+
+		lastID = sinceID
+	}
+
+	replay, changes, unsubscribe, err := h.feed.SubscribeAfter(lastID)
+	defer unsubscribe()
+
+	if err != nil {
+		return h.reset(c, h.feed.LastID())
+	}
+
+	scope := subtree.NewScope(h.client, id)
+	fields := redact.FromContext(ctx)
+
+	resp := pollResponse{Changes: []polledChange{}}
+
+	// add advances the cursor past the change, returning whether it is within the subtree and
+	// selected by the filter
+	add := func(ch changefeed.Change) (bool, error) {
+		lastID = ch.ID
+
+		inScope, err := scope.Contains(ctx, ch.Message)
+		if err != nil || !inScope {
+			return false, err
+		}
+
+		data := newChange(ch.Message, fields)
+		if !only.matches(data) {
+			return false, nil
+		}
+
+		resp.Changes = append(resp.Changes, polledChange{
+			ID:     h.cursor(ch.ID),
+			change: data,
+		})
+
+		return true, nil
+	}
+
+	for _, ch := range replay {
+		if _, err := add(ch); err != nil {
+			return err
+		}
+	}
+
+	if watch && len(resp.Changes) == 0 {
+		timer := time.NewTimer(h.watchTimeout)
+		defer timer.Stop()
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-timer.C:
+				break wait
+			case ch, ok := <-changes:
+				if !ok {
+					reqlog.FromContext(ctx, h.logger).Warnw("ending slow tenant change poll")
+
+					break wait
+				}
+
+				inScope, err := add(ch)
+				if err != nil {
+					return err
+				}
+
+				if inScope {
+					break wait
+				}
+			}
+		}
+	}
+
+	resp.Cursor = h.cursor(lastID)
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// reset tells the client to refresh its state and poll from the last change published.
+func (h *Handler) reset(c echo.Context, lastID uint64) error {
+	return c.JSON(http.StatusOK, pollResponse{
+		Changes: []polledChange{},
+		Cursor:  h.cursor(lastID),
+		Reset:   true,
+	})
+}
+
+// cursor returns the id of the change, as used for event ids and poll cursors.
+func (h *Handler) cursor(id uint64) string {
+	return fmt.Sprintf("%s.%d", h.feed.Epoch(), id)
+}
@@ -0,0 +1,135 @@
+package eventstream_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/eventstream"
+)
+
+type polled struct {
+	Changes []struct {
+		ID        string `json:"id"`
+		EventType string `json:"eventType"`
+		TenantID  string `json:"tenantID"`
+	} `json:"changes"`
+	Cursor string `json:"cursor"`
+	Reset  bool   `json:"reset"`
+}
+
+// poll polls the changes of the tenant with the query.
+func (env *testEnv) poll(t *testing.T, id string, query url.Values) (int, polled) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(env.ctx, http.MethodGet, env.url+"/v1/tenants/"+id+"/changes?"+query.Encode(), nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var got polled
+
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.Unmarshal(body, &got), string(body))
+	}
+
+	return resp.StatusCode, got
+}
+
+func TestPollChanges(t *testing.T) {
+	env := newTestEnv(t, permissions.DefaultAllowChecker, eventstream.WithWatchTimeout(time.Second))
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	other := env.client.Tenant.Create().SetName("other").SaveX(env.ctx)
+
+	status, got := env.poll(t, root.ID.String(), nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, got.Changes, "without a cursor only later changes are returned")
+	require.NotEmpty(t, got.Cursor)
+
+	start := got.Cursor
+
+	env.client.Tenant.UpdateOne(other).SetName("unrelated").ExecX(env.ctx)
+	grandchild := env.client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(env.ctx)
+
+	_, got = env.poll(t, root.ID.String(), url.Values{"since": {start}})
+	require.Len(t, got.Changes, 1)
+	assert.Equal(t, "create", got.Changes[0].EventType)
+	assert.Equal(t, grandchild.ID.String(), got.Changes[0].TenantID)
+	assert.Equal(t, got.Changes[0].ID, got.Cursor)
+
+	cursor := got.Cursor
+
+	t.Run("watch", func(t *testing.T) {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+
+			env.client.Tenant.UpdateOne(other).SetName("still unrelated").ExecX(env.ctx)
+			env.client.Tenant.UpdateOne(child).SetName("renamed").ExecX(env.ctx)
+		}()
+
+		started := time.Now()
+
+		_, got := env.poll(t, root.ID.String(), url.Values{"since": {cursor}, "watch": {"true"}})
+		require.Len(t, got.Changes, 1)
+		assert.Equal(t, "update", got.Changes[0].EventType)
+		assert.Equal(t, child.ID.String(), got.Changes[0].TenantID)
+		assert.Less(t, time.Since(started), time.Second, "the poll returns as soon as a change is made")
+
+		cursor = got.Cursor
+	})
+
+	t.Run("watch timeout", func(t *testing.T) {
+		started := time.Now()
+
+		_, got := env.poll(t, root.ID.String(), url.Values{"since": {cursor}, "watch": {"true"}})
+		assert.Empty(t, got.Changes)
+		assert.Equal(t, cursor, got.Cursor)
+		assert.GreaterOrEqual(t, time.Since(started), time.Second)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		_, got := env.poll(t, root.ID.String(), url.Values{"since": {"unknown.1"}})
+		assert.True(t, got.Reset)
+		assert.Empty(t, got.Changes)
+		assert.Equal(t, cursor, got.Cursor)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		_, got := env.poll(t, root.ID.String(), nil)
+		start := got.Cursor
+
+		env.client.Tenant.UpdateOne(child).SetSettings(map[string]any{"region": "us"}).ExecX(env.ctx)
+
+		_, got = env.poll(t, root.ID.String(), url.Values{"since": {start}, "fields": {"name"}})
+		assert.Empty(t, got.Changes)
+		assert.NotEqual(t, start, got.Cursor, "the cursor advances past filtered changes")
+	})
+
+	status, _ = env.poll(t, root.ID.String(), url.Values{"fields": {"colour"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = env.poll(t, root.ID.String(), url.Values{"since": {"invalid"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = env.poll(t, root.ID.String(), url.Values{"watch": {"maybe"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = env.poll(t, "tnntten-missing", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,141 @@
+package eventstream
+
+import (
+	"context"
+	"strconv"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// maxResumedChanges is the most recorded changes a stream is resumed with, clients which missed
+// more are reset.
+const maxResumedChanges = 1000
+
+// resume subscribes to the feed and returns the changes recorded after the change sequence, so
+// streams are resumed whichever instance the client was connected to, even one restarted since.
+// The changes are replayed as they were recorded, with the tenant, the operation and the time of
+// the change but without field changes; the parent the subtree is told from is that of the tenant
+// now, or the one it had when it was deleted. ErrHistoryUnavailable is returned along with the
+// live subscription when changes after the sequence are past their retention, or too many to
+// replay.
+func (h *Handler) resume(ctx context.Context, after int64) ([]changefeed.Change, <-chan changefeed.Change, func(), error) {
+	// subscribed first, the changes made while reading the recorded ones are delivered live
+	changes, unsubscribe := h.feed.Subscribe()
+
+	replay, err := h.recordedChanges(ctx, after)
+	if err != nil {
+		return nil, changes, unsubscribe, err
+	}
+
+	return replay, changes, unsubscribe, nil
+}
+
+// recordedChanges returns the changes recorded after the change sequence, as change messages.
+func (h *Handler) recordedChanges(ctx context.Context, after int64) ([]changefeed.Change, error) {
+	if h.changeRetention > 0 {
+		horizon, err := changeseq.Horizon(ctx, h.client, h.clock.Now().UTC().Add(-h.changeRetention))
+		if err != nil {
+			return nil, err
+		}
+
+		if after < horizon {
+			return nil, changefeed.ErrHistoryUnavailable
+		}
+	}
+
+	recorded, err := h.client.TenantChange.Query().
+		Where(enttenantchange.IDGT(after)).
+		Order(ent.Asc(enttenantchange.FieldID)).
+		Limit(maxResumedChanges + 1).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recorded) > maxResumedChanges {
+		return nil, changefeed.ErrHistoryUnavailable
+	}
+
+	parents, err := changeParents(ctx, h.client, recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := make([]changefeed.Change, 0, len(recorded))
+
+	for _, rc := range recorded {
+		msg := events.ChangeMessage{
+			SubjectID:      rc.TenantID,
+			EventType:      rc.Operation,
+			Timestamp:      rc.ChangedAt,
+			AdditionalData: map[string]any{changeseq.Key: rc.ID},
+		}
+
+		if parent := parents[rc.TenantID]; parent != gidx.NullPrefixedID {
+			msg.AdditionalSubjectIDs = []gidx.PrefixedID{parent}
+		}
+
+		replay = append(replay, changefeed.Change{Message: msg})
+	}
+
+	return replay, nil
+}
+
+// changeParents returns the parents of the tenants of the changes, those of the tenants which
+// still exist, and the parents deleted tenants had.
+func changeParents(ctx context.Context, client *ent.Client, changes []*ent.TenantChange) (map[gidx.PrefixedID]gidx.PrefixedID, error) {
+	parents := make(map[gidx.PrefixedID]gidx.PrefixedID, len(changes))
+	ids := make([]gidx.PrefixedID, 0, len(changes))
+
+	for _, ch := range changes {
+		if ch.Operation == changeseq.OpDelete {
+			parents[ch.TenantID] = ch.ParentTenantID
+		} else {
+			ids = append(ids, ch.TenantID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return parents, nil
+	}
+
+	tenants, err := client.Tenant.Query().
+		Where(enttenant.IDIn(ids...)).
+		Select(enttenant.FieldID, enttenant.FieldParentTenantID).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tenants {
+		parents[t.ID] = t.ParentTenantID
+	}
+
+	return parents, nil
+}
+
+// changeSeq returns the sequence of the recorded change the message carries, false for changes
+// without one.
+func changeSeq(msg events.ChangeMessage) (int64, bool) {
+	seq, ok := msg.AdditionalData[changeseq.Key].(int64)
+
+	return seq, ok
+}
+
+// eventID returns the id of the event of the change: the sequence of its recorded change, which
+// streams resume from on any instance, or the id of the change in the feed of this instance for
+// changes which weren't recorded.
+func (h *Handler) eventID(ch changefeed.Change) string {
+	if seq, ok := changeSeq(ch.Message); ok {
+		return strconv.FormatInt(seq, 10)
+	}
+
+	return h.cursor(ch.ID)
+}
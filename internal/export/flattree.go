@@ -0,0 +1,358 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/stream"
+	"go.infratographer.com/tenant-api/internal/traversal"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+const (
+	// ViewFlatTree is the view listing descendants depth first, each with its depth.
+	ViewFlatTree = "flat_tree"
+
+	// NextPageTokenHeader is set to the token of the next page of a csv flat tree.
+	NextPageTokenHeader = "X-Next-Page-Token"
+
+	// MaxFlatTreeDepth is the deepest level below the tenant listed in a flat tree.
+	MaxFlatTreeDepth = 100
+
+	defaultFlatTreePageSize = 100
+	maxFlatTreePageSize     = 1000
+
+	// pathSeparator sorts before every character of an id, so a tenant's descendants sort right
+	// after it and before its next sibling.
+	pathSeparator = "!"
+)
+
+// flatTreeQuery lists the page of the descendants of the tenant in $1 following the path in $3,
+// down to the depth in $2. A path is the ids from the child of the tenant down to the row,
+// ordering by it lists the rows depth first and siblings by id. Comparing paths needs the
+// bytewise collation SQLite and CockroachDB default to, PostgreSQL databases need the C collation.
+const flatTreeQuery = `
+WITH RECURSIVE tree (id, depth, path) AS (
+	SELECT id, 1, CAST(id AS TEXT)
+	FROM tenants
+	WHERE parent_tenant_id = $1
+	UNION ALL
+	SELECT t.id, tree.depth + 1, tree.path || '` + pathSeparator + `' || t.id
+	FROM tenants t
+	JOIN tree ON t.parent_tenant_id = tree.id
+	WHERE tree.depth < $2
+)
+SELECT id, depth, path
+FROM tree
+WHERE path > $3
+ORDER BY path
+LIMIT $4`
+
+// flatTreeRow is a descendant listed in a flat tree.
+type flatTreeRow struct {
+	id    gidx.PrefixedID
+	depth int
+	path  string
+}
+
+// flatTreePage is the json representation of a flat tree page. The tenants have the columns of the
+// csv export visible to the caller, and their depth below the tenant, children being at depth 1.
+type flatTreePage struct {
+	Tenants       []map[string]any `json:"tenants"`
+	NextPageToken string           `json:"nextPageToken,omitempty"`
+}
+
+// pageToken is the position of a flat tree page: the number of rows listed before it, counted
+// against the row cap, and the path of the last of them.
+type pageToken struct {
+	listed int
+	after  string
+}
+
+func (p pageToken) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(p.listed) + ":" + p.after))
+}
+
+func parsePageToken(raw string) (pageToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return pageToken{}, err
+	}
+
+	listed, after, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return pageToken{}, fmt.Errorf("malformed page token")
+	}
+
+	n, err := strconv.Atoi(listed)
+	if err != nil || n < 0 {
+		return pageToken{}, fmt.Errorf("malformed page token")
+	}
+
+	return pageToken{listed: n, after: after}, nil
+}
+
+// descendants serves the descendants export, or the flat tree when view=flat_tree is given.
+func (h *Handler) descendants(c echo.Context) error {
+	switch view := c.QueryParam("view"); view {
+	case "":
+		return h.export(h.countDescendants, h.listDescendants)(c)
+	case ViewFlatTree:
+		return h.flatTree(c)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown view %q", view))
+	}
+}
+
+// flatTree lists a page of the descendants of the tenant depth first, so a tree view can show the
+// children of each tenant right below it from a single request. Pages are requested with the limit
+// and page_token query parameters, and max_depth limits how deep below the tenant the listing goes.
+// No more than the row cap of rows are listed over all pages. The page is csv when requested, the
+// next page token being in the X-Next-Page-Token header, and json otherwise.
+func (h *Handler) flatTree(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := urnx.ParseTenantID(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).WithInternal(err)
+	}
+
+	limit, err := intParam(c, "limit", defaultFlatTreePageSize, maxFlatTreePageSize)
+	if err != nil {
+		return err
+	}
+
+	maxDepth, err := intParam(c, "max_depth", MaxFlatTreeDepth, MaxFlatTreeDepth)
+	if err != nil {
+		return err
+	}
+
+	var token pageToken
+
+	if raw := c.QueryParam("page_token"); raw != "" {
+		if token, err = parsePageToken(raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid page token").WithInternal(err)
+		}
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantList); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if _, err := h.client.Tenant.Get(ctx, id); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	truncated := false
+
+	switch remaining := h.rowCap - token.listed; {
+	case remaining <= 0:
+		limit = 0
+		truncated = true
+	case remaining <= limit:
+		limit = remaining
+		truncated = true
+	}
+
+	var rows []flatTreeRow
+
+	if limit > 0 {
+		// one more than the limit is listed to know whether there is a next page
+		if rows, err = h.flatTreeRows(ctx, id, maxDepth, token.after, limit+1); err != nil {
+			return err
+		}
+	}
+
+	var next string
+
+	switch {
+	case limit == 0:
+	case len(rows) > limit:
+		rows = rows[:limit]
+
+		if !truncated {
+			next = pageToken{listed: token.listed + limit, after: rows[limit-1].path}.String()
+		}
+	default:
+		// the listing ended before reaching the row cap
+		truncated = false
+	}
+
+	cols := visibleColumns(redact.FromContext(ctx))
+
+	tenants, err := h.load(ctx, cols, rows)
+	if err != nil {
+		return err
+	}
+
+	if truncated {
+		c.Response().Header().Set(TruncatedHeader, "true")
+	}
+
+	if !WantsCSV(c.Request()) {
+		page := flatTreePage{Tenants: make([]map[string]any, 0, len(rows)), NextPageToken: next}
+
+		for _, r := range rows {
+			if t, ok := tenants[r.id]; ok {
+				page.Tenants = append(page.Tenants, jsonRow(cols, t, r.depth))
+			}
+		}
+
+		return c.JSON(http.StatusOK, page)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, csvContentType+"; charset=utf-8")
+
+	if next != "" {
+		res.Header().Set(NextPageTokenHeader, next)
+	}
+
+	w := stream.NewWriter(ctx, res)
+	w.Start(http.StatusOK)
+
+	if err := writeFlatTree(stream.NewCSV(w), cols, rows, tenants); err != nil {
+		w.Fail(err)
+
+		reqlog.FromEcho(c, h.logger).Errorw("failed to write tenant flat tree", "error", err)
+	}
+
+	return nil
+}
+
+func (h *Handler) flatTreeRows(ctx context.Context, id gidx.PrefixedID, maxDepth int, after string, limit int) ([]flatTreeRow, error) {
+	start := time.Now()
+
+	rows, err := h.client.QueryContext(ctx, flatTreeQuery, id, maxDepth, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		tree  []flatTreeRow
+		depth int
+	)
+
+	for rows.Next() {
+		var r flatTreeRow
+
+		if err := rows.Scan(&r.id, &r.depth, &r.path); err != nil {
+			return nil, err
+		}
+
+		tree = append(tree, r)
+
+		if r.depth > depth {
+			depth = r.depth
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	h.traversals.Observe(ctx, traversal.OpTree, len(tree), depth, time.Since(start))
+
+	return tree, nil
+}
+
+// load returns the tenants of the rows by id.
+func (h *Handler) load(ctx context.Context, cols []column, rows []flatTreeRow) (map[gidx.PrefixedID]tenantRow, error) {
+	byID := make(map[gidx.PrefixedID]tenantRow, len(rows))
+
+	for start := 0; start < len(rows); start += fetchBatchSize {
+		batch := rows[start:batchEnd(start, len(rows))]
+
+		ids := make([]gidx.PrefixedID, len(batch))
+
+		for i, r := range batch {
+			ids[i] = r.id
+		}
+
+		tenants, err := h.client.Tenant.Query().Where(tenant.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded, err := h.tenantRows(ctx, cols, tenants)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range loaded {
+			byID[t.ID] = t
+		}
+	}
+
+	return byID, nil
+}
+
+func writeFlatTree(w *stream.CSV, cols []column, rows []flatTreeRow, tenants map[gidx.PrefixedID]tenantRow) error {
+	header := []string{"depth"}
+
+	for _, col := range cols {
+		header = append(header, col.name)
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		t, ok := tenants[r.id]
+		if !ok {
+			// deleted since the rows were listed
+			continue
+		}
+
+		if err := w.Write(append([]string{strconv.Itoa(r.depth)}, row(cols, t)...)); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+func jsonRow(cols []column, t tenantRow, depth int) map[string]any {
+	values := make(map[string]any, len(cols)+1)
+
+	for _, col := range cols {
+		values[col.name] = col.value(t)
+	}
+
+	values["depth"] = depth
+
+	return values
+}
+
+// intParam parses the query parameter, which must be between 1 and upper, returning def when it
+// isn't given.
+func intParam(c echo.Context, name string, def, upper int) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 || n > upper {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s must be between 1 and %d", name, upper))
+	}
+
+	return n, nil
+}
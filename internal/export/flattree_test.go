@@ -0,0 +1,185 @@
+package export_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/export"
+)
+
+// newFixtureTree creates the tree below, where the ids sort the way they are listed among siblings.
+// tnntten-a-b sorts between tnntten-a and its children by id, but after them by path.
+//
+//	tnntten-root
+//	├── tnntten-a
+//	│   ├── tnntten-a1
+//	│   │   └── tnntten-a11
+//	│   └── tnntten-a2
+//	├── tnntten-a-b
+//	└── tnntten-b
+//	    └── tnntten-b1
+func newFixtureTree(t *testing.T, client *ent.Client) gidx.PrefixedID {
+	t.Helper()
+
+	ctx := context.Background()
+
+	create := func(id string, parent *ent.Tenant) *ent.Tenant {
+		c := client.Tenant.Create().SetID(gidx.PrefixedID(id)).SetName(id)
+
+		if parent != nil {
+			c.SetParent(parent)
+		}
+
+		return c.SaveX(ctx)
+	}
+
+	root := create("tnntten-root", nil)
+
+	// created out of order, so the listing can't follow the creation order
+	b := create("tnntten-b", root)
+	create("tnntten-b1", b)
+	create("tnntten-a-b", root)
+
+	a := create("tnntten-a", root)
+	create("tnntten-a2", a)
+	create("tnntten-a11", create("tnntten-a1", a))
+
+	return root.ID
+}
+
+type flatTreePage struct {
+	Tenants []struct {
+		ID    string `json:"id"`
+		Depth int    `json:"depth"`
+	} `json:"tenants"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func getFlatTree(t *testing.T, url string) (*http.Response, flatTreePage) {
+	t.Helper()
+
+	resp, body := get(t, url, "application/json")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+
+	var page flatTreePage
+
+	require.NoError(t, json.Unmarshal([]byte(body), &page))
+
+	return resp, page
+}
+
+func (p flatTreePage) rows() []string {
+	rows := make([]string, len(p.Tenants))
+
+	for i, t := range p.Tenants {
+		rows[i] = strings.Repeat("  ", t.Depth-1) + t.ID
+	}
+
+	return rows
+}
+
+func TestFlatTree(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:export-flat-tree?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := newFixtureTree(t, client)
+
+	url := newTestServer(t, client)
+	path := url + "/v1/tenants/" + root.String() + "/descendants?view=flat_tree"
+
+	expected := []string{
+		"tnntten-a",
+		"  tnntten-a1",
+		"    tnntten-a11",
+		"  tnntten-a2",
+		"tnntten-a-b",
+		"tnntten-b",
+		"  tnntten-b1",
+	}
+
+	resp, page := getFlatTree(t, path)
+	assert.Equal(t, expected, page.rows())
+	assert.Empty(t, page.NextPageToken)
+	assert.Empty(t, resp.Header.Get(export.TruncatedHeader))
+
+	t.Run("pages", func(t *testing.T) {
+		var rows []string
+
+		token := ""
+
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+
+			_, page := getFlatTree(t, path+"&limit=3&page_token="+token)
+			rows = append(rows, page.rows()...)
+
+			if token = page.NextPageToken; token == "" {
+				break
+			}
+		}
+
+		assert.Equal(t, expected, rows)
+	})
+
+	t.Run("depth cap", func(t *testing.T) {
+		_, page := getFlatTree(t, path+"&max_depth=1")
+		assert.Equal(t, []string{"tnntten-a", "tnntten-a-b", "tnntten-b"}, page.rows())
+
+		_, page = getFlatTree(t, url+"/v1/tenants/tnntten-a/descendants?view=flat_tree&max_depth=1")
+		assert.Equal(t, []string{"tnntten-a1", "tnntten-a2"}, page.rows())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		resp, body := get(t, path+"&limit=2", "text/csv")
+		require.Equal(t, http.StatusOK, resp.StatusCode, body)
+		assert.NotEmpty(t, resp.Header.Get(export.NextPageTokenHeader))
+
+		records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"depth", "id", "name"}, records[0][:3])
+		assert.Equal(t, []string{"1", "tnntten-a"}, records[1][:2])
+		assert.Equal(t, []string{"2", "tnntten-a1"}, records[2][:2])
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, query := range []string{"&limit=0", "&max_depth=101", "&page_token=!"} {
+			resp, body := get(t, path+query, "")
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+		}
+
+		resp, body := get(t, url+"/v1/tenants/"+root.String()+"/descendants?view=tree", "")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, body)
+	})
+}
+
+func TestFlatTreeRowCap(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:export-flat-tree-cap?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := newFixtureTree(t, client)
+
+	url := newTestServer(t, client, export.WithRowCap(5))
+	path := url + "/v1/tenants/" + root.String() + "/descendants?view=flat_tree&limit=3"
+
+	resp, page := getFlatTree(t, path)
+	assert.Equal(t, []string{"tnntten-a", "  tnntten-a1", "    tnntten-a11"}, page.rows())
+	assert.Empty(t, resp.Header.Get(export.TruncatedHeader))
+	require.NotEmpty(t, page.NextPageToken)
+
+	// the cap applies over all pages
+	resp, page = getFlatTree(t, path+"&page_token="+page.NextPageToken)
+	assert.Equal(t, []string{"  tnntten-a2", "tnntten-a-b"}, page.rows())
+	assert.Equal(t, "true", resp.Header.Get(export.TruncatedHeader))
+	assert.Empty(t, page.NextPageToken)
+}
@@ -0,0 +1,554 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/concurrency"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/stream"
+	"go.infratographer.com/tenant-api/internal/traversal"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+const (
+	// DefaultRowCap is the maximum number of rows written to a single export.
+	DefaultRowCap = 10000
+
+	// TotalCountHeader is set to the number of tenants matched, before applying the row cap.
+	TotalCountHeader = "X-Total-Count"
+	// TruncatedHeader is set to true when the export was cut off at the row cap.
+	TruncatedHeader = "X-Truncated"
+
+	csvContentType = "text/csv"
+
+	actionTenantList = "tenant_list"
+
+	// fetchBatchSize is the number of tenants loaded per query, keeping IN clauses small.
+	fetchBatchSize = 500
+)
+
+// tenantRow is a tenant exported as a row, with the values loaded for its whole batch.
+type tenantRow struct {
+	*ent.Tenant
+
+	// status is the effective status of the tenant, only loaded when the status column is visible.
+	status string
+}
+
+// column is a csv column and the tenant field it holds.
+type column struct {
+	name  string
+	field string
+	value func(t tenantRow) string
+}
+
+// statusColumn is the name of the column holding the effective status, which is loaded per batch.
+const statusColumn = "status"
+
+var columns = []column{
+	{name: "id", field: redact.FieldID, value: func(t tenantRow) string { return t.ID.String() }},
+	{name: "name", field: redact.FieldName, value: func(t tenantRow) string { return t.Name }},
+	{name: "display_name", field: redact.FieldDisplayName, value: func(t tenantRow) string { return t.DisplayName }},
+	{name: "description", field: redact.FieldDescription, value: func(t tenantRow) string { return t.Description }},
+	{name: "parent_id", field: redact.FieldParent, value: func(t tenantRow) string {
+		if t.ParentTenantID == gidx.NullPrefixedID {
+			return ""
+		}
+
+		return t.ParentTenantID.String()
+	}},
+	{name: "created_at", field: redact.FieldCreatedAt, value: func(t tenantRow) string { return t.CreatedAt.UTC().Format(time.RFC3339) }},
+	{name: "updated_at", field: redact.FieldUpdatedAt, value: func(t tenantRow) string { return t.UpdatedAt.UTC().Format(time.RFC3339) }},
+	{name: "deletion_scheduled_at", field: redact.FieldDeletionScheduledAt, value: func(t tenantRow) string {
+		if t.DeletionScheduledAt.IsZero() {
+			return ""
+		}
+
+		return t.DeletionScheduledAt.UTC().Format(time.RFC3339)
+	}},
+	{name: statusColumn, field: redact.FieldDeletionScheduledAt, value: func(t tenantRow) string { return t.status }},
+	{name: "contact_email", field: redact.FieldContactEmail, value: func(t tenantRow) string { return t.ContactEmail }},
+	{name: "billing_reference", field: redact.FieldBillingReference, value: func(t tenantRow) string { return t.BillingReference }},
+	{name: "labels", field: redact.FieldLabels, value: func(t tenantRow) string { return flattenLabels(t.Labels) }},
+}
+
+// labelEscaper escapes the separators in label values, keys can't hold them.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`)
+
+// flattenLabels returns the labels the tenant sets itself as key=value pairs separated by
+// semicolons, ordered by key. Backslashes and semicolons in values are escaped with a backslash.
+func flattenLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+
+	for k := range labels {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+
+	for i, k := range keys {
+		pairs[i] = k + "=" + labelEscaper.Replace(labels[k])
+	}
+
+	return strings.Join(pairs, ";")
+}
+
+// visibleColumns returns the columns holding fields visible to the caller, redacted columns are left out.
+func visibleColumns(fields redact.Fields) []column {
+	var visible []column
+
+	for _, col := range columns {
+		if fields.Visible(col.field) {
+			visible = append(visible, col)
+		}
+	}
+
+	return visible
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithRowCap sets the maximum number of rows written to a single export.
+func WithRowCap(rowCap int) Option {
+	return func(h *Handler) {
+		h.rowCap = rowCap
+	}
+}
+
+// WithConcurrencyLimiter limits the number of exports served at once, both routes share its slots.
+func WithConcurrencyLimiter(l *concurrency.Limiter) Option {
+	return func(h *Handler) {
+		h.limiter = l
+	}
+}
+
+// WithTraversalMetrics records the walks of the descendants export and the flat tree on the metrics.
+func WithTraversalMetrics(m *traversal.Metrics) Option {
+	return func(h *Handler) {
+		h.traversals = m
+	}
+}
+
+// Handler exports the children or descendants of a tenant as CSV.
+type Handler struct {
+	client     *ent.Client
+	logger     *zap.SugaredLogger
+	middleware []echo.MiddlewareFunc
+	rowCap     int
+	limiter    *concurrency.Limiter
+	traversals *traversal.Metrics
+}
+
+// NewHandler returns an export handler. The middleware authenticates requests and installs the
+// permissions checker, the same as for the graph api.
+func NewHandler(client *ent.Client, logger *zap.SugaredLogger, middleware []echo.MiddlewareFunc, opts ...Option) *Handler {
+	h := &Handler{
+		client:     client,
+		logger:     logger,
+		middleware: append(append([]echo.MiddlewareFunc{}, middleware...), reqlog.Middleware(logger)),
+		rowCap:     DefaultRowCap,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Routes registers the export routes. CSV is selected with an Accept: text/csv header or ?format=csv.
+// The flat tree view of the descendants is also served as json.
+func (h *Handler) Routes(e *echo.Group) {
+	middleware := append(append([]echo.MiddlewareFunc{}, h.middleware...), h.limiter.Middleware())
+
+	e.GET("/v1/tenants/:id/children", h.export(h.countChildren, h.listChildren), middleware...)
+	e.GET("/v1/tenants/:id/descendants", h.descendants, middleware...)
+}
+
+// counter counts the tenants to export, at most limit of them.
+type counter func(ctx context.Context, id gidx.PrefixedID, limit int) (int, error)
+
+// lister passes the tenants to export to fn in order a batch at a time, at most limit of them.
+// Only a batch is loaded at once, so exports take the same memory whatever their size.
+type lister func(ctx context.Context, id gidx.PrefixedID, limit int, fn func([]*ent.Tenant) error) error
+
+// Counter counts the tenants of an export, at most limit of them.
+type Counter func(ctx context.Context, limit int) (int, error)
+
+// Lister passes the tenants of an export to fn in order a batch at a time, at most limit of them.
+type Lister func(ctx context.Context, limit int, fn func([]*ent.Tenant) error) error
+
+func (h *Handler) export(count counter, list lister) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !WantsCSV(c.Request()) {
+			return echo.NewHTTPError(http.StatusNotAcceptable, "only text/csv is supported, use the graph api for json")
+		}
+
+		ctx := c.Request().Context()
+
+		id, err := urnx.ParseTenantID(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).WithInternal(err)
+		}
+
+		if err := permissions.CheckAccess(ctx, id, actionTenantList); err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		if _, err := h.client.Tenant.Get(ctx, id); err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		return h.respond(c, id.String()+".csv",
+			func(ctx context.Context, limit int) (int, error) {
+				return count(ctx, id, limit)
+			},
+			func(ctx context.Context, limit int, fn func([]*ent.Tenant) error) error {
+				return list(ctx, id, limit, fn)
+			},
+		)
+	}
+}
+
+// Respond writes the tenants listed as a csv attachment named filename, the same as the export
+// routes, for other handlers serving tenants as csv. It takes a slot of the concurrency limiter of
+// the exports and writes at most the row cap of tenants, with the same headers.
+func (h *Handler) Respond(c echo.Context, filename string, count Counter, list Lister) error {
+	return h.limiter.Middleware()(func(c echo.Context) error {
+		return h.respond(c, filename, count, list)
+	})(c)
+}
+
+func (h *Handler) respond(c echo.Context, filename string, count Counter, list Lister) error {
+	ctx := c.Request().Context()
+
+	// the tenants are counted before the export starts, so the headers can tell whether
+	// it is truncated; one more than the cap is counted to know
+	matched, err := count(ctx, h.rowCap+1)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, csvContentType+"; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if matched > h.rowCap {
+		res.Header().Set(TruncatedHeader, "true")
+	} else {
+		res.Header().Set(TotalCountHeader, strconv.Itoa(matched))
+	}
+
+	w := stream.NewWriter(ctx, res)
+	w.Start(http.StatusOK)
+
+	if err := h.write(ctx, stream.NewCSV(w), func(fn func([]*ent.Tenant) error) error {
+		return list(ctx, h.rowCap, fn)
+	}); err != nil {
+		// the response has started, all that can be done is to stop writing and report it
+		w.Fail(err)
+
+		reqlog.FromEcho(c, h.logger).Errorw("failed to write tenant export", "error", err)
+	}
+
+	return nil
+}
+
+// write writes the header and the tenants listed, a batch at a time. The effective statuses are
+// loaded for a whole batch at once.
+func (h *Handler) write(ctx context.Context, w *stream.CSV, list func(fn func([]*ent.Tenant) error) error) error {
+	cols := visibleColumns(redact.FromContext(ctx))
+
+	header := make([]string, len(cols))
+
+	for i, col := range cols {
+		header[i] = col.name
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	if err := list(func(tenants []*ent.Tenant) error {
+		rows, err := h.tenantRows(ctx, cols, tenants)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range rows {
+			if err := w.Write(row(cols, t)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// tenantRows returns the rows of a batch of tenants, loading the effective statuses of all of them
+// at once when the status column is exported.
+func (h *Handler) tenantRows(ctx context.Context, cols []column, tenants []*ent.Tenant) ([]tenantRow, error) {
+	var statuses map[gidx.PrefixedID]string
+
+	for _, col := range cols {
+		if col.name != statusColumn {
+			continue
+		}
+
+		var err error
+
+		if statuses, err = deletion.EffectiveStatuses(ctx, h.client, tenants); err != nil {
+			return nil, err
+		}
+	}
+
+	rows := make([]tenantRow, len(tenants))
+
+	for i, t := range tenants {
+		rows[i] = tenantRow{Tenant: t, status: statuses[t.ID]}
+	}
+
+	return rows, nil
+}
+
+func row(cols []column, t tenantRow) []string {
+	values := make([]string, len(cols))
+
+	for i, col := range cols {
+		values[i] = col.value(t)
+	}
+
+	return values
+}
+
+func (h *Handler) countChildren(ctx context.Context, id gidx.PrefixedID, limit int) (int, error) {
+	n, err := h.client.Tenant.Query().Where(tenant.ParentTenantID(id)).Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > limit {
+		n = limit
+	}
+
+	return n, nil
+}
+
+// listChildren lists the children by id a page at a time, each page following the last id of the
+// one before.
+func (h *Handler) listChildren(ctx context.Context, id gidx.PrefixedID, limit int, fn func([]*ent.Tenant) error) error {
+	after := gidx.NullPrefixedID
+
+	for listed := 0; listed < limit; {
+		query := h.client.Tenant.Query().Where(tenant.ParentTenantID(id))
+
+		if after != gidx.NullPrefixedID {
+			query.Where(tenant.IDGT(after))
+		}
+
+		children, err := query.
+			Order(ent.Asc(tenant.FieldID)).
+			Limit(batchEnd(listed, limit) - listed).
+			All(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(children) == 0 {
+			return nil
+		}
+
+		if err := fn(children); err != nil {
+			return err
+		}
+
+		listed += len(children)
+		after = children[len(children)-1].ID
+	}
+
+	return nil
+}
+
+// maxDescendantDepth bounds the walk of the descendants, keeping a cycle in the hierarchy from
+// recursing forever.
+const maxDescendantDepth = 1000
+
+// descendantsQuery walks the subtree below the tenant in $1, down to the depth in $2. The rows are
+// ordered by depth so parents are always listed before their children.
+const descendantsQuery = `
+WITH RECURSIVE descendants (id, depth) AS (
+	SELECT id, 1
+	FROM tenants
+	WHERE parent_tenant_id = $1
+	UNION ALL
+	SELECT t.id, d.depth + 1
+	FROM tenants t
+	JOIN descendants d ON t.parent_tenant_id = d.id
+	WHERE d.depth < $2
+)`
+
+const (
+	countDescendantsQuery = descendantsQuery + `
+SELECT COUNT(*) FROM (SELECT id FROM descendants LIMIT $3) capped`
+
+	listDescendantsQuery = descendantsQuery + `
+SELECT id, depth FROM descendants ORDER BY depth, id LIMIT $3`
+)
+
+func (h *Handler) countDescendants(ctx context.Context, id gidx.PrefixedID, limit int) (int, error) {
+	rows, err := h.client.QueryContext(ctx, countDescendantsQuery, id, maxDescendantDepth, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	var n int
+
+	if rows.Next() {
+		if err := rows.Scan(&n); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, rows.Err()
+}
+
+// listDescendants reads the descendants from a database cursor, loading them a batch at a time.
+func (h *Handler) listDescendants(ctx context.Context, id gidx.PrefixedID, limit int, fn func([]*ent.Tenant) error) error {
+	start := time.Now()
+
+	rows, err := h.client.QueryContext(ctx, listDescendantsQuery, id, maxDescendantDepth, limit)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var (
+		batch  = make([]gidx.PrefixedID, 0, fetchBatchSize)
+		listed int
+		depth  int
+	)
+
+	flush := func() error {
+		tenants, err := h.loadOrdered(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		listed += len(batch)
+		batch = batch[:0]
+
+		return fn(tenants)
+	}
+
+	for rows.Next() {
+		var (
+			child gidx.PrefixedID
+			level int
+		)
+
+		if err := rows.Scan(&child, &level); err != nil {
+			return err
+		}
+
+		batch = append(batch, child)
+
+		if level > depth {
+			depth = level
+		}
+
+		if len(batch) == fetchBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) != 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+
+	h.traversals.Observe(ctx, traversal.OpDescendants, listed, depth, time.Since(start))
+
+	return nil
+}
+
+// loadOrdered loads the tenants in the order of the ids, leaving out those deleted since.
+func (h *Handler) loadOrdered(ctx context.Context, ids []gidx.PrefixedID) ([]*ent.Tenant, error) {
+	tenants, err := h.client.Tenant.Query().Where(tenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[gidx.PrefixedID]*ent.Tenant, len(tenants))
+
+	for _, t := range tenants {
+		byID[t.ID] = t
+	}
+
+	ordered := make([]*ent.Tenant, 0, len(tenants))
+
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			ordered = append(ordered, t)
+		}
+	}
+
+	return ordered, nil
+}
+
+func batchEnd(start, length int) int {
+	if end := start + fetchBatchSize; end < length {
+		return end
+	}
+
+	return length
+}
+
+// WantsCSV reports whether csv was requested with the format query parameter or the Accept header.
+func WantsCSV(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "csv"
+	}
+
+	for _, accept := range strings.Split(r.Header.Get(echo.HeaderAccept), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && mediaType == csvContentType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,237 @@
+package export_test
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/export"
+)
+
+func newTestServer(t *testing.T, client *ent.Client, opts ...export.Option) string {
+	t.Helper()
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	export.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()}, opts...).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func get(t *testing.T, url, accept string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(body)
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:export?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName(`Acme, "Inc"`).SetDisplayName("Acme").SetDescription("multi\nline").SetParent(root).
+		SetLabels(map[string]string{"env": "prod", "note": `a;b\c`}).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("deleted").SetParent(root).SetDeletionScheduledAt(time.Now()).SaveX(ctx)
+
+	url := newTestServer(t, client)
+
+	resp, body := get(t, url+"/v1/tenants/"+root.ID.String()+"/children", "text/csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "2", resp.Header.Get(export.TotalCountHeader))
+	assert.Contains(t, body, `"Acme, ""Inc""",Acme,"multi`+"\n"+`line"`)
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "name", "display_name", "description", "parent_id", "created_at", "updated_at", "deletion_scheduled_at", "status", "contact_email", "billing_reference", "labels"}, records[0])
+
+	rows := map[string][]string{}
+
+	for _, record := range records[1:] {
+		rows[record[0]] = record
+	}
+
+	assert.Equal(t, []string{child.ID.String(), `Acme, "Inc"`, "Acme", "multi\nline", root.ID.String()}, rows[child.ID.String()][:5])
+	assert.Equal(t, "active", rows[child.ID.String()][8])
+	assert.Equal(t, "pending_deletion", rows[deleted.ID.String()][8])
+	assert.Equal(t, `env=prod;note=a\;b\\c`, rows[child.ID.String()][11], "labels are flattened, separators in values escaped")
+
+	resp, body = get(t, url+"/v1/tenants/"+root.ID.String()+"/descendants?format=csv", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+
+	records, err = csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	statuses := map[string]string{}
+
+	for _, record := range records[1:] {
+		statuses[record[0]] = record[8]
+	}
+
+	assert.Equal(t, map[string]string{
+		child.ID.String():      "active",
+		deleted.ID.String():    "pending_deletion",
+		grandchild.ID.String(): "active",
+	}, statuses)
+	assert.Equal(t, grandchild.ID.String(), records[3][0], "parents are listed before their children")
+
+	resp, _ = get(t, url+"/v1/tenants/"+root.ID.String()+"/children", "application/json")
+	assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+
+	resp, _ = get(t, url+"/v1/tenants/tnntten-missing/children?format=csv", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestExportRowCap(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:export-cap?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := client.Tenant.Create().SetName("root").SetDeletionScheduledAt(time.Now()).SaveX(ctx)
+
+	for i := 0; i < 5; i++ {
+		client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	}
+
+	url := newTestServer(t, client, export.WithRowCap(3))
+
+	resp, body := get(t, url+"/v1/tenants/"+root.ID.String()+"/descendants", "text/csv;q=0.9, */*;q=0.1")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+	assert.Equal(t, "true", resp.Header.Get(export.TruncatedHeader))
+	assert.Empty(t, resp.Header.Get(export.TotalCountHeader))
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	for _, record := range records[1:] {
+		assert.Equal(t, "parent_deleted", record[8], "the status follows the deletion of the root")
+	}
+}
+
+// sampler is a response writer which drops the body, sampling the heap as the rows are flushed.
+type sampler struct {
+	header  http.Header
+	written int
+	flushes int
+	maxHeap uint64
+}
+
+func (s *sampler) Header() http.Header {
+	return s.header
+}
+
+func (s *sampler) Write(p []byte) (int, error) {
+	s.written += len(p)
+
+	return len(p), nil
+}
+
+func (s *sampler) WriteHeader(int) {}
+
+func (s *sampler) Flush() {
+	s.flushes++
+
+	if s.flushes%10 == 0 {
+		if heap := heapAlloc(); heap > s.maxHeap {
+			s.maxHeap = heap
+		}
+	}
+}
+
+func heapAlloc() uint64 {
+	var stats runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+func TestExportMemoryStaysFlat(t *testing.T) {
+	const tenants = 10000
+
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:export-memory?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	description := strings.Repeat("a description long enough for the rows to add up to megabytes ", 8)
+
+	for created := 0; created < tenants; created += 1000 {
+		builders := make([]*ent.TenantCreate, 0, 1000)
+
+		for i := created; i < created+1000; i++ {
+			builders = append(builders, client.Tenant.Create().SetName("tenant "+strconv.Itoa(i)).SetDescription(description).SetParent(root))
+		}
+
+		client.Tenant.CreateBulk(builders...).ExecX(ctx)
+	}
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+	export.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()}).Routes(e.Group(""))
+
+	for _, path := range []string{"/children", "/descendants"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/tenants/"+root.ID.String()+path+"?format=csv", nil)
+		res := &sampler{header: http.Header{}}
+
+		before := heapAlloc()
+
+		e.ServeHTTP(res, req)
+
+		assert.Equal(t, strconv.Itoa(tenants), res.header.Get(export.TotalCountHeader), path)
+		assert.Greater(t, res.written, 4<<20, "the rows add up to megabytes")
+
+		// the heap held a batch of tenants at a time, never the whole export
+		require.NotZero(t, res.maxHeap, path)
+		assert.Less(t, int64(res.maxHeap)-int64(before), int64(res.written/4), path)
+	}
+}
@@ -0,0 +1,56 @@
+package export_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/export"
+	"go.infratographer.com/tenant-api/internal/traversal"
+)
+
+func TestTraversalMetrics(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:export-traversal?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := newFixtureTree(t, client)
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := traversal.NewMetrics(zap.NewNop().Sugar(), reg)
+	require.NoError(t, err)
+
+	url := newTestServer(t, client, export.WithTraversalMetrics(metrics))
+
+	getFlatTree(t, url+"/v1/tenants/"+root.String()+"/descendants?view=flat_tree")
+
+	resp, body := get(t, url+"/v1/tenants/"+root.String()+"/descendants", "text/csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode, body)
+
+	// the fixture tree has seven descendants, three levels deep
+	for _, op := range []string{traversal.OpTree, traversal.OpDescendants} {
+		observed := map[string]float64{}
+
+		families, err := reg.Gather()
+		require.NoError(t, err)
+
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				if m.GetLabel()[0].GetValue() == op {
+					assert.Equal(t, uint64(1), m.GetHistogram().GetSampleCount(), "%s %s", family.GetName(), op)
+
+					observed[family.GetName()] = m.GetHistogram().GetSampleSum()
+				}
+			}
+		}
+
+		assert.Equal(t, float64(7), observed["tenant_api_traversal_nodes"], op)
+		assert.Equal(t, float64(3), observed["tenant_api_traversal_depth"], op)
+		assert.Contains(t, observed, "tenant_api_traversal_duration_seconds", op)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failures keeps the recent failed requests in memory, so they can be looked at without
+// access to the logs. Requests are recorded with their request id, actor, tenant and trace id,
+// which correlate them with the logs, traces and change events of the request. The record is a
+// ring buffer with a hard cap, the oldest failures are dropped as new ones are recorded and
+// failures older than the retention aren't reported.
+package failures
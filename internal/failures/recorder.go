@@ -0,0 +1,259 @@
+package failures
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/echojwtx"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+)
+
+const (
+	// DefaultSize is the default number of failures kept.
+	DefaultSize = 256
+	// MaxSize bounds the number of failures kept, whatever the configured size.
+	MaxSize = 10000
+	// DefaultRetention is the default time failures are reported for.
+	DefaultRetention = time.Hour
+
+	// maxErrorLength bounds the length of the error message kept for a failure.
+	maxErrorLength = 512
+)
+
+// DefaultStatuses are the client error statuses recorded by default, server errors are always
+// recorded.
+var DefaultStatuses = []int{
+	http.StatusUnauthorized,
+	http.StatusForbidden,
+	http.StatusConflict,
+	http.StatusTooManyRequests,
+}
+
+// Failure is a failed request.
+type Failure struct {
+	RequestID string    `json:"requestID,omitempty"`
+	TraceID   string    `json:"traceID,omitempty"`
+	Method    string    `json:"method"`
+	Route     string    `json:"route"`
+	Status    int       `json:"status"`
+	Code      string    `json:"code,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	TenantID  string    `json:"tenantID,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+	Duration  string    `json:"duration"`
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithSize sets the number of failures kept, at most MaxSize.
+func WithSize(size int) Option {
+	return func(r *Recorder) {
+		switch {
+		case size > MaxSize:
+			r.size = MaxSize
+		case size > 0:
+			r.size = size
+		}
+	}
+}
+
+// WithRetention sets the time failures are reported for.
+func WithRetention(retention time.Duration) Option {
+	return func(r *Recorder) {
+		if retention > 0 {
+			r.retention = retention
+		}
+	}
+}
+
+// WithStatuses sets the client error statuses recorded, server errors are always recorded.
+func WithStatuses(statuses []int) Option {
+	return func(r *Recorder) {
+		r.statuses = make(map[int]bool, len(statuses))
+
+		for _, status := range statuses {
+			r.statuses[status] = true
+		}
+	}
+}
+
+// WithClock sets the clock requests are timed with and the retention applies by, the wall clock
+// by default.
+func WithClock(c clock.Clock) Option {
+	return func(r *Recorder) {
+		r.clock = c
+	}
+}
+
+// Recorder records the recent failed requests. It is safe for concurrent use.
+type Recorder struct {
+	size      int
+	retention time.Duration
+	statuses  map[int]bool
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	failures []Failure
+	next     int
+}
+
+// NewRecorder returns a recorder.
+func NewRecorder(opts ...Option) *Recorder {
+	r := &Recorder{
+		size:      DefaultSize,
+		retention: DefaultRetention,
+		clock:     clock.Real{},
+	}
+
+	WithStatuses(DefaultStatuses)(r)
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.failures = make([]Failure, 0, r.size)
+
+	return r
+}
+
+// Size returns the number of failures kept.
+func (r *Recorder) Size() int {
+	return r.size
+}
+
+// Retention returns the time failures are reported for.
+func (r *Recorder) Retention() time.Duration {
+	return r.retention
+}
+
+// Records reports whether requests failing with the status are recorded.
+func (r *Recorder) Records(status int) bool {
+	return status >= http.StatusInternalServerError || r.statuses[status]
+}
+
+// Record records the failure, replacing the oldest one once the recorder is full.
+func (r *Recorder) Record(f Failure) {
+	if len(f.Error) > maxErrorLength {
+		f.Error = f.Error[:maxErrorLength]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.failures) < r.size {
+		r.failures = append(r.failures, f)
+	} else {
+		r.failures[r.next] = f
+	}
+
+	r.next = (r.next + 1) % r.size
+}
+
+// Recent returns the failures recorded within the retention, the latest first.
+func (r *Recorder) Recent() []Failure {
+	cutoff := r.clock.Now().Add(-r.retention)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recent := make([]Failure, 0, len(r.failures))
+
+	for i := 1; i <= len(r.failures); i++ {
+		f := r.failures[(r.next-i+len(r.failures))%len(r.failures)]
+
+		// failures are recorded in order, the rest are older still
+		if f.StartedAt.Before(cutoff) {
+			break
+		}
+
+		recent = append(recent, f)
+	}
+
+	return recent
+}
+
+// Middleware returns echo middleware recording the requests which fail with a recorded status.
+// It should run before the authentication middleware so requests it rejects are recorded, the
+// actor is read from the request once handled.
+func (r *Recorder) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := r.clock.Now()
+
+			err := next(c)
+
+			status, code := Outcome(c, err)
+			if !r.Records(status) {
+				return err
+			}
+
+			req := c.Request()
+
+			f := Failure{
+				Method:    req.Method,
+				Route:     c.Path(),
+				Status:    status,
+				Code:      code,
+				TenantID:  c.Param("id"),
+				StartedAt: start.UTC(),
+				Duration:  r.clock.Now().Sub(start).String(),
+			}
+
+			// requests rejected before routing, and grpc calls, have no route
+			if f.Route == "" {
+				f.Route = req.URL.Path
+			}
+
+			if f.RequestID = c.Response().Header().Get(echo.HeaderXRequestID); f.RequestID == "" {
+				f.RequestID = req.Header.Get(echo.HeaderXRequestID)
+			}
+
+			if sc := trace.SpanContextFromContext(req.Context()); sc.HasTraceID() {
+				f.TraceID = sc.TraceID().String()
+			}
+
+			if actor, ok := req.Context().Value(echojwtx.ActorCtxKey).(string); ok {
+				f.Actor = actor
+			}
+
+			if err != nil {
+				f.Error = err.Error()
+			}
+
+			r.Record(f)
+
+			return err
+		}
+	}
+}
+
+// Outcome returns the status of the response to the request and the error code of its body,
+// the status is taken from the error when the handler failed as echo writes the response later.
+func Outcome(c echo.Context, err error) (int, string) {
+	if err == nil {
+		return c.Response().Status, ""
+	}
+
+	var herr *echo.HTTPError
+	if !errors.As(err, &herr) {
+		return http.StatusInternalServerError, ""
+	}
+
+	switch msg := herr.Message.(type) {
+	case map[string]string:
+		return herr.Code, msg["code"]
+	case map[string]any:
+		code, _ := msg["code"].(string)
+
+		return herr.Code, code
+	default:
+		return herr.Code, ""
+	}
+}
@@ -0,0 +1,135 @@
+package failures_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/failures"
+)
+
+func TestRecorderRing(t *testing.T) {
+	r := failures.NewRecorder(failures.WithSize(3))
+
+	for i := 0; i < 5; i++ {
+		r.Record(failures.Failure{RequestID: fmt.Sprint(i), StartedAt: time.Now()})
+	}
+
+	recent := r.Recent()
+	require.Len(t, recent, 3)
+
+	// the oldest failures are dropped, the latest come first
+	assert.Equal(t, []string{"4", "3", "2"}, []string{recent[0].RequestID, recent[1].RequestID, recent[2].RequestID})
+
+	assert.Equal(t, failures.MaxSize, failures.NewRecorder(failures.WithSize(failures.MaxSize+1)).Size())
+	assert.Equal(t, failures.DefaultSize, failures.NewRecorder(failures.WithSize(0)).Size())
+}
+
+func TestRecorderRetention(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	r := failures.NewRecorder(failures.WithRetention(time.Minute), failures.WithClock(fake))
+
+	r.Record(failures.Failure{RequestID: "old", StartedAt: fake.Now()})
+
+	fake.Advance(time.Minute + time.Second)
+
+	r.Record(failures.Failure{RequestID: "new", StartedAt: fake.Now()})
+
+	recent := r.Recent()
+	require.Len(t, recent, 1)
+	assert.Equal(t, "new", recent[0].RequestID)
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	r := failures.NewRecorder(failures.WithSize(10))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				r.Record(failures.Failure{StartedAt: time.Now()})
+				r.Recent()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, r.Recent(), 10)
+}
+
+func TestRecorderMiddleware(t *testing.T) {
+	r := failures.NewRecorder(failures.WithStatuses([]int{http.StatusConflict}))
+
+	e := echo.New()
+	e.Use(r.Middleware())
+
+	actor := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, "idntusr-tester")))
+
+			return next(c)
+		}
+	}
+
+	e.GET("/tenants/:id", func(c echo.Context) error {
+		switch c.Param("id") {
+		case "broken":
+			return errors.New("database unreachable")
+		case "taken":
+			return echo.NewHTTPError(http.StatusConflict, map[string]string{"code": "name_taken", "message": "taken"})
+		case "missing":
+			return echo.NewHTTPError(http.StatusNotFound, "not found")
+		case "unavailable":
+			return c.String(http.StatusServiceUnavailable, "try later")
+		default:
+			return c.String(http.StatusOK, "ok")
+		}
+	}, actor)
+
+	for _, id := range []string{"ok", "broken", "taken", "missing", "unavailable"} {
+		req := httptest.NewRequest(http.MethodGet, "/tenants/"+id, nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-"+id)
+
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	recent := r.Recent()
+	require.Len(t, recent, 3, "successes and unselected client errors aren't recorded")
+
+	unavailable, taken, broken := recent[0], recent[1], recent[2]
+
+	assert.Equal(t, "req-unavailable", unavailable.RequestID)
+	assert.Equal(t, http.StatusServiceUnavailable, unavailable.Status)
+
+	assert.Equal(t, "req-taken", taken.RequestID)
+	assert.Equal(t, http.StatusConflict, taken.Status)
+	assert.Equal(t, "name_taken", taken.Code)
+	assert.Equal(t, "taken", taken.TenantID)
+
+	assert.Equal(t, "req-broken", broken.RequestID)
+	assert.Equal(t, http.StatusInternalServerError, broken.Status)
+	assert.Equal(t, http.MethodGet, broken.Method)
+	assert.Equal(t, "/tenants/:id", broken.Route)
+	assert.Equal(t, "idntusr-tester", broken.Actor)
+	assert.Equal(t, "broken", broken.TenantID)
+	assert.Contains(t, broken.Error, "database unreachable")
+	assert.False(t, broken.StartedAt.IsZero())
+}
@@ -0,0 +1,7 @@
+//go:build faults
+
+package faults
+
+// Compiled reports whether the binary was built with the faults build tag, enabling injection
+// without the unsafe setting.
+const Compiled = true
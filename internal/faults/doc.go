@@ -0,0 +1,32 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faults injects errors and latency at defined points of the service, to test the retry
+// and publish paths under failure without changing the code.
+//
+// Faults are programmed on an Injector for each point, firing with a probability. When the
+// Injector accepts the header trigger, a request may also force the faults of the points listed
+// in its X-Fault-Inject header, which is meant for tests only. The points are:
+//
+//   - db.before_commit and db.after_commit, around the commit of transactions made through the
+//     ent driver wrapped with Driver. A fault after the commit fails the call although the
+//     transaction was committed, like a commit acknowledgement lost on the way.
+//   - events.publish, before changes are published on the connection wrapped with Connection.
+//   - jwks.fetch, before the signing keys of tokens are fetched through Transport. Keys are
+//     fetched in the background, so only the probability of its fault applies.
+//
+// Injection must never be enabled in production by accident. The server only creates an
+// Injector when it is built with the faults build tag, or when the unsafe fault injection
+// setting is explicitly enabled, and warns about it on startup.
+package faults
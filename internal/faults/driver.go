@@ -0,0 +1,125 @@
+package faults
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"entgo.io/ent/dialect"
+)
+
+var errUnsupported = errors.New("not supported by the wrapped driver")
+
+type (
+	queryContexter interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	}
+
+	execContexter interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	}
+
+	txBeginner interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	}
+)
+
+type driver struct {
+	dialect.Driver
+	injector *Injector
+}
+
+// Driver wraps the ent driver to inject the faults of db.before_commit and db.after_commit around
+// the commit of transactions, the faults being forced by the context the transaction was started
+// with. Statements run outside of transactions aren't affected. A nil injector returns the driver
+// as it is.
+func Driver(drv dialect.Driver, i *Injector) dialect.Driver {
+	if i == nil {
+		return drv
+	}
+
+	return &driver{Driver: drv, injector: i}
+}
+
+// QueryContext is used by the client for raw queries.
+func (d *driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	qc, ok := d.Driver.(queryContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	return qc.QueryContext(ctx, query, args...)
+}
+
+// ExecContext is used by the client for raw statements.
+func (d *driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ec, ok := d.Driver.(execContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	return ec.ExecContext(ctx, query, args...)
+}
+
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txn{Tx: tx, ctx: ctx, injector: d.injector}, nil
+}
+
+// BeginTx is used by the client to start transactions with options.
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	b, ok := d.Driver.(txBeginner)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	tx, err := b.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txn{Tx: tx, ctx: ctx, injector: d.injector}, nil
+}
+
+type txn struct {
+	dialect.Tx
+	// ctx is the context the transaction was started with, commits don't take one
+	ctx      context.Context
+	injector *Injector
+}
+
+// Commit rolls the transaction back when a fault fires before the commit, and reports the
+// fault firing after it although the transaction is committed.
+func (t *txn) Commit() error {
+	if err := t.injector.Inject(t.ctx, PointBeforeCommit); err != nil {
+		return errors.Join(err, t.Tx.Rollback())
+	}
+
+	if err := t.Tx.Commit(); err != nil {
+		return err
+	}
+
+	return t.injector.Inject(t.ctx, PointAfterCommit)
+}
+
+func (t *txn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	qc, ok := t.Tx.(queryContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	return qc.QueryContext(ctx, query, args...)
+}
+
+func (t *txn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ec, ok := t.Tx.(execContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	return ec.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,225 @@
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Point names a place faults are injected at.
+type Point string
+
+// The points faults are injected at.
+const (
+	PointBeforeCommit Point = "db.before_commit"
+	PointAfterCommit  Point = "db.after_commit"
+	PointPublish      Point = "events.publish"
+	PointJWKSFetch    Point = "jwks.fetch"
+)
+
+// Points lists every point faults are injected at.
+var Points = []Point{PointBeforeCommit, PointAfterCommit, PointPublish, PointJWKSFetch}
+
+var (
+	// ErrInjected is returned by injected faults, wrapping their error when they have one.
+	ErrInjected = errors.New("injected fault")
+
+	// ErrUnknownPoint is returned when parsing a rule of an unknown point.
+	ErrUnknownPoint = errors.New("unknown fault point")
+
+	// ErrInvalidRule is returned when parsing a malformed rule.
+	ErrInvalidRule = errors.New("invalid fault rule")
+)
+
+// ParsePoint parses the name of a point.
+func ParsePoint(s string) (Point, error) {
+	for _, p := range Points {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownPoint, s)
+}
+
+// Fault is injected at a point. It waits for the latency, then fails with the error, ErrInjected
+// when none is set. A fault with a latency and no error only delays, unless forced by a request.
+type Fault struct {
+	// Probability is the chance of the fault firing on each pass, from 0 to 1. Faults forced by
+	// a request always fire.
+	Probability float64
+	Latency     time.Duration
+	Err         error
+}
+
+// ParseRule parses a fault rule formatted as point:probability[:latency], such as
+// events.publish:0.1:200ms. A rule with a latency only delays.
+func ParseRule(s string) (Point, Fault, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", Fault{}, fmt.Errorf("%w: expected point:probability[:latency], got %q", ErrInvalidRule, s)
+	}
+
+	point, err := ParsePoint(parts[0])
+	if err != nil {
+		return "", Fault{}, err
+	}
+
+	probability, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || probability < 0 || probability > 1 {
+		return "", Fault{}, fmt.Errorf("%w: probability must be between 0 and 1, got %q", ErrInvalidRule, parts[1])
+	}
+
+	fault := Fault{Probability: probability}
+
+	if len(parts) == 3 {
+		latency, err := time.ParseDuration(parts[2])
+		if err != nil || latency < 0 {
+			return "", Fault{}, fmt.Errorf("%w: latency must be a positive duration, got %q", ErrInvalidRule, parts[2])
+		}
+
+		fault.Latency = latency
+	}
+
+	return point, fault, nil
+}
+
+// Option configures an Injector.
+type Option func(*Injector)
+
+// WithFault programs the fault of the point.
+func WithFault(point Point, f Fault) Option {
+	return func(i *Injector) {
+		i.faults[point] = f
+	}
+}
+
+// WithHeaderTrigger lets requests force the faults of the points listed in their X-Fault-Inject
+// header. It is meant for tests only, any caller could fail its requests at will.
+func WithHeaderTrigger() Option {
+	return func(i *Injector) {
+		i.header = true
+	}
+}
+
+// WithRand sets the source of the numbers, in [0, 1), deciding whether faults fire.
+func WithRand(f func() float64) Option {
+	return func(i *Injector) {
+		i.rand = f
+	}
+}
+
+// Injector injects the faults programmed for each point. A nil Injector injects nothing, so the
+// wrappers can be installed unconditionally.
+type Injector struct {
+	header bool
+	rand   func() float64
+
+	mu     sync.Mutex
+	faults map[Point]Fault
+	fired  map[Point]int
+}
+
+// New returns an injector.
+func New(opts ...Option) *Injector {
+	i := &Injector{
+		rand:   rand.Float64,
+		faults: make(map[Point]Fault),
+		fired:  make(map[Point]int),
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// Set programs the fault of the point, replacing the former one.
+func (i *Injector) Set(point Point, f Fault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.faults[point] = f
+}
+
+// Clear removes the fault of the point.
+func (i *Injector) Clear(point Point) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.faults, point)
+}
+
+// Reset removes every fault and the counts of those fired.
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.faults = make(map[Point]Fault)
+	i.fired = make(map[Point]int)
+}
+
+// Fired returns the number of times a fault fired at the point.
+func (i *Injector) Fired(point Point) int {
+	if i == nil {
+		return 0
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.fired[point]
+}
+
+// Inject fires the fault of the point when it is programmed and its probability allows it, or
+// when the context forces it. It waits for the latency of the fault, returning early with the
+// error of the context when it is done, then returns the error of the fault.
+func (i *Injector) Inject(ctx context.Context, point Point) error {
+	if i == nil {
+		return nil
+	}
+
+	forced := i.header && isForced(ctx, point)
+
+	i.mu.Lock()
+
+	f, ok := i.faults[point]
+
+	fire := forced || (ok && f.Probability > 0 && i.rand() < f.Probability)
+	if fire {
+		i.fired[point]++
+	}
+
+	i.mu.Unlock()
+
+	if !fire {
+		return nil
+	}
+
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if f.Err == nil && !forced {
+			return nil
+		}
+	}
+
+	if f.Err != nil {
+		return fmt.Errorf("%w at %s: %w", ErrInjected, point, f.Err)
+	}
+
+	return fmt.Errorf("%w at %s", ErrInjected, point)
+}
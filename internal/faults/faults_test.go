@@ -0,0 +1,207 @@
+package faults_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/faults"
+)
+
+func TestParseRule(t *testing.T) {
+	point, fault, err := faults.ParseRule("events.publish:0.25:150ms")
+	require.NoError(t, err)
+	assert.Equal(t, faults.PointPublish, point)
+	assert.Equal(t, faults.Fault{Probability: 0.25, Latency: 150 * time.Millisecond}, fault)
+
+	point, fault, err = faults.ParseRule("db.after_commit:1")
+	require.NoError(t, err)
+	assert.Equal(t, faults.PointAfterCommit, point)
+	assert.Equal(t, faults.Fault{Probability: 1}, fault)
+
+	_, _, err = faults.ParseRule("db.rollback:1")
+	assert.ErrorIs(t, err, faults.ErrUnknownPoint)
+
+	for _, rule := range []string{"events.publish", "events.publish:2", "events.publish:x", "events.publish:1:soon", "events.publish:1:1s:extra"} {
+		_, _, err = faults.ParseRule(rule)
+		assert.ErrorIs(t, err, faults.ErrInvalidRule, rule)
+	}
+}
+
+func TestInject(t *testing.T) {
+	ctx := context.Background()
+	roll := 0.5
+
+	i := faults.New(faults.WithRand(func() float64 { return roll }))
+
+	assert.NoError(t, i.Inject(ctx, faults.PointPublish), "nothing fires without a fault")
+
+	i.Set(faults.PointPublish, faults.Fault{Probability: 0.5})
+	assert.NoError(t, i.Inject(ctx, faults.PointPublish), "faults fire below their probability")
+
+	roll = 0.49
+	assert.ErrorIs(t, i.Inject(ctx, faults.PointPublish), faults.ErrInjected)
+	assert.Equal(t, 1, i.Fired(faults.PointPublish))
+
+	refused := errors.New("connection refused")
+
+	i.Set(faults.PointPublish, faults.Fault{Probability: 1, Err: refused})
+	err := i.Inject(ctx, faults.PointPublish)
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	assert.ErrorIs(t, err, refused)
+
+	i.Set(faults.PointPublish, faults.Fault{Probability: 1, Latency: 20 * time.Millisecond})
+
+	start := time.Now()
+	assert.NoError(t, i.Inject(ctx, faults.PointPublish), "latency only faults only delay")
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, i.Inject(canceled, faults.PointPublish), context.Canceled)
+
+	i.Clear(faults.PointPublish)
+	assert.NoError(t, i.Inject(ctx, faults.PointPublish))
+
+	i.Reset()
+	assert.Zero(t, i.Fired(faults.PointPublish))
+
+	var disabled *faults.Injector
+
+	assert.NoError(t, disabled.Inject(ctx, faults.PointPublish), "a nil injector injects nothing")
+}
+
+func TestForce(t *testing.T) {
+	ctx := faults.Force(context.Background(), faults.PointPublish)
+
+	assert.NoError(t, faults.New().Inject(ctx, faults.PointPublish), "forcing requires the header trigger")
+
+	i := faults.New(faults.WithHeaderTrigger())
+	assert.ErrorIs(t, i.Inject(ctx, faults.PointPublish), faults.ErrInjected)
+	assert.NoError(t, i.Inject(ctx, faults.PointBeforeCommit), "other points aren't forced")
+
+	e := echo.New()
+	e.Use(faults.Middleware(i))
+	e.GET("/", func(c echo.Context) error {
+		if err := i.Inject(c.Request().Context(), faults.PointAfterCommit); err != nil {
+			return c.String(http.StatusServiceUnavailable, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for header, expected := range map[string]int{
+		"":                               http.StatusNoContent,
+		"events.publish":                 http.StatusNoContent,
+		"bogus, db.after_commit":         http.StatusServiceUnavailable,
+		"events.publish,db.after_commit": http.StatusServiceUnavailable,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(faults.HeaderTrigger, header)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, expected, rec.Code, header)
+	}
+}
+
+func TestConnection(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	assert.Same(t, conn, faults.Connection(conn, nil), "a nil injector doesn't wrap")
+
+	i := faults.New(faults.WithFault(faults.PointPublish, faults.Fault{Probability: 1}))
+	wrapped := faults.Connection(conn, i)
+
+	_, err := wrapped.PublishChange(context.Background(), "tenant", events.ChangeMessage{})
+	assert.ErrorIs(t, err, faults.ErrInjected)
+	conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+
+	i.Clear(faults.PointPublish)
+
+	_, err = wrapped.PublishChange(context.Background(), "tenant", events.ChangeMessage{})
+	require.NoError(t, err)
+	conn.AssertNumberOfCalls(t, "PublishChange", 1)
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	i := faults.New(faults.WithFault(faults.PointJWKSFetch, faults.Fault{Probability: 1}))
+	client := &http.Client{Transport: faults.Transport(nil, faults.PointJWKSFetch, i)}
+
+	_, err := client.Get(srv.URL)
+	assert.ErrorIs(t, err, faults.ErrInjected)
+
+	i.Reset()
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestDriver(t *testing.T) {
+	ctx := context.Background()
+	i := faults.New()
+
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(faults.Driver(entsql.OpenDB(dialect.SQLite, db), i)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(ctx))
+
+	create := func(name string) error {
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		if _, err := tx.Tenant.Create().SetName(name).Save(ctx); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+
+		return tx.Commit()
+	}
+
+	exists := func(name string) bool {
+		return client.Tenant.Query().Where(tenant.Name(name)).ExistX(ctx)
+	}
+
+	require.NoError(t, create("healthy"))
+	assert.True(t, exists("healthy"))
+
+	i.Set(faults.PointBeforeCommit, faults.Fault{Probability: 1})
+	assert.ErrorIs(t, create("before"), faults.ErrInjected)
+	assert.False(t, exists("before"), "faults before the commit roll the transaction back")
+
+	i.Reset()
+	i.Set(faults.PointAfterCommit, faults.Fault{Probability: 1})
+	assert.ErrorIs(t, create("after"), faults.ErrInjected)
+	assert.True(t, exists("after"), "faults after the commit fail committed transactions")
+
+	// statements outside of transactions aren't affected
+	i.Set(faults.PointBeforeCommit, faults.Fault{Probability: 1})
+	require.NoError(t, client.Tenant.Create().SetName("outside").Exec(ctx))
+}
@@ -0,0 +1,30 @@
+package faults
+
+import (
+	"context"
+
+	"go.infratographer.com/x/events"
+)
+
+type connection struct {
+	events.Connection
+	injector *Injector
+}
+
+// Connection wraps the events connection to inject the fault of events.publish before changes
+// are published. A nil injector returns the connection as it is.
+func Connection(conn events.Connection, i *Injector) events.Connection {
+	if i == nil {
+		return conn
+	}
+
+	return &connection{Connection: conn, injector: i}
+}
+
+func (c *connection) PublishChange(ctx context.Context, topic string, message events.ChangeMessage) (events.Message[events.ChangeMessage], error) {
+	if err := c.injector.Inject(ctx, PointPublish); err != nil {
+		return nil, err
+	}
+
+	return c.Connection.PublishChange(ctx, topic, message)
+}
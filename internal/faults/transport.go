@@ -0,0 +1,32 @@
+package faults
+
+import "net/http"
+
+type transport struct {
+	base     http.RoundTripper
+	point    Point
+	injector *Injector
+}
+
+// Transport wraps the round tripper to inject the fault of the point before each request, such
+// as jwks.fetch for the client fetching the signing keys of tokens. The default transport is
+// wrapped when base is nil, and a nil injector returns base as it is.
+func Transport(base http.RoundTripper, point Point, i *Injector) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if i == nil {
+		return base
+	}
+
+	return &transport{base: base, point: point, injector: i}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.injector.Inject(req.Context(), t.point); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
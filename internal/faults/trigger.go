@@ -0,0 +1,65 @@
+package faults
+
+import (
+	"context"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderTrigger is the header listing the points whose faults a request forces, separated by
+// commas, when the injector accepts the header trigger.
+const HeaderTrigger = "X-Fault-Inject"
+
+type forcedCtxKey struct{}
+
+// Force returns a context forcing the faults of the points, whatever their probability, when the
+// injector accepts the header trigger. A point without a programmed fault fails with
+// ErrInjected.
+func Force(ctx context.Context, points ...Point) context.Context {
+	forced := make(map[Point]bool, len(points))
+
+	if parent, ok := ctx.Value(forcedCtxKey{}).(map[Point]bool); ok {
+		for p := range parent {
+			forced[p] = true
+		}
+	}
+
+	for _, p := range points {
+		forced[p] = true
+	}
+
+	return context.WithValue(ctx, forcedCtxKey{}, forced)
+}
+
+func isForced(ctx context.Context, point Point) bool {
+	forced, _ := ctx.Value(forcedCtxKey{}).(map[Point]bool)
+
+	return forced[point]
+}
+
+// Middleware forces the faults of the points listed in the X-Fault-Inject header of requests,
+// unknown points are ignored. It does nothing unless the injector accepts the header trigger.
+func Middleware(i *Injector) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(HeaderTrigger)
+			if i == nil || !i.header || header == "" {
+				return next(c)
+			}
+
+			var points []Point
+
+			for _, name := range strings.Split(header, ",") {
+				if p, err := ParsePoint(strings.TrimSpace(name)); err == nil {
+					points = append(points, p)
+				}
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(Force(req.Context(), points...)))
+
+			return next(c)
+		}
+	}
+}
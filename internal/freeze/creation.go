@@ -0,0 +1,149 @@
+package freeze
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// MaxCreationFreeze bounds how far ahead creations may be frozen until, so a forgotten freeze
+// lifts by itself within a week.
+const MaxCreationFreeze = 7 * 24 * time.Hour
+
+// CreationFreeze is the freeze of the creation of tenants in the subtree of a tenant.
+type CreationFreeze struct {
+	// TenantID is the tenant whose subtree is frozen.
+	TenantID gidx.PrefixedID
+	// Until is the time the freeze expires at.
+	Until time.Time
+}
+
+// CreationFrozenError is returned when creating a tenant in a subtree whose creations are frozen.
+// It has the ErrTenantFrozen class, the freeze tells clients when to retry.
+type CreationFrozenError struct {
+	Freeze CreationFreeze
+}
+
+// Error implements the error interface.
+func (e *CreationFrozenError) Error() string {
+	return fmt.Sprintf("creating tenants under %s is frozen until %s", e.Freeze.TenantID, e.Freeze.Until.UTC().Format(time.RFC3339))
+}
+
+// Is reports whether the target is ErrTenantFrozen, the class of the error.
+func (e *CreationFrozenError) Is(target error) bool {
+	return target == apierrors.ErrTenantFrozen
+}
+
+// FreezeCreation freezes creating tenants in the subtree of the tenant until the time, replacing
+// the expiry of the freeze it has. The freeze expires by itself.
+func FreezeCreation(ctx context.Context, client *generated.Client, id gidx.PrefixedID, until time.Time) (*generated.Tenant, error) {
+	return client.Tenant.UpdateOneID(id).SetCreationFrozenUntil(until.UTC()).Save(ctx)
+}
+
+// UnfreezeCreation lifts the creation freeze of the tenant before it expires. Unfreezing a tenant
+// without one does nothing.
+func UnfreezeCreation(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*generated.Tenant, error) {
+	t, err := client.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.CreationFrozenUntil.IsZero() {
+		return t, nil
+	}
+
+	return client.Tenant.UpdateOne(t).ClearCreationFrozenUntil().Save(ctx)
+}
+
+// CreationOption configures a CreationGuard.
+type CreationOption func(*CreationGuard)
+
+// WithClock sets the clock freezes expire with.
+func WithClock(c clock.Clock) CreationOption {
+	return func(g *CreationGuard) {
+		g.clock = c
+	}
+}
+
+// CreationGuard rejects the creation of tenants in subtrees whose creations are frozen, walking
+// the ancestors of the parent with the cached parents of a subtree resolver.
+type CreationGuard struct {
+	ancestors *subtree.Resolver
+	clock     clock.Clock
+}
+
+// NewCreationGuard returns a guard finding the ancestors of tenants with the resolver.
+func NewCreationGuard(ancestors *subtree.Resolver, opts ...CreationOption) *CreationGuard {
+	g := &CreationGuard{
+		ancestors: ancestors,
+		clock:     clock.Real{},
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Active returns the freeze keeping tenants from being created under the tenant, of the tenant
+// itself or of one of its ancestors, the one expiring last when there are several. Nil is
+// returned when there is none.
+func (g *CreationGuard) Active(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*CreationFreeze, error) {
+	ids, err := g.ancestors.Ancestors(ctx, client, id)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	t, err := client.Tenant.Query().
+		Where(enttenant.IDIn(ids...), enttenant.CreationFrozenUntilGT(g.clock.Now())).
+		Order(generated.Desc(enttenant.FieldCreationFrozenUntil)).
+		Select(enttenant.FieldID, enttenant.FieldCreationFrozenUntil).
+		First(ctx)
+
+	switch {
+	case generated.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return &CreationFreeze{TenantID: t.ID, Until: t.CreationFrozenUntil}, nil
+}
+
+// Hook returns an ent hook rejecting the creation of tenants under a parent whose subtree is
+// frozen with a CreationFrozenError. Tenants moved into the subtree aren't rejected, nor are roots.
+func (g *CreationGuard) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				parentID, ok := m.ParentTenantID()
+				if !ok || parentID == gidx.NullPrefixedID {
+					return next.Mutate(ctx, m)
+				}
+
+				freeze, err := g.Active(ctx, m.Client(), parentID)
+				if err != nil {
+					return nil, err
+				}
+
+				if freeze != nil {
+					return nil, &CreationFrozenError{Freeze: *freeze}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate,
+	)
+}
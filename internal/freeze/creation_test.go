@@ -0,0 +1,62 @@
+package freeze_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestCreationGuard(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	fake := clock.NewFake(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	guard := freeze.NewCreationGuard(subtree.NewResolver(subtree.WithClock(fake)), freeze.WithClock(fake))
+
+	client.Tenant.Use(guard.Hook())
+
+	grandparent := client.Tenant.Create().SetName("grandparent").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(grandparent).SaveX(ctx)
+
+	_, err := freeze.FreezeCreation(ctx, client, grandparent.ID, fake.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+
+	_, err = freeze.FreezeCreation(ctx, client, parent.ID, fake.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	active, err := guard.Active(ctx, client, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &freeze.CreationFreeze{TenantID: grandparent.ID, Until: fake.Now().Add(2 * time.Hour)}, active, "the freeze expiring last wins")
+
+	_, err = client.Tenant.Create().SetName("grandchild").SetParent(parent).Save(ctx)
+
+	var ferr *freeze.CreationFrozenError
+
+	require.ErrorAs(t, err, &ferr)
+	assert.Equal(t, *active, ferr.Freeze)
+	assert.True(t, errors.Is(err, apierrors.ErrTenantFrozen))
+
+	_, err = client.Tenant.Create().SetName("root").Save(ctx)
+	assert.NoError(t, err, "roots have no subtree to freeze")
+
+	fake.Advance(2 * time.Hour)
+
+	active, err = guard.Active(ctx, client, parent.ID)
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	_, err = client.Tenant.Create().SetName("grandchild").SetParent(parent).Save(ctx)
+	assert.NoError(t, err, "the freezes expired")
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package freeze lets admins lock a tenant against every change during an investigation. Unlike
+// suspending it, freezing doesn't affect the service the tenant gets, it is still read as usual.
+//
+// Admins may also freeze the creation of tenants in the subtree of a tenant until a time, such as
+// during a migration. Unlike freezing the tenant, it only rejects creates, and it expires by
+// itself.
+package freeze
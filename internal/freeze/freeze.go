@@ -0,0 +1,93 @@
+package freeze
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Freeze freezes the tenant. Freezing a frozen tenant does nothing.
+func Freeze(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*generated.Tenant, error) {
+	return setFrozen(ctx, client, id, true)
+}
+
+// Unfreeze unfreezes the frozen tenant. Unfreezing a tenant which isn't frozen does nothing.
+func Unfreeze(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*generated.Tenant, error) {
+	return setFrozen(ctx, client, id, false)
+}
+
+func setFrozen(ctx context.Context, client *generated.Client, id gidx.PrefixedID, frozen bool) (*generated.Tenant, error) {
+	t, err := client.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Frozen == frozen {
+		return t, nil
+	}
+
+	return client.Tenant.UpdateOne(t).SetFrozen(frozen).Save(ctx)
+}
+
+// Hook returns an ent hook rejecting every change of frozen tenants, and the creation of tenants
+// under a frozen parent or moving tenants under one. Changes setting the frozen field are let
+// through, they are only made by Freeze and Unfreeze. It applies to the changes the service makes
+// itself as well, such as scheduled deletions, which are retried once the tenant is unfrozen.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if _, ok := m.Frozen(); ok {
+					return next.Mutate(ctx, m)
+				}
+
+				if !m.Op().Is(ent.OpCreate) {
+					ids, err := m.IDs(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					if err := checkFrozen(ctx, m.Client(), ids); err != nil {
+						return nil, err
+					}
+				}
+
+				if parentID, ok := m.ParentTenantID(); ok && parentID != gidx.NullPrefixedID {
+					if err := checkFrozen(ctx, m.Client(), []gidx.PrefixedID{parentID}); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+// checkFrozen returns the error of the first of the tenants which is frozen.
+func checkFrozen(ctx context.Context, client *generated.Client, ids []gidx.PrefixedID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	id, err := client.Tenant.Query().
+		Where(enttenant.IDIn(ids...), enttenant.Frozen(true)).
+		FirstID(ctx)
+
+	switch {
+	case generated.IsNotFound(err):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	return apierrors.New(apierrors.ErrTenantFrozen, fmt.Sprintf("tenant %s is frozen", id))
+}
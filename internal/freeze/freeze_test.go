@@ -0,0 +1,90 @@
+package freeze_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestHook(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(freeze.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	frozen := client.Tenant.Create().SetName("frozen").SetParent(root).SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SetParent(root).SaveX(ctx)
+
+	conn.Calls = nil
+
+	tnt, err := freeze.Freeze(ctx, client, frozen.ID)
+	require.NoError(t, err)
+	assert.True(t, tnt.Frozen)
+
+	// freezing publishes an update event, freezing again changes nothing
+	_, err = freeze.Freeze(ctx, client, frozen.ID)
+	require.NoError(t, err)
+
+	conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, string(events.UpdateChangeType), msg.EventType)
+	assert.Contains(t, msg.FieldChanges, events.FieldChange{Field: "frozen", PreviousValue: "false", CurrentValue: "true"})
+
+	for name, mutate := range map[string]func() error{
+		"rename":       func() error { return client.Tenant.UpdateOneID(frozen.ID).SetName("renamed").Exec(ctx) },
+		"reparent":     func() error { return client.Tenant.UpdateOneID(frozen.ID).SetParentTenantID(other.ID).Exec(ctx) },
+		"detach":       func() error { return client.Tenant.UpdateOneID(frozen.ID).ClearParentTenantID().Exec(ctx) },
+		"create child": func() error { return client.Tenant.Create().SetName("child").SetParentTenantID(frozen.ID).Exec(ctx) },
+		"move under":   func() error { return client.Tenant.UpdateOneID(other.ID).SetParentTenantID(frozen.ID).Exec(ctx) },
+		"delete":       func() error { return client.Tenant.DeleteOneID(frozen.ID).Exec(ctx) },
+		"update many": func() error {
+			return client.Tenant.Update().Where(enttenant.ParentTenantID(root.ID)).SetDescription("all").Exec(ctx)
+		},
+		"delete many": func() error { _, err := client.Tenant.Delete().Where(enttenant.Name("frozen")).Exec(ctx); return err },
+	} {
+		err := mutate()
+		require.ErrorIs(t, err, apierrors.ErrTenantFrozen, name)
+		assert.Equal(t, "tenant "+frozen.ID.String()+" is frozen", err.Error(), name)
+	}
+
+	assert.Equal(t, "frozen", client.Tenant.GetX(ctx, frozen.ID).Name)
+	assert.Equal(t, 3, client.Tenant.Query().CountX(ctx))
+
+	// other tenants are changed as usual
+	require.NoError(t, client.Tenant.UpdateOneID(other.ID).SetDescription("changed").Exec(ctx))
+
+	tnt, err = freeze.Unfreeze(ctx, client, frozen.ID)
+	require.NoError(t, err)
+	assert.False(t, tnt.Frozen)
+
+	require.NoError(t, client.Tenant.UpdateOneID(frozen.ID).SetName("renamed").Exec(ctx))
+}
@@ -0,0 +1,109 @@
+package graphapi
+
+import (
+	"context"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	"go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// createTenant validates the tenant with the pipeline, then creates it in a transaction, so the
+// children limit of the parent is checked against a count which can't change before the insert
+// commits. The transaction is run again when it is aborted by a serialization failure, and the
+// change is published once committed. With TenantNameConflictSuffix the first name not taken by
+// a sibling is used, which is reported as renamed when it isn't the requested one, with
+// TenantNameConflictError a taken name fails the create. Whether deleted siblings keep their
+// names depends on the name reuse policy.
+func (r *Resolver) createTenant(ctx context.Context, input generated.CreateTenantInput, onConflict TenantNameConflict) (*generated.Tenant, bool, error) {
+	t := validation.Tenant{
+		Name:             &input.Name,
+		DisplayName:      input.DisplayName,
+		Description:      input.Description,
+		ContactEmail:     input.ContactEmail,
+		BillingReference: input.BillingReference,
+	}
+
+	if err := r.validator.ValidateNewTenant(ctx, &t); err != nil {
+		return nil, false, err
+	}
+
+	var (
+		tnt     *generated.Tenant
+		renamed bool
+	)
+
+	err := crdb.Retry(ctx, func(ctx context.Context) error {
+		var err error
+
+		tnt, renamed, err = r.createTenantTx(ctx, input, onConflict)
+
+		return err
+	})
+
+	return tnt, renamed, err
+}
+
+// createTenantTx runs one attempt of createTenant, after the tenant is validated.
+func (r *Resolver) createTenantTx(ctx context.Context, input generated.CreateTenantInput, onConflict TenantNameConflict) (*generated.Tenant, bool, error) {
+	txCtx, batch := changefeed.WithBatch(ctx)
+
+	tx, err := r.client.Tx(txCtx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	renamed := false
+
+	switch onConflict {
+	case TenantNameConflictSuffix:
+		name, err := r.nameReuse.FreeSiblingName(txCtx, tx.Client(), input.ParentID, input.Name)
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				r.logger.Errorw("failed to roll back tenant create", "error", rerr)
+			}
+
+			return nil, false, err
+		}
+
+		renamed = name != input.Name
+		input.Name = name
+	case TenantNameConflictError:
+		if err := r.nameReuse.CheckSiblingName(txCtx, tx.Client(), input.ParentID, input.Name); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				r.logger.Errorw("failed to roll back tenant create", "error", rerr)
+			}
+
+			return nil, false, err
+		}
+	}
+
+	create := tx.Tenant.Create().SetInput(input)
+
+	// the name was checked against the siblings, the index rejects a concurrent create taking it
+	if onConflict == TenantNameConflictSuffix || onConflict == TenantNameConflictError {
+		create.SetUniqueName(true)
+	}
+
+	tnt, err := create.Save(txCtx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			r.logger.Errorw("failed to roll back tenant create", "error", rerr)
+		}
+
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the tenant is committed, report it even though the event was lost
+		r.logger.Errorw("failed to publish tenant create", "error", err)
+	}
+
+	// edges are resolved after the transaction is done
+	return tnt.Unwrap(), renamed, nil
+}
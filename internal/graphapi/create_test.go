@@ -0,0 +1,285 @@
+package graphapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/hookchain"
+	"go.infratographer.com/tenant-api/internal/testclient"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestTenantCreateNameConflict(t *testing.T) {
+	ctx := context.WithValue(context.Background(), permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	client := newTestClient(t, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+
+	graph := newTestServer(t, client).GraphClient
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+	client.Tenant.Create().SetName("staging").SetParent(parent).SaveX(ctx)
+	client.Tenant.Create().SetName("staging-2").SetParent(parent).SaveX(ctx)
+
+	create := func(name string, onConflict testclient.TenantNameConflict) (string, bool) {
+		resp, err := graph.TenantCreateOnConflict(ctx, testclient.CreateTenantInput{Name: name, ParentID: &parent.ID}, onConflict)
+		require.NoError(t, err)
+
+		return resp.TenantCreate.Tenant.Name, resp.TenantCreate.Renamed
+	}
+
+	name, renamed := create("staging", testclient.TenantNameConflictAllow)
+	assert.Equal(t, "staging", name, "siblings may share names by default")
+	assert.False(t, renamed)
+
+	name, renamed = create(" staging ", testclient.TenantNameConflictSuffix)
+	assert.Equal(t, "staging-3", name)
+	assert.True(t, renamed)
+
+	name, renamed = create("production", testclient.TenantNameConflictSuffix)
+	assert.Equal(t, "production", name)
+	assert.False(t, renamed)
+
+	// names are only compared with siblings
+	resp, err := graph.TenantCreateOnConflict(ctx, testclient.CreateTenantInput{Name: "staging"}, testclient.TenantNameConflictSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", resp.TenantCreate.Tenant.Name)
+	assert.False(t, resp.TenantCreate.Renamed)
+
+	// suffixes are tried a bounded number of times
+	full := client.Tenant.Create().SetName("full").SaveX(ctx)
+	client.Tenant.Create().SetName("dev").SetParent(full).SaveX(ctx)
+
+	for i := 2; i <= 100; i++ {
+		client.Tenant.Create().SetName(fmt.Sprintf("dev-%d", i)).SetParent(full).SaveX(ctx)
+	}
+
+	srv := newTestServer(t, client, WithAuthDisabled())
+
+	body := postGraph(t, srv.URL, `mutation($input: CreateTenantInput!) { tenantCreate(input: $input, onConflict: SUFFIX) { tenant { id } } }`,
+		map[string]any{"input": map[string]any{"name": "dev", "parentID": full.ID}},
+	)
+
+	var failed struct {
+		Errors []struct {
+			Message    string            `json:"message"`
+			Extensions map[string]string `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &failed))
+	require.Len(t, failed.Errors, 1)
+	assert.Contains(t, failed.Errors[0].Message, "taken by siblings")
+	assert.Equal(t, map[string]string{"class": "name_conflict", "code": "name_taken", "field": "name"}, failed.Errors[0].Extensions)
+	assert.Equal(t, 100, full.QueryChildren().CountX(ctx))
+}
+
+func TestTenantCreateDescriptionValidation(t *testing.T) {
+	ctx := context.WithValue(context.Background(), permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	rules := validation.NewNameValidator().Rules()
+	rules = append(rules, validation.NewMetadataValidator(validation.WithDescriptionMaxLength(4)).Rules()...)
+
+	client := newTestClient(t, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1", hookchain.WithPipeline(validation.NewPipeline(rules...)))
+
+	graph := newTestServer(t, client).GraphClient
+
+	description := "日本\r\n語"
+
+	resp, err := graph.TenantCreate(ctx, testclient.CreateTenantInput{Name: "acme", Description: &description})
+	require.NoError(t, err)
+	assert.Equal(t, "日本\n語", *resp.TenantCreate.Tenant.Description)
+
+	srv := newTestServer(t, client, WithAuthDisabled())
+
+	body := postGraph(t, srv.URL, `mutation($input: CreateTenantInput!) { tenantCreate(input: $input) { tenant { id } } }`,
+		map[string]any{"input": map[string]any{"name": "acme", "description": "日本語テキスト"}},
+	)
+
+	var failed struct {
+		Errors []struct {
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &failed))
+	require.Len(t, failed.Errors, 1)
+	assert.Equal(t, map[string]any{
+		"class": "invalid_argument",
+		"code":  validation.CodeDescriptionTooLong,
+		"field": "description",
+		"limit": float64(4),
+		"details": []any{map[string]any{
+			"field":   "description",
+			"code":    validation.CodeDescriptionTooLong,
+			"message": "must be at most 4 characters, got 7",
+			"limit":   float64(4),
+		}},
+	}, failed.Errors[0].Extensions)
+}
+
+func TestTenantCreateNameReusePolicy(t *testing.T) {
+	type outcome struct {
+		name string
+		code string
+	}
+
+	testCases := []struct {
+		policy validation.NameReusePolicy
+		// outcomes of creating tenants named after a live sibling, one scheduled for deletion and
+		// one purged, failing on conflicts or suffixing the names
+		onError  []outcome
+		suffixed []string
+	}{
+		{
+			policy:   validation.NameReuseAllow,
+			onError:  []outcome{{code: validation.CodeNameTaken}, {name: "pending"}, {name: "purged"}},
+			suffixed: []string{"live-2", "pending", "purged"},
+		},
+		{
+			policy:   validation.NameReuseBlockDuringRetention,
+			onError:  []outcome{{code: validation.CodeNameTaken}, {code: validation.CodeNameTakenByDeleted}, {name: "purged"}},
+			suffixed: []string{"live-2", "pending-2", "purged"},
+		},
+		{
+			policy:   validation.NameReuseAlwaysBlock,
+			onError:  []outcome{{code: validation.CodeNameTaken}, {code: validation.CodeNameTakenByDeleted}, {code: validation.CodeNameTakenByDeleted}},
+			suffixed: []string{"live-2", "pending-2", "purged-2"},
+		},
+	}
+
+	for _, tt := range testCases {
+		// the same siblings in a new database for each mode
+		setup := func(t *testing.T, mode string) (*testServer, gidx.PrefixedID) {
+			ctx := context.WithValue(context.Background(), permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+			// deletions are recorded with their names by the change sequence hook
+			client := newTestClient(t, "file:"+t.Name()+mode+"?mode=memory&cache=shared&_fk=1")
+
+			parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+			client.Tenant.Create().SetName("live").SetParent(parent).SaveX(ctx)
+			client.Tenant.Create().SetName("pending").SetParent(parent).SetDeletionScheduledAt(time.Now().Add(time.Hour)).SaveX(ctx)
+			purged := client.Tenant.Create().SetName("purged").SetParent(parent).SaveX(ctx)
+			client.Tenant.DeleteOne(purged).ExecX(ctx)
+
+			return newTestServer(t, client, WithAuthDisabled(), WithResolverOptions(graphapi.WithNameReusePolicy(tt.policy))), parent.ID
+		}
+
+		create := func(t *testing.T, srv *testServer, parentID gidx.PrefixedID, name, onConflict string) outcome {
+			body := postGraph(t, srv.URL, `mutation($input: CreateTenantInput!) { tenantCreate(input: $input, onConflict: `+onConflict+`) { tenant { name } } }`,
+				map[string]any{"input": map[string]any{"name": name, "parentID": parentID}},
+			)
+
+			var resp struct {
+				Data *struct {
+					TenantCreate struct {
+						Tenant struct {
+							Name string `json:"name"`
+						} `json:"tenant"`
+					} `json:"tenantCreate"`
+				} `json:"data"`
+				Errors []struct {
+					Extensions map[string]string `json:"extensions"`
+				} `json:"errors"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &resp), string(body))
+
+			if len(resp.Errors) != 0 {
+				// live and deleted conflicts are both name conflicts, told apart by their code
+				assert.Equal(t, "name_conflict", resp.Errors[0].Extensions["class"])
+
+				return outcome{code: resp.Errors[0].Extensions["code"]}
+			}
+
+			return outcome{name: resp.Data.TenantCreate.Tenant.Name}
+		}
+
+		t.Run(string(tt.policy), func(t *testing.T) {
+			var onError, suffixed []outcome
+
+			srv, parentID := setup(t, "error")
+
+			for _, name := range []string{"live", "pending", "purged"} {
+				onError = append(onError, create(t, srv, parentID, name, "ERROR"))
+			}
+
+			srv, parentID = setup(t, "suffix")
+
+			for _, name := range []string{"live", "pending", "purged"} {
+				suffixed = append(suffixed, create(t, srv, parentID, name, "SUFFIX"))
+			}
+
+			assert.Equal(t, tt.onError, onError)
+
+			for i, name := range tt.suffixed {
+				assert.Equal(t, outcome{name: name}, suffixed[i])
+			}
+		})
+	}
+}
+
+func TestTenantCreateNameConflictConcurrent(t *testing.T) {
+	ctx := context.WithValue(context.Background(), permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	// immediate transactions take the sqlite write lock when they begin, so the creates run one
+	// after the other. On cockroachdb they overlap, and those aborted by a serialization failure
+	// are run again by crdb.Retry.
+	dsn := "file:" + filepath.Join(t.TempDir(), "tenants.db") + "?_fk=1&_txlock=immediate&_busy_timeout=5000"
+
+	client := newTestClient(t, dsn)
+
+	graph := newTestServer(t, client).GraphClient
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+
+	const creates = 5
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		names = make([]string, creates)
+		errs  = make([]error, creates)
+	)
+
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			resp, err := graph.TenantCreateOnConflict(ctx, testclient.CreateTenantInput{Name: "staging", ParentID: &parent.ID}, testclient.TenantNameConflictSuffix)
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+
+			names[i] = resp.TenantCreate.Tenant.Name
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	sort.Strings(names)
+
+	assert.Equal(t, []string{"staging", "staging-2", "staging-3", "staging-4", "staging-5"}, names)
+}
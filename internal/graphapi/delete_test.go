@@ -0,0 +1,74 @@
+package graphapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/dependents"
+	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/hookchain"
+	"go.infratographer.com/tenant-api/internal/scopes"
+)
+
+func TestTenantDeleteDependents(t *testing.T) {
+	ctx := context.WithValue(context.Background(), permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	client := newTestClient(t, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		hookchain.WithDependents(dependents.New([]dependents.Checker{&dependents.Stub{Types: []string{"instance"}}})),
+	)
+
+	tnt := client.Tenant.Create().SetName("owner").SaveX(ctx)
+
+	grantForce := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(scopes.WithScopes(c.Request().Context(), []string{"tenants:force"})))
+
+			return next(c)
+		}
+	}
+
+	withoutScope := newTestServer(t, client, WithAuthDisabled(), WithResolverOptions(graphapi.WithForceDeleteScope("tenants:force")))
+	withScope := newTestServer(t, client, WithAuthDisabled(), WithMiddleware(grantForce), WithResolverOptions(graphapi.WithForceDeleteScope("tenants:force")))
+
+	type response struct {
+		Data *struct {
+			TenantDelete struct {
+				DeletedID string `json:"deletedID"`
+			} `json:"tenantDelete"`
+		} `json:"data"`
+		Errors []struct {
+			Message    string         `json:"message"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+
+	remove := func(srv *testServer, force bool) response {
+		body := postGraph(t, srv.URL, `mutation($id: ID!, $force: Boolean) { tenantDelete(id: $id, force: $force) { deletedID } }`,
+			map[string]any{"id": tnt.ID, "force": force},
+		)
+
+		var resp response
+
+		require.NoError(t, json.Unmarshal(body, &resp), string(body))
+
+		return resp
+	}
+
+	resp := remove(withScope, false)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, map[string]any{"class": "conflict", "code": "has_dependents", "dependents": []any{"instance"}}, resp.Errors[0].Extensions)
+
+	resp = remove(withoutScope, true)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "permission_denied", resp.Errors[0].Extensions["class"])
+
+	resp = remove(withScope, true)
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, tnt.ID.String(), resp.Data.TenantDelete.DeletedID)
+}
@@ -4,7 +4,34 @@ package graphapi
 // will be copied through when generating and any unknown code will be moved to the end.
 // Code generated by github.com/99designs/gqlgen version v0.17.34
 
+import (
+	"context"
+
+	"entgo.io/contrib/entgql"
+	"go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/x/gidx"
+)
+
+// Children is the resolver for the children field.
+func (r *tenantResolver) Children(ctx context.Context, obj *generated.Tenant, after *entgql.Cursor[gidx.PrefixedID], first *int, before *entgql.Cursor[gidx.PrefixedID], last *int, orderBy *generated.TenantOrder, where *generated.TenantWhereInput, includeArchived bool) (*generated.TenantConnection, error) {
+	if !includeArchived {
+		if where == nil {
+			where = &generated.TenantWhereInput{}
+		}
+
+		// the eager loaded children already leave them out, see internal/ent/gqltemplates
+		where.AddPredicates(tenant.Or(tenant.ArchivedIsNil(), tenant.Archived(false)))
+	}
+
+	return obj.Children(ctx, after, first, before, last, orderBy, where)
+}
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Tenant returns TenantResolver implementation.
+func (r *Resolver) Tenant() TenantResolver { return &tenantResolver{r} }
+
 type queryResolver struct{ *Resolver }
+type tenantResolver struct{ *Resolver }
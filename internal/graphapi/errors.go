@@ -0,0 +1,66 @@
+package graphapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"go.infratographer.com/tenant-api/internal/dependents"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// errorPresenter adds the apierrors class of the error to the error extensions so clients can
+// handle specific failures, along with the code of validation errors or else of the class, and
+// the field of validation errors, with the limit of those of values which are too long. Errors
+// found validating a whole mutation list every one of them in their details, as REST requests do.
+// Errors of tenants with dependents list the types of the resources depending on them, and creates
+// rejected by a creation freeze have the time it expires at in frozenUntil. Errors of
+// requests the caller canceled have the canceled class.
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	class, ok := errmap.ClassifyContext(ctx, err)
+	if !ok {
+		return gqlErr
+	}
+
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+
+	gqlErr.Extensions["class"] = class.Code
+	gqlErr.Extensions["code"] = class.Code
+
+	var (
+		errs validation.Errors
+		verr *validation.Error
+		derr *dependents.Error
+		ferr *freeze.CreationFrozenError
+	)
+
+	if errors.As(err, &errs) {
+		gqlErr.Extensions["details"] = errmap.Details(errs)
+	}
+
+	switch {
+	case errors.As(err, &verr):
+		gqlErr.Extensions["code"] = verr.Code
+		gqlErr.Extensions["field"] = verr.Field
+
+		if verr.Limit != 0 {
+			gqlErr.Extensions["limit"] = verr.Limit
+		}
+	case errors.As(err, &derr):
+		gqlErr.Extensions["code"] = dependents.CodeHasDependents
+		gqlErr.Extensions["dependents"] = derr.Types
+	case errors.As(err, &ferr):
+		gqlErr.Extensions["frozenUntil"] = timefmt.New(ferr.Freeze.Until).String()
+	}
+
+	return gqlErr
+}
@@ -3,6 +3,10 @@
 package graphapi
 
 import (
+	"fmt"
+	"io"
+	"strconv"
+
 	"go.infratographer.com/tenant-api/internal/ent/generated"
 	"go.infratographer.com/x/gidx"
 )
@@ -11,6 +15,8 @@ import (
 type TenantCreatePayload struct {
 	// The created tenant.
 	Tenant *generated.Tenant `json:"tenant"`
+	// Whether the tenant was created with a suffixed name as a sibling already had the requested one.
+	Renamed bool `json:"renamed"`
 }
 
 // Return response from tenantDelete.
@@ -24,3 +30,51 @@ type TenantUpdatePayload struct {
 	// The updated tenant.
 	Tenant *generated.Tenant `json:"tenant"`
 }
+
+// Handling of tenants created with the name of a sibling.
+type TenantNameConflict string
+
+const (
+	// Create the tenant with the name regardless, the default.
+	TenantNameConflictAllow TenantNameConflict = "ALLOW"
+	// Append the first free numeric suffix to the name, such as staging-2.
+	TenantNameConflictSuffix TenantNameConflict = "SUFFIX"
+	// Fail with a name conflict, telling whether the sibling is live or deleted. Whether deleted
+	// siblings keep their names depends on the name reuse policy of the api.
+	TenantNameConflictError TenantNameConflict = "ERROR"
+)
+
+var AllTenantNameConflict = []TenantNameConflict{
+	TenantNameConflictAllow,
+	TenantNameConflictSuffix,
+	TenantNameConflictError,
+}
+
+func (e TenantNameConflict) IsValid() bool {
+	switch e {
+	case TenantNameConflictAllow, TenantNameConflictSuffix, TenantNameConflictError:
+		return true
+	}
+	return false
+}
+
+func (e TenantNameConflict) String() string {
+	return string(e)
+}
+
+func (e *TenantNameConflict) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TenantNameConflict(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TenantNameConflict", str)
+	}
+	return nil
+}
+
+func (e TenantNameConflict) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
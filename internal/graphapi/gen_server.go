@@ -43,6 +43,7 @@ type ResolverRoot interface {
 	Entity() EntityResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Tenant() TenantResolver
 }
 
 type DirectiveRoot struct {
@@ -55,8 +56,8 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		TenantCreate func(childComplexity int, input generated.CreateTenantInput) int
-		TenantDelete func(childComplexity int, id gidx.PrefixedID) int
+		TenantCreate func(childComplexity int, input generated.CreateTenantInput, onConflict TenantNameConflict) int
+		TenantDelete func(childComplexity int, id gidx.PrefixedID, force *bool) int
 		TenantUpdate func(childComplexity int, id gidx.PrefixedID, input generated.UpdateTenantInput) int
 	}
 
@@ -74,13 +75,19 @@ type ComplexityRoot struct {
 	}
 
 	Tenant struct {
-		Children    func(childComplexity int, after *entgql.Cursor[gidx.PrefixedID], first *int, before *entgql.Cursor[gidx.PrefixedID], last *int, orderBy *generated.TenantOrder, where *generated.TenantWhereInput) int
-		CreatedAt   func(childComplexity int) int
-		Description func(childComplexity int) int
-		ID          func(childComplexity int) int
-		Name        func(childComplexity int) int
-		Parent      func(childComplexity int) int
-		UpdatedAt   func(childComplexity int) int
+		BillingReference    func(childComplexity int) int
+		ChangeSeq           func(childComplexity int) int
+		Children            func(childComplexity int, after *entgql.Cursor[gidx.PrefixedID], first *int, before *entgql.Cursor[gidx.PrefixedID], last *int, orderBy *generated.TenantOrder, where *generated.TenantWhereInput, includeArchived bool) int
+		ContactEmail        func(childComplexity int) int
+		CreatedAt           func(childComplexity int) int
+		DeletionProtected   func(childComplexity int) int
+		DeletionScheduledAt func(childComplexity int) int
+		Description         func(childComplexity int) int
+		DisplayName         func(childComplexity int) int
+		ID                  func(childComplexity int) int
+		Name                func(childComplexity int) int
+		Parent              func(childComplexity int) int
+		UpdatedAt           func(childComplexity int) int
 	}
 
 	TenantConnection struct {
@@ -90,7 +97,8 @@ type ComplexityRoot struct {
 	}
 
 	TenantCreatePayload struct {
-		Tenant func(childComplexity int) int
+		Renamed func(childComplexity int) int
+		Tenant  func(childComplexity int) int
 	}
 
 	TenantDeletePayload struct {
@@ -115,13 +123,17 @@ type EntityResolver interface {
 	FindTenantByID(ctx context.Context, id gidx.PrefixedID) (*generated.Tenant, error)
 }
 type MutationResolver interface {
-	TenantCreate(ctx context.Context, input generated.CreateTenantInput) (*TenantCreatePayload, error)
+	TenantCreate(ctx context.Context, input generated.CreateTenantInput, onConflict TenantNameConflict) (*TenantCreatePayload, error)
 	TenantUpdate(ctx context.Context, id gidx.PrefixedID, input generated.UpdateTenantInput) (*TenantUpdatePayload, error)
-	TenantDelete(ctx context.Context, id gidx.PrefixedID) (*TenantDeletePayload, error)
+	TenantDelete(ctx context.Context, id gidx.PrefixedID, force *bool) (*TenantDeletePayload, error)
 }
 type QueryResolver interface {
 	Tenant(ctx context.Context, id gidx.PrefixedID) (*generated.Tenant, error)
 }
+type TenantResolver interface {
+	Children(ctx context.Context, obj *generated.Tenant, after *entgql.Cursor[gidx.PrefixedID], first *int, before *entgql.Cursor[gidx.PrefixedID], last *int, orderBy *generated.TenantOrder, where *generated.TenantWhereInput, includeArchived bool) (*generated.TenantConnection, error)
+	DeletionScheduledAt(ctx context.Context, obj *generated.Tenant) (*time.Time, error)
+}
 
 type executableSchema struct {
 	resolvers  ResolverRoot
@@ -160,7 +172,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.TenantCreate(childComplexity, args["input"].(generated.CreateTenantInput)), true
+		return e.complexity.Mutation.TenantCreate(childComplexity, args["input"].(generated.CreateTenantInput), args["onConflict"].(TenantNameConflict)), true
 
 	case "Mutation.tenantDelete":
 		if e.complexity.Mutation.TenantDelete == nil {
@@ -172,7 +184,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Mutation.TenantDelete(childComplexity, args["id"].(gidx.PrefixedID)), true
+		return e.complexity.Mutation.TenantDelete(childComplexity, args["id"].(gidx.PrefixedID), args["force"].(*bool)), true
 
 	case "Mutation.tenantUpdate":
 		if e.complexity.Mutation.TenantUpdate == nil {
@@ -245,6 +257,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.__resolve_entities(childComplexity, args["representations"].([]map[string]interface{})), true
 
+	case "Tenant.billingReference":
+		if e.complexity.Tenant.BillingReference == nil {
+			break
+		}
+
+		return e.complexity.Tenant.BillingReference(childComplexity), true
+
+	case "Tenant.changeSeq":
+		if e.complexity.Tenant.ChangeSeq == nil {
+			break
+		}
+
+		return e.complexity.Tenant.ChangeSeq(childComplexity), true
+
 	case "Tenant.children":
 		if e.complexity.Tenant.Children == nil {
 			break
@@ -255,7 +281,14 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Tenant.Children(childComplexity, args["after"].(*entgql.Cursor[gidx.PrefixedID]), args["first"].(*int), args["before"].(*entgql.Cursor[gidx.PrefixedID]), args["last"].(*int), args["orderBy"].(*generated.TenantOrder), args["where"].(*generated.TenantWhereInput)), true
+		return e.complexity.Tenant.Children(childComplexity, args["after"].(*entgql.Cursor[gidx.PrefixedID]), args["first"].(*int), args["before"].(*entgql.Cursor[gidx.PrefixedID]), args["last"].(*int), args["orderBy"].(*generated.TenantOrder), args["where"].(*generated.TenantWhereInput), args["includeArchived"].(bool)), true
+
+	case "Tenant.contactEmail":
+		if e.complexity.Tenant.ContactEmail == nil {
+			break
+		}
+
+		return e.complexity.Tenant.ContactEmail(childComplexity), true
 
 	case "Tenant.createdAt":
 		if e.complexity.Tenant.CreatedAt == nil {
@@ -264,6 +297,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tenant.CreatedAt(childComplexity), true
 
+	case "Tenant.deletionProtected":
+		if e.complexity.Tenant.DeletionProtected == nil {
+			break
+		}
+
+		return e.complexity.Tenant.DeletionProtected(childComplexity), true
+
+	case "Tenant.deletionScheduledAt":
+		if e.complexity.Tenant.DeletionScheduledAt == nil {
+			break
+		}
+
+		return e.complexity.Tenant.DeletionScheduledAt(childComplexity), true
+
 	case "Tenant.description":
 		if e.complexity.Tenant.Description == nil {
 			break
@@ -271,6 +318,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Tenant.Description(childComplexity), true
 
+	case "Tenant.displayName":
+		if e.complexity.Tenant.DisplayName == nil {
+			break
+		}
+
+		return e.complexity.Tenant.DisplayName(childComplexity), true
+
 	case "Tenant.id":
 		if e.complexity.Tenant.ID == nil {
 			break
@@ -320,6 +374,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.TenantConnection.TotalCount(childComplexity), true
 
+	case "TenantCreatePayload.renamed":
+		if e.complexity.TenantCreatePayload.Renamed == nil {
+			break
+		}
+
+		return e.complexity.TenantCreatePayload.Renamed(childComplexity), true
+
 	case "TenantCreatePayload.tenant":
 		if e.complexity.TenantCreatePayload.Tenant == nil {
 			break
@@ -477,8 +538,14 @@ directive @goModel(model: String, models: [String!]) on OBJECT | INPUT_OBJECT |
 input CreateTenantInput {
   """The name of a tenant."""
   name: String!
+  """The display name of a tenant, defaults to the name and may be changed freely."""
+  displayName: String
   """An optional description of the tenant."""
   description: String
+  """An optional email address to contact the owners of the tenant at."""
+  contactEmail: String
+  """An optional reference to the tenant in the billing system."""
+  billingReference: String
   parentID: ID
 }
 """
@@ -525,8 +592,18 @@ type Tenant implements Node & ResourceOwner & MetadataNode @key(fields: "id") @p
   updatedAt: Time!
   """The name of a tenant."""
   name: String!
+  """The display name of a tenant, defaults to the name and may be changed freely."""
+  displayName: String
   """An optional description of the tenant."""
   description: String
+  """An optional email address to contact the owners of the tenant at."""
+  contactEmail: String
+  """An optional reference to the tenant in the billing system."""
+  billingReference: String
+  """Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection."""
+  deletionProtected: Boolean
+  """The sequence of the last change of the tenant, increasing with every change."""
+  changeSeq: Int
   parent: Tenant
   children(
     """Returns the elements in the list that come after the specified cursor."""
@@ -546,6 +623,9 @@ type Tenant implements Node & ResourceOwner & MetadataNode @key(fields: "id") @p
 
     """Filtering options for Tenants returned from the connection."""
     where: TenantWhereInput
+
+    """Whether archived children are listed as well."""
+    includeArchived: Boolean! = false
   ): TenantConnection!
 }
 """A connection to a list of items."""
@@ -576,6 +656,8 @@ enum TenantOrderField {
   CREATED_AT
   UPDATED_AT
   NAME
+  DISPLAY_NAME
+  CHANGE_SEQ
 }
 """
 TenantWhereInput is used for filtering Tenant objects.
@@ -612,6 +694,17 @@ input TenantWhereInput {
   updatedAtGTE: Time
   updatedAtLT: Time
   updatedAtLTE: Time
+  """change_seq field predicates"""
+  changeSeq: Int
+  changeSeqNEQ: Int
+  changeSeqIn: [Int!]
+  changeSeqNotIn: [Int!]
+  changeSeqGT: Int
+  changeSeqGTE: Int
+  changeSeqLT: Int
+  changeSeqLTE: Int
+  changeSeqIsNil: Boolean
+  changeSeqNotNil: Boolean
   """parent edge predicates"""
   hasParent: Boolean
   hasParentWith: [TenantWhereInput!]
@@ -625,9 +718,23 @@ scalar Time
 input UpdateTenantInput {
   """The name of a tenant."""
   name: String
+  """The display name of a tenant, defaults to the name and may be changed freely."""
+  displayName: String
+  clearDisplayName: Boolean
   """An optional description of the tenant."""
   description: String
   clearDescription: Boolean
+  """An optional email address to contact the owners of the tenant at."""
+  contactEmail: String
+  clearContactEmail: Boolean
+  """An optional reference to the tenant in the billing system."""
+  billingReference: String
+  clearBillingReference: Boolean
+  """Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection."""
+  deletionProtected: Boolean
+  clearDeletionProtected: Boolean
+  parentID: ID
+  clearParent: Boolean
 }
 `, BuiltIn: false},
 	{Name: "../../schema/tenant.graphql", Input: `directive @prefixedID(prefix: String!) on OBJECT
@@ -641,6 +748,13 @@ interface MetadataNode {
   id: ID!
 }
 
+extend type Tenant {
+  """
+  The time the tenant will be deleted at, null unless a deletion is pending.
+  """
+  deletionScheduledAt: Time
+}
+
 extend type Query {
   """
   Lookup a tenant by ID.
@@ -659,18 +773,30 @@ extend type Mutation {
   """
   tenantCreate(
     input: CreateTenantInput!
+    """
+    What to do when a sibling of the tenant already has its name.
+    """
+    onConflict: TenantNameConflict! = ALLOW
   ): TenantCreatePayload!
    """
-  Update a tenant.
+  Update a tenant. Setting the parent moves the tenant under it, which the caller must be allowed
+  to create tenants under, tenants can't be made roots nor moved under their descendants.
   """
   tenantUpdate(
     id: ID!
     input: UpdateTenantInput!
   ): TenantUpdatePayload!
   """
-  Delete a tenant.
+  Delete a tenant. Tenants which resources of other services depend on can't be deleted unless
+  forced, which requires an elevated scope.
   """
-  tenantDelete(id: ID!): TenantDeletePayload!
+  tenantDelete(
+    id: ID!
+    """
+    Delete the tenant regardless of the resources depending on it, they are left orphaned.
+    """
+    force: Boolean = false
+  ): TenantDeletePayload!
 }
 
 """
@@ -681,6 +807,29 @@ type TenantCreatePayload {
   The created tenant.
   """
   tenant: Tenant!
+  """
+  Whether the tenant was created with a suffixed name as a sibling already had the requested one.
+  """
+  renamed: Boolean!
+}
+
+"""
+Handling of tenants created with the name of a sibling.
+"""
+enum TenantNameConflict {
+  """
+  Create the tenant with the name regardless, the default.
+  """
+  ALLOW
+  """
+  Append the first free numeric suffix to the name, such as staging-2.
+  """
+  SUFFIX
+  """
+  Fail with a name conflict, telling whether the sibling is live or deleted. Whether deleted
+  siblings keep their names depends on the name reuse policy of the api.
+  """
+  ERROR
 }
 
 """
@@ -807,6 +956,15 @@ func (ec *executionContext) field_Mutation_tenantCreate_args(ctx context.Context
 		}
 	}
 	args["input"] = arg0
+	var arg1 TenantNameConflict
+	if tmp, ok := rawArgs["onConflict"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("onConflict"))
+		arg1, err = ec.unmarshalNTenantNameConflict2goᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋgraphapiᚐTenantNameConflict(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["onConflict"] = arg1
 	return args, nil
 }
 
@@ -822,6 +980,15 @@ func (ec *executionContext) field_Mutation_tenantDelete_args(ctx context.Context
 		}
 	}
 	args["id"] = arg0
+	var arg1 *bool
+	if tmp, ok := rawArgs["force"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("force"))
+		arg1, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["force"] = arg1
 	return args, nil
 }
 
@@ -951,6 +1118,15 @@ func (ec *executionContext) field_Tenant_children_args(ctx context.Context, rawA
 		}
 	}
 	args["where"] = arg5
+	var arg6 bool
+	if tmp, ok := rawArgs["includeArchived"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeArchived"))
+		arg6, err = ec.unmarshalNBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeArchived"] = arg6
 	return args, nil
 }
 
@@ -1039,12 +1215,24 @@ func (ec *executionContext) fieldContext_Entity_findTenantByID(ctx context.Conte
 				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			case "name":
 				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
 			case "description":
 				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
 			case "parent":
 				return ec.fieldContext_Tenant_parent(ctx, field)
 			case "children":
 				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
@@ -1077,7 +1265,7 @@ func (ec *executionContext) _Mutation_tenantCreate(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().TenantCreate(rctx, fc.Args["input"].(generated.CreateTenantInput))
+		return ec.resolvers.Mutation().TenantCreate(rctx, fc.Args["input"].(generated.CreateTenantInput), fc.Args["onConflict"].(TenantNameConflict))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1104,6 +1292,8 @@ func (ec *executionContext) fieldContext_Mutation_tenantCreate(ctx context.Conte
 			switch field.Name {
 			case "tenant":
 				return ec.fieldContext_TenantCreatePayload_tenant(ctx, field)
+			case "renamed":
+				return ec.fieldContext_TenantCreatePayload_renamed(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type TenantCreatePayload", field.Name)
 		},
@@ -1195,7 +1385,7 @@ func (ec *executionContext) _Mutation_tenantDelete(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().TenantDelete(rctx, fc.Args["id"].(gidx.PrefixedID))
+		return ec.resolvers.Mutation().TenantDelete(rctx, fc.Args["id"].(gidx.PrefixedID), fc.Args["force"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1457,12 +1647,24 @@ func (ec *executionContext) fieldContext_Query_tenant(ctx context.Context, field
 				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			case "name":
 				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
 			case "description":
 				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
 			case "parent":
 				return ec.fieldContext_Tenant_parent(ctx, field)
 			case "children":
 				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
@@ -1889,6 +2091,47 @@ func (ec *executionContext) fieldContext_Tenant_name(ctx context.Context, field
 	return fc, nil
 }
 
+func (ec *executionContext) _Tenant_displayName(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_displayName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DisplayName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_displayName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Tenant_description(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Tenant_description(ctx, field)
 	if err != nil {
@@ -1930,6 +2173,170 @@ func (ec *executionContext) fieldContext_Tenant_description(ctx context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _Tenant_contactEmail(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_contactEmail(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ContactEmail, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_contactEmail(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_billingReference(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_billingReference(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BillingReference, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_billingReference(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_deletionProtected(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_deletionProtected(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeletionProtected, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_deletionProtected(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Tenant_changeSeq(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_changeSeq(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChangeSeq, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(int64)
+	fc.Result = res
+	return ec.marshalOInt2int64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_changeSeq(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Tenant_parent(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Tenant_parent(ctx, field)
 	if err != nil {
@@ -1974,12 +2381,24 @@ func (ec *executionContext) fieldContext_Tenant_parent(ctx context.Context, fiel
 				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			case "name":
 				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
 			case "description":
 				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
 			case "parent":
 				return ec.fieldContext_Tenant_parent(ctx, field)
 			case "children":
 				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
@@ -2001,7 +2420,7 @@ func (ec *executionContext) _Tenant_children(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Children(ctx, fc.Args["after"].(*entgql.Cursor[gidx.PrefixedID]), fc.Args["first"].(*int), fc.Args["before"].(*entgql.Cursor[gidx.PrefixedID]), fc.Args["last"].(*int), fc.Args["orderBy"].(*generated.TenantOrder), fc.Args["where"].(*generated.TenantWhereInput))
+		return ec.resolvers.Tenant().Children(rctx, obj, fc.Args["after"].(*entgql.Cursor[gidx.PrefixedID]), fc.Args["first"].(*int), fc.Args["before"].(*entgql.Cursor[gidx.PrefixedID]), fc.Args["last"].(*int), fc.Args["orderBy"].(*generated.TenantOrder), fc.Args["where"].(*generated.TenantWhereInput), fc.Args["includeArchived"].(bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2023,7 +2442,7 @@ func (ec *executionContext) fieldContext_Tenant_children(ctx context.Context, fi
 		Object:     "Tenant",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "edges":
@@ -2050,6 +2469,47 @@ func (ec *executionContext) fieldContext_Tenant_children(ctx context.Context, fi
 	return fc, nil
 }
 
+func (ec *executionContext) _Tenant_deletionScheduledAt(ctx context.Context, field graphql.CollectedField, obj *generated.Tenant) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Tenant().DeletionScheduledAt(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Tenant_deletionScheduledAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Tenant",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _TenantConnection_edges(ctx context.Context, field graphql.CollectedField, obj *generated.TenantConnection) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_TenantConnection_edges(ctx, field)
 	if err != nil {
@@ -2233,23 +2693,79 @@ func (ec *executionContext) fieldContext_TenantCreatePayload_tenant(ctx context.
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Tenant_id(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Tenant_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Tenant_updatedAt(ctx, field)
-			case "name":
-				return ec.fieldContext_Tenant_name(ctx, field)
-			case "description":
-				return ec.fieldContext_Tenant_description(ctx, field)
-			case "parent":
-				return ec.fieldContext_Tenant_parent(ctx, field)
-			case "children":
-				return ec.fieldContext_Tenant_children(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Tenant_id(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Tenant_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Tenant_updatedAt(ctx, field)
+			case "name":
+				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
+			case "parent":
+				return ec.fieldContext_Tenant_parent(ctx, field)
+			case "children":
+				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TenantCreatePayload_renamed(ctx context.Context, field graphql.CollectedField, obj *TenantCreatePayload) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_TenantCreatePayload_renamed(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Renamed, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_TenantCreatePayload_renamed(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TenantCreatePayload",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
@@ -2343,12 +2859,24 @@ func (ec *executionContext) fieldContext_TenantEdge_node(ctx context.Context, fi
 				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			case "name":
 				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
 			case "description":
 				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
 			case "parent":
 				return ec.fieldContext_Tenant_parent(ctx, field)
 			case "children":
 				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
@@ -2447,12 +2975,24 @@ func (ec *executionContext) fieldContext_TenantUpdatePayload_tenant(ctx context.
 				return ec.fieldContext_Tenant_updatedAt(ctx, field)
 			case "name":
 				return ec.fieldContext_Tenant_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Tenant_displayName(ctx, field)
 			case "description":
 				return ec.fieldContext_Tenant_description(ctx, field)
+			case "contactEmail":
+				return ec.fieldContext_Tenant_contactEmail(ctx, field)
+			case "billingReference":
+				return ec.fieldContext_Tenant_billingReference(ctx, field)
+			case "deletionProtected":
+				return ec.fieldContext_Tenant_deletionProtected(ctx, field)
+			case "changeSeq":
+				return ec.fieldContext_Tenant_changeSeq(ctx, field)
 			case "parent":
 				return ec.fieldContext_Tenant_parent(ctx, field)
 			case "children":
 				return ec.fieldContext_Tenant_children(ctx, field)
+			case "deletionScheduledAt":
+				return ec.fieldContext_Tenant_deletionScheduledAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Tenant", field.Name)
 		},
@@ -4281,7 +4821,7 @@ func (ec *executionContext) unmarshalInputCreateTenantInput(ctx context.Context,
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"name", "description", "parentID"}
+	fieldsInOrder := [...]string{"name", "displayName", "description", "contactEmail", "billingReference", "parentID"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4297,6 +4837,15 @@ func (ec *executionContext) unmarshalInputCreateTenantInput(ctx context.Context,
 				return it, err
 			}
 			it.Name = data
+		case "displayName":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("displayName"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DisplayName = data
 		case "description":
 			var err error
 
@@ -4306,6 +4855,24 @@ func (ec *executionContext) unmarshalInputCreateTenantInput(ctx context.Context,
 				return it, err
 			}
 			it.Description = data
+		case "contactEmail":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("contactEmail"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ContactEmail = data
+		case "billingReference":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("billingReference"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.BillingReference = data
 		case "parentID":
 			var err error
 
@@ -4370,7 +4937,7 @@ func (ec *executionContext) unmarshalInputTenantWhereInput(ctx context.Context,
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"not", "and", "or", "id", "idNEQ", "idIn", "idNotIn", "idGT", "idGTE", "idLT", "idLTE", "createdAt", "createdAtNEQ", "createdAtIn", "createdAtNotIn", "createdAtGT", "createdAtGTE", "createdAtLT", "createdAtLTE", "updatedAt", "updatedAtNEQ", "updatedAtIn", "updatedAtNotIn", "updatedAtGT", "updatedAtGTE", "updatedAtLT", "updatedAtLTE", "hasParent", "hasParentWith", "hasChildren", "hasChildrenWith"}
+	fieldsInOrder := [...]string{"not", "and", "or", "id", "idNEQ", "idIn", "idNotIn", "idGT", "idGTE", "idLT", "idLTE", "createdAt", "createdAtNEQ", "createdAtIn", "createdAtNotIn", "createdAtGT", "createdAtGTE", "createdAtLT", "createdAtLTE", "updatedAt", "updatedAtNEQ", "updatedAtIn", "updatedAtNotIn", "updatedAtGT", "updatedAtGTE", "updatedAtLT", "updatedAtLTE", "changeSeq", "changeSeqNEQ", "changeSeqIn", "changeSeqNotIn", "changeSeqGT", "changeSeqGTE", "changeSeqLT", "changeSeqLTE", "changeSeqIsNil", "changeSeqNotNil", "hasParent", "hasParentWith", "hasChildren", "hasChildrenWith"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4620,6 +5187,96 @@ func (ec *executionContext) unmarshalInputTenantWhereInput(ctx context.Context,
 				return it, err
 			}
 			it.UpdatedAtLTE = data
+		case "changeSeq":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeq"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeq = data
+		case "changeSeqNEQ":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqNEQ"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqNEQ = data
+		case "changeSeqIn":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqIn"))
+			data, err := ec.unmarshalOInt2ᚕint64ᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqIn = data
+		case "changeSeqNotIn":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqNotIn"))
+			data, err := ec.unmarshalOInt2ᚕint64ᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqNotIn = data
+		case "changeSeqGT":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqGT"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqGT = data
+		case "changeSeqGTE":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqGTE"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqGTE = data
+		case "changeSeqLT":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqLT"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqLT = data
+		case "changeSeqLTE":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqLTE"))
+			data, err := ec.unmarshalOInt2ᚖint64(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqLTE = data
+		case "changeSeqIsNil":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqIsNil"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqIsNil = data
+		case "changeSeqNotNil":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("changeSeqNotNil"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ChangeSeqNotNil = data
 		case "hasParent":
 			var err error
 
@@ -4669,7 +5326,7 @@ func (ec *executionContext) unmarshalInputUpdateTenantInput(ctx context.Context,
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"name", "description", "clearDescription"}
+	fieldsInOrder := [...]string{"name", "displayName", "clearDisplayName", "description", "clearDescription", "contactEmail", "clearContactEmail", "billingReference", "clearBillingReference", "deletionProtected", "clearDeletionProtected", "parentID", "clearParent"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4685,6 +5342,24 @@ func (ec *executionContext) unmarshalInputUpdateTenantInput(ctx context.Context,
 				return it, err
 			}
 			it.Name = data
+		case "displayName":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("displayName"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DisplayName = data
+		case "clearDisplayName":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearDisplayName"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearDisplayName = data
 		case "description":
 			var err error
 
@@ -4703,6 +5378,78 @@ func (ec *executionContext) unmarshalInputUpdateTenantInput(ctx context.Context,
 				return it, err
 			}
 			it.ClearDescription = data
+		case "contactEmail":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("contactEmail"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ContactEmail = data
+		case "clearContactEmail":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearContactEmail"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearContactEmail = data
+		case "billingReference":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("billingReference"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.BillingReference = data
+		case "clearBillingReference":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearBillingReference"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearBillingReference = data
+		case "deletionProtected":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("deletionProtected"))
+			data, err := ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DeletionProtected = data
+		case "clearDeletionProtected":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearDeletionProtected"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearDeletionProtected = data
+		case "parentID":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("parentID"))
+			data, err := ec.unmarshalOID2ᚖgoᚗinfratographerᚗcomᚋxᚋgidxᚐPrefixedID(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ParentID = data
+		case "clearParent":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clearParent"))
+			data, err := ec.unmarshalOBoolean2bool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClearParent = data
 		}
 	}
 
@@ -5101,8 +5848,18 @@ func (ec *executionContext) _Tenant(ctx context.Context, sel ast.SelectionSet, o
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&out.Invalids, 1)
 			}
+		case "displayName":
+			out.Values[i] = ec._Tenant_displayName(ctx, field, obj)
 		case "description":
 			out.Values[i] = ec._Tenant_description(ctx, field, obj)
+		case "contactEmail":
+			out.Values[i] = ec._Tenant_contactEmail(ctx, field, obj)
+		case "billingReference":
+			out.Values[i] = ec._Tenant_billingReference(ctx, field, obj)
+		case "deletionProtected":
+			out.Values[i] = ec._Tenant_deletionProtected(ctx, field, obj)
+		case "changeSeq":
+			out.Values[i] = ec._Tenant_changeSeq(ctx, field, obj)
 		case "parent":
 			field := field
 
@@ -5171,6 +5928,39 @@ func (ec *executionContext) _Tenant(ctx context.Context, sel ast.SelectionSet, o
 				continue
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "deletionScheduledAt":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Tenant_deletionScheduledAt(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
@@ -5257,6 +6047,11 @@ func (ec *executionContext) _TenantCreatePayload(ctx context.Context, sel ast.Se
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "renamed":
+			out.Values[i] = ec._TenantCreatePayload_renamed(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -5831,6 +6626,21 @@ func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.Selecti
 	return res
 }
 
+func (ec *executionContext) unmarshalNInt2int64(ctx context.Context, v interface{}) (int64, error) {
+	res, err := graphql.UnmarshalInt64(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNInt2int64(ctx context.Context, sel ast.SelectionSet, v int64) graphql.Marshaler {
+	res := graphql.MarshalInt64(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) unmarshalNOrderDirection2entgoᚗioᚋcontribᚋentgqlᚐOrderDirection(ctx context.Context, v interface{}) (entgql.OrderDirection, error) {
 	var res entgql.OrderDirection
 	err := res.UnmarshalGQL(v)
@@ -5874,6 +6684,10 @@ func (ec *executionContext) marshalNTenant2ᚖgoᚗinfratographerᚗcomᚋtenant
 	return ec._Tenant(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNTenantConnection2goᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋentᚋgeneratedᚐTenantConnection(ctx context.Context, sel ast.SelectionSet, v generated.TenantConnection) graphql.Marshaler {
+	return ec._TenantConnection(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalNTenantConnection2ᚖgoᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋentᚋgeneratedᚐTenantConnection(ctx context.Context, sel ast.SelectionSet, v *generated.TenantConnection) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -5912,6 +6726,16 @@ func (ec *executionContext) marshalNTenantDeletePayload2ᚖgoᚗinfratographer
 	return ec._TenantDeletePayload(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNTenantNameConflict2goᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋgraphapiᚐTenantNameConflict(ctx context.Context, v interface{}) (TenantNameConflict, error) {
+	var res TenantNameConflict
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTenantNameConflict2goᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋgraphapiᚐTenantNameConflict(ctx context.Context, sel ast.SelectionSet, v TenantNameConflict) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNTenantOrderField2ᚖgoᚗinfratographerᚗcomᚋtenantᚑapiᚋinternalᚋentᚋgeneratedᚐTenantOrderField(ctx context.Context, v interface{}) (*generated.TenantOrderField, error) {
 	var res = new(generated.TenantOrderField)
 	err := res.UnmarshalGQL(v)
@@ -6411,6 +7235,54 @@ func (ec *executionContext) marshalOID2ᚖgoᚗinfratographerᚗcomᚋxᚋgidx
 	return v
 }
 
+func (ec *executionContext) unmarshalOInt2int64(ctx context.Context, v interface{}) (int64, error) {
+	res, err := graphql.UnmarshalInt64(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2int64(ctx context.Context, sel ast.SelectionSet, v int64) graphql.Marshaler {
+	res := graphql.MarshalInt64(v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOInt2ᚕint64ᚄ(ctx context.Context, v interface{}) ([]int64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]int64, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNInt2int64(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOInt2ᚕint64ᚄ(ctx context.Context, sel ast.SelectionSet, v []int64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNInt2int64(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
 	if v == nil {
 		return nil, nil
@@ -6427,6 +7299,22 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return res
 }
 
+func (ec *executionContext) unmarshalOInt2ᚖint64(ctx context.Context, v interface{}) (*int64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalInt64(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2ᚖint64(ctx context.Context, sel ast.SelectionSet, v *int64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalInt64(*v)
+	return res
+}
+
 func (ec *executionContext) unmarshalOString2string(ctx context.Context, v interface{}) (string, error) {
 	res, err := graphql.UnmarshalString(v)
 	return res, graphql.ErrorOnPath(ctx, err)
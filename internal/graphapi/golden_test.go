@@ -0,0 +1,196 @@
+package graphapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/hookchain"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// goldenTime is the fixed time used for all timestamps while deterministic output is enabled.
+var goldenTime = time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// useDeterministicOutput replaces the id and timestamp generators with a fixed clock
+// and sequential ids until the test completes.
+func useDeterministicOutput(t *testing.T) {
+	t.Helper()
+
+	defaultID, defaultCreatedAt := tenant.DefaultID, tenant.DefaultCreatedAt
+	defaultUpdatedAt, updateDefaultUpdatedAt := tenant.DefaultUpdatedAt, tenant.UpdateDefaultUpdatedAt
+
+	var seq int
+
+	tenant.DefaultID = func() gidx.PrefixedID {
+		seq++
+
+		return gidx.PrefixedID(fmt.Sprintf("tnntten-golden%08d", seq))
+	}
+
+	clock := func() time.Time { return goldenTime }
+
+	tenant.DefaultCreatedAt = clock
+	tenant.DefaultUpdatedAt = clock
+	tenant.UpdateDefaultUpdatedAt = clock
+
+	t.Cleanup(func() {
+		tenant.DefaultID, tenant.DefaultCreatedAt = defaultID, defaultCreatedAt
+		tenant.DefaultUpdatedAt, tenant.UpdateDefaultUpdatedAt = defaultUpdatedAt, updateDefaultUpdatedAt
+	})
+}
+
+// assertGolden compares the response body against the named golden file, rewriting it when -update is set.
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	var pretty bytes.Buffer
+
+	require.NoError(t, json.Indent(&pretty, body, "", "  "))
+	pretty.WriteByte('\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, pretty.Bytes(), 0o600))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "golden file missing, run with -update to create it")
+
+	assert.Equal(t, string(expected), pretty.String())
+}
+
+func postGraph(t *testing.T, url string, query string, vars map[string]any) []byte {
+	t.Helper()
+
+	reqBody, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	require.NoError(t, err)
+
+	resp, err := http.Post(url+"/query", "application/json", bytes.NewReader(reqBody))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return body
+}
+
+const (
+	goldenTenantFields = `id name description createdAt updatedAt parent { id }`
+
+	goldenCreateMutation = `mutation($input: CreateTenantInput!) { tenantCreate(input: $input) { tenant { ` + goldenTenantFields + ` } } }`
+	goldenGetQuery       = `query($id: ID!) { tenant(id: $id) { ` + goldenTenantFields + ` } }`
+	goldenDeleteMutation = `mutation($id: ID!) { tenantDelete(id: $id) { deletedID } }`
+	goldenChildrenQuery  = `query($id: ID!, $after: Cursor) {
+		tenant(id: $id) {
+			children(first: 2, after: $after, orderBy: {field: NAME}) {
+				edges { cursor node { ` + goldenTenantFields + ` } }
+				pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+				totalCount
+			}
+		}
+	}`
+)
+
+func TestGoldenResponses(t *testing.T) {
+	ctx := context.Background()
+
+	useDeterministicOutput(t)
+
+	// golden output uses its own database so sequential ids never collide with other tests
+	entClient, err := ent.Open(dialect.SQLite, "file:golden?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	defer entClient.Close()
+
+	require.NoError(t, entClient.Schema.Create(ctx))
+
+	rules := validation.NewNameValidator(validation.WithReservedNames("api", "system-*")).Rules()
+	rules = append(rules, validation.NewMetadataValidator().Rules()...)
+	rules = append(rules, validation.NewSettingsValidator().Rules()...)
+	rules = append(rules, validation.NewLabelsValidator().Rules()...)
+
+	registerHooks(entClient, hookchain.WithPipeline(validation.NewPipeline(rules...)), hookchain.WithMaxChildren(3))
+
+	srv := newTestServer(t, entClient, WithAuthDisabled())
+
+	body := postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+		"input": map[string]any{"name": "golden-root", "description": "the root tenant"},
+	})
+	assertGolden(t, "create", body)
+
+	rootID := "tnntten-golden00000001"
+
+	for _, name := range []string{"charlie", "alpha", "bravo"} {
+		postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+			"input": map[string]any{"name": name, "parentID": rootID},
+		})
+	}
+
+	assertGolden(t, "get", postGraph(t, srv.URL, goldenGetQuery, map[string]any{"id": rootID}))
+
+	body = postGraph(t, srv.URL, goldenChildrenQuery, map[string]any{"id": rootID})
+	assertGolden(t, "children_page_1", body)
+
+	var page struct {
+		Data struct {
+			Tenant struct {
+				Children struct {
+					PageInfo struct {
+						EndCursor string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"children"`
+			} `json:"tenant"`
+		} `json:"data"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &page))
+
+	assertGolden(t, "children_page_2", postGraph(t, srv.URL, goldenChildrenQuery, map[string]any{
+		"id":    rootID,
+		"after": page.Data.Tenant.Children.PageInfo.EndCursor,
+	}))
+
+	assertGolden(t, "error_not_found", postGraph(t, srv.URL, goldenGetQuery, map[string]any{"id": "tnntten-missing"}))
+	assertGolden(t, "error_has_children", postGraph(t, srv.URL, goldenDeleteMutation, map[string]any{"id": rootID}))
+	assertGolden(t, "error_reserved_name", postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+		"input": map[string]any{"name": " System-EU "},
+	}))
+	assertGolden(t, "error_invalid_name", postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+		"input": map[string]any{"name": "zero\u200bwidth"},
+	}))
+	assertGolden(t, "error_parent_not_found", postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+		"input": map[string]any{"name": "orphan", "parentID": "tnntten-missing"},
+	}))
+	assertGolden(t, "error_children_limit", postGraph(t, srv.URL, goldenCreateMutation, map[string]any{
+		"input": map[string]any{"name": "delta", "parentID": rootID},
+	}))
+
+	denied := newTestServer(t, entClient, WithAuthDisabled(),
+		WithPermissionsOptions(permissions.WithDefaultChecker(permissions.DefaultDenyChecker)),
+	)
+
+	assertGolden(t, "error_permission_denied", postGraph(t, denied.URL, goldenGetQuery, map[string]any{"id": rootID}))
+}
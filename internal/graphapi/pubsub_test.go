@@ -19,25 +19,18 @@ import (
 func TestTenantPubsub(t *testing.T) {
 	ctx := context.Background()
 
-	// Permit request
-	ctx = context.WithValue(ctx, permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
-
 	name := gofakeit.DomainName()
 	description := gofakeit.Phrase()
 
-	graphC := graphTestClient(testTools.pubsubEntClient)
-
 	sub, err := events.NewConnection(testTools.eventsConfig)
 	require.NoError(t, err)
 
 	defer sub.Shutdown(ctx) //nolint:errcheck // skip check in test
 
-	perms, err := permissions.New(permissions.Config{}, permissions.WithEventsPublisher(sub))
-	if err != nil {
-		t.Fatalf("failed to initialize permissions: %s", err.Error())
-	}
-
-	ctx = context.WithValue(ctx, permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+	graphC := newTestServer(t, testTools.pubsubEntClient,
+		WithAuthDisabled(),
+		WithPermissionsOptions(permissions.WithEventsPublisher(sub)),
+	).GraphClient
 
 	authMsgs, err := sub.SubscribeAuthRelationshipRequests(ctx, ">")
 	require.NoError(t, err)
@@ -69,8 +62,8 @@ func TestTenantPubsub(t *testing.T) {
 	assert.Equal(t, "tenant-api-test", msg.Source)
 	assert.Equal(t, rootTenant.ID, msg.SubjectID)
 	assert.Empty(t, msg.AdditionalSubjectIDs)
-	// expect created_at, updated_at, name, and description changeset
-	assert.Len(t, msg.FieldChanges, 4)
+	// expect created_at, updated_at, name, display_name, description, change_seq and version changeset
+	assert.Len(t, msg.FieldChanges, 7)
 
 	var createdAtVisited, updatedAtVisited, nameVisited, descriptionVisited bool
 
@@ -92,10 +85,14 @@ func TestTenantPubsub(t *testing.T) {
 			nameVisited = true
 
 			assert.EqualValues(t, name, change.CurrentValue)
+		case "display_name":
+			assert.EqualValues(t, name, change.CurrentValue, "the display name defaults to the name")
 		case "description":
 			descriptionVisited = true
 
 			assert.EqualValues(t, description, change.CurrentValue)
+		case "change_seq", "version":
+			assert.NotEmpty(t, change.CurrentValue)
 		default:
 			assert.Fail(t, "unexpected field in changeset %s")
 			t.Fail()
@@ -124,8 +121,8 @@ func TestTenantPubsub(t *testing.T) {
 	assert.Equal(t, "tenant-api-test", msg.Source)
 	assert.Equal(t, childTnt.ID, msg.SubjectID)
 	assert.EqualValues(t, []gidx.PrefixedID{rootTenant.ID}, msg.AdditionalSubjectIDs)
-	// expect created_at, updated_at, name, and description changeset
-	assert.Len(t, msg.FieldChanges, 4)
+	// expect created_at, updated_at, name, display_name, parent_tenant_id, change_seq and version changeset
+	assert.Len(t, msg.FieldChanges, 7)
 
 	createdAtVisited = false
 	updatedAtVisited = false
@@ -150,11 +147,15 @@ func TestTenantPubsub(t *testing.T) {
 		case "name":
 			nameVisited = true
 
+			assert.EqualValues(t, "child", change.CurrentValue)
+		case "display_name":
 			assert.EqualValues(t, "child", change.CurrentValue)
 		case "parent_tenant_id":
 			parentIDVisited = true
 
 			assert.EqualValues(t, rootTenant.ID.String(), change.CurrentValue)
+		case "change_seq", "version":
+			assert.NotEmpty(t, change.CurrentValue)
 		default:
 			assert.Fail(t, fmt.Sprintf("unexpected field in changeset %s", change.Field))
 			t.Fail()
@@ -179,8 +180,8 @@ func TestTenantPubsub(t *testing.T) {
 	assert.Equal(t, "tenant-api-test", msg.Source)
 	assert.Equal(t, childTnt.ID, msg.SubjectID)
 	assert.EqualValues(t, []gidx.PrefixedID{rootTenant.ID}, msg.AdditionalSubjectIDs)
-	// expect updated_at, and name changeset
-	assert.Len(t, msg.FieldChanges, 2)
+	// expect updated_at, name, display_name and change_seq changeset
+	assert.Len(t, msg.FieldChanges, 4)
 
 	updatedAtVisited = false
 	nameVisited = false
@@ -199,6 +200,10 @@ func TestTenantPubsub(t *testing.T) {
 
 			assert.EqualValues(t, "child", change.PreviousValue)
 			assert.EqualValues(t, newName, change.CurrentValue)
+		case "display_name":
+			assert.EqualValues(t, "child", change.CurrentValue, "the display name isn't renamed along")
+		case "change_seq":
+			assert.NotEqual(t, change.PreviousValue, change.CurrentValue)
 		default:
 			assert.Fail(t, "unexpected field in changeset %s")
 			t.Fail()
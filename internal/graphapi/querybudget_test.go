@@ -0,0 +1,99 @@
+package graphapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/querybudget"
+)
+
+// childrenQueryBudget is the most queries a page of children with their parent and children count
+// may issue, whatever its size.
+const childrenQueryBudget = 5
+
+func TestTenantChildrenQueryBudget(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	entClient := ent.NewClient(ent.Driver(querybudget.Driver(drv)))
+	t.Cleanup(func() { entClient.Close() })
+
+	require.NoError(t, entClient.Schema.Create(ctx))
+
+	registerHooks(entClient)
+
+	srv := newTestServer(t, entClient, WithAuthDisabled(), WithMiddleware(
+		querybudget.Middleware(querybudget.WithBudget(childrenQueryBudget), querybudget.WithHeader(true)),
+	))
+
+	root := entClient.Tenant.Create().SetName("root").SaveX(ctx)
+
+	for i := 0; i < 20; i++ {
+		child := entClient.Tenant.Create().SetName("child-" + strconv.Itoa(i)).SetParent(root).SaveX(ctx)
+		entClient.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	}
+
+	query := `query($id: ID!, $first: Int) {
+		tenant(id: $id) {
+			children(first: $first) {
+				edges { node { id parent { id } children { totalCount } } }
+				totalCount
+			}
+		}
+	}`
+
+	queries := func(first int) string {
+		t.Helper()
+
+		reqBody, err := json.Marshal(map[string]any{"query": query, "variables": map[string]any{"id": root.ID, "first": first}})
+		require.NoError(t, err)
+
+		resp, err := http.Post(srv.URL+"/query", "application/json", bytes.NewReader(reqBody))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var out struct {
+			Errors []any `json:"errors"`
+			Data   struct {
+				Tenant struct {
+					Children struct {
+						Edges []struct {
+							Node struct {
+								Children struct {
+									TotalCount int `json:"totalCount"`
+								} `json:"children"`
+							} `json:"node"`
+						} `json:"edges"`
+						TotalCount int `json:"totalCount"`
+					} `json:"children"`
+				} `json:"tenant"`
+			} `json:"data"`
+		}
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		require.Empty(t, out.Errors)
+		require.Len(t, out.Data.Tenant.Children.Edges, first)
+		assert.Equal(t, 20, out.Data.Tenant.Children.TotalCount)
+
+		for _, edge := range out.Data.Tenant.Children.Edges {
+			assert.Equal(t, 1, edge.Node.Children.TotalCount)
+		}
+
+		return resp.Header.Get(querybudget.HeaderQueryCount)
+	}
+
+	assert.Equal(t, queries(1), queries(20))
+}
@@ -0,0 +1,29 @@
+package graphapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+const errCodeFieldRedacted = "FIELD_REDACTED"
+
+// redactFields rejects tenant fields which aren't visible with the caller's scopes. Callers limited
+// to a subset of fields are expected to only select those.
+func redactFields(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+
+	if fc != nil && fc.Object == "Tenant" && !redact.FromContext(ctx).Visible(fc.Field.Name) {
+		return nil, &gqlerror.Error{
+			Message:    fmt.Sprintf("field %s is not visible with the scopes of the caller", fc.Field.Name),
+			Path:       fc.Path(),
+			Extensions: map[string]any{"code": errCodeFieldRedacted},
+		}
+	}
+
+	return next(ctx)
+}
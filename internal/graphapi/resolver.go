@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/validation"
 )
 
 // This file will not be regenerated automatically.
@@ -24,18 +25,57 @@ var (
 	graphFullPath = fmt.Sprintf("/%s", graphPath)
 )
 
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithNameReusePolicy sets whether created tenants may take the names of deleted siblings when
+// checking for name conflicts.
+func WithNameReusePolicy(policy validation.NameReusePolicy) Option {
+	return func(r *Resolver) {
+		r.nameReuse = policy
+	}
+}
+
+// WithForceDeleteScope sets the token scope required to delete tenants despite their dependents,
+// deletions can't be forced without one.
+func WithForceDeleteScope(scope string) Option {
+	return func(r *Resolver) {
+		r.forceDeleteScope = scope
+	}
+}
+
+// WithValidation sets the pipeline validating and normalizing the tenants created and updated,
+// the default rules by default. It should be the pipeline the ent client's hook applies, so
+// mutations are rejected with every error at once rather than by the hook with the first.
+func WithValidation(p *validation.Pipeline) Option {
+	return func(r *Resolver) {
+		r.validator = p
+	}
+}
+
 // Resolver provides a graph response resolver
 type Resolver struct {
-	client *ent.Client
-	logger *zap.SugaredLogger
+	client           *ent.Client
+	logger           *zap.SugaredLogger
+	nameReuse        validation.NameReusePolicy
+	forceDeleteScope string
+	validator        *validation.Pipeline
 }
 
 // NewResolver returns a resolver configured with the given ent client
-func NewResolver(client *ent.Client, logger *zap.SugaredLogger) *Resolver {
-	return &Resolver{
-		client: client,
-		logger: logger,
+func NewResolver(client *ent.Client, logger *zap.SugaredLogger, opts ...Option) *Resolver {
+	r := &Resolver{
+		client:    client,
+		logger:    logger,
+		nameReuse: validation.NameReuseBlockDuringRetention,
+		validator: validation.DefaultPipeline(),
 	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
 // Handler is an http handler wrapping a Resolver
@@ -57,6 +97,8 @@ func (r *Resolver) Handler(withPlayground bool, middleware []echo.MiddlewareFunc
 	)
 
 	srv.Use(oteltracing.Tracer{})
+	srv.AroundFields(redactFields)
+	srv.SetErrorPresenter(errorPresenter)
 
 	h := &Handler{
 		r:              r,
@@ -0,0 +1,55 @@
+package graphapi_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+
+	"go.infratographer.com/tenant-api/internal/testclient"
+)
+
+const tenantCreateBody = `{"query":"mutation { tenantCreate(input: {name: \"server-test\"}) { tenant { id } } }"}`
+
+func TestTestServerRequiresAuthByDefault(t *testing.T) {
+	srv := newTestServer(t, testTools.entClient)
+
+	resp, err := http.Post(srv.URL+"/query", "application/json", bytes.NewBufferString(tenantCreateBody))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	created, err := srv.GraphClient.TenantCreate(context.Background(), testclient.CreateTenantInput{Name: "authenticated"})
+	require.NoError(t, err)
+	assert.Equal(t, "authenticated", created.TenantCreate.Tenant.Name)
+}
+
+func TestTestServerAuthDisabled(t *testing.T) {
+	var actor string
+
+	srv := newTestServer(t, testTools.entClient,
+		WithAuthDisabled(),
+		WithMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				actor = echojwtx.Actor(c)
+
+				return next(c)
+			}
+		}),
+	)
+
+	resp, err := http.Post(srv.URL+"/query", "application/json", bytes.NewBufferString(tenantCreateBody))
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, testActor, actor)
+}
@@ -7,15 +7,20 @@ package graphapi
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/dependents"
 	"go.infratographer.com/tenant-api/internal/ent/generated"
 	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/internal/validation"
 	"go.infratographer.com/x/gidx"
 )
 
 // TenantCreate is the resolver for the tenantCreate field.
-func (r *mutationResolver) TenantCreate(ctx context.Context, input generated.CreateTenantInput) (*TenantCreatePayload, error) {
+func (r *mutationResolver) TenantCreate(ctx context.Context, input generated.CreateTenantInput, onConflict TenantNameConflict) (*TenantCreatePayload, error) {
 	resource := gidx.NullPrefixedID
 
 	if input.ParentID != nil {
@@ -26,12 +31,12 @@ func (r *mutationResolver) TenantCreate(ctx context.Context, input generated.Cre
 		return nil, err
 	}
 
-	tnt, err := r.client.Tenant.Create().SetInput(input).Save(ctx)
+	tnt, renamed, err := r.createTenant(ctx, input, onConflict)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TenantCreatePayload{Tenant: tnt}, nil
+	return &TenantCreatePayload{Tenant: tnt, Renamed: renamed}, nil
 }
 
 // TenantUpdate is the resolver for the tenantUpdate field.
@@ -40,7 +45,29 @@ func (r *mutationResolver) TenantUpdate(ctx context.Context, id gidx.PrefixedID,
 		return nil, err
 	}
 
-	tnt, err := r.client.Tenant.UpdateOneID(id).SetInput(input).Save(ctx)
+	t := validation.Tenant{
+		Name:             input.Name,
+		DisplayName:      input.DisplayName,
+		Description:      input.Description,
+		ContactEmail:     input.ContactEmail,
+		BillingReference: input.BillingReference,
+	}
+
+	if err := r.validator.ValidateUpdate(ctx, &t); err != nil {
+		return nil, err
+	}
+
+	if input.ClearParent {
+		return nil, &validation.Error{Field: "clearParent", Code: validation.CodeInvalidValue, Message: "tenants can't be made roots"}
+	}
+
+	if input.ParentID != nil {
+		if err := permissions.CheckAccess(ctx, *input.ParentID, actionTenantCreate); err != nil {
+			return nil, err
+		}
+	}
+
+	tnt, err := r.updateTenant(ctx, id, input)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +75,17 @@ func (r *mutationResolver) TenantUpdate(ctx context.Context, id gidx.PrefixedID,
 }
 
 // TenantDelete is the resolver for the tenantDelete field.
-func (r *mutationResolver) TenantDelete(ctx context.Context, id gidx.PrefixedID) (*TenantDeletePayload, error) {
+func (r *mutationResolver) TenantDelete(ctx context.Context, id gidx.PrefixedID, force *bool) (*TenantDeletePayload, error) {
+	if force != nil && *force {
+		if r.forceDeleteScope == "" || !scopes.Has(ctx, r.forceDeleteScope) {
+			return nil, fmt.Errorf("%w: forcing deletions requires an elevated scope", permissions.ErrPermissionDenied)
+		}
+
+		r.logger.Warnw("forcing tenant deletion regardless of its dependents", "tenant_id", id)
+
+		ctx = dependents.Force(ctx)
+	}
+
 	if err := permissions.CheckAccess(ctx, id, actionTenantDelete); err != nil {
 		return nil, err
 	}
@@ -59,7 +96,7 @@ func (r *mutationResolver) TenantDelete(ctx context.Context, id gidx.PrefixedID)
 	}
 
 	if childrenCount != 0 {
-		return nil, fmt.Errorf("tenant has children and can't be deleted")
+		return nil, deletion.ErrHasChildren
 	}
 
 	if err := r.client.Tenant.DeleteOneID(id).Exec(ctx); err != nil {
@@ -78,6 +115,15 @@ func (r *queryResolver) Tenant(ctx context.Context, id gidx.PrefixedID) (*genera
 	return r.client.Tenant.Get(ctx, id)
 }
 
+// DeletionScheduledAt is the resolver for the deletionScheduledAt field.
+func (r *tenantResolver) DeletionScheduledAt(ctx context.Context, obj *generated.Tenant) (*time.Time, error) {
+	if obj.DeletionScheduledAt.IsZero() {
+		return nil, nil
+	}
+
+	return &obj.DeletionScheduledAt, nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
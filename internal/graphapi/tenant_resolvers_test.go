@@ -13,6 +13,7 @@ import (
 	"go.infratographer.com/x/gidx"
 
 	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
 	"go.infratographer.com/tenant-api/internal/testclient"
 )
 
@@ -48,9 +49,11 @@ func TestTenantQueryByID(t *testing.T) {
 		},
 	}
 
+	graph := newTestServer(t, testTools.entClient).GraphClient
+
 	for _, tt := range testCases {
 		t.Run(tt.TestName, func(t *testing.T) {
-			resp, err := graphTestClient(testTools.entClient).GetTenant(ctx, tt.ID)
+			resp, err := graph.GetTenant(ctx, tt.ID)
 
 			if tt.errorMsg != "" {
 				assert.Error(t, err)
@@ -149,9 +152,11 @@ func TestTenantChildrenSorting(t *testing.T) {
 		},
 	}
 
+	graph := newTestServer(t, testTools.entClient).GraphClient
+
 	for _, tt := range testCases {
 		t.Run(tt.TestName, func(t *testing.T) {
-			resp, err := graphTestClient(testTools.entClient).GetTenantChildren(ctx, tt.TenantID, tt.OrderBy)
+			resp, err := graph.GetTenantChildren(ctx, tt.TenantID, tt.OrderBy)
 
 			if tt.errorMsg != "" {
 				assert.Error(t, err)
@@ -220,9 +225,11 @@ func TestTenantChildrenWhereFiltering(t *testing.T) {
 		},
 	}
 
+	graph := newTestServer(t, testTools.entClient).GraphClient
+
 	for _, tt := range testCases {
 		t.Run(tt.TestName, func(t *testing.T) {
-			resp, err := graphTestClient(testTools.entClient).GetTenantChildByID(ctx, tt.TenantID, tt.ChildID)
+			resp, err := graph.GetTenantChildByID(ctx, tt.TenantID, tt.ChildID)
 
 			if tt.errorMsg != "" {
 				assert.Error(t, err)
@@ -246,13 +253,65 @@ func TestTenantChildrenWhereFiltering(t *testing.T) {
 	}
 }
 
+func TestTenantChildrenArchived(t *testing.T) {
+	ctx := context.Background()
+
+	perms := new(mockpermissions.MockPermissions)
+	perms.On("CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx = perms.ContextWithHandler(ctx)
+
+	// Permit request
+	ctx = context.WithValue(ctx, permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	parent := TenantBuilder{}.MustNew(ctx)
+	active := TenantBuilder{Parent: parent}.MustNew(ctx)
+	archived := TenantBuilder{Parent: parent}.MustNew(ctx)
+
+	testTools.entClient.Tenant.UpdateOne(archived).SetArchived(true).ExecX(ctx)
+
+	testCases := []struct {
+		TestName        string
+		IncludeArchived bool
+		ChildIDs        []gidx.PrefixedID
+	}{
+		{
+			TestName: "Archived children are left out by default",
+			ChildIDs: []gidx.PrefixedID{active.ID},
+		},
+		{
+			TestName:        "Archived children are listed when included",
+			IncludeArchived: true,
+			ChildIDs:        []gidx.PrefixedID{active.ID, archived.ID},
+		},
+	}
+
+	graph := newTestServer(t, testTools.entClient).GraphClient
+
+	for _, tt := range testCases {
+		t.Run(tt.TestName, func(t *testing.T) {
+			resp, err := graph.GetTenantChildrenArchived(ctx, parent.ID, tt.IncludeArchived)
+			require.NoError(t, err)
+			require.NotNil(t, resp.Tenant)
+
+			var childIDs []gidx.PrefixedID
+
+			for _, edge := range resp.Tenant.Children.Edges {
+				childIDs = append(childIDs, edge.Node.ID)
+			}
+
+			assert.ElementsMatch(t, tt.ChildIDs, childIDs)
+		})
+	}
+}
+
 func TestFullTenantLifecycle(t *testing.T) {
 	ctx := context.Background()
 
 	name := gofakeit.DomainName()
 	description := gofakeit.Phrase()
 
-	graphC := graphTestClient(testTools.entClient)
+	graphC := newTestServer(t, testTools.entClient, WithTestContext(func() context.Context { return ctx })).GraphClient
 
 	perms := new(mockpermissions.MockPermissions)
 	perms.On("CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -405,3 +464,67 @@ func TestFullTenantLifecycle(t *testing.T) {
 	perms.AssertNumberOfCalls(t, "CreateAuthRelationships", 1)
 	perms.AssertNumberOfCalls(t, "DeleteAuthRelationships", 1)
 }
+
+func TestTenantUpdateMove(t *testing.T) {
+	ctx := context.Background()
+
+	perms := new(mockpermissions.MockPermissions)
+	perms.On("CreateAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	perms.On("DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx = perms.ContextWithHandler(ctx)
+	ctx = context.WithValue(ctx, permissions.CheckerCtxKey, permissions.DefaultAllowChecker)
+
+	graphC := newTestServer(t, testTools.entClient, WithTestContext(func() context.Context { return ctx })).GraphClient
+
+	first := TenantBuilder{}.MustNew(ctx)
+	second := TenantBuilder{}.MustNew(ctx)
+	moved := TenantBuilder{Parent: first}.MustNew(ctx)
+	child := TenantBuilder{Parent: moved}.MustNew(ctx)
+
+	perms.Calls = nil
+
+	resp, err := graphC.TenantUpdate(ctx, moved.ID, testclient.UpdateTenantInput{ParentID: &second.ID})
+	require.NoError(t, err)
+	require.NotNil(t, resp.TenantUpdate.Tenant.Parent)
+	assert.Equal(t, second.ID, resp.TenantUpdate.Tenant.Parent.ID)
+
+	// the relationship to the previous parent is replaced once the move commits
+	perms.AssertNumberOfCalls(t, "DeleteAuthRelationships", 1)
+	perms.AssertNumberOfCalls(t, "CreateAuthRelationships", 1)
+
+	_, err = graphC.TenantUpdate(ctx, moved.ID, testclient.UpdateTenantInput{ParentID: &first.ID})
+	require.NoError(t, err)
+
+	history := testTools.entClient.TenantParentHistory.Query().
+		Where(tenantparenthistory.TenantID(moved.ID)).
+		Order(ent.Asc(tenantparenthistory.FieldChangedAt), ent.Asc(tenantparenthistory.FieldID)).
+		AllX(ctx)
+	require.Len(t, history, 2)
+	assert.Equal(t, second.ID, history[0].NewParentID)
+	assert.Equal(t, second.ID, history[1].PreviousParentID)
+	assert.Equal(t, first.ID, history[1].NewParentID)
+
+	_, err = graphC.TenantUpdate(ctx, moved.ID, testclient.UpdateTenantInput{ParentID: &child.ID})
+	require.ErrorContains(t, err, "descendants")
+
+	clear := true
+
+	_, err = graphC.TenantUpdate(ctx, moved.ID, testclient.UpdateTenantInput{ClearParent: &clear})
+	require.Error(t, err)
+
+	ctx = context.WithValue(ctx, permissions.CheckerCtxKey, permissions.Checker(func(_ context.Context, requests ...permissions.AccessRequest) error {
+		for _, req := range requests {
+			if req.ResourceID == second.ID {
+				return permissions.ErrPermissionDenied
+			}
+		}
+
+		return nil
+	}))
+
+	_, err = graphC.TenantUpdate(ctx, moved.ID, testclient.UpdateTenantInput{ParentID: &second.ID})
+	require.ErrorContains(t, err, permissions.ErrPermissionDenied.Error())
+
+	assert.Equal(t, first.ID, testTools.entClient.Tenant.GetX(ctx, moved.ID).ParentTenantID)
+}
@@ -10,20 +10,25 @@ import (
 	"testing"
 
 	"entgo.io/ent/dialect"
-	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/labstack/echo/v4"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/permissions-api/pkg/permissions"
 	"go.infratographer.com/x/echojwtx"
 	"go.infratographer.com/x/events"
 	"go.infratographer.com/x/goosex"
+	authtest "go.infratographer.com/x/testing/auth"
 	"go.infratographer.com/x/testing/containersx"
 	"go.infratographer.com/x/testing/eventtools"
 	"go.uber.org/zap"
 
 	"go.infratographer.com/tenant-api/db"
+	"go.infratographer.com/tenant-api/internal/actor"
 	ent "go.infratographer.com/tenant-api/internal/ent/generated"
-	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
 	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/hookchain"
 	"go.infratographer.com/tenant-api/internal/testclient"
 )
 
@@ -130,7 +135,26 @@ func setupDB() {
 	testTools.dbContainer = cntr
 	testTools.entClient = c
 	testTools.pubsubEntClient = c
-	eventhooks.EventHooks(testTools.pubsubEntClient)
+	registerHooks(testTools.pubsubEntClient, hookchain.WithEvents(true))
+}
+
+// registerHooks registers the hooks of the server on the client, letting changes without an
+// actor through so tests can set up tenants directly.
+func registerHooks(client *ent.Client, opts ...hookchain.Option) {
+	hookchain.Register(client, append([]hookchain.Option{hookchain.WithActorGuard(actor.WithAnonymous(true))}, opts...)...)
+}
+
+// newTestClient opens a sqlite database with the hooks of the server, closed once the test
+// completes.
+func newTestClient(t *testing.T, dsn string, opts ...hookchain.Option) *ent.Client {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+
+	registerHooks(client, opts...)
+
+	return client
 }
 
 func teardownDB() {
@@ -149,24 +173,150 @@ func teardownDB() {
 	}
 }
 
-func graphTestClient(entClient *ent.Client) testclient.TestClient {
-	return testclient.NewClient(&http.Client{Transport: localRoundTripper{handler: handler.NewDefaultServer(
-		graphapi.NewExecutableSchema(
-			graphapi.Config{Resolvers: graphapi.NewResolver(entClient, zap.NewNop().Sugar())},
-		))}}, "graph")
+type testServerConfig struct {
+	authDisabled bool
+	authConfig   *echojwtx.AuthConfig
+	permsOptions []permissions.Option
+	middleware   []echo.MiddlewareFunc
+	resolverOpts []graphapi.Option
+}
+
+// testServerOption configures the test server created by newTestServer.
+type testServerOption func(*testServerConfig)
+
+// WithAuthDisabled skips token validation, installing the synthetic testing actor on every request instead.
+func WithAuthDisabled() testServerOption {
+	return func(c *testServerConfig) {
+		c.authDisabled = true
+	}
+}
+
+// WithAuth validates tokens against the provided issuer configuration.
+func WithAuth(authConfig echojwtx.AuthConfig) testServerOption {
+	return func(c *testServerConfig) {
+		c.authConfig = &authConfig
+	}
+}
+
+// WithMiddleware adds middleware after the built-in auth and permissions middleware.
+func WithMiddleware(mdw ...echo.MiddlewareFunc) testServerOption {
+	return func(c *testServerConfig) {
+		c.middleware = append(c.middleware, mdw...)
+	}
+}
+
+// WithTestContext hands the permissions checker and relationship handler of the test context to
+// every request, after the permissions middleware. The context is read on each request, so tests
+// pass a closure over the variable they change between requests.
+func WithTestContext(ctx func() context.Context) testServerOption {
+	return WithMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqCtx, testCtx := c.Request().Context(), ctx()
+
+			for _, key := range []any{permissions.CheckerCtxKey, permissions.AuthRelationshipRequestHandlerCtxKey} {
+				if v := testCtx.Value(key); v != nil {
+					reqCtx = context.WithValue(reqCtx, key, v)
+				}
+			}
+
+			c.SetRequest(c.Request().WithContext(reqCtx))
+
+			return next(c)
+		}
+	})
+}
+
+// WithResolverOptions sets the options the resolver is created with.
+func WithResolverOptions(opts ...graphapi.Option) testServerOption {
+	return func(c *testServerConfig) {
+		c.resolverOpts = append(c.resolverOpts, opts...)
+	}
+}
+
+// WithPermissionsOptions sets additional options used to initialize the permissions middleware.
+func WithPermissionsOptions(opts ...permissions.Option) testServerOption {
+	return func(c *testServerConfig) {
+		c.permsOptions = append(c.permsOptions, opts...)
+	}
 }
 
-// localRoundTripper is an http.RoundTripper that executes HTTP transactions
-// by using handler directly, instead of going over an HTTP connection.
-type localRoundTripper struct {
-	handler http.Handler
+// testServer is a tenant-api server listening on a local port, wired the same way as the serve command.
+type testServer struct {
+	*httptest.Server
+
+	// Client is an http client which authenticates with the test issuer when auth is enabled.
+	Client *http.Client
+	// GraphClient is a graph client using Client.
+	GraphClient testclient.TestClient
 }
 
-func (l localRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
-	w := httptest.NewRecorder()
-	// set the actor to "testing-roundtrip-actor"
-	req := r.WithContext(context.WithValue(r.Context(), echojwtx.ActorCtxKey, "testing-roundtrip-actor"))
-	l.handler.ServeHTTP(w, req)
+// newTestServer starts a test server, by default with token validation enabled against a test issuer
+// so that the easy path matches production wiring.
+func newTestServer(t *testing.T, entClient *ent.Client, opts ...testServerOption) *testServer {
+	t.Helper()
+
+	ctx := context.Background()
+
+	cfg := new(testServerConfig)
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	srv := &testServer{Client: http.DefaultClient}
+
+	var middleware []echo.MiddlewareFunc
+
+	switch {
+	case cfg.authDisabled:
+		middleware = append(middleware, syntheticActorMiddleware)
+	default:
+		authConfig := cfg.authConfig
+
+		if authConfig == nil {
+			oauthClient, issuer, closer := authtest.OAuthTestClient(testActor, "")
+			t.Cleanup(closer)
+
+			srv.Client = oauthClient
+			authConfig = &echojwtx.AuthConfig{Issuer: issuer}
+		}
 
-	return w.Result(), nil
+		auth, err := echojwtx.NewAuth(ctx, *authConfig)
+		require.NoError(t, err)
+
+		middleware = append(middleware, auth.Middleware())
+	}
+
+	perms, err := permissions.New(permissions.Config{},
+		append([]permissions.Option{permissions.WithDefaultChecker(permissions.DefaultAllowChecker)}, cfg.permsOptions...)...,
+	)
+	require.NoError(t, err)
+
+	middleware = append(middleware, perms.Middleware())
+	middleware = append(middleware, cfg.middleware...)
+
+	e := echo.New()
+
+	graphapi.NewResolver(entClient, zap.NewNop().Sugar(), cfg.resolverOpts...).Handler(false, middleware).Routes(e.Group(""))
+
+	srv.Server = httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	srv.GraphClient = testclient.NewClient(srv.Client, srv.URL+"/query")
+
+	return srv
+}
+
+// testActor is the actor set on requests to the test server.
+const testActor = "testing-roundtrip-actor"
+
+// syntheticActorMiddleware sets the testing actor on the request in place of a validated token.
+func syntheticActorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, testActor)))
+		c.Set(echojwtx.ActorKey, testActor)
+
+		return next(c)
+	}
 }
@@ -0,0 +1,44 @@
+package graphapi
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// updateTenant updates the tenant in a transaction, so a move is committed along with its parent
+// history. The change events and the auth relationship requests of the move are held until the
+// transaction commits.
+func (r *Resolver) updateTenant(ctx context.Context, id gidx.PrefixedID, input generated.UpdateTenantInput) (*generated.Tenant, error) {
+	txCtx, batch := changefeed.WithBatch(ctx)
+	txCtx = changefeed.HoldRelationships(txCtx)
+
+	tx, err := r.client.Tx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	tnt, err := tx.Tenant.UpdateOneID(id).SetInput(input).Save(txCtx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			r.logger.Errorw("failed to roll back tenant update", "error", rerr)
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the update is committed, report it even though some events or relationships were lost
+		r.logger.Errorw("failed to publish tenant update", "error", err)
+	}
+
+	// edges are resolved after the transaction is done
+	return tnt.Unwrap(), nil
+}
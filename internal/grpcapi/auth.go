@@ -0,0 +1,114 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationMetadataKey is the metadata key carrying the bearer token, matching the http header.
+const authorizationMetadataKey = "authorization"
+
+// middlewareRunner runs echo middleware for grpc calls so that the jwt validation and permissions
+// checks are shared with the graph api instead of reimplemented.
+type middlewareRunner struct {
+	echo       *echo.Echo
+	middleware []echo.MiddlewareFunc
+}
+
+// UnaryInterceptor returns a grpc interceptor running the given echo middleware before each unary call.
+func UnaryInterceptor(middleware ...echo.MiddlewareFunc) grpc.UnaryServerInterceptor {
+	r := &middlewareRunner{echo: echo.New(), middleware: middleware}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := r.run(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a grpc interceptor running the given echo middleware before each streaming call.
+func StreamInterceptor(middleware ...echo.MiddlewareFunc) grpc.StreamServerInterceptor {
+	r := &middlewareRunner{echo: echo.New(), middleware: middleware}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := r.run(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// run builds a request from the incoming call metadata, runs the middleware against it and
+// returns the resulting request context, which carries the actor and permission checker.
+func (r *middlewareRunner) run(ctx context.Context, fullMethod string) (context.Context, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullMethod, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(authorizationMetadataKey) {
+			req.Header.Add(echo.HeaderAuthorization, v)
+		}
+	}
+
+	var out context.Context
+
+	h := func(c echo.Context) error {
+		out = c.Request().Context()
+
+		return nil
+	}
+
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+
+	if err := h(r.echo.NewContext(req, httptest.NewRecorder())); err != nil {
+		return nil, httpErrorToStatus(err)
+	}
+
+	return out, nil
+}
+
+func httpErrorToStatus(err error) error {
+	code := codes.Internal
+
+	var herr *echo.HTTPError
+
+	if errors.As(err, &herr) {
+		switch herr.Code {
+		case http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case http.StatusUnauthorized:
+			code = codes.Unauthenticated
+		case http.StatusForbidden:
+			code = codes.PermissionDenied
+		}
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// serverStream overrides the context of a grpc stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,158 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	tenantv1 "go.infratographer.com/tenant-api/pkg/proto/tenant/v1"
+)
+
+const (
+	// maxTemplateDepth is the number of levels of children a create may include.
+	maxTemplateDepth = 3
+
+	// maxTemplateTenants is the number of children a create may include.
+	maxTemplateTenants = 100
+)
+
+// createTenant creates the tenant and the children described by the templates in a transaction,
+// so the children limit of the parent is checked against a count which can't change before the
+// insert commits and a failing child leaves nothing behind. The transaction is run again when it
+// is aborted by a serialization failure, and the changes are published once committed. The owner
+// is only set when given, on the tenant itself. The children are returned each parent before its
+// children.
+func (s *Server) createTenant(ctx context.Context, input ent.CreateTenantInput, ownerID gidx.PrefixedID, templates []*tenantv1.TenantTemplate) (*ent.Tenant, []*ent.Tenant, error) {
+	var (
+		tnt      *ent.Tenant
+		children []*ent.Tenant
+	)
+
+	err := crdb.Retry(ctx, func(ctx context.Context) error {
+		var err error
+
+		tnt, children, err = s.createTenantTx(ctx, input, ownerID, templates)
+
+		return err
+	})
+
+	return tnt, children, err
+}
+
+// createTenantTx runs one attempt of createTenant.
+func (s *Server) createTenantTx(ctx context.Context, input ent.CreateTenantInput, ownerID gidx.PrefixedID, templates []*tenantv1.TenantTemplate) (*ent.Tenant, []*ent.Tenant, error) {
+	txCtx, batch := changefeed.WithBatch(ctx)
+
+	tx, err := s.client.Tx(txCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	create := tx.Tenant.Create().SetInput(input)
+
+	if ownerID != gidx.NullPrefixedID {
+		create.SetOwnerID(ownerID)
+	}
+
+	tnt, err := create.Save(txCtx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			s.logger.Errorw("failed to roll back tenant create", "error", rerr)
+		}
+
+		return nil, nil, err
+	}
+
+	children, err := createChildren(txCtx, tx.Client(), tnt.ID, templates, nil)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			s.logger.Errorw("failed to roll back tenant create", "error", rerr)
+		}
+
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the tenants are committed, report them even though the events were lost
+		s.logger.Errorw("failed to publish tenant create", "error", err)
+	}
+
+	for i := range children {
+		children[i] = children[i].Unwrap()
+	}
+
+	return tnt.Unwrap(), children, nil
+}
+
+// createChildren creates the tenants described by the templates under the parent, depth first,
+// appending them to created.
+func createChildren(ctx context.Context, client *ent.Client, parentID gidx.PrefixedID, templates []*tenantv1.TenantTemplate, created []*ent.Tenant) ([]*ent.Tenant, error) {
+	for _, tmpl := range templates {
+		child, err := client.Tenant.Create().
+			SetInput(ent.CreateTenantInput{
+				Name:        tmpl.GetName(),
+				Description: tmpl.Description,
+				ParentID:    &parentID,
+			}).
+			Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create child %q: %w", tmpl.GetName(), err)
+		}
+
+		created, err = createChildren(ctx, client, child.ID, tmpl.GetChildren(), append(created, child))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+// validateTemplates checks the children templates of a create before anything is written.
+// Names are compared after trimming whitespace, the same as they are stored.
+func validateTemplates(templates []*tenantv1.TenantTemplate) error {
+	count := 0
+
+	return validateLevel(templates, 1, &count)
+}
+
+func validateLevel(templates []*tenantv1.TenantTemplate, depth int, count *int) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	if depth > maxTemplateDepth {
+		return fmt.Errorf("%w: children may be nested at most %d levels", ErrInvalidTemplate, maxTemplateDepth)
+	}
+
+	names := make(map[string]struct{}, len(templates))
+
+	for _, tmpl := range templates {
+		if *count++; *count > maxTemplateTenants {
+			return fmt.Errorf("%w: at most %d children may be created", ErrInvalidTemplate, maxTemplateTenants)
+		}
+
+		name := strings.TrimSpace(tmpl.GetName())
+
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("%w: siblings must have different names, %q is used twice", ErrInvalidTemplate, name)
+		}
+
+		names[name] = struct{}{}
+
+		if err := validateLevel(tmpl.GetChildren(), depth+1, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.infratographer.com/tenant-api/internal/dependents"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+var (
+	// ErrTenantHasChildren is returned when deleting a tenant which still has children.
+	ErrTenantHasChildren = apierrors.New(apierrors.ErrConflict, "tenant has children and can't be deleted")
+
+	// ErrInvalidID is returned when an id is not a valid tenant id.
+	ErrInvalidID = apierrors.New(apierrors.ErrInvalidArgument, "invalid tenant id")
+
+	// ErrInvalidPageToken is returned when a page token can't be decoded.
+	ErrInvalidPageToken = apierrors.New(apierrors.ErrInvalidArgument, "invalid page token")
+
+	// ErrInvalidUpdateMask is returned when an update mask contains a path which can't be updated.
+	ErrInvalidUpdateMask = apierrors.New(apierrors.ErrInvalidArgument, "invalid update mask")
+
+	// ErrInvalidNameField is returned when a name filter targets an unknown field.
+	ErrInvalidNameField = apierrors.New(apierrors.ErrInvalidArgument, "invalid name field")
+
+	// ErrInvalidTemplate is returned when the children templates of a create are too deep, too
+	// large or give siblings the same name.
+	ErrInvalidTemplate = apierrors.New(apierrors.ErrInvalidArgument, "invalid children template")
+
+	// ErrWatcherTooSlow is returned to a watcher which fell too far behind the change feed.
+	ErrWatcherTooSlow = errors.New("watcher fell behind the change feed")
+)
+
+// grpcCodes are the status codes of the apierrors classes.
+var grpcCodes = map[error]codes.Code{
+	apierrors.ErrUnauthenticated:   codes.Unauthenticated,
+	apierrors.ErrPermissionDenied:  codes.PermissionDenied,
+	apierrors.ErrTenantNotFound:    codes.NotFound,
+	apierrors.ErrParentNotFound:    codes.FailedPrecondition,
+	apierrors.ErrNameConflict:      codes.AlreadyExists,
+	apierrors.ErrInvalidArgument:   codes.InvalidArgument,
+	apierrors.ErrConflict:          codes.FailedPrecondition,
+	apierrors.ErrUnavailable:       codes.Unavailable,
+	apierrors.ErrTenantFrozen:      codes.FailedPrecondition,
+	apierrors.ErrDeletionProtected: codes.FailedPrecondition,
+	apierrors.ErrCanceled:          codes.Canceled,
+}
+
+// toStatus converts errors into grpc status errors with the code of their apierrors class.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+
+	class, ok := errmap.Classify(err)
+
+	switch {
+	case ok:
+		code = grpcCodes[class.Err]
+	case errors.Is(err, ErrWatcherTooSlow):
+		code = codes.ResourceExhausted
+	case errors.Is(err, dependents.ErrCheckFailed):
+		code = codes.Unavailable
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// canceledStatus reports the internal errors of calls the caller canceled, or whose deadline
+// passed, with the status of the cancellation. The caller has gone away, the call didn't fail.
+// Calls which are still live keep their error, including timeouts the server applies itself.
+func canceledStatus(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	if code := status.Code(err); code != codes.Internal && code != codes.Unknown {
+		return err
+	}
+
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+func canceledUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+
+	return resp, canceledStatus(ctx, err)
+}
+
+func canceledStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return canceledStatus(ss.Context(), handler(srv, ss))
+}
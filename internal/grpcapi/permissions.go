@@ -0,0 +1,9 @@
+package grpcapi
+
+const (
+	actionTenantCreate = "tenant_create"
+	actionTenantUpdate = "tenant_update"
+	actionTenantDelete = "tenant_delete"
+	actionTenantList   = "tenant_list"
+	actionTenantGet    = "tenant_get"
+)
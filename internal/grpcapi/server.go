@@ -0,0 +1,66 @@
+package grpcapi
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/validation"
+	tenantv1 "go.infratographer.com/tenant-api/pkg/proto/tenant/v1"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithValidation sets the pipeline validating and normalizing the tenants created and updated,
+// the default rules by default. It should be the pipeline the ent client's hook applies, so
+// calls are rejected with every error at once rather than by the hook with the first.
+func WithValidation(p *validation.Pipeline) Option {
+	return func(s *Server) {
+		s.validator = p
+	}
+}
+
+// Server implements the grpc tenant service
+type Server struct {
+	tenantv1.UnimplementedTenantServiceServer
+
+	client    *ent.Client
+	feed      *changefeed.Feed
+	logger    *zap.SugaredLogger
+	validator *validation.Pipeline
+}
+
+// NewServer returns a tenant service backed by the given ent client. Changes published through
+// feed are streamed to watchers, when feed is nil Watch is unavailable.
+func NewServer(client *ent.Client, feed *changefeed.Feed, logger *zap.SugaredLogger, opts ...Option) *Server {
+	s := &Server{
+		client:    client,
+		feed:      feed,
+		logger:    logger,
+		validator: validation.DefaultPipeline(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// GRPCServer returns a grpc server with the tenant service registered, running the given echo
+// middleware for authentication and permissions before every call.
+func (s *Server) GRPCServer(middleware []echo.MiddlewareFunc, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(UnaryInterceptor(middleware...), canceledUnaryInterceptor),
+		grpc.ChainStreamInterceptor(StreamInterceptor(middleware...), canceledStreamInterceptor),
+	)
+
+	srv := grpc.NewServer(opts...)
+
+	tenantv1.RegisterTenantServiceServer(srv, s)
+
+	return srv
+}
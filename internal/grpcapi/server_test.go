@@ -0,0 +1,579 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	authtest "go.infratographer.com/x/testing/auth"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/grpcapi"
+	"go.infratographer.com/tenant-api/internal/history"
+	"go.infratographer.com/tenant-api/internal/reparent"
+	"go.infratographer.com/tenant-api/internal/validation"
+	tenantv1 "go.infratographer.com/tenant-api/pkg/proto/tenant/v1"
+)
+
+const testActor = "testing-grpc-actor"
+
+// newTestClient returns an ent client with event hooks publishing through a change feed.
+func newTestClient(t *testing.T) (*ent.Client, *changefeed.Feed) {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	feed := changefeed.New(conn)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(feed)),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(reparent.Hook())
+	client.Tenant.Use(history.Hook())
+	eventhooks.EventHooks(client)
+
+	return client, feed
+}
+
+// newTestServer serves the tenant service over an in-process connection.
+func newTestServer(t *testing.T, client *ent.Client, feed *changefeed.Feed, middleware ...echo.MiddlewareFunc) tenantv1.TenantServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	srv := grpcapi.NewServer(client, feed, zap.NewNop().Sugar()).GRPCServer(middleware)
+
+	go srv.Serve(lis) //nolint:errcheck // returns once stopped
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	return tenantv1.NewTenantServiceClient(conn)
+}
+
+func permissionsMiddleware(t *testing.T, checker permissions.Checker) echo.MiddlewareFunc {
+	t.Helper()
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(checker))
+	require.NoError(t, err)
+
+	return perms.Middleware()
+}
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	assert.Equal(t, code, status.Code(err), err.Error())
+}
+
+func TestTenantService(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	root, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "root", Description: proto.String("the root")})
+	require.NoError(t, err)
+	assert.Equal(t, "root", root.Tenant.Name)
+	assert.Equal(t, "the root", root.Tenant.GetDescription())
+	assert.Empty(t, root.Tenant.ParentId)
+
+	rootID := root.Tenant.Id
+
+	for _, name := range []string{"a", "b", "c"} {
+		child, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: name, ParentId: rootID})
+		require.NoError(t, err)
+		assert.Equal(t, rootID, child.Tenant.ParentId)
+	}
+
+	got, err := svc.Get(ctx, &tenantv1.GetRequest{Id: rootID})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(root.Tenant, got.Tenant))
+
+	page, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, PageSize: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Tenants, 2)
+	assert.EqualValues(t, 3, page.TotalCount)
+	require.NotEmpty(t, page.NextPageToken)
+
+	last, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, PageSize: 2, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	assert.Len(t, last.Tenants, 1)
+	assert.Empty(t, last.NextPageToken)
+
+	_, err = svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, PageToken: "not-a-token"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	t.Run("update mask", func(t *testing.T) {
+		updated, err := svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant:     &tenantv1.Tenant{Id: rootID, Name: "renamed", Description: proto.String("ignored")},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updated.Tenant.Name)
+		assert.Equal(t, "the root", updated.Tenant.GetDescription())
+
+		updated, err = svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant:     &tenantv1.Tenant{Id: rootID},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updated.Tenant.Name)
+		assert.Nil(t, updated.Tenant.Description)
+
+		updated, err = svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant: &tenantv1.Tenant{Id: rootID, Description: proto.String("populated fields")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updated.Tenant.Name)
+		assert.Equal(t, "populated fields", updated.Tenant.GetDescription())
+
+		_, err = svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant:     &tenantv1.Tenant{Id: rootID, ParentId: page.Tenants[0].Id},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+		})
+		requireCode(t, codes.FailedPrecondition, err)
+
+		_, err = svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant:     &tenantv1.Tenant{Id: page.Tenants[0].Id},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"parent_id"}},
+		})
+		requireCode(t, codes.InvalidArgument, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := svc.Get(ctx, &tenantv1.GetRequest{Id: "tnntten-missing"})
+		requireCode(t, codes.NotFound, err)
+
+		_, err = svc.Get(ctx, &tenantv1.GetRequest{Id: "invalid"})
+		requireCode(t, codes.InvalidArgument, err)
+
+		_, err = svc.Get(ctx, &tenantv1.GetRequest{Id: "loadbal-abc"})
+		requireCode(t, codes.InvalidArgument, err)
+
+		_, err = svc.Delete(ctx, &tenantv1.DeleteRequest{Id: rootID})
+		requireCode(t, codes.FailedPrecondition, err)
+	})
+
+	deleted, err := svc.Delete(ctx, &tenantv1.DeleteRequest{Id: last.Tenants[0].Id})
+	require.NoError(t, err)
+	assert.Equal(t, last.Tenants[0].Id, deleted.DeletedId)
+
+	_, err = svc.Get(ctx, &tenantv1.GetRequest{Id: deleted.DeletedId})
+	requireCode(t, codes.NotFound, err)
+}
+
+func TestTenantServiceNameFilter(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+	client.Tenant.Use(validation.NewNameValidator().Hook())
+
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	root, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "root"})
+	require.NoError(t, err)
+	assert.Equal(t, "root", root.Tenant.DisplayName, "the display name defaults to the name")
+
+	rootID := root.Tenant.Id
+
+	for name, displayName := range map[string]string{"acme-prod": "Acme Production", "acme-dev": "Development", "globex": "Globex Acme"} {
+		_, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: name, DisplayName: proto.String(displayName), ParentId: rootID})
+		require.NoError(t, err)
+	}
+
+	names := func(resp *tenantv1.ListResponse) []string {
+		var names []string
+
+		for _, tnt := range resp.Tenants {
+			names = append(names, tnt.Name)
+		}
+
+		return names
+	}
+
+	byName, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, NameContains: "ACME"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"acme-prod", "acme-dev"}, names(byName))
+	assert.EqualValues(t, 2, byName.TotalCount)
+
+	byDisplayName, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, NameContains: "acme", NameField: tenantv1.NameField_NAME_FIELD_DISPLAY_NAME})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"acme-prod", "globex"}, names(byDisplayName))
+
+	_, err = svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, NameContains: "acme", NameField: 42})
+	requireCode(t, codes.InvalidArgument, err)
+
+	updated, err := svc.Update(ctx, &tenantv1.UpdateRequest{
+		Tenant:     &tenantv1.Tenant{Id: rootID, DisplayName: "The Root"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "The Root", updated.Tenant.DisplayName)
+	assert.Equal(t, "root", updated.Tenant.Name)
+
+	updated, err = svc.Update(ctx, &tenantv1.UpdateRequest{
+		Tenant:     &tenantv1.Tenant{Id: rootID},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "root", updated.Tenant.DisplayName, "clearing resets the display name to the name")
+}
+
+func TestTenantServiceBillingReference(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+	client.Tenant.Use(validation.NewMetadataValidator().Hook())
+
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	root, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "root"})
+	require.NoError(t, err)
+
+	rootID := root.Tenant.Id
+
+	billed, err := svc.Create(ctx, &tenantv1.CreateRequest{
+		Name:             "billed",
+		ParentId:         rootID,
+		ContactEmail:     proto.String(" ops@example.com "),
+		BillingReference: proto.String("ACCT-42"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ops@example.com", billed.Tenant.GetContactEmail())
+	assert.Equal(t, "ACCT-42", billed.Tenant.GetBillingReference())
+
+	for _, reference := range []string{"ACCT-4", "acct-42"} {
+		_, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: reference, ParentId: rootID, BillingReference: proto.String(reference)})
+		require.NoError(t, err)
+	}
+
+	_, err = svc.Create(ctx, &tenantv1.CreateRequest{Name: "invalid", ParentId: rootID, ContactEmail: proto.String("ops at example.com")})
+	requireCode(t, codes.InvalidArgument, err)
+
+	list, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: rootID, BillingReference: "ACCT-42"})
+	require.NoError(t, err)
+	require.Len(t, list.Tenants, 1, "the billing reference matches exactly")
+	assert.Equal(t, billed.Tenant.Id, list.Tenants[0].Id)
+
+	updated, err := svc.Update(ctx, &tenantv1.UpdateRequest{
+		Tenant:     &tenantv1.Tenant{Id: billed.Tenant.Id},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contact_email"}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, updated.Tenant.ContactEmail)
+	assert.Equal(t, "ACCT-42", updated.Tenant.GetBillingReference())
+}
+
+func TestTenantServiceArchived(t *testing.T) {
+	perms, err := permissions.New(permissions.Config{})
+	require.NoError(t, err)
+
+	// the event hooks create the parent relationships through the permissions handler
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	client, feed := newTestClient(t)
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	active := client.Tenant.Create().SetName("active").SetParent(root).SaveX(ctx)
+	archived := client.Tenant.Create().SetName("archived").SetParent(root).SetArchived(true).SaveX(ctx)
+
+	list, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: root.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, list.Tenants, 1, "archived children are left out by default")
+	assert.Equal(t, active.ID.String(), list.Tenants[0].Id)
+
+	all, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: root.ID.String(), IncludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, all.Tenants, 2)
+	assert.ElementsMatch(t, []string{active.ID.String(), archived.ID.String()}, []string{all.Tenants[0].Id, all.Tenants[1].Id})
+}
+
+func TestTenantServiceMove(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	var ids []string
+
+	for _, name := range []string{"first", "second", "moved"} {
+		created, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: name})
+		require.NoError(t, err)
+
+		ids = append(ids, created.Tenant.Id)
+	}
+
+	moved := ids[2]
+
+	for _, parentID := range ids[:2] {
+		updated, err := svc.Update(ctx, &tenantv1.UpdateRequest{
+			Tenant: &tenantv1.Tenant{Id: moved, ParentId: parentID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, parentID, updated.Tenant.ParentId)
+	}
+
+	history := client.TenantParentHistory.Query().
+		Where(tenantparenthistory.TenantID(gidx.PrefixedID(moved))).
+		Order(ent.Asc(tenantparenthistory.FieldChangedAt), ent.Asc(tenantparenthistory.FieldID)).
+		AllX(ctx)
+	require.Len(t, history, 2)
+	assert.Empty(t, history[0].PreviousParentID)
+	assert.EqualValues(t, ids[0], history[0].NewParentID)
+	assert.EqualValues(t, ids[0], history[1].PreviousParentID)
+	assert.EqualValues(t, ids[1], history[1].NewParentID)
+}
+
+func TestTenantServiceOwner(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	owned, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "owned", OwnerId: "rsrcprv-owner"})
+	require.NoError(t, err)
+	assert.Equal(t, "rsrcprv-owner", owned.Tenant.GetOwnerId())
+	assert.Nil(t, owned.Tenant.SuspendedTime)
+
+	unowned, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "unowned"})
+	require.NoError(t, err)
+	assert.Empty(t, unowned.Tenant.GetOwnerId())
+
+	_, err = svc.Create(ctx, &tenantv1.CreateRequest{Name: "invalid", OwnerId: "owner"})
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestTenantServiceCreateChildren(t *testing.T) {
+	ctx := context.Background()
+
+	client, feed := newTestClient(t)
+
+	// the second level template exceeds the limit of two children
+	client.Tenant.Use(validation.NewChildrenLimit(2).Hook())
+
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	before := feed.LastID()
+
+	created, err := svc.Create(ctx, &tenantv1.CreateRequest{
+		Name: "organization",
+		Children: []*tenantv1.TenantTemplate{
+			{Name: "infra", Children: []*tenantv1.TenantTemplate{{Name: "network"}, {Name: "storage"}}},
+			{Name: "staging", Description: proto.String("pre-production")},
+		},
+	})
+	require.NoError(t, err)
+
+	var (
+		order []string
+		names = map[string]*tenantv1.Tenant{}
+	)
+
+	for _, child := range created.Children {
+		order = append(order, child.GetName())
+		names[child.GetName()] = child
+	}
+
+	assert.Equal(t, []string{"infra", "network", "storage", "staging"}, order, "parents come before their children")
+	assert.Equal(t, created.Tenant.GetId(), names["infra"].GetParentId())
+	assert.Equal(t, names["infra"].GetId(), names["network"].GetParentId())
+	assert.Equal(t, names["infra"].GetId(), names["storage"].GetParentId())
+	assert.Equal(t, "pre-production", names["staging"].GetDescription())
+	assert.Equal(t, uint64(5), feed.LastID()-before, "every created tenant is published")
+
+	// a failing child rolls back every tenant of the create
+	before = feed.LastID()
+
+	_, err = svc.Create(ctx, &tenantv1.CreateRequest{
+		Name: "failing",
+		Children: []*tenantv1.TenantTemplate{
+			{Name: "infra"},
+			{Name: "prod", Children: []*tenantv1.TenantTemplate{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+		},
+	})
+	requireCode(t, codes.InvalidArgument, err)
+	assert.Equal(t, 5, client.Tenant.Query().CountX(ctx))
+	assert.Equal(t, before, feed.LastID(), "nothing is published for a rolled back create")
+
+	invalid := []struct {
+		name     string
+		children []*tenantv1.TenantTemplate
+	}{
+		{"duplicate siblings", []*tenantv1.TenantTemplate{{Name: "prod"}, {Name: " prod "}}},
+		{"too deep", []*tenantv1.TenantTemplate{{Name: "a", Children: []*tenantv1.TenantTemplate{{Name: "b", Children: []*tenantv1.TenantTemplate{{Name: "c", Children: []*tenantv1.TenantTemplate{{Name: "d"}}}}}}}}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "invalid", Children: tt.children})
+			requireCode(t, codes.InvalidArgument, err)
+		})
+	}
+
+	assert.Equal(t, 5, client.Tenant.Query().CountX(ctx))
+}
+
+func TestTenantServiceEffectiveStatus(t *testing.T) {
+	perms, err := permissions.New(permissions.Config{})
+	require.NoError(t, err)
+
+	// the event hooks create the parent relationships through the permissions handler
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	client, feed := newTestClient(t)
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	grandparent := client.Tenant.Create().SetName("grandparent").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(grandparent).SaveX(ctx)
+
+	for _, name := range []string{"a", "b"} {
+		client.Tenant.Create().SetName(name).SetParent(parent).SaveX(ctx)
+	}
+
+	client.Tenant.UpdateOne(grandparent).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(ctx)
+
+	list, err := svc.List(ctx, &tenantv1.ListRequest{ParentId: parent.ID.String(), IncludeEffectiveStatus: true})
+	require.NoError(t, err)
+	require.Len(t, list.Tenants, 2)
+
+	for _, tnt := range list.Tenants {
+		assert.Equal(t, "parent_deleted", tnt.EffectiveStatus)
+	}
+
+	got, err := svc.Get(ctx, &tenantv1.GetRequest{Id: grandparent.ID.String(), IncludeEffectiveStatus: true})
+	require.NoError(t, err)
+	assert.Equal(t, "pending_deletion", got.Tenant.EffectiveStatus)
+
+	got, err = svc.Get(ctx, &tenantv1.GetRequest{Id: parent.ID.String()})
+	require.NoError(t, err)
+	assert.Empty(t, got.Tenant.EffectiveStatus, "the effective status is only computed when requested")
+}
+
+func TestTenantServiceAuth(t *testing.T) {
+	ctx := context.Background()
+
+	oauthClient, issuer, closer := authtest.OAuthTestClient(testActor, "")
+	t.Cleanup(closer)
+
+	token, err := oauthClient.Transport.(*oauth2.Transport).Source.Token()
+	require.NoError(t, err)
+
+	auth, err := echojwtx.NewAuth(ctx, echojwtx.AuthConfig{Issuer: issuer})
+	require.NoError(t, err)
+
+	client, feed := newTestClient(t)
+
+	svc := newTestServer(t, client, feed, auth.Middleware(), permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	_, err = svc.Create(ctx, &tenantv1.CreateRequest{Name: "anonymous"})
+	requireCode(t, codes.Unauthenticated, err)
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token.AccessToken)
+
+	_, err = svc.Create(authCtx, &tenantv1.CreateRequest{Name: "authenticated"})
+	require.NoError(t, err)
+
+	denied := newTestServer(t, client, feed, auth.Middleware(), permissionsMiddleware(t, permissions.DefaultDenyChecker))
+
+	_, err = denied.Create(authCtx, &tenantv1.CreateRequest{Name: "denied"})
+	requireCode(t, codes.PermissionDenied, err)
+}
+
+func TestTenantServiceWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, feed := newTestClient(t)
+	svc := newTestServer(t, client, feed, permissionsMiddleware(t, permissions.DefaultAllowChecker))
+
+	root, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "root"})
+	require.NoError(t, err)
+
+	other, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "other"})
+	require.NoError(t, err)
+
+	stream, err := svc.Watch(ctx, &tenantv1.WatchRequest{TenantId: root.Tenant.Id})
+	require.NoError(t, err)
+
+	// headers are sent once the watcher is subscribed
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	_, err = svc.Update(ctx, &tenantv1.UpdateRequest{Tenant: &tenantv1.Tenant{Id: other.Tenant.Id, Name: "unrelated"}})
+	require.NoError(t, err)
+
+	child, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "child", ParentId: root.Tenant.Id})
+	require.NoError(t, err)
+
+	_, err = svc.Update(ctx, &tenantv1.UpdateRequest{Tenant: &tenantv1.Tenant{Id: root.Tenant.Id, Name: "renamed"}})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "create", resp.Change.EventType)
+	assert.Equal(t, child.Tenant.Id, resp.Change.TenantId)
+	assert.Contains(t, resp.Change.AdditionalSubjectIds, root.Tenant.Id)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "update", resp.Change.EventType)
+	assert.Equal(t, root.Tenant.Id, resp.Change.TenantId)
+	assert.Contains(t, resp.Change.FieldChanges, &tenantv1.FieldChange{Field: "name", PreviousValue: "root", CurrentValue: "renamed"})
+
+	// the whole subtree is watched, not only the children
+	grandchild, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: "grandchild", ParentId: child.Tenant.Id})
+	require.NoError(t, err)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "create", resp.Change.EventType)
+	assert.Equal(t, grandchild.Tenant.Id, resp.Change.TenantId)
+
+	_, err = svc.Delete(ctx, &tenantv1.DeleteRequest{Id: grandchild.Tenant.Id})
+	require.NoError(t, err)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "delete", resp.Change.EventType)
+	assert.Equal(t, grandchild.Tenant.Id, resp.Change.TenantId)
+}
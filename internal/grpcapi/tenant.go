@@ -0,0 +1,536 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/internal/validation"
+	tenantv1 "go.infratographer.com/tenant-api/pkg/proto/tenant/v1"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Create creates a new tenant.
+func (s *Server) Create(ctx context.Context, req *tenantv1.CreateRequest) (*tenantv1.CreateResponse, error) {
+	input := ent.CreateTenantInput{
+		Name:             req.GetName(),
+		DisplayName:      req.DisplayName,
+		Description:      req.Description,
+		ContactEmail:     req.ContactEmail,
+		BillingReference: req.BillingReference,
+	}
+
+	resource := gidx.NullPrefixedID
+
+	if req.GetParentId() != "" {
+		parentID, err := parseTenantID(req.GetParentId())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		input.ParentID = &parentID
+		resource = parentID
+	}
+
+	ownerID := gidx.NullPrefixedID
+
+	if req.GetOwnerId() != "" {
+		id, err := gidx.Parse(req.GetOwnerId())
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("%w: %s", ErrInvalidID, err))
+		}
+
+		ownerID = id
+	}
+
+	if err := s.validator.ValidateNewTenant(ctx, &validation.Tenant{
+		Name:             &input.Name,
+		DisplayName:      input.DisplayName,
+		Description:      input.Description,
+		ContactEmail:     input.ContactEmail,
+		BillingReference: input.BillingReference,
+	}); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := validateTemplates(req.GetChildren()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, resource, actionTenantCreate); err != nil {
+		return nil, toStatus(err)
+	}
+
+	tnt, children, err := s.createTenant(ctx, input, ownerID, req.GetChildren())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	fields := redact.FromContext(ctx)
+
+	resp := &tenantv1.CreateResponse{
+		Tenant:   toProto(tnt, fields),
+		Children: make([]*tenantv1.Tenant, 0, len(children)),
+	}
+
+	for _, child := range children {
+		resp.Children = append(resp.Children, toProto(child, fields))
+	}
+
+	return resp, nil
+}
+
+// Get returns a single tenant.
+func (s *Server) Get(ctx context.Context, req *tenantv1.GetRequest) (*tenantv1.GetResponse, error) {
+	id, err := parseTenantID(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return nil, toStatus(err)
+	}
+
+	tnt, err := s.client.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pb := toProto(tnt, redact.FromContext(ctx))
+
+	if req.GetIncludeEffectiveStatus() {
+		if pb.EffectiveStatus, err = deletion.EffectiveStatus(ctx, s.client, tnt); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	return &tenantv1.GetResponse{Tenant: pb}, nil
+}
+
+// List returns a page of the children of a tenant. Archived children are left out unless
+// include_archived is set.
+func (s *Server) List(ctx context.Context, req *tenantv1.ListRequest) (*tenantv1.ListResponse, error) {
+	parentID, err := parseTenantID(req.GetParentId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, parentID, actionTenantList); err != nil {
+		return nil, toStatus(err)
+	}
+
+	pageSize := int(req.GetPageSize())
+
+	switch {
+	case pageSize <= 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	query := s.client.Tenant.Query().Where(tenant.ParentTenantID(parentID))
+
+	if contains := req.GetNameContains(); contains != "" {
+		filter, err := nameFilter(req.GetNameField(), contains)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		query = query.Where(filter)
+	}
+
+	if reference := req.GetBillingReference(); reference != "" {
+		query = query.Where(tenant.BillingReference(reference))
+	}
+
+	if !req.GetIncludeArchived() {
+		query = query.Where(tenant.Or(tenant.ArchivedIsNil(), tenant.Archived(false)))
+	}
+
+	var after *ent.Cursor
+
+	if token := req.GetPageToken(); token != "" {
+		after = new(ent.Cursor)
+
+		if err := after.UnmarshalGQL(token); err != nil {
+			return nil, toStatus(fmt.Errorf("%w: %s", ErrInvalidPageToken, err))
+		}
+	}
+
+	conn, err := query.Paginate(ctx, after, &pageSize, nil, nil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &tenantv1.ListResponse{
+		Tenants:    make([]*tenantv1.Tenant, 0, len(conn.Edges)),
+		TotalCount: int32(conn.TotalCount),
+	}
+
+	var statuses map[gidx.PrefixedID]string
+
+	if req.GetIncludeEffectiveStatus() {
+		nodes := make([]*ent.Tenant, 0, len(conn.Edges))
+
+		for _, edge := range conn.Edges {
+			nodes = append(nodes, edge.Node)
+		}
+
+		if statuses, err = deletion.EffectiveStatuses(ctx, s.client, nodes); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	for _, edge := range conn.Edges {
+		pb := toProto(edge.Node, redact.FromContext(ctx))
+		pb.EffectiveStatus = statuses[edge.Node.ID]
+
+		resp.Tenants = append(resp.Tenants, pb)
+	}
+
+	if conn.PageInfo.HasNextPage && conn.PageInfo.EndCursor != nil {
+		resp.NextPageToken = encodeCursor(*conn.PageInfo.EndCursor)
+	}
+
+	return resp, nil
+}
+
+// Update updates the fields of a tenant selected by the update mask. Updating the parent moves the
+// tenant under it, which the caller must be allowed to create tenants under.
+func (s *Server) Update(ctx context.Context, req *tenantv1.UpdateRequest) (*tenantv1.UpdateResponse, error) {
+	id, err := parseTenantID(req.GetTenant().GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	input, err := updateInput(req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := s.validator.ValidateUpdate(ctx, &validation.Tenant{
+		Name:             input.Name,
+		DisplayName:      input.DisplayName,
+		Description:      input.Description,
+		ContactEmail:     input.ContactEmail,
+		BillingReference: input.BillingReference,
+	}); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if input.ParentID != nil {
+		if err := permissions.CheckAccess(ctx, *input.ParentID, actionTenantCreate); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	tnt, err := s.updateTenant(ctx, id, input)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &tenantv1.UpdateResponse{Tenant: toProto(tnt, redact.FromContext(ctx))}, nil
+}
+
+// Delete deletes a tenant which has no children.
+func (s *Server) Delete(ctx context.Context, req *tenantv1.DeleteRequest) (*tenantv1.DeleteResponse, error) {
+	id, err := parseTenantID(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantDelete); err != nil {
+		return nil, toStatus(err)
+	}
+
+	childrenCount, err := s.client.Tenant.Query().Where(tenant.ParentTenantID(id)).Count(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if childrenCount != 0 {
+		return nil, toStatus(ErrTenantHasChildren)
+	}
+
+	if err := s.client.Tenant.DeleteOneID(id).Exec(ctx); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &tenantv1.DeleteResponse{DeletedId: id.String()}, nil
+}
+
+// Watch streams changes to a tenant and its descendants until the client disconnects, the same
+// subtree the event stream of the tenant covers. Response headers are sent once the watcher is
+// subscribed, changes made after that are delivered.
+func (s *Server) Watch(req *tenantv1.WatchRequest, stream tenantv1.TenantService_WatchServer) error {
+	ctx := stream.Context()
+
+	if s.feed == nil {
+		return status.Error(codes.Unavailable, "change feed is not configured")
+	}
+
+	id, err := parseTenantID(req.GetTenantId())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return toStatus(err)
+	}
+
+	scope := subtree.NewScope(s.client, id)
+
+	changes, unsubscribe := s.feed.Subscribe()
+	defer unsubscribe()
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				s.logger.Warnw("dropping slow tenant watcher", "tenant_id", id)
+
+				return toStatus(ErrWatcherTooSlow)
+			}
+
+			inScope, err := scope.Contains(ctx, change.Message)
+			if err != nil {
+				s.logger.Errorw("failed to determine tenant watch scope", "tenant_id", id, "subject_id", change.Message.SubjectID, "error", err)
+
+				continue
+			}
+
+			if !inScope {
+				continue
+			}
+
+			if err := stream.Send(&tenantv1.WatchResponse{Change: changeToProto(change.Message, redact.FromContext(ctx))}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func parseTenantID(s string) (gidx.PrefixedID, error) {
+	id, err := gidx.Parse(s)
+	if err != nil {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: %s", ErrInvalidID, err)
+	}
+
+	if id.Prefix() != schema.TenantPrefix {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: expected prefix %s, got %s", ErrInvalidID, schema.TenantPrefix, id.Prefix())
+	}
+
+	return id, nil
+}
+
+// updateInput maps the update mask onto the partial update input. Without a mask every populated
+// field is updated, masked optional fields which aren't set are cleared.
+func updateInput(req *tenantv1.UpdateRequest) (ent.UpdateTenantInput, error) {
+	var input ent.UpdateTenantInput
+
+	tnt := req.GetTenant()
+	paths := req.GetUpdateMask().GetPaths()
+
+	if len(paths) == 0 {
+		if tnt.GetName() != "" {
+			paths = append(paths, "name")
+		}
+
+		if tnt.GetDisplayName() != "" {
+			paths = append(paths, "display_name")
+		}
+
+		if tnt.Description != nil {
+			paths = append(paths, "description")
+		}
+
+		if tnt.ContactEmail != nil {
+			paths = append(paths, "contact_email")
+		}
+
+		if tnt.BillingReference != nil {
+			paths = append(paths, "billing_reference")
+		}
+
+		if tnt.GetParentId() != "" {
+			paths = append(paths, "parent_id")
+		}
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			name := tnt.GetName()
+			input.Name = &name
+		case "display_name":
+			if displayName := tnt.GetDisplayName(); displayName == "" {
+				input.ClearDisplayName = true
+			} else {
+				input.DisplayName = &displayName
+			}
+		case "description":
+			if tnt.Description == nil {
+				input.ClearDescription = true
+			} else {
+				input.Description = tnt.Description
+			}
+		case "contact_email":
+			if tnt.ContactEmail == nil {
+				input.ClearContactEmail = true
+			} else {
+				input.ContactEmail = tnt.ContactEmail
+			}
+		case "billing_reference":
+			if tnt.BillingReference == nil {
+				input.ClearBillingReference = true
+			} else {
+				input.BillingReference = tnt.BillingReference
+			}
+		case "parent_id":
+			if tnt.GetParentId() == "" {
+				return input, fmt.Errorf("%w: tenants can't be made roots", ErrInvalidUpdateMask)
+			}
+
+			parentID, err := parseTenantID(tnt.GetParentId())
+			if err != nil {
+				return input, err
+			}
+
+			input.ParentID = &parentID
+		default:
+			return input, fmt.Errorf("%w: field %q can't be updated", ErrInvalidUpdateMask, path)
+		}
+	}
+
+	return input, nil
+}
+
+// nameFilter returns the predicate matching tenants whose selected name contains the value.
+func nameFilter(field tenantv1.NameField, contains string) (predicate.Tenant, error) {
+	switch field {
+	case tenantv1.NameField_NAME_FIELD_UNSPECIFIED, tenantv1.NameField_NAME_FIELD_NAME:
+		return tenant.NameContainsFold(contains), nil
+	case tenantv1.NameField_NAME_FIELD_DISPLAY_NAME:
+		return tenant.DisplayNameContainsFold(contains), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidNameField, field)
+	}
+}
+
+func encodeCursor(c ent.Cursor) string {
+	var sb strings.Builder
+
+	c.MarshalGQL(&sb)
+
+	return strings.Trim(sb.String(), `"`)
+}
+
+// toProto converts the tenant, leaving redacted fields unset.
+func toProto(t *ent.Tenant, fields redact.Fields) *tenantv1.Tenant {
+	pb := &tenantv1.Tenant{Id: t.ID.String()}
+
+	if fields.Visible(redact.FieldName) {
+		pb.Name = t.Name
+	}
+
+	if fields.Visible(redact.FieldDisplayName) {
+		pb.DisplayName = t.DisplayName
+	}
+
+	if fields.Visible(redact.FieldDescription) && t.Description != "" {
+		pb.Description = &t.Description
+	}
+
+	if fields.Visible(redact.FieldParent) && t.ParentTenantID != gidx.NullPrefixedID {
+		pb.ParentId = t.ParentTenantID.String()
+	}
+
+	if fields.Visible(redact.FieldCreatedAt) {
+		pb.CreateTime = timestamppb.New(t.CreatedAt)
+	}
+
+	if fields.Visible(redact.FieldUpdatedAt) {
+		pb.UpdateTime = timestamppb.New(t.UpdatedAt)
+	}
+
+	if fields.Visible(redact.FieldDeletionScheduledAt) && !t.DeletionScheduledAt.IsZero() {
+		pb.DeletionScheduledTime = timestamppb.New(t.DeletionScheduledAt)
+	}
+
+	if fields.Visible(redact.FieldContactEmail) && t.ContactEmail != "" {
+		pb.ContactEmail = &t.ContactEmail
+	}
+
+	if fields.Visible(redact.FieldBillingReference) && t.BillingReference != "" {
+		pb.BillingReference = &t.BillingReference
+	}
+
+	if fields.Visible(redact.FieldOwner) && t.OwnerID != gidx.NullPrefixedID {
+		pb.OwnerId = t.OwnerID.String()
+	}
+
+	if fields.Visible(redact.FieldSuspendedAt) && !t.SuspendedAt.IsZero() {
+		pb.SuspendedTime = timestamppb.New(t.SuspendedAt)
+	}
+
+	return pb
+}
+
+// changeToProto converts the change, leaving out redacted field changes and the parent when it is redacted.
+func changeToProto(msg events.ChangeMessage, fields redact.Fields) *tenantv1.TenantChange {
+	change := &tenantv1.TenantChange{
+		EventType: msg.EventType,
+		TenantId:  msg.SubjectID.String(),
+		Timestamp: timestamppb.New(msg.Timestamp),
+	}
+
+	if fields.Visible(redact.FieldParent) {
+		for _, subject := range msg.AdditionalSubjectIDs {
+			change.AdditionalSubjectIds = append(change.AdditionalSubjectIds, subject.String())
+		}
+	}
+
+	for _, fc := range msg.FieldChanges {
+		if !fields.VisibleEventField(fc.Field) {
+			continue
+		}
+
+		change.FieldChanges = append(change.FieldChanges, &tenantv1.FieldChange{
+			Field:         fc.Field,
+			PreviousValue: fc.PreviousValue,
+			CurrentValue:  fc.CurrentValue,
+		})
+	}
+
+	return change
+}
@@ -0,0 +1,43 @@
+package grpcapi
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// updateTenant updates the tenant in a transaction, so a move is committed along with its parent
+// history. The change events and the auth relationship requests of the move are held until the
+// transaction commits.
+func (s *Server) updateTenant(ctx context.Context, id gidx.PrefixedID, input ent.UpdateTenantInput) (*ent.Tenant, error) {
+	txCtx, batch := changefeed.WithBatch(ctx)
+	txCtx = changefeed.HoldRelationships(txCtx)
+
+	tx, err := s.client.Tx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	tnt, err := tx.Tenant.UpdateOneID(id).SetInput(input).Save(txCtx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			s.logger.Errorw("failed to roll back tenant update", "error", rerr)
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the update is committed, report it even though some events or relationships were lost
+		s.logger.Errorw("failed to publish tenant update", "error", err)
+	}
+
+	return tnt.Unwrap(), nil
+}
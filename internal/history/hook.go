@@ -0,0 +1,91 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// Hook returns an ent hook recording a parent history entry for every tenant whose parent is
+// changed. Entries are written with the client of the mutation, so they are part of the same
+// transaction as the change itself.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				newParentID, set := m.ParentTenantID()
+				if !set && !m.ParentTenantIDCleared() {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				previous, err := m.Client().Tenant.Query().
+					Where(enttenant.IDIn(ids...)).
+					Select(enttenant.FieldID, enttenant.FieldParentTenantID).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := next.Mutate(ctx, m)
+				if err != nil {
+					return value, err
+				}
+
+				if err := record(ctx, m.Client(), previous, newParentID); err != nil {
+					return nil, err
+				}
+
+				return value, nil
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// record writes an entry for each of the tenants whose parent differs from the new one.
+func record(ctx context.Context, client *generated.Client, previous []*generated.Tenant, newParentID gidx.PrefixedID) error {
+	actor, _ := ctx.Value(echojwtx.ActorCtxKey).(string)
+	changedAt := time.Now().UTC()
+
+	builders := make([]*generated.TenantParentHistoryCreate, 0, len(previous))
+
+	for _, t := range previous {
+		if t.ParentTenantID == newParentID {
+			continue
+		}
+
+		create := client.TenantParentHistory.Create().
+			SetTenantID(t.ID).
+			SetActor(actor).
+			SetChangedAt(changedAt)
+
+		// root tenants have no parent, leave the column null rather than storing an empty id
+		if t.ParentTenantID != gidx.NullPrefixedID {
+			create.SetPreviousParentID(t.ParentTenantID)
+		}
+
+		if newParentID != gidx.NullPrefixedID {
+			create.SetNewParentID(newParentID)
+		}
+
+		builders = append(builders, create)
+	}
+
+	if len(builders) == 0 {
+		return nil
+	}
+
+	return client.TenantParentHistory.CreateBulk(builders...).Exec(ctx)
+}
@@ -0,0 +1,204 @@
+package hookchain
+
+import (
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/archive"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/dependents"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/history"
+	"go.infratographer.com/tenant-api/internal/labels"
+	"go.infratographer.com/tenant-api/internal/migration"
+	"go.infratographer.com/tenant-api/internal/protection"
+	"go.infratographer.com/tenant-api/internal/reparent"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/internal/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/snapshot"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// Option configures the hooks registered by Register.
+type Option func(*chain)
+
+// WithActorGuard sets the options of the guard rejecting changes without an actor.
+func WithActorGuard(opts ...actor.Option) Option {
+	return func(c *chain) {
+		c.actor = append(c.actor, opts...)
+	}
+}
+
+// WithAncestors sets the resolver walking the ancestors of tenants, shared with the apis so the
+// tenants moved are forgotten. By default the chain gets its own.
+func WithAncestors(r *subtree.Resolver) Option {
+	return func(c *chain) {
+		c.ancestors = r
+	}
+}
+
+// WithAdminScope sets the scope allowed to delete protected tenants.
+func WithAdminScope(scope string) Option {
+	return func(c *chain) {
+		c.adminScope = scope
+	}
+}
+
+// WithRenameScope requires the scope to rename tenants, anyone may rename them when empty.
+func WithRenameScope(scope string) Option {
+	return func(c *chain) {
+		c.renameScope = scope
+	}
+}
+
+// WithPipeline sets the pipeline validating and normalizing tenant fields, by default the
+// validators with their defaults.
+func WithPipeline(p *validation.Pipeline) Option {
+	return func(c *chain) {
+		c.pipeline = p
+	}
+}
+
+// WithNameReusePolicy rejects renames and moves giving a tenant the name of a sibling, or of a
+// deleted one as kept by the policy. Renames aren't checked without a policy.
+func WithNameReusePolicy(policy validation.NameReusePolicy) Option {
+	return func(c *chain) {
+		c.nameReuse = policy
+	}
+}
+
+// WithMaxChildren sets the number of direct children a tenant may have, zero is unlimited.
+func WithMaxChildren(max int) Option {
+	return func(c *chain) {
+		c.maxChildren = max
+	}
+}
+
+// WithMaxDepth sets the depth of the hierarchy, zero is unlimited.
+func WithMaxDepth(max int) Option {
+	return func(c *chain) {
+		c.maxDepth = max
+	}
+}
+
+// WithDeletion sets the options of the hook deleting the subtrees of tenants.
+func WithDeletion(opts ...deletion.HookOption) Option {
+	return func(c *chain) {
+		c.deletion = append(c.deletion, opts...)
+	}
+}
+
+// WithDependents rejects deleting tenants which other services still have resources in.
+func WithDependents(check *dependents.Check) Option {
+	return func(c *chain) {
+		c.dependents = check
+	}
+}
+
+// WithEvents registers the event hooks publishing the changes, with the client's publisher.
+func WithEvents(enabled bool) Option {
+	return func(c *chain) {
+		c.events = enabled
+	}
+}
+
+// WithSnapshots adds the snapshot of the tenant to the events published.
+func WithSnapshots(enabled bool) Option {
+	return func(c *chain) {
+		c.snapshots = enabled
+	}
+}
+
+// WithEffectiveLabels adds the labels tenants inherit to the events published.
+func WithEffectiveLabels(enabled bool) Option {
+	return func(c *chain) {
+		c.effectiveLabels = enabled
+	}
+}
+
+type chain struct {
+	actor           []actor.Option
+	ancestors       *subtree.Resolver
+	adminScope      string
+	renameScope     string
+	pipeline        *validation.Pipeline
+	nameReuse       validation.NameReusePolicy
+	maxChildren     int
+	maxDepth        int
+	deletion        []deletion.HookOption
+	dependents      *dependents.Check
+	events          bool
+	snapshots       bool
+	effectiveLabels bool
+}
+
+// Register registers the tenant hooks on the client, after those it has already.
+func Register(client *ent.Client, opts ...Option) {
+	c := &chain{}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.ancestors == nil {
+		c.ancestors = subtree.NewResolver()
+	}
+
+	if c.pipeline == nil {
+		c.pipeline = validation.DefaultPipeline()
+	}
+
+	// the actor is checked first, so the hooks recording it see the system actor of internal changes
+	client.Tenant.Use(actor.NewGuard(c.actor...).Hook())
+	client.Tenant.Use(serviceaccount.ScopeHook())
+
+	client.Tenant.Use(freeze.Hook())
+	client.Tenant.Use(freeze.NewCreationGuard(c.ancestors).Hook())
+	client.Tenant.Use(c.ancestors.Hook())
+	client.Tenant.Use(protection.Hook(c.adminScope))
+
+	if c.renameScope != "" {
+		client.Tenant.Use(scopes.RenameHook(c.renameScope))
+	}
+
+	// fields are normalized before the event hooks so events carry the stored values
+	client.Tenant.Use(c.pipeline.Hook())
+
+	client.Tenant.Use(validation.SiblingNameIndexHook())
+
+	if c.nameReuse != "" {
+		client.Tenant.Use(c.nameReuse.RenameHook())
+	}
+
+	client.Tenant.Use(validation.NewChildrenLimit(c.maxChildren).Hook())
+	client.Tenant.Use(validation.NewDepthLimit(c.maxDepth).Hook())
+	client.Tenant.Use(deletion.Hook(c.deletion...))
+	client.Tenant.Use(archive.Hook())
+	client.Tenant.Use(reparent.Hook())
+
+	// dependents are checked before the event hooks remove the relationships of deleted tenants
+	if c.dependents != nil {
+		client.Tenant.Use(c.dependents.Hook())
+	}
+
+	client.Tenant.Use(history.Hook())
+	client.Tenant.Use(changeseq.Hook())
+	client.Tenant.Use(migration.VersionHook())
+	client.Tenant.Use(tombstone.Hook())
+	client.Tenant.Use(serviceaccount.CascadeHook())
+
+	if c.events {
+		if c.snapshots {
+			client.Tenant.Use(snapshot.Hook())
+		}
+
+		if c.effectiveLabels {
+			client.Tenant.Use(labels.Hook())
+		}
+
+		eventhooks.EventHooks(client)
+	}
+}
@@ -0,0 +1,42 @@
+package hookchain_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/hookchain"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestRegister(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	hookchain.Register(client, hookchain.WithMaxChildren(1))
+
+	_, err := client.Tenant.Create().SetName("anonymous").Save(context.Background())
+	assert.ErrorIs(t, err, actor.ErrMissingActor)
+
+	ctx := actor.NewContext(context.Background(), "idntusr-tester")
+
+	root, err := client.Tenant.Create().SetName("  root ").Save(ctx)
+	require.NoError(t, err)
+
+	// fields are normalized and the change is sequenced and versioned
+	assert.Equal(t, "root", root.Name)
+	assert.NotZero(t, root.ChangeSeq)
+	assert.NotZero(t, root.Version)
+
+	client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+
+	_, err = client.Tenant.Create().SetName("sibling").SetParent(root).Save(ctx)
+
+	var verr *validation.Error
+	assert.ErrorAs(t, err, &verr)
+}
@@ -0,0 +1,205 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for requests to a host whose circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// State is the state of the circuit breaker of a host.
+type State int
+
+// States of a circuit breaker.
+const (
+	// StateClosed lets requests through, the host is considered healthy.
+	StateClosed State = iota
+	// StateHalfOpen lets a single request through to probe whether the host recovered.
+	StateHalfOpen
+	// StateOpen rejects requests until the open timeout has passed.
+	StateOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// breaker is the circuit breaker of a host. It opens after a number of consecutive failures and
+// rejects requests until the open timeout has passed, then lets a probe through which closes it
+// again when it succeeds.
+type breaker struct {
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// breakerTransport is an http.RoundTripper with a circuit breaker per host. Transport errors and
+// 5xx responses are failures, requests canceled by the caller aren't counted.
+type breakerTransport struct {
+	next        http.RoundTripper
+	client      string
+	threshold   int
+	openTimeout time.Duration
+	metrics     *Metrics
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+
+	if !t.allow(host) {
+		t.metrics.reject(t.client, host)
+
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, host)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		t.release(host)
+	case err != nil || resp.StatusCode >= http.StatusInternalServerError:
+		t.record(host, false)
+	default:
+		t.record(host, true)
+	}
+
+	return resp, err
+}
+
+// allow reports whether a request to the host may be made, turning an open breaker whose timeout
+// has passed half open for a single probe.
+func (t *breakerTransport) allow(host string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := t.breaker(host)
+
+	switch b.state {
+	case StateClosed:
+		return true
+	case StateOpen:
+		if time.Since(b.openedAt) < t.openTimeout {
+			return false
+		}
+
+		t.setState(host, b, StateHalfOpen)
+	}
+
+	if b.probing {
+		return false
+	}
+
+	b.probing = true
+
+	return true
+}
+
+// record counts the outcome of a request to the host.
+func (t *breakerTransport) record(host string, success bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := t.breaker(host)
+	b.probing = false
+
+	if success {
+		b.failures = 0
+		t.setState(host, b, StateClosed)
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= t.threshold {
+		b.openedAt = time.Now()
+		t.setState(host, b, StateOpen)
+	}
+}
+
+// release ends a probe whose outcome isn't counted.
+func (t *breakerTransport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.breaker(host).probing = false
+}
+
+func (t *breakerTransport) breaker(host string) *breaker {
+	b, ok := t.breakers[host]
+	if !ok {
+		b = &breaker{}
+		t.breakers[host] = b
+
+		t.metrics.setState(t.client, host, StateClosed)
+	}
+
+	return b
+}
+
+func (t *breakerTransport) setState(host string, b *breaker, state State) {
+	if b.state != state {
+		b.state = state
+
+		t.metrics.setState(t.client, host, state)
+	}
+}
+
+// state returns the state of the breaker of the host.
+func (t *breakerTransport) state(host string) State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if b, ok := t.breakers[host]; ok {
+		return b.state
+	}
+
+	return StateClosed
+}
+
+// openHosts returns the hosts whose breaker is open, sorted.
+func (t *breakerTransport) openHosts() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var hosts []string
+
+	for host, b := range t.breakers {
+		if b.state == StateOpen {
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// openError returns an error listing the hosts whose breaker is open, nil when there are none.
+func (t *breakerTransport) openError() error {
+	hosts := t.openHosts()
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrCircuitOpen, strings.Join(hosts, ", "))
+}
@@ -0,0 +1,159 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Defaults of the client configuration.
+const (
+	DefaultTimeout             = 10 * time.Second
+	DefaultMaxAttempts         = 3
+	DefaultRetryBackoff        = 100 * time.Millisecond
+	DefaultMaxIdleConnsPerHost = 16
+	DefaultMaxConnsPerHost     = 64
+	DefaultBreakerFailures     = 5
+	DefaultBreakerOpenTimeout  = 30 * time.Second
+)
+
+// Config configures the clients.
+type Config struct {
+	// Timeout bounds a request, including its retries and reading the response body.
+	Timeout time.Duration
+	// MaxAttempts is the number of times an idempotent request is sent at most.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, doubled on each further retry.
+	RetryBackoff time.Duration
+	// MaxIdleConnsPerHost is the number of idle connections kept for reuse per host.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections to a host, zero for no limit.
+	MaxConnsPerHost int
+	// BreakerFailures is the number of consecutive failures opening the breaker of a host.
+	BreakerFailures int
+	// BreakerOpenTimeout is the time the breaker of a host stays open before a request probes it.
+	BreakerOpenTimeout time.Duration
+}
+
+// DefaultConfig returns the default client configuration.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             DefaultTimeout,
+		MaxAttempts:         DefaultMaxAttempts,
+		RetryBackoff:        DefaultRetryBackoff,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		MaxConnsPerHost:     DefaultMaxConnsPerHost,
+		BreakerFailures:     DefaultBreakerFailures,
+		BreakerOpenTimeout:  DefaultBreakerOpenTimeout,
+	}
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	metrics   *Metrics
+	transport http.RoundTripper
+}
+
+// WithMetrics sets the metrics the client reports to.
+func WithMetrics(m *Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithTransport sets the transport sending the requests, a pooled transport built from the
+// configuration by default.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// Client is an http client for an outbound integration.
+type Client struct {
+	*http.Client
+
+	name     string
+	breakers *breakerTransport
+}
+
+// New returns the client of the named integration. The name labels its metrics and spans.
+// Requests are traced once, each attempt goes through the circuit breaker of its host.
+func New(name string, cfg Config, opts ...Option) *Client {
+	defaults := DefaultConfig()
+
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaults.RetryBackoff
+	}
+
+	if cfg.BreakerFailures < 1 {
+		cfg.BreakerFailures = defaults.BreakerFailures
+	}
+
+	if cfg.BreakerOpenTimeout <= 0 {
+		cfg.BreakerOpenTimeout = defaults.BreakerOpenTimeout
+	}
+
+	o := options{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.transport == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+
+		o.transport = transport
+	}
+
+	breakers := &breakerTransport{
+		next:        o.transport,
+		client:      name,
+		threshold:   cfg.BreakerFailures,
+		openTimeout: cfg.BreakerOpenTimeout,
+		metrics:     o.metrics,
+		breakers:    map[string]*breaker{},
+	}
+
+	retry := &retryTransport{
+		next:        breakers,
+		client:      name,
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     cfg.RetryBackoff,
+		metrics:     o.metrics,
+	}
+
+	return &Client{
+		Client: &http.Client{
+			Transport: otelhttp.NewTransport(retry,
+				otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+					return name + " " + r.Method
+				}),
+			),
+			Timeout: cfg.Timeout,
+		},
+		name:     name,
+		breakers: breakers,
+	}
+}
+
+// State returns the state of the circuit breaker of the host, given as host[:port].
+func (c *Client) State(host string) State {
+	return c.breakers.state(host)
+}
+
+// Check fails while the circuit breaker of a host is open, listing those hosts. It is meant as a
+// readiness check of the critical dependencies.
+func (c *Client) Check(_ context.Context) error {
+	return c.breakers.openError()
+}
@@ -0,0 +1,269 @@
+package httpx_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/httpx"
+)
+
+// flakyBackend responds with a 503 while failing is set and counts the requests and bodies it got.
+type flakyBackend struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	failing  bool
+	failNext int
+	requests int
+	bodies   []string
+}
+
+func newFlakyBackend(t *testing.T) *flakyBackend {
+	t.Helper()
+
+	b := &flakyBackend{}
+
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.requests++
+		b.bodies = append(b.bodies, string(body))
+
+		if b.failing || b.failNext > 0 {
+			b.failNext--
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(b.Close)
+
+	return b
+}
+
+func (b *flakyBackend) setFailing(failing bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failing = failing
+}
+
+func (b *flakyBackend) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.requests
+}
+
+func (b *flakyBackend) host(t *testing.T) string {
+	t.Helper()
+
+	u, err := url.Parse(b.URL)
+	require.NoError(t, err)
+
+	return u.Host
+}
+
+func do(t *testing.T, c *httpx.Client, method, target, body string) (int, error) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, target, reader)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// metricValue returns the value of the gauge or counter of the client and host.
+func metricValue(t *testing.T, reg *prometheus.Registry, name, client, host string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+
+			if labels["client"] != client || labels["host"] != host {
+				continue
+			}
+
+			if m.GetGauge() != nil {
+				return m.GetGauge().GetValue()
+			}
+
+			return m.GetCounter().GetValue()
+		}
+	}
+
+	return 0
+}
+
+func newClient(t *testing.T, cfg httpx.Config) (*httpx.Client, *prometheus.Registry) {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := httpx.NewMetrics(reg)
+	require.NoError(t, err)
+
+	return httpx.New("test", cfg, httpx.WithMetrics(metrics)), reg
+}
+
+func TestBreakerTripsAndRecovers(t *testing.T) {
+	backend := newFlakyBackend(t)
+	host := backend.host(t)
+
+	cfg := httpx.DefaultConfig()
+	cfg.MaxAttempts = 1
+	cfg.BreakerFailures = 3
+	cfg.BreakerOpenTimeout = 50 * time.Millisecond
+
+	client, reg := newClient(t, cfg)
+
+	backend.setFailing(true)
+
+	for i := 0; i < 3; i++ {
+		status, err := do(t, client, http.MethodGet, backend.URL, "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+	}
+
+	assert.Equal(t, httpx.StateOpen, client.State(host))
+	assert.Equal(t, float64(httpx.StateOpen), metricValue(t, reg, "tenant_api_http_client_breaker_state", "test", host))
+	assert.ErrorIs(t, client.Check(context.Background()), httpx.ErrCircuitOpen)
+
+	// open breakers fail fast without reaching the backend
+	_, err := do(t, client, http.MethodGet, backend.URL, "")
+	assert.ErrorIs(t, err, httpx.ErrCircuitOpen)
+	assert.Equal(t, 3, backend.count())
+	assert.Equal(t, float64(1), metricValue(t, reg, "tenant_api_http_client_breaker_rejections_total", "test", host))
+
+	backend.setFailing(false)
+
+	time.Sleep(2 * cfg.BreakerOpenTimeout)
+
+	status, err := do(t, client, http.MethodGet, backend.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	assert.Equal(t, httpx.StateClosed, client.State(host))
+	assert.Equal(t, float64(httpx.StateClosed), metricValue(t, reg, "tenant_api_http_client_breaker_state", "test", host))
+	assert.NoError(t, client.Check(context.Background()))
+}
+
+func TestBreakerReopensWhenProbeFails(t *testing.T) {
+	backend := newFlakyBackend(t)
+	host := backend.host(t)
+
+	cfg := httpx.DefaultConfig()
+	cfg.MaxAttempts = 1
+	cfg.BreakerFailures = 2
+	cfg.BreakerOpenTimeout = 50 * time.Millisecond
+
+	client, _ := newClient(t, cfg)
+
+	backend.setFailing(true)
+
+	for i := 0; i < 2; i++ {
+		_, err := do(t, client, http.MethodGet, backend.URL, "")
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, httpx.StateOpen, client.State(host))
+
+	time.Sleep(2 * cfg.BreakerOpenTimeout)
+
+	// a single failing probe opens the breaker again
+	status, err := do(t, client, http.MethodGet, backend.URL, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, httpx.StateOpen, client.State(host))
+
+	_, err = do(t, client, http.MethodGet, backend.URL, "")
+	assert.ErrorIs(t, err, httpx.ErrCircuitOpen)
+	assert.Equal(t, 3, backend.count())
+}
+
+func TestRetry(t *testing.T) {
+	cfg := httpx.DefaultConfig()
+	cfg.MaxAttempts = 3
+	cfg.RetryBackoff = time.Millisecond
+
+	t.Run("idempotent requests are retried", func(t *testing.T) {
+		backend := newFlakyBackend(t)
+		backend.failNext = 2
+
+		client, reg := newClient(t, cfg)
+
+		status, err := do(t, client, http.MethodPut, backend.URL, "payload")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, 3, backend.count())
+		assert.Equal(t, []string{"payload", "payload", "payload"}, backend.bodies)
+		assert.Equal(t, float64(2), metricValue(t, reg, "tenant_api_http_client_retries_total", "test", backend.host(t)))
+	})
+
+	t.Run("attempts are bounded", func(t *testing.T) {
+		backend := newFlakyBackend(t)
+		backend.setFailing(true)
+
+		client, _ := newClient(t, cfg)
+
+		status, err := do(t, client, http.MethodGet, backend.URL, "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, 3, backend.count())
+	})
+
+	t.Run("other requests aren't retried", func(t *testing.T) {
+		backend := newFlakyBackend(t)
+		backend.failNext = 1
+
+		client, _ := newClient(t, cfg)
+
+		status, err := do(t, client, http.MethodPost, backend.URL, "payload")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, 1, backend.count())
+	})
+}
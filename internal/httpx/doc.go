@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpx builds the http clients of the outbound integrations, such as the permissions
+// checks and the resource reporters. Clients share their timeouts and connection pooling limits,
+// retry idempotent requests failing transiently, trace requests and stop calling hosts which keep
+// failing with a circuit breaker per host, whose state is exposed as metrics and readiness checks.
+package httpx
@@ -0,0 +1,68 @@
+package httpx
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "tenant_api"
+	metricsSubsystem = "http_client"
+)
+
+// Metrics exposes the state of the circuit breakers and how many requests they rejected and how
+// many were retried, by client and host.
+type Metrics struct {
+	state      *prometheus.GaugeVec
+	rejections *prometheus.CounterVec
+	retries    *prometheus.CounterVec
+}
+
+// NewMetrics returns the http client metrics, registered with the registerer.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		state: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "breaker_state",
+			Help:      "State of the circuit breaker of the host: 0 closed, 1 half open, 2 open.",
+		}, []string{"client", "host"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "breaker_rejections_total",
+			Help:      "Number of requests rejected as the circuit breaker of the host was open.",
+		}, []string{"client", "host"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "retries_total",
+			Help:      "Number of requests retried after a transient failure.",
+		}, []string{"client", "host"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.state, m.rejections, m.retries} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) setState(client, host string, state State) {
+	if m != nil {
+		m.state.WithLabelValues(client, host).Set(float64(state))
+	}
+}
+
+func (m *Metrics) reject(client, host string) {
+	if m != nil {
+		m.rejections.WithLabelValues(client, host).Inc()
+	}
+}
+
+func (m *Metrics) retry(client, host string) {
+	if m != nil {
+		m.retries.WithLabelValues(client, host).Inc()
+	}
+}
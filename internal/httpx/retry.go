@@ -0,0 +1,86 @@
+package httpx
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// idempotentMethods are the methods whose requests may be sent again.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodTrace:   true,
+}
+
+// retryTransport is an http.RoundTripper retrying idempotent requests which fail with a transport
+// error, a 429 or a 5xx response. Requests rejected by an open circuit breaker and requests
+// canceled by the caller aren't retried.
+type retryTransport struct {
+	next        http.RoundTripper
+	client      string
+	maxAttempts int
+	backoff     time.Duration
+	metrics     *Metrics
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxAttempts || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		t.metrics.retry(t.client, req.URL.Host)
+
+		backoff *= 2
+	}
+}
+
+// retryable reports whether the request may be sent again after the outcome.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if !idempotentMethods[req.Method] || req.Context().Err() != nil {
+		return false
+	}
+
+	// requests without a way to rewind the body can't be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
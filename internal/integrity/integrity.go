@@ -0,0 +1,214 @@
+// Package integrity verifies the tenant hierarchy and repairs the problems which are safe to fix.
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// Kinds of problems found in the hierarchy.
+const (
+	// KindOrphan is a tenant whose parent doesn't exist.
+	KindOrphan = "orphan"
+	// KindCycle is a loop in the parent pointers, none of its tenants reach a root.
+	KindCycle = "cycle"
+)
+
+// Problem is a single integrity violation.
+type Problem struct {
+	Kind     string          `json:"kind"`
+	TenantID gidx.PrefixedID `json:"tenantID"`
+	// ParentID is the missing parent of an orphan.
+	ParentID gidx.PrefixedID `json:"parentID,omitempty"`
+	// Cycle lists the tenants of a cycle, starting with the smallest id.
+	Cycle []gidx.PrefixedID `json:"cycle,omitempty"`
+	// Fixed reports whether the problem was repaired.
+	Fixed bool `json:"fixed"`
+}
+
+// Report lists the problems found by a verification.
+type Report struct {
+	Tenants  int       `json:"tenants"`
+	Problems []Problem `json:"problems"`
+}
+
+// OK reports whether every problem found was fixed.
+func (r *Report) OK() bool {
+	for _, p := range r.Problems {
+		if !p.Fixed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String implements fmt.Stringer, summarizing the problems by kind.
+func (r *Report) String() string {
+	if len(r.Problems) == 0 {
+		return fmt.Sprintf("checked %d tenants, no problems found", r.Tenants)
+	}
+
+	found := map[string]int{}
+	fixed := 0
+
+	for _, p := range r.Problems {
+		found[p.Kind]++
+
+		if p.Fixed {
+			fixed++
+		}
+	}
+
+	kinds := make([]string, 0, len(found))
+
+	for kind, n := range found {
+		kinds = append(kinds, fmt.Sprintf("%d %s", n, kind))
+	}
+
+	sort.Strings(kinds)
+
+	return fmt.Sprintf("checked %d tenants, found %d problems (%s), fixed %d",
+		r.Tenants, len(r.Problems), strings.Join(kinds, ", "), fixed)
+}
+
+// Options configures a verification.
+type Options struct {
+	// Fix repairs the safe problems: orphans are detached from their missing parent and become
+	// roots, as the foreign key would have done had the parent been deleted with it in place.
+	Fix bool
+}
+
+// Verify scans every tenant and reports orphans and cycles. Repairs are applied one at a time, a failed repair leaves the earlier ones in place.
+//
+// The client should not have the event hooks registered when fixing, they would relate the
+// detached orphans to their missing parent again.
+func Verify(ctx context.Context, client *ent.Client, opts Options) (*Report, error) {
+	tenants, err := client.Tenant.Query().
+		Select(tenant.FieldID, tenant.FieldParentTenantID).
+		Order(ent.Asc(tenant.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Tenants: len(tenants), Problems: []Problem{}}
+
+	parents := make(map[gidx.PrefixedID]gidx.PrefixedID, len(tenants))
+
+	for _, t := range tenants {
+		parents[t.ID] = t.ParentTenantID
+	}
+
+	for _, t := range tenants {
+		if t.ParentTenantID == gidx.NullPrefixedID {
+			continue
+		}
+
+		if _, ok := parents[t.ParentTenantID]; !ok {
+			report.Problems = append(report.Problems, Problem{
+				Kind:     KindOrphan,
+				TenantID: t.ID,
+				ParentID: t.ParentTenantID,
+			})
+		}
+	}
+
+	for _, cycle := range findCycles(tenants, parents) {
+		report.Problems = append(report.Problems, Problem{
+			Kind:     KindCycle,
+			TenantID: cycle[0],
+			Cycle:    cycle,
+		})
+	}
+
+	if opts.Fix {
+		if err := fix(ctx, client, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// findCycles follows the parent pointers from every tenant, returning each cycle once. The
+// tenants are visited in id order so the result is stable.
+func findCycles(tenants []*ent.Tenant, parents map[gidx.PrefixedID]gidx.PrefixedID) [][]gidx.PrefixedID {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	state := make(map[gidx.PrefixedID]int, len(tenants))
+
+	var cycles [][]gidx.PrefixedID
+
+	for _, t := range tenants {
+		var path []gidx.PrefixedID
+
+		id := t.ID
+
+		for id != gidx.NullPrefixedID && state[id] == unvisited {
+			if _, ok := parents[id]; !ok {
+				// orphans end the walk, they are reported separately
+				break
+			}
+
+			state[id] = visiting
+			path = append(path, id)
+			id = parents[id]
+		}
+
+		if id != gidx.NullPrefixedID && state[id] == visiting {
+			for i, p := range path {
+				if p == id {
+					cycles = append(cycles, rotate(path[i:]))
+					break
+				}
+			}
+		}
+
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+
+	return cycles
+}
+
+// rotate returns the cycle starting with its smallest id.
+func rotate(cycle []gidx.PrefixedID) []gidx.PrefixedID {
+	start := 0
+
+	for i, id := range cycle {
+		if id < cycle[start] {
+			start = i
+		}
+	}
+
+	return append(append([]gidx.PrefixedID{}, cycle[start:]...), cycle[:start]...)
+}
+
+func fix(ctx context.Context, client *ent.Client, report *Report) error {
+	for i, p := range report.Problems {
+		if p.Kind != KindOrphan {
+			continue
+		}
+
+		if err := client.Tenant.UpdateOneID(p.TenantID).ClearParentTenantID().Exec(ctx); err != nil {
+			return fmt.Errorf("failed to detach orphan %s: %w", p.TenantID, err)
+		}
+
+		report.Problems[i].Fixed = true
+	}
+
+	return nil
+}
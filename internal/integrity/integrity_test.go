@@ -0,0 +1,135 @@
+package integrity_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/integrity"
+)
+
+// openCorruptible opens a database whose foreign keys are turned off once the schema is created,
+// so broken parent pointers can be written with setParent.
+func openCorruptible(t *testing.T) (*ent.Client, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	// the pragma is per connection
+	db.SetMaxOpenConns(1)
+
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	_, err = db.Exec("PRAGMA foreign_keys = OFF")
+	require.NoError(t, err)
+
+	return client, db
+}
+
+// setParent writes the parent pointer directly in the database.
+func setParent(t *testing.T, db *sql.DB, id, parentID gidx.PrefixedID) {
+	t.Helper()
+
+	_, err := db.Exec("UPDATE tenants SET parent_tenant_id = ? WHERE id = ?", parentID, id)
+	require.NoError(t, err)
+}
+
+func problemsOf(report *integrity.Report, kind string) []integrity.Problem {
+	var problems []integrity.Problem
+
+	for _, p := range report.Problems {
+		if p.Kind == kind {
+			problems = append(problems, p)
+		}
+	}
+
+	return problems
+}
+
+func TestVerifyHealthy(t *testing.T) {
+	ctx := context.Background()
+	client, _ := openCorruptible(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+
+	report, err := integrity.Verify(ctx, client, integrity.Options{})
+	require.NoError(t, err)
+
+	assert.True(t, report.OK())
+	assert.Equal(t, 2, report.Tenants)
+	assert.Empty(t, report.Problems)
+	assert.Equal(t, "checked 2 tenants, no problems found", report.String())
+}
+
+func TestVerifyOrphan(t *testing.T) {
+	ctx := context.Background()
+	client, db := openCorruptible(t)
+
+	orphan := client.Tenant.Create().SetName("orphan").SaveX(ctx)
+	setParent(t, db, orphan.ID, "tnntten-deleted")
+
+	report, err := integrity.Verify(ctx, client, integrity.Options{})
+	require.NoError(t, err)
+
+	assert.False(t, report.OK())
+	assert.Equal(t, []integrity.Problem{{
+		Kind:     integrity.KindOrphan,
+		TenantID: orphan.ID,
+		ParentID: "tnntten-deleted",
+	}}, report.Problems)
+
+	report, err = integrity.Verify(ctx, client, integrity.Options{Fix: true})
+	require.NoError(t, err)
+
+	assert.True(t, report.OK())
+	require.Len(t, report.Problems, 1)
+	assert.True(t, report.Problems[0].Fixed)
+	assert.Equal(t, gidx.NullPrefixedID, client.Tenant.GetX(ctx, orphan.ID).ParentTenantID)
+
+	report, err = integrity.Verify(ctx, client, integrity.Options{})
+	require.NoError(t, err)
+	assert.Empty(t, report.Problems)
+}
+
+func TestVerifyCycle(t *testing.T) {
+	ctx := context.Background()
+	client, db := openCorruptible(t)
+
+	a := client.Tenant.Create().SetID("tnntten-a").SetName("a").SaveX(ctx)
+	b := client.Tenant.Create().SetID("tnntten-b").SetName("b").SetParent(a).SaveX(ctx)
+	c := client.Tenant.Create().SetID("tnntten-c").SetName("c").SetParent(b).SaveX(ctx)
+	setParent(t, db, a.ID, c.ID)
+
+	// below the cycle, reported through the cycle only
+	client.Tenant.Create().SetName("leaf").SetParent(c).SaveX(ctx)
+
+	self := client.Tenant.Create().SetID("tnntten-self").SetName("self").SaveX(ctx)
+	setParent(t, db, self.ID, self.ID)
+
+	report, err := integrity.Verify(ctx, client, integrity.Options{Fix: true})
+	require.NoError(t, err)
+
+	cycles := problemsOf(report, integrity.KindCycle)
+	require.Len(t, cycles, 2)
+	assert.Equal(t, []gidx.PrefixedID{a.ID, c.ID, b.ID}, cycles[0].Cycle)
+	assert.Equal(t, []gidx.PrefixedID{self.ID}, cycles[1].Cycle)
+	assert.Len(t, report.Problems, 2)
+
+	// cycles aren't safe to repair
+	assert.False(t, report.OK())
+	assert.False(t, cycles[0].Fixed)
+	assert.Equal(t, "checked 5 tenants, found 2 problems (2 cycle), fixed 0", report.String())
+}
@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+)
+
+// DefaultRetention is the default time finished jobs are kept for.
+const DefaultRetention = 24 * time.Hour
+
+// Statuses of a job.
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Job is the state of a job at some point.
+type Job struct {
+	ID         gidx.PrefixedID `json:"id"`
+	Kind       string          `json:"kind"`
+	Status     string          `json:"status"`
+	Done       int64           `json:"done"`
+	Total      int64           `json:"total"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+}
+
+// Func is the work of a job, reporting its progress to the tracker.
+type Func func(ctx context.Context, progress *Tracker) error
+
+// Tracker records the progress of a running job.
+type Tracker struct {
+	registry *Registry
+	id       gidx.PrefixedID
+}
+
+// SetTotal sets the amount of work the job has to do.
+func (t *Tracker) SetTotal(total int64) {
+	t.registry.update(t.id, func(j *Job) { j.Total = total })
+}
+
+// Add records that n more units of work were done.
+func (t *Tracker) Add(n int64) {
+	t.registry.update(t.id, func(j *Job) { j.Done += n })
+}
+
+// Option configures a Registry.
+type Option func(*Registry)
+
+// WithRetention sets the time finished jobs are kept for.
+func WithRetention(d time.Duration) Option {
+	return func(r *Registry) {
+		if d > 0 {
+			r.retention = d
+		}
+	}
+}
+
+// WithClock sets the clock jobs are timed with, the wall clock by default.
+func WithClock(c clock.Clock) Option {
+	return func(r *Registry) {
+		r.clock = c
+	}
+}
+
+// Registry runs jobs and keeps their state in memory. Jobs only exist on the instance which
+// started them and are lost when it stops.
+type Registry struct {
+	retention time.Duration
+	clock     clock.Clock
+
+	mu   sync.Mutex
+	jobs map[gidx.PrefixedID]*Job
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		retention: DefaultRetention,
+		clock:     clock.Real{},
+		jobs:      map[gidx.PrefixedID]*Job{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Start runs the job in the background and returns its initial state. The job isn't bound to the
+// context it is started from, it runs until fn returns.
+func (r *Registry) Start(kind string, fn Func) Job {
+	now := r.clock.Now().UTC()
+
+	job := &Job{
+		ID:        gidx.MustNewID(schema.JobPrefix),
+		Kind:      kind,
+		Status:    StatusRunning,
+		StartedAt: now,
+	}
+
+	r.mu.Lock()
+	r.forgetFinished(now)
+	r.jobs[job.ID] = job
+	started := *job
+	r.mu.Unlock()
+
+	go func() {
+		err := fn(context.Background(), &Tracker{registry: r, id: job.ID})
+
+		r.update(job.ID, func(j *Job) {
+			finished := r.clock.Now().UTC()
+			j.FinishedAt = &finished
+
+			if err != nil {
+				j.Status = StatusFailed
+				j.Error = err.Error()
+			} else {
+				j.Status = StatusSucceeded
+			}
+		})
+	}()
+
+	return started
+}
+
+// Get returns the current state of the job, false when there is no such job.
+func (r *Registry) Get(id gidx.PrefixedID) (Job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	return *job, true
+}
+
+func (r *Registry) update(id gidx.PrefixedID, fn func(*Job)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job, ok := r.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+// forgetFinished drops the jobs finished longer than the retention ago, the lock must be held.
+func (r *Registry) forgetFinished(now time.Time) {
+	for id, job := range r.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > r.retention {
+			delete(r.jobs, id)
+		}
+	}
+}
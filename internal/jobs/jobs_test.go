@@ -0,0 +1,107 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/jobs"
+)
+
+// wait returns the job once it is finished.
+func wait(t *testing.T, r *jobs.Registry, id gidx.PrefixedID) jobs.Job {
+	t.Helper()
+
+	var job jobs.Job
+
+	require.Eventually(t, func() bool {
+		var ok bool
+
+		job, ok = r.Get(id)
+		require.True(t, ok)
+
+		return job.Status != jobs.StatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestRegistry(t *testing.T) {
+	r := jobs.NewRegistry()
+
+	release := make(chan struct{})
+
+	started := r.Start("count", func(_ context.Context, progress *jobs.Tracker) error {
+		progress.SetTotal(3)
+		progress.Add(1)
+
+		<-release
+
+		progress.Add(2)
+
+		return nil
+	})
+
+	assert.Equal(t, "count", started.Kind)
+	assert.Equal(t, jobs.StatusRunning, started.Status)
+
+	require.Eventually(t, func() bool {
+		job, _ := r.Get(started.ID)
+
+		return job.Done == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	running, _ := r.Get(started.ID)
+	assert.Equal(t, int64(3), running.Total)
+	assert.Nil(t, running.FinishedAt)
+
+	close(release)
+
+	job := wait(t, r, started.ID)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.Equal(t, int64(3), job.Done)
+	assert.Empty(t, job.Error)
+	assert.NotNil(t, job.FinishedAt)
+
+	failed := wait(t, r, r.Start("fail", func(context.Context, *jobs.Tracker) error {
+		return errors.New("broken")
+	}).ID)
+
+	assert.Equal(t, jobs.StatusFailed, failed.Status)
+	assert.Equal(t, "broken", failed.Error)
+
+	_, ok := r.Get(gidx.MustNewID("tnntjob"))
+	assert.False(t, ok)
+}
+
+func TestRegistryRetention(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	r := jobs.NewRegistry(jobs.WithRetention(time.Hour), jobs.WithClock(fake))
+
+	first := wait(t, r, r.Start("noop", func(context.Context, *jobs.Tracker) error { return nil }).ID)
+
+	// finished jobs are kept for the retention
+	kept := r.Start("noop", func(context.Context, *jobs.Tracker) error { return nil })
+
+	_, ok := r.Get(first.ID)
+	assert.True(t, ok)
+
+	wait(t, r, kept.ID)
+
+	fake.Advance(time.Hour + time.Second)
+
+	// finished jobs are forgotten when later ones start
+	second := r.Start("noop", func(context.Context, *jobs.Tracker) error { return nil })
+
+	_, ok = r.Get(first.ID)
+	assert.False(t, ok)
+
+	_, ok = r.Get(second.ID)
+	assert.True(t, ok)
+}
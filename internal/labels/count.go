@@ -0,0 +1,110 @@
+package labels
+
+import (
+	"context"
+	"fmt"
+
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// countQuery counts the descendants of the tenant in $3 by the effective value of a label in a
+// single statement: the ancestors of the tenant are walked up for the value it inherits, which is
+// then carried down the subtree, each tenant setting the key replacing it for its own subtree.
+//
+// The key is looked up as $1 and as $2, the key quoted. Postgres reads the key of ->> as is, where
+// sqlite reads it as a json path which keys holding a '.' break, unless quoted. Valid keys never
+// hold quotes, so the quoted key matches nothing on postgres, nor does the unquoted one on sqlite
+// when it holds a '.', the labels being flat.
+//
+// The tenant itself is returned first, as a group of its own flagged as the root, so a missing
+// tenant is told apart from one without descendants. Groups are ordered by count, largest first,
+// and limited to $5. Parameters are numbered in the order they first appear, which is how sqlite
+// binds them.
+const countQuery = `
+WITH RECURSIVE ancestors (id, parent_tenant_id, value, depth) AS (
+	SELECT id, parent_tenant_id, COALESCE(labels ->> CAST($1 AS TEXT), labels ->> CAST($2 AS TEXT)), 0
+	FROM tenants
+	WHERE id = $3
+	UNION ALL
+	SELECT t.id, t.parent_tenant_id, COALESCE(t.labels ->> CAST($1 AS TEXT), t.labels ->> CAST($2 AS TEXT)), a.depth + 1
+	FROM tenants t
+	JOIN ancestors a ON t.id = a.parent_tenant_id
+	WHERE a.depth < $4
+),
+subtree (id, value, depth) AS (
+	SELECT id, (SELECT value FROM ancestors WHERE value IS NOT NULL ORDER BY depth LIMIT 1), 0
+	FROM tenants
+	WHERE id = $3
+	UNION ALL
+	SELECT t.id, COALESCE(t.labels ->> CAST($1 AS TEXT), t.labels ->> CAST($2 AS TEXT), s.value), s.depth + 1
+	FROM tenants t
+	JOIN subtree s ON t.parent_tenant_id = s.id
+	WHERE s.depth < $4
+)
+SELECT value, depth = 0 AS root, COUNT(*) AS tenants
+FROM subtree
+WHERE depth = 0 OR (value IS NOT NULL AND value <> '')
+GROUP BY value, depth = 0
+ORDER BY root DESC, tenants DESC, value
+LIMIT $5`
+
+// Group is the number of tenants with a value of a label.
+type Group struct {
+	Value string
+	Count int64
+}
+
+// Count counts the descendants of the tenant by the effective value of the label key, with a
+// single grouped query over the labels of the tenant's ancestors and subtree. Descendants without
+// the label, set or inherited, aren't counted. At most limit groups are returned, the largest ones
+// first, truncated tells whether others were left out. An error of the ErrTenantNotFound class is
+// returned when the tenant doesn't exist.
+func Count(ctx context.Context, client *generated.Client, id gidx.PrefixedID, key string, limit int) (groups []Group, truncated bool, err error) {
+	// one more row than the root and the groups returned, telling whether groups were left out
+	rows, err := client.QueryContext(ctx, countQuery, key, `"`+key+`"`, id, maxDepth, limit+2)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	found := false
+	groups = []Group{}
+
+	for rows.Next() {
+		var (
+			value *string
+			root  bool
+			count int64
+		)
+
+		if err := rows.Scan(&value, &root, &count); err != nil {
+			return nil, false, err
+		}
+
+		if root {
+			found = true
+
+			continue
+		}
+
+		groups = append(groups, Group{Value: *value, Count: count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, fmt.Errorf("%w: %s", apierrors.ErrTenantNotFound, id)
+	}
+
+	if len(groups) > limit {
+		return groups[:limit], true, nil
+	}
+
+	return groups, false, nil
+}
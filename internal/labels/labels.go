@@ -0,0 +1,125 @@
+package labels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// maxDepth bounds the ancestor walk, keeping a cycle in the hierarchy from recursing forever.
+const maxDepth = 1000
+
+// ancestorsQuery walks up from the tenant in $1 to its root in a single statement, returning the
+// labels of every tenant on the way from the root down.
+const ancestorsQuery = `
+WITH RECURSIVE ancestors (id, parent_tenant_id, labels, depth) AS (
+	SELECT id, parent_tenant_id, labels, 0
+	FROM tenants
+	WHERE id = $1
+	UNION ALL
+	SELECT t.id, t.parent_tenant_id, t.labels, a.depth + 1
+	FROM tenants t
+	JOIN ancestors a ON t.id = a.parent_tenant_id
+	WHERE a.depth < $2
+)
+SELECT id, labels FROM ancestors ORDER BY depth DESC`
+
+// Effective are the labels a tenant has once those of its ancestors are inherited.
+type Effective struct {
+	// Labels are the merged labels.
+	Labels map[string]string
+	// Provenance is the tenant each label is taken from, the tenant itself or one of its
+	// ancestors.
+	Provenance map[string]gidx.PrefixedID
+}
+
+// Resolve returns the effective labels of the tenant with a single query of its ancestors. The
+// labels are merged from the root down, so the nearest tenant setting a key wins. An error of the
+// ErrTenantNotFound class is returned when the tenant doesn't exist.
+func Resolve(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (*Effective, error) {
+	rows, err := client.QueryContext(ctx, ancestorsQuery, id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	e := &Effective{
+		Labels:     map[string]string{},
+		Provenance: map[string]gidx.PrefixedID{},
+	}
+
+	found := false
+
+	for rows.Next() {
+		var (
+			tenantID gidx.PrefixedID
+			raw      []byte
+		)
+
+		if err := rows.Scan(&tenantID, &raw); err != nil {
+			return nil, err
+		}
+
+		found = true
+
+		if len(raw) == 0 {
+			continue
+		}
+
+		var labels map[string]string
+
+		if err := json.Unmarshal(raw, &labels); err != nil {
+			return nil, fmt.Errorf("decoding the labels of %s: %w", tenantID, err)
+		}
+
+		for k, v := range labels {
+			e.Labels[k] = v
+			e.Provenance[k] = tenantID
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", apierrors.ErrTenantNotFound, id)
+	}
+
+	return e, nil
+}
+
+// Hook returns an ent hook making the change events of created and updated tenants carry their
+// effective labels under changefeed.EffectiveLabelsKey. It must be registered before the event
+// hooks. The labels are resolved as the event is published, with the client of the mutation so
+// they include its changes. The events of deleted tenants don't carry them.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				client := m.Client()
+
+				labeler := func(ctx context.Context, id gidx.PrefixedID) (map[string]string, error) {
+					e, err := Resolve(ctx, client, id)
+					if err != nil {
+						return nil, err
+					}
+
+					return e.Labels, nil
+				}
+
+				return next.Mutate(changefeed.WithLabeler(ctx, labeler), m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
@@ -0,0 +1,173 @@
+package labels_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/labels"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	org := client.Tenant.Create().SetName("org").SetLabels(map[string]string{"compliance": "pci", "env": "prod", "owner": "finance"}).SaveX(ctx)
+	team := client.Tenant.Create().SetName("team").SetParent(org).SetLabels(map[string]string{"env": "staging", "owner": "payments"}).SaveX(ctx)
+	project := client.Tenant.Create().SetName("project").SetParent(team).SetLabels(map[string]string{"owner": "checkout"}).SaveX(ctx)
+	unlabeled := client.Tenant.Create().SetName("unlabeled").SetParent(project).SaveX(ctx)
+
+	tests := []struct {
+		name       string
+		id         gidx.PrefixedID
+		labels     map[string]string
+		provenance map[string]gidx.PrefixedID
+	}{
+		{
+			name:       "root",
+			id:         org.ID,
+			labels:     map[string]string{"compliance": "pci", "env": "prod", "owner": "finance"},
+			provenance: map[string]gidx.PrefixedID{"compliance": org.ID, "env": org.ID, "owner": org.ID},
+		},
+		{
+			name:       "child overrides",
+			id:         team.ID,
+			labels:     map[string]string{"compliance": "pci", "env": "staging", "owner": "payments"},
+			provenance: map[string]gidx.PrefixedID{"compliance": org.ID, "env": team.ID, "owner": team.ID},
+		},
+		{
+			name:       "nearest ancestor wins",
+			id:         project.ID,
+			labels:     map[string]string{"compliance": "pci", "env": "staging", "owner": "checkout"},
+			provenance: map[string]gidx.PrefixedID{"compliance": org.ID, "env": team.ID, "owner": project.ID},
+		},
+		{
+			name:       "inherited only",
+			id:         unlabeled.ID,
+			labels:     map[string]string{"compliance": "pci", "env": "staging", "owner": "checkout"},
+			provenance: map[string]gidx.PrefixedID{"compliance": org.ID, "env": team.ID, "owner": project.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := labels.Resolve(ctx, client, tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.labels, e.Labels)
+			assert.Equal(t, tt.provenance, e.Provenance)
+		})
+	}
+
+	lone := client.Tenant.Create().SetName("lone").SaveX(ctx)
+
+	e, err := labels.Resolve(ctx, client, lone.ID)
+	require.NoError(t, err)
+	assert.Empty(t, e.Labels)
+	assert.Empty(t, e.Provenance)
+
+	_, err = labels.Resolve(ctx, client, "tnntten-missing")
+	assert.ErrorIs(t, err, apierrors.ErrTenantNotFound)
+}
+
+func TestCount(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	org := client.Tenant.Create().SetName("org").SetLabels(map[string]string{"tier": "gold", "example.com/team": "core"}).SaveX(ctx)
+	team := client.Tenant.Create().SetName("team").SetParent(org).SaveX(ctx)
+	client.Tenant.Create().SetName("project").SetParent(team).SetLabels(map[string]string{"tier": "silver"}).SaveX(ctx)
+	client.Tenant.Create().SetName("sandbox").SetParent(team).SaveX(ctx)
+
+	groups, truncated, err := labels.Count(ctx, client, org.ID, "tier", 10)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []labels.Group{{Value: "gold", Count: 2}, {Value: "silver", Count: 1}}, groups, "the tenant itself isn't counted")
+
+	groups, _, err = labels.Count(ctx, client, team.ID, "tier", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []labels.Group{{Value: "gold", Count: 1}, {Value: "silver", Count: 1}}, groups, "the value of the ancestors is inherited")
+
+	groups, _, err = labels.Count(ctx, client, org.ID, "example.com/team", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []labels.Group{{Value: "core", Count: 3}}, groups, "keys holding a '.' are looked up as keys")
+
+	groups, truncated, err = labels.Count(ctx, client, org.ID, "tier", 1)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, []labels.Group{{Value: "gold", Count: 2}}, groups, "the largest groups are kept")
+
+	groups, _, err = labels.Count(ctx, client, org.ID, "owner", 10)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	groups, _, err = labels.Count(ctx, client, client.Tenant.Create().SetName("lone").SaveX(ctx).ID, "tier", 10)
+	require.NoError(t, err)
+	assert.Empty(t, groups, "a tenant without descendants has no groups")
+
+	_, _, err = labels.Count(ctx, client, "tnntten-missing", "tier", 10)
+	assert.ErrorIs(t, err, apierrors.ErrTenantNotFound)
+}
+
+func TestHook(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(labels.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	org := client.Tenant.Create().SetName("org").SetLabels(map[string]string{"compliance": "pci"}).SaveX(ctx)
+	team := client.Tenant.Create().SetName("team").SetParent(org).SaveX(ctx)
+	client.Tenant.UpdateOne(team).SetLabels(map[string]string{"env": "staging"}).ExecX(ctx)
+	client.Tenant.DeleteOne(team).ExecX(ctx)
+
+	var effective []any
+
+	for _, call := range conn.Calls {
+		raw, err := json.Marshal(call.Arguments.Get(1))
+		require.NoError(t, err)
+
+		var msg struct {
+			AdditionalData map[string]any `json:"additionalData"`
+		}
+
+		require.NoError(t, json.Unmarshal(raw, &msg))
+
+		effective = append(effective, msg.AdditionalData[changefeed.EffectiveLabelsKey])
+	}
+
+	assert.Equal(t, []any{
+		map[string]any{"compliance": "pci"},
+		map[string]any{"compliance": "pci"},
+		map[string]any{"compliance": "pci", "env": "staging"},
+		nil,
+	}, effective, "creates and updates carry the labels as changed, deletions none")
+}
@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package liveconfig holds the settings which can be changed while the service runs, such as the
+// log level, rate limits, maintenance mode and page size caps. The service reads them from a Store
+// for each request, so a reload is seen by every request started after it. Reloads are validated
+// as a whole: an invalid one is rejected and the settings in use are kept.
+package liveconfig
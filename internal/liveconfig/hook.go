@@ -0,0 +1,29 @@
+package liveconfig
+
+import (
+	"context"
+
+	"entgo.io/ent"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// ErrMaintenance is returned when changing a tenant while the service is in maintenance.
+var ErrMaintenance = apierrors.New(apierrors.ErrUnavailable, "tenants can't be changed during maintenance, retry later")
+
+// MaintenanceHook returns an ent hook rejecting every change of tenants while the settings of the
+// store have maintenance on. It applies to the changes of every api and to those the service makes
+// itself, such as scheduled deletions, which are retried once maintenance is over.
+func MaintenanceHook(s *Store) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+			if s.Load().Maintenance {
+				return nil, ErrMaintenance
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package liveconfig
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reload outcomes, the values of the result label.
+const (
+	resultApplied  = "applied"
+	resultRejected = "rejected"
+)
+
+// Metrics counts the reloads of the settings by outcome.
+type Metrics struct {
+	reloads *prometheus.CounterVec
+}
+
+// NewMetrics returns the reload metrics, registered with the registerer.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		reloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tenant_api",
+			Subsystem: "config",
+			Name:      "reloads_total",
+			Help:      "Number of reloads of the live settings, by whether they were applied or rejected.",
+		}, []string{"result"}),
+	}
+
+	if err := reg.Register(m.reloads); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) reloaded(result string) {
+	if m != nil {
+		m.reloads.WithLabelValues(result).Inc()
+	}
+}
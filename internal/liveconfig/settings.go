@@ -0,0 +1,58 @@
+package liveconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// DefaultMaxPageSize is the default largest page of tenants a list may request.
+	DefaultMaxPageSize = 100
+
+	// MaxPageSizeLimit bounds the page size cap, keeping a typo from allowing huge pages.
+	MaxPageSizeLimit = 1000
+)
+
+// Settings are the settings which can be changed without restarting the service.
+type Settings struct {
+	// Debug enables debug logging.
+	Debug bool
+	// Maintenance rejects every change of tenants, reads are still served.
+	Maintenance bool
+	// CrawlRate is the number of batches each caller may crawl per second.
+	CrawlRate float64
+	// CrawlBurst is the number of batches each caller may crawl at once.
+	CrawlBurst int
+	// MaxPageSize is the largest page of tenants a list may request.
+	MaxPageSize int
+}
+
+// Validate returns the problems of the settings joined, nil when they can be applied.
+func (s Settings) Validate() error {
+	var errs []error
+
+	if s.CrawlRate <= 0 {
+		errs = append(errs, fmt.Errorf("crawl rate must be positive, got %v", s.CrawlRate))
+	}
+
+	if s.CrawlBurst < 1 {
+		errs = append(errs, fmt.Errorf("crawl burst must be at least 1, got %d", s.CrawlBurst))
+	}
+
+	if s.MaxPageSize < 1 || s.MaxPageSize > MaxPageSizeLimit {
+		errs = append(errs, fmt.Errorf("max page size must be between 1 and %d, got %d", MaxPageSizeLimit, s.MaxPageSize))
+	}
+
+	return errors.Join(errs...)
+}
+
+// logFields returns the settings as logger key value pairs.
+func (s Settings) logFields() []any {
+	return []any{
+		"debug", s.Debug,
+		"maintenance", s.Maintenance,
+		"crawl_rate", s.CrawlRate,
+		"crawl_burst", s.CrawlBurst,
+		"max_page_size", s.MaxPageSize,
+	}
+}
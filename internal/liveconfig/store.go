@@ -0,0 +1,106 @@
+package liveconfig
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithMetrics counts the reloads with the metrics.
+func WithMetrics(m *Metrics) Option {
+	return func(s *Store) {
+		s.metrics = m
+	}
+}
+
+// WithLogger logs the outcome of the reloads with the logger.
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(s *Store) {
+		s.logger = logger
+	}
+}
+
+// Store holds the settings in use. They are replaced as a whole, so readers never see a mix of
+// the settings before and after a reload.
+type Store struct {
+	current atomic.Pointer[Settings]
+	logger  *zap.SugaredLogger
+	metrics *Metrics
+
+	// mu serializes the reloads and the calls of the subscribers
+	mu          sync.Mutex
+	subscribers []func(Settings)
+}
+
+// NewStore returns a store holding the settings, which must be valid.
+func NewStore(initial Settings, opts ...Option) (*Store, error) {
+	if err := initial.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &Store{logger: zap.NewNop().Sugar()}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.current.Store(&initial)
+
+	return s, nil
+}
+
+// Load returns the settings in use.
+func (s *Store) Load() Settings {
+	return *s.current.Load()
+}
+
+// Subscribe calls fn with the settings in use, and again with the new settings after each
+// applied reload. It suits settings which are held elsewhere, such as the log level.
+func (s *Store) Subscribe(fn func(Settings)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscribers = append(s.subscribers, fn)
+
+	fn(*s.current.Load())
+}
+
+// Reload replaces the settings with those returned by load. When load fails or returns invalid
+// settings the reload is rejected as a whole and the settings in use are kept. The outcome is
+// logged and counted, rejections are also returned.
+func (s *Store) Reload(load func() (Settings, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next, err := load()
+	if err == nil {
+		err = next.Validate()
+	}
+
+	if err != nil {
+		s.logger.Errorw("rejected settings reload, keeping the current settings", "error", err)
+		s.metrics.reloaded(resultRejected)
+
+		return err
+	}
+
+	previous := s.current.Swap(&next)
+
+	for _, fn := range s.subscribers {
+		fn(next)
+	}
+
+	if *previous == next {
+		s.logger.Infow("reloaded settings, nothing changed")
+	} else {
+		s.logger.Infow("reloaded settings", next.logFields()...)
+	}
+
+	s.metrics.reloaded(resultApplied)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package liveconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/liveconfig"
+)
+
+func validSettings() liveconfig.Settings {
+	return liveconfig.Settings{
+		CrawlRate:   5,
+		CrawlBurst:  10,
+		MaxPageSize: liveconfig.DefaultMaxPageSize,
+	}
+}
+
+func newStore(t *testing.T) (*liveconfig.Store, *prometheus.Registry) {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+
+	metrics, err := liveconfig.NewMetrics(reg)
+	require.NoError(t, err)
+
+	store, err := liveconfig.NewStore(validSettings(), liveconfig.WithMetrics(metrics))
+	require.NoError(t, err)
+
+	return store, reg
+}
+
+func reloads(t *testing.T, reg *prometheus.Registry, result string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "result" && label.GetValue() == result {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestNewStoreRejectsInvalid(t *testing.T) {
+	_, err := liveconfig.NewStore(liveconfig.Settings{})
+	assert.Error(t, err)
+}
+
+func TestReloadApplied(t *testing.T) {
+	store, reg := newStore(t)
+
+	var seen []liveconfig.Settings
+
+	store.Subscribe(func(s liveconfig.Settings) {
+		seen = append(seen, s)
+	})
+
+	next := validSettings()
+	next.Debug = true
+	next.Maintenance = true
+	next.CrawlRate = 1
+	next.MaxPageSize = 20
+
+	require.NoError(t, store.Reload(func() (liveconfig.Settings, error) {
+		return next, nil
+	}))
+
+	assert.Equal(t, next, store.Load())
+
+	// subscribers are called with the settings in use, then with the reloaded ones
+	assert.Equal(t, []liveconfig.Settings{validSettings(), next}, seen)
+
+	assert.Equal(t, float64(1), reloads(t, reg, "applied"))
+	assert.Equal(t, float64(0), reloads(t, reg, "rejected"))
+}
+
+func TestReloadRejected(t *testing.T) {
+	store, reg := newStore(t)
+
+	var calls int
+
+	store.Subscribe(func(liveconfig.Settings) {
+		calls++
+	})
+
+	// the valid settings of an invalid reload aren't applied either
+	invalid := validSettings()
+	invalid.Maintenance = true
+	invalid.CrawlRate = 0
+	invalid.MaxPageSize = liveconfig.MaxPageSizeLimit + 1
+
+	err := store.Reload(func() (liveconfig.Settings, error) {
+		return invalid, nil
+	})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "crawl rate")
+	assert.ErrorContains(t, err, "max page size")
+
+	errLoad := errors.New("unreadable config file")
+
+	assert.ErrorIs(t, store.Reload(func() (liveconfig.Settings, error) {
+		return liveconfig.Settings{}, errLoad
+	}), errLoad)
+
+	assert.Equal(t, validSettings(), store.Load())
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, float64(0), reloads(t, reg, "applied"))
+	assert.Equal(t, float64(2), reloads(t, reg, "rejected"))
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "tenant_api_config_reloads_total"))
+}
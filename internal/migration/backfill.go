@@ -0,0 +1,133 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// DefaultBatchSize is the default number of rows a backfill fills at once.
+const DefaultBatchSize = 1000
+
+// Backfill fills the column added by an expand migration on the rows written before binaries
+// wrote it.
+type Backfill struct {
+	Name        string
+	Description string
+
+	// Batch fills at most size rows, returning how many it filled. The backfill is done once a
+	// batch fills none, batches must not refill rows.
+	Batch func(ctx context.Context, client *ent.Client, size int) (int, error)
+}
+
+// Backfills returns the backfills of the expand migrations not contracted yet.
+func Backfills() []Backfill {
+	return []Backfill{VersionBackfill}
+}
+
+// LookupBackfill returns the backfill with the name.
+func LookupBackfill(name string) (Backfill, bool) {
+	for _, b := range Backfills() {
+		if b.Name == name {
+			return b, true
+		}
+	}
+
+	return Backfill{}, false
+}
+
+// Option configures a Runner.
+type Option func(*Runner)
+
+// WithBatchSize sets the number of rows filled at once.
+func WithBatchSize(size int) Option {
+	return func(r *Runner) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// WithPause sets the time waited between two batches, sparing the database on large tables.
+func WithPause(d time.Duration) Option {
+	return func(r *Runner) {
+		if d >= 0 {
+			r.pause = d
+		}
+	}
+}
+
+// WithLogger sets the logger the progress is logged with.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(r *Runner) {
+		r.logger = l
+	}
+}
+
+// Runner runs backfills batch after batch, each batch in its own statement so rows are only
+// locked briefly.
+type Runner struct {
+	batchSize int
+	pause     time.Duration
+	logger    *zap.SugaredLogger
+}
+
+// NewRunner returns a runner with the options applied.
+func NewRunner(opts ...Option) *Runner {
+	r := &Runner{
+		batchSize: DefaultBatchSize,
+		logger:    zap.NewNop().Sugar(),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Run runs the backfill until a batch fills no row, returning how many rows were filled. It stops
+// with the error of the context when it ends first, the rows filled so far stay filled and running
+// it again resumes the backfill.
+func (r *Runner) Run(ctx context.Context, client *ent.Client, b Backfill) (int, error) {
+	start := time.Now()
+	total := 0
+
+	for batch := 1; ; batch++ {
+		filled, err := b.Batch(ctx, client, r.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += filled
+
+		if filled == 0 {
+			r.logger.Infow("backfill done", "backfill", b.Name, "rows", total, "batches", batch, "elapsed", time.Since(start).String())
+
+			return total, nil
+		}
+
+		r.logger.Debugw("backfilled batch", "backfill", b.Name, "batch", batch, "rows", filled, "total", total)
+
+		if r.pause == 0 {
+			if err := ctx.Err(); err != nil {
+				return total, err
+			}
+
+			continue
+		}
+
+		timer := time.NewTimer(r.pause)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return total, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// ErrBinaryTooOld is returned when an applied migration declares binaries newer than the running
+// one as the oldest compatible with the schema.
+var ErrBinaryTooOld = errors.New("binary too old for the database schema")
+
+// MinBinaryVersion returns the version of the oldest binary compatible with the schema, the
+// newest declared by the applied migrations, zero when none declares one.
+func MinBinaryVersion(ctx context.Context, client *ent.Client) (int64, error) {
+	rows, err := client.QueryContext(ctx, `SELECT COALESCE(MAX("min_binary_version"), 0) FROM "schema_compatibility"`)
+	if err != nil {
+		return 0, fmt.Errorf("reading the schema compatibility: %w", err)
+	}
+
+	defer rows.Close()
+
+	var required int64
+
+	if rows.Next() {
+		if err := rows.Scan(&required); err != nil {
+			return 0, err
+		}
+	}
+
+	return required, rows.Err()
+}
+
+// CheckCompatible returns an error wrapping ErrBinaryTooOld when the schema requires binaries
+// newer than version, the version of the latest migration the running binary embeds.
+func CheckCompatible(ctx context.Context, client *ent.Client, version int64) error {
+	required, err := MinBinaryVersion(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if required > version {
+		return fmt.Errorf("%w: the schema requires binaries of version %d or newer, this one is %d", ErrBinaryTooOld, required, version)
+	}
+
+	return nil
+}
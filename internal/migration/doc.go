@@ -0,0 +1,42 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migration changes the schema without downtime. During a rolling deploy pods of the
+// previous release keep serving against the migrated schema, so schema changes which old binaries
+// can't work with are split in steps shipped in separate releases:
+//
+//   - expand: a migration adds the new column, nullable and without a default so old binaries
+//     keep writing rows. It declares no compatibility.
+//   - write both: the binaries of the release write the new column along with the old one, with a
+//     hook such as VersionHook.
+//   - backfill: once no old binary runs anymore, the backfill command fills the column on the rows
+//     written before, in batches, see Backfill and Runner.
+//   - contract: a migration of a later release makes the column required or drops the old one. It
+//     declares the binaries writing both as the oldest compatible ones.
+//
+// Binaries are versioned by the latest migration they embed, so compatibility doesn't depend on
+// release tags. A migration declares the oldest binary compatible with it by inserting its own
+// version and that binary version into the schema_compatibility table, deleting the row when
+// reverted:
+//
+//	-- +goose Up
+//	ALTER TABLE "tenants" ALTER COLUMN "version" SET NOT NULL;
+//	INSERT INTO "schema_compatibility" ("id", "min_binary_version") VALUES (20261101000000, 20261018110000);
+//	-- +goose Down
+//	DELETE FROM "schema_compatibility" WHERE "id" = 20261101000000;
+//	ALTER TABLE "tenants" ALTER COLUMN "version" DROP NOT NULL;
+//
+// The startup schema check of a binary older than the schema refuses to run it when an applied
+// migration declares a newer binary, see CheckCompatible.
+package migration
@@ -0,0 +1,135 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/migration"
+)
+
+func newClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestCheckCompatible(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	// no migration declares anything
+	require.NoError(t, migration.CheckCompatible(ctx, client, 20230101000000))
+
+	client.SchemaCompatibility.Create().SetID(20230202000000).SetMinBinaryVersion(20230101000000).ExecX(ctx)
+	client.SchemaCompatibility.Create().SetID(20230303000000).SetMinBinaryVersion(20230202000000).ExecX(ctx)
+
+	minimum, err := migration.MinBinaryVersion(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230202000000), minimum)
+
+	assert.NoError(t, migration.CheckCompatible(ctx, client, 20230202000000))
+	assert.NoError(t, migration.CheckCompatible(ctx, client, 20230303000000))
+	assert.ErrorIs(t, migration.CheckCompatible(ctx, client, 20230101000000), migration.ErrBinaryTooOld)
+}
+
+func TestVersionHook(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	client.Tenant.Use(migration.VersionHook())
+
+	tnt := client.Tenant.Create().SetName("tenant").SaveX(ctx)
+	require.NotNil(t, tnt.Version)
+	assert.Equal(t, int64(1), *tnt.Version)
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDescription("updated").SaveX(ctx)
+	assert.Equal(t, int64(2), *tnt.Version)
+
+	client.Tenant.Update().Where(enttenant.ID(tnt.ID)).SetDescription("again").ExecX(ctx)
+	assert.Equal(t, int64(3), *client.Tenant.GetX(ctx, tnt.ID).Version)
+
+	// written by a binary predating the column
+	client.Tenant.Update().Where(enttenant.ID(tnt.ID)).ClearVersion().ExecX(ctx)
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDescription("by a new binary").SaveX(ctx)
+	assert.Equal(t, int64(1), *tnt.Version)
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	// created by a binary predating the column, without the hook
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		client.Tenant.Create().SetName(name).ExecX(ctx)
+	}
+
+	written := client.Tenant.Create().SetName("written").SetVersion(4).SaveX(ctx)
+
+	var batches []int
+
+	counted := migration.VersionBackfill
+	counted.Batch = func(ctx context.Context, client *ent.Client, size int) (int, error) {
+		filled, err := migration.VersionBackfill.Batch(ctx, client, size)
+		batches = append(batches, filled)
+
+		return filled, err
+	}
+
+	filled, err := migration.NewRunner(migration.WithBatchSize(2)).Run(ctx, client, counted)
+	require.NoError(t, err)
+	assert.Equal(t, 5, filled)
+	assert.Equal(t, []int{2, 2, 1, 0}, batches)
+
+	assert.Zero(t, client.Tenant.Query().Where(enttenant.VersionIsNil()).CountX(ctx))
+	assert.Equal(t, 5, client.Tenant.Query().Where(enttenant.Version(1)).CountX(ctx))
+	assert.Equal(t, int64(4), *client.Tenant.GetX(ctx, written.ID).Version, "written versions are kept")
+
+	// done, running it again fills nothing
+	filled, err = migration.NewRunner().Run(ctx, client, migration.VersionBackfill)
+	require.NoError(t, err)
+	assert.Zero(t, filled)
+}
+
+func TestRunnerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newClient(t)
+
+	client.Tenant.Create().SetName("a").ExecX(ctx)
+	client.Tenant.Create().SetName("b").ExecX(ctx)
+
+	counted := migration.VersionBackfill
+	counted.Batch = func(ctx context.Context, client *ent.Client, size int) (int, error) {
+		defer cancel()
+
+		return migration.VersionBackfill.Batch(ctx, client, size)
+	}
+
+	filled, err := migration.NewRunner(migration.WithBatchSize(1)).Run(ctx, client, counted)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, filled)
+
+	// resumed where it stopped
+	filled, err = migration.NewRunner().Run(context.Background(), client, migration.VersionBackfill)
+	require.NoError(t, err)
+	assert.Equal(t, 1, filled)
+}
+
+func TestLookupBackfill(t *testing.T) {
+	b, ok := migration.LookupBackfill("tenant-version")
+	require.True(t, ok)
+	assert.Equal(t, migration.VersionBackfill.Name, b.Name)
+
+	_, ok = migration.LookupBackfill("missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,63 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+)
+
+// VersionBackfill sets the version of the tenants written by binaries predating the version
+// column to one.
+var VersionBackfill = Backfill{
+	Name:        "tenant-version",
+	Description: "sets the version of tenants written before the version column to one",
+	Batch:       backfillVersion,
+}
+
+// VersionHook writes the version of tenants, one when they are created and incremented by every
+// update. It is the write both step of the version column. Tenants updated before the backfill
+// start at one as well, nothing reads the version until the column is contracted.
+func VersionHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if _, ok := m.Version(); ok {
+					return next.Mutate(ctx, m)
+				}
+
+				if _, ok := m.AddedVersion(); ok || m.VersionCleared() {
+					return next.Mutate(ctx, m)
+				}
+
+				if m.Op().Is(ent.OpCreate) {
+					m.SetVersion(1)
+				} else {
+					m.AddVersion(1)
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// backfillVersion fills the version of a batch of tenants without one. The statement bypasses the
+// hooks, filling the version is neither a change of the tenants nor an update of their version.
+func backfillVersion(ctx context.Context, client *generated.Client, size int) (int, error) {
+	res, err := client.ExecContext(ctx, fmt.Sprintf(
+		`UPDATE "tenants" SET "version" = 1 WHERE "id" IN (SELECT "id" FROM "tenants" WHERE "version" IS NULL LIMIT %d)`,
+		size,
+	))
+	if err != nil {
+		return 0, err
+	}
+
+	filled, err := res.RowsAffected()
+
+	return int(filled), err
+}
@@ -0,0 +1,126 @@
+package protection
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+type confirmationKey struct{}
+
+type confirmation struct {
+	name string
+	used atomic.Bool
+}
+
+// Confirm returns a context confirming the deletion of protected tenants with the name, they are
+// only deleted when it is exactly theirs.
+func Confirm(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, confirmationKey{}, &confirmation{name: name})
+}
+
+// Confirmed reports whether a protected tenant was deleted with the confirmation of the context.
+func Confirmed(ctx context.Context) bool {
+	c, ok := ctx.Value(confirmationKey{}).(*confirmation)
+
+	return ok && c.used.Load()
+}
+
+// Hook returns an ent hook rejecting the deletion of protected tenants unless the context confirms
+// their name, see Confirm, and lifting the protection without the admin scope. Nobody lifts it
+// when the scope is empty. It applies to every deletion, so the deletion of a subtree holding a
+// protected tenant is rejected as well.
+func Hook(adminScope string) ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				switch {
+				case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+					if err := checkDelete(ctx, m); err != nil {
+						return nil, err
+					}
+				case lifts(m) && (adminScope == "" || !scopes.Has(ctx, adminScope)):
+					if err := checkLift(ctx, m, adminScope); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+// lifts reports whether the mutation lifts the protection of the tenants it changes.
+func lifts(m *generated.TenantMutation) bool {
+	protected, ok := m.DeletionProtected()
+
+	return (ok && !protected) || m.DeletionProtectedCleared()
+}
+
+// protectedTenants returns the tenants changed by the mutation which are protected.
+func protectedTenants(ctx context.Context, m *generated.TenantMutation) ([]*generated.Tenant, error) {
+	ids, err := m.IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	return m.Client().Tenant.Query().
+		Where(enttenant.IDIn(ids...), enttenant.DeletionProtected(true)).
+		Select(enttenant.FieldName).
+		All(ctx)
+}
+
+// checkDelete returns an error for the first protected tenant the context doesn't confirm the
+// name of.
+func checkDelete(ctx context.Context, m *generated.TenantMutation) error {
+	tenants, err := protectedTenants(ctx, m)
+	if err != nil || len(tenants) == 0 {
+		return err
+	}
+
+	c, _ := ctx.Value(confirmationKey{}).(*confirmation)
+
+	for _, t := range tenants {
+		switch {
+		case c == nil:
+			return protectedError(t.ID, "confirm its name to delete it")
+		case c.name != t.Name:
+			return protectedError(t.ID, "the confirmed name doesn't match its name")
+		}
+	}
+
+	c.used.Store(true)
+
+	return nil
+}
+
+// checkLift returns an error when one of the tenants whose protection is lifted is protected.
+func checkLift(ctx context.Context, m *generated.TenantMutation, adminScope string) error {
+	tenants, err := protectedTenants(ctx, m)
+	if err != nil || len(tenants) == 0 {
+		return err
+	}
+
+	if adminScope == "" {
+		return fmt.Errorf("%w: the deletion protection of tenant %s can't be lifted", permissions.ErrPermissionDenied, tenants[0].ID)
+	}
+
+	return fmt.Errorf("%w: lifting the deletion protection of a tenant requires the %s scope", permissions.ErrPermissionDenied, adminScope)
+}
+
+func protectedError(id gidx.PrefixedID, reason string) error {
+	return apierrors.New(apierrors.ErrDeletionProtected, fmt.Sprintf("tenant %s is protected against deletion, %s", id, reason))
+}
@@ -0,0 +1,79 @@
+package protection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/protection"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestHookDelete(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(protection.Hook("tenants:admin"))
+
+	root := client.Tenant.Create().SetName("production").SetDeletionProtected(true).SaveX(ctx)
+	other := client.Tenant.Create().SetName("staging").SaveX(ctx)
+
+	err := client.Tenant.DeleteOne(root).Exec(ctx)
+	assert.True(t, errors.Is(err, apierrors.ErrDeletionProtected), "deleting without confirmation is refused")
+
+	mismatch := protection.Confirm(ctx, "staging")
+
+	err = client.Tenant.DeleteOne(root).Exec(mismatch)
+	assert.True(t, errors.Is(err, apierrors.ErrDeletionProtected), "deleting with another name is refused")
+	assert.False(t, protection.Confirmed(mismatch))
+
+	_, err = client.Tenant.Delete().Where(enttenant.IDIn(root.ID, other.ID)).Exec(ctx)
+	assert.True(t, errors.Is(err, apierrors.ErrDeletionProtected), "bulk deletes holding a protected tenant are refused")
+	assert.Equal(t, 2, client.Tenant.Query().CountX(ctx))
+
+	require.NoError(t, client.Tenant.DeleteOne(other).Exec(ctx), "unprotected tenants are deleted as usual")
+
+	confirmed := protection.Confirm(ctx, "production")
+
+	require.NoError(t, client.Tenant.DeleteOne(root).Exec(confirmed))
+	assert.True(t, protection.Confirmed(confirmed))
+	assert.Zero(t, client.Tenant.Query().CountX(ctx))
+}
+
+func TestHookLift(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(protection.Hook("tenants:admin"))
+
+	tnt := client.Tenant.Create().SetName("production").SaveX(ctx)
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDeletionProtected(true).SaveX(ctx)
+	assert.True(t, tnt.DeletionProtected, "anyone may protect a tenant")
+
+	err := client.Tenant.UpdateOne(tnt).SetDeletionProtected(false).Exec(ctx)
+	assert.True(t, errors.Is(err, permissions.ErrPermissionDenied), "lifting without the scope is denied")
+
+	err = client.Tenant.UpdateOne(tnt).ClearDeletionProtected().Exec(ctx)
+	assert.True(t, errors.Is(err, permissions.ErrPermissionDenied), "clearing without the scope is denied")
+
+	require.NoError(t, client.Tenant.UpdateOne(tnt).SetDescription("prod").Exec(ctx), "other changes are let through")
+
+	adminCtx := scopes.WithScopes(ctx, []string{"tenants:admin"})
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDeletionProtected(false).SaveX(adminCtx)
+	assert.False(t, tnt.DeletionProtected)
+}
@@ -0,0 +1,201 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.infratographer.com/x/events"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/pkg/eventcrypt"
+)
+
+const (
+	// DefaultMaxDeliveries is the default number of times a message is delivered before giving up.
+	DefaultMaxDeliveries = 5
+
+	// DefaultRetryDelay is the default delay before a failed message is delivered again.
+	DefaultRetryDelay = 10 * time.Second
+)
+
+// ErrPermanent is wrapped by handler errors which retrying the message won't fix.
+var ErrPermanent = errors.New("permanent failure")
+
+// Handler handles a change received on a subscribed topic. Returning an error delivers the
+// message again later unless the error wraps ErrPermanent, so handlers must be idempotent.
+type Handler func(ctx context.Context, msg events.ChangeMessage) error
+
+// Option configures a Consumer.
+type Option func(*Consumer)
+
+// WithMaxDeliveries sets the number of times a message is delivered before it is dropped.
+func WithMaxDeliveries(n int) Option {
+	return func(c *Consumer) {
+		if n > 0 {
+			c.maxDeliveries = uint64(n)
+		}
+	}
+}
+
+// WithRetryDelay sets the delay before a failed message is delivered again.
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *Consumer) {
+		if d >= 0 {
+			c.retryDelay = d
+		}
+	}
+}
+
+// WithDecryption decrypts the payloads of changes encrypted with eventcrypt.Encrypt before
+// handling them, changes which aren't encrypted are handled as they are. Changes which can't be
+// decrypted are dropped like those which can't be decoded.
+func WithDecryption(keys eventcrypt.KeyProvider) Option {
+	return func(c *Consumer) {
+		c.keys = keys
+	}
+}
+
+// Consumer subscribes to the topics handlers are registered for and dispatches the received
+// changes to them. A message is acked once every handler of its topic succeeded, nacked to be
+// delivered again when one failed and terminated once it can't succeed: when it can't be decoded,
+// a handler failed permanently or it was delivered the maximum number of times.
+type Consumer struct {
+	subscriber    events.Subscriber
+	logger        *zap.SugaredLogger
+	maxDeliveries uint64
+	retryDelay    time.Duration
+	keys          eventcrypt.KeyProvider
+	topics        []string
+	handlers      map[string][]Handler
+}
+
+// NewConsumer returns a consumer receiving changes from the subscriber.
+func NewConsumer(subscriber events.Subscriber, logger *zap.SugaredLogger, opts ...Option) *Consumer {
+	c := &Consumer{
+		subscriber:    subscriber,
+		logger:        logger,
+		maxDeliveries: DefaultMaxDeliveries,
+		retryDelay:    DefaultRetryDelay,
+		handlers:      map[string][]Handler{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Handle registers the handler for the changes on the topic, which is subscribed to the same as
+// with events.Subscriber.SubscribeChanges. Handlers of a topic run in the order registered.
+// Handlers must be registered before Run.
+func (c *Consumer) Handle(topic string, handler Handler) {
+	if _, ok := c.handlers[topic]; !ok {
+		c.topics = append(c.topics, topic)
+	}
+
+	c.handlers[topic] = append(c.handlers[topic], handler)
+}
+
+// Run subscribes to every topic with handlers and dispatches the changes received until the
+// context is done. It returns early when subscribing fails.
+func (c *Consumer) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	subscriptions := make([]<-chan events.Message[events.ChangeMessage], 0, len(c.topics))
+
+	for _, topic := range c.topics {
+		messages, err := c.subscriber.SubscribeChanges(ctx, topic)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", topic, err)
+		}
+
+		subscriptions = append(subscriptions, messages)
+	}
+
+	var wg sync.WaitGroup
+
+	for i, messages := range subscriptions {
+		wg.Add(1)
+
+		go func(topic string, messages <-chan events.Message[events.ChangeMessage]) {
+			defer wg.Done()
+
+			for msg := range messages {
+				c.dispatch(ctx, topic, msg)
+			}
+		}(c.topics[i], messages)
+	}
+
+	<-ctx.Done()
+
+	wg.Wait()
+
+	return nil
+}
+
+// dispatch runs the handlers of the topic for the message and settles it.
+func (c *Consumer) dispatch(ctx context.Context, topic string, msg events.Message[events.ChangeMessage]) {
+	logger := c.logger.With(
+		"topic", msg.Topic(),
+		"message_id", msg.ID(),
+		"deliveries", msg.Deliveries(),
+	)
+
+	if err := msg.Error(); err != nil {
+		logger.Errorw("dropping message which can't be decoded", "error", err)
+
+		settle(logger, msg.Term())
+
+		return
+	}
+
+	change := msg.Message()
+
+	if c.keys != nil {
+		var err error
+
+		if change, err = eventcrypt.Decrypt(ctx, c.keys, change); err != nil {
+			logger.Errorw("dropping message which can't be decrypted", "error", err)
+
+			settle(logger, msg.Term())
+
+			return
+		}
+	}
+
+	ctx = reqlog.WithLogger(change.GetTraceContext(ctx), logger.With("subject_id", change.SubjectID))
+
+	var err error
+
+	for _, handler := range c.handlers[topic] {
+		if err = handler(ctx, change); err != nil {
+			break
+		}
+	}
+
+	switch {
+	case err == nil:
+		settle(logger, msg.Ack())
+	case errors.Is(err, ErrPermanent), msg.Deliveries() >= c.maxDeliveries:
+		logger.Errorw("dropping message which failed to be handled", "error", err)
+
+		settle(logger, msg.Term())
+	default:
+		logger.Warnw("failed to handle message, retrying", "error", err, "retry_delay", c.retryDelay)
+
+		settle(logger, msg.Nak(c.retryDelay))
+	}
+}
+
+// settle logs failures to ack, nack or terminate the message.
+func settle(logger *zap.SugaredLogger, err error) {
+	if err != nil {
+		logger.Errorw("failed to settle message", "error", err)
+	}
+}
@@ -0,0 +1,169 @@
+package pubsub_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/pubsub"
+)
+
+const ownerTopic = "resource-provider"
+
+// newConnection starts an embedded nats server and returns a connection to it.
+func newConnection(t *testing.T) events.Connection {
+	t.Helper()
+
+	nats, err := eventtools.NewNatsServer()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		nats.Close()
+		nats.Server.Shutdown()
+	})
+
+	cfg := nats.Config
+	cfg.NATS.Source = "tenant-api-test"
+	cfg.NATS.SubscriberFetchTimeout = 100 * time.Millisecond
+	cfg.NATS.SubscriberFetchBackoff = 10 * time.Millisecond
+
+	conn, err := events.NewConnection(cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Shutdown(context.Background()) }) //nolint:errcheck
+
+	return conn
+}
+
+// run runs the consumer until the test ends.
+func run(t *testing.T, consumer *pubsub.Consumer) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() { done <- consumer.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
+
+func publish(t *testing.T, conn events.Connection, topic, eventType string, subject gidx.PrefixedID) {
+	t.Helper()
+
+	_, err := conn.PublishChange(context.Background(), topic, events.ChangeMessage{
+		SubjectID: subject,
+		EventType: eventType,
+		Timestamp: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+}
+
+func TestConsumerRetries(t *testing.T) {
+	conn := newConnection(t)
+
+	var (
+		mu    sync.Mutex
+		calls = map[gidx.PrefixedID]int{}
+	)
+
+	consumer := pubsub.NewConsumer(conn, zap.NewNop().Sugar(), pubsub.WithMaxDeliveries(3), pubsub.WithRetryDelay(0))
+
+	consumer.Handle("*."+ownerTopic, func(_ context.Context, msg events.ChangeMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls[msg.SubjectID]++
+
+		switch msg.SubjectID {
+		case "rsrcprv-flaky":
+			if calls[msg.SubjectID] == 1 {
+				return errors.New("temporary failure")
+			}
+		case "rsrcprv-broken":
+			return fmt.Errorf("invalid owner: %w", pubsub.ErrPermanent)
+		case "rsrcprv-failing":
+			return errors.New("always failing")
+		}
+
+		return nil
+	})
+
+	run(t, consumer)
+
+	for _, subject := range []gidx.PrefixedID{"rsrcprv-flaky", "rsrcprv-broken", "rsrcprv-failing", "rsrcprv-working"} {
+		publish(t, conn, ownerTopic, "update", subject)
+	}
+
+	expected := map[gidx.PrefixedID]int{
+		"rsrcprv-flaky":   2,
+		"rsrcprv-broken":  1,
+		"rsrcprv-failing": 3,
+		"rsrcprv-working": 1,
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return assert.ObjectsAreEqual(expected, calls)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// settled messages aren't delivered again
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, expected, calls)
+}
+
+func TestSuspendOnOwnerDelete(t *testing.T) {
+	ctx := context.Background()
+
+	conn := newConnection(t)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	owner := gidx.MustNewID("rsrcprv")
+	other := gidx.MustNewID("rsrcprv")
+	suspendedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	root := client.Tenant.Create().SetName("root").SetOwnerID(owner).SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetOwnerID(owner).SetParent(root).SaveX(ctx)
+	suspended := client.Tenant.Create().SetName("suspended").SetOwnerID(owner).SetSuspendedAt(suspendedAt).SaveX(ctx)
+	unrelated := client.Tenant.Create().SetName("unrelated").SetOwnerID(other).SaveX(ctx)
+
+	consumer := pubsub.NewConsumer(conn, zap.NewNop().Sugar())
+	consumer.Handle("*."+ownerTopic, pubsub.SuspendOnOwnerDelete(client, zap.NewNop().Sugar()))
+
+	run(t, consumer)
+
+	// only deletions of the owner suspend its tenants
+	publish(t, conn, ownerTopic, "update", other)
+	publish(t, conn, ownerTopic, "delete", owner)
+
+	assert.Eventually(t, func() bool {
+		return !client.Tenant.GetX(ctx, root.ID).SuspendedAt.IsZero()
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.True(t, client.Tenant.GetX(ctx, child.ID).SuspendedAt.IsZero(), "only root tenants are suspended")
+	assert.True(t, client.Tenant.GetX(ctx, unrelated.ID).SuspendedAt.IsZero())
+	assert.True(t, suspendedAt.Equal(client.Tenant.GetX(ctx, suspended.ID).SuspendedAt), "suspended tenants keep their suspension time")
+}
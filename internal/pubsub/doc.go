@@ -0,0 +1,20 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pubsub consumes the change events other services publish and dispatches them to handlers.
+//
+// It also wires the encryption of package eventcrypt into the server: the payloads of the change
+// events published are encrypted, for deployments sharing the events server with less trusted
+// workloads, and those consumed are decrypted.
+package pubsub
@@ -0,0 +1,34 @@
+package pubsub
+
+import (
+	"context"
+
+	"go.infratographer.com/x/events"
+
+	"go.infratographer.com/tenant-api/pkg/eventcrypt"
+)
+
+type encryptingConnection struct {
+	events.Connection
+
+	keys eventcrypt.KeyProvider
+}
+
+// EncryptConnection wraps the events connection to encrypt the payloads of the changes it
+// publishes with eventcrypt.Encrypt, for deployments sharing the events server with less trusted
+// workloads. Subscriptions through it are left as they are, consumers decrypt with
+// eventcrypt.Decrypt or WithDecryption. A connection signing changes must be wrapped by this one,
+// so it signs what is published.
+func EncryptConnection(conn events.Connection, keys eventcrypt.KeyProvider) events.Connection {
+	return &encryptingConnection{Connection: conn, keys: keys}
+}
+
+// PublishChange encrypts the payload of the change and publishes it.
+func (c *encryptingConnection) PublishChange(ctx context.Context, topic string, message events.ChangeMessage) (events.Message[events.ChangeMessage], error) {
+	encrypted, err := eventcrypt.Encrypt(ctx, c.keys, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Connection.PublishChange(ctx, topic, encrypted)
+}
@@ -0,0 +1,127 @@
+package pubsub_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/pubsub"
+	"go.infratographer.com/tenant-api/pkg/eventcrypt"
+)
+
+// key returns a key entry of the id with a key of 32 bytes of the letter.
+func key(id string, letter string) string {
+	return id + "=" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(letter, 32)))
+}
+
+func keys(t *testing.T, entries ...string) *eventcrypt.StaticKeys {
+	t.Helper()
+
+	k, err := eventcrypt.ParseKeys(entries)
+	require.NoError(t, err)
+
+	return k
+}
+
+func change() events.ChangeMessage {
+	return events.ChangeMessage{
+		SubjectID:            "tnntten-secret",
+		EventType:            string(events.UpdateChangeType),
+		AdditionalSubjectIDs: []gidx.PrefixedID{"tnntten-parent"},
+		ActorID:              "idntusr-admin",
+		Source:               "tenant-api",
+		Timestamp:            time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		SubjectFields:        map[string]string{"name": "acme"},
+		FieldChanges:         []events.FieldChange{{Field: "billing_reference", PreviousValue: "", CurrentValue: "PO-1234"}},
+		AdditionalData: map[string]any{
+			changefeed.EventIDKey:    "4e4c0c1e",
+			changefeed.OccurredAtKey: "2026-10-01T12:00:00.000Z",
+			changefeed.SnapshotKey:   map[string]any{"contactEmail": "ops@example.com"},
+		},
+	}
+}
+
+func TestEncryptionConsumerContract(t *testing.T) {
+	msg := change()
+	msg.AdditionalData[changefeed.ResourceVersionKey] = 3
+
+	encrypted, err := eventcrypt.Encrypt(context.Background(), keys(t, key("k1", "a")), msg)
+	require.NoError(t, err)
+
+	// the keys consumers order and deduplicate changes with stay in clear text
+	for _, k := range []string{changefeed.EventIDKey, changefeed.ResourceVersionKey, changefeed.OccurredAtKey} {
+		assert.Equal(t, msg.AdditionalData[k], encrypted.AdditionalData[k], k)
+	}
+
+	assert.NotContains(t, encrypted.AdditionalData, changefeed.SnapshotKey)
+}
+
+func TestConsumerDecryption(t *testing.T) {
+	conn := newConnection(t)
+	encrypting := pubsub.EncryptConnection(conn, keys(t, key("k1", "a")))
+
+	var (
+		mu       sync.Mutex
+		received []events.ChangeMessage
+	)
+
+	consumer := pubsub.NewConsumer(conn, zap.NewNop().Sugar(), pubsub.WithDecryption(keys(t, key("k1", "a"))))
+	consumer.Handle("*."+ownerTopic, func(_ context.Context, msg events.ChangeMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		received = append(received, msg)
+
+		return nil
+	})
+
+	run(t, consumer)
+
+	// the change published without encryption is handled as it is, the one encrypted with an
+	// unknown key is dropped
+	publish(t, conn, ownerTopic, "update", "tnntten-plain")
+
+	unknown, err := eventcrypt.Encrypt(context.Background(), keys(t, key("k0", "z")), change())
+	require.NoError(t, err)
+
+	unknown.SubjectID = "tnntten-unknown"
+
+	_, err = conn.PublishChange(context.Background(), ownerTopic, unknown)
+	require.NoError(t, err)
+
+	_, err = encrypting.PublishChange(context.Background(), ownerTopic, change())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(received) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// nothing else is delivered
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, received, 2)
+	assert.Equal(t, gidx.PrefixedID("tnntten-plain"), received[0].SubjectID)
+
+	decrypted := received[1]
+	assert.Equal(t, gidx.PrefixedID("tnntten-secret"), decrypted.SubjectID)
+	assert.Equal(t, map[string]string{"name": "acme"}, decrypted.SubjectFields)
+	assert.Equal(t, change().FieldChanges, decrypted.FieldChanges)
+	assert.Equal(t, change().AdditionalData[changefeed.SnapshotKey], decrypted.AdditionalData[changefeed.SnapshotKey])
+	assert.NotContains(t, decrypted.AdditionalData, eventcrypt.EncryptedPayloadKey)
+}
@@ -0,0 +1,55 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.infratographer.com/x/events"
+	"go.uber.org/zap"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+)
+
+// SuspendOnOwnerDelete returns a handler suspending the root tenants owned by the subject of a
+// delete change, other changes are ignored. Tenants which are already suspended keep their
+// suspension time, so redelivered changes don't change anything. The context the consumer runs
+// with must carry the auth relationship handler used by the event hooks.
+func SuspendOnOwnerDelete(client *ent.Client, logger *zap.SugaredLogger) Handler {
+	return func(ctx context.Context, msg events.ChangeMessage) error {
+		if msg.EventType != string(events.DeleteChangeType) {
+			return nil
+		}
+
+		ids, err := client.Tenant.Query().
+			Where(
+				tenant.OwnerID(msg.SubjectID),
+				tenant.ParentTenantIDIsNil(),
+				tenant.SuspendedAtIsNil(),
+			).
+			IDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		var errs []error
+
+		for _, id := range ids {
+			err := client.Tenant.UpdateOneID(id).SetSuspendedAt(now).Exec(ctx)
+
+			switch {
+			case err == nil:
+				reqlog.FromContext(ctx, logger).Infow("suspended tenant of deleted owner", "tenant_id", id)
+			case ent.IsNotFound(err):
+			default:
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
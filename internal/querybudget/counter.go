@@ -0,0 +1,59 @@
+package querybudget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrExceeded is returned for the queries of a request past its budget.
+var ErrExceeded = errors.New("query budget exceeded")
+
+type counterCtxKey struct{}
+
+// Counter counts the queries of a request.
+type Counter struct {
+	count  atomic.Int64
+	budget int64
+}
+
+// NewContext returns a context counting the queries issued with it, and the counter. A budget
+// greater than zero fails the queries past it with ErrExceeded.
+func NewContext(ctx context.Context, budget int) (context.Context, *Counter) {
+	c := &Counter{budget: int64(budget)}
+
+	return context.WithValue(ctx, counterCtxKey{}, c), c
+}
+
+// FromContext returns the counter of the context, nil when it has none.
+func FromContext(ctx context.Context) *Counter {
+	c, _ := ctx.Value(counterCtxKey{}).(*Counter)
+
+	return c
+}
+
+// Count returns the number of queries counted.
+func (c *Counter) Count() int64 {
+	return c.count.Load()
+}
+
+// Exceeded reports whether more queries were issued than the budget allows.
+func (c *Counter) Exceeded() bool {
+	return c.budget > 0 && c.count.Load() > c.budget
+}
+
+// add counts a query of the context, if it has a counter.
+func add(ctx context.Context) error {
+	c := FromContext(ctx)
+	if c == nil {
+		return nil
+	}
+
+	n := c.count.Add(1)
+	if c.budget > 0 && n > c.budget {
+		return fmt.Errorf("%w: query %d of a budget of %d", ErrExceeded, n, c.budget)
+	}
+
+	return nil
+}
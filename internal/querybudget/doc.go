@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package querybudget counts the database queries each request issues. The ent driver is wrapped
+// with Driver and the counter attached to the request context by Middleware, which reports the
+// count as a span attribute and, when asked to, a response header. A budget can be set to fail
+// requests issuing more queries, meant for tests to catch N+1 regressions.
+package querybudget
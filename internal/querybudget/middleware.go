@@ -0,0 +1,76 @@
+package querybudget
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// HeaderQueryCount is the response header reporting the queries of the request.
+	HeaderQueryCount = "X-Query-Count"
+	// AttributeQueryCount is the span attribute reporting the queries of the request.
+	AttributeQueryCount = "db.query_count"
+)
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	budget int
+	header bool
+}
+
+// WithBudget fails the requests issuing more than budget queries, zero doesn't limit them.
+func WithBudget(budget int) Option {
+	return func(c *config) {
+		c.budget = budget
+	}
+}
+
+// WithHeader reports the queries of each request in the X-Query-Count response header. The
+// count is the one when the response headers are written, streamed responses may issue more.
+func WithHeader(enabled bool) Option {
+	return func(c *config) {
+		c.header = enabled
+	}
+}
+
+// Middleware counts the queries each request issues through a client using Driver. The count is
+// set as the db.query_count attribute of the span of the request. With a budget, the queries past
+// it fail with ErrExceeded, and the middleware returns it when the handler ignored the failure.
+func Middleware(opts ...Option) echo.MiddlewareFunc {
+	cfg := new(config)
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx, counter := NewContext(req.Context(), cfg.budget)
+			c.SetRequest(req.WithContext(ctx))
+
+			if cfg.header {
+				resp := c.Response()
+				resp.Before(func() {
+					resp.Header().Set(HeaderQueryCount, strconv.FormatInt(counter.Count(), 10))
+				})
+			}
+
+			err := next(c)
+
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Int64(AttributeQueryCount, counter.Count()))
+
+			if err == nil && counter.Exceeded() {
+				err = ErrExceeded
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package querybudget_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/querybudget"
+)
+
+func newClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(querybudget.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	return client
+}
+
+func TestDriverCounts(t *testing.T) {
+	client := newClient(t)
+
+	ctx, counter := querybudget.NewContext(context.Background(), 0)
+
+	client.Tenant.Create().SetName("root").ExecX(ctx)
+	client.Tenant.Query().AllX(ctx)
+
+	rows, err := client.QueryContext(ctx, "SELECT 1")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	tx, err := client.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	tx.Tenant.Query().CountX(ctx)
+	require.NoError(t, tx.Commit())
+
+	assert.EqualValues(t, 4, counter.Count())
+	assert.False(t, counter.Exceeded())
+
+	client.Tenant.Query().AllX(context.Background())
+	assert.EqualValues(t, 4, counter.Count(), "queries without a counter aren't counted")
+}
+
+func TestDriverBudget(t *testing.T) {
+	client := newClient(t)
+
+	ctx, counter := querybudget.NewContext(context.Background(), 2)
+
+	client.Tenant.Query().AllX(ctx)
+	client.Tenant.Query().AllX(ctx)
+
+	_, err := client.Tenant.Query().All(ctx)
+	assert.ErrorIs(t, err, querybudget.ErrExceeded)
+	assert.True(t, counter.Exceeded())
+}
+
+func TestMiddleware(t *testing.T) {
+	client := newClient(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	// queries runs as many queries as the n query parameter has characters, ignoring their errors
+	// unless strict is set
+	queries := func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		for i := 0; i < len(c.QueryParam("n")); i++ {
+			if _, err := client.Tenant.Query().All(ctx); err != nil && c.QueryParam("strict") != "" {
+				return err
+			}
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+
+	serve := func(target string, opts ...querybudget.Option) (*httptest.ResponseRecorder, error) {
+		ctx, span := tracer.Start(context.Background(), "request")
+		defer span.End()
+
+		rec := httptest.NewRecorder()
+
+		e := echo.New()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, target, nil).WithContext(ctx), rec)
+
+		err := querybudget.Middleware(opts...)(queries)(c)
+		if err != nil {
+			e.HTTPErrorHandler(err, c)
+		}
+
+		return rec, err
+	}
+
+	rec, err := serve("/?n=xxx", querybudget.WithHeader(true))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get(querybudget.HeaderQueryCount))
+
+	rec, err = serve("/?n=xxx")
+	require.NoError(t, err)
+	assert.Empty(t, rec.Header().Get(querybudget.HeaderQueryCount))
+
+	rec, err = serve("/?n=xxx&strict=1", querybudget.WithBudget(2))
+	assert.ErrorIs(t, err, querybudget.ErrExceeded)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	_, err = serve("/?n=xxx", querybudget.WithBudget(2))
+	assert.ErrorIs(t, err, querybudget.ErrExceeded, "requests over the budget fail even when the handler ignores the failed query")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64(querybudget.AttributeQueryCount, 3))
+}
@@ -0,0 +1,198 @@
+package querycost
+
+import (
+	"fmt"
+
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// DefaultUnscopedMaxLimit is the default largest page a query which isn't limited to the children
+// of a parent may request.
+const DefaultUnscopedMaxLimit = 20
+
+// Fields the errors of rejected queries are reported on.
+const (
+	fieldScope = "parent_id"
+	fieldLimit = "limit"
+)
+
+// Action is what a rule requires of the queries it applies to.
+type Action int
+
+const (
+	// RequireScope rejects the queries which aren't limited to the children of a parent.
+	RequireScope Action = iota + 1
+	// RequireLimit rejects the queries requesting pages larger than the max limit of the rule.
+	RequireLimit
+	// Reject rejects every query the rule applies to.
+	Reject
+)
+
+// Query describes a list request.
+type Query struct {
+	// Filters are the names of the filters given.
+	Filters []string
+	// Sort is the field the results are sorted by, empty for the default order.
+	Sort string
+	// Scoped is set when the query is limited to the children of a parent.
+	Scoped bool
+	// Limit is the page size requested.
+	Limit int
+}
+
+func (q Query) has(filter string) bool {
+	for _, f := range q.Filters {
+		if f == filter {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rule applies to the queries given all its filters, sorted by its sort when set, and only to
+// unscoped ones when Unscoped is set. A rule without conditions applies to every query.
+type Rule struct {
+	Filters  []string
+	Sort     string
+	Unscoped bool
+
+	Action Action
+	// MaxLimit is the largest page allowed by a RequireLimit rule.
+	MaxLimit int
+	// Guidance tells the caller how to change a rejected query.
+	Guidance string
+}
+
+func (r Rule) appliesTo(q Query) bool {
+	if r.Unscoped && q.Scoped {
+		return false
+	}
+
+	if r.Sort != "" && r.Sort != q.Sort {
+		return false
+	}
+
+	for _, f := range r.Filters {
+		if !q.has(f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Rule) check(q Query) error {
+	switch r.Action {
+	case RequireScope:
+		if q.Scoped {
+			return nil
+		}
+
+		return r.error(fieldScope, "a parent scope is required")
+	case RequireLimit:
+		if q.Limit <= r.MaxLimit {
+			return nil
+		}
+
+		return r.error(fieldLimit, fmt.Sprintf("at most %d results may be requested", r.MaxLimit))
+	case Reject:
+		field := fieldScope
+		if len(r.Filters) != 0 {
+			field = r.Filters[0]
+		}
+
+		return r.error(field, "the combination of filters isn't supported")
+	default:
+		return nil
+	}
+}
+
+func (r Rule) error(field, message string) error {
+	if r.Guidance != "" {
+		message += ": " + r.Guidance
+	}
+
+	return &validation.Error{Field: field, Code: validation.CodeUnindexedQuery, Message: message}
+}
+
+// DefaultRules returns the rules of a deployment whose tenants are too many to scan: name filters
+// require a parent scope and unscoped queries request pages of at most unscopedMaxLimit tenants.
+func DefaultRules(unscopedMaxLimit int) []Rule {
+	if unscopedMaxLimit < 1 {
+		unscopedMaxLimit = DefaultUnscopedMaxLimit
+	}
+
+	return []Rule{
+		{
+			Filters:  []string{"name_contains"},
+			Action:   RequireScope,
+			Guidance: "name_contains scans every tenant without one, give parent_id",
+		},
+		{
+			Unscoped: true,
+			Action:   RequireLimit,
+			MaxLimit: unscopedMaxLimit,
+			Guidance: "give parent_id or a smaller limit",
+		},
+	}
+}
+
+// Option configures a Guard.
+type Option func(*Guard)
+
+// WithRules adds rules to the guard, queries must pass all of them.
+func WithRules(rules ...Rule) Option {
+	return func(g *Guard) {
+		g.rules = append(g.rules, rules...)
+	}
+}
+
+// WithExemption exempts the endpoint from the rules, its queries may request pages of at most
+// maxLimit results instead.
+func WithExemption(endpoint string, maxLimit int) Option {
+	return func(g *Guard) {
+		g.exempt[endpoint] = maxLimit
+	}
+}
+
+// Guard checks the queries of the list endpoints against its rules. A nil guard allows every query.
+type Guard struct {
+	rules  []Rule
+	exempt map[string]int
+}
+
+// New returns a guard.
+func New(opts ...Option) *Guard {
+	g := &Guard{exempt: make(map[string]int)}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// Check returns a validation error telling how to change the query when the endpoint may not
+// serve it.
+func (g *Guard) Check(endpoint string, q Query) error {
+	if g == nil {
+		return nil
+	}
+
+	if maxLimit, ok := g.exempt[endpoint]; ok {
+		return Rule{Action: RequireLimit, MaxLimit: maxLimit, Guidance: endpoint + " has its own limits"}.check(q)
+	}
+
+	for _, r := range g.rules {
+		if !r.appliesTo(q) {
+			continue
+		}
+
+		if err := r.check(q); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
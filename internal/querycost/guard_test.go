@@ -0,0 +1,67 @@
+package querycost_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestGuardCheck(t *testing.T) {
+	guard := querycost.New(
+		querycost.WithRules(querycost.DefaultRules(20)...),
+		querycost.WithRules(querycost.Rule{
+			Filters:  []string{"name_contains", "owner_id"},
+			Sort:     "name",
+			Action:   querycost.Reject,
+			Guidance: "sort by id",
+		}),
+		querycost.WithExemption("tenants.search", 50),
+	)
+
+	for _, tt := range []struct {
+		name     string
+		endpoint string
+		query    querycost.Query
+		field    string
+	}{
+		{"scoped", "tenants.list", querycost.Query{Scoped: true, Limit: 100}, ""},
+		{"scoped name filter", "tenants.list", querycost.Query{Filters: []string{"name_contains"}, Scoped: true, Limit: 100}, ""},
+		{"unscoped small page", "tenants.list", querycost.Query{Limit: 20}, ""},
+		{"unscoped large page", "tenants.list", querycost.Query{Limit: 21}, "limit"},
+		{"unscoped name filter", "tenants.list", querycost.Query{Filters: []string{"name_contains"}, Limit: 10}, "parent_id"},
+		{"rejected combination", "tenants.list", querycost.Query{Filters: []string{"owner_id", "name_contains"}, Sort: "name", Scoped: true, Limit: 10}, "name_contains"},
+		{"combination sorted otherwise", "tenants.list", querycost.Query{Filters: []string{"owner_id", "name_contains"}, Sort: "id", Scoped: true, Limit: 10}, ""},
+		{"partial combination", "tenants.list", querycost.Query{Filters: []string{"owner_id"}, Sort: "name", Scoped: true, Limit: 10}, ""},
+		{"exempt", "tenants.search", querycost.Query{Filters: []string{"name_contains"}, Limit: 50}, ""},
+		{"exempt over its limit", "tenants.search", querycost.Query{Filters: []string{"name_contains"}, Limit: 51}, "limit"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := guard.Check(tt.endpoint, tt.query)
+
+			if tt.field == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var verr *validation.Error
+
+			require.True(t, errors.As(err, &verr), "got %v", err)
+			assert.Equal(t, tt.field, verr.Field)
+			assert.Equal(t, validation.CodeUnindexedQuery, verr.Code)
+			assert.NotEmpty(t, verr.Message)
+		})
+	}
+}
+
+func TestGuardDisabled(t *testing.T) {
+	var guard *querycost.Guard
+
+	assert.NoError(t, guard.Check("tenants.list", querycost.Query{Filters: []string{"name_contains"}, Limit: 1000}))
+	assert.NoError(t, querycost.New().Check("tenants.list", querycost.Query{Filters: []string{"name_contains"}, Limit: 1000}))
+}
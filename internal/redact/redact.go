@@ -0,0 +1,170 @@
+package redact
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"go.infratographer.com/tenant-api/internal/scopes"
+)
+
+// Tenant fields which can be redacted, named as in the graph api. The id is always visible.
+const (
+	FieldID          = "id"
+	FieldName        = "name"
+	FieldDisplayName = "displayName"
+	FieldDescription = "description"
+	FieldParent      = "parent"
+	FieldCreatedAt   = "createdAt"
+	FieldUpdatedAt   = "updatedAt"
+
+	FieldDeletionScheduledAt = "deletionScheduledAt"
+	FieldSettings            = "settings"
+	FieldContactEmail        = "contactEmail"
+	FieldBillingReference    = "billingReference"
+	FieldOwner               = "owner"
+	FieldSuspendedAt         = "suspendedAt"
+	FieldExternalID          = "externalID"
+	FieldLabels              = "labels"
+
+	// AllFields grants every field when listed for a scope.
+	AllFields = "*"
+)
+
+var redactable = map[string]bool{
+	FieldName:        true,
+	FieldDisplayName: true,
+	FieldDescription: true,
+	FieldParent:      true,
+	FieldCreatedAt:   true,
+	FieldUpdatedAt:   true,
+
+	FieldDeletionScheduledAt: true,
+	FieldSettings:            true,
+	FieldContactEmail:        true,
+	FieldBillingReference:    true,
+	FieldOwner:               true,
+	FieldSuspendedAt:         true,
+	FieldExternalID:          true,
+	FieldLabels:              true,
+}
+
+// eventFields maps the field names used in change events, and the keys of their additional data, to
+// the redactable fields.
+var eventFields = map[string]string{
+	"name":             FieldName,
+	"display_name":     FieldDisplayName,
+	"description":      FieldDescription,
+	"parent_tenant_id": FieldParent,
+	"created_at":       FieldCreatedAt,
+	"updated_at":       FieldUpdatedAt,
+
+	"deletion_scheduled_at": FieldDeletionScheduledAt,
+	"settings":              FieldSettings,
+	"contact_email":         FieldContactEmail,
+	"billing_reference":     FieldBillingReference,
+	"owner_id":              FieldOwner,
+	"suspended_at":          FieldSuspendedAt,
+	"external_id":           FieldExternalID,
+	"labels":                FieldLabels,
+	"effective_labels":      FieldLabels,
+}
+
+type fieldsCtxKey struct{}
+
+// Fields is the set of fields visible to a caller. A nil Fields makes every field visible.
+type Fields map[string]bool
+
+// Visible reports whether the field is visible. Fields which can't be redacted are always visible.
+func (f Fields) Visible(field string) bool {
+	return f == nil || !redactable[field] || f[field]
+}
+
+// VisibleEventField reports whether a field named as in change events is visible.
+func (f Fields) VisibleEventField(field string) bool {
+	if name, ok := eventFields[field]; ok {
+		return f.Visible(name)
+	}
+
+	return f.Visible(field)
+}
+
+// Key identifies the set of visible fields, it is empty when every field is visible.
+func (f Fields) Key() string {
+	if f == nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(f))
+
+	for k := range f {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	// the id is always visible, which also keeps the key of an empty set from being empty
+	return strings.Join(append([]string{FieldID}, keys...), ",")
+}
+
+// WithFields returns a context carrying the visible fields.
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	return context.WithValue(ctx, fieldsCtxKey{}, fields)
+}
+
+// FromContext returns the visible fields from the context, every field is visible when none are set.
+func FromContext(ctx context.Context) Fields {
+	fields, _ := ctx.Value(fieldsCtxKey{}).(Fields)
+
+	return fields
+}
+
+// Policy maps token scopes to the fields visible with them.
+type Policy struct {
+	scopes map[string][]string
+}
+
+// NewPolicy returns a policy granting each scope the listed fields. An empty policy doesn't redact anything.
+func NewPolicy(scopes map[string][]string) *Policy {
+	return &Policy{scopes: scopes}
+}
+
+// FieldsForScopes returns the union of the fields granted to the scopes. Scopes not in the policy grant nothing.
+func (p *Policy) FieldsForScopes(scopes []string) Fields {
+	if len(p.scopes) == 0 {
+		return nil
+	}
+
+	fields := Fields{}
+
+	for _, scope := range scopes {
+		for _, field := range p.scopes[scope] {
+			if field == AllFields {
+				return nil
+			}
+
+			fields[field] = true
+		}
+	}
+
+	return fields
+}
+
+// Middleware sets the fields visible to the caller on the request context. It must run after the
+// jwt middleware so the validated token is available.
+func (p *Policy) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if len(p.scopes) == 0 {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithFields(req.Context(), p.FieldsForScopes(scopes.FromToken(c)))))
+
+			return next(c)
+		}
+	}
+}
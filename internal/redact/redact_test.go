@@ -0,0 +1,98 @@
+package redact_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+func TestFieldsForScopes(t *testing.T) {
+	policy := redact.NewPolicy(map[string][]string{
+		"full":    {redact.AllFields},
+		"summary": {redact.FieldName},
+		"tree":    {redact.FieldName, redact.FieldParent},
+	})
+
+	testCases := []struct {
+		name   string
+		scopes []string
+		key    string
+	}{
+		{name: "all fields", scopes: []string{"summary", "full"}, key: ""},
+		{name: "single scope", scopes: []string{"summary"}, key: "id,name"},
+		{name: "union", scopes: []string{"summary", "tree"}, key: "id,name,parent"},
+		{name: "unknown scope", scopes: []string{"other"}, key: "id"},
+		{name: "no scopes", key: "id"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := policy.FieldsForScopes(tt.scopes)
+			assert.Equal(t, tt.key, fields.Key())
+			assert.True(t, fields.Visible(redact.FieldID))
+		})
+	}
+
+	assert.Nil(t, redact.NewPolicy(nil).FieldsForScopes(nil), "an empty policy doesn't redact")
+}
+
+func TestFieldsVisible(t *testing.T) {
+	fields := redact.Fields{redact.FieldParent: true}
+
+	assert.True(t, fields.Visible(redact.FieldParent))
+	assert.False(t, fields.Visible(redact.FieldName))
+	assert.True(t, fields.VisibleEventField("parent_tenant_id"))
+	assert.False(t, fields.VisibleEventField("updated_at"))
+	assert.False(t, fields.VisibleEventField("labels"))
+	assert.False(t, fields.VisibleEventField("effective_labels"), "the effective labels are redacted with the labels")
+	assert.True(t, redact.Fields{redact.FieldLabels: true}.VisibleEventField("effective_labels"))
+
+	var all redact.Fields
+
+	assert.True(t, all.Visible(redact.FieldName))
+	assert.True(t, all.VisibleEventField("description"))
+}
+
+func TestMiddleware(t *testing.T) {
+	policy := redact.NewPolicy(map[string][]string{
+		"summary": {redact.FieldName},
+		"dates":   {redact.FieldCreatedAt},
+	})
+
+	testCases := []struct {
+		name   string
+		claims jwt.MapClaims
+		key    string
+	}{
+		{name: "scope claim", claims: jwt.MapClaims{"scope": "openid summary"}, key: "id,name"},
+		{name: "scope list", claims: jwt.MapClaims{"scope": []any{"summary", "dates"}}, key: "id,createdAt,name"},
+		{name: "scp claim", claims: jwt.MapClaims{"scp": []any{"dates"}}, key: "id,createdAt"},
+		{name: "no scopes", claims: jwt.MapClaims{}, key: "id"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+			c.Set("user", &jwt.Token{Claims: tt.claims})
+
+			var fields redact.Fields
+
+			err := policy.Middleware()(func(c echo.Context) error {
+				fields = redact.FromContext(c.Request().Context())
+
+				return nil
+			})(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.key, fields.Key())
+		})
+	}
+}
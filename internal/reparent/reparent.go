@@ -0,0 +1,112 @@
+package reparent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// maxWalkDepth bounds the walk up the ancestors of the new parent, keeping a cycle already in the
+// hierarchy from looping forever.
+const maxWalkDepth = 1000
+
+// Hook returns an ent hook rejecting tenants moved under themselves or one of their descendants,
+// and deleting the auth relationship of the tenants moved to their previous parent once they are.
+// The event hooks only create the relationship to the new parent. Moves made in a transaction
+// should hold the relationship requests until it commits, see changefeed.HoldRelationships.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				parentID, set := m.ParentTenantID()
+				if !set && !m.ParentTenantIDCleared() {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := checkCycle(ctx, m.Client(), ids, parentID); err != nil {
+					return nil, err
+				}
+
+				previous, err := m.Client().Tenant.Query().
+					Where(enttenant.IDIn(ids...)).
+					Select(enttenant.FieldID, enttenant.FieldParentTenantID).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := next.Mutate(ctx, m)
+				if err != nil {
+					return value, err
+				}
+
+				for _, t := range previous {
+					if t.ParentTenantID == gidx.NullPrefixedID || t.ParentTenantID == parentID {
+						continue
+					}
+
+					if err := permissions.DeleteAuthRelationships(ctx, "tenant", t.ID, events.AuthRelationshipRelation{
+						Relation:  "parent",
+						SubjectID: t.ParentTenantID,
+					}); err != nil {
+						return nil, err
+					}
+				}
+
+				return value, nil
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// checkCycle walks up from the new parent, rejecting it when it is one of the tenants moved or
+// one of their descendants. A missing ancestor ends the walk, missing parents are left to the
+// deletion hook.
+func checkCycle(ctx context.Context, client *generated.Client, ids []gidx.PrefixedID, parentID gidx.PrefixedID) error {
+	moved := make(map[gidx.PrefixedID]bool, len(ids))
+
+	for _, id := range ids {
+		moved[id] = true
+	}
+
+	for id, depth := parentID, 0; id != gidx.NullPrefixedID && depth < maxWalkDepth; depth++ {
+		if moved[id] {
+			return &validation.Error{
+				Field:   "parent",
+				Code:    validation.CodeParentCycle,
+				Message: fmt.Sprintf("%s is the tenant moved or one of its descendants", parentID),
+			}
+		}
+
+		ancestor, err := client.Tenant.Query().
+			Where(enttenant.ID(id)).
+			Select(enttenant.FieldParentTenantID).
+			Only(ctx)
+
+		switch {
+		case generated.IsNotFound(err):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		id = ancestor.ParentTenantID
+	}
+
+	return nil
+}
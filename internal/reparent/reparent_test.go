@@ -0,0 +1,59 @@
+package reparent_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+
+	"go.infratographer.com/permissions-api/pkg/permissions/mockpermissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/reparent"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestHook(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(reparent.Hook())
+
+	perms := new(mockpermissions.MockPermissions)
+	perms.On("DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := perms.ContextWithHandler(context.Background())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	// moving a tenant under itself or under one of its descendants
+	for _, parent := range []*ent.Tenant{child, grandchild} {
+		err := client.Tenant.UpdateOne(child).SetParent(parent).Exec(ctx)
+
+		var verr *validation.Error
+
+		require.ErrorAs(t, err, &verr, parent.Name)
+		assert.Equal(t, validation.CodeParentCycle, verr.Code)
+		assert.ErrorIs(t, err, apierrors.ErrConflict)
+	}
+
+	perms.AssertNotCalled(t, "DeleteAuthRelationships", mock.Anything, mock.Anything, mock.Anything)
+
+	// the relationship to the parent left is deleted, moving under the same parent keeps it
+	client.Tenant.UpdateOne(child).SetParentTenantID(other.ID).ExecX(ctx)
+	client.Tenant.UpdateOne(child).SetParentTenantID(other.ID).ExecX(ctx)
+
+	require.Len(t, perms.Calls, 1)
+	assert.Equal(t, child.ID, perms.Calls[0].Arguments.Get(1))
+	assert.Equal(t, events.AuthRelationshipRelation{Relation: "parent", SubjectID: root.ID}, perms.Calls[0].Arguments.Get(2))
+	assert.Equal(t, other.ID, client.Tenant.GetX(ctx, child.ID).ParentTenantID)
+}
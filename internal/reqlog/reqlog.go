@@ -0,0 +1,82 @@
+package reqlog
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/echojwtx"
+	"go.uber.org/zap"
+)
+
+// Fields added to the loggers of requests.
+const (
+	FieldRequestID = "request_id"
+	FieldRoute     = "route"
+	FieldActor     = "actor"
+	FieldTenantID  = "tenant_id"
+)
+
+// echoKey is the echo context key the request logger is stored under.
+const echoKey = "reqlog.logger"
+
+type loggerCtxKey struct{}
+
+// WithLogger returns a context carrying the logger.
+func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext returns the logger of the request the context belongs to, or the fallback outside
+// of requests.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerCtxKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// FromEcho returns the logger of the request, or the fallback when the middleware didn't run.
+func FromEcho(c echo.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if logger, ok := c.Get(echoKey).(*zap.SugaredLogger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// Middleware derives a logger from the base logger with the request id, route, actor and tenant
+// id path parameter of the request, and stores it on the echo context and the request context
+// for the data layer. It must run after the authentication middleware to know the actor, and is
+// meant to be added to routes rather than the server so the route is known.
+func Middleware(base *zap.SugaredLogger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			logger := base.With(FieldRoute, c.Path())
+
+			requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = req.Header.Get(echo.HeaderXRequestID)
+			}
+
+			if requestID != "" {
+				logger = logger.With(FieldRequestID, requestID)
+			}
+
+			if actor, ok := req.Context().Value(echojwtx.ActorCtxKey).(string); ok && actor != "" {
+				logger = logger.With(FieldActor, actor)
+			}
+
+			if id := c.Param("id"); id != "" {
+				logger = logger.With(FieldTenantID, id)
+			}
+
+			c.Set(echoKey, logger)
+			c.SetRequest(req.WithContext(WithLogger(req.Context(), logger)))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package reqlog_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.infratographer.com/tenant-api/internal/reqlog"
+)
+
+func TestMiddleware(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	base := zap.New(core).Sugar()
+
+	actor := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, "idntusr-tester")))
+
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	e.Use(middleware.RequestID())
+
+	e.GET("/v1/tenants/:id", func(c echo.Context) error {
+		reqlog.FromEcho(c, base).Info("from the handler")
+		reqlog.FromContext(c.Request().Context(), base).Info("from the data layer")
+
+		return c.NoContent(http.StatusNoContent)
+	}, actor, reqlog.Middleware(base))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/tenants/tnntten-abc", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	require.NotEmpty(t, requestID)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		assert.Equal(t, map[string]any{
+			reqlog.FieldRequestID: requestID,
+			reqlog.FieldRoute:     "/v1/tenants/:id",
+			reqlog.FieldActor:     "idntusr-tester",
+			reqlog.FieldTenantID:  "tnntten-abc",
+		}, entry.ContextMap(), entry.Message)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	fallback := zap.New(core).Sugar()
+
+	reqlog.FromContext(context.Background(), fallback).Info("outside a request")
+	reqlog.FromEcho(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder()), fallback).Info("without the middleware")
+
+	assert.Equal(t, 2, logs.Len())
+}
@@ -0,0 +1,142 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/clientcert"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/failures"
+	"go.infratographer.com/tenant-api/internal/integrity"
+	"go.infratographer.com/tenant-api/internal/scopes"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// AdminPolicy is how the callers of the admin routes are authorized.
+type AdminPolicy string
+
+const (
+	// AdminPolicyScope requires a token carrying the admin scope, the default.
+	AdminPolicyScope AdminPolicy = "scope"
+	// AdminPolicyMTLS requires a client certificate verified by the listener, see clientcert.
+	AdminPolicyMTLS AdminPolicy = "mtls"
+)
+
+// ParseAdminPolicy parses an admin policy, the empty string is the scope policy.
+func ParseAdminPolicy(s string) (AdminPolicy, error) {
+	switch policy := AdminPolicy(s); policy {
+	case "":
+		return AdminPolicyScope, nil
+	case AdminPolicyScope, AdminPolicyMTLS:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("unknown admin policy %q, expected scope or mtls", s)
+}
+
+// requireAdmin rejects callers whose token doesn't carry the admin scope, or under the mtls policy
+// callers without a verified client certificate.
+func (h *Handler) requireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	if h.adminPolicy == AdminPolicyMTLS {
+		return func(c echo.Context) error {
+			if clientcert.Verified(c.Request()) == nil {
+				return echo.ErrForbidden
+			}
+
+			return next(c)
+		}
+	}
+
+	return requireScope(h.adminScope)(next)
+}
+
+// requireScope returns a middleware rejecting callers whose token doesn't carry the scope.
+func requireScope(required string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			for _, scope := range scopes.FromToken(c) {
+				if scope == required {
+					return next(c)
+				}
+			}
+
+			return echo.ErrForbidden
+		}
+	}
+}
+
+// adminVerify reports the integrity problems of the tenant hierarchy. Problems are only reported,
+// repairs are made with the verify command which runs without the event hooks.
+func (h *Handler) adminVerify(c echo.Context) error {
+	report, err := integrity.Verify(c.Request().Context(), h.client, integrity.Options{})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+type maxChildrenRequest struct {
+	MaxChildren *int `json:"maxChildren"`
+}
+
+type maxChildrenResponse struct {
+	ID          gidx.PrefixedID `json:"id"`
+	MaxChildren int             `json:"maxChildren"`
+}
+
+// adminSetMaxChildren overrides the maximum number of direct children of a tenant, zero restores
+// the configured limit. Lowering it below the current number of children only blocks new ones.
+func (h *Handler) adminSetMaxChildren(c echo.Context) error {
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req maxChildrenRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	switch {
+	case req.MaxChildren == nil:
+		return errmap.BadRequest(&validation.Error{Field: "maxChildren", Code: validation.CodeRequired, Message: "maxChildren is required"})
+	case *req.MaxChildren < 0:
+		return errmap.BadRequest(&validation.Error{Field: "maxChildren", Code: validation.CodeInvalidValue, Message: "must be zero or positive"})
+	}
+
+	tnt, err := h.client.Tenant.UpdateOneID(id).SetMaxChildren(*req.MaxChildren).Save(c.Request().Context())
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, maxChildrenResponse{ID: tnt.ID, MaxChildren: tnt.MaxChildren})
+}
+
+// WithFailureRecorder registers the admin endpoint listing the recent failed requests recorded by
+// the recorder, whose middleware is installed by the server so failures of every api are
+// recorded.
+func WithFailureRecorder(r *failures.Recorder) Option {
+	return func(h *Handler) {
+		h.failures = r
+	}
+}
+
+type adminErrorsResponse struct {
+	Errors    []failures.Failure `json:"errors"`
+	Size      int                `json:"size"`
+	Retention string             `json:"retention"`
+}
+
+// adminErrors lists the recent failed requests, the latest first.
+func (h *Handler) adminErrors(c echo.Context) error {
+	return c.JSON(http.StatusOK, adminErrorsResponse{
+		Errors:    h.failures.Recent(),
+		Size:      h.failures.Size(),
+		Retention: h.failures.Retention().String(),
+	})
+}
@@ -0,0 +1,394 @@
+package restapi_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/failures"
+	"go.infratographer.com/tenant-api/internal/jobs"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+func TestAdminVerify(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+
+	client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:admin"})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var report struct {
+		Tenants  int   `json:"tenants"`
+		Problems []any `json:"problems"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.Equal(t, 1, report.Tenants)
+	assert.Empty(t, report.Problems)
+
+	resp, body = get(t, url+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	// without an admin scope the admin endpoints don't exist
+	_, url = newTestServer(t)
+
+	resp, body = get(t, url+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:admin"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestAdminSetMaxChildren(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+
+	tnt := client.Tenant.Create().SetName("large").SaveX(ctx)
+	path := url + "/v1/admin/tenants/" + tnt.ID.String() + "/max-children"
+
+	resp, body := put(t, path, `{"maxChildren":250000}`, map[string]string{"X-Scope": "tenants:admin"})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.JSONEq(t, `{"id":"`+tnt.ID.String()+`","maxChildren":250000}`, string(body))
+	assert.Equal(t, 250000, client.Tenant.GetX(ctx, tnt.ID).MaxChildren)
+
+	resp, body = put(t, path, `{"maxChildren":-1}`, map[string]string{"X-Scope": "tenants:admin"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = put(t, path, `{}`, map[string]string{"X-Scope": "tenants:admin"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = put(t, path, `{"maxChildren":0}`, map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = put(t, url+"/v1/admin/tenants/tnntten-missing/max-children", `{"maxChildren":0}`, map[string]string{"X-Scope": "tenants:admin"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestAdminRebuild(t *testing.T) {
+	ctx := context.Background()
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+
+	// created before sequences were recorded
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	client.Tenant.Use(changeseq.Hook())
+
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	client.Tenant.UpdateOneID(grandchild.ID).SetDescription("updated").ExecX(ctx)
+	sibling := client.Tenant.Create().SetName("sibling").SetParent(root).SaveX(ctx)
+	outside := client.Tenant.Create().SetName("outside").SaveX(ctx)
+
+	expected := map[gidx.PrefixedID]int64{
+		child.ID:      child.ChangeSeq,
+		grandchild.ID: client.Tenant.GetX(ctx, grandchild.ID).ChangeSeq,
+		sibling.ID:    sibling.ChangeSeq,
+	}
+
+	// corrupt the sequences, inside and outside the subtree
+	client.Tenant.Update().
+		Where(enttenant.IDIn(child.ID, grandchild.ID, outside.ID)).
+		SetChangeSeq(1).
+		ExecX(ctx)
+
+	resp, body := send(t, http.MethodPost, url+"/v1/admin/tenants/"+root.ID.String()+"/rebuild", "", admin)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode, string(body))
+
+	var job jobs.Job
+
+	require.NoError(t, json.Unmarshal(body, &job))
+	assert.Equal(t, "/v1/admin/jobs/"+job.ID.String(), resp.Header.Get(echo.HeaderLocation))
+
+	require.Eventually(t, func() bool {
+		resp, body := get(t, url+resp.Header.Get(echo.HeaderLocation), admin)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.NoError(t, json.Unmarshal(body, &job))
+
+		return job.Status != jobs.StatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, jobs.StatusSucceeded, job.Status, job.Error)
+	assert.Equal(t, int64(4), job.Total)
+	assert.Equal(t, int64(4), job.Done)
+
+	for id, seq := range expected {
+		assert.Equal(t, seq, client.Tenant.GetX(ctx, id).ChangeSeq)
+	}
+
+	// the root gets a sequence recorded, the tenant outside the subtree is left as is
+	assert.NotZero(t, client.Tenant.GetX(ctx, root.ID).ChangeSeq)
+	assert.Equal(t, int64(1), client.Tenant.GetX(ctx, outside.ID).ChangeSeq)
+
+	resp, body = send(t, http.MethodPost, url+"/v1/admin/tenants/tnntten-missing/rebuild", "", admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = send(t, http.MethodPost, url+"/v1/admin/tenants/"+root.ID.String()+"/rebuild", "", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/admin/jobs/tnntjob-missing", admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/admin/jobs/"+root.ID.String(), admin)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+func put(t *testing.T, url, body string, headers map[string]string) (*http.Response, []byte) {
+	t.Helper()
+
+	return send(t, http.MethodPut, url, body, headers)
+}
+
+func send(t *testing.T, method, url, body string, headers map[string]string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, respBody
+}
+
+func TestAdminErrors(t *testing.T) {
+	ctx := context.Background()
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	recorder := failures.NewRecorder(failures.WithSize(2))
+
+	client, url := newTestServerWithMiddleware(t,
+		[]echo.MiddlewareFunc{recorder.Middleware(), scopeMiddleware},
+		restapi.WithAdminScope("tenants:admin"),
+		restapi.WithFailureRecorder(recorder),
+	)
+
+	tnt := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	// successes and unselected client errors aren't recorded
+	resp, body := get(t, url+"/v1/tenants/"+tnt.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/tenants/tnntten-missing", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = put(t, url+"/v1/admin/tenants/"+tnt.ID.String()+"/max-children", `{"maxChildren":0}`, map[string]string{
+		"X-Scope":             "tenants:full",
+		echo.HeaderXRequestID: "req-denied",
+	})
+	require.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/admin/errors", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var report struct {
+		Errors    []failures.Failure `json:"errors"`
+		Size      int                `json:"size"`
+		Retention string             `json:"retention"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.Equal(t, 2, report.Size)
+	assert.Equal(t, time.Hour.String(), report.Retention)
+	require.Len(t, report.Errors, 1)
+
+	denied := report.Errors[0]
+	assert.Equal(t, "req-denied", denied.RequestID)
+	assert.Equal(t, http.MethodPut, denied.Method)
+	assert.Equal(t, "/v1/admin/tenants/:id/max-children", denied.Route)
+	assert.Equal(t, http.StatusForbidden, denied.Status)
+	assert.Equal(t, tnt.ID.String(), denied.TenantID)
+
+	// reading the failures is itself an admin request
+	resp, body = get(t, url+"/v1/admin/errors", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/admin/errors", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &report))
+	assert.Len(t, report.Errors, 2)
+
+	// without a recorder the endpoint doesn't exist
+	_, url = newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+
+	resp, body = get(t, url+"/v1/admin/errors", admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestAdminDispatchDrain(t *testing.T) {
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	_, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware},
+		restapi.WithAdminScope("tenants:admin"),
+		restapi.WithDispatcher(changefeed.NewDispatcher(
+			changefeed.WithDispatchWorkers(2),
+			changefeed.WithDispatchRate(5),
+		)),
+	)
+
+	var status struct {
+		Workers    int     `json:"workers"`
+		BatchSize  int     `json:"batchSize"`
+		Rate       float64 `json:"rate"`
+		DrainUntil *string `json:"drainUntil"`
+	}
+
+	resp, body := get(t, url+"/v1/admin/dispatch", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, 2, status.Workers)
+	assert.Equal(t, changefeed.DefaultDispatchBatchSize, status.BatchSize)
+	assert.Equal(t, 5.0, status.Rate)
+	assert.Nil(t, status.DrainUntil)
+
+	resp, _ = send(t, http.MethodPost, url+"/v1/admin/dispatch/drain", `{"rate":50,"duration":"10m"}`, map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	for _, body := range []string{
+		`{"duration":"10m"}`,
+		`{"rate":50}`,
+		`{"rate":50,"duration":"soon"}`,
+		`{"rate":50,"duration":"48h"}`,
+	} {
+		resp, respBody := send(t, http.MethodPost, url+"/v1/admin/dispatch/drain", body, admin)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "%s: %s", body, respBody)
+	}
+
+	resp, body = send(t, http.MethodPost, url+"/v1/admin/dispatch/drain", `{"rate":50,"duration":"10m"}`, admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.Equal(t, 50.0, status.Rate)
+	require.NotNil(t, status.DrainUntil)
+
+	until, err := time.Parse(time.RFC3339, *status.DrainUntil)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), until, time.Minute)
+}
+
+// newAdminTestServers returns the client and the urls of a public and an admin listener serving
+// the routes of the same handler, created with the options and WithAdminListener.
+func newAdminTestServers(t *testing.T, policy restapi.AdminPolicy, adminMiddleware []echo.MiddlewareFunc, opts ...restapi.Option) (*ent.Client, string, string) {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	opts = append(opts, restapi.WithAdminListener(policy, adminMiddleware))
+	handler := restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware(), scopeMiddleware}, opts...)
+
+	public, admin := echo.New(), echo.New()
+
+	handler.Routes(public.Group(""))
+	handler.AdminRoutes(admin.Group(""))
+
+	publicSrv, adminSrv := httptest.NewServer(public), httptest.NewServer(admin)
+	t.Cleanup(publicSrv.Close)
+	t.Cleanup(adminSrv.Close)
+
+	return client, publicSrv.URL, adminSrv.URL
+}
+
+func TestAdminListener(t *testing.T) {
+	ctx := context.Background()
+
+	client, publicURL, adminURL := newAdminTestServers(t, restapi.AdminPolicyScope, nil, restapi.WithAdminScope("tenants:admin"))
+
+	tnt := client.Tenant.Create().SetName("root").SaveX(ctx)
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	// the public listener refuses every admin route, even to admins
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/v1/admin/verify"},
+		{http.MethodPost, "/v1/admin/tenants"},
+		{http.MethodPut, "/v1/admin/tenants/" + tnt.ID.String() + "/max-children"},
+		{http.MethodPost, "/v1/tenants/" + tnt.ID.String() + "/freeze"},
+		{http.MethodDelete, "/v1/tenants/" + tnt.ID.String() + "/creation-freeze"},
+	} {
+		resp, body := send(t, route.method, publicURL+route.path, "{}", admin)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "%s %s: %s", route.method, route.path, body)
+	}
+
+	resp, body := get(t, adminURL+"/v1/admin/verify", admin)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, adminURL+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	// the admin listener only serves the admin routes, the public one the others
+	resp, body = get(t, adminURL+"/v1/tenants/"+tnt.ID.String(), admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+
+	resp, body = get(t, publicURL+"/v1/tenants/"+tnt.ID.String(), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	// tenants created on the admin listener are located on the public one
+	resp, body = send(t, http.MethodPost, adminURL+"/v1/admin/tenants", `{"id":"tnntten-adopted","name":"adopted"}`, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	require.Equal(t, "/v1/tenants/tnntten-adopted", resp.Header.Get(echo.HeaderLocation))
+
+	resp, body = get(t, publicURL+resp.Header.Get(echo.HeaderLocation), nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
+
+func TestAdminListenerMTLS(t *testing.T) {
+	// stands in for the listener verifying the client certificate
+	verified := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if name := c.Request().Header.Get("X-Client-Cert"); name != "" {
+				cert := &x509.Certificate{Subject: pkix.Name{CommonName: name}}
+				c.Request().TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+			}
+
+			return next(c)
+		}
+	}
+
+	// no admin scope is needed under the mtls policy, nor does a token grant access
+	_, publicURL, adminURL := newAdminTestServers(t, restapi.AdminPolicyMTLS, []echo.MiddlewareFunc{verified})
+
+	resp, body := get(t, adminURL+"/v1/admin/verify", map[string]string{"X-Client-Cert": "operator"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, adminURL+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:admin"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = get(t, publicURL+"/v1/admin/verify", map[string]string{"X-Client-Cert": "operator"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
@@ -0,0 +1,110 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// adoptedKey is the additional data key flagging the events of tenants created with the id they
+// had in another system.
+const adoptedKey = "adopted"
+
+type adoptRequest struct {
+	ID string `json:"id"`
+	createRequest
+}
+
+func (r *adoptRequest) validate(ctx context.Context, p *validation.Pipeline) error {
+	var errs validation.Errors
+
+	if r.ID == "" {
+		errs.Add("id", validation.CodeRequired, "the id of the adopted tenant is required")
+	} else if id, err := gidx.Parse(r.ID); err != nil || id.Prefix() != schema.TenantPrefix {
+		errs.Add("id", validation.CodeInvalidID, fmt.Sprintf("%q is not a tenant id", r.ID))
+	}
+
+	if r.ExternalID != "" {
+		errs.Add("externalID", validation.CodeInvalidValue, "adopted tenants keep their id, it isn't derived from an external id")
+	}
+
+	if cerrs, ok := r.createRequest.validate(ctx, p).(validation.Errors); ok {
+		errs = append(errs, cerrs...)
+	}
+
+	return errs.Err()
+}
+
+// adminTenantAdopt creates a tenant with the id it had in another system, so the references other
+// services hold to it stay valid when migrating to this one. The tenant is validated as any other,
+// only its id is given rather than generated, and its create event is flagged as an adoption.
+// Ids taken by another tenant are a conflict.
+func (h *Handler) adminTenantAdopt(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req adoptRequest
+
+	if err := h.decodeInput(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := req.validate(ctx, h.validator); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	resource := gidx.NullPrefixedID
+
+	if req.ParentID != nil {
+		resource = *req.ParentID
+	}
+
+	if err := permissions.CheckAccess(ctx, resource, actionTenantCreate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	req.adoptedID = gidx.PrefixedID(req.ID)
+
+	idTaken := &validation.Error{
+		Field:   "id",
+		Code:    validation.CodeIDTaken,
+		Message: fmt.Sprintf("%s is the id of another tenant", req.adoptedID),
+	}
+
+	exists, err := h.client.Tenant.Query().Where(enttenant.ID(req.adoptedID)).Exist(ctx)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return errmap.HTTPError(idTaken)
+	}
+
+	t, _, err := h.createTenant(c, req.createRequest)
+	if err != nil {
+		// a concurrent request adopted the id first
+		if ent.IsConstraintError(err) {
+			if exists, eerr := h.client.Tenant.Query().Where(enttenant.ID(req.adoptedID)).Exist(ctx); eerr == nil && exists {
+				return errmap.HTTPError(idTaken)
+			}
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if err := h.setParentLimitHeaders(c, t.ParentTenantID); err != nil {
+		return err
+	}
+
+	return h.respondCreated(c, newTenant(t, redact.FromContext(ctx)), RouteTenantGet, t.ID)
+}
@@ -0,0 +1,71 @@
+package restapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+func TestAdminTenantAdopt(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied", restapi.WithAdminScope("tenants:admin"))
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants",
+		`{"id":"tnntten-legacy-42","name":"legacy","parentID":"`+root.ID.String()+`"}`, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	assert.Equal(t, "/v1/tenants/tnntten-legacy-42", resp.Header.Get("Location"))
+
+	adopted := env.client.Tenant.GetX(env.ctx, "tnntten-legacy-42")
+	assert.Equal(t, "legacy", adopted.Name)
+	assert.Equal(t, root.ID, adopted.ParentTenantID)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, string(events.CreateChangeType), msg.EventType)
+	assert.Equal(t, true, msg.AdditionalData["adopted"])
+
+	t.Run("duplicate ids are rejected", func(t *testing.T) {
+		for _, id := range []string{"tnntten-legacy-42", root.ID.String()} {
+			resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants", `{"id":"`+id+`","name":"other"}`, admin)
+			assert.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+			assert.Contains(t, string(body), "id_taken")
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []string{
+			`{"name":"no-id"}`,
+			`{"id":"idntusr-not-a-tenant","name":"user"}`,
+			`{"id":"not an id","name":"malformed"}`,
+			`{"id":"tnntten-unnamed"}`,
+			`{"id":"tnntten-external","name":"external","parentID":"` + root.ID.String() + `","externalID":"ref"}`,
+		} {
+			resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants", req, admin)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, req+": "+string(body))
+		}
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants", `{"id":"tnntten-sneaky","name":"sneaky"}`, map[string]string{"X-Scope": "tenants:full"})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+	})
+
+	t.Run("created tenants can't give an id", func(t *testing.T) {
+		status, body := env.post(t, "/v1/tenants", `{"id":"tnntten-chosen","name":"chosen"}`)
+		assert.Equal(t, http.StatusBadRequest, status, string(body))
+		assert.Contains(t, string(body), "unknown_field")
+
+		assert.False(t, env.client.Tenant.Query().Where(enttenant.ID("tnntten-chosen")).ExistX(env.ctx))
+	})
+}
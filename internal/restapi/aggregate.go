@@ -0,0 +1,152 @@
+package restapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/labels"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+const (
+	// aggregateGroupByStatus groups the tenants by their effective status.
+	aggregateGroupByStatus = "status"
+
+	// aggregateGroupByLabel prefixes the key of the label grouping the tenants by its effective
+	// value, such as label:cost-center.
+	aggregateGroupByLabel = "label:"
+
+	// maxAggregateGroups bounds the number of groups returned, the largest ones are kept.
+	maxAggregateGroups = 1000
+)
+
+type aggregateGroup struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type aggregateResponse struct {
+	GroupBy   string           `json:"groupBy"`
+	Under     gidx.PrefixedID  `json:"under"`
+	Groups    []aggregateGroup `json:"groups"`
+	Truncated bool             `json:"truncated"`
+	// ComputedAt is the time the counts were computed at, they are the statistics of the tenant.
+	ComputedAt timefmt.Time `json:"computedAt"`
+}
+
+// tenantAggregate counts the descendants of the under tenant grouped by the group_by dimension:
+// their effective status, or the effective value of a label with label:<key>. Groups are ordered
+// by count, largest first, and empty groups are left out, as are the descendants without the
+// label. Tenants have no kinds to group by. Status counts are computed by the same single query
+// over the subtree as the statistics and cached the same, with the exact and refresh query
+// parameters, and their age is told the same way. Label counts are computed by a single grouped
+// query over the labels of the subtree on every request, for callers who may see labels.
+func (h *Handler) tenantAggregate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var errs validation.Errors
+
+	groupBy := c.QueryParam("group_by")
+	labelKey, byLabel := strings.CutPrefix(groupBy, aggregateGroupByLabel)
+
+	switch {
+	case groupBy == aggregateGroupByStatus:
+	case byLabel && validation.ValidLabelKey(labelKey):
+	case byLabel:
+		errs.Add("group_by", validation.CodeInvalidLabel, "label keys must be lowercase letters, digits, '.', '-', '_' or '/', starting and ending with a letter or digit")
+	case groupBy == "":
+		errs.Add("group_by", validation.CodeRequired, "group_by is required")
+	default:
+		errs.Add("group_by", validation.CodeInvalidValue, "must be "+aggregateGroupByStatus+" or "+aggregateGroupByLabel+"<key>")
+	}
+
+	under, err := gidx.Parse(c.QueryParam("under"))
+
+	switch {
+	case c.QueryParam("under") == "":
+		errs.Add("under", validation.CodeRequired, "under is required")
+	case err != nil || under.Prefix() != schema.TenantPrefix:
+		errs.Add("under", validation.CodeInvalidID, "must be a tenant id")
+	}
+
+	if err := errs.Err(); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, under, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	resp := aggregateResponse{
+		GroupBy: groupBy,
+		Under:   under,
+	}
+
+	if byLabel {
+		if !redact.FromContext(ctx).Visible(redact.FieldLabels) {
+			return echo.ErrForbidden
+		}
+
+		groups, truncated, err := labels.Count(ctx, h.client, under, labelKey, maxAggregateGroups)
+		if err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		resp.Groups = make([]aggregateGroup, len(groups))
+
+		for i, g := range groups {
+			resp.Groups[i] = aggregateGroup{Value: g.Value, Count: g.Count}
+		}
+
+		resp.Truncated = truncated
+		resp.ComputedAt = timefmt.New(h.clock.Now().UTC())
+	} else {
+		stats, err := h.readStats(c, under)
+		if err != nil {
+			return err
+		}
+
+		resp.Groups, resp.Truncated = statusGroups(stats.StatusCounts)
+		resp.ComputedAt = stats.ComputedAt
+	}
+
+	h.setAge(c, resp.ComputedAt.Time)
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// statusGroups returns the non empty groups of the status counts, largest first, at most
+// maxAggregateGroups of them.
+func statusGroups(counts map[string]int64) ([]aggregateGroup, bool) {
+	groups := make([]aggregateGroup, 0, len(counts))
+
+	for value, count := range counts {
+		if count != 0 {
+			groups = append(groups, aggregateGroup{Value: value, Count: count})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+
+		return groups[i].Value < groups[j].Value
+	})
+
+	if len(groups) > maxAggregateGroups {
+		return groups[:maxAggregateGroups], true
+	}
+
+	return groups, false
+}
@@ -0,0 +1,59 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/archive"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+// tenantArchive archives the tenant, tenants scheduled for deletion are a conflict.
+func (h *Handler) tenantArchive(c echo.Context) error {
+	return h.changeArchived(c, archive.Archive)
+}
+
+// tenantUnarchive restores the archived tenant.
+func (h *Handler) tenantUnarchive(c echo.Context) error {
+	return h.changeArchived(c, archive.Unarchive)
+}
+
+func (h *Handler) changeArchived(c echo.Context, change func(context.Context, *ent.Client, gidx.PrefixedID) (*ent.Tenant, error)) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	t, err := change(ctx, tx.Client(), id)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.log(c).Errorw("failed to roll back archive change", "error", rerr)
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, newTenant(t.Unwrap(), redact.FromContext(ctx)))
+}
@@ -0,0 +1,110 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+
+	"go.infratographer.com/tenant-api/internal/archive"
+)
+
+func TestTenantArchive(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	env.client.Tenant.Use(archive.Hook())
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	dormant := env.client.Tenant.Create().SetName("dormant").SetParent(root).SaveX(env.ctx)
+	active := env.client.Tenant.Create().SetName("active").SetParent(root).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants/"+dormant.ID.String()+"/archive", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Contains(t, string(body), `"archived":true`)
+
+	// the update event carries the new state
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, string(events.UpdateChangeType), msg.EventType)
+	assert.Contains(t, msg.FieldChanges, events.FieldChange{Field: "archived", PreviousValue: "false", CurrentValue: "true"})
+
+	// archiving again changes nothing
+	status, body = env.post(t, "/v1/tenants/"+dormant.ID.String()+"/archive", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	list := func(query string) []string {
+		t.Helper()
+
+		status, body := env.do(t, http.MethodGet, "/v1/tenants?parent_id="+root.ID.String()+query, "", "")
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		var page struct {
+			Tenants []struct {
+				Name string `json:"name"`
+			} `json:"tenants"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+
+		names := []string{}
+
+		for _, tnt := range page.Tenants {
+			names = append(names, tnt.Name)
+		}
+
+		return names
+	}
+
+	assert.Equal(t, []string{"active"}, list(""))
+	assert.ElementsMatch(t, []string{"active", "dormant"}, list("&include_archived=true"))
+
+	// archived tenants get no new children
+	_, err := env.client.Tenant.Create().SetName("new").SetParent(dormant).Save(env.ctx)
+	assert.ErrorIs(t, err, archive.ErrArchivedParent)
+
+	_, err = env.client.Tenant.UpdateOne(active).SetParentID(dormant.ID).Save(env.ctx)
+	assert.ErrorIs(t, err, archive.ErrArchivedParent)
+
+	status, body = env.post(t, "/v1/tenants/"+dormant.ID.String()+"/unarchive", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.NotContains(t, string(body), `"archived"`)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 2)
+
+	msg = env.conn.Calls[1].Arguments.Get(1).(events.ChangeMessage)
+	assert.Contains(t, msg.FieldChanges, events.FieldChange{Field: "archived", PreviousValue: "true", CurrentValue: "false"})
+
+	env.client.Tenant.Create().SetName("new").SetParent(dormant).SaveX(env.ctx)
+}
+
+func TestTenantArchiveDeleted(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	deleted := env.client.Tenant.Create().SetName("deleted").SetDeletionScheduledAt(time.Now().Add(time.Hour)).SaveX(env.ctx)
+
+	env.client.Tenant.UpdateOne(root).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(env.ctx)
+
+	// tenants scheduled for deletion, or below one which is, can't be archived
+	for _, tnt := range []string{deleted.ID.String(), child.ID.String()} {
+		status, body := env.post(t, "/v1/tenants/"+tnt+"/archive", "")
+		require.Equal(t, http.StatusConflict, status, string(body))
+	}
+
+	assert.False(t, env.client.Tenant.GetX(env.ctx, child.ID).Archived)
+
+	status, body := env.post(t, "/v1/tenants/tnntten-missing/archive", "")
+	assert.Equal(t, http.StatusNotFound, status, string(body))
+
+	status, body = env.post(t, "/v1/tenants/tnntten-denied/unarchive", "")
+	assert.Equal(t, http.StatusForbidden, status, string(body))
+}
@@ -0,0 +1,225 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+)
+
+// Page sizes of the audit.
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 100
+)
+
+// WithAuditRecorder records the audited mutation attempts of single tenants with the recorder and
+// registers the audit endpoint, neither is done without one. Whether rejected attempts are
+// recorded is up to the recorder, see audit.WithRejectedAttempts.
+func WithAuditRecorder(r *audit.Recorder) Option {
+	return func(h *Handler) {
+		h.audit = r
+	}
+}
+
+// auditTenant names the tenant of audit entries, from its tombstone once it is deleted.
+type auditTenant struct {
+	ID        gidx.PrefixedID  `json:"id"`
+	Name      string           `json:"name,omitempty"`
+	ParentID  *gidx.PrefixedID `json:"parentId,omitempty"`
+	DeletedAt *timefmt.Time    `json:"deletedAt,omitempty"`
+	PurgedAt  *timefmt.Time    `json:"purgedAt,omitempty"`
+}
+
+type auditEntry struct {
+	ID              gidx.PrefixedID `json:"id"`
+	Tenant          *auditTenant    `json:"tenant,omitempty"`
+	Actor           string          `json:"actor,omitempty"`
+	Operation       string          `json:"operation"`
+	AttemptedChange string          `json:"attemptedChange,omitempty"`
+	Outcome         string          `json:"outcome"`
+	Code            string          `json:"code,omitempty"`
+	RecordedAt      timefmt.Time    `json:"recordedAt"`
+}
+
+type auditResponse struct {
+	Entries       []auditEntry `json:"entries"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+// tenantAudit lists the audited mutation attempts of a tenant, oldest first. The outcome query
+// parameter keeps the attempts with that outcome, denied, conflicted, confirmed or suppressed.
+// Pages are requested with the limit and page_token query parameters, the token being the
+// nextPageToken of the previous page, or its nextCursor in FormatV11. Entries name their tenant,
+// deleted tenants by the name and parent of their tombstone with the times they were deleted and
+// purged, and don't name it once the tombstone is purged in turn.
+func (h *Handler) tenantAudit(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultAuditPageSize
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxAuditPageSize {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAuditPageSize))
+		}
+	}
+
+	outcome := c.QueryParam("outcome")
+	switch outcome {
+	case "", audit.OutcomeDenied, audit.OutcomeConflicted, audit.OutcomeConfirmed, audit.OutcomeSuppressed, audit.OutcomeMerged:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("outcome must be %s, %s, %s, %s or %s",
+			audit.OutcomeDenied, audit.OutcomeConflicted, audit.OutcomeConfirmed, audit.OutcomeSuppressed, audit.OutcomeMerged))
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	query := h.client.TenantAudit.Query().
+		Where(tenantaudit.TenantID(id))
+
+	if outcome != "" {
+		query = query.Where(tenantaudit.Outcome(outcome))
+	}
+
+	if token := c.QueryParam("page_token"); token != "" {
+		after, err := h.auditCursor(c, id, token)
+		if err != nil {
+			return err
+		}
+
+		query = query.Where(tenantaudit.Or(
+			tenantaudit.RecordedAtGT(after.RecordedAt),
+			tenantaudit.And(
+				tenantaudit.RecordedAt(after.RecordedAt),
+				tenantaudit.IDGT(after.ID),
+			),
+		))
+	}
+
+	entries, err := query.
+		Order(ent.Asc(tenantaudit.FieldRecordedAt), ent.Asc(tenantaudit.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp := auditResponse{Entries: make([]auditEntry, 0, len(entries))}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp.NextPageToken = entries[limit-1].ID.String()
+	}
+
+	tnt, err := h.auditTenant(c, id)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, auditEntry{
+			ID:              e.ID,
+			Tenant:          tnt,
+			Actor:           e.Actor,
+			Operation:       e.Operation,
+			AttemptedChange: e.AttemptedChange,
+			Outcome:         e.Outcome,
+			Code:            e.Code,
+			RecordedAt:      timefmt.New(e.RecordedAt),
+		})
+	}
+
+	return respondList(c, resp, resp.Entries, resp.NextPageToken)
+}
+
+// auditTenant returns the tenant the audit entries name, nil when neither the tenant nor its
+// tombstone exist. Its name and parent are omitted when they are redacted for the caller.
+func (h *Handler) auditTenant(c echo.Context, id gidx.PrefixedID) (*auditTenant, error) {
+	ctx := c.Request().Context()
+
+	var (
+		tnt      = &auditTenant{ID: id}
+		name     string
+		parentID gidx.PrefixedID
+	)
+
+	t, err := h.client.Tenant.Query().
+		Where(enttenant.ID(id)).
+		Select(enttenant.FieldID, enttenant.FieldName, enttenant.FieldParentTenantID).
+		Only(ctx)
+
+	switch {
+	case err == nil:
+		name, parentID = t.Name, t.ParentTenantID
+	case ent.IsNotFound(err):
+		tombstones, err := tombstone.Find(ctx, h.client, id)
+		if err != nil {
+			return nil, err
+		}
+
+		ts, ok := tombstones[id]
+		if !ok {
+			return nil, nil
+		}
+
+		name, parentID = ts.Name, ts.ParentTenantID
+		tnt.DeletedAt, tnt.PurgedAt = timefmt.Ptr(&ts.DeletedAt), timefmt.Ptr(&ts.PurgedAt)
+	default:
+		return nil, err
+	}
+
+	fields := redact.FromContext(ctx)
+
+	if fields.Visible(redact.FieldName) {
+		tnt.Name = name
+	}
+
+	if fields.Visible(redact.FieldParent) && parentID != gidx.NullPrefixedID {
+		tnt.ParentID = &parentID
+	}
+
+	return tnt, nil
+}
+
+// auditCursor loads the entry a page token refers to, which must belong to the tenant.
+func (h *Handler) auditCursor(c echo.Context, tenantID gidx.PrefixedID, token string) (*ent.TenantAudit, error) {
+	id, err := gidx.Parse(token)
+	if err != nil || id.Prefix() != schema.AuditPrefix {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid page token")
+	}
+
+	entry, err := h.client.TenantAudit.Query().
+		Where(tenantaudit.ID(id), tenantaudit.TenantID(tenantID)).
+		Only(c.Request().Context())
+
+	switch {
+	case ent.IsNotFound(err):
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid page token")
+	case err != nil:
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,160 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// newAuditServer serves the REST api denying every action on the denied tenant, recording the
+// rejected attempts when recording is set.
+// newAuditServer returns a server auditing the mutation attempts as the service does, the rejected
+// ones only when recording.
+func newAuditServer(t *testing.T, denied gidx.PrefixedID, recording bool, opts ...restapi.Option) (*ent.Client, string) {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(freeze.Hook())
+
+	checker := func(_ context.Context, requests ...permissions.AccessRequest) error {
+		for _, req := range requests {
+			if req.ResourceID == denied {
+				return permissions.ErrPermissionDenied
+			}
+		}
+
+		return nil
+	}
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(checker))
+	require.NoError(t, err)
+
+	opts = append(opts, restapi.WithAuditRecorder(audit.NewRecorder(client, audit.WithRejectedAttempts(recording))))
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{actorMiddleware, perms.Middleware(), scopeMiddleware}, opts...).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return client, srv.URL
+}
+
+// statusOf sends the request and returns the status of the response.
+func statusOf(t *testing.T, method, url, body string) int {
+	t.Helper()
+
+	resp, _ := send(t, method, url, body, nil)
+
+	return resp.StatusCode
+}
+
+func TestAuditRejectedDelete(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		recording bool
+		entries   int
+	}{
+		{"enabled", true, 1},
+		{"disabled", false, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client, url := newAuditServer(t, "tnntten-denied", tt.recording)
+			client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(ctx)
+
+			require.Equal(t, http.StatusForbidden, statusOf(t, http.MethodDelete, url+"/v1/tenants/tnntten-denied", ""))
+
+			entries := client.TenantAudit.Query().AllX(ctx)
+			require.Len(t, entries, tt.entries)
+
+			if tt.entries == 0 {
+				return
+			}
+
+			assert.Equal(t, gidx.PrefixedID("tnntten-denied"), entries[0].TenantID)
+			assert.Equal(t, testActor, entries[0].Actor)
+			assert.Equal(t, restapi.RouteTenantDelete, entries[0].Operation)
+			assert.Equal(t, audit.OutcomeDenied, entries[0].Outcome)
+			assert.Equal(t, "permission_denied", entries[0].Code)
+		})
+	}
+}
+
+func TestAuditEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newAuditServer(t, "tnntten-denied", true)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	frozen := client.Tenant.Create().SetName("frozen").SaveX(ctx)
+	_, err := freeze.Freeze(ctx, client, frozen.ID)
+	require.NoError(t, err)
+
+	// a conflict, the root still has a child
+	require.Equal(t, http.StatusConflict, statusOf(t, http.MethodDelete, url+"/v1/tenants/"+root.ID.String(), ""))
+	// a freeze violation, with the attempted change
+	require.Equal(t, http.StatusLocked, statusOf(t, http.MethodPut, url+"/v1/tenants/"+frozen.ID.String()+"/settings", `{"plan":"gold"}`))
+	// successful changes aren't audited
+	require.Equal(t, http.StatusOK, statusOf(t, http.MethodPut, url+"/v1/tenants/"+root.ID.String()+"/settings", `{"plan":"gold"}`))
+
+	type response struct {
+		Entries []struct {
+			Operation       string `json:"operation"`
+			AttemptedChange string `json:"attemptedChange"`
+			Outcome         string `json:"outcome"`
+			Code            string `json:"code"`
+		} `json:"entries"`
+	}
+
+	list := func(id gidx.PrefixedID, query string) response {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants/"+id.String()+"/audit"+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var r response
+
+		require.NoError(t, json.Unmarshal(body, &r))
+
+		return r
+	}
+
+	rootAudit := list(root.ID, "")
+	require.Len(t, rootAudit.Entries, 1)
+	assert.Equal(t, audit.OutcomeConflicted, rootAudit.Entries[0].Outcome)
+
+	assert.Empty(t, list(root.ID, "?outcome=denied").Entries)
+
+	frozenAudit := list(frozen.ID, "?outcome=denied")
+	require.Len(t, frozenAudit.Entries, 1)
+	assert.Equal(t, restapi.RouteTenantSettingsPut, frozenAudit.Entries[0].Operation)
+	assert.Equal(t, "tenant_frozen", frozenAudit.Entries[0].Code)
+	assert.JSONEq(t, `{"plan":"gold"}`, frozenAudit.Entries[0].AttemptedChange)
+
+	resp, _ := get(t, url+"/v1/tenants/"+root.ID.String()+"/audit?outcome=nope", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	assert.Equal(t, 2, client.TenantAudit.Query().Where(tenantaudit.TenantIDIn(root.ID, frozen.ID)).CountX(ctx))
+}
@@ -0,0 +1,419 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Per tenant outcomes of a batch update.
+const (
+	batchStatusUpdated  = "updated"
+	batchStatusNotFound = "not_found"
+	batchStatusConflict = "conflict"
+)
+
+// Statuses a batch update can move tenants to. Suspended and archived tenants must be made active
+// before moving to the other status, and tenants pending deletion, or below a tenant which is,
+// can't change status.
+const (
+	patchStatusActive    = "active"
+	patchStatusSuspended = "suspended"
+	patchStatusArchived  = "archived"
+)
+
+type batchUpdateRequest struct {
+	IDs   []gidx.PrefixedID `json:"ids"`
+	Patch tenantPatch       `json:"patch"`
+}
+
+// tenantPatch holds the changes applied to every tenant of a batch, unset fields are left as is.
+// The labels are merged into those the tenants set themselves, a null value removing the label.
+// The status suspends, archives or reactivates the tenants.
+type tenantPatch struct {
+	Description *string            `json:"description"`
+	Labels      map[string]*string `json:"labels"`
+	Status      *string            `json:"status"`
+}
+
+func (p tenantPatch) empty() bool {
+	return p.Description == nil && len(p.Labels) == 0 && p.Status == nil
+}
+
+// validate normalizes the fields of the patch with the pipeline, recording the errors of the
+// fields it rejects under the patch. The labels set are validated on their own, the labels each
+// tenant ends up with are validated as it is updated.
+func (p *tenantPatch) validate(ctx context.Context, pipeline *validation.Pipeline, errs *validation.Errors) error {
+	if p.Status != nil {
+		switch *p.Status {
+		case patchStatusActive, patchStatusSuspended, patchStatusArchived:
+		default:
+			errs.Add("patch.status", validation.CodeInvalidValue, fmt.Sprintf("status must be %s, %s or %s", patchStatusActive, patchStatusSuspended, patchStatusArchived))
+		}
+	}
+
+	var labels map[string]string
+
+	for k, v := range p.Labels {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		if v != nil {
+			labels[k] = *v
+		} else {
+			// removed labels only need a valid key
+			labels[k] = ""
+		}
+	}
+
+	err := pipeline.ValidateUpdate(ctx, &validation.Tenant{Description: p.Description, Labels: labels})
+	if err == nil {
+		return nil
+	}
+
+	var perrs validation.Errors
+	if !errors.As(err, &perrs) {
+		return err
+	}
+
+	for _, perr := range perrs {
+		patchErr := *perr
+		patchErr.Field = "patch." + perr.Field
+
+		*errs = append(*errs, &patchErr)
+	}
+
+	return nil
+}
+
+// Codes of the conflicts of a batch update, tenants rejected by the validation of their update
+// get the code of the error, such as tenant_frozen or a validation code.
+const (
+	batchCodeDuplicate    = "duplicate_id"
+	batchCodeStatusChange = "invalid_status_change"
+)
+
+// batchSavepoint is the savepoint each tenant of a batch update is updated under.
+const batchSavepoint = "batch_update_tenant"
+
+type batchUpdateResult struct {
+	ID     gidx.PrefixedID `json:"id"`
+	Status string          `json:"status"`
+	// Code identifies why the tenant is a conflict.
+	Code string `json:"code,omitempty"`
+	// Reason tells why the tenant is a conflict.
+	Reason string `json:"reason,omitempty"`
+}
+
+type batchUpdateResponse struct {
+	Results []batchUpdateResult `json:"results"`
+}
+
+// tenantBatchUpdate applies the same patch to every listed tenant in a single transaction. The
+// caller must be allowed to update every listed tenant, otherwise nothing is updated, and to see
+// labels to patch them. Tenants listed more than once are only updated for their first occurrence,
+// later ones are reported as conflicts, as are tenants which can't move to the patched status and
+// those whose update is rejected, such as frozen tenants, the others still being updated. Change
+// events are published once the transaction commits.
+func (h *Handler) tenantBatchUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req batchUpdateRequest
+
+	if err := h.decodeInput(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	var errs validation.Errors
+
+	checkTenantIDs(&errs, "ids", req.IDs, h.maxBatchSize)
+
+	if req.Patch.empty() {
+		errs.Add("patch", validation.CodeRequired, "the patch doesn't change anything")
+	}
+
+	if err := req.Patch.validate(ctx, h.validator, &errs); err != nil {
+		return err
+	}
+
+	if err := errs.Err(); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if len(req.Patch.Labels) != 0 && !redact.FromContext(ctx).Visible(redact.FieldLabels) {
+		return echo.ErrForbidden
+	}
+
+	unique := uniqueIDs(req.IDs)
+
+	for _, id := range unique {
+		if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+			return errmap.HTTPError(err)
+		}
+	}
+
+	batchCtx, batch := changefeed.WithBatch(ctx)
+
+	results, err := h.applyBatch(batchCtx, req.IDs, unique, req.Patch)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the changes are committed, report them even though some events were lost
+		h.log(c).Errorw("failed to publish batch update changes", "error", err)
+	}
+
+	return c.JSON(http.StatusOK, batchUpdateResponse{Results: results})
+}
+
+// applyBatch updates the tenants in a transaction, returning the outcome for each listed id.
+func (h *Handler) applyBatch(ctx context.Context, ids, unique []gidx.PrefixedID, patch tenantPatch) ([]batchUpdateResult, error) {
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := updateTenants(ctx, tx, ids, unique, patch, h.clock.Now().UTC())
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			reqlog.FromContext(ctx, h.logger).Errorw("failed to roll back batch update", "error", rerr)
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// uniqueIDs returns the ids without the repeated ones, in the order they are first listed.
+func uniqueIDs(ids []gidx.PrefixedID) []gidx.PrefixedID {
+	unique := make([]gidx.PrefixedID, 0, len(ids))
+	seen := make(map[gidx.PrefixedID]bool, len(ids))
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+func updateTenants(ctx context.Context, tx *ent.Tx, ids, unique []gidx.PrefixedID, patch tenantPatch, now time.Time) ([]batchUpdateResult, error) {
+	existing, err := tx.Tenant.Query().Where(enttenant.IDIn(unique...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[gidx.PrefixedID]*ent.Tenant, len(existing))
+
+	for _, t := range existing {
+		found[t.ID] = t
+	}
+
+	var statuses map[gidx.PrefixedID]string
+
+	if patch.Status != nil {
+		if statuses, err = deletion.EffectiveStatuses(ctx, tx.Client(), existing); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]batchUpdateResult, 0, len(ids))
+	listed := make(map[gidx.PrefixedID]bool, len(unique))
+
+	for _, id := range ids {
+		result := batchUpdateResult{ID: id}
+
+		var reason string
+
+		if found[id] != nil && patch.Status != nil {
+			reason = statusConflict(found[id], statuses[id], *patch.Status)
+		}
+
+		switch {
+		case listed[id]:
+			result.Status = batchStatusConflict
+			result.Code = batchCodeDuplicate
+			result.Reason = "listed more than once"
+		case found[id] == nil:
+			result.Status = batchStatusNotFound
+		case reason != "":
+			result.Status = batchStatusConflict
+			result.Code = batchCodeStatusChange
+			result.Reason = reason
+		default:
+			update := tx.Tenant.UpdateOneID(id).SetNillableDescription(patch.Description)
+
+			if patch.Status != nil {
+				patchStatus(update, found[id], *patch.Status, now)
+			}
+
+			if len(patch.Labels) != 0 {
+				if labels := patchLabels(found[id].Labels, patch.Labels); len(labels) == 0 {
+					update.ClearLabels()
+				} else {
+					update.SetLabels(labels)
+				}
+			}
+
+			if err := updateTenant(ctx, tx, update, &result); err != nil {
+				return nil, err
+			}
+		}
+
+		listed[id] = true
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// updateTenant applies the update of a tenant of the batch under a savepoint, recording its
+// outcome in the result. Updates rejected by the hooks, such as those of frozen tenants or which
+// fail validation, are rolled back to the savepoint and reported as conflicts with the code of the
+// error, the rest of the batch is still applied. Other errors fail the whole batch.
+func updateTenant(ctx context.Context, tx *ent.Tx, update *ent.TenantUpdateOne, result *batchUpdateResult) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+batchSavepoint); err != nil {
+		return err
+	}
+
+	err := update.Exec(ctx)
+	if err == nil {
+		result.Status = batchStatusUpdated
+
+		_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+batchSavepoint)
+
+		return err
+	}
+
+	code := rejectionCode(err)
+
+	switch {
+	case ent.IsNotFound(err):
+		result.Status = batchStatusNotFound
+	case code != "":
+		result.Status = batchStatusConflict
+		result.Code = code
+		result.Reason = err.Error()
+	default:
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+batchSavepoint)
+
+	return err
+}
+
+// rejectionCode returns the code of an error rejecting the update of a single tenant, the code of
+// the validation error when it is one, or the code of its class. The empty string is returned for
+// other errors.
+func rejectionCode(err error) string {
+	var verr *validation.Error
+
+	if errors.As(err, &verr) {
+		return verr.Code
+	}
+
+	class, ok := apierrors.ClassOf(err)
+	if !ok {
+		return ""
+	}
+
+	switch class.Err {
+	case apierrors.ErrTenantFrozen, apierrors.ErrConflict, apierrors.ErrInvalidArgument, apierrors.ErrNameConflict:
+		return class.Code
+	}
+
+	return ""
+}
+
+// statusConflict returns why the tenant can't move to the status, or the empty string when it can.
+// Moving to the status the tenant already has changes nothing.
+func statusConflict(t *ent.Tenant, effective, status string) string {
+	current := patchStatusActive
+
+	switch {
+	case t.Archived:
+		current = patchStatusArchived
+	case !t.SuspendedAt.IsZero():
+		current = patchStatusSuspended
+	}
+
+	switch {
+	case current == status:
+		return ""
+	case effective != deletion.StatusActive:
+		return fmt.Sprintf("tenant is %s and can't be made %s", effective, status)
+	case current != patchStatusActive && status != patchStatusActive:
+		return fmt.Sprintf("tenant is %s and must be made active before it is %s", current, status)
+	}
+
+	return ""
+}
+
+// patchStatus moves the tenant to the status, suspended tenants keep the time they were first
+// suspended at.
+func patchStatus(update *ent.TenantUpdateOne, t *ent.Tenant, status string, now time.Time) {
+	switch status {
+	case patchStatusActive:
+		if t.Archived {
+			update.SetArchived(false)
+		}
+
+		if !t.SuspendedAt.IsZero() {
+			update.ClearSuspendedAt()
+		}
+	case patchStatusSuspended:
+		if t.SuspendedAt.IsZero() {
+			update.SetSuspendedAt(now)
+		}
+	case patchStatusArchived:
+		update.SetArchived(true)
+	}
+}
+
+// patchLabels returns the labels with the patch applied, a null value removing the label.
+func patchLabels(labels map[string]string, patch map[string]*string) map[string]string {
+	patched := make(map[string]string, len(labels)+len(patch))
+
+	for k, v := range labels {
+		patched[k] = v
+	}
+
+	for k, v := range patch {
+		if v == nil {
+			delete(patched, k)
+		} else {
+			patched[k] = *v
+		}
+	}
+
+	return patched
+}
@@ -0,0 +1,343 @@
+package restapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/history"
+	"go.infratographer.com/tenant-api/internal/reparent"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+const (
+	testActor     = "idntusr-tester"
+	testRequestID = "test-request-id"
+)
+
+type eventEnv struct {
+	ctx    context.Context
+	client *ent.Client
+	conn   *eventtools.MockConnection
+	logs   *observer.ObservedLogs
+	url    string
+}
+
+func newEventEnv(t *testing.T, denied gidx.PrefixedID, opts ...restapi.Option) *eventEnv {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(deletion.Hook())
+	client.Tenant.Use(reparent.Hook())
+	client.Tenant.Use(history.Hook())
+	client.Tenant.Use(validation.NewSettingsValidator(validation.WithSettingsMaxSize(256), validation.WithSettingsMaxDepth(3)).Hook())
+	eventhooks.EventHooks(client)
+
+	checker := func(_ context.Context, requests ...permissions.AccessRequest) error {
+		for _, req := range requests {
+			if req.ResourceID == denied {
+				return permissions.ErrPermissionDenied
+			}
+		}
+
+		return nil
+	}
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(checker))
+	require.NoError(t, err)
+
+	core, logs := observer.New(zap.DebugLevel)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.New(core).Sugar(), []echo.MiddlewareFunc{actorMiddleware, perms.Middleware(), scopeMiddleware},
+		append([]restapi.Option{restapi.WithMaxBatchSize(3)}, opts...)...,
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return &eventEnv{
+		ctx:    context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms),
+		client: client,
+		conn:   conn,
+		logs:   logs,
+		url:    srv.URL,
+	}
+}
+
+// actorMiddleware sets the actor the jwt middleware would set for a validated token.
+func actorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, testActor)))
+
+		return next(c)
+	}
+}
+
+func (env *eventEnv) post(t *testing.T, path, body string) (int, []byte) {
+	t.Helper()
+
+	return env.do(t, http.MethodPost, path, echo.MIMEApplicationJSON, body)
+}
+
+func (env *eventEnv) do(t *testing.T, method, path, contentType, body string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, env.url+path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	req.Header.Set(echo.HeaderContentType, contentType)
+	req.Header.Set(echo.HeaderXRequestID, testRequestID)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, respBody
+}
+
+func TestTenantBatchUpdate(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`","tnntten-missing","`+child.ID.String()+`"],"patch":{"description":"suspended"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"results":[
+		{"id":"`+root.ID.String()+`","status":"updated"},
+		{"id":"tnntten-missing","status":"not_found"},
+		{"id":"`+child.ID.String()+`","status":"updated"}
+	]}`, string(body))
+
+	assert.Equal(t, "suspended", env.client.Tenant.GetX(env.ctx, root.ID).Description)
+	assert.Equal(t, "suspended", env.client.Tenant.GetX(env.ctx, child.ID).Description)
+
+	// one event per updated tenant, published after the commit
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 2)
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`","`+root.ID.String()+`"],"patch":{"description":"again"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var resp struct {
+		Results []struct {
+			Status string `json:"status"`
+		} `json:"results"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, "updated", resp.Results[0].Status)
+	assert.Equal(t, "conflict", resp.Results[1].Status)
+}
+
+func TestTenantBatchUpdateLabels(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SetLabels(map[string]string{"env": "prod", "owner": "finance"}).SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`","`+child.ID.String()+`"],"patch":{"labels":{"cost-center":"fin","owner":null}}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	// the labels are merged into those of each tenant, null removes one
+	assert.Equal(t, map[string]string{"env": "prod", "cost-center": "fin"}, env.client.Tenant.GetX(env.ctx, root.ID).Labels)
+	assert.Equal(t, map[string]string{"cost-center": "fin"}, env.client.Tenant.GetX(env.ctx, child.ID).Labels)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 2)
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+child.ID.String()+`"],"patch":{"labels":{"cost-center":null}}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Nil(t, env.client.Tenant.GetX(env.ctx, child.ID).Labels, "removing the last label clears them")
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`"],"patch":{"labels":{"Bad Key":"x"}}}`)
+	assert.Equal(t, http.StatusBadRequest, status, string(body))
+	assert.Contains(t, string(body), "patch.labels.Bad Key")
+}
+
+func TestTenantBatchUpdateStatus(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied", restapi.WithMaxBatchSize(5))
+
+	suspendedAt := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	active := env.client.Tenant.Create().SetName("active").SaveX(env.ctx)
+	suspended := env.client.Tenant.Create().SetName("suspended").SetSuspendedAt(suspendedAt).SaveX(env.ctx)
+	archived := env.client.Tenant.Create().SetName("archived").SetArchived(true).SaveX(env.ctx)
+	deleted := env.client.Tenant.Create().SetName("deleted").SaveX(env.ctx)
+	below := env.client.Tenant.Create().SetName("below").SetParent(deleted).SaveX(env.ctx)
+
+	env.client.Tenant.UpdateOne(deleted).SetDeletionScheduledAt(time.Now()).ExecX(env.ctx)
+
+	ids := `["` + active.ID.String() + `","` + suspended.ID.String() + `","` + archived.ID.String() + `","` + deleted.ID.String() + `","` + below.ID.String() + `"]`
+
+	statuses := func(t *testing.T, body []byte) []string {
+		t.Helper()
+
+		var resp struct {
+			Results []struct {
+				Status string `json:"status"`
+				Reason string `json:"reason"`
+			} `json:"results"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &resp))
+
+		got := make([]string, len(resp.Results))
+
+		for i, r := range resp.Results {
+			got[i] = r.Status
+
+			assert.Equal(t, r.Status == "conflict", r.Reason != "", "conflicts tell why")
+		}
+
+		return got
+	}
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", `{"ids":`+ids+`,"patch":{"status":"suspended"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, []string{"updated", "updated", "conflict", "conflict", "conflict"}, statuses(t, body))
+
+	assert.False(t, env.client.Tenant.GetX(env.ctx, active.ID).SuspendedAt.IsZero())
+	assert.Equal(t, suspendedAt, env.client.Tenant.GetX(env.ctx, suspended.ID).SuspendedAt.UTC(), "suspended tenants keep their suspension time")
+	assert.True(t, env.client.Tenant.GetX(env.ctx, archived.ID).Archived, "archived tenants must be made active first")
+	assert.True(t, env.client.Tenant.GetX(env.ctx, below.ID).SuspendedAt.IsZero())
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":`+ids+`,"patch":{"status":"archived"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, []string{"conflict", "conflict", "updated", "conflict", "conflict"}, statuses(t, body))
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":`+ids+`,"patch":{"status":"active"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, []string{"updated", "updated", "updated", "updated", "updated"}, statuses(t, body), "tenants pending deletion are already active")
+
+	for _, id := range []gidx.PrefixedID{active.ID, suspended.ID, archived.ID} {
+		tnt := env.client.Tenant.GetX(env.ctx, id)
+
+		assert.True(t, tnt.SuspendedAt.IsZero(), tnt.Name)
+		assert.False(t, tnt.Archived, tnt.Name)
+	}
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":`+ids+`,"patch":{"status":"archived"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, []string{"updated", "updated", "updated", "conflict", "conflict"}, statuses(t, body))
+	assert.True(t, env.client.Tenant.GetX(env.ctx, active.ID).Archived)
+}
+
+func TestTenantBatchUpdateConflicts(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	env.client.Tenant.Use(freeze.Hook())
+	env.client.Tenant.Use(validation.NewPipeline(validation.NewLabelsValidator(validation.WithMaxLabels(2)).Rules()...).Hook())
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	frozen := env.client.Tenant.Create().SetName("frozen").SetParent(root).SaveX(env.ctx)
+	labeled := env.client.Tenant.Create().SetName("labeled").SetParent(root).SetLabels(map[string]string{"env": "prod", "owner": "finance"}).SaveX(env.ctx)
+
+	_, err := freeze.Freeze(env.ctx, env.client, frozen.ID)
+	require.NoError(t, err)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`","`+frozen.ID.String()+`","`+labeled.ID.String()+`"],"patch":{"description":"patched","labels":{"tier":"gold"}}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var resp struct {
+		Results []struct {
+			ID     gidx.PrefixedID `json:"id"`
+			Status string          `json:"status"`
+			Code   string          `json:"code"`
+			Reason string          `json:"reason"`
+		} `json:"results"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp.Results, 3)
+
+	assert.Equal(t, "updated", resp.Results[0].Status)
+	assert.Equal(t, "conflict", resp.Results[1].Status)
+	assert.Equal(t, "tenant_frozen", resp.Results[1].Code)
+	assert.Contains(t, resp.Results[1].Reason, "frozen")
+	assert.Equal(t, "conflict", resp.Results[2].Status)
+	assert.Equal(t, validation.CodeTooManyLabels, resp.Results[2].Code)
+
+	// the rejected tenants are left as they were, the others are updated
+	assert.Equal(t, "patched", env.client.Tenant.GetX(env.ctx, root.ID).Description)
+	assert.Empty(t, env.client.Tenant.GetX(env.ctx, frozen.ID).Description)
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "finance"}, env.client.Tenant.GetX(env.ctx, labeled.ID).Labels)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+}
+
+func TestTenantBatchUpdateRejected(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SetDescription("unchanged").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{name: "outside authorized subtree", body: `{"ids":["` + root.ID.String() + `","tnntten-denied"],"patch":{"description":"x"}}`, expected: http.StatusForbidden},
+		{name: "too many ids", body: `{"ids":["tnntten-a","tnntten-b","tnntten-c","tnntten-d"],"patch":{"description":"x"}}`, expected: http.StatusBadRequest},
+		{name: "no ids", body: `{"ids":[],"patch":{"description":"x"}}`, expected: http.StatusBadRequest},
+		{name: "empty patch", body: `{"ids":["` + root.ID.String() + `"],"patch":{}}`, expected: http.StatusBadRequest},
+		{name: "unsupported field", body: `{"ids":["` + root.ID.String() + `"],"patch":{"name":"renamed"}}`, expected: http.StatusBadRequest},
+		{name: "unknown status", body: `{"ids":["` + root.ID.String() + `"],"patch":{"status":"frozen"}}`, expected: http.StatusBadRequest},
+		{name: "invalid id", body: `{"ids":["loadbal-test"],"patch":{"description":"x"}}`, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.post(t, "/v1/tenants:batchUpdate", tt.body)
+			assert.Equal(t, tt.expected, status, string(body))
+		})
+	}
+
+	assert.Equal(t, "unchanged", env.client.Tenant.GetX(env.ctx, root.ID).Description)
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+}
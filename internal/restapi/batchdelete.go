@@ -0,0 +1,258 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/dependents"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Per tenant outcomes of a batch delete, besides not_found and conflict for ids listed more than
+// once. Skipped tenants could have been deleted but weren't, as another tenant of an all or nothing
+// batch failed.
+const (
+	batchStatusDeleted     = "deleted"
+	batchStatusHasChildren = "has_children"
+	batchStatusForbidden   = "forbidden"
+	batchStatusSkipped     = "skipped"
+)
+
+// Modes of a batch delete.
+const (
+	batchModeAllOrNothing = "all_or_nothing"
+	batchModeBestEffort   = "best_effort"
+)
+
+type batchDeleteRequest struct {
+	IDs  []gidx.PrefixedID `json:"ids"`
+	Mode string            `json:"mode"`
+}
+
+type batchDeleteResponse struct {
+	Mode      string              `json:"mode"`
+	Committed bool                `json:"committed"`
+	Results   []batchUpdateResult `json:"results"`
+}
+
+// tenantBatchDelete deletes the listed tenants in a single transaction. Each tenant is checked on
+// its own: tenants the caller may not delete are forbidden, and tenants with children are only
+// deleted when all their children are deleted by the same batch, so a subtree can be deleted in
+// one request whatever the order of the ids. In all_or_nothing mode, the default, the transaction
+// is rolled back unless every tenant is deleted and the response is a conflict, in best_effort mode
+// the deletable tenants are deleted regardless. Change events are published once the transaction
+// commits. Tenants which resources of other services depend on have dependents, unless the
+// deletion is forced with force=true. Tenants protected against deletion are never deleted by a
+// batch, as their names can't be confirmed, so neither are their ancestors.
+func (h *Handler) tenantBatchDelete(c echo.Context) error {
+	ctx, err := h.deleteContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req batchDeleteRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if req.Mode == "" {
+		req.Mode = batchModeAllOrNothing
+	}
+
+	var errs validation.Errors
+
+	checkTenantIDs(&errs, "ids", req.IDs, h.maxBatchSize)
+
+	if req.Mode != batchModeAllOrNothing && req.Mode != batchModeBestEffort {
+		errs.Add("mode", validation.CodeInvalidValue, fmt.Sprintf("must be %s or %s", batchModeAllOrNothing, batchModeBestEffort))
+	}
+
+	if err := errs.Err(); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	unique := uniqueIDs(req.IDs)
+
+	statuses := make(map[gidx.PrefixedID]string, len(unique))
+	allowed := make([]gidx.PrefixedID, 0, len(unique))
+
+	for _, id := range unique {
+		err := permissions.CheckAccess(ctx, id, actionTenantDelete)
+
+		switch {
+		case err == nil:
+			allowed = append(allowed, id)
+		case errors.Is(err, permissions.ErrPermissionDenied):
+			statuses[id] = batchStatusForbidden
+		default:
+			return errmap.HTTPError(err)
+		}
+	}
+
+	batchCtx, batch := changefeed.WithBatch(ctx)
+
+	committed, err := h.applyBatchDelete(batchCtx, req.Mode, allowed, statuses)
+	if err != nil {
+		return deleteError(err)
+	}
+
+	if committed {
+		if err := batch.Flush(ctx); err != nil {
+			// the deletions are committed, report them even though some events were lost
+			h.log(c).Errorw("failed to publish batch delete changes", "error", err)
+		}
+	}
+
+	resp := batchDeleteResponse{
+		Mode:      req.Mode,
+		Committed: committed,
+		Results:   make([]batchUpdateResult, 0, len(req.IDs)),
+	}
+
+	listed := make(map[gidx.PrefixedID]bool, len(unique))
+
+	for _, id := range req.IDs {
+		result := batchUpdateResult{ID: id, Status: statuses[id]}
+
+		switch {
+		case listed[id]:
+			result.Status = batchStatusConflict
+		case !committed && result.Status == batchStatusDeleted:
+			result.Status = batchStatusSkipped
+		}
+
+		listed[id] = true
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	if !committed {
+		return c.JSON(http.StatusConflict, resp)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// applyBatchDelete deletes the tenants in a transaction, recording the outcome of each in
+// statuses. The transaction is only committed in all or nothing mode when every tenant listed was
+// deleted, the returned bool reports whether it was.
+func (h *Handler) applyBatchDelete(ctx context.Context, mode string, ids []gidx.PrefixedID, statuses map[gidx.PrefixedID]string) (bool, error) {
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rollback := func() {
+		if rerr := tx.Rollback(); rerr != nil {
+			reqlog.FromContext(ctx, h.logger).Errorw("failed to roll back batch delete", "error", rerr)
+		}
+	}
+
+	if err := deleteTenants(ctx, tx, ids, statuses); err != nil {
+		rollback()
+
+		return false, err
+	}
+
+	if mode == batchModeAllOrNothing {
+		for _, status := range statuses {
+			if status != batchStatusDeleted {
+				rollback()
+
+				return false, nil
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deleteTenants deletes the tenants without children, over and over as deleting children may leave
+// their parents without any, until no more tenant can be deleted. The remaining ones have
+// children which aren't part of the batch, which have dependents or which are protected. Tenants
+// with dependents or protected are rejected before anything is written, so the transaction carries
+// on.
+func deleteTenants(ctx context.Context, tx *ent.Tx, ids []gidx.PrefixedID, statuses map[gidx.PrefixedID]string) error {
+	existing, err := tx.Tenant.Query().Where(enttenant.IDIn(ids...)).IDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[gidx.PrefixedID]bool, len(existing))
+
+	for _, id := range existing {
+		found[id] = true
+	}
+
+	pending := make([]gidx.PrefixedID, 0, len(existing))
+
+	for _, id := range ids {
+		if found[id] {
+			pending = append(pending, id)
+		} else {
+			statuses[id] = batchStatusNotFound
+		}
+	}
+
+	for deleted := true; deleted && len(pending) != 0; {
+		deleted = false
+		remaining := pending[:0]
+
+		for _, id := range pending {
+			children, err := tx.Tenant.Query().Where(enttenant.ParentTenantID(id)).Count(ctx)
+			if err != nil {
+				return err
+			}
+
+			if children != 0 {
+				remaining = append(remaining, id)
+
+				continue
+			}
+
+			err = tx.Tenant.DeleteOneID(id).Exec(ctx)
+
+			switch {
+			case errors.Is(err, dependents.ErrHasDependents):
+				statuses[id] = batchStatusHasDependents
+
+				continue
+			case errors.Is(err, apierrors.ErrDeletionProtected):
+				statuses[id] = batchStatusProtected
+
+				continue
+			case err != nil:
+				return err
+			}
+
+			statuses[id] = batchStatusDeleted
+			deleted = true
+		}
+
+		pending = remaining
+	}
+
+	for _, id := range pending {
+		statuses[id] = batchStatusHasChildren
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package restapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantBatchDelete(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	body := `{"ids":["` + root.ID.String() + `","` + child.ID.String() + `","tnntten-missing"],"mode":"best_effort"}`
+
+	// the parent is listed first, it is deleted once its child is
+	status, resp := env.do(t, http.MethodDelete, "/v1/tenants", echo.MIMEApplicationJSON, body)
+	require.Equal(t, http.StatusOK, status, string(resp))
+	assert.JSONEq(t, `{"mode":"best_effort","committed":true,"results":[
+		{"id":"`+root.ID.String()+`","status":"deleted"},
+		{"id":"`+child.ID.String()+`","status":"deleted"},
+		{"id":"tnntten-missing","status":"not_found"}
+	]}`, string(resp))
+
+	assert.False(t, env.client.Tenant.Query().ExistX(env.ctx))
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 2)
+
+	leaf := env.client.Tenant.Create().SetName("leaf").SaveX(env.ctx)
+
+	status, resp = env.do(t, http.MethodDelete, "/v1/tenants", echo.MIMEApplicationJSON, `{"ids":["`+leaf.ID.String()+`","`+leaf.ID.String()+`"]}`)
+	require.Equal(t, http.StatusOK, status, string(resp))
+	assert.JSONEq(t, `{"mode":"all_or_nothing","committed":true,"results":[
+		{"id":"`+leaf.ID.String()+`","status":"deleted"},
+		{"id":"`+leaf.ID.String()+`","status":"conflict"}
+	]}`, string(resp))
+}
+
+func TestTenantBatchDeleteModes(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	leaf := env.client.Tenant.Create().SetName("leaf").SaveX(env.ctx)
+	parent := env.client.Tenant.Create().SetName("parent").SaveX(env.ctx)
+	env.client.Tenant.Create().SetName("kept").SetParent(parent).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	ids := `"` + leaf.ID.String() + `","` + parent.ID.String() + `","tnntten-denied"`
+
+	status, resp := env.do(t, http.MethodDelete, "/v1/tenants", echo.MIMEApplicationJSON, `{"ids":[`+ids+`]}`)
+	require.Equal(t, http.StatusConflict, status, string(resp))
+	assert.JSONEq(t, `{"mode":"all_or_nothing","committed":false,"results":[
+		{"id":"`+leaf.ID.String()+`","status":"skipped"},
+		{"id":"`+parent.ID.String()+`","status":"has_children"},
+		{"id":"tnntten-denied","status":"forbidden"}
+	]}`, string(resp))
+
+	assert.Equal(t, 4, env.client.Tenant.Query().CountX(env.ctx))
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+
+	status, resp = env.do(t, http.MethodDelete, "/v1/tenants", echo.MIMEApplicationJSON, `{"ids":[`+ids+`],"mode":"best_effort"}`)
+	require.Equal(t, http.StatusOK, status, string(resp))
+	assert.JSONEq(t, `{"mode":"best_effort","committed":true,"results":[
+		{"id":"`+leaf.ID.String()+`","status":"deleted"},
+		{"id":"`+parent.ID.String()+`","status":"has_children"},
+		{"id":"tnntten-denied","status":"forbidden"}
+	]}`, string(resp))
+
+	assert.Equal(t, 3, env.client.Tenant.Query().CountX(env.ctx))
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+}
+
+func TestTenantBatchDeleteRejected(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "too many ids", body: `{"ids":["tnntten-a","tnntten-b","tnntten-c","tnntten-d"]}`},
+		{name: "no ids", body: `{"ids":[]}`},
+		{name: "unknown mode", body: `{"ids":["tnntten-a"],"mode":"eventually"}`},
+		{name: "invalid id", body: `{"ids":["loadbal-test"]}`},
+		{name: "unknown field", body: `{"ids":["tnntten-a"],"cascade":true}`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.do(t, http.MethodDelete, "/v1/tenants", echo.MIMEApplicationJSON, tt.body)
+			assert.Equal(t, http.StatusBadRequest, status, string(body))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+const (
+	// Page sizes of the changes.
+	defaultChangesPageSize = 500
+	maxChangesPageSize     = 1000
+
+	codeResyncRequired = "resync_required"
+)
+
+// WithChangeRetention sets the time the recorded tenant changes are kept for, the same as the
+// deletion scheduler purges them with. Catching up from a sequence older than that is rejected,
+// as changes following it may be gone. Catching up is never rejected without a retention.
+func WithChangeRetention(d time.Duration) Option {
+	return func(h *Handler) {
+		h.changeRetention = d
+	}
+}
+
+type tenantChange struct {
+	Seq       int64           `json:"seq"`
+	Operation string          `json:"operation"`
+	TenantID  gidx.PrefixedID `json:"tenantID"`
+	ChangedAt timefmt.Time    `json:"changedAt"`
+	Snapshot  *tenant         `json:"snapshot,omitempty"`
+}
+
+type changesResponse struct {
+	Changes   []tenantChange `json:"changes"`
+	NextSince int64          `json:"nextSince"`
+	HasMore   bool           `json:"hasMore"`
+}
+
+// tenantChanges lists the changes made to every tenant after the since sequence, in sequence
+// order. Replicas catch up by passing the nextSince of the previous page until hasMore is false.
+// The snapshot of a change is the tenant as it is now rather than as the change left it, so
+// replicas applying the changes in order end up with the current tenants. Deletions and the
+// changes of tenants deleted since have no snapshot. A since older than the change retention is
+// rejected with resync_required, the replica must crawl every tenant again.
+func (h *Handler) tenantChanges(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := defaultChangesPageSize
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		var err error
+
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxChangesPageSize {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxChangesPageSize))
+		}
+	}
+
+	since, err := strconv.ParseInt(c.QueryParam("since"), 10, 64)
+	if err != nil || since < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "since must be a non negative integer")
+	}
+
+	if err := h.checkChangeRetention(ctx, since, "crawl the tenants again"); err != nil {
+		return err
+	}
+
+	changes, err := h.client.TenantChange.Query().
+		Where(enttenantchange.IDGT(since)).
+		Order(ent.Asc(enttenantchange.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp := changesResponse{
+		Changes:   make([]tenantChange, 0, len(changes)),
+		NextSince: since,
+	}
+
+	if len(changes) > limit {
+		changes = changes[:limit]
+		resp.HasMore = true
+	}
+
+	snapshots, err := h.changeSnapshots(c, changes)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range changes {
+		change := tenantChange{
+			Seq:       ch.ID,
+			Operation: ch.Operation,
+			TenantID:  ch.TenantID,
+			ChangedAt: timefmt.New(ch.ChangedAt),
+		}
+
+		if snapshot, ok := snapshots[ch.TenantID]; ok && ch.Operation != changeseq.OpDelete {
+			change.Snapshot = &snapshot
+		}
+
+		resp.Changes = append(resp.Changes, change)
+		resp.NextSince = ch.ID
+	}
+
+	var next string
+	if resp.HasMore {
+		next = strconv.FormatInt(resp.NextSince, 10)
+	}
+
+	return respondList(c, resp, resp.Changes, next)
+}
+
+// changeSnapshots loads the tenants which still exist among those of the changes.
+func (h *Handler) changeSnapshots(c echo.Context, changes []*ent.TenantChange) (map[gidx.PrefixedID]tenant, error) {
+	ids := make([]gidx.PrefixedID, 0, len(changes))
+	seen := make(map[gidx.PrefixedID]bool, len(changes))
+
+	for _, ch := range changes {
+		if ch.Operation != changeseq.OpDelete && !seen[ch.TenantID] {
+			seen[ch.TenantID] = true
+			ids = append(ids, ch.TenantID)
+		}
+	}
+
+	snapshots := make(map[gidx.PrefixedID]tenant, len(ids))
+
+	if len(ids) == 0 {
+		return snapshots, nil
+	}
+
+	ctx := c.Request().Context()
+
+	tenants, err := h.client.Tenant.Query().Where(enttenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := redact.FromContext(ctx)
+
+	for _, t := range tenants {
+		snapshots[t.ID] = newTenant(t, fields)
+	}
+
+	return snapshots, nil
+}
+
+// checkChangeRetention rejects a since sequence older than the change retention with
+// resync_required, as changes following it may be gone. The message tells the client what to
+// refresh instead.
+func (h *Handler) checkChangeRetention(ctx context.Context, since int64, refresh string) error {
+	if h.changeRetention <= 0 {
+		return nil
+	}
+
+	horizon, err := changeseq.Horizon(ctx, h.client, h.clock.Now().UTC().Add(-h.changeRetention))
+	if err != nil {
+		return err
+	}
+
+	if since < horizon {
+		return echo.NewHTTPError(http.StatusGone, map[string]string{
+			"code":    codeResyncRequired,
+			"message": fmt.Sprintf("the changes after sequence %d are past their retention, %s", since, refresh),
+		})
+	}
+
+	return nil
+}
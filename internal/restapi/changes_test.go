@@ -0,0 +1,167 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+type changesPage struct {
+	Changes []struct {
+		Seq       int64           `json:"seq"`
+		Operation string          `json:"operation"`
+		TenantID  gidx.PrefixedID `json:"tenantID"`
+		Snapshot  *struct {
+			Name string `json:"name"`
+		} `json:"snapshot"`
+	} `json:"changes"`
+	NextSince int64 `json:"nextSince"`
+	HasMore   bool  `json:"hasMore"`
+}
+
+func listChanges(t *testing.T, baseURL string, since int64, limit int) changesPage {
+	t.Helper()
+
+	resp, body := get(t, baseURL+"/v1/tenants/changes?since="+strconv.FormatInt(since, 10)+"&limit="+strconv.Itoa(limit), crawlHeaders)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var page changesPage
+
+	require.NoError(t, json.Unmarshal(body, &page))
+
+	return page
+}
+
+func TestTenantChangesCatchUp(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	one := client.Tenant.Create().SetName("one").SaveX(ctx)
+	two := client.Tenant.Create().SetName("two").SaveX(ctx)
+	client.Tenant.UpdateOneID(one.ID).SetName("renamed").ExecX(ctx)
+	client.Tenant.DeleteOneID(two.ID).ExecX(ctx)
+
+	baseURL, _ := startServer(t, client, restapi.WithChangeRetention(time.Hour))
+
+	first := listChanges(t, baseURL, 0, 3)
+	require.Len(t, first.Changes, 3)
+	assert.True(t, first.HasMore)
+
+	second := listChanges(t, baseURL, first.NextSince, 3)
+	require.Len(t, second.Changes, 1)
+	assert.False(t, second.HasMore)
+
+	changes := append(first.Changes, second.Changes...)
+
+	var seqs []int64
+
+	for i, change := range changes {
+		seqs = append(seqs, change.Seq)
+
+		if i > 0 {
+			assert.Greater(t, change.Seq, changes[i-1].Seq)
+		}
+	}
+
+	assert.Equal(t, []string{changeseq.OpCreate, changeseq.OpCreate, changeseq.OpUpdate, changeseq.OpDelete},
+		[]string{changes[0].Operation, changes[1].Operation, changes[2].Operation, changes[3].Operation})
+	assert.Equal(t, []gidx.PrefixedID{one.ID, two.ID, one.ID, two.ID},
+		[]gidx.PrefixedID{changes[0].TenantID, changes[1].TenantID, changes[2].TenantID, changes[3].TenantID})
+
+	// snapshots are the current tenants, deleted ones have none
+	require.NotNil(t, changes[0].Snapshot)
+	assert.Equal(t, "renamed", changes[0].Snapshot.Name)
+	assert.Nil(t, changes[1].Snapshot)
+	assert.Nil(t, changes[3].Snapshot)
+
+	// at the head there is nothing more, the cursor stays
+	head := listChanges(t, baseURL, second.NextSince, 3)
+	assert.Empty(t, head.Changes)
+	assert.False(t, head.HasMore)
+	assert.Equal(t, seqs[3], head.NextSince)
+
+	for _, query := range []string{"", "?since=-1", "?since=nope", "?since=0&limit=0"} {
+		resp, body := get(t, baseURL+"/v1/tenants/changes"+query, crawlHeaders)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "%s: %s", query, body)
+	}
+
+	resp, body := get(t, baseURL+"/v1/tenants/changes?since=0", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+}
+
+func TestTenantChangesResyncRequired(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	old := client.TenantChange.Create().
+		SetTenantID(gidx.MustNewID(schema.TenantPrefix)).
+		SetOperation(changeseq.OpCreate).
+		SetChangedAt(time.Now().UTC().Add(-2 * time.Hour)).
+		SaveX(ctx)
+
+	client.Tenant.Create().SetName("recent").ExecX(ctx)
+
+	baseURL, _ := startServer(t, client, restapi.WithChangeRetention(time.Hour))
+
+	resp, body := get(t, baseURL+"/v1/tenants/changes?since=0", crawlHeaders)
+	assert.Equal(t, http.StatusGone, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), "resync_required")
+
+	// the changes after the last one past the retention are all kept
+	page := listChanges(t, baseURL, old.ID, 10)
+	require.Len(t, page.Changes, 1)
+
+	// without a retention every change is kept and served
+	baseURL, _ = startServer(t, client)
+
+	page = listChanges(t, baseURL, 0, 10)
+	assert.Len(t, page.Changes, 2)
+}
+
+func TestTenantChangesRetentionPassed(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	client.Tenant.Create().SetName("first").ExecX(ctx)
+
+	fake := clock.NewFake(time.Now().UTC())
+
+	baseURL, _ := startServer(t, client, restapi.WithChangeRetention(time.Hour), restapi.WithClock(fake))
+
+	page := listChanges(t, baseURL, 0, 10)
+	require.Len(t, page.Changes, 1)
+
+	// once the first change is past the retention, replicas which haven't seen it must resync
+	fake.Advance(time.Hour + time.Minute)
+
+	resp, body := get(t, baseURL+"/v1/tenants/changes?since=0", crawlHeaders)
+	assert.Equal(t, http.StatusGone, resp.StatusCode, string(body))
+
+	page = listChanges(t, baseURL, page.NextSince, 10)
+	assert.Empty(t, page.Changes)
+}
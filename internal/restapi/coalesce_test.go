@@ -0,0 +1,226 @@
+package restapi_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// gatedDriver counts the queries read through it, holding them while the gate is closed.
+type gatedDriver struct {
+	*entsql.Driver
+
+	queries atomic.Int64
+	gate    atomic.Pointer[chan struct{}]
+}
+
+func (d *gatedDriver) wait() {
+	d.queries.Add(1)
+
+	if gate := d.gate.Load(); gate != nil {
+		<-*gate
+	}
+}
+
+func (d *gatedDriver) Query(ctx context.Context, query string, args, v any) error {
+	d.wait()
+
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+func (d *gatedDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	d.wait()
+
+	return d.Driver.QueryContext(ctx, query, args...)
+}
+
+func TestReadCoalescing(t *testing.T) {
+	const callers = 10
+
+	ctx := context.Background()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	driver := &gatedDriver{Driver: drv}
+
+	client := ent.NewClient(ent.Driver(driver))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(ctx))
+
+	var (
+		checks atomic.Int64
+		hidden atomic.Pointer[string]
+	)
+
+	// callers sending X-Orphan may not access the hidden tenant
+	checker := func(ctx context.Context, reqs ...permissions.AccessRequest) error {
+		checks.Add(1)
+
+		for _, req := range reqs {
+			if id := hidden.Load(); id != nil && req.ResourceID.String() == *id && ctx.Value(orphanKey{}) != nil {
+				return permissions.ErrPermissionDenied
+			}
+		}
+
+		return nil
+	}
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(checker))
+	require.NoError(t, err)
+
+	// callers sending the header may only see the id and the name
+	restricted := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("X-Restricted") != "" {
+				fields := redact.Fields{redact.FieldName: true}
+				c.SetRequest(c.Request().WithContext(redact.WithFields(c.Request().Context(), fields)))
+			}
+
+			if c.Request().Header.Get("X-Orphan") != "" {
+				c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), orphanKey{}, true)))
+			}
+
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware(), restricted}, restapi.WithReadCoalescing()).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	tnt := client.Tenant.Create().SetName("popular").SetDescription("secret").SaveX(ctx)
+
+	// run sends the requests at once, holding the database until every caller passed its access
+	// check and joined a read in flight, the first ones sending the header
+	run := func(t *testing.T, path, header string, sending int) ([][]byte, int64) {
+		t.Helper()
+
+		gate := make(chan struct{})
+		driver.gate.Store(&gate)
+		driver.queries.Store(0)
+		checks.Store(0)
+
+		bodies := make([][]byte, callers)
+
+		var wg sync.WaitGroup
+
+		wg.Add(callers)
+
+		for i := 0; i < callers; i++ {
+			go func(i int) {
+				defer wg.Done()
+
+				headers := map[string]string{}
+				if i < sending {
+					headers[header] = "1"
+				}
+
+				resp, body := get(t, srv.URL+path, headers)
+				assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+				bodies[i] = body
+			}(i)
+		}
+
+		require.Eventually(t, func() bool { return checks.Load() == callers }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+
+		driver.gate.Store(nil)
+		close(gate)
+		wg.Wait()
+
+		return bodies, driver.queries.Load()
+	}
+
+	t.Run("get", func(t *testing.T) {
+		bodies, queries := run(t, "/v1/tenants/"+tnt.ID.String(), "", 0)
+
+		assert.Equal(t, int64(1), queries)
+
+		for _, body := range bodies {
+			assert.JSONEq(t, string(bodies[0]), string(body))
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		// the ancestors walked for the creation freeze are cached by the first computation
+		run(t, "/v1/tenants/"+tnt.ID.String()+"/stats", "", 0)
+
+		_, single := run(t, "/v1/tenants/"+tnt.ID.String()+"/stats", "X-Restricted", callers-1)
+		_, coalesced := run(t, "/v1/tenants/"+tnt.ID.String()+"/stats", "", 0)
+
+		// the restricted caller computed the statistics on its own
+		assert.Equal(t, 2*coalesced, single)
+	})
+
+	t.Run("redaction scopes", func(t *testing.T) {
+		bodies, queries := run(t, "/v1/tenants/"+tnt.ID.String(), "X-Restricted", callers/2)
+
+		assert.Equal(t, int64(2), queries)
+
+		for i, body := range bodies {
+			var resp map[string]any
+
+			require.NoError(t, json.Unmarshal(body, &resp))
+
+			if i < callers/2 {
+				assert.NotContains(t, resp, "description", "restricted caller %d", i)
+			} else {
+				assert.Equal(t, "secret", resp["description"], "caller %d", i)
+			}
+		}
+	})
+
+	t.Run("parent access", func(t *testing.T) {
+		child := client.Tenant.Create().SetName("child").SetParent(tnt).SaveX(ctx)
+
+		parentID := tnt.ID.String()
+		hidden.Store(&parentID)
+		t.Cleanup(func() { hidden.Store(nil) })
+
+		path := "/v1/tenants/" + child.ID.String() + "?include=parent"
+
+		_, alone := run(t, path, "", 0)
+		bodies, queries := run(t, path, "X-Orphan", callers/2)
+
+		assert.Equal(t, alone, queries, "callers with and without access to the parent share a read")
+
+		for i, body := range bodies {
+			var resp map[string]any
+
+			require.NoError(t, json.Unmarshal(body, &resp))
+
+			if i < callers/2 {
+				assert.NotContains(t, resp, "parent", "caller %d may not access the parent", i)
+			} else {
+				require.Contains(t, resp, "parent", "caller %d", i)
+				assert.Equal(t, "popular", resp["parent"].(map[string]any)["name"], "caller %d", i)
+			}
+		}
+	})
+}
+
+type orphanKey struct{}
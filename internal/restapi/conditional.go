@@ -0,0 +1,30 @@
+package restapi
+
+import "strings"
+
+// ifNoneMatch evaluates an If-None-Match header against the current entity tag as described by
+// RFC 9110 section 13.1.2, reporting whether the condition fails and a 304 should be returned.
+// Comparison is weak, so a weak validator from the client matches the strong tag.
+func ifNoneMatch(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if weakMatch(strings.TrimSpace(candidate), etag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weakMatch compares two entity tags ignoring the weak indicator.
+func weakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
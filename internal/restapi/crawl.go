@@ -0,0 +1,384 @@
+package restapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/gidx"
+	"golang.org/x/time/rate"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+const (
+	// DefaultCrawlMaxAge is the default age of its snapshot after which a crawl can't be resumed.
+	// It must stay below the garbage collection window of the database.
+	DefaultCrawlMaxAge = 4 * time.Hour
+
+	// DefaultCrawlRate is the default number of batches a caller may crawl per second.
+	DefaultCrawlRate = 5
+	// DefaultCrawlBurst is the default number of batches a caller may crawl at once.
+	DefaultCrawlBurst = 10
+
+	// SnapshotTimeHeader is set to the time of the snapshot a crawl batch is read at.
+	SnapshotTimeHeader = "X-Snapshot-Time"
+
+	// Batch sizes of the crawl.
+	defaultCrawlBatchSize = 500
+	maxCrawlBatchSize     = 1000
+
+	// followerReadDelay is how far in the past crawls start, old enough for follower reads.
+	followerReadDelay = 5 * time.Second
+
+	// crawlClockSkew is how far ahead of this replica's clock the snapshot of a resumed crawl may be.
+	crawlClockSkew = time.Minute
+
+	// crawlLimitersMax bounds the number of callers rate limited at once, the limiters are
+	// forgotten when it is reached.
+	crawlLimitersMax = 10000
+
+	codeCrawlExpired = "crawl_expired"
+)
+
+// WithCrawlScope sets the token scope required to crawl every tenant and list their changes, the
+// crawl and changes endpoints aren't registered without one.
+func WithCrawlScope(scope string) Option {
+	return func(h *Handler) {
+		h.crawl.scope = scope
+	}
+}
+
+// WithCrawlRateLimit sets the number of batches each caller may crawl per second, with bursts of up
+// to burst batches.
+func WithCrawlRateLimit(limit float64, burst int) Option {
+	return func(h *Handler) {
+		if limit > 0 && burst > 0 {
+			h.crawl.limit = rate.Limit(limit)
+			h.crawl.burst = burst
+		}
+	}
+}
+
+// WithCrawlMaxAge sets the age of its snapshot after which a crawl can't be resumed.
+func WithCrawlMaxAge(maxAge time.Duration) Option {
+	return func(h *Handler) {
+		if maxAge > 0 {
+			h.crawl.maxAge = maxAge
+		}
+	}
+}
+
+// WithCrawlTokenKey sets the key resume tokens are signed with. Replicas sharing a crawl need the
+// same key, a random one only valid in this process is used without it.
+func WithCrawlTokenKey(key []byte) Option {
+	return func(h *Handler) {
+		if len(key) > 0 {
+			h.crawl.tokenKey = key
+		}
+	}
+}
+
+// WithFollowerReads reads crawls at their snapshot time with AS OF SYSTEM TIME, which requires
+// CockroachDB. Without it crawls leave out the tenants created after their snapshot, but do see
+// the later changes and deletions of the others.
+func WithFollowerReads() Option {
+	return func(h *Handler) {
+		h.crawl.followerReads = true
+	}
+}
+
+// crawler holds the crawl settings and the rate limiters of the callers.
+type crawler struct {
+	scope         string
+	limit         rate.Limit
+	burst         int
+	maxAge        time.Duration
+	followerReads bool
+	tokenKey      []byte
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newCrawler() *crawler {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+
+	return &crawler{
+		limit:    DefaultCrawlRate,
+		burst:    DefaultCrawlBurst,
+		maxAge:   DefaultCrawlMaxAge,
+		tokenKey: key,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// allow reports whether the caller may crawl another batch now, along with the rate limit.
+func (cr *crawler) allow(caller string) (bool, rate.Limit) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	limiter, ok := cr.limiters[caller]
+	if !ok {
+		if len(cr.limiters) >= crawlLimitersMax {
+			cr.limiters = map[string]*rate.Limiter{}
+		}
+
+		limiter = rate.NewLimiter(cr.limit, cr.burst)
+		cr.limiters[caller] = limiter
+	}
+
+	return limiter.Allow(), cr.limit
+}
+
+// setRateLimit changes the rate limit of every caller, keeping the batches they have left.
+func (cr *crawler) setRateLimit(limit rate.Limit, burst int) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	cr.limit = limit
+	cr.burst = burst
+
+	for _, limiter := range cr.limiters {
+		limiter.SetLimit(limit)
+		limiter.SetBurst(burst)
+	}
+}
+
+// crawlToken is the state of a crawl, encoded in its resume token so any replica can continue it.
+// Tokens are signed, callers can't move a crawl to a snapshot or position of their choosing.
+type crawlToken struct {
+	SnapshotTime time.Time       `json:"s"`
+	CreatedAt    time.Time       `json:"c"`
+	ID           gidx.PrefixedID `json:"i"`
+	MinChangeSeq int64           `json:"m,omitempty"`
+}
+
+func (t crawlToken) encode(key []byte) string {
+	raw, _ := json.Marshal(t)
+
+	return base64.RawURLEncoding.EncodeToString(raw) + "." + base64.RawURLEncoding.EncodeToString(signCrawlToken(key, raw))
+}
+
+func signCrawlToken(key, raw []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+
+	return mac.Sum(nil)
+}
+
+func decodeCrawlToken(key []byte, token string) (crawlToken, error) {
+	var t crawlToken
+
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return t, fmt.Errorf("unsigned crawl token")
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return t, err
+	}
+
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return t, err
+	}
+
+	if !hmac.Equal(sum, signCrawlToken(key, raw)) {
+		return t, fmt.Errorf("invalid crawl token signature")
+	}
+
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return t, err
+	}
+
+	if t.SnapshotTime.IsZero() || t.ID.Prefix() != schema.TenantPrefix {
+		return t, fmt.Errorf("incomplete crawl token")
+	}
+
+	return t, nil
+}
+
+type crawlResponse struct {
+	Tenants      []tenant     `json:"tenants"`
+	SnapshotTime timefmt.Time `json:"snapshotTime"`
+	ResumeToken  string       `json:"resumeToken,omitempty"`
+}
+
+// limitCrawl rejects callers crawling faster than allowed.
+func (h *Handler) limitCrawl(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		caller, _ := c.Request().Context().Value(echojwtx.ActorCtxKey).(string)
+
+		if ok, limit := h.crawl.allow(caller); !ok {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(1/float64(limit)))))
+
+			return echo.NewHTTPError(http.StatusTooManyRequests, "crawl rate limit exceeded")
+		}
+
+		return next(c)
+	}
+}
+
+// tenantCrawl returns every tenant in batches ordered by creation time and id. A crawl is read at
+// the snapshot time of its first batch, reported with each batch, and continued with the
+// resume_token query parameter until a batch comes without one. Tokens carry the whole state of the
+// crawl, they stay valid across restarts, and on the replicas sharing the token key, until the
+// snapshot is older than the max age. With
+// min_change_seq only the tenants changed at or after that sequence are returned, letting callers
+// catch up from the highest sequence they've seen.
+func (h *Handler) tenantCrawl(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := defaultCrawlBatchSize
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		var err error
+
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxCrawlBatchSize {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxCrawlBatchSize))
+		}
+	}
+
+	var minChangeSeq int64
+
+	if raw := c.QueryParam("min_change_seq"); raw != "" {
+		var err error
+
+		minChangeSeq, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || minChangeSeq < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "min_change_seq must be a non negative integer")
+		}
+	}
+
+	var after *crawlToken
+
+	snapshotTime := h.clock.Now().UTC()
+	if h.crawl.followerReads {
+		snapshotTime = snapshotTime.Add(-followerReadDelay)
+	}
+
+	if raw := c.QueryParam("resume_token"); raw != "" {
+		token, err := decodeCrawlToken(h.crawl.tokenKey, raw)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid resume token").WithInternal(err)
+		}
+
+		if token.SnapshotTime.After(h.clock.Now().Add(crawlClockSkew)) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid resume token").WithInternal(fmt.Errorf("crawl snapshot in the future"))
+		}
+
+		if h.clock.Now().Sub(token.SnapshotTime) > h.crawl.maxAge {
+			return echo.NewHTTPError(http.StatusGone, map[string]string{
+				"code":    codeCrawlExpired,
+				"message": "the crawl snapshot expired, start a new crawl",
+			})
+		}
+
+		if c.QueryParam("min_change_seq") != "" && minChangeSeq != token.MinChangeSeq {
+			return echo.NewHTTPError(http.StatusBadRequest, "min_change_seq doesn't match the resumed crawl")
+		}
+
+		after = &token
+		snapshotTime = token.SnapshotTime
+		minChangeSeq = token.MinChangeSeq
+	}
+
+	tenants, err := h.crawlBatch(ctx, snapshotTime, minChangeSeq, after, limit+1)
+	if err != nil {
+		return err
+	}
+
+	resp := crawlResponse{
+		Tenants:      make([]tenant, 0, len(tenants)),
+		SnapshotTime: timefmt.New(snapshotTime),
+	}
+
+	if len(tenants) > limit {
+		tenants = tenants[:limit]
+		last := tenants[limit-1]
+
+		resp.ResumeToken = crawlToken{
+			SnapshotTime: snapshotTime,
+			CreatedAt:    last.CreatedAt,
+			ID:           last.ID,
+			MinChangeSeq: minChangeSeq,
+		}.encode(h.crawl.tokenKey)
+	}
+
+	fields := redact.FromContext(ctx)
+
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, newTenant(t, fields))
+	}
+
+	c.Response().Header().Set(SnapshotTimeHeader, resp.SnapshotTime.String())
+
+	return respondList(c, resp, resp.Tenants, resp.ResumeToken)
+}
+
+// crawlBatch loads the tenants following the token at the snapshot time in a read only
+// transaction, leaving out those whose last change is before minChangeSeq when it is set.
+func (h *Handler) crawlBatch(ctx context.Context, snapshotTime time.Time, minChangeSeq int64, after *crawlToken, limit int) ([]*ent.Tenant, error) {
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			reqlog.FromContext(ctx, h.logger).Errorw("failed to roll back crawl", "error", err)
+		}
+	}()
+
+	if h.crawl.followerReads {
+		// AS OF SYSTEM TIME doesn't take placeholders, the snapshot is given as its nanoseconds since
+		// the epoch, an integer
+		stmt := "SET TRANSACTION AS OF SYSTEM TIME " + strconv.FormatInt(snapshotTime.UnixNano(), 10)
+
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	query := tx.Tenant.Query().Where(enttenant.CreatedAtLTE(snapshotTime))
+
+	if minChangeSeq > 0 {
+		query = query.Where(enttenant.ChangeSeqGTE(minChangeSeq))
+	}
+
+	if after != nil {
+		query = query.Where(enttenant.Or(
+			enttenant.CreatedAtGT(after.CreatedAt),
+			enttenant.And(
+				enttenant.CreatedAt(after.CreatedAt),
+				enttenant.IDGT(after.ID),
+			),
+		))
+	}
+
+	return query.
+		Order(ent.Asc(enttenant.FieldCreatedAt), ent.Asc(enttenant.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
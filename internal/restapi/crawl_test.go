@@ -0,0 +1,321 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/clock"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/liveconfig"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+const crawlScope = "tenants:crawl"
+
+var crawlHeaders = map[string]string{"X-Scope": crawlScope}
+
+var crawlTokenKey = []byte("crawl token key")
+
+// startServer serves the REST api for the client until the returned function is called, as a
+// server replica which can be restarted without losing the database. The replicas share their
+// crawl token key.
+func startServer(t *testing.T, client *ent.Client, opts ...restapi.Option) (string, func()) {
+	t.Helper()
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware(), scopeMiddleware},
+		append([]restapi.Option{restapi.WithCrawlScope(crawlScope), restapi.WithCrawlTokenKey(crawlTokenKey)}, opts...)...,
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return srv.URL, srv.Close
+}
+
+type crawlBatch struct {
+	Tenants []struct {
+		ID gidx.PrefixedID `json:"id"`
+	} `json:"tenants"`
+	SnapshotTime time.Time `json:"snapshotTime"`
+	ResumeToken  string    `json:"resumeToken"`
+}
+
+func crawl(t *testing.T, baseURL, token string) crawlBatch {
+	t.Helper()
+
+	query := url.Values{"limit": {"2"}}
+	if token != "" {
+		query.Set("resume_token", token)
+	}
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl?"+query.Encode(), crawlHeaders)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var batch crawlBatch
+
+	require.NoError(t, json.Unmarshal(body, &batch))
+	header, err := time.Parse(time.RFC3339Nano, resp.Header.Get(restapi.SnapshotTimeHeader))
+	require.NoError(t, err)
+	assert.True(t, header.Equal(batch.SnapshotTime), "the header and the body report the same snapshot time")
+
+	return batch
+}
+
+func TestTenantCrawlResumesAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	expected := []gidx.PrefixedID{root.ID}
+
+	for _, name := range []string{"one", "two", "three", "four"} {
+		expected = append(expected, client.Tenant.Create().SetName(name).SetParent(root).SaveX(ctx).ID)
+	}
+
+	baseURL, stop := startServer(t, client)
+
+	first := crawl(t, baseURL, "")
+	require.Len(t, first.Tenants, 2)
+	require.NotEmpty(t, first.ResumeToken)
+
+	// created after the snapshot, the crawl doesn't see it
+	client.Tenant.Create().SetName("late").SaveX(ctx)
+
+	stop()
+
+	var crawled []gidx.PrefixedID
+
+	for _, tnt := range first.Tenants {
+		crawled = append(crawled, tnt.ID)
+	}
+
+	token := first.ResumeToken
+
+	for token != "" {
+		// every batch is served by a new replica, the token carries the whole crawl
+		baseURL, stop = startServer(t, client)
+
+		batch := crawl(t, baseURL, token)
+		assert.True(t, first.SnapshotTime.Equal(batch.SnapshotTime), "the snapshot time is kept for the whole crawl")
+
+		for _, tnt := range batch.Tenants {
+			crawled = append(crawled, tnt.ID)
+		}
+
+		token = batch.ResumeToken
+
+		stop()
+	}
+
+	assert.Equal(t, expected, crawled)
+}
+
+func TestTenantCrawlRejected(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	for _, name := range []string{"one", "two", "three"} {
+		client.Tenant.Create().SetName(name).SaveX(ctx)
+	}
+
+	baseURL, _ := startServer(t, client, restapi.WithCrawlMaxAge(time.Nanosecond))
+
+	token := crawl(t, baseURL, "").ResumeToken
+	require.NotEmpty(t, token)
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl?resume_token="+token, crawlHeaders)
+	assert.Equal(t, http.StatusGone, resp.StatusCode, "the snapshot expired: %s", body)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?resume_token=garbage", crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	payload, signature, ok := strings.Cut(token, ".")
+	require.True(t, ok, "the token is signed")
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	require.NoError(t, err)
+
+	var state map[string]any
+
+	require.NoError(t, json.Unmarshal(raw, &state))
+
+	state["s"] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339Nano)
+	raw, err = json.Marshal(state)
+	require.NoError(t, err)
+
+	tampered := base64.RawURLEncoding.EncodeToString(raw) + "." + signature
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?resume_token="+tampered, crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the token was changed: %s", body)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?resume_token="+payload, crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the token isn't signed: %s", body)
+
+	otherURL, _ := startServer(t, client, restapi.WithCrawlTokenKey([]byte("another key")))
+
+	resp, body = get(t, otherURL+"/v1/tenants:crawl?resume_token="+token, crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the token is signed with another key: %s", body)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?limit=0", crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+}
+
+func TestTenantCrawlFutureSnapshotRejected(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	for _, name := range []string{"one", "two", "three"} {
+		client.Tenant.Create().SetName(name).SaveX(ctx)
+	}
+
+	// a replica whose clock is an hour ahead hands out snapshots in the future of the others
+	ahead := clock.NewFake(time.Now().UTC().Add(time.Hour))
+
+	aheadURL, _ := startServer(t, client, restapi.WithClock(ahead))
+
+	token := crawl(t, aheadURL, "").ResumeToken
+	require.NotEmpty(t, token)
+
+	baseURL, _ := startServer(t, client)
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl?resume_token="+token, crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+func TestTenantCrawlRateLimit(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	baseURL, _ := startServer(t, client, restapi.WithCrawlRateLimit(0.1, 2))
+
+	for i := 0; i < 2; i++ {
+		resp, body := get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	}
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, string(body))
+	assert.Equal(t, "10", resp.Header.Get("Retry-After"))
+
+	_, url := newTestServer(t)
+
+	resp, body = get(t, url+"/v1/tenants:crawl", crawlHeaders)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "crawling is disabled without a scope: %s", body)
+}
+
+func TestTenantCrawlRateLimitReloaded(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	live, err := liveconfig.NewStore(liveconfig.Settings{CrawlRate: 0.1, CrawlBurst: 1, MaxPageSize: liveconfig.DefaultMaxPageSize})
+	require.NoError(t, err)
+
+	baseURL, _ := startServer(t, client, restapi.WithLiveConfig(live))
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode, string(body))
+
+	// a rejected reload keeps the limit, an applied one is used from the next request on
+	require.Error(t, live.Reload(func() (liveconfig.Settings, error) {
+		return liveconfig.Settings{CrawlRate: 1000, CrawlBurst: 0, MaxPageSize: liveconfig.DefaultMaxPageSize}, nil
+	}))
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode, string(body))
+
+	require.NoError(t, live.Reload(func() (liveconfig.Settings, error) {
+		return liveconfig.Settings{CrawlRate: 1000, CrawlBurst: 1, MaxPageSize: liveconfig.DefaultMaxPageSize}, nil
+	}))
+
+	time.Sleep(5 * time.Millisecond)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl", crawlHeaders)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+}
+
+func TestTenantCrawlMinChangeSeq(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	var tenants []*ent.Tenant
+
+	for _, name := range []string{"one", "two", "three", "four"} {
+		tenants = append(tenants, client.Tenant.Create().SetName(name).SaveX(ctx))
+	}
+
+	// the first tenant changes last, it is caught up along with the ones created after the third
+	tenants[0] = client.Tenant.UpdateOneID(tenants[0].ID).SetDescription("updated").SaveX(ctx)
+
+	baseURL, _ := startServer(t, client)
+
+	query := url.Values{"limit": {"2"}, "min_change_seq": {strconv.FormatInt(tenants[2].ChangeSeq, 10)}}
+
+	resp, body := get(t, baseURL+"/v1/tenants:crawl?"+query.Encode(), crawlHeaders)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var first crawlBatch
+
+	require.NoError(t, json.Unmarshal(body, &first))
+	require.NotEmpty(t, first.ResumeToken)
+
+	crawled := []gidx.PrefixedID{first.Tenants[0].ID, first.Tenants[1].ID}
+
+	// the resumed crawl keeps the filter, which can't be changed
+	query.Set("min_change_seq", "0")
+	query.Set("resume_token", first.ResumeToken)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?"+query.Encode(), crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	batch := crawl(t, baseURL, first.ResumeToken)
+	assert.Empty(t, batch.ResumeToken)
+
+	for _, tnt := range batch.Tenants {
+		crawled = append(crawled, tnt.ID)
+	}
+
+	assert.Equal(t, []gidx.PrefixedID{tenants[0].ID, tenants[2].ID, tenants[3].ID}, crawled)
+
+	resp, body = get(t, baseURL+"/v1/tenants:crawl?min_change_seq=-1", crawlHeaders)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+}
@@ -0,0 +1,431 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	"go.infratographer.com/tenant-api/internal/duplicates"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/externalid"
+)
+
+// HeaderDuplicateSuppressed is set on the responses of creates recognized as the duplicate of a
+// recent one, which return the tenant created then.
+const HeaderDuplicateSuppressed = "X-Duplicate-Suppressed"
+
+// HeaderRenamedFrom is set to the requested name on the responses of creates with
+// on_conflict=suffix which gave the tenant another name, as a sibling had it.
+const HeaderRenamedFrom = "X-Renamed-From"
+
+// What tenantCreate does when a sibling has the name of the tenant, as given by the on_conflict
+// query parameter.
+const (
+	// onConflictAllow creates the tenant with the name regardless, the default.
+	onConflictAllow = "allow"
+	// onConflictSuffix appends the first free numeric suffix to the name, such as staging-2.
+	onConflictSuffix = "suffix"
+	// onConflictError fails the create with a name conflict.
+	onConflictError = "error"
+)
+
+// WithNameReusePolicy sets whether created tenants checking for name conflicts may take the
+// names of deleted siblings, block_during_retention by default.
+func WithNameReusePolicy(policy validation.NameReusePolicy) Option {
+	return func(h *Handler) {
+		h.nameReuse = policy
+	}
+}
+
+// WithDuplicateGuard returns the tenant created by a recent create with the same parent, name and
+// actor rather than creating another, for clients retrying creates without an external id.
+func WithDuplicateGuard(g *duplicates.Guard) Option {
+	return func(h *Handler) {
+		h.duplicates = g
+	}
+}
+
+type createRequest struct {
+	Name             string           `json:"name"`
+	DisplayName      *string          `json:"displayName"`
+	Description      *string          `json:"description"`
+	ContactEmail     *string          `json:"contactEmail"`
+	BillingReference *string          `json:"billingReference"`
+	ParentID         *gidx.PrefixedID `json:"parentID"`
+	ExternalID       string           `json:"externalID"`
+
+	// adoptedID is the id of a tenant adopted from another system, set by adminTenantAdopt only
+	// as other tenants get a generated id
+	adoptedID gidx.PrefixedID
+	// onConflict is what to do when a sibling has the name, from the on_conflict query parameter
+	onConflict string
+}
+
+// validate validates the request, normalizing the fields of the tenant with the pipeline.
+func (r *createRequest) validate(ctx context.Context, p *validation.Pipeline) error {
+	t := validation.Tenant{
+		Name:             &r.Name,
+		DisplayName:      r.DisplayName,
+		Description:      r.Description,
+		ContactEmail:     r.ContactEmail,
+		BillingReference: r.BillingReference,
+	}
+
+	var errs validation.Errors
+
+	if err := p.ValidateNewTenant(ctx, &t); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	if r.ParentID != nil && r.ParentID.Prefix() != schema.TenantPrefix {
+		errs.Add("parentID", validation.CodeInvalidID, fmt.Sprintf("%q is not a tenant id", *r.ParentID))
+	}
+
+	if r.ExternalID != "" {
+		if r.ParentID == nil {
+			errs.Add("parentID", validation.CodeRequired, "tenants with an external id must have a parent")
+		}
+
+		if len(r.ExternalID) > externalid.MaxLength {
+			errs.Add("externalID", validation.CodeExternalIDTooLong, fmt.Sprintf("must be at most %d bytes long", externalid.MaxLength))
+		}
+	}
+
+	switch r.onConflict {
+	case "", onConflictAllow, onConflictError:
+	case onConflictSuffix:
+		if r.ExternalID != "" {
+			// replays must find the tenant under the name they ask for
+			errs.Add("on_conflict", validation.CodeInvalidValue, "names of tenants with an external id aren't suffixed")
+		}
+	default:
+		errs.Add("on_conflict", validation.CodeInvalidValue, fmt.Sprintf("must be %s, %s or %s", onConflictAllow, onConflictSuffix, onConflictError))
+	}
+
+	return errs.Err()
+}
+
+// tenantCreate creates a tenant, responding with 201 Created. Tenants created with an external id
+// get the id derived from it and their parent, see pkg/externalid, so creating one again with
+// the same name returns the existing tenant with 200 OK, which makes replaying the request safe.
+// Creating one again with another name is a conflict. With a duplicate guard, creates without an
+// external id duplicating a recent one respond with the tenant it created, see
+// respondDuplicate.
+//
+// Siblings may have the same name unless the on_conflict query parameter asks otherwise: with
+// error a taken name fails the create with a name conflict, with suffix the first name not taken,
+// staging-2 for staging, is used and the requested one returned in the X-Renamed-From header.
+// Whether deleted siblings keep their names depends on the name reuse policy. The siblings are
+// checked in the transaction of the create, so with serializable isolation concurrent creates
+// don't take the same name.
+func (h *Handler) tenantCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	req := createRequest{onConflict: c.QueryParam("on_conflict")}
+
+	if err := h.decodeInput(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := req.validate(ctx, h.validator); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	resource := gidx.NullPrefixedID
+
+	if req.ParentID != nil {
+		resource = *req.ParentID
+	}
+
+	if err := permissions.CheckAccess(ctx, resource, actionTenantCreate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	fields := redact.FromContext(ctx)
+
+	if req.ExternalID != "" {
+		existing, err := h.client.Tenant.Get(ctx, externalid.TenantID(*req.ParentID, req.ExternalID))
+
+		switch {
+		case err == nil:
+			return h.respondReplayed(c, req, existing)
+		case !ent.IsNotFound(err):
+			return errmap.HTTPError(err)
+		}
+	}
+
+	t, duplicate, err := h.createTenant(c, req)
+	if err != nil {
+		// a concurrent request with the same external id got there first
+		if ent.IsConstraintError(err) && req.ExternalID != "" {
+			existing, gerr := h.client.Tenant.Get(ctx, externalid.TenantID(*req.ParentID, req.ExternalID))
+			if gerr == nil {
+				return h.respondReplayed(c, req, existing)
+			}
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if duplicate {
+		return h.respondDuplicate(c, t)
+	}
+
+	if err := h.setParentLimitHeaders(c, t.ParentTenantID); err != nil {
+		return err
+	}
+
+	if t.Name != req.Name {
+		c.Response().Header().Set(HeaderRenamedFrom, req.Name)
+	}
+
+	return h.respondCreated(c, newTenant(t, fields), RouteTenantGet, t.ID)
+}
+
+// createTenant creates the tenant in a transaction, publishing the change once committed. The
+// change of an adopted tenant is marked as an adoption. When the create is the duplicate of a
+// recent one the tenant it created is returned instead, with true. The names of siblings are
+// checked in the transaction as the on_conflict mode of the request asks. The transaction is run
+// again when it is aborted by a serialization failure, such as a concurrent create of a sibling.
+func (h *Handler) createTenant(c echo.Context, req createRequest) (*ent.Tenant, bool, error) {
+	var (
+		t         *ent.Tenant
+		duplicate bool
+	)
+
+	err := crdb.Retry(c.Request().Context(), func(ctx context.Context) error {
+		var err error
+
+		t, duplicate, err = h.createTenantTx(ctx, c, req)
+
+		return err
+	})
+
+	return t, duplicate, err
+}
+
+// createTenantTx runs one attempt of createTenant.
+func (h *Handler) createTenantTx(ctx context.Context, c echo.Context, req createRequest) (*ent.Tenant, bool, error) {
+	key := h.duplicateKey(ctx, req)
+
+	txCtx := ctx
+	if req.adoptedID != gidx.NullPrefixedID {
+		txCtx = changefeed.WithAdditionalData(ctx, map[string]any{adoptedKey: true})
+	}
+
+	txCtx, batch := changefeed.WithBatch(txCtx)
+
+	tx, err := h.client.Tx(txCtx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rollback := func() {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.log(c).Errorw("failed to roll back tenant create", "error", rerr)
+		}
+	}
+
+	if key != "" {
+		existing, err := h.duplicates.Find(txCtx, tx.Client(), key)
+		if err != nil {
+			rollback()
+
+			return nil, false, err
+		}
+
+		if existing != nil {
+			rollback()
+
+			return existing, true, nil
+		}
+	}
+
+	switch req.onConflict {
+	case onConflictSuffix:
+		if req.Name, err = h.nameReuse.FreeSiblingName(txCtx, tx.Client(), req.ParentID, req.Name); err != nil {
+			rollback()
+
+			return nil, false, err
+		}
+	case onConflictError:
+		if err := h.nameReuse.CheckSiblingName(txCtx, tx.Client(), req.ParentID, req.Name); err != nil {
+			rollback()
+
+			return nil, false, err
+		}
+	}
+
+	t, err := createWithInput(txCtx, tx.Client(), req)
+	if err == nil && key != "" {
+		err = h.duplicates.Record(txCtx, tx.Client(), key, t.ID)
+	}
+
+	if err != nil {
+		rollback()
+
+		// a concurrent duplicate was committed first
+		if errors.Is(err, duplicates.ErrConcurrent) {
+			if existing, ferr := h.duplicates.Find(ctx, h.client, key); ferr == nil && existing != nil {
+				return existing, true, nil
+			}
+		}
+
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the tenant is committed, report it even though the event was lost
+		h.log(c).Errorw("failed to publish tenant create", "error", err)
+	}
+
+	return t, false, nil
+}
+
+// duplicateKey returns the key recognizing the duplicates of the create, empty when they aren't
+// looked for. Creates with an external id or an adopted id are idempotent already.
+func (h *Handler) duplicateKey(ctx context.Context, req createRequest) string {
+	if h.duplicates == nil || req.ExternalID != "" || req.adoptedID != gidx.NullPrefixedID {
+		return ""
+	}
+
+	parentID := gidx.NullPrefixedID
+	if req.ParentID != nil {
+		parentID = *req.ParentID
+	}
+
+	return duplicates.Key(parentID, req.Name, actor.FromContext(ctx))
+}
+
+// respondDuplicate responds with the tenant created by the create the request duplicates, with
+// 200 OK and the X-Duplicate-Suppressed header. Callers which may not get the tenant are denied,
+// as for other reads.
+func (h *Handler) respondDuplicate(c echo.Context, existing *ent.Tenant) error {
+	ctx := c.Request().Context()
+
+	if err := permissions.CheckAccess(ctx, existing.ID, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if err := h.setParentLimitHeaders(c, existing.ParentTenantID); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(HeaderDuplicateSuppressed, "true")
+	h.setLocation(c, RouteTenantGet, existing.ID)
+
+	return c.JSON(http.StatusOK, newTenant(existing, redact.FromContext(ctx)))
+}
+
+func createWithInput(ctx context.Context, client *ent.Client, req createRequest) (*ent.Tenant, error) {
+	create := client.Tenant.Create().SetInput(ent.CreateTenantInput{
+		Name:             req.Name,
+		DisplayName:      req.DisplayName,
+		Description:      req.Description,
+		ContactEmail:     req.ContactEmail,
+		BillingReference: req.BillingReference,
+		ParentID:         req.ParentID,
+	})
+
+	switch {
+	case req.adoptedID != gidx.NullPrefixedID:
+		create.SetID(req.adoptedID)
+	case req.ExternalID != "":
+		create.
+			SetID(externalid.TenantID(*req.ParentID, req.ExternalID)).
+			SetExternalID(req.ExternalID)
+	}
+
+	// the name was checked against the siblings, the index rejects a concurrent create taking it
+	if req.onConflict == onConflictSuffix || req.onConflict == onConflictError {
+		create.SetUniqueName(true)
+	}
+
+	return create.Save(ctx)
+}
+
+// respondReplayed responds with the tenant created before with the external id of the request,
+// or with a conflict when it has another name. The name is the only field compared, other fields
+// may have been changed since. Callers which may not get the tenant are denied, as for other
+// reads, and the conflict only names the tenant to those which may.
+func (h *Handler) respondReplayed(c echo.Context, req createRequest, existing *ent.Tenant) error {
+	ctx := c.Request().Context()
+
+	access := permissions.CheckAccess(ctx, existing.ID, actionTenantGet)
+	if access != nil && !errors.Is(access, permissions.ErrPermissionDenied) {
+		return errmap.HTTPError(access)
+	}
+
+	if existing.ParentTenantID != *req.ParentID || existing.ExternalID != req.ExternalID ||
+		existing.Name != req.Name {
+		message := "is taken by a tenant which has another name"
+		if access == nil {
+			message = fmt.Sprintf("is taken by %s, which has another name", existing.ID)
+		}
+
+		return errmap.HTTPError(&validation.Error{
+			Field:   "externalID",
+			Code:    validation.CodeExternalIDTaken,
+			Message: message,
+		})
+	}
+
+	if access != nil {
+		return errmap.HTTPError(access)
+	}
+
+	if err := h.setParentLimitHeaders(c, existing.ParentTenantID); err != nil {
+		return err
+	}
+
+	h.setLocation(c, RouteTenantGet, existing.ID)
+
+	return c.JSON(http.StatusOK, newTenant(existing, redact.FromContext(ctx)))
+}
+
+// tenantGetByExternalID responds with the child of the tenant with the external id. The caller
+// must be allowed to get the parent, so the external ids it has aren't revealed to anyone else.
+func (h *Handler) tenantGetByExternalID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	parentID, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, parentID, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	id, err := h.client.Tenant.Query().
+		Where(
+			enttenant.ParentTenantID(parentID),
+			enttenant.ExternalID(c.Param("ref")),
+		).
+		OnlyID(ctx)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return h.respondTenant(c, id)
+}
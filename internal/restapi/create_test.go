@@ -0,0 +1,338 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/pkg/externalid"
+)
+
+func TestTenantCreate(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants", `{"name":"root","description":"the root"}`)
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	var root struct {
+		ID          gidx.PrefixedID `json:"id"`
+		Description string          `json:"description"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &root))
+	assert.Equal(t, "the root", root.Description)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	t.Run("invalid requests", func(t *testing.T) {
+		status, body := env.post(t, "/v1/tenants", `{"description":"nameless"}`)
+		assert.Equal(t, http.StatusBadRequest, status, string(body))
+		assert.Contains(t, string(body), `"field":"name"`)
+
+		// external ids are unique among siblings, so they need a parent
+		status, body = env.post(t, "/v1/tenants", `{"name":"orphan","externalID":"ref"}`)
+		assert.Equal(t, http.StatusBadRequest, status, string(body))
+		assert.Contains(t, string(body), `"field":"parentID"`)
+
+		status, body = env.post(t, "/v1/tenants", `{"name":"child","parentID":"tnntten-denied"}`)
+		assert.Equal(t, http.StatusForbidden, status, string(body))
+	})
+}
+
+func TestTenantCreateByExternalID(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	request := `{"name":"acme","parentID":"` + root.ID.String() + `","externalID":"acct-42"}`
+
+	status, body := env.post(t, "/v1/tenants", request)
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	var created struct {
+		ID         gidx.PrefixedID `json:"id"`
+		ExternalID string          `json:"externalID"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &created))
+
+	// provisioning systems can compute the id before creating the tenant
+	assert.Equal(t, externalid.TenantID(root.ID, "acct-42"), created.ID)
+	assert.Equal(t, "acct-42", created.ExternalID)
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	t.Run("replayed creation returns the tenant", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			status, body := env.post(t, "/v1/tenants", request)
+			require.Equal(t, http.StatusOK, status, string(body))
+			assert.Contains(t, string(body), `"id":"`+created.ID.String()+`"`)
+		}
+
+		env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+		count := env.client.Tenant.Query().Where(enttenant.ParentTenantID(root.ID)).CountX(env.ctx)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("another name conflicts", func(t *testing.T) {
+		status, body := env.post(t, "/v1/tenants", `{"name":"other","parentID":"`+root.ID.String()+`","externalID":"acct-42"}`)
+		assert.Equal(t, http.StatusConflict, status, string(body))
+		assert.Contains(t, string(body), `"code":"external_id_taken"`)
+	})
+
+	t.Run("the same external id under another parent", func(t *testing.T) {
+		other := env.client.Tenant.Create().SetName("other root").SaveX(env.ctx)
+
+		status, body := env.post(t, "/v1/tenants", `{"name":"acme","parentID":"`+other.ID.String()+`","externalID":"acct-42"}`)
+		require.Equal(t, http.StatusCreated, status, string(body))
+		assert.Contains(t, string(body), `"id":"`+externalid.TenantID(other.ID, "acct-42").String()+`"`)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		status, body := env.do(t, http.MethodGet, "/v1/tenants/"+root.ID.String()+"/by-external-id/acct-42", "", "")
+		require.Equal(t, http.StatusOK, status, string(body))
+		assert.Contains(t, string(body), `"id":"`+created.ID.String()+`"`)
+
+		status, body = env.do(t, http.MethodGet, "/v1/tenants/"+root.ID.String()+"/by-external-id/acct-43", "", "")
+		assert.Equal(t, http.StatusNotFound, status, string(body))
+
+		status, body = env.do(t, http.MethodGet, "/v1/tenants/tnntten-denied/by-external-id/acct-42", "", "")
+		assert.Equal(t, http.StatusForbidden, status, string(body))
+	})
+}
+
+func TestTenantCreateByExternalIDDenied(t *testing.T) {
+	parentID := gidx.PrefixedID("tnntten-parent")
+	takenID := externalid.TenantID(parentID, "acct-7")
+
+	env := newEventEnv(t, takenID)
+
+	parent := env.client.Tenant.Create().SetID(parentID).SetName("parent").SaveX(env.ctx)
+	env.client.Tenant.Create().SetID(takenID).SetName("hidden").SetParent(parent).SetExternalID("acct-7").SaveX(env.ctx)
+
+	t.Run("another name", func(t *testing.T) {
+		status, body := env.post(t, "/v1/tenants", `{"name":"other","parentID":"`+parentID.String()+`","externalID":"acct-7"}`)
+		require.Equal(t, http.StatusConflict, status, string(body))
+		assert.Contains(t, string(body), `"code":"external_id_taken"`)
+		assert.NotContains(t, string(body), takenID.String(), "the tenant the caller may not get isn't named")
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		status, body := env.post(t, "/v1/tenants", `{"name":"hidden","parentID":"`+parentID.String()+`","externalID":"acct-7"}`)
+		require.Equal(t, http.StatusForbidden, status, string(body))
+		assert.NotContains(t, string(body), takenID.String())
+	})
+}
+
+func TestTenantCreateLocation(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	// the routes are served under a prefix, the Location keeps it
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()}).Routes(e.Group("/api"))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	root := client.Tenant.Create().SetName("root").SaveX(context.Background())
+
+	resp, body := send(t, http.MethodPost, srv.URL+"/api/v1/tenants", `{"name":"child","parentID":"`+root.ID.String()+`"}`, nil)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+	var child struct {
+		ID       gidx.PrefixedID `json:"id"`
+		Name     string          `json:"name"`
+		ParentID gidx.PrefixedID `json:"parentID"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &child))
+	assert.Equal(t, "child", child.Name)
+	assert.Equal(t, root.ID, child.ParentID)
+	assert.Equal(t, "/api/v1/tenants/"+child.ID.String(), resp.Header.Get(echo.HeaderLocation))
+
+	// the Location is the url the created resource is read from
+	resp, fetched := get(t, srv.URL+resp.Header.Get(echo.HeaderLocation), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(fetched))
+	assert.JSONEq(t, string(body), string(fetched))
+
+	accounts := "/api/v1/tenants/" + child.ID.String() + "/service-accounts"
+
+	resp, body = send(t, http.MethodPost, srv.URL+accounts, `{"name":"deployer"}`, nil)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+	var account struct {
+		ID gidx.PrefixedID `json:"id"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &account))
+	assert.Equal(t, accounts+"/"+account.ID.String(), resp.Header.Get(echo.HeaderLocation))
+
+	resp, fetched = get(t, srv.URL+resp.Header.Get(echo.HeaderLocation), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(fetched))
+	assert.JSONEq(t, string(body), string(fetched))
+}
+
+func TestTenantCreateOnConflict(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("staging").SetParent(root).SaveX(ctx)
+
+	create := func(t *testing.T, query, body string) (*http.Response, string) {
+		t.Helper()
+
+		resp, respBody := send(t, http.MethodPost, url+"/v1/tenants"+query, body, nil)
+
+		var created struct {
+			Name string `json:"name"`
+		}
+
+		if resp.StatusCode == http.StatusCreated {
+			require.NoError(t, json.Unmarshal(respBody, &created))
+		}
+
+		return resp, created.Name
+	}
+
+	staging := `{"name":" staging","parentID":"` + root.ID.String() + `"}`
+
+	t.Run("allowed by default", func(t *testing.T) {
+		resp, name := create(t, "", staging)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "staging", name)
+		assert.Empty(t, resp.Header.Get(restapi.HeaderRenamedFrom))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, url+"/v1/tenants?on_conflict=error", staging, nil)
+		require.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+		assert.Contains(t, string(body), "is taken by a sibling")
+	})
+
+	t.Run("suffix", func(t *testing.T) {
+		resp, name := create(t, "?on_conflict=suffix", staging)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "staging-2", name)
+		assert.Equal(t, "staging", resp.Header.Get(restapi.HeaderRenamedFrom), "the requested name is reported once normalized")
+
+		resp, name = create(t, "?on_conflict=suffix", `{"name":"production","parentID":"`+root.ID.String()+`"}`)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "production", name)
+		assert.Empty(t, resp.Header.Get(restapi.HeaderRenamedFrom), "free names aren't changed")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, url+"/v1/tenants?on_conflict=rename", staging, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Contains(t, string(body), "on_conflict")
+
+		resp, body = send(t, http.MethodPost, url+"/v1/tenants?on_conflict=suffix", `{"name":"staging","parentID":"`+root.ID.String()+`","externalID":"acct-1"}`, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Contains(t, string(body), "external id")
+	})
+}
+
+func TestTenantCreateOnConflictConcurrent(t *testing.T) {
+	ctx := context.Background()
+
+	// immediate transactions take the sqlite write lock when they begin, so the creates run one
+	// after the other. On cockroachdb they overlap, and those aborted by a serialization failure
+	// are run again by crdb.Retry.
+	client := enttest.Open(t, "sqlite3", "file:"+filepath.Join(t.TempDir(), "tenants.db")+"?_fk=1&_txlock=immediate&_busy_timeout=5000")
+	t.Cleanup(func() { client.Close() })
+
+	url, _ := startServer(t, client)
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+	body := `{"name":"staging","parentID":"` + parent.ID.String() + `"}`
+
+	const creates = 5
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		names = make([]string, creates)
+		errs  = make([]error, creates)
+	)
+
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, url+"/v1/tenants?on_conflict=suffix", strings.NewReader(body))
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+
+			defer resp.Body.Close()
+
+			var created struct {
+				Name string `json:"name"`
+			}
+
+			if resp.StatusCode != http.StatusCreated {
+				errs[i] = fmt.Errorf("unexpected status %d", resp.StatusCode)
+
+				return
+			}
+
+			errs[i] = json.NewDecoder(resp.Body).Decode(&created)
+			names[i] = created.Name
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	sort.Strings(names)
+
+	assert.Equal(t, []string{"staging", "staging-2", "staging-3", "staging-4", "staging-5"}, names)
+}
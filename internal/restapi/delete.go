@@ -0,0 +1,154 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/dependents"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/protection"
+	"go.infratographer.com/tenant-api/internal/scopes"
+)
+
+// Outcomes of the tenants of a batch delete which resources of other services depend on, and of
+// those protected against deletion, which a batch never deletes.
+const (
+	batchStatusHasDependents = "has_dependents"
+	batchStatusProtected     = "protected"
+)
+
+// HeaderConfirmName confirms the deletion of a tenant protected against deletion, it must hold the
+// exact name of the tenant.
+const HeaderConfirmName = "X-Confirm-Tenant-Name"
+
+// WithForceDeleteScope sets the token scope required to delete tenants despite their dependents
+// with force=true, deletions can't be forced without one.
+func WithForceDeleteScope(scope string) Option {
+	return func(h *Handler) {
+		h.forceDeleteScope = scope
+	}
+}
+
+type dependentsResponse struct {
+	Code       string   `json:"code"`
+	Message    string   `json:"message"`
+	Dependents []string `json:"dependents"`
+}
+
+// tenantDelete deletes a tenant without children. Tenants which resources of other services
+// depend on are a conflict listing the types of those resources, unless the deletion is forced.
+// Tenants protected against deletion are a conflict unless the X-Confirm-Tenant-Name header holds
+// their exact name, such deletions are audited.
+func (h *Handler) tenantDelete(c echo.Context) error {
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := h.deleteContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantDelete); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if name := c.Request().Header.Get(HeaderConfirmName); name != "" {
+		ctx = protection.Confirm(ctx, name)
+	}
+
+	children, err := h.client.Tenant.Query().Where(enttenant.ParentTenantID(id)).Count(ctx)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if children != 0 {
+		return errmap.HTTPError(deletion.ErrHasChildren)
+	}
+
+	if err := h.client.Tenant.DeleteOneID(id).Exec(ctx); err != nil {
+		var derr *dependents.Error
+
+		if errors.As(err, &derr) {
+			return c.JSON(http.StatusConflict, dependentsResponse{
+				Code:       dependents.CodeHasDependents,
+				Message:    dependents.ErrHasDependents.Error(),
+				Dependents: derr.Types,
+			})
+		}
+
+		return deleteError(err)
+	}
+
+	if protection.Confirmed(ctx) {
+		h.log(c).Warnw("deleted tenant protected against deletion", "tenant_id", id)
+
+		audit.Confirm(c)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// deleteContext returns the context of a deletion, forced when the force query parameter is set.
+// Forcing requires the force delete scope and is logged, as the dependents are left orphaned.
+func (h *Handler) deleteContext(c echo.Context) (context.Context, error) {
+	ctx := c.Request().Context()
+
+	raw := c.QueryParam("force")
+	if raw == "" {
+		return ctx, nil
+	}
+
+	force, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid force %q", raw)).WithInternal(err)
+	}
+
+	if !force {
+		return ctx, nil
+	}
+
+	if !hasScope(c, h.forceDeleteScope) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "forcing deletions requires an elevated scope")
+	}
+
+	h.log(c).Warnw("forcing tenant deletion regardless of its dependents", "path", c.Request().URL.Path)
+
+	return dependents.Force(ctx), nil
+}
+
+// hasScope reports whether the token of the caller carries the scope, which is never the case for
+// an empty one.
+func hasScope(c echo.Context, required string) bool {
+	if required == "" {
+		return false
+	}
+
+	for _, scope := range scopes.FromToken(c) {
+		if scope == required {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteError converts errors of deletions, dependents which couldn't be checked make the service
+// unavailable rather than failing.
+func deleteError(err error) error {
+	if errors.Is(err, dependents.ErrCheckFailed) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, dependents.ErrCheckFailed.Error()).WithInternal(err)
+	}
+
+	return errmap.HTTPError(err)
+}
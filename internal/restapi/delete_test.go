@@ -0,0 +1,119 @@
+package restapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/dependents"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+func TestTenantDeleteDependents(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithForceDeleteScope("tenants:force"))
+
+	// checkers run in parallel, their dependents are merged
+	lbs := &dependents.Stub{Types: []string{"load-balancer"}}
+	instances := &dependents.Stub{Types: []string{"instance", "load-balancer"}}
+
+	client.Tenant.Use(dependents.New([]dependents.Checker{lbs, instances}).Hook())
+
+	tnt := client.Tenant.Create().SetName("owner").SaveX(ctx)
+
+	resp, body := send(t, http.MethodDelete, url+"/v1/tenants/"+tnt.ID.String(), "", nil)
+	require.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+	assert.JSONEq(t, `{"code":"has_dependents","message":"resources of other services depend on the tenant","dependents":["instance","load-balancer"]}`, string(body))
+	assert.Equal(t, []string{urnx.NewTenantURN(tnt.ID)}, lbs.URNs())
+
+	// forcing requires the scope
+	resp, body = send(t, http.MethodDelete, url+"/v1/tenants/"+tnt.ID.String()+"?force=true", "", map[string]string{"X-Scope": "tenants:other"})
+	require.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+	assert.True(t, client.Tenant.Query().ExistX(ctx))
+
+	resp, body = send(t, http.MethodDelete, url+"/v1/tenants/"+tnt.ID.String()+"?force=true", "", map[string]string{"X-Scope": "tenants:force"})
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+	assert.False(t, client.Tenant.Query().ExistX(ctx))
+
+	// forced deletions aren't checked
+	assert.Len(t, lbs.URNs(), 1)
+}
+
+func TestTenantDeleteDependentsTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	slow := &dependents.Stub{Types: []string{"instance"}, Delay: time.Minute}
+
+	tests := []struct {
+		name     string
+		failOpen bool
+		status   int
+	}{
+		{"fail closed", false, http.StatusServiceUnavailable},
+		{"fail open", true, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, url := newTestServer(t)
+
+			check := dependents.New([]dependents.Checker{slow, &dependents.Stub{}},
+				dependents.WithTimeout(50*time.Millisecond),
+				dependents.WithFailOpen(tt.failOpen),
+			)
+
+			client.Tenant.Use(check.Hook())
+
+			tnt := client.Tenant.Create().SetName("owner").SaveX(ctx)
+
+			start := time.Now()
+
+			resp, body := send(t, http.MethodDelete, url+"/v1/tenants/"+tnt.ID.String(), "", nil)
+			require.Equal(t, tt.status, resp.StatusCode, string(body))
+			assert.Less(t, time.Since(start), 10*time.Second)
+			assert.Equal(t, tt.failOpen, !client.Tenant.Query().ExistX(ctx))
+		})
+	}
+}
+
+func TestTenantBatchDeleteDependents(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	owner := client.Tenant.Create().SetName("owner").SetParent(root).SaveX(ctx)
+	free := client.Tenant.Create().SetName("free").SaveX(ctx)
+
+	client.Tenant.Use(dependents.New([]dependents.Checker{dependents.CheckerFunc(func(_ context.Context, urn string) ([]string, error) {
+		switch urn {
+		case urnx.NewTenantURN(owner.ID):
+			return []string{"instance"}, nil
+		case urnx.NewTenantURN(free.ID):
+			return nil, nil
+		default:
+			return nil, errors.New("unexpected tenant")
+		}
+	})}).Hook())
+
+	body := `{"ids":["` + root.ID.String() + `","` + owner.ID.String() + `","` + free.ID.String() + `"],"mode":"best_effort"}`
+
+	// the parent keeps its child with dependents, the other tenant is deleted
+	resp, respBody := send(t, http.MethodDelete, url+"/v1/tenants", body, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(respBody))
+	assert.JSONEq(t, `{"mode":"best_effort","committed":true,"results":[
+		{"id":"`+root.ID.String()+`","status":"has_children"},
+		{"id":"`+owner.ID.String()+`","status":"has_dependents"},
+		{"id":"`+free.ID.String()+`","status":"deleted"}
+	]}`, string(respBody))
+
+	assert.Equal(t, 2, client.Tenant.Query().CountX(ctx))
+}
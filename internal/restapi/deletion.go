@@ -0,0 +1,46 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+// tenantScheduleDeletion schedules the deletion of the tenant and its descendants once the grace
+// period has passed.
+func (h *Handler) tenantScheduleDeletion(c echo.Context) error {
+	return h.changeDeletion(c, h.deletion.Schedule)
+}
+
+// tenantCancelDeletion aborts the pending deletion of the tenant.
+func (h *Handler) tenantCancelDeletion(c echo.Context) error {
+	return h.changeDeletion(c, h.deletion.Cancel)
+}
+
+func (h *Handler) changeDeletion(c echo.Context, change func(context.Context, gidx.PrefixedID) (*ent.Tenant, error)) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantDelete); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	t, err := change(ctx, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, newTenant(t, redact.FromContext(ctx)))
+}
@@ -0,0 +1,65 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantScheduleDeletion(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	leaf := env.client.Tenant.Create().SetName("leaf").SetParent(root).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SetParent(root).SaveX(env.ctx)
+
+	status, body := env.post(t, "/v1/tenants/"+leaf.ID.String()+"/schedule-deletion", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var got struct {
+		DeletionScheduledAt *time.Time `json:"deletionScheduledAt"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &got))
+	require.NotNil(t, got.DeletionScheduledAt)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), *got.DeletionScheduledAt, time.Minute)
+
+	status, body = env.post(t, "/v1/tenants/tnntten-denied/schedule-deletion", "")
+	assert.Equal(t, http.StatusForbidden, status, string(body))
+
+	status, body = env.post(t, "/v1/tenants/tnntten-missing/schedule-deletion", "")
+	assert.Equal(t, http.StatusNotFound, status, string(body))
+
+	status, body = env.post(t, "/v1/tenants/"+leaf.ID.String()+"/cancel-deletion", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var cancelled map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &cancelled))
+	assert.NotContains(t, cancelled, "deletionScheduledAt")
+	assert.True(t, env.client.Tenant.GetX(env.ctx, leaf.ID).DeletionScheduledAt.IsZero())
+
+	env.client.Tenant.UpdateOneID(leaf.ID).SetDeletionProtected(true).ExecX(env.ctx)
+
+	status, body = env.post(t, "/v1/tenants/"+root.ID.String()+"/schedule-deletion", "")
+	assert.Equal(t, http.StatusConflict, status, "tenants with protected descendants can't be scheduled: %s", body)
+
+	env.client.Tenant.UpdateOneID(leaf.ID).SetDeletionProtected(false).ExecX(env.ctx)
+
+	status, body = env.post(t, "/v1/tenants/"+root.ID.String()+"/schedule-deletion", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = env.do(t, http.MethodGet, "/v1/tenants/"+leaf.ID.String()+"?include_effective_status=true", "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var descendant struct {
+		EffectiveStatus string `json:"effectiveStatus"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &descendant))
+	assert.Equal(t, "parent_deleted", descendant.EffectiveStatus, "the descendants are deleted with the tenant")
+}
@@ -0,0 +1,97 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// maxDrainDuration bounds the time a drain raises the dispatch rate for, so a forgotten drain
+// doesn't keep the broker under load.
+const maxDrainDuration = 24 * time.Hour
+
+// WithDispatcher registers the admin endpoints reporting the dispatcher publishing held changes
+// and raising its rate to drain a backlog.
+func WithDispatcher(d *changefeed.Dispatcher) Option {
+	return func(h *Handler) {
+		h.dispatcher = d
+	}
+}
+
+type dispatchResponse struct {
+	Workers    int           `json:"workers"`
+	BatchSize  int           `json:"batchSize"`
+	Rate       float64       `json:"rate"`
+	DrainUntil *timefmt.Time `json:"drainUntil,omitempty"`
+	Backlog    int           `json:"backlog"`
+}
+
+func newDispatchResponse(s changefeed.DispatchStatus) dispatchResponse {
+	return dispatchResponse{
+		Workers:    s.Workers,
+		BatchSize:  s.BatchSize,
+		Rate:       s.Rate,
+		DrainUntil: timefmt.Ptr(s.DrainUntil),
+		Backlog:    s.Backlog,
+	}
+}
+
+type dispatchDrainRequest struct {
+	Rate     float64 `json:"rate"`
+	Duration string  `json:"duration"`
+}
+
+// parse validates the request, returning the duration of the drain.
+func (r dispatchDrainRequest) parse() (time.Duration, error) {
+	var errs validation.Errors
+
+	if r.Rate <= 0 {
+		errs.Add("rate", validation.CodeInvalidValue, "must be positive")
+	}
+
+	duration, err := time.ParseDuration(r.Duration)
+
+	switch {
+	case r.Duration == "":
+		errs.Add("duration", validation.CodeRequired, "a duration is required")
+	case err != nil:
+		errs.Add("duration", validation.CodeInvalidValue, "must be a duration, such as 15m")
+	case duration <= 0 || duration > maxDrainDuration:
+		errs.Add("duration", validation.CodeInvalidValue, fmt.Sprintf("must be positive and at most %s", maxDrainDuration))
+	}
+
+	return duration, errs.Err()
+}
+
+// adminDispatch reports the workers, batch size and rate of the dispatcher, the drain in
+// progress and the changes waiting to be published. A rate of zero doesn't limit the batches.
+func (h *Handler) adminDispatch(c echo.Context) error {
+	return c.JSON(http.StatusOK, newDispatchResponse(h.dispatcher.Status()))
+}
+
+// adminDispatchDrain raises the dispatch rate to the requested number of batches per second for
+// the duration, to drain a backlog faster during recovery, then lowers it back to the configured
+// rate. A drain replaces the one in progress.
+func (h *Handler) adminDispatchDrain(c echo.Context) error {
+	var req dispatchDrainRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	duration, err := req.parse()
+	if err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	h.log(c).Infow("dispatch drain started", "rate", req.Rate, "duration", duration)
+
+	return c.JSON(http.StatusOK, newDispatchResponse(h.dispatcher.Drain(req.Rate, duration)))
+}
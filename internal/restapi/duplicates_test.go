@@ -0,0 +1,101 @@
+package restapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/duplicates"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+func TestTenantCreateDuplicate(t *testing.T) {
+	ctx := context.Background()
+
+	clk := clock.NewFake(time.Now())
+
+	// shared in-memory databases fail concurrent writes rather than waiting for the lock, as
+	// other databases and sqlite files do
+	client := enttest.Open(t, "sqlite3", "file:"+filepath.Join(t.TempDir(), "tenants.db")+"?_fk=1&_txlock=immediate&_busy_timeout=5000")
+	t.Cleanup(func() { client.Close() })
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{actorMiddleware, perms.Middleware()},
+		restapi.WithDuplicateGuard(duplicates.New(10*time.Second, duplicates.WithClock(clk))),
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	url := srv.URL
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	body := `{"name":"acme","parentID":"` + root.ID.String() + `"}`
+
+	t.Run("concurrent creates", func(t *testing.T) {
+		var (
+			wg        sync.WaitGroup
+			start     = make(chan struct{})
+			responses = make([]*http.Response, 2)
+		)
+
+		for i := range responses {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				<-start
+
+				responses[i], _ = send(t, http.MethodPost, url+"/v1/tenants", body, nil)
+			}(i)
+		}
+
+		close(start)
+		wg.Wait()
+
+		statuses := []int{responses[0].StatusCode, responses[1].StatusCode}
+		assert.ElementsMatch(t, []int{http.StatusCreated, http.StatusOK}, statuses)
+
+		for _, resp := range responses {
+			if resp.StatusCode == http.StatusOK {
+				assert.Equal(t, "true", resp.Header.Get(restapi.HeaderDuplicateSuppressed))
+			} else {
+				assert.Empty(t, resp.Header.Get(restapi.HeaderDuplicateSuppressed))
+			}
+		}
+
+		assert.Equal(t, responses[0].Header.Get("Location"), responses[1].Header.Get("Location"), "both respond with the same tenant")
+		assert.Equal(t, 1, client.Tenant.Query().Where(enttenant.Name("acme")).CountX(ctx))
+	})
+
+	t.Run("other creates", func(t *testing.T) {
+		resp, respBody := send(t, http.MethodPost, url+"/v1/tenants", `{"name":"acme"}`, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, "another parent: %s", respBody)
+
+		clk.Advance(10 * time.Second)
+
+		resp, respBody = send(t, http.MethodPost, url+"/v1/tenants", body, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, "past the window: %s", respBody)
+
+		assert.Equal(t, 3, client.Tenant.Query().Where(enttenant.Name("acme")).CountX(ctx))
+	})
+}
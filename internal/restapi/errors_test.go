@@ -0,0 +1,235 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+func TestErrorClasses(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+		field  string
+	}{
+		{"tenant not found", http.MethodGet, "/v1/tenants/tnntten-missing", "", http.StatusNotFound, "tenant_not_found", ""},
+		{"permission denied", http.MethodGet, "/v1/tenants/tnntten-denied", "", http.StatusForbidden, "permission_denied", ""},
+		{"conflict", http.MethodDelete, "/v1/tenants/" + root.ID.String(), "", http.StatusConflict, "conflict", ""},
+		{"invalid argument", http.MethodPut, "/v1/tenants/" + root.ID.String() + "/settings", `{"a":{"b":{"c":{"d":1}}}}`, http.StatusUnprocessableEntity, "settings_too_deep", "settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.do(t, tt.method, tt.path, echo.MIMEApplicationJSON, tt.body)
+			require.Equal(t, tt.status, status, string(body))
+
+			var got map[string]string
+
+			require.NoError(t, json.Unmarshal(body, &got))
+			assert.Equal(t, tt.code, got["code"])
+			assert.Equal(t, tt.field, got["field"])
+			assert.NotEmpty(t, got["message"])
+		})
+	}
+}
+
+func TestRequestValidationDetails(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	type detail struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		details []detail
+	}{
+		{
+			name:   "every problem of a batch update",
+			method: http.MethodPost,
+			path:   "/v1/tenants:batchUpdate",
+			body:   `{"ids":["tnntten-a","loadbal-b","tnntten-c","other"],"patch":{}}`,
+			details: []detail{
+				{Field: "ids", Code: "too_many"},
+				{Field: "ids[1]", Code: "invalid_id"},
+				{Field: "ids[3]", Code: "invalid_id"},
+				{Field: "patch", Code: "required"},
+			},
+		},
+		{
+			name:   "missing ids and unknown mode",
+			method: http.MethodDelete,
+			path:   "/v1/tenants",
+			body:   `{"mode":"eventually"}`,
+			details: []detail{
+				{Field: "ids", Code: "required"},
+				{Field: "mode", Code: "invalid_value"},
+			},
+		},
+		{
+			name:    "type mismatch",
+			method:  http.MethodPost,
+			path:    "/v1/tenants:batchUpdate",
+			body:    `{"ids":["tnntten-a"],"patch":{"description":42}}`,
+			details: []detail{{Field: "patch.description", Code: "invalid_type"}},
+		},
+		{
+			name:    "unknown field",
+			method:  http.MethodPost,
+			path:    "/v1/tenants/" + root.ID.String() + "/merge",
+			body:    `{"sourceID":"tnntten-a","into":"x"}`,
+			details: []detail{{Field: "into", Code: "unknown_field"}},
+		},
+		{
+			name:    "malformed body",
+			method:  http.MethodPost,
+			path:    "/v1/tenants/" + root.ID.String() + "/merge",
+			body:    `{"sourceID":`,
+			details: []detail{{Code: "malformed"}},
+		},
+		{
+			name:    "merge into itself",
+			method:  http.MethodPost,
+			path:    "/v1/tenants/" + root.ID.String() + "/merge",
+			body:    `{"sourceID":"` + root.ID.String() + `"}`,
+			details: []detail{{Field: "sourceID", Code: "invalid_value"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.do(t, tt.method, tt.path, echo.MIMEApplicationJSON, tt.body)
+			require.Equal(t, http.StatusBadRequest, status, string(body))
+
+			var got struct {
+				Code    string   `json:"code"`
+				Message string   `json:"message"`
+				Details []detail `json:"details"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &got))
+			assert.Equal(t, "invalid_request", got.Code)
+			assert.NotEmpty(t, got.Message)
+			assert.Equal(t, tt.details, got.Details)
+		})
+	}
+}
+
+func TestUnknownFieldDecoding(t *testing.T) {
+	type detail struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	}
+
+	details := func(t *testing.T, body []byte) []detail {
+		t.Helper()
+
+		var got struct {
+			Details []detail `json:"details"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		return got.Details
+	}
+
+	strict := map[string]string{restapi.HeaderStrictValidation: "true"}
+
+	t.Run("strict", func(t *testing.T) {
+		env := newEventEnv(t, "tnntten-denied")
+		root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+		resp, body := send(t, http.MethodPost, env.url+"/v1/tenants", `{"name":"typo","descripton":"x","colour":"red"}`, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "colour", Code: "unknown_field"}, {Field: "descripton", Code: "unknown_field"}}, details(t, body), "every unknown field is reported")
+		assert.False(t, env.client.Tenant.Query().Where(enttenant.Name("typo")).ExistX(env.ctx))
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`"],"patch":{"descripton":"x"}}`, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "patch.descripton", Code: "unknown_field"}}, details(t, body))
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants", `{"Name":"cased"}`, nil)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, "fields are matched regardless of case: "+string(body))
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		env := newEventEnv(t, "tnntten-denied", restapi.WithLenientDecoding())
+		root := env.client.Tenant.Create().SetName("root").SetDescription("before").SaveX(env.ctx)
+
+		resp, body := send(t, http.MethodPost, env.url+"/v1/tenants", `{"name":"typo","descripton":"x"}`, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+		assert.Empty(t, env.client.Tenant.Query().Where(enttenant.Name("typo")).OnlyX(env.ctx).Description, "unknown fields are ignored")
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`"],"patch":{"descripton":"x"}}`, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "patch", Code: "required"}}, details(t, body), "the patch is empty without the unknown field")
+		assert.Equal(t, "before", env.client.Tenant.GetX(env.ctx, root.ID).Description)
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants", `{"name":"strict","descripton":"x"}`, strict)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "descripton", Code: "unknown_field"}}, details(t, body), "callers still ask for strict decoding")
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants:batchUpdate", `{"ids":["`+root.ID.String()+`"],"patch":{"descripton":"x"}}`, strict)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "patch.descripton", Code: "unknown_field"}}, details(t, body))
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants", `{"name":"other"}`, map[string]string{restapi.HeaderStrictValidation: "maybe"})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Code: "invalid_value"}}, details(t, body))
+
+		resp, body = send(t, http.MethodPost, env.url+"/v1/tenants/"+root.ID.String()+"/merge", `{"sourceID":"tnntten-a","into":"x"}`, nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		assert.Equal(t, []detail{{Field: "into", Code: "unknown_field"}}, details(t, body), "only creates and updates are decoded leniently")
+	})
+}
+
+func TestRequestValidationDetailsV11(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, env.url+"/v1/tenants", strings.NewReader(`{"ids":["loadbal-a"]}`))
+	require.NoError(t, err)
+
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAccept, "application/vnd.tenant-api.v1.1+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+	assert.JSONEq(t, `{"error":{
+		"code":"invalid_request",
+		"message":"invalid ids[0]: \"loadbal-a\" is not a tenant id",
+		"details":[{"field":"ids[0]","code":"invalid_id","message":"\"loadbal-a\" is not a tenant id"}]
+	}}`, string(body))
+}
@@ -0,0 +1,242 @@
+package restapi_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/faults"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+type faultEnv struct {
+	ctx    context.Context
+	client *ent.Client
+	conn   *eventtools.MockConnection
+	faults *faults.Injector
+	url    string
+}
+
+// newFaultEnv serves the REST api with faults injected at the commits of transactions and the
+// publishing of changes. Faults are programmed for a test with inject, or forced for a request
+// with the X-Fault-Inject header. Changes are recorded with their sequence, the changes endpoint
+// is served to the crawl scope.
+func newFaultEnv(t *testing.T) *faultEnv {
+	t.Helper()
+
+	injector := faults.New(faults.WithHeaderTrigger())
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(
+		ent.Driver(faults.Driver(entsql.OpenDB(dialect.SQLite, db), injector)),
+		ent.EventsPublisher(changefeed.New(faults.Connection(conn, injector))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	client.Tenant.Use(changeseq.Hook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(),
+		[]echo.MiddlewareFunc{faults.Middleware(injector), actorMiddleware, perms.Middleware(), scopeMiddleware},
+		restapi.WithCrawlScope(crawlScope),
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return &faultEnv{
+		ctx:    context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms),
+		client: client,
+		conn:   conn,
+		faults: injector,
+		url:    srv.URL,
+	}
+}
+
+// inject programs the fault of the point until the end of the test.
+func (env *faultEnv) inject(t *testing.T, point faults.Point, f faults.Fault) {
+	t.Helper()
+
+	env.faults.Set(point, f)
+	t.Cleanup(func() { env.faults.Clear(point) })
+}
+
+// post sends the request, forcing the faults of the points.
+func (env *faultEnv) post(t *testing.T, path, body string, points ...faults.Point) (int, []byte) {
+	t.Helper()
+
+	headers := map[string]string{echo.HeaderContentType: echo.MIMEApplicationJSON}
+
+	if len(points) != 0 {
+		forced := make([]string, len(points))
+		for i, p := range points {
+			forced[i] = string(p)
+		}
+
+		headers[faults.HeaderTrigger] = strings.Join(forced, ",")
+	}
+
+	resp, respBody := send(t, http.MethodPost, env.url+path, body, headers)
+
+	return resp.StatusCode, respBody
+}
+
+// tenants creates the named tenants, then forgets their published changes.
+func (env *faultEnv) tenants(names ...string) ([]gidx.PrefixedID, int64) {
+	ids := make([]gidx.PrefixedID, len(names))
+
+	var seq int64
+
+	for i, name := range names {
+		tnt := env.client.Tenant.Create().SetName(name).SaveX(env.ctx)
+		ids[i], seq = tnt.ID, tnt.ChangeSeq
+	}
+
+	env.conn.Calls = nil
+
+	return ids, seq
+}
+
+// changedSince returns the tenants of the changes recorded after the sequence, in order.
+func (env *faultEnv) changedSince(t *testing.T, since int64) []gidx.PrefixedID {
+	t.Helper()
+
+	var ids []gidx.PrefixedID
+
+	for _, change := range listChanges(t, env.url, since, 100).Changes {
+		ids = append(ids, change.TenantID)
+	}
+
+	return ids
+}
+
+func batchRename(ids []gidx.PrefixedID, description string) string {
+	body := `{"ids":[`
+
+	for i, id := range ids {
+		if i > 0 {
+			body += ","
+		}
+
+		body += `"` + id.String() + `"`
+	}
+
+	return body + `],"patch":{"description":"` + description + `"}}`
+}
+
+// TestFaultAfterCommitKeepsChanges fails a batch update between the commit of its transaction and
+// the publishing of its changes. The events are never published, but no change is lost: every
+// change is recorded with the transaction and replicas catch up from the changes endpoint.
+func TestFaultAfterCommitKeepsChanges(t *testing.T) {
+	env := newFaultEnv(t)
+
+	ids, since := env.tenants("one", "two", "three")
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", batchRename(ids, "moved"), faults.PointAfterCommit)
+	require.Equal(t, http.StatusInternalServerError, status, string(body))
+	assert.Equal(t, 1, env.faults.Fired(faults.PointAfterCommit))
+
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+
+	for _, id := range ids {
+		assert.Equal(t, "moved", env.client.Tenant.GetX(env.ctx, id).Description, "the transaction was committed")
+	}
+
+	assert.Equal(t, ids, env.changedSince(t, since), "every committed change is recorded")
+}
+
+// TestFaultOnPublishKeepsChanges fails publishing every change of a committed batch update. The
+// update succeeds and every change can be caught up on.
+func TestFaultOnPublishKeepsChanges(t *testing.T) {
+	env := newFaultEnv(t)
+
+	ids, since := env.tenants("one", "two")
+
+	env.inject(t, faults.PointPublish, faults.Fault{Probability: 1})
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", batchRename(ids, "moved"))
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, len(ids), env.faults.Fired(faults.PointPublish))
+
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+	assert.Equal(t, ids, env.changedSince(t, since))
+}
+
+// TestFaultBeforeCommit fails a batch update before its transaction is committed. Nothing is
+// changed, recorded or published, so no event announces a change which didn't happen.
+func TestFaultBeforeCommit(t *testing.T) {
+	env := newFaultEnv(t)
+
+	ids, since := env.tenants("one", "two")
+
+	status, body := env.post(t, "/v1/tenants:batchUpdate", batchRename(ids, "moved"), faults.PointBeforeCommit)
+	require.Equal(t, http.StatusInternalServerError, status, string(body))
+
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+	assert.Empty(t, env.changedSince(t, since))
+
+	for _, id := range ids {
+		assert.Empty(t, env.client.Tenant.GetX(env.ctx, id).Description)
+	}
+
+	// the faults only apply to the requests forcing them
+	status, body = env.post(t, "/v1/tenants:batchUpdate", batchRename(ids, "moved"))
+	require.Equal(t, http.StatusOK, status, string(body))
+	env.conn.AssertNumberOfCalls(t, "PublishChange", len(ids))
+}
+
+// TestFaultSerializationFailureRetried aborts every commit of a create with a serialization
+// failure. The create is run again up to its attempts, then reported as unavailable, and nothing
+// is published for the aborted attempts.
+func TestFaultSerializationFailureRetried(t *testing.T) {
+	env := newFaultEnv(t)
+
+	env.inject(t, faults.PointBeforeCommit, faults.Fault{Probability: 1, Err: &pq.Error{Code: "40001"}})
+
+	status, body := env.post(t, "/v1/tenants", `{"name":"retried"}`)
+	require.Equal(t, http.StatusServiceUnavailable, status, string(body))
+	assert.Equal(t, crdb.DefaultAttempts, env.faults.Fired(faults.PointBeforeCommit))
+
+	env.conn.AssertNotCalled(t, "PublishChange", mock.Anything, mock.Anything)
+	assert.Zero(t, env.client.Tenant.Query().CountX(env.ctx))
+
+	env.faults.Clear(faults.PointBeforeCommit)
+
+	status, body = env.post(t, "/v1/tenants", `{"name":"retried"}`)
+	require.Equal(t, http.StatusCreated, status, string(body))
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+}
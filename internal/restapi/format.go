@@ -0,0 +1,257 @@
+package restapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"go.infratographer.com/tenant-api/internal/stream"
+)
+
+// Response formats clients can select with the Accept header. Plain JSON gets FormatV1, the
+// original format. FormatV11 wraps lists in an envelope with cursor pagination and errors in an
+// error object with a code.
+const (
+	FormatV1  = "v1"
+	FormatV11 = "v1.1"
+)
+
+const (
+	vendorMediaTypePrefix = "application/vnd.tenant-api."
+	vendorMediaTypeSuffix = "+json"
+
+	// formatKey is the echo context key the negotiated format is stored under.
+	formatKey = "restapi.format"
+)
+
+// supportedFormats lists the formats in the order they are reported when none is acceptable.
+var supportedFormats = []string{FormatV1, FormatV11}
+
+// mediaType returns the vendor media type of the format.
+func mediaType(format string) string {
+	return vendorMediaTypePrefix + format + vendorMediaTypeSuffix
+}
+
+// negotiateFormat selects the response format from the Accept header, the first supported media
+// range wins. Requests without an Accept header, accepting plain JSON or anything get FormatV1.
+// Only requests asking for vendor formats which aren't supported are rejected, with the supported
+// media types.
+func negotiateFormat(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		format, ok := acceptedFormat(c.Request().Header.Get(echo.HeaderAccept))
+		if !ok {
+			supported := make([]string, 0, len(supportedFormats)+1)
+
+			for _, f := range supportedFormats {
+				supported = append(supported, mediaType(f))
+			}
+
+			return c.JSON(http.StatusNotAcceptable, map[string]any{
+				"message":   "unsupported response format",
+				"supported": append(supported, echo.MIMEApplicationJSON),
+			})
+		}
+
+		c.Set(formatKey, format)
+		c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+		if format == FormatV1 {
+			return next(c)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, mediaType(format))
+
+		err := next(c)
+		if err != nil && !c.Response().Committed {
+			if werr := writeError(c, err); werr != nil {
+				return werr
+			}
+		}
+
+		// the error is still returned so it is logged, the response is already written
+		return err
+	}
+}
+
+// acceptedFormat returns the format selected by the Accept header, false when it only lists vendor
+// formats which aren't supported.
+func acceptedFormat(accept string) (string, bool) {
+	if accept == "" {
+		return FormatV1, true
+	}
+
+	unsupported := false
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaRange, _, _ = strings.Cut(mediaRange, ";")
+		mediaRange = strings.ToLower(strings.TrimSpace(mediaRange))
+
+		switch {
+		case mediaRange == echo.MIMEApplicationJSON, mediaRange == "application/*", mediaRange == "*/*":
+			return FormatV1, true
+		case strings.HasPrefix(mediaRange, vendorMediaTypePrefix) && strings.HasSuffix(mediaRange, vendorMediaTypeSuffix):
+			version := strings.TrimSuffix(strings.TrimPrefix(mediaRange, vendorMediaTypePrefix), vendorMediaTypeSuffix)
+
+			for _, f := range supportedFormats {
+				if version == f {
+					return f, true
+				}
+			}
+
+			unsupported = true
+		}
+	}
+
+	// clients not asking for json at all got it anyway before formats were negotiated
+	return FormatV1, !unsupported
+}
+
+// responseFormat returns the format negotiated for the request.
+func responseFormat(c echo.Context) string {
+	if format, ok := c.Get(formatKey).(string); ok {
+		return format
+	}
+
+	return FormatV1
+}
+
+// listEnvelope wraps a page of a list in FormatV11.
+type listEnvelope struct {
+	Data       any            `json:"data"`
+	Pagination listPagination `json:"pagination"`
+}
+
+type listPagination struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+// respondList responds with a page of a list: the legacy response in FormatV1, the items and the
+// cursor of the next page, empty on the last one, in an envelope in FormatV11. The envelope is
+// streamed, encoding the items one at a time. Once the status is sent errors are reported in the
+// stream.ErrorTrailer and returned to be logged.
+func respondList[T any](c echo.Context, legacy any, items []T, next string) error {
+	if responseFormat(c) == FormatV1 {
+		return c.JSON(http.StatusOK, legacy)
+	}
+
+	page := listPagination{
+		NextCursor: next,
+		HasMore:    next != "",
+	}
+
+	// indented responses are for people reading them, they are small enough to marshal whole
+	if _, pretty := c.QueryParams()["pretty"]; pretty {
+		return c.JSON(http.StatusOK, listEnvelope{Data: items, Pagination: page})
+	}
+
+	res := c.Response()
+
+	if res.Header().Get(echo.HeaderContentType) == "" {
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	}
+
+	w := stream.NewWriter(c.Request().Context(), res)
+	w.Start(http.StatusOK)
+
+	if err := writeEnvelope(w, items, page); err != nil {
+		w.Fail(err)
+
+		return err
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+// setLocation sets the Location header to the url of the named route with the params, such as the
+// url a resource is read from. It is built from the registered route, so it keeps the prefix the
+// routes are served under, even when another listener serves the route, as the admin one does for
+// the tenants it creates.
+func (h *Handler) setLocation(c echo.Context, route string, params ...any) {
+	c.Response().Header().Set(echo.HeaderLocation, h.locations.Reverse(route, params...))
+}
+
+// respondCreated responds with 201 Created, the created resource in the body and its url, that of
+// the named get route with the params, in the Location header. Every endpoint creating a resource
+// responds with it, clients follow the Location to read the resource again.
+func (h *Handler) respondCreated(c echo.Context, resource any, route string, params ...any) error {
+	h.setLocation(c, route, params...)
+
+	return c.JSON(http.StatusCreated, resource)
+}
+
+// writeEnvelope writes the list envelope the same as encoding a listEnvelope would.
+func writeEnvelope[T any](w *stream.Writer, items []T, page listPagination) error {
+	if _, err := w.Write([]byte(`{"data":`)); err != nil {
+		return err
+	}
+
+	if err := stream.JSONArray(w, items); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(append([]byte(`,"pagination":`), encoded...), "}\n"...))
+
+	return err
+}
+
+// errorBody is the error object of FormatV11 error responses.
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// writeError writes the error in FormatV11. Errors with a code, such as validation errors, keep
+// it along with their details, others get a code derived from the status. Errors which aren't http errors are reported as
+// internal errors without details, the same as by echo.
+func writeError(c echo.Context, err error) error {
+	he := echo.ErrInternalServerError
+
+	var herr *echo.HTTPError
+	if errors.As(err, &herr) {
+		he = herr
+	}
+
+	body := errorBody{
+		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(he.Code)), " ", "_"),
+		Message: http.StatusText(he.Code),
+	}
+
+	switch m := he.Message.(type) {
+	case string:
+		body.Message = m
+	case map[string]string:
+		if m["code"] != "" {
+			body.Code = m["code"]
+		}
+
+		body.Message = m["message"]
+		body.Field = m["field"]
+	case map[string]any:
+		if code, _ := m["code"].(string); code != "" {
+			body.Code = code
+		}
+
+		body.Message, _ = m["message"].(string)
+		body.Field, _ = m["field"].(string)
+		body.Details = m["details"]
+	}
+
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(he.Code)
+	}
+
+	return c.JSON(he.Code, map[string]errorBody{"error": body})
+}
@@ -0,0 +1,152 @@
+package restapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+const formatV11 = "application/vnd.tenant-api.v1.1+json"
+
+// assertGolden compares the response body against the named golden file, rewriting it when -update is set.
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	var pretty bytes.Buffer
+
+	require.NoError(t, json.Indent(&pretty, body, "", "  "))
+	pretty.WriteByte('\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, pretty.Bytes(), 0o600))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "golden file missing, run with -update to create it")
+
+	assert.Equal(t, string(expected), pretty.String())
+}
+
+func TestResponseFormats(t *testing.T) {
+	ctx := context.Background()
+
+	// the golden files pin the default timestamp format
+	timefmt.SetFormat(timefmt.RFC3339)
+
+	client, url := newTestServer(t)
+
+	changedAt := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	client.Tenant.Create().SetID("tnntten-golden00000001").SetName("first").SaveX(ctx)
+	client.Tenant.Create().SetID("tnntten-golden00000002").SetName("second").SaveX(ctx)
+	moved := client.Tenant.Create().SetID("tnntten-golden00000003").SetName("moved").SaveX(ctx)
+
+	client.TenantParentHistory.Create().
+		SetID("tnntphs-golden00000001").
+		SetTenantID(moved.ID).
+		SetNewParentID("tnntten-golden00000001").
+		SetActor("golden").
+		SetChangedAt(changedAt).
+		SaveX(ctx)
+	client.TenantParentHistory.Create().
+		SetID("tnntphs-golden00000002").
+		SetTenantID(moved.ID).
+		SetPreviousParentID("tnntten-golden00000001").
+		SetNewParentID("tnntten-golden00000002").
+		SetActor("golden").
+		SetChangedAt(changedAt.Add(time.Hour)).
+		SaveX(ctx)
+
+	historyURL := url + "/v1/tenants/" + moved.ID.String() + "/parent-history"
+
+	tests := []struct {
+		name        string
+		url         string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"history_v1", historyURL + "?limit=1", "", http.StatusOK, "application/json; charset=UTF-8"},
+		{"history_v1_json", historyURL + "?limit=1", "application/json", http.StatusOK, "application/json; charset=UTF-8"},
+		{"history_v1.1", historyURL + "?limit=1", formatV11, http.StatusOK, formatV11},
+		{"history_v1.1_last_page", historyURL + "?page_token=tnntphs-golden00000001", formatV11, http.StatusOK, formatV11},
+		{"not_found_v1", url + "/v1/tenants/tnntten-missing", "", http.StatusNotFound, "application/json; charset=UTF-8"},
+		{"not_found_v1.1", url + "/v1/tenants/tnntten-missing", formatV11, http.StatusNotFound, formatV11},
+		{"bad_request_v1.1", historyURL + "?limit=0", formatV11 + ", application/json;q=0.5", http.StatusBadRequest, formatV11},
+		{"not_acceptable", historyURL, "application/vnd.tenant-api.v9+json", http.StatusNotAcceptable, "application/json; charset=UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.accept != "" {
+				headers["Accept"] = tt.accept
+			}
+
+			resp, body := get(t, tt.url, headers)
+			require.Equal(t, tt.status, resp.StatusCode, string(body))
+			assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+
+			assertGolden(t, tt.name, body)
+		})
+	}
+}
+
+func TestTimestampFormats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Cleanup(func() { timefmt.SetFormat(timefmt.RFC3339) })
+
+	client, url := newTestServer(t)
+
+	moved := client.Tenant.Create().SetID("tnntten-golden00000001").SetName("moved").SaveX(ctx)
+
+	client.TenantParentHistory.Create().
+		SetID("tnntphs-golden00000001").
+		SetTenantID(moved.ID).
+		SetActor("golden").
+		SetChangedAt(time.Date(2023, time.June, 1, 14, 0, 0, 123456789, time.FixedZone("", 2*60*60))).
+		SaveX(ctx)
+
+	for _, format := range []timefmt.Format{timefmt.RFC3339, timefmt.RFC3339Nano} {
+		t.Run(string(format), func(t *testing.T) {
+			timefmt.SetFormat(format)
+
+			resp, body := get(t, url+"/v1/tenants/"+moved.ID.String()+"/parent-history", nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+			assertGolden(t, "timestamps_"+string(format), body)
+		})
+	}
+}
+
+func TestResponseFormatFallback(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	tnt := client.Tenant.Create().SetName("tenant").SaveX(ctx)
+
+	// unknown versions are fine as long as something supported is acceptable as well
+	for _, accept := range []string{"application/vnd.tenant-api.v9+json, */*;q=0.1", "text/html", "application/*"} {
+		resp, body := get(t, url+"/v1/tenants/"+tnt.ID.String(), map[string]string{"Accept": accept})
+		assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.Contains(t, resp.Header.Values("Vary"), "Accept")
+	}
+}
@@ -0,0 +1,145 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// WithCreationGuard finds the creation freezes shown in the stats of tenants with the guard. A
+// guard with the default cache ttl and the clock of the handler is used by default.
+func WithCreationGuard(g *freeze.CreationGuard) Option {
+	return func(h *Handler) {
+		h.creation = g
+	}
+}
+
+// adminFreeze freezes the tenant, every change of it is rejected with tenant_frozen until it is
+// unfrozen, while it is still read as usual.
+func (h *Handler) adminFreeze(c echo.Context) error {
+	return h.changeFrozen(c, freeze.Freeze)
+}
+
+// adminUnfreeze unfreezes the frozen tenant.
+func (h *Handler) adminUnfreeze(c echo.Context) error {
+	return h.changeFrozen(c, freeze.Unfreeze)
+}
+
+func (h *Handler) changeFrozen(c echo.Context, change func(context.Context, *ent.Client, gidx.PrefixedID) (*ent.Tenant, error)) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	t, err := change(ctx, h.client, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	h.log(c).Infow("tenant freeze changed", "tenant_id", t.ID, "frozen", t.Frozen)
+
+	return c.JSON(http.StatusOK, newTenant(t, redact.FromContext(ctx)))
+}
+
+type creationFreezeRequest struct {
+	Until *time.Time `json:"until"`
+}
+
+// creationFreeze is the REST representation of the creation freeze of a subtree.
+type creationFreeze struct {
+	TenantID gidx.PrefixedID `json:"tenantID"`
+	Until    timefmt.Time    `json:"until"`
+}
+
+// adminFreezeCreation freezes creating tenants in the subtree of the tenant until the time in the
+// body, which must be in the future and at most freeze.MaxCreationFreeze ahead. Creates under the
+// tenant or its descendants are rejected with tenant_frozen and the expiry until then, a later
+// freeze replaces the expiry.
+func (h *Handler) adminFreezeCreation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req creationFreezeRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	now := h.clock.Now()
+
+	switch {
+	case req.Until == nil:
+		return errmap.BadRequest(&validation.Error{Field: "until", Code: validation.CodeRequired, Message: "until is required"})
+	case !req.Until.After(now):
+		return errmap.BadRequest(&validation.Error{Field: "until", Code: validation.CodeInvalidValue, Message: "must be in the future"})
+	case req.Until.Sub(now) > freeze.MaxCreationFreeze:
+		return errmap.BadRequest(&validation.Error{
+			Field:   "until",
+			Code:    validation.CodeInvalidValue,
+			Message: fmt.Sprintf("must be at most %d days ahead", int(freeze.MaxCreationFreeze.Hours()/24)),
+		})
+	}
+
+	t, err := freeze.FreezeCreation(ctx, h.client, id, *req.Until)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	h.log(c).Infow("tenant creation frozen", "tenant_id", t.ID, "until", t.CreationFrozenUntil)
+
+	return c.JSON(http.StatusOK, h.newFrozenTenant(t, redact.FromContext(ctx)))
+}
+
+// adminUnfreezeCreation lifts the creation freeze of the tenant before it expires.
+func (h *Handler) adminUnfreezeCreation(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	t, err := freeze.UnfreezeCreation(ctx, h.client, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	h.log(c).Infow("tenant creation unfrozen", "tenant_id", t.ID)
+
+	return c.JSON(http.StatusOK, h.newFrozenTenant(t, redact.FromContext(ctx)))
+}
+
+// newFrozenTenant returns the REST representation of the tenant with the expiry of its creation
+// freeze, which is only shown until it passes.
+func (h *Handler) newFrozenTenant(t *ent.Tenant, fields redact.Fields) tenant {
+	resp := newTenant(t, fields)
+
+	if h.creationFrozen(t) {
+		resp.CreationFrozenUntil = timefmt.Ptr(&t.CreationFrozenUntil)
+	}
+
+	return resp
+}
+
+// creationFrozen reports whether creating tenants in the subtree of the tenant is frozen by a
+// freeze of its own.
+func (h *Handler) creationFrozen(t *ent.Tenant) bool {
+	return t.CreationFrozenUntil.After(h.clock.Now())
+}
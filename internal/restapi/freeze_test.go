@@ -0,0 +1,236 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/archive"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/subtree"
+)
+
+func TestTenantFrozen(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	env.client.Tenant.Use(freeze.Hook())
+	env.client.Tenant.Use(archive.Hook())
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	frozen := env.client.Tenant.Create().SetName("frozen").SetParent(root).SetDescription("before").SaveX(env.ctx)
+	other := env.client.Tenant.Create().SetName("other").SetParent(root).SaveX(env.ctx)
+
+	_, err := freeze.Freeze(env.ctx, env.client, frozen.ID)
+	require.NoError(t, err)
+
+	path := "/v1/tenants/" + frozen.ID.String()
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"create child", http.MethodPost, "/v1/tenants", `{"name":"child","parentID":"` + frozen.ID.String() + `"}`},
+		{"delete", http.MethodDelete, path, ""},
+		{"schedule deletion", http.MethodPost, path + "/schedule-deletion", ""},
+		{"archive", http.MethodPost, path + "/archive", ""},
+		{"replace settings", http.MethodPut, path + "/settings", `{"theme":"dark"}`},
+		{"patch settings", http.MethodPatch, path + "/settings", `{"theme":"dark"}`},
+		{"batch delete", http.MethodDelete, "/v1/tenants", `{"ids":["` + frozen.ID.String() + `"]}`},
+		{"merge", http.MethodPost, "/v1/tenants/" + other.ID.String() + "/merge", `{"sourceID":"` + frozen.ID.String() + `"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.do(t, tt.method, tt.path, echo.MIMEApplicationJSON, tt.body)
+			require.Equal(t, http.StatusLocked, status, string(body))
+
+			var resp struct {
+				Code string `json:"code"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &resp))
+			assert.Equal(t, "tenant_frozen", resp.Code)
+		})
+	}
+
+	// nothing was changed, batch updates report frozen tenants as conflicts, see
+	// TestTenantBatchUpdateConflicts
+	tnt := env.client.Tenant.GetX(env.ctx, frozen.ID)
+	assert.Equal(t, "before", tnt.Description)
+	assert.Empty(t, tnt.Settings)
+	assert.False(t, tnt.Archived)
+	assert.Zero(t, env.client.Tenant.GetX(env.ctx, other.ID).Description)
+	assert.Equal(t, 3, env.client.Tenant.Query().CountX(env.ctx))
+
+	// reads continue normally
+	status, body := env.do(t, http.MethodGet, path, "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Contains(t, string(body), `"frozen":true`)
+
+	_, err = freeze.Unfreeze(env.ctx, env.client, frozen.ID)
+	require.NoError(t, err)
+
+	status, body = env.do(t, http.MethodPut, path+"/settings", echo.MIMEApplicationJSON, `{"theme":"dark"}`)
+	assert.Equal(t, http.StatusOK, status, string(body))
+}
+
+func TestAdminFreeze(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+
+	tnt := client.Tenant.Create().SetName("investigated").SaveX(ctx)
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	resp, body := send(t, http.MethodPost, url+"/v1/tenants/"+tnt.ID.String()+"/freeze", "", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"frozen":true`)
+	assert.True(t, client.Tenant.GetX(ctx, tnt.ID).Frozen)
+
+	resp, body = send(t, http.MethodPost, url+"/v1/tenants/"+tnt.ID.String()+"/unfreeze", "", map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = send(t, http.MethodPost, url+"/v1/tenants/"+tnt.ID.String()+"/unfreeze", "", admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.NotContains(t, string(body), `"frozen"`)
+	assert.False(t, client.Tenant.GetX(ctx, tnt.ID).Frozen)
+
+	resp, body = send(t, http.MethodPost, url+"/v1/tenants/tnntten-missing/freeze", "", admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestAdminFreezeCreation(t *testing.T) {
+	ctx := context.Background()
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	fake := clock.NewFake(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	guard := freeze.NewCreationGuard(subtree.NewResolver(subtree.WithClock(fake)), freeze.WithClock(fake))
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware},
+		restapi.WithAdminScope("tenants:admin"),
+		restapi.WithClock(fake),
+		restapi.WithCreationGuard(guard),
+	)
+	client.Tenant.Use(guard.Hook())
+
+	grandparent := client.Tenant.Create().SetName("grandparent").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(grandparent).SaveX(ctx)
+
+	freezePath := url + "/v1/tenants/" + grandparent.ID.String() + "/creation-freeze"
+	until := fake.Now().Add(time.Hour)
+
+	// create posts a child of the parent, a grandchild of the frozen tenant
+	create := func(t *testing.T, name string) (*http.Response, []byte) {
+		t.Helper()
+
+		return send(t, http.MethodPost, url+"/v1/tenants", `{"name":"`+name+`","parentID":"`+parent.ID.String()+`"}`, nil)
+	}
+
+	// stats returns the creation freeze in the stats of the parent
+	stats := func(t *testing.T) map[string]any {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants/"+parent.ID.String()+"/stats", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var out struct {
+			CreationFreeze map[string]any `json:"creationFreeze"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &out))
+
+		return out.CreationFreeze
+	}
+
+	for _, tt := range []struct {
+		name   string
+		body   string
+		scope  map[string]string
+		status int
+	}{
+		{"not an admin", `{"until":"` + until.Format(time.RFC3339) + `"}`, map[string]string{"X-Scope": "tenants:full"}, http.StatusForbidden},
+		{"no expiry", `{}`, admin, http.StatusBadRequest},
+		{"past expiry", `{"until":"` + fake.Now().Add(-time.Minute).Format(time.RFC3339) + `"}`, admin, http.StatusBadRequest},
+		{"expiry too far", `{"until":"` + fake.Now().Add(freeze.MaxCreationFreeze+time.Minute).Format(time.RFC3339) + `"}`, admin, http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := send(t, http.MethodPut, freezePath, tt.body, tt.scope)
+			assert.Equal(t, tt.status, resp.StatusCode, string(body))
+		})
+	}
+
+	resp, body := send(t, http.MethodPut, freezePath, `{"until":"`+until.Format(time.RFC3339)+`"}`, admin)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"creationFrozenUntil":"2026-10-01T13:00:00.000Z"`)
+
+	t.Run("grandchild create rejected", func(t *testing.T) {
+		resp, body := create(t, "grandchild")
+		require.Equal(t, http.StatusLocked, resp.StatusCode, string(body))
+
+		var out struct {
+			Code        string `json:"code"`
+			FrozenUntil string `json:"frozenUntil"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &out))
+		assert.Equal(t, "tenant_frozen", out.Code)
+		assert.Equal(t, "2026-10-01T13:00:00.000Z", out.FrozenUntil)
+	})
+
+	t.Run("other tenants created", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, url+"/v1/tenants", `{"name":"elsewhere"}`, nil)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		_, err := client.Tenant.UpdateOneID(parent.ID).SetDescription("still changed").Save(ctx)
+		assert.NoError(t, err, "only creates are frozen")
+	})
+
+	t.Run("shown while active", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants/"+grandparent.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.Contains(t, string(body), `"creationFrozenUntil":"2026-10-01T13:00:00.000Z"`)
+
+		resp, body = get(t, url+"/v1/tenants/"+parent.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.NotContains(t, string(body), "creationFrozenUntil", "the freeze belongs to the grandparent")
+
+		assert.Equal(t, map[string]any{"tenantID": grandparent.ID.String(), "until": "2026-10-01T13:00:00.000Z"}, stats(t))
+	})
+
+	fake.Advance(time.Hour)
+
+	t.Run("expires by itself", func(t *testing.T) {
+		resp, body := create(t, "grandchild")
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		resp, body = get(t, url+"/v1/tenants/"+grandparent.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.NotContains(t, string(body), "creationFrozenUntil")
+
+		assert.Nil(t, stats(t))
+	})
+
+	t.Run("lifted early", func(t *testing.T) {
+		resp, body := send(t, http.MethodPut, freezePath, `{"until":"`+fake.Now().Add(time.Hour).Format(time.RFC3339)+`"}`, admin)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		resp, body = create(t, "blocked")
+		require.Equal(t, http.StatusLocked, resp.StatusCode, string(body))
+
+		resp, body = send(t, http.MethodDelete, freezePath, "", admin)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.NotContains(t, string(body), "creationFrozenUntil")
+		assert.True(t, client.Tenant.GetX(ctx, grandparent.ID).CreationFrozenUntil.IsZero())
+
+		resp, body = create(t, "unblocked")
+		assert.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	})
+}
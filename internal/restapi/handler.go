@@ -0,0 +1,398 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/concurrency"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	"go.infratographer.com/tenant-api/internal/duplicates"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/export"
+	"go.infratographer.com/tenant-api/internal/failures"
+	"go.infratographer.com/tenant-api/internal/freeze"
+	"go.infratographer.com/tenant-api/internal/jobs"
+	"go.infratographer.com/tenant-api/internal/liveconfig"
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/reqlog"
+	"go.infratographer.com/tenant-api/internal/search"
+	"go.infratographer.com/tenant-api/internal/subtree"
+	"go.infratographer.com/tenant-api/internal/traversal"
+	"go.infratographer.com/tenant-api/internal/usage"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// DefaultMaxBatchSize is the default maximum number of tenants a batch request may list.
+const DefaultMaxBatchSize = 100
+
+// DefaultMaxIncludedChildren is the default maximum number of children included with a tenant.
+const DefaultMaxIncludedChildren = 50
+
+// DefaultMaxListOffset is the default maximum number of tenants a list may skip with offset.
+const DefaultMaxListOffset = 1000
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithCacheMaxAge sets the max-age clients may cache tenant responses for.
+func WithCacheMaxAge(maxAge time.Duration) Option {
+	return func(h *Handler) {
+		h.cacheMaxAge = maxAge
+	}
+}
+
+// WithMaxBatchSize sets the maximum number of tenants a batch request may list.
+func WithMaxBatchSize(size int) Option {
+	return func(h *Handler) {
+		if size > 0 {
+			h.maxBatchSize = size
+		}
+	}
+}
+
+// WithMaxIncludedChildren sets the maximum number of children included with a tenant requested
+// with ?include=children, the others are listed with the list endpoint.
+func WithMaxIncludedChildren(n int) Option {
+	return func(h *Handler) {
+		if n > 0 {
+			h.maxIncludedChildren = n
+		}
+	}
+}
+
+// WithMaxListOffset sets the maximum number of tenants a list may skip with the offset query
+// parameter. Skipped tenants are still read, so lists going further follow the page tokens.
+func WithMaxListOffset(n int) Option {
+	return func(h *Handler) {
+		if n > 0 {
+			h.maxListOffset = n
+		}
+	}
+}
+
+// WithDeletionScheduler sets the scheduler used to schedule and cancel tenant deletions.
+func WithDeletionScheduler(s *deletion.Scheduler) Option {
+	return func(h *Handler) {
+		h.deletion = s
+	}
+}
+
+// WithAdminScope sets the token scope required by the admin endpoints, they aren't registered
+// without one.
+func WithAdminScope(scope string) Option {
+	return func(h *Handler) {
+		h.adminScope = scope
+	}
+}
+
+// WithAdminListener moves the admin routes from Routes to AdminRoutes, which serves them on a
+// listener of their own and authorizes callers with the policy. A non-nil middleware replaces the
+// one of NewHandler on those routes, such as to authenticate by client certificate. Under the mtls
+// policy the routes are registered even without an admin scope.
+func WithAdminListener(policy AdminPolicy, middleware []echo.MiddlewareFunc) Option {
+	return func(h *Handler) {
+		h.adminSeparate = true
+		h.adminPolicy = policy
+		h.adminMiddleware = middleware
+	}
+}
+
+// WithLiveConfig takes the crawl rate limit and the page size cap of the tenant list from the
+// store, so reloaded settings apply to the next request. Its crawl rate limit replaces the one of
+// WithCrawlRateLimit.
+func WithLiveConfig(s *liveconfig.Store) Option {
+	return func(h *Handler) {
+		h.live = s
+	}
+}
+
+// WithClock sets the clock measuring cache ttls, retentions and crawl snapshots. It defaults to the
+// wall clock and is shared with the default deletion scheduler and the job registry.
+func WithClock(c clock.Clock) Option {
+	return func(h *Handler) {
+		h.clock = c
+	}
+}
+
+// WithTraversalMetrics records the walks through the hierarchy made for the stats, the aggregate
+// and the limit headers on the metrics.
+func WithTraversalMetrics(m *traversal.Metrics) Option {
+	return func(h *Handler) {
+		h.traversals = m
+	}
+}
+
+// WithQueryGuard rejects the tenant lists whose filters can't use an index. Without it every filter
+// combination is served.
+func WithQueryGuard(g *querycost.Guard) Option {
+	return func(h *Handler) {
+		h.queryGuard = g
+	}
+}
+
+// WithReadCoalescing lets concurrent identical reads of a tenant or of its statistics share one
+// query and response. Only callers seeing the same fields share a read, and each one's access is
+// still checked.
+func WithReadCoalescing() Option {
+	return func(h *Handler) {
+		h.reads = concurrency.NewCoalescer[tenantRead]()
+		h.statsReads = concurrency.NewCoalescer[tenantStats]()
+	}
+}
+
+// WithValidation sets the pipeline validating and normalizing the tenants created and updated,
+// the default rules by default. Passing the pipeline of the ent client's hook reports every error
+// of a request at once, not only the first one the hook finds.
+func WithValidation(p *validation.Pipeline) Option {
+	return func(h *Handler) {
+		h.validator = p
+	}
+}
+
+// Handler serves the REST endpoints.
+type Handler struct {
+	client       *ent.Client
+	logger       *zap.SugaredLogger
+	middleware   []echo.MiddlewareFunc
+	cacheMaxAge  time.Duration
+	maxBatchSize int
+	deletion     *deletion.Scheduler
+	adminScope   string
+	adminPolicy  AdminPolicy
+	hooks        RouteHooks
+	stats        *statsCache
+	crawl        *crawler
+	jobs         *jobs.Registry
+
+	forceDeleteScope string
+	usage            *usage.Recorder
+	failures         *failures.Recorder
+	live             *liveconfig.Store
+	changeRetention  time.Duration
+	maxChildren      int
+	maxDepth         int
+	clock            clock.Clock
+	traversals       *traversal.Metrics
+	queryGuard       *querycost.Guard
+	audit            *audit.Recorder
+	reads            *concurrency.Coalescer[tenantRead]
+	statsReads       *concurrency.Coalescer[tenantStats]
+	dispatcher       *changefeed.Dispatcher
+	duplicates       *duplicates.Guard
+	validator        *validation.Pipeline
+	nameReuse        validation.NameReusePolicy
+	creation         *freeze.CreationGuard
+	search           search.Indexer
+	lenientDecoding  bool
+	adminSeparate    bool
+	adminMiddleware  []echo.MiddlewareFunc
+
+	// locations holds every route of the handler, whichever listener serves it, to build the
+	// urls of the Location header from
+	locations *echo.Echo
+
+	maxIncludedChildren int
+	maxListOffset       int
+
+	export       *export.Handler
+	feed         *changefeed.Feed
+	watchTimeout time.Duration
+}
+
+// NewHandler returns a REST handler. The middleware authenticates requests and installs the
+// permissions checker, the same as for the graph api. Handlers log through a logger derived from
+// the given one for each request. The response format is negotiated before the middleware runs, so
+// its errors are formatted as well.
+func NewHandler(client *ent.Client, logger *zap.SugaredLogger, middleware []echo.MiddlewareFunc, opts ...Option) *Handler {
+	h := &Handler{
+		client:       client,
+		logger:       logger,
+		middleware:   append(append([]echo.MiddlewareFunc{negotiateFormat, normalizeTenantParam}, middleware...), reqlog.Middleware(logger)),
+		maxBatchSize: DefaultMaxBatchSize,
+		stats:        newStatsCache(),
+		crawl:        newCrawler(),
+		clock:        clock.Real{},
+		validator:    validation.DefaultPipeline(),
+		nameReuse:    validation.NameReuseBlockDuringRetention,
+		adminPolicy:  AdminPolicyScope,
+		locations:    echo.New(),
+
+		maxIncludedChildren: DefaultMaxIncludedChildren,
+		maxListOffset:       DefaultMaxListOffset,
+		watchTimeout:        DefaultWatchTimeout,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.adminMiddleware != nil {
+		h.adminMiddleware = append(append([]echo.MiddlewareFunc{negotiateFormat, normalizeTenantParam}, h.adminMiddleware...), reqlog.Middleware(logger))
+	} else {
+		h.adminMiddleware = h.middleware
+	}
+
+	if h.deletion == nil {
+		h.deletion = deletion.NewScheduler(client, logger, deletion.WithClock(h.clock))
+	}
+
+	if h.creation == nil {
+		h.creation = freeze.NewCreationGuard(subtree.NewResolver(subtree.WithClock(h.clock)), freeze.WithClock(h.clock))
+	}
+
+	if h.search == nil {
+		h.search = search.NewSQL(client)
+	}
+
+	h.jobs = jobs.NewRegistry(jobs.WithClock(h.clock))
+
+	if h.live != nil {
+		h.live.Subscribe(func(s liveconfig.Settings) {
+			h.crawl.setRateLimit(rate.Limit(s.CrawlRate), s.CrawlBurst)
+		})
+	}
+
+	return h
+}
+
+// Routes registers the REST routes, see RouteHooks for the order their middleware runs in.
+func (h *Handler) Routes(e *echo.Group) {
+	h.add(e, http.MethodGet, "/v1/tenants", RouteTenantList, h.tenantList)
+	h.add(e, http.MethodPost, "/v1/tenants", RouteTenantCreate, h.tenantCreate)
+	h.add(e, http.MethodGet, "/v1/tenants/:id", RouteTenantGet, h.tenantGet)
+	h.add(e, http.MethodPatch, "/v1/tenants/:id", RouteTenantUpdate, h.tenantUpdate)
+	h.add(e, http.MethodGet, "/v1/tenants/search", RouteTenantSearch, h.tenantSearch)
+	h.add(e, http.MethodGet, "/v1/tenants/aggregate", RouteTenantAggregate, h.tenantAggregate)
+	h.add(e, http.MethodGet, "/v1/tenants/by-urn", RouteTenantGetByURN, h.tenantGetByURN)
+	h.add(e, http.MethodPost, "/v1/tenants/lookup-by-urn", RouteTenantLookupByURN, h.tenantLookupByURN)
+	h.add(e, http.MethodPost, "/v1/tenants\\:batchUpdate", RouteTenantBatchUpdate, h.tenantBatchUpdate)
+	h.add(e, http.MethodDelete, "/v1/tenants", RouteTenantBatchDelete, h.tenantBatchDelete)
+	h.add(e, http.MethodDelete, "/v1/tenants/:id", RouteTenantDelete, h.tenantDelete)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/merge", RouteTenantMerge, h.tenantMerge)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/schedule-deletion", RouteTenantScheduleDeletion, h.tenantScheduleDeletion)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/cancel-deletion", RouteTenantCancelDeletion, h.tenantCancelDeletion)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/archive", RouteTenantArchive, h.tenantArchive)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/unarchive", RouteTenantUnarchive, h.tenantUnarchive)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/by-external-id/:ref", RouteTenantGetByExternalID, h.tenantGetByExternalID)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/parent-history", RouteTenantParentHistory, h.tenantParentHistory)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/stats", RouteTenantStats, h.tenantStats)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/settings", RouteTenantSettingsGet, h.tenantSettingsGet)
+	h.add(e, http.MethodPut, "/v1/tenants/:id/settings", RouteTenantSettingsPut, h.tenantSettingsPut)
+	h.add(e, http.MethodPatch, "/v1/tenants/:id/settings", RouteTenantSettingsPatch, h.tenantSettingsPatch)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/labels", RouteTenantLabelsGet, h.tenantLabelsGet)
+	h.add(e, http.MethodPut, "/v1/tenants/:id/labels", RouteTenantLabelsPut, h.tenantLabelsPut)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/effective-labels", RouteTenantEffectiveLabels, h.tenantEffectiveLabels)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/service-accounts", RouteServiceAccountList, h.tenantServiceAccounts)
+	h.add(e, http.MethodPost, "/v1/tenants/:id/service-accounts", RouteServiceAccountCreate, h.tenantServiceAccountCreate)
+	h.add(e, http.MethodGet, "/v1/tenants/:id/service-accounts/:account_id", RouteServiceAccountGet, h.tenantServiceAccountGet)
+	h.add(e, http.MethodDelete, "/v1/tenants/:id/service-accounts/:account_id", RouteServiceAccountDelete, h.tenantServiceAccountDelete)
+	h.add(e, http.MethodGet, "/v1/collations", RouteCollationList, h.collationList)
+
+	if h.usage != nil {
+		h.add(e, http.MethodGet, "/v1/tenants/:id/usage", RouteTenantUsage, h.tenantUsage)
+	}
+
+	if h.audit != nil {
+		h.add(e, http.MethodGet, "/v1/tenants/:id/audit", RouteTenantAudit, h.tenantAudit)
+	}
+
+	if h.crawl.scope != "" {
+		h.add(e, http.MethodGet, "/v1/tenants\\:crawl", RouteTenantCrawl, h.tenantCrawl, requireScope(h.crawl.scope), h.limitCrawl)
+		h.add(e, http.MethodGet, "/v1/tenants/changes", RouteTenantChanges, h.tenantChanges, requireScope(h.crawl.scope))
+	}
+
+	if !h.adminSeparate {
+		h.adminRoutes(e)
+	}
+}
+
+// AdminRoutes registers the admin routes on the group of the admin listener, when the handler was
+// created WithAdminListener. Otherwise they are registered by Routes with the other routes.
+func (h *Handler) AdminRoutes(e *echo.Group) {
+	if h.adminSeparate {
+		h.adminRoutes(e)
+	}
+}
+
+// adminRoutes registers the admin routes, unless they are disabled.
+func (h *Handler) adminRoutes(e *echo.Group) {
+	if h.adminScope == "" && h.adminPolicy != AdminPolicyMTLS {
+		return
+	}
+
+	h.addAdmin(e, http.MethodGet, "/v1/admin/verify", RouteAdminVerify, h.adminVerify)
+	h.addAdmin(e, http.MethodPost, "/v1/admin/tenants", RouteAdminAdopt, h.adminTenantAdopt)
+	h.addAdmin(e, http.MethodPost, "/v1/admin/tenants\\:import", RouteAdminImport, h.adminTenantImport)
+	h.addAdmin(e, http.MethodPost, "/v1/admin/tenants/:id/restore", RouteAdminRestore, h.adminTenantRestore)
+	h.addAdmin(e, http.MethodPut, "/v1/admin/tenants/:id/max-children", RouteAdminSetMaxChildren, h.adminSetMaxChildren)
+	h.addAdmin(e, http.MethodPost, "/v1/admin/tenants/:id/rebuild", RouteAdminRebuild, h.adminRebuild)
+	h.addAdmin(e, http.MethodGet, "/v1/admin/jobs/:id", RouteAdminJobGet, h.adminJobGet)
+	h.addAdmin(e, http.MethodPost, "/v1/tenants/:id/freeze", RouteAdminFreeze, h.adminFreeze)
+	h.addAdmin(e, http.MethodPost, "/v1/tenants/:id/unfreeze", RouteAdminUnfreeze, h.adminUnfreeze)
+	h.addAdmin(e, http.MethodPut, "/v1/tenants/:id/creation-freeze", RouteAdminFreezeCreation, h.adminFreezeCreation)
+	h.addAdmin(e, http.MethodDelete, "/v1/tenants/:id/creation-freeze", RouteAdminUnfreezeCreation, h.adminUnfreezeCreation)
+
+	if h.failures != nil {
+		h.addAdmin(e, http.MethodGet, "/v1/admin/errors", RouteAdminErrors, h.adminErrors)
+	}
+
+	if h.dispatcher != nil {
+		h.addAdmin(e, http.MethodGet, "/v1/admin/dispatch", RouteAdminDispatch, h.adminDispatch)
+		h.addAdmin(e, http.MethodPost, "/v1/admin/dispatch/drain", RouteAdminDispatchDrain, h.adminDispatchDrain)
+	}
+}
+
+// log returns the logger of the request.
+func (h *Handler) log(c echo.Context) *zap.SugaredLogger {
+	return reqlog.FromEcho(c, h.logger)
+}
+
+// normalizeTenantParam replaces a tenant URN given as the id path parameter with the tenant id,
+// before the middleware and handlers read it, so either identifies the tenant. URNs of other
+// resource types and malformed URNs are rejected with their code, raw ids are left to the
+// handlers.
+func normalizeTenantParam(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		names := c.ParamNames()
+
+		for i, name := range names {
+			if name != "id" || !strings.HasPrefix(c.Param(name), "urn:") {
+				continue
+			}
+
+			id, code, err := parseURN(c.Param(name))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, map[string]string{
+					"code":    code,
+					"message": err.Error(),
+				}).WithInternal(err)
+			}
+
+			values := append([]string(nil), c.ParamValues()...)
+			values[i] = id.String()
+			c.SetParamValues(values...)
+		}
+
+		return next(c)
+	}
+}
+
+// parseTenantID parses the id path parameter, tenant URNs were replaced by the tenant id.
+func parseTenantID(c echo.Context) (gidx.PrefixedID, error) {
+	id, err := gidx.Parse(c.Param("id"))
+	if err != nil || id.Prefix() != schema.TenantPrefix {
+		return gidx.NullPrefixedID, echo.NewHTTPError(http.StatusBadRequest, "invalid tenant id")
+	}
+
+	return id, nil
+}
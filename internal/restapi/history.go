@@ -0,0 +1,147 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantparenthistory"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+// Page sizes of the parent history.
+const (
+	defaultHistoryPageSize = 50
+	maxHistoryPageSize     = 100
+)
+
+type parentChange struct {
+	ID               gidx.PrefixedID  `json:"id"`
+	PreviousParentID *gidx.PrefixedID `json:"previousParentID,omitempty"`
+	NewParentID      *gidx.PrefixedID `json:"newParentID,omitempty"`
+	Actor            string           `json:"actor,omitempty"`
+	ChangedAt        timefmt.Time     `json:"changedAt"`
+}
+
+type parentHistoryResponse struct {
+	Changes       []parentChange `json:"changes"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+// newParentChange converts a history entry, the parents are omitted when they are redacted for
+// the caller.
+func newParentChange(e *ent.TenantParentHistory, fields redact.Fields) parentChange {
+	change := parentChange{
+		ID:        e.ID,
+		Actor:     e.Actor,
+		ChangedAt: timefmt.New(e.ChangedAt),
+	}
+
+	if fields.Visible(redact.FieldParent) {
+		if e.PreviousParentID != gidx.NullPrefixedID {
+			change.PreviousParentID = &e.PreviousParentID
+		}
+
+		if e.NewParentID != gidx.NullPrefixedID {
+			change.NewParentID = &e.NewParentID
+		}
+	}
+
+	return change
+}
+
+// tenantParentHistory lists the parent changes of a tenant, oldest first. Pages are requested
+// with the limit and page_token query parameters, the token being the nextPageToken of the
+// previous page, or its nextCursor in FormatV11.
+func (h *Handler) tenantParentHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultHistoryPageSize
+
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxHistoryPageSize {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxHistoryPageSize))
+		}
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	query := h.client.TenantParentHistory.Query().
+		Where(tenantparenthistory.TenantID(id))
+
+	if token := c.QueryParam("page_token"); token != "" {
+		after, err := h.historyCursor(c, id, token)
+		if err != nil {
+			return err
+		}
+
+		query = query.Where(tenantparenthistory.Or(
+			tenantparenthistory.ChangedAtGT(after.ChangedAt),
+			tenantparenthistory.And(
+				tenantparenthistory.ChangedAt(after.ChangedAt),
+				tenantparenthistory.IDGT(after.ID),
+			),
+		))
+	}
+
+	entries, err := query.
+		Order(ent.Asc(tenantparenthistory.FieldChangedAt), ent.Asc(tenantparenthistory.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp := parentHistoryResponse{Changes: make([]parentChange, 0, len(entries))}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp.NextPageToken = entries[limit-1].ID.String()
+	}
+
+	fields := redact.FromContext(ctx)
+
+	for _, e := range entries {
+		resp.Changes = append(resp.Changes, newParentChange(e, fields))
+	}
+
+	return respondList(c, resp, resp.Changes, resp.NextPageToken)
+}
+
+// historyCursor loads the entry a page token refers to, which must belong to the tenant.
+func (h *Handler) historyCursor(c echo.Context, tenantID gidx.PrefixedID, token string) (*ent.TenantParentHistory, error) {
+	id, err := gidx.Parse(token)
+	if err != nil || id.Prefix() != schema.ParentHistoryPrefix {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid page token")
+	}
+
+	entry, err := h.client.TenantParentHistory.Query().
+		Where(tenantparenthistory.ID(id), tenantparenthistory.TenantID(tenantID)).
+		Only(c.Request().Context())
+
+	switch {
+	case ent.IsNotFound(err):
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid page token")
+	case err != nil:
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,84 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+)
+
+type historyPage struct {
+	Changes []struct {
+		ID               gidx.PrefixedID `json:"id"`
+		PreviousParentID gidx.PrefixedID `json:"previousParentID"`
+		NewParentID      gidx.PrefixedID `json:"newParentID"`
+		Actor            string          `json:"actor"`
+	} `json:"changes"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func TestTenantParentHistory(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	first := env.client.Tenant.Create().SetName("first").SaveX(env.ctx)
+	second := env.client.Tenant.Create().SetName("second").SaveX(env.ctx)
+	third := env.client.Tenant.Create().SetName("third").SaveX(env.ctx)
+	moved := env.client.Tenant.Create().SetName("moved").SetParent(first).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	status, body := env.post(t, "/v1/tenants/"+second.ID.String()+"/merge", `{"sourceID":"`+first.ID.String()+`"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	status, body = env.post(t, "/v1/tenants/"+third.ID.String()+"/merge", `{"sourceID":"`+second.ID.String()+`"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	historyURL := env.url + "/v1/tenants/" + moved.ID.String() + "/parent-history"
+
+	resp, body := get(t, historyURL, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var page historyPage
+
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.Len(t, page.Changes, 2)
+	assert.Empty(t, page.NextPageToken)
+
+	assert.Equal(t, first.ID, page.Changes[0].PreviousParentID)
+	assert.Equal(t, second.ID, page.Changes[0].NewParentID)
+	assert.Equal(t, second.ID, page.Changes[1].PreviousParentID)
+	assert.Equal(t, third.ID, page.Changes[1].NewParentID)
+	assert.Equal(t, testActor, page.Changes[1].Actor)
+
+	// the same history one entry at a time
+	resp, body = get(t, historyURL+"?limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var paged historyPage
+
+	require.NoError(t, json.Unmarshal(body, &paged))
+	require.Len(t, paged.Changes, 1)
+	assert.Equal(t, page.Changes[0].ID, paged.Changes[0].ID)
+	require.NotEmpty(t, paged.NextPageToken)
+
+	resp, body = get(t, historyURL+"?limit=1&page_token="+paged.NextPageToken, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	paged = historyPage{}
+
+	require.NoError(t, json.Unmarshal(body, &paged))
+	require.Len(t, paged.Changes, 1)
+	assert.Equal(t, page.Changes[1].ID, paged.Changes[0].ID)
+	assert.Empty(t, paged.NextPageToken)
+
+	resp, body = get(t, historyURL+"?limit=0", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = get(t, historyURL+"?page_token="+page.Changes[0].ID.String()+"x", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = get(t, env.url+"/v1/tenants/tnntten-denied/parent-history", nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+}
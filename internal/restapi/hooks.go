@@ -0,0 +1,135 @@
+package restapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Names of the REST routes, used to attach middleware to single routes with RouteHooks.
+const (
+	RouteTenantGet              = "tenants.get"
+	RouteTenantList             = "tenants.list"
+	RouteTenantSearch           = "tenants.search"
+	RouteTenantCreate           = "tenants.create"
+	RouteTenantUpdate           = "tenants.update"
+	RouteTenantGetByExternalID  = "tenants.getByExternalID"
+	RouteTenantGetByURN         = "tenants.getByURN"
+	RouteTenantLookupByURN      = "tenants.lookupByURN"
+	RouteTenantAggregate        = "tenants.aggregate"
+	RouteTenantBatchUpdate      = "tenants.batchUpdate"
+	RouteTenantBatchDelete      = "tenants.batchDelete"
+	RouteTenantDelete           = "tenants.delete"
+	RouteTenantCrawl            = "tenants.crawl"
+	RouteTenantChanges          = "tenants.changes"
+	RouteTenantMerge            = "tenants.merge"
+	RouteTenantScheduleDeletion = "tenants.scheduleDeletion"
+	RouteTenantCancelDeletion   = "tenants.cancelDeletion"
+	RouteTenantArchive          = "tenants.archive"
+	RouteTenantUnarchive        = "tenants.unarchive"
+	RouteTenantParentHistory    = "tenants.parentHistory"
+	RouteTenantStats            = "tenants.stats"
+	RouteTenantSettingsGet      = "tenants.settings.get"
+	RouteTenantSettingsPut      = "tenants.settings.put"
+	RouteTenantSettingsPatch    = "tenants.settings.patch"
+	RouteTenantLabelsGet        = "tenants.labels.get"
+	RouteTenantLabelsPut        = "tenants.labels.put"
+	RouteTenantEffectiveLabels  = "tenants.effectiveLabels"
+	RouteTenantUsage            = "tenants.usage"
+	RouteTenantAudit            = "tenants.audit"
+	RouteServiceAccountList     = "tenants.serviceAccounts.list"
+	RouteServiceAccountCreate   = "tenants.serviceAccounts.create"
+	RouteServiceAccountGet      = "tenants.serviceAccounts.get"
+	RouteServiceAccountDelete   = "tenants.serviceAccounts.delete"
+	RouteCollationList          = "collations.list"
+	RouteAdminVerify            = "admin.verify"
+	RouteAdminSetMaxChildren    = "admin.setMaxChildren"
+	RouteAdminRebuild           = "admin.rebuild"
+	RouteAdminJobGet            = "admin.jobs.get"
+	RouteAdminErrors            = "admin.errors"
+	RouteAdminFreeze            = "admin.freeze"
+	RouteAdminUnfreeze          = "admin.unfreeze"
+	RouteAdminFreezeCreation    = "admin.freezeCreation"
+	RouteAdminUnfreezeCreation  = "admin.unfreezeCreation"
+	RouteAdminDispatch          = "admin.dispatch"
+	RouteAdminDispatchDrain     = "admin.dispatch.drain"
+	RouteAdminAdopt             = "admin.adopt"
+	RouteAdminRestore           = "admin.restore"
+	RouteAdminImport            = "admin.import"
+)
+
+// RouteHooks holds middleware an embedding service attaches to the REST routes, for example for
+// its own authorization or accounting.
+//
+// For every route the middleware runs in this order:
+//
+//  1. middleware added to the echo server or the group passed to Routes or AdminRoutes, such as
+//     tracing and request logging
+//  2. the response format negotiation, then the middleware passed to NewHandler, which
+//     authenticates the request and installs the permissions checker, or on admin routes the one
+//     passed to WithAdminListener if any, and the request logger, followed by the usage counting
+//     on the routes of single tenants
+//  3. the admin scope or client certificate check, on admin routes only
+//  4. Read middleware on GET routes, Write middleware on every other route
+//  5. the middleware registered for the route by name in Routes
+//
+// Hooks therefore see authenticated requests only and may rely on the permissions checker, and
+// a hook rejecting a request keeps the later hooks and the handler from running. The handler
+// checks access to the tenant itself after every hook.
+type RouteHooks struct {
+	// Read is attached to the routes which don't change anything.
+	Read []echo.MiddlewareFunc
+	// Write is attached to the routes which may change tenants.
+	Write []echo.MiddlewareFunc
+	// Routes is attached to single routes by route name. Names of routes which aren't registered,
+	// such as the admin routes without an admin scope, are ignored.
+	Routes map[string][]echo.MiddlewareFunc
+}
+
+// WithRouteHooks sets the middleware attached to the REST routes in addition to the built-in one.
+func WithRouteHooks(hooks RouteHooks) Option {
+	return func(h *Handler) {
+		h.hooks = hooks
+	}
+}
+
+// add registers the route with the built-in middleware, the extra middleware and the hooks, in
+// the order documented on RouteHooks.
+func (h *Handler) add(e *echo.Group, method, path, name string, handler echo.HandlerFunc, extra ...echo.MiddlewareFunc) {
+	h.register(e, h.middleware, method, path, name, handler, extra...)
+}
+
+// addAdmin registers the admin route with the middleware of the admin routes and the admin check.
+func (h *Handler) addAdmin(e *echo.Group, method, path, name string, handler echo.HandlerFunc) {
+	h.register(e, h.adminMiddleware, method, path, name, handler, h.requireAdmin)
+}
+
+// register registers the route with the base middleware, followed by the same as add.
+func (h *Handler) register(e *echo.Group, base []echo.MiddlewareFunc, method, path, name string, handler echo.HandlerFunc, extra ...echo.MiddlewareFunc) {
+	middleware := make([]echo.MiddlewareFunc, 0, len(base)+len(extra))
+	middleware = append(middleware, base...)
+
+	if h.usage != nil && strings.HasPrefix(path, "/v1/tenants/:id") {
+		middleware = append(middleware, h.usage.Middleware())
+	}
+
+	if h.audit != nil && method != http.MethodGet && (strings.HasPrefix(path, "/v1/tenants/:id") || suppressibleRoutes[name]) {
+		middleware = append(middleware, h.audit.Middleware(name))
+	}
+
+	middleware = append(middleware, extra...)
+
+	if method == http.MethodGet {
+		middleware = append(middleware, h.hooks.Read...)
+	} else {
+		middleware = append(middleware, h.hooks.Write...)
+	}
+
+	middleware = append(middleware, h.hooks.Routes[name]...)
+
+	route := e.Add(method, path, handler, middleware...)
+	route.Name = name
+
+	h.locations.Add(method, route.Path, handler).Name = name
+}
@@ -0,0 +1,168 @@
+package restapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// recorder records the order its middleware runs in.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) middleware(name string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r.mu.Lock()
+			r.calls = append(r.calls, name)
+			r.mu.Unlock()
+
+			return next(c)
+		}
+	}
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := r.calls
+	r.calls = nil
+
+	return calls
+}
+
+func TestRouteHooksOrder(t *testing.T) {
+	ctx := context.Background()
+
+	rec := &recorder{}
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware, rec.middleware("builtin")},
+		restapi.WithAdminScope("tenants:admin"),
+		restapi.WithRouteHooks(restapi.RouteHooks{
+			Read:  []echo.MiddlewareFunc{rec.middleware("read")},
+			Write: []echo.MiddlewareFunc{rec.middleware("write")},
+			Routes: map[string][]echo.MiddlewareFunc{
+				restapi.RouteTenantGet:   {rec.middleware("named")},
+				restapi.RouteTenantMerge: {rec.middleware("named")},
+				restapi.RouteAdminVerify: {rec.middleware("named")},
+				"tenants.unknown":        {rec.middleware("unknown")},
+			},
+		}),
+	)
+
+	tnt := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/"+tnt.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, []string{"builtin", "read", "named"}, rec.take())
+
+	resp, _ = get(t, url+"/v1/tenants/"+tnt.ID.String()+"/settings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"builtin", "read"}, rec.take(), "named hooks only run on their route")
+
+	resp, _ = put(t, url+"/v1/tenants/"+tnt.ID.String()+"/settings", `{}`, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"builtin", "write"}, rec.take())
+
+	resp, _ = get(t, url+"/v1/admin/verify", map[string]string{"X-Scope": "tenants:admin"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"builtin", "read", "named"}, rec.take())
+
+	resp, _ = get(t, url+"/v1/admin/verify", nil)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, []string{"builtin"}, rec.take(), "hooks run after the admin scope check")
+}
+
+func TestRouteHooksReject(t *testing.T) {
+	ctx := context.Background()
+
+	deny := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusPaymentRequired, "no chargeback account")
+		}
+	}
+
+	client, url := newTestServer(t, restapi.WithRouteHooks(restapi.RouteHooks{
+		Write: []echo.MiddlewareFunc{deny},
+	}))
+
+	tnt := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+	resp, _ := put(t, url+"/v1/tenants/"+tnt.ID.String()+"/settings", `{"theme":"dark"}`, nil)
+	assert.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+
+	assert.Nil(t, client.Tenant.GetX(ctx, tnt.ID).Settings, "rejected requests don't reach the handler")
+}
+
+// An embedding service requires a chargeback account on every tenant read.
+func ExampleWithRouteHooks() {
+	ctx := context.Background()
+
+	client, err := ent.Open("sqlite3", "file:example-route-hooks?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		panic(err)
+	}
+
+	defer client.Close()
+
+	if err := client.Schema.Create(ctx); err != nil {
+		panic(err)
+	}
+
+	tnt := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+	requireAccount := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("X-Chargeback-Account") == "" {
+				return echo.NewHTTPError(http.StatusBadRequest, "missing chargeback account")
+			}
+
+			return next(c)
+		}
+	}
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	if err != nil {
+		panic(err)
+	}
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()},
+		restapi.WithRouteHooks(restapi.RouteHooks{
+			Routes: map[string][]echo.MiddlewareFunc{
+				restapi.RouteTenantGet: {requireAccount},
+			},
+		}),
+	).Routes(e.Group(""))
+
+	for _, account := range []string{"", "team-42"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/tenants/"+tnt.ID.String(), nil)
+		req.Header.Set("X-Chargeback-Account", account)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		fmt.Println(rec.Code)
+	}
+
+	// Output:
+	// 400
+	// 200
+}
@@ -0,0 +1,235 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/crdb"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// maxImportSize is the number of tenants a single import may create.
+const maxImportSize = 10000
+
+type importRequest struct {
+	Tenants []adoptRequest `json:"tenants"`
+}
+
+type importResponse struct {
+	Root     gidx.PrefixedID `json:"root"`
+	Imported int             `json:"imported"`
+}
+
+// validate validates the tenants of the import as adopted tenants. The first tenant is the root
+// of the imported subtree, its parent, if any, must exist already. The parents of the others must
+// be tenants listed before them.
+func (r *importRequest) validate(c echo.Context, p *validation.Pipeline) error {
+	ctx := c.Request().Context()
+
+	var errs validation.Errors
+
+	switch {
+	case len(r.Tenants) == 0:
+		errs.Add("tenants", validation.CodeRequired, "at least one tenant is required")
+	case len(r.Tenants) > maxImportSize:
+		errs.Add("tenants", validation.CodeTooMany, fmt.Sprintf("at most %d tenants may be imported at once", maxImportSize))
+	}
+
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	seen := make(map[gidx.PrefixedID]bool, len(r.Tenants))
+
+	for i := range r.Tenants {
+		item := &r.Tenants[i]
+		prefix := fmt.Sprintf("tenants[%d].", i)
+
+		if err := item.validate(ctx, p); err != nil {
+			var ierrs validation.Errors
+			if !errors.As(err, &ierrs) {
+				return err
+			}
+
+			for _, ierr := range ierrs {
+				errs.Add(prefix+ierr.Field, ierr.Code, ierr.Message)
+			}
+
+			continue
+		}
+
+		id := gidx.PrefixedID(item.ID)
+
+		if seen[id] {
+			errs.Add(prefix+"id", validation.CodeInvalidValue, fmt.Sprintf("%s is listed more than once", id))
+		}
+
+		if i != 0 && (item.ParentID == nil || !seen[*item.ParentID]) {
+			errs.Add(prefix+"parentID", validation.CodeInvalidValue, "must be a tenant listed before, only the first tenant may have another parent")
+		}
+
+		seen[id] = true
+		item.adoptedID = id
+	}
+
+	return errs.Err()
+}
+
+// adminTenantImport creates a subtree of tenants with the ids they had in another system, as
+// adminTenantAdopt does for a single tenant, in one transaction. The first tenant is the root of
+// the subtree, the caller must be allowed to create tenants under its parent. Ids taken by other
+// tenants are a conflict. With suppress_events=true the creates aren't published, a single
+// summary event is once the import is committed.
+func (h *Handler) adminTenantImport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req importRequest
+
+	if err := h.decodeInput(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := req.validate(c, h.validator); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	root := req.Tenants[0]
+
+	suppress, err := suppressEvents(c, root.adoptedID)
+	if err != nil {
+		return err
+	}
+
+	resource := gidx.NullPrefixedID
+	if root.ParentID != nil {
+		resource = *root.ParentID
+	}
+
+	if err := permissions.CheckAccess(ctx, resource, actionTenantCreate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	ids := make([]gidx.PrefixedID, len(req.Tenants))
+
+	for i, item := range req.Tenants {
+		ids[i] = item.adoptedID
+	}
+
+	if err := h.checkImportIDs(c, ids); err != nil {
+		return err
+	}
+
+	txCtx, s := suppression(changefeed.WithAdditionalData(ctx, map[string]any{adoptedKey: true}), suppress)
+
+	err = crdb.Retry(txCtx, func(txCtx context.Context) error {
+		return h.importTenants(txCtx, c, req.Tenants)
+	})
+	if err != nil {
+		var verr *validation.Error
+		if errors.As(err, &verr) {
+			return errmap.BadRequest(verr)
+		}
+
+		// a concurrent request took one of the ids first
+		if ent.IsConstraintError(err) {
+			if cerr := h.checkImportIDs(c, ids); cerr != nil {
+				return cerr
+			}
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	h.publishSummary(ctx, h.log(c), s, changefeed.Summary{Operation: bulkImport, Root: root.adoptedID, Tenants: len(ids)})
+
+	h.log(c).Infow("imported tenants", "tenant_id", root.adoptedID, "tenants", len(ids), "suppressed", s != nil)
+
+	return h.respondCreated(c, importResponse{Root: root.adoptedID, Imported: len(ids)}, RouteTenantGet, root.adoptedID)
+}
+
+// importTenants creates the tenants in a transaction, publishing their changes once committed. It
+// is run again by adminTenantImport when the transaction is aborted by a serialization failure.
+// The validation errors of a tenant are reported for its index in the import.
+func (h *Handler) importTenants(ctx context.Context, c echo.Context, tenants []adoptRequest) error {
+	txCtx, batch := changefeed.WithBatch(ctx)
+
+	tx, err := h.client.Tx(txCtx)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range tenants {
+		if _, err := createWithInput(txCtx, tx.Client(), item.createRequest); err != nil {
+			if rerr := tx.Rollback(); rerr != nil {
+				h.log(c).Errorw("failed to roll back tenant import", "error", rerr)
+			}
+
+			var verr *validation.Error
+			if errors.As(err, &verr) {
+				return &validation.Error{
+					Field:   fmt.Sprintf("tenants[%d].%s", i, verr.Field),
+					Code:    verr.Code,
+					Message: verr.Message,
+				}
+			}
+
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := batch.Flush(c.Request().Context()); err != nil {
+		// the tenants are committed, report them even though some events were lost
+		h.log(c).Errorw("failed to publish tenant import", "error", err)
+	}
+
+	return nil
+}
+
+// checkImportIDs returns a conflict when one of the ids is taken by a tenant already.
+func (h *Handler) checkImportIDs(c echo.Context, ids []gidx.PrefixedID) error {
+	ctx := c.Request().Context()
+
+	for start := 0; start < len(ids); start += rebuildBatchSize {
+		end := start + rebuildBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		taken, err := h.client.Tenant.Query().Where(enttenant.IDIn(ids[start:end]...)).Order(ent.Asc(enttenant.FieldID)).IDs(ctx)
+		if err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		if len(taken) != 0 {
+			index := 0
+
+			for i, id := range ids {
+				if id == taken[0] {
+					index = i
+				}
+			}
+
+			return errmap.HTTPError(&validation.Error{
+				Field:   fmt.Sprintf("tenants[%d].id", index),
+				Code:    validation.CodeIDTaken,
+				Message: fmt.Sprintf("%s is the id of another tenant", taken[0]),
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package restapi_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// importBody returns an import of n tenants below the parent, a root tenant when empty, each
+// tenant but the first one a child of an earlier one.
+func importBody(parent string, n int) string {
+	items := make([]string, n)
+
+	for i := range items {
+		parentID := parent
+		if i != 0 {
+			parentID = fmt.Sprintf("tnntten-imported-%d", (i-1)/4)
+		}
+
+		if parentID == "" {
+			items[i] = fmt.Sprintf(`{"id":"tnntten-imported-%d","name":"imported-%d"}`, i, i)
+		} else {
+			items[i] = fmt.Sprintf(`{"id":"tnntten-imported-%d","name":"imported-%d","parentID":"%s"}`, i, i, parentID)
+		}
+	}
+
+	return `{"tenants":[` + strings.Join(items, ",") + `]}`
+}
+
+func TestAdminTenantImport(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied", restapi.WithAdminScope("tenants:admin"))
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+	path := env.url + "/v1/admin/tenants:import"
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	resp, body := send(t, http.MethodPost, path, importBody(root.ID.String(), 3), admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	assert.Equal(t, "/v1/tenants/tnntten-imported-0", resp.Header.Get("Location"))
+	assert.JSONEq(t, `{"root":"tnntten-imported-0","imported":3}`, string(body))
+
+	assert.Equal(t, 3, env.client.Tenant.Query().Where(enttenant.ParentTenantIDIn("tnntten-imported-0", root.ID)).CountX(env.ctx))
+
+	// one create per tenant, flagged as adoptions
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 3)
+
+	for _, call := range env.conn.Calls {
+		msg := call.Arguments.Get(1).(events.ChangeMessage)
+		assert.Equal(t, string(events.CreateChangeType), msg.EventType)
+		assert.Equal(t, true, msg.AdditionalData["adopted"])
+	}
+
+	t.Run("taken ids are a conflict", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, path, importBody(root.ID.String(), 2), admin)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+		assert.Contains(t, string(body), "id_taken")
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []string{
+			`{"tenants":[]}`,
+			`{"tenants":[{"id":"tnntten-a","name":"a"},{"id":"tnntten-b","name":"b"}]}`,
+			`{"tenants":[{"id":"tnntten-a","name":"a"},{"id":"tnntten-a","name":"b","parentID":"tnntten-a"}]}`,
+			`{"tenants":[{"id":"tnntten-a","name":"a"},{"id":"tnntten-b","name":"b","parentID":"` + root.ID.String() + `"}]}`,
+			`{"tenants":[{"id":"tnntten-a"}]}`,
+		} {
+			resp, body := send(t, http.MethodPost, path, req, admin)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, req+": "+string(body))
+		}
+
+		assert.False(t, env.client.Tenant.Query().Where(enttenant.ID("tnntten-a")).ExistX(env.ctx), "nothing is imported")
+	})
+
+	t.Run("admins only", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, path, importBody(root.ID.String(), 1), map[string]string{"X-Scope": "tenants:full"})
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+	})
+}
+
+func TestAdminTenantImportSuppressed(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied", restapi.WithAdminScope("tenants:admin"))
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants:import?suppress_events=true", importBody(root.ID.String(), 500), admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+	assert.Equal(t, 501, env.client.Tenant.Query().CountX(env.ctx))
+
+	// a single summary in place of the 500 creates
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, changefeed.SummaryEventType, msg.EventType)
+	assert.Equal(t, "tnntten-imported-0", msg.SubjectID.String())
+	assert.Equal(t, "import", msg.AdditionalData[changefeed.SummaryOperationKey])
+	assert.Equal(t, 500, msg.AdditionalData[changefeed.SummaryTenantsKey])
+	assert.Equal(t, map[string]int{string(events.CreateChangeType): 500}, msg.AdditionalData[changefeed.SummarySuppressedKey])
+	assert.NotEmpty(t, msg.AdditionalData[changefeed.SummaryRootURNKey])
+
+	t.Run("other routes don't suppress events", func(t *testing.T) {
+		env.conn.Calls = nil
+
+		resp, body := send(t, http.MethodPost, env.url+"/v1/tenants?suppress_events=true", `{"name":"regular","parentID":"`+root.ID.String()+`"}`, admin)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+		msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+		assert.Equal(t, string(events.CreateChangeType), msg.EventType)
+	})
+
+	t.Run("invalid parameter", func(t *testing.T) {
+		resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants:import?suppress_events=maybe", importBody(root.ID.String(), 1), admin)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+	})
+}
+
+func TestAdminTenantImportSuppressedAudit(t *testing.T) {
+	ctx := context.Background()
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	// suppressed operations are audited even though rejected attempts aren't recorded
+	client, url := newAuditServer(t, "tnntten-denied", false, restapi.WithAdminScope("tenants:admin"))
+
+	// imports publishing their changes aren't audited
+	resp, body := send(t, http.MethodPost, url+"/v1/admin/tenants:import", importBody("", 2), admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	assert.Equal(t, 0, client.TenantAudit.Query().CountX(ctx))
+
+	resp, body = send(t, http.MethodPost, url+"/v1/admin/tenants:import?suppress_events=true",
+		`{"tenants":[{"id":"tnntten-suppressed-0","name":"suppressed","parentID":"tnntten-imported-1"},`+
+			`{"id":"tnntten-suppressed-1","name":"child","parentID":"tnntten-suppressed-0"}]}`, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+	entries := client.TenantAudit.Query().AllX(ctx)
+	require.Len(t, entries, 1)
+	assert.Equal(t, audit.OutcomeSuppressed, entries[0].Outcome)
+	assert.Equal(t, restapi.RouteAdminImport, entries[0].Operation)
+	assert.Equal(t, "tnntten-suppressed-0", entries[0].TenantID.String())
+
+	// the entry is listed with those of the root of the subtree
+	resp, body = get(t, url+"/v1/tenants/tnntten-suppressed-0/audit?outcome=suppressed", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), restapi.RouteAdminImport)
+}
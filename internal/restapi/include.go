@@ -0,0 +1,371 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+// Values of the include query parameter.
+const (
+	includeSettingsName      = "settings"
+	includeParentName        = "parent"
+	includeChildrenCountName = "children_count"
+	includePathName          = "path"
+	includeChildrenName      = "children"
+)
+
+// includeNames are the values the include query parameter accepts.
+var includeNames = map[string]bool{
+	includeSettingsName:      true,
+	includeParentName:        true,
+	includeChildrenCountName: true,
+	includePathName:          true,
+	includeChildrenName:      true,
+}
+
+// includes is the set of values of the include query parameter.
+type includes map[string]bool
+
+// parseIncludes parses the include query parameter, a comma separated list. Unknown values are
+// rejected so clients notice when they ask for something which isn't supported.
+func parseIncludes(c echo.Context) (includes, error) {
+	inc := includes{}
+
+	raw := c.QueryParam("include")
+	if raw == "" {
+		return inc, nil
+	}
+
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+
+		if !includeNames[name] {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown include %q", name))
+		}
+
+		inc[name] = true
+	}
+
+	return inc, nil
+}
+
+// related reports whether any value requires other tenants than the included one, whose
+// representation then depends on more than the tenant itself.
+func (inc includes) related() bool {
+	return inc[includeParentName] || inc[includeChildrenCountName] || inc[includePathName] || inc[includeChildrenName]
+}
+
+// includedTenant is a tenant included on request, null when there is none.
+type includedTenant struct {
+	*tenant
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t includedTenant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.tenant)
+}
+
+// expansion holds the related tenants included with a page of tenants, each loaded with a single
+// query for the whole page.
+type expansion struct {
+	inc    includes
+	fields redact.Fields
+
+	parents       map[gidx.PrefixedID]*ent.Tenant
+	deniedParents map[gidx.PrefixedID]bool
+	childCounts   map[gidx.PrefixedID]int
+	parentOf      map[gidx.PrefixedID]gidx.PrefixedID
+}
+
+// expand loads what the includes request for the tenants and checks which parents the caller
+// may access.
+func (h *Handler) expand(ctx context.Context, tenants []*ent.Tenant, inc includes) (*expansion, error) {
+	x, err := h.loadExpansion(ctx, tenants, inc, redact.FromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := x.checkParents(ctx); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+// loadExpansion loads what the includes request for the tenants, the same for every caller with
+// the fields. The parents and the paths are only loaded when the parents are visible.
+func (h *Handler) loadExpansion(ctx context.Context, tenants []*ent.Tenant, inc includes, fields redact.Fields) (*expansion, error) {
+	x := &expansion{
+		inc:    inc,
+		fields: fields,
+	}
+
+	parentIDs := distinctParents(tenants)
+	withParents := x.fields.Visible(redact.FieldParent)
+
+	if inc[includeParentName] && withParents && len(parentIDs) != 0 {
+		parents, err := h.client.Tenant.Query().Where(enttenant.IDIn(parentIDs...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		x.parents = make(map[gidx.PrefixedID]*ent.Tenant, len(parents))
+
+		for _, p := range parents {
+			x.parents[p.ID] = p
+		}
+	}
+
+	if inc[includeChildrenCountName] && len(tenants) != 0 {
+		counts, err := childCounts(ctx, h.client, tenantIDs(tenants))
+		if err != nil {
+			return nil, err
+		}
+
+		x.childCounts = counts
+	}
+
+	if inc[includePathName] && withParents {
+		parentOf, err := ancestry(ctx, h.client, tenants)
+		if err != nil {
+			return nil, err
+		}
+
+		x.parentOf = parentOf
+	}
+
+	return x, nil
+}
+
+// checkParents checks the access of the caller to the loaded parents. Parents the caller may not
+// access are left out rather than included as null, which stands for root tenants.
+func (x *expansion) checkParents(ctx context.Context) error {
+	x.deniedParents = map[gidx.PrefixedID]bool{}
+
+	for id := range x.parents {
+		if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+			if !errors.Is(err, permissions.ErrPermissionDenied) {
+				return err
+			}
+
+			x.deniedParents[id] = true
+		}
+	}
+
+	return nil
+}
+
+// apply sets the included fields on the representation of the tenant.
+func (x *expansion) apply(resp *tenant, t *ent.Tenant) {
+	if x.inc[includeParentName] && x.fields.Visible(redact.FieldParent) {
+		switch parent, ok := x.parents[t.ParentTenantID]; {
+		case t.ParentTenantID == gidx.NullPrefixedID:
+			resp.Parent = &includedTenant{}
+		case ok && !x.deniedParents[parent.ID]:
+			p := newTenant(parent, x.fields)
+			resp.Parent = &includedTenant{&p}
+		}
+	}
+
+	if x.inc[includeChildrenCountName] {
+		count := x.childCounts[t.ID]
+		resp.ChildrenCount = &count
+	}
+
+	if x.inc[includePathName] && x.parentOf != nil {
+		resp.Path = path(t.ID, x.parentOf)
+	}
+}
+
+// includedChildren are the children included with a tenant, at most the configured number of
+// them. When there are more, HasMore is set and Next is the list request returning the following
+// ones, under the prefix the routes are served with.
+type includedChildren struct {
+	Tenants    []tenant `json:"tenants"`
+	TotalCount int      `json:"totalCount"`
+	HasMore    bool     `json:"hasMore"`
+	Next       string   `json:"next,omitempty"`
+}
+
+// childrenTotalColumn is the column counting every child next to the included ones.
+const childrenTotalColumn = "children_total"
+
+// includeChildren loads the first children of the tenant by name, and their total count, with a
+// single query. Archived children are left out, as the list endpoint does by default, which
+// continues the listing from the last included child.
+func (h *Handler) includeChildren(ctx context.Context, t *ent.Tenant, fields redact.Fields) (*includedChildren, error) {
+	children, err := h.client.Tenant.Query().
+		Where(
+			enttenant.ParentTenantID(t.ID),
+			enttenant.Or(enttenant.ArchivedIsNil(), enttenant.Archived(false)),
+			// the window counts the children before the limit applies
+			func(s *sql.Selector) {
+				s.AppendSelectExprAs(sql.Raw("COUNT(*) OVER ()"), childrenTotalColumn)
+			},
+		).
+		Order(ent.Asc(enttenant.FieldName), ent.Asc(enttenant.FieldID)).
+		Limit(h.maxIncludedChildren).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	included := &includedChildren{Tenants: make([]tenant, len(children))}
+
+	for i, child := range children {
+		included.Tenants[i] = newTenant(child, fields)
+	}
+
+	if len(children) == 0 {
+		return included, nil
+	}
+
+	total, err := children[0].Value(childrenTotalColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch total := total.(type) {
+	case int64:
+		included.TotalCount = int(total)
+	default:
+		return nil, fmt.Errorf("unexpected count of children %T", total)
+	}
+
+	if included.TotalCount > len(children) {
+		included.HasMore = true
+
+		last := children[len(children)-1]
+		query := url.Values{
+			"parent_id":  {t.ID.String()},
+			"order_by":   {orderByName},
+			"page_token": {listOrder{byName: true}.cursor(last).Encode()},
+		}
+
+		included.Next = h.locations.Reverse(RouteTenantList) + "?" + query.Encode()
+	}
+
+	return included, nil
+}
+
+// childCounts counts the children of each of the tenants with a single grouped query.
+func childCounts(ctx context.Context, client *ent.Client, ids []gidx.PrefixedID) (map[gidx.PrefixedID]int, error) {
+	var rows []struct {
+		ParentTenantID gidx.PrefixedID `json:"parent_tenant_id"`
+		Count          int             `json:"count"`
+	}
+
+	err := client.Tenant.Query().
+		Where(enttenant.ParentTenantIDIn(ids...)).
+		GroupBy(enttenant.FieldParentTenantID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[gidx.PrefixedID]int, len(rows))
+
+	for _, row := range rows {
+		counts[row.ParentTenantID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// ancestry maps the tenants and all their ancestors to their parents, loading the ancestors a
+// level at a time so the number of queries depends on the depth of the hierarchy rather than on
+// the number of tenants. Ancestors already seen are skipped so a cycle doesn't walk forever.
+func ancestry(ctx context.Context, client *ent.Client, tenants []*ent.Tenant) (map[gidx.PrefixedID]gidx.PrefixedID, error) {
+	parentOf := make(map[gidx.PrefixedID]gidx.PrefixedID, len(tenants))
+
+	for _, t := range tenants {
+		parentOf[t.ID] = t.ParentTenantID
+	}
+
+	level := distinctParents(tenants)
+
+	for depth := 0; len(level) != 0 && depth < statsMaxDepth; depth++ {
+		ancestors, err := client.Tenant.Query().
+			Where(enttenant.IDIn(level...)).
+			Select(enttenant.FieldID, enttenant.FieldParentTenantID).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		level = level[:0]
+
+		for _, a := range ancestors {
+			parentOf[a.ID] = a.ParentTenantID
+
+			if _, seen := parentOf[a.ParentTenantID]; a.ParentTenantID != gidx.NullPrefixedID && !seen {
+				level = append(level, a.ParentTenantID)
+			}
+		}
+	}
+
+	return parentOf, nil
+}
+
+// path returns the IDs from the root down to the tenant.
+func path(id gidx.PrefixedID, parentOf map[gidx.PrefixedID]gidx.PrefixedID) []gidx.PrefixedID {
+	ids := []gidx.PrefixedID{id}
+	seen := map[gidx.PrefixedID]bool{id: true}
+
+	for parent := parentOf[id]; parent != gidx.NullPrefixedID && !seen[parent]; parent = parentOf[parent] {
+		ids = append(ids, parent)
+		seen[parent] = true
+	}
+
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+
+	return ids
+}
+
+// distinctParents returns the IDs of the parents of the tenants, sorted.
+func distinctParents(tenants []*ent.Tenant) []gidx.PrefixedID {
+	seen := map[gidx.PrefixedID]bool{}
+	ids := []gidx.PrefixedID{}
+
+	for _, t := range tenants {
+		if t.ParentTenantID != gidx.NullPrefixedID && !seen[t.ParentTenantID] {
+			seen[t.ParentTenantID] = true
+
+			ids = append(ids, t.ParentTenantID)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// tenantIDs returns the IDs of the tenants.
+func tenantIDs(tenants []*ent.Tenant) []gidx.PrefixedID {
+	ids := make([]gidx.PrefixedID, len(tenants))
+
+	for i, t := range tenants {
+		ids[i] = t.ID
+	}
+
+	return ids
+}
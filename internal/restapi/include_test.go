@@ -0,0 +1,357 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/querybudget"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+// listIncludeBudget is the most queries a page of tenants with every include may issue, whatever
+// its size.
+const listIncludeBudget = 5
+
+func TestTenantIncludes(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(root).SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(parent).SaveX(ctx)
+	client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	type included struct {
+		ID            string   `json:"id"`
+		Parent        *any     `json:"parent"`
+		ChildrenCount *int     `json:"childrenCount"`
+		Path          []string `json:"path"`
+	}
+
+	getTenant := func(id, query string) (included, map[string]any) {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants/"+id+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got included
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		var raw map[string]any
+
+		require.NoError(t, json.Unmarshal(body, &raw))
+
+		return got, raw
+	}
+
+	got, raw := getTenant(child.ID.String(), "?include=parent,children_count,path")
+	require.NotNil(t, got.Parent)
+	assert.Equal(t, parent.ID.String(), (*got.Parent).(map[string]any)["id"])
+	assert.Equal(t, "parent", (*got.Parent).(map[string]any)["name"])
+	assert.Equal(t, 1, *got.ChildrenCount)
+	assert.Equal(t, []string{root.ID.String(), parent.ID.String(), child.ID.String()}, got.Path)
+	assert.NotContains(t, raw["parent"], "parent")
+
+	// roots have a null parent, nothing is included unless requested
+	_, raw = getTenant(root.ID.String(), "?include=parent")
+	assert.Contains(t, raw, "parent")
+	assert.Nil(t, raw["parent"])
+
+	_, raw = getTenant(root.ID.String(), "")
+	assert.NotContains(t, raw, "parent")
+	assert.NotContains(t, raw, "childrenCount")
+	assert.NotContains(t, raw, "path")
+
+	// responses including related tenants aren't tagged, they change with them
+	resp, _ := get(t, url+"/v1/tenants/"+child.ID.String()+"?include=parent", nil)
+	assert.Empty(t, resp.Header.Get("ETag"))
+
+	resp, body := get(t, url+"/v1/tenants/"+child.ID.String()+"?include=parent,ancestors", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = get(t, url+"/v1/tenants?parent_id="+root.ID.String()+"&include=parent,ancestors", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+func TestTenantList(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+
+	for i := 0; i < 5; i++ {
+		client.Tenant.Create().SetName("child-" + strconv.Itoa(i)).SetParent(parent).SaveX(ctx)
+	}
+
+	type page struct {
+		Tenants []struct {
+			ID     string `json:"id"`
+			Parent *struct {
+				ID string `json:"id"`
+			} `json:"parent"`
+		} `json:"tenants"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+
+	var (
+		ids   []string
+		token string
+	)
+
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+
+		resp, body := get(t, url+"/v1/tenants?parent_id="+parent.ID.String()+"&limit=2&include=parent&page_token="+token, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var p page
+
+		require.NoError(t, json.Unmarshal(body, &p))
+
+		for _, tnt := range p.Tenants {
+			require.NotNil(t, tnt.Parent)
+			assert.Equal(t, parent.ID.String(), tnt.Parent.ID)
+
+			ids = append(ids, tnt.ID)
+		}
+
+		if token = p.NextPageToken; token == "" {
+			break
+		}
+	}
+
+	assert.Len(t, ids, 5)
+	assert.IsIncreasing(t, ids)
+
+	for _, query := range []string{"?parent_id=nope", "?parent_id=" + parent.ID.String() + "&limit=0", "?parent_id=" + parent.ID.String() + "&page_token=nope"} {
+		resp, body := get(t, url+"/v1/tenants"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query+": "+string(body))
+	}
+}
+
+func TestTenantListIncludeQueries(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(querybudget.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(ctx))
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{
+		querybudget.Middleware(querybudget.WithBudget(listIncludeBudget), querybudget.WithHeader(true)),
+		perms.Middleware(),
+	}).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(root).SaveX(ctx)
+
+	for i := 0; i < 20; i++ {
+		child := client.Tenant.Create().SetName("child-" + strconv.Itoa(i)).SetParent(parent).SaveX(ctx)
+		client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	}
+
+	queries := func(limit int) string {
+		t.Helper()
+
+		resp, body := get(t, srv.URL+"/v1/tenants?parent_id="+parent.ID.String()+"&include=parent,children_count,path&limit="+strconv.Itoa(limit), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page struct {
+			Tenants []struct {
+				ChildrenCount int      `json:"childrenCount"`
+				Path          []string `json:"path"`
+			} `json:"tenants"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page.Tenants, limit)
+
+		for _, tnt := range page.Tenants {
+			assert.Equal(t, 1, tnt.ChildrenCount)
+			assert.Len(t, tnt.Path, 3)
+		}
+
+		return resp.Header.Get(querybudget.HeaderQueryCount)
+	}
+
+	assert.Equal(t, queries(1), queries(20))
+}
+
+func TestTenantIncludeChildren(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(querybudget.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(ctx))
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{
+		querybudget.Middleware(querybudget.WithHeader(true)),
+		perms.Middleware(),
+	}, restapi.WithMaxIncludedChildren(3)).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	small := client.Tenant.Create().SetName("small").SetParent(root).SaveX(ctx)
+	large := client.Tenant.Create().SetName("large").SetParent(root).SaveX(ctx)
+
+	client.Tenant.Create().SetName("b").SetParent(small).SaveX(ctx)
+	client.Tenant.Create().SetName("a").SetParent(small).SaveX(ctx)
+
+	for _, name := range []string{"e", "c", "a", "d", "b"} {
+		client.Tenant.Create().SetName(name).SetParent(large).SaveX(ctx)
+	}
+
+	client.Tenant.Create().SetName("archived").SetArchived(true).SetParent(large).SaveX(ctx)
+
+	type children struct {
+		Tenants []struct {
+			Name string `json:"name"`
+		} `json:"tenants"`
+		TotalCount int    `json:"totalCount"`
+		HasMore    bool   `json:"hasMore"`
+		Next       string `json:"next"`
+	}
+
+	getChildren := func(id gidx.PrefixedID) (children, []string) {
+		t.Helper()
+
+		resp, body := get(t, srv.URL+"/v1/tenants/"+id.String()+"?include=children", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got struct {
+			Children *children `json:"children"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.NotNil(t, got.Children)
+
+		names := []string{}
+		for _, child := range got.Children.Tenants {
+			names = append(names, child.Name)
+		}
+
+		return *got.Children, names
+	}
+
+	queryCount := func(query string) int {
+		t.Helper()
+
+		resp, body := get(t, srv.URL+"/v1/tenants/"+large.ID.String()+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		count, err := strconv.Atoi(resp.Header.Get(querybudget.HeaderQueryCount))
+		require.NoError(t, err)
+
+		return count
+	}
+
+	t.Run("under the cap", func(t *testing.T) {
+		got, names := getChildren(small.ID)
+		assert.Equal(t, []string{"a", "b"}, names)
+		assert.Equal(t, 2, got.TotalCount)
+		assert.False(t, got.HasMore)
+		assert.Empty(t, got.Next)
+	})
+
+	t.Run("over the cap", func(t *testing.T) {
+		got, names := getChildren(large.ID)
+		assert.Equal(t, []string{"a", "b", "c"}, names)
+		assert.Equal(t, 5, got.TotalCount, "archived children aren't counted")
+		assert.True(t, got.HasMore)
+		require.NotEmpty(t, got.Next)
+
+		resp, body := get(t, srv.URL+got.Next, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page struct {
+			Tenants []struct {
+				Name string `json:"name"`
+			} `json:"tenants"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page.Tenants, 2, "the list continues after the included children")
+		assert.Equal(t, "d", page.Tenants[0].Name)
+		assert.Equal(t, "e", page.Tenants[1].Name)
+	})
+
+	t.Run("under a prefix", func(t *testing.T) {
+		prefixed := echo.New()
+		restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()}, restapi.WithMaxIncludedChildren(3)).Routes(prefixed.Group("/api"))
+
+		prefixedSrv := httptest.NewServer(prefixed)
+		t.Cleanup(prefixedSrv.Close)
+
+		resp, body := get(t, prefixedSrv.URL+"/api/v1/tenants/"+large.ID.String()+"?include=children", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got struct {
+			Children children `json:"children"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.True(t, strings.HasPrefix(got.Children.Next, "/api/v1/tenants?"), got.Children.Next)
+
+		resp, body = get(t, prefixedSrv.URL+got.Children.Next, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	})
+
+	t.Run("childless", func(t *testing.T) {
+		leaf := client.Tenant.Query().Where(enttenant.Name("a"), enttenant.ParentTenantID(small.ID)).OnlyX(ctx)
+
+		got, names := getChildren(leaf.ID)
+		assert.Empty(t, names)
+		assert.NotNil(t, got.Tenants, "an empty list is included")
+		assert.Zero(t, got.TotalCount)
+		assert.False(t, got.HasMore)
+	})
+
+	t.Run("one more query", func(t *testing.T) {
+		assert.Equal(t, queryCount("")+1, queryCount("?include=children"))
+	})
+
+	t.Run("not on lists", func(t *testing.T) {
+		resp, body := get(t, srv.URL+"/v1/tenants?parent_id="+root.ID.String()+"&include=children", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+	})
+}
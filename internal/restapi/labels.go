@@ -0,0 +1,138 @@
+package restapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/labels"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// effectiveLabelsResponse holds the labels of a tenant merged with those of its ancestors, and the
+// tenant each label is taken from.
+type effectiveLabelsResponse struct {
+	Labels     map[string]string          `json:"labels"`
+	Provenance map[string]gidx.PrefixedID `json:"provenance"`
+}
+
+// tenantLabelsGet returns the labels a tenant sets itself, an empty object when it sets none.
+func (h *Handler) tenantLabelsGet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.labelsAccess(c, actionTenantGet)
+	if err != nil {
+		return err
+	}
+
+	t, err := h.client.Tenant.Get(ctx, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if t.Labels == nil {
+		return c.JSON(http.StatusOK, map[string]string{})
+	}
+
+	return c.JSON(http.StatusOK, t.Labels)
+}
+
+// tenantLabelsPut replaces the labels a tenant sets itself, an empty object removes them. The
+// labels its ancestors set are inherited all the same.
+func (h *Handler) tenantLabelsPut(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.labelsAccess(c, actionTenantUpdate)
+	if err != nil {
+		return err
+	}
+
+	doc, err := decodeLabels(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.validator.ValidateUpdate(ctx, &validation.Tenant{Labels: doc}); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	update := h.client.Tenant.UpdateOneID(id)
+
+	if len(doc) == 0 {
+		update.ClearLabels()
+	} else {
+		update.SetLabels(doc)
+	}
+
+	if _, err := update.Save(ctx); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, doc)
+}
+
+// labelsAccess returns the id of the tenant whose labels are requested once the caller is allowed
+// the action on it and may see its labels.
+func (h *Handler) labelsAccess(c echo.Context, action string) (gidx.PrefixedID, error) {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return gidx.NullPrefixedID, err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, action); err != nil {
+		return gidx.NullPrefixedID, errmap.HTTPError(err)
+	}
+
+	if !redact.FromContext(ctx).Visible(redact.FieldLabels) {
+		return gidx.NullPrefixedID, echo.ErrForbidden
+	}
+
+	return id, nil
+}
+
+// decodeLabels reads the labels from the request body, which must be an object of strings.
+func decodeLabels(c echo.Context) (map[string]string, error) {
+	var doc map[string]string
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&doc); err != nil {
+		return nil, errmap.BadRequest(&validation.Error{
+			Field:   "labels",
+			Code:    validation.CodeMalformed,
+			Message: fmt.Sprintf("must be a json object of strings: %s", err),
+			Err:     err,
+		})
+	}
+
+	if doc == nil {
+		return nil, errmap.BadRequest(&validation.Error{Field: "labels", Code: validation.CodeMalformed, Message: "must be a json object of strings"})
+	}
+
+	return doc, nil
+}
+
+// tenantEffectiveLabels returns the labels of a tenant merged with those of its ancestors, the
+// nearest tenant setting a key winning, with the tenant each label is taken from.
+func (h *Handler) tenantEffectiveLabels(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.labelsAccess(c, actionTenantGet)
+	if err != nil {
+		return err
+	}
+
+	e, err := labels.Resolve(ctx, h.client, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, effectiveLabelsResponse{Labels: e.Labels, Provenance: e.Provenance})
+}
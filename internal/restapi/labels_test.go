@@ -0,0 +1,115 @@
+package restapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+func TestTenantEffectiveLabels(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	org := env.client.Tenant.Create().SetName("org").SaveX(env.ctx)
+	team := env.client.Tenant.Create().SetName("team").SetParent(org).SaveX(env.ctx)
+	project := env.client.Tenant.Create().SetName("project").SetParent(team).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SetParent(org).SaveX(env.ctx)
+
+	put := func(t *testing.T, id, body string) {
+		t.Helper()
+
+		status, resp := env.do(t, http.MethodPut, "/v1/tenants/"+id+"/labels", echo.MIMEApplicationJSON, body)
+		require.Equal(t, http.StatusOK, status, string(resp))
+		assert.JSONEq(t, body, string(resp))
+	}
+
+	put(t, org.ID.String(), `{"compliance":"pci","env":"prod","owner":"finance"}`)
+	put(t, team.ID.String(), `{"env":"staging","owner":"payments"}`)
+	put(t, project.ID.String(), `{"owner":"checkout"}`)
+
+	status, body := env.do(t, http.MethodGet, "/v1/tenants/"+project.ID.String()+"/labels", "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"owner":"checkout"}`, string(body), "only the labels the tenant sets itself")
+
+	status, body = env.do(t, http.MethodGet, "/v1/tenants/"+project.ID.String()+"/effective-labels", "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{
+		"labels":{"compliance":"pci","env":"staging","owner":"checkout"},
+		"provenance":{"compliance":"`+org.ID.String()+`","env":"`+team.ID.String()+`","owner":"`+project.ID.String()+`"}
+	}`, string(body))
+
+	// removing the labels of the team exposes those of the org
+	put(t, team.ID.String(), `{}`)
+
+	status, body = env.do(t, http.MethodGet, "/v1/tenants/"+project.ID.String()+"/effective-labels", "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{
+		"labels":{"compliance":"pci","env":"prod","owner":"checkout"},
+		"provenance":{"compliance":"`+org.ID.String()+`","env":"`+org.ID.String()+`","owner":"`+project.ID.String()+`"}
+	}`, string(body))
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"invalid key", http.MethodPut, "/v1/tenants/" + org.ID.String() + "/labels", `{"Bad Key":"x"}`, http.StatusBadRequest},
+		{"not strings", http.MethodPut, "/v1/tenants/" + org.ID.String() + "/labels", `{"tier":1}`, http.StatusBadRequest},
+		{"null", http.MethodPut, "/v1/tenants/" + org.ID.String() + "/labels", `null`, http.StatusBadRequest},
+		{"denied", http.MethodGet, "/v1/tenants/tnntten-denied/effective-labels", "", http.StatusForbidden},
+		{"not found", http.MethodGet, "/v1/tenants/tnntten-missing/effective-labels", "", http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.do(t, tt.method, tt.path, echo.MIMEApplicationJSON, tt.body)
+			assert.Equal(t, tt.status, status, string(body))
+		})
+	}
+}
+
+func TestTenantLabelsRedacted(t *testing.T) {
+	ctx := context.Background()
+
+	policy := redact.NewPolicy(map[string][]string{
+		"tenants:full":    {redact.AllFields},
+		"tenants:labels":  {redact.FieldName, redact.FieldLabels},
+		"tenants:summary": {redact.FieldName},
+	})
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware, policy.Middleware()})
+
+	tnt := client.Tenant.Create().SetName("acme").SetLabels(map[string]string{"env": "prod"}).SaveX(ctx)
+
+	for _, scope := range []string{"tenants:full", "tenants:labels"} {
+		t.Run(scope, func(t *testing.T) {
+			for _, path := range []string{"/labels", "/effective-labels"} {
+				resp, body := get(t, url+"/v1/tenants/"+tnt.ID.String()+path, map[string]string{"X-Scope": scope})
+				assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+				assert.Contains(t, string(body), "prod")
+			}
+		})
+	}
+
+	t.Run("tenants:summary", func(t *testing.T) {
+		summary := map[string]string{"X-Scope": "tenants:summary"}
+
+		for _, path := range []string{"/labels", "/effective-labels"} {
+			resp, body := get(t, url+"/v1/tenants/"+tnt.ID.String()+path, summary)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+			assert.NotContains(t, string(body), "prod")
+		}
+
+		resp, body := send(t, http.MethodPut, url+"/v1/tenants/"+tnt.ID.String()+"/labels", `{"env":"dev"}`, summary)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+		resp, body = send(t, http.MethodPost, url+"/v1/tenants:batchUpdate", `{"ids":["`+tnt.ID.String()+`"],"patch":{"labels":{"env":"dev"}}}`, summary)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+		assert.Equal(t, map[string]string{"env": "prod"}, client.Tenant.GetX(ctx, tnt.ID).Labels)
+	})
+}
@@ -0,0 +1,148 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/traversal"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+const (
+	// HeaderChildrenRemaining is the number of children which may still be added to the tenant.
+	HeaderChildrenRemaining = "X-Tenant-Children-Remaining"
+	// HeaderDepthRemaining is the number of levels which may still be added below the tenant.
+	HeaderDepthRemaining = "X-Tenant-Depth-Remaining"
+)
+
+// WithLimits sets the limits on the children of a tenant and the depth of the hierarchy the
+// validation enforces, zero being unlimited. Responses for a tenant tell how far it is from them,
+// so clients can warn before hitting them. Tenants with their own max children use it instead.
+func WithLimits(maxChildren, maxDepth int) Option {
+	return func(h *Handler) {
+		h.maxChildren = maxChildren
+		h.maxDepth = maxDepth
+	}
+}
+
+// limitCounts are the counts the remaining children and depth of a tenant are computed from,
+// those which weren't needed are negative.
+type limitCounts struct {
+	children   int
+	depth      int
+	computedAt time.Time
+}
+
+// setLimitHeaders sets the remaining children and depth of the tenant on the response. Headers
+// for unlimited ones are left out. The counts are served from the cache within the stats ttl,
+// the child count of recently computed statistics being used as well, so they may lag behind
+// by as much. They are advisory, the validation enforces the limits.
+func (h *Handler) setLimitHeaders(c echo.Context, t *ent.Tenant) error {
+	return h.limitHeaders(c.Request().Context(), t, c.Response().Header())
+}
+
+// limitHeaders sets the limit headers of the tenant on the header, see setLimitHeaders.
+func (h *Handler) limitHeaders(ctx context.Context, t *ent.Tenant, header http.Header) error {
+	maxChildren := h.maxChildren
+	if t.MaxChildren > 0 {
+		maxChildren = t.MaxChildren
+	}
+
+	if maxChildren == 0 && h.maxDepth == 0 {
+		return nil
+	}
+
+	counts, err := h.limitCounts(ctx, t, maxChildren > 0, h.maxDepth > 0)
+	if err != nil {
+		return err
+	}
+
+	if maxChildren > 0 {
+		header.Set(HeaderChildrenRemaining, strconv.Itoa(remaining(maxChildren, counts.children)))
+	}
+
+	if h.maxDepth > 0 {
+		header.Set(HeaderDepthRemaining, strconv.Itoa(remaining(h.maxDepth, counts.depth)))
+	}
+
+	return nil
+}
+
+func remaining(limit, count int) int {
+	if count > limit {
+		return 0
+	}
+
+	return limit - count
+}
+
+// limitCounts returns the counts of the tenant, computing those asked for which aren't cached.
+func (h *Handler) limitCounts(ctx context.Context, t *ent.Tenant, children, depth bool) (limitCounts, error) {
+	now := h.clock.Now().UTC()
+
+	counts, ok := h.stats.getLimits(t.ID, now)
+	if !ok {
+		counts = limitCounts{children: -1, depth: -1, computedAt: now}
+	}
+
+	computed := false
+
+	if children && counts.children < 0 {
+		if stats, ok := h.stats.get(t.ID, now); ok {
+			counts.children = int(stats.ChildCount)
+		} else {
+			n, err := h.client.Tenant.Query().Where(enttenant.ParentTenantID(t.ID)).Count(ctx)
+			if err != nil {
+				return limitCounts{}, err
+			}
+
+			counts.children = n
+		}
+
+		computed = true
+	}
+
+	if depth && counts.depth < 0 {
+		start := time.Now()
+
+		d, err := validation.Depth(ctx, h.client, t.ID)
+		if err != nil {
+			return limitCounts{}, err
+		}
+
+		h.traversals.Observe(ctx, traversal.OpAncestors, d, d, time.Since(start))
+
+		counts.depth = d
+		computed = true
+	}
+
+	if computed {
+		h.stats.putLimits(t.ID, counts)
+	}
+
+	return counts, nil
+}
+
+// setParentLimitHeaders sets the limit headers of the parent of a tenant just created, with the
+// counts of the parent computed again to include it.
+func (h *Handler) setParentLimitHeaders(c echo.Context, parentID gidx.PrefixedID) error {
+	if parentID == gidx.NullPrefixedID {
+		return nil
+	}
+
+	h.stats.forget(parentID)
+
+	parent, err := h.client.Tenant.Get(c.Request().Context(), parentID)
+	if err != nil {
+		return err
+	}
+
+	return h.setLimitHeaders(c, parent)
+}
@@ -0,0 +1,88 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestTenantLimitHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t, restapi.WithLimits(3, 3))
+
+	client.Tenant.Use(validation.NewChildrenLimit(3).Hook())
+	client.Tenant.Use(validation.NewDepthLimit(3).Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	headers := func(resp *http.Response) [2]string {
+		return [2]string{resp.Header.Get(restapi.HeaderChildrenRemaining), resp.Header.Get(restapi.HeaderDepthRemaining)}
+	}
+
+	create := func(name string, parentID gidx.PrefixedID) (*http.Response, gidx.PrefixedID) {
+		resp, body := send(t, http.MethodPost, url+"/v1/tenants", `{"name":"`+name+`","parentID":"`+parentID.String()+`"}`, nil)
+		require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+		var created struct {
+			ID gidx.PrefixedID `json:"id"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &created))
+
+		return resp, created.ID
+	}
+
+	resp, body := get(t, url+"/v1/tenants/"+root.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, [2]string{"3", "2"}, headers(resp))
+
+	// creates tell how many more children the parent may have, counting the one created
+	var child gidx.PrefixedID
+
+	for i, expected := range []string{"2", "1", "0"} {
+		resp, id := create("child", root.ID)
+		assert.Equal(t, [2]string{expected, "2"}, headers(resp), "child %d", i)
+
+		child = id
+	}
+
+	resp, body = send(t, http.MethodPost, url+"/v1/tenants", `{"name":"one too many","parentID":"`+root.ID.String()+`"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode, string(body))
+
+	// the parent is counted again after a create, the cached counts don't hide the new children
+	resp, body = get(t, url+"/v1/tenants/"+root.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, [2]string{"0", "2"}, headers(resp))
+
+	resp, grandchild := create("grandchild", child)
+	assert.Equal(t, [2]string{"2", "1"}, headers(resp))
+
+	resp, body = get(t, url+"/v1/tenants/"+grandchild.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, [2]string{"3", "0"}, headers(resp))
+
+	// tenants with their own max children count toward it
+	client.Tenant.UpdateOneID(child).SetMaxChildren(5).ExecX(ctx)
+
+	resp, body = get(t, url+"/v1/tenants/"+child.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, "4", resp.Header.Get(restapi.HeaderChildrenRemaining))
+
+	t.Run("without limits there are no headers", func(t *testing.T) {
+		client, url := newTestServer(t)
+		other := client.Tenant.Create().SetName("other").SaveX(ctx)
+
+		resp, body := get(t, url+"/v1/tenants/"+other.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		assert.Equal(t, [2]string{"", ""}, headers(resp))
+	})
+}
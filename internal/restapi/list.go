@@ -0,0 +1,453 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/export"
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+// Page sizes of the tenant list.
+const (
+	defaultListPageSize = 50
+	maxListPageSize     = 100
+)
+
+// csvBatchSize is the number of tenants loaded per query of a tenant list served as csv.
+const csvBatchSize = 500
+
+// Fields the name_contains filter of the tenant list applies to.
+const (
+	nameFieldName        = "name"
+	nameFieldDisplayName = "display_name"
+)
+
+// WithExport serves the tenant list as csv when it is requested with an Accept: text/csv header or
+// ?format=csv, through the export handler, so the list takes its concurrency slots and row cap.
+func WithExport(x *export.Handler) Option {
+	return func(h *Handler) {
+		h.export = x
+	}
+}
+
+type tenantListResponse struct {
+	Tenants       []tenant `json:"tenants"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
+}
+
+// tenantList lists the children of the tenant given by the parent_id query parameter, or every
+// tenant the caller may get without one. Tenants are ordered by ID, or by name with
+// order_by=name, names being compared by their bytes unless the collation query parameter gives
+// the language they are sorted for, see collationList. The name_contains query parameter keeps the
+// tenants whose name contains it, or whose display name does with name_field=display_name, and
+// billing_reference the tenants with exactly that billing reference; the query guard decides which
+// combinations may be served. Archived tenants are left out unless include_archived=true is given.
+// Pages are requested with the limit and page_token query parameters, the token being the
+// nextPageToken of the previous page, or its nextCursor in FormatV11. The first page may skip
+// tenants with the offset query parameter, up to the maximum offset. Related tenants requested
+// with the include query parameter are loaded for the whole page at once.
+//
+// Children are listed as csv when it is requested and the handler was created WithExport, every
+// tenant matched rather than a page, up to the row cap of the exports. With watch=true the
+// children changed after the since query parameter are returned instead, see tenantListWatch.
+func (h *Handler) tenantList(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	parentID := gidx.NullPrefixedID
+
+	if raw := c.QueryParam("parent_id"); raw != "" {
+		var err error
+
+		parentID, err = gidx.Parse(raw)
+		if err != nil || parentID.Prefix() != schema.TenantPrefix {
+			return echo.NewHTTPError(http.StatusBadRequest, "parent_id must be a tenant id")
+		}
+	}
+
+	watch := false
+
+	if raw := c.QueryParam("watch"); raw != "" {
+		var err error
+
+		if watch, err = strconv.ParseBool(raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid watch %q", raw)).WithInternal(err)
+		}
+	}
+
+	if watch {
+		if parentID == gidx.NullPrefixedID {
+			return echo.NewHTTPError(http.StatusBadRequest, "watching for changes requires parent_id")
+		}
+
+		if err := permissions.CheckAccess(ctx, parentID, actionTenantGet); err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		return h.tenantListWatch(c, parentID)
+	}
+
+	csv := h.export != nil && export.WantsCSV(c.Request())
+
+	if csv && parentID == gidx.NullPrefixedID {
+		return echo.NewHTTPError(http.StatusBadRequest, "tenants are listed as csv with parent_id")
+	}
+
+	order, err := parseListOrder(c, parentID != gidx.NullPrefixedID)
+	if err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	page, err := parsePagination(c, h.maxPageSize(), h.maxListOffset, order)
+	if err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	limit := page.limit
+
+	nameContains := c.QueryParam("name_contains")
+	billingReference := c.QueryParam("billing_reference")
+
+	nameFilter, err := parseNameFilter(c.QueryParam("name_field"), nameContains)
+	if err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := h.queryGuard.Check(RouteTenantList, listQuery(parentID, nameContains, billingReference, limit, order)); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	inc, err := parseIncludes(c)
+	if err != nil {
+		return err
+	}
+
+	if inc[includeChildrenName] {
+		return echo.NewHTTPError(http.StatusBadRequest, "children are only included with a single tenant, list them with parent_id")
+	}
+
+	withArchived := false
+
+	if raw := c.QueryParam("include_archived"); raw != "" {
+		if withArchived, err = strconv.ParseBool(raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid include_archived %q", raw)).WithInternal(err)
+		}
+	}
+
+	query := h.client.Tenant.Query()
+
+	if parentID != gidx.NullPrefixedID {
+		if err := permissions.CheckAccess(ctx, parentID, actionTenantGet); err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		query = query.Where(enttenant.ParentTenantID(parentID))
+	}
+
+	if nameFilter != nil {
+		query = query.Where(nameFilter)
+	}
+
+	if billingReference != "" {
+		query = query.Where(enttenant.BillingReference(billingReference))
+	}
+
+	if !withArchived {
+		query = query.Where(enttenant.Or(enttenant.ArchivedIsNil(), enttenant.Archived(false)))
+	}
+
+	if csv {
+		return h.export.Respond(c, parentID.String()+".csv",
+			func(ctx context.Context, limit int) (int, error) {
+				n, err := query.Clone().Count(ctx)
+				if n > limit {
+					n = limit
+				}
+
+				return n, err
+			},
+			func(ctx context.Context, limit int, fn func([]*ent.Tenant) error) error {
+				return listAll(ctx, h.client, query, order, limit, fn)
+			},
+		)
+	}
+
+	tenants, err := listTenants(ctx, h.client, query, order, page.after, page.offset, limit)
+	if err != nil {
+		return err
+	}
+
+	resp := tenantListResponse{Tenants: make([]tenant, 0, len(tenants))}
+
+	tenants, more := pagination.Trim(tenants, limit)
+	if more {
+		resp.NextPageToken = order.cursor(tenants[limit-1]).Encode()
+	}
+
+	if parentID == gidx.NullPrefixedID {
+		// the page may come back short, the token still follows the last tenant read
+		if tenants, err = accessible(ctx, tenants); err != nil {
+			return err
+		}
+	}
+
+	var x *expansion
+
+	if inc.related() {
+		if x, err = h.expand(ctx, tenants, inc); err != nil {
+			return err
+		}
+	}
+
+	fields := redact.FromContext(ctx)
+
+	for _, t := range tenants {
+		item := newTenant(t, fields)
+
+		if inc[includeSettingsName] && fields.Visible(redact.FieldSettings) {
+			item.Settings = settingsDocument(t)
+		}
+
+		if x != nil {
+			x.apply(&item, t)
+		}
+
+		resp.Tenants = append(resp.Tenants, item)
+	}
+
+	return respondList(c, resp, resp.Tenants, resp.NextPageToken)
+}
+
+// listTenants returns the tenants of the query following the cursor, or the offset, in the order, at
+// most limit+1 so the caller can tell whether more follow.
+func listTenants(ctx context.Context, client *ent.Client, query *ent.TenantQuery, order listOrder, after *pagination.Cursor, offset, limit int) ([]*ent.Tenant, error) {
+	if order.collation != nil {
+		return order.collatedPage(ctx, client, query, after, offset, limit)
+	}
+
+	query, err := order.apply(query, after)
+	if err != nil {
+		return nil, errmap.BadRequest(invalidCursor(err))
+	}
+
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	return query.Limit(limit + 1).All(ctx)
+}
+
+// listAll passes every tenant of the query to fn in the order, a batch at a time, at most limit of
+// them. Each batch follows the cursor of the last tenant of the one before.
+func listAll(ctx context.Context, client *ent.Client, query *ent.TenantQuery, order listOrder, limit int, fn func([]*ent.Tenant) error) error {
+	var after *pagination.Cursor
+
+	for listed := 0; listed < limit; {
+		size := csvBatchSize
+		if size > limit-listed {
+			size = limit - listed
+		}
+
+		tenants, err := listTenants(ctx, client, query.Clone(), order, after, 0, size)
+		if err != nil {
+			return err
+		}
+
+		tenants, more := pagination.Trim(tenants, size)
+		if len(tenants) == 0 {
+			return nil
+		}
+
+		if err := fn(tenants); err != nil {
+			return err
+		}
+
+		if !more {
+			return nil
+		}
+
+		listed += len(tenants)
+		last := order.cursor(tenants[len(tenants)-1])
+		after = &last
+	}
+
+	return nil
+}
+
+// parseNameFilter returns the predicate of the name_contains filter on the field given by the
+// name_field query parameter, the name by default. It is nil without a filter.
+func parseNameFilter(field, contains string) (predicate.Tenant, error) {
+	var filter func(string) predicate.Tenant
+
+	switch field {
+	case "", nameFieldName:
+		filter = enttenant.NameContains
+	case nameFieldDisplayName:
+		filter = enttenant.DisplayNameContains
+	default:
+		return nil, &validation.Error{
+			Field:   "name_field",
+			Code:    validation.CodeInvalidValue,
+			Message: fmt.Sprintf("must be %s or %s", nameFieldName, nameFieldDisplayName),
+		}
+	}
+
+	if contains == "" {
+		return nil, nil
+	}
+
+	return filter(contains), nil
+}
+
+// listQuery describes a tenant list to the query guard. Name filters are reported as name_contains
+// whichever field they apply to, neither is indexed.
+func listQuery(parentID gidx.PrefixedID, nameContains, billingReference string, limit int, order listOrder) querycost.Query {
+	q := querycost.Query{Sort: order.sortField(), Scoped: parentID != gidx.NullPrefixedID, Limit: limit}
+
+	if nameContains != "" {
+		q.Filters = append(q.Filters, "name_contains")
+	}
+
+	if billingReference != "" {
+		q.Filters = append(q.Filters, "billing_reference")
+	}
+
+	return q
+}
+
+// accessible returns the tenants the caller may get.
+func accessible(ctx context.Context, tenants []*ent.Tenant) ([]*ent.Tenant, error) {
+	allowed := tenants[:0]
+
+	for _, t := range tenants {
+		err := permissions.CheckAccess(ctx, t.ID, actionTenantGet)
+
+		switch {
+		case err == nil:
+			allowed = append(allowed, t)
+		case errors.Is(err, permissions.ErrPermissionDenied):
+		default:
+			return nil, err
+		}
+	}
+
+	return allowed, nil
+}
+
+// maxPageSize returns the largest page of the tenant list, from the live settings when configured.
+func (h *Handler) maxPageSize() int {
+	if h.live != nil {
+		return h.live.Load().MaxPageSize
+	}
+
+	return maxListPageSize
+}
+
+// listPage is the page of the tenant list requested with the pagination query parameters.
+type listPage struct {
+	limit  int
+	offset int
+	after  *pagination.Cursor
+}
+
+// parsePagination parses the limit, offset and page_token query parameters of the tenant list,
+// the token being the cursor of the last tenant of the previous page in the order. Tokens which are
+// tenant IDs, as returned before cursors were used, are still accepted when sorting by ID. Pages
+// default to the smaller of the default page size and maxSize. Offsets only apply to the first
+// page, up to maxOffset. Every invalid parameter is reported at once.
+func parsePagination(c echo.Context, maxSize, maxOffset int, order listOrder) (listPage, error) {
+	var (
+		page listPage
+		errs validation.Errors
+		err  error
+	)
+
+	page.limit, err = pagination.Limits{Default: defaultListPageSize, Max: maxSize}.Parse(c.QueryParam("limit"))
+	if err != nil {
+		errs.Add("limit", validation.CodeInvalidValue, fmt.Sprintf("must be between 1 and %d", maxSize))
+	}
+
+	token := c.QueryParam("page_token")
+
+	if raw := c.QueryParam("offset"); raw != "" {
+		n, err := strconv.Atoi(raw)
+
+		// offsets too large for an int are too large to skip as well
+		tooLarge := (err == nil && n > maxOffset) || (errors.Is(err, strconv.ErrRange) && !strings.HasPrefix(raw, "-"))
+
+		switch {
+		case tooLarge:
+			errs = append(errs, &validation.Error{
+				Field:   "offset",
+				Code:    validation.CodeOffsetTooLarge,
+				Message: fmt.Sprintf("at most %d tenants are skipped, follow nextPageToken to list further", maxOffset),
+				Limit:   maxOffset,
+			})
+		case err != nil || n < 0:
+			errs.Add("offset", validation.CodeInvalidValue, "must be a number of tenants to skip, at least 0")
+		case token != "" && n != 0:
+			errs.Add("offset", validation.CodeInvalidValue, "only applies to the first page, it can't be given with page_token")
+		default:
+			page.offset = n
+		}
+	}
+
+	if token != "" {
+		page.after, err = parsePageToken(token, order)
+		if err != nil {
+			errs = append(errs, invalidCursor(err))
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return listPage{}, err
+	}
+
+	return page, nil
+}
+
+// parsePageToken parses the page token of the tenant list in the order.
+func parsePageToken(token string, order listOrder) (*pagination.Cursor, error) {
+	if id, err := gidx.Parse(token); err == nil && id.Prefix() == schema.TenantPrefix && !order.byName {
+		return &pagination.Cursor{Keys: []string{id.String()}}, nil
+	}
+
+	cursor, err := pagination.Decode(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.validCursor(cursor) {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// invalidCursor returns the validation error of a page token which isn't one returned by the list,
+// because it was altered, truncated or returned for another order.
+func invalidCursor(err error) *validation.Error {
+	return &validation.Error{
+		Field:   "page_token",
+		Code:    validation.CodeInvalidCursor,
+		Message: "is not a token returned by this list, start again from the first page",
+		Err:     err,
+	}
+}
@@ -0,0 +1,373 @@
+package restapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/export"
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+func TestTenantListQueryGuard(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t, restapi.WithQueryGuard(querycost.New(querycost.WithRules(querycost.DefaultRules(2)...))))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("alpha").SetParent(root).SaveX(ctx)
+	client.Tenant.Create().SetName("beta").SetParent(root).SaveX(ctx)
+
+	for _, tt := range []struct {
+		name   string
+		query  string
+		status int
+		names  []string
+	}{
+		{"scoped", "?parent_id=" + root.ID.String(), http.StatusOK, []string{"alpha", "beta"}},
+		{"scoped name filter", "?parent_id=" + root.ID.String() + "&name_contains=lph", http.StatusOK, []string{"alpha"}},
+		{"unscoped small page", "?limit=2", http.StatusOK, nil},
+		{"unscoped large page", "?limit=3", http.StatusUnprocessableEntity, nil},
+		{"unscoped name filter", "?name_contains=lph&limit=2", http.StatusUnprocessableEntity, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, url+"/v1/tenants"+tt.query, nil)
+			require.Equal(t, tt.status, resp.StatusCode, string(body))
+
+			if tt.status != http.StatusOK {
+				assert.Contains(t, string(body), "unindexed_query")
+
+				return
+			}
+
+			var list struct {
+				Tenants []struct {
+					Name string `json:"name"`
+				} `json:"tenants"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &list))
+
+			if tt.names == nil {
+				assert.Len(t, list.Tenants, 2)
+
+				return
+			}
+
+			var names []string
+
+			for _, t := range list.Tenants {
+				names = append(names, t.Name)
+			}
+
+			assert.ElementsMatch(t, tt.names, names)
+		})
+	}
+}
+
+func TestTenantListWithoutGuard(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("alpha").SetParent(root).SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants?name_contains=lph", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"alpha"`)
+}
+
+func TestTenantListPageTokens(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	first := client.Tenant.Create().SetName("alpha").SetParent(root).SaveX(ctx)
+	second := client.Tenant.Create().SetName("beta").SetParent(root).SaveX(ctx)
+
+	if second.ID < first.ID {
+		first, second = second, first
+	}
+
+	list := func(t *testing.T, token string) (int, []string, string) {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants?limit=1&parent_id="+root.ID.String()+"&page_token="+token, nil)
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil, ""
+		}
+
+		var page struct {
+			Tenants []struct {
+				ID string `json:"id"`
+			} `json:"tenants"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+
+		ids := make([]string, len(page.Tenants))
+
+		for i, tenant := range page.Tenants {
+			ids[i] = tenant.ID
+		}
+
+		return resp.StatusCode, ids, page.NextPageToken
+	}
+
+	_, ids, token := list(t, "")
+	assert.Equal(t, []string{first.ID.String()}, ids)
+
+	cursor, err := pagination.Decode(token)
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Cursor{Keys: []string{first.ID.String()}}, cursor)
+
+	_, ids, token = list(t, token)
+	assert.Equal(t, []string{second.ID.String()}, ids)
+	assert.Empty(t, token)
+
+	t.Run("legacy token", func(t *testing.T) {
+		_, ids, _ := list(t, first.ID.String())
+		assert.Equal(t, []string{second.ID.String()}, ids)
+	})
+
+	for name, token := range map[string]string{
+		"malformed":      "not-a-cursor",
+		"descending":     pagination.Cursor{Keys: []string{first.ID.String()}, Direction: pagination.Descending}.Encode(),
+		"two keys":       pagination.Cursor{Keys: []string{"alpha", first.ID.String()}}.Encode(),
+		"not a tenant":   pagination.Cursor{Keys: []string{"tnntphs-history"}}.Encode(),
+		"not an id":      pagination.Cursor{Keys: []string{"' OR 1=1"}}.Encode(),
+		"legacy history": "tnntphs-history",
+	} {
+		t.Run(name, func(t *testing.T) {
+			status, _, _ := list(t, token)
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestTenantListPaginationErrors(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t, restapi.WithMaxListOffset(2))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	var ids []string
+
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		ids = append(ids, client.Tenant.Create().SetName(name).SetParent(root).SaveX(ctx).ID.String())
+	}
+
+	sort.Strings(ids)
+
+	token := pagination.Cursor{Keys: []string{ids[0]}}.Encode()
+	tampered := token[:len(token)-1] + string(token[len(token)-1]^1)
+
+	for _, tt := range []struct {
+		name  string
+		query string
+		codes map[string]string
+	}{
+		{"zero limit", "limit=0", map[string]string{"limit": "invalid_value"}},
+		{"negative limit", "limit=-5", map[string]string{"limit": "invalid_value"}},
+		{"negative offset", "offset=-1", map[string]string{"offset": "invalid_value"}},
+		{"offset beyond the maximum", "offset=3", map[string]string{"offset": "offset_too_large"}},
+		{"enormous offset", "offset=99999999999999999999999", map[string]string{"offset": "offset_too_large"}},
+		{"offset with a token", "offset=1&page_token=" + token, map[string]string{"offset": "invalid_value"}},
+		{"garbage token", "page_token=Z2FyYmFnZQ", map[string]string{"page_token": "invalid_cursor"}},
+		{"tampered token", "page_token=" + tampered, map[string]string{"page_token": "invalid_cursor"}},
+		{"token of another order", "order_by=name&page_token=" + token, map[string]string{"page_token": "invalid_cursor"}},
+		{"every parameter", "limit=-5&offset=-1&page_token=garbage", map[string]string{"limit": "invalid_value", "offset": "invalid_value", "page_token": "invalid_cursor"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, url+"/v1/tenants?parent_id="+root.ID.String()+"&"+tt.query, nil)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+			var errBody struct {
+				Details []struct {
+					Field string `json:"field"`
+					Code  string `json:"code"`
+				} `json:"details"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &errBody))
+
+			codes := map[string]string{}
+
+			for _, d := range errBody.Details {
+				codes[d.Field] = d.Code
+			}
+
+			assert.Equal(t, tt.codes, codes)
+		})
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants?limit=1&offset=2&parent_id="+root.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page struct {
+			Tenants []struct {
+				ID string `json:"id"`
+			} `json:"tenants"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page.Tenants, 1)
+		assert.Equal(t, ids[2], page.Tenants[0].ID)
+		assert.Empty(t, page.NextPageToken)
+	})
+}
+
+func TestTenantListFilters(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.Create().SetName("alpha").SetDisplayName("Storefront").SetBillingReference("acct-1").SetParent(root).SaveX(ctx)
+	client.Tenant.Create().SetName("beta").SetDisplayName("Alpha Labs").SetBillingReference("acct-10").SetParent(root).SaveX(ctx)
+
+	for _, tt := range []struct {
+		name   string
+		query  neturl.Values
+		status int
+		names  []string
+	}{
+		{"billing reference", neturl.Values{"billing_reference": {"acct-1"}}, http.StatusOK, []string{"alpha"}},
+		{"unknown billing reference", neturl.Values{"billing_reference": {"acct"}}, http.StatusOK, []string{}},
+		{"name field", neturl.Values{"name_contains": {"lph"}, "name_field": {"name"}}, http.StatusOK, []string{"alpha"}},
+		{"display name field", neturl.Values{"name_contains": {"lph"}, "name_field": {"display_name"}}, http.StatusOK, []string{"beta"}},
+		{"unknown name field", neturl.Values{"name_contains": {"lph"}, "name_field": {"description"}}, http.StatusBadRequest, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Set("parent_id", root.ID.String())
+
+			resp, body := get(t, url+"/v1/tenants?"+tt.query.Encode(), nil)
+			require.Equal(t, tt.status, resp.StatusCode, string(body))
+
+			if tt.status != http.StatusOK {
+				assert.Contains(t, string(body), "name_field")
+
+				return
+			}
+
+			var list struct {
+				Tenants []struct {
+					Name string `json:"name"`
+				} `json:"tenants"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &list))
+
+			names := []string{}
+
+			for _, t := range list.Tenants {
+				names = append(names, t.Name)
+			}
+
+			assert.ElementsMatch(t, tt.names, names)
+		})
+	}
+}
+
+func TestTenantListCSV(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	url, _ := startServer(t, client, restapi.WithExport(export.NewHandler(client, zap.NewNop().Sugar(), nil, export.WithRowCap(3))))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	for _, name := range []string{"delta", "alpha", "charlie", "bravo", "echo"} {
+		client.Tenant.Create().SetName(name).SetBillingReference("acct-" + name).SetParent(root).SaveX(ctx)
+	}
+
+	client.Tenant.Create().SetName("archived").SetArchived(true).SetParent(root).SaveX(ctx)
+
+	names := func(t *testing.T, body []byte) []string {
+		t.Helper()
+
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+		require.Equal(t, "name", records[0][1])
+
+		var names []string
+
+		for _, record := range records[1:] {
+			names = append(names, record[1])
+		}
+
+		return names
+	}
+
+	t.Run("every tenant up to the row cap", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants?order_by=name&limit=1&parent_id="+root.ID.String(), map[string]string{"Accept": "text/csv"})
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+		assert.Equal(t, "true", resp.Header.Get(export.TruncatedHeader))
+		assert.Equal(t, []string{"alpha", "bravo", "charlie"}, names(t, body))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants?format=csv&billing_reference=acct-echo&parent_id="+root.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		assert.Equal(t, "1", resp.Header.Get(export.TotalCountHeader))
+		assert.Equal(t, []string{"echo"}, names(t, body))
+	})
+
+	t.Run("without parent", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants?format=csv", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+	})
+}
+
+func FuzzTenantListPagination(f *testing.F) {
+	client, url := newTestServer(f, restapi.WithMaxListOffset(10))
+
+	root := client.Tenant.Create().SetName("root").SaveX(context.Background())
+	client.Tenant.Create().SetName("child").SetParent(root).SaveX(context.Background())
+
+	f.Add("10", "0", "", "")
+	f.Add("-5", "0", "garbage-base64", "name")
+	f.Add("0", "99999999999999999999", "", "id")
+	f.Add("1", "-1", pagination.Cursor{Keys: []string{"tnntten-abc"}}.Encode(), "")
+	f.Add("", "3", pagination.Cursor{Keys: []string{"b", "tnntten-abc", ""}}.Encode(), "name")
+
+	f.Fuzz(func(t *testing.T, limit, offset, token, orderBy string) {
+		query := neturl.Values{
+			"parent_id":  {root.ID.String()},
+			"limit":      {limit},
+			"offset":     {offset},
+			"page_token": {token},
+			"order_by":   {orderBy},
+		}
+
+		resp, body := get(t, url+"/v1/tenants?"+query.Encode(), nil)
+
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode < http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", resp.StatusCode, body)
+		}
+	})
+}
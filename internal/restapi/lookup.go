@@ -0,0 +1,129 @@
+package restapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// maxLookupURNs is the maximum number of URNs a lookup may list.
+const maxLookupURNs = 100
+
+type lookupByURNRequest struct {
+	URNs []string `json:"urns"`
+}
+
+type lookupByURNResponse struct {
+	Tenants map[string]tenant `json:"tenants"`
+	Invalid []invalidURN      `json:"invalid"`
+	Missing []string          `json:"missing"`
+}
+
+type invalidURN struct {
+	URN     string `json:"urn"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// tenantLookupByURN resolves up to maxLookupURNs tenant URNs at once, for event consumers
+// hydrating the URNs of their messages. The tenants found are keyed by the URN as listed, URNs
+// which don't parse as tenant URNs are invalid, and those of tenants which don't exist or which the
+// caller may not get are missing, so a lookup doesn't tell apart tenants outside of the caller's
+// reach. The tenants are read with a single query.
+func (h *Handler) tenantLookupByURN(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req lookupByURNRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	switch {
+	case len(req.URNs) == 0:
+		return errmap.BadRequest(&validation.Error{Field: "urns", Code: validation.CodeRequired, Message: "at least one urn is required"})
+	case len(req.URNs) > maxLookupURNs:
+		return errmap.BadRequest(&validation.Error{Field: "urns", Code: validation.CodeTooMany, Message: fmt.Sprintf("at most %d urns may be listed", maxLookupURNs)})
+	}
+
+	resp := lookupByURNResponse{
+		Tenants: make(map[string]tenant),
+		Invalid: []invalidURN{},
+		Missing: []string{},
+	}
+
+	ids := make(map[string]gidx.PrefixedID, len(req.URNs))
+	unique := make([]gidx.PrefixedID, 0, len(req.URNs))
+	seen := make(map[gidx.PrefixedID]bool, len(req.URNs))
+
+	for _, urn := range req.URNs {
+		if _, ok := ids[urn]; ok {
+			continue
+		}
+
+		id, code, err := parseURN(urn)
+		if err != nil {
+			resp.Invalid = append(resp.Invalid, invalidURN{URN: urn, Code: code, Message: err.Error()})
+			ids[urn] = gidx.NullPrefixedID
+
+			continue
+		}
+
+		ids[urn] = id
+
+		if !seen[id] {
+			seen[id] = true
+
+			unique = append(unique, id)
+		}
+	}
+
+	tenants, err := h.client.Tenant.Query().Where(enttenant.IDIn(unique...)).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	fields := redact.FromContext(ctx)
+	found := make(map[gidx.PrefixedID]tenant, len(tenants))
+
+	for _, t := range tenants {
+		err := permissions.CheckAccess(ctx, t.ID, actionTenantGet)
+
+		switch {
+		case err == nil:
+			found[t.ID] = newTenant(t, fields)
+		case errors.Is(err, permissions.ErrPermissionDenied):
+		default:
+			return errmap.HTTPError(err)
+		}
+	}
+
+	listed := make(map[string]bool, len(req.URNs))
+
+	for _, urn := range req.URNs {
+		id := ids[urn]
+		if id == gidx.NullPrefixedID || listed[urn] {
+			continue
+		}
+
+		listed[urn] = true
+
+		if t, ok := found[id]; ok {
+			resp.Tenants[urn] = t
+		} else {
+			resp.Missing = append(resp.Missing, urn)
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
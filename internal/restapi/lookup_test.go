@@ -0,0 +1,85 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+func TestTenantLookupByURN(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	rootURN := urnx.NewTenantURN(root.ID)
+	childURN := urnx.NewTenantURN(child.ID)
+	deniedURN := urnx.NewTenantURN("tnntten-denied")
+	absentURN := urnx.NewTenantURN("tnntten-absent")
+
+	urns := []string{
+		rootURN,
+		"not-a-urn",
+		childURN,
+		"urn:infratographer:load-balancer:loadbal-abc123",
+		deniedURN,
+		absentURN,
+		rootURN,
+	}
+
+	body, err := json.Marshal(map[string][]string{"urns": urns})
+	require.NoError(t, err)
+
+	status, resp := env.post(t, "/v1/tenants/lookup-by-urn", string(body))
+	require.Equal(t, http.StatusOK, status, string(resp))
+
+	var result struct {
+		Tenants map[string]struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"tenants"`
+		Invalid []struct {
+			URN  string `json:"urn"`
+			Code string `json:"code"`
+		} `json:"invalid"`
+		Missing []string `json:"missing"`
+	}
+
+	require.NoError(t, json.Unmarshal(resp, &result))
+
+	require.Len(t, result.Tenants, 2)
+	assert.Equal(t, "root", result.Tenants[rootURN].Name)
+	assert.Equal(t, child.ID.String(), result.Tenants[childURN].ID)
+
+	require.Len(t, result.Invalid, 2)
+	assert.Equal(t, "not-a-urn", result.Invalid[0].URN)
+	assert.Equal(t, "invalid_urn", result.Invalid[0].Code)
+	assert.Equal(t, "unexpected_resource_type", result.Invalid[1].Code)
+
+	// tenants the caller may not get are reported the same as those which don't exist
+	assert.Equal(t, []string{deniedURN, absentURN}, result.Missing)
+	assert.NotContains(t, string(resp), `"denied"`)
+}
+
+func TestTenantLookupByURNLimits(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%q", urnx.NewTenantURN("tnntten-absent"))
+	}
+
+	for _, body := range []string{`{}`, `{"urns":[]}`, `{"urns":[` + strings.Join(tooMany, ",") + `]}`, `{"urns":"nope"}`} {
+		status, resp := env.post(t, "/v1/tenants/lookup-by-urn", body)
+		assert.NotEqual(t, http.StatusOK, status, string(resp))
+		assert.Less(t, status, http.StatusInternalServerError, string(resp))
+	}
+}
@@ -0,0 +1,183 @@
+package restapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// mergedIntoKey is the additional data key referencing the target on the events of a merge.
+const mergedIntoKey = "merged_into"
+
+type mergeRequest struct {
+	SourceID gidx.PrefixedID `json:"sourceID"`
+}
+
+type mergeResponse struct {
+	Tenant        tenant            `json:"tenant"`
+	MovedChildIDs []gidx.PrefixedID `json:"movedChildIDs"`
+}
+
+// tenantMerge merges the source tenant into the target: the children of the source are moved
+// under the target, the labels of the source are merged into those of the target, the target
+// winning on conflict, the source is deleted and the merge is recorded in the audit of the target,
+// all in one transaction. The caller must be allowed to update the target and delete the source.
+// The target may not be a descendant of the source, as moving the children would then create a
+// cycle. The auth relationships of the moved children are rewritten once the transaction commits,
+// along with the change events.
+func (h *Handler) tenantMerge(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	targetID, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req mergeRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	switch {
+	case req.SourceID == gidx.NullPrefixedID:
+		return errmap.BadRequest(&validation.Error{Field: "sourceID", Code: validation.CodeRequired, Message: "the source tenant is required"})
+	case req.SourceID.Prefix() != schema.TenantPrefix:
+		return errmap.BadRequest(&validation.Error{Field: "sourceID", Code: validation.CodeInvalidID, Message: fmt.Sprintf("%q is not a tenant id", req.SourceID)})
+	case req.SourceID == targetID:
+		return errmap.BadRequest(&validation.Error{Field: "sourceID", Code: validation.CodeInvalidValue, Message: "a tenant can't be merged into itself"})
+	}
+
+	if err := permissions.CheckAccess(ctx, targetID, actionTenantUpdate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, req.SourceID, actionTenantDelete); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	mergeCtx, batch := changefeed.WithBatch(changefeed.WithAdditionalData(ctx, map[string]any{mergedIntoKey: targetID.String()}))
+	mergeCtx = changefeed.HoldRelationships(mergeCtx)
+
+	tx, err := h.client.Tx(mergeCtx)
+	if err != nil {
+		return err
+	}
+
+	target, moved, err := mergeTenants(mergeCtx, tx, req.SourceID, targetID, h.clock.Now())
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.log(c).Errorw("failed to roll back merge", "error", rerr)
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the merge is committed, report it even though some events or relationships were lost
+		h.log(c).Errorw("failed to publish merge changes", "error", err)
+	}
+
+	return c.JSON(http.StatusOK, mergeResponse{
+		Tenant:        newTenant(target, redact.FromContext(ctx)),
+		MovedChildIDs: moved,
+	})
+}
+
+// mergeTenants moves the children of the source under the target, merges the labels of the source
+// into those of the target, deletes the source and records the merge in the audit of the target,
+// returning the target and the moved children.
+func mergeTenants(ctx context.Context, tx *ent.Tx, sourceID, targetID gidx.PrefixedID, now time.Time) (*ent.Tenant, []gidx.PrefixedID, error) {
+	target, err := tx.Tenant.Get(ctx, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := tx.Tenant.Get(ctx, sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for id := target.ParentTenantID; id != gidx.NullPrefixedID; {
+		if id == sourceID {
+			return nil, nil, echo.NewHTTPError(http.StatusConflict, "the target is a descendant of the source")
+		}
+
+		ancestor, err := tx.Tenant.Get(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id = ancestor.ParentTenantID
+	}
+
+	children, err := tx.Tenant.Query().Where(enttenant.ParentTenantID(sourceID)).IDs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, child := range children {
+		// the relationships to the source and the target are rewritten by the hooks, the requests
+		// are held until the transaction commits, see internal/reparent
+		if err := tx.Tenant.UpdateOneID(child).SetParentTenantID(targetID).Exec(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the target is only updated when the source sets labels it doesn't, its own win on conflict
+	if labels := mergeLabels(source.Labels, target.Labels); len(labels) != len(target.Labels) {
+		if target, err = tx.Tenant.UpdateOne(target).SetLabels(labels).Save(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the source is deleted as any other tenant, through the hooks keeping the tombstone it is
+	// restored from, see internal/tombstone
+	if err := tx.Tenant.DeleteOneID(sourceID).Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	if err := audit.RecordMerge(ctx, tx.Client(), RouteTenantMerge, targetID, sourceID, now); err != nil {
+		return nil, nil, err
+	}
+
+	if children == nil {
+		children = []gidx.PrefixedID{}
+	}
+
+	return target, children, nil
+}
+
+// mergeLabels returns the labels of the source overridden by those of the target.
+func mergeLabels(source, target map[string]string) map[string]string {
+	merged := make(map[string]string, len(source)+len(target))
+
+	for k, v := range source {
+		merged[k] = v
+	}
+
+	for k, v := range target {
+		merged[k] = v
+	}
+
+	return merged
+}
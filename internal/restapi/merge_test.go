@@ -0,0 +1,205 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+)
+
+func TestTenantMerge(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	target := env.client.Tenant.Create().SetName("target").SetParent(root).SaveX(env.ctx)
+	source := env.client.Tenant.Create().SetName("source").SetParent(root).SaveX(env.ctx)
+	first := env.client.Tenant.Create().SetName("first").SetParent(source).SaveX(env.ctx)
+	second := env.client.Tenant.Create().SetName("second").SetParent(source).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants/"+target.ID.String()+"/merge", `{"sourceID":"`+source.ID.String()+`"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var resp struct {
+		Tenant struct {
+			ID gidx.PrefixedID `json:"id"`
+		} `json:"tenant"`
+		MovedChildIDs []gidx.PrefixedID `json:"movedChildIDs"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, target.ID, resp.Tenant.ID)
+	assert.ElementsMatch(t, []gidx.PrefixedID{first.ID, second.ID}, resp.MovedChildIDs)
+
+	assert.Equal(t, target.ID, env.client.Tenant.GetX(env.ctx, first.ID).ParentTenantID)
+	assert.Equal(t, target.ID, env.client.Tenant.GetX(env.ctx, second.ID).ParentTenantID)
+
+	_, err := env.client.Tenant.Get(env.ctx, source.ID)
+	assert.Error(t, err, "source is deleted")
+
+	eventTypes := map[gidx.PrefixedID]string{}
+
+	for _, call := range env.conn.Calls {
+		msg := call.Arguments.Get(1).(events.ChangeMessage)
+
+		eventTypes[msg.SubjectID] = msg.EventType
+		assert.Equal(t, target.ID.String(), msg.AdditionalData["merged_into"])
+	}
+
+	assert.Equal(t, map[gidx.PrefixedID]string{
+		first.ID:  string(events.UpdateChangeType),
+		second.ID: string(events.UpdateChangeType),
+		source.ID: string(events.DeleteChangeType),
+	}, eventTypes)
+}
+
+func TestTenantMergeLabels(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	target := env.client.Tenant.Create().SetName("target").SetParent(root).
+		SetLabels(map[string]string{"env": "prod", "owner": "payments"}).SaveX(env.ctx)
+	source := env.client.Tenant.Create().SetName("source").SetParent(root).
+		SetLabels(map[string]string{"env": "staging", "cost-center": "fin"}).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	status, body := env.post(t, "/v1/tenants/"+target.ID.String()+"/merge", `{"sourceID":"`+source.ID.String()+`"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	// the target wins on conflict, the labels only the source sets are kept
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "payments", "cost-center": "fin"}, env.client.Tenant.GetX(env.ctx, target.ID).Labels)
+
+	eventTypes := map[gidx.PrefixedID]string{}
+
+	for _, call := range env.conn.Calls {
+		msg := call.Arguments.Get(1).(events.ChangeMessage)
+
+		eventTypes[msg.SubjectID] = msg.EventType
+		assert.Equal(t, target.ID.String(), msg.AdditionalData["merged_into"])
+	}
+
+	assert.Equal(t, map[gidx.PrefixedID]string{
+		target.ID: string(events.UpdateChangeType),
+		source.ID: string(events.DeleteChangeType),
+	}, eventTypes)
+
+	t.Run("labels the target has already", func(t *testing.T) {
+		other := env.client.Tenant.Create().SetName("other").SetParent(root).
+			SetLabels(map[string]string{"env": "dev"}).SaveX(env.ctx)
+
+		env.conn.Calls = nil
+
+		status, body := env.post(t, "/v1/tenants/"+target.ID.String()+"/merge", `{"sourceID":"`+other.ID.String()+`"}`)
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		assert.Equal(t, "prod", env.client.Tenant.GetX(env.ctx, target.ID).Labels["env"])
+		env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+	})
+}
+
+func TestTenantMergeAudited(t *testing.T) {
+	ctx := context.Background()
+
+	// merges are audited even though rejected attempts aren't recorded
+	client, url := newAuditServer(t, "tnntten-denied", false)
+	client.Tenant.Use(tombstone.Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	target := client.Tenant.Create().SetName("target").SetParent(root).SaveX(ctx)
+	source := client.Tenant.Create().SetName("source").SetParent(root).SaveX(ctx)
+
+	resp, body := send(t, http.MethodPost, url+"/v1/tenants/"+target.ID.String()+"/merge", `{"sourceID":"`+source.ID.String()+`"}`, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	entries := client.TenantAudit.Query().AllX(ctx)
+	require.Len(t, entries, 1)
+	assert.Equal(t, target.ID, entries[0].TenantID)
+	assert.Equal(t, audit.OutcomeMerged, entries[0].Outcome)
+	assert.Equal(t, restapi.RouteTenantMerge, entries[0].Operation)
+	assert.Equal(t, testActor, entries[0].Actor)
+	assert.JSONEq(t, `{"sourceID":"`+source.ID.String()+`"}`, entries[0].AttemptedChange)
+
+	resp, body = get(t, url+"/v1/tenants/"+target.ID.String()+"/audit?outcome=merged", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), source.ID.String())
+
+	// the source is deleted as any other tenant, its tombstone is kept to restore it from
+	tomb := client.TenantTombstone.GetX(ctx, source.ID)
+	assert.Equal(t, "source", tomb.Name)
+	assert.Equal(t, root.ID, tomb.ParentTenantID)
+}
+
+func TestTenantMergeAuditFailure(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newAuditServer(t, "tnntten-denied", false)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	target := client.Tenant.Create().SetName("target").SetParent(root).SaveX(ctx)
+	source := client.Tenant.Create().SetName("source").SetParent(root).SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(source).SaveX(ctx)
+
+	client.TenantAudit.Use(func(ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+			return nil, errors.New("audit unavailable")
+		})
+	})
+
+	resp, body := send(t, http.MethodPost, url+"/v1/tenants/"+target.ID.String()+"/merge", `{"sourceID":"`+source.ID.String()+`"}`, nil)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode, string(body))
+
+	// a merge which can't be audited is rolled back
+	assert.True(t, client.Tenant.Query().Where(enttenant.ID(source.ID)).ExistX(ctx))
+	assert.Equal(t, source.ID, client.Tenant.GetX(ctx, child.ID).ParentTenantID)
+}
+
+func TestTenantMergeRejected(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	target := env.client.Tenant.Create().SetName("target").SetParent(root).SaveX(env.ctx)
+	nested := env.client.Tenant.Create().SetName("nested").SetParent(target).SaveX(env.ctx)
+	denied := env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SetParent(root).SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	testCases := []struct {
+		name     string
+		target   gidx.PrefixedID
+		source   gidx.PrefixedID
+		expected int
+	}{
+		{name: "cycle", target: nested.ID, source: target.ID, expected: http.StatusConflict},
+		{name: "ancestor into descendant", target: nested.ID, source: root.ID, expected: http.StatusConflict},
+		{name: "source outside scope", target: target.ID, source: denied.ID, expected: http.StatusForbidden},
+		{name: "target outside scope", target: denied.ID, source: target.ID, expected: http.StatusForbidden},
+		{name: "itself", target: target.ID, source: target.ID, expected: http.StatusBadRequest},
+		{name: "missing source", target: target.ID, source: "tnntten-missing", expected: http.StatusNotFound},
+		{name: "invalid source", target: target.ID, source: "loadbal-test", expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := env.post(t, "/v1/tenants/"+tt.target.String()+"/merge", `{"sourceID":"`+tt.source.String()+`"}`)
+			assert.Equal(t, tt.expected, status, string(body))
+		})
+	}
+
+	assert.Equal(t, target.ID, env.client.Tenant.GetX(env.ctx, nested.ID).ParentTenantID)
+	assert.Equal(t, 4, env.client.Tenant.Query().CountX(env.ctx))
+	assert.Empty(t, env.conn.Calls)
+}
@@ -0,0 +1,246 @@
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/collation"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+// Orders of the tenant list.
+const (
+	orderByID   = "id"
+	orderByName = "name"
+)
+
+// maxCollatedTenants bounds the tenants sorted by a collation for a page. The database compares
+// names by their bytes, so the listing is sorted by the service.
+const maxCollatedTenants = 10000
+
+// listOrder is the order of the tenant list, by ID or by name, names being compared by their bytes
+// unless a collation is given. Ties between names are broken by ID.
+type listOrder struct {
+	byName    bool
+	collation *collation.Collation
+}
+
+// parseListOrder parses the order_by and collation query parameters of the tenant list. Lists
+// sorted by a collation must be limited to the children of a parent.
+func parseListOrder(c echo.Context, scoped bool) (listOrder, error) {
+	var (
+		order listOrder
+		errs  validation.Errors
+	)
+
+	switch orderBy := c.QueryParam("order_by"); orderBy {
+	case "", orderByID:
+	case orderByName:
+		order.byName = true
+	default:
+		errs.Add("order_by", validation.CodeInvalidValue, fmt.Sprintf("must be %s or %s", orderByID, orderByName))
+	}
+
+	raw := c.QueryParam("collation")
+
+	switch {
+	case raw == "":
+	case !order.byName:
+		errs.Add("collation", validation.CodeInvalidValue, "only names are sorted by a collation, give order_by=name")
+	case !scoped:
+		errs.Add("collation", validation.CodeInvalidValue, "sorting by a collation requires parent_id")
+	default:
+		coll, err := collation.Parse(raw)
+		if err != nil {
+			errs.Add("collation", validation.CodeInvalidValue, fmt.Sprintf("unsupported collation %q, the supported ones are listed by GET /v1/collations", raw))
+
+			break
+		}
+
+		order.collation = &coll
+	}
+
+	return order, errs.Err()
+}
+
+// sortField returns the field the list is sorted by, for the query guard.
+func (o listOrder) sortField() string {
+	if o.byName {
+		return enttenant.FieldName
+	}
+
+	return enttenant.FieldID
+}
+
+// collationName returns the canonical name of the collation, empty when names are compared by
+// their bytes.
+func (o listOrder) collationName() string {
+	if o.collation == nil {
+		return ""
+	}
+
+	return o.collation.String()
+}
+
+// cursor returns the cursor of the page ending with the tenant. The cursors of name orders carry
+// the collation, so a page token is never used with another one.
+func (o listOrder) cursor(t *ent.Tenant) pagination.Cursor {
+	if !o.byName {
+		return pagination.Cursor{Keys: []string{t.ID.String()}}
+	}
+
+	return pagination.Cursor{Keys: []string{t.Name, t.ID.String(), o.collationName()}}
+}
+
+// validCursor reports whether the cursor was returned by a list in the order.
+func (o listOrder) validCursor(cursor pagination.Cursor) bool {
+	if cursor.Direction != pagination.Ascending {
+		return false
+	}
+
+	idKey := 0
+
+	if o.byName {
+		if len(cursor.Keys) != 3 || cursor.Keys[2] != o.collationName() {
+			return false
+		}
+
+		idKey = 1
+	} else if len(cursor.Keys) != 1 {
+		return false
+	}
+
+	id, err := gidx.Parse(cursor.Keys[idKey])
+
+	return err == nil && id.Prefix() == schema.TenantPrefix
+}
+
+// apply sorts the query in the order, after the cursor when set. Collated orders are applied by
+// collatedPage instead.
+func (o listOrder) apply(query *ent.TenantQuery, after *pagination.Cursor) (*ent.TenantQuery, error) {
+	columns := []string{enttenant.FieldID}
+	if o.byName {
+		columns = []string{enttenant.FieldName, enttenant.FieldID}
+	}
+
+	if after != nil {
+		p, err := pagination.Cursor{Keys: after.Keys[:len(columns)]}.Predicate(columns...)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Where(predicate.Tenant(p))
+	}
+
+	for _, column := range columns {
+		query = query.Order(ent.Asc(column))
+	}
+
+	return query, nil
+}
+
+type collatedTenant struct {
+	id  gidx.PrefixedID
+	key []byte
+}
+
+// collatedPage returns the tenants of the query following the cursor, or the offset, in the
+// collated order, at most limit+1 so the caller can tell whether more follow. Every tenant of the query is sorted,
+// up to maxCollatedTenants.
+func (o listOrder) collatedPage(ctx context.Context, client *ent.Client, query *ent.TenantQuery, after *pagination.Cursor, offset, limit int) ([]*ent.Tenant, error) {
+	rows, err := query.Select(enttenant.FieldID, enttenant.FieldName).Limit(maxCollatedTenants + 1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > maxCollatedTenants {
+		return nil, errmap.BadRequest(&validation.Error{
+			Field:   "collation",
+			Code:    validation.CodeTooMany,
+			Message: fmt.Sprintf("at most %d tenants are sorted by a collation, narrow the list with name_contains", maxCollatedTenants),
+			Limit:   maxCollatedTenants,
+		})
+	}
+
+	keyer := o.collation.NewKeyer()
+	sorted := make([]collatedTenant, len(rows))
+
+	for i, t := range rows {
+		sorted[i] = collatedTenant{id: t.ID, key: keyer.Key(t.Name)}
+	}
+
+	less := func(a, b collatedTenant) bool {
+		if cmp := bytes.Compare(a.key, b.key); cmp != 0 {
+			return cmp < 0
+		}
+
+		return a.id < b.id
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+
+	start := offset
+
+	if after != nil {
+		last := collatedTenant{id: gidx.PrefixedID(after.Keys[1]), key: keyer.Key(after.Keys[0])}
+		start = sort.Search(len(sorted), func(i int) bool { return less(last, sorted[i]) })
+	}
+
+	if start > len(sorted) {
+		start = len(sorted)
+	}
+
+	end := start + limit + 1
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+
+	ids := make([]gidx.PrefixedID, 0, end-start)
+	for _, t := range sorted[start:end] {
+		ids = append(ids, t.id)
+	}
+
+	tenants, err := client.Tenant.Query().Where(enttenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[gidx.PrefixedID]*ent.Tenant, len(tenants))
+	for _, t := range tenants {
+		byID[t.ID] = t
+	}
+
+	page := make([]*ent.Tenant, 0, len(ids))
+
+	for _, id := range ids {
+		// tenants deleted since they were sorted are left out
+		if t, ok := byID[id]; ok {
+			page = append(page, t)
+		}
+	}
+
+	return page, nil
+}
+
+type collationsResponse struct {
+	Collations []string `json:"collations"`
+}
+
+// collationList lists the collations the tenant list may be sorted by, the tags of the supported
+// languages. Each may carry collation options in a -u- extension, such as und-u-ks-level2 which
+// ignores case.
+func (h *Handler) collationList(c echo.Context) error {
+	return c.JSON(http.StatusOK, collationsResponse{Collations: collation.Supported()})
+}
@@ -0,0 +1,153 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+func TestTenantListCollation(t *testing.T) {
+	ctx := context.Background()
+
+	client, srvURL := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	for _, name := range []string{"zoo", "Zebra", "apple", "Apple", "Ångström", "Émile", "eagle", "Éclair", "北京", "上海", "東京", "大阪"} {
+		client.Tenant.Create().SetName(name).SetParent(root).SaveX(ctx)
+	}
+
+	// list pages through the children of the root three at a time, returning their names
+	list := func(t *testing.T, query url.Values) []string {
+		t.Helper()
+
+		query.Set("parent_id", root.ID.String())
+		query.Set("limit", "3")
+
+		var names []string
+
+		for {
+			resp, body := get(t, srvURL+"/v1/tenants?"+query.Encode(), nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+			var page struct {
+				Tenants []struct {
+					Name string `json:"name"`
+				} `json:"tenants"`
+				NextPageToken string `json:"nextPageToken"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &page))
+
+			for _, tnt := range page.Tenants {
+				names = append(names, tnt.Name)
+			}
+
+			if page.NextPageToken == "" {
+				return names
+			}
+
+			query.Set("page_token", page.NextPageToken)
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		collation string
+		expected  []string
+	}{
+		{"bytes", "", []string{"Apple", "Zebra", "apple", "eagle", "zoo", "Ångström", "Éclair", "Émile", "上海", "北京", "大阪", "東京"}},
+		{"english", "en", []string{"Ångström", "apple", "Apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "上海", "北京", "大阪", "東京"}},
+		{"swedish", "sv", []string{"apple", "Apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "Ångström", "上海", "北京", "大阪", "東京"}},
+		{"chinese", "zh", []string{"Ångström", "apple", "Apple", "eagle", "Éclair", "Émile", "Zebra", "zoo", "北京", "大阪", "東京", "上海"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{"order_by": {"name"}}
+			if tt.collation != "" {
+				query.Set("collation", tt.collation)
+			}
+
+			assert.Equal(t, tt.expected, list(t, query))
+		})
+	}
+
+	t.Run("case insensitive", func(t *testing.T) {
+		names := list(t, url.Values{"order_by": {"name"}, "collation": {"und-u-ks-level2"}})
+
+		// apple and Apple are equal, ordered by ID
+		require.Len(t, names, 12)
+		assert.Equal(t, []string{"Ångström"}, names[:1])
+		assert.ElementsMatch(t, []string{"apple", "Apple"}, names[1:3])
+		assert.Equal(t, []string{"eagle", "Éclair", "Émile", "Zebra", "zoo"}, names[3:8])
+	})
+
+	t.Run("page tokens carry the collation", func(t *testing.T) {
+		base := srvURL + "/v1/tenants?limit=3&parent_id=" + root.ID.String()
+
+		resp, body := get(t, base+"&order_by=name&collation=EN", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page struct {
+			NextPageToken string `json:"nextPageToken"`
+		}
+
+		require.NoError(t, json.Unmarshal(body, &page))
+
+		cursor, err := pagination.Decode(page.NextPageToken)
+		require.NoError(t, err)
+		require.Len(t, cursor.Keys, 3)
+		assert.Equal(t, []string{"Apple", "en"}, []string{cursor.Keys[0], cursor.Keys[2]})
+
+		resp, _ = get(t, base+"&order_by=name&collation=en&page_token="+page.NextPageToken, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "the canonical collation matches")
+
+		for _, query := range []string{"&order_by=name&collation=sv", "&order_by=name", ""} {
+			resp, body := get(t, base+query+"&page_token="+page.NextPageToken, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+		}
+	})
+}
+
+func TestTenantListCollationInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	client, srvURL := newTestServer(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	scoped := "&parent_id=" + root.ID.String()
+
+	for _, tt := range []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"unknown order", "order_by=created_at" + scoped, "must be id or name"},
+		{"collated ids", "collation=en" + scoped, "give order_by=name"},
+		{"unscoped", "order_by=name&collation=en", "requires parent_id"},
+		{"unsupported", "order_by=name&collation=xx" + scoped, "GET /v1/collations"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, srvURL+"/v1/tenants?"+tt.query, nil)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+			assert.Contains(t, string(body), tt.message)
+		})
+	}
+
+	resp, body := get(t, srvURL+"/v1/collations", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var collations struct {
+		Collations []string `json:"collations"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &collations))
+	assert.Contains(t, collations.Collations, "en")
+	assert.Contains(t, collations.Collations, "zh")
+}
@@ -0,0 +1,8 @@
+package restapi
+
+const (
+	actionTenantCreate = "tenant_create"
+	actionTenantGet    = "tenant_get"
+	actionTenantUpdate = "tenant_update"
+	actionTenantDelete = "tenant_delete"
+)
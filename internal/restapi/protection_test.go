@@ -0,0 +1,124 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantaudit"
+	"go.infratographer.com/tenant-api/internal/protection"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+func TestTenantDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newAuditServer(t, "tnntten-denied", true)
+	client.Tenant.Use(protection.Hook("tenants:admin"))
+
+	root := client.Tenant.Create().SetName("production").SetDeletionProtected(true).SaveX(ctx)
+	path := url + "/v1/tenants/" + root.ID.String()
+
+	resp, body := get(t, path, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"deletionProtected":true`)
+
+	for _, tt := range []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"unconfirmed", nil},
+		{"mismatch", map[string]string{restapi.HeaderConfirmName: "Production"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := send(t, http.MethodDelete, path, "", tt.headers)
+			require.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+
+			var errResp struct {
+				Code string `json:"code"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, "deletion_protected", errResp.Code)
+			assert.True(t, client.Tenant.Query().ExistX(ctx), "the tenant is kept")
+		})
+	}
+
+	resp, body = send(t, http.MethodDelete, path, "", map[string]string{restapi.HeaderConfirmName: "production"})
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+	assert.False(t, client.Tenant.Query().ExistX(ctx))
+
+	entries := client.TenantAudit.Query().Where(tenantaudit.TenantID(root.ID)).Order(tenantaudit.ByRecordedAt()).AllX(ctx)
+	require.Len(t, entries, 3)
+	assert.Equal(t, audit.OutcomeConflicted, entries[0].Outcome)
+	assert.Equal(t, "deletion_protected", entries[0].Code)
+	assert.Equal(t, audit.OutcomeConflicted, entries[1].Outcome)
+	assert.Equal(t, audit.OutcomeConfirmed, entries[2].Outcome)
+	assert.Equal(t, testActor, entries[2].Actor)
+	assert.Equal(t, restapi.RouteTenantDelete, entries[2].Operation)
+}
+
+func TestTenantDeleteProtectedAuditedByDefault(t *testing.T) {
+	ctx := context.Background()
+
+	// rejected attempts aren't recorded, confirmed deletions are nonetheless
+	client, url := newAuditServer(t, "tnntten-denied", false)
+	client.Tenant.Use(protection.Hook("tenants:admin"))
+
+	root := client.Tenant.Create().SetName("production").SetDeletionProtected(true).SaveX(ctx)
+	path := url + "/v1/tenants/" + root.ID.String()
+
+	require.Equal(t, http.StatusConflict, statusOf(t, http.MethodDelete, path, ""))
+
+	resp, body := send(t, http.MethodDelete, path, "", map[string]string{restapi.HeaderConfirmName: "production"})
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+
+	entries := client.TenantAudit.Query().AllX(ctx)
+	require.Len(t, entries, 1)
+	assert.Equal(t, root.ID, entries[0].TenantID)
+	assert.Equal(t, audit.OutcomeConfirmed, entries[0].Outcome)
+}
+
+func TestTenantBatchDeleteProtectedDescendant(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newAuditServer(t, "tnntten-denied", false)
+	client.Tenant.Use(protection.Hook("tenants:admin"))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SetDeletionProtected(true).SaveX(ctx)
+
+	for _, mode := range []string{"all_or_nothing", "best_effort"} {
+		t.Run(mode, func(t *testing.T) {
+			resp, body := send(t, http.MethodDelete, url+"/v1/tenants",
+				`{"ids":["`+root.ID.String()+`","`+child.ID.String()+`","`+grandchild.ID.String()+`"],"mode":"`+mode+`"}`,
+				map[string]string{restapi.HeaderConfirmName: "grandchild"},
+			)
+
+			var batchResp struct {
+				Results []struct {
+					Status string `json:"status"`
+				} `json:"results"`
+			}
+
+			require.NoError(t, json.Unmarshal(body, &batchResp), string(body))
+			require.Len(t, batchResp.Results, 3)
+
+			// the batch ignores the confirmation, the protected grandchild keeps its ancestors
+			assert.Equal(t, "has_children", batchResp.Results[0].Status)
+			assert.Equal(t, "has_children", batchResp.Results[1].Status)
+			assert.Equal(t, "protected", batchResp.Results[2].Status)
+			assert.Equal(t, 3, client.Tenant.Query().CountX(ctx))
+
+			if mode == "all_or_nothing" {
+				assert.Equal(t, http.StatusConflict, resp.StatusCode)
+			}
+		})
+	}
+}
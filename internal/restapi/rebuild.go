@@ -0,0 +1,181 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/jobs"
+)
+
+const (
+	// jobKindRebuild is the kind of the jobs rebuilding the derived data of a subtree.
+	jobKindRebuild = "rebuild"
+
+	// rebuildBatchSize is the number of tenants rebuilt per transaction.
+	rebuildBatchSize = 100
+)
+
+// adminRebuild starts a job rebuilding the data derived from the tenants of the subtree below the
+// tenant, itself included: their change sequences are set back to their latest recorded change
+// and their cached statistics are dropped. The effective status and the hierarchy aren't stored
+// apart from the parent ids, so they have nothing to rebuild. The tenants are rebuilt in batches,
+// each in its own transaction, the response is the job to poll for the progress. With
+// suppress_events=true the changes of the rebuild aren't published, a single summary event is
+// once the job is done.
+func (h *Handler) adminRebuild(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	exists, err := h.client.Tenant.Query().Where(enttenant.ID(id)).Exist(ctx)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if !exists {
+		return echo.NewHTTPError(http.StatusNotFound, "tenant not found")
+	}
+
+	suppress, err := suppressEvents(c, id)
+	if err != nil {
+		return err
+	}
+
+	// the job outlives the request, its changes are attributed to the admin who started it
+	caller := actor.FromContext(ctx)
+	logger := h.log(c)
+
+	job := h.jobs.Start(jobKindRebuild, func(ctx context.Context, progress *jobs.Tracker) error {
+		ctx = actor.NewContext(actor.Internal(ctx), caller)
+		jobCtx, s := suppression(ctx, suppress)
+
+		rebuilt, err := h.rebuildSubtree(jobCtx, id, progress)
+		if err != nil {
+			return err
+		}
+
+		h.publishSummary(ctx, logger, s, changefeed.Summary{Operation: bulkRebuild, Root: id, Tenants: rebuilt})
+
+		return nil
+	})
+
+	h.log(c).Infow("started subtree rebuild", "tenant_id", id, "job_id", job.ID)
+
+	h.setLocation(c, RouteAdminJobGet, job.ID)
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// rebuildSubtree rebuilds the subtree a batch at a time, counting the tenants rebuilt, and returns
+// their number.
+func (h *Handler) rebuildSubtree(ctx context.Context, root gidx.PrefixedID, progress *jobs.Tracker) (int, error) {
+	ids, err := subtreeIDs(ctx, h.client, root)
+	if err != nil {
+		return 0, err
+	}
+
+	progress.SetTotal(int64(len(ids)))
+
+	for start := 0; start < len(ids); start += rebuildBatchSize {
+		end := start + rebuildBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		batch := ids[start:end]
+
+		if err := h.rebuildBatch(ctx, batch); err != nil {
+			return 0, err
+		}
+
+		h.stats.forget(batch...)
+
+		progress.Add(int64(len(batch)))
+	}
+
+	return len(ids), nil
+}
+
+func (h *Handler) rebuildBatch(ctx context.Context, ids []gidx.PrefixedID) error {
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := changeseq.Repair(ctx, tx.Client(), ids); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.logger.Errorw("failed to roll back subtree rebuild", "error", rerr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// subtreeIDs returns the tenant and its descendants, a level at a time. Tenants already seen are
+// skipped so a cycle in the hierarchy doesn't walk forever.
+func subtreeIDs(ctx context.Context, client *ent.Client, root gidx.PrefixedID) ([]gidx.PrefixedID, error) {
+	ids := []gidx.PrefixedID{root}
+	seen := map[gidx.PrefixedID]bool{root: true}
+
+	for level := ids; len(level) != 0; {
+		var next []gidx.PrefixedID
+
+		for start := 0; start < len(level); start += rebuildBatchSize {
+			end := start + rebuildBatchSize
+			if end > len(level) {
+				end = len(level)
+			}
+
+			children, err := client.Tenant.Query().
+				Where(enttenant.ParentTenantIDIn(level[start:end]...)).
+				Order(ent.Asc(enttenant.FieldID)).
+				IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, child := range children {
+				if !seen[child] {
+					seen[child] = true
+
+					next = append(next, child)
+				}
+			}
+		}
+
+		ids = append(ids, next...)
+		level = next
+	}
+
+	return ids, nil
+}
+
+// adminJobGet returns the current state of a job started on this instance.
+func (h *Handler) adminJobGet(c echo.Context) error {
+	id, err := gidx.Parse(c.Param("id"))
+	if err != nil || id.Prefix() != schema.JobPrefix {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid job id")
+	}
+
+	job, ok := h.jobs.Get(id)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "job not found")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
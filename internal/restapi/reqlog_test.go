@@ -0,0 +1,56 @@
+package restapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// failPublishing makes every following change fail to publish.
+func (env *eventEnv) failPublishing() {
+	env.conn.ExpectedCalls = nil
+	env.conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, errors.New("broker unavailable"))
+}
+
+func requireLogged(t *testing.T, logs *observer.ObservedLogs, message string) map[string]any {
+	t.Helper()
+
+	entries := logs.FilterMessage(message).All()
+	require.Len(t, entries, 1, "expected %q to be logged once", message)
+
+	return entries[0].ContextMap()
+}
+
+func TestRequestLoggerFields(t *testing.T) {
+	env := newEventEnv(t, "")
+
+	tnt := env.client.Tenant.Create().SetName("acme").SaveX(env.ctx)
+
+	env.failPublishing()
+
+	status, body := env.do(t, http.MethodPut, "/v1/tenants/"+tnt.ID.String()+"/settings", "application/json", `{"theme":"dark"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	fields := requireLogged(t, env.logs, "failed to publish settings update")
+	assert.Equal(t, testRequestID, fields["request_id"])
+	assert.Equal(t, "/v1/tenants/:id/settings", fields["route"])
+	assert.Equal(t, testActor, fields["actor"])
+	assert.Equal(t, tnt.ID.String(), fields["tenant_id"])
+	assert.Contains(t, fields, "error")
+
+	status, body = env.post(t, "/v1/tenants:batchUpdate", `{"ids":["`+tnt.ID.String()+`"],"patch":{"description":"suspended"}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	fields = requireLogged(t, env.logs, "failed to publish batch update changes")
+	assert.Equal(t, testRequestID, fields["request_id"])
+	assert.Equal(t, "/v1/tenants\\:batchUpdate", fields["route"])
+	assert.Equal(t, testActor, fields["actor"])
+	assert.NotContains(t, fields, "tenant_id", "the route has no tenant id")
+}
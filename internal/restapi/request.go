@@ -0,0 +1,284 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// unknownFieldPrefix starts the message of the json decoding error for fields which aren't part of
+// the request, the decoder has no error type for it.
+const unknownFieldPrefix = "json: unknown field "
+
+// HeaderStrictValidation asks for the body of a create or update to be decoded strictly when true,
+// for callers of servers decoding them leniently.
+const HeaderStrictValidation = "X-Strict-Validation"
+
+// WithLenientDecoding ignores the fields the bodies of creates and updates don't have rather than
+// rejecting them, for clients sending more than the api knows. Requests with the
+// X-Strict-Validation header set to true are still decoded strictly, the other endpoints always are.
+func WithLenientDecoding() Option {
+	return func(h *Handler) {
+		h.lenientDecoding = true
+	}
+}
+
+// decodeRequest decodes the json body of the request into v, rejecting fields it doesn't have.
+// Decoding errors are returned as a validation error naming the field at fault when known, to be
+// reported with errmap.BadRequest like the errors found validating the request.
+func decodeRequest(c echo.Context, v any) error {
+	return decodeBody(c, v, true)
+}
+
+// decodeInput decodes the body of a create or update into v like decodeRequest, but ignores the
+// fields it doesn't have when the handler decodes leniently and the request doesn't ask for strict
+// decoding with the X-Strict-Validation header.
+func (h *Handler) decodeInput(c echo.Context, v any) error {
+	strict := !h.lenientDecoding
+
+	if value := c.Request().Header.Get(HeaderStrictValidation); value != "" {
+		requested, err := strconv.ParseBool(value)
+		if err != nil {
+			return &validation.Error{
+				Code:    validation.CodeInvalidValue,
+				Message: fmt.Sprintf("the %s header must be true or false, not %q", HeaderStrictValidation, value),
+				Err:     err,
+			}
+		}
+
+		strict = strict || requested
+	}
+
+	return decodeBody(c, v, strict)
+}
+
+func decodeBody(c echo.Context, v any, strict bool) error {
+	raw, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return &validation.Error{
+			Code:    validation.CodeMalformed,
+			Message: fmt.Sprintf("the body could not be read: %s", err),
+			Err:     err,
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+
+	err = dec.Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &typeErr):
+		return &validation.Error{
+			Field:   typeErr.Field,
+			Code:    validation.CodeInvalidType,
+			Message: fmt.Sprintf("must be a %s, not a %s", jsonType(typeErr.Type), typeErr.Value),
+			Err:     err,
+		}
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		// the decoder stops at the first unknown field, the others are found walking the body so
+		// they are all reported at once
+		var errs validation.Errors
+
+		for _, field := range unknownFields(raw, reflect.TypeOf(v), "") {
+			errs.Add(field, validation.CodeUnknownField, "is not a field of the request")
+		}
+
+		if len(errs) > 0 {
+			return errs
+		}
+
+		field, uerr := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldPrefix))
+		if uerr != nil {
+			field = strings.TrimPrefix(err.Error(), unknownFieldPrefix)
+		}
+
+		return &validation.Error{
+			Field:   field,
+			Code:    validation.CodeUnknownField,
+			Message: "is not a field of the request",
+			Err:     err,
+		}
+	default:
+		return &validation.Error{
+			Code:    validation.CodeMalformed,
+			Message: fmt.Sprintf("the body must be a json object: %s", err),
+			Err:     err,
+		}
+	}
+}
+
+// unknownFields returns the paths of the members of the json value which aren't fields of the go
+// type, descending into the objects and lists of its fields. Members are matched to fields without
+// regard to case, as the decoder does.
+func unknownFields(raw json.RawMessage, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return nil
+		}
+
+		var unknown []string
+
+		for i, item := range items {
+			unknown = append(unknown, unknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+		}
+
+		return unknown
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	var members map[string]json.RawMessage
+	if json.Unmarshal(raw, &members) != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(members))
+
+	for name := range members {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fields := jsonFields(t)
+
+	var unknown []string
+
+	for _, name := range names {
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field, ok := lookupField(fields, name)
+		if !ok {
+			unknown = append(unknown, path)
+
+			continue
+		}
+
+		unknown = append(unknown, unknownFields(members[name], field.Type, path)...)
+	}
+
+	return unknown
+}
+
+// jsonFields returns the fields of the struct type by their json name, with the fields of
+// embedded structs promoted.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for n, ef := range jsonFields(ft) {
+				if _, ok := fields[n]; !ok {
+					fields[n] = ef
+				}
+			}
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = f
+	}
+
+	return fields
+}
+
+// lookupField returns the field of the json name, preferring an exact match to one ignoring case.
+func lookupField(fields map[string]reflect.StructField, name string) (reflect.StructField, bool) {
+	if f, ok := fields[name]; ok {
+		return f, true
+	}
+
+	for n, f := range fields {
+		if strings.EqualFold(n, name) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// jsonType names the json type values of the go type are decoded from.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "number"
+	}
+}
+
+// checkTenantIDs records a validation error for the list of tenant ids when it is empty or longer
+// than max, and for each id which isn't a tenant id.
+func checkTenantIDs(errs *validation.Errors, field string, ids []gidx.PrefixedID, max int) {
+	switch {
+	case len(ids) == 0:
+		errs.Add(field, validation.CodeRequired, "at least one id is required")
+	case len(ids) > max:
+		errs.Add(field, validation.CodeTooMany, fmt.Sprintf("at most %d ids may be listed", max))
+	}
+
+	for i, id := range ids {
+		if id.Prefix() != schema.TenantPrefix {
+			errs.Add(fmt.Sprintf("%s[%d]", field, i), validation.CodeInvalidID, fmt.Sprintf("%q is not a tenant id", id))
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// restoredKey is the additional data key flagging the events of tenants restored from their
+// tombstone.
+const restoredKey = "restored"
+
+// restoreCascadeParam is the query parameter restoring the deleted descendants of the tenant too.
+const restoreCascadeParam = "cascade"
+
+type restoreRequest struct {
+	Name           string                 `json:"name"`
+	OnNameConflict tombstone.NameConflict `json:"onNameConflict"`
+}
+
+type restoreConflictResponse struct {
+	Code          string `json:"code"`
+	Field         string `json:"field"`
+	Message       string `json:"message"`
+	SuggestedName string `json:"suggestedName"`
+}
+
+// adminTenantRestore creates a deleted tenant again from its tombstone, with its id, under its
+// parent and with its final name unless another one is given. When a sibling took the name since,
+// onNameConflict suffix restores it under the first free suffixed name, otherwise it is a conflict
+// suggesting that name. Tenants whose parent is deleted are a conflict until the parent is
+// restored, those whose tombstone was purged aren't found. With cascade=true the deleted
+// descendants whose tombstones are kept are restored too, suffixing their names when taken. With
+// suppress_events=true the creates aren't published, a single summary event is once committed.
+func (h *Handler) adminTenantRestore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req restoreRequest
+
+	if c.Request().ContentLength != 0 {
+		if err := decodeRequest(c, &req); err != nil {
+			return errmap.BadRequest(err)
+		}
+	}
+
+	switch req.OnNameConflict {
+	case "":
+		req.OnNameConflict = tombstone.NameConflictError
+	case tombstone.NameConflictError, tombstone.NameConflictSuffix:
+	default:
+		return errmap.BadRequest(&validation.Error{
+			Field:   "onNameConflict",
+			Code:    validation.CodeInvalidValue,
+			Message: fmt.Sprintf("must be %s or %s", tombstone.NameConflictError, tombstone.NameConflictSuffix),
+		})
+	}
+
+	tombstones, err := tombstone.Find(ctx, h.client, id)
+	if err != nil {
+		return err
+	}
+
+	ts, ok := tombstones[id]
+	if !ok {
+		return errmap.HTTPError(tombstone.ErrNotFound)
+	}
+
+	resource := gidx.NullPrefixedID
+	if ts.ParentTenantID != gidx.NullPrefixedID {
+		resource = ts.ParentTenantID
+	}
+
+	if err := permissions.CheckAccess(ctx, resource, actionTenantCreate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	cascade, err := parseBoolParam(c, restoreCascadeParam, false)
+	if err != nil {
+		return err
+	}
+
+	suppress, err := suppressEvents(c, id)
+	if err != nil {
+		return err
+	}
+
+	txCtx, s := suppression(changefeed.WithAdditionalData(ctx, map[string]any{restoredKey: true}), suppress)
+	txCtx, batch := changefeed.WithBatch(txCtx)
+
+	restored, err := h.restore(txCtx, id, req, cascade)
+	if err != nil {
+		var taken *tombstone.NameTakenError
+
+		if errors.As(err, &taken) {
+			return c.JSON(http.StatusConflict, restoreConflictResponse{
+				Code:          taken.Err.Code,
+				Field:         taken.Err.Field,
+				Message:       taken.Err.Message,
+				SuggestedName: taken.Suggested,
+			})
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the tenant is committed, report it even though the event was lost
+		h.log(c).Errorw("failed to publish tenant restore", "error", err)
+	}
+
+	h.publishSummary(ctx, h.log(c), s, changefeed.Summary{Operation: bulkRestore, Root: id, Tenants: len(restored)})
+
+	t := restored[0]
+
+	h.log(c).Infow("restored deleted tenant", "tenant_id", t.ID, "name", t.Name, "tenants", len(restored))
+
+	return h.respondCreated(c, newTenant(t, redact.FromContext(ctx)), RouteTenantGet, t.ID)
+}
+
+// restore restores the tenant, first of the returned tenants, with its deleted descendants when
+// cascading.
+func (h *Handler) restore(ctx context.Context, id gidx.PrefixedID, req restoreRequest, cascade bool) ([]*ent.Tenant, error) {
+	if cascade {
+		return tombstone.RestoreSubtree(ctx, h.client, id, req.Name, req.OnNameConflict)
+	}
+
+	t, err := tombstone.Restore(ctx, h.client, id, req.Name, req.OnNameConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ent.Tenant{t}, nil
+}
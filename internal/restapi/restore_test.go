@@ -0,0 +1,139 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+)
+
+func TestAdminRestore(t *testing.T) {
+	ctx := context.Background()
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware}, restapi.WithAdminScope("tenants:admin"))
+	client.Tenant.Use(tombstone.Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("reused").SetParent(root).SaveX(ctx)
+
+	client.Tenant.DeleteOne(deleted).ExecX(ctx)
+	client.Tenant.Create().SetName("reused").SetParent(root).ExecX(ctx)
+
+	path := url + "/v1/admin/tenants/" + deleted.ID.String() + "/restore"
+
+	// a sibling took the name since
+	resp, body := send(t, http.MethodPost, path, "", admin)
+	require.Equal(t, http.StatusConflict, resp.StatusCode, string(body))
+
+	var conflict struct {
+		Code          string `json:"code"`
+		Field         string `json:"field"`
+		SuggestedName string `json:"suggestedName"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &conflict))
+	assert.Equal(t, "name_taken", conflict.Code)
+	assert.Equal(t, "name", conflict.Field)
+	assert.Equal(t, "reused-2", conflict.SuggestedName)
+
+	resp, body = send(t, http.MethodPost, path, `{"onNameConflict":"rename"}`, admin)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+
+	resp, body = send(t, http.MethodPost, path, `{"onNameConflict":"suffix"}`, map[string]string{"X-Scope": "tenants:full"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, string(body))
+
+	resp, body = send(t, http.MethodPost, path, `{"onNameConflict":"suffix"}`, admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+	assert.Equal(t, "/v1/tenants/"+deleted.ID.String(), resp.Header.Get(echo.HeaderLocation))
+
+	restored := client.Tenant.GetX(ctx, deleted.ID)
+	assert.Equal(t, "reused-2", restored.Name)
+	assert.Equal(t, root.ID, restored.ParentTenantID)
+
+	// the tombstone is gone with the restore
+	resp, body = send(t, http.MethodPost, path, "", admin)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestAuditAfterPurge(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newAuditServer(t, "tnntten-denied", true)
+	client.Tenant.Use(tombstone.Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+
+	// a conflict, the root still has a child
+	require.Equal(t, http.StatusConflict, statusOf(t, http.MethodDelete, url+"/v1/tenants/"+root.ID.String(), ""))
+
+	client.Tenant.DeleteOne(child).ExecX(ctx)
+	client.Tenant.DeleteOne(root).ExecX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/"+root.ID.String()+"/audit", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var audit struct {
+		Entries []struct {
+			Outcome string `json:"outcome"`
+			Tenant  struct {
+				ID        string     `json:"id"`
+				Name      string     `json:"name"`
+				DeletedAt *time.Time `json:"deletedAt"`
+				PurgedAt  *time.Time `json:"purgedAt"`
+			} `json:"tenant"`
+		} `json:"entries"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &audit))
+	require.Len(t, audit.Entries, 1)
+
+	// the entries still name the purged tenant
+	tnt := audit.Entries[0].Tenant
+	assert.Equal(t, root.ID.String(), tnt.ID)
+	assert.Equal(t, "root", tnt.Name)
+	assert.NotNil(t, tnt.DeletedAt)
+	assert.NotNil(t, tnt.PurgedAt)
+}
+
+func TestAdminRestoreCascade(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied", restapi.WithAdminScope("tenants:admin"))
+	env.client.Tenant.Use(tombstone.Hook())
+
+	admin := map[string]string{"X-Scope": "tenants:admin"}
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(root).SaveX(env.ctx)
+	grandchild := env.client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(env.ctx)
+
+	env.client.Tenant.DeleteOne(grandchild).ExecX(env.ctx)
+	env.client.Tenant.DeleteOne(child).ExecX(env.ctx)
+
+	env.conn.Calls = nil
+
+	resp, body := send(t, http.MethodPost, env.url+"/v1/admin/tenants/"+child.ID.String()+"/restore?cascade=true&suppress_events=true", "", admin)
+	require.Equal(t, http.StatusCreated, resp.StatusCode, string(body))
+
+	restored := env.client.Tenant.GetX(env.ctx, grandchild.ID)
+	assert.Equal(t, child.ID, restored.ParentTenantID)
+
+	// a single summary in place of the two creates
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, changefeed.SummaryEventType, msg.EventType)
+	assert.Equal(t, child.ID, msg.SubjectID)
+	assert.Equal(t, "restore", msg.AdditionalData[changefeed.SummaryOperationKey])
+	assert.Equal(t, 2, msg.AdditionalData[changefeed.SummaryTenantsKey])
+}
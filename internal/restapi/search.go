@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/search"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+// WithSearchIndexer sets the indexer tenants are searched with, the SQL indexer of the client by
+// default.
+func WithSearchIndexer(i search.Indexer) Option {
+	return func(h *Handler) {
+		h.search = i
+	}
+}
+
+// tenantSearch returns the tenants whose name or display name contains the q query parameter,
+// ignoring case, ordered by name. The parent_id query parameter keeps the children of a tenant,
+// limit the number of tenants returned. Archived tenants aren't searched, and tenants the caller
+// may not get are left out, so a search may return fewer tenants than its limit when more match.
+// Searches are checked by the query guard as unscoped lists unless their route is exempted.
+func (h *Handler) tenantSearch(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	q := search.Query{Text: c.QueryParam("q")}
+	if q.Text == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "q is required")
+	}
+
+	limit, err := pagination.Limits{Default: search.DefaultLimit, Max: search.MaxLimit}.Parse(c.QueryParam("limit"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", search.MaxLimit)).WithInternal(err)
+	}
+
+	q.Limit = limit
+
+	if raw := c.QueryParam("parent_id"); raw != "" {
+		q.ParentID, err = gidx.Parse(raw)
+		if err != nil || q.ParentID.Prefix() != schema.TenantPrefix {
+			return echo.NewHTTPError(http.StatusBadRequest, "parent_id must be a tenant id")
+		}
+
+		if err := permissions.CheckAccess(ctx, q.ParentID, actionTenantGet); err != nil {
+			return errmap.HTTPError(err)
+		}
+	}
+
+	if err := h.queryGuard.Check(RouteTenantSearch, searchQuery(q)); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	ids, err := h.search.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	// the index may lag behind the tenants, those deleted since are skipped
+	found, err := h.client.Tenant.Query().Where(enttenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[gidx.PrefixedID]int, len(found))
+	for i, t := range found {
+		byID[t.ID] = i
+	}
+
+	tenants := found[:0:0]
+
+	for _, id := range ids {
+		if i, ok := byID[id]; ok {
+			tenants = append(tenants, found[i])
+		}
+	}
+
+	if tenants, err = accessible(ctx, tenants); err != nil {
+		return err
+	}
+
+	fields := redact.FromContext(ctx)
+	resp := tenantListResponse{Tenants: make([]tenant, 0, len(tenants))}
+
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, newTenant(t, fields))
+	}
+
+	return respondList(c, resp, resp.Tenants, "")
+}
+
+// searchQuery describes the search to the query guard, the text is a filter of its own.
+func searchQuery(q search.Query) querycost.Query {
+	return querycost.Query{Filters: []string{"q"}, Scoped: q.ParentID != gidx.NullPrefixedID, Limit: q.Limit}
+}
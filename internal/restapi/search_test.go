@@ -0,0 +1,140 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/querycost"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/search"
+)
+
+// searchNames searches for the tenants through the endpoint and returns their names.
+func searchNames(t *testing.T, env *eventEnv, query url.Values) []string {
+	t.Helper()
+
+	status, body := env.do(t, http.MethodGet, "/v1/tenants/search?"+query.Encode(), "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var resp struct {
+		Tenants []struct {
+			Name string `json:"name"`
+		} `json:"tenants"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	names := []string{}
+	for _, tnt := range resp.Tenants {
+		names = append(names, tnt.Name)
+	}
+
+	return names
+}
+
+func TestTenantSearch(t *testing.T) {
+	queries := map[string]url.Values{
+		"name":          {"q": {"acme"}},
+		"ignoring case": {"q": {"ACME"}},
+		"display name":  {"q": {"corp"}},
+		"wildcards":     {"q": {"_"}},
+		"limit":         {"q": {"acme"}, "limit": {"2"}},
+		"none":          {"q": {"umbrella"}},
+		"deleted":       {"q": {"gone"}},
+	}
+
+	results := map[string]map[string][]string{}
+
+	for _, backend := range []string{"sql", "bleve"} {
+		t.Run(backend, func(t *testing.T) {
+			var (
+				opts  []restapi.Option
+				index *search.Bleve
+			)
+
+			if backend == "bleve" {
+				var err error
+
+				index, err = search.NewBleve("")
+				require.NoError(t, err)
+
+				t.Cleanup(func() { index.Close() })
+
+				opts = append(opts, restapi.WithSearchIndexer(index))
+			}
+
+			env := newEventEnv(t, "tnntten-denied", opts...)
+
+			acme := env.client.Tenant.Create().SetName("acme").SetDisplayName("ACME Corporation").SaveX(env.ctx)
+			env.client.Tenant.Create().SetName("acme-staging").SetDisplayName("Acme Staging").SetParent(acme).SaveX(env.ctx)
+			env.client.Tenant.Create().SetName("acme-prod").SetDisplayName("Production").SetParent(acme).SaveX(env.ctx)
+			env.client.Tenant.Create().SetName("Beta_Labs").SetDisplayName("beta labs").SetParent(acme).SaveX(env.ctx)
+			env.client.Tenant.Create().SetName("globex").SetDisplayName("Globex Corp").SaveX(env.ctx)
+			env.client.Tenant.Create().SetName("acme-archived").SetParent(acme).SetArchived(true).SaveX(env.ctx)
+			gone := env.client.Tenant.Create().SetName("gone").SaveX(env.ctx)
+
+			if index != nil {
+				_, err := search.Rebuild(env.ctx, env.client, index)
+				require.NoError(t, err)
+			}
+
+			// the index only learns of the deletion when synced, the endpoint skips the tenant
+			env.client.Tenant.DeleteOne(gone).ExecX(env.ctx)
+
+			results[backend] = map[string][]string{}
+
+			for name, query := range queries {
+				results[backend][name] = searchNames(t, env, query)
+			}
+
+			assert.Equal(t, []string{"Beta_Labs", "acme-prod", "acme-staging"}, searchNames(t, env, url.Values{"q": {"a"}, "parent_id": {acme.ID.String()}}))
+
+			for _, query := range []string{"", "?q=", "?q=acme&limit=0", "?q=acme&limit=101", "?q=acme&parent_id=nope"} {
+				status, body := env.do(t, http.MethodGet, "/v1/tenants/search"+query, "", "")
+				assert.Equal(t, http.StatusBadRequest, status, query+": "+string(body))
+			}
+		})
+	}
+
+	require.Len(t, results, 2)
+	assert.Equal(t, results["sql"], results["bleve"], "both backends find the same tenants")
+	assert.Equal(t, []string{"acme", "acme-prod", "acme-staging"}, results["sql"]["name"])
+	assert.Equal(t, []string{"acme", "globex"}, results["sql"]["display name"])
+	assert.Equal(t, []string{"Beta_Labs"}, results["sql"]["wildcards"])
+	assert.Equal(t, []string{"acme", "acme-prod"}, results["sql"]["limit"])
+	assert.Empty(t, results["sql"]["none"])
+	assert.Empty(t, results["sql"]["deleted"])
+}
+
+func TestTenantSearchQueryGuard(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name   string
+		opts   []querycost.Option
+		status int
+	}{
+		{"unscoped rules", nil, http.StatusUnprocessableEntity},
+		{"exempt", []querycost.Option{querycost.WithExemption(restapi.RouteTenantSearch, search.MaxLimit)}, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]querycost.Option{querycost.WithRules(querycost.DefaultRules(2)...)}, tt.opts...)
+
+			client, url := newTestServer(t, restapi.WithQueryGuard(querycost.New(opts...)))
+
+			root := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+			resp, body := get(t, url+"/v1/tenants/search?q=acme&limit=10", nil)
+			require.Equal(t, tt.status, resp.StatusCode, string(body))
+
+			resp, body = get(t, url+"/v1/tenants/search?q=acme&limit=10&parent_id="+root.ID.String(), nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body), "searches under a parent are scoped")
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package restapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/servertiming"
+)
+
+func TestServerTiming(t *testing.T) {
+	ctx := context.Background()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(servertiming.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(ctx))
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(),
+		[]echo.MiddlewareFunc{servertiming.Middleware(servertiming.WithHeader(true)), perms.Middleware()},
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	client.Tenant.Create().SetName("root").ExecX(ctx)
+
+	resp, body := get(t, srv.URL+"/v1/tenants", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	header := resp.Header.Get(servertiming.HeaderServerTiming)
+	require.NotEmpty(t, header)
+
+	durations := make(map[string]float64)
+
+	for _, metric := range strings.Split(header, ",") {
+		name, dur, ok := strings.Cut(strings.TrimSpace(metric), ";dur=")
+		require.True(t, ok, metric)
+
+		durations[name], err = strconv.ParseFloat(dur, 64)
+		require.NoError(t, err, metric)
+	}
+
+	assert.Positive(t, durations[servertiming.MetricDB], "listing tenants queries the database")
+	assert.Zero(t, durations[servertiming.MetricPublish], "listing tenants publishes nothing")
+	assert.GreaterOrEqual(t, durations[servertiming.MetricTotal], durations[servertiming.MetricDB])
+
+	resp, _ = get(t, srv.URL+"/v1/tenants/not-an-id", nil)
+	assert.NotEmpty(t, resp.Header.Get(servertiming.HeaderServerTiming), "errors are timed as well")
+}
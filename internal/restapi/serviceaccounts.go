@@ -0,0 +1,265 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	entserviceaccount "go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+const (
+	// maxServiceAccountNameLength bounds the names of service accounts.
+	maxServiceAccountNameLength = 63
+
+	codeServiceAccountNotFound = "service_account_not_found"
+)
+
+// serviceAccountLimits are the page sizes of the service accounts of a tenant.
+var serviceAccountLimits = pagination.Limits{Default: 50, Max: 100}
+
+type serviceAccount struct {
+	ID          gidx.PrefixedID `json:"id"`
+	TenantID    gidx.PrefixedID `json:"tenantID"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	CreatedBy   string          `json:"createdBy,omitempty"`
+	CreatedAt   timefmt.Time    `json:"createdAt"`
+	UpdatedAt   timefmt.Time    `json:"updatedAt"`
+}
+
+func newServiceAccount(a *ent.ServiceAccount) serviceAccount {
+	return serviceAccount{
+		ID:          a.ID,
+		TenantID:    a.TenantID,
+		Name:        a.Name,
+		Description: a.Description,
+		CreatedBy:   a.CreatedBy,
+		CreatedAt:   timefmt.New(a.CreatedAt),
+		UpdatedAt:   timefmt.New(a.UpdatedAt),
+	}
+}
+
+type serviceAccountsResponse struct {
+	ServiceAccounts []serviceAccount `json:"serviceAccounts"`
+	NextPageToken   string           `json:"nextPageToken,omitempty"`
+}
+
+type serviceAccountCreateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r serviceAccountCreateRequest) validate() error {
+	var errs validation.Errors
+
+	switch {
+	case strings.TrimSpace(r.Name) == "":
+		errs.Add("name", validation.CodeRequired, "a name is required")
+	case len(r.Name) > maxServiceAccountNameLength:
+		errs.Add("name", validation.CodeNameTooLong, fmt.Sprintf("must be at most %d bytes long", maxServiceAccountNameLength))
+	}
+
+	return errs.Err()
+}
+
+// tenantServiceAccountCreate creates a service account belonging to the tenant, responding with
+// 201 Created and its url in the Location header. Its ID is the subject credentials are bound to, requests made with them may only
+// act within the subtree of the tenant. Names are unique within a tenant, reusing one is a
+// conflict.
+func (h *Handler) tenantServiceAccountCreate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req serviceAccountCreateRequest
+
+	if err := decodeRequest(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := req.validate(); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if _, err := h.client.Tenant.Get(ctx, id); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	create := h.client.ServiceAccount.Create().
+		SetTenantID(id).
+		SetName(req.Name).
+		SetDescription(req.Description)
+
+	if subject := actor.FromContext(ctx); subject != "" {
+		create = create.SetCreatedBy(subject)
+	}
+
+	account, err := create.Save(ctx)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return h.respondCreated(c, newServiceAccount(account), RouteServiceAccountGet, id, account.ID)
+}
+
+// tenantServiceAccountGet responds with a service account of the tenant.
+func (h *Handler) tenantServiceAccountGet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	accountID, err := parseServiceAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	account, err := h.client.ServiceAccount.Query().
+		Where(entserviceaccount.ID(accountID), entserviceaccount.TenantID(id)).
+		Only(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		return errServiceAccountNotFound()
+	case err != nil:
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, newServiceAccount(account))
+}
+
+// tenantServiceAccounts lists the service accounts of the tenant, ordered by ID. Pages are
+// requested with the limit and page_token query parameters, the token being the nextPageToken of
+// the previous page, or its nextCursor in FormatV11.
+func (h *Handler) tenantServiceAccounts(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := serviceAccountLimits.Parse(c.QueryParam("limit"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", serviceAccountLimits.Max)).WithInternal(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	query := h.client.ServiceAccount.Query().
+		Where(entserviceaccount.TenantID(id))
+
+	if token := c.QueryParam("page_token"); token != "" {
+		after, err := pagination.Decode(token)
+		if err != nil || after.Direction != pagination.Ascending {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid page token").WithInternal(err)
+		}
+
+		p, err := after.Predicate(entserviceaccount.FieldID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid page token").WithInternal(err)
+		}
+
+		query = query.Where(predicate.ServiceAccount(p))
+	}
+
+	accounts, err := query.
+		Order(ent.Asc(entserviceaccount.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp := serviceAccountsResponse{ServiceAccounts: make([]serviceAccount, 0, len(accounts))}
+
+	accounts, more := pagination.Trim(accounts, limit)
+	if more {
+		resp.NextPageToken = pagination.Cursor{Keys: []string{accounts[limit-1].ID.String()}}.Encode()
+	}
+
+	for _, a := range accounts {
+		resp.ServiceAccounts = append(resp.ServiceAccounts, newServiceAccount(a))
+	}
+
+	return respondList(c, resp, resp.ServiceAccounts, resp.NextPageToken)
+}
+
+// tenantServiceAccountDelete deletes a service account of the tenant, responding with 204 No
+// Content. Requests made with credentials bound to it are rejected from then on.
+func (h *Handler) tenantServiceAccountDelete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	accountID, err := parseServiceAccountID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	err = h.client.ServiceAccount.DeleteOneID(accountID).
+		Where(entserviceaccount.TenantID(id)).
+		Exec(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		return errServiceAccountNotFound()
+	case err != nil:
+		return errmap.HTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseServiceAccountID returns the service account id of the account_id path parameter.
+func parseServiceAccountID(c echo.Context) (gidx.PrefixedID, error) {
+	id, err := gidx.Parse(c.Param("account_id"))
+	if err != nil || id.Prefix() != schema.ServiceAccountPrefix {
+		return gidx.NullPrefixedID, echo.NewHTTPError(http.StatusBadRequest, "invalid service account id")
+	}
+
+	return id, nil
+}
+
+func errServiceAccountNotFound() error {
+	return echo.NewHTTPError(http.StatusNotFound, map[string]string{
+		"code":    codeServiceAccountNotFound,
+		"message": "service account not found",
+	})
+}
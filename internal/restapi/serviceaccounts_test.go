@@ -0,0 +1,235 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	entserviceaccount "go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/serviceaccount"
+)
+
+const headerTestActor = "X-Test-Actor"
+
+type serviceAccountEnv struct {
+	ctx    context.Context
+	client *ent.Client
+	conn   *eventtools.MockConnection
+	url    string
+}
+
+// newServiceAccountEnv serves the REST api allowing every action, limited to their subtree for
+// service accounts. Requests are made by the actor named in the X-Test-Actor header, testActor
+// when it is missing.
+func newServiceAccountEnv(t *testing.T) *serviceAccountEnv {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(serviceaccount.CascadeHook())
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	headerActor := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			subject := c.Request().Header.Get(headerTestActor)
+			if subject == "" {
+				subject = testActor
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, subject)))
+
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(),
+		[]echo.MiddlewareFunc{headerActor, perms.Middleware(), serviceaccount.Middleware(client)},
+	).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return &serviceAccountEnv{
+		ctx:    context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms),
+		client: client,
+		conn:   conn,
+		url:    srv.URL,
+	}
+}
+
+// as sends the request as the subject.
+func (env *serviceAccountEnv) as(t *testing.T, subject, method, path, body string) (int, []byte) {
+	t.Helper()
+
+	resp, respBody := send(t, method, env.url+path, body, map[string]string{headerTestActor: subject})
+
+	return resp.StatusCode, respBody
+}
+
+func TestTenantServiceAccounts(t *testing.T) {
+	env := newServiceAccountEnv(t)
+
+	tenant := env.client.Tenant.Create().SetName("acme").SaveX(env.ctx)
+	path := "/v1/tenants/" + tenant.ID.String() + "/service-accounts"
+
+	status, body := env.as(t, "", http.MethodPost, path, `{"name":"deployer","description":"ci deploys"}`)
+	require.Equal(t, http.StatusCreated, status, string(body))
+
+	var created struct {
+		ID          string `json:"id"`
+		TenantID    string `json:"tenantID"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		CreatedBy   string `json:"createdBy"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &created))
+	assert.Equal(t, tenant.ID.String(), created.TenantID)
+	assert.Equal(t, "deployer", created.Name)
+	assert.Equal(t, "ci deploys", created.Description)
+	assert.Equal(t, testActor, created.CreatedBy)
+	assert.True(t, serviceaccount.IsAccount(created.ID))
+
+	status, _ = env.as(t, "", http.MethodPost, path, `{"name":"deployer"}`)
+	assert.Equal(t, http.StatusConflict, status, "names are unique within a tenant")
+
+	status, _ = env.as(t, "", http.MethodPost, path, `{"name":" "}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = env.as(t, "", http.MethodPost, "/v1/tenants/tnntten-missing/service-accounts", `{"name":"deployer"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = env.as(t, "", http.MethodPost, path, `{"name":"auditor"}`)
+	require.Equal(t, http.StatusCreated, status)
+
+	var page struct {
+		ServiceAccounts []struct {
+			Name string `json:"name"`
+		} `json:"serviceAccounts"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+
+	var names []string
+
+	for token := ""; ; {
+		status, body = env.as(t, "", http.MethodGet, path+"?limit=1&page_token="+token, "")
+		require.Equal(t, http.StatusOK, status, string(body))
+
+		page.NextPageToken = ""
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page.ServiceAccounts, 1)
+
+		names = append(names, page.ServiceAccounts[0].Name)
+
+		if token = page.NextPageToken; token == "" {
+			break
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"deployer", "auditor"}, names)
+
+	status, _ = env.as(t, "", http.MethodGet, path+"?page_token=bogus", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = env.as(t, "", http.MethodDelete, path+"/"+created.ID, "")
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status, body = env.as(t, "", http.MethodDelete, path+"/"+created.ID, "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, string(body), "service_account_not_found")
+
+	status, _ = env.as(t, "", http.MethodDelete, path+"/tnntten-notanaccount", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestServiceAccountScope(t *testing.T) {
+	env := newServiceAccountEnv(t)
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	team := env.client.Tenant.Create().SetName("team").SetParentID(root.ID).SaveX(env.ctx)
+	project := env.client.Tenant.Create().SetName("project").SetParentID(team.ID).SaveX(env.ctx)
+	sibling := env.client.Tenant.Create().SetName("sibling").SetParentID(root.ID).SaveX(env.ctx)
+
+	account := env.client.ServiceAccount.Create().SetTenantID(team.ID).SetName("deployer").SaveX(env.ctx)
+	other := env.client.ServiceAccount.Create().SetTenantID(sibling.ID).SetName("deployer").SaveX(env.ctx)
+
+	subject := account.ID.String()
+
+	status, _ := env.as(t, subject, http.MethodGet, "/v1/tenants/"+team.ID.String(), "")
+	assert.Equal(t, http.StatusOK, status, "its own tenant is within the subtree")
+
+	status, _ = env.as(t, subject, http.MethodGet, "/v1/tenants/"+project.ID.String(), "")
+	assert.Equal(t, http.StatusOK, status, "descendants are within the subtree")
+
+	status, _ = env.as(t, subject, http.MethodPost, "/v1/tenants/"+project.ID.String()+"/service-accounts", `{"name":"builder"}`)
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, _ = env.as(t, subject, http.MethodGet, "/v1/tenants/"+root.ID.String(), "")
+	assert.Equal(t, http.StatusForbidden, status, "ancestors are outside the subtree")
+
+	status, _ = env.as(t, subject, http.MethodGet, "/v1/tenants/"+sibling.ID.String(), "")
+	assert.Equal(t, http.StatusForbidden, status, "siblings are outside the subtree")
+
+	status, _ = env.as(t, subject, http.MethodDelete, "/v1/tenants/"+sibling.ID.String()+"/service-accounts/"+other.ID.String(), "")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.True(t, env.client.ServiceAccount.Query().Where(entserviceaccount.ID(other.ID)).ExistX(env.ctx))
+
+	env.client.ServiceAccount.DeleteOne(account).ExecX(env.ctx)
+
+	status, _ = env.as(t, subject, http.MethodGet, "/v1/tenants/"+team.ID.String(), "")
+	assert.Equal(t, http.StatusUnauthorized, status, "deleted accounts are rejected")
+}
+
+func TestServiceAccountCascade(t *testing.T) {
+	env := newServiceAccountEnv(t)
+
+	tenant := env.client.Tenant.Create().SetName("acme").SaveX(env.ctx)
+	first := env.client.ServiceAccount.Create().SetTenantID(tenant.ID).SetName("first").SaveX(env.ctx)
+	second := env.client.ServiceAccount.Create().SetTenantID(tenant.ID).SetName("second").SaveX(env.ctx)
+
+	env.conn.Calls = nil
+
+	env.client.Tenant.DeleteOne(tenant).ExecX(env.ctx)
+
+	assert.Zero(t, env.client.ServiceAccount.Query().CountX(env.ctx))
+
+	var deleted []string
+
+	for _, call := range env.conn.Calls {
+		msg := call.Arguments.Get(1).(events.ChangeMessage)
+		if msg.EventType == string(events.DeleteChangeType) && call.Arguments.Get(0) == "service-account" {
+			deleted = append(deleted, msg.SubjectID.String())
+		}
+	}
+
+	assert.ElementsMatch(t, []string{first.ID.String(), second.ID.String()}, deleted)
+}
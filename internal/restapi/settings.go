@@ -0,0 +1,206 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// settingsOnlyKey is the additional data key flagging events of changes made to the settings alone.
+const settingsOnlyKey = "settings_only"
+
+// mimeMergePatch is the media type of JSON merge patches, RFC 7396.
+const mimeMergePatch = "application/merge-patch+json"
+
+// settingsDocument returns the settings of the tenant, an empty document when none are set.
+func settingsDocument(t *ent.Tenant) map[string]any {
+	if t.Settings == nil {
+		return map[string]any{}
+	}
+
+	return t.Settings
+}
+
+// tenantSettingsGet returns the settings document of a tenant.
+func (h *Handler) tenantSettingsGet(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := h.settingsAccess(c, actionTenantGet)
+	if err != nil {
+		return err
+	}
+
+	t, err := h.client.Tenant.Get(ctx, id)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, settingsDocument(t))
+}
+
+// tenantSettingsPut replaces the settings document of a tenant.
+func (h *Handler) tenantSettingsPut(c echo.Context) error {
+	id, err := h.settingsAccess(c, actionTenantUpdate)
+	if err != nil {
+		return err
+	}
+
+	doc, err := decodeSettings(c)
+	if err != nil {
+		return err
+	}
+
+	return h.updateSettings(c, id, func(map[string]any) map[string]any { return doc })
+}
+
+// tenantSettingsPatch applies a JSON merge patch to the settings document of a tenant: null
+// members are removed, objects are merged recursively and any other value replaces the current
+// one.
+func (h *Handler) tenantSettingsPatch(c echo.Context) error {
+	id, err := h.settingsAccess(c, actionTenantUpdate)
+	if err != nil {
+		return err
+	}
+
+	if ct := c.Request().Header.Get(echo.HeaderContentType); ct != "" {
+		if mt, _, err := mime.ParseMediaType(ct); err != nil || (mt != mimeMergePatch && mt != echo.MIMEApplicationJSON) {
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType, fmt.Sprintf("settings patches must be %s", mimeMergePatch))
+		}
+	}
+
+	patch, err := decodeSettings(c)
+	if err != nil {
+		return err
+	}
+
+	return h.updateSettings(c, id, func(current map[string]any) map[string]any {
+		return mergePatch(current, patch)
+	})
+}
+
+// settingsAccess parses the tenant id and checks the caller may perform the action and see the
+// settings.
+func (h *Handler) settingsAccess(c echo.Context, action string) (gidx.PrefixedID, error) {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return gidx.NullPrefixedID, err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, action); err != nil {
+		return gidx.NullPrefixedID, errmap.HTTPError(err)
+	}
+
+	if !redact.FromContext(ctx).Visible(redact.FieldSettings) {
+		return gidx.NullPrefixedID, echo.ErrForbidden
+	}
+
+	return id, nil
+}
+
+// decodeSettings reads a settings document or patch from the request body, which must be an object.
+func decodeSettings(c echo.Context) (map[string]any, error) {
+	var doc map[string]any
+
+	if err := json.NewDecoder(c.Request().Body).Decode(&doc); err != nil {
+		return nil, errmap.BadRequest(&validation.Error{
+			Field:   "settings",
+			Code:    validation.CodeMalformed,
+			Message: fmt.Sprintf("must be a json object: %s", err),
+			Err:     err,
+		})
+	}
+
+	if doc == nil {
+		return nil, errmap.BadRequest(&validation.Error{Field: "settings", Code: validation.CodeMalformed, Message: "must be a json object"})
+	}
+
+	return doc, nil
+}
+
+// updateSettings replaces the settings with the result of apply in a transaction, so concurrent
+// patches don't lose each other's changes. The update event is flagged as settings only and
+// published once committed.
+func (h *Handler) updateSettings(c echo.Context, id gidx.PrefixedID, apply func(map[string]any) map[string]any) error {
+	ctx := c.Request().Context()
+
+	txCtx, batch := changefeed.WithBatch(changefeed.WithAdditionalData(ctx, map[string]any{settingsOnlyKey: true}))
+
+	tx, err := h.client.Tx(txCtx)
+	if err != nil {
+		return err
+	}
+
+	t, err := replaceSettings(txCtx, tx, h.validator, id, apply)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.log(c).Errorw("failed to roll back settings update", "error", rerr)
+		}
+
+		return errmap.HTTPError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the settings are committed, report them even though the event was lost
+		h.log(c).Errorw("failed to publish settings update", "error", err)
+	}
+
+	return c.JSON(http.StatusOK, settingsDocument(t))
+}
+
+func replaceSettings(ctx context.Context, tx *ent.Tx, p *validation.Pipeline, id gidx.PrefixedID, apply func(map[string]any) map[string]any) (*ent.Tenant, error) {
+	t, err := tx.Tenant.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := apply(settingsDocument(t))
+
+	if err := p.ValidateUpdate(ctx, &validation.Tenant{Settings: settings}); err != nil {
+		return nil, err
+	}
+
+	return tx.Tenant.UpdateOne(t).SetSettings(settings).Save(ctx)
+}
+
+// mergePatch applies a JSON merge patch object to the target as described by RFC 7396, returning
+// the result without modifying the target.
+func mergePatch(target, patch map[string]any) map[string]any {
+	result := make(map[string]any, len(target)+len(patch))
+
+	for k, v := range target {
+		result[k] = v
+	}
+
+	for k, v := range patch {
+		switch v := v.(type) {
+		case nil:
+			delete(result, k)
+		case map[string]any:
+			current, _ := result[k].(map[string]any)
+			result[k] = mergePatch(current, v)
+		default:
+			result[k] = v
+		}
+	}
+
+	return result
+}
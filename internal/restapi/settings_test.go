@@ -0,0 +1,115 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+)
+
+const mimeMergePatch = "application/merge-patch+json"
+
+func TestTenantSettings(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	tnt := env.client.Tenant.Create().SetName("acme").SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	path := "/v1/tenants/" + tnt.ID.String() + "/settings"
+
+	status, body := env.do(t, http.MethodGet, path, "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{}`, string(body))
+
+	env.conn.Calls = nil
+
+	status, body = env.do(t, http.MethodPut, path, echo.MIMEApplicationJSON, `{"region":"us-east","flags":{"beta":true,"dark":false}}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"region":"us-east","flags":{"beta":true,"dark":false}}`, string(body))
+
+	updated := env.client.Tenant.GetX(env.ctx, tnt.ID)
+	assert.True(t, updated.UpdatedAt.After(tnt.UpdatedAt), "updated_at is bumped")
+
+	env.conn.AssertNumberOfCalls(t, "PublishChange", 1)
+
+	msg := env.conn.Calls[0].Arguments.Get(1).(events.ChangeMessage)
+	assert.Equal(t, string(events.UpdateChangeType), msg.EventType)
+	assert.Equal(t, true, msg.AdditionalData["settings_only"])
+
+	status, body = env.do(t, http.MethodPatch, path, mimeMergePatch, `{"region":null,"flags":{"dark":true,"new":1},"zones":["a","b"]}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"flags":{"beta":true,"dark":true,"new":1},"zones":["a","b"]}`, string(body))
+
+	status, body = env.do(t, http.MethodGet, path, "", "")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.JSONEq(t, `{"flags":{"beta":true,"dark":true,"new":1},"zones":["a","b"]}`, string(body))
+
+	resp, body := get(t, env.url+"/v1/tenants/"+tnt.ID.String()+"?include=settings", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var withSettings map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &withSettings))
+	assert.Equal(t, map[string]any{"flags": map[string]any{"beta": true, "dark": true, "new": float64(1)}, "zones": []any{"a", "b"}}, withSettings["settings"])
+
+	plain, body := get(t, env.url+"/v1/tenants/"+tnt.ID.String(), nil)
+	require.Equal(t, http.StatusOK, plain.StatusCode, string(body))
+	assert.NotContains(t, string(body), "settings")
+	assert.NotEqual(t, resp.Header.Get("ETag"), plain.Header.Get("ETag"))
+
+	resp, body = get(t, env.url+"/v1/tenants/"+tnt.ID.String()+"?include=grandchildren", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+}
+
+func TestTenantSettingsRejected(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	tnt := env.client.Tenant.Create().SetName("acme").SetSettings(map[string]any{"region": "us-east"}).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	path := "/v1/tenants/" + tnt.ID.String() + "/settings"
+
+	testCases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		{name: "too large", method: http.MethodPut, contentType: echo.MIMEApplicationJSON, body: `{"notes":"` + strings.Repeat("x", 256) + `"}`, status: http.StatusUnprocessableEntity, code: "settings_too_large"},
+		{name: "too deep", method: http.MethodPatch, contentType: mimeMergePatch, body: `{"a":{"b":{"c":{}}}}`, status: http.StatusUnprocessableEntity, code: "settings_too_deep"},
+		{name: "not an object", method: http.MethodPut, contentType: echo.MIMEApplicationJSON, body: `["region"]`, status: http.StatusBadRequest},
+		{name: "null document", method: http.MethodPut, contentType: echo.MIMEApplicationJSON, body: `null`, status: http.StatusBadRequest},
+		{name: "patch media type", method: http.MethodPatch, contentType: "application/json-patch+json", body: `[]`, status: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := env.do(t, tc.method, path, tc.contentType, tc.body)
+			require.Equal(t, tc.status, status, string(body))
+
+			if tc.code != "" {
+				var resp struct {
+					Code string `json:"code"`
+				}
+
+				require.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, tc.code, resp.Code)
+			}
+		})
+	}
+
+	assert.Equal(t, map[string]any{"region": "us-east"}, env.client.Tenant.GetX(env.ctx, tnt.ID).Settings)
+
+	status, body := env.do(t, http.MethodGet, "/v1/tenants/tnntten-denied/settings", "", "")
+	assert.Equal(t, http.StatusForbidden, status, string(body))
+
+	status, body = env.do(t, http.MethodPut, "/v1/tenants/tnntten-missing/settings", echo.MIMEApplicationJSON, `{}`)
+	assert.Equal(t, http.StatusNotFound, status, string(body))
+}
@@ -0,0 +1,483 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/concurrency"
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/traversal"
+)
+
+const (
+	// DefaultStatsCacheTTL is the default time subtree statistics are served from the cache for.
+	DefaultStatsCacheTTL = 30 * time.Second
+
+	// DefaultStatsRefreshInterval is the default least time between two refreshes of the
+	// statistics of a tenant forced with refresh=true.
+	DefaultStatsRefreshInterval = 10 * time.Second
+)
+
+// statsMaxDepth bounds the subtree walk, keeping a cycle in the hierarchy from recursing forever.
+const statsMaxDepth = 1000
+
+// statsQuery walks the subtree below the tenant in $2 and aggregates it in a single statement.
+// Every row carries whether the tenant itself is scheduled for deletion and whether one of its
+// ancestors is, the latter seeded with $1 for the ancestors above the subtree. SQLite numbers the
+// parameters in the order they first appear, so they have to be numbered that way too.
+const statsQuery = `
+WITH RECURSIVE subtree (id, depth, pending, inherited) AS (
+	SELECT id, 0,
+		CASE WHEN deletion_scheduled_at IS NULL THEN 0 ELSE 1 END,
+		CAST($1 AS INTEGER)
+	FROM tenants
+	WHERE id = $2
+	UNION ALL
+	SELECT t.id, s.depth + 1,
+		CASE WHEN t.deletion_scheduled_at IS NULL THEN 0 ELSE 1 END,
+		CASE WHEN s.pending = 1 OR s.inherited = 1 THEN 1 ELSE 0 END
+	FROM tenants t
+	JOIN subtree s ON t.parent_tenant_id = s.id
+	WHERE s.depth < $3
+)
+SELECT
+	COALESCE(SUM(CASE WHEN depth = 1 THEN 1 ELSE 0 END), 0),
+	COUNT(*) - 1,
+	COALESCE(MAX(depth), 0),
+	COALESCE(SUM(CASE WHEN depth > 0 AND pending = 0 AND inherited = 0 THEN 1 ELSE 0 END), 0),
+	COALESCE(SUM(CASE WHEN depth > 0 AND pending = 1 THEN 1 ELSE 0 END), 0),
+	COALESCE(SUM(CASE WHEN depth > 0 AND pending = 0 AND inherited = 1 THEN 1 ELSE 0 END), 0)
+FROM subtree`
+
+// WithStatsCacheTTL sets the time subtree statistics may be served from the cache for when the
+// client doesn't ask for exact ones.
+func WithStatsCacheTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		if ttl > 0 {
+			h.stats.ttl = ttl
+		}
+	}
+}
+
+// WithStatsStaleAfter sets the age past which cached statistics are refreshed in the background
+// while still being served, until the ttl passes. Cached statistics are only computed again once
+// the ttl passed when it isn't positive, the default.
+func WithStatsStaleAfter(d time.Duration) Option {
+	return func(h *Handler) {
+		h.stats.staleAfter = d
+	}
+}
+
+// WithStatsRefreshInterval sets the least time between two refreshes of the statistics of a
+// tenant forced with refresh=true, DefaultStatsRefreshInterval by default.
+func WithStatsRefreshInterval(d time.Duration) Option {
+	return func(h *Handler) {
+		if d > 0 {
+			h.stats.refreshInterval = d
+		}
+	}
+}
+
+type tenantStats struct {
+	ID              gidx.PrefixedID  `json:"id"`
+	ChildCount      int64            `json:"childCount"`
+	DescendantCount int64            `json:"descendantCount"`
+	MaxDepth        int64            `json:"maxDepth"`
+	StatusCounts    map[string]int64 `json:"statusCounts"`
+	ComputedAt      timefmt.Time     `json:"computedAt"`
+
+	// CreationFreeze is the freeze rejecting creates in the subtree, of the tenant or of one of its
+	// ancestors. Cached statistics drop it once it expires.
+	CreationFreeze *creationFreeze `json:"creationFreeze,omitempty"`
+}
+
+// statsCache keeps the recently computed statistics of each tenant.
+type statsCache struct {
+	mu              sync.Mutex
+	ttl             time.Duration
+	staleAfter      time.Duration
+	refreshInterval time.Duration
+	entries         map[gidx.PrefixedID]tenantStats
+	limits          map[gidx.PrefixedID]limitCounts
+
+	// refreshes shares the computations of the cached statistics of a tenant, refreshing tracks the
+	// tenants refreshed in the background and forced the last refresh forced for each tenant.
+	refreshes  *concurrency.Coalescer[tenantStats]
+	refreshing map[gidx.PrefixedID]bool
+	forced     map[gidx.PrefixedID]time.Time
+}
+
+func newStatsCache() *statsCache {
+	return &statsCache{
+		ttl:             DefaultStatsCacheTTL,
+		refreshInterval: DefaultStatsRefreshInterval,
+		entries:         map[gidx.PrefixedID]tenantStats{},
+		limits:          map[gidx.PrefixedID]limitCounts{},
+		refreshes:       concurrency.NewCoalescer[tenantStats](),
+		refreshing:      map[gidx.PrefixedID]bool{},
+		forced:          map[gidx.PrefixedID]time.Time{},
+	}
+}
+
+// get returns the statistics of the tenant if they were computed within the ttl.
+func (c *statsCache) get(id gidx.PrefixedID, now time.Time) (tenantStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.entries[id]
+	if !ok || now.Sub(stats.ComputedAt.Time) >= c.ttl {
+		return tenantStats{}, false
+	}
+
+	return stats, true
+}
+
+// stale reports whether the cached statistics are old enough to be refreshed in the background.
+func (c *statsCache) stale(stats tenantStats, now time.Time) bool {
+	return c.staleAfter > 0 && now.Sub(stats.ComputedAt.Time) >= c.staleAfter
+}
+
+// put stores the statistics, dropping the expired ones so the cache only holds recent entries.
+func (c *statsCache) put(stats tenantStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, cached := range c.entries {
+		if stats.ComputedAt.Sub(cached.ComputedAt.Time) >= c.ttl {
+			delete(c.entries, id)
+		}
+	}
+
+	c.entries[stats.ID] = stats
+}
+
+// startRefresh reports whether a background refresh of the statistics of the tenant may start,
+// false when one is already running. endRefresh must be called once it is done.
+func (c *statsCache) startRefresh(id gidx.PrefixedID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refreshing[id] {
+		return false
+	}
+
+	c.refreshing[id] = true
+
+	return true
+}
+
+func (c *statsCache) endRefresh(id gidx.PrefixedID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.refreshing, id)
+}
+
+// allowRefresh reports whether a refresh of the statistics of the tenant may be forced now,
+// recording it when it may, or how long until it may otherwise.
+func (c *statsCache) allowRefresh(id gidx.PrefixedID, now time.Time) (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if last, ok := c.forced[id]; ok && now.Sub(last) < c.refreshInterval {
+		return false, c.refreshInterval - now.Sub(last)
+	}
+
+	for forcedID, last := range c.forced {
+		if now.Sub(last) >= c.refreshInterval {
+			delete(c.forced, forcedID)
+		}
+	}
+
+	c.forced[id] = now
+
+	return true, 0
+}
+
+// getLimits returns the limit counts of the tenant if they were computed within the ttl.
+func (c *statsCache) getLimits(id gidx.PrefixedID, now time.Time) (limitCounts, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts, ok := c.limits[id]
+	if !ok || now.Sub(counts.computedAt) >= c.ttl {
+		return limitCounts{}, false
+	}
+
+	return counts, true
+}
+
+// putLimits stores the limit counts of the tenant, dropping the expired ones as put does.
+func (c *statsCache) putLimits(id gidx.PrefixedID, counts limitCounts) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cachedID, cached := range c.limits {
+		if counts.computedAt.Sub(cached.computedAt) >= c.ttl {
+			delete(c.limits, cachedID)
+		}
+	}
+
+	c.limits[id] = counts
+}
+
+// forget drops the statistics and limit counts of the tenants.
+func (c *statsCache) forget(ids ...gidx.PrefixedID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.entries, id)
+		delete(c.limits, id)
+	}
+}
+
+// tenantStats responds with statistics about the subtree below the tenant: the number of children
+// and descendants, how deep the subtree is and how many descendants have each effective status.
+// The creation freeze of the subtree, if any, is included as well. computedAt and the Age header
+// tell how recent the statistics are, see readStats for when they are computed.
+func (h *Handler) tenantStats(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	stats, err := h.readStats(c, id)
+	if err != nil {
+		return err
+	}
+
+	return h.respondStats(c, stats)
+}
+
+// readStats returns the statistics of the tenant the caller was checked to have access to. They
+// are computed on every request unless exact=false is given, in which case statistics computed
+// within the cache ttl are served. Those older than the staleness threshold are still served while
+// a single refresh runs in the background. refresh=true computes them again for the cache, at most
+// once per refresh interval for each tenant, rejecting the requests in between with 429.
+// Concurrent requests computing the statistics of the tenant share their computation, when reads
+// are coalesced for exact ones.
+func (h *Handler) readStats(c echo.Context, id gidx.PrefixedID) (tenantStats, error) {
+	ctx := c.Request().Context()
+
+	exact, err := parseBoolParam(c, "exact", true)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	refresh, err := parseBoolParam(c, "refresh", false)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	var stats tenantStats
+
+	switch now := h.clock.Now().UTC(); {
+	case refresh:
+		if ok, wait := h.stats.allowRefresh(id, now); !ok {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+
+			return tenantStats{}, echo.NewHTTPError(http.StatusTooManyRequests, "statistics were refreshed recently, retry later")
+		}
+
+		stats, err = h.refreshStats(ctx, id)
+	case exact:
+		key := "stats|" + id.String() + "|" + redact.FromContext(ctx).Key()
+
+		stats, _, err = h.statsReads.Do(ctx, key, func(ctx context.Context) (tenantStats, error) {
+			return h.computeStats(ctx, id)
+		})
+	default:
+		cached, ok := h.stats.get(id, now)
+		if !ok {
+			stats, err = h.refreshStats(ctx, id)
+
+			break
+		}
+
+		if h.stats.stale(cached, now) {
+			h.revalidateStats(id)
+		}
+
+		stats = cached
+	}
+
+	if err != nil {
+		return tenantStats{}, errmap.HTTPError(err)
+	}
+
+	return stats, nil
+}
+
+// parseBoolParam parses the boolean query parameter, the default when it isn't given.
+func parseBoolParam(c echo.Context, name string, def bool) (bool, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid %s %q", name, raw)).WithInternal(err)
+	}
+
+	return v, nil
+}
+
+// computeStats computes the statistics of the tenant with its creation freeze and caches them.
+func (h *Handler) computeStats(ctx context.Context, id gidx.PrefixedID) (tenantStats, error) {
+	t, err := h.client.Tenant.Get(ctx, id)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	stats, err := h.subtreeStats(ctx, t)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	active, err := h.creation.Active(ctx, h.client, t.ID)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	if active != nil {
+		stats.CreationFreeze = &creationFreeze{TenantID: active.TenantID, Until: timefmt.New(active.Until)}
+	}
+
+	stats.ComputedAt = timefmt.New(h.clock.Now().UTC())
+
+	h.stats.put(stats)
+
+	return stats, nil
+}
+
+// refreshStats computes the cached statistics of the tenant again, joining the computation in
+// flight for it if any.
+func (h *Handler) refreshStats(ctx context.Context, id gidx.PrefixedID) (tenantStats, error) {
+	stats, _, err := h.stats.refreshes.Do(ctx, id.String(), func(ctx context.Context) (tenantStats, error) {
+		return h.computeStats(ctx, id)
+	})
+
+	return stats, err
+}
+
+// revalidateStats refreshes the cached statistics of the tenant in the background, unless a
+// background refresh of them is already running. The refresh outlives the request.
+func (h *Handler) revalidateStats(id gidx.PrefixedID) {
+	if !h.stats.startRefresh(id) {
+		return
+	}
+
+	go func() {
+		defer h.stats.endRefresh(id)
+
+		_, err := h.refreshStats(context.Background(), id)
+
+		switch {
+		case ent.IsNotFound(err):
+			h.stats.forget(id)
+		case err != nil:
+			h.logger.Warnw("failed to refresh tenant statistics", "tenant_id", id, "error", err)
+		}
+	}()
+}
+
+// respondStats responds with the statistics, dropping their creation freeze once it expired.
+func (h *Handler) respondStats(c echo.Context, stats tenantStats) error {
+	if stats.CreationFreeze != nil && !stats.CreationFreeze.Until.After(h.clock.Now()) {
+		stats.CreationFreeze = nil
+	}
+
+	h.setAge(c, stats.ComputedAt.Time)
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// setAge sets the Age header to the whole seconds since the statistics were computed.
+func (h *Handler) setAge(c echo.Context, computedAt time.Time) {
+	age := h.clock.Now().Sub(computedAt)
+	if age < 0 {
+		age = 0
+	}
+
+	c.Response().Header().Set("Age", strconv.Itoa(int(age/time.Second)))
+}
+
+// subtreeStats computes the statistics of the subtree below the tenant, recording the walk.
+func (h *Handler) subtreeStats(ctx context.Context, t *ent.Tenant) (tenantStats, error) {
+	start := time.Now()
+
+	stats, err := computeSubtreeStats(ctx, h.client, t)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	h.traversals.Observe(ctx, traversal.OpDescendants, int(stats.DescendantCount), int(stats.MaxDepth), time.Since(start))
+
+	return stats, nil
+}
+
+func computeSubtreeStats(ctx context.Context, client *ent.Client, t *ent.Tenant) (tenantStats, error) {
+	status, err := deletion.EffectiveStatus(ctx, client, t)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	inherited := 0
+	if status == deletion.StatusParentDeleted {
+		inherited = 1
+	}
+
+	rows, err := client.QueryContext(ctx, statsQuery, inherited, t.ID, statsMaxDepth)
+	if err != nil {
+		return tenantStats{}, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return tenantStats{}, err
+		}
+
+		return tenantStats{}, sql.ErrNoRows
+	}
+
+	var active, pending, parentDeleted int64
+
+	stats := tenantStats{ID: t.ID}
+
+	if err := rows.Scan(&stats.ChildCount, &stats.DescendantCount, &stats.MaxDepth, &active, &pending, &parentDeleted); err != nil {
+		return tenantStats{}, err
+	}
+
+	stats.StatusCounts = map[string]int64{
+		deletion.StatusActive:          active,
+		deletion.StatusPendingDeletion: pending,
+		deletion.StatusParentDeleted:   parentDeleted,
+	}
+
+	return stats, rows.Close()
+}
@@ -0,0 +1,344 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/clock"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+type statsResponse struct {
+	ChildCount      int64            `json:"childCount"`
+	DescendantCount int64            `json:"descendantCount"`
+	MaxDepth        int64            `json:"maxDepth"`
+	StatusCounts    map[string]int64 `json:"statusCounts"`
+	ComputedAt      time.Time        `json:"computedAt"`
+}
+
+func TestTenantStats(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	// root
+	// ├── deleted (pending deletion)
+	// │   └── orphan
+	// │       └── grandorphan
+	// ├── kept
+	// │   └── leaf
+	// └── sibling
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("deleted").SetParent(root).SaveX(ctx)
+	orphan := client.Tenant.Create().SetName("orphan").SetParent(deleted).SaveX(ctx)
+	client.Tenant.Create().SetName("grandorphan").SetParent(orphan).SaveX(ctx)
+	kept := client.Tenant.Create().SetName("kept").SetParent(root).SaveX(ctx)
+	leaf := client.Tenant.Create().SetName("leaf").SetParent(kept).SaveX(ctx)
+	sibling := client.Tenant.Create().SetName("sibling").SetParent(root).SaveX(ctx)
+
+	client.Tenant.UpdateOne(deleted).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(ctx)
+
+	stats := func(path string) statsResponse {
+		resp, body := get(t, url+"/v1/tenants/"+path, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got statsResponse
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		return got
+	}
+
+	got := stats(root.ID.String() + "/stats")
+	assert.Equal(t, int64(3), got.ChildCount)
+	assert.Equal(t, int64(6), got.DescendantCount)
+	assert.Equal(t, int64(3), got.MaxDepth)
+	assert.Equal(t, map[string]int64{"active": 3, "pending_deletion": 1, "parent_deleted": 2}, got.StatusCounts)
+	assert.False(t, got.ComputedAt.IsZero())
+
+	got = stats(kept.ID.String() + "/stats")
+	assert.Equal(t, int64(1), got.ChildCount)
+	assert.Equal(t, int64(1), got.DescendantCount)
+	assert.Equal(t, int64(1), got.MaxDepth)
+	assert.Equal(t, map[string]int64{"active": 1, "pending_deletion": 0, "parent_deleted": 0}, got.StatusCounts)
+
+	// the deletion pending above the subtree applies to it as well
+	got = stats(orphan.ID.String() + "/stats")
+	assert.Equal(t, int64(1), got.DescendantCount)
+	assert.Equal(t, map[string]int64{"active": 0, "pending_deletion": 0, "parent_deleted": 1}, got.StatusCounts)
+
+	got = stats(leaf.ID.String() + "/stats")
+	assert.Equal(t, int64(0), got.ChildCount)
+	assert.Equal(t, int64(0), got.DescendantCount)
+	assert.Equal(t, int64(0), got.MaxDepth)
+
+	exact := stats(root.ID.String() + "/stats")
+
+	client.Tenant.Create().SetName("late").SetParent(sibling).SaveX(ctx)
+
+	cached := stats(root.ID.String() + "/stats?exact=false")
+	assert.Equal(t, int64(6), cached.DescendantCount, "inexact statistics are served from the cache")
+	assert.True(t, exact.ComputedAt.Equal(cached.ComputedAt))
+
+	fresh := stats(root.ID.String() + "/stats")
+	assert.Equal(t, int64(7), fresh.DescendantCount)
+	assert.Equal(t, int64(3), fresh.MaxDepth)
+
+	resp, _ := get(t, url+"/v1/tenants/"+root.ID.String()+"/stats?exact=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = get(t, url+"/v1/tenants/tnntten-missing/stats", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTenantStatsCacheTTL(t *testing.T) {
+	ctx := context.Background()
+
+	fake := clock.NewFake(time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+
+	client, url := newTestServer(t, restapi.WithClock(fake), restapi.WithStatsCacheTTL(time.Minute))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	stats := func() statsResponse {
+		resp, body := get(t, url+"/v1/tenants/"+root.ID.String()+"/stats?exact=false", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got statsResponse
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		return got
+	}
+
+	first := stats()
+	assert.Equal(t, int64(0), first.ChildCount)
+	assert.True(t, fake.Now().Equal(first.ComputedAt))
+
+	client.Tenant.Create().SetName("child").SetParent(root).ExecX(ctx)
+
+	fake.Advance(59 * time.Second)
+	assert.Equal(t, int64(0), stats().ChildCount, "served from the cache within the ttl")
+
+	fake.Advance(time.Second)
+
+	expired := stats()
+	assert.Equal(t, int64(1), expired.ChildCount, "computed again once the ttl passed")
+	assert.True(t, fake.Now().Equal(expired.ComputedAt))
+}
+
+func TestTenantStatsStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+
+	fake := clock.NewFake(time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+
+	client, url := newTestServer(t,
+		restapi.WithClock(fake),
+		restapi.WithStatsCacheTTL(time.Minute),
+		restapi.WithStatsStaleAfter(10*time.Second),
+		restapi.WithStatsRefreshInterval(30*time.Second),
+	)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	stats := func(t *testing.T, query string) (statsResponse, string) {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants/"+root.ID.String()+"/stats?"+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got statsResponse
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		return got, resp.Header.Get("Age")
+	}
+
+	first, age := stats(t, "exact=false")
+	assert.Equal(t, int64(0), first.ChildCount)
+	assert.Equal(t, "0", age)
+
+	client.Tenant.Create().SetName("child").SetParent(root).ExecX(ctx)
+
+	fake.Advance(5 * time.Second)
+
+	cached, age := stats(t, "exact=false")
+	assert.Equal(t, int64(0), cached.ChildCount, "served from the cache while fresh")
+	assert.Equal(t, "5", age)
+
+	fake.Advance(5 * time.Second)
+
+	stale, age := stats(t, "exact=false")
+	assert.Equal(t, int64(0), stale.ChildCount, "stale statistics are still served at once")
+	assert.Equal(t, "10", age)
+
+	require.Eventually(t, func() bool {
+		refreshed, _ := stats(t, "exact=false")
+
+		return refreshed.ChildCount == 1
+	}, time.Second, 10*time.Millisecond, "refreshed in the background")
+
+	refreshed, age := stats(t, "exact=false")
+	assert.True(t, fake.Now().Equal(refreshed.ComputedAt))
+	assert.Equal(t, "0", age)
+
+	resp, body := get(t, url+"/v1/tenants/aggregate?group_by=status&exact=false&under="+root.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `{"value":"active","count":1}`, "the aggregate shares the cache")
+
+	client.Tenant.Create().SetName("forced").SetParent(root).ExecX(ctx)
+
+	fake.Advance(time.Second)
+
+	forced, age := stats(t, "exact=false&refresh=true")
+	assert.Equal(t, int64(2), forced.ChildCount, "a forced refresh computes the statistics at once")
+	assert.Equal(t, "0", age)
+
+	fake.Advance(10 * time.Second)
+
+	resp, body = get(t, url+"/v1/tenants/"+root.ID.String()+"/stats?refresh=true", nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, string(body))
+	assert.Equal(t, "20", resp.Header.Get("Retry-After"))
+
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=status&refresh=true&under="+root.ID.String(), nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "the aggregate shares the refresh limit: %s", body)
+
+	fake.Advance(20 * time.Second)
+
+	_, age = stats(t, "refresh=true")
+	assert.Equal(t, "0", age, "refreshes are allowed again once the interval passed")
+
+	resp, _ = get(t, url+"/v1/tenants/"+root.ID.String()+"/stats?refresh=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTenantAggregate(t *testing.T) {
+	ctx := context.Background()
+
+	fake := clock.NewFake(time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC))
+
+	client, url := newTestServer(t, restapi.WithClock(fake))
+
+	// root
+	// ├── deleted (pending deletion)
+	// │   └── orphan
+	// │       └── grandorphan
+	// ├── kept
+	// │   └── leaf
+	// └── sibling
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("deleted").SetParent(root).SaveX(ctx)
+	orphan := client.Tenant.Create().SetName("orphan").SetParent(deleted).SaveX(ctx)
+	client.Tenant.Create().SetName("grandorphan").SetParent(orphan).SaveX(ctx)
+	kept := client.Tenant.Create().SetName("kept").SetParent(root).SaveX(ctx)
+	client.Tenant.Create().SetName("leaf").SetParent(kept).SaveX(ctx)
+	client.Tenant.Create().SetName("sibling").SetParent(root).SaveX(ctx)
+
+	client.Tenant.UpdateOne(deleted).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/aggregate?group_by=status&under="+root.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.JSONEq(t, `{
+		"groupBy": "status",
+		"under": "`+root.ID.String()+`",
+		"groups": [
+			{"value": "active", "count": 3},
+			{"value": "parent_deleted", "count": 2},
+			{"value": "pending_deletion", "count": 1}
+		],
+		"truncated": false,
+		"computedAt": "2026-03-01T12:00:00.000Z"
+	}`, string(body))
+	assert.Equal(t, "0", resp.Header.Get("Age"))
+
+	// only the subtree is counted, empty groups are left out
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=status&under="+kept.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.JSONEq(t, `{"groupBy":"status","under":"`+kept.ID.String()+`","groups":[{"value":"active","count":1}],"truncated":false,"computedAt":"2026-03-01T12:00:00.000Z"}`, string(body))
+
+	for _, query := range []string{
+		"group_by=status",
+		"group_by=label:Cost%20Center&under=" + root.ID.String(),
+		"group_by=label:&under=" + root.ID.String(),
+		"group_by=kind&under=" + root.ID.String(),
+		"group_by=status&under=not-an-id",
+	} {
+		resp, body = get(t, url+"/v1/tenants/aggregate?"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "%s: %s", query, body)
+	}
+
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=status&under=tnntten-missing", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
+
+func TestTenantAggregateLabels(t *testing.T) {
+	ctx := context.Background()
+
+	policy := redact.NewPolicy(map[string][]string{
+		"tenants:full":    {redact.AllFields},
+		"tenants:summary": {redact.FieldName},
+	})
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware, policy.Middleware()})
+	full := map[string]string{"X-Scope": "tenants:full"}
+
+	// root               cost-center=platform
+	// ├── payments       cost-center=fin
+	// │   ├── checkout
+	// │   └── ledger     cost-center=acct
+	// ├── search
+	// └── untagged       cost-center=""
+	//     └── scratch
+	root := client.Tenant.Create().SetName("root").SetLabels(map[string]string{"cost-center": "platform"}).SaveX(ctx)
+	payments := client.Tenant.Create().SetName("payments").SetParent(root).SetLabels(map[string]string{"cost-center": "fin", "env": "prod"}).SaveX(ctx)
+	client.Tenant.Create().SetName("checkout").SetParent(payments).SaveX(ctx)
+	client.Tenant.Create().SetName("ledger").SetParent(payments).SetLabels(map[string]string{"cost-center": "acct"}).SaveX(ctx)
+	client.Tenant.Create().SetName("search").SetParent(root).SaveX(ctx)
+	untagged := client.Tenant.Create().SetName("untagged").SetParent(root).SetLabels(map[string]string{"cost-center": ""}).SaveX(ctx)
+	client.Tenant.Create().SetName("scratch").SetParent(untagged).SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/aggregate?group_by=label:cost-center&under="+root.ID.String(), full)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var agg struct {
+		GroupBy string `json:"groupBy"`
+		Groups  []struct {
+			Value string `json:"value"`
+			Count int64  `json:"count"`
+		} `json:"groups"`
+		Truncated bool `json:"truncated"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &agg))
+	assert.Equal(t, "label:cost-center", agg.GroupBy)
+	assert.False(t, agg.Truncated)
+
+	// the effective value is counted, the tenants clearing the label aren't
+	groups := map[string]int64{}
+
+	for _, g := range agg.Groups {
+		groups[g.Value] = g.Count
+	}
+
+	assert.Equal(t, map[string]int64{"fin": 2, "platform": 1, "acct": 1}, groups)
+	assert.Equal(t, "fin", agg.Groups[0].Value, "largest group first")
+
+	// the value inherited by the under tenant counts for its descendants
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=label:env&under="+payments.ID.String(), full)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"groups":[{"value":"prod","count":2}]`)
+
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=label:cost-center&under="+root.ID.String(), map[string]string{"X-Scope": "tenants:summary"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "labels are redacted: %s", body)
+
+	resp, body = get(t, url+"/v1/tenants/aggregate?group_by=label:cost-center&under=tnntten-missing", full)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, string(body))
+}
@@ -0,0 +1,70 @@
+package restapi
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/audit"
+	"go.infratographer.com/tenant-api/internal/changefeed"
+)
+
+// suppressEventsParam is the query parameter of the admin bulk operations suppressing the change
+// events of the tenants they touch, publishing a single summary instead.
+const suppressEventsParam = "suppress_events"
+
+// Bulk operations of the summary events.
+const (
+	bulkImport  = "import"
+	bulkRebuild = "rebuild"
+	bulkRestore = "restore"
+)
+
+// suppressibleRoutes are the admin bulk operations which may suppress their change events, they
+// are audited. No other route reads the suppress_events parameter, so the changes of the regular
+// api are always published.
+var suppressibleRoutes = map[string]bool{
+	RouteAdminImport:  true,
+	RouteAdminRebuild: true,
+	RouteAdminRestore: true,
+}
+
+// suppressEvents reports whether the request asks for its change events to be suppressed with
+// the suppress_events parameter, and if so audits it as suppressing the events of the subtree
+// under root, whether rejected attempts are recorded or not. The operation suppresses them with
+// changefeed.WithSuppression.
+func suppressEvents(c echo.Context, root gidx.PrefixedID) (bool, error) {
+	suppress, err := parseBoolParam(c, suppressEventsParam, false)
+	if err != nil || !suppress {
+		return false, err
+	}
+
+	audit.Suppress(c, root)
+
+	return true, nil
+}
+
+// suppression returns a context suppressing the change events when suppress is set, along with
+// the suppression counting them. Otherwise ctx is returned as is with a nil suppression.
+func suppression(ctx context.Context, suppress bool) (context.Context, *changefeed.Suppression) {
+	if !suppress {
+		return ctx, nil
+	}
+
+	return changefeed.WithSuppression(ctx)
+}
+
+// publishSummary publishes the summary of the changes the bulk operation suppressed, unless it
+// didn't suppress them or changes aren't published at all. The operation succeeded, failing to
+// publish its summary is logged.
+func (h *Handler) publishSummary(ctx context.Context, logger *zap.SugaredLogger, s *changefeed.Suppression, summary changefeed.Summary) {
+	if s == nil || h.client.EventsPublisher == nil {
+		return
+	}
+
+	if err := changefeed.PublishSummary(ctx, h.client.EventsPublisher, summary, s); err != nil {
+		logger.Errorw("failed to publish the summary of suppressed changes", "operation", summary.Operation, "tenant_id", summary.Root, "error", err)
+	}
+}
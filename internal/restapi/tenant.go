@@ -0,0 +1,343 @@
+package restapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/deletion"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+// Error codes of malformed tenant URNs.
+const (
+	codeInvalidURN             = "invalid_urn"
+	codeUnexpectedResourceType = "unexpected_resource_type"
+)
+
+// tenant is the REST representation of a tenant, using the same field names as the graph api.
+// Fields redacted for the caller are omitted, visible empty fields are still included.
+type tenant struct {
+	ID          gidx.PrefixedID  `json:"id"`
+	URN         string           `json:"urn"`
+	Name        *string          `json:"name,omitempty"`
+	DisplayName *string          `json:"displayName,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	ParentID    *gidx.PrefixedID `json:"parentID,omitempty"`
+	CreatedAt   *timefmt.Time    `json:"createdAt,omitempty"`
+	UpdatedAt   *timefmt.Time    `json:"updatedAt,omitempty"`
+
+	DeletionScheduledAt *timefmt.Time    `json:"deletionScheduledAt,omitempty"`
+	ContactEmail        *string          `json:"contactEmail,omitempty"`
+	BillingReference    *string          `json:"billingReference,omitempty"`
+	OwnerID             *gidx.PrefixedID `json:"ownerID,omitempty"`
+	SuspendedAt         *timefmt.Time    `json:"suspendedAt,omitempty"`
+	ExternalID          *string          `json:"externalID,omitempty"`
+	Archived            bool             `json:"archived,omitempty"`
+	Frozen              bool             `json:"frozen,omitempty"`
+	DeletionProtected   bool             `json:"deletionProtected,omitempty"`
+
+	// CreationFrozenUntil is the expiry of the creation freeze of the tenant, omitted once it
+	// passes.
+	CreationFrozenUntil *timefmt.Time `json:"creationFrozenUntil,omitempty"`
+
+	// ChangeSeq is the sequence of the last change of the tenant, omitted for tenants not changed
+	// since sequences were introduced.
+	ChangeSeq int64 `json:"changeSeq,omitempty"`
+
+	// Settings is only set when requested with ?include=settings, an interface so an empty
+	// document is still included.
+	Settings any `json:"settings,omitempty"`
+
+	// EffectiveStatus is only set when requested with ?include_effective_status=true.
+	EffectiveStatus string `json:"effectiveStatus,omitempty"`
+
+	// Parent is only set when requested with ?include=parent, null for root tenants.
+	Parent *includedTenant `json:"parent,omitempty"`
+	// ChildrenCount is only set when requested with ?include=children_count.
+	ChildrenCount *int `json:"childrenCount,omitempty"`
+	// Path is only set when requested with ?include=path, the IDs from the root down to the
+	// tenant.
+	Path []gidx.PrefixedID `json:"path,omitempty"`
+	// Children is only set when requested with ?include=children, on the get of a tenant.
+	Children *includedChildren `json:"children,omitempty"`
+}
+
+func newTenant(t *ent.Tenant, fields redact.Fields) tenant {
+	resp := tenant{
+		ID:                t.ID,
+		URN:               urnx.NewTenantURN(t.ID),
+		ChangeSeq:         t.ChangeSeq,
+		Archived:          t.Archived,
+		Frozen:            t.Frozen,
+		DeletionProtected: t.DeletionProtected,
+	}
+
+	if fields.Visible(redact.FieldName) {
+		resp.Name = &t.Name
+	}
+
+	if fields.Visible(redact.FieldDisplayName) {
+		resp.DisplayName = &t.DisplayName
+	}
+
+	if fields.Visible(redact.FieldDescription) {
+		resp.Description = &t.Description
+	}
+
+	if fields.Visible(redact.FieldParent) && t.ParentTenantID != gidx.NullPrefixedID {
+		resp.ParentID = &t.ParentTenantID
+	}
+
+	if fields.Visible(redact.FieldCreatedAt) {
+		resp.CreatedAt = timefmt.Ptr(&t.CreatedAt)
+	}
+
+	if fields.Visible(redact.FieldUpdatedAt) {
+		resp.UpdatedAt = timefmt.Ptr(&t.UpdatedAt)
+	}
+
+	if fields.Visible(redact.FieldDeletionScheduledAt) && !t.DeletionScheduledAt.IsZero() {
+		resp.DeletionScheduledAt = timefmt.Ptr(&t.DeletionScheduledAt)
+	}
+
+	if fields.Visible(redact.FieldContactEmail) && t.ContactEmail != "" {
+		resp.ContactEmail = &t.ContactEmail
+	}
+
+	if fields.Visible(redact.FieldBillingReference) && t.BillingReference != "" {
+		resp.BillingReference = &t.BillingReference
+	}
+
+	if fields.Visible(redact.FieldOwner) && t.OwnerID != gidx.NullPrefixedID {
+		resp.OwnerID = &t.OwnerID
+	}
+
+	if fields.Visible(redact.FieldSuspendedAt) && !t.SuspendedAt.IsZero() {
+		resp.SuspendedAt = timefmt.Ptr(&t.SuspendedAt)
+	}
+
+	if fields.Visible(redact.FieldExternalID) && t.ExternalID != "" {
+		resp.ExternalID = &t.ExternalID
+	}
+
+	return resp
+}
+
+func (h *Handler) tenantGet(c echo.Context) error {
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	return h.respondTenant(c, id)
+}
+
+// tenantGetByURN resolves a tenant URN to the tenant.
+func (h *Handler) tenantGetByURN(c echo.Context) error {
+	id, code, err := parseURN(c.QueryParam("urn"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]string{
+			"code":    code,
+			"message": err.Error(),
+		}).WithInternal(err)
+	}
+
+	return h.respondTenant(c, id)
+}
+
+// parseURN parses a tenant URN, returning the code of its error when it isn't one.
+func parseURN(raw string) (gidx.PrefixedID, string, error) {
+	id, err := urnx.ParseTenantURN(raw)
+
+	switch {
+	case err == nil:
+		return id, "", nil
+	case errors.Is(err, urnx.ErrUnexpectedResourceType):
+		return gidx.NullPrefixedID, codeUnexpectedResourceType, err
+	default:
+		return gidx.NullPrefixedID, codeInvalidURN, err
+	}
+}
+
+// tenantRead is a tenant read, shared by the concurrent identical reads. The parents it includes
+// are checked against the access of each caller.
+type tenantRead struct {
+	tenant    *ent.Tenant
+	resp      tenant
+	expansion *expansion
+	etag      string
+	headers   http.Header
+}
+
+// respondTenant responds with the tenant, or not modified when the client's copy is current. Access
+// is checked for every caller, while concurrent reads of the tenant with the same query and the
+// same visible fields may share the read when reads are coalesced.
+func (h *Handler) respondTenant(c echo.Context, id gidx.PrefixedID) error {
+	ctx := c.Request().Context()
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	inc, err := parseIncludes(c)
+	if err != nil {
+		return err
+	}
+
+	withStatus, err := includeEffectiveStatus(c)
+	if err != nil {
+		return err
+	}
+
+	fields := redact.FromContext(ctx)
+
+	read, _, err := h.reads.Do(ctx, readKey(id, fields, c.QueryParams()), func(ctx context.Context) (tenantRead, error) {
+		return h.readTenant(ctx, id, inc, withStatus, fields)
+	})
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	resp := read.resp
+
+	if read.expansion != nil {
+		// the shared expansion is copied, the parents denied depend on the caller
+		x := *read.expansion
+
+		if err := x.checkParents(ctx); err != nil {
+			return errmap.HTTPError(err)
+		}
+
+		x.apply(&resp, read.tenant)
+	}
+
+	header := c.Response().Header()
+
+	for k, v := range read.headers {
+		header[k] = v
+	}
+
+	header.Set(echo.HeaderCacheControl, fmt.Sprintf("private, max-age=%d", int(h.cacheMaxAge.Seconds())))
+
+	// related tenants change without the tenant, responses including them have no tag
+	if read.etag != "" {
+		header.Set("ETag", read.etag)
+
+		if ifNoneMatch(c.Request().Header.Get("If-None-Match"), read.etag) {
+			return c.NoContent(http.StatusNotModified)
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// readTenant reads the tenant and what the includes request of it.
+func (h *Handler) readTenant(ctx context.Context, id gidx.PrefixedID, inc includes, withStatus bool, fields redact.Fields) (tenantRead, error) {
+	t, err := h.client.Tenant.Get(ctx, id)
+	if err != nil {
+		return tenantRead{}, err
+	}
+
+	status := ""
+
+	if withStatus {
+		if status, err = deletion.EffectiveStatus(ctx, h.client, t); err != nil {
+			return tenantRead{}, err
+		}
+	}
+
+	read := tenantRead{tenant: t, headers: http.Header{}}
+
+	if err := h.limitHeaders(ctx, t, read.headers); err != nil {
+		return tenantRead{}, err
+	}
+
+	if !inc.related() {
+		read.etag = tenantETag(t, fields, inc[includeSettingsName], status, h.creationFrozen(t))
+	}
+
+	read.resp = h.newFrozenTenant(t, fields)
+	read.resp.EffectiveStatus = status
+
+	if inc[includeSettingsName] && fields.Visible(redact.FieldSettings) {
+		read.resp.Settings = settingsDocument(t)
+	}
+
+	if inc.related() {
+		if read.expansion, err = h.loadExpansion(ctx, []*ent.Tenant{t}, inc, fields); err != nil {
+			return tenantRead{}, err
+		}
+	}
+
+	if inc[includeChildrenName] {
+		if read.resp.Children, err = h.includeChildren(ctx, t, fields); err != nil {
+			return tenantRead{}, err
+		}
+	}
+
+	return read, nil
+}
+
+// readKey identifies the reads of the tenant which get the same response, those with the same
+// query and the same visible fields.
+func readKey(id gidx.PrefixedID, fields redact.Fields, query url.Values) string {
+	return id.String() + "|" + fields.Key() + "|" + query.Encode()
+}
+
+// includeEffectiveStatus reports whether the include_effective_status query parameter requests
+// the effective status, which walks the ancestors of the tenant.
+func includeEffectiveStatus(c echo.Context) (bool, error) {
+	raw := c.QueryParam("include_effective_status")
+	if raw == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid include_effective_status %q", raw)).WithInternal(err)
+	}
+
+	return include, nil
+}
+
+// tenantETag derives a strong entity tag from the last time the tenant was updated. Redacted
+// representations and those including the settings get their own tag. The effective status
+// depends on the ancestors rather than the tenant, so it is part of the tag when included, and so
+// is an active creation freeze, which expires without the tenant being updated.
+func tenantETag(t *ent.Tenant, fields redact.Fields, withSettings bool, status string, creationFrozen bool) string {
+	tag := strconv.FormatInt(t.UpdatedAt.UnixNano(), 36)
+
+	if withSettings {
+		tag += "-s"
+	}
+
+	if creationFrozen {
+		tag += "-f"
+	}
+
+	if status != "" {
+		tag += "-" + status
+	}
+
+	if key := fields.Key(); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		tag += "-" + hex.EncodeToString(sum[:4])
+	}
+
+	return `"` + tag + `"`
+}
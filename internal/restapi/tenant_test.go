@@ -0,0 +1,352 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+func newTestServer(t testing.TB, opts ...restapi.Option) (*ent.Client, string) {
+	t.Helper()
+
+	return newTestServerWithMiddleware(t, nil, opts...)
+}
+
+func newTestServerWithMiddleware(t testing.TB, middleware []echo.MiddlewareFunc, opts ...restapi.Option) (*ent.Client, string) {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	e := echo.New()
+
+	restapi.NewHandler(client, zap.NewNop().Sugar(), append([]echo.MiddlewareFunc{perms.Middleware()}, middleware...), opts...).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	return client, srv.URL
+}
+
+func get(t *testing.T, url string, headers map[string]string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, body
+}
+
+func TestTenantGet(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t, restapi.WithCacheMaxAge(time.Minute))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetDescription("a child").SetParent(root).SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/"+child.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, "private, max-age=60", resp.Header.Get("Cache-Control"))
+
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var got map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, child.ID.String(), got["id"])
+	assert.Equal(t, "child", got["name"])
+	assert.Equal(t, "a child", got["description"])
+	assert.Equal(t, root.ID.String(), got["parentID"])
+
+	resp, _ = get(t, url+"/v1/tenants/"+root.ID.String(), nil)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+		expected    int
+	}{
+		{name: "match", ifNoneMatch: etag, expected: http.StatusNotModified},
+		{name: "weak match", ifNoneMatch: "W/" + etag, expected: http.StatusNotModified},
+		{name: "match in list", ifNoneMatch: `"other", ` + etag, expected: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: "*", expected: http.StatusNotModified},
+		{name: "mismatch", ifNoneMatch: `"other"`, expected: http.StatusOK},
+		{name: "absent", expected: http.StatusOK},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if tt.ifNoneMatch != "" {
+				headers["If-None-Match"] = tt.ifNoneMatch
+			}
+
+			resp, body := get(t, url+"/v1/tenants/"+child.ID.String(), headers)
+			assert.Equal(t, tt.expected, resp.StatusCode)
+			assert.Equal(t, etag, resp.Header.Get("ETag"))
+			assert.Equal(t, "private, max-age=60", resp.Header.Get("Cache-Control"))
+
+			if tt.expected == http.StatusNotModified {
+				assert.Empty(t, body)
+			}
+		})
+	}
+
+	// updates change the entity tag
+	time.Sleep(time.Millisecond)
+	client.Tenant.UpdateOne(child).SetName("renamed").ExecX(ctx)
+
+	resp, _ = get(t, url+"/v1/tenants/"+child.ID.String(), map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+	resp, _ = get(t, url+"/v1/tenants/tnntten-missing", map[string]string{"If-None-Match": "*"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = get(t, url+"/v1/tenants/invalid", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTenantGetEffectiveStatus(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	grandparent := client.Tenant.Create().SetName("grandparent").SaveX(ctx)
+	parent := client.Tenant.Create().SetName("parent").SetParent(grandparent).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(parent).SaveX(ctx)
+
+	status := func(query string) (string, string) {
+		resp, body := get(t, url+"/v1/tenants/"+grandchild.ID.String()+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var got map[string]any
+
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		status, _ := got["effectiveStatus"].(string)
+
+		return status, resp.Header.Get("ETag")
+	}
+
+	got, _ := status("")
+	assert.Empty(t, got, "the effective status is only computed when requested")
+
+	got, activeETag := status("?include_effective_status=true")
+	assert.Equal(t, "active", got)
+
+	client.Tenant.UpdateOne(grandparent).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(ctx)
+
+	got, deletedETag := status("?include_effective_status=true")
+	assert.Equal(t, "parent_deleted", got)
+	assert.NotEqual(t, activeETag, deletedETag, "the tag changes with the status of the ancestors")
+
+	resp, _ := get(t, url+"/v1/tenants/"+grandchild.ID.String()+"?include_effective_status=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// scopeMiddleware stands in for the jwt middleware, setting a token with the scopes from the X-Scope header.
+func scopeMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Set("user", &jwt.Token{Claims: jwt.MapClaims{"scope": c.Request().Header.Get("X-Scope")}})
+
+		return next(c)
+	}
+}
+
+func TestTenantGetRedacted(t *testing.T) {
+	ctx := context.Background()
+
+	policy := redact.NewPolicy(map[string][]string{
+		"tenants:full":    {redact.AllFields},
+		"tenants:summary": {redact.FieldName},
+	})
+
+	client, url := newTestServerWithMiddleware(t, []echo.MiddlewareFunc{scopeMiddleware, policy.Middleware()})
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetDescription("a child").
+		SetContactEmail("ops@example.com").SetBillingReference("ACCT-42").SetParent(root).SaveX(ctx)
+
+	fullResp, body := get(t, url+"/v1/tenants/"+child.ID.String(), map[string]string{"X-Scope": "tenants:full"})
+	require.Equal(t, http.StatusOK, fullResp.StatusCode, string(body))
+
+	var full map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &full))
+	assert.Equal(t, "a child", full["description"])
+	assert.Equal(t, root.ID.String(), full["parentID"])
+	assert.Equal(t, "ops@example.com", full["contactEmail"])
+	assert.Equal(t, "ACCT-42", full["billingReference"])
+	assert.Contains(t, full, "createdAt")
+
+	summaryResp, body := get(t, url+"/v1/tenants/"+child.ID.String(), map[string]string{"X-Scope": "openid tenants:summary"})
+	require.Equal(t, http.StatusOK, summaryResp.StatusCode, string(body))
+
+	var summary map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &summary))
+	assert.Equal(t, map[string]any{"id": child.ID.String(), "urn": urnx.NewTenantURN(child.ID), "name": "child"}, summary, "redacted fields are omitted, not null")
+	assert.NotEqual(t, fullResp.Header.Get("ETag"), summaryResp.Header.Get("ETag"))
+
+	// a redacted representation doesn't revalidate a full one
+	resp, _ := get(t, url+"/v1/tenants/"+child.ID.String(), map[string]string{
+		"X-Scope":       "tenants:full",
+		"If-None-Match": summaryResp.Header.Get("ETag"),
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body = get(t, url+"/v1/tenants/"+child.ID.String(), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"id":"`+child.ID.String()+`","urn":"`+urnx.NewTenantURN(child.ID)+`"}`, string(body), "unknown scopes only see the id")
+}
+
+func TestTenantGetByURN(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	tnt := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+	resp, body := get(t, url+"/v1/tenants/by-urn?urn="+urnx.NewTenantURN(tnt.ID), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	var got map[string]any
+
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, tnt.ID.String(), got["id"])
+	assert.Equal(t, "acme", got["name"])
+
+	testCases := []struct {
+		name     string
+		urn      string
+		expected int
+		code     string
+	}{
+		{name: "missing", urn: urnx.NewTenantURN("tnntten-missing"), expected: http.StatusNotFound},
+		{name: "malformed", urn: "urn:infratographer:" + tnt.ID.String(), expected: http.StatusBadRequest, code: "invalid_urn"},
+		{name: "absent", expected: http.StatusBadRequest, code: "invalid_urn"},
+		{name: "other resource type", urn: "urn:infratographer:load-balancer:loadbal-abc", expected: http.StatusBadRequest, code: "unexpected_resource_type"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := get(t, url+"/v1/tenants/by-urn?urn="+tt.urn, nil)
+			require.Equal(t, tt.expected, resp.StatusCode, string(body))
+
+			if tt.code != "" {
+				var errResp struct {
+					Code string `json:"code"`
+				}
+
+				require.NoError(t, json.Unmarshal(body, &errResp))
+				assert.Equal(t, tt.code, errResp.Code)
+			}
+		})
+	}
+}
+
+func TestTenantPathURN(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	forms := map[string]func(gidx.PrefixedID) string{
+		"id":  gidx.PrefixedID.String,
+		"urn": urnx.NewTenantURN,
+	}
+
+	for name, form := range forms {
+		t.Run(name, func(t *testing.T) {
+			tnt := client.Tenant.Create().SetName("acme-" + name).SaveX(ctx)
+			path := url + "/v1/tenants/" + form(tnt.ID)
+
+			resp, body := get(t, path, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+			var got map[string]any
+
+			require.NoError(t, json.Unmarshal(body, &got))
+			assert.Equal(t, tnt.ID.String(), got["id"])
+			assert.Equal(t, urnx.NewTenantURN(tnt.ID), got["urn"])
+
+			resp, body = send(t, http.MethodPut, path+"/settings", `{"region":"us-east"}`, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+			assert.Equal(t, map[string]any{"region": "us-east"}, client.Tenant.GetX(ctx, tnt.ID).Settings)
+
+			resp, body = send(t, http.MethodDelete, path, "", nil)
+			require.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+
+			resp, _ = get(t, path, nil)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	}
+
+	testCases := []struct {
+		name     string
+		id       string
+		expected int
+		code     string
+	}{
+		{name: "missing", id: urnx.NewTenantURN("tnntten-missing"), expected: http.StatusNotFound},
+		{name: "malformed", id: "urn:infratographer:tnntten-missing", expected: http.StatusBadRequest, code: "invalid_urn"},
+		{name: "other resource type", id: "urn:infratographer:load-balancer:loadbal-abc", expected: http.StatusBadRequest, code: "unexpected_resource_type"},
+		{name: "raw id of other resource", id: "loadbal-abc", expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodDelete} {
+				resp, body := send(t, method, url+"/v1/tenants/"+tt.id, "", nil)
+				require.Equal(t, tt.expected, resp.StatusCode, "%s: %s", method, body)
+
+				if tt.code != "" {
+					var errResp struct {
+						Code string `json:"code"`
+					}
+
+					require.NoError(t, json.Unmarshal(body, &errResp))
+					assert.Equal(t, tt.code, errResp.Code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/redact"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// updateRequest holds the fields of a tenant update, fields left out or null are left as is.
+// Setting the parent moves the tenant, tenants can't be made roots.
+type updateRequest struct {
+	Name             *string          `json:"name"`
+	DisplayName      *string          `json:"displayName"`
+	Description      *string          `json:"description"`
+	ContactEmail     *string          `json:"contactEmail"`
+	BillingReference *string          `json:"billingReference"`
+	ParentID         *gidx.PrefixedID `json:"parentID"`
+}
+
+// validate validates the request, normalizing the fields of the tenant with the pipeline.
+func (r *updateRequest) validate(ctx context.Context, p *validation.Pipeline) error {
+	t := validation.Tenant{
+		Name:             r.Name,
+		DisplayName:      r.DisplayName,
+		Description:      r.Description,
+		ContactEmail:     r.ContactEmail,
+		BillingReference: r.BillingReference,
+	}
+
+	var errs validation.Errors
+
+	if err := p.ValidateUpdate(ctx, &t); err != nil {
+		if !errors.As(err, &errs) {
+			return err
+		}
+	}
+
+	if r.ParentID != nil && r.ParentID.Prefix() != schema.TenantPrefix {
+		errs.Add("parentID", validation.CodeInvalidID, fmt.Sprintf("%q is not a tenant id", *r.ParentID))
+	}
+
+	return errs.Err()
+}
+
+// tenantUpdate updates the fields of a tenant. Setting the parent moves the tenant under it, which
+// the caller must be allowed to create tenants under, and records the move in the parent history
+// of the tenant in the same transaction. A tenant can't be moved under itself or one of its
+// descendants. Change events and auth relationships are published once the transaction commits.
+func (h *Handler) tenantUpdate(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	var req updateRequest
+
+	if err := h.decodeInput(c, &req); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := req.validate(ctx, h.validator); err != nil {
+		return errmap.BadRequest(err)
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantUpdate); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if req.ParentID != nil {
+		if err := permissions.CheckAccess(ctx, *req.ParentID, actionTenantCreate); err != nil {
+			return errmap.HTTPError(err)
+		}
+	}
+
+	t, err := h.updateTenant(c, id, req)
+	if err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, newTenant(t, redact.FromContext(ctx)))
+}
+
+// updateTenant updates the tenant in a transaction, holding the change events and the auth
+// relationship requests until it commits.
+func (h *Handler) updateTenant(c echo.Context, id gidx.PrefixedID, req updateRequest) (*ent.Tenant, error) {
+	ctx := c.Request().Context()
+
+	txCtx, batch := changefeed.WithBatch(ctx)
+	txCtx = changefeed.HoldRelationships(txCtx)
+
+	tx, err := h.client.Tx(txCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tx.Tenant.UpdateOneID(id).
+		SetInput(ent.UpdateTenantInput{
+			Name:             req.Name,
+			DisplayName:      req.DisplayName,
+			Description:      req.Description,
+			ContactEmail:     req.ContactEmail,
+			BillingReference: req.BillingReference,
+			ParentID:         req.ParentID,
+		}).
+		Save(txCtx)
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			h.log(c).Errorw("failed to roll back tenant update", "error", rerr)
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if err := batch.Flush(ctx); err != nil {
+		// the update is committed, report it even though some events or relationships were lost
+		h.log(c).Errorw("failed to publish tenant update", "error", err)
+	}
+
+	return t.Unwrap(), nil
+}
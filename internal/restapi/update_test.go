@@ -0,0 +1,88 @@
+package restapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestTenantUpdate(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	root := env.client.Tenant.Create().SetName("root").SaveX(env.ctx)
+	tnt := env.client.Tenant.Create().SetName("tenant").SetParent(root).SaveX(env.ctx)
+
+	status, body := env.do(t, http.MethodPatch, "/v1/tenants/"+tnt.ID.String(), "application/json", `{"name":" renamed ","description":"described"}`)
+	require.Equal(t, http.StatusOK, status, string(body))
+
+	var got struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		ParentID    gidx.PrefixedID `json:"parentID"`
+	}
+
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "renamed", got.Name)
+	assert.Equal(t, "described", got.Description)
+	assert.Equal(t, root.ID, got.ParentID, "the parent is left as is")
+
+	status, body = env.do(t, http.MethodPatch, "/v1/tenants/"+tnt.ID.String(), "application/json", `{"contactEmail":"ops at example.com","parentID":"idntusr-user"}`)
+	require.Equal(t, http.StatusBadRequest, status, string(body))
+	assert.Contains(t, string(body), validation.CodeInvalidContactEmail)
+	assert.Contains(t, string(body), validation.CodeInvalidID)
+
+	status, body = env.do(t, http.MethodPatch, "/v1/tenants/tnntten-denied", "application/json", `{"name":"denied"}`)
+	assert.Equal(t, http.StatusForbidden, status, string(body))
+}
+
+func TestTenantUpdateMove(t *testing.T) {
+	env := newEventEnv(t, "tnntten-denied")
+
+	first := env.client.Tenant.Create().SetName("first").SaveX(env.ctx)
+	second := env.client.Tenant.Create().SetName("second").SaveX(env.ctx)
+	moved := env.client.Tenant.Create().SetName("moved").SaveX(env.ctx)
+	child := env.client.Tenant.Create().SetName("child").SetParent(moved).SaveX(env.ctx)
+	env.client.Tenant.Create().SetID("tnntten-denied").SetName("denied").SaveX(env.ctx)
+
+	move := func(parentID gidx.PrefixedID) (int, []byte) {
+		return env.do(t, http.MethodPatch, "/v1/tenants/"+moved.ID.String(), "application/json", `{"parentID":"`+parentID.String()+`"}`)
+	}
+
+	for _, parent := range []gidx.PrefixedID{first.ID, second.ID} {
+		status, body := move(parent)
+		require.Equal(t, http.StatusOK, status, string(body))
+	}
+
+	assert.Equal(t, second.ID, env.client.Tenant.GetX(env.ctx, moved.ID).ParentTenantID)
+	assert.Equal(t, moved.ID, env.client.Tenant.GetX(env.ctx, child.ID).ParentTenantID, "descendants move along")
+
+	resp, body := get(t, env.url+"/v1/tenants/"+moved.ID.String()+"/parent-history", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var page historyPage
+
+	require.NoError(t, json.Unmarshal(body, &page))
+	require.Len(t, page.Changes, 2)
+
+	assert.Empty(t, page.Changes[0].PreviousParentID, "the tenant was a root")
+	assert.Equal(t, first.ID, page.Changes[0].NewParentID)
+	assert.Equal(t, first.ID, page.Changes[1].PreviousParentID)
+	assert.Equal(t, second.ID, page.Changes[1].NewParentID)
+	assert.Equal(t, testActor, page.Changes[1].Actor)
+
+	// under a descendant, under a parent the caller may not create tenants under
+	status, body := move(child.ID)
+	assert.Equal(t, http.StatusConflict, status, string(body))
+	assert.Contains(t, string(body), validation.CodeParentCycle)
+
+	status, body = move("tnntten-denied")
+	assert.Equal(t, http.StatusForbidden, status, string(body))
+
+	assert.Equal(t, 2, env.client.TenantParentHistory.Query().CountX(env.ctx), "rejected moves aren't recorded")
+}
@@ -0,0 +1,103 @@
+package restapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/timefmt"
+	"go.infratographer.com/tenant-api/internal/usage"
+)
+
+const (
+	// defaultUsageWindow is the window of usage returned when none is requested.
+	defaultUsageWindow = 24 * time.Hour
+	// maxUsageWindow bounds the window of usage, and with it the buckets read for a request.
+	maxUsageWindow = 31 * 24 * time.Hour
+)
+
+// WithUsageRecorder counts the requests of the routes of single tenants with the recorder and
+// registers the usage endpoint, neither is done without one.
+func WithUsageRecorder(r *usage.Recorder) Option {
+	return func(h *Handler) {
+		h.usage = r
+	}
+}
+
+type usageResponse struct {
+	ID         gidx.PrefixedID  `json:"id"`
+	From       timefmt.Time     `json:"from"`
+	To         timefmt.Time     `json:"to"`
+	BucketSize string           `json:"bucketSize"`
+	Totals     map[string]int64 `json:"totals"`
+	Buckets    []usage.Bucket   `json:"buckets"`
+}
+
+// tenantUsage responds with the requests counted for the tenant in the buckets starting within
+// the window given by the from and to query parameters, by operation class. The window defaults to
+// the last day and may span a month at most. Counts are flushed periodically, so the latest
+// requests may not be included yet.
+func (h *Handler) tenantUsage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := parseTenantID(c)
+	if err != nil {
+		return err
+	}
+
+	to := h.clock.Now().UTC()
+
+	if raw := c.QueryParam("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid to %q", raw)).WithInternal(err)
+		}
+	}
+
+	from := to.Add(-defaultUsageWindow)
+
+	if raw := c.QueryParam("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid from %q", raw)).WithInternal(err)
+		}
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxUsageWindow {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("from must be before to and at most %s earlier", maxUsageWindow))
+	}
+
+	if err := permissions.CheckAccess(ctx, id, actionTenantGet); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	if _, err := h.client.Tenant.Get(ctx, id); err != nil {
+		return errmap.HTTPError(err)
+	}
+
+	buckets, err := usage.Buckets(ctx, h.client, id, from, to)
+	if err != nil {
+		return err
+	}
+
+	resp := usageResponse{
+		ID:         id,
+		From:       timefmt.New(from),
+		To:         timefmt.New(to),
+		BucketSize: h.usage.BucketSize().String(),
+		Totals:     map[string]int64{usage.OpRead: 0, usage.OpWrite: 0},
+		Buckets:    buckets,
+	}
+
+	for _, b := range buckets {
+		for op, n := range b.Counts {
+			resp.Totals[op] += n
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,123 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/usage"
+)
+
+func TestTenantUsage(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	recorder := usage.NewRecorder(client, zap.NewNop().Sugar())
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), []echo.MiddlewareFunc{perms.Middleware()}, restapi.WithUsageRecorder(recorder)).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	busy := client.Tenant.Create().SetName("busy").SaveX(ctx)
+	quiet := client.Tenant.Create().SetName("quiet").SaveX(ctx)
+	idle := client.Tenant.Create().SetName("idle").SaveX(ctx)
+
+	for i := 0; i < 3; i++ {
+		resp, body := get(t, srv.URL+"/v1/tenants/"+busy.ID.String(), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	}
+
+	resp, body := send(t, http.MethodPut, srv.URL+"/v1/tenants/"+busy.ID.String()+"/settings", `{"region":"us-east"}`, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	resp, body = get(t, srv.URL+"/v1/tenants/"+quiet.ID.String()+"/stats", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	// failed requests aren't counted
+	resp, _ = get(t, srv.URL+"/v1/tenants/tnntten-unknown", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = get(t, srv.URL+"/v1/tenants/"+quiet.ID.String()+"/parent-history?limit=0", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// counts are served once flushed
+	require.NoError(t, recorder.Flush(ctx))
+
+	type response struct {
+		BucketSize string           `json:"bucketSize"`
+		Totals     map[string]int64 `json:"totals"`
+		Buckets    []usage.Bucket   `json:"buckets"`
+	}
+
+	counts := func(id string, query string) response {
+		t.Helper()
+
+		resp, body := get(t, srv.URL+"/v1/tenants/"+id+"/usage"+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var r response
+
+		require.NoError(t, json.Unmarshal(body, &r))
+
+		return r
+	}
+
+	busyUsage := counts(busy.ID.String(), "")
+	assert.Equal(t, "1h0m0s", busyUsage.BucketSize)
+	assert.Equal(t, map[string]int64{"read": 3, "write": 1}, busyUsage.Totals)
+	require.Len(t, busyUsage.Buckets, 1)
+	assert.Equal(t, time.Now().UTC().Truncate(time.Hour), busyUsage.Buckets[0].Start)
+
+	assert.Equal(t, map[string]int64{"read": 1, "write": 0}, counts(quiet.ID.String(), "").Totals)
+
+	idleUsage := counts(idle.ID.String(), "")
+	assert.Equal(t, map[string]int64{"read": 0, "write": 0}, idleUsage.Totals)
+	assert.Empty(t, idleUsage.Buckets)
+
+	// the usage reads above are counted with the next flush, added to the stored counts
+	require.NoError(t, recorder.Flush(ctx))
+
+	assert.Equal(t, map[string]int64{"read": 4, "write": 1}, counts(busy.ID.String(), "").Totals)
+
+	// buckets are selected by their start
+	window := "?from=" + time.Now().Add(-48*time.Hour).UTC().Format(time.RFC3339) + "&to=" + time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339)
+	assert.Empty(t, counts(busy.ID.String(), window).Buckets)
+
+	// only tenants accessed have counters
+	assert.Equal(t, 4, client.TenantUsage.Query().CountX(ctx))
+
+	for _, query := range []string{"?from=yesterday", "?from=2026-01-01T00:00:00Z&to=2025-01-01T00:00:00Z", "?from=2025-01-01T00:00:00Z&to=2026-01-01T00:00:00Z"} {
+		resp, body := get(t, srv.URL+"/v1/tenants/"+busy.ID.String()+"/usage"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query+": "+string(body))
+	}
+}
+
+func TestTenantUsageDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	client, url := newTestServer(t)
+
+	tnt := client.Tenant.Create().SetName("tenant").SaveX(ctx)
+
+	resp, _ := get(t, url+"/v1/tenants/"+tnt.ID.String()+"/usage", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,125 @@
+package restapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestValidationSameAcrossAPIs(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	var rules []validation.Rule
+
+	rules = append(rules, validation.NewNameValidator(validation.WithReservedNames("system-*")).Rules()...)
+	rules = append(rules, validation.NewMetadataValidator(validation.WithDescriptionMaxLength(8)).Rules()...)
+
+	pipeline := validation.NewPipeline(rules...)
+	client.Tenant.Use(pipeline.Hook())
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	middleware := []echo.MiddlewareFunc{perms.Middleware()}
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), middleware, restapi.WithValidation(pipeline)).Routes(e.Group(""))
+	graphapi.NewResolver(client, zap.NewNop().Sugar(), graphapi.WithValidation(pipeline)).Handler(false, middleware).Routes(e.Group("/graph"))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	post := func(t *testing.T, path string, body any) []byte {
+		t.Helper()
+
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		resp, err := http.Post(srv.URL+path, echo.MIMEApplicationJSON, bytes.NewReader(raw))
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var out bytes.Buffer
+
+		_, err = out.ReadFrom(resp.Body)
+		require.NoError(t, err)
+
+		return out.Bytes()
+	}
+
+	for _, tt := range []struct {
+		name  string
+		input map[string]any
+		codes []string
+	}{
+		{
+			name:  "every field at once",
+			input: map[string]any{"name": " System-EU ", "description": "far too long", "contactEmail": "Ops <ops@example.com>"},
+			codes: []string{validation.CodeReservedName, validation.CodeDescriptionTooLong, validation.CodeInvalidContactEmail},
+		},
+		{
+			name:  "blank name",
+			input: map[string]any{"name": "  ", "displayName": "acme\u200b"},
+			codes: []string{validation.CodeRequired, validation.CodeInvalidName},
+		},
+		{
+			name:  "bidirectional override",
+			input: map[string]any{"name": "acme\u202e", "billingReference": strings.Repeat("x", validation.DefaultBillingReferenceMaxLength+1)},
+			codes: []string{validation.CodeInvalidName, validation.CodeBillingReferenceTooLong},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var rest struct {
+				Message string          `json:"message"`
+				Details []errmap.Detail `json:"details"`
+			}
+
+			require.NoError(t, json.Unmarshal(post(t, "/v1/tenants", tt.input), &rest))
+
+			var graph struct {
+				Errors []struct {
+					Message    string `json:"message"`
+					Extensions struct {
+						Details []errmap.Detail `json:"details"`
+					} `json:"extensions"`
+				} `json:"errors"`
+			}
+
+			require.NoError(t, json.Unmarshal(post(t, "/graph/query", map[string]any{
+				"query":     `mutation($input: CreateTenantInput!) { tenantCreate(input: $input) { tenant { id } } }`,
+				"variables": map[string]any{"input": tt.input},
+			}), &graph))
+			require.Len(t, graph.Errors, 1)
+
+			codes := make([]string, len(rest.Details))
+			for i, detail := range rest.Details {
+				codes[i] = detail.Code
+			}
+
+			assert.Equal(t, tt.codes, codes)
+			assert.Equal(t, rest.Details, graph.Errors[0].Extensions.Details)
+			assert.Equal(t, rest.Message, graph.Errors[0].Message)
+		})
+	}
+
+	assert.Zero(t, client.Tenant.Query().CountX(context.Background()), "nothing was created")
+}
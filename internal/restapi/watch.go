@@ -0,0 +1,216 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+	"go.infratographer.com/tenant-api/internal/redact"
+)
+
+const (
+	// DefaultWatchTimeout is how long a watching tenant list waits for changes by default.
+	DefaultWatchTimeout = 30 * time.Second
+
+	// watchRecheckInterval is how often a watching list reads the recorded changes again, seeing
+	// those made through other instances.
+	watchRecheckInterval = time.Second
+
+	// maxWatchedChanges is the most recorded changes read at once by a watching list.
+	maxWatchedChanges = 1000
+)
+
+// WithWatchTimeout sets how long a tenant list with watch=true waits for changes before returning
+// none.
+func WithWatchTimeout(timeout time.Duration) Option {
+	return func(h *Handler) {
+		if timeout > 0 {
+			h.watchTimeout = timeout
+		}
+	}
+}
+
+// WithChangeFeed wakes the tenant lists watching for changes up as soon as a change is published
+// through the feed of this instance, rather than at their next check of the recorded changes.
+func WithChangeFeed(feed *changefeed.Feed) Option {
+	return func(h *Handler) {
+		h.feed = feed
+	}
+}
+
+type watchResponse struct {
+	Tenants   []tenant          `json:"tenants"`
+	Deleted   []gidx.PrefixedID `json:"deleted"`
+	NextSince int64             `json:"nextSince"`
+}
+
+// tenantListWatch returns the children of the parent changed after the since sequence, and the
+// children deleted since, along with the sequence to watch from next. Without since, only the
+// changes made from now on are returned. The request is held until a child changes or the watch
+// timeout passes, returning no tenants in the latter case. The changes are read from the recorded
+// tenant changes, so those made through every instance are seen, the feed only wakes watchers up
+// sooner. Children moved to another parent aren't reported, and the other list filters don't
+// apply. A since older than the change retention is rejected with resync_required, the children
+// must be listed again.
+func (h *Handler) tenantListWatch(c echo.Context, parentID gidx.PrefixedID) error {
+	ctx := c.Request().Context()
+
+	var since int64
+
+	if raw := c.QueryParam("since"); raw != "" {
+		var err error
+
+		since, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || since < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "since must be a non negative integer")
+		}
+
+		if err := h.checkChangeRetention(ctx, since, "list the children again"); err != nil {
+			return err
+		}
+	} else {
+		var err error
+
+		if since, err = lastChangeSeq(ctx, h.client); err != nil {
+			return err
+		}
+	}
+
+	// subscribed first, the changes made while reading the recorded ones still wake the watcher
+	var wake <-chan changefeed.Change
+
+	if h.feed != nil {
+		changes, unsubscribe := h.feed.Subscribe()
+		defer unsubscribe()
+
+		wake = changes
+	}
+
+	timeout := time.NewTimer(h.watchTimeout)
+	defer timeout.Stop()
+
+	recheck := time.NewTicker(watchRecheckInterval)
+	defer recheck.Stop()
+
+	for {
+		resp, more, err := h.watchedChanges(ctx, parentID, since)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Tenants) != 0 || len(resp.Deleted) != 0 {
+			return c.JSON(http.StatusOK, resp)
+		}
+
+		since = resp.NextSince
+
+		if more {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timeout.C:
+			return c.JSON(http.StatusOK, resp)
+		case _, ok := <-wake:
+			if !ok {
+				// dropped for falling behind, the recorded changes are still checked
+				wake = nil
+			}
+		case <-recheck.C:
+		}
+	}
+}
+
+// watchedChanges reads the recorded changes after the since sequence, up to maxWatchedChanges,
+// and returns the children of the parent among the tenants changed as they are now, and the ones
+// deleted. The next sequence follows every change read, whichever tenant it was made to, more
+// reports whether others follow it.
+func (h *Handler) watchedChanges(ctx context.Context, parentID gidx.PrefixedID, since int64) (watchResponse, bool, error) {
+	resp := watchResponse{
+		Tenants:   []tenant{},
+		Deleted:   []gidx.PrefixedID{},
+		NextSince: since,
+	}
+
+	changes, err := h.client.TenantChange.Query().
+		Where(enttenantchange.IDGT(since)).
+		Order(ent.Asc(enttenantchange.FieldID)).
+		Limit(maxWatchedChanges + 1).
+		All(ctx)
+	if err != nil {
+		return resp, false, err
+	}
+
+	more := len(changes) > maxWatchedChanges
+	if more {
+		changes = changes[:maxWatchedChanges]
+	}
+
+	changed := make([]gidx.PrefixedID, 0, len(changes))
+	seen := make(map[gidx.PrefixedID]bool, len(changes))
+
+	for _, ch := range changes {
+		resp.NextSince = ch.ID
+
+		switch {
+		case ch.Operation == changeseq.OpDelete:
+			if ch.ParentTenantID == parentID {
+				resp.Deleted = append(resp.Deleted, ch.TenantID)
+			}
+		case !seen[ch.TenantID]:
+			seen[ch.TenantID] = true
+			changed = append(changed, ch.TenantID)
+		}
+	}
+
+	if len(changed) == 0 {
+		return resp, more, nil
+	}
+
+	tenants, err := h.client.Tenant.Query().
+		Where(enttenant.IDIn(changed...), enttenant.ParentTenantID(parentID)).
+		Order(ent.Asc(enttenant.FieldID)).
+		All(ctx)
+	if err != nil {
+		return resp, false, err
+	}
+
+	fields := redact.FromContext(ctx)
+
+	for _, t := range tenants {
+		resp.Tenants = append(resp.Tenants, newTenant(t, fields))
+	}
+
+	return resp, more, nil
+}
+
+// lastChangeSeq returns the sequence of the last recorded change, zero before the first.
+func lastChangeSeq(ctx context.Context, client *ent.Client) (int64, error) {
+	var last []struct {
+		Max sql.NullInt64 `json:"max"`
+	}
+
+	if err := client.TenantChange.Query().
+		Aggregate(ent.Max(enttenantchange.FieldID)).
+		Scan(ctx, &last); err != nil {
+		return 0, err
+	}
+
+	if len(last) == 0 {
+		return 0, nil
+	}
+
+	return last[0].Max.Int64, nil
+}
@@ -0,0 +1,100 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/restapi"
+)
+
+type watchPage struct {
+	Tenants []struct {
+		ID   gidx.PrefixedID `json:"id"`
+		Name string          `json:"name"`
+	} `json:"tenants"`
+	Deleted   []gidx.PrefixedID `json:"deleted"`
+	NextSince int64             `json:"nextSince"`
+}
+
+func TestTenantListWatch(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	url, _ := startServer(t, client, restapi.WithWatchTimeout(50*time.Millisecond))
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+	kept := client.Tenant.Create().SetName("kept").SetParent(root).SaveX(ctx)
+	gone := client.Tenant.Create().SetName("gone").SetParent(root).SaveX(ctx)
+
+	watch := func(t *testing.T, query string) watchPage {
+		t.Helper()
+
+		resp, body := get(t, url+"/v1/tenants?watch=true&parent_id="+root.ID.String()+query, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page watchPage
+
+		require.NoError(t, json.Unmarshal(body, &page))
+
+		return page
+	}
+
+	start := watch(t, "")
+	assert.Empty(t, start.Tenants, "only changes made from now on are returned without since")
+
+	client.Tenant.UpdateOneID(kept.ID).SetDescription("changed").ExecX(ctx)
+	client.Tenant.UpdateOneID(other.ID).SetDescription("changed").ExecX(ctx)
+	client.Tenant.DeleteOneID(gone.ID).ExecX(ctx)
+
+	t.Run("changes", func(t *testing.T) {
+		page := watch(t, "&since="+strconv.FormatInt(start.NextSince, 10))
+
+		require.Len(t, page.Tenants, 1)
+		assert.Equal(t, kept.ID, page.Tenants[0].ID)
+		assert.Equal(t, []gidx.PrefixedID{gone.ID}, page.Deleted)
+
+		empty := watch(t, "&since="+strconv.FormatInt(page.NextSince, 10))
+		assert.Empty(t, empty.Tenants, "nothing changed since")
+		assert.Empty(t, empty.Deleted)
+		assert.Equal(t, page.NextSince, empty.NextSince)
+	})
+
+	t.Run("held until a change", func(t *testing.T) {
+		recheckURL, _ := startServer(t, client, restapi.WithWatchTimeout(10*time.Second))
+		last := watch(t, "").NextSince
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			client.Tenant.Create().SetName("added").SetParent(root).SaveX(ctx)
+		}()
+
+		resp, body := get(t, recheckURL+"/v1/tenants?watch=true&parent_id="+root.ID.String()+"&since="+strconv.FormatInt(last, 10), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+		var page watchPage
+
+		require.NoError(t, json.Unmarshal(body, &page))
+		require.Len(t, page.Tenants, 1)
+		assert.Equal(t, "added", page.Tenants[0].Name)
+	})
+
+	t.Run("without parent", func(t *testing.T) {
+		resp, body := get(t, url+"/v1/tenants?watch=true", nil)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, string(body))
+	})
+}
@@ -0,0 +1,44 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+)
+
+// RenameHook returns an ent hook rejecting changes to the name of existing tenants unless the
+// caller holds the scope. Automations key off the name, the display name is for everything else.
+// Updates of a single tenant repeating its current name are allowed.
+func RenameHook(scope string) ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				name, ok := m.Name()
+				if !ok || Has(ctx, scope) {
+					return next.Mutate(ctx, m)
+				}
+
+				if m.Op().Is(ent.OpUpdateOne) {
+					old, err := m.OldName(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					if strings.TrimSpace(name) == old {
+						return next.Mutate(ctx, m)
+					}
+				}
+
+				return nil, fmt.Errorf("%w: renaming a tenant requires the %s scope", permissions.ErrPermissionDenied, scope)
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
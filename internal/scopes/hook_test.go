@@ -0,0 +1,40 @@
+package scopes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/scopes"
+)
+
+func TestRenameHook(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(scopes.RenameHook("tenants:rename"))
+
+	tnt := client.Tenant.Create().SetName("acme").SaveX(ctx)
+
+	err := client.Tenant.UpdateOne(tnt).SetName("acme-inc").Exec(ctx)
+	assert.True(t, errors.Is(err, permissions.ErrPermissionDenied), "renaming without the scope is denied")
+
+	err = client.Tenant.Update().SetName("acme-inc").Exec(ctx)
+	assert.True(t, errors.Is(err, permissions.ErrPermissionDenied), "bulk renames without the scope are denied")
+
+	require.NoError(t, client.Tenant.UpdateOne(tnt).SetName("acme").SetDisplayName("Acme Inc").Exec(ctx), "repeating the name isn't a rename")
+
+	renameCtx := scopes.WithScopes(ctx, []string{"tenants:read", "tenants:rename"})
+
+	tnt = client.Tenant.UpdateOneID(tnt.ID).SetName("acme-inc").SaveX(renameCtx)
+	assert.Equal(t, "acme-inc", tnt.Name)
+}
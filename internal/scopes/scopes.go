@@ -0,0 +1,89 @@
+package scopes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+type scopesCtxKey struct{}
+
+// FromToken returns the scopes of the validated token, read from the space delimited scope claim
+// or the scp claim.
+func FromToken(c echo.Context) []string {
+	token, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	switch scope := claims["scope"].(type) {
+	case string:
+		return strings.Fields(scope)
+	case []any:
+		return stringSlice(scope)
+	}
+
+	switch scp := claims["scp"].(type) {
+	case string:
+		return strings.Fields(scp)
+	case []any:
+		return stringSlice(scp)
+	}
+
+	return nil
+}
+
+// WithScopes returns a context carrying the scopes of the caller.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesCtxKey{}, scopes)
+}
+
+// FromContext returns the scopes of the caller from the context.
+func FromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesCtxKey{}).([]string)
+
+	return scopes
+}
+
+// Has reports whether the caller holds the scope.
+func Has(ctx context.Context, scope string) bool {
+	for _, s := range FromContext(ctx) {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Middleware sets the scopes of the validated token on the request context, so they are available
+// past the http layer. It must run after the jwt middleware.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithScopes(req.Context(), FromToken(c))))
+
+			return next(c)
+		}
+	}
+}
+
+func stringSlice(values []any) []string {
+	var out []string
+
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
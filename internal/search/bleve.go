@@ -0,0 +1,162 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"go.infratographer.com/x/gidx"
+)
+
+// Fields of the documents in the Bleve index. The names are indexed whole and lower cased, so
+// substrings are matched the way the SQL indexer does, the name as it is for sorting.
+const (
+	fieldName          = "name"
+	fieldNameLower     = "name_lc"
+	fieldDisplayLower  = "display_name_lc"
+	fieldParentID      = "parent_id"
+	checkpointInternal = "checkpoint"
+)
+
+// Bleve is an Indexer keeping an in-process Bleve index of the tenants, in memory or on disk. It
+// is kept up to date by a Syncer.
+type Bleve struct {
+	index bleve.Index
+}
+
+// NewBleve opens the Bleve index at the path, creating it when there is none. The index is kept
+// in memory when the path is empty, it must then be rebuilt whenever the process starts.
+func NewBleve(path string) (*Bleve, error) {
+	if path == "" {
+		index, err := bleve.NewMemOnly(indexMapping())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Bleve{index: index}, nil
+	}
+
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, indexMapping())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bleve{index: index}, nil
+}
+
+func indexMapping() mapping.IndexMapping {
+	keyword := bleve.NewKeywordFieldMapping()
+	keyword.Store = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt(fieldName, keyword)
+	doc.AddFieldMappingsAt(fieldNameLower, keyword)
+	doc.AddFieldMappingsAt(fieldDisplayLower, keyword)
+	doc.AddFieldMappingsAt(fieldParentID, keyword)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+
+	return m
+}
+
+// Close closes the index.
+func (b *Bleve) Close() error {
+	return b.index.Close()
+}
+
+// Index implements Indexer.
+func (b *Bleve) Index(_ context.Context, docs ...Document) error {
+	batch := b.index.NewBatch()
+
+	for _, doc := range docs {
+		fields := map[string]any{
+			fieldName:         doc.Name,
+			fieldNameLower:    strings.ToLower(doc.Name),
+			fieldDisplayLower: strings.ToLower(doc.DisplayName),
+		}
+
+		if doc.ParentID != "" {
+			fields[fieldParentID] = doc.ParentID.String()
+		}
+
+		if err := batch.Index(doc.ID.String(), fields); err != nil {
+			return err
+		}
+	}
+
+	return b.index.Batch(batch)
+}
+
+// Delete implements Indexer.
+func (b *Bleve) Delete(_ context.Context, ids ...gidx.PrefixedID) error {
+	batch := b.index.NewBatch()
+
+	for _, id := range ids {
+		batch.Delete(id.String())
+	}
+
+	return b.index.Batch(batch)
+}
+
+// Query implements Indexer.
+func (b *Bleve) Query(_ context.Context, q Query) ([]gidx.PrefixedID, error) {
+	pattern := ".*" + regexp.QuoteMeta(strings.ToLower(q.Text)) + ".*"
+
+	name := bleve.NewRegexpQuery(pattern)
+	name.SetField(fieldNameLower)
+
+	display := bleve.NewRegexpQuery(pattern)
+	display.SetField(fieldDisplayLower)
+
+	var match query.Query = bleve.NewDisjunctionQuery(name, display)
+
+	if q.ParentID != "" {
+		parent := bleve.NewTermQuery(q.ParentID.String())
+		parent.SetField(fieldParentID)
+
+		match = bleve.NewConjunctionQuery(match, parent)
+	}
+
+	req := bleve.NewSearchRequestOptions(match, q.limit(), 0, false)
+	req.SortBy([]string{fieldName, "_id"})
+
+	res, err := b.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []gidx.PrefixedID
+
+	for _, hit := range res.Hits {
+		ids = append(ids, gidx.PrefixedID(hit.ID))
+	}
+
+	return ids, nil
+}
+
+// Checkpoint implements SyncedIndexer.
+func (b *Bleve) Checkpoint(context.Context) (int64, bool, error) {
+	raw, err := b.index.GetInternal([]byte(checkpointInternal))
+	if err != nil || raw == nil {
+		return 0, false, err
+	}
+
+	seq, err := strconv.ParseInt(string(raw), 10, 64)
+
+	return seq, err == nil, err
+}
+
+// SetCheckpoint implements SyncedIndexer.
+func (b *Bleve) SetCheckpoint(_ context.Context, seq int64) error {
+	return b.index.SetInternal([]byte(checkpointInternal), []byte(strconv.FormatInt(seq, 10)))
+}
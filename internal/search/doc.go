@@ -0,0 +1,23 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package search finds tenants by their names through an Indexer. The default indexer queries
+// the database directly, with the same case insensitive substring match as the name_contains
+// filter of the list, so there is nothing to keep up to date. The Bleve indexer keeps an index in
+// the process instead, for deployments with more tenants than scanning their names allows.
+//
+// Indexes are kept up to date after the changes are committed by a Syncer tailing the
+// tenant_changes table, the outbox every committed mutation of a tenant is recorded in, and are
+// rebuilt from the database with Rebuild, such as by the search-rebuild command.
+package search
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// Limits of the tenants a query returns.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Document is what is indexed of a tenant.
+type Document struct {
+	ID          gidx.PrefixedID
+	Name        string
+	DisplayName string
+	ParentID    gidx.PrefixedID
+}
+
+// NewDocument returns the document of the tenant.
+func NewDocument(t *generated.Tenant) Document {
+	return Document{
+		ID:          t.ID,
+		Name:        t.Name,
+		DisplayName: t.DisplayName,
+		ParentID:    t.ParentTenantID,
+	}
+}
+
+// indexed reports whether the tenant is searched for, archived tenants aren't.
+func indexed(t *generated.Tenant) bool {
+	return !t.Archived
+}
+
+// Query is a search for tenants.
+type Query struct {
+	// Text is matched against the names and display names of the tenants, ignoring case.
+	Text string
+	// ParentID keeps the children of the tenant when set.
+	ParentID gidx.PrefixedID
+	// Limit is the most tenants returned, DefaultLimit when not positive.
+	Limit int
+}
+
+func (q Query) limit() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultLimit
+	case q.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return q.Limit
+	}
+}
+
+// Indexer is the SearchIndexer the tenants are searched with. The tenants it returns are ordered
+// by name, then by ID, so every implementation returns the same tenants in the same order.
+type Indexer interface {
+	// Index adds the documents to the index, replacing those of the same tenants.
+	Index(ctx context.Context, docs ...Document) error
+	// Delete removes the tenants from the index. Tenants which aren't indexed are ignored.
+	Delete(ctx context.Context, ids ...gidx.PrefixedID) error
+	// Query returns the IDs of the tenants matching the query, at most its limit.
+	Query(ctx context.Context, q Query) ([]gidx.PrefixedID, error)
+}
+
+// SyncedIndexer is an Indexer kept up to date from the recorded tenant changes, which remembers
+// the sequence of the last change it was synced with.
+type SyncedIndexer interface {
+	Indexer
+	// Checkpoint returns the sequence of the last change indexed, false when the index was never
+	// built.
+	Checkpoint(ctx context.Context) (int64, bool, error)
+	// SetCheckpoint records the sequence of the last change indexed.
+	SetCheckpoint(ctx context.Context, seq int64) error
+}
@@ -0,0 +1,164 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/search"
+)
+
+func newClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	return client
+}
+
+func newBleve(t *testing.T) *search.Bleve {
+	t.Helper()
+
+	index, err := search.NewBleve("")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { index.Close() })
+
+	return index
+}
+
+// fixture creates tenants with names and display names overlapping in case and substrings, and
+// returns them by name.
+func fixture(ctx context.Context, t *testing.T, client *ent.Client) map[string]*ent.Tenant {
+	t.Helper()
+
+	tenants := map[string]*ent.Tenant{}
+
+	create := func(name, displayName string, parent *ent.Tenant) *ent.Tenant {
+		c := client.Tenant.Create().SetName(name).SetDisplayName(displayName)
+		if parent != nil {
+			c.SetParent(parent)
+		}
+
+		tenants[name] = c.SaveX(ctx)
+
+		return tenants[name]
+	}
+
+	acme := create("acme", "ACME Corporation", nil)
+	create("acme-staging", "Acme Staging", acme)
+	create("acme-prod", "Production", acme)
+	create("Beta_Labs", "beta labs", acme)
+	create("globex", "Globex Corp", nil)
+	create("initech", "Initech 100%", nil)
+
+	archived := create("acme-archived", "Old Acme", acme)
+	client.Tenant.UpdateOne(archived).SetArchived(true).ExecX(ctx)
+
+	return tenants
+}
+
+func ids(tenants map[string]*ent.Tenant, names ...string) []gidx.PrefixedID {
+	out := make([]gidx.PrefixedID, 0, len(names))
+
+	for _, name := range names {
+		out = append(out, tenants[name].ID)
+	}
+
+	return out
+}
+
+func TestIndexersMatch(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+	tenants := fixture(ctx, t, client)
+
+	bleve := newBleve(t)
+
+	indexed, err := search.Rebuild(ctx, client, bleve)
+	require.NoError(t, err)
+	assert.Equal(t, 6, indexed, "archived tenants aren't indexed")
+
+	indexers := map[string]search.Indexer{"sql": search.NewSQL(client), "bleve": bleve}
+
+	for _, tt := range []struct {
+		name  string
+		query search.Query
+		want  []gidx.PrefixedID
+	}{
+		{"name", search.Query{Text: "acme"}, ids(tenants, "acme", "acme-prod", "acme-staging")},
+		{"ignoring case", search.Query{Text: "ACME"}, ids(tenants, "acme", "acme-prod", "acme-staging")},
+		{"display name", search.Query{Text: "corp"}, ids(tenants, "acme", "globex")},
+		{"wildcards are literal", search.Query{Text: "_"}, ids(tenants, "Beta_Labs")},
+		{"percent", search.Query{Text: "100%"}, ids(tenants, "initech")},
+		{"regexp characters", search.Query{Text: "a.me"}, nil},
+		{"parent", search.Query{Text: "a", ParentID: tenants["acme"].ID}, ids(tenants, "Beta_Labs", "acme-prod", "acme-staging")},
+		{"limit", search.Query{Text: "acme", Limit: 2}, ids(tenants, "acme", "acme-prod")},
+		{"none", search.Query{Text: "umbrella"}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, indexer := range indexers {
+				got, err := indexer.Query(ctx, tt.query)
+				require.NoError(t, err, name)
+				assert.Equal(t, tt.want, got, name)
+			}
+		})
+	}
+}
+
+func TestSyncer(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+	tenants := fixture(ctx, t, client)
+
+	bleve := newBleve(t)
+	syncer := search.NewSyncer(client, bleve, zap.NewNop().Sugar())
+
+	_, built, err := bleve.Checkpoint(ctx)
+	require.NoError(t, err)
+	assert.False(t, built)
+
+	_, err = syncer.Sync(ctx)
+	require.NoError(t, err)
+
+	query := func(text string) []gidx.PrefixedID {
+		got, err := bleve.Query(ctx, search.Query{Text: text})
+		require.NoError(t, err)
+
+		return got
+	}
+
+	assert.Equal(t, ids(tenants, "globex"), query("globex"), "the index is built on the first sync")
+
+	client.Tenant.UpdateOne(tenants["globex"]).SetName("hooli").SetDisplayName("Hooli").ExecX(ctx)
+	client.Tenant.UpdateOne(tenants["acme-archived"]).SetArchived(false).ExecX(ctx)
+	client.Tenant.UpdateOne(tenants["acme-prod"]).SetArchived(true).ExecX(ctx)
+	client.Tenant.DeleteOne(tenants["acme-staging"]).ExecX(ctx)
+	umbrella := client.Tenant.Create().SetName("umbrella").SaveX(ctx)
+
+	assert.Empty(t, query("hooli"), "changes are indexed when synced")
+
+	synced, err := syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, synced)
+
+	assert.Empty(t, query("globex"))
+	assert.Equal(t, ids(tenants, "globex"), query("hooli"))
+	assert.Equal(t, ids(tenants, "acme", "acme-archived"), query("acme"))
+	assert.Equal(t, []gidx.PrefixedID{umbrella.ID}, query("umbrella"))
+
+	synced, err = syncer.Sync(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, synced, "nothing changed since")
+}
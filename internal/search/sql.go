@@ -0,0 +1,49 @@
+package search
+
+import (
+	"context"
+
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// SQL is the default Indexer, querying the tenants table with a case insensitive LIKE, ILIKE on
+// PostgreSQL. The table is the index, so indexing and deleting do nothing.
+type SQL struct {
+	client *generated.Client
+}
+
+// NewSQL returns the indexer querying the tenants of the client.
+func NewSQL(client *generated.Client) *SQL {
+	return &SQL{client: client}
+}
+
+// Index implements Indexer, doing nothing.
+func (*SQL) Index(context.Context, ...Document) error {
+	return nil
+}
+
+// Delete implements Indexer, doing nothing.
+func (*SQL) Delete(context.Context, ...gidx.PrefixedID) error {
+	return nil
+}
+
+// Query implements Indexer.
+func (s *SQL) Query(ctx context.Context, q Query) ([]gidx.PrefixedID, error) {
+	query := s.client.Tenant.Query().
+		Where(
+			enttenant.Or(enttenant.NameContainsFold(q.Text), enttenant.DisplayNameContainsFold(q.Text)),
+			enttenant.Or(enttenant.ArchivedIsNil(), enttenant.Archived(false)),
+		)
+
+	if q.ParentID != "" {
+		query = query.Where(enttenant.ParentTenantID(q.ParentID))
+	}
+
+	return query.
+		Order(generated.Asc(enttenant.FieldName), generated.Asc(enttenant.FieldID)).
+		Limit(q.limit()).
+		IDs(ctx)
+}
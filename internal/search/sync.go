@@ -0,0 +1,258 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// Defaults of the Syncer.
+const (
+	DefaultSyncInterval  = 5 * time.Second
+	DefaultSyncBatchSize = 500
+)
+
+// Rebuild indexes every tenant of the database and checkpoints the index at the last recorded
+// change, returning how many tenants were indexed. The checkpoint is read first, so the changes
+// made while rebuilding are indexed again by the Syncer. Tenants deleted before the rebuild are
+// only dropped from an index which didn't have them, rebuild into a new one to drop them.
+func Rebuild(ctx context.Context, client *generated.Client, index SyncedIndexer) (int, error) {
+	seq, err := lastChange(ctx, client)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		after   gidx.PrefixedID
+		indexed int
+	)
+
+	for {
+		query := client.Tenant.Query().
+			Order(generated.Asc(enttenant.FieldID)).
+			Limit(DefaultSyncBatchSize)
+
+		if after != "" {
+			query = query.Where(enttenant.IDGT(after))
+		}
+
+		tenants, err := query.All(ctx)
+		if err != nil {
+			return indexed, err
+		}
+
+		if len(tenants) == 0 {
+			break
+		}
+
+		n, err := apply(ctx, index, tenants, nil)
+		if err != nil {
+			return indexed, err
+		}
+
+		indexed += n
+		after = tenants[len(tenants)-1].ID
+	}
+
+	return indexed, index.SetCheckpoint(ctx, seq)
+}
+
+// lastChange returns the sequence of the last recorded change, 0 when there is none.
+func lastChange(ctx context.Context, client *generated.Client) (int64, error) {
+	var last []struct {
+		Max sql.NullInt64 `json:"max"`
+	}
+
+	err := client.TenantChange.Query().
+		Aggregate(generated.Max(enttenantchange.FieldID)).
+		Scan(ctx, &last)
+	if err != nil || len(last) == 0 {
+		return 0, err
+	}
+
+	return last[0].Max.Int64, nil
+}
+
+// apply indexes the tenants, deleting the archived ones and the missing ones from the index,
+// and returns how many were indexed.
+func apply(ctx context.Context, index Indexer, tenants []*generated.Tenant, missing []gidx.PrefixedID) (int, error) {
+	var docs []Document
+
+	deleted := missing
+
+	for _, t := range tenants {
+		if indexed(t) {
+			docs = append(docs, NewDocument(t))
+		} else {
+			deleted = append(deleted, t.ID)
+		}
+	}
+
+	if len(docs) > 0 {
+		if err := index.Index(ctx, docs...); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(deleted) > 0 {
+		if err := index.Delete(ctx, deleted...); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(docs), nil
+}
+
+// SyncOption configures a Syncer.
+type SyncOption func(*Syncer)
+
+// WithSyncInterval sets the interval Run syncs the index at.
+func WithSyncInterval(d time.Duration) SyncOption {
+	return func(s *Syncer) {
+		if d > 0 {
+			s.interval = d
+		}
+	}
+}
+
+// WithSyncBatchSize sets the most changes read at once.
+func WithSyncBatchSize(n int) SyncOption {
+	return func(s *Syncer) {
+		if n > 0 {
+			s.batchSize = n
+		}
+	}
+}
+
+// Syncer keeps an index up to date by tailing the tenant_changes table, the outbox of the tenant
+// mutations. Changes are only recorded when their mutation commits, so the index never sees a
+// tenant rolled back. The tenants are indexed as they are when synced rather than as each change
+// left them, so syncing a change twice is harmless.
+type Syncer struct {
+	client    *generated.Client
+	index     SyncedIndexer
+	logger    *zap.SugaredLogger
+	interval  time.Duration
+	batchSize int
+}
+
+// NewSyncer returns a syncer of the index with the tenants of the client.
+func NewSyncer(client *generated.Client, index SyncedIndexer, logger *zap.SugaredLogger, opts ...SyncOption) *Syncer {
+	s := &Syncer{
+		client:    client,
+		index:     index,
+		logger:    logger,
+		interval:  DefaultSyncInterval,
+		batchSize: DefaultSyncBatchSize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Sync indexes the tenants changed since the checkpoint of the index and moves the checkpoint to
+// the last change, returning how many changes were synced. Indexes which were never built are
+// rebuilt first.
+func (s *Syncer) Sync(ctx context.Context) (int, error) {
+	seq, built, err := s.index.Checkpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if !built {
+		if _, err := Rebuild(ctx, s.client, s.index); err != nil {
+			return 0, err
+		}
+
+		if seq, _, err = s.index.Checkpoint(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	synced := 0
+
+	for {
+		changes, err := s.client.TenantChange.Query().
+			Where(enttenantchange.IDGT(seq)).
+			Order(generated.Asc(enttenantchange.FieldID)).
+			Limit(s.batchSize).
+			All(ctx)
+		if err != nil || len(changes) == 0 {
+			return synced, err
+		}
+
+		if err := s.syncChanges(ctx, changes); err != nil {
+			return synced, err
+		}
+
+		seq = changes[len(changes)-1].ID
+
+		if err := s.index.SetCheckpoint(ctx, seq); err != nil {
+			return synced, err
+		}
+
+		synced += len(changes)
+	}
+}
+
+// syncChanges indexes the tenants of the changes, deleting those which are gone.
+func (s *Syncer) syncChanges(ctx context.Context, changes []*generated.TenantChange) error {
+	seen := make(map[gidx.PrefixedID]bool, len(changes))
+	ids := make([]gidx.PrefixedID, 0, len(changes))
+
+	for _, c := range changes {
+		if !seen[c.TenantID] {
+			seen[c.TenantID] = true
+			ids = append(ids, c.TenantID)
+		}
+	}
+
+	tenants, err := s.client.Tenant.Query().Where(enttenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tenants {
+		delete(seen, t.ID)
+	}
+
+	missing := make([]gidx.PrefixedID, 0, len(seen))
+
+	for _, id := range ids {
+		if seen[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	_, err = apply(ctx, s.index, tenants, missing)
+
+	return err
+}
+
+// Run syncs the index every interval until the context is done.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Errorw("failed to sync the search index", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
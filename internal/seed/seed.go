@@ -0,0 +1,240 @@
+// Package seed generates tenant hierarchies for performance testing.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+const (
+	// DefaultNamePattern is the pattern used to name generated tenants.
+	DefaultNamePattern = "tenant-{level}-{n}"
+	// DefaultBatchSize is the number of tenants inserted per statement.
+	DefaultBatchSize = 500
+)
+
+var (
+	// ErrDatabaseNotEmpty is returned when seeding a database which already contains tenants without forcing it.
+	ErrDatabaseNotEmpty = errors.New("database already contains tenants, use force to seed anyway")
+
+	// ErrInvalidShape is returned when the requested hierarchy shape is invalid.
+	ErrInvalidShape = errors.New("roots, depth and fan-out must be positive")
+
+	// ErrInvalidLabels is returned when the requested label distribution is invalid.
+	ErrInvalidLabels = errors.New("label keys and values must not be negative, values must be positive with keys and the probability between 0 and 1")
+)
+
+// LabelDistribution describes the labels set on the generated tenants. Each of the keys is set on
+// each tenant with the probability, to one of the values picked uniformly.
+type LabelDistribution struct {
+	// Keys is the number of label keys, named key-0 to key-{Keys-1}. No labels are set without keys.
+	Keys int
+	// Values is the number of values of each key, named value-0 to value-{Values-1}.
+	Values int
+	// Probability is the probability of each key being set on a tenant, between 0 and 1.
+	Probability float64
+	// Seed seeds the random choices, the same seed generates the same labels.
+	Seed int64
+}
+
+func (d LabelDistribution) validate() error {
+	if d.Keys < 0 || d.Values < 0 || (d.Keys > 0 && d.Values < 1) || d.Probability < 0 || d.Probability > 1 {
+		return ErrInvalidLabels
+	}
+
+	return nil
+}
+
+// labelGenerator picks the labels of each tenant following a distribution.
+type labelGenerator struct {
+	dist LabelDistribution
+	rand *rand.Rand
+}
+
+func newLabelGenerator(dist LabelDistribution) *labelGenerator {
+	return &labelGenerator{
+		dist: dist,
+		rand: rand.New(rand.NewSource(dist.Seed)), //nolint:gosec // seed data doesn't need a secure source
+	}
+}
+
+// next returns the labels of the next tenant, nil when none is picked.
+func (g *labelGenerator) next() map[string]string {
+	var labels map[string]string
+
+	for k := 0; k < g.dist.Keys; k++ {
+		if g.rand.Float64() >= g.dist.Probability {
+			continue
+		}
+
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		labels["key-"+strconv.Itoa(k)] = "value-" + strconv.Itoa(g.rand.Intn(g.dist.Values))
+	}
+
+	return labels
+}
+
+// Config describes the shape of the generated hierarchy.
+type Config struct {
+	// Roots is the number of root tenants created.
+	Roots int
+	// Depth is the number of levels created below each root.
+	Depth int
+	// FanOut is the number of children created for each tenant above the last level.
+	FanOut int
+	// NamePattern names each tenant, {level} and {n} are replaced with the level and index within the level.
+	NamePattern string
+	// BatchSize is the number of tenants inserted per statement.
+	BatchSize int
+	// Labels is the distribution of the labels set on the generated tenants.
+	Labels LabelDistribution
+	// Force allows seeding a database which already contains tenants.
+	Force bool
+}
+
+// Summary reports what was generated.
+type Summary struct {
+	Tenants  int           `json:"tenants"`
+	Levels   int           `json:"levels"`
+	Labels   int           `json:"labels"`
+	Duration time.Duration `json:"duration"`
+}
+
+// String implements fmt.Stringer.
+func (s Summary) String() string {
+	return fmt.Sprintf("created %d tenants across %d levels with %d labels in %s", s.Tenants, s.Levels, s.Labels, s.Duration)
+}
+
+// tenantSpec is a tenant to create.
+type tenantSpec struct {
+	input  ent.CreateTenantInput
+	labels map[string]string
+}
+
+// Run generates the hierarchy described by cfg.
+func Run(ctx context.Context, client *ent.Client, cfg Config) (Summary, error) {
+	if cfg.Roots < 1 || cfg.Depth < 0 || cfg.FanOut < 1 {
+		return Summary{}, ErrInvalidShape
+	}
+
+	if err := cfg.Labels.validate(); err != nil {
+		return Summary{}, err
+	}
+
+	if cfg.NamePattern == "" {
+		cfg.NamePattern = DefaultNamePattern
+	}
+
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+
+	if !cfg.Force {
+		exists, err := client.Tenant.Query().Exist(ctx)
+		if err != nil {
+			return Summary{}, err
+		}
+
+		if exists {
+			return Summary{}, ErrDatabaseNotEmpty
+		}
+	}
+
+	start := time.Now()
+	summary := Summary{Levels: cfg.Depth + 1}
+
+	labels := newLabelGenerator(cfg.Labels)
+
+	parents := make([]*gidx.PrefixedID, cfg.Roots)
+
+	for level := 0; level <= cfg.Depth; level++ {
+		var specs []tenantSpec
+
+		for _, parent := range parents {
+			count := cfg.FanOut
+			if parent == nil {
+				count = 1
+			}
+
+			for i := 0; i < count; i++ {
+				spec := tenantSpec{
+					input: ent.CreateTenantInput{
+						Name:     tenantName(cfg.NamePattern, level, len(specs)),
+						ParentID: parent,
+					},
+					labels: labels.next(),
+				}
+
+				summary.Labels += len(spec.labels)
+				specs = append(specs, spec)
+			}
+		}
+
+		created, err := createBatches(ctx, client, specs, cfg.BatchSize)
+		if err != nil {
+			return summary, err
+		}
+
+		summary.Tenants += len(created)
+
+		parents = make([]*gidx.PrefixedID, len(created))
+		for i := range created {
+			parents[i] = &created[i].ID
+		}
+	}
+
+	summary.Duration = time.Since(start)
+
+	return summary, nil
+}
+
+func createBatches(ctx context.Context, client *ent.Client, specs []tenantSpec, size int) ([]*ent.Tenant, error) {
+	created := make([]*ent.Tenant, 0, len(specs))
+
+	for start := 0; start < len(specs); start += size {
+		end := start + size
+		if end > len(specs) {
+			end = len(specs)
+		}
+
+		builders := make([]*ent.TenantCreate, 0, end-start)
+
+		for _, spec := range specs[start:end] {
+			builder := client.Tenant.Create().SetInput(spec.input)
+
+			if spec.labels != nil {
+				builder.SetLabels(spec.labels)
+			}
+
+			builders = append(builders, builder)
+		}
+
+		tenants, err := client.Tenant.CreateBulk(builders...).Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert batch: %w", err)
+		}
+
+		created = append(created, tenants...)
+	}
+
+	return created, nil
+}
+
+func tenantName(pattern string, level, n int) string {
+	return strings.NewReplacer(
+		"{level}", strconv.Itoa(level),
+		"{n}", strconv.Itoa(n),
+	).Replace(pattern)
+}
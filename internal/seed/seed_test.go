@@ -0,0 +1,92 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/seed"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:seed?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	summary, err := seed.Run(ctx, client, seed.Config{
+		Roots:     2,
+		Depth:     2,
+		FanOut:    3,
+		BatchSize: 4,
+	})
+	require.NoError(t, err)
+
+	// 2 roots, 6 children, 18 grandchildren
+	assert.Equal(t, 26, summary.Tenants)
+	assert.Equal(t, 3, summary.Levels)
+	assert.Equal(t, 26, client.Tenant.Query().CountX(ctx))
+	assert.Equal(t, 2, client.Tenant.Query().Where(tenant.ParentTenantIDIsNil()).CountX(ctx))
+	assert.Equal(t, 18, client.Tenant.Query().Where(tenant.Not(tenant.HasChildren())).CountX(ctx))
+
+	_, err = seed.Run(ctx, client, seed.Config{Roots: 1, FanOut: 1})
+	assert.ErrorIs(t, err, seed.ErrDatabaseNotEmpty)
+
+	summary, err = seed.Run(ctx, client, seed.Config{Roots: 1, FanOut: 1, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Tenants)
+}
+
+func TestRunLabels(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:seed-labels?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	summary, err := seed.Run(ctx, client, seed.Config{
+		Roots:  2,
+		Depth:  2,
+		FanOut: 3,
+		Labels: seed.LabelDistribution{Keys: 3, Values: 2, Probability: 0.5, Seed: 1},
+	})
+	require.NoError(t, err)
+
+	var labels int
+
+	for _, tnt := range client.Tenant.Query().AllX(ctx) {
+		for k, v := range tnt.Labels {
+			assert.Contains(t, []string{"key-0", "key-1", "key-2"}, k)
+			assert.Contains(t, []string{"value-0", "value-1"}, v)
+
+			labels++
+		}
+	}
+
+	assert.Equal(t, summary.Labels, labels)
+
+	// 26 tenants with 3 keys each set half of the time
+	assert.InDelta(t, 39, labels, 20)
+
+	summary, err = seed.Run(ctx, client, seed.Config{
+		Roots:  1,
+		FanOut: 1,
+		Labels: seed.LabelDistribution{Keys: 2, Values: 1, Probability: 1},
+		Force:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.Labels, "every key is set with a probability of 1")
+
+	for _, dist := range []seed.LabelDistribution{
+		{Keys: 1},
+		{Keys: -1, Values: 1},
+		{Keys: 1, Values: 1, Probability: 1.5},
+	} {
+		_, err = seed.Run(ctx, client, seed.Config{Roots: 1, FanOut: 1, Labels: dist, Force: true})
+		assert.ErrorIs(t, err, seed.ErrInvalidLabels, dist)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servertiming measures the server-side duration of each request, telling the time spent
+// by the service from the time spent on the network. The ent driver is wrapped with Driver and the
+// events connection with Connection, which add the time spent in the database and publishing
+// events to the timings attached to the request context by Middleware. The middleware reports
+// them, with the total duration of the request, in the Server-Timing response header and as
+// attributes of the span of the request.
+package servertiming
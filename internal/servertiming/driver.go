@@ -0,0 +1,152 @@
+package servertiming
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"entgo.io/ent/dialect"
+)
+
+var errUnsupported = errors.New("not supported by the wrapped driver")
+
+type (
+	queryContexter interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	}
+
+	execContexter interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	}
+
+	txBeginner interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	}
+)
+
+type driver struct {
+	dialect.Driver
+}
+
+// Driver wraps the ent driver to time the statements run with a context carrying timings,
+// including those of transactions and their commit. The rows of raw queries are read by the
+// caller, only the time until the query returns is measured.
+func Driver(drv dialect.Driver) dialect.Driver {
+	return &driver{Driver: drv}
+}
+
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	defer measure(ctx, dbCounter)()
+
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	defer measure(ctx, dbCounter)()
+
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// QueryContext is used by the client for raw queries.
+func (d *driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, d.Driver, query, args...)
+}
+
+// ExecContext is used by the client for raw statements.
+func (d *driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execContext(ctx, d.Driver, query, args...)
+}
+
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	done := measure(ctx, dbCounter)
+
+	tx, err := d.Driver.Tx(ctx)
+
+	done()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &txn{Tx: tx, ctx: ctx}, nil
+}
+
+// BeginTx is used by the client to start transactions with options.
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	b, ok := d.Driver.(txBeginner)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	done := measure(ctx, dbCounter)
+
+	tx, err := b.BeginTx(ctx, opts)
+
+	done()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &txn{Tx: tx, ctx: ctx}, nil
+}
+
+type txn struct {
+	dialect.Tx
+	// ctx is the context the transaction was started with, commits don't take one
+	ctx context.Context
+}
+
+func (t *txn) Exec(ctx context.Context, query string, args, v any) error {
+	defer measure(ctx, dbCounter)()
+
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+func (t *txn) Query(ctx context.Context, query string, args, v any) error {
+	defer measure(ctx, dbCounter)()
+
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+func (t *txn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, t.Tx, query, args...)
+}
+
+func (t *txn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return execContext(ctx, t.Tx, query, args...)
+}
+
+func (t *txn) Commit() error {
+	defer measure(t.ctx, dbCounter)()
+
+	return t.Tx.Commit()
+}
+
+func (t *txn) Rollback() error {
+	defer measure(t.ctx, dbCounter)()
+
+	return t.Tx.Rollback()
+}
+
+func queryContext(ctx context.Context, q any, query string, args ...any) (*sql.Rows, error) {
+	qc, ok := q.(queryContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	defer measure(ctx, dbCounter)()
+
+	return qc.QueryContext(ctx, query, args...)
+}
+
+func execContext(ctx context.Context, e any, query string, args ...any) (sql.Result, error) {
+	ec, ok := e.(execContexter)
+	if !ok {
+		return nil, errUnsupported
+	}
+
+	defer measure(ctx, dbCounter)()
+
+	return ec.ExecContext(ctx, query, args...)
+}
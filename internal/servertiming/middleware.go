@@ -0,0 +1,94 @@
+package servertiming
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// HeaderServerTiming is the response header reporting the durations of the request.
+	HeaderServerTiming = "Server-Timing"
+
+	// AttributeTotal is the span attribute reporting the duration of the request, in milliseconds.
+	AttributeTotal = "server.duration_ms"
+	// AttributeDB is the span attribute reporting the time spent in the database, in milliseconds.
+	AttributeDB = "db.duration_ms"
+	// AttributePublish is the span attribute reporting the time spent publishing events, in
+	// milliseconds.
+	AttributePublish = "events.publish_duration_ms"
+)
+
+// Metrics of the Server-Timing header.
+const (
+	MetricTotal   = "total"
+	MetricDB      = "db"
+	MetricPublish = "publish"
+)
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	header bool
+}
+
+// WithHeader reports the durations of each request in the Server-Timing response header. They
+// are the ones when the response headers are written, streamed responses may take longer.
+func WithHeader(enabled bool) Option {
+	return func(c *config) {
+		c.header = enabled
+	}
+}
+
+// Middleware times the requests, their queries through a client using Driver and the changes they
+// publish through a Connection. The durations are set as attributes of the span of the request.
+func Middleware(opts ...Option) echo.MiddlewareFunc {
+	cfg := new(config)
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ctx, timings := NewContext(req.Context())
+			c.SetRequest(req.WithContext(ctx))
+
+			if cfg.header {
+				resp := c.Response()
+				resp.Before(func() {
+					resp.Header().Set(HeaderServerTiming, timings.header())
+				})
+			}
+
+			err := next(c)
+
+			trace.SpanFromContext(ctx).SetAttributes(
+				attribute.Float64(AttributeTotal, milliseconds(timings.Total())),
+				attribute.Float64(AttributeDB, milliseconds(timings.DB())),
+				attribute.Float64(AttributePublish, milliseconds(timings.Publish())),
+			)
+
+			return err
+		}
+	}
+}
+
+// header formats the timings as the value of the Server-Timing header.
+func (t *Timings) header() string {
+	return fmt.Sprintf("%s;dur=%.3f, %s;dur=%.3f, %s;dur=%.3f",
+		MetricDB, milliseconds(t.DB()),
+		MetricPublish, milliseconds(t.Publish()),
+		MetricTotal, milliseconds(t.Total()),
+	)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,23 @@
+package servertiming
+
+import (
+	"context"
+
+	"go.infratographer.com/x/events"
+)
+
+type connection struct {
+	events.Connection
+}
+
+// Connection wraps the events connection to time the changes published with a context carrying
+// timings. Changes held until a transaction commits are timed as they are flushed.
+func Connection(conn events.Connection) events.Connection {
+	return &connection{Connection: conn}
+}
+
+func (c *connection) PublishChange(ctx context.Context, topic string, message events.ChangeMessage) (events.Message[events.ChangeMessage], error) {
+	defer measure(ctx, publishCounter)()
+
+	return c.Connection.PublishChange(ctx, topic, message)
+}
@@ -0,0 +1,120 @@
+package servertiming_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/servertiming"
+)
+
+func newClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	drv, err := entsql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := ent.NewClient(ent.Driver(servertiming.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	return client
+}
+
+func TestDriver(t *testing.T) {
+	client := newClient(t)
+
+	ctx, timings := servertiming.NewContext(context.Background())
+
+	client.Tenant.Create().SetName("root").ExecX(ctx)
+	afterCreate := timings.DB()
+	assert.Positive(t, afterCreate)
+
+	tx, err := client.BeginTx(ctx, nil)
+	require.NoError(t, err)
+
+	tx.Tenant.Query().CountX(ctx)
+	require.NoError(t, tx.Commit())
+
+	afterTx := timings.DB()
+	assert.Greater(t, afterTx, afterCreate, "transactions are timed")
+
+	client.Tenant.Query().AllX(context.Background())
+	assert.Equal(t, afterTx, timings.DB(), "queries without timings aren't timed")
+
+	assert.Zero(t, timings.Publish())
+	assert.GreaterOrEqual(t, timings.Total(), timings.DB())
+}
+
+func TestConnection(t *testing.T) {
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	ctx, timings := servertiming.NewContext(context.Background())
+
+	_, err := servertiming.Connection(conn).PublishChange(ctx, "tenant", events.ChangeMessage{})
+	require.NoError(t, err)
+
+	assert.Positive(t, timings.Publish())
+	assert.Zero(t, timings.DB())
+}
+
+func TestMiddleware(t *testing.T) {
+	client := newClient(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	list := func(c echo.Context) error {
+		if _, err := client.Tenant.Query().All(c.Request().Context()); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+
+	serve := func(opts ...servertiming.Option) *httptest.ResponseRecorder {
+		ctx, span := tracer.Start(context.Background(), "request")
+		defer span.End()
+
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), rec)
+
+		require.NoError(t, servertiming.Middleware(opts...)(list)(c))
+
+		return rec
+	}
+
+	rec := serve(servertiming.WithHeader(true))
+	assert.Regexp(t, `^db;dur=\d+\.\d{3}, publish;dur=0\.000, total;dur=\d+\.\d{3}$`, rec.Header().Get(servertiming.HeaderServerTiming))
+
+	rec = serve()
+	assert.Empty(t, rec.Header().Get(servertiming.HeaderServerTiming), "the header is suppressed")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	attributes := make(map[string]float64)
+	for _, attr := range spans[1].Attributes() {
+		attributes[string(attr.Key)] = attr.Value.AsFloat64()
+	}
+
+	assert.Positive(t, attributes[servertiming.AttributeDB], "the span has the durations without the header")
+	assert.Positive(t, attributes[servertiming.AttributeTotal])
+	assert.Contains(t, attributes, servertiming.AttributePublish)
+}
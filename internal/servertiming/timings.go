@@ -0,0 +1,64 @@
+package servertiming
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type timingsCtxKey struct{}
+
+// Timings accumulates the durations of the work of a request. Queries and publishes running
+// concurrently are all added, so their durations may exceed the total.
+type Timings struct {
+	start   time.Time
+	db      atomic.Int64
+	publish atomic.Int64
+}
+
+// NewContext returns a context timing the queries and publishes done with it, and the timings.
+func NewContext(ctx context.Context) (context.Context, *Timings) {
+	t := &Timings{start: time.Now()}
+
+	return context.WithValue(ctx, timingsCtxKey{}, t), t
+}
+
+// FromContext returns the timings of the context, nil when it has none.
+func FromContext(ctx context.Context) *Timings {
+	t, _ := ctx.Value(timingsCtxKey{}).(*Timings)
+
+	return t
+}
+
+// Total returns the time elapsed since the timings were created.
+func (t *Timings) Total() time.Duration {
+	return time.Since(t.start)
+}
+
+// DB returns the time spent running database statements.
+func (t *Timings) DB() time.Duration {
+	return time.Duration(t.db.Load())
+}
+
+// Publish returns the time spent publishing events.
+func (t *Timings) Publish() time.Duration {
+	return time.Duration(t.publish.Load())
+}
+
+// measure returns a function adding the time elapsed until it is called to the counter chosen
+// from the timings of the context. Contexts without timings aren't measured, not even reading
+// the clock.
+func measure(ctx context.Context, counter func(*Timings) *atomic.Int64) func() {
+	t := FromContext(ctx)
+	if t == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() { counter(t).Add(int64(time.Since(start))) }
+}
+
+func dbCounter(t *Timings) *atomic.Int64 { return &t.db }
+
+func publishCounter(t *Timings) *atomic.Int64 { return &t.publish }
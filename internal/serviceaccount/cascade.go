@@ -0,0 +1,47 @@
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	entserviceaccount "go.infratographer.com/tenant-api/internal/ent/generated/serviceaccount"
+)
+
+// CascadeHook deletes the service accounts of tenants as they are deleted, one at a time so the
+// event hooks publish the deletion of each. The foreign key would delete them silently otherwise.
+// It should be registered after the hooks which may reject deletions, so accounts aren't deleted
+// for tenants which remain.
+func CascadeHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(ids) != 0 {
+					accounts, err := m.Client().ServiceAccount.Query().
+						Where(entserviceaccount.TenantIDIn(ids...)).
+						All(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					for _, account := range accounts {
+						if err := m.Client().ServiceAccount.DeleteOne(account).Exec(ctx); err != nil {
+							return nil, fmt.Errorf("deleting service account %s: %w", account.ID, err)
+						}
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpDelete|ent.OpDeleteOne,
+	)
+}
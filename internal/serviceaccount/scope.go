@@ -0,0 +1,201 @@
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/permissions-api/pkg/permissions"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/actor"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/internal/errmap"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// maxAncestors bounds the ancestors walked to find whether a tenant is within a subtree.
+const maxAncestors = 1000
+
+// ErrUnknownAccount is returned when a request is made by a service account which doesn't exist,
+// such as one deleted while credentials bound to it are still valid.
+var ErrUnknownAccount = apierrors.New(apierrors.ErrUnauthenticated, "unknown service account")
+
+// IsAccount reports whether the subject is a service account.
+func IsAccount(subject string) bool {
+	id, err := gidx.Parse(subject)
+
+	return err == nil && id.Prefix() == schema.ServiceAccountPrefix
+}
+
+// Middleware limits the requests made by service accounts to the subtree of their tenant. It
+// runs after the permissions middleware, wrapping the permission checker it installed: access to
+// tenants outside the subtree, and to their service accounts, is denied before the checker is
+// asked. Requests made by other subjects are left as they are. The changes are checked again by
+// ScopeHook.
+func Middleware(client *generated.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := req.Context()
+
+			subject := actor.FromContext(ctx)
+			if !IsAccount(subject) {
+				return next(c)
+			}
+
+			account, err := client.ServiceAccount.Get(ctx, gidx.PrefixedID(subject))
+			if err != nil {
+				if generated.IsNotFound(err) {
+					err = ErrUnknownAccount
+				}
+
+				return errmap.HTTPError(err)
+			}
+
+			checker, ok := ctx.Value(permissions.CheckerCtxKey).(permissions.Checker)
+			if !ok {
+				checker = permissions.DefaultDenyChecker
+			}
+
+			scoped := Scope(client, account.TenantID, checker)
+
+			c.SetRequest(req.WithContext(context.WithValue(ctx, permissions.CheckerCtxKey, scoped)))
+
+			return next(c)
+		}
+	}
+}
+
+// ScopeHook returns an ent hook rejecting the tenant changes service accounts make outside the
+// subtree of their tenant, whichever api they are made through. Tenants are created and moved
+// within it only, and only those in it are changed or deleted. It backs the checker installed by
+// Middleware, which also covers reads.
+func ScopeHook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+			subject := actor.FromContext(ctx)
+			if !IsAccount(subject) {
+				return next.Mutate(ctx, m)
+			}
+
+			if err := checkScope(ctx, m, gidx.PrefixedID(subject)); err != nil {
+				return nil, err
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// checkScope returns an error when the mutation changes a tenant, or moves one under a parent,
+// outside the subtree of the account's tenant.
+func checkScope(ctx context.Context, m *generated.TenantMutation, subject gidx.PrefixedID) error {
+	client := m.Client()
+
+	account, err := client.ServiceAccount.Get(ctx, subject)
+	if err != nil {
+		if generated.IsNotFound(err) {
+			err = ErrUnknownAccount
+		}
+
+		return err
+	}
+
+	var ids []gidx.PrefixedID
+
+	if !m.Op().Is(ent.OpCreate) {
+		if ids, err = m.IDs(ctx); err != nil {
+			return err
+		}
+	}
+
+	parent, moved := m.ParentTenantID()
+
+	switch {
+	case moved:
+		ids = append(ids, parent)
+	case m.Op().Is(ent.OpCreate) || m.ParentTenantIDCleared() || m.ParentCleared():
+		// root tenants are outside every subtree
+		return fmt.Errorf("%w: service accounts can't make root tenants", permissions.ErrPermissionDenied)
+	}
+
+	for _, id := range ids {
+		within, err := contains(ctx, client, account.TenantID, id)
+		if err != nil {
+			return err
+		}
+
+		if !within {
+			return fmt.Errorf("%w: %s is outside the subtree of the service account", permissions.ErrPermissionDenied, id)
+		}
+	}
+
+	return nil
+}
+
+// Scope returns a checker denying access to resources outside the subtree of root, asking the
+// checker about the others. Tenants are within the subtree when root is one of their ancestors or
+// the tenant itself, service accounts when their tenant is. Other resources are denied.
+func Scope(client *generated.Client, root gidx.PrefixedID, checker permissions.Checker) permissions.Checker {
+	return func(ctx context.Context, requests ...permissions.AccessRequest) error {
+		for _, r := range requests {
+			within, err := contains(ctx, client, root, r.ResourceID)
+			if err != nil {
+				return err
+			}
+
+			if !within {
+				return fmt.Errorf("%w: %s is outside the subtree of the service account", permissions.ErrPermissionDenied, r.ResourceID)
+			}
+		}
+
+		return checker(ctx, requests...)
+	}
+}
+
+// contains reports whether the resource is within the subtree of root.
+func contains(ctx context.Context, client *generated.Client, root, id gidx.PrefixedID) (bool, error) {
+	switch id.Prefix() {
+	case schema.ServiceAccountPrefix:
+		account, err := client.ServiceAccount.Get(ctx, id)
+		if err != nil {
+			if generated.IsNotFound(err) {
+				err = nil
+			}
+
+			return false, err
+		}
+
+		id = account.TenantID
+	case schema.TenantPrefix:
+	default:
+		return false, nil
+	}
+
+	for i := 0; i < maxAncestors && id != gidx.NullPrefixedID; i++ {
+		if id == root {
+			return true, nil
+		}
+
+		t, err := client.Tenant.Query().
+			Where(enttenant.ID(id)).
+			Select(enttenant.FieldParentTenantID).
+			Only(ctx)
+		if err != nil {
+			if generated.IsNotFound(err) {
+				err = nil
+			}
+
+			return false, err
+		}
+
+		id = t.ParentTenantID
+	}
+
+	return false, nil
+}
@@ -0,0 +1,238 @@
+package serviceaccount_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/echojwtx"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/graphapi"
+	"go.infratographer.com/tenant-api/internal/grpcapi"
+	"go.infratographer.com/tenant-api/internal/restapi"
+	"go.infratographer.com/tenant-api/internal/serviceaccount"
+	tenantv1 "go.infratographer.com/tenant-api/pkg/proto/tenant/v1"
+)
+
+// asActor returns a middleware making every request as the subject.
+func asActor(subject string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(context.WithValue(req.Context(), echojwtx.ActorCtxKey, subject)))
+
+			return next(c)
+		}
+	}
+}
+
+// TestScopeHookAcrossAPIs makes the same changes as a service account through every api. The
+// permission checker allows everything and the scope middleware isn't installed, the hook alone
+// keeps the account within its subtree.
+func TestScopeHookAcrossAPIs(t *testing.T) {
+	ctx := context.Background()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(serviceaccount.ScopeHook())
+
+	acme := client.Tenant.Create().SetName("acme").SaveX(ctx)
+	team := client.Tenant.Create().SetName("team").SetParent(acme).SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+	account := client.ServiceAccount.Create().SetName("deployer").SetTenantID(team.ID).SaveX(ctx)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	middleware := []echo.MiddlewareFunc{asActor(account.ID.String()), perms.Middleware()}
+
+	e := echo.New()
+	restapi.NewHandler(client, zap.NewNop().Sugar(), middleware).Routes(e.Group(""))
+	graphapi.NewResolver(client, zap.NewNop().Sugar()).Handler(false, middleware).Routes(e.Group(""))
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+
+	lis := bufconn.Listen(1 << 20)
+	grpcSrv := grpcapi.NewServer(client, changefeed.New(conn), zap.NewNop().Sugar()).GRPCServer(middleware)
+
+	go grpcSrv.Serve(lis) //nolint:errcheck // returns once stopped
+
+	t.Cleanup(grpcSrv.Stop)
+
+	grpcConn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { grpcConn.Close() })
+
+	svc := tenantv1.NewTenantServiceClient(grpcConn)
+
+	post := func(t *testing.T, path string, body any) (int, []byte) {
+		t.Helper()
+
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		resp, err := http.Post(srv.URL+path, echo.MIMEApplicationJSON, bytes.NewReader(raw)) //nolint:noctx // test request
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		var out bytes.Buffer
+
+		_, err = out.ReadFrom(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, out.Bytes()
+	}
+
+	apis := []struct {
+		name string
+		// create returns the status of the create, permission denials are PermissionDenied
+		create func(t *testing.T, name string, parent gidx.PrefixedID) error
+	}{
+		{
+			name: "rest",
+			create: func(t *testing.T, name string, parent gidx.PrefixedID) error {
+				code, body := post(t, "/v1/tenants", map[string]any{"name": name, "parentID": parent})
+
+				switch code {
+				case http.StatusCreated:
+					return nil
+				case http.StatusForbidden:
+					return status.Error(codes.PermissionDenied, string(body))
+				default:
+					return status.Errorf(codes.Unknown, "%d: %s", code, body)
+				}
+			},
+		},
+		{
+			name: "graph",
+			create: func(t *testing.T, name string, parent gidx.PrefixedID) error {
+				code, body := post(t, "/query", map[string]any{
+					"query":     "mutation ($input: CreateTenantInput!) { tenantCreate(input: $input) { tenant { id } } }",
+					"variables": map[string]any{"input": map[string]any{"name": name, "parentID": parent}},
+				})
+
+				var resp struct {
+					Errors []struct {
+						Extensions struct {
+							Code string `json:"code"`
+						} `json:"extensions"`
+					} `json:"errors"`
+				}
+
+				require.NoError(t, json.Unmarshal(body, &resp))
+
+				switch {
+				case code == http.StatusOK && len(resp.Errors) == 0:
+					return nil
+				case code == http.StatusOK && resp.Errors[0].Extensions.Code == "permission_denied":
+					return status.Error(codes.PermissionDenied, string(body))
+				default:
+					return status.Errorf(codes.Unknown, "%d: %s", code, body)
+				}
+			},
+		},
+		{
+			name: "grpc",
+			create: func(t *testing.T, name string, parent gidx.PrefixedID) error {
+				_, err := svc.Create(ctx, &tenantv1.CreateRequest{Name: name, ParentId: parent.String()})
+
+				return err
+			},
+		},
+	}
+
+	for _, api := range apis {
+		t.Run(api.name, func(t *testing.T) {
+			require.NoError(t, api.create(t, api.name+"-inside", team.ID))
+			err := api.create(t, api.name+"-outside", other.ID)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), "the parent is outside the subtree: %v", err)
+
+			err = api.create(t, api.name+"-sibling", acme.ID)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err), "the parent of its tenant is outside the subtree: %v", err)
+
+			assert.True(t, client.Tenant.Query().Where(enttenant.Name(api.name+"-inside")).ExistX(ctx))
+			assert.False(t, client.Tenant.Query().Where(enttenant.NameHasPrefix(api.name+"-"), enttenant.NameNEQ(api.name+"-inside")).ExistX(ctx))
+		})
+	}
+
+	_, err = svc.Create(ctx, &tenantv1.CreateRequest{Name: "root"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "service accounts can't create root tenants")
+}
+
+func TestScopeHook(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(serviceaccount.ScopeHook())
+
+	acme := client.Tenant.Create().SetName("acme").SaveX(ctx)
+	team := client.Tenant.Create().SetName("team").SetParent(acme).SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(team).SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+	account := client.ServiceAccount.Create().SetName("deployer").SetTenantID(team.ID).SaveX(ctx)
+
+	asAccount := context.WithValue(ctx, echojwtx.ActorCtxKey, account.ID.String())
+
+	require.NoError(t, client.Tenant.UpdateOne(child).SetDescription("within").Exec(asAccount))
+
+	err := client.Tenant.UpdateOne(other).SetDescription("outside").Exec(asAccount)
+	assert.ErrorIs(t, err, permissions.ErrPermissionDenied)
+
+	err = client.Tenant.UpdateOne(child).SetParentID(other.ID).Exec(asAccount)
+	assert.ErrorIs(t, err, permissions.ErrPermissionDenied, "tenants can't be moved out of the subtree")
+
+	err = client.Tenant.UpdateOne(child).ClearParent().Exec(asAccount)
+	assert.ErrorIs(t, err, permissions.ErrPermissionDenied, "tenants can't be made roots")
+
+	err = client.Tenant.UpdateOne(other).SetParentID(child.ID).Exec(asAccount)
+	assert.ErrorIs(t, err, permissions.ErrPermissionDenied, "tenants can't be moved into the subtree")
+
+	_, err = client.Tenant.Delete().Where(enttenant.IDIn(child.ID, other.ID)).Exec(asAccount)
+	assert.ErrorIs(t, err, permissions.ErrPermissionDenied, "a single tenant outside the subtree rejects the deletion")
+	assert.Equal(t, 4, client.Tenant.Query().CountX(ctx))
+
+	require.NoError(t, client.Tenant.DeleteOne(child).Exec(asAccount))
+
+	client.ServiceAccount.DeleteOne(account).ExecX(ctx)
+
+	err = client.Tenant.UpdateOne(team).SetDescription("deleted account").Exec(asAccount)
+	assert.ErrorIs(t, err, serviceaccount.ErrUnknownAccount)
+
+	// other subjects are left to the permission checks
+	require.NoError(t, client.Tenant.UpdateOne(other).SetDescription("outside").Exec(context.WithValue(ctx, echojwtx.ActorCtxKey, "idntusr-someone")))
+}
@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// Version is the version of the snapshot format, it is bumped when the tenant resource changes in a
+// way consumers can't ignore.
+const Version = "v1"
+
+// Snapshot is the tenant resource carried by a change event under changefeed.SnapshotKey.
+type Snapshot struct {
+	Version string `json:"version"`
+	Tenant  Tenant `json:"tenant"`
+}
+
+// Tenant has the same shape as the tenant resource of the v1 REST api. Events are only consumed by
+// trusted services, so no field is redacted and the settings aren't included as they may be large.
+type Tenant struct {
+	ID          gidx.PrefixedID  `json:"id"`
+	Name        string           `json:"name"`
+	DisplayName string           `json:"displayName"`
+	Description string           `json:"description"`
+	ParentID    *gidx.PrefixedID `json:"parentID,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+
+	DeletionScheduledAt *time.Time       `json:"deletionScheduledAt,omitempty"`
+	ContactEmail        string           `json:"contactEmail,omitempty"`
+	BillingReference    string           `json:"billingReference,omitempty"`
+	OwnerID             *gidx.PrefixedID `json:"ownerID,omitempty"`
+	SuspendedAt         *time.Time       `json:"suspendedAt,omitempty"`
+	ExternalID          string           `json:"externalID,omitempty"`
+
+	ChangeSeq int64 `json:"changeSeq,omitempty"`
+}
+
+// New returns the snapshot of the tenant.
+func New(t *generated.Tenant) Snapshot {
+	s := Tenant{
+		ID:               t.ID,
+		Name:             t.Name,
+		DisplayName:      t.DisplayName,
+		Description:      t.Description,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+		ContactEmail:     t.ContactEmail,
+		BillingReference: t.BillingReference,
+		ExternalID:       t.ExternalID,
+		ChangeSeq:        t.ChangeSeq,
+	}
+
+	if t.ParentTenantID != gidx.NullPrefixedID {
+		s.ParentID = &t.ParentTenantID
+	}
+
+	if !t.DeletionScheduledAt.IsZero() {
+		s.DeletionScheduledAt = &t.DeletionScheduledAt
+	}
+
+	if t.OwnerID != gidx.NullPrefixedID {
+		s.OwnerID = &t.OwnerID
+	}
+
+	if !t.SuspendedAt.IsZero() {
+		s.SuspendedAt = &t.SuspendedAt
+	}
+
+	return Snapshot{Version: Version, Tenant: s}
+}
+
+// Hook returns an ent hook making the change events of the mutation carry the snapshot of their
+// tenant. It must be registered before the event hooks. Created and updated tenants are loaded as
+// the event is published, with the client of the mutation so they are seen as changed by it.
+// Deleted tenants are loaded before they are deleted, their events carry the final snapshot.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				client := m.Client()
+
+				snapshotter := func(ctx context.Context, id gidx.PrefixedID) (any, error) {
+					t, err := client.Tenant.Get(ctx, id)
+					if err != nil {
+						return nil, err
+					}
+
+					return New(t), nil
+				}
+
+				if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+					ids, err := m.IDs(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					tenants, err := client.Tenant.Query().Where(enttenant.IDIn(ids...)).All(ctx)
+					if err != nil {
+						return nil, err
+					}
+
+					deleted := make(map[gidx.PrefixedID]Snapshot, len(tenants))
+
+					for _, t := range tenants {
+						deleted[t.ID] = New(t)
+					}
+
+					snapshotter = func(_ context.Context, id gidx.PrefixedID) (any, error) {
+						if s, ok := deleted[id]; ok {
+							return s, nil
+						}
+
+						return nil, nil
+					}
+				}
+
+				return next.Mutate(changefeed.WithSnapshotter(ctx, snapshotter), m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
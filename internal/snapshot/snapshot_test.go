@@ -0,0 +1,173 @@
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/snapshot"
+)
+
+func newClient(t *testing.T, snapshots bool) (context.Context, *ent.Client, *eventtools.MockConnection) {
+	t.Helper()
+
+	conn := new(eventtools.MockConnection)
+	conn.On("PublishChange", mock.Anything, mock.Anything).Return(&eventtools.MockMessage[events.ChangeMessage]{}, nil)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.EventsPublisher(changefeed.New(conn))),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	if snapshots {
+		client.Tenant.Use(snapshot.Hook())
+	}
+
+	eventhooks.EventHooks(client)
+
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	return context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms), client, conn
+}
+
+// published returns the published changes as consumers decode them.
+func published(t *testing.T, conn *eventtools.MockConnection) []map[string]any {
+	t.Helper()
+
+	var messages []map[string]any
+
+	for _, call := range conn.Calls {
+		raw, err := json.Marshal(call.Arguments.Get(1))
+		require.NoError(t, err)
+
+		var msg map[string]any
+
+		require.NoError(t, json.Unmarshal(raw, &msg))
+
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+func keys(m any) []string {
+	var keys []string
+
+	for k := range m.(map[string]any) {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func TestSnapshotSchema(t *testing.T) {
+	testCases := []struct {
+		name      string
+		snapshots bool
+	}{
+		{name: "disabled", snapshots: false},
+		{name: "enabled", snapshots: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, client, conn := newClient(t, tt.snapshots)
+
+			root := client.Tenant.Create().SetName("root").SaveX(ctx)
+			child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+			client.Tenant.UpdateOneID(child.ID).SetDescription("updated").ExecX(ctx)
+			client.Tenant.DeleteOneID(child.ID).ExecX(ctx)
+
+			messages := published(t, conn)
+			require.Len(t, messages, 4)
+
+			expected := []struct {
+				eventType string
+				subject   string
+			}{
+				{"create", root.ID.String()},
+				{"create", child.ID.String()},
+				{"update", child.ID.String()},
+				{"delete", child.ID.String()},
+			}
+
+			for i, msg := range messages {
+				// consumers reading the subjects see the same messages either way
+				assert.Equal(t, expected[i].eventType, msg["eventType"])
+				assert.Equal(t, expected[i].subject, msg["subjectID"])
+
+				additional, _ := msg["additionalData"].(map[string]any)
+
+				if !tt.snapshots {
+					assert.NotContains(t, additional, changefeed.SnapshotKey)
+
+					continue
+				}
+
+				require.Contains(t, additional, changefeed.SnapshotKey)
+
+				s := additional[changefeed.SnapshotKey].(map[string]any)
+				assert.ElementsMatch(t, []string{"version", "tenant"}, keys(s))
+				assert.Equal(t, snapshot.Version, s["version"])
+
+				tenant := s["tenant"].(map[string]any)
+				assert.Equal(t, expected[i].subject, tenant["id"])
+
+				if i == 0 {
+					assert.ElementsMatch(t, []string{"id", "name", "displayName", "description", "createdAt", "updatedAt"}, keys(tenant))
+				} else {
+					assert.ElementsMatch(t, []string{"id", "name", "displayName", "description", "parentID", "createdAt", "updatedAt"}, keys(tenant))
+					assert.Equal(t, root.ID.String(), tenant["parentID"])
+				}
+			}
+
+			if tt.snapshots {
+				// the deletion carries the tenant as it was before being deleted
+				deleted := messages[3]["additionalData"].(map[string]any)[changefeed.SnapshotKey].(map[string]any)["tenant"].(map[string]any)
+				assert.Equal(t, "updated", deleted["description"])
+			}
+		})
+	}
+}
+
+func TestSnapshotBatch(t *testing.T) {
+	ctx, client, conn := newClient(t, true)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+
+	conn.Calls = nil
+
+	batchCtx, batch := changefeed.WithBatch(ctx)
+
+	tx, err := client.Tx(batchCtx)
+	require.NoError(t, err)
+
+	tx.Tenant.UpdateOneID(root.ID).SetDescription("first").ExecX(batchCtx)
+	tx.Tenant.UpdateOneID(root.ID).SetDescription("second").ExecX(batchCtx)
+
+	require.NoError(t, tx.Commit())
+	require.NoError(t, batch.Flush(ctx))
+
+	messages := published(t, conn)
+	require.Len(t, messages, 2)
+
+	// each held change carries the tenant as of its own mutation
+	for i, description := range []string{"first", "second"} {
+		s := messages[i]["additionalData"].(map[string]any)[changefeed.SnapshotKey].(map[string]any)
+		assert.Equal(t, description, s["tenant"].(map[string]any)["description"])
+	}
+}
@@ -0,0 +1,109 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/migration"
+)
+
+// DatabaseCheck returns a check of the database accepting connections.
+func DatabaseCheck(client *ent.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		rows, err := client.QueryContext(ctx, "SELECT 1")
+		if err != nil {
+			return err
+		}
+
+		return rows.Close()
+	}
+}
+
+// MigrationsCheck returns a check of the database being migrated to the latest of the goose
+// migrations of the file system. The database may be migrated further by a newer release being
+// deployed, the check then fails permanently when one of those migrations declares the binary
+// too old for the schema, see migration.CheckCompatible.
+func MigrationsCheck(client *ent.Client, migrations fs.FS) (func(ctx context.Context) error, error) {
+	latest, err := LatestMigration(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		current, err := migrationVersion(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if current < latest {
+			return fmt.Errorf("migrations pending: the database is at version %d, want %d", current, latest)
+		}
+
+		if current == latest {
+			return nil
+		}
+
+		if err := migration.CheckCompatible(ctx, client, latest); err != nil {
+			if errors.Is(err, migration.ErrBinaryTooOld) {
+				return Permanent(err)
+			}
+
+			return err
+		}
+
+		return nil
+	}, nil
+}
+
+// migrationVersion returns the version of the latest migration applied to the database.
+func migrationVersion(ctx context.Context, client *ent.Client) (int64, error) {
+	rows, err := client.QueryContext(ctx, "SELECT version_id FROM goose_db_version WHERE is_applied ORDER BY id DESC LIMIT 1")
+	if err != nil {
+		return 0, fmt.Errorf("reading the migration version: %w", err)
+	}
+
+	defer rows.Close()
+
+	var current int64
+
+	if rows.Next() {
+		if err := rows.Scan(&current); err != nil {
+			return 0, err
+		}
+	}
+
+	return current, rows.Err()
+}
+
+// LatestMigration returns the version of the latest goose migration of the file system, the
+// number its sql file name starts with.
+func LatestMigration(migrations fs.FS) (int64, error) {
+	var latest int64
+
+	err := fs.WalkDir(migrations, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".sql" {
+			return err
+		}
+
+		prefix, _, _ := strings.Cut(d.Name(), "_")
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return fmt.Errorf("migration %s has no version: %w", p, err)
+		}
+
+		if version > latest {
+			latest = version
+		}
+
+		return nil
+	})
+
+	return latest, err
+}
@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package startup waits for the dependencies of the service before it serves requests. Pods are
+// often started before the database or the events server are reachable, rather than crash looping
+// the service retries each dependency with an exponential backoff up to a deadline. Its readiness
+// tells a service still starting from one whose dependencies failed once it was running.
+package startup
@@ -0,0 +1,37 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrStarting is reported by the readiness checks until the service finished starting.
+var ErrStarting = errors.New("starting")
+
+// Readiness tells a service still starting from one whose dependencies failed after it started.
+type Readiness struct {
+	started atomic.Bool
+}
+
+// MarkStarted records that the service finished starting.
+func (r *Readiness) MarkStarted() {
+	r.started.Store(true)
+}
+
+// Check wraps a readiness check. It fails with ErrStarting until the service finished starting,
+// then the failures of the check are reported as degraded.
+func (r *Readiness) Check(check func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if !r.started.Load() {
+			return ErrStarting
+		}
+
+		if err := check(ctx); err != nil {
+			return fmt.Errorf("degraded: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,226 @@
+package startup_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/migration"
+	"go.infratographer.com/tenant-api/internal/startup"
+)
+
+// freeAddr returns an address nothing listens on yet.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	return addr
+}
+
+func dial(addr string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		conn, err := new(net.Dialer).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
+
+func TestWaitForDelayedPort(t *testing.T) {
+	addr := freeAddr(t)
+
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+
+		lis, err := net.Listen("tcp", addr)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		t.Cleanup(func() { lis.Close() })
+	}()
+
+	attempts := 0
+	check := dial(addr)
+
+	w := startup.NewWaiter(5*time.Second, startup.WithBackoff(20*time.Millisecond, 100*time.Millisecond))
+
+	err := w.Wait(context.Background(), startup.Dependency{Name: "delayed", Check: func(ctx context.Context) error {
+		attempts++
+
+		return check(ctx)
+	}})
+	require.NoError(t, err)
+	assert.Greater(t, attempts, 1)
+}
+
+func TestWaitDeadline(t *testing.T) {
+	addr := freeAddr(t)
+
+	w := startup.NewWaiter(200*time.Millisecond, startup.WithBackoff(20*time.Millisecond, 50*time.Millisecond))
+
+	start := time.Now()
+
+	err := w.Wait(context.Background(),
+		startup.Dependency{Name: "available", Check: func(context.Context) error { return nil }},
+		startup.Dependency{Name: "database", Check: dial(addr)},
+	)
+	require.ErrorIs(t, err, startup.ErrDeadlineExceeded)
+	assert.Contains(t, err.Error(), "database")
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := startup.NewWaiter(time.Minute, startup.WithBackoff(time.Second, time.Second))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	err := w.Wait(ctx, startup.Dependency{Name: "never", Check: func(context.Context) error { return errors.New("unavailable") }})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReadiness(t *testing.T) {
+	var (
+		r      startup.Readiness
+		failed error
+	)
+
+	check := r.Check(func(context.Context) error { return failed })
+
+	assert.ErrorIs(t, check(context.Background()), startup.ErrStarting)
+
+	r.MarkStarted()
+
+	assert.NoError(t, check(context.Background()))
+
+	failed = errors.New("connection refused")
+
+	err := check(context.Background())
+	assert.EqualError(t, err, "degraded: connection refused")
+	assert.NotErrorIs(t, err, startup.ErrStarting)
+}
+
+func TestMigrationsCheck(t *testing.T) {
+	ctx := context.Background()
+
+	migrations := fstest.MapFS{
+		"migrations/20230101000000_init.sql":   {},
+		"migrations/20230202000000_change.sql": {},
+		"migrations/atlas.sum":                 {},
+	}
+
+	latest, err := startup.LatestMigration(migrations)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20230202000000), latest)
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	require.NoError(t, startup.DatabaseCheck(client)(ctx))
+
+	check, err := startup.MigrationsCheck(client, migrations)
+	require.NoError(t, err)
+
+	// goose hasn't run yet
+	assert.Error(t, check(ctx))
+
+	_, err = client.ExecContext(ctx, "CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY, version_id INTEGER, is_applied BOOLEAN)")
+	require.NoError(t, err)
+
+	_, err = client.ExecContext(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES (20230101000000, true)")
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, check(ctx), "migrations pending")
+
+	_, err = client.ExecContext(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES (20230202000000, true)")
+	require.NoError(t, err)
+
+	assert.NoError(t, check(ctx))
+}
+
+func TestWaitPermanent(t *testing.T) {
+	attempts := 0
+
+	w := startup.NewWaiter(time.Minute, startup.WithBackoff(time.Second, time.Second))
+
+	err := w.Wait(context.Background(), startup.Dependency{Name: "schema", Check: func(context.Context) error {
+		attempts++
+
+		return startup.Permanent(context.DeadlineExceeded)
+	}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, startup.ErrDeadlineExceeded)
+	assert.Equal(t, "schema: context deadline exceeded", err.Error())
+	assert.Equal(t, 1, attempts, "permanent failures aren't retried")
+}
+
+func TestMigrationsCheckBinaryTooOld(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	_, err := client.ExecContext(ctx, "CREATE TABLE goose_db_version (id INTEGER PRIMARY KEY, version_id INTEGER, is_applied BOOLEAN)")
+	require.NoError(t, err)
+
+	// the previous release embeds the migrations up to the expand one
+	old, err := startup.MigrationsCheck(client, fstest.MapFS{
+		"migrations/20230101000000_init.sql":   {},
+		"migrations/20230202000000_expand.sql": {},
+	})
+	require.NoError(t, err)
+
+	// the release being deployed migrated the schema further
+	for _, version := range []int64{20230101000000, 20230202000000, 20230303000000} {
+		_, err = client.ExecContext(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES (?, true)", version)
+		require.NoError(t, err)
+	}
+
+	// the migration doesn't declare a newer binary, the previous release keeps running
+	require.NoError(t, old(ctx))
+
+	// a contract migration declares the binaries of the expand migration as the oldest compatible
+	_, err = client.ExecContext(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES (20230404000000, true)")
+	require.NoError(t, err)
+
+	client.SchemaCompatibility.Create().SetID(20230404000000).SetMinBinaryVersion(20230202000000).ExecX(ctx)
+
+	require.NoError(t, old(ctx))
+
+	// older binaries are refused right away
+	older, err := startup.MigrationsCheck(client, fstest.MapFS{
+		"migrations/20230101000000_init.sql": {},
+	})
+	require.NoError(t, err)
+
+	err = older(ctx)
+	require.ErrorIs(t, err, migration.ErrBinaryTooOld)
+
+	w := startup.NewWaiter(time.Minute)
+
+	start := time.Now()
+
+	err = w.Wait(ctx, startup.Dependency{Name: "migrations", Check: older})
+	require.ErrorIs(t, err, migration.ErrBinaryTooOld)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,158 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Backoff defaults, the delay before the first retry doubles on each further one up to the max.
+const (
+	DefaultBackoff    = 250 * time.Millisecond
+	DefaultMaxBackoff = 10 * time.Second
+)
+
+// ErrDeadlineExceeded is returned when a dependency isn't available by the deadline.
+var ErrDeadlineExceeded = errors.New("dependency unavailable at the startup deadline")
+
+// permanentError is the failure of a check which retrying can't fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks the failure of a check as one retrying can't fix, Wait returns it right away.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Dependency is a dependency waited for, available once its check succeeds.
+type Dependency struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Option configures a Waiter.
+type Option func(*Waiter)
+
+// WithBackoff sets the delay before the first retry and the longest delay between two retries.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(w *Waiter) {
+		if initial > 0 {
+			w.backoff = initial
+		}
+
+		if max >= w.backoff {
+			w.maxBackoff = max
+		}
+	}
+}
+
+// WithLogger sets the logger the progress is logged with.
+func WithLogger(l *zap.SugaredLogger) Option {
+	return func(w *Waiter) {
+		w.logger = l
+	}
+}
+
+// Waiter waits for dependencies until a deadline.
+type Waiter struct {
+	timeout    time.Duration
+	backoff    time.Duration
+	maxBackoff time.Duration
+	logger     *zap.SugaredLogger
+}
+
+// NewWaiter returns a waiter giving the dependencies timeout to become available, all of them
+// together.
+func NewWaiter(timeout time.Duration, opts ...Option) *Waiter {
+	w := &Waiter{
+		timeout:    timeout,
+		backoff:    DefaultBackoff,
+		maxBackoff: DefaultMaxBackoff,
+		logger:     zap.NewNop().Sugar(),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Wait checks the dependencies in order, retrying each until it is available. It returns an error
+// wrapping ErrDeadlineExceeded and the last failure of the dependency which wasn't available by
+// the deadline, the permanent failure of a dependency, or the error of the context when it ends
+// first.
+func (w *Waiter) Wait(ctx context.Context, deps ...Dependency) error {
+	start := time.Now()
+	deadline := start.Add(w.timeout)
+
+	for _, dep := range deps {
+		if err := w.wait(ctx, dep, deadline); err != nil {
+			return err
+		}
+	}
+
+	w.logger.Infow("dependencies available", "elapsed", time.Since(start).String())
+
+	return nil
+}
+
+func (w *Waiter) wait(ctx context.Context, dep Dependency, deadline time.Time) error {
+	delay := w.backoff
+
+	for attempt := 1; ; attempt++ {
+		checkCtx, cancel := context.WithDeadline(ctx, deadline)
+		err := dep.Check(checkCtx)
+
+		cancel()
+
+		if err == nil {
+			w.logger.Infow("dependency available", "dependency", dep.Name, "attempts", attempt)
+
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var permanent *permanentError
+
+		if errors.As(err, &permanent) {
+			return fmt.Errorf("%s: %w", dep.Name, permanent.err)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%w: %s after %d attempts: %v", ErrDeadlineExceeded, dep.Name, attempt, err)
+		}
+
+		if delay > remaining {
+			delay = remaining
+		}
+
+		w.logger.Warnw("waiting for dependency", "dependency", dep.Name, "attempt", attempt, "retry_in", delay.String(), "error", err)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > w.maxBackoff {
+			delay = w.maxBackoff
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package stream
+
+import (
+	"encoding/csv"
+)
+
+// CSV writes csv records to a Writer, each record being a row.
+type CSV struct {
+	w   *Writer
+	csv *csv.Writer
+}
+
+// NewCSV returns a csv writer writing to the writer.
+func NewCSV(w *Writer) *CSV {
+	return &CSV{w: w, csv: csv.NewWriter(w)}
+}
+
+// Write writes the record, flushing every few records.
+func (c *CSV) Write(record []string) error {
+	if err := c.csv.Write(record); err != nil {
+		return err
+	}
+
+	// the records buffered by the csv writer have to reach the response before it is flushed
+	if c.w.flushDue() {
+		c.csv.Flush()
+
+		if err := c.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	return c.w.Row()
+}
+
+// Close flushes the records written.
+func (c *CSV) Close() error {
+	c.csv.Flush()
+	c.w.Flush()
+
+	return c.csv.Error()
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stream writes large responses as they are produced rather than marshaling them whole.
+// Rows are encoded one at a time and flushed to the client every few rows, so writing a response
+// takes no more memory for more rows. Its memory stays flat as long as the rows are produced a
+// batch at a time too, as the exports do. Once the status is sent, an error can no longer be
+// reported with it: the body is cut short and the error is reported in the X-Stream-Error trailer,
+// which clients check once they read the body to the end.
+package stream
@@ -0,0 +1,49 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONArray writes the items as a json array, encoding them one at a time so only one is held
+// encoded at once. A nil slice is written as null, the same as by encoding/json.
+func JSONArray[T any](w *Writer, items []T) error {
+	if items == nil {
+		_, err := w.Write([]byte("null"))
+
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+
+	if _, err := w.Write([]byte{'['}); err != nil {
+		return err
+	}
+
+	for i := range items {
+		buf.Reset()
+
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := enc.Encode(items[i]); err != nil {
+			return err
+		}
+
+		// the encoder ends each value with a newline, which has no place inside the array
+		if _, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})); err != nil {
+			return err
+		}
+
+		if err := w.Row(); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{']'})
+
+	return err
+}
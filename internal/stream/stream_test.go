@@ -0,0 +1,188 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/stream"
+)
+
+// sink discards the response body, sampling the heap on some of the flushes.
+type sink struct {
+	header  http.Header
+	written int
+	flushes int
+	maxHeap uint64
+}
+
+func (s *sink) Header() http.Header {
+	return s.header
+}
+
+func (s *sink) Write(p []byte) (int, error) {
+	s.written += len(p)
+
+	return len(p), nil
+}
+
+func (s *sink) WriteHeader(int) {}
+
+func (s *sink) Flush() {
+	s.flushes++
+
+	if s.flushes%50 == 0 {
+		if heap := heapInUse(); heap > s.maxHeap {
+			s.maxHeap = heap
+		}
+	}
+}
+
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+func TestCSVMemoryStaysFlat(t *testing.T) {
+	const rows = 50000
+
+	res := &sink{header: http.Header{}}
+
+	before := heapInUse()
+
+	w := stream.NewWriter(context.Background(), res)
+	w.Start(http.StatusOK)
+
+	c := stream.NewCSV(w)
+
+	for i := 0; i < rows; i++ {
+		id := strconv.Itoa(i)
+
+		require.NoError(t, c.Write([]string{"tnntten-" + id, "tenant " + id, "a description long enough for the rows to add up to megabytes"}))
+	}
+
+	require.NoError(t, c.Close())
+
+	assert.Equal(t, rows, w.Rows())
+	assert.Equal(t, rows/stream.DefaultFlushEvery+1, res.flushes)
+	assert.Greater(t, res.written, 4<<20, "the rows add up to megabytes")
+
+	// the heap never held more than a few flushes worth of rows
+	require.NotZero(t, res.maxHeap)
+	assert.Less(t, int64(res.maxHeap)-int64(before), int64(1<<20))
+}
+
+func TestCSVAllocationsPerRow(t *testing.T) {
+	record := []string{"tnntten-1", "tenant", "description"}
+
+	perRun := func(rows int) float64 {
+		return testing.AllocsPerRun(5, func() {
+			w := stream.NewWriter(context.Background(), &sink{header: http.Header{}})
+			c := stream.NewCSV(w)
+
+			for i := 0; i < rows; i++ {
+				_ = c.Write(record)
+			}
+
+			_ = c.Close()
+		})
+	}
+
+	// writing a record allocates nothing, whatever the number of rows written before
+	assert.Equal(t, perRun(1000), perRun(20000))
+}
+
+func TestJSONArray(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+		HTML string `json:"html,omitempty"`
+	}
+
+	for _, items := range [][]item{nil, {}, {{Name: "one"}}, {{Name: "one"}, {Name: "two", HTML: "<b>"}}} {
+		res := httptest.NewRecorder()
+
+		w := stream.NewWriter(context.Background(), res)
+		w.Start(http.StatusOK)
+
+		require.NoError(t, stream.JSONArray(w, items))
+
+		expected, err := json.Marshal(items)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(expected), res.Body.String())
+	}
+}
+
+func TestErrorTrailer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		w := stream.NewWriter(req.Context(), res, stream.WithFlushEvery(2))
+		w.Start(http.StatusOK)
+
+		c := stream.NewCSV(w)
+
+		for i := 0; i < 5; i++ {
+			_ = c.Write([]string{fmt.Sprint(i)})
+		}
+
+		if req.URL.Query().Has("fail") {
+			w.Fail(errors.New("database went away"))
+
+			return
+		}
+
+		_ = c.Close()
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, tt := range []struct {
+		query    string
+		expected string
+	}{
+		{"", ""},
+		{"?fail", "database went away"},
+	} {
+		resp, err := http.Get(srv.URL + tt.query) //nolint:noctx // test request
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, tt.expected, resp.Trailer.Get(stream.ErrorTrailer), tt.query)
+
+		if tt.expected == "" {
+			assert.Equal(t, "0\n1\n2\n3\n4\n", string(body))
+		} else {
+			// only the flushed rows made it
+			assert.Equal(t, "0\n1\n2\n3\n", string(body))
+		}
+	}
+}
+
+func TestCancelStopsRows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := stream.NewWriter(ctx, httptest.NewRecorder())
+	c := stream.NewCSV(w)
+
+	require.NoError(t, c.Write([]string{"before"}))
+
+	cancel()
+
+	assert.ErrorIs(t, c.Write([]string{"after"}), context.Canceled)
+}
@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// ErrorTrailer is the trailer reporting the error which cut a streamed response short. It is
+	// only set when the response is incomplete.
+	ErrorTrailer = "X-Stream-Error"
+
+	// DefaultFlushEvery is the default number of rows written between flushes.
+	DefaultFlushEvery = 100
+)
+
+// Option configures a Writer.
+type Option func(*Writer)
+
+// WithFlushEvery sets the number of rows written between flushes.
+func WithFlushEvery(n int) Option {
+	return func(w *Writer) {
+		if n > 0 {
+			w.every = n
+		}
+	}
+}
+
+// Writer writes a response body a row at a time, flushing it to the client every few rows. Rows
+// stop being accepted once the context of the request is done, so the query producing them can be
+// stopped right away.
+type Writer struct {
+	ctx   context.Context
+	res   http.ResponseWriter
+	every int
+	rows  int
+}
+
+// NewWriter returns a writer of the response of the request with the context.
+func NewWriter(ctx context.Context, res http.ResponseWriter, opts ...Option) *Writer {
+	w := &Writer{
+		ctx:   ctx,
+		res:   res,
+		every: DefaultFlushEvery,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Start announces the error trailer and sends the status, the headers must be set before.
+func (w *Writer) Start(status int) {
+	w.res.Header().Add("Trailer", ErrorTrailer)
+	w.res.WriteHeader(status)
+}
+
+// Write writes to the body without flushing.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.res.Write(p)
+}
+
+// Row records that a row was written, flushing every few rows. It returns the error of the
+// context once the request is done.
+func (w *Writer) Row() error {
+	w.rows++
+
+	if w.rows%w.every == 0 {
+		w.Flush()
+	}
+
+	return w.ctx.Err()
+}
+
+// flushDue reports whether the next row is flushed.
+func (w *Writer) flushDue() bool {
+	return (w.rows+1)%w.every == 0
+}
+
+// Rows returns the number of rows written.
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// Flush sends what was written to the client.
+func (w *Writer) Flush() {
+	if f, ok := w.res.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Fail reports the error in the trailer, leaving the body cut short.
+func (w *Writer) Fail(err error) {
+	w.res.Header().Set(ErrorTrailer, err.Error())
+}
@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package subtree resolves the roots of the subtrees tenants are in, so their change events can be
+// published to the topic of their subtree, and the ancestors of tenants, such as to find the
+// creation freezes of the subtrees they are in. Watchers of a subtree tell the changes within it
+// with a Scope.
+package subtree
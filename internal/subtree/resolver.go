@@ -0,0 +1,210 @@
+package subtree
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/clock"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+)
+
+const (
+	// DefaultTTL is the time the parents of tenants are cached for.
+	DefaultTTL = time.Minute
+
+	// maxWalkDepth bounds the walk up the hierarchy, keeping a cycle from looping forever.
+	maxWalkDepth = 1000
+)
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithTTL sets the time the parents of tenants are cached for, it bounds the time the changes of
+// tenants moved by another replica are published to the subtree they were moved out of.
+func WithTTL(d time.Duration) Option {
+	return func(r *Resolver) {
+		r.ttl = d
+	}
+}
+
+// WithClock sets the clock the cached parents expire with.
+func WithClock(c clock.Clock) Option {
+	return func(r *Resolver) {
+		r.clock = c
+	}
+}
+
+type cachedParent struct {
+	parent   gidx.PrefixedID
+	cachedAt time.Time
+}
+
+// Resolver resolves the roots of the subtrees tenants are in, caching the parents of the tenants it
+// walks through. Tenants moved or deleted through this process are forgotten as they change.
+type Resolver struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	parents map[gidx.PrefixedID]cachedParent
+}
+
+// NewResolver returns a resolver with an empty cache.
+func NewResolver(opts ...Option) *Resolver {
+	r := &Resolver{
+		clock:   clock.Real{},
+		ttl:     DefaultTTL,
+		parents: make(map[gidx.PrefixedID]cachedParent),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Root returns the root of the subtree the tenant is in, loading the parents which aren't cached
+// with the client. A missing ancestor ends the walk as if the tenant below it were a root, a null id
+// is returned when the tenant itself is missing.
+func (r *Resolver) Root(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (gidx.PrefixedID, error) {
+	ids, err := r.Ancestors(ctx, client, id)
+	if err != nil || len(ids) == 0 {
+		return gidx.NullPrefixedID, err
+	}
+
+	return ids[len(ids)-1], nil
+}
+
+// Ancestors returns the tenant followed by its ancestors up to the root of its subtree, loading the
+// parents which aren't cached with the client. The walk ends at a missing ancestor as it does for
+// Root, nothing is returned when the tenant itself is missing.
+func (r *Resolver) Ancestors(ctx context.Context, client *generated.Client, id gidx.PrefixedID) ([]gidx.PrefixedID, error) {
+	var ids []gidx.PrefixedID
+
+	for depth := 0; depth < maxWalkDepth && id != gidx.NullPrefixedID; depth++ {
+		parent, ok, err := r.parent(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		ids = append(ids, id)
+		id = parent
+	}
+
+	return ids, nil
+}
+
+// Forget drops the cached parents of the tenants.
+func (r *Resolver) Forget(ids ...gidx.PrefixedID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range ids {
+		delete(r.parents, id)
+	}
+}
+
+func (r *Resolver) parent(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (gidx.PrefixedID, bool, error) {
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	cached, ok := r.parents[id]
+	r.mu.Unlock()
+
+	if ok && now.Sub(cached.cachedAt) < r.ttl {
+		return cached.parent, true, nil
+	}
+
+	t, err := client.Tenant.Query().
+		Where(enttenant.ID(id)).
+		Select(enttenant.FieldParentTenantID).
+		Only(ctx)
+
+	switch {
+	case generated.IsNotFound(err):
+		r.Forget(id)
+
+		return gidx.NullPrefixedID, false, nil
+	case err != nil:
+		return gidx.NullPrefixedID, false, err
+	}
+
+	r.mu.Lock()
+	r.parents[id] = cachedParent{parent: t.ParentTenantID, cachedAt: now}
+	r.mu.Unlock()
+
+	return t.ParentTenantID, true, nil
+}
+
+// Hook returns an ent hook letting the change events of the mutation be published to the topic of
+// their subtree, see changefeed.WithSubtreeTopics, and carry the ancestors of their tenant, see
+// changefeed.WithAncestry. It must be registered before the event hooks. Roots and ancestors are
+// resolved from the parent the event carries with the client of the mutation, so deleted tenants
+// are resolved as well, and tenants without one are roots themselves. The tenants moved or
+// deleted are forgotten before and after the mutation, so a rolled back move isn't cached.
+func (r *Resolver) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				client := m.Client()
+
+				var changed []gidx.PrefixedID
+
+				if _, moved := m.ParentTenantID(); moved || m.ParentTenantIDCleared() || m.Op().Is(ent.OpDelete|ent.OpDeleteOne) {
+					if !m.Op().Is(ent.OpCreate) {
+						ids, err := m.IDs(ctx)
+						if err != nil {
+							return nil, err
+						}
+
+						changed = ids
+					}
+				}
+
+				r.Forget(changed...)
+				defer r.Forget(changed...)
+
+				resolver := func(ctx context.Context, message events.ChangeMessage) (gidx.PrefixedID, error) {
+					for _, id := range message.AdditionalSubjectIDs {
+						if id.Prefix() == schema.TenantPrefix {
+							return r.Root(ctx, client, id)
+						}
+					}
+
+					return message.SubjectID, nil
+				}
+
+				// a missing parent leaves the change with the parent the event hooks added
+				ancestry := func(ctx context.Context, message events.ChangeMessage) ([]gidx.PrefixedID, error) {
+					for _, id := range message.AdditionalSubjectIDs {
+						if id.Prefix() == schema.TenantPrefix {
+							return r.Ancestors(ctx, client, id)
+						}
+					}
+
+					return []gidx.PrefixedID{}, nil
+				}
+
+				ctx = changefeed.WithRootResolver(ctx, resolver)
+				ctx = changefeed.WithAncestry(ctx, ancestry)
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
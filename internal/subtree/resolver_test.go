@@ -0,0 +1,209 @@
+package subtree_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+	"go.infratographer.com/x/testing/eventtools"
+
+	"go.infratographer.com/permissions-api/pkg/permissions"
+
+	"go.infratographer.com/tenant-api/internal/changefeed"
+	"go.infratographer.com/tenant-api/internal/clock"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/eventhooks"
+	"go.infratographer.com/tenant-api/internal/subtree"
+)
+
+// newConnection starts an embedded nats server and returns a connection to it.
+func newConnection(t *testing.T) events.Connection {
+	t.Helper()
+
+	nats, err := eventtools.NewNatsServer()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		nats.Close()
+		nats.Server.Shutdown()
+	})
+
+	cfg := nats.Config
+	cfg.NATS.Source = "tenant-api-test"
+	cfg.NATS.SubscriberFetchTimeout = 100 * time.Millisecond
+	cfg.NATS.SubscriberFetchBackoff = 10 * time.Millisecond
+
+	conn, err := events.NewConnection(cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Shutdown(context.Background()) }) //nolint:errcheck
+
+	return conn
+}
+
+// receive returns the subjects of the changes delivered on the channel until none is delivered for a while.
+func receive(t *testing.T, ch <-chan events.Message[events.ChangeMessage]) []gidx.PrefixedID {
+	t.Helper()
+
+	var subjects []gidx.PrefixedID
+
+	for {
+		select {
+		case msg := <-ch:
+			require.NoError(t, msg.Ack())
+
+			subjects = append(subjects, msg.Message().SubjectID)
+		case <-time.After(time.Second):
+			return subjects
+		}
+	}
+}
+
+func TestSubtreeTopics(t *testing.T) {
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	conn := newConnection(t)
+	feed := changefeed.New(conn, changefeed.WithSubtreeTopics())
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(generated.EventsPublisher(feed)),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(subtree.NewResolver().Hook())
+	eventhooks.EventHooks(client)
+
+	one := client.Tenant.Create().SetName("one").SaveX(ctx)
+	two := client.Tenant.Create().SetName("two").SaveX(ctx)
+
+	subCtx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+
+	changes, err := conn.SubscribeChanges(subCtx, "*."+changefeed.SubtreeTopic(one.ID))
+	require.NoError(t, err)
+
+	child := client.Tenant.Create().SetName("child").SetParent(one).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	other := client.Tenant.Create().SetName("other").SetParent(two).SaveX(ctx)
+	client.Tenant.UpdateOneID(two.ID).SetDescription("updated").ExecX(ctx)
+
+	// deleted tenants are resolved from their parent, moved ones from the new one
+	client.Tenant.DeleteOneID(grandchild.ID).ExecX(ctx)
+	client.Tenant.UpdateOneID(other.ID).SetParentTenantID(child.ID).ExecX(ctx)
+	client.Tenant.UpdateOneID(one.ID).SetDescription("updated").ExecX(ctx)
+
+	// the creation of the root was published before subscribing, the stream delivers it as well
+	assert.Equal(t, []gidx.PrefixedID{one.ID, child.ID, grandchild.ID, grandchild.ID, other.ID, one.ID}, receive(t, changes))
+
+	// every change is still published to the tenant topic
+	all, err := conn.SubscribeChanges(subCtx, "*."+changefeed.TenantTopic)
+	require.NoError(t, err)
+
+	assert.Len(t, receive(t, all), 9)
+}
+
+func TestHookAncestors(t *testing.T) {
+	perms, err := permissions.New(permissions.Config{}, permissions.WithDefaultChecker(permissions.DefaultAllowChecker))
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), permissions.AuthRelationshipRequestHandlerCtxKey, perms)
+
+	conn := newConnection(t)
+	feed := changefeed.New(conn, changefeed.WithAncestorLimit(changefeed.AncestorsFull))
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(generated.EventsPublisher(feed)),
+	)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(subtree.NewResolver().Hook())
+	eventhooks.EventHooks(client)
+
+	changes, unsubscribe := feed.Subscribe()
+	t.Cleanup(unsubscribe)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	// deleted tenants carry the ancestors of the parent they had
+	client.Tenant.DeleteOneID(grandchild.ID).ExecX(ctx)
+
+	for _, expected := range []struct {
+		subjects []gidx.PrefixedID
+		depth    int
+	}{
+		{[]gidx.PrefixedID{}, 0},
+		{[]gidx.PrefixedID{root.ID}, 1},
+		{[]gidx.PrefixedID{child.ID, root.ID}, 2},
+		{[]gidx.PrefixedID{child.ID, root.ID}, 2},
+	} {
+		message := (<-changes).Message
+		assert.Equal(t, expected.subjects, message.AdditionalSubjectIDs, message.SubjectID)
+		assert.Equal(t, expected.depth, message.AdditionalData[changefeed.DepthKey], message.SubjectID)
+	}
+}
+
+func TestResolverRoot(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	one := client.Tenant.Create().SetName("one").SaveX(ctx)
+	two := client.Tenant.Create().SetName("two").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(one).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	fake := clock.NewFake(time.Now())
+	r := subtree.NewResolver(subtree.WithTTL(time.Minute), subtree.WithClock(fake))
+
+	for _, tt := range []struct {
+		id   gidx.PrefixedID
+		root gidx.PrefixedID
+	}{
+		{one.ID, one.ID},
+		{child.ID, one.ID},
+		{grandchild.ID, one.ID},
+		{two.ID, two.ID},
+		{"tnntten-missing", gidx.NullPrefixedID},
+	} {
+		got, err := r.Root(ctx, client, tt.id)
+		require.NoError(t, err)
+		assert.Equal(t, tt.root, got, tt.id)
+	}
+
+	ancestors, err := r.Ancestors(ctx, client, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []gidx.PrefixedID{grandchild.ID, child.ID, one.ID}, ancestors)
+
+	// moves made elsewhere are seen once the cached parents expire
+	client.Tenant.UpdateOneID(child.ID).SetParentTenantID(two.ID).ExecX(ctx)
+
+	got, err := r.Root(ctx, client, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, one.ID, got)
+
+	fake.Advance(time.Minute)
+
+	got, err = r.Root(ctx, client, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, two.ID, got)
+
+	// forgotten tenants are loaded again
+	client.Tenant.UpdateOneID(child.ID).SetParentTenantID(one.ID).ExecX(ctx)
+	r.Forget(child.ID)
+
+	got, err = r.Root(ctx, client, grandchild.ID)
+	require.NoError(t, err)
+	assert.Equal(t, one.ID, got)
+}
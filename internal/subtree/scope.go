@@ -0,0 +1,85 @@
+package subtree
+
+import (
+	"context"
+
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+)
+
+// Scope determines whether changes are within the subtree of a tenant, for the watchers of a
+// subtree. The ancestry of each tenant looked up is remembered for the life of the scope, so a
+// scope is used by a single watcher and isn't safe for concurrent use.
+type Scope struct {
+	client *ent.Client
+	root   gidx.PrefixedID
+	known  map[gidx.PrefixedID]bool
+}
+
+// NewScope returns the scope of the subtree under root, whose tenants are looked up with the client.
+func NewScope(client *ent.Client, root gidx.PrefixedID) *Scope {
+	return &Scope{
+		client: client,
+		root:   root,
+		known:  map[gidx.PrefixedID]bool{root: true},
+	}
+}
+
+// Contains reports whether the changed tenant is the root or one of its descendants.
+// The additional subjects of a change include the parent, which still exists when a tenant is deleted.
+func (s *Scope) Contains(ctx context.Context, msg events.ChangeMessage) (bool, error) {
+	if in, ok := s.known[msg.SubjectID]; ok {
+		return in, nil
+	}
+
+	for _, id := range msg.AdditionalSubjectIDs {
+		in, err := s.includes(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if in {
+			s.known[msg.SubjectID] = true
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// includes walks up from the tenant until it reaches the root or a tenant with known ancestry.
+func (s *Scope) includes(ctx context.Context, id gidx.PrefixedID) (bool, error) {
+	var visited []gidx.PrefixedID
+
+	in := false
+
+	for id != gidx.NullPrefixedID {
+		if known, ok := s.known[id]; ok {
+			in = known
+
+			break
+		}
+
+		visited = append(visited, id)
+
+		t, err := s.client.Tenant.Get(ctx, id)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				break
+			}
+
+			return false, err
+		}
+
+		id = t.ParentTenantID
+	}
+
+	for _, v := range visited {
+		s.known[v] = in
+	}
+
+	return in, nil
+}
@@ -15,7 +15,9 @@ type TestClient interface {
 	GetTenant(ctx context.Context, id gidx.PrefixedID, httpRequestOptions ...client.HTTPRequestOption) (*GetTenant, error)
 	GetTenantChildByID(ctx context.Context, id gidx.PrefixedID, childID gidx.PrefixedID, httpRequestOptions ...client.HTTPRequestOption) (*GetTenantChildByID, error)
 	GetTenantChildren(ctx context.Context, id gidx.PrefixedID, orderBy *TenantOrder, httpRequestOptions ...client.HTTPRequestOption) (*GetTenantChildren, error)
+	GetTenantChildrenArchived(ctx context.Context, id gidx.PrefixedID, includeArchived bool, httpRequestOptions ...client.HTTPRequestOption) (*GetTenantChildrenArchived, error)
 	TenantCreate(ctx context.Context, input CreateTenantInput, httpRequestOptions ...client.HTTPRequestOption) (*TenantCreate, error)
+	TenantCreateOnConflict(ctx context.Context, input CreateTenantInput, onConflict TenantNameConflict, httpRequestOptions ...client.HTTPRequestOption) (*TenantCreateOnConflict, error)
 	TenantDelete(ctx context.Context, id gidx.PrefixedID, httpRequestOptions ...client.HTTPRequestOption) (*TenantDelete, error)
 	TenantUpdate(ctx context.Context, id gidx.PrefixedID, input UpdateTenantInput, httpRequestOptions ...client.HTTPRequestOption) (*TenantUpdate, error)
 }
@@ -77,6 +79,18 @@ type GetTenantChildren struct {
 		} "json:\"children\" graphql:\"children\""
 	} "json:\"tenant\" graphql:\"tenant\""
 }
+type GetTenantChildrenArchived struct {
+	Tenant struct {
+		Children struct {
+			Edges []*struct {
+				Node *struct {
+					ID   gidx.PrefixedID "json:\"id\" graphql:\"id\""
+					Name string          "json:\"name\" graphql:\"name\""
+				} "json:\"node\" graphql:\"node\""
+			} "json:\"edges\" graphql:\"edges\""
+		} "json:\"children\" graphql:\"children\""
+	} "json:\"tenant\" graphql:\"tenant\""
+}
 type TenantCreate struct {
 	TenantCreate struct {
 		Tenant struct {
@@ -89,6 +103,15 @@ type TenantCreate struct {
 		} "json:\"tenant\" graphql:\"tenant\""
 	} "json:\"tenantCreate\" graphql:\"tenantCreate\""
 }
+type TenantCreateOnConflict struct {
+	TenantCreate struct {
+		Tenant struct {
+			ID   gidx.PrefixedID "json:\"id\" graphql:\"id\""
+			Name string          "json:\"name\" graphql:\"name\""
+		} "json:\"tenant\" graphql:\"tenant\""
+		Renamed bool "json:\"renamed\" graphql:\"renamed\""
+	} "json:\"tenantCreate\" graphql:\"tenantCreate\""
+}
 type TenantDelete struct {
 	TenantDelete struct {
 		DeletedID gidx.PrefixedID "json:\"deletedID\" graphql:\"deletedID\""
@@ -193,6 +216,34 @@ func (c *Client) GetTenantChildren(ctx context.Context, id gidx.PrefixedID, orde
 	return &res, nil
 }
 
+const GetTenantChildrenArchivedDocument = `query GetTenantChildrenArchived ($id: ID!, $includeArchived: Boolean!) {
+	tenant(id: $id) {
+		children(includeArchived: $includeArchived) {
+			edges {
+				node {
+					id
+					name
+				}
+			}
+		}
+	}
+}
+`
+
+func (c *Client) GetTenantChildrenArchived(ctx context.Context, id gidx.PrefixedID, includeArchived bool, httpRequestOptions ...client.HTTPRequestOption) (*GetTenantChildrenArchived, error) {
+	vars := map[string]interface{}{
+		"id":              id,
+		"includeArchived": includeArchived,
+	}
+
+	var res GetTenantChildrenArchived
+	if err := c.Client.Post(ctx, "GetTenantChildrenArchived", GetTenantChildrenArchivedDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const TenantCreateDocument = `mutation TenantCreate ($input: CreateTenantInput!) {
 	tenantCreate(input: $input) {
 		tenant {
@@ -220,6 +271,31 @@ func (c *Client) TenantCreate(ctx context.Context, input CreateTenantInput, http
 	return &res, nil
 }
 
+const TenantCreateOnConflictDocument = `mutation TenantCreateOnConflict ($input: CreateTenantInput!, $onConflict: TenantNameConflict!) {
+	tenantCreate(input: $input, onConflict: $onConflict) {
+		tenant {
+			id
+			name
+		}
+		renamed
+	}
+}
+`
+
+func (c *Client) TenantCreateOnConflict(ctx context.Context, input CreateTenantInput, onConflict TenantNameConflict, httpRequestOptions ...client.HTTPRequestOption) (*TenantCreateOnConflict, error) {
+	vars := map[string]interface{}{
+		"input":      input,
+		"onConflict": onConflict,
+	}
+
+	var res TenantCreateOnConflict
+	if err := c.Client.Post(ctx, "TenantCreateOnConflict", TenantCreateOnConflictDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const TenantDeleteDocument = `mutation TenantDelete ($id: ID!) {
 	tenantDelete(id: $id) {
 		deletedID
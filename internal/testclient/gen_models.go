@@ -37,9 +37,15 @@ type Entity interface {
 type CreateTenantInput struct {
 	// The name of a tenant.
 	Name string `json:"name"`
+	// The display name of a tenant, defaults to the name and may be changed freely.
+	DisplayName *string `json:"displayName,omitempty"`
 	// An optional description of the tenant.
-	Description *string          `json:"description,omitempty"`
-	ParentID    *gidx.PrefixedID `json:"parentID,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// An optional email address to contact the owners of the tenant at.
+	ContactEmail *string `json:"contactEmail,omitempty"`
+	// An optional reference to the tenant in the billing system.
+	BillingReference *string          `json:"billingReference,omitempty"`
+	ParentID         *gidx.PrefixedID `json:"parentID,omitempty"`
 }
 
 // Information about pagination in a connection.
@@ -62,10 +68,22 @@ type Tenant struct {
 	UpdatedAt time.Time       `json:"updatedAt"`
 	// The name of a tenant.
 	Name string `json:"name"`
+	// The display name of a tenant, defaults to the name and may be changed freely.
+	DisplayName *string `json:"displayName,omitempty"`
 	// An optional description of the tenant.
-	Description *string          `json:"description,omitempty"`
-	Parent      *Tenant          `json:"parent,omitempty"`
-	Children    TenantConnection `json:"children"`
+	Description *string `json:"description,omitempty"`
+	// An optional email address to contact the owners of the tenant at.
+	ContactEmail *string `json:"contactEmail,omitempty"`
+	// An optional reference to the tenant in the billing system.
+	BillingReference *string `json:"billingReference,omitempty"`
+	// Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection.
+	DeletionProtected *bool `json:"deletionProtected,omitempty"`
+	// The sequence of the last change of the tenant, increasing with every change.
+	ChangeSeq *int64           `json:"changeSeq,omitempty"`
+	Parent    *Tenant          `json:"parent,omitempty"`
+	Children  TenantConnection `json:"children"`
+	// The time the tenant will be deleted at, null unless a deletion is pending.
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt,omitempty"`
 }
 
 func (Tenant) IsMetadataNode()             {}
@@ -93,6 +111,8 @@ type TenantConnection struct {
 type TenantCreatePayload struct {
 	// The created tenant.
 	Tenant Tenant `json:"tenant"`
+	// Whether the tenant was created with a suffixed name as a sibling already had the requested one.
+	Renamed bool `json:"renamed"`
 }
 
 // Return response from tenantDelete.
@@ -156,6 +176,17 @@ type TenantWhereInput struct {
 	UpdatedAtGte   *time.Time   `json:"updatedAtGTE,omitempty"`
 	UpdatedAtLt    *time.Time   `json:"updatedAtLT,omitempty"`
 	UpdatedAtLte   *time.Time   `json:"updatedAtLTE,omitempty"`
+	// change_seq field predicates
+	ChangeSeq       *int64  `json:"changeSeq,omitempty"`
+	ChangeSeqNeq    *int64  `json:"changeSeqNEQ,omitempty"`
+	ChangeSeqIn     []int64 `json:"changeSeqIn,omitempty"`
+	ChangeSeqNotIn  []int64 `json:"changeSeqNotIn,omitempty"`
+	ChangeSeqGt     *int64  `json:"changeSeqGT,omitempty"`
+	ChangeSeqGte    *int64  `json:"changeSeqGTE,omitempty"`
+	ChangeSeqLt     *int64  `json:"changeSeqLT,omitempty"`
+	ChangeSeqLte    *int64  `json:"changeSeqLTE,omitempty"`
+	ChangeSeqIsNil  *bool   `json:"changeSeqIsNil,omitempty"`
+	ChangeSeqNotNil *bool   `json:"changeSeqNotNil,omitempty"`
 	// parent edge predicates
 	HasParent     *bool               `json:"hasParent,omitempty"`
 	HasParentWith []*TenantWhereInput `json:"hasParentWith,omitempty"`
@@ -168,9 +199,23 @@ type TenantWhereInput struct {
 type UpdateTenantInput struct {
 	// The name of a tenant.
 	Name *string `json:"name,omitempty"`
+	// The display name of a tenant, defaults to the name and may be changed freely.
+	DisplayName      *string `json:"displayName,omitempty"`
+	ClearDisplayName *bool   `json:"clearDisplayName,omitempty"`
 	// An optional description of the tenant.
 	Description      *string `json:"description,omitempty"`
 	ClearDescription *bool   `json:"clearDescription,omitempty"`
+	// An optional email address to contact the owners of the tenant at.
+	ContactEmail      *string `json:"contactEmail,omitempty"`
+	ClearContactEmail *bool   `json:"clearContactEmail,omitempty"`
+	// An optional reference to the tenant in the billing system.
+	BillingReference      *string `json:"billingReference,omitempty"`
+	ClearBillingReference *bool   `json:"clearBillingReference,omitempty"`
+	// Whether the tenant is protected against deletion, deleting it requires confirming its name and only admins lift the protection.
+	DeletionProtected      *bool            `json:"deletionProtected,omitempty"`
+	ClearDeletionProtected *bool            `json:"clearDeletionProtected,omitempty"`
+	ParentID               *gidx.PrefixedID `json:"parentID,omitempty"`
+	ClearParent            *bool            `json:"clearParent,omitempty"`
 }
 
 type Service struct {
@@ -221,24 +266,76 @@ func (e OrderDirection) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// Handling of tenants created with the name of a sibling.
+type TenantNameConflict string
+
+const (
+	// Create the tenant with the name regardless, the default.
+	TenantNameConflictAllow TenantNameConflict = "ALLOW"
+	// Append the first free numeric suffix to the name, such as staging-2.
+	TenantNameConflictSuffix TenantNameConflict = "SUFFIX"
+	// Fail with a name conflict, telling whether the sibling is live or deleted. Whether deleted
+	// siblings keep their names depends on the name reuse policy of the api.
+	TenantNameConflictError TenantNameConflict = "ERROR"
+)
+
+var AllTenantNameConflict = []TenantNameConflict{
+	TenantNameConflictAllow,
+	TenantNameConflictSuffix,
+	TenantNameConflictError,
+}
+
+func (e TenantNameConflict) IsValid() bool {
+	switch e {
+	case TenantNameConflictAllow, TenantNameConflictSuffix, TenantNameConflictError:
+		return true
+	}
+	return false
+}
+
+func (e TenantNameConflict) String() string {
+	return string(e)
+}
+
+func (e *TenantNameConflict) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TenantNameConflict(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TenantNameConflict", str)
+	}
+	return nil
+}
+
+func (e TenantNameConflict) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // Properties by which Tenant connections can be ordered.
 type TenantOrderField string
 
 const (
-	TenantOrderFieldCreatedAt TenantOrderField = "CREATED_AT"
-	TenantOrderFieldUpdatedAt TenantOrderField = "UPDATED_AT"
-	TenantOrderFieldName      TenantOrderField = "NAME"
+	TenantOrderFieldCreatedAt   TenantOrderField = "CREATED_AT"
+	TenantOrderFieldUpdatedAt   TenantOrderField = "UPDATED_AT"
+	TenantOrderFieldName        TenantOrderField = "NAME"
+	TenantOrderFieldDisplayName TenantOrderField = "DISPLAY_NAME"
+	TenantOrderFieldChangeSeq   TenantOrderField = "CHANGE_SEQ"
 )
 
 var AllTenantOrderField = []TenantOrderField{
 	TenantOrderFieldCreatedAt,
 	TenantOrderFieldUpdatedAt,
 	TenantOrderFieldName,
+	TenantOrderFieldDisplayName,
+	TenantOrderFieldChangeSeq,
 }
 
 func (e TenantOrderField) IsValid() bool {
 	switch e {
-	case TenantOrderFieldCreatedAt, TenantOrderFieldUpdatedAt, TenantOrderFieldName:
+	case TenantOrderFieldCreatedAt, TenantOrderFieldUpdatedAt, TenantOrderFieldName, TenantOrderFieldDisplayName, TenantOrderFieldChangeSeq:
 		return true
 	}
 	return false
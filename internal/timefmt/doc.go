@@ -0,0 +1,19 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timefmt formats the timestamps of responses and event payloads. Every timestamp is
+// written in UTC in the format the deployment selects: RFC 3339 with millisecond precision by
+// default, or RFC 3339 with nanosecond precision, as encoding/json writes times, for clients
+// relying on the former output.
+package timefmt
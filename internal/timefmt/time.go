@@ -0,0 +1,92 @@
+package timefmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Format is a format timestamps are written in.
+type Format string
+
+const (
+	// RFC3339 writes timestamps in RFC 3339 with millisecond precision, such as
+	// 2023-06-01T12:00:00.000Z.
+	RFC3339 Format = "rfc3339"
+	// RFC3339Nano writes timestamps in RFC 3339 with up to nanosecond precision and trailing zeros
+	// removed, such as 2023-06-01T12:00:00.123456789Z, as encoding/json writes times.
+	RFC3339Nano Format = "rfc3339nano"
+
+	layoutMilli = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// ErrUnknownFormat is returned when parsing a format which doesn't exist.
+var ErrUnknownFormat = errors.New("unknown timestamp format")
+
+var current atomic.Value
+
+func init() {
+	current.Store(RFC3339)
+}
+
+// ParseFormat returns the format named by s, case insensitively.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case RFC3339, RFC3339Nano:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w %q, expected %s or %s", ErrUnknownFormat, s, RFC3339, RFC3339Nano)
+	}
+}
+
+// SetFormat selects the format timestamps are written in, it is set once at startup.
+func SetFormat(f Format) {
+	current.Store(f)
+}
+
+// CurrentFormat returns the format timestamps are written in.
+func CurrentFormat() Format {
+	return current.Load().(Format)
+}
+
+// Layout returns the time layout of the format.
+func (f Format) Layout() string {
+	if f == RFC3339Nano {
+		return time.RFC3339Nano
+	}
+
+	return layoutMilli
+}
+
+// Time is a timestamp written in UTC in the current format. It is read from any RFC 3339
+// timestamp, as time.Time is.
+type Time struct {
+	time.Time
+}
+
+// New returns the timestamp of t.
+func New(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// Ptr returns the timestamp of t, nil when t is nil.
+func Ptr(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+
+	return &Time{Time: *t}
+}
+
+// String returns the timestamp in the current format.
+func (t Time) String() string {
+	return t.UTC().Format(CurrentFormat().Layout())
+}
+
+// MarshalJSON writes the timestamp in UTC in the current format.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
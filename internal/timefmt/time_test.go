@@ -0,0 +1,68 @@
+package timefmt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/timefmt"
+)
+
+func TestParseFormat(t *testing.T) {
+	for raw, expected := range map[string]timefmt.Format{
+		"rfc3339":     timefmt.RFC3339,
+		"RFC3339":     timefmt.RFC3339,
+		"rfc3339nano": timefmt.RFC3339Nano,
+	} {
+		f, err := timefmt.ParseFormat(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, f)
+	}
+
+	_, err := timefmt.ParseFormat("unix")
+	assert.ErrorIs(t, err, timefmt.ErrUnknownFormat)
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	t.Cleanup(func() { timefmt.SetFormat(timefmt.RFC3339) })
+
+	local := time.FixedZone("", -5*60*60)
+
+	for _, tt := range []struct {
+		format   timefmt.Format
+		time     time.Time
+		expected string
+	}{
+		{timefmt.RFC3339, time.Date(2023, time.June, 1, 7, 0, 0, 123456789, local), `"2023-06-01T12:00:00.123Z"`},
+		{timefmt.RFC3339, time.Date(2023, time.June, 1, 7, 0, 0, 0, local), `"2023-06-01T12:00:00.000Z"`},
+		{timefmt.RFC3339Nano, time.Date(2023, time.June, 1, 7, 0, 0, 123456789, local), `"2023-06-01T12:00:00.123456789Z"`},
+		{timefmt.RFC3339Nano, time.Date(2023, time.June, 1, 7, 0, 0, 0, local), `"2023-06-01T12:00:00Z"`},
+	} {
+		timefmt.SetFormat(tt.format)
+
+		data, err := json.Marshal(timefmt.New(tt.time))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, string(data), tt.format)
+
+		var back timefmt.Time
+
+		require.NoError(t, json.Unmarshal(data, &back))
+		assert.True(t, back.Equal(tt.time.Truncate(time.Millisecond)) || back.Equal(tt.time))
+	}
+}
+
+func TestTimePtr(t *testing.T) {
+	assert.Nil(t, timefmt.Ptr(nil))
+
+	now := time.Now()
+	assert.True(t, timefmt.Ptr(&now).Equal(now))
+
+	data, err := json.Marshal(struct {
+		At *timefmt.Time `json:"at,omitempty"`
+	}{})
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
@@ -0,0 +1,20 @@
+// Copyright 2023 The Infratographer Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tombstone keeps a minimal record of deleted tenants: their id, final name, parent and
+// the times their deletion took effect and they were removed. Tombstones are recorded by Hook in
+// the transaction of the deletion, so the audit history of a purged tenant can still name it, and
+// Restore creates the tenant again from its tombstone. Tombstones are kept for their own
+// retention, Purge removes the older ones.
+package tombstone
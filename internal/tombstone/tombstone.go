@@ -0,0 +1,310 @@
+package tombstone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttombstone "go.infratographer.com/tenant-api/internal/ent/generated/tenanttombstone"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+var (
+	// ErrNotFound is returned when restoring a tenant which has no tombstone, because it was
+	// never deleted or its tombstone was purged.
+	ErrNotFound = apierrors.New(apierrors.ErrTenantNotFound, "no deleted tenant with this id is known")
+
+	// ErrParentDeleted is returned when restoring a tenant whose parent was deleted as well, the
+	// parent must be restored first.
+	ErrParentDeleted = apierrors.New(apierrors.ErrConflict, "the parent of the tenant was deleted, restore it first")
+
+	// ErrLive is returned when restoring a tenant which exists, it was restored already.
+	ErrLive = apierrors.New(apierrors.ErrConflict, "the tenant exists, it was restored already")
+)
+
+// Hook returns an ent hook recording a tombstone of each tenant deleted, in the transaction of
+// the deletion. The tombstone of a tenant deleted again after being restored is replaced.
+func Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(ids) == 0 {
+					return next.Mutate(ctx, m)
+				}
+
+				deleted, err := m.Client().Tenant.Query().
+					Where(enttenant.IDIn(ids...)).
+					Select(enttenant.FieldID, enttenant.FieldName, enttenant.FieldParentTenantID, enttenant.FieldDeletionScheduledAt).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil || len(deleted) == 0 {
+					return v, err
+				}
+
+				if err := record(ctx, m.Client(), deleted, time.Now().UTC()); err != nil {
+					return nil, err
+				}
+
+				return v, nil
+			})
+		},
+		ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+// record replaces the tombstones of the deleted tenants, purged at the time.
+func record(ctx context.Context, client *generated.Client, deleted []*generated.Tenant, purgedAt time.Time) error {
+	ids := make([]gidx.PrefixedID, len(deleted))
+	builders := make([]*generated.TenantTombstoneCreate, len(deleted))
+
+	for i, t := range deleted {
+		// scheduled deletions took effect when they were due, the scheduler may remove them later
+		deletedAt := purgedAt
+		if !t.DeletionScheduledAt.IsZero() && t.DeletionScheduledAt.Before(purgedAt) {
+			deletedAt = t.DeletionScheduledAt.UTC()
+		}
+
+		ids[i] = t.ID
+		builders[i] = client.TenantTombstone.Create().
+			SetID(t.ID).
+			SetName(t.Name).
+			SetDeletedAt(deletedAt).
+			SetPurgedAt(purgedAt)
+
+		if t.ParentTenantID != gidx.NullPrefixedID {
+			builders[i].SetParentTenantID(t.ParentTenantID)
+		}
+	}
+
+	if _, err := client.TenantTombstone.Delete().Where(enttombstone.IDIn(ids...)).Exec(ctx); err != nil {
+		return err
+	}
+
+	return client.TenantTombstone.CreateBulk(builders...).Exec(ctx)
+}
+
+// Find returns the tombstones of the deleted tenants among the ids, by id.
+func Find(ctx context.Context, client *generated.Client, ids ...gidx.PrefixedID) (map[gidx.PrefixedID]*generated.TenantTombstone, error) {
+	tombstones, err := client.TenantTombstone.Query().Where(enttombstone.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[gidx.PrefixedID]*generated.TenantTombstone, len(tombstones))
+
+	for _, t := range tombstones {
+		found[t.ID] = t
+	}
+
+	return found, nil
+}
+
+// Purge deletes the tombstones of the tenants removed before the time, returning how many were
+// deleted. Those tenants can't be restored anymore and their audit history no longer names them.
+func Purge(ctx context.Context, client *generated.Client, before time.Time) (int, error) {
+	return client.TenantTombstone.Delete().Where(enttombstone.PurgedAtLT(before)).Exec(ctx)
+}
+
+// NameConflict tells how a tenant is restored when a live sibling has taken its name.
+type NameConflict string
+
+const (
+	// NameConflictError fails the restore with a NameTakenError suggesting a free name, the
+	// default.
+	NameConflictError NameConflict = "error"
+	// NameConflictSuffix restores the tenant under the name with the lowest numeric suffix no
+	// sibling has.
+	NameConflictSuffix NameConflict = "suffix"
+)
+
+// NameTakenError is returned when restoring a tenant under a name a live sibling has taken since
+// it was deleted. Suggested is the free name the tenant would be restored under with
+// NameConflictSuffix.
+type NameTakenError struct {
+	Name      string
+	Suggested string
+	Err       *validation.Error
+}
+
+// Error implements the error interface.
+func (e *NameTakenError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the name_taken validation error.
+func (e *NameTakenError) Unwrap() error {
+	return e.Err
+}
+
+// Restore creates the deleted tenant again, with its id and under its parent, and deletes its
+// tombstone, in a transaction. The tenant is named name, or its final name when empty. Only live
+// siblings, including those scheduled for deletion, keep the name from it, and onConflict tells
+// what to do when one has. Its other fields aren't kept, the tenant is restored with their
+// defaults.
+func Restore(ctx context.Context, client *generated.Client, id gidx.PrefixedID, name string, onConflict NameConflict) (*generated.Tenant, error) {
+	var t *generated.Tenant
+
+	err := inTx(ctx, client, func(client *generated.Client) error {
+		var err error
+
+		t, err = restore(ctx, client, id, name, onConflict)
+
+		return err
+	})
+
+	return t, err
+}
+
+// RestoreSubtree restores the deleted tenant as Restore does, then its deleted descendants whose
+// tombstones are kept, a level at a time, in the same transaction. Descendants keep their final
+// name, suffixed when a sibling has taken it since. The tenant is returned first, then its
+// descendants level by level.
+func RestoreSubtree(ctx context.Context, client *generated.Client, id gidx.PrefixedID, name string, onConflict NameConflict) ([]*generated.Tenant, error) {
+	var restored []*generated.Tenant
+
+	err := inTx(ctx, client, func(client *generated.Client) error {
+		root, err := restore(ctx, client, id, name, onConflict)
+		if err != nil {
+			return err
+		}
+
+		restored = append(restored, root)
+
+		// restored tenants lose their tombstone, so the levels can't cycle
+		for level := []gidx.PrefixedID{id}; len(level) != 0; {
+			children, err := client.TenantTombstone.Query().
+				Where(enttombstone.ParentTenantIDIn(level...)).
+				Order(generated.Asc(enttombstone.FieldID)).
+				IDs(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, child := range children {
+				t, err := restore(ctx, client, child, "", NameConflictSuffix)
+				if err != nil {
+					return err
+				}
+
+				restored = append(restored, t)
+			}
+
+			level = children
+		}
+
+		return nil
+	})
+
+	return restored, err
+}
+
+// inTx runs fn with the client of a transaction, committed when it succeeds.
+func inTx(ctx context.Context, client *generated.Client, fn func(client *generated.Client) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx.Client()); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func restore(ctx context.Context, client *generated.Client, id gidx.PrefixedID, name string, onConflict NameConflict) (*generated.Tenant, error) {
+	tombstone, err := client.TenantTombstone.Get(ctx, id)
+
+	switch {
+	case generated.IsNotFound(err):
+		return nil, ErrNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	live, err := client.Tenant.Query().Where(enttenant.ID(id)).Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if live {
+		return nil, ErrLive
+	}
+
+	var parentID *gidx.PrefixedID
+
+	if tombstone.ParentTenantID != gidx.NullPrefixedID {
+		parentID = &tombstone.ParentTenantID
+
+		exists, err := client.Tenant.Query().Where(enttenant.ID(*parentID)).Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, ErrParentDeleted
+		}
+	}
+
+	if name == "" {
+		name = tombstone.Name
+	}
+
+	// the deletion records of purged siblings, its own among them, don't keep names from restores
+	policy := validation.NameReuseBlockDuringRetention
+
+	free, err := policy.FreeSiblingName(ctx, client, parentID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if free != name && onConflict != NameConflictSuffix {
+		return nil, &NameTakenError{
+			Name:      name,
+			Suggested: free,
+			Err: &validation.Error{
+				Field:   "name",
+				Code:    validation.CodeNameTaken,
+				Message: fmt.Sprintf("%q was taken by a sibling since the tenant was deleted, restore it under another name", name),
+			},
+		}
+	}
+
+	create := client.Tenant.Create().SetID(id).SetName(free)
+
+	if parentID != nil {
+		create.SetParentID(*parentID)
+	}
+
+	t, err := create.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.TenantTombstone.DeleteOneID(id).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
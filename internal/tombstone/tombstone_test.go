@@ -0,0 +1,204 @@
+package tombstone_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/tombstone"
+	"go.infratographer.com/tenant-api/internal/validation"
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func newClient(t *testing.T) *ent.Client {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(tombstone.Hook())
+
+	return client
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+
+	due := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	scheduled := client.Tenant.Create().SetName("scheduled").SetParent(root).SetDeletionScheduledAt(due).SaveX(ctx)
+
+	before := time.Now().UTC()
+
+	client.Tenant.DeleteOne(child).ExecX(ctx)
+	client.Tenant.DeleteOne(scheduled).ExecX(ctx)
+
+	tombstones, err := tombstone.Find(ctx, client, root.ID, child.ID, scheduled.ID)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 2, "live tenants have no tombstone")
+
+	ts := tombstones[child.ID]
+	assert.Equal(t, "child", ts.Name)
+	assert.Equal(t, root.ID, ts.ParentTenantID)
+	assert.False(t, ts.PurgedAt.Before(before))
+	assert.Equal(t, ts.PurgedAt, ts.DeletedAt, "deleted right away")
+
+	// scheduled deletions took effect when due
+	ts = tombstones[scheduled.ID]
+	assert.True(t, due.Equal(ts.DeletedAt))
+	assert.True(t, ts.PurgedAt.After(ts.DeletedAt))
+
+	// deletions which fail leave no tombstone
+	err = client.Tenant.DeleteOneID("tnntten-missing").Exec(ctx)
+	require.True(t, ent.IsNotFound(err))
+	assert.Equal(t, 2, client.TenantTombstone.Query().CountX(ctx))
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SetDescription("gone").SaveX(ctx)
+
+	client.Tenant.DeleteOne(child).ExecX(ctx)
+
+	restored, err := tombstone.Restore(ctx, client, child.ID, "", tombstone.NameConflictError)
+	require.NoError(t, err)
+
+	assert.Equal(t, child.ID, restored.ID)
+	assert.Equal(t, "child", restored.Name)
+	assert.Equal(t, root.ID, restored.ParentTenantID)
+	assert.Empty(t, restored.Description, "only the tombstone is restored")
+	assert.Equal(t, 0, client.TenantTombstone.Query().CountX(ctx))
+
+	_, err = tombstone.Restore(ctx, client, child.ID, "", tombstone.NameConflictError)
+	assert.ErrorIs(t, err, tombstone.ErrNotFound)
+	assert.ErrorIs(t, err, apierrors.ErrTenantNotFound)
+
+	// deleted again, its tombstone is recorded again
+	client.Tenant.DeleteOneID(child.ID).ExecX(ctx)
+
+	restored, err = tombstone.Restore(ctx, client, child.ID, "renamed", tombstone.NameConflictError)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", restored.Name)
+}
+
+func TestRestoreNameTaken(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	deleted := client.Tenant.Create().SetName("reused").SetParent(root).SaveX(ctx)
+
+	client.Tenant.DeleteOne(deleted).ExecX(ctx)
+
+	// a sibling takes the name, and the first suffix, once the tenant is purged
+	client.Tenant.Create().SetName("reused").SetParent(root).ExecX(ctx)
+	client.Tenant.Create().SetName("reused-2").SetParent(root).ExecX(ctx)
+
+	_, err := tombstone.Restore(ctx, client, deleted.ID, "", tombstone.NameConflictError)
+
+	var taken *tombstone.NameTakenError
+
+	require.ErrorAs(t, err, &taken)
+	assert.Equal(t, "reused", taken.Name)
+	assert.Equal(t, "reused-3", taken.Suggested)
+	assert.Equal(t, validation.CodeNameTaken, taken.Err.Code)
+	assert.ErrorIs(t, err, apierrors.ErrNameConflict)
+
+	assert.Equal(t, 1, client.TenantTombstone.Query().CountX(ctx), "failed restores keep the tombstone")
+
+	restored, err := tombstone.Restore(ctx, client, deleted.ID, "", tombstone.NameConflictSuffix)
+	require.NoError(t, err)
+	assert.Equal(t, deleted.ID, restored.ID)
+	assert.Equal(t, "reused-3", restored.Name)
+}
+
+func TestRestoreParentDeleted(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+
+	client.Tenant.DeleteOne(child).ExecX(ctx)
+	client.Tenant.DeleteOne(root).ExecX(ctx)
+
+	_, err := tombstone.Restore(ctx, client, child.ID, "", tombstone.NameConflictError)
+	assert.ErrorIs(t, err, tombstone.ErrParentDeleted)
+	assert.ErrorIs(t, err, apierrors.ErrConflict)
+
+	// the parent is restored first
+	_, err = tombstone.Restore(ctx, client, root.ID, "", tombstone.NameConflictError)
+	require.NoError(t, err)
+
+	_, err = tombstone.Restore(ctx, client, child.ID, "", tombstone.NameConflictError)
+	require.NoError(t, err)
+}
+
+func TestRestoreSubtree(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+	sibling := client.Tenant.Create().SetName("sibling").SetParent(child).SaveX(ctx)
+
+	client.Tenant.DeleteOne(grandchild).ExecX(ctx)
+	client.Tenant.DeleteOne(sibling).ExecX(ctx)
+	client.Tenant.DeleteOne(child).ExecX(ctx)
+
+	restored, err := tombstone.RestoreSubtree(ctx, client, child.ID, "", tombstone.NameConflictError)
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+	assert.Equal(t, child.ID, restored[0].ID, "the tenant comes first")
+
+	for _, descendant := range restored[1:] {
+		assert.Equal(t, child.ID, descendant.ParentTenantID)
+	}
+
+	assert.Equal(t, 0, client.TenantTombstone.Query().CountX(ctx))
+}
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	client := newClient(t)
+
+	old := client.Tenant.Create().SetName("old").SaveX(ctx)
+	client.Tenant.DeleteOne(old).ExecX(ctx)
+
+	cutoff := time.Now().UTC()
+
+	recent := client.Tenant.Create().SetName("recent").SaveX(ctx)
+	client.Tenant.DeleteOne(recent).ExecX(ctx)
+
+	purged, err := tombstone.Purge(ctx, client, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	tombstones, err := tombstone.Find(ctx, client, old.ID, recent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []gidx.PrefixedID{recent.ID}, keys(tombstones))
+}
+
+func keys(m map[gidx.PrefixedID]*ent.TenantTombstone) []gidx.PrefixedID {
+	ids := make([]gidx.PrefixedID, 0, len(m))
+
+	for id := range m {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
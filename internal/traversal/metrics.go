@@ -0,0 +1,125 @@
+package traversal
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/reqlog"
+)
+
+// Operations, the values of the operation label.
+const (
+	// OpDescendants walks down the subtree below a tenant.
+	OpDescendants = "descendants"
+	// OpAncestors walks up from a tenant to its root.
+	OpAncestors = "ancestors"
+	// OpCascadeCheck walks up from a new parent checking no ancestor is scheduled for deletion.
+	OpCascadeCheck = "cascade-check"
+	// OpTree lists a subtree depth first, as the flat tree view does.
+	OpTree = "tree"
+)
+
+// Option configures Metrics.
+type Option func(*Metrics)
+
+// WithWarnNodes logs the walks returning more than n tenants, zero never does.
+func WithWarnNodes(n int) Option {
+	return func(m *Metrics) {
+		m.warnNodes = n
+	}
+}
+
+// WithWarnDepth logs the walks going more than n levels deep, zero never does.
+func WithWarnDepth(n int) Option {
+	return func(m *Metrics) {
+		m.warnDepth = n
+	}
+}
+
+// WithWarnDuration logs the walks taking longer than d, zero never does.
+func WithWarnDuration(d time.Duration) Option {
+	return func(m *Metrics) {
+		m.warnDuration = d
+	}
+}
+
+// Metrics records the size, depth and duration of hierarchy walks by operation. A nil Metrics
+// records nothing, so callers don't have to check whether they were given one.
+type Metrics struct {
+	logger       *zap.SugaredLogger
+	warnNodes    int
+	warnDepth    int
+	warnDuration time.Duration
+
+	nodes    *prometheus.HistogramVec
+	depth    *prometheus.HistogramVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics returns the traversal metrics, registered with the registerer.
+func NewMetrics(logger *zap.SugaredLogger, reg prometheus.Registerer, opts ...Option) (*Metrics, error) {
+	m := &Metrics{
+		logger: logger,
+		nodes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tenant_api",
+			Subsystem: "traversal",
+			Name:      "nodes",
+			Help:      "Number of tenants returned by a walk through the hierarchy, by operation.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+		}, []string{"operation"}),
+		depth: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tenant_api",
+			Subsystem: "traversal",
+			Name:      "depth",
+			Help:      "Number of levels a walk through the hierarchy went, by operation.",
+			Buckets:   []float64{1, 2, 3, 5, 8, 13, 21, 34, 55, 100},
+		}, []string{"operation"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tenant_api",
+			Subsystem: "traversal",
+			Name:      "duration_seconds",
+			Help:      "Time a walk through the hierarchy took, by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	for _, c := range []prometheus.Collector{m.nodes, m.depth, m.duration} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Observe records a walk which returned the number of tenants, went the number of levels deep and
+// took the duration. Walks past one of the thresholds are logged with the fields of the request.
+func (m *Metrics) Observe(ctx context.Context, op string, nodes, depth int, d time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.nodes.WithLabelValues(op).Observe(float64(nodes))
+	m.depth.WithLabelValues(op).Observe(float64(depth))
+	m.duration.WithLabelValues(op).Observe(d.Seconds())
+
+	if exceeds(nodes, m.warnNodes) || exceeds(depth, m.warnDepth) || (m.warnDuration > 0 && d > m.warnDuration) {
+		reqlog.FromContext(ctx, m.logger).Warnw("hierarchy walk exceeded the traversal thresholds",
+			"operation", op,
+			"nodes", nodes,
+			"depth", depth,
+			"duration", d,
+		)
+	}
+}
+
+func exceeds(n, threshold int) bool {
+	return threshold > 0 && n > threshold
+}
@@ -0,0 +1,84 @@
+package traversal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.infratographer.com/tenant-api/internal/traversal"
+)
+
+// histogram returns the sample count and sum of the histogram for the operation.
+func histogram(t *testing.T, reg *prometheus.Registry, name, op string) (uint64, float64) {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, m := range family.GetMetric() {
+			if m.GetLabel()[0].GetValue() == op {
+				return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	reg := prometheus.NewRegistry()
+	core, logs := observer.New(zap.WarnLevel)
+
+	m, err := traversal.NewMetrics(zap.New(core).Sugar(), reg,
+		traversal.WithWarnNodes(100),
+		traversal.WithWarnDepth(10),
+		traversal.WithWarnDuration(time.Second),
+	)
+	require.NoError(t, err)
+
+	m.Observe(ctx, traversal.OpDescendants, 7, 3, 10*time.Millisecond)
+	m.Observe(ctx, traversal.OpDescendants, 5, 2, 20*time.Millisecond)
+	m.Observe(ctx, traversal.OpAncestors, 4, 4, time.Millisecond)
+
+	count, sum := histogram(t, reg, "tenant_api_traversal_nodes", traversal.OpDescendants)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, float64(12), sum)
+
+	count, sum = histogram(t, reg, "tenant_api_traversal_depth", traversal.OpDescendants)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, float64(5), sum)
+
+	_, sum = histogram(t, reg, "tenant_api_traversal_duration_seconds", traversal.OpDescendants)
+	assert.InDelta(t, 0.03, sum, 1e-9)
+
+	count, _ = histogram(t, reg, "tenant_api_traversal_nodes", traversal.OpAncestors)
+	assert.Equal(t, uint64(1), count)
+
+	assert.Zero(t, logs.Len(), "walks within the thresholds aren't logged")
+
+	m.Observe(ctx, traversal.OpTree, 101, 1, 0)
+	m.Observe(ctx, traversal.OpTree, 1, 11, 0)
+	m.Observe(ctx, traversal.OpTree, 1, 1, 2*time.Second)
+
+	require.Equal(t, 3, logs.Len())
+	assert.Equal(t, traversal.OpTree, logs.All()[0].ContextMap()["operation"])
+	assert.Equal(t, int64(101), logs.All()[0].ContextMap()["nodes"])
+
+	// nil metrics record nothing
+	var none *traversal.Metrics
+
+	none.Observe(ctx, traversal.OpTree, 1, 1, 0)
+}
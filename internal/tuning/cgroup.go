@@ -0,0 +1,168 @@
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CgroupRoot is where the cgroup filesystem is mounted, containers with their own cgroup
+// namespace see their limits at its root.
+const CgroupRoot = "/sys/fs/cgroup"
+
+// cgroup v1 reports memory limits above this as unlimited, it is the page counter maximum rounded
+// down to pages rather than a limit anyone set.
+const cgroupV1Unlimited = math.MaxInt64 / 2
+
+// Limits are the resources a cgroup limits the process to.
+type Limits struct {
+	// CPU is the number of cpus the quota allows, zero without a quota.
+	CPU float64
+	// Memory is the memory limit in bytes, zero without a limit.
+	Memory int64
+}
+
+// DetectCgroup reads the limits of the cgroup mounted at the root of the filesystem, cgroup v2 or
+// v1. Missing files are read as no limit, so it returns no limits outside of a cgroup.
+func DetectCgroup(fsys fs.FS) (Limits, error) {
+	var (
+		l   Limits
+		err error
+	)
+
+	if _, serr := fs.Stat(fsys, "cgroup.controllers"); serr == nil {
+		l.CPU, err = cpuV2(fsys)
+		if err != nil {
+			return Limits{}, err
+		}
+
+		l.Memory, err = memoryV2(fsys)
+
+		return l, err
+	}
+
+	l.CPU, err = cpuV1(fsys)
+	if err != nil {
+		return Limits{}, err
+	}
+
+	l.Memory, err = memoryV1(fsys)
+
+	return l, err
+}
+
+// cpuV2 reads cpu.max, "max 100000" or "<quota> <period>" in microseconds.
+func cpuV2(fsys fs.FS) (float64, error) {
+	content, ok, err := readFile(fsys, "cpu.max")
+	if !ok || err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(content)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, fmt.Errorf("malformed cpu.max %q", content)
+	}
+
+	if fields[0] == "max" {
+		return 0, nil
+	}
+
+	period := "100000"
+	if len(fields) == 2 {
+		period = fields[1]
+	}
+
+	return quota(fields[0], period)
+}
+
+// cpuV1 reads cpu.cfs_quota_us and cpu.cfs_period_us, the quota is -1 without a limit.
+func cpuV1(fsys fs.FS) (float64, error) {
+	for _, dir := range []string{"cpu", "cpu,cpuacct", "cpuacct,cpu"} {
+		q, ok, err := readFile(fsys, dir+"/cpu.cfs_quota_us")
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		if strings.HasPrefix(q, "-") {
+			return 0, nil
+		}
+
+		p, ok, err := readFile(fsys, dir+"/cpu.cfs_period_us")
+		if !ok || err != nil {
+			return 0, err
+		}
+
+		return quota(q, p)
+	}
+
+	return 0, nil
+}
+
+func quota(q, p string) (float64, error) {
+	quota, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed cpu quota %q: %w", q, err)
+	}
+
+	period, err := strconv.ParseFloat(p, 64)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("malformed cpu period %q", p)
+	}
+
+	return quota / period, nil
+}
+
+// memoryV2 reads memory.max, "max" or the limit in bytes.
+func memoryV2(fsys fs.FS) (int64, error) {
+	content, ok, err := readFile(fsys, "memory.max")
+	if !ok || err != nil || content == "max" {
+		return 0, err
+	}
+
+	return parseBytes(content)
+}
+
+// memoryV1 reads memory.limit_in_bytes, a value near the maximum without a limit.
+func memoryV1(fsys fs.FS) (int64, error) {
+	content, ok, err := readFile(fsys, "memory/memory.limit_in_bytes")
+	if !ok || err != nil {
+		return 0, err
+	}
+
+	limit, err := parseBytes(content)
+	if err != nil || limit >= cgroupV1Unlimited {
+		return 0, err
+	}
+
+	return limit, nil
+}
+
+func parseBytes(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed memory limit %q: %w", s, err)
+	}
+
+	return n, nil
+}
+
+// readFile returns the trimmed content of the file, false when it doesn't exist.
+func readFile(fsys fs.FS, name string) (string, bool, error) {
+	content, err := fs.ReadFile(fsys, name)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "", false, nil
+	case err != nil:
+		return "", false, err
+	}
+
+	return strings.TrimSpace(string(content)), true, nil
+}
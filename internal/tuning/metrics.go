@@ -0,0 +1,76 @@
+package tuning
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const (
+	metricsNamespace = "tenant_api"
+	metricsSubsystem = "runtime"
+)
+
+// RegisterMetrics registers the runtime metrics with the registerer: those of the go collector,
+// such as the goroutines, the garbage collection pauses and the heap, unless it is already
+// registered as with the default registry, and the settings in effect with the cgroup limits they
+// were derived from.
+func RegisterMetrics(reg prometheus.Registerer, s Settings) error {
+	var already prometheus.AlreadyRegisteredError
+
+	if err := reg.Register(collectors.NewGoCollector()); err != nil && !errors.As(err, &already) {
+		return err
+	}
+
+	gcPercent := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "gc_percent",
+		Help:      "Garbage collection target percentage, GOGC, negative when the collector is off.",
+	})
+	gcPercent.Set(float64(s.GCPercent))
+
+	cgroupCPU := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "cgroup_cpu_limit",
+		Help:      "Number of cpus the cgroup quota allows, zero without a quota.",
+	})
+	cgroupCPU.Set(s.Cgroup.CPU)
+
+	cgroupMemory := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "cgroup_memory_limit_bytes",
+		Help:      "Memory limit of the cgroup, zero without a limit.",
+	})
+	cgroupMemory.Set(float64(s.Cgroup.Memory))
+
+	gauges := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "gomaxprocs",
+			Help:      "Number of cpus go code runs on at once, GOMAXPROCS.",
+		}, func() float64 { return float64(runtime.GOMAXPROCS(0)) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "memory_limit_bytes",
+			Help:      "Soft memory limit of the runtime, GOMEMLIMIT, zero without one.",
+		}, func() float64 { return float64(currentMemoryLimit()) }),
+		gcPercent,
+		cgroupCPU,
+		cgroupMemory,
+	}
+
+	for _, c := range gauges {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
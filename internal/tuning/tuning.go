@@ -0,0 +1,266 @@
+package tuning
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// DefaultMemoryLimitRatio is the default share of the cgroup memory limit the soft memory limit
+// is set to, leaving room for the memory the runtime doesn't account for.
+const DefaultMemoryLimitRatio = 0.9
+
+// Sources of the settings.
+const (
+	// SourceConfig is a setting given by the configuration.
+	SourceConfig = "config"
+	// SourceEnv is a setting the runtime took from the GOMAXPROCS, GOMEMLIMIT or GOGC environment
+	// variables.
+	SourceEnv = "env"
+	// SourceCgroup is a setting derived from the cgroup limits.
+	SourceCgroup = "cgroup"
+	// SourceDefault is the default of the runtime.
+	SourceDefault = "default"
+)
+
+// Settings are the runtime settings planned by Plan and where each comes from.
+type Settings struct {
+	// MaxProcs is GOMAXPROCS.
+	MaxProcs       int
+	MaxProcsSource string
+	// MemoryLimit is the soft memory limit in bytes, zero without one.
+	MemoryLimit       int64
+	MemoryLimitSource string
+	// GCPercent is the garbage collection target percentage, negative when it is off.
+	GCPercent       int
+	GCPercentSource string
+	// Cgroup are the limits detected, zero when detection is off.
+	Cgroup Limits
+}
+
+// Option configures Plan.
+type Option func(*planner)
+
+// WithMaxProcs sets GOMAXPROCS, overriding the environment and the cgroup when positive.
+func WithMaxProcs(n int) Option {
+	return func(p *planner) {
+		p.maxProcs = n
+	}
+}
+
+// WithMemoryLimit sets the soft memory limit in bytes, overriding the environment and the cgroup
+// when positive.
+func WithMemoryLimit(bytes int64) Option {
+	return func(p *planner) {
+		p.memoryLimit = bytes
+	}
+}
+
+// WithMemoryLimitRatio sets the share of the cgroup memory limit the soft memory limit is set to,
+// DefaultMemoryLimitRatio unless it is within (0, 1].
+func WithMemoryLimitRatio(ratio float64) Option {
+	return func(p *planner) {
+		if ratio > 0 && ratio <= 1 {
+			p.memoryLimitRatio = ratio
+		}
+	}
+}
+
+// WithGCPercent sets the garbage collection target percentage, overriding GOGC when not zero. A
+// negative percentage turns the collector off until the memory limit is reached.
+func WithGCPercent(percent int) Option {
+	return func(p *planner) {
+		p.gcPercent = percent
+	}
+}
+
+// WithCgroupDetection sets whether the settings which aren't configured or set by the environment
+// are derived from the cgroup limits, they are by default.
+func WithCgroupDetection(enabled bool) Option {
+	return func(p *planner) {
+		p.detect = enabled
+	}
+}
+
+// WithCgroupFS sets the filesystem the cgroup limits are read from, CgroupRoot by default.
+func WithCgroupFS(fsys fs.FS) Option {
+	return func(p *planner) {
+		p.cgroup = fsys
+	}
+}
+
+// WithEnv sets the lookup of the environment variables, os.LookupEnv by default.
+func WithEnv(lookup func(string) (string, bool)) Option {
+	return func(p *planner) {
+		p.env = lookup
+	}
+}
+
+type planner struct {
+	maxProcs         int
+	memoryLimit      int64
+	memoryLimitRatio float64
+	gcPercent        int
+	detect           bool
+	cgroup           fs.FS
+	env              func(string) (string, bool)
+}
+
+// Plan returns the runtime settings, each taken from the first of: the options, the environment
+// variables the runtime reads itself, the cgroup limits and the runtime defaults. GOMAXPROCS is
+// the cpu quota rounded down, so the process isn't throttled, and at least one. The soft memory
+// limit is a share of the memory limit.
+func Plan(opts ...Option) (Settings, error) {
+	p := &planner{
+		memoryLimitRatio: DefaultMemoryLimitRatio,
+		detect:           true,
+		env:              os.LookupEnv,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	var s Settings
+
+	if p.detect {
+		if p.cgroup == nil {
+			p.cgroup = os.DirFS(CgroupRoot)
+		}
+
+		limits, err := DetectCgroup(p.cgroup)
+		if err != nil {
+			return Settings{}, fmt.Errorf("detecting cgroup limits: %w", err)
+		}
+
+		s.Cgroup = limits
+	}
+
+	switch {
+	case p.maxProcs > 0:
+		s.MaxProcs, s.MaxProcsSource = p.maxProcs, SourceConfig
+	case p.set("GOMAXPROCS"):
+		s.MaxProcs, s.MaxProcsSource = runtime.GOMAXPROCS(0), SourceEnv
+	case s.Cgroup.CPU > 0:
+		s.MaxProcs, s.MaxProcsSource = int(math.Max(1, math.Min(math.Floor(s.Cgroup.CPU), float64(runtime.NumCPU())))), SourceCgroup
+	default:
+		s.MaxProcs, s.MaxProcsSource = runtime.NumCPU(), SourceDefault
+	}
+
+	switch {
+	case p.memoryLimit > 0:
+		s.MemoryLimit, s.MemoryLimitSource = p.memoryLimit, SourceConfig
+	case p.set("GOMEMLIMIT"):
+		s.MemoryLimit, s.MemoryLimitSource = currentMemoryLimit(), SourceEnv
+	case s.Cgroup.Memory > 0:
+		s.MemoryLimit, s.MemoryLimitSource = int64(float64(s.Cgroup.Memory)*p.memoryLimitRatio), SourceCgroup
+	default:
+		s.MemoryLimit, s.MemoryLimitSource = 0, SourceDefault
+	}
+
+	switch {
+	case p.gcPercent != 0:
+		s.GCPercent, s.GCPercentSource = p.gcPercent, SourceConfig
+	case p.set("GOGC"):
+		s.GCPercent, s.GCPercentSource = p.envGCPercent(), SourceEnv
+	default:
+		s.GCPercent, s.GCPercentSource = 100, SourceDefault
+	}
+
+	return s, nil
+}
+
+func (p *planner) set(name string) bool {
+	v, ok := p.env(name)
+
+	return ok && v != ""
+}
+
+// Apply sets the settings on the runtime. Those taken from the environment are left as the
+// runtime set them.
+func (s Settings) Apply() {
+	if s.MaxProcsSource != SourceEnv {
+		runtime.GOMAXPROCS(s.MaxProcs)
+	}
+
+	if s.MemoryLimitSource != SourceEnv {
+		limit := s.MemoryLimit
+		if limit <= 0 {
+			limit = math.MaxInt64
+		}
+
+		debug.SetMemoryLimit(limit)
+	}
+
+	if s.GCPercentSource != SourceEnv {
+		debug.SetGCPercent(s.GCPercent)
+	}
+}
+
+// currentMemoryLimit returns the soft memory limit of the runtime, zero without one.
+func currentMemoryLimit() int64 {
+	limit := debug.SetMemoryLimit(-1)
+	if limit == math.MaxInt64 {
+		return 0
+	}
+
+	return limit
+}
+
+// envGCPercent returns the garbage collection target percentage GOGC sets, as the runtime reads it.
+func (p *planner) envGCPercent() int {
+	v, _ := p.env("GOGC")
+	if v == "off" {
+		return -1
+	}
+
+	percent, err := strconv.Atoi(v)
+	if err != nil {
+		return 100
+	}
+
+	return percent
+}
+
+// sizeUnits are the suffixes ParseSize accepts, those of GOMEMLIMIT.
+var sizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseSize parses a number of bytes written as GOMEMLIMIT is, such as 1536MiB or 2GiB. An empty
+// size is zero.
+func ParseSize(s string) (int64, error) {
+	number := strings.TrimSpace(s)
+	if number == "" {
+		return 0, nil
+	}
+
+	factor := int64(1)
+
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(number, u.suffix) {
+			number, factor = strings.TrimSuffix(number, u.suffix), u.factor
+
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n < 0 || n > math.MaxInt64/factor {
+		return 0, fmt.Errorf("invalid size %q, expected bytes with an optional B, KiB, MiB, GiB or TiB suffix", s)
+	}
+
+	return n * factor, nil
+}
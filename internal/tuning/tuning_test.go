@@ -0,0 +1,179 @@
+package tuning_test
+
+import (
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/tuning"
+)
+
+func cgroupV2(cpu, memory string) fstest.MapFS {
+	return fstest.MapFS{
+		"cgroup.controllers": {Data: []byte("cpu memory")},
+		"cpu.max":            {Data: []byte(cpu + "\n")},
+		"memory.max":         {Data: []byte(memory + "\n")},
+	}
+}
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+
+		return v, ok
+	}
+}
+
+func TestDetectCgroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		fsys   fstest.MapFS
+		limits tuning.Limits
+	}{
+		{"v2", cgroupV2("250000 100000", "1073741824"), tuning.Limits{CPU: 2.5, Memory: 1 << 30}},
+		{"v2 unlimited", cgroupV2("max 100000", "max"), tuning.Limits{}},
+		{"v1", fstest.MapFS{
+			"cpu,cpuacct/cpu.cfs_quota_us":  {Data: []byte("50000")},
+			"cpu,cpuacct/cpu.cfs_period_us": {Data: []byte("100000")},
+			"memory/memory.limit_in_bytes":  {Data: []byte("536870912")},
+		}, tuning.Limits{CPU: 0.5, Memory: 512 << 20}},
+		{"v1 unlimited", fstest.MapFS{
+			"cpu/cpu.cfs_quota_us":         {Data: []byte("-1")},
+			"cpu/cpu.cfs_period_us":        {Data: []byte("100000")},
+			"memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712")},
+		}, tuning.Limits{}},
+		{"no cgroup", fstest.MapFS{}, tuning.Limits{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := tuning.DetectCgroup(tt.fsys)
+			require.NoError(t, err)
+			assert.Equal(t, tt.limits, limits)
+		})
+	}
+
+	_, err := tuning.DetectCgroup(cgroupV2("lots", "max"))
+	assert.Error(t, err)
+}
+
+func TestPlan(t *testing.T) {
+	cgroup := tuning.WithCgroupFS(cgroupV2("150000 100000", "1000000000"))
+
+	t.Run("cgroup", func(t *testing.T) {
+		s, err := tuning.Plan(cgroup, tuning.WithEnv(env(nil)))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, s.MaxProcs, "the quota is rounded down")
+		assert.Equal(t, tuning.SourceCgroup, s.MaxProcsSource)
+		assert.Equal(t, int64(900000000), s.MemoryLimit)
+		assert.Equal(t, tuning.SourceCgroup, s.MemoryLimitSource)
+		assert.Equal(t, 100, s.GCPercent)
+		assert.Equal(t, tuning.SourceDefault, s.GCPercentSource)
+		assert.Equal(t, tuning.Limits{CPU: 1.5, Memory: 1000000000}, s.Cgroup)
+	})
+
+	t.Run("ratio", func(t *testing.T) {
+		s, err := tuning.Plan(cgroup, tuning.WithEnv(env(nil)), tuning.WithMemoryLimitRatio(0.5))
+		require.NoError(t, err)
+		assert.Equal(t, int64(500000000), s.MemoryLimit)
+	})
+
+	t.Run("environment wins over the cgroup", func(t *testing.T) {
+		s, err := tuning.Plan(cgroup, tuning.WithEnv(env(map[string]string{"GOMAXPROCS": "7", "GOMEMLIMIT": "1GiB", "GOGC": "off"})))
+		require.NoError(t, err)
+
+		assert.Equal(t, tuning.SourceEnv, s.MaxProcsSource)
+		assert.Equal(t, runtime.GOMAXPROCS(0), s.MaxProcs, "the runtime already applied it")
+		assert.Equal(t, tuning.SourceEnv, s.MemoryLimitSource)
+		assert.Equal(t, -1, s.GCPercent)
+		assert.Equal(t, tuning.SourceEnv, s.GCPercentSource)
+	})
+
+	t.Run("config wins over everything", func(t *testing.T) {
+		s, err := tuning.Plan(cgroup,
+			tuning.WithEnv(env(map[string]string{"GOMAXPROCS": "7", "GOMEMLIMIT": "1GiB", "GOGC": "200"})),
+			tuning.WithMaxProcs(3),
+			tuning.WithMemoryLimit(256<<20),
+			tuning.WithGCPercent(50),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, tuning.Settings{
+			MaxProcs:          3,
+			MaxProcsSource:    tuning.SourceConfig,
+			MemoryLimit:       256 << 20,
+			MemoryLimitSource: tuning.SourceConfig,
+			GCPercent:         50,
+			GCPercentSource:   tuning.SourceConfig,
+			Cgroup:            tuning.Limits{CPU: 1.5, Memory: 1000000000},
+		}, s)
+	})
+
+	t.Run("detection off", func(t *testing.T) {
+		s, err := tuning.Plan(cgroup, tuning.WithEnv(env(nil)), tuning.WithCgroupDetection(false))
+		require.NoError(t, err)
+
+		assert.Equal(t, runtime.NumCPU(), s.MaxProcs)
+		assert.Equal(t, tuning.SourceDefault, s.MaxProcsSource)
+		assert.Zero(t, s.MemoryLimit)
+		assert.Equal(t, tuning.Limits{}, s.Cgroup)
+	})
+}
+
+func TestParseSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"":         0,
+		"1024":     1024,
+		"512B":     512,
+		"64KiB":    64 << 10,
+		"1536MiB":  1536 << 20,
+		" 2GiB ":   2 << 30,
+		"1TiB":     1 << 40,
+		"0MiB":     0,
+		"10000MiB": 10000 << 20,
+	} {
+		got, err := tuning.ParseSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"1GB", "-1", "lots", "1.5GiB", "9999999999TiB"} {
+		_, err := tuning.ParseSize(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	require.NoError(t, tuning.RegisterMetrics(reg, tuning.Settings{GCPercent: 100, Cgroup: tuning.Limits{CPU: 2, Memory: 1 << 30}}))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	values := map[string]float64{}
+
+	for _, f := range families {
+		values[f.GetName()] = f.GetMetric()[0].GetGauge().GetValue()
+	}
+
+	for _, name := range []string{"go_goroutines", "go_gc_duration_seconds", "go_memstats_heap_alloc_bytes"} {
+		assert.Contains(t, values, name)
+	}
+
+	assert.Equal(t, float64(runtime.GOMAXPROCS(0)), values["tenant_api_runtime_gomaxprocs"])
+	assert.Contains(t, values, "tenant_api_runtime_memory_limit_bytes")
+	assert.Equal(t, 100.0, values["tenant_api_runtime_gc_percent"])
+	assert.Equal(t, 2.0, values["tenant_api_runtime_cgroup_cpu_limit"])
+	assert.Equal(t, float64(1<<30), values["tenant_api_runtime_cgroup_memory_limit_bytes"])
+
+	// registries with a go collector, such as the default one, keep theirs
+	withGo := prometheus.NewPedanticRegistry()
+	require.NoError(t, withGo.Register(prometheus.NewGoCollector()))
+	assert.NoError(t, tuning.RegisterMetrics(withGo, tuning.Settings{}))
+}
@@ -0,0 +1,45 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+)
+
+// Bucket holds the requests counted for a tenant in a time bucket, by operation class.
+type Bucket struct {
+	Start  time.Time        `json:"start"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Buckets returns the flushed counts of the tenant in the buckets starting within [from, to),
+// oldest first. Buckets without requests are left out.
+func Buckets(ctx context.Context, client *ent.Client, tenantID gidx.PrefixedID, from, to time.Time) ([]Bucket, error) {
+	rows, err := client.TenantUsage.Query().
+		Where(
+			tenantusage.TenantID(tenantID),
+			tenantusage.BucketStartGTE(from),
+			tenantusage.BucketStartLT(to),
+		).
+		Order(ent.Asc(tenantusage.FieldBucketStart), ent.Asc(tenantusage.FieldOperation)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []Bucket{}
+
+	for _, row := range rows {
+		if n := len(buckets); n == 0 || !buckets[n-1].Start.Equal(row.BucketStart) {
+			buckets = append(buckets, Bucket{Start: row.BucketStart.UTC(), Counts: map[string]int64{}})
+		}
+
+		buckets[len(buckets)-1].Counts[row.Operation] += row.Count
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,297 @@
+package usage
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/tenantusage"
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+)
+
+// Operation classes the requests are counted by.
+const (
+	OpRead  = "read"
+	OpWrite = "write"
+)
+
+const (
+	// DefaultBucketSize is the default length of the time buckets requests are counted in.
+	DefaultBucketSize = time.Hour
+	// DefaultFlushInterval is the default interval the counts are flushed at.
+	DefaultFlushInterval = time.Minute
+	// DefaultMaxPending is the default number of counters kept between flushes.
+	DefaultMaxPending = 10000
+	// DefaultBatchSize is the default number of counters flushed in a transaction.
+	DefaultBatchSize = 100
+)
+
+// key identifies a counter.
+type key struct {
+	tenantID  gidx.PrefixedID
+	operation string
+	bucket    time.Time
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithBucketSize sets the length of the time buckets requests are counted in. Changing it doesn't
+// affect the buckets already stored.
+func WithBucketSize(size time.Duration) Option {
+	return func(r *Recorder) {
+		if size > 0 {
+			r.bucketSize = size
+		}
+	}
+}
+
+// WithMaxPending sets the number of counters kept between flushes. Requests which would need
+// another counter once it is reached aren't counted until the next flush, which bounds the memory
+// taken by requests for many tenants.
+func WithMaxPending(n int) Option {
+	return func(r *Recorder) {
+		if n > 0 {
+			r.maxPending = n
+		}
+	}
+}
+
+// WithBatchSize sets the number of counters flushed in a transaction.
+func WithBatchSize(n int) Option {
+	return func(r *Recorder) {
+		if n > 0 {
+			r.batchSize = n
+		}
+	}
+}
+
+// Recorder counts the requests made for tenants and flushes the counts to the database.
+type Recorder struct {
+	client     *ent.Client
+	logger     *zap.SugaredLogger
+	bucketSize time.Duration
+	maxPending int
+	batchSize  int
+
+	mu      sync.Mutex
+	pending map[key]int64
+	dropped int64
+}
+
+// NewRecorder returns a recorder storing the counts with the client.
+func NewRecorder(client *ent.Client, logger *zap.SugaredLogger, opts ...Option) *Recorder {
+	r := &Recorder{
+		client:     client,
+		logger:     logger,
+		bucketSize: DefaultBucketSize,
+		maxPending: DefaultMaxPending,
+		batchSize:  DefaultBatchSize,
+		pending:    map[key]int64{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// BucketSize returns the length of the time buckets requests are counted in.
+func (r *Recorder) BucketSize() time.Duration {
+	return r.bucketSize
+}
+
+// Record counts a request of the operation class made for the tenant now.
+func (r *Recorder) Record(tenantID gidx.PrefixedID, operation string) {
+	k := key{
+		tenantID:  tenantID,
+		operation: operation,
+		bucket:    time.Now().UTC().Truncate(r.bucketSize),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pending[k]; !ok && len(r.pending) >= r.maxPending {
+		r.dropped++
+
+		return
+	}
+
+	r.pending[k]++
+}
+
+// Middleware returns echo middleware counting the requests of routes with a tenant id path
+// parameter. Only requests which succeed are counted, so requests for tenants which don't exist
+// or which the caller may not access don't create counters. GET, HEAD and OPTIONS requests are
+// reads, all others writes.
+func (r *Recorder) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err != nil || c.Response().Status >= http.StatusBadRequest {
+				return err
+			}
+
+			id, perr := gidx.Parse(c.Param("id"))
+			if perr != nil || id.Prefix() != schema.TenantPrefix {
+				return nil
+			}
+
+			r.Record(id, Operation(c.Request().Method))
+
+			return nil
+		}
+	}
+}
+
+// Operation returns the operation class of requests with the method.
+func Operation(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return OpRead
+	default:
+		return OpWrite
+	}
+}
+
+// Run flushes the counts every interval until the context is done. The counts of the last
+// interval are left pending, they should be flushed on shutdown.
+func (r *Recorder) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.Flush(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Errorw("failed to flush tenant usage", "error", err)
+		}
+	}
+}
+
+// Flush adds the pending counts to the stored ones, a batch of counters per transaction. The
+// counts of batches which fail are kept for the next flush. Counters are inserted when the
+// tenant has none for the bucket yet, so replicas inserting the same counter at once make one of
+// them fail and retry with the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending, dropped := r.pending, r.dropped
+	r.pending, r.dropped = map[key]int64{}, 0
+	r.mu.Unlock()
+
+	if dropped != 0 {
+		r.logger.Warnw("tenant usage counters exhausted, requests weren't counted", "dropped", dropped, "max_pending", r.maxPending)
+	}
+
+	keys := make([]key, 0, len(pending))
+
+	for k := range pending {
+		keys = append(keys, k)
+	}
+
+	// a stable order keeps concurrent flushes of replicas from deadlocking each other
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+
+		switch {
+		case a.tenantID != b.tenantID:
+			return a.tenantID < b.tenantID
+		case !a.bucket.Equal(b.bucket):
+			return a.bucket.Before(b.bucket)
+		default:
+			return a.operation < b.operation
+		}
+	})
+
+	for start := 0; start < len(keys); start += r.batchSize {
+		end := start + r.batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		if err := r.flushBatch(ctx, keys[start:end], pending); err != nil {
+			r.restore(keys[start:], pending)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) flushBatch(ctx context.Context, keys []key, counts map[key]int64) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := addCounts(ctx, tx.Client(), keys, counts); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			r.logger.Errorw("failed to roll back tenant usage flush", "error", rerr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func addCounts(ctx context.Context, client *ent.Client, keys []key, counts map[key]int64) error {
+	for _, k := range keys {
+		updated, err := client.TenantUsage.Update().
+			Where(
+				tenantusage.TenantID(k.tenantID),
+				tenantusage.BucketStart(k.bucket),
+				tenantusage.Operation(k.operation),
+			).
+			AddCount(counts[k]).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+
+		if updated != 0 {
+			continue
+		}
+
+		err = client.TenantUsage.Create().
+			SetTenantID(k.tenantID).
+			SetBucketStart(k.bucket).
+			SetOperation(k.operation).
+			SetCount(counts[k]).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restore adds the counts which couldn't be flushed back to the pending ones. They are kept even
+// beyond the maximum, they were counted already.
+func (r *Recorder) restore(keys []key, counts map[key]int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range keys {
+		r.pending[k] += counts[k]
+	}
+}
@@ -0,0 +1,91 @@
+package usage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+	"go.uber.org/zap"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/usage"
+)
+
+func TestRecorderFlush(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	recorder := usage.NewRecorder(client, zap.NewNop().Sugar(), usage.WithMaxPending(3), usage.WithBatchSize(2))
+
+	one, two, three := gidx.PrefixedID("tnntten-one"), gidx.PrefixedID("tnntten-two"), gidx.PrefixedID("tnntten-three")
+
+	recorder.Record(one, usage.OpRead)
+	recorder.Record(one, usage.OpRead)
+	recorder.Record(one, usage.OpWrite)
+	recorder.Record(two, usage.OpRead)
+
+	// the counters are exhausted until the next flush, existing ones still count
+	recorder.Record(three, usage.OpRead)
+	recorder.Record(two, usage.OpRead)
+
+	require.NoError(t, recorder.Flush(ctx))
+
+	recorder.Record(one, usage.OpRead)
+	recorder.Record(three, usage.OpWrite)
+
+	require.NoError(t, recorder.Flush(ctx))
+
+	// nothing pending
+	require.NoError(t, recorder.Flush(ctx))
+
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	totals := func(id gidx.PrefixedID) map[string]int64 {
+		buckets, err := usage.Buckets(ctx, client, id, from, to)
+		require.NoError(t, err)
+		require.Len(t, buckets, 1)
+
+		return buckets[0].Counts
+	}
+
+	assert.Equal(t, map[string]int64{usage.OpRead: 3, usage.OpWrite: 1}, totals(one))
+	assert.Equal(t, map[string]int64{usage.OpRead: 2}, totals(two))
+	assert.Equal(t, map[string]int64{usage.OpWrite: 1}, totals(three))
+	assert.Equal(t, 4, client.TenantUsage.Query().CountX(ctx))
+}
+
+func TestRecorderFlushFailure(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	recorder := usage.NewRecorder(client, zap.NewNop().Sugar())
+
+	recorder.Record("tnntten-one", usage.OpRead)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	// counts which couldn't be flushed are kept for the next flush
+	require.Error(t, recorder.Flush(canceled))
+	require.NoError(t, recorder.Flush(ctx))
+
+	buckets, err := usage.Buckets(ctx, client, "tnntten-one", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, buckets, 1)
+	assert.Equal(t, map[string]int64{usage.OpRead: 1}, buckets[0].Counts)
+}
+
+func TestOperation(t *testing.T) {
+	assert.Equal(t, usage.OpRead, usage.Operation("GET"))
+	assert.Equal(t, usage.OpRead, usage.Operation("HEAD"))
+	assert.Equal(t, usage.OpWrite, usage.Operation("PATCH"))
+	assert.Equal(t, usage.OpWrite, usage.Operation("DELETE"))
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+const fieldParent = "parent"
+
+// ChildrenLimit limits the number of direct children of a tenant. Tenants with a positive
+// max_children use it instead of the configured limit.
+type ChildrenLimit struct {
+	max int
+}
+
+// NewChildrenLimit returns a children limit, zero only limits the tenants which set their own.
+func NewChildrenLimit(max int) *ChildrenLimit {
+	return &ChildrenLimit{max: max}
+}
+
+// Hook returns an ent hook rejecting tenants created or moved under a parent which already has as
+// many children as it may have. The children are counted with the client of the mutation, creates
+// must run in a transaction for two of them not to both take the last place.
+func (l *ChildrenLimit) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if parentID, ok := m.ParentTenantID(); ok && parentID != gidx.NullPrefixedID {
+					if err := l.check(ctx, m, parentID); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+func (l *ChildrenLimit) check(ctx context.Context, m *generated.TenantMutation, parentID gidx.PrefixedID) error {
+	parent, err := m.Client().Tenant.Query().
+		Where(enttenant.ID(parentID)).
+		Select(enttenant.FieldMaxChildren).
+		Only(ctx)
+
+	switch {
+	case generated.IsNotFound(err):
+		// leave missing parents to the foreign key
+		return nil
+	case err != nil:
+		return err
+	}
+
+	limit := l.max
+	if parent.MaxChildren > 0 {
+		limit = parent.MaxChildren
+	}
+
+	if limit == 0 {
+		return nil
+	}
+
+	adding := 1
+
+	if !m.Op().Is(ent.OpCreate) {
+		// tenants already under the parent don't take another place
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		moving, err := m.Client().Tenant.Query().
+			Where(enttenant.IDIn(ids...), enttenant.Or(enttenant.ParentTenantIDNEQ(parentID), enttenant.ParentTenantIDIsNil())).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		adding = moving
+	}
+
+	if adding == 0 {
+		return nil
+	}
+
+	count, err := m.Client().Tenant.Query().Where(enttenant.ParentTenantID(parentID)).Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count+adding > limit {
+		return &Error{
+			Field:   fieldParent,
+			Code:    CodeChildrenLimitExceeded,
+			Message: fmt.Sprintf("%s already has the maximum of %d children", parentID, limit),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func requireCode(t *testing.T, code string, err error) {
+	t.Helper()
+
+	var verr *validation.Error
+
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, code, verr.Code)
+}
+
+func TestChildrenLimit(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(validation.NewChildrenLimit(2).Hook())
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+	first := client.Tenant.Create().SetName("first").SetParent(parent).SaveX(ctx)
+	client.Tenant.Create().SetName("second").SetParent(parent).SaveX(ctx)
+
+	_, err := client.Tenant.Create().SetName("third").SetParent(parent).Save(ctx)
+	requireCode(t, validation.CodeChildrenLimitExceeded, err)
+
+	// moving a tenant under the full parent counts the same
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+
+	err = client.Tenant.UpdateOne(other).SetParent(parent).Exec(ctx)
+	requireCode(t, validation.CodeChildrenLimitExceeded, err)
+
+	// children staying under the parent don't take another place
+	require.NoError(t, client.Tenant.UpdateOne(first).SetParent(parent).SetDescription("still here").Exec(ctx))
+
+	// the override of the parent replaces the configured limit, both up and down
+	client.Tenant.UpdateOne(parent).SetMaxChildren(3).ExecX(ctx)
+	client.Tenant.Create().SetName("third").SetParent(parent).SaveX(ctx)
+
+	client.Tenant.UpdateOne(other).SetMaxChildren(1).ExecX(ctx)
+	client.Tenant.Create().SetName("only").SetParent(other).SaveX(ctx)
+
+	_, err = client.Tenant.Create().SetName("extra").SetParent(other).Save(ctx)
+	requireCode(t, validation.CodeChildrenLimitExceeded, err)
+
+	// zero only limits the tenants with an override
+	unlimited := enttest.Open(t, "sqlite3", "file:"+t.Name()+"-unlimited?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { unlimited.Close() })
+
+	unlimited.Tenant.Use(validation.NewChildrenLimit(0).Hook())
+
+	root := unlimited.Tenant.Create().SetName("root").SaveX(ctx)
+
+	for i := 0; i < 3; i++ {
+		unlimited.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	}
+}
+
+func TestChildrenLimitConcurrentCreates(t *testing.T) {
+	ctx := context.Background()
+
+	// immediate transactions take the sqlite write lock when they begin, so the count and the
+	// insert of one create aren't interleaved with another's. On cockroachdb the creates overlap,
+	// and those aborted by a serialization failure are run again by the apis with crdb.Retry.
+	dsn := "file:" + filepath.Join(t.TempDir(), "tenants.db") + "?_fk=1&_txlock=immediate&_busy_timeout=5000"
+
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(validation.NewChildrenLimit(1).Hook())
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+
+	const creates = 5
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, creates)
+	)
+
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			errs[i] = createInTx(ctx, client, parent.ID)
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	created := 0
+
+	for _, err := range errs {
+		if err == nil {
+			created++
+
+			continue
+		}
+
+		requireCode(t, validation.CodeChildrenLimitExceeded, err)
+	}
+
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, parent.QueryChildren().CountX(ctx))
+}
+
+// createInTx creates a child the way the api does, counting and inserting in one transaction.
+func createInTx(ctx context.Context, client *ent.Client, parentID gidx.PrefixedID) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Tenant.Create().SetName("child").SetParentTenantID(parentID).Save(ctx); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
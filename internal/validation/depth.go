@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+)
+
+// maxWalkDepth bounds the walks up and down the hierarchy, keeping a cycle from looping forever.
+const maxWalkDepth = 1000
+
+// Depth returns the level of the tenant in the hierarchy, roots being at depth 1. The ancestors
+// are loaded one level at a time. A missing ancestor ends the walk as if it were a root.
+func Depth(ctx context.Context, client *generated.Client, id gidx.PrefixedID) (int, error) {
+	depth := 0
+
+	for depth < maxWalkDepth {
+		depth++
+
+		parent, err := client.Tenant.Query().
+			Where(enttenant.ID(id)).
+			Select(enttenant.FieldParentTenantID).
+			Only(ctx)
+
+		switch {
+		case generated.IsNotFound(err):
+			return depth - 1, nil
+		case err != nil:
+			return 0, err
+		case parent.ParentTenantID == gidx.NullPrefixedID:
+			return depth, nil
+		}
+
+		id = parent.ParentTenantID
+	}
+
+	return depth, nil
+}
+
+// DepthLimit limits the depth of the hierarchy, so tenants are at most max levels deep.
+type DepthLimit struct {
+	max int
+}
+
+// NewDepthLimit returns a depth limit, zero is unlimited.
+func NewDepthLimit(max int) *DepthLimit {
+	return &DepthLimit{max: max}
+}
+
+// Hook returns an ent hook rejecting tenants created or moved under a parent so deep that they,
+// or the deepest of the descendants moved along, would be deeper than the limit.
+func (l *DepthLimit) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				if parentID, ok := m.ParentTenantID(); ok && parentID != gidx.NullPrefixedID && l.max > 0 {
+					if err := l.check(ctx, m, parentID); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+func (l *DepthLimit) check(ctx context.Context, m *generated.TenantMutation, parentID gidx.PrefixedID) error {
+	depth, err := Depth(ctx, m.Client(), parentID)
+	if err != nil {
+		return err
+	}
+
+	// a missing parent is left to the foreign key
+	if depth == 0 {
+		return nil
+	}
+
+	deepest := depth + 1
+
+	if !m.Op().Is(ent.OpCreate) {
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		height, err := subtreeHeight(ctx, m.Client(), ids, l.max-deepest)
+		if err != nil {
+			return err
+		}
+
+		deepest += height
+	}
+
+	if deepest > l.max {
+		return &Error{
+			Field:   fieldParent,
+			Code:    CodeDepthLimitExceeded,
+			Message: fmt.Sprintf("tenants may be at most %d levels deep, %s is at level %d", l.max, parentID, depth),
+		}
+	}
+
+	return nil
+}
+
+// subtreeHeight returns the number of levels below the tenants, loading one level at a time. It
+// stops once it is past limit, which is enough to tell the limit is exceeded.
+func subtreeHeight(ctx context.Context, client *generated.Client, ids []gidx.PrefixedID, limit int) (int, error) {
+	height := 0
+
+	for height <= limit && height < maxWalkDepth {
+		children, err := client.Tenant.Query().
+			Where(enttenant.ParentTenantIDIn(ids...)).
+			IDs(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(children) == 0 {
+			break
+		}
+
+		height++
+		ids = children
+	}
+
+	return height, nil
+}
@@ -0,0 +1,65 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestDepthLimit(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(validation.NewDepthLimit(3).Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	child := client.Tenant.Create().SetName("child").SetParent(root).SaveX(ctx)
+	grandchild := client.Tenant.Create().SetName("grandchild").SetParent(child).SaveX(ctx)
+
+	for _, tt := range []struct {
+		id    gidx.PrefixedID
+		depth int
+	}{{root.ID, 1}, {child.ID, 2}, {grandchild.ID, 3}, {gidx.MustNewID("tnntten"), 0}} {
+		depth, err := validation.Depth(ctx, client, tt.id)
+		require.NoError(t, err)
+		assert.Equal(t, tt.depth, depth)
+	}
+
+	_, err := client.Tenant.Create().SetName("too deep").SetParent(grandchild).Save(ctx)
+	requireCode(t, validation.CodeDepthLimitExceeded, err)
+
+	// moving a subtree counts its descendants, the child would put the grandchild at level 4
+	other := client.Tenant.Create().SetName("other").SaveX(ctx)
+	otherChild := client.Tenant.Create().SetName("other child").SetParent(other).SaveX(ctx)
+
+	err = client.Tenant.UpdateOne(child).SetParent(otherChild).Exec(ctx)
+	requireCode(t, validation.CodeDepthLimitExceeded, err)
+
+	// a leaf fits at the deepest level
+	leaf := client.Tenant.Create().SetName("leaf").SaveX(ctx)
+	require.NoError(t, client.Tenant.UpdateOne(leaf).SetParent(otherChild).Exec(ctx))
+
+	// without a limit any depth is allowed
+	unlimited := enttest.Open(t, "sqlite3", "file:"+t.Name()+"-unlimited?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { unlimited.Close() })
+
+	unlimited.Tenant.Use(validation.NewDepthLimit(0).Hook())
+
+	parent := unlimited.Tenant.Create().SetName("level 1").SaveX(ctx)
+
+	for i := 0; i < 5; i++ {
+		parent = unlimited.Tenant.Create().SetName("deeper").SetParent(parent).SaveX(ctx)
+	}
+
+	depth, err := validation.Depth(ctx, unlimited, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6, depth)
+}
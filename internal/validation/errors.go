@@ -0,0 +1,163 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+// Codes identifying why a value was rejected.
+const (
+	CodeInvalidName        = "invalid_name"
+	CodeNameTooLong        = "name_too_long"
+	CodeReservedName       = "reserved_name"
+	CodeNameTaken          = "name_taken"
+	CodeNameTakenByDeleted = "name_taken_by_deleted"
+
+	CodeChildrenLimitExceeded = "children_limit_exceeded"
+	CodeDepthLimitExceeded    = "depth_limit_exceeded"
+
+	CodeSettingsTooLarge = "settings_too_large"
+	CodeSettingsTooDeep  = "settings_too_deep"
+
+	CodeInvalidLabel  = "invalid_label"
+	CodeTooManyLabels = "too_many_labels"
+
+	CodeInvalidContactEmail     = "invalid_contact_email"
+	CodeInvalidBillingReference = "invalid_billing_reference"
+	CodeBillingReferenceTooLong = "billing_reference_too_long"
+
+	CodeDescriptionTooLong = "description_too_long"
+
+	CodeParentDeleted  = "parent_deleted"
+	CodeParentNotFound = "parent_not_found"
+	CodeParentArchived = "parent_archived"
+	CodeParentCycle    = "parent_cycle"
+
+	CodeExternalIDTaken   = "external_id_taken"
+	CodeExternalIDTooLong = "external_id_too_long"
+
+	CodeIDTaken = "id_taken"
+
+	CodeRequired     = "required"
+	CodeInvalidID    = "invalid_id"
+	CodeInvalidType  = "invalid_type"
+	CodeInvalidValue = "invalid_value"
+	CodeUnknownField = "unknown_field"
+	CodeTooMany      = "too_many"
+	CodeMalformed    = "malformed"
+
+	CodeUnindexedQuery = "unindexed_query"
+
+	CodeInvalidCursor  = "invalid_cursor"
+	CodeOffsetTooLarge = "offset_too_large"
+)
+
+// Error is returned when a field fails validation. The code lets clients handle specific failures.
+type Error struct {
+	Field   string
+	Code    string
+	Message string
+	// Limit is the limit the value exceeds, for errors of values which are too long, zero for
+	// other errors.
+	Limit int
+	// Err is the underlying error when the failure is also reported by another package.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is the apierrors class of the error, which is ErrInvalidArgument
+// except for taken names, ids and external ids, missing parents and parents which would make a
+// cycle.
+func (e *Error) Is(target error) bool {
+	switch e.Code {
+	case CodeNameTaken, CodeNameTakenByDeleted:
+		return target == apierrors.ErrNameConflict
+	case CodeParentNotFound:
+		return target == apierrors.ErrParentNotFound
+	case CodeExternalIDTaken, CodeIDTaken, CodeParentCycle:
+		return target == apierrors.ErrConflict
+	default:
+		return target == apierrors.ErrInvalidArgument
+	}
+}
+
+// Errors accumulates the validation errors of a request, so every problem is reported at once
+// rather than only the first.
+type Errors []*Error
+
+// Add records a validation error of the field.
+func (e *Errors) Add(field, code, message string) {
+	*e = append(*e, &Error{Field: field, Code: code, Message: message})
+}
+
+// add records the error of the field, which is a validation error unless a rule failed
+// unexpectedly, such as for a settings document json can't represent.
+func (e *Errors) add(field string, err error) {
+	var verr *Error
+	if !errors.As(err, &verr) {
+		verr = &Error{Field: field, Code: CodeMalformed, Message: err.Error(), Err: err}
+	}
+
+	*e = append(*e, verr)
+}
+
+// has reports whether an error of the field was recorded.
+func (e Errors) has(field string) bool {
+	for _, err := range e {
+		if err.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the errors as an error, nil when there are none.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the accumulated errors, so errors.As finds the first of them.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// IsValidationError reports whether the error, or one it wraps, is a validation error.
+func IsValidationError(err error) bool {
+	var verr *Error
+
+	return errors.As(err, &verr)
+}
@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"entgo.io/ent"
+)
+
+const (
+	// DefaultMaxLabels is the default maximum number of labels a tenant may set itself.
+	DefaultMaxLabels = 64
+
+	// labelKeyMaxLength is the maximum length of a label key in bytes.
+	labelKeyMaxLength = 63
+	// labelValueMaxLength is the maximum length of a label value in characters.
+	labelValueMaxLength = 255
+)
+
+const fieldLabels = "labels"
+
+// labelKey matches the label keys: lowercase letters, digits, dots, dashes, underscores and
+// slashes, starting and ending with a letter or digit, such as compliance or example.com/tier.
+var labelKey = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]*[a-z0-9])?$`)
+
+// ValidLabelKey reports whether the key is one tenants may set a label under.
+func ValidLabelKey(key string) bool {
+	return len(key) <= labelKeyMaxLength && labelKey.MatchString(key)
+}
+
+// LabelsOption configures a LabelsValidator.
+type LabelsOption func(*LabelsValidator)
+
+// WithMaxLabels sets the maximum number of labels a tenant may set itself, those it inherits
+// aren't counted.
+func WithMaxLabels(n int) LabelsOption {
+	return func(v *LabelsValidator) {
+		if n > 0 {
+			v.maxLabels = n
+		}
+	}
+}
+
+// LabelsValidator validates the labels of tenants.
+type LabelsValidator struct {
+	maxLabels int
+}
+
+// NewLabelsValidator returns a labels validator.
+func NewLabelsValidator(opts ...LabelsOption) *LabelsValidator {
+	v := &LabelsValidator{maxLabels: DefaultMaxLabels}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate checks the number of labels and each of their keys and values, returning the Errors of
+// every label rejected under labels.<key>.
+func (v *LabelsValidator) Validate(labels map[string]string) error {
+	var errs Errors
+
+	v.validate(labels, &errs)
+
+	return errs.Err()
+}
+
+func (v *LabelsValidator) validate(labels map[string]string, errs *Errors) {
+	if len(labels) > v.maxLabels {
+		errs.Add(fieldLabels, CodeTooManyLabels, fmt.Sprintf("at most %d labels may be set, got %d", v.maxLabels, len(labels)))
+	}
+
+	keys := make([]string, 0, len(labels))
+
+	for k := range labels {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		field := fieldLabels + "." + k
+
+		switch {
+		case len(k) > labelKeyMaxLength:
+			errs.Add(field, CodeInvalidLabel, fmt.Sprintf("keys must be at most %d bytes, got %d", labelKeyMaxLength, len(k)))
+		case !labelKey.MatchString(k):
+			errs.Add(field, CodeInvalidLabel, "keys must be lowercase letters, digits, '.', '-', '_' or '/', starting and ending with a letter or digit")
+		case !utf8.ValidString(labels[k]):
+			errs.Add(field, CodeInvalidLabel, "values must be valid utf-8")
+		case utf8.RuneCountInString(labels[k]) > labelValueMaxLength:
+			errs.Add(field, CodeInvalidLabel, fmt.Sprintf("values must be at most %d characters, got %d", labelValueMaxLength, utf8.RuneCountInString(labels[k])))
+		}
+	}
+}
+
+// Rules returns the rule validating the labels.
+func (v *LabelsValidator) Rules() []Rule {
+	return []Rule{
+		rule{name: "labels", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			if t.Labels == nil || errs.has(fieldLabels) {
+				return
+			}
+
+			v.validate(t.Labels, errs)
+		}},
+	}
+}
+
+// Hook returns an ent hook applying the rules of the validator, see Pipeline.Hook.
+func (v *LabelsValidator) Hook() ent.Hook {
+	return NewPipeline(v.Rules()...).Hook()
+}
@@ -0,0 +1,55 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestLabelsValidatorValidate(t *testing.T) {
+	v := validation.NewLabelsValidator(validation.WithMaxLabels(2))
+
+	testCases := []struct {
+		name   string
+		labels map[string]string
+		fields []string
+		code   string
+	}{
+		{name: "empty", labels: map[string]string{}},
+		{name: "valid", labels: map[string]string{"compliance": "pci", "example.com/tier": ""}},
+		{name: "too many", labels: map[string]string{"a": "1", "b": "2", "c": "3"}, fields: []string{"labels"}, code: validation.CodeTooManyLabels},
+		{name: "uppercase", labels: map[string]string{"Env": "prod"}, fields: []string{"labels.Env"}, code: validation.CodeInvalidLabel},
+		{name: "edges", labels: map[string]string{"-env": "prod", "env/": "prod"}, fields: []string{"labels.-env", "labels.env/"}, code: validation.CodeInvalidLabel},
+		{name: "long key", labels: map[string]string{strings.Repeat("k", 64): "v"}, fields: []string{"labels." + strings.Repeat("k", 64)}, code: validation.CodeInvalidLabel},
+		{name: "long value", labels: map[string]string{"notes": strings.Repeat("é", 256)}, fields: []string{"labels.notes"}, code: validation.CodeInvalidLabel},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.Validate(tc.labels)
+
+			if tc.code == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var errs validation.Errors
+
+			require.ErrorAs(t, err, &errs)
+
+			fields := make([]string, len(errs))
+
+			for i, e := range errs {
+				fields[i] = e.Field
+				assert.Equal(t, tc.code, e.Code)
+			}
+
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"entgo.io/ent"
+)
+
+const (
+	// DefaultBillingReferenceMaxLength is the default maximum length of a billing reference in characters.
+	DefaultBillingReferenceMaxLength = 64
+	// DefaultDescriptionMaxLength is the default maximum length of a description in characters.
+	DefaultDescriptionMaxLength = 1024
+
+	// contactEmailMaxLength is the longest address which can be used in the path of an smtp message.
+	contactEmailMaxLength = 254
+)
+
+const (
+	fieldContactEmail     = "contactEmail"
+	fieldBillingReference = "billingReference"
+	fieldDescription      = "description"
+)
+
+// lineEndings replaces the line endings of other platforms with newlines.
+var lineEndings = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// MetadataOption configures a MetadataValidator.
+type MetadataOption func(*MetadataValidator)
+
+// WithBillingReferenceMaxLength sets the maximum length of a billing reference in characters.
+func WithBillingReferenceMaxLength(length int) MetadataOption {
+	return func(v *MetadataValidator) {
+		if length > 0 {
+			v.billingReferenceMaxLength = length
+		}
+	}
+}
+
+// WithDescriptionMaxLength sets the maximum length of a description in characters.
+func WithDescriptionMaxLength(length int) MetadataOption {
+	return func(v *MetadataValidator) {
+		if length > 0 {
+			v.descriptionMaxLength = length
+		}
+	}
+}
+
+// MetadataValidator validates the description and the contact and billing metadata of tenants.
+type MetadataValidator struct {
+	billingReferenceMaxLength int
+	descriptionMaxLength      int
+}
+
+// NewMetadataValidator returns a metadata validator.
+func NewMetadataValidator(opts ...MetadataOption) *MetadataValidator {
+	v := &MetadataValidator{
+		billingReferenceMaxLength: DefaultBillingReferenceMaxLength,
+		descriptionMaxLength:      DefaultDescriptionMaxLength,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// NormalizeContactEmail trims leading and trailing whitespace from the email and validates the
+// result is a bare address, without a display name or angle brackets.
+func (v *MetadataValidator) NormalizeContactEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+
+	if len(email) > contactEmailMaxLength {
+		return "", metadataError(fieldContactEmail, CodeInvalidContactEmail, fmt.Sprintf("must be at most %d bytes, got %d", contactEmailMaxLength, len(email)))
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Name != "" || addr.Address != email {
+		return "", metadataError(fieldContactEmail, CodeInvalidContactEmail, fmt.Sprintf("%q is not an email address", email))
+	}
+
+	return email, nil
+}
+
+// NormalizeBillingReference trims leading and trailing whitespace from the reference and validates
+// the result.
+func (v *MetadataValidator) NormalizeBillingReference(reference string) (string, error) {
+	if !utf8.ValidString(reference) {
+		return "", metadataError(fieldBillingReference, CodeInvalidBillingReference, "must be valid UTF-8")
+	}
+
+	reference = strings.TrimSpace(reference)
+
+	if reference == "" {
+		return "", metadataError(fieldBillingReference, CodeInvalidBillingReference, "must not be empty")
+	}
+
+	if n := utf8.RuneCountInString(reference); n > v.billingReferenceMaxLength {
+		return "", tooLongError(fieldBillingReference, CodeBillingReferenceTooLong, v.billingReferenceMaxLength, n)
+	}
+
+	for _, r := range reference {
+		if disallowed(r) {
+			return "", metadataError(fieldBillingReference, CodeInvalidBillingReference, fmt.Sprintf("must not contain the character %U", r))
+		}
+	}
+
+	return reference, nil
+}
+
+// NormalizeDescription strips invalid UTF-8 from the description, as left by text pasted in mixed
+// encodings, and turns CRLF and CR line endings into LF. The result must be at most the maximum
+// length, counted in characters.
+func (v *MetadataValidator) NormalizeDescription(description string) (string, error) {
+	description = lineEndings.Replace(strings.ToValidUTF8(description, ""))
+
+	if n := utf8.RuneCountInString(description); n > v.descriptionMaxLength {
+		return "", tooLongError(fieldDescription, CodeDescriptionTooLong, v.descriptionMaxLength, n)
+	}
+
+	return description, nil
+}
+
+// Rules returns the rules normalizing the description, contact email and billing reference.
+func (v *MetadataValidator) Rules() []Rule {
+	return []Rule{
+		rule{name: "description", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			normalizeField(errs, fieldDescription, t.Description, v.NormalizeDescription)
+		}},
+		rule{name: "contact_email", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			normalizeField(errs, fieldContactEmail, t.ContactEmail, v.NormalizeContactEmail)
+		}},
+		rule{name: "billing_reference", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			normalizeField(errs, fieldBillingReference, t.BillingReference, v.NormalizeBillingReference)
+		}},
+	}
+}
+
+// Hook returns an ent hook applying the rules of the validator, see Pipeline.Hook.
+func (v *MetadataValidator) Hook() ent.Hook {
+	return NewPipeline(v.Rules()...).Hook()
+}
+
+func metadataError(field, code, message string) error {
+	return &Error{Field: field, Code: code, Message: message}
+}
+
+func tooLongError(field, code string, limit, length int) error {
+	return &Error{
+		Field:   field,
+		Code:    code,
+		Message: fmt.Sprintf("must be at most %d characters, got %d", limit, length),
+		Limit:   limit,
+	}
+}
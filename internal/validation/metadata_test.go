@@ -0,0 +1,155 @@
+package validation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestMetadataValidatorContactEmail(t *testing.T) {
+	v := validation.NewMetadataValidator()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		invalid  bool
+	}{
+		{name: "plain", input: "ops@example.com", expected: "ops@example.com"},
+		{name: "trimmed", input: "  ops@example.com\n", expected: "ops@example.com"},
+		{name: "plus addressing", input: "ops+tenants@example.com", expected: "ops+tenants@example.com"},
+		{name: "subdomain", input: "billing@eu.example.co.uk", expected: "billing@eu.example.co.uk"},
+		{name: "empty", input: "", invalid: true},
+		{name: "only whitespace", input: "   ", invalid: true},
+		{name: "missing at", input: "ops.example.com", invalid: true},
+		{name: "missing local part", input: "@example.com", invalid: true},
+		{name: "missing domain", input: "ops@", invalid: true},
+		{name: "two ats", input: "ops@@example.com", invalid: true},
+		{name: "inner space", input: "ops team@example.com", invalid: true},
+		{name: "display name", input: "Ops <ops@example.com>", invalid: true},
+		{name: "angle brackets", input: "<ops@example.com>", invalid: true},
+		{name: "list", input: "ops@example.com, dev@example.com", invalid: true},
+		{name: "newline injection", input: "ops@example.com\nBcc: x@example.com", invalid: true},
+		{name: "trailing dot", input: "ops.@example.com", invalid: true},
+		{name: "too long", input: strings.Repeat("a", 250) + "@example.com", invalid: true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.NormalizeContactEmail(tt.input)
+
+			if tt.invalid {
+				var verr *validation.Error
+
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, validation.CodeInvalidContactEmail, verr.Code)
+				assert.Equal(t, "contactEmail", verr.Field)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMetadataValidatorBillingReference(t *testing.T) {
+	v := validation.NewMetadataValidator(validation.WithBillingReferenceMaxLength(8))
+
+	got, err := v.NormalizeBillingReference(" ACCT-42 ")
+	require.NoError(t, err)
+	assert.Equal(t, "ACCT-42", got)
+
+	_, err = v.NormalizeBillingReference("ACCT-4242")
+	requireCode(t, validation.CodeBillingReferenceTooLong, err)
+
+	_, err = v.NormalizeBillingReference("  ")
+	requireCode(t, validation.CodeInvalidBillingReference, err)
+
+	_, err = v.NormalizeBillingReference("ACCT\x00")
+	requireCode(t, validation.CodeInvalidBillingReference, err)
+
+	_, err = validation.NewMetadataValidator().NormalizeBillingReference(strings.Repeat("a", validation.DefaultBillingReferenceMaxLength))
+	assert.NoError(t, err)
+}
+
+func TestMetadataValidatorDescription(t *testing.T) {
+	v := validation.NewMetadataValidator(validation.WithDescriptionMaxLength(8))
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		tooLong  bool
+	}{
+		{name: "plain", input: "staging", expected: "staging"},
+		{name: "empty", input: "", expected: ""},
+		{name: "multi-byte at the limit", input: "日本語テキスト🎉", expected: "日本語テキスト🎉"},
+		{name: "multi-byte over the limit", input: "日本語テキスト🎉!", tooLong: true},
+		{name: "invalid utf-8 stripped", input: "caf\xe9 bar", expected: "caf bar"},
+		{name: "invalid utf-8 stripped to the limit", input: "abcd\xff\xfeefgh", expected: "abcdefgh"},
+		{name: "crlf", input: "a\r\nb\r\nc", expected: "a\nb\nc"},
+		{name: "cr", input: "a\rb", expected: "a\nb"},
+		{name: "crlf counted once", input: "1234567\r\n", expected: "1234567\n"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.NormalizeDescription(tt.input)
+
+			if tt.tooLong {
+				var verr *validation.Error
+
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, validation.CodeDescriptionTooLong, verr.Code)
+				assert.Equal(t, "description", verr.Field)
+				assert.Equal(t, 8, verr.Limit)
+				assert.Contains(t, verr.Message, "at most 8 characters")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err := validation.NewMetadataValidator().NormalizeDescription(strings.Repeat("é", validation.DefaultDescriptionMaxLength))
+	assert.NoError(t, err)
+
+	_, err = validation.NewMetadataValidator().NormalizeDescription(strings.Repeat("é", validation.DefaultDescriptionMaxLength+1))
+	requireCode(t, validation.CodeDescriptionTooLong, err)
+}
+
+func TestMetadataValidatorHook(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(validation.NewMetadataValidator().Hook())
+
+	tnt := client.Tenant.Create().SetName("acme").SetContactEmail(" ops@example.com ").SetBillingReference("ACCT-42").SaveX(ctx)
+	assert.Equal(t, "ops@example.com", tnt.ContactEmail)
+	assert.Equal(t, "ACCT-42", tnt.BillingReference)
+
+	_, err := client.Tenant.UpdateOne(tnt).SetContactEmail("not an email").Save(ctx)
+	requireCode(t, validation.CodeInvalidContactEmail, err)
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDescription("line\r\nnext\xff").SaveX(ctx)
+	assert.Equal(t, "line\nnext", tnt.Description)
+
+	_, err = client.Tenant.UpdateOne(tnt).SetDescription(strings.Repeat("x", validation.DefaultDescriptionMaxLength+1)).Save(ctx)
+	requireCode(t, validation.CodeDescriptionTooLong, err)
+
+	tnt = client.Tenant.UpdateOne(tnt).ClearContactEmail().ClearBillingReference().SaveX(ctx)
+	assert.Empty(t, tnt.ContactEmail)
+	assert.Empty(t, tnt.BillingReference)
+}
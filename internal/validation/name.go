@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"entgo.io/ent"
+)
+
+// DefaultNameMaxLength is the default maximum length of a tenant name in characters.
+const DefaultNameMaxLength = 255
+
+const (
+	fieldName        = "name"
+	fieldDisplayName = "displayName"
+)
+
+// NameOption configures a NameValidator.
+type NameOption func(*NameValidator)
+
+// WithNameMaxLength sets the maximum length of a name in characters.
+func WithNameMaxLength(length int) NameOption {
+	return func(v *NameValidator) {
+		if length > 0 {
+			v.maxLength = length
+		}
+	}
+}
+
+// WithReservedNames sets the names which can't be used. Names are compared case insensitively and
+// may contain glob patterns as supported by path.Match.
+func WithReservedNames(names ...string) NameOption {
+	return func(v *NameValidator) {
+		for _, name := range names {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				v.reserved = append(v.reserved, name)
+			}
+		}
+	}
+}
+
+// NameValidator validates tenant names.
+type NameValidator struct {
+	maxLength int
+	reserved  []string
+}
+
+// NewNameValidator returns a name validator.
+func NewNameValidator(opts ...NameOption) *NameValidator {
+	v := &NameValidator{
+		maxLength: DefaultNameMaxLength,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Normalize trims leading and trailing whitespace from the name and validates the result.
+func (v *NameValidator) Normalize(name string) (string, error) {
+	name, err := v.normalize(fieldName, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := v.checkReserved(name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// NormalizeDisplayName normalizes a display name the same as a name, except that reserved names
+// are allowed as they don't identify the tenant.
+func (v *NameValidator) NormalizeDisplayName(name string) (string, error) {
+	return v.normalize(fieldDisplayName, name)
+}
+
+func (v *NameValidator) normalize(field, name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", nameError(field, CodeInvalidName, "must be valid UTF-8")
+	}
+
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", nameError(field, CodeInvalidName, "must not be empty")
+	}
+
+	if n := utf8.RuneCountInString(name); n > v.maxLength {
+		return "", nameError(field, CodeNameTooLong, fmt.Sprintf("must be at most %d characters, got %d", v.maxLength, n))
+	}
+
+	for _, r := range name {
+		if disallowed(r) {
+			return "", nameError(field, CodeInvalidName, fmt.Sprintf("must not contain the character %U", r))
+		}
+	}
+
+	return name, nil
+}
+
+func (v *NameValidator) checkReserved(name string) error {
+	lower := strings.ToLower(name)
+
+	for _, pattern := range v.reserved {
+		if ok, _ := path.Match(pattern, lower); ok {
+			return nameError(fieldName, CodeReservedName, fmt.Sprintf("%q is reserved", name))
+		}
+	}
+
+	return nil
+}
+
+// Rules returns the rules normalizing the name and display name, the reserved names being checked
+// by a rule of their own when there are any.
+func (v *NameValidator) Rules() []Rule {
+	rules := []Rule{
+		rule{name: "name", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			normalizeField(errs, fieldName, t.Name, func(name string) (string, error) { return v.normalize(fieldName, name) })
+		}},
+	}
+
+	if len(v.reserved) != 0 {
+		rules = append(rules, rule{name: "reserved_names", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			normalizeField(errs, fieldName, t.Name, func(name string) (string, error) { return name, v.checkReserved(name) })
+		}})
+	}
+
+	return append(rules, rule{name: "display_name", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+		normalizeField(errs, fieldDisplayName, t.DisplayName, v.NormalizeDisplayName)
+	}})
+}
+
+// Hook returns an ent hook applying the rules of the validator, see Pipeline.Hook.
+func (v *NameValidator) Hook() ent.Hook {
+	return NewPipeline(v.Rules()...).Hook()
+}
+
+// disallowed reports whether the character is a control, invisible formatting, line or paragraph
+// separator, private use or unassigned character. Zero width characters and bidirectional
+// overrides are formatting characters.
+func disallowed(r rune) bool {
+	return r == utf8.RuneError ||
+		unicode.IsControl(r) ||
+		unicode.In(r, unicode.Cf, unicode.Co, unicode.Zl, unicode.Zp) ||
+		!unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Zs)
+}
+
+func nameError(field, code, message string) error {
+	return &Error{Field: field, Code: code, Message: message}
+}
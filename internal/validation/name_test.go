@@ -0,0 +1,137 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"entgo.io/ent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestNameValidatorNormalize(t *testing.T) {
+	v := validation.NewNameValidator(
+		validation.WithNameMaxLength(10),
+		validation.WithReservedNames("api", "NULL", "system-*", " "),
+	)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		code     string
+	}{
+		{name: "plain", input: "acme", expected: "acme"},
+		{name: "trimmed", input: " \t acme corp\n", expected: "acme corp"},
+		{name: "trimmed unicode spaces", input: " \u3000acme\u00a0", expected: "acme"},
+		{name: "inner no-break space", input: "acme\u00a0co", expected: "acme\u00a0co"},
+		{name: "accents", input: "Zürich Ünï", expected: "Zürich Ünï"},
+		{name: "combining marks", input: "Zu\u0308rich", expected: "Zu\u0308rich"},
+		{name: "cjk", input: "株式会社", expected: "株式会社"},
+		{name: "emoji", input: "rocket 🚀", expected: "rocket 🚀"},
+		{name: "length counts characters not bytes", input: "ääääääääää", expected: "ääääääääää"},
+		{name: "too long", input: "abcdefghijk", code: validation.CodeNameTooLong},
+		{name: "too long after trimming is fine", input: "  abcdefghij  ", expected: "abcdefghij"},
+		{name: "empty", input: "", code: validation.CodeInvalidName},
+		{name: "only whitespace", input: "   ", code: validation.CodeInvalidName},
+		{name: "invalid utf-8", input: "acme\xff", code: validation.CodeInvalidName},
+		{name: "replacement character", input: "acme\ufffd", code: validation.CodeInvalidName},
+		{name: "null byte", input: "ac\x00me", code: validation.CodeInvalidName},
+		{name: "inner newline", input: "acme\ncorp", code: validation.CodeInvalidName},
+		{name: "escape", input: "acme\x1b[31m", code: validation.CodeInvalidName},
+		{name: "c1 control", input: "acme\u0085x", code: validation.CodeInvalidName},
+		{name: "zero width space", input: "ac\u200bme", code: validation.CodeInvalidName},
+		{name: "zero width joiner", input: "ac\u200dme", code: validation.CodeInvalidName},
+		{name: "byte order mark", input: "\ufeffacme", code: validation.CodeInvalidName},
+		{name: "right to left override", input: "acme\u202egpj", code: validation.CodeInvalidName},
+		{name: "line separator", input: "ac\u2028me", code: validation.CodeInvalidName},
+		{name: "private use", input: "acme\ue000", code: validation.CodeInvalidName},
+		{name: "unassigned", input: "acme\U000e0080", code: validation.CodeInvalidName},
+		{name: "reserved", input: "api", code: validation.CodeReservedName},
+		{name: "reserved case insensitive", input: "Null", code: validation.CodeReservedName},
+		{name: "reserved after trimming", input: "  API ", code: validation.CodeReservedName},
+		{name: "reserved glob", input: "system-eu", code: validation.CodeReservedName},
+		{name: "reserved glob is anchored", input: "my-system", expected: "my-system"},
+		{name: "reserved name as part of another", input: "apis", expected: "apis"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Normalize(tt.input)
+
+			if tt.code != "" {
+				var verr *validation.Error
+
+				require.True(t, errors.As(err, &verr), "expected a validation error, got %v", err)
+				assert.Equal(t, tt.code, verr.Code)
+				assert.Equal(t, "name", verr.Field)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNameValidatorDefaults(t *testing.T) {
+	v := validation.NewNameValidator()
+
+	_, err := v.Normalize(strings.Repeat("a", validation.DefaultNameMaxLength))
+	assert.NoError(t, err)
+
+	_, err = v.Normalize(strings.Repeat("a", validation.DefaultNameMaxLength+1))
+	assert.True(t, validation.IsValidationError(err))
+
+	_, err = v.Normalize("api")
+	assert.NoError(t, err, "nothing is reserved by default")
+}
+
+func TestNameValidatorHookDisplayName(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	// records the fields of each mutation as the event hooks would see them
+	var fields []string
+
+	client.Tenant.Use(validation.NewNameValidator().Hook(), func(next ent.Mutator) ent.Mutator {
+		return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+			fields = m.Fields()
+
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	tnt := client.Tenant.Create().SetName(" acme ").SaveX(ctx)
+	assert.Equal(t, "acme", tnt.DisplayName, "the display name defaults to the name")
+
+	tnt = client.Tenant.UpdateOne(tnt).SetDisplayName(" Acme Corp ").SaveX(ctx)
+	assert.Equal(t, "Acme Corp", tnt.DisplayName)
+	assert.Equal(t, "acme", tnt.Name)
+	assert.Contains(t, fields, "name", "updates carry both names")
+
+	tnt = client.Tenant.UpdateOne(tnt).SetName("acme-inc").SaveX(ctx)
+	assert.Equal(t, "Acme Corp", tnt.DisplayName, "renames leave the display name")
+	assert.Contains(t, fields, "display_name", "updates carry both names")
+
+	tnt = client.Tenant.UpdateOne(tnt).ClearDisplayName().SaveX(ctx)
+	assert.Equal(t, "acme-inc", tnt.DisplayName, "clearing resets the display name to the name")
+
+	_, err := client.Tenant.UpdateOne(tnt).SetDisplayName("  ").Save(ctx)
+
+	var verr *validation.Error
+
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "displayName", verr.Field)
+	assert.Equal(t, validation.CodeInvalidName, verr.Code)
+}
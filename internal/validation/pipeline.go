@@ -0,0 +1,291 @@
+package validation
+
+import (
+	"context"
+	"strings"
+
+	"entgo.io/ent"
+
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+)
+
+// Tenant holds the fields of a created or updated tenant which are validated, normalized in place.
+// Nil fields aren't part of the mutation and are left as they are.
+type Tenant struct {
+	Name             *string
+	DisplayName      *string
+	Description      *string
+	ContactEmail     *string
+	BillingReference *string
+	Settings         map[string]any
+	Labels           map[string]string
+}
+
+// Rule validates and normalizes fields of a created or updated tenant. It records an error for
+// each field it rejects, leaving its value as it was, and skips the fields which aren't set or
+// were rejected by an earlier rule, so each field is reported once.
+type Rule interface {
+	// Name identifies the rule, such as in the startup logs.
+	Name() string
+	// Apply checks the fields of the tenant the rule covers. The context carries the request, for
+	// rules depending on who makes it.
+	Apply(ctx context.Context, t *Tenant, errs *Errors)
+}
+
+type rule struct {
+	name  string
+	apply func(ctx context.Context, t *Tenant, errs *Errors)
+}
+
+// Name implements Rule.
+func (r rule) Name() string {
+	return r.name
+}
+
+// Apply implements Rule.
+func (r rule) Apply(ctx context.Context, t *Tenant, errs *Errors) {
+	r.apply(ctx, t, errs)
+}
+
+// Pipeline validates and normalizes tenants with a list of rules, in the same way on every
+// mutation path: the REST and GraphQL apis check their requests with it before writing, and its
+// hook checks the mutations of anything else writing tenants, such as imports.
+type Pipeline struct {
+	rules []Rule
+}
+
+// NewPipeline returns a pipeline applying the rules in order.
+func NewPipeline(rules ...Rule) *Pipeline {
+	return &Pipeline{rules: rules}
+}
+
+// DefaultPipeline returns a pipeline with the rules of the name, metadata, settings and labels
+// validators with their defaults.
+func DefaultPipeline() *Pipeline {
+	var rules []Rule
+
+	rules = append(rules, NewNameValidator().Rules()...)
+	rules = append(rules, NewMetadataValidator().Rules()...)
+	rules = append(rules, NewSettingsValidator().Rules()...)
+	rules = append(rules, NewLabelsValidator().Rules()...)
+
+	return NewPipeline(rules...)
+}
+
+// Rules returns the names of the rules of the pipeline, in the order they're applied.
+func (p *Pipeline) Rules() []string {
+	names := make([]string, len(p.rules))
+
+	for i, r := range p.rules {
+		names[i] = r.Name()
+	}
+
+	return names
+}
+
+// ValidateNewTenant validates and normalizes the fields of a tenant being created, returning the
+// Errors of every field rejected. A name is required.
+func (p *Pipeline) ValidateNewTenant(ctx context.Context, t *Tenant) error {
+	var errs Errors
+
+	if t.Name == nil || strings.TrimSpace(*t.Name) == "" {
+		errs.Add(fieldName, CodeRequired, "a name is required")
+	}
+
+	p.apply(ctx, t, &errs)
+
+	return errs.Err()
+}
+
+// ValidateUpdate validates and normalizes the fields set by an update, returning the Errors of
+// every field rejected.
+func (p *Pipeline) ValidateUpdate(ctx context.Context, t *Tenant) error {
+	var errs Errors
+
+	p.apply(ctx, t, &errs)
+
+	return errs.Err()
+}
+
+func (p *Pipeline) apply(ctx context.Context, t *Tenant, errs *Errors) {
+	for _, r := range p.rules {
+		r.Apply(ctx, t, errs)
+	}
+}
+
+// Hook returns an ent hook validating and normalizing the fields of created and updated tenants,
+// rejecting the mutation when any is invalid. The display name defaults to the name when a tenant
+// is created or its display name is cleared, and updates of a single tenant carry both names, so change events have them for
+// consumers keeping their own displays. It must be registered before the event hooks so events
+// carry the normalized values.
+func (p *Pipeline) Hook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				t := mutationTenant(m)
+
+				validate := p.ValidateUpdate
+				if m.Op().Is(ent.OpCreate) {
+					validate = p.ValidateNewTenant
+				}
+
+				if err := validate(ctx, t); err != nil {
+					return nil, err
+				}
+
+				setMutationTenant(m, t)
+
+				if err := resetDisplayName(ctx, m); err != nil {
+					return nil, err
+				}
+
+				if err := includeBothNames(ctx, m); err != nil {
+					return nil, err
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// mutationTenant returns the fields set by the mutation.
+func mutationTenant(m *generated.TenantMutation) *Tenant {
+	var t Tenant
+
+	if v, ok := m.Name(); ok {
+		t.Name = &v
+	}
+
+	if v, ok := m.DisplayName(); ok {
+		t.DisplayName = &v
+	}
+
+	if v, ok := m.Description(); ok {
+		t.Description = &v
+	}
+
+	if v, ok := m.ContactEmail(); ok {
+		t.ContactEmail = &v
+	}
+
+	if v, ok := m.BillingReference(); ok {
+		t.BillingReference = &v
+	}
+
+	if v, ok := m.Settings(); ok {
+		t.Settings = v
+	}
+
+	if v, ok := m.Labels(); ok {
+		t.Labels = v
+	}
+
+	return &t
+}
+
+// setMutationTenant sets the normalized fields on the mutation.
+func setMutationTenant(m *generated.TenantMutation, t *Tenant) {
+	if t.Name != nil {
+		m.SetName(*t.Name)
+	}
+
+	if t.DisplayName != nil {
+		m.SetDisplayName(*t.DisplayName)
+	}
+
+	if t.Description != nil {
+		m.SetDescription(*t.Description)
+	}
+
+	if t.ContactEmail != nil {
+		m.SetContactEmail(*t.ContactEmail)
+	}
+
+	if t.BillingReference != nil {
+		m.SetBillingReference(*t.BillingReference)
+	}
+}
+
+// resetDisplayName sets the display name of a created tenant, or the one cleared by an update, to
+// the name.
+func resetDisplayName(ctx context.Context, m *generated.TenantMutation) error {
+	if _, ok := m.DisplayName(); ok {
+		return nil
+	}
+
+	if !m.Op().Is(ent.OpCreate) && !m.DisplayNameCleared() {
+		return nil
+	}
+
+	name, ok := m.Name()
+	if !ok {
+		if !m.Op().Is(ent.OpUpdateOne) {
+			// clearing the display names of many tenants at once leaves them empty
+			return nil
+		}
+
+		var err error
+
+		if name, err = m.OldName(ctx); err != nil {
+			return err
+		}
+	}
+
+	// drops the clear, which would otherwise win over the value
+	m.ResetDisplayName()
+	m.SetDisplayName(name)
+
+	return nil
+}
+
+// includeBothNames sets the unchanged one of the name and display name to its current value when a
+// single tenant is updated, so change events carry both for consumers keeping their own displays.
+func includeBothNames(ctx context.Context, m *generated.TenantMutation) error {
+	if !m.Op().Is(ent.OpUpdateOne) {
+		return nil
+	}
+
+	_, nameSet := m.Name()
+	_, displayNameSet := m.DisplayName()
+
+	switch {
+	case nameSet && !displayNameSet && !m.DisplayNameCleared():
+		old, err := m.OldDisplayName(ctx)
+		if err != nil {
+			return err
+		}
+
+		if old != "" {
+			m.SetDisplayName(old)
+		}
+	case displayNameSet && !nameSet:
+		old, err := m.OldName(ctx)
+		if err != nil {
+			return err
+		}
+
+		m.SetName(old)
+	}
+
+	return nil
+}
+
+// normalizeField applies the normalization to the field when it's set and hasn't been rejected,
+// recording the error it returns.
+func normalizeField(errs *Errors, field string, value *string, normalize func(string) (string, error)) {
+	if value == nil || errs.has(field) {
+		return
+	}
+
+	normalized, err := normalize(*value)
+	if err != nil {
+		errs.add(field, err)
+
+		return
+	}
+
+	*value = normalized
+}
@@ -0,0 +1,149 @@
+package validation_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+// codes returns the field and code of each of the errors.
+func codes(t *testing.T, err error) [][2]string {
+	t.Helper()
+
+	var errs validation.Errors
+
+	require.ErrorAs(t, err, &errs)
+
+	out := make([][2]string, len(errs))
+	for i, verr := range errs {
+		out[i] = [2]string{verr.Field, verr.Code}
+	}
+
+	return out
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestPipelineRules(t *testing.T) {
+	assert.Equal(t,
+		[]string{"name", "display_name", "description", "contact_email", "billing_reference", "settings", "labels"},
+		validation.DefaultPipeline().Rules(),
+	)
+
+	names := validation.NewNameValidator(validation.WithReservedNames("api")).Rules()
+	require.Len(t, names, 3)
+	assert.Equal(t, "reserved_names", names[1].Name(), "reserved names are only checked when there are any")
+}
+
+func TestRulesApplyAlone(t *testing.T) {
+	ctx := context.Background()
+
+	rules := map[string]validation.Rule{}
+
+	for _, r := range validation.NewNameValidator(validation.WithReservedNames("api")).Rules() {
+		rules[r.Name()] = r
+	}
+
+	for _, r := range validation.NewMetadataValidator(validation.WithDescriptionMaxLength(4)).Rules() {
+		rules[r.Name()] = r
+	}
+
+	for _, r := range validation.NewSettingsValidator(validation.WithSettingsMaxDepth(1)).Rules() {
+		rules[r.Name()] = r
+	}
+
+	t.Run("name", func(t *testing.T) {
+		tnt := validation.Tenant{Name: ptr(" api ")}
+
+		var errs validation.Errors
+
+		rules["name"].Apply(ctx, &tnt, &errs)
+		assert.Empty(t, errs, "the name rule doesn't know the reserved names")
+		assert.Equal(t, "api", *tnt.Name)
+
+		rules["reserved_names"].Apply(ctx, &tnt, &errs)
+		requireCode(t, validation.CodeReservedName, errs.Err())
+	})
+
+	t.Run("description", func(t *testing.T) {
+		tnt := validation.Tenant{Description: ptr("a\r\nb")}
+
+		var errs validation.Errors
+
+		rules["description"].Apply(ctx, &tnt, &errs)
+		assert.Empty(t, errs)
+		assert.Equal(t, "a\nb", *tnt.Description)
+
+		tnt.Description = ptr("abcde")
+
+		rules["description"].Apply(ctx, &tnt, &errs)
+		requireCode(t, validation.CodeDescriptionTooLong, errs.Err())
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		var errs validation.Errors
+
+		rules["settings"].Apply(ctx, &validation.Tenant{Settings: map[string]any{"a": map[string]any{}}}, &errs)
+		requireCode(t, validation.CodeSettingsTooDeep, errs.Err())
+	})
+
+	t.Run("unset fields", func(t *testing.T) {
+		var errs validation.Errors
+
+		for _, r := range rules {
+			r.Apply(ctx, &validation.Tenant{}, &errs)
+		}
+
+		assert.Empty(t, errs)
+	})
+}
+
+func TestPipelineValidateNewTenant(t *testing.T) {
+	ctx := context.Background()
+
+	p := validation.DefaultPipeline()
+
+	tnt := validation.Tenant{Name: ptr(" acme "), ContactEmail: ptr(" ops@example.com ")}
+	require.NoError(t, p.ValidateNewTenant(ctx, &tnt))
+	assert.Equal(t, "acme", *tnt.Name)
+	assert.Equal(t, "ops@example.com", *tnt.ContactEmail)
+	assert.Nil(t, tnt.DisplayName, "the display name is defaulted when stored")
+
+	err := p.ValidateNewTenant(ctx, &validation.Tenant{})
+	assert.Equal(t, [][2]string{{"name", validation.CodeRequired}}, codes(t, err))
+
+	err = p.ValidateNewTenant(ctx, &validation.Tenant{
+		Name:             ptr("  "),
+		DisplayName:      ptr("\u200b"),
+		ContactEmail:     ptr("nope"),
+		BillingReference: ptr(strings.Repeat("x", validation.DefaultBillingReferenceMaxLength+1)),
+	})
+	assert.Equal(t, [][2]string{
+		{"name", validation.CodeRequired},
+		{"displayName", validation.CodeInvalidName},
+		{"contactEmail", validation.CodeInvalidContactEmail},
+		{"billingReference", validation.CodeBillingReferenceTooLong},
+	}, codes(t, err), "every field is reported once")
+}
+
+func TestPipelineValidateUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	p := validation.DefaultPipeline()
+
+	require.NoError(t, p.ValidateUpdate(ctx, &validation.Tenant{}), "updates need no name")
+
+	tnt := validation.Tenant{Description: ptr("line\rnext")}
+	require.NoError(t, p.ValidateUpdate(ctx, &tnt))
+	assert.Equal(t, "line\nnext", *tnt.Description)
+
+	err := p.ValidateUpdate(ctx, &validation.Tenant{Name: ptr(" ")})
+	assert.Equal(t, [][2]string{{"name", validation.CodeInvalidName}}, codes(t, err))
+}
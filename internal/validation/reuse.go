@@ -0,0 +1,265 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	generated "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/hook"
+	"go.infratographer.com/tenant-api/internal/ent/generated/predicate"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	enttenantchange "go.infratographer.com/tenant-api/internal/ent/generated/tenantchange"
+)
+
+// maxNameAttempts bounds the names tried when suffixing a name taken by a sibling.
+const maxNameAttempts = 100
+
+// NameReusePolicy tells whether the name of a deleted tenant may be taken by a new sibling, or by
+// a tenant renamed or moved next to it. Tenants scheduled for deletion are kept until their grace
+// period ends and they are purged.
+//
+// The policy is checked by queries in the transaction of the mutation. The creates and renames
+// which checked the name mark the tenant with unique_name, and a unique index on the parent and
+// name of the marked tenants not scheduled for deletion rejects a concurrent write of the same
+// name which the queries missed, see SiblingNameIndexHook. Tenants created without checking,
+// the default, and those existing before the index keep their duplicate names. The index doesn't
+// follow the policy, names kept by deleted siblings are only checked by the queries, and neither
+// does it cover root tenants, whose parent is null.
+type NameReusePolicy string
+
+const (
+	// NameReuseAllow lets siblings take the names of tenants scheduled for deletion.
+	NameReuseAllow NameReusePolicy = "allow"
+	// NameReuseBlockDuringRetention keeps the names of tenants scheduled for deletion until they
+	// are purged, the default.
+	NameReuseBlockDuringRetention NameReusePolicy = "block_during_retention"
+	// NameReuseAlwaysBlock keeps the names of deleted tenants even once purged, as recorded in
+	// the tenant changes.
+	NameReuseAlwaysBlock NameReusePolicy = "always_block"
+)
+
+// ParseNameReusePolicy parses a name reuse policy, the empty string is the default one.
+func ParseNameReusePolicy(policy string) (NameReusePolicy, error) {
+	switch p := NameReusePolicy(policy); p {
+	case "":
+		return NameReuseBlockDuringRetention, nil
+	case NameReuseAllow, NameReuseBlockDuringRetention, NameReuseAlwaysBlock:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown name reuse policy %q", policy)
+	}
+}
+
+// CheckSiblingName returns a name_taken error when a live sibling under the parent, nil for root
+// tenants, has the name, or a name_taken_by_deleted error when a deleted one keeps it under the
+// policy. The name must already be normalized.
+func (p NameReusePolicy) CheckSiblingName(ctx context.Context, client *generated.Client, parentID *gidx.PrefixedID, name string) error {
+	return p.checkSiblingName(ctx, client, parentID, name)
+}
+
+// checkSiblingName checks the name among the siblings under the parent, leaving out the tenants
+// given, such as the one renamed.
+func (p NameReusePolicy) checkSiblingName(ctx context.Context, client *generated.Client, parentID *gidx.PrefixedID, name string, except ...gidx.PrefixedID) error {
+	siblings := enttenant.ParentTenantIDIsNil()
+	if parentID != nil {
+		siblings = enttenant.ParentTenantID(*parentID)
+	}
+
+	live := []predicate.Tenant{siblings, enttenant.Name(name)}
+
+	if len(except) != 0 {
+		live = append(live, enttenant.IDNotIn(except...))
+	}
+
+	if p == NameReuseAllow {
+		live = append(live, enttenant.DeletionScheduledAtIsNil())
+	}
+
+	taken, err := client.Tenant.Query().Where(live...).All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range taken {
+		if t.DeletionScheduledAt.IsZero() {
+			return nameError(fieldName, CodeNameTaken, fmt.Sprintf("%q is taken by a sibling", name))
+		}
+	}
+
+	if len(taken) != 0 {
+		return nameError(fieldName, CodeNameTakenByDeleted, fmt.Sprintf("%q is kept by a sibling scheduled for deletion until it is purged", name))
+	}
+
+	if p != NameReuseAlwaysBlock {
+		return nil
+	}
+
+	deletedSiblings := enttenantchange.ParentTenantIDIsNil()
+	if parentID != nil {
+		deletedSiblings = enttenantchange.ParentTenantID(*parentID)
+	}
+
+	purged, err := client.TenantChange.Query().
+		Where(
+			deletedSiblings,
+			enttenantchange.Name(name),
+			enttenantchange.Operation(changeseq.OpDelete),
+		).
+		Exist(ctx)
+	if err != nil {
+		return err
+	}
+
+	if purged {
+		return nameError(fieldName, CodeNameTakenByDeleted, fmt.Sprintf("%q was used by a deleted sibling and can't be reused", name))
+	}
+
+	return nil
+}
+
+// siblingNameIndex is the unique index on the parent and name of tenants marked with unique_name.
+const siblingNameIndex = "tenant_parent_tenant_id_name"
+
+// IsSiblingNameTaken reports whether the error is the sibling name index rejecting a write, as
+// reported by cockroachdb with the name of the index or by sqlite with its columns.
+func IsSiblingNameTaken(err error) bool {
+	if !generated.IsConstraintError(err) {
+		return false
+	}
+
+	msg := err.Error()
+
+	return strings.Contains(msg, siblingNameIndex) || strings.Contains(msg, "tenants.parent_tenant_id, tenants.name")
+}
+
+// SiblingNameIndexHook returns an ent hook reporting the writes rejected by the sibling name index
+// with a name_taken error, the same as the queries checking the names of siblings.
+func SiblingNameIndexHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				v, err := next.Mutate(ctx, m)
+				if err == nil || !IsSiblingNameTaken(err) {
+					return v, err
+				}
+
+				message := "the name is taken by a sibling"
+				if name, ok := m.Name(); ok {
+					message = fmt.Sprintf("%q is taken by a sibling", name)
+				}
+
+				return nil, nameError(fieldName, CodeNameTaken, message)
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
+
+// FreeSiblingName returns the name, or the name with the lowest numeric suffix from 2 on, which no
+// tenant under the parent has, or had as allowed by the policy. When the siblings are queried in
+// the transaction of the create, with serializable isolation a concurrent create taking the same
+// name makes one of them fail instead of both using it.
+func (p NameReusePolicy) FreeSiblingName(ctx context.Context, client *generated.Client, parentID *gidx.PrefixedID, name string) (string, error) {
+	candidate := name
+
+	for i := 1; i <= maxNameAttempts; i++ {
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", name, i)
+		}
+
+		err := p.CheckSiblingName(ctx, client, parentID, candidate)
+
+		var taken *Error
+
+		switch {
+		case err == nil:
+			return candidate, nil
+		case !errors.As(err, &taken):
+			return "", err
+		}
+	}
+
+	return "", &Error{
+		Field:   fieldName,
+		Code:    CodeNameTaken,
+		Message: fmt.Sprintf("%q and its first %d suffixes are taken by siblings", name, maxNameAttempts-1),
+	}
+}
+
+// RenameHook returns an ent hook rejecting updates which rename a tenant or move it under another
+// parent when a sibling has the name, or a deleted one keeps it under the policy, with the same
+// errors as creates checking for conflicts. Renames have no conflict mode, so they are always
+// checked. It must be registered after the pipeline hook, so the normalized names are compared.
+// Updates of many tenants check each against the siblings stored, not against each other, the
+// sibling name index rejects those giving the same name to two of them. The tenants renamed or
+// moved are marked with unique_name.
+func (p NameReusePolicy) RenameHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.TenantFunc(func(ctx context.Context, m *generated.TenantMutation) (ent.Value, error) {
+				name, renamed := m.Name()
+				parentID, moved := m.ParentTenantID()
+
+				if moved && parentID == gidx.NullPrefixedID {
+					moved = false
+				}
+
+				if !renamed && !moved && !m.ParentTenantIDCleared() {
+					return next.Mutate(ctx, m)
+				}
+
+				ids, err := m.IDs(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				tenants, err := m.Client().Tenant.Query().
+					Where(enttenant.IDIn(ids...)).
+					Select(enttenant.FieldID, enttenant.FieldName, enttenant.FieldParentTenantID).
+					All(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, t := range tenants {
+					newName, newParentID := t.Name, t.ParentTenantID
+
+					if renamed {
+						newName = name
+					}
+
+					switch {
+					case moved:
+						newParentID = parentID
+					case m.ParentTenantIDCleared():
+						newParentID = gidx.NullPrefixedID
+					}
+
+					if newName == t.Name && newParentID == t.ParentTenantID {
+						continue
+					}
+
+					var siblingsOf *gidx.PrefixedID
+					if newParentID != gidx.NullPrefixedID {
+						siblingsOf = &newParentID
+					}
+
+					if err := p.checkSiblingName(ctx, m.Client(), siblingsOf, newName, t.ID); err != nil {
+						return nil, err
+					}
+				}
+
+				m.SetUniqueName(true)
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne,
+	)
+}
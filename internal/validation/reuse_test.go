@@ -0,0 +1,184 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/changeseq"
+	ent "go.infratographer.com/tenant-api/internal/ent/generated"
+	"go.infratographer.com/tenant-api/internal/ent/generated/enttest"
+	enttenant "go.infratographer.com/tenant-api/internal/ent/generated/tenant"
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestParseNameReusePolicy(t *testing.T) {
+	policy, err := validation.ParseNameReusePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, validation.NameReuseBlockDuringRetention, policy)
+
+	policy, err = validation.ParseNameReusePolicy("always_block")
+	require.NoError(t, err)
+	assert.Equal(t, validation.NameReuseAlwaysBlock, policy)
+
+	_, err = validation.ParseNameReusePolicy("never")
+	assert.ErrorContains(t, err, `unknown name reuse policy "never"`)
+}
+
+func TestCheckSiblingNameRoots(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	client.Tenant.Use(changeseq.Hook())
+
+	root := client.Tenant.Create().SetName("root").SaveX(ctx)
+	client.Tenant.DeleteOne(root).ExecX(ctx)
+
+	// a child with the name doesn't make it taken among the roots
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+	client.Tenant.Create().SetName("child").SetParent(parent).SaveX(ctx)
+
+	assert.NoError(t, validation.NameReuseAlwaysBlock.CheckSiblingName(ctx, client, nil, "child"))
+	assert.NoError(t, validation.NameReuseBlockDuringRetention.CheckSiblingName(ctx, client, nil, "root"))
+
+	requireCode(t, validation.CodeNameTaken, validation.NameReuseAllow.CheckSiblingName(ctx, client, nil, "parent"))
+	requireCode(t, validation.CodeNameTakenByDeleted, validation.NameReuseAlwaysBlock.CheckSiblingName(ctx, client, nil, "root"))
+}
+
+func TestNameReusePolicyRenames(t *testing.T) {
+	testCases := []struct {
+		policy validation.NameReusePolicy
+		// codes of taking the names of a live sibling, one scheduled for deletion and one purged,
+		// empty when the name may be taken
+		codes []string
+	}{
+		{
+			policy: validation.NameReuseAllow,
+			codes:  []string{validation.CodeNameTaken, "", ""},
+		},
+		{
+			policy: validation.NameReuseBlockDuringRetention,
+			codes:  []string{validation.CodeNameTaken, validation.CodeNameTakenByDeleted, ""},
+		},
+		{
+			policy: validation.NameReuseAlwaysBlock,
+			codes:  []string{validation.CodeNameTaken, validation.CodeNameTakenByDeleted, validation.CodeNameTakenByDeleted},
+		},
+	}
+
+	names := []string{"live", "pending", "purged"}
+
+	for _, tt := range testCases {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			ctx := context.Background()
+
+			client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+			t.Cleanup(func() { client.Close() })
+
+			client.Tenant.Use(tt.policy.RenameHook())
+			client.Tenant.Use(changeseq.Hook())
+
+			parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+			other := client.Tenant.Create().SetName("other").SaveX(ctx)
+			client.Tenant.Create().SetName("live").SetParent(parent).SaveX(ctx)
+			client.Tenant.Create().SetName("pending").SetParent(parent).SetDeletionScheduledAt(time.Now().Add(time.Hour)).SaveX(ctx)
+			purged := client.Tenant.Create().SetName("purged").SetParent(parent).SaveX(ctx)
+			client.Tenant.DeleteOne(purged).ExecX(ctx)
+
+			// the tenants given the names are deleted again, so they don't take them from the
+			// next cases
+			check := func(t *testing.T, code string, err error, taker *ent.Tenant) {
+				t.Helper()
+
+				if code != "" {
+					requireCode(t, code, err)
+
+					return
+				}
+
+				require.NoError(t, err)
+
+				if taker != nil {
+					client.Tenant.DeleteOne(taker).ExecX(ctx)
+				}
+			}
+
+			for i, name := range names {
+				t.Run("create "+name, func(t *testing.T) {
+					check(t, tt.codes[i], tt.policy.CheckSiblingName(ctx, client, &parent.ID, name), nil)
+				})
+
+				t.Run("rename to "+name, func(t *testing.T) {
+					sibling := client.Tenant.Create().SetName("sibling-" + name).SetParent(parent).SaveX(ctx)
+
+					check(t, tt.codes[i], client.Tenant.UpdateOne(sibling).SetName(name).Exec(ctx), sibling)
+				})
+
+				t.Run("move "+name, func(t *testing.T) {
+					moved := client.Tenant.Create().SetName(name).SetParent(other).SaveX(ctx)
+
+					check(t, tt.codes[i], client.Tenant.UpdateOne(moved).SetParent(parent).Exec(ctx), moved)
+				})
+			}
+
+			t.Run("unchanged name", func(t *testing.T) {
+				live := client.Tenant.Query().Where(enttenant.Name("live"), enttenant.ParentTenantID(parent.ID)).OnlyX(ctx)
+
+				assert.NoError(t, client.Tenant.UpdateOne(live).SetName("live").SetDescription("kept").Exec(ctx))
+			})
+		})
+	}
+}
+
+// TestSiblingNameIndex interleaves two creates the way concurrent transactions may: both check
+// the name before either inserts, so only the index can reject the second.
+func TestSiblingNameIndex(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	policy := validation.NameReuseAllow
+
+	client.Tenant.Use(validation.SiblingNameIndexHook())
+	client.Tenant.Use(policy.RenameHook())
+
+	parent := client.Tenant.Create().SetName("parent").SaveX(ctx)
+
+	require.NoError(t, policy.CheckSiblingName(ctx, client, &parent.ID, "staging"))
+	require.NoError(t, policy.CheckSiblingName(ctx, client, &parent.ID, "staging"))
+
+	first := client.Tenant.Create().SetName("staging").SetParent(parent).SetUniqueName(true).SaveX(ctx)
+
+	_, err := client.Tenant.Create().SetName("staging").SetParent(parent).SetUniqueName(true).Save(ctx)
+	requireCode(t, validation.CodeNameTaken, err)
+
+	t.Run("unchecked creates", func(t *testing.T) {
+		_, err := client.Tenant.Create().SetName("staging").SetParent(parent).Save(ctx)
+		assert.NoError(t, err, "creates which don't check the name may duplicate it")
+	})
+
+	t.Run("renames", func(t *testing.T) {
+		a := client.Tenant.Create().SetName("a").SetParent(parent).SaveX(ctx)
+		b := client.Tenant.Create().SetName("b").SetParent(parent).SaveX(ctx)
+
+		// both renames are checked against the siblings stored, neither has the name yet
+		err := client.Tenant.Update().Where(enttenant.IDIn(a.ID, b.ID)).SetName("c").Exec(ctx)
+		requireCode(t, validation.CodeNameTaken, err)
+	})
+
+	t.Run("scheduled for deletion", func(t *testing.T) {
+		client.Tenant.UpdateOne(first).SetDeletionScheduledAt(time.Now().Add(time.Hour)).ExecX(ctx)
+
+		second := client.Tenant.Create().SetName("staging").SetParent(parent).SetUniqueName(true).SaveX(ctx)
+		assert.Equal(t, "staging", second.Name, "the allow policy lets the name be taken")
+
+		err := client.Tenant.UpdateOne(first).ClearDeletionScheduledAt().Exec(ctx)
+		requireCode(t, validation.CodeNameTaken, err)
+	})
+}
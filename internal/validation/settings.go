@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"entgo.io/ent"
+)
+
+const (
+	// DefaultSettingsMaxSize is the default maximum size of a settings document in bytes of json.
+	DefaultSettingsMaxSize = 16 << 10
+	// DefaultSettingsMaxDepth is the default maximum nesting depth of a settings document.
+	DefaultSettingsMaxDepth = 8
+)
+
+const fieldSettings = "settings"
+
+// SettingsOption configures a SettingsValidator.
+type SettingsOption func(*SettingsValidator)
+
+// WithSettingsMaxSize sets the maximum size of a settings document in bytes of json.
+func WithSettingsMaxSize(size int) SettingsOption {
+	return func(v *SettingsValidator) {
+		if size > 0 {
+			v.maxSize = size
+		}
+	}
+}
+
+// WithSettingsMaxDepth sets the maximum nesting depth of a settings document, the document itself
+// being the first level.
+func WithSettingsMaxDepth(depth int) SettingsOption {
+	return func(v *SettingsValidator) {
+		if depth > 0 {
+			v.maxDepth = depth
+		}
+	}
+}
+
+// SettingsValidator validates tenant settings documents.
+type SettingsValidator struct {
+	maxSize  int
+	maxDepth int
+}
+
+// NewSettingsValidator returns a settings validator.
+func NewSettingsValidator(opts ...SettingsOption) *SettingsValidator {
+	v := &SettingsValidator{
+		maxSize:  DefaultSettingsMaxSize,
+		maxDepth: DefaultSettingsMaxDepth,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Validate checks the size and nesting depth of the settings document.
+func (v *SettingsValidator) Validate(settings map[string]any) error {
+	if d := depth(settings); d > v.maxDepth {
+		return settingsError(CodeSettingsTooDeep, fmt.Sprintf("must be nested at most %d levels deep, got %d", v.maxDepth, d))
+	}
+
+	doc, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	if len(doc) > v.maxSize {
+		return settingsError(CodeSettingsTooLarge, fmt.Sprintf("must be at most %d bytes, got %d", v.maxSize, len(doc)))
+	}
+
+	return nil
+}
+
+// Rules returns the rule validating the settings document.
+func (v *SettingsValidator) Rules() []Rule {
+	return []Rule{
+		rule{name: "settings", apply: func(_ context.Context, t *Tenant, errs *Errors) {
+			if t.Settings == nil || errs.has(fieldSettings) {
+				return
+			}
+
+			if err := v.Validate(t.Settings); err != nil {
+				errs.add(fieldSettings, err)
+			}
+		}},
+	}
+}
+
+// Hook returns an ent hook applying the rules of the validator, see Pipeline.Hook.
+func (v *SettingsValidator) Hook() ent.Hook {
+	return NewPipeline(v.Rules()...).Hook()
+}
+
+// depth returns the number of nested objects and arrays of the value, zero for scalars.
+func depth(value any) int {
+	deepest := 0
+
+	switch value := value.(type) {
+	case map[string]any:
+		for _, v := range value {
+			if d := depth(v); d > deepest {
+				deepest = d
+			}
+		}
+	case []any:
+		for _, v := range value {
+			if d := depth(v); d > deepest {
+				deepest = d
+			}
+		}
+	default:
+		return 0
+	}
+
+	return deepest + 1
+}
+
+func settingsError(code, message string) error {
+	return &Error{Field: fieldSettings, Code: code, Message: message}
+}
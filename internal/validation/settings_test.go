@@ -0,0 +1,48 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/internal/validation"
+)
+
+func TestSettingsValidatorValidate(t *testing.T) {
+	v := validation.NewSettingsValidator(
+		validation.WithSettingsMaxSize(64),
+		validation.WithSettingsMaxDepth(3),
+	)
+
+	testCases := []struct {
+		name     string
+		settings map[string]any
+		code     string
+	}{
+		{name: "empty", settings: map[string]any{}},
+		{name: "flat", settings: map[string]any{"region": "us-east", "beta": true}},
+		{name: "at max depth", settings: map[string]any{"a": map[string]any{"b": []any{1}}}},
+		{name: "too deep", settings: map[string]any{"a": map[string]any{"b": []any{[]any{}}}}, code: validation.CodeSettingsTooDeep},
+		{name: "too large", settings: map[string]any{"notes": strings.Repeat("x", 64)}, code: validation.CodeSettingsTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.Validate(tc.settings)
+
+			if tc.code == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var verr *validation.Error
+
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tc.code, verr.Code)
+			assert.Equal(t, "settings", verr.Field)
+		})
+	}
+}
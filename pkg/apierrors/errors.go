@@ -0,0 +1,147 @@
+package apierrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error classes. Errors reported by the api match exactly one of them with errors.Is.
+var (
+	// ErrTenantNotFound is returned when the requested tenant does not exist.
+	ErrTenantNotFound = errors.New("tenant not found")
+
+	// ErrParentNotFound is returned when a tenant is created or moved under a parent which does
+	// not exist.
+	ErrParentNotFound = errors.New("parent tenant not found")
+
+	// ErrNameConflict is returned when a name can't be used as a sibling already has it.
+	ErrNameConflict = errors.New("name conflicts with a sibling")
+
+	// ErrConflict is returned when a change conflicts with the current state of the tenant, such
+	// as deleting a tenant which still has children.
+	ErrConflict = errors.New("conflict with the current state of the tenant")
+
+	// ErrInvalidArgument is returned when a value of the request is invalid, errors with field
+	// information are FieldErrors.
+	ErrInvalidArgument = errors.New("invalid argument")
+
+	// ErrPermissionDenied is returned when the caller is not allowed to perform the request.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrUnauthenticated is returned when a request requires an authenticated caller and has none.
+	ErrUnauthenticated = errors.New("unauthenticated")
+
+	// ErrUnavailable is returned when the request can't be served for now, such as changes made
+	// while the service is in maintenance. The request may be retried later.
+	ErrUnavailable = errors.New("service unavailable")
+
+	// ErrTenantFrozen is returned when changing a tenant an admin froze, or creating or moving a
+	// tenant under one. Changes are accepted again once the tenant is unfrozen.
+	ErrTenantFrozen = errors.New("tenant is frozen")
+
+	// ErrDeletionProtected is returned when deleting a tenant protected against deletion without
+	// confirming its name, or a tenant whose deletion can't be confirmed, such as in a batch.
+	ErrDeletionProtected = errors.New("tenant is protected against deletion")
+
+	// ErrCanceled is returned when the caller canceled the request or its deadline passed before
+	// it was served. The caller has usually gone away, so it rarely sees the error.
+	ErrCanceled = errors.New("request canceled")
+)
+
+// StatusClientClosedRequest is the non standard status of requests the client canceled, as nginx
+// logs them.
+const StatusClientClosedRequest = 499
+
+// Class describes how the errors of a class are reported.
+type Class struct {
+	// Err is the error of the class, the errors reported match it with errors.Is.
+	Err error
+	// HTTPStatus is the status of http responses reporting the error.
+	HTTPStatus int
+	// Code identifies the class in responses, such as in the extensions of graph errors.
+	Code string
+}
+
+// classes in the order they are matched, errors which match several classes get the first one.
+var classes = []Class{
+	{ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{ErrPermissionDenied, http.StatusForbidden, "permission_denied"},
+	{ErrTenantNotFound, http.StatusNotFound, "tenant_not_found"},
+	{ErrParentNotFound, http.StatusUnprocessableEntity, "parent_not_found"},
+	{ErrNameConflict, http.StatusConflict, "name_conflict"},
+	{ErrInvalidArgument, http.StatusUnprocessableEntity, "invalid_argument"},
+	{ErrConflict, http.StatusConflict, "conflict"},
+	{ErrUnavailable, http.StatusServiceUnavailable, "unavailable"},
+	{ErrTenantFrozen, http.StatusLocked, "tenant_frozen"},
+	{ErrDeletionProtected, http.StatusConflict, "deletion_protected"},
+	{ErrCanceled, StatusClientClosedRequest, "canceled"},
+}
+
+// ClassOf returns the class of the error, false when it doesn't belong to any.
+func ClassOf(err error) (Class, bool) {
+	for _, class := range classes {
+		if errors.Is(err, class.Err) {
+			return class, true
+		}
+	}
+
+	return Class{}, false
+}
+
+// FromCode returns the error of the class with the code, nil for unknown codes.
+func FromCode(code string) error {
+	for _, class := range classes {
+		if class.Code == code {
+			return class.Err
+		}
+	}
+
+	return nil
+}
+
+// New returns an error of the class with the message. Declaring package level errors with it
+// lets callers match both the specific error and its class.
+func New(class error, message string) error {
+	return &classError{class: class, message: message}
+}
+
+type classError struct {
+	class   error
+	message string
+}
+
+// Error implements the error interface.
+func (e *classError) Error() string {
+	return e.message
+}
+
+// Is reports whether the target is the class of the error.
+func (e *classError) Is(target error) bool {
+	return target == e.class
+}
+
+// FieldError is an error of a class about a single field of the request. Code further details
+// the failure, such as the validation rule which rejected the value.
+type FieldError struct {
+	Class   error
+	Field   string
+	Code    string
+	Message string
+	// Err is the underlying error when the failure is also reported by another package.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return "invalid " + e.Field + ": " + e.Message
+}
+
+// Is reports whether the target is the class of the error.
+func (e *FieldError) Is(target error) bool {
+	return target == e.Class
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,60 @@
+package apierrors_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+func TestClassOf(t *testing.T) {
+	errHasChildren := apierrors.New(apierrors.ErrConflict, "tenant has children")
+
+	tests := []struct {
+		name   string
+		err    error
+		class  error
+		status int
+		code   string
+	}{
+		{"tenant not found", fmt.Errorf("get: %w", apierrors.ErrTenantNotFound), apierrors.ErrTenantNotFound, http.StatusNotFound, "tenant_not_found"},
+		{"parent not found", apierrors.ErrParentNotFound, apierrors.ErrParentNotFound, http.StatusUnprocessableEntity, "parent_not_found"},
+		{"name conflict", apierrors.ErrNameConflict, apierrors.ErrNameConflict, http.StatusConflict, "name_conflict"},
+		{"conflict", fmt.Errorf("delete: %w", errHasChildren), apierrors.ErrConflict, http.StatusConflict, "conflict"},
+		{
+			"field error",
+			&apierrors.FieldError{Class: apierrors.ErrInvalidArgument, Field: "name", Code: "invalid_name", Message: "must not be empty"},
+			apierrors.ErrInvalidArgument, http.StatusUnprocessableEntity, "invalid_argument",
+		},
+		{"permission denied", apierrors.ErrPermissionDenied, apierrors.ErrPermissionDenied, http.StatusForbidden, "permission_denied"},
+		{"unauthenticated", apierrors.ErrUnauthenticated, apierrors.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+		{"unavailable", apierrors.ErrUnavailable, apierrors.ErrUnavailable, http.StatusServiceUnavailable, "unavailable"},
+		{"tenant frozen", apierrors.ErrTenantFrozen, apierrors.ErrTenantFrozen, http.StatusLocked, "tenant_frozen"},
+		{"deletion protected", apierrors.ErrDeletionProtected, apierrors.ErrDeletionProtected, http.StatusConflict, "deletion_protected"},
+		{"canceled", apierrors.ErrCanceled, apierrors.ErrCanceled, apierrors.StatusClientClosedRequest, "canceled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, ok := apierrors.ClassOf(tt.err)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.class, class.Err)
+			assert.Equal(t, tt.status, class.HTTPStatus)
+			assert.Equal(t, tt.code, class.Code)
+			assert.Equal(t, tt.class, apierrors.FromCode(tt.code))
+		})
+	}
+
+	_, ok := apierrors.ClassOf(errors.New("connection refused"))
+	assert.False(t, ok)
+	assert.Nil(t, apierrors.FromCode("unknown"))
+
+	assert.ErrorIs(t, errHasChildren, apierrors.ErrConflict)
+	assert.NotErrorIs(t, errHasChildren, apierrors.ErrInvalidArgument)
+	assert.Equal(t, "tenant has children", errHasChildren.Error())
+}
@@ -0,0 +1,262 @@
+package client
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheMaxEntries = 1000
+	defaultCacheTTL        = 5 * time.Minute
+)
+
+// CachePolicy configures the responses kept by a cache transport.
+type CachePolicy struct {
+	// MaxEntries bounds the number of responses kept, the least recently used are evicted first.
+	MaxEntries int
+	// TTL is how long a response is kept after it was received. Revalidating it doesn't extend
+	// its lifetime, so a body is never older than the TTL.
+	TTL time.Duration
+}
+
+// DefaultCachePolicy returns the default cache policy.
+func DefaultCachePolicy() CachePolicy {
+	return CachePolicy{
+		MaxEntries: defaultCacheMaxEntries,
+		TTL:        defaultCacheTTL,
+	}
+}
+
+// CacheTransport is an http.RoundTripper keeping GET responses which have an ETag. Later GET
+// requests of the same url by the same caller are revalidated with If-None-Match, and the kept
+// response is served when the server responds with 304 Not Modified.
+type CacheTransport struct {
+	next   http.RoundTripper
+	policy CachePolicy
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru orders the entries from the most to the least recently used
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	url     string
+	etag    string
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// NewCacheTransport wraps next with a transport caching GET responses according to policy.
+// Responses are kept per url, Authorization header and impersonated actor, so callers with
+// different credentials or acting as different actors never see each other's responses.
+func NewCacheTransport(next http.RoundTripper, policy CachePolicy) *CacheTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if policy.MaxEntries < 1 {
+		policy.MaxEntries = defaultCacheMaxEntries
+	}
+
+	if policy.TTL <= 0 {
+		policy.TTL = defaultCacheTTL
+	}
+
+	return &CacheTransport{
+		next:    next,
+		policy:  policy,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+
+		// requests which may change the resource invalidate the responses kept for it
+		if err == nil && !safeMethod(req.Method) {
+			t.Invalidate(req.URL.String())
+		}
+
+		return resp, err
+	}
+
+	// callers making their own conditional or partial requests handle the responses themselves
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry := t.lookup(key)
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		resp.Body.Close()
+
+		return entry.response(req), nil
+	case resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") == "" || noStore(resp.Header):
+		t.remove(key)
+
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.store(&cacheEntry{
+		key:     key,
+		url:     req.URL.String(),
+		etag:    resp.Header.Get("ETag"),
+		status:  resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		expires: time.Now().Add(t.policy.TTL),
+	})
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// Invalidate drops the responses kept for the url, whoever requested them.
+func (t *CacheTransport) Invalidate(url string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, elem := range t.entries {
+		if elem.Value.(*cacheEntry).url == url {
+			t.lru.Remove(elem)
+			delete(t.entries, key)
+		}
+	}
+}
+
+// Len returns the number of responses kept.
+func (t *CacheTransport) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.lru.Len()
+}
+
+// lookup returns the entry kept for the key, nil when there is none or it expired.
+func (t *CacheTransport) lookup(key string) *cacheEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elem, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := elem.Value.(*cacheEntry)
+
+	if time.Now().After(entry.expires) {
+		t.lru.Remove(elem)
+		delete(t.entries, key)
+
+		return nil
+	}
+
+	t.lru.MoveToFront(elem)
+
+	return entry
+}
+
+func (t *CacheTransport) store(entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, ok := t.entries[entry.key]; ok {
+		t.lru.Remove(elem)
+	}
+
+	t.entries[entry.key] = t.lru.PushFront(entry)
+
+	for t.lru.Len() > t.policy.MaxEntries {
+		oldest := t.lru.Back()
+
+		t.lru.Remove(oldest)
+		delete(t.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (t *CacheTransport) remove(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, ok := t.entries[key]; ok {
+		t.lru.Remove(elem)
+		delete(t.entries, key)
+	}
+}
+
+// response returns a copy of the kept response for the request.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.status) + " " + http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies the caller by a hash of its Authorization header and the actor it
+// impersonates, so credentials aren't kept in memory by the cache.
+func cacheKey(req *http.Request) string {
+	identity := sha256.Sum256([]byte(req.Header.Get("Authorization") + "\x00" + req.Header.Get(HeaderImpersonateActor)))
+
+	return hex.EncodeToString(identity[:]) + " " + req.URL.String()
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+func noStore(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+
+	return false
+}
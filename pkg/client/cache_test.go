@@ -0,0 +1,199 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/client"
+)
+
+// etagServer serves tenants over rest with ETags which change on every update made through the
+// graph endpoint, and records the requests it receives.
+type etagServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	version  int
+	requests []*http.Request
+}
+
+func newETagServer(t *testing.T) *etagServer {
+	s := &etagServer{version: 1}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v1/tenants/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r)
+
+		if r.Header.Get("Authorization") == "Bearer denied" {
+			w.WriteHeader(http.StatusForbidden)
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"code": "permission_denied", "message": "permission denied"}))
+
+			return
+		}
+
+		etag := `"` + strconv.Itoa(s.version) + `"`
+
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"id":       "tnntten-child",
+			"name":     "child-v" + strconv.Itoa(s.version),
+			"parentID": "tnntten-parent",
+		}))
+	})
+
+	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r)
+		s.version++
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"tenantUpdate": map[string]any{"tenant": map[string]any{"id": "tnntten-child"}}},
+		}))
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// conditional returns the If-None-Match header of each request received, - for unconditional ones.
+func (s *etagServer) conditional() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	headers := make([]string, len(s.requests))
+
+	for i, r := range s.requests {
+		headers[i] = r.Header.Get("If-None-Match")
+		if headers[i] == "" {
+			headers[i] = "-"
+		}
+	}
+
+	return headers
+}
+
+func TestClientCacheRevalidates(t *testing.T) {
+	ctx := context.Background()
+	srv := newETagServer(t)
+
+	cli := client.New(srv.URL+"/query", client.WithRESTURL(srv.URL+"/api/"), client.WithCache(client.DefaultCachePolicy()))
+
+	for i := 0; i < 3; i++ {
+		tenant, err := cli.Get(ctx, "tnntten-child")
+		require.NoError(t, err)
+
+		assert.Equal(t, "child-v1", tenant.Name)
+		assert.Equal(t, gidx.PrefixedID("tnntten-parent"), tenant.Parent.ID)
+	}
+
+	// every call reaches the server, the kept response is only served once it is revalidated
+	assert.Equal(t, []string{"-", `"1"`, `"1"`}, srv.conditional())
+
+	// tenants are requested by their urn
+	assert.Equal(t, "/api/v1/tenants/urn:infratographer:tenant:tnntten-child", srv.requests[0].URL.Path)
+
+	_, err := cli.Update(ctx, "tnntten-child", client.UpdateTenantInput{})
+	require.NoError(t, err)
+
+	tenant, err := cli.Get(ctx, "tnntten-child")
+	require.NoError(t, err)
+
+	// the update dropped the kept response, so it is fetched again unconditionally
+	assert.Equal(t, "child-v2", tenant.Name)
+	assert.Equal(t, []string{"-", `"1"`, `"1"`, "-", "-"}, srv.conditional())
+}
+
+func TestClientCacheErrors(t *testing.T) {
+	srv := newETagServer(t)
+
+	cli := client.New(srv.URL+"/query", client.WithRESTURL(srv.URL+"/api"), client.WithCache(client.DefaultCachePolicy()), client.WithToken("denied"))
+
+	_, err := cli.Get(context.Background(), "tnntten-child")
+	assert.ErrorIs(t, err, client.ErrPermissionDenied)
+}
+
+func TestCacheTransportIdentity(t *testing.T) {
+	srv := newETagServer(t)
+	cache := client.NewCacheTransport(nil, client.DefaultCachePolicy())
+	cli := &http.Client{Transport: cache}
+
+	get := func(token string) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/api/v1/tenants/tnntten-child", nil)
+		require.NoError(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := cli.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	get("first")
+	get("second")
+	get("first")
+
+	// callers with other credentials don't revalidate each other's responses
+	assert.Equal(t, []string{"-", "-", `"1"`}, srv.conditional())
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestCacheTransportBounds(t *testing.T) {
+	srv := newETagServer(t)
+
+	get := func(cli *http.Client, path string) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/api/v1/tenants/"+path, nil)
+		require.NoError(t, err)
+
+		resp, err := cli.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	bounded := client.NewCacheTransport(nil, client.CachePolicy{MaxEntries: 1, TTL: time.Hour})
+	cli := &http.Client{Transport: bounded}
+
+	get(cli, "a")
+	get(cli, "b")
+	get(cli, "a")
+
+	// b evicted a, which is fetched again unconditionally
+	assert.Equal(t, []string{"-", "-", "-"}, srv.conditional())
+	assert.Equal(t, 1, bounded.Len())
+
+	short := client.NewCacheTransport(nil, client.CachePolicy{MaxEntries: 10, TTL: 10 * time.Millisecond})
+	cli = &http.Client{Transport: short}
+
+	get(cli, "c")
+	get(cli, "c")
+	time.Sleep(20 * time.Millisecond)
+	get(cli, "c")
+
+	assert.Equal(t, []string{"-", "-", "-", "-", `"1"`, "-"}, srv.conditional())
+}
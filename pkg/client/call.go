@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+// Headers the client sends and reads with their semantics, so callers don't set them by hand.
+const (
+	// HeaderRequestID identifies the request in the logs of the api, it is echoed in the response.
+	HeaderRequestID = "X-Request-ID"
+	// HeaderIdempotencyKey lets the api recognize a mutation retried with the same key.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderImpersonateActor asks the api to make the request as another actor, for callers
+	// allowed to impersonate.
+	HeaderImpersonateActor = "X-Impersonate-Actor"
+	// HeaderImpersonatedActor confirms the actor the api made an impersonated request as.
+	HeaderImpersonatedActor = "X-Impersonated-Actor"
+	// HeaderDuplicateSuppressed is set when the api recognized a create as the duplicate of an
+	// earlier one and returned the tenant it created.
+	HeaderDuplicateSuppressed = "X-Duplicate-Suppressed"
+)
+
+// CallOption configures a single call of the client, see WithCallOptions.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	requestID      string
+	idempotencyKey string
+	impersonate    string
+	metadata       *ResponseMetadata
+}
+
+// WithRequestID sets the request ID sent with the call. One is generated when none is set.
+func WithRequestID(id string) CallOption {
+	return func(o *callOptions) {
+		o.requestID = id
+	}
+}
+
+// WithIdempotencyKey sets the idempotency key sent with the call. The same key must be given when
+// retrying a mutation whose outcome is unknown, the retries made by the retry policy send it
+// already.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithImpersonation makes the call as the actor, for callers allowed to impersonate. Whether the
+// api did is reported by ResponseMetadata.ImpersonatedActor.
+func WithImpersonation(actorID string) CallOption {
+	return func(o *callOptions) {
+		o.impersonate = actorID
+	}
+}
+
+// WithMetadata stores the metadata of the response of the call in md once it returns, whether or
+// not it failed, so it can be logged with the outcome.
+func WithMetadata(md *ResponseMetadata) CallOption {
+	return func(o *callOptions) {
+		o.metadata = md
+	}
+}
+
+// ResponseMetadata is what the api told about a call besides its result.
+type ResponseMetadata struct {
+	// RequestID is the ID of the request, as echoed by the api or as sent when it wasn't.
+	RequestID string
+	// StatusCode is the http status of the response, zero when none was received.
+	StatusCode int
+	// DuplicateSuppressed reports whether the api recognized the create as the duplicate of an
+	// earlier one and returned the tenant it created.
+	DuplicateSuppressed bool
+	// ImpersonatedActor is the actor the api confirmed making the request as, empty when it wasn't
+	// impersonated.
+	ImpersonatedActor string
+	// Location is the absolute url of the resource the api created or found, read with
+	// Client.GetLocation, empty when it sent none.
+	Location string
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context whose calls are made with the options, in addition to those
+// the context carries already and to the defaults of the client, the options given last winning.
+// Options are carried by the context so the methods of TenantsService keep their signatures.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	parent, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+
+	all := make([]CallOption, 0, len(parent)+len(opts))
+	all = append(append(all, parent...), opts...)
+
+	return context.WithValue(ctx, callOptionsKey{}, all)
+}
+
+// WithDefaultCallOptions sets the options every call of the client is made with, such as the
+// actor impersonated by all of them.
+func WithDefaultCallOptions(opts ...CallOption) Option {
+	return func(c *Client) {
+		c.callDefaults = append(c.callDefaults, opts...)
+	}
+}
+
+// callOptionsFor returns the options of a call made with the context.
+func (c *Client) callOptionsFor(ctx context.Context) callOptions {
+	var o callOptions
+
+	for _, opt := range c.callDefaults {
+		opt(&o)
+	}
+
+	if opts, ok := ctx.Value(callOptionsKey{}).([]CallOption); ok {
+		for _, opt := range opts {
+			opt(&o)
+		}
+	}
+
+	if o.requestID == "" {
+		o.requestID = newRequestID()
+	}
+
+	return o
+}
+
+// send sends the request with the token and the headers of the call options of its context, and
+// records the metadata of the response when asked to.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	o := c.callOptionsFor(req.Context())
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	req.Header.Set(HeaderRequestID, o.requestID)
+
+	if o.idempotencyKey != "" {
+		req.Header.Set(HeaderIdempotencyKey, o.idempotencyKey)
+	}
+
+	if o.impersonate != "" {
+		req.Header.Set(HeaderImpersonateActor, o.impersonate)
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if o.metadata != nil {
+		*o.metadata = ResponseMetadata{RequestID: o.requestID}
+
+		if resp != nil {
+			o.metadata.read(resp)
+		}
+	}
+
+	return resp, err
+}
+
+func (md *ResponseMetadata) read(resp *http.Response) {
+	md.StatusCode = resp.StatusCode
+	md.ImpersonatedActor = resp.Header.Get(HeaderImpersonatedActor)
+	md.DuplicateSuppressed, _ = strconv.ParseBool(resp.Header.Get(HeaderDuplicateSuppressed))
+
+	if id := resp.Header.Get(HeaderRequestID); id != "" {
+		md.RequestID = id
+	}
+
+	// the api sends the path of the resource, it is resolved against the url requested
+	if loc, err := resp.Location(); err == nil {
+		md.Location = loc.String()
+	}
+}
+
+// newRequestID returns a random request ID, of the form the api generates.
+func newRequestID() string {
+	b := make([]byte, 16)
+
+	// the ID only correlates logs, a failed read leaves it less random
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,154 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/pkg/client"
+)
+
+// headerServer answers graph requests with a created tenant, recording the headers received. It
+// echoes the request ID, confirms impersonations and reports creates retried with the same
+// idempotency key as suppressed duplicates.
+type headerServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	headers []http.Header
+	keys    map[string]bool
+	status  int
+}
+
+func newHeaderServer(t *testing.T) *headerServer {
+	s := &headerServer{keys: map[string]bool{}, status: http.StatusOK}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.headers = append(s.headers, r.Header.Clone())
+
+		w.Header().Set(client.HeaderRequestID, r.Header.Get(client.HeaderRequestID))
+
+		if actor := r.Header.Get(client.HeaderImpersonateActor); actor != "" {
+			w.Header().Set(client.HeaderImpersonatedActor, actor)
+		}
+
+		if key := r.Header.Get(client.HeaderIdempotencyKey); key != "" {
+			if s.keys[key] {
+				w.Header().Set(client.HeaderDuplicateSuppressed, "true")
+			}
+
+			s.keys[key] = true
+		}
+
+		if s.status != http.StatusOK {
+			w.WriteHeader(s.status)
+
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"tenantCreate": map[string]any{"tenant": map[string]any{"id": "tnntten-created", "name": "created"}}},
+		}))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *headerServer) last() http.Header {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.headers[len(s.headers)-1]
+}
+
+func TestCallOptions(t *testing.T) {
+	srv := newHeaderServer(t)
+
+	cli := client.New(srv.URL, client.WithDefaultCallOptions(client.WithImpersonation("idntusr-default")))
+
+	var md client.ResponseMetadata
+
+	ctx := client.WithCallOptions(context.Background(),
+		client.WithRequestID("req-1"),
+		client.WithIdempotencyKey("create-1"),
+		client.WithMetadata(&md),
+	)
+
+	_, err := cli.Create(ctx, client.CreateTenantInput{Name: "created"})
+	require.NoError(t, err)
+
+	sent := srv.last()
+	assert.Equal(t, "req-1", sent.Get(client.HeaderRequestID))
+	assert.Equal(t, "create-1", sent.Get(client.HeaderIdempotencyKey))
+	assert.Equal(t, "idntusr-default", sent.Get(client.HeaderImpersonateActor), "the default of the client")
+
+	assert.Equal(t, client.ResponseMetadata{
+		RequestID:         "req-1",
+		StatusCode:        http.StatusOK,
+		ImpersonatedActor: "idntusr-default",
+	}, md)
+
+	// the retry is recognized by its key, the options of the call override the defaults
+	_, err = cli.Create(client.WithCallOptions(ctx, client.WithImpersonation("idntusr-other")), client.CreateTenantInput{Name: "created"})
+	require.NoError(t, err)
+
+	assert.True(t, md.DuplicateSuppressed)
+	assert.Equal(t, "idntusr-other", md.ImpersonatedActor)
+	assert.Equal(t, "idntusr-other", srv.last().Get(client.HeaderImpersonateActor))
+}
+
+func TestCallRequestIDs(t *testing.T) {
+	srv := newHeaderServer(t)
+	cli := client.New(srv.URL)
+
+	var first, second client.ResponseMetadata
+
+	_, err := cli.Create(client.WithCallOptions(context.Background(), client.WithMetadata(&first)), client.CreateTenantInput{Name: "created"})
+	require.NoError(t, err)
+
+	_, err = cli.Create(client.WithCallOptions(context.Background(), client.WithMetadata(&second)), client.CreateTenantInput{Name: "created"})
+	require.NoError(t, err)
+
+	// a request ID is generated for every call and returned to log it with
+	assert.NotEmpty(t, first.RequestID)
+	assert.Equal(t, srv.headers[0].Get(client.HeaderRequestID), first.RequestID)
+	assert.NotEqual(t, first.RequestID, second.RequestID)
+	assert.Empty(t, srv.headers[0].Get(client.HeaderIdempotencyKey))
+	assert.Empty(t, srv.headers[0].Get(client.HeaderImpersonateActor))
+	assert.Empty(t, first.ImpersonatedActor)
+
+	// the metadata of failed calls is returned as well
+	srv.status = http.StatusBadGateway
+
+	var failed client.ResponseMetadata
+
+	_, err = cli.Create(client.WithCallOptions(context.Background(), client.WithRequestID("req-failed"), client.WithMetadata(&failed)), client.CreateTenantInput{Name: "created"})
+	require.ErrorIs(t, err, client.ErrUnexpectedResponse)
+
+	assert.Equal(t, client.ResponseMetadata{RequestID: "req-failed", StatusCode: http.StatusBadGateway}, failed)
+}
+
+func TestCacheImpersonation(t *testing.T) {
+	srv := newETagServer(t)
+
+	cli := client.New(srv.URL+"/query", client.WithRESTURL(srv.URL+"/api"), client.WithCache(client.DefaultCachePolicy()))
+
+	for _, actor := range []string{"idntusr-one", "idntusr-two", "idntusr-one"} {
+		_, err := cli.Get(client.WithCallOptions(context.Background(), client.WithImpersonation(actor)), "tnntten-child")
+		require.NoError(t, err)
+	}
+
+	// the response kept for an actor is only revalidated for the same actor
+	assert.Equal(t, []string{"-", "-", `"1"`}, srv.conditional())
+	assert.Equal(t, "idntusr-two", srv.requests[1].Header.Get(client.HeaderImpersonateActor))
+}
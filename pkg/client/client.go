@@ -0,0 +1,575 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+	"go.infratographer.com/tenant-api/pkg/pagination"
+	"go.infratographer.com/tenant-api/pkg/urlx"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+const defaultPageSize = 100
+
+// pageLimits bounds the pages requested when listing, the api enforces its own maximum.
+var pageLimits = pagination.Limits{Default: defaultPageSize}
+
+// TenantsService describes the tenant operations provided by the client.
+type TenantsService interface {
+	Get(ctx context.Context, id gidx.PrefixedID) (*Tenant, error)
+	Create(ctx context.Context, input CreateTenantInput) (*Tenant, error)
+	Update(ctx context.Context, id gidx.PrefixedID, input UpdateTenantInput) (*Tenant, error)
+	Move(ctx context.Context, id, parentID gidx.PrefixedID) (*Tenant, error)
+	Delete(ctx context.Context, id gidx.PrefixedID) error
+	ListChildren(ctx context.Context, id gidx.PrefixedID, opts *ListOptions) (*TenantPage, error)
+	ListAllChildren(ctx context.Context, id gidx.PrefixedID) ([]*Tenant, error)
+	ChildrenIterator(id gidx.PrefixedID, pageSize int) *Iterator
+	ChildrenOffsetIterator(id gidx.PrefixedID, pageSize int) *Iterator
+}
+
+// Client is a client for the tenant api.
+type Client struct {
+	gqlURL      string
+	restURL     string
+	httpClient  *http.Client
+	retry       *RetryPolicy
+	cachePolicy *CachePolicy
+	cache       *CacheTransport
+	token       string
+
+	callDefaults []CallOption
+}
+
+var _ TenantsService = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the http client used to make requests.
+func WithHTTPClient(cli *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = cli
+	}
+}
+
+// WithRetryPolicy enables retrying of transient failures using the given policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// WithCache enables caching of tenants fetched with Get, which are revalidated with the ETag of
+// the kept response on every call. Get only uses conditional requests with WithRESTURL, as graph
+// queries aren't cacheable.
+func WithCache(policy CachePolicy) Option {
+	return func(c *Client) {
+		c.cachePolicy = &policy
+	}
+}
+
+// WithRESTURL sets the base url of the rest api, such as https://tenants.example.com/api, used by
+// Get to fetch tenants with cacheable requests, by Create, whose response tells the url of the
+// created tenant, see GetLocation, and by ListChildren, which supports more ListOptions.
+func WithRESTURL(baseURL string) Option {
+	return func(c *Client) {
+		c.restURL = baseURL
+	}
+}
+
+// WithToken sets the bearer token sent with every request.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New creates a new tenant api client for the graph endpoint at the given url.
+func New(url string, opts ...Option) *Client {
+	c := &Client{
+		gqlURL:     url,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.retry != nil {
+		cli := *c.httpClient
+		cli.Transport = NewRetryTransport(cli.Transport, *c.retry)
+
+		c.httpClient = &cli
+	}
+
+	// the cache wraps the retries, so failed revalidations are retried with the same ETag
+	if c.cachePolicy != nil {
+		cli := *c.httpClient
+		c.cache = NewCacheTransport(cli.Transport, *c.cachePolicy)
+		cli.Transport = c.cache
+
+		c.httpClient = &cli
+	}
+
+	return c
+}
+
+// Get returns the tenant with the given id.
+func (c *Client) Get(ctx context.Context, id gidx.PrefixedID) (*Tenant, error) {
+	if c.restURL != "" {
+		return c.getREST(ctx, id)
+	}
+
+	var resp struct {
+		Tenant *Tenant `json:"tenant"`
+	}
+
+	if err := c.do(ctx, getTenantQuery, map[string]any{"id": id}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Tenant, nil
+}
+
+// Create creates a new tenant. With WithRESTURL the tenant is created with the rest api, the url
+// it is read from is returned in ResponseMetadata.Location.
+func (c *Client) Create(ctx context.Context, input CreateTenantInput) (*Tenant, error) {
+	if c.restURL != "" {
+		return c.createREST(ctx, input)
+	}
+
+	var resp struct {
+		TenantCreate struct {
+			Tenant *Tenant `json:"tenant"`
+		} `json:"tenantCreate"`
+	}
+
+	if err := c.do(ctx, tenantCreateMutation, map[string]any{"input": input}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.TenantCreate.Tenant, nil
+}
+
+// Update updates the tenant with the given id.
+func (c *Client) Update(ctx context.Context, id gidx.PrefixedID, input UpdateTenantInput) (*Tenant, error) {
+	var resp struct {
+		TenantUpdate struct {
+			Tenant *Tenant `json:"tenant"`
+		} `json:"tenantUpdate"`
+	}
+
+	defer c.invalidate(id)
+
+	if err := c.do(ctx, tenantUpdateMutation, map[string]any{"id": id, "input": input}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.TenantUpdate.Tenant, nil
+}
+
+// Move moves the tenant with the given id under another parent. Tenants can't be moved under
+// themselves or their descendants, which is reported as ErrConflict.
+func (c *Client) Move(ctx context.Context, id, parentID gidx.PrefixedID) (*Tenant, error) {
+	return c.Update(ctx, id, UpdateTenantInput{ParentID: &parentID})
+}
+
+// Delete deletes the tenant with the given id.
+func (c *Client) Delete(ctx context.Context, id gidx.PrefixedID) error {
+	var resp struct {
+		TenantDelete struct {
+			DeletedID gidx.PrefixedID `json:"deletedID"`
+		} `json:"tenantDelete"`
+	}
+
+	defer c.invalidate(id)
+
+	return c.do(ctx, tenantDeleteMutation, map[string]any{"id": id}, &resp)
+}
+
+// ListChildren returns a single page of the children of the given tenant. With WithRESTURL the
+// children are listed with the rest api, which is required by the filters, the order and the
+// offset of ListOptions, ErrRESTRequired is returned otherwise.
+func (c *Client) ListChildren(ctx context.Context, id gidx.PrefixedID, opts *ListOptions) (*TenantPage, error) {
+	if c.restURL != "" {
+		return c.listREST(ctx, id, opts)
+	}
+
+	if opts != nil && opts.restOnly() {
+		return nil, ErrRESTRequired
+	}
+
+	first := pageLimits.Clamp(0)
+
+	vars := map[string]any{"id": id}
+
+	if opts != nil {
+		first = pageLimits.Clamp(opts.First)
+
+		if opts.After != nil {
+			vars["after"] = *opts.After
+		}
+	}
+
+	vars["first"] = first
+
+	var resp struct {
+		Tenant struct {
+			Children struct {
+				Edges []struct {
+					Node *Tenant `json:"node"`
+				} `json:"edges"`
+				PageInfo   PageInfo `json:"pageInfo"`
+				TotalCount int      `json:"totalCount"`
+			} `json:"children"`
+		} `json:"tenant"`
+	}
+
+	if err := c.do(ctx, tenantChildrenQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	page := &TenantPage{
+		PageInfo:   resp.Tenant.Children.PageInfo,
+		TotalCount: resp.Tenant.Children.TotalCount,
+	}
+
+	for _, edge := range resp.Tenant.Children.Edges {
+		if edge.Node != nil {
+			page.Tenants = append(page.Tenants, edge.Node)
+		}
+	}
+
+	return page, nil
+}
+
+// ListAllChildren returns every child of the given tenant, following pagination automatically.
+func (c *Client) ListAllChildren(ctx context.Context, id gidx.PrefixedID) ([]*Tenant, error) {
+	var tenants []*Tenant
+
+	iter := c.ChildrenIterator(id, defaultPageSize)
+
+	for iter.Next(ctx) {
+		tenants = append(tenants, iter.Value())
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+// ChildrenIterator returns an iterator over all children of the given tenant.
+func (c *Client) ChildrenIterator(id gidx.PrefixedID, pageSize int) *Iterator {
+	return newIterator(func(ctx context.Context, after *string, _ int) (*TenantPage, error) {
+		return c.ListChildren(ctx, id, &ListOptions{First: pageSize, After: after})
+	})
+}
+
+// ChildrenOffsetIterator returns an iterator over all children of the given tenant, requesting
+// each page by the number of children skipped rather than by cursor. It requires the rest api, see
+// WithRESTURL, which caps the offset of a list: iterating past it fails with the api's
+// offset_too_large error, ChildrenIterator has no such limit.
+func (c *Client) ChildrenOffsetIterator(id gidx.PrefixedID, pageSize int) *Iterator {
+	return newIterator(func(ctx context.Context, _ *string, offset int) (*TenantPage, error) {
+		if c.restURL == "" {
+			return nil, ErrRESTRequired
+		}
+
+		return c.ListChildren(ctx, id, &ListOptions{First: pageSize, Offset: offset})
+	})
+}
+
+// invalidate drops the cached copy of the tenant after a mutation, whether or not it succeeded.
+func (c *Client) invalidate(id gidx.PrefixedID) {
+	if c.cache != nil && c.restURL != "" {
+		if u, err := c.tenantURL(id); err == nil {
+			c.cache.Invalidate(u)
+		}
+	}
+}
+
+// tenantURL returns the url of the tenant, identified by its URN which the api accepts in place
+// of the id.
+func (c *Client) tenantURL(id gidx.PrefixedID) (string, error) {
+	return urlx.Join(c.restURL, []string{"v1", "tenants", urnx.NewTenantURN(id)}, nil)
+}
+
+// restTenant is the representation of a tenant returned by the rest api.
+type restTenant struct {
+	ID               gidx.PrefixedID  `json:"id"`
+	Name             string           `json:"name"`
+	DisplayName      *string          `json:"displayName"`
+	Description      *string          `json:"description"`
+	ContactEmail     *string          `json:"contactEmail"`
+	BillingReference *string          `json:"billingReference"`
+	ParentID         *gidx.PrefixedID `json:"parentID"`
+	CreatedAt        time.Time        `json:"createdAt"`
+	UpdatedAt        time.Time        `json:"updatedAt"`
+}
+
+// restError is the body of the errors returned by the rest api.
+type restError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// GetLocation returns the tenant at the url the api responded with in the Location header, such as
+// that of a tenant created with the rest api, see ResponseMetadata.Location. It follows the url
+// as is rather than building one from the id of the tenant.
+func (c *Client) GetLocation(ctx context.Context, location string) (*Tenant, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sendREST(req, http.StatusOK)
+}
+
+func (c *Client) getREST(ctx context.Context, id gidx.PrefixedID) (*Tenant, error) {
+	u, err := c.tenantURL(id)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sendREST(req, http.StatusOK)
+}
+
+// createREST creates the tenant with the rest api. Creates recognized as the duplicate of an
+// earlier one respond with the tenant it created, with 200 OK.
+func (c *Client) createREST(ctx context.Context, input CreateTenantInput) (*Tenant, error) {
+	u, err := urlx.Join(c.restURL, []string{"v1", "tenants"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.sendREST(req, http.StatusCreated, http.StatusOK)
+}
+
+// listREST lists a page of the children of the tenant with the rest api.
+func (c *Client) listREST(ctx context.Context, id gidx.PrefixedID, opts *ListOptions) (*TenantPage, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	query := url.Values{}
+	query.Set("parent_id", id.String())
+	query.Set("limit", strconv.Itoa(pageLimits.Clamp(opts.First)))
+
+	if opts.After != nil {
+		query.Set("page_token", *opts.After)
+	}
+
+	if opts.NameContains != "" {
+		query.Set("name_contains", opts.NameContains)
+	}
+
+	if opts.IncludeArchived {
+		query.Set("include_archived", "true")
+	}
+
+	if opts.OrderBy != "" {
+		query.Set("order_by", opts.OrderBy)
+	}
+
+	if opts.Collation != "" {
+		query.Set("collation", opts.Collation)
+	}
+
+	if opts.Offset != 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+
+	u, err := urlx.Join(c.restURL, []string{"v1", "tenants"}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.receiveREST(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Tenants       []restTenant `json:"tenants"`
+		NextPageToken string       `json:"nextPageToken"`
+	}
+
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedResponse, err)
+	}
+
+	page := &TenantPage{Tenants: make([]*Tenant, 0, len(list.Tenants))}
+
+	for i := range list.Tenants {
+		page.Tenants = append(page.Tenants, list.Tenants[i].tenant())
+	}
+
+	if list.NextPageToken != "" {
+		page.PageInfo = PageInfo{HasNextPage: true, EndCursor: &list.NextPageToken}
+	}
+
+	return page, nil
+}
+
+// sendREST sends the request to the rest api and returns the tenant it responds with, with one of
+// the expected statuses.
+func (c *Client) sendREST(req *http.Request, expected ...int) (*Tenant, error) {
+	body, err := c.receiveREST(req, expected...)
+	if err != nil {
+		return nil, err
+	}
+
+	var t restTenant
+
+	if err := json.Unmarshal(body, &t); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnexpectedResponse, err)
+	}
+
+	return t.tenant(), nil
+}
+
+// receiveREST sends the request to the rest api and returns the body it responds with, with one
+// of the expected statuses.
+func (c *Client) receiveREST(req *http.Request, expected ...int) ([]byte, error) {
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ok := false
+
+	for _, status := range expected {
+		ok = ok || resp.StatusCode == status
+	}
+
+	if !ok {
+		var re restError
+
+		// errors with a class are reported like the graph api reports them
+		if json.Unmarshal(body, &re) == nil && apierrors.FromCode(re.Code) != nil {
+			return nil, GraphError{Message: re.Message, Extensions: GraphExtensions{Class: re.Code, Code: re.Code}}
+		}
+
+		return nil, fmt.Errorf("%w: status %d", ErrUnexpectedResponse, resp.StatusCode)
+	}
+
+	return body, nil
+}
+
+// tenant returns the tenant of the rest representation.
+func (t *restTenant) tenant() *Tenant {
+	tenant := &Tenant{
+		ID:               t.ID,
+		Name:             t.Name,
+		DisplayName:      t.DisplayName,
+		Description:      t.Description,
+		ContactEmail:     t.ContactEmail,
+		BillingReference: t.BillingReference,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+
+	if t.ParentID != nil {
+		tenant.Parent = &TenantRef{ID: *t.ParentID}
+	}
+
+	return tenant
+}
+
+type graphRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphErrors     `json:"errors"`
+}
+
+func (c *Client) do(ctx context.Context, query string, vars map[string]any, out any) error {
+	if strings.HasPrefix(query, "query ") {
+		ctx = withReadOnly(ctx)
+	}
+
+	body, err := json.Marshal(graphRequest{Query: query, Variables: vars})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.gqlURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var gr graphResponse
+
+	if err := json.Unmarshal(respBody, &gr); err != nil {
+		return fmt.Errorf("%w: status %d", ErrUnexpectedResponse, resp.StatusCode)
+	}
+
+	if len(gr.Errors) != 0 {
+		return gr.Errors
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: status %d", ErrUnexpectedResponse, resp.StatusCode)
+	}
+
+	return json.Unmarshal(gr.Data, out)
+}
@@ -0,0 +1,439 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/client"
+)
+
+type graphRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func writeChildrenPage(t *testing.T, w http.ResponseWriter, ids []string, next string) {
+	edges := make([]map[string]any, len(ids))
+
+	for i, id := range ids {
+		edges[i] = map[string]any{"node": map[string]any{"id": id, "name": id}}
+	}
+
+	pageInfo := map[string]any{"hasNextPage": next != ""}
+	if next != "" {
+		pageInfo["endCursor"] = next
+	}
+
+	err := json.NewEncoder(w).Encode(map[string]any{
+		"data": map[string]any{
+			"tenant": map[string]any{
+				"children": map[string]any{
+					"edges":      edges,
+					"pageInfo":   pageInfo,
+					"totalCount": 5,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestListAllChildrenFollowsPages(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var req graphRequest
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch req.Variables["after"] {
+		case nil:
+			writeChildrenPage(t, w, []string{"tnntten-1", "tnntten-2"}, "cursor-1")
+		case "cursor-1":
+			writeChildrenPage(t, w, []string{"tnntten-3", "tnntten-4"}, "cursor-2")
+		case "cursor-2":
+			writeChildrenPage(t, w, []string{"tnntten-5"}, "")
+		default:
+			t.Errorf("unexpected cursor %v", req.Variables["after"])
+		}
+	}))
+	defer srv.Close()
+
+	cli := client.New(srv.URL)
+
+	tenants, err := cli.ListAllChildren(context.Background(), "tnntten-parent")
+	require.NoError(t, err)
+
+	ids := make([]gidx.PrefixedID, len(tenants))
+	for i, tnt := range tenants {
+		ids[i] = tnt.ID
+	}
+
+	assert.Equal(t, []gidx.PrefixedID{"tnntten-1", "tnntten-2", "tnntten-3", "tnntten-4", "tnntten-5"}, ids)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+}
+
+func TestIteratorStopsOnCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeChildrenPage(t, w, []string{"tnntten-1"}, "cursor-1")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	iter := client.New(srv.URL).ChildrenIterator("tnntten-parent", 1)
+
+	require.True(t, iter.Next(ctx))
+
+	cancel()
+
+	assert.False(t, iter.Next(ctx))
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestRetryThenSucceed(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"data":{"tenant":{"id":"tnntten-1","name":"retried"}}}`)
+		}
+	}))
+	defer srv.Close()
+
+	cli := client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}))
+
+	tnt, err := cli.Get(context.Background(), "tnntten-1")
+	require.NoError(t, err)
+	assert.Equal(t, "retried", tnt.Name)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&requests))
+}
+
+func TestChildrenOffsetIterator(t *testing.T) {
+	var offsets []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("page_token"))
+
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+
+		pages := map[string]string{
+			"":  `{"tenants":[{"id":"tnntten-1"},{"id":"tnntten-2"}],"nextPageToken":"token-1"}`,
+			"2": `{"tenants":[{"id":"tnntten-3"},{"id":"tnntten-4"}],"nextPageToken":"token-2"}`,
+			"4": `{"tenants":[{"id":"tnntten-5"}]}`,
+		}
+
+		page, ok := pages[r.URL.Query().Get("offset")]
+		if !ok {
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+
+		fmt.Fprint(w, page)
+	}))
+	defer srv.Close()
+
+	iter := client.New(srv.URL+"/query", client.WithRESTURL(srv.URL)).ChildrenOffsetIterator("tnntten-parent", 2)
+
+	var ids []gidx.PrefixedID
+
+	for iter.Next(context.Background()) {
+		ids = append(ids, iter.Value().ID)
+	}
+
+	require.NoError(t, iter.Err())
+	assert.Equal(t, []gidx.PrefixedID{"tnntten-1", "tnntten-2", "tnntten-3", "tnntten-4", "tnntten-5"}, ids)
+	assert.Equal(t, []string{"", "2", "4"}, offsets)
+
+	without := client.New(srv.URL+"/query").ChildrenOffsetIterator("tnntten-parent", 2)
+
+	assert.False(t, without.Next(context.Background()))
+	assert.ErrorIs(t, without.Err(), client.ErrRESTRequired)
+}
+
+func TestRetryAfterBeyondMaxBackoff(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"data":{"tenant":{"id":"tnntten-1","name":"retried"}}}`)
+	}))
+	defer srv.Close()
+
+	cli := client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}))
+
+	t.Run("waited for", func(t *testing.T) {
+		start := time.Now()
+
+		tnt, err := cli.Get(context.Background(), "tnntten-1")
+		require.NoError(t, err)
+		assert.Equal(t, "retried", tnt.Name)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second, "the requested delay isn't capped by MaxBackoff")
+	})
+
+	t.Run("past the deadline", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err := cli.Get(ctx, "tnntten-1")
+		require.Error(t, err)
+		assert.Less(t, time.Since(start), 500*time.Millisecond, "the response is returned rather than waiting")
+		assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+	})
+}
+
+func TestRetryAfterBeyondMaxRetryAfter(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		name          string
+		maxRetryAfter time.Duration
+	}{
+		{"default", 0},
+		{"set", 500 * time.Millisecond},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+
+			cli := client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{
+				MaxAttempts:   2,
+				MinBackoff:    time.Millisecond,
+				MaxRetryAfter: tt.maxRetryAfter,
+			}))
+
+			start := time.Now()
+
+			// no deadline, only the policy keeps the request from waiting a day
+			_, err := cli.Get(context.Background(), "tnntten-1")
+			require.Error(t, err)
+			assert.Less(t, time.Since(start), time.Second, "the response is returned rather than waiting")
+			assert.EqualValues(t, 1, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	cli := client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Millisecond,
+	}))
+
+	_, err := cli.Get(context.Background(), "tnntten-1")
+	require.ErrorIs(t, err, client.ErrUnexpectedResponse)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requests))
+}
+
+func TestRetryDoesNotReplayMutations(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		status   int
+		requests int32
+	}{
+		{"server error", context.Background(), http.StatusInternalServerError, 1},
+		{"server error with idempotency key", client.WithCallOptions(context.Background(), client.WithIdempotencyKey("create-1")), http.StatusInternalServerError, 3},
+		{"too many requests", context.Background(), http.StatusTooManyRequests, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			cli := client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{
+				MaxAttempts: 3,
+				MinBackoff:  time.Millisecond,
+			}))
+
+			_, err := cli.Create(tt.ctx, client.CreateTenantInput{Name: "created-once"})
+			require.ErrorIs(t, err, client.ErrUnexpectedResponse)
+			assert.Equal(t, tt.requests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestRetryConnectionErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"tenantCreate":{"tenant":{"id":"tnntten-1","name":"created"}}}}`)
+	}))
+	defer srv.Close()
+
+	var dials int32
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if atomic.AddInt32(&dials, 1) == 1 {
+				return nil, errors.New("connection refused")
+			}
+
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}
+
+	cli := client.New(srv.URL,
+		client.WithHTTPClient(&http.Client{Transport: transport}),
+		client.WithRetryPolicy(client.RetryPolicy{
+			MaxAttempts: 2,
+			MinBackoff:  time.Millisecond,
+		}),
+	)
+
+	tnt, err := cli.Create(context.Background(), client.CreateTenantInput{Name: "created"})
+	require.NoError(t, err)
+	assert.Equal(t, "created", tnt.Name)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&dials))
+}
+
+func TestErrorClasses(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   map[string]any
+		class error
+	}{
+		{"tenant not found", map[string]any{"message": "generated: tenant not found", "extensions": map[string]any{"class": "tenant_not_found"}}, client.ErrTenantNotFound},
+		{"parent not found", map[string]any{"message": "invalid parent: missing", "extensions": map[string]any{"class": "parent_not_found", "code": "parent_not_found", "field": "parent"}}, client.ErrParentNotFound},
+		{"name conflict", map[string]any{"message": "invalid name: taken", "extensions": map[string]any{"class": "name_conflict", "code": "name_taken", "field": "name"}}, client.ErrNameConflict},
+		{"conflict", map[string]any{"message": "tenant has children and can't be deleted", "extensions": map[string]any{"class": "conflict"}}, client.ErrConflict},
+		{"invalid argument", map[string]any{"message": "invalid name: must not be empty", "extensions": map[string]any{"class": "invalid_argument", "code": "invalid_name", "field": "name"}}, client.ErrInvalidArgument},
+		{"permission denied", map[string]any{"message": "subject doesn't have access", "extensions": map[string]any{"class": "permission_denied"}}, client.ErrPermissionDenied},
+		{"legacy not found", map[string]any{"message": "generated: tenant not found"}, client.ErrTenantNotFound},
+		{"legacy permission denied", map[string]any{"message": "subject doesn't have access"}, client.ErrPermissionDenied},
+	}
+
+	classes := []error{
+		client.ErrTenantNotFound, client.ErrParentNotFound, client.ErrNameConflict,
+		client.ErrConflict, client.ErrInvalidArgument, client.ErrPermissionDenied,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				err := json.NewEncoder(w).Encode(map[string]any{"errors": []any{tt.err}})
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			_, err := client.New(srv.URL).Get(context.Background(), "tnntten-test")
+			require.Error(t, err)
+
+			for _, class := range classes {
+				assert.Equal(t, class == tt.class, errors.Is(err, class), class.Error())
+			}
+
+			var gerr client.GraphError
+
+			require.ErrorAs(t, err, &gerr)
+
+			extensions, _ := tt.err["extensions"].(map[string]any)
+			assert.Equal(t, extensions["field"] != nil, gerr.Extensions.Field != "")
+		})
+	}
+}
+
+func TestCreateFollowsLocation(t *testing.T) {
+	tenant := map[string]any{"id": "tnntten-child", "name": "child", "parentID": "tnntten-parent"}
+
+	var paths []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants":
+			var input map[string]any
+
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			assert.Equal(t, map[string]any{"name": "child", "parentID": "tnntten-parent"}, input)
+
+			w.Header().Set("Location", "/api/v1/tenants/tnntten-child")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tnntten-child":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(tenant))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := client.New(srv.URL+"/query", client.WithRESTURL(srv.URL+"/api"))
+
+	var md client.ResponseMetadata
+
+	parent := gidx.PrefixedID("tnntten-parent")
+
+	created, err := cli.Create(client.WithCallOptions(context.Background(), client.WithMetadata(&md)), client.CreateTenantInput{Name: "child", ParentID: &parent})
+	require.NoError(t, err)
+
+	assert.Equal(t, gidx.PrefixedID("tnntten-child"), created.ID)
+	assert.Equal(t, parent, created.Parent.ID)
+	assert.Equal(t, http.StatusCreated, md.StatusCode)
+	assert.Equal(t, srv.URL+"/api/v1/tenants/tnntten-child", md.Location)
+
+	fetched, err := cli.GetLocation(context.Background(), md.Location)
+	require.NoError(t, err)
+	assert.Equal(t, created, fetched)
+
+	assert.Equal(t, []string{"POST /api/v1/tenants", "GET /api/v1/tenants/tnntten-child"}, paths)
+}
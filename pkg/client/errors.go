@@ -0,0 +1,131 @@
+package client
+
+import (
+	"errors"
+	"strings"
+
+	"go.infratographer.com/tenant-api/pkg/apierrors"
+)
+
+var (
+	// ErrUnexpectedResponse is returned when the api responds with something other than a graph response.
+	ErrUnexpectedResponse = errors.New("unexpected response from tenant api")
+
+	// ErrRESTRequired is returned when listing with options only supported by the rest api,
+	// without WithRESTURL.
+	ErrRESTRequired = errors.New("list options require the rest api url")
+
+	// ErrTenantNotFound is returned when the requested tenant does not exist.
+	ErrTenantNotFound = apierrors.ErrTenantNotFound
+
+	// ErrParentNotFound is returned when the parent of a created tenant does not exist.
+	ErrParentNotFound = apierrors.ErrParentNotFound
+
+	// ErrNameConflict is returned when a name can't be used as a sibling already has it.
+	ErrNameConflict = apierrors.ErrNameConflict
+
+	// ErrConflict is returned when a change conflicts with the current state of the tenant, such
+	// as deleting a tenant which still has children.
+	ErrConflict = apierrors.ErrConflict
+
+	// ErrInvalidArgument is returned when a value of the request is invalid, GraphError.Field
+	// names the field when known.
+	ErrInvalidArgument = apierrors.ErrInvalidArgument
+
+	// ErrPermissionDenied is returned when the caller is not allowed to perform the request.
+	ErrPermissionDenied = apierrors.ErrPermissionDenied
+
+	// ErrUnauthenticated is returned when a mutation is made without an authenticated caller.
+	ErrUnauthenticated = apierrors.ErrUnauthenticated
+
+	// ErrUnavailable is returned when a request can't be served for now, such as a mutation made
+	// while the api is in maintenance. It may be retried later.
+	ErrUnavailable = apierrors.ErrUnavailable
+
+	// ErrTenantFrozen is returned when changing a tenant frozen by an admin, or creating or moving
+	// a tenant under one.
+	ErrTenantFrozen = apierrors.ErrTenantFrozen
+
+	// ErrDeletionProtected is returned when deleting a tenant protected against deletion without
+	// confirming its name.
+	ErrDeletionProtected = apierrors.ErrDeletionProtected
+)
+
+// permissionDeniedMessage is the error message returned by the permissions-api when access is denied.
+const permissionDeniedMessage = "subject doesn't have access"
+
+// GraphError is a single error returned by the graph api.
+type GraphError struct {
+	Message    string          `json:"message"`
+	Path       []string        `json:"path"`
+	Extensions GraphExtensions `json:"extensions"`
+}
+
+// GraphExtensions are the details the api adds to graph errors.
+type GraphExtensions struct {
+	// Class is the code of the apierrors class of the error.
+	Class string `json:"class"`
+	// Code is the code of the validation rule which rejected a value, or else of the class.
+	Code string `json:"code"`
+	// Field is the field of the request a validation error is about.
+	Field string `json:"field"`
+}
+
+// Error implements the error interface.
+func (e GraphError) Error() string {
+	return e.Message
+}
+
+// Is allows errors.Is to match the class of the error. Errors of apis which don't report the class
+// are matched by their message.
+func (e GraphError) Is(target error) bool {
+	if e.Extensions.Class != "" {
+		return apierrors.FromCode(e.Extensions.Class) == target
+	}
+
+	switch {
+	case target == ErrTenantNotFound:
+		return strings.HasSuffix(e.Message, "tenant not found")
+	case target == ErrPermissionDenied:
+		return strings.Contains(e.Message, permissionDeniedMessage)
+	default:
+		return false
+	}
+}
+
+// GraphErrors is the list of errors returned by the graph api.
+type GraphErrors []GraphError
+
+// Error implements the error interface.
+func (e GraphErrors) Error() string {
+	msgs := make([]string, len(e))
+
+	for i, ge := range e {
+		msgs[i] = ge.Message
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Is allows errors.Is to match the classes of the errors returned by the api.
+func (e GraphErrors) Is(target error) bool {
+	for _, ge := range e {
+		if ge.Is(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As allows errors.As to find the first GraphError, for example for the field of a validation
+// error.
+func (e GraphErrors) As(target any) bool {
+	if t, ok := target.(*GraphError); ok && len(e) != 0 {
+		*t = e[0]
+
+		return true
+	}
+
+	return false
+}
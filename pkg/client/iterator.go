@@ -0,0 +1,71 @@
+package client
+
+import "context"
+
+// pageFetcher fetches the page following the cursor, or skipping offset tenants, depending on how
+// the listing is paginated.
+type pageFetcher func(ctx context.Context, after *string, offset int) (*TenantPage, error)
+
+// Iterator walks every tenant of a paginated listing, fetching pages as needed.
+type Iterator struct {
+	fetch pageFetcher
+
+	page    []*Tenant
+	idx     int
+	cursor  *string
+	offset  int
+	done    bool
+	current *Tenant
+	err     error
+}
+
+func newIterator(fetch pageFetcher) *Iterator {
+	return &Iterator{fetch: fetch}
+}
+
+// Next advances the iterator, returning false when there are no more tenants or an error occurred.
+func (it *Iterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.idx >= len(it.page) {
+		if it.done {
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			it.err = err
+
+			return false
+		}
+
+		page, err := it.fetch(ctx, it.cursor, it.offset)
+		if err != nil {
+			it.err = err
+
+			return false
+		}
+
+		it.page = page.Tenants
+		it.idx = 0
+		it.cursor = page.PageInfo.EndCursor
+		it.offset += len(page.Tenants)
+		it.done = !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil
+	}
+
+	it.current = it.page[it.idx]
+	it.idx++
+
+	return true
+}
+
+// Value returns the current tenant.
+func (it *Iterator) Value() *Tenant {
+	return it.current
+}
+
+// Err returns the error which stopped iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
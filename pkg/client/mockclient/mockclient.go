@@ -0,0 +1,96 @@
+// Package mockclient implements client.TenantsService.
+// Simplifying testing of applications consuming the tenant api client.
+package mockclient
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/client"
+)
+
+var _ client.TenantsService = (*MockTenants)(nil)
+
+// MockTenants implements client.TenantsService.
+type MockTenants struct {
+	mock.Mock
+}
+
+// Get implements client.TenantsService.
+func (m *MockTenants) Get(ctx context.Context, id gidx.PrefixedID) (*client.Tenant, error) {
+	args := m.Called(id)
+
+	return tenantArg(args, 0), args.Error(1)
+}
+
+// Create implements client.TenantsService.
+func (m *MockTenants) Create(ctx context.Context, input client.CreateTenantInput) (*client.Tenant, error) {
+	args := m.Called(input)
+
+	return tenantArg(args, 0), args.Error(1)
+}
+
+// Update implements client.TenantsService.
+func (m *MockTenants) Update(ctx context.Context, id gidx.PrefixedID, input client.UpdateTenantInput) (*client.Tenant, error) {
+	args := m.Called(id, input)
+
+	return tenantArg(args, 0), args.Error(1)
+}
+
+// Move implements client.TenantsService.
+func (m *MockTenants) Move(ctx context.Context, id, parentID gidx.PrefixedID) (*client.Tenant, error) {
+	args := m.Called(id, parentID)
+
+	return tenantArg(args, 0), args.Error(1)
+}
+
+// Delete implements client.TenantsService.
+func (m *MockTenants) Delete(ctx context.Context, id gidx.PrefixedID) error {
+	args := m.Called(id)
+
+	return args.Error(0)
+}
+
+// ListChildren implements client.TenantsService.
+func (m *MockTenants) ListChildren(ctx context.Context, id gidx.PrefixedID, opts *client.ListOptions) (*client.TenantPage, error) {
+	args := m.Called(id, opts)
+
+	page, _ := args.Get(0).(*client.TenantPage)
+
+	return page, args.Error(1)
+}
+
+// ListAllChildren implements client.TenantsService.
+func (m *MockTenants) ListAllChildren(ctx context.Context, id gidx.PrefixedID) ([]*client.Tenant, error) {
+	args := m.Called(id)
+
+	tenants, _ := args.Get(0).([]*client.Tenant)
+
+	return tenants, args.Error(1)
+}
+
+// ChildrenIterator implements client.TenantsService.
+func (m *MockTenants) ChildrenIterator(id gidx.PrefixedID, pageSize int) *client.Iterator {
+	args := m.Called(id, pageSize)
+
+	iter, _ := args.Get(0).(*client.Iterator)
+
+	return iter
+}
+
+// ChildrenOffsetIterator implements client.TenantsService.
+func (m *MockTenants) ChildrenOffsetIterator(id gidx.PrefixedID, pageSize int) *client.Iterator {
+	args := m.Called(id, pageSize)
+
+	iter, _ := args.Get(0).(*client.Iterator)
+
+	return iter
+}
+
+func tenantArg(args mock.Arguments, idx int) *client.Tenant {
+	tnt, _ := args.Get(idx).(*client.Tenant)
+
+	return tnt
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"time"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+// Tenant is the representation of a tenant returned by the tenant api.
+type Tenant struct {
+	ID               gidx.PrefixedID `json:"id"`
+	Name             string          `json:"name"`
+	DisplayName      *string         `json:"displayName"`
+	Description      *string         `json:"description"`
+	ContactEmail     *string         `json:"contactEmail"`
+	BillingReference *string         `json:"billingReference"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+	Parent           *TenantRef      `json:"parent"`
+}
+
+// URN returns the URN of the tenant, the identifier used by events and permissions. The api
+// accepts it wherever it accepts the id.
+func (t *Tenant) URN() string {
+	return urnx.NewTenantURN(t.ID)
+}
+
+// TenantRef is a minimal reference to a tenant.
+type TenantRef struct {
+	ID gidx.PrefixedID `json:"id"`
+}
+
+// CreateTenantInput is the input used to create a tenant.
+type CreateTenantInput struct {
+	Name             string           `json:"name"`
+	DisplayName      *string          `json:"displayName,omitempty"`
+	Description      *string          `json:"description,omitempty"`
+	ContactEmail     *string          `json:"contactEmail,omitempty"`
+	BillingReference *string          `json:"billingReference,omitempty"`
+	ParentID         *gidx.PrefixedID `json:"parentID,omitempty"`
+}
+
+// UpdateTenantInput is the input used to update a tenant.
+type UpdateTenantInput struct {
+	Name             *string `json:"name,omitempty"`
+	DisplayName      *string `json:"displayName,omitempty"`
+	ClearDisplayName *bool   `json:"clearDisplayName,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	ClearDescription *bool   `json:"clearDescription,omitempty"`
+
+	ContactEmail          *string `json:"contactEmail,omitempty"`
+	ClearContactEmail     *bool   `json:"clearContactEmail,omitempty"`
+	BillingReference      *string `json:"billingReference,omitempty"`
+	ClearBillingReference *bool   `json:"clearBillingReference,omitempty"`
+
+	// ParentID moves the tenant under the given parent, see Client.Move.
+	ParentID *gidx.PrefixedID `json:"parentID,omitempty"`
+}
+
+// PageInfo describes the position of a page within a connection.
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor"`
+}
+
+// TenantPage is a single page of tenants.
+type TenantPage struct {
+	Tenants    []*Tenant
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+// ListOptions controls the pages returned when listing children. The filters, the order and the
+// offset are only supported by the rest api, see WithRESTURL.
+type ListOptions struct {
+	// First is the maximum number of tenants returned in a page.
+	First int
+	// After is the cursor to continue listing from.
+	After *string
+
+	// NameContains keeps the tenants whose name contains it.
+	NameContains string
+	// IncludeArchived lists archived tenants as well.
+	IncludeArchived bool
+	// OrderBy orders tenants by "id", the default, or by "name".
+	OrderBy string
+	// Collation is the language names are sorted for when ordering by name, such as "de".
+	Collation string
+	// Offset skips tenants on the first page.
+	Offset int
+}
+
+// restOnly tells whether the options use features only supported by the rest api.
+func (o *ListOptions) restOnly() bool {
+	return o.NameContains != "" || o.IncludeArchived || o.OrderBy != "" || o.Collation != "" || o.Offset != 0
+}
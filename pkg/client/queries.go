@@ -0,0 +1,54 @@
+package client
+
+const tenantFields = `
+	id
+	name
+	displayName
+	description
+	contactEmail
+	billingReference
+	createdAt
+	updatedAt
+	parent {
+		id
+	}
+`
+
+var (
+	getTenantQuery = `query GetTenant($id: ID!) {
+	tenant(id: $id) {` + tenantFields + `}
+}`
+
+	tenantChildrenQuery = `query GetTenantChildren($id: ID!, $first: Int, $after: Cursor) {
+	tenant(id: $id) {
+		children(first: $first, after: $after, orderBy: {field: CREATED_AT, direction: ASC}) {
+			edges {
+				node {` + tenantFields + `}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			totalCount
+		}
+	}
+}`
+
+	tenantCreateMutation = `mutation TenantCreate($input: CreateTenantInput!) {
+	tenantCreate(input: $input) {
+		tenant {` + tenantFields + `}
+	}
+}`
+
+	tenantUpdateMutation = `mutation TenantUpdate($id: ID!, $input: UpdateTenantInput!) {
+	tenantUpdate(id: $id, input: $input) {
+		tenant {` + tenantFields + `}
+	}
+}`
+
+	tenantDeleteMutation = `mutation TenantDelete($id: ID!) {
+	tenantDelete(id: $id) {
+		deletedID
+	}
+}`
+)
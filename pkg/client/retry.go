@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryMinBackoff  = 100 * time.Millisecond
+	defaultRetryMaxBackoff  = 5 * time.Second
+
+	// DefaultMaxRetryAfter is the longest delay requested with Retry-After which is waited for when
+	// the policy doesn't set one.
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// RetryPolicy configures how transient failures are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, doubled on each further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Delays requested with Retry-After aren't capped
+	// by it, see MaxRetryAfter.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest delay requested with Retry-After which is waited for,
+	// DefaultMaxRetryAfter when zero. Responses asking for a longer delay, or for one ending past
+	// the deadline of the request's context, are returned without retrying.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the default retry policy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   defaultRetryMaxAttempts,
+		MinBackoff:    defaultRetryMinBackoff,
+		MaxBackoff:    defaultRetryMaxBackoff,
+		MaxRetryAfter: DefaultMaxRetryAfter,
+	}
+}
+
+// readOnlyKey marks the context of a request which doesn't change anything though it isn't a GET,
+// such as a graphql query.
+type readOnlyKey struct{}
+
+// withReadOnly marks requests made with the context as safe to replay.
+func withReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// replayable tells whether the request may be sent again once the server received it: GET and HEAD
+// requests, read only requests and requests carrying an idempotency key.
+func replayable(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+
+	if readOnly, _ := req.Context().Value(readOnlyKey{}).(bool); readOnly {
+		return true
+	}
+
+	return req.Header.Get(HeaderIdempotencyKey) != ""
+}
+
+// retryTransport is an http.RoundTripper retrying requests which failed transiently.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+// NewRetryTransport wraps next with a transport retrying requests according to policy. 429
+// responses and connection errors happening before the request was written are retried for all
+// requests, 5xx responses only for requests which are safe to replay: GET requests, graphql queries
+// and requests carrying an Idempotency-Key header. Retry-After headers are honored in full, the
+// response is returned without retrying when the delay it requests is longer than the
+// MaxRetryAfter of the policy or ends past the deadline of the request's context.
+func NewRetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	if policy.MaxRetryAfter <= 0 {
+		policy.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	return &retryTransport{
+		next:   next,
+		policy: policy,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.policy.MinBackoff
+
+	for attempt := 1; ; attempt++ {
+		var wrote atomic.Bool
+
+		trace := &httptrace.ClientTrace{
+			WroteHeaderField: func(string, []string) { wrote.Store(true) },
+		}
+
+		resp, err := t.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+
+		retry := false
+
+		switch {
+		case err != nil:
+			// nothing reached the server when the connection failed before the request was written
+			retry = !wrote.Load() && req.Context().Err() == nil
+		case resp.StatusCode == http.StatusTooManyRequests:
+			retry = true
+		case resp.StatusCode >= http.StatusInternalServerError:
+			retry = replayable(req)
+		}
+
+		if !retry || attempt >= t.policy.MaxAttempts {
+			return resp, err
+		}
+
+		// requests without a way to rewind the body can't be retried
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait, requested := backoff, false
+
+		if resp != nil {
+			wait, requested = retryAfter(resp)
+			if !requested {
+				wait = backoff
+			}
+		}
+
+		if !requested && t.policy.MaxBackoff > 0 && wait > t.policy.MaxBackoff {
+			wait = t.policy.MaxBackoff
+		}
+
+		// retrying sooner than the server asked wouldn't succeed, the failure is final
+		if requested && wait > t.policy.MaxRetryAfter {
+			return resp, err
+		}
+
+		// the server asked to wait longer than the caller is willing to, the failure is final
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		backoff *= 2
+	}
+}
+
+// retryAfter returns the delay requested by the response's Retry-After header, and whether a
+// valid one is set.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,253 @@
+package eventcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/x/events"
+)
+
+// Additional data keys of encrypted changes, which are left in clear text.
+const (
+	// EncryptedPayloadKey is the key of the encrypted payload of the change, base64 encoded.
+	EncryptedPayloadKey = "encrypted_payload"
+	// EncryptionKeyIDKey is the key of the id of the key the payload is encrypted with, so keys
+	// can be rotated.
+	EncryptionKeyIDKey = "encryption_key_id"
+)
+
+var (
+	// ErrUnknownKey is returned when a change is encrypted with a key the provider doesn't have.
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrDecrypt is returned when the payload of a change can't be decrypted, it was encrypted
+	// with another key of the same id or its clear text metadata was altered.
+	ErrDecrypt = errors.New("failed to decrypt change payload")
+
+	// ErrInvalidKey is returned when parsing a key which isn't a base64 AES key.
+	ErrInvalidKey = errors.New("invalid encryption key")
+)
+
+// cleartextKeys are the additional data keys left in clear text, those of the consumer contract
+// of the change events which consumers order and deduplicate changes with: the event id, the
+// resource version and the time the change occurred at.
+var cleartextKeys = []string{"event_id", "resource_version", "occurred_at"}
+
+// KeyProvider provides the keys the payloads of changes are encrypted with, such as from the
+// configuration or a key management service.
+type KeyProvider interface {
+	// CurrentKey returns the id of the key changes are encrypted with and the key.
+	CurrentKey(ctx context.Context) (string, []byte, error)
+	// Key returns the key with the id, an error wrapping ErrUnknownKey when there is none.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeys is a KeyProvider of a fixed set of keys.
+type StaticKeys struct {
+	current string
+	keys    map[string][]byte
+}
+
+// ParseKeys returns the keys, each formatted as id=base64 key of 16, 24 or 32 bytes for AES-128,
+// AES-192 or AES-256. The first key encrypts changes, every key decrypts them, so keys are rotated
+// by adding the new one first and dropping the old one once no change encrypted with it is left.
+func ParseKeys(entries []string) (*StaticKeys, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no keys given", ErrInvalidKey)
+	}
+
+	k := &StaticKeys{keys: make(map[string][]byte, len(entries))}
+
+	for _, entry := range entries {
+		id, encoded, ok := strings.Cut(entry, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: expected id=base64 key", ErrInvalidKey)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidKey, id, err)
+		}
+
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidKey, id, err)
+		}
+
+		if _, ok := k.keys[id]; ok {
+			return nil, fmt.Errorf("%w %q: given twice", ErrInvalidKey, id)
+		}
+
+		if k.current == "" {
+			k.current = id
+		}
+
+		k.keys[id] = key
+	}
+
+	return k, nil
+}
+
+// CurrentKey implements KeyProvider, returning the first key.
+func (k *StaticKeys) CurrentKey(context.Context) (string, []byte, error) {
+	return k.current, k.keys[k.current], nil
+}
+
+// Key implements KeyProvider.
+func (k *StaticKeys) Key(_ context.Context, id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+
+	return key, nil
+}
+
+// payload is the part of a change which is encrypted.
+type payload struct {
+	SubjectFields  map[string]string    `json:"subjectFields,omitempty"`
+	FieldChanges   []events.FieldChange `json:"fieldChanges,omitempty"`
+	AdditionalData map[string]any       `json:"additionalData,omitempty"`
+}
+
+// Encrypt encrypts the payload of the change with AES-GCM under the current key: its subject
+// fields, field changes and additional data. The subject, event type, additional subjects, actor,
+// source, timestamp, trace context and the additional data of the changefeed consumer contract are
+// left in clear text, so changes are still routed, ordered and traced, and the key id is added.
+// The subject, event type and key id are authenticated with the payload.
+func Encrypt(ctx context.Context, keys KeyProvider, msg events.ChangeMessage) (events.ChangeMessage, error) {
+	id, key, err := keys.CurrentKey(ctx)
+	if err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	p := payload{SubjectFields: msg.SubjectFields, FieldChanges: msg.FieldChanges}
+	data := make(map[string]any, len(cleartextKeys)+2)
+
+	for k, v := range msg.AdditionalData {
+		if isCleartext(k) {
+			data[k] = v
+
+			continue
+		}
+
+		if p.AdditionalData == nil {
+			p.AdditionalData = map[string]any{}
+		}
+
+		p.AdditionalData[k] = v
+	}
+
+	plaintext, err := json.Marshal(p)
+	if err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, associatedData(msg, id))
+
+	data[EncryptionKeyIDKey] = id
+	data[EncryptedPayloadKey] = base64.StdEncoding.EncodeToString(sealed)
+
+	msg.SubjectFields = nil
+	msg.FieldChanges = nil
+	msg.AdditionalData = data
+
+	return msg, nil
+}
+
+// Decrypt returns the change with the payload Encrypt encrypted restored, for consumers of
+// encrypted changes. Changes which aren't encrypted are returned as they are, so consumers keep
+// working while publishers enable encryption.
+func Decrypt(ctx context.Context, keys KeyProvider, msg events.ChangeMessage) (events.ChangeMessage, error) {
+	encoded, ok := msg.AdditionalData[EncryptedPayloadKey].(string)
+	if !ok {
+		return msg, nil
+	}
+
+	id, _ := msg.AdditionalData[EncryptionKeyIDKey].(string)
+
+	key, err := keys.Key(ctx, id)
+	if err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return events.ChangeMessage{}, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return events.ChangeMessage{}, fmt.Errorf("%w: malformed payload", ErrDecrypt)
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], associatedData(msg, id))
+	if err != nil {
+		return events.ChangeMessage{}, fmt.Errorf("%w with key %q", ErrDecrypt, id)
+	}
+
+	var p payload
+
+	if err := json.Unmarshal(plaintext, &p); err != nil {
+		return events.ChangeMessage{}, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+
+	data := make(map[string]any, len(msg.AdditionalData)+len(p.AdditionalData))
+
+	for k, v := range msg.AdditionalData {
+		if k != EncryptedPayloadKey && k != EncryptionKeyIDKey {
+			data[k] = v
+		}
+	}
+
+	for k, v := range p.AdditionalData {
+		data[k] = v
+	}
+
+	msg.SubjectFields = p.SubjectFields
+	msg.FieldChanges = p.FieldChanges
+	msg.AdditionalData = data
+
+	return msg, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// associatedData binds the payload to the clear text it is routed with and the key id.
+func associatedData(msg events.ChangeMessage, keyID string) []byte {
+	return []byte(msg.SubjectID.String() + "\x00" + msg.EventType + "\x00" + keyID)
+}
+
+func isCleartext(key string) bool {
+	for _, k := range cleartextKeys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
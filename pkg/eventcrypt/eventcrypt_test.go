@@ -0,0 +1,129 @@
+package eventcrypt_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/events"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/eventcrypt"
+)
+
+// key returns a key entry of the id with a key of 32 bytes of the letter.
+func key(id string, letter string) string {
+	return id + "=" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(letter, 32)))
+}
+
+func keys(t *testing.T, entries ...string) *eventcrypt.StaticKeys {
+	t.Helper()
+
+	k, err := eventcrypt.ParseKeys(entries)
+	require.NoError(t, err)
+
+	return k
+}
+
+func change() events.ChangeMessage {
+	return events.ChangeMessage{
+		SubjectID:            "tnntten-secret",
+		EventType:            string(events.UpdateChangeType),
+		AdditionalSubjectIDs: []gidx.PrefixedID{"tnntten-parent"},
+		ActorID:              "idntusr-admin",
+		Source:               "tenant-api",
+		Timestamp:            time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		SubjectFields:        map[string]string{"name": "acme"},
+		FieldChanges:         []events.FieldChange{{Field: "billing_reference", PreviousValue: "", CurrentValue: "PO-1234"}},
+		AdditionalData: map[string]any{
+			"event_id":    "4e4c0c1e",
+			"occurred_at": "2026-10-01T12:00:00.000Z",
+			"snapshot":    map[string]any{"contactEmail": "ops@example.com"},
+		},
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	k := keys(t, key("k1", "a"))
+
+	original := change()
+
+	encrypted, err := eventcrypt.Encrypt(ctx, k, original)
+	require.NoError(t, err)
+
+	// routing and ordering metadata stays in clear text, the rest is only in the payload
+	assert.Equal(t, original.SubjectID, encrypted.SubjectID)
+	assert.Equal(t, original.EventType, encrypted.EventType)
+	assert.Equal(t, original.AdditionalSubjectIDs, encrypted.AdditionalSubjectIDs)
+	assert.Equal(t, original.ActorID, encrypted.ActorID)
+	assert.Nil(t, encrypted.SubjectFields)
+	assert.Nil(t, encrypted.FieldChanges)
+	assert.Equal(t, "k1", encrypted.AdditionalData[eventcrypt.EncryptionKeyIDKey])
+	assert.Equal(t, "4e4c0c1e", encrypted.AdditionalData["event_id"])
+	assert.NotContains(t, encrypted.AdditionalData, "snapshot")
+	assert.NotContains(t, encrypted.AdditionalData[eventcrypt.EncryptedPayloadKey], "acme")
+
+	decrypted, err := eventcrypt.Decrypt(ctx, k, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, original, decrypted)
+
+	again, err := eventcrypt.Encrypt(ctx, k, original)
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted.AdditionalData[eventcrypt.EncryptedPayloadKey], again.AdditionalData[eventcrypt.EncryptedPayloadKey], "every change gets its own nonce")
+
+	plain, err := eventcrypt.Decrypt(ctx, k, original)
+	require.NoError(t, err)
+	assert.Equal(t, original, plain, "changes which aren't encrypted are left as they are")
+}
+
+func TestDecryptWrongKey(t *testing.T) {
+	ctx := context.Background()
+
+	encrypted, err := eventcrypt.Encrypt(ctx, keys(t, key("k1", "a")), change())
+	require.NoError(t, err)
+
+	_, err = eventcrypt.Decrypt(ctx, keys(t, key("k1", "b")), encrypted)
+	assert.ErrorIs(t, err, eventcrypt.ErrDecrypt, "another key with the same id")
+
+	_, err = eventcrypt.Decrypt(ctx, keys(t, key("k2", "a")), encrypted)
+	assert.ErrorIs(t, err, eventcrypt.ErrUnknownKey)
+
+	tampered := encrypted
+	tampered.SubjectID = "tnntten-other"
+
+	_, err = eventcrypt.Decrypt(ctx, keys(t, key("k1", "a")), tampered)
+	assert.ErrorIs(t, err, eventcrypt.ErrDecrypt, "the subject is authenticated")
+
+	// rotated keys still decrypt the changes encrypted with the previous one
+	rotated := keys(t, key("k2", "c"), key("k1", "a"))
+
+	_, err = eventcrypt.Decrypt(ctx, rotated, encrypted)
+	require.NoError(t, err)
+
+	reencrypted, err := eventcrypt.Encrypt(ctx, rotated, change())
+	require.NoError(t, err)
+	assert.Equal(t, "k2", reencrypted.AdditionalData[eventcrypt.EncryptionKeyIDKey])
+}
+
+func TestParseKeys(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		entries []string
+	}{
+		{"none", nil},
+		{"no id", []string{"=" + base64.StdEncoding.EncodeToString(make([]byte, 32))}},
+		{"not base64", []string{"k1=not base64!"}},
+		{"wrong size", []string{"k1=" + base64.StdEncoding.EncodeToString(make([]byte, 20))}},
+		{"duplicate", []string{key("k1", "a"), key("k1", "b")}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := eventcrypt.ParseKeys(tt.entries)
+			assert.ErrorIs(t, err, eventcrypt.ErrInvalidKey)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package externalid
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+// MaxLength is the maximum length of an external ID.
+const MaxLength = 255
+
+// domain separates the hashes of external IDs from other uses of the same input, and versions
+// the derivation.
+const domain = "tenant-api/external-id/v1"
+
+// TenantID returns the ID of the tenant created under the parent with the external ID. It hashes
+// both with SHA-256 and takes the first characters of the base64url encoded hash, which are from
+// the same alphabet as random tenant IDs.
+func TenantID(parentID gidx.PrefixedID, externalID string) gidx.PrefixedID {
+	h := sha256.New()
+
+	for _, part := range []string{domain, parentID.String(), externalID} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+
+	return gidx.PrefixedID(urnx.TenantPrefix + "-" + encoded[:gidx.IDPartLength])
+}
@@ -0,0 +1,32 @@
+package externalid_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/pkg/externalid"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+func TestTenantID(t *testing.T) {
+	parent := gidx.PrefixedID("tnntten-parent")
+
+	id := externalid.TenantID(parent, "acct-42")
+
+	parsed, err := gidx.Parse(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, urnx.TenantPrefix, parsed.Prefix())
+	assert.Len(t, id.String(), gidx.TotalLength)
+
+	// clients precompute the ids, the derivation must not change
+	assert.Equal(t, gidx.PrefixedID("tnntten-96drwK59zmoacp1OD9DGJ"), id)
+
+	assert.NotEqual(t, id, externalid.TenantID(parent, "acct-43"))
+	assert.NotEqual(t, id, externalid.TenantID("tnntten-other", "acct-42"))
+
+	// the separator keeps the parts from running into each other
+	assert.NotEqual(t, externalid.TenantID("tnntten-a", "bc"), externalid.TenantID("tnntten-ab", "c"))
+}
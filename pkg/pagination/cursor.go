@@ -0,0 +1,146 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Version is the version of the cursor encoding, the first byte of encoded cursors.
+const Version byte = 1
+
+var (
+	// ErrInvalidCursor is returned when a cursor can't be decoded, was altered or doesn't match
+	// the listing it is used with.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrUnsupportedVersion is returned when a cursor was encoded with an unknown version.
+	ErrUnsupportedVersion = errors.New("unsupported cursor version")
+)
+
+// Direction is the direction items are sorted in.
+type Direction byte
+
+// Sort directions.
+const (
+	Ascending Direction = iota
+	Descending
+)
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	switch d {
+	case Ascending:
+		return "asc"
+	case Descending:
+		return "desc"
+	default:
+		return fmt.Sprintf("direction(%d)", byte(d))
+	}
+}
+
+// Cursor is the position after the last item of a page: the values of the sort keys of that item,
+// in sort order, and the direction of the sort.
+type Cursor struct {
+	Keys      []string
+	Direction Direction
+}
+
+// headerSize is the size of the version and direction bytes, checksumSize that of the crc32
+// checksum ending the cursor, which catches cursors altered by mistake.
+const (
+	headerSize   = 2
+	checksumSize = 4
+)
+
+// Encode returns the cursor as an opaque url safe string.
+func (c Cursor) Encode() string {
+	keys, _ := json.Marshal(c.Keys) // strings always marshal
+
+	b := make([]byte, 0, headerSize+len(keys)+checksumSize)
+	b = append(b, Version, byte(c.Direction))
+	b = append(b, keys...)
+	b = binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(b))
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// MaxEncodedSize bounds the size of the cursors decoded, those of the apis are much smaller.
+const MaxEncodedSize = 4096
+
+// Decode decodes a cursor returned by Encode. Cursors which were altered, truncated or are larger
+// than MaxEncodedSize are rejected with ErrInvalidCursor.
+func Decode(raw string) (Cursor, error) {
+	if len(raw) > MaxEncodedSize {
+		return Cursor{}, fmt.Errorf("%w: longer than %d bytes", ErrInvalidCursor, MaxEncodedSize)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	if len(b) < headerSize+checksumSize {
+		return Cursor{}, fmt.Errorf("%w: too short", ErrInvalidCursor)
+	}
+
+	if b[0] != Version {
+		return Cursor{}, fmt.Errorf("%w %d", ErrUnsupportedVersion, b[0])
+	}
+
+	body, sum := b[:len(b)-checksumSize], b[len(b)-checksumSize:]
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(sum) {
+		return Cursor{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidCursor)
+	}
+
+	c := Cursor{Direction: Direction(body[1])}
+
+	if c.Direction != Ascending && c.Direction != Descending {
+		return Cursor{}, fmt.Errorf("%w: unknown direction %d", ErrInvalidCursor, body[1])
+	}
+
+	if err := json.Unmarshal(body[headerSize:], &c.Keys); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	if len(c.Keys) == 0 {
+		return Cursor{}, fmt.Errorf("%w: no sort keys", ErrInvalidCursor)
+	}
+
+	return c, nil
+}
+
+// Predicate returns a predicate selecting the items after the cursor, the columns being those of
+// the sort keys in order. It can be given to the Where of ent queries by converting it to their
+// predicate type. The cursor must have a key for every column.
+func (c Cursor) Predicate(columns ...string) (func(*sql.Selector), error) {
+	if len(columns) == 0 || len(columns) != len(c.Keys) {
+		return nil, fmt.Errorf("%w: expected %d sort keys, got %d", ErrInvalidCursor, len(columns), len(c.Keys))
+	}
+
+	args := make([]any, len(c.Keys))
+
+	for i, key := range c.Keys {
+		args[i] = key
+	}
+
+	return func(s *sql.Selector) {
+		qualified := make([]string, len(columns))
+
+		for i, column := range columns {
+			qualified[i] = s.C(column)
+		}
+
+		if c.Direction == Descending {
+			s.Where(sql.CompositeLT(qualified, args...))
+		} else {
+			s.Where(sql.CompositeGT(qualified, args...))
+		}
+	}, nil
+}
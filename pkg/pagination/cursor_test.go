@@ -0,0 +1,88 @@
+package pagination_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, cursor := range []pagination.Cursor{
+		{Keys: []string{"tnntten-abc"}},
+		{Keys: []string{"2023-10-01T00:00:00Z", "tnntten-abc"}, Direction: pagination.Descending},
+		{Keys: []string{"", `quotes " and \ slashes`, "ünïcödé"}},
+	} {
+		encoded := cursor.Encode()
+
+		assert.NotContains(t, encoded, "=", "cursors are unpadded")
+		assert.NotContains(t, encoded, "+")
+		assert.NotContains(t, encoded, "/")
+
+		decoded, err := pagination.Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, cursor, decoded)
+	}
+}
+
+func TestCursorTampered(t *testing.T) {
+	encoded := pagination.Cursor{Keys: []string{"tnntten-abc", "name"}}.Encode()
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, pagination.Version, b[0], "cursors start with their version")
+
+	for i := range b {
+		tampered := append([]byte{}, b...)
+		tampered[i] ^= 0x01
+
+		_, err := pagination.Decode(base64.RawURLEncoding.EncodeToString(tampered))
+		assert.Error(t, err, "byte %d", i)
+	}
+
+	for name, raw := range map[string]string{
+		"empty":     "",
+		"not b64":   "not a cursor!",
+		"truncated": encoded[:len(encoded)-2],
+		"too short": base64.RawURLEncoding.EncodeToString([]byte{pagination.Version, 0}),
+		"too long":  pagination.Cursor{Keys: []string{strings.Repeat("k", pagination.MaxEncodedSize)}}.Encode(),
+	} {
+		_, err := pagination.Decode(raw)
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor, name)
+	}
+
+	newer := append([]byte{}, b...)
+	newer[0] = pagination.Version + 1
+
+	_, err = pagination.Decode(base64.RawURLEncoding.EncodeToString(newer))
+	assert.ErrorIs(t, err, pagination.ErrUnsupportedVersion)
+}
+
+func TestCursorPredicate(t *testing.T) {
+	query := func(c pagination.Cursor, columns ...string) (string, []any) {
+		p, err := c.Predicate(columns...)
+		require.NoError(t, err)
+
+		s := sql.Select("*").From(sql.Table("tenants"))
+		p(s)
+
+		return s.Query()
+	}
+
+	q, args := query(pagination.Cursor{Keys: []string{"tnntten-abc"}}, "id")
+	assert.Equal(t, "SELECT * FROM `tenants` WHERE (`tenants`.`id`) > (?)", q)
+	assert.Equal(t, []any{"tnntten-abc"}, args)
+
+	q, args = query(pagination.Cursor{Keys: []string{"beta", "tnntten-abc"}, Direction: pagination.Descending}, "name", "id")
+	assert.Equal(t, "SELECT * FROM `tenants` WHERE (`tenants`.`name`, `tenants`.`id`) < (?, ?)", q)
+	assert.Equal(t, []any{"beta", "tnntten-abc"}, args)
+
+	_, err := pagination.Cursor{Keys: []string{"tnntten-abc"}}.Predicate("name", "id")
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
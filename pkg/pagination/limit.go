@@ -0,0 +1,60 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidLimit is returned when a requested page size is not a number or out of bounds.
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// Limits bounds the size of pages. Max is the largest page, unbounded when zero, and Default the
+// size of pages when none is requested.
+type Limits struct {
+	Default int
+	Max     int
+}
+
+// Clamp returns the page size to use for the requested one, the default when n isn't positive.
+// Neither the default nor n exceed the max.
+func (l Limits) Clamp(n int) int {
+	if n <= 0 {
+		n = l.Default
+	}
+
+	if l.Max > 0 && n > l.Max {
+		n = l.Max
+	}
+
+	return n
+}
+
+// Parse parses the requested page size, returning the clamped default when raw is empty. Unlike
+// Clamp, sizes which are out of bounds are rejected, so callers learn the limit doesn't apply.
+func (l Limits) Parse(raw string) (int, error) {
+	if raw == "" {
+		return l.Clamp(0), nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 || (l.Max > 0 && n > l.Max) {
+		if l.Max > 0 {
+			return 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidLimit, l.Max)
+		}
+
+		return 0, fmt.Errorf("%w: limit must be a positive number", ErrInvalidLimit)
+	}
+
+	return n, nil
+}
+
+// Trim returns the first limit items and whether there are more. Listings query one item more
+// than the limit to learn whether another page follows.
+func Trim[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+
+	return items, false
+}
@@ -0,0 +1,47 @@
+package pagination_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.infratographer.com/tenant-api/pkg/pagination"
+)
+
+func TestLimitsClamp(t *testing.T) {
+	limits := pagination.Limits{Default: 50, Max: 100}
+
+	assert.Equal(t, 50, limits.Clamp(0))
+	assert.Equal(t, 50, limits.Clamp(-1))
+	assert.Equal(t, 10, limits.Clamp(10))
+	assert.Equal(t, 100, limits.Clamp(1000))
+
+	assert.Equal(t, 20, pagination.Limits{Default: 50, Max: 20}.Clamp(0), "the default doesn't exceed the max")
+	assert.Equal(t, 1000, pagination.Limits{Default: 50}.Clamp(1000), "pages are unbounded without a max")
+}
+
+func TestLimitsParse(t *testing.T) {
+	limits := pagination.Limits{Default: 50, Max: 20}
+
+	for raw, want := range map[string]int{"": 20, "1": 1, "20": 20} {
+		got, err := limits.Parse(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, want, got, raw)
+	}
+
+	for _, raw := range []string{"0", "-1", "21", "ten", "1.5"} {
+		_, err := limits.Parse(raw)
+		assert.ErrorIs(t, err, pagination.ErrInvalidLimit, raw)
+		assert.ErrorContains(t, err, "between 1 and 20", raw)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	items, more := pagination.Trim([]int{1, 2, 3}, 2)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.True(t, more)
+
+	items, more = pagination.Trim([]int{1, 2}, 2)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.False(t, more)
+}
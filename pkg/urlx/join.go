@@ -0,0 +1,56 @@
+package urlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Join returns the url of the path segments below the base url, with the query merged into that
+// of the base.
+//
+// The path of the base is kept as it is escaped, with or without a trailing slash, so the prefix
+// an api is served under is kept. Segments are escaped, so ids containing slashes, percent signs
+// or other special characters stay single segments, and segments which already look escaped are
+// escaped again rather than decoded. Dot segments are escaped as well, so an id such as ".." can't
+// walk out of the base path. Values of the query replace those of the same keys in the
+// query of the base, other keys of the base are kept.
+func Join(base string, segments []string, query url.Values) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	escaped := strings.TrimSuffix(u.EscapedPath(), "/")
+
+	for _, segment := range segments {
+		escaped += "/" + escapeSegment(segment)
+	}
+
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path, u.RawPath = path, escaped
+
+	if len(query) != 0 {
+		merged := u.Query()
+
+		for key, values := range query {
+			merged[key] = values
+		}
+
+		u.RawQuery = merged.Encode()
+	}
+
+	return u.String(), nil
+}
+
+func escapeSegment(segment string) string {
+	switch segment {
+	case ".", "..":
+		return strings.Repeat("%2E", len(segment))
+	}
+
+	return url.PathEscape(segment)
+}
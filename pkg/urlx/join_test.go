@@ -0,0 +1,56 @@
+package urlx_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.infratographer.com/tenant-api/pkg/urlx"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		segments []string
+		query    url.Values
+		expected string
+	}{
+		{"no path", "https://tenants.example.com", []string{"v1", "tenants"}, nil, "https://tenants.example.com/v1/tenants"},
+		{"trailing slash", "https://tenants.example.com/", []string{"v1", "tenants"}, nil, "https://tenants.example.com/v1/tenants"},
+		{"prefix", "https://example.com/api", []string{"v1", "tenants", "tnntten-abc"}, nil, "https://example.com/api/v1/tenants/tnntten-abc"},
+		{"prefix with trailing slash", "https://example.com/api/", []string{"v1"}, nil, "https://example.com/api/v1"},
+		{"escaped prefix", "https://example.com/tenant%20api/", []string{"v1"}, nil, "https://example.com/tenant%20api/v1"},
+		{"escaped slash in prefix", "https://example.com/a%2Fb", []string{"v1"}, nil, "https://example.com/a%2Fb/v1"},
+		{"slash in segment", "https://example.com", []string{"v1", "tenants", "tnntten-a/b"}, nil, "https://example.com/v1/tenants/tnntten-a%2Fb"},
+		{"special characters", "https://example.com", []string{"tnntten-a b?c#d"}, nil, "https://example.com/tnntten-a%20b%3Fc%23d"},
+		{"pre-encoded segment", "https://example.com", []string{"tnntten-a%2Fb"}, nil, "https://example.com/tnntten-a%252Fb"},
+		{"dot segments", "https://example.com/api", []string{"..", ".", "admin"}, nil, "https://example.com/api/%2E%2E/%2E/admin"},
+		{"no segments", "https://example.com/api/", nil, nil, "https://example.com/api"},
+		{"query", "https://example.com", []string{"v1"}, url.Values{"limit": {"10"}}, "https://example.com/v1?limit=10"},
+		{"query merged", "https://example.com/?token=abc", []string{"v1"}, url.Values{"limit": {"10"}}, "https://example.com/v1?limit=10&token=abc"},
+		{"query conflict", "https://example.com/?limit=5&token=abc", []string{"v1"}, url.Values{"limit": {"10", "20"}}, "https://example.com/v1?limit=10&limit=20&token=abc"},
+		{"base query kept", "https://example.com/?token=a%2Bb", []string{"v1"}, nil, "https://example.com/v1?token=a%2Bb"},
+		{"relative", "/api/", []string{"v1", "tenants"}, nil, "/api/v1/tenants"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := urlx.Join(tt.base, tt.segments, tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+
+			if len(tt.segments) != 0 {
+				// the segments are decoded back as they were given
+				u, err := url.Parse(got)
+				require.NoError(t, err)
+				assert.Equal(t, tt.segments[len(tt.segments)-1], u.Path[len(u.Path)-len(tt.segments[len(tt.segments)-1]):])
+			}
+		})
+	}
+
+	_, err := urlx.Join("https://example.com/%zz", []string{"v1"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package urnx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.infratographer.com/x/gidx"
+)
+
+const (
+	// Namespace is the URN namespace of Infratographer resources.
+	Namespace = "infratographer"
+
+	// TenantResourceType is the resource type of tenant URNs.
+	TenantResourceType = "tenant"
+
+	// TenantPrefix is the id prefix of tenants.
+	TenantPrefix = "tnntten"
+
+	scheme = "urn"
+)
+
+var (
+	// ErrInvalidURN is returned when a URN is malformed.
+	ErrInvalidURN = errors.New("invalid urn")
+
+	// ErrUnexpectedResourceType is returned when a URN is valid but references another type of resource.
+	ErrUnexpectedResourceType = errors.New("unexpected urn resource type")
+
+	// ErrInvalidTenantID is returned when a raw id isn't a valid tenant id.
+	ErrInvalidTenantID = errors.New("invalid tenant id")
+)
+
+// URN references an Infratographer resource, formatted as urn:infratographer:<type>:<id>.
+type URN struct {
+	ResourceType string
+	ResourceID   gidx.PrefixedID
+}
+
+// String returns the formatted URN.
+func (u URN) String() string {
+	return strings.Join([]string{scheme, Namespace, u.ResourceType, u.ResourceID.String()}, ":")
+}
+
+// NewTenantURN returns the URN of the tenant.
+func NewTenantURN(id gidx.PrefixedID) string {
+	return URN{ResourceType: TenantResourceType, ResourceID: id}.String()
+}
+
+// Parse parses a URN of any resource type. Parsing is strict: the scheme, namespace and type
+// must be lowercase and the id must be a valid prefixed id.
+func Parse(s string) (URN, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 4 {
+		return URN{}, fmt.Errorf("%w: expected urn:%s:<type>:<id>, got %q", ErrInvalidURN, Namespace, s)
+	}
+
+	if parts[0] != scheme {
+		return URN{}, fmt.Errorf("%w: scheme must be %q, got %q", ErrInvalidURN, scheme, parts[0])
+	}
+
+	if parts[1] != Namespace {
+		return URN{}, fmt.Errorf("%w: namespace must be %q, got %q", ErrInvalidURN, Namespace, parts[1])
+	}
+
+	if !validResourceType(parts[2]) {
+		return URN{}, fmt.Errorf("%w: resource type must be lowercase letters and dashes, got %q", ErrInvalidURN, parts[2])
+	}
+
+	if parts[3] == "" {
+		return URN{}, fmt.Errorf("%w: resource id is required", ErrInvalidURN)
+	}
+
+	id, err := gidx.Parse(parts[3])
+	if err != nil {
+		return URN{}, fmt.Errorf("%w: %s", ErrInvalidURN, err)
+	}
+
+	return URN{ResourceType: parts[2], ResourceID: id}, nil
+}
+
+// ParseTenantURN parses a tenant URN and returns the tenant id. ErrUnexpectedResourceType is
+// returned for valid URNs of other resource types.
+func ParseTenantURN(s string) (gidx.PrefixedID, error) {
+	urn, err := Parse(s)
+	if err != nil {
+		return gidx.NullPrefixedID, err
+	}
+
+	if urn.ResourceType != TenantResourceType {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: expected %q, got %q", ErrUnexpectedResourceType, TenantResourceType, urn.ResourceType)
+	}
+
+	if urn.ResourceID.Prefix() != TenantPrefix {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: tenant id must have the %q prefix, got %q", ErrInvalidURN, TenantPrefix, urn.ResourceID)
+	}
+
+	return urn.ResourceID, nil
+}
+
+// ParseTenantID parses either a raw tenant id or a tenant URN, returning the tenant id. Values
+// starting with the urn scheme are parsed as URNs, with the errors of ParseTenantURN, others as
+// ids, ErrInvalidTenantID being returned for those which aren't tenant ids.
+func ParseTenantID(s string) (gidx.PrefixedID, error) {
+	if strings.HasPrefix(s, scheme+":") {
+		return ParseTenantURN(s)
+	}
+
+	id, err := gidx.Parse(s)
+	if err != nil {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: %s", ErrInvalidTenantID, err)
+	}
+
+	if id.Prefix() != TenantPrefix {
+		return gidx.NullPrefixedID, fmt.Errorf("%w: must have the %q prefix, got %q", ErrInvalidTenantID, TenantPrefix, id)
+	}
+
+	return id, nil
+}
+
+func validResourceType(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package urnx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.infratographer.com/x/gidx"
+
+	"go.infratographer.com/tenant-api/internal/ent/schema"
+	"go.infratographer.com/tenant-api/pkg/urnx"
+)
+
+func TestParseTenantURN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		urn      string
+		expected gidx.PrefixedID
+		err      error
+	}{
+		{name: "valid", urn: "urn:infratographer:tenant:tnntten-abc123", expected: "tnntten-abc123"},
+		{name: "other resource type", urn: "urn:infratographer:load-balancer:loadbal-abc123", err: urnx.ErrUnexpectedResourceType},
+		{name: "tenant type with other prefix", urn: "urn:infratographer:tenant:loadbal-abc123", err: urnx.ErrInvalidURN},
+		{name: "empty", urn: "", err: urnx.ErrInvalidURN},
+		{name: "raw id", urn: "tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "too few parts", urn: "urn:infratographer:tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "too many parts", urn: "urn:infratographer:tenant:tnntten-abc123:extra", err: urnx.ErrInvalidURN},
+		{name: "uppercase scheme", urn: "URN:infratographer:tenant:tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "other namespace", urn: "urn:example:tenant:tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "uppercase type", urn: "urn:infratographer:Tenant:tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "empty type", urn: "urn:infratographer::tnntten-abc123", err: urnx.ErrInvalidURN},
+		{name: "empty id", urn: "urn:infratographer:tenant:", err: urnx.ErrInvalidURN},
+		{name: "invalid id", urn: "urn:infratographer:tenant:tnntten", err: urnx.ErrInvalidURN},
+		{name: "whitespace", urn: " urn:infratographer:tenant:tnntten-abc123", err: urnx.ErrInvalidURN},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := urnx.ParseTenantURN(tt.urn)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, gidx.NullPrefixedID, id)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestNewTenantURN(t *testing.T) {
+	assert.Equal(t, schema.TenantPrefix, urnx.TenantPrefix)
+
+	urn := urnx.NewTenantURN("tnntten-abc123")
+	assert.Equal(t, "urn:infratographer:tenant:tnntten-abc123", urn)
+
+	id, err := urnx.ParseTenantURN(urn)
+	require.NoError(t, err)
+	assert.Equal(t, gidx.PrefixedID("tnntten-abc123"), id)
+}
+
+func TestParseTenantID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected gidx.PrefixedID
+		err      error
+	}{
+		{name: "raw id", value: "tnntten-abc123", expected: "tnntten-abc123"},
+		{name: "urn", value: "urn:infratographer:tenant:tnntten-abc123", expected: "tnntten-abc123"},
+		{name: "raw id of another resource", value: "loadbal-abc123", err: urnx.ErrInvalidTenantID},
+		{name: "invalid raw id", value: "tnntten", err: urnx.ErrInvalidTenantID},
+		{name: "empty", value: "", err: urnx.ErrInvalidTenantID},
+		{name: "urn of another resource type", value: "urn:infratographer:load-balancer:loadbal-abc123", err: urnx.ErrUnexpectedResourceType},
+		{name: "malformed urn", value: "urn:infratographer:tenant", err: urnx.ErrInvalidURN},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := urnx.ParseTenantID(tt.value)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, gidx.NullPrefixedID, id)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}